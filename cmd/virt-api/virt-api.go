@@ -29,7 +29,7 @@ import (
 )
 
 func main() {
-	klog.InitializeLogging("virt-api")
+	klog.InitializeLibraryLogging("virt-api")
 
 	app := virt_api.NewVirtApi()
 	service.Setup(app)
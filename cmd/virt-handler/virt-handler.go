@@ -41,7 +41,6 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	k8coresv1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/certificate"
 	"k8s.io/client-go/util/flowcontrol"
 
@@ -235,7 +234,7 @@ func (app *virtHandlerApp) Run() {
 	}()
 
 	// Create event recorder
-	broadcaster := record.NewBroadcaster()
+	broadcaster := controller.NewEventBroadcaster()
 	broadcaster.StartRecordingToSink(&k8coresv1.EventSinkImpl{Interface: app.virtCli.CoreV1().Events(k8sv1.NamespaceAll)})
 	// Scheme is used to create an ObjectReference from an Object (e.g. VirtualMachineInstance) during Event creation
 	recorder := broadcaster.NewRecorder(scheme.Scheme, k8sv1.EventSource{Component: "virt-handler", Host: app.HostOverride})
@@ -245,6 +244,7 @@ func (app *virtHandlerApp) Run() {
 
 	vmiSourceInformer := factory.VMISourceHost(app.HostOverride)
 	vmiTargetInformer := factory.VMITargetHost(app.HostOverride)
+	nodeInformer := factory.HostNode(app.HostOverride)
 
 	// Wire Domain controller
 	domainSharedInformer, err := virtcache.NewSharedInformer(app.VirtShareDir, int(app.WatchdogTimeoutDuration.Seconds()), recorder, vmiSourceInformer.GetStore(), time.Duration(app.domainResyncPeriodSeconds)*time.Second)
@@ -334,6 +334,7 @@ func (app *virtHandlerApp) Run() {
 		vmiTargetInformer,
 		domainSharedInformer,
 		gracefulShutdownInformer,
+		nodeInformer,
 		int(app.WatchdogTimeoutDuration.Seconds()),
 		app.MaxDevices,
 		app.clusterConfig,
@@ -355,6 +356,18 @@ func (app *virtHandlerApp) Run() {
 		app.VirtShareDir,
 	)
 
+	memoryDumpHandler := rest.NewMemoryDumpHandler(
+		podIsolationDetector,
+		vmiSourceInformer,
+	)
+
+	domainLogHandler := rest.NewDomainLogHandler(
+		podIsolationDetector,
+		vmiSourceInformer,
+	)
+
+	vsockHandler := rest.NewVSOCKHandler(vmiSourceInformer)
+
 	promdomain.SetupDomainStatsCollector(app.virtCli, app.VirtShareDir, app.HostOverride, app.MaxRequestsInFlight, vmiSourceInformer)
 	if err := downwardmetrics.RunDownwardMetricsCollector(context.Background(), app.HostOverride, vmiSourceInformer, podIsolationDetector); err != nil {
 		panic(fmt.Errorf("failed to set up the downwardMetrics collector: %v", err))
@@ -389,7 +402,7 @@ func (app *virtHandlerApp) Run() {
 		panic(fmt.Errorf("failed to detect the presence of selinux: %v", err))
 	}
 
-	cache.WaitForCacheSync(stop, factory.ConfigMap().HasSynced, vmiSourceInformer.HasSynced, factory.CRD().HasSynced)
+	cache.WaitForCacheSync(stop, factory.ConfigMap().HasSynced, vmiSourceInformer.HasSynced, nodeInformer.HasSynced, factory.CRD().HasSynced)
 
 	go vmController.Run(10, stop)
 
@@ -397,7 +410,7 @@ func (app *virtHandlerApp) Run() {
 	defer close(doneCh)
 
 	errCh := make(chan error)
-	go app.runServer(errCh, consoleHandler, lifecycleHandler)
+	go app.runServer(errCh, consoleHandler, lifecycleHandler, memoryDumpHandler, domainLogHandler, vsockHandler)
 
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt,
@@ -447,6 +460,7 @@ func (app *virtHandlerApp) Run() {
 func (app *virtHandlerApp) shouldChangeLogVerbosity() {
 	verbosity := app.clusterConfig.GetVirtHandlerVerbosity(app.HostOverride)
 	log.Log.SetVerbosityLevel(int(verbosity))
+	log.SetLibraryJSONFormat(app.clusterConfig.LibraryLogsJSONEnabled())
 	log.Log.V(2).Infof("set verbosity to %d", verbosity)
 }
 
@@ -481,9 +495,11 @@ func (app *virtHandlerApp) runPrometheusServer(errCh chan error) {
 	errCh <- server.ListenAndServeTLS("", "")
 }
 
-func (app *virtHandlerApp) runServer(errCh chan error, consoleHandler *rest.ConsoleHandler, lifecycleHandler *rest.LifecycleHandler) {
+func (app *virtHandlerApp) runServer(errCh chan error, consoleHandler *rest.ConsoleHandler, lifecycleHandler *rest.LifecycleHandler, memoryDumpHandler *rest.MemoryDumpHandler, domainLogHandler *rest.DomainLogHandler, vsockHandler *rest.VSOCKHandler) {
 	ws := new(restful.WebService)
 	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/console").To(consoleHandler.SerialHandler))
+	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/consolelog").To(consoleHandler.SerialLogHandler).Produces("text/plain"))
+	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/domainlog").To(domainLogHandler.DomainLogHandler).Produces("text/plain"))
 	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/vnc").To(consoleHandler.VNCHandler))
 	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/usbredir").To(consoleHandler.USBRedirHandler))
 	ws.Route(ws.PUT("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/pause").To(lifecycleHandler.PauseHandler))
@@ -493,6 +509,9 @@ func (app *virtHandlerApp) runServer(errCh chan error, consoleHandler *rest.Cons
 	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/guestosinfo").To(lifecycleHandler.GetGuestInfo).Produces(restful.MIME_JSON).Consumes(restful.MIME_JSON).Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceGuestAgentInfo{}))
 	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/userlist").To(lifecycleHandler.GetUsers).Produces(restful.MIME_JSON).Consumes(restful.MIME_JSON).Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceGuestOSUserList{}))
 	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/filesystemlist").To(lifecycleHandler.GetFilesystems).Produces(restful.MIME_JSON).Consumes(restful.MIME_JSON).Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceFileSystemList{}))
+	ws.Route(ws.POST("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/guestexec").To(lifecycleHandler.GuestExecHandler).Produces(restful.MIME_JSON).Consumes(restful.MIME_JSON).Reads(v1.GuestExecOptions{}).Returns(http.StatusOK, "OK", v1.GuestExecResult{}))
+	ws.Route(ws.PUT("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/memorydump").To(memoryDumpHandler.MemoryDumpHandler).Produces(restful.MIME_OCTET))
+	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/vsock").To(vsockHandler.VSOCKHandler))
 	restful.DefaultContainer.Add(ws)
 	server := &http.Server{
 		Addr:    fmt.Sprintf("%s:%d", app.ServiceListen.BindAddress, app.consoleServerPort),
@@ -587,7 +606,7 @@ func (app *virtHandlerApp) setupTLS(factory controller.KubeInformerFactory) erro
 func main() {
 	app := &virtHandlerApp{}
 	service.Setup(app)
-	log.InitializeLogging("virt-handler")
+	log.InitializeLibraryLogging("virt-handler")
 	app.Run()
 }
 
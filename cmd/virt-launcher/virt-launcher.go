@@ -366,7 +366,7 @@ func main() {
 	pflag.CommandLine.AddGoFlag(goflag.CommandLine.Lookup("v"))
 	pflag.Parse()
 
-	log.InitializeLogging("virt-launcher")
+	log.InitializeLibraryLogging("virt-launcher")
 
 	// check if virt-launcher verbosity should be changed
 	if verbosityStr, ok := os.LookupEnv("VIRT_LAUNCHER_LOG_VERBOSITY"); ok {
@@ -377,6 +377,9 @@ func main() {
 			log.Log.Warningf("failed to set log verbosity. The value of logVerbosity label should be an integer, got %s instead.", verbosityStr)
 		}
 	}
+	if os.Getenv("VIRT_LAUNCHER_LOG_FORMAT") == string(v1.LogFormatJSON) {
+		log.SetLibraryJSONFormat(true)
+	}
 
 	if !*noFork {
 		exitCode, err := ForkAndMonitor(*containerDiskDir)
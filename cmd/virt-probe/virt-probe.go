@@ -29,6 +29,7 @@ import (
 
 	"kubevirt.io/client-go/log"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 )
 
 func main() {
@@ -41,6 +42,7 @@ func main() {
 	memProfile := pflag.String("memProfile", "", "Path to store a memory profile. Profiling is skipped if empty")
 	timeoutSeconds := pflag.Int32("timeoutSeconds", 1, "Duration in seconds the probe will wait for the guest command to return.")
 	guestAgentPing := pflag.Bool("guestAgentPing", false, "Flag to specify readiness probe based of guest-agent ping")
+	skipWhenPaused := pflag.Bool("skipWhenPaused", false, "Succeed without probing while the domain is paused. Set for liveness probes only")
 
 	pflag.CommandLine.AddGoFlag(goflag.CommandLine.Lookup("v"))
 	pflag.Parse()
@@ -53,6 +55,16 @@ func main() {
 		os.Exit(1)
 	}
 
+	// a paused guest can't answer, it must not be restarted for that, but it is not ready either
+	if *skipWhenPaused {
+		if paused, err := isDomainPaused(client); err != nil {
+			log.Log.Reason(err).Error("Failed to get the domain state")
+		} else if paused {
+			log.Log.V(4).Info("The domain is paused, skipping the probe")
+			os.Exit(0)
+		}
+	}
+
 	if *guestAgentPing {
 		err := client.GuestPing(*domainName, *timeoutSeconds)
 		if err != nil {
@@ -74,6 +86,14 @@ func main() {
 	os.Exit(exitCode)
 }
 
+func isDomainPaused(client cmdclient.LauncherClient) (bool, error) {
+	domain, exists, err := client.GetDomain()
+	if err != nil || !exists {
+		return false, err
+	}
+	return domain.Status.Status == api.Paused, nil
+}
+
 func saveMemoryProfile(path string) {
 	if len(path) > 0 {
 		log.Log.Info("creating memory profile")
@@ -0,0 +1,144 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package main
+
+import (
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"kubevirt.io/client-go/log"
+)
+
+// openLogFile waits for the log file to appear and opens it. It returns a nil file if stop is closed first.
+func openLogFile(logFile string, pollInterval time.Duration, stop <-chan struct{}) (*os.File, error) {
+	for {
+		file, err := os.Open(logFile)
+		if err == nil {
+			return file, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		select {
+		case <-stop:
+			return nil, nil
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// rotated checks if the log file was replaced by a new one. virtlogd rotates the log once it
+// reaches its max_size, by renaming it and creating a new one in its place.
+func rotated(logFile string, info os.FileInfo) (bool, error) {
+	current, err := os.Stat(logFile)
+	if os.IsNotExist(err) {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+	return !os.SameFile(info, current), nil
+}
+
+// follow waits for the log file to appear and copies everything which gets appended to it to out,
+// until stop is closed. A truncated log file is followed from its beginning again, a rotated log
+// file is followed into the new one once the old one was copied completely.
+func follow(logFile string, pollInterval time.Duration, out io.Writer, stop <-chan struct{}) error {
+	file, err := openLogFile(logFile, pollInterval, stop)
+	if file == nil {
+		return err
+	}
+	defer func() { file.Close() }()
+
+	var offset int64
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := file.Read(buf)
+		if n > 0 {
+			if _, err := out.Write(buf[:n]); err != nil {
+				return err
+			}
+			offset += int64(n)
+			continue
+		}
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		if info.Size() < offset {
+			if offset, err = file.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			continue
+		}
+		isRotated, err := rotated(logFile, info)
+		if err != nil {
+			return err
+		}
+		if isRotated {
+			file.Close()
+			if file, err = openLogFile(logFile, pollInterval, stop); file == nil {
+				return err
+			}
+			offset = 0
+			continue
+		}
+
+		select {
+		case <-stop:
+			return nil
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func main() {
+	logFile := pflag.String("logfile", "", "Path of the log file to stream to stdout")
+	pollInterval := pflag.Duration("poll-interval", 500*time.Millisecond, "Interval in which the log file is checked for new content")
+	pflag.Parse()
+
+	log.InitializeLogging("virt-tail")
+
+	if *logFile == "" {
+		log.Log.Error("The --logfile flag must be provided")
+		os.Exit(1)
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	stop := make(chan struct{})
+	go func() {
+		<-signals
+		close(stop)
+	}()
+
+	if err := follow(*logFile, *pollInterval, os.Stdout, stop); err != nil {
+		log.Log.Reason(err).Errorf("Failed to stream %s", *logFile)
+		os.Exit(1)
+	}
+}
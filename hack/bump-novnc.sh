@@ -0,0 +1,21 @@
+#!/usr/bin/env bash
+
+set -ex
+
+source $(dirname "$0")/common.sh
+
+# noVNC is bundled with virt-api, which serves it to the browser VNC console
+NOVNC_VERSION=${NOVNC_VERSION:-v1.3.0}
+NOVNC_DIR=${KUBEVIRT_DIR}/pkg/virt-api/rest/novnc
+
+tmp_dir=$(mktemp -d)
+trap "rm -rf ${tmp_dir}" EXIT
+
+curl -L https://github.com/novnc/noVNC/archive/refs/tags/${NOVNC_VERSION}.tar.gz | tar -xz -C ${tmp_dir} --strip-components=1
+
+rm -rf ${NOVNC_DIR}/core ${NOVNC_DIR}/vendor ${NOVNC_DIR}/LICENSE.txt
+cp -r ${tmp_dir}/core ${NOVNC_DIR}/
+mkdir -p ${NOVNC_DIR}/vendor
+cp -r ${tmp_dir}/vendor/pako ${NOVNC_DIR}/vendor/
+cp ${tmp_dir}/LICENSE.txt ${NOVNC_DIR}/
+sed -i "s/^Version: .*/Version: ${NOVNC_VERSION}/" ${NOVNC_DIR}/README.md
@@ -39,7 +39,7 @@ func GetConfigMapDiskPath(volumeName string) string {
 // CreateConfigMapDisks creates ConfigMap iso disks which are attached to vmis
 func CreateConfigMapDisks(vmi *v1.VirtualMachineInstance, emptyIso bool) error {
 	for _, volume := range vmi.Spec.Volumes {
-		if volume.ConfigMap != nil {
+		if volume.ConfigMap != nil && !isSharedAsFilesystem(vmi, volume.Name) {
 			var filesPath []string
 			filesPath, err := getFilesLayout(GetConfigMapSourcePath(volume.Name))
 			if err != nil {
@@ -165,6 +165,17 @@ func createIsoConfigImage(output string, volID string, files []string, size int6
 	return nil
 }
 
+// isSharedAsFilesystem returns true if the volume is shared with the guest through
+// virtiofs and therefore does not need an iso image
+func isSharedAsFilesystem(vmi *v1.VirtualMachineInstance, volumeName string) bool {
+	for _, fs := range vmi.Spec.Domain.Devices.Filesystems {
+		if fs.Name == volumeName {
+			return true
+		}
+	}
+	return false
+}
+
 func findIsoSize(vmi *v1.VirtualMachineInstance, volume *v1.Volume, emptyIso bool) (int64, error) {
 	if emptyIso {
 		for _, vs := range vmi.Status.VolumeStatus {
@@ -338,6 +338,9 @@ func CreateEphemeralImages(
 			if info == nil {
 				return fmt.Errorf("no disk info provided for volume %s", volume.Name)
 			}
+			if overlay := volume.ContainerDisk.Overlay; overlay != nil && overlay.Capacity != nil && overlay.Capacity.Value() < int64(info.VirtualSize) {
+				return fmt.Errorf("overlay capacity %s of volume %s is smaller than its image of %d bytes", overlay.Capacity.String(), volume.Name, info.VirtualSize)
+			}
 			if backingFile, err := GetDiskTargetPartFromLauncherView(i); err != nil {
 				return err
 			} else if err := diskCreator.CreateBackedImageForVolume(volume, backingFile, info.Format); err != nil {
@@ -112,6 +112,32 @@ func VirtualMachineKey(vm *v1.VirtualMachine) string {
 	return fmt.Sprintf("%v/%v", vm.ObjectMeta.Namespace, vm.ObjectMeta.Name)
 }
 
+// StartAfterGroups returns the start groups the VirtualMachine waits for before it is started
+func StartAfterGroups(vm *v1.VirtualMachine) []string {
+	var groups []string
+	for _, group := range strings.Split(vm.Annotations[v1.StartAfterAnnotation], ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// IsStartDependency tells if the VirtualMachine waits for the dependency, because the dependency
+// is a member of one of its start groups
+func IsStartDependency(vm *v1.VirtualMachine, dependency *v1.VirtualMachine) bool {
+	group, isMember := dependency.Labels[v1.StartGroupLabel]
+	if !isMember || dependency.Name == vm.Name || dependency.Namespace != vm.Namespace {
+		return false
+	}
+	for _, g := range StartAfterGroups(vm) {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
 func PodKey(pod *k8sv1.Pod) string {
 	return fmt.Sprintf("%v/%v", pod.Namespace, pod.Name)
 }
@@ -338,6 +364,9 @@ func VMIHasHotplugVolumes(vmi *v1.VirtualMachineInstance) bool {
 		if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.Hotpluggable {
 			return true
 		}
+		if volume.MemoryDump != nil {
+			return true
+		}
 	}
 	return false
 }
@@ -0,0 +1,38 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package controller
+
+import (
+	"k8s.io/client-go/tools/record"
+)
+
+// EventCorrelatorOptions are shared by the event broadcasters of all components. Only the
+// aggregation differs from the client-go defaults, which apply to all fields left empty: events
+// of an object which only differ in their message are combined after 5 instead of 10 of them
+// within 10 minutes. The rate limit of an object stays at the default burst of 25 events,
+// afterwards one event every 5 minutes.
+var EventCorrelatorOptions = record.CorrelatorOptions{
+	MaxEvents: 5,
+}
+
+// NewEventBroadcaster returns an event broadcaster which deduplicates and rate limits the events
+func NewEventBroadcaster() record.EventBroadcaster {
+	return record.NewBroadcasterWithCorrelatorOptions(EventCorrelatorOptions)
+}
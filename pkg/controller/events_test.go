@@ -0,0 +1,76 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package controller
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+var _ = Describe("Event correlation", func() {
+
+	newEvent := func(reason string, message string) *k8sv1.Event {
+		now := metav1.Now()
+		return &k8sv1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "testvmi.123", Namespace: "default"},
+			InvolvedObject: k8sv1.ObjectReference{Kind: "VirtualMachineInstance", Namespace: "default", Name: "testvmi", UID: "1234"},
+			Source:         k8sv1.EventSource{Component: "virt-handler", Host: "node01"},
+			Type:           k8sv1.EventTypeWarning,
+			Reason:         reason,
+			Message:        message,
+			FirstTimestamp: now,
+			LastTimestamp:  now,
+			Count:          1,
+		}
+	}
+
+	It("should combine similar events of an object", func() {
+		correlator := record.NewEventCorrelatorWithOptions(EventCorrelatorOptions)
+
+		var result *record.EventCorrelateResult
+		for i := 0; i < EventCorrelatorOptions.MaxEvents; i++ {
+			var err error
+			result, err = correlator.EventCorrelate(newEvent("SyncFailed", fmt.Sprintf("failure %d", i)))
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(result.Event.Message).To(HavePrefix("(combined from similar events)"))
+	})
+
+	It("should rate limit the events of an object", func() {
+		correlator := record.NewEventCorrelatorWithOptions(EventCorrelatorOptions)
+
+		// the default burst of client-go
+		const burstSize = 25
+		skipped := 0
+		for i := 0; i < burstSize+5; i++ {
+			result, err := correlator.EventCorrelate(newEvent(fmt.Sprintf("Reason%d", i), "message"))
+			Expect(err).ToNot(HaveOccurred())
+			if result.Skip {
+				skipped++
+			}
+		}
+		Expect(skipped).To(Equal(5))
+	})
+})
@@ -20,6 +20,7 @@
 package controller
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -40,9 +41,12 @@ import (
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	extclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
@@ -50,11 +54,14 @@ import (
 	aggregatorclient "k8s.io/kube-aggregator/pkg/client/clientset_generated/clientset"
 
 	kubev1 "kubevirt.io/client-go/api/v1"
+	poolv1 "kubevirt.io/client-go/apis/pool/v1alpha1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1beta1"
+	"kubevirt.io/kubevirt/pkg/network/macpool"
 	"kubevirt.io/kubevirt/pkg/testutils"
+	typesutil "kubevirt.io/kubevirt/pkg/util/types"
 )
 
 const (
@@ -92,9 +99,15 @@ type KubeInformerFactory interface {
 	// Watches for pods related only to kubevirt
 	KubeVirtPod() cache.SharedIndexInformer
 
+	// Watches for services related only to kubevirt
+	KubeVirtService() cache.SharedIndexInformer
+
 	// Watches for nodes
 	KubeVirtNode() cache.SharedIndexInformer
 
+	// Watches for a specific node
+	HostNode(hostName string) cache.SharedIndexInformer
+
 	// VirtualMachine handles the VMIs that are stopped or not running
 	VirtualMachine() cache.SharedIndexInformer
 
@@ -110,12 +123,18 @@ type KubeInformerFactory interface {
 	// Watches VirtualMachineRestore objects
 	VirtualMachineRestore() cache.SharedIndexInformer
 
+	// Watches VirtualMachinePool objects
+	VirtualMachinePool() cache.SharedIndexInformer
+
 	// Watches for k8s extensions api configmap
 	ApiAuthConfigMap() cache.SharedIndexInformer
 
 	// Watches for the kubevirt CA config map
 	KubeVirtCAConfigMap() cache.SharedIndexInformer
 
+	// Watches for the config map with the capabilities of the storage classes
+	StorageCapabilitiesConfigMap() cache.SharedIndexInformer
+
 	// ConfigMaps which are managed by the operator
 	OperatorConfigMap() cache.SharedIndexInformer
 
@@ -143,6 +162,12 @@ type KubeInformerFactory interface {
 	// Fake CDI DataSource informer used when feature gate is disabled
 	DummyDataSource() cache.SharedIndexInformer
 
+	// Watches for CDI StorageProfile objects, which are kept unstructured
+	StorageProfile() cache.SharedIndexInformer
+
+	// Fake CDI StorageProfile informer used when CDI is not installed
+	DummyStorageProfile() cache.SharedIndexInformer
+
 	// CRD
 	CRD() cache.SharedIndexInformer
 
@@ -331,6 +356,7 @@ func (f *kubeInformerFactory) VMI() cache.SharedIndexInformer {
 			"node": func(obj interface{}) (strings []string, e error) {
 				return []string{obj.(*kubev1.VirtualMachineInstance).Status.NodeName}, nil
 			},
+			macpool.MacAddressIndex: macpool.VirtualMachineInstanceMacAddresses,
 		})
 	})
 }
@@ -403,6 +429,19 @@ func (f *kubeInformerFactory) KubeVirtPod() cache.SharedIndexInformer {
 	})
 }
 
+func (f *kubeInformerFactory) KubeVirtService() cache.SharedIndexInformer {
+	return f.getInformer("kubeVirtServiceInformer", func() cache.SharedIndexInformer {
+		// Watch all services with the kubevirt app label
+		labelSelector, err := labels.Parse(kubev1.AppLabel)
+		if err != nil {
+			panic(err)
+		}
+
+		lw := NewListWatchFromClient(f.clientSet.CoreV1().RESTClient(), "services", k8sv1.NamespaceAll, fields.Everything(), labelSelector)
+		return cache.NewSharedIndexInformer(lw, &k8sv1.Service{}, f.defaultResync, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	})
+}
+
 func (f *kubeInformerFactory) KubeVirtNode() cache.SharedIndexInformer {
 	return f.getInformer("kubeVirtNodeInformer", func() cache.SharedIndexInformer {
 		lw := NewListWatchFromClient(f.clientSet.CoreV1().RESTClient(), "nodes", k8sv1.NamespaceAll, fields.Everything(), labels.Everything())
@@ -410,10 +449,21 @@ func (f *kubeInformerFactory) KubeVirtNode() cache.SharedIndexInformer {
 	})
 }
 
+func (f *kubeInformerFactory) HostNode(hostName string) cache.SharedIndexInformer {
+	return f.getInformer("hostNodeInformer", func() cache.SharedIndexInformer {
+		fieldSelector := fields.OneTermEqualSelector("metadata.name", hostName)
+		lw := NewListWatchFromClient(f.clientSet.CoreV1().RESTClient(), "nodes", k8sv1.NamespaceAll, fieldSelector, labels.Everything())
+		return cache.NewSharedIndexInformer(lw, &k8sv1.Node{}, f.defaultResync, cache.Indexers{})
+	})
+}
+
 func (f *kubeInformerFactory) VirtualMachine() cache.SharedIndexInformer {
 	return f.getInformer("vmInformer", func() cache.SharedIndexInformer {
 		lw := cache.NewListWatchFromClient(f.restClient, "virtualmachines", k8sv1.NamespaceAll, fields.Everything())
-		return cache.NewSharedIndexInformer(lw, &kubev1.VirtualMachine{}, f.defaultResync, cache.Indexers{})
+		return cache.NewSharedIndexInformer(lw, &kubev1.VirtualMachine{}, f.defaultResync, cache.Indexers{
+			cache.NamespaceIndex:    cache.MetaNamespaceIndexFunc,
+			macpool.MacAddressIndex: macpool.VirtualMachineMacAddresses,
+		})
 	})
 }
 
@@ -496,6 +546,13 @@ func (f *kubeInformerFactory) VirtualMachineRestore() cache.SharedIndexInformer
 	})
 }
 
+func (f *kubeInformerFactory) VirtualMachinePool() cache.SharedIndexInformer {
+	return f.getInformer("vmPoolInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.clientSet.GeneratedKubeVirtClient().PoolV1alpha1().RESTClient(), "virtualmachinepools", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &poolv1.VirtualMachinePool{}, f.defaultResync, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	})
+}
+
 func (f *kubeInformerFactory) DataVolume() cache.SharedIndexInformer {
 	return f.getInformer("dataVolumeInformer", func() cache.SharedIndexInformer {
 		lw := cache.NewListWatchFromClient(f.clientSet.CdiClient().CdiV1beta1().RESTClient(), "datavolumes", k8sv1.NamespaceAll, fields.Everything())
@@ -524,6 +581,30 @@ func (f *kubeInformerFactory) DummyDataSource() cache.SharedIndexInformer {
 	})
 }
 
+func (f *kubeInformerFactory) StorageProfile() cache.SharedIndexInformer {
+	return f.getInformer("storageProfileInformer", func() cache.SharedIndexInformer {
+		// the vendored CDI API does not know every field CDI reports in the status of a StorageProfile,
+		// like the clone strategy, so StorageProfiles are kept unstructured
+		resource := f.clientSet.DynamicClient().Resource(cdiv1.SchemeGroupVersion.WithResource("storageprofiles"))
+		lw := &cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return resource.List(context.Background(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return resource.Watch(context.Background(), options)
+			},
+		}
+		return cache.NewSharedIndexInformer(lw, &unstructured.Unstructured{}, f.defaultResync, cache.Indexers{})
+	})
+}
+
+func (f *kubeInformerFactory) DummyStorageProfile() cache.SharedIndexInformer {
+	return f.getInformer("fakeStorageProfileInformer", func() cache.SharedIndexInformer {
+		informer, _ := testutils.NewFakeInformerFor(&unstructured.Unstructured{})
+		return informer
+	})
+}
+
 func (f *kubeInformerFactory) ApiAuthConfigMap() cache.SharedIndexInformer {
 	return f.getInformer("extensionsConfigMapInformer", func() cache.SharedIndexInformer {
 		restClient := f.clientSet.CoreV1().RESTClient()
@@ -542,6 +623,15 @@ func (f *kubeInformerFactory) KubeVirtCAConfigMap() cache.SharedIndexInformer {
 	})
 }
 
+func (f *kubeInformerFactory) StorageCapabilitiesConfigMap() cache.SharedIndexInformer {
+	return f.getInformer("storageCapabilitiesConfigMapInformer", func() cache.SharedIndexInformer {
+		restClient := f.clientSet.CoreV1().RESTClient()
+		fieldSelector := fields.OneTermEqualSelector("metadata.name", typesutil.StorageCapabilitiesConfigMapName)
+		lw := cache.NewListWatchFromClient(restClient, "configmaps", f.kubevirtNamespace, fieldSelector)
+		return cache.NewSharedIndexInformer(lw, &k8sv1.ConfigMap{}, f.defaultResync, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	})
+}
+
 func (f *kubeInformerFactory) ConfigMap() cache.SharedIndexInformer {
 	return f.getInformer("configMapInformer", func() cache.SharedIndexInformer {
 		restClient := f.clientSet.CoreV1().RESTClient()
@@ -24,6 +24,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	diskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
@@ -39,13 +40,15 @@ type EphemeralDiskCreatorInterface interface {
 	CreateBackedImageForVolume(volume v1.Volume, backingFile string, backingFormat string) error
 	CreateEphemeralImages(vmi *v1.VirtualMachineInstance) error
 	GetFilePath(volumeName string) string
+	GetPVCFilePath(volumeName string) string
 	Init() error
 }
 
 type ephemeralDiskCreator struct {
 	mountBaseDir   string
 	pvcBaseDir     string
-	discCreateFunc func(backingFile string, backingFormat string, imagePath string) ([]byte, error)
+	discCreateFunc func(backingFile string, backingFormat string, imagePath string, size int64) ([]byte, error)
+	discRebaseFunc func(backingFile string, backingFormat string, imagePath string) ([]byte, error)
 }
 
 func NewEphemeralDiskCreator(mountBaseDir string) *ephemeralDiskCreator {
@@ -53,6 +56,7 @@ func NewEphemeralDiskCreator(mountBaseDir string) *ephemeralDiskCreator {
 		mountBaseDir:   mountBaseDir,
 		pvcBaseDir:     ephemeralDiskPVCBaseDir,
 		discCreateFunc: createBackingDisk,
+		discRebaseFunc: rebaseBackingDisk,
 	}
 }
 
@@ -84,21 +88,43 @@ func (c *ephemeralDiskCreator) GetFilePath(volumeName string) string {
 	return filepath.Join(volumeMountDir, "disk.qcow2")
 }
 
+// GetPVCFilePath returns the path of the COW image of a volume, which is stored on the PVC mounted for the volume
+func (c *ephemeralDiskCreator) GetPVCFilePath(volumeName string) string {
+	return filepath.Join(c.pvcBaseDir, volumeName, "disk.qcow2")
+}
+
 func (c *ephemeralDiskCreator) CreateBackedImageForVolume(volume v1.Volume, backingFile string, backingFormat string) error {
-	err := c.createVolumeDirectory(volume.Name)
-	if err != nil {
-		return err
+	var overlay *v1.ContainerDiskOverlay
+	if volume.ContainerDisk != nil {
+		overlay = volume.ContainerDisk.Overlay
 	}
 
 	imagePath := c.GetFilePath(volume.Name)
+	if overlay != nil && overlay.ClaimName != "" {
+		imagePath = c.GetPVCFilePath(volume.Name)
+	} else if err := c.createVolumeDirectory(volume.Name); err != nil {
+		return err
+	}
 
 	if _, err := os.Stat(imagePath); err == nil {
+		if overlay == nil || overlay.ClaimName == "" {
+			return nil
+		}
+		// An image kept on a PVC can be reused, but the path of its backing file depends on the position of
+		// the volume, which may have changed since the image was created.
+		if output, err := c.discRebaseFunc(backingFile, backingFormat, imagePath); err != nil {
+			return fmt.Errorf("qemu-img failed with output '%s': %v", string(output), err)
+		}
 		return nil
 	} else if !os.IsNotExist(err) {
 		return err
 	}
 
-	output, err := c.discCreateFunc(backingFile, backingFormat, imagePath)
+	var size int64
+	if overlay != nil && overlay.Capacity != nil {
+		size = overlay.Capacity.Value()
+	}
+	output, err := c.discCreateFunc(backingFile, backingFormat, imagePath, size)
 
 	// Cleanup of previous images isn't really necessary as they're all on EmptyDir.
 	if err != nil {
@@ -130,9 +156,8 @@ func (c *ephemeralDiskCreator) CreateEphemeralImages(vmi *v1.VirtualMachineInsta
 	return nil
 }
 
-func createBackingDisk(backingFile string, backingFormat string, imagePath string) ([]byte, error) {
-	// #nosec No risk for attacket injection. Parameters are predefined strings
-	cmd := exec.Command("qemu-img",
+func createBackingDisk(backingFile string, backingFormat string, imagePath string, size int64) ([]byte, error) {
+	args := []string{
 		"create",
 		"-f",
 		"qcow2",
@@ -141,6 +166,25 @@ func createBackingDisk(backingFile string, backingFormat string, imagePath strin
 		"-F",
 		backingFormat,
 		imagePath,
+	}
+	if size > 0 {
+		args = append(args, strconv.FormatInt(size, 10))
+	}
+	// #nosec No risk for attacket injection. Parameters are predefined strings
+	cmd := exec.Command("qemu-img", args...)
+	return cmd.CombinedOutput()
+}
+
+func rebaseBackingDisk(backingFile string, backingFormat string, imagePath string) ([]byte, error) {
+	// #nosec No risk for attacket injection. Parameters are predefined strings
+	cmd := exec.Command("qemu-img",
+		"rebase",
+		"-u",
+		"-b",
+		backingFile,
+		"-F",
+		backingFormat,
+		imagePath,
 	)
 	return cmd.CombinedOutput()
 }
@@ -28,6 +28,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	v1 "kubevirt.io/client-go/api/v1"
 )
@@ -146,9 +147,63 @@ var _ = Describe("ContainerDisk", func() {
 			})
 		})
 	})
+
+	Describe("containerDisk overlay", func() {
+		var createdSize int64
+		var rebasedImages []string
+
+		BeforeEach(func() {
+			createdSize = -1
+			rebasedImages = nil
+			creator.discCreateFunc = func(_ string, _ string, imagePath string, size int64) ([]byte, error) {
+				createdSize = size
+				f, err := os.Create(imagePath)
+				if err != nil {
+					return nil, err
+				}
+				return nil, f.Close()
+			}
+			creator.discRebaseFunc = func(_ string, _ string, imagePath string) ([]byte, error) {
+				rebasedImages = append(rebasedImages, imagePath)
+				return nil, nil
+			}
+		})
+
+		newContainerDiskVolume := func(overlay *v1.ContainerDiskOverlay) v1.Volume {
+			return v1.Volume{
+				Name: "containerdisk",
+				VolumeSource: v1.VolumeSource{
+					ContainerDisk: &v1.ContainerDiskSource{Image: "my-image", Overlay: overlay},
+				},
+			}
+		}
+
+		It("should create the overlay with the requested capacity", func() {
+			capacity := resource.MustParse("20Gi")
+			volume := newContainerDiskVolume(&v1.ContainerDiskOverlay{Capacity: &capacity})
+
+			Expect(creator.CreateBackedImageForVolume(volume, "/disk_0.img", "qcow2")).To(Succeed())
+			Expect(createdSize).To(Equal(capacity.Value()))
+			Expect(creator.GetFilePath("containerdisk")).To(BeAnExistingFile())
+		})
+
+		It("should create the overlay on the PVC and rebase it when it exists", func() {
+			Expect(os.Mkdir(filepath.Join(pvcBaseTempDirPath, "containerdisk"), 0755)).To(Succeed())
+			volume := newContainerDiskVolume(&v1.ContainerDiskOverlay{ClaimName: "overlay-pvc"})
+
+			Expect(creator.CreateBackedImageForVolume(volume, "/disk_0.img", "qcow2")).To(Succeed())
+			Expect(createdSize).To(BeZero())
+			Expect(creator.GetPVCFilePath("containerdisk")).To(BeAnExistingFile())
+			Expect(rebasedImages).To(BeEmpty())
+
+			Expect(creator.CreateBackedImageForVolume(volume, "/disk_1.img", "qcow2")).To(Succeed())
+			Expect(rebasedImages).To(Equal([]string{creator.GetPVCFilePath("containerdisk")}))
+			Expect(filepath.Join(creator.mountBaseDir, "containerdisk")).ToNot(BeADirectory())
+		})
+	})
 })
 
-func fakeCreateBackingDisk(backingFile string, backingFormat string, imagePath string) ([]byte, error) {
+func fakeCreateBackingDisk(backingFile string, backingFormat string, imagePath string, _ int64) ([]byte, error) {
 	if backingFormat != "raw" {
 		return nil, fmt.Errorf("wrong backing format")
 	}
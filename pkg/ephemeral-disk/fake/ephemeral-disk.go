@@ -22,6 +22,10 @@ func (m *MockEphemeralDiskImageCreator) GetFilePath(volumeName string) string {
 	return filepath.Join(m.BaseDir, volumeName, "disk.qcow2")
 }
 
+func (m *MockEphemeralDiskImageCreator) GetPVCFilePath(volumeName string) string {
+	return filepath.Join(m.BaseDir, "pvc", volumeName, "disk.qcow2")
+}
+
 func (m *MockEphemeralDiskImageCreator) Init() error {
 	return nil
 }
@@ -31,6 +31,7 @@ It has these top-level messages:
 	ExecResponse
 	GuestPingRequest
 	GuestPingResponse
+	MemoryDumpRequest
 */
 package v1
 
@@ -335,6 +336,7 @@ type VirtualMachineOptions struct {
 	PreallocatedVolumes   []string             `protobuf:"bytes,3,rep,name=PreallocatedVolumes" json:"PreallocatedVolumes,omitempty"`
 	Topology              *Topology            `protobuf:"bytes,4,opt,name=topology" json:"topology,omitempty"`
 	DisksInfo             map[string]*DiskInfo `protobuf:"bytes,5,rep,name=DisksInfo" json:"DisksInfo,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ExpandDisksEnabled    bool                 `protobuf:"varint,6,opt,name=ExpandDisksEnabled" json:"ExpandDisksEnabled,omitempty"`
 }
 
 func (m *VirtualMachineOptions) Reset()                    { *m = VirtualMachineOptions{} }
@@ -377,6 +379,13 @@ func (m *VirtualMachineOptions) GetDisksInfo() map[string]*DiskInfo {
 	return nil
 }
 
+func (m *VirtualMachineOptions) GetExpandDisksEnabled() bool {
+	if m != nil {
+		return m.ExpandDisksEnabled
+	}
+	return false
+}
+
 type VMIRequest struct {
 	Vmi     *VMI                   `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
 	Options *VirtualMachineOptions `protobuf:"bytes,2,opt,name=options" json:"options,omitempty"`
@@ -689,6 +698,30 @@ func (m *GuestPingResponse) GetResponse() *Response {
 	return nil
 }
 
+type MemoryDumpRequest struct {
+	Vmi      *VMI   `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
+	DumpPath string `protobuf:"bytes,2,opt,name=dumpPath" json:"dumpPath,omitempty"`
+}
+
+func (m *MemoryDumpRequest) Reset()                    { *m = MemoryDumpRequest{} }
+func (m *MemoryDumpRequest) String() string            { return proto.CompactTextString(m) }
+func (*MemoryDumpRequest) ProtoMessage()               {}
+func (*MemoryDumpRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *MemoryDumpRequest) GetVmi() *VMI {
+	if m != nil {
+		return m.Vmi
+	}
+	return nil
+}
+
+func (m *MemoryDumpRequest) GetDumpPath() string {
+	if m != nil {
+		return m.DumpPath
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*VMI)(nil), "kubevirt.cmd.v1.VMI")
 	proto.RegisterType((*CPU)(nil), "kubevirt.cmd.v1.CPU")
@@ -713,6 +746,7 @@ func init() {
 	proto.RegisterType((*ExecResponse)(nil), "kubevirt.cmd.v1.ExecResponse")
 	proto.RegisterType((*GuestPingRequest)(nil), "kubevirt.cmd.v1.GuestPingRequest")
 	proto.RegisterType((*GuestPingResponse)(nil), "kubevirt.cmd.v1.GuestPingResponse")
+	proto.RegisterType((*MemoryDumpRequest)(nil), "kubevirt.cmd.v1.MemoryDumpRequest")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -747,6 +781,7 @@ type CmdClient interface {
 	Ping(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*Response, error)
 	Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (*ExecResponse, error)
 	GuestPing(ctx context.Context, in *GuestPingRequest, opts ...grpc.CallOption) (*GuestPingResponse, error)
+	VirtualMachineMemoryDump(ctx context.Context, in *MemoryDumpRequest, opts ...grpc.CallOption) (*Response, error)
 }
 
 type cmdClient struct {
@@ -946,6 +981,15 @@ func (c *cmdClient) GuestPing(ctx context.Context, in *GuestPingRequest, opts ..
 	return out, nil
 }
 
+func (c *cmdClient) VirtualMachineMemoryDump(ctx context.Context, in *MemoryDumpRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/VirtualMachineMemoryDump", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Cmd service
 
 type CmdServer interface {
@@ -970,6 +1014,7 @@ type CmdServer interface {
 	Ping(context.Context, *EmptyRequest) (*Response, error)
 	Exec(context.Context, *ExecRequest) (*ExecResponse, error)
 	GuestPing(context.Context, *GuestPingRequest) (*GuestPingResponse, error)
+	VirtualMachineMemoryDump(context.Context, *MemoryDumpRequest) (*Response, error)
 }
 
 func RegisterCmdServer(s *grpc.Server, srv CmdServer) {
@@ -1354,6 +1399,24 @@ func _Cmd_GuestPing_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Cmd_VirtualMachineMemoryDump_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemoryDumpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).VirtualMachineMemoryDump(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/VirtualMachineMemoryDump",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).VirtualMachineMemoryDump(ctx, req.(*MemoryDumpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Cmd_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "kubevirt.cmd.v1.Cmd",
 	HandlerType: (*CmdServer)(nil),
@@ -1442,6 +1505,10 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GuestPing",
 			Handler:    _Cmd_GuestPing_Handler,
 		},
+		{
+			MethodName: "VirtualMachineMemoryDump",
+			Handler:    _Cmd_VirtualMachineMemoryDump_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/handler-launcher-com/cmd/v1/cmd.proto",
@@ -1450,86 +1517,90 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("pkg/handler-launcher-com/cmd/v1/cmd.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1289 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0x5d, 0x6f, 0xdb, 0x36,
-	0x17, 0x8e, 0xbf, 0x52, 0xfb, 0xe4, 0xe3, 0x6d, 0xd9, 0xb8, 0xaf, 0x96, 0xad, 0x6d, 0x26, 0x0c,
-	0x41, 0x0a, 0xb4, 0xc9, 0x92, 0xa5, 0xc3, 0xd0, 0x8b, 0xa1, 0x8b, 0x9b, 0x06, 0x6d, 0xe7, 0xd6,
-	0xa3, 0x93, 0x14, 0xeb, 0x06, 0x14, 0x8c, 0xc4, 0x28, 0x44, 0x24, 0xd2, 0x13, 0x29, 0xaf, 0xee,
-	0xed, 0x86, 0x5d, 0x0c, 0xd8, 0x8f, 0xd9, 0xaf, 0xd9, 0xcd, 0x7e, 0xcc, 0x40, 0x8a, 0x72, 0x6c,
-	0x4b, 0x59, 0x3a, 0xd8, 0x57, 0xe6, 0xf9, 0x7a, 0xce, 0xe1, 0xe1, 0x43, 0x1e, 0xc1, 0x70, 0xaf,
-	0x77, 0x1e, 0x6c, 0x9d, 0x11, 0xee, 0x87, 0x34, 0x7e, 0x10, 0x92, 0x84, 0x7b, 0x67, 0x34, 0x7e,
-	0xe0, 0x89, 0x68, 0xcb, 0x8b, 0xfc, 0xad, 0xfe, 0xb6, 0xfe, 0xd9, 0xec, 0xc5, 0x42, 0x09, 0xf4,
-	0xbf, 0xf3, 0xe4, 0x84, 0xf6, 0x59, 0xac, 0x36, 0xb5, 0xae, 0xbf, 0xed, 0xde, 0x85, 0xca, 0x71,
-	0xfb, 0x19, 0x72, 0xe0, 0x5a, 0x3f, 0x62, 0xcf, 0xa5, 0xe0, 0x4e, 0x69, 0xad, 0xb4, 0xb1, 0x88,
-	0x33, 0xd1, 0xdd, 0x86, 0x4a, 0xab, 0x73, 0x84, 0x96, 0xa1, 0xcc, 0x7c, 0x63, 0x5b, 0xc2, 0x65,
-	0xe6, 0xa3, 0x55, 0xa8, 0x4b, 0x76, 0x12, 0x32, 0x1e, 0x48, 0xa7, 0xbc, 0x56, 0xd9, 0x58, 0xc2,
-	0x43, 0xd9, 0xdd, 0x82, 0x6b, 0xdd, 0x74, 0x9d, 0x0b, 0x5b, 0x81, 0x5a, 0x9f, 0x84, 0x09, 0x75,
-	0xca, 0x6b, 0xa5, 0x8d, 0x2a, 0x4e, 0x05, 0x77, 0x1f, 0x6a, 0x1d, 0x12, 0x50, 0xa9, 0xcd, 0x9e,
-	0x48, 0xb8, 0x32, 0x11, 0x55, 0x9c, 0x0a, 0x08, 0x41, 0x35, 0xe1, 0x4c, 0x99, 0x98, 0x06, 0x36,
-	0x6b, 0xad, 0x93, 0xec, 0x3d, 0x75, 0x2a, 0x06, 0xda, 0xac, 0xdd, 0x5d, 0x98, 0x6f, 0xd3, 0x48,
-	0xc4, 0x03, 0x74, 0x0b, 0xe6, 0x49, 0x34, 0x02, 0x64, 0xa5, 0x22, 0x24, 0xf7, 0xaf, 0x12, 0x54,
-	0x5b, 0x34, 0x0c, 0x73, 0xb5, 0x6e, 0xc1, 0x7c, 0x64, 0xe0, 0x8c, 0xfb, 0xc2, 0xce, 0xff, 0x37,
-	0x27, 0x9a, 0xb7, 0x99, 0x66, 0xc3, 0xd6, 0x0d, 0xdd, 0x87, 0x5a, 0x4f, 0x6f, 0xc3, 0xa9, 0xac,
-	0x55, 0x36, 0x16, 0x76, 0x6e, 0xe5, 0xfc, 0xcd, 0x26, 0x71, 0xea, 0x84, 0xbe, 0x84, 0x86, 0xcf,
-	0xa4, 0x22, 0xdc, 0xa3, 0xd2, 0xa9, 0x9a, 0x08, 0x27, 0x17, 0x61, 0xfb, 0x88, 0x2f, 0x5c, 0xd1,
-	0x06, 0x54, 0xbd, 0x5e, 0x22, 0x9d, 0x9a, 0x09, 0x59, 0xc9, 0x85, 0xb4, 0x3a, 0x47, 0xd8, 0x78,
-	0xb8, 0x8f, 0xa1, 0x7e, 0x28, 0x7a, 0x22, 0x14, 0xc1, 0x00, 0xed, 0x02, 0xf0, 0x24, 0x22, 0x6f,
-	0x3d, 0x1a, 0x86, 0xd2, 0x29, 0x99, 0xd8, 0x66, 0x3e, 0x96, 0x86, 0x21, 0x6e, 0x68, 0x47, 0xbd,
-	0x92, 0xee, 0xef, 0x25, 0x98, 0xef, 0xb6, 0xf7, 0x98, 0x90, 0xc8, 0x85, 0xc5, 0x88, 0xf0, 0xe4,
-	0x94, 0x78, 0x2a, 0x89, 0x69, 0x6c, 0xfa, 0xd4, 0xc0, 0x63, 0x3a, 0xcd, 0xa2, 0x5e, 0x2c, 0xfc,
-	0xc4, 0xcb, 0x3a, 0x9c, 0x89, 0x86, 0x5f, 0x34, 0x96, 0x4c, 0x70, 0x73, 0x62, 0x0d, 0x9c, 0x89,
-	0xe8, 0x3a, 0x54, 0xe4, 0x79, 0xe2, 0x54, 0x8d, 0x56, 0x2f, 0xf5, 0xe1, 0x9d, 0x92, 0x88, 0x85,
-	0x03, 0xa7, 0x66, 0x94, 0x56, 0x72, 0x7f, 0x2b, 0x41, 0xfd, 0x09, 0x93, 0xe7, 0xcf, 0xf8, 0xa9,
-	0x30, 0x4e, 0x22, 0x8e, 0x88, 0xb2, 0x85, 0x58, 0x09, 0xad, 0xc1, 0xc2, 0x09, 0xf1, 0xce, 0x19,
-	0x0f, 0x9e, 0xb2, 0x90, 0xda, 0x32, 0x46, 0x55, 0xe8, 0x0e, 0x80, 0xae, 0x97, 0x84, 0xdd, 0x8c,
-	0x3f, 0x55, 0x3c, 0xa2, 0xd1, 0x08, 0xba, 0x25, 0x99, 0x43, 0xd5, 0x38, 0x8c, 0xaa, 0xdc, 0x3f,
-	0x2b, 0xd0, 0x3c, 0x4e, 0xe5, 0x36, 0xf1, 0xce, 0x18, 0xa7, 0xaf, 0x7a, 0x8a, 0x09, 0x2e, 0xd1,
-	0x0b, 0x58, 0x19, 0x37, 0xa4, 0xcd, 0x33, 0x35, 0x16, 0x11, 0x28, 0x35, 0xe3, 0xc2, 0x20, 0xb4,
-	0x0b, 0xcd, 0x36, 0x8d, 0xf6, 0x48, 0x18, 0x0a, 0xc1, 0xbb, 0x8a, 0x28, 0xd9, 0xa1, 0x31, 0x13,
-	0xbe, 0xd9, 0xd4, 0x12, 0x2e, 0x36, 0xa2, 0xcf, 0xe1, 0x66, 0x27, 0xa6, 0x5a, 0xef, 0x11, 0x45,
-	0xfd, 0x63, 0x11, 0x26, 0x91, 0xa5, 0x64, 0x03, 0x17, 0x99, 0xd0, 0x43, 0xa8, 0x2b, 0x4b, 0x13,
-	0xb3, 0xdb, 0x85, 0x9d, 0x8f, 0x72, 0x85, 0x66, 0x3c, 0xc2, 0x43, 0x57, 0xd4, 0x85, 0x86, 0x3e,
-	0x0d, 0xa9, 0x8f, 0xc3, 0x92, 0xf1, 0x61, 0x2e, 0xae, 0xb0, 0x4d, 0x9b, 0xc3, 0xb8, 0x7d, 0xae,
-	0xe2, 0x01, 0xbe, 0xc0, 0x59, 0x7d, 0x0d, 0xcb, 0xe3, 0x46, 0xcd, 0x8f, 0x73, 0x3a, 0xb0, 0xa7,
-	0xac, 0x97, 0x68, 0x6b, 0xf4, 0x0d, 0x29, 0x2a, 0x36, 0x23, 0x89, 0x7d, 0x5e, 0x1e, 0x95, 0xbf,
-	0x2a, 0xb9, 0x7d, 0x80, 0xe3, 0xf6, 0x33, 0x4c, 0x7f, 0x4a, 0xa8, 0x54, 0x68, 0x1d, 0x2a, 0xfd,
-	0x88, 0xd9, 0x63, 0xc9, 0x5f, 0x21, 0xed, 0xa9, 0x1d, 0xd0, 0x63, 0xb8, 0x26, 0xd2, 0x9a, 0x6d,
-	0xb2, 0xf5, 0x0f, 0xdb, 0x21, 0xce, 0xc2, 0xdc, 0x43, 0xb8, 0xde, 0x66, 0x41, 0x4c, 0xb4, 0xf4,
-	0x5f, 0xb3, 0x3b, 0xe3, 0xd9, 0x17, 0x2f, 0x50, 0x7f, 0x29, 0xc1, 0xc2, 0xfe, 0x3b, 0xea, 0x65,
-	0x88, 0x77, 0x00, 0x7c, 0x11, 0x11, 0xc6, 0x5f, 0x92, 0x88, 0xda, 0x5e, 0x8d, 0x68, 0x34, 0x52,
-	0x4b, 0x44, 0x11, 0xe1, 0x7e, 0x76, 0x31, 0xad, 0xa8, 0x5f, 0xc4, 0x6f, 0xe2, 0x20, 0xe3, 0x87,
-	0x59, 0xa3, 0x75, 0x58, 0x56, 0x2c, 0xa2, 0x22, 0x51, 0x5d, 0xea, 0x09, 0xee, 0x4b, 0x43, 0x8b,
-	0x1a, 0x9e, 0xd0, 0xba, 0xcb, 0xb0, 0xb8, 0x1f, 0xf5, 0xd4, 0xc0, 0x56, 0xe1, 0x7e, 0x0d, 0x75,
-	0x4c, 0x65, 0x4f, 0x70, 0x69, 0x32, 0xca, 0xc4, 0xf3, 0xa8, 0x4c, 0xc9, 0x5f, 0xc7, 0x99, 0xa8,
-	0x2d, 0x11, 0x95, 0x92, 0x04, 0xd9, 0xed, 0xcc, 0x44, 0xf7, 0x2d, 0x2c, 0x3f, 0x31, 0x35, 0x0f,
-	0x51, 0x1e, 0x42, 0x3d, 0xb6, 0x6b, 0xdb, 0xae, 0xfc, 0x69, 0x67, 0xce, 0x78, 0xe8, 0xaa, 0x1f,
-	0x87, 0x74, 0xf3, 0x36, 0x83, 0x95, 0x5c, 0x0e, 0x37, 0xd3, 0x04, 0xe6, 0xc2, 0x4c, 0x9b, 0x65,
-	0x0d, 0x16, 0xfc, 0x0b, 0xb4, 0xec, 0xa9, 0x19, 0x51, 0xb9, 0xef, 0xe0, 0xc6, 0x81, 0xee, 0x8c,
-	0x21, 0xe3, 0x94, 0xd9, 0xee, 0xc3, 0x8d, 0x60, 0x12, 0xcb, 0xe6, 0xcc, 0x1b, 0xdc, 0x5f, 0x4b,
-	0xd0, 0x34, 0xa9, 0x8f, 0x24, 0x8d, 0xbf, 0x65, 0x52, 0x4d, 0x9b, 0x7e, 0x17, 0x9a, 0x41, 0x11,
-	0x9e, 0x2d, 0xa1, 0xd8, 0xe8, 0xfe, 0x51, 0x02, 0xc7, 0x94, 0xa1, 0x5f, 0x5e, 0x39, 0x90, 0x8a,
-	0x46, 0x53, 0xb7, 0xfd, 0x11, 0x38, 0xc1, 0x25, 0x90, 0xb6, 0x98, 0x4b, 0xed, 0xee, 0x00, 0x16,
-	0xd3, 0x6b, 0x33, 0x5d, 0x09, 0xab, 0x50, 0xa7, 0xef, 0x98, 0x6a, 0x09, 0x3f, 0x4d, 0x59, 0xc3,
-	0x43, 0x59, 0x73, 0x4f, 0x2a, 0xff, 0x55, 0xa2, 0xec, 0xa0, 0xb3, 0x92, 0xfb, 0x06, 0xae, 0x9b,
-	0x4e, 0x74, 0xf4, 0x38, 0xff, 0xc0, 0x6b, 0x9b, 0xbf, 0x88, 0xe5, 0xc2, 0x8b, 0xf8, 0xdc, 0xf2,
-	0x2c, 0xc5, 0x9e, 0x6a, 0x6f, 0x3b, 0x7f, 0x2f, 0x41, 0xa5, 0x15, 0xf9, 0xe8, 0x25, 0xa0, 0xee,
-	0x80, 0x7b, 0xe3, 0xcf, 0x1b, 0xfa, 0xb8, 0xf0, 0xb5, 0x4a, 0xb7, 0xb3, 0x7a, 0x39, 0xbe, 0x3b,
-	0x87, 0x5e, 0xc1, 0xcd, 0x0e, 0x49, 0x24, 0x9d, 0x19, 0xe0, 0x77, 0xd0, 0x3c, 0xe2, 0xbd, 0x99,
-	0x42, 0x76, 0x60, 0xe5, 0x69, 0x4c, 0xe9, 0xfb, 0xd9, 0x21, 0x62, 0xb8, 0x75, 0xc4, 0x4f, 0x67,
-	0x8e, 0xd9, 0x3d, 0x4b, 0x94, 0x2f, 0x7e, 0xe6, 0x33, 0xc3, 0x7c, 0x09, 0xe8, 0x05, 0x0b, 0xc3,
-	0x59, 0x76, 0xf2, 0x09, 0x0d, 0xa9, 0x9a, 0xdd, 0xae, 0x5f, 0x43, 0x33, 0x1d, 0xa4, 0x93, 0x90,
-	0x9f, 0xe6, 0x3f, 0xcb, 0x27, 0x06, 0xee, 0x95, 0xc4, 0xd4, 0x44, 0x1f, 0x06, 0x1d, 0x92, 0x38,
-	0xa0, 0x6a, 0x8a, 0x4a, 0xbf, 0x87, 0xdb, 0x2d, 0xfd, 0xa9, 0x3e, 0xd1, 0xcd, 0x61, 0x82, 0x29,
-	0x8f, 0x9e, 0x05, 0x9c, 0x84, 0x69, 0x91, 0x1d, 0xe1, 0xb7, 0x42, 0x4a, 0x78, 0xd2, 0x9b, 0x02,
-	0xf3, 0x07, 0xb8, 0xfb, 0x94, 0x71, 0x12, 0xb2, 0x49, 0x8a, 0xce, 0xa2, 0xe0, 0x36, 0x34, 0x0e,
-	0xa8, 0x4a, 0x87, 0x2e, 0xba, 0x9d, 0xf3, 0x1c, 0xfd, 0x7c, 0x58, 0xbd, 0x9b, 0xff, 0x90, 0x1b,
-	0xfb, 0x1a, 0x30, 0x24, 0x58, 0x1e, 0xc2, 0x99, 0x11, 0x7b, 0x15, 0xe6, 0x67, 0x97, 0x60, 0x8e,
-	0x7d, 0x00, 0xb8, 0x73, 0xa8, 0x0b, 0x8b, 0x07, 0x54, 0x0d, 0x87, 0xf5, 0x55, 0xb0, 0x6e, 0xce,
-	0x9c, 0x9b, 0xf3, 0x06, 0xb4, 0x7e, 0x40, 0xcd, 0x50, 0xbc, 0xb2, 0xce, 0xf5, 0x62, 0xc0, 0xdc,
-	0x40, 0x9d, 0x43, 0x3f, 0x9a, 0x16, 0x8c, 0x0c, 0xb7, 0xab, 0xa0, 0xef, 0x15, 0x43, 0x17, 0x8d,
-	0xc7, 0x39, 0xb4, 0x07, 0x55, 0x3d, 0x44, 0xae, 0xc2, 0xfc, 0xd7, 0x33, 0xdf, 0x87, 0xaa, 0x1e,
-	0xb2, 0xe8, 0x93, 0x3c, 0xc6, 0xc5, 0x27, 0xeb, 0xea, 0xed, 0x4b, 0xac, 0x43, 0x98, 0x43, 0x68,
-	0x0c, 0x87, 0x5a, 0xc1, 0x25, 0x9f, 0x1c, 0xa6, 0x97, 0x9d, 0xc9, 0xe8, 0x4c, 0x74, 0xe7, 0xf6,
-	0xaa, 0x6f, 0xca, 0xfd, 0xed, 0x93, 0x79, 0xf3, 0x6f, 0xc8, 0x17, 0xff, 0x04, 0x00, 0x00, 0xff,
-	0xff, 0x00, 0x1b, 0xf6, 0x34, 0x3a, 0x11, 0x00, 0x00,
+	// 1350 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0x5f, 0x4f, 0x1b, 0xc7,
+	0x16, 0xc7, 0xd8, 0x10, 0xfb, 0x40, 0xb8, 0x61, 0x02, 0xb9, 0x7b, 0x7d, 0x6f, 0x12, 0xee, 0xa8,
+	0x42, 0x44, 0x4a, 0xa0, 0x50, 0x52, 0x55, 0x79, 0xa8, 0x52, 0x0c, 0x41, 0x49, 0xea, 0xc4, 0x1d,
+	0x03, 0x51, 0xd3, 0x4a, 0xd1, 0xb0, 0x3b, 0x98, 0x11, 0xbb, 0x33, 0xee, 0xce, 0xac, 0x8b, 0xf3,
+	0xda, 0xaa, 0x0f, 0x95, 0xfa, 0x81, 0xfa, 0x49, 0xfa, 0xdc, 0x6f, 0x52, 0xcd, 0xec, 0x1f, 0x6c,
+	0xef, 0x3a, 0x24, 0xb2, 0x9f, 0x3c, 0xe7, 0xdf, 0xef, 0x9c, 0x39, 0x73, 0xce, 0x9c, 0xf1, 0xc2,
+	0x83, 0xee, 0x45, 0x67, 0xeb, 0x9c, 0x0a, 0xcf, 0x67, 0xe1, 0x23, 0x9f, 0x46, 0xc2, 0x3d, 0x67,
+	0xe1, 0x23, 0x57, 0x06, 0x5b, 0x6e, 0xe0, 0x6d, 0xf5, 0xb6, 0xcd, 0xcf, 0x66, 0x37, 0x94, 0x5a,
+	0xa2, 0x7f, 0x5d, 0x44, 0xa7, 0xac, 0xc7, 0x43, 0xbd, 0x69, 0x78, 0xbd, 0x6d, 0x7c, 0x1f, 0xca,
+	0x27, 0xcd, 0xe7, 0xc8, 0x81, 0x1b, 0xbd, 0x80, 0xbf, 0x50, 0x52, 0x38, 0xa5, 0xb5, 0xd2, 0xc6,
+	0x22, 0x49, 0x49, 0xbc, 0x0d, 0xe5, 0x46, 0xeb, 0x18, 0x2d, 0xc1, 0x2c, 0xf7, 0xac, 0xec, 0x26,
+	0x99, 0xe5, 0x1e, 0xaa, 0x43, 0x55, 0xf1, 0x53, 0x9f, 0x8b, 0x8e, 0x72, 0x66, 0xd7, 0xca, 0x1b,
+	0x37, 0x49, 0x46, 0xe3, 0x2d, 0xb8, 0xd1, 0x8e, 0xd7, 0x39, 0xb3, 0x15, 0x98, 0xeb, 0x51, 0x3f,
+	0x62, 0xce, 0xec, 0x5a, 0x69, 0xa3, 0x42, 0x62, 0x02, 0x1f, 0xc0, 0x5c, 0x8b, 0x76, 0x98, 0x32,
+	0x62, 0x57, 0x46, 0x42, 0x5b, 0x8b, 0x0a, 0x89, 0x09, 0x84, 0xa0, 0x12, 0x09, 0xae, 0xad, 0x4d,
+	0x8d, 0xd8, 0xb5, 0xe1, 0x29, 0xfe, 0x9e, 0x39, 0x65, 0x0b, 0x6d, 0xd7, 0x78, 0x17, 0xe6, 0x9b,
+	0x2c, 0x90, 0x61, 0x1f, 0xdd, 0x81, 0x79, 0x1a, 0x0c, 0x00, 0x25, 0x54, 0x11, 0x12, 0xfe, 0xab,
+	0x04, 0x95, 0x06, 0xf3, 0xfd, 0x5c, 0xac, 0x5b, 0x30, 0x1f, 0x58, 0x38, 0xab, 0xbe, 0xb0, 0xf3,
+	0xef, 0xcd, 0x91, 0xe4, 0x6d, 0xc6, 0xde, 0x48, 0xa2, 0x86, 0x1e, 0xc2, 0x5c, 0xd7, 0x6c, 0xc3,
+	0x29, 0xaf, 0x95, 0x37, 0x16, 0x76, 0xee, 0xe4, 0xf4, 0xed, 0x26, 0x49, 0xac, 0x84, 0xbe, 0x84,
+	0x9a, 0xc7, 0x95, 0xa6, 0xc2, 0x65, 0xca, 0xa9, 0x58, 0x0b, 0x27, 0x67, 0x91, 0xe4, 0x91, 0x5c,
+	0xa9, 0xa2, 0x0d, 0xa8, 0xb8, 0xdd, 0x48, 0x39, 0x73, 0xd6, 0x64, 0x25, 0x67, 0xd2, 0x68, 0x1d,
+	0x13, 0xab, 0x81, 0x9f, 0x42, 0xf5, 0x48, 0x76, 0xa5, 0x2f, 0x3b, 0x7d, 0xb4, 0x0b, 0x20, 0xa2,
+	0x80, 0xbe, 0x73, 0x99, 0xef, 0x2b, 0xa7, 0x64, 0x6d, 0x57, 0xf3, 0xb6, 0xcc, 0xf7, 0x49, 0xcd,
+	0x28, 0x9a, 0x95, 0xc2, 0xbf, 0x97, 0x60, 0xbe, 0xdd, 0xdc, 0xe3, 0x52, 0x21, 0x0c, 0x8b, 0x01,
+	0x15, 0xd1, 0x19, 0x75, 0x75, 0x14, 0xb2, 0xd0, 0xe6, 0xa9, 0x46, 0x86, 0x78, 0xa6, 0x8a, 0xba,
+	0xa1, 0xf4, 0x22, 0x37, 0xcd, 0x70, 0x4a, 0x1a, 0x49, 0x8f, 0x85, 0x8a, 0x4b, 0x61, 0x4f, 0xac,
+	0x46, 0x52, 0x12, 0xdd, 0x82, 0xb2, 0xba, 0x88, 0x9c, 0x8a, 0xe5, 0x9a, 0xa5, 0x39, 0xbc, 0x33,
+	0x1a, 0x70, 0xbf, 0xef, 0xcc, 0x59, 0x66, 0x42, 0xe1, 0xdf, 0x4a, 0x50, 0xdd, 0xe7, 0xea, 0xe2,
+	0xb9, 0x38, 0x93, 0x56, 0x49, 0x86, 0x01, 0xd5, 0x49, 0x20, 0x09, 0x85, 0xd6, 0x60, 0xe1, 0x94,
+	0xba, 0x17, 0x5c, 0x74, 0x9e, 0x71, 0x9f, 0x25, 0x61, 0x0c, 0xb2, 0xd0, 0x3d, 0x00, 0x13, 0x2f,
+	0xf5, 0xdb, 0x69, 0xfd, 0x54, 0xc8, 0x00, 0xc7, 0x20, 0x98, 0x94, 0xa4, 0x0a, 0x15, 0xab, 0x30,
+	0xc8, 0xc2, 0x7f, 0x97, 0x61, 0xf5, 0x24, 0xa6, 0x9b, 0xd4, 0x3d, 0xe7, 0x82, 0xbd, 0xee, 0x6a,
+	0x2e, 0x85, 0x42, 0x2f, 0x61, 0x65, 0x58, 0x10, 0x27, 0xcf, 0x29, 0x8d, 0x29, 0xa0, 0x58, 0x4c,
+	0x0a, 0x8d, 0xd0, 0x2e, 0xac, 0x36, 0x59, 0xb0, 0x47, 0x7d, 0x5f, 0x4a, 0xd1, 0xd6, 0x54, 0xab,
+	0x16, 0x0b, 0xb9, 0xf4, 0xec, 0xa6, 0x6e, 0x92, 0x62, 0x21, 0xfa, 0x1c, 0x6e, 0xb7, 0x42, 0x66,
+	0xf8, 0x2e, 0xd5, 0xcc, 0x3b, 0x91, 0x7e, 0x14, 0x24, 0x25, 0x59, 0x23, 0x45, 0x22, 0xf4, 0x18,
+	0xaa, 0x3a, 0x29, 0x13, 0xbb, 0xdb, 0x85, 0x9d, 0xff, 0xe4, 0x02, 0x4d, 0xeb, 0x88, 0x64, 0xaa,
+	0xa8, 0x0d, 0x35, 0x73, 0x1a, 0xca, 0x1c, 0x47, 0x52, 0x8c, 0x8f, 0x73, 0x76, 0x85, 0x69, 0xda,
+	0xcc, 0xec, 0x0e, 0x84, 0x0e, 0xfb, 0xe4, 0x0a, 0x07, 0x6d, 0x02, 0x3a, 0xb8, 0xec, 0x52, 0xe1,
+	0x59, 0xd6, 0x81, 0xa0, 0xa7, 0x3e, 0xf3, 0x9c, 0xf9, 0xb5, 0xd2, 0x46, 0x95, 0x14, 0x48, 0xea,
+	0x6f, 0x60, 0x69, 0x18, 0xcc, 0xd4, 0xd3, 0x05, 0xeb, 0x27, 0x55, 0x61, 0x96, 0x68, 0x6b, 0xf0,
+	0xce, 0x29, 0xda, 0x5c, 0x5a, 0x54, 0xc9, 0x75, 0xf4, 0x64, 0xf6, 0xab, 0x12, 0xee, 0x01, 0x9c,
+	0x34, 0x9f, 0x13, 0xf6, 0x53, 0xc4, 0x94, 0x46, 0xeb, 0x50, 0xee, 0x05, 0x3c, 0x39, 0xc6, 0x7c,
+	0xcb, 0x19, 0x4d, 0xa3, 0x80, 0x9e, 0xc2, 0x0d, 0x19, 0xef, 0x31, 0x71, 0xb6, 0xfe, 0x71, 0x19,
+	0x21, 0xa9, 0x19, 0x3e, 0x82, 0x5b, 0x4d, 0xde, 0x09, 0xa9, 0xa1, 0x3e, 0xd5, 0xbb, 0x33, 0xec,
+	0x7d, 0xf1, 0x0a, 0xf5, 0x97, 0x12, 0x2c, 0x1c, 0x5c, 0x32, 0x37, 0x45, 0xbc, 0x07, 0xe0, 0xc9,
+	0x80, 0x72, 0xf1, 0x8a, 0x06, 0x2c, 0xc9, 0xd5, 0x00, 0xc7, 0x20, 0x35, 0x64, 0x10, 0x50, 0xe1,
+	0xa5, 0x8d, 0x9c, 0x90, 0xe6, 0x06, 0xfd, 0x26, 0xec, 0xa4, 0xf5, 0x64, 0xd7, 0x68, 0x1d, 0x96,
+	0x34, 0x0f, 0x98, 0x8c, 0x74, 0x9b, 0xb9, 0x52, 0x78, 0xca, 0x96, 0xd1, 0x1c, 0x19, 0xe1, 0xe2,
+	0x25, 0x58, 0x3c, 0x08, 0xba, 0xba, 0x9f, 0x44, 0x81, 0xbf, 0x86, 0x2a, 0x61, 0xaa, 0x2b, 0x85,
+	0xb2, 0x1e, 0x55, 0xe4, 0xba, 0x4c, 0xc5, 0xcd, 0x52, 0x25, 0x29, 0x69, 0x24, 0x01, 0x53, 0x8a,
+	0x76, 0xd2, 0x6e, 0x4e, 0x49, 0xfc, 0x0e, 0x96, 0xf6, 0x6d, 0xcc, 0x19, 0xca, 0x63, 0xa8, 0x86,
+	0xc9, 0xda, 0x29, 0x8d, 0x39, 0xed, 0x54, 0x99, 0x64, 0xaa, 0xe6, 0x32, 0x89, 0x37, 0x9f, 0x78,
+	0x48, 0x28, 0x2c, 0xe0, 0x76, 0xec, 0xc0, 0x36, 0xd8, 0xa4, 0x5e, 0xd6, 0x60, 0xc1, 0xbb, 0x42,
+	0x4b, 0xaf, 0xa6, 0x01, 0x16, 0xbe, 0x84, 0xe5, 0x43, 0x93, 0x19, 0x5b, 0x8c, 0x13, 0x7a, 0x7b,
+	0x08, 0xcb, 0x9d, 0x51, 0xac, 0xc4, 0x67, 0x5e, 0x80, 0x7f, 0x2d, 0xc1, 0xaa, 0x75, 0x7d, 0xac,
+	0x58, 0xf8, 0x2d, 0x57, 0x7a, 0x52, 0xf7, 0xbb, 0xb0, 0xda, 0x29, 0xc2, 0x4b, 0x42, 0x28, 0x16,
+	0xe2, 0x3f, 0x4a, 0xe0, 0xd8, 0x30, 0xcc, 0x4d, 0xad, 0xfa, 0x4a, 0xb3, 0x60, 0xe2, 0xb4, 0x3f,
+	0x01, 0xa7, 0x33, 0x06, 0x32, 0x09, 0x66, 0xac, 0x1c, 0xf7, 0x61, 0x31, 0x6e, 0x9b, 0xc9, 0x42,
+	0xa8, 0x43, 0x95, 0x5d, 0x72, 0xdd, 0x90, 0x5e, 0xec, 0x72, 0x8e, 0x64, 0xb4, 0xa9, 0x3d, 0xa5,
+	0xbd, 0xd7, 0x91, 0x4e, 0x06, 0x63, 0x42, 0xe1, 0xb7, 0x70, 0xcb, 0x66, 0xa2, 0x65, 0xc6, 0xff,
+	0x47, 0xb6, 0x6d, 0xbe, 0x11, 0x67, 0x0b, 0x1b, 0xf1, 0x05, 0x2c, 0x0f, 0x60, 0x4f, 0xb4, 0x37,
+	0xfc, 0x06, 0x96, 0xe3, 0x67, 0xd0, 0x7e, 0x14, 0x74, 0x3f, 0xf5, 0xc6, 0xaa, 0x43, 0xd5, 0x8b,
+	0x82, 0x6e, 0x8b, 0xea, 0xf3, 0xe4, 0x2c, 0x32, 0x7a, 0xe7, 0xcf, 0x25, 0x28, 0x37, 0x02, 0x0f,
+	0xbd, 0x02, 0xd4, 0xee, 0x0b, 0x77, 0xf8, 0xde, 0x44, 0xff, 0x2d, 0x04, 0x8d, 0xdd, 0xd7, 0xc7,
+	0x07, 0x8e, 0x67, 0xd0, 0x6b, 0xb8, 0xdd, 0xa2, 0x91, 0x62, 0x53, 0x03, 0xfc, 0x0e, 0x56, 0x8f,
+	0x45, 0x77, 0xaa, 0x90, 0x2d, 0x58, 0x79, 0x16, 0x32, 0xf6, 0x7e, 0x7a, 0x88, 0x04, 0xee, 0x1c,
+	0x8b, 0xb3, 0xa9, 0x63, 0xb6, 0xcf, 0x23, 0xed, 0xc9, 0x9f, 0xc5, 0xd4, 0x30, 0x5f, 0x01, 0x7a,
+	0xc9, 0x7d, 0x7f, 0x9a, 0x99, 0xdc, 0x67, 0x3e, 0xd3, 0xd3, 0xdb, 0xf5, 0x1b, 0x58, 0x8d, 0x27,
+	0xf4, 0x28, 0xe4, 0xff, 0xf3, 0xff, 0x0f, 0x46, 0x26, 0xf9, 0xb5, 0x85, 0x69, 0x0a, 0x3d, 0x33,
+	0x3a, 0xa2, 0x61, 0x87, 0xe9, 0x09, 0x22, 0xfd, 0x1e, 0xee, 0x36, 0xcc, 0x7f, 0x86, 0x91, 0x6c,
+	0x66, 0x0e, 0x26, 0x3c, 0x7a, 0xde, 0x11, 0xd4, 0x8f, 0x83, 0x6c, 0x49, 0xaf, 0xe1, 0x33, 0x2a,
+	0xa2, 0xee, 0x04, 0x98, 0x3f, 0xc0, 0xfd, 0x67, 0x5c, 0x50, 0x9f, 0xbf, 0x67, 0xd3, 0x0f, 0xb8,
+	0x09, 0xb5, 0x43, 0xa6, 0xe3, 0x69, 0x8e, 0xee, 0xe6, 0x34, 0x07, 0xdf, 0x25, 0xf5, 0xfb, 0xf9,
+	0x17, 0xe2, 0xd0, 0x33, 0xc3, 0x16, 0xc1, 0x52, 0x06, 0x67, 0x67, 0xf7, 0x75, 0x98, 0x9f, 0x8d,
+	0xc1, 0x1c, 0x7a, 0x59, 0xe0, 0x19, 0xd4, 0x86, 0xc5, 0x43, 0xa6, 0xb3, 0x57, 0xc0, 0x75, 0xb0,
+	0x38, 0x27, 0xce, 0x3d, 0x20, 0x2c, 0x68, 0xf5, 0x90, 0xd9, 0x69, 0x7b, 0x6d, 0x9c, 0xeb, 0xc5,
+	0x80, 0xb9, 0x49, 0x3d, 0x83, 0x7e, 0xb4, 0x29, 0x18, 0x98, 0x9a, 0xd7, 0x41, 0x3f, 0x28, 0x86,
+	0x2e, 0x9a, 0xbb, 0x33, 0x68, 0x0f, 0x2a, 0x66, 0x3a, 0x5d, 0x87, 0xf9, 0xc1, 0x33, 0x3f, 0x80,
+	0x8a, 0x99, 0xde, 0xe8, 0x7f, 0x79, 0x8c, 0xab, 0xb7, 0x70, 0xfd, 0xee, 0x18, 0x69, 0x06, 0x73,
+	0x04, 0xb5, 0x6c, 0x5a, 0x16, 0x34, 0xf9, 0xe8, 0x94, 0xae, 0xe3, 0x0f, 0xa9, 0x0c, 0x54, 0xbb,
+	0x33, 0x52, 0xe5, 0xd9, 0x14, 0x45, 0x78, 0xcc, 0x97, 0x86, 0x81, 0x11, 0xfb, 0xc1, 0x9d, 0xef,
+	0x55, 0xde, 0xce, 0xf6, 0xb6, 0x4f, 0xe7, 0xed, 0x37, 0x9f, 0x2f, 0xfe, 0x19, 0x00, 0x69, 0xf5,
+	0x47, 0xf3, 0x20, 0x12, 0x00, 0x00,
 }
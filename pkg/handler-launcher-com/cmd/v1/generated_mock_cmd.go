@@ -366,6 +366,22 @@ func (_mr *_MockCmdClientRecorder) GuestPing(arg0, arg1 interface{}, arg2 ...int
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GuestPing", _s...)
 }
 
+func (_m *MockCmdClient) VirtualMachineMemoryDump(ctx context.Context, in *MemoryDumpRequest, opts ...grpc.CallOption) (*Response, error) {
+	_s := []interface{}{ctx, in}
+	for _, _x := range opts {
+		_s = append(_s, _x)
+	}
+	ret := _m.ctrl.Call(_m, "VirtualMachineMemoryDump", _s...)
+	ret0, _ := ret[0].(*Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockCmdClientRecorder) VirtualMachineMemoryDump(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	_s := append([]interface{}{arg0, arg1}, arg2...)
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineMemoryDump", _s...)
+}
+
 // Mock of CmdServer interface
 type MockCmdServer struct {
 	ctrl     *gomock.Controller
@@ -617,3 +633,14 @@ func (_m *MockCmdServer) GuestPing(_param0 context.Context, _param1 *GuestPingRe
 func (_mr *_MockCmdServerRecorder) GuestPing(arg0, arg1 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GuestPing", arg0, arg1)
 }
+
+func (_m *MockCmdServer) VirtualMachineMemoryDump(_param0 context.Context, _param1 *MemoryDumpRequest) (*Response, error) {
+	ret := _m.ctrl.Call(_m, "VirtualMachineMemoryDump", _param0, _param1)
+	ret0, _ := ret[0].(*Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockCmdServerRecorder) VirtualMachineMemoryDump(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineMemoryDump", arg0, arg1)
+}
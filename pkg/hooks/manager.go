@@ -31,12 +31,15 @@ import (
 	"sync"
 	"time"
 
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
 	cloudinit "kubevirt.io/kubevirt/pkg/cloud-init"
 	hooksInfo "kubevirt.io/kubevirt/pkg/hooks/info"
 	hooksV1alpha1 "kubevirt.io/kubevirt/pkg/hooks/v1alpha1"
 	hooksV1alpha2 "kubevirt.io/kubevirt/pkg/hooks/v1alpha2"
+	hooksV1alpha3 "kubevirt.io/kubevirt/pkg/hooks/v1alpha3"
 	grpcutil "kubevirt.io/kubevirt/pkg/util/net/grpc"
 	virtwrapApi "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 )
@@ -47,6 +50,10 @@ type callBackClient struct {
 	subscribedHookPoints []*hooksInfo.HookPoint
 }
 
+// supportedVersions lists the versions of the Callbacks service known to KubeVirt, newest first.
+// The newest version which is exposed by a hook sidecar is used to communicate with it.
+var supportedVersions = []string{hooksV1alpha3.Version, hooksV1alpha2.Version, hooksV1alpha1.Version}
+
 var manager *Manager
 var once sync.Once
 
@@ -147,23 +154,19 @@ func processSideCarSocket(socketPath string) (*callBackClient, bool, error) {
 		versionsSet[version] = true
 	}
 
-	if _, found := versionsSet[hooksV1alpha2.Version]; found {
-		return &callBackClient{
-			SocketPath:           socketPath,
-			Version:              hooksV1alpha2.Version,
-			subscribedHookPoints: info.GetHookPoints(),
-		}, false, nil
-	} else if _, found := versionsSet[hooksV1alpha1.Version]; found {
-		return &callBackClient{
-			SocketPath:           socketPath,
-			Version:              hooksV1alpha1.Version,
-			subscribedHookPoints: info.GetHookPoints(),
-		}, false, nil
-	} else {
-		return nil, false,
-			fmt.Errorf("Hook sidecar does not expose a supported version. Exposed versions: %v, supported versions: %v",
-				info.GetVersions(), []string{hooksV1alpha1.Version, hooksV1alpha2.Version})
+	for _, version := range supportedVersions {
+		if versionsSet[version] {
+			log.Log.Infof("Using version %s of the hook sidecar API on socket %s", version, socketPath)
+			return &callBackClient{
+				SocketPath:           socketPath,
+				Version:              version,
+				subscribedHookPoints: info.GetHookPoints(),
+			}, false, nil
+		}
 	}
+	return nil, false,
+		fmt.Errorf("Hook sidecar does not expose a supported version. Exposed versions: %v, supported versions: %v",
+			info.GetVersions(), supportedVersions)
 }
 
 func sortCallbacksPerHookPoint(callbacksPerHookPoint map[string][]*callBackClient) {
@@ -187,7 +190,7 @@ func (m *Manager) OnDefineDomain(domainSpec *virtwrapApi.DomainSpec, vmi *v1.Vir
 	}
 	if callbacks, found := m.CallbacksPerHookPoint[hooksInfo.OnDefineDomainHookPointName]; found {
 		for _, callback := range callbacks {
-			if callback.Version == hooksV1alpha1.Version || callback.Version == hooksV1alpha2.Version {
+			if callback.Version == hooksV1alpha1.Version || callback.Version == hooksV1alpha2.Version || callback.Version == hooksV1alpha3.Version {
 				vmiJSON, err := json.Marshal(vmi)
 				if err != nil {
 					return "", fmt.Errorf("Failed to marshal VMI spec: %v", vmi)
@@ -224,6 +227,16 @@ func (m *Manager) OnDefineDomain(domainSpec *virtwrapApi.DomainSpec, vmi *v1.Vir
 						return "", err
 					}
 					domainSpecXML = result.GetDomainXML()
+				case hooksV1alpha3.Version:
+					client := hooksV1alpha3.NewCallbacksClient(conn)
+					result, err := client.OnDefineDomain(ctx, &hooksV1alpha3.OnDefineDomainParams{
+						DomainXML: domainSpecXML,
+						Vmi:       vmiJSON,
+					})
+					if err != nil {
+						return "", err
+					}
+					domainSpecXML = result.GetDomainXML()
 				default:
 					panic("Should never happen, version compatibility check is done during Info call")
 				}
@@ -236,7 +249,7 @@ func (m *Manager) OnDefineDomain(domainSpec *virtwrapApi.DomainSpec, vmi *v1.Vir
 func (m *Manager) PreCloudInitIso(vmi *v1.VirtualMachineInstance, cloudInitData *cloudinit.CloudInitData) (*cloudinit.CloudInitData, error) {
 	if callbacks, found := m.CallbacksPerHookPoint[hooksInfo.PreCloudInitIsoHookPointName]; found {
 		for _, callback := range callbacks {
-			if callback.Version == hooksV1alpha2.Version {
+			if callback.Version == hooksV1alpha2.Version || callback.Version == hooksV1alpha3.Version {
 				var resultData *cloudinit.CloudInitData
 				vmiJSON, err := json.Marshal(vmi)
 				if err != nil {
@@ -266,19 +279,36 @@ func (m *Manager) PreCloudInitIso(vmi *v1.VirtualMachineInstance, cloudInitData
 				}
 				defer conn.Close()
 
-				client := hooksV1alpha2.NewCallbacksClient(conn)
 				ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 				defer cancel()
-				result, err := client.PreCloudInitIso(ctx, &hooksV1alpha2.PreCloudInitIsoParams{
-					CloudInitData:          cloudInitDataJSON,
-					CloudInitNoCloudSource: cloudInitNoCloudSourceJSON,
-					Vmi:                    vmiJSON,
-				})
-				if err != nil {
-					return cloudInitData, err
+
+				var resultCloudInitData, resultCloudInitNoCloudSource []byte
+				switch callback.Version {
+				case hooksV1alpha2.Version:
+					client := hooksV1alpha2.NewCallbacksClient(conn)
+					result, err := client.PreCloudInitIso(ctx, &hooksV1alpha2.PreCloudInitIsoParams{
+						CloudInitData:          cloudInitDataJSON,
+						CloudInitNoCloudSource: cloudInitNoCloudSourceJSON,
+						Vmi:                    vmiJSON,
+					})
+					if err != nil {
+						return cloudInitData, err
+					}
+					resultCloudInitData, resultCloudInitNoCloudSource = result.GetCloudInitData(), result.GetCloudInitNoCloudSource()
+				case hooksV1alpha3.Version:
+					client := hooksV1alpha3.NewCallbacksClient(conn)
+					result, err := client.PreCloudInitIso(ctx, &hooksV1alpha3.PreCloudInitIsoParams{
+						CloudInitData:          cloudInitDataJSON,
+						CloudInitNoCloudSource: cloudInitNoCloudSourceJSON,
+						Vmi:                    vmiJSON,
+					})
+					if err != nil {
+						return cloudInitData, err
+					}
+					resultCloudInitData, resultCloudInitNoCloudSource = result.GetCloudInitData(), result.GetCloudInitNoCloudSource()
 				}
 
-				err = json.Unmarshal(result.GetCloudInitData(), &resultData)
+				err = json.Unmarshal(resultCloudInitData, &resultData)
 				if err != nil {
 					log.Log.Reason(err).Infof("Failed to unmarshal CloudInitData result")
 					return cloudInitData, err
@@ -286,7 +316,7 @@ func (m *Manager) PreCloudInitIso(vmi *v1.VirtualMachineInstance, cloudInitData
 				if !cloudinit.IsValidCloudInitData(resultData) {
 					// Be backwards compatible for hook sidecars still working on CloudInitNoCloudSource objects instead of CloudInitData
 					var resultNoCloudSourceData *v1.CloudInitNoCloudSource
-					err = json.Unmarshal(result.GetCloudInitNoCloudSource(), &resultNoCloudSourceData)
+					err = json.Unmarshal(resultCloudInitNoCloudSource, &resultNoCloudSourceData)
 					if err != nil {
 						log.Log.Reason(err).Infof("Failed to unmarshal CloudInitNoCloudSource result")
 						return cloudInitData, err
@@ -305,3 +335,61 @@ func (m *Manager) PreCloudInitIso(vmi *v1.VirtualMachineInstance, cloudInitData
 	}
 	return cloudInitData, nil
 }
+
+// Shutdown notifies all hook sidecars which subscribed to the Shutdown hook point that the VMI is going
+// to be shut down. The sidecars are notified in parallel and share the given timeout to respond, so that
+// slow sidecars do not delay the shutdown beyond the remaining grace period of the VMI.
+func (m *Manager) Shutdown(vmi *v1.VirtualMachineInstance, timeout time.Duration) error {
+	callbacks, found := m.CallbacksPerHookPoint[hooksInfo.ShutdownHookPointName]
+	if !found {
+		return nil
+	}
+
+	vmiJSON, err := json.Marshal(vmi)
+	if err != nil {
+		return fmt.Errorf("Failed to marshal VMI spec: %v", vmi)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errChan := make(chan error, len(callbacks))
+	for _, callback := range callbacks {
+		if callback.Version != hooksV1alpha3.Version {
+			log.Log.Object(vmi).Warningf("Hook sidecar on socket %s subscribed to the %s hook point, which requires version %s, ignoring it",
+				callback.SocketPath, hooksInfo.ShutdownHookPointName, hooksV1alpha3.Version)
+			continue
+		}
+		wg.Add(1)
+		go func(socketPath string) {
+			defer wg.Done()
+			if err := callShutdown(ctx, socketPath, vmiJSON); err != nil {
+				errChan <- fmt.Errorf("Shutdown hook of the sidecar on socket %s failed: %v", socketPath, err)
+			}
+		}(callback.SocketPath)
+	}
+	wg.Wait()
+	close(errChan)
+
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func callShutdown(ctx context.Context, socketPath string, vmiJSON []byte) error {
+	conn, err := grpcutil.DialSocketWithTimeout(socketPath, 1)
+	if err != nil {
+		log.Log.Reason(err).Infof("Failed to Dial hook socket: %s", socketPath)
+		return err
+	}
+	defer conn.Close()
+
+	client := hooksV1alpha3.NewCallbacksClient(conn)
+	_, err = client.Shutdown(ctx, &hooksV1alpha3.ShutdownParams{
+		Vmi: vmiJSON,
+	})
+	return err
+}
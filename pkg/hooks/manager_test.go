@@ -35,22 +35,30 @@ import (
 
 	hooksInfo "kubevirt.io/kubevirt/pkg/hooks/info"
 	hooksV1alpha1 "kubevirt.io/kubevirt/pkg/hooks/v1alpha1"
+	hooksV1alpha2 "kubevirt.io/kubevirt/pkg/hooks/v1alpha2"
+	hooksV1alpha3 "kubevirt.io/kubevirt/pkg/hooks/v1alpha3"
+
+	v1 "kubevirt.io/client-go/api/v1"
 )
 
 type dynamicInfoServer struct {
 	hookName          string
 	hookPointName     string
 	hookPointPriority int32
+	versions          []string
 }
 
 func (s dynamicInfoServer) Info(ctx context.Context, params *hooksInfo.InfoParams) (*hooksInfo.InfoResult, error) {
 	fmt.Fprintf(GinkgoWriter, "Hook's Info method has been called")
 
+	versions := s.versions
+	if versions == nil {
+		versions = []string{hooksV1alpha1.Version}
+	}
+
 	return &hooksInfo.InfoResult{
-		Name: s.hookName,
-		Versions: []string{
-			hooksV1alpha1.Version,
-		},
+		Name:     s.hookName,
+		Versions: versions,
 		HookPoints: []*hooksInfo.HookPoint{
 			{
 				Name:     s.hookPointName,
@@ -60,7 +68,32 @@ func (s dynamicInfoServer) Info(ctx context.Context, params *hooksInfo.InfoParam
 	}, nil
 }
 
+type shutdownCallbacksServer struct {
+	shutdownCalls chan []byte
+}
+
+func (s shutdownCallbacksServer) OnDefineDomain(ctx context.Context, params *hooksV1alpha3.OnDefineDomainParams) (*hooksV1alpha3.OnDefineDomainResult, error) {
+	return &hooksV1alpha3.OnDefineDomainResult{
+		DomainXML: params.GetDomainXML(),
+	}, nil
+}
+
+func (s shutdownCallbacksServer) PreCloudInitIso(ctx context.Context, params *hooksV1alpha3.PreCloudInitIsoParams) (*hooksV1alpha3.PreCloudInitIsoResult, error) {
+	return &hooksV1alpha3.PreCloudInitIsoResult{
+		CloudInitData: params.GetCloudInitData(),
+	}, nil
+}
+
+func (s shutdownCallbacksServer) Shutdown(ctx context.Context, params *hooksV1alpha3.ShutdownParams) (*hooksV1alpha3.ShutdownResult, error) {
+	s.shutdownCalls <- params.GetVmi()
+	return &hooksV1alpha3.ShutdownResult{}, nil
+}
+
 func hookListenAndServe(socketPath string, hookName string, hookPointName string, hookPointPriority int32) (net.Listener, error) {
+	return hookListenAndServeWithVersions(socketPath, hookName, hookPointName, hookPointPriority, nil, nil)
+}
+
+func hookListenAndServeWithVersions(socketPath string, hookName string, hookPointName string, hookPointPriority int32, versions []string, shutdownCalls chan []byte) (net.Listener, error) {
 	socket, err := net.Listen("unix", socketPath)
 	if err != nil {
 		return nil, err
@@ -71,7 +104,11 @@ func hookListenAndServe(socketPath string, hookName string, hookPointName string
 		hookName:          hookName,
 		hookPointName:     hookPointName,
 		hookPointPriority: hookPointPriority,
+		versions:          versions,
 	})
+	if shutdownCalls != nil {
+		hooksV1alpha3.RegisterCallbacksServer(server, shutdownCallbacksServer{shutdownCalls: shutdownCalls})
+	}
 	fmt.Fprintf(GinkgoWriter, "Starting hook server exposing 'info' services on socket %s", socketPath)
 	go func() {
 		server.Serve(socket)
@@ -154,6 +191,95 @@ var _ = Describe("HooksManager", func() {
 			}
 		})
 
+		It("Should use the newest version exposed by the sidecar", func() {
+			hookPointName := hooksInfo.OnDefineDomainHookPointName
+
+			socketPath := filepath.Join(socketDir, "hook1.sock")
+			socket, err := hookListenAndServeWithVersions(socketPath, "hook1", hookPointName, 0,
+				[]string{hooksV1alpha1.Version, hooksV1alpha3.Version, hooksV1alpha2.Version}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			defer socket.Close()
+			defer os.Remove(socketPath)
+
+			manager := newManager(socketDir)
+			err = manager.Collect(1, 10*time.Second)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(manager.CallbacksPerHookPoint[hookPointName]).To(HaveLen(1))
+			Expect(manager.CallbacksPerHookPoint[hookPointName][0].Version).To(Equal(hooksV1alpha3.Version))
+		})
+
+		It("Should fail if the sidecar does not expose a supported version", func() {
+			socketPath := filepath.Join(socketDir, "hook1.sock")
+			socket, err := hookListenAndServeWithVersions(socketPath, "hook1", hooksInfo.OnDefineDomainHookPointName, 0,
+				[]string{"v1alpha0"}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			defer socket.Close()
+			defer os.Remove(socketPath)
+
+			manager := newManager(socketDir)
+			err = manager.Collect(1, 10*time.Second)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("does not expose a supported version"))
+		})
+
+		It("Should call the Shutdown hook of the subscribed sidecars", func() {
+			shutdownCalls := make(chan []byte, 1)
+			socketPath := filepath.Join(socketDir, "hook1.sock")
+			socket, err := hookListenAndServeWithVersions(socketPath, "hook1", hooksInfo.ShutdownHookPointName, 0,
+				[]string{hooksV1alpha3.Version}, shutdownCalls)
+			Expect(err).ToNot(HaveOccurred())
+			defer socket.Close()
+			defer os.Remove(socketPath)
+
+			manager := newManager(socketDir)
+			err = manager.Collect(1, 10*time.Second)
+			Expect(err).ToNot(HaveOccurred())
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			Expect(manager.Shutdown(vmi, 10*time.Second)).To(Succeed())
+			Expect(shutdownCalls).To(Receive(ContainSubstring("testvmi")))
+		})
+
+		It("Should call the Shutdown hooks in parallel within the timeout", func() {
+			// nobody receives the calls until the hooks returned, so that both sidecars hang
+			shutdownCalls := make(chan []byte)
+			for _, name := range []string{"hook1", "hook2"} {
+				socketPath := filepath.Join(socketDir, name+".sock")
+				socket, err := hookListenAndServeWithVersions(socketPath, name, hooksInfo.ShutdownHookPointName, 0,
+					[]string{hooksV1alpha3.Version}, shutdownCalls)
+				Expect(err).ToNot(HaveOccurred())
+				defer socket.Close()
+				defer os.Remove(socketPath)
+			}
+
+			manager := newManager(socketDir)
+			err := manager.Collect(2, 10*time.Second)
+			Expect(err).ToNot(HaveOccurred())
+
+			start := time.Now()
+			err = manager.Shutdown(v1.NewMinimalVMI("testvmi"), 2*time.Second)
+			Expect(err).To(HaveOccurred())
+			Expect(time.Since(start)).To(BeNumerically("<", 3*time.Second))
+			Expect(shutdownCalls).To(Receive())
+			Expect(shutdownCalls).To(Receive())
+		})
+
+		It("Should skip the Shutdown hook of sidecars not supporting it", func() {
+			socketPath := filepath.Join(socketDir, "hook1.sock")
+			socket, err := hookListenAndServeWithVersions(socketPath, "hook1", hooksInfo.ShutdownHookPointName, 0,
+				[]string{hooksV1alpha2.Version}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			defer socket.Close()
+			defer os.Remove(socketPath)
+
+			manager := newManager(socketDir)
+			err = manager.Collect(1, 10*time.Second)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(manager.Shutdown(v1.NewMinimalVMI("testvmi"), 10*time.Second)).To(Succeed())
+		})
+
 		AfterEach(func() {
 			os.RemoveAll(socketDir)
 		})
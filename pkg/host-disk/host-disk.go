@@ -30,6 +30,7 @@ import (
 	ephemeraldiskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
 
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/client-go/tools/record"
 
 	v1 "kubevirt.io/client-go/api/v1"
@@ -197,7 +198,7 @@ func (hdc *DiskImgCreator) mountHostDiskAndSetOwnership(vmi *v1.VirtualMachineIn
 		return err
 	}
 	if !fileExists {
-		if err := hdc.handleRequestedSizeAndCreateSparseRaw(vmi, diskDir, diskPath, hostDisk); err != nil {
+		if err := hdc.handleRequestedSizeAndCreateSparseRaw(vmi, volumeName, diskDir, diskPath, hostDisk); err != nil {
 			return err
 		}
 	}
@@ -209,7 +210,7 @@ func (hdc *DiskImgCreator) mountHostDiskAndSetOwnership(vmi *v1.VirtualMachineIn
 	return nil
 }
 
-func (hdc *DiskImgCreator) handleRequestedSizeAndCreateSparseRaw(vmi *v1.VirtualMachineInstance, diskDir string, diskPath string, hostDisk *v1.HostDisk) error {
+func (hdc *DiskImgCreator) handleRequestedSizeAndCreateSparseRaw(vmi *v1.VirtualMachineInstance, volumeName string, diskDir string, diskPath string, hostDisk *v1.HostDisk) error {
 	size, err := hdc.dirBytesAvailableFunc(diskDir, hdc.minimumPVCReserveBytes)
 	availableSize := int64(size)
 	if err != nil {
@@ -217,7 +218,7 @@ func (hdc *DiskImgCreator) handleRequestedSizeAndCreateSparseRaw(vmi *v1.Virtual
 	}
 	requestedSize, _ := hostDisk.Capacity.AsInt64()
 	if requestedSize > availableSize {
-		requestedSize, err = hdc.shrinkRequestedSize(vmi, requestedSize, availableSize, hostDisk)
+		requestedSize, err = hdc.shrinkRequestedSize(vmi, volumeName, requestedSize, availableSize, hostDisk)
 		if err != nil {
 			return err
 		}
@@ -230,19 +231,37 @@ func (hdc *DiskImgCreator) handleRequestedSizeAndCreateSparseRaw(vmi *v1.Virtual
 	return nil
 }
 
-func (hdc *DiskImgCreator) shrinkRequestedSize(vmi *v1.VirtualMachineInstance, requestedSize int64, availableSize int64, hostDisk *v1.HostDisk) (int64, error) {
+func (hdc *DiskImgCreator) shrinkRequestedSize(vmi *v1.VirtualMachineInstance, volumeName string, requestedSize int64, availableSize int64, hostDisk *v1.HostDisk) (int64, error) {
 	// Some storage provisioners provide less space than requested, due to filesystem overhead etc.
 	// We tolerate some difference in requested and available capacity up to some degree.
 	// This can be configured with the "pvc-tolerate-less-space-up-to-percent" parameter in the kubevirt-config ConfigMap.
 	// It is provided as argument to virt-launcher.
 	toleratedSize := requestedSize * (100 - int64(hdc.lessPVCSpaceToleration)) / 100
+	toleration := fmt.Sprintf("%v %% toleration", hdc.lessPVCSpaceToleration)
+	// A larger filesystem overhead configured for the storage class of the PVC widens the toleration.
+	if capacity := getDiskCapacity(vmi, volumeName); capacity != nil && capacity.Value() < toleratedSize {
+		toleratedSize = capacity.Value()
+		toleration = "the filesystem overhead"
+	}
 	if toleratedSize > availableSize {
-		return 0, fmt.Errorf("unable to create %s, not enough space, demanded size %d B is bigger than available space %d B, also after taking %v %% toleration into account",
-			hostDisk.Path, uint64(requestedSize), availableSize, hdc.lessPVCSpaceToleration)
+		return 0, fmt.Errorf("unable to create %s, not enough space, demanded size %d B is bigger than available space %d B, also after taking %s into account",
+			hostDisk.Path, uint64(requestedSize), availableSize, toleration)
 	}
 
-	msg := fmt.Sprintf("PV size too small: expected %v B, found %v B. Using it anyway, it is within %v %% toleration", requestedSize, availableSize, hdc.lessPVCSpaceToleration)
+	msg := fmt.Sprintf("PV size too small: expected %v B, found %v B. Using it anyway, it is within %s", requestedSize, availableSize, toleration)
 	log.Log.Info(msg)
 	hdc.recorder.Event(vmi, EventTypeToleratedSmallPV, EventReasonToleratedSmallPV, msg)
 	return availableSize, nil
 }
+
+// getDiskCapacity returns the capacity of the PVC of the given volume without its filesystem overhead,
+// or nil if no overhead is known for it.
+func getDiskCapacity(vmi *v1.VirtualMachineInstance, volumeName string) *resource.Quantity {
+	for _, volumeStatus := range vmi.Status.VolumeStatus {
+		if volumeStatus.Name == volumeName && volumeStatus.PersistentVolumeClaimInfo != nil &&
+			volumeStatus.PersistentVolumeClaimInfo.FilesystemOverhead != "" {
+			return types.GetDiskCapacity(volumeStatus.PersistentVolumeClaimInfo)
+		}
+	}
+	return nil
+}
@@ -316,6 +316,54 @@ var _ = Describe("HostDisk", func() {
 					close(done)
 				}, 5)
 
+				It("Should take the filesystem overhead of the PVC into account when creating disk images", func(done Done) {
+					By("Creating a new minimal vmi")
+					vmi := v1.NewMinimalVMI("fake-vmi")
+					size64Mi := uint64(67108864) // 64 Mi
+
+					hostDiskCreator.dirBytesAvailableFunc = func(path string, reserve uint64) (uint64, error) {
+						if strings.Contains(path, "volume1") {
+							// within the overhead of 10%
+							return size64Mi * 91 / 100, nil
+						} else if strings.Contains(path, "volume2") {
+							// beyond the overhead of 10%
+							return size64Mi * 89 / 100, nil
+						} else {
+							return 0, fmt.Errorf("fix your test please")
+						}
+					}
+
+					By("Adding HostDisk volumes of PVCs with a filesystem overhead")
+					addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "64Mi")
+					addHostDisk(vmi, "volume2", v1.HostDiskExistsOrCreate, "64Mi")
+					for _, name := range []string{"volume1", "volume2"} {
+						vmi.Status.VolumeStatus = append(vmi.Status.VolumeStatus, v1.VolumeStatus{
+							Name: name,
+							PersistentVolumeClaimInfo: &v1.PersistentVolumeClaimInfo{
+								Capacity: k8sv1.ResourceList{
+									k8sv1.ResourceStorage: resource.MustParse("64Mi"),
+								},
+								FilesystemOverhead: "0.1",
+							},
+						})
+					}
+
+					By("Executing CreateHostDisks func which should only create the first disk.img")
+					err := hostDiskCreator.Create(vmi)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("the filesystem overhead"))
+
+					img1, err := os.Stat(vmi.Spec.Volumes[0].HostDisk.Path)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(uint64(img1.Size())).To(Equal(size64Mi * 91 / 100))
+
+					_, err = os.Stat(vmi.Spec.Volumes[1].HostDisk.Path)
+					Expect(true).To(Equal(os.IsNotExist(err)))
+
+					testutils.ExpectEvent(recorder, "PV size too small")
+					close(done)
+				}, 5)
+
 			})
 		})
 		Context("With existing disk.img", func() {
@@ -44,6 +44,7 @@ var (
 type HotplugDiskManagerInterface interface {
 	GetHotplugTargetPodPathOnHost(virtlauncherPodUID types.UID) (string, error)
 	GetFileSystemDiskTargetPathFromHostView(virtlauncherPodUID types.UID, volumeName string, create bool) (string, error)
+	GetFileSystemDirectoryTargetPathFromHostView(virtlauncherPodUID types.UID, volumeName string, create bool) (string, error)
 }
 
 func NewHotplugDiskManager() *hotplugDiskManager {
@@ -94,6 +95,23 @@ func (h *hotplugDiskManager) GetFileSystemDiskTargetPathFromHostView(virtlaunche
 	return diskFile, err
 }
 
+// GetFileSystemDirectoryTargetPathFromHostView gets the directory of a volume, which is mounted as a whole instead of
+// as a disk image, in the target pod (virt-launcher) on the host.
+func (h *hotplugDiskManager) GetFileSystemDirectoryTargetPathFromHostView(virtlauncherPodUID types.UID, volumeName string, create bool) (string, error) {
+	targetPath, err := h.GetHotplugTargetPodPathOnHost(virtlauncherPodUID)
+	if err != nil {
+		return targetPath, err
+	}
+	directory := filepath.Join(targetPath, volumeName)
+	exists, _ := diskutils.FileExists(directory)
+	if !exists && create {
+		if err := os.Mkdir(directory, 0750); err != nil {
+			return directory, err
+		}
+	}
+	return directory, err
+}
+
 // CreateLocalDirectory creates the base directory where disk images will be mounted when hotplugged. File system volumes will be in
 // a directory under this, that contains the volume name. block volumes will be in this directory as a block device.
 func CreateLocalDirectory(dir string) error {
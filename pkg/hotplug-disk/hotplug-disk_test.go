@@ -98,4 +98,22 @@ var _ = Describe("HotplugDisk", func() {
 		_, err := hotplug.GetFileSystemDiskTargetPathFromHostView(testUID, "testvolume", false)
 		Expect(err).To(HaveOccurred())
 	})
+
+	It("GetFileSystemDirectoryTargetPathFromHostView should create the volume directory", func() {
+		testUID := types.UID("abcd")
+		_ = os.MkdirAll(TargetPodBasePath(podsBaseDir, testUID), 0755)
+		res, err := hotplug.GetFileSystemDirectoryTargetPathFromHostView(testUID, "testvolume", true)
+		Expect(err).ToNot(HaveOccurred())
+		testPath := filepath.Join(TargetPodBasePath(podsBaseDir, testUID), "testvolume")
+		info, err := os.Stat(testPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.IsDir()).To(BeTrue())
+		Expect(res).To(Equal(testPath))
+	})
+
+	It("GetFileSystemDirectoryTargetPathFromHostView should fail on invalid UID", func() {
+		testUID := types.UID("abcde")
+		_, err := hotplug.GetFileSystemDirectoryTargetPathFromHostView(testUID, "testvolume", false)
+		Expect(err).To(HaveOccurred())
+	})
 })
@@ -187,11 +187,14 @@ func (r *rtWrapper) RoundTrip(request *http.Request) (response *http.Response, e
 	return response, err
 }
 
-func addHTTPRoundTripClientMonitoring(config *rest.Config) {
-	fn := func(rt http.RoundTripper) http.RoundTripper {
-		return &rtWrapper{
-			origRoundTripper: rt,
-		}
+// InstrumentRoundTripper counts the requests of rt in rest_client_requests_total. All KubeVirt
+// clients are instrumented once this package is imported, it allows to instrument other clients.
+func InstrumentRoundTripper(rt http.RoundTripper) http.RoundTripper {
+	return &rtWrapper{
+		origRoundTripper: rt,
 	}
-	config.Wrap(fn)
+}
+
+func addHTTPRoundTripClientMonitoring(config *rest.Config) {
+	config.Wrap(InstrumentRoundTripper)
 }
@@ -21,9 +21,13 @@ package prometheus
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strings"
 
 	"github.com/onsi/ginkgo/extensions/table"
+	"github.com/prometheus/client_golang/prometheus"
+	io_prometheus_client "github.com/prometheus/client_model/go"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -69,3 +73,28 @@ var _ = Describe("URL Parsing", func() {
 	})
 
 })
+
+var _ = Describe("Instrumented round tripper", func() {
+	It("should count the requests by status code, resource and verb", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+		host := strings.TrimPrefix(server.URL, "http://")
+		counter := requestResult.WithLabelValues("404", "GET", host, "virtualmachineinstances", "GET")
+		before := counterValue(counter)
+
+		client := &http.Client{Transport: InstrumentRoundTripper(http.DefaultTransport)}
+		response, err := client.Get(server.URL + "/apis/kubevirt.io/v1/namespaces/default/virtualmachineinstances/testvmi")
+		Expect(err).ToNot(HaveOccurred())
+		response.Body.Close()
+
+		Expect(counterValue(counter)).To(Equal(before + 1))
+	})
+})
+
+func counterValue(counter prometheus.Counter) float64 {
+	dto := &io_prometheus_client.Metric{}
+	Expect(counter.Write(dto)).To(Succeed())
+	return dto.GetCounter().GetValue()
+}
@@ -25,6 +25,7 @@ import (
 	"strings"
 	"time"
 
+	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/cache"
 
 	vms "kubevirt.io/kubevirt/pkg/monitoring/domainstats"
@@ -159,6 +160,67 @@ func (metrics *vmiMetrics) updateMemory(mem *stats.DomainStatsMemory) {
 	}
 }
 
+// updateMemoryRequest reports the memory requested for the domain next to the memory of the guest,
+// which together with the resident memory shows how much of an overcommitted guest memory is used.
+func (metrics *vmiMetrics) updateMemoryRequest() {
+	request, hasRequest := metrics.vmi.Spec.Domain.Resources.Requests[k8sv1.ResourceMemory]
+	if hasRequest {
+		metrics.pushCommonMetric(
+			"kubevirt_vmi_memory_requested_bytes",
+			"The amount of memory in bytes requested for the domain.",
+			prometheus.GaugeValue,
+			float64(request.Value()),
+		)
+	}
+
+	if memory := metrics.vmi.Spec.Domain.Memory; memory != nil && memory.Guest != nil {
+		metrics.pushCommonMetric(
+			"kubevirt_vmi_memory_domain_bytes",
+			"The amount of memory in bytes visible to the guest.",
+			prometheus.GaugeValue,
+			float64(memory.Guest.Value()),
+		)
+	} else if hasRequest {
+		metrics.pushCommonMetric(
+			"kubevirt_vmi_memory_domain_bytes",
+			"The amount of memory in bytes visible to the guest.",
+			prometheus.GaugeValue,
+			float64(request.Value()),
+		)
+	}
+}
+
+// updateCPU reports the cpu time consumed by the domain, which allows to scale groups of
+// VirtualMachineInstances on their cpu usage.
+func (metrics *vmiMetrics) updateCPU(cpu *stats.DomainStatsCPU) {
+	if cpu.TimeSet {
+		metrics.pushCommonMetric(
+			"kubevirt_vmi_cpu_usage_seconds_total",
+			"Total CPU time spent in all modes by the domain in seconds.",
+			prometheus.CounterValue,
+			float64(cpu.Time)/float64(1000000000),
+		)
+	}
+
+	if cpu.UserSet {
+		metrics.pushCommonMetric(
+			"kubevirt_vmi_cpu_user_usage_seconds_total",
+			"Total CPU time spent in user mode by the domain in seconds.",
+			prometheus.CounterValue,
+			float64(cpu.User)/float64(1000000000),
+		)
+	}
+
+	if cpu.SystemSet {
+		metrics.pushCommonMetric(
+			"kubevirt_vmi_cpu_system_usage_seconds_total",
+			"Total CPU time spent in system mode by the domain in seconds.",
+			prometheus.CounterValue,
+			float64(cpu.System)/float64(1000000000),
+		)
+	}
+}
+
 func (metrics *vmiMetrics) updateCPUAffinity(cpuMap [][]bool) {
 	affinityLabels := []string{}
 	affinityValues := []string{}
@@ -308,6 +370,50 @@ func (metrics *vmiMetrics) updateBlock(blkStats []stats.DomainStatsBlock) {
 				blkLabelValues,
 			)
 		}
+
+		if block.ErrorsSet {
+			metrics.pushCustomMetric(
+				"kubevirt_vmi_storage_errors_total",
+				"Storage I/O errors.",
+				prometheus.CounterValue,
+				float64(block.Errors),
+				blkLabels,
+				blkLabelValues,
+			)
+		}
+
+		if block.CapacitySet {
+			metrics.pushCustomMetric(
+				"kubevirt_vmi_storage_capacity_bytes",
+				"Logical size of the disk as seen by the guest in bytes.",
+				prometheus.GaugeValue,
+				float64(block.Capacity),
+				blkLabels,
+				blkLabelValues,
+			)
+		}
+
+		if block.AllocationSet {
+			metrics.pushCustomMetric(
+				"kubevirt_vmi_storage_allocation_bytes",
+				"Highest offset written to the disk in bytes, for sparse and thin provisioned disks this is the allocated space.",
+				prometheus.GaugeValue,
+				float64(block.Allocation),
+				blkLabels,
+				blkLabelValues,
+			)
+		}
+
+		if block.PhysicalSet {
+			metrics.pushCustomMetric(
+				"kubevirt_vmi_storage_physical_bytes",
+				"Size of the file or block device which backs the disk in bytes.",
+				prometheus.GaugeValue,
+				float64(block.Physical),
+				blkLabels,
+				blkLabelValues,
+			)
+		}
 	}
 }
 
@@ -567,7 +673,11 @@ type vmiMetrics struct {
 func (metrics *vmiMetrics) updateMetrics(vmStats *stats.DomainStats) {
 	metrics.updateKubernetesLabels()
 
+	if vmStats.Cpu != nil {
+		metrics.updateCPU(vmStats.Cpu)
+	}
 	metrics.updateMemory(vmStats.Memory)
+	metrics.updateMemoryRequest()
 	metrics.updateVcpu(vmStats.Vcpu)
 	metrics.updateBlock(vmStats.Block)
 	metrics.updateNetwork(vmStats.Net)
@@ -24,6 +24,8 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	io_prometheus_client "github.com/prometheus/client_model/go"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"libvirt.org/go/libvirt"
@@ -310,6 +312,92 @@ var _ = Describe("Prometheus", func() {
 			Expect(dto.Gauge.GetValue()).To(BeEquivalentTo(float64(1024)))
 		})
 
+		It("should handle the requested and the guest memory metrics", func() {
+			ch := make(chan prometheus.Metric, 2)
+			defer close(ch)
+
+			ps := prometheusScraper{ch: ch}
+
+			vmStats := &stats.DomainStats{
+				Cpu:    &stats.DomainStatsCPU{},
+				Memory: &stats.DomainStatsMemory{},
+			}
+			guestMemory := resource.MustParse("2Gi")
+			vmi := k6tv1.VirtualMachineInstance{
+				Spec: k6tv1.VirtualMachineInstanceSpec{
+					Domain: k6tv1.DomainSpec{
+						Resources: k6tv1.ResourceRequirements{
+							Requests: k8sv1.ResourceList{k8sv1.ResourceMemory: resource.MustParse("1Gi")},
+						},
+						Memory: &k6tv1.Memory{Guest: &guestMemory},
+					},
+				},
+			}
+			ps.Report("test", &vmi, vmStats)
+
+			result := <-ch
+			dto := &io_prometheus_client.Metric{}
+			result.Write(dto)
+			Expect(result.Desc().String()).To(ContainSubstring("kubevirt_vmi_memory_requested_bytes"))
+			Expect(dto.Gauge.GetValue()).To(BeEquivalentTo(float64(1024 * 1024 * 1024)))
+
+			result = <-ch
+			dto = &io_prometheus_client.Metric{}
+			result.Write(dto)
+			Expect(result.Desc().String()).To(ContainSubstring("kubevirt_vmi_memory_domain_bytes"))
+			Expect(dto.Gauge.GetValue()).To(BeEquivalentTo(float64(2 * 1024 * 1024 * 1024)))
+		})
+
+		It("should handle cpu usage metrics", func() {
+			ch := make(chan prometheus.Metric, 1)
+			defer close(ch)
+
+			ps := prometheusScraper{ch: ch}
+
+			vmStats := &stats.DomainStats{
+				Cpu: &stats.DomainStatsCPU{
+					TimeSet: true,
+					Time:    2500000000,
+				},
+				Memory: &stats.DomainStatsMemory{},
+			}
+
+			vmi := k6tv1.VirtualMachineInstance{}
+			ps.Report("test", &vmi, vmStats)
+
+			result := <-ch
+			Expect(result).ToNot(BeNil())
+			Expect(result.Desc().String()).To(ContainSubstring("kubevirt_vmi_cpu_usage_seconds_total"))
+			dto := &io_prometheus_client.Metric{}
+			result.Write(dto)
+			Expect(dto.Counter.GetValue()).To(BeEquivalentTo(2.5))
+		})
+
+		It("should handle cpu user and system usage metrics", func() {
+			ch := make(chan prometheus.Metric, 2)
+			defer close(ch)
+
+			ps := prometheusScraper{ch: ch}
+
+			vmStats := &stats.DomainStats{
+				Cpu: &stats.DomainStatsCPU{
+					UserSet:   true,
+					User:      1000000000,
+					SystemSet: true,
+					System:    3000000000,
+				},
+				Memory: &stats.DomainStatsMemory{},
+			}
+
+			vmi := k6tv1.VirtualMachineInstance{}
+			ps.Report("test", &vmi, vmStats)
+
+			result := <-ch
+			Expect(result.Desc().String()).To(ContainSubstring("kubevirt_vmi_cpu_user_usage_seconds_total"))
+			result = <-ch
+			Expect(result.Desc().String()).To(ContainSubstring("kubevirt_vmi_cpu_system_usage_seconds_total"))
+		})
+
 		It("should handle vcpu metrics", func() {
 			ch := make(chan prometheus.Metric, 1)
 			defer close(ch)
@@ -664,6 +752,119 @@ var _ = Describe("Prometheus", func() {
 			Expect(result.Desc().String()).To(ContainSubstring("kubevirt_vmi_storage_flush_times_ms_total"))
 		})
 
+		It("should handle Errors metrics", func() {
+			ch := make(chan prometheus.Metric, 1)
+			defer close(ch)
+
+			ps := prometheusScraper{ch: ch}
+
+			vmStats := &stats.DomainStats{
+				Cpu:    &stats.DomainStatsCPU{},
+				Memory: &stats.DomainStatsMemory{},
+				Block: []stats.DomainStatsBlock{
+					{
+						NameSet:   true,
+						Name:      "vda",
+						ErrorsSet: true,
+						Errors:    3,
+					},
+				},
+			}
+
+			vmi := k6tv1.VirtualMachineInstance{}
+			ps.Report("test", &vmi, vmStats)
+
+			result := <-ch
+			Expect(result).ToNot(BeNil())
+			Expect(result.Desc().String()).To(ContainSubstring("kubevirt_vmi_storage_errors_total"))
+		})
+
+		It("should handle Capacity metrics", func() {
+			ch := make(chan prometheus.Metric, 1)
+			defer close(ch)
+
+			ps := prometheusScraper{ch: ch}
+
+			vmStats := &stats.DomainStats{
+				Cpu:    &stats.DomainStatsCPU{},
+				Memory: &stats.DomainStatsMemory{},
+				Block: []stats.DomainStatsBlock{
+					{
+						NameSet:     true,
+						Name:        "vda",
+						CapacitySet: true,
+						Capacity:    10737418240,
+					},
+				},
+			}
+
+			vmi := k6tv1.VirtualMachineInstance{}
+			ps.Report("test", &vmi, vmStats)
+
+			result := <-ch
+			Expect(result).ToNot(BeNil())
+			Expect(result.Desc().String()).To(ContainSubstring("kubevirt_vmi_storage_capacity_bytes"))
+
+			dto := &io_prometheus_client.Metric{}
+			err := result.Write(dto)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(dto.GetGauge().GetValue()).To(BeEquivalentTo(10737418240))
+		})
+
+		It("should handle Allocation metrics", func() {
+			ch := make(chan prometheus.Metric, 1)
+			defer close(ch)
+
+			ps := prometheusScraper{ch: ch}
+
+			vmStats := &stats.DomainStats{
+				Cpu:    &stats.DomainStatsCPU{},
+				Memory: &stats.DomainStatsMemory{},
+				Block: []stats.DomainStatsBlock{
+					{
+						NameSet:       true,
+						Name:          "vda",
+						AllocationSet: true,
+						Allocation:    1073741824,
+					},
+				},
+			}
+
+			vmi := k6tv1.VirtualMachineInstance{}
+			ps.Report("test", &vmi, vmStats)
+
+			result := <-ch
+			Expect(result).ToNot(BeNil())
+			Expect(result.Desc().String()).To(ContainSubstring("kubevirt_vmi_storage_allocation_bytes"))
+		})
+
+		It("should handle Physical metrics", func() {
+			ch := make(chan prometheus.Metric, 1)
+			defer close(ch)
+
+			ps := prometheusScraper{ch: ch}
+
+			vmStats := &stats.DomainStats{
+				Cpu:    &stats.DomainStatsCPU{},
+				Memory: &stats.DomainStatsMemory{},
+				Block: []stats.DomainStatsBlock{
+					{
+						NameSet:     true,
+						Name:        "vda",
+						PhysicalSet: true,
+						Physical:    2147483648,
+					},
+				},
+			}
+
+			vmi := k6tv1.VirtualMachineInstance{}
+			ps.Report("test", &vmi, vmStats)
+
+			result := <-ch
+			Expect(result).ToNot(BeNil())
+			Expect(result.Desc().String()).To(ContainSubstring("kubevirt_vmi_storage_physical_bytes"))
+		})
+
 		It("should use alias when alias is not empty", func() {
 			ch := make(chan prometheus.Metric, 1)
 			defer close(ch)
@@ -0,0 +1,58 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package prometheus
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+var (
+	clientConnections = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kubevirt_grpc_client_connections",
+			Help: "Amount of open gRPC client connections on internal APIs, broken down by server and connection state",
+		},
+		[]string{"server", "state"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(clientConnections)
+}
+
+// MonitorConnectionState counts the connection in the gauge of its current state until it is closed
+func MonitorConnectionState(conn *grpc.ClientConn, server string) {
+	state := conn.GetState()
+	clientConnections.WithLabelValues(server, state.String()).Inc()
+	go func() {
+		for state != connectivity.Shutdown {
+			conn.WaitForStateChange(context.Background(), state)
+			clientConnections.WithLabelValues(server, state.String()).Dec()
+			state = conn.GetState()
+			if state != connectivity.Shutdown {
+				clientConnections.WithLabelValues(server, state.String()).Inc()
+			}
+		}
+	}()
+}
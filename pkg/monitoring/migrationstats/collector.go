@@ -0,0 +1,161 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package migrationstats
+
+import (
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/tools/cache"
+
+	k6tv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+)
+
+var (
+	migrationPhaseCountDesc = prometheus.NewDesc(
+		"kubevirt_migrate_vmi_phase_count",
+		"Number of VirtualMachineInstance migrations which are not finished yet, per phase.",
+		[]string{
+			"phase",
+		},
+		nil,
+	)
+
+	migrationAgeDesc = prometheus.NewDesc(
+		"kubevirt_migrate_vmi_age_seconds",
+		"Time since an unfinished VirtualMachineInstance migration was created in seconds.",
+		[]string{
+			"namespace", "name", "vmi", "phase",
+		},
+		nil,
+	)
+
+	migrationDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "kubevirt_migrate_vmi_duration_seconds",
+			Help:    "Time from the creation of a VirtualMachineInstance migration until it finished in seconds.",
+			Buckets: prometheus.ExponentialBuckets(10, 2, 10),
+		},
+		[]string{
+			"result",
+		},
+	)
+
+	migrationFailed = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kubevirt_migrate_vmi_failed_total",
+			Help: "Number of failed VirtualMachineInstance migrations, per reason.",
+		},
+		[]string{
+			"reason",
+		},
+	)
+
+	migrationSucceeded = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "kubevirt_migrate_vmi_succeeded_total",
+			Help: "Number of succeeded VirtualMachineInstance migrations.",
+		},
+	)
+
+	migrationDataTransferred = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "kubevirt_migrate_vmi_data_transferred_bytes_total",
+			Help: "Amount of data transferred by succeeded VirtualMachineInstance migrations in bytes.",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(migrationDuration, migrationFailed, migrationSucceeded, migrationDataTransferred)
+}
+
+// MigrationCollector reports the migrations which are not finished yet
+type MigrationCollector struct {
+	migrationInformer cache.SharedIndexInformer
+	now               func() time.Time
+}
+
+func SetupMigrationCollector(migrationInformer cache.SharedIndexInformer) {
+	log.Log.Infof("Starting migration collector")
+	prometheus.MustRegister(&MigrationCollector{
+		migrationInformer: migrationInformer,
+		now:               time.Now,
+	})
+}
+
+func (co *MigrationCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- migrationPhaseCountDesc
+	ch <- migrationAgeDesc
+}
+
+// Note that Collect could be called concurrently
+func (co *MigrationCollector) Collect(ch chan<- prometheus.Metric) {
+	now := co.now()
+	phaseCount := make(map[string]float64)
+	for _, obj := range co.migrationInformer.GetIndexer().List() {
+		migration := obj.(*k6tv1.VirtualMachineInstanceMigration)
+		if migration.IsFinal() {
+			continue
+		}
+		phase := phaseLabel(migration.Status.Phase)
+		phaseCount[phase]++
+
+		age := now.Sub(migration.CreationTimestamp.Time).Seconds()
+		if age < 0 {
+			age = 0
+		}
+		ch <- prometheus.MustNewConstMetric(migrationAgeDesc, prometheus.GaugeValue, age,
+			migration.Namespace, migration.Name, migration.Spec.VMIName, phase)
+	}
+	for phase, count := range phaseCount {
+		ch <- prometheus.MustNewConstMetric(migrationPhaseCountDesc, prometheus.GaugeValue, count, phase)
+	}
+}
+
+// ObserveFinishedMigration records the result of a migration, it has to be called once when the migration
+// reaches a final phase. The reason is only used for failed migrations.
+func ObserveFinishedMigration(migration *k6tv1.VirtualMachineInstanceMigration, vmi *k6tv1.VirtualMachineInstance, reason string, now time.Time) {
+	duration := now.Sub(migration.CreationTimestamp.Time).Seconds()
+	if duration < 0 {
+		duration = 0
+	}
+	migrationDuration.WithLabelValues(phaseLabel(migration.Status.Phase)).Observe(duration)
+
+	switch migration.Status.Phase {
+	case k6tv1.MigrationFailed:
+		migrationFailed.WithLabelValues(reason).Inc()
+	case k6tv1.MigrationSucceeded:
+		migrationSucceeded.Inc()
+		if vmi != nil && vmi.Status.MigrationState != nil && vmi.Status.MigrationState.MigrationUID == migration.UID &&
+			vmi.Status.MigrationState.Progress != nil {
+			migrationDataTransferred.Add(float64(vmi.Status.MigrationState.Progress.TransferredBytes))
+		}
+	}
+}
+
+func phaseLabel(phase k6tv1.VirtualMachineInstanceMigrationPhase) string {
+	if phase == k6tv1.MigrationPhaseUnset {
+		return "new"
+	}
+	return strings.ToLower(string(phase))
+}
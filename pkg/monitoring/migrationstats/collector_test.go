@@ -0,0 +1,137 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package migrationstats
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	k6tv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Migration Collector", func() {
+	var informer cache.SharedIndexInformer
+	var co *MigrationCollector
+	var now time.Time
+
+	newMigration := func(name string, phase k6tv1.VirtualMachineInstanceMigrationPhase, age time.Duration) *k6tv1.VirtualMachineInstanceMigration {
+		return &k6tv1.VirtualMachineInstanceMigration{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "default",
+				Name:              name,
+				UID:               types.UID("migration-" + name),
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			},
+			Spec:   k6tv1.VirtualMachineInstanceMigrationSpec{VMIName: "testvmi-" + name},
+			Status: k6tv1.VirtualMachineInstanceMigrationStatus{Phase: phase},
+		}
+	}
+
+	BeforeEach(func() {
+		now = time.Now()
+		informer, _ = testutils.NewFakeInformerFor(&k6tv1.VirtualMachineInstanceMigration{})
+		co = &MigrationCollector{
+			migrationInformer: informer,
+			now:               func() time.Time { return now },
+		}
+	})
+
+	It("should report unfinished migrations", func() {
+		Expect(informer.GetIndexer().Add(newMigration("pending1", k6tv1.MigrationPending, time.Minute))).To(Succeed())
+		Expect(informer.GetIndexer().Add(newMigration("pending2", k6tv1.MigrationPending, time.Minute))).To(Succeed())
+		Expect(informer.GetIndexer().Add(newMigration("running", k6tv1.MigrationRunning, time.Hour))).To(Succeed())
+		Expect(informer.GetIndexer().Add(newMigration("done", k6tv1.MigrationSucceeded, time.Hour))).To(Succeed())
+
+		ch := make(chan prometheus.Metric, 10)
+		co.Collect(ch)
+		close(ch)
+
+		phaseCounts := map[string]float64{}
+		ages := map[string]float64{}
+		for metric := range ch {
+			dto := &io_prometheus_client.Metric{}
+			Expect(metric.Write(dto)).To(Succeed())
+			labels := map[string]string{}
+			for _, label := range dto.Label {
+				labels[label.GetName()] = label.GetValue()
+			}
+			switch metric.Desc() {
+			case migrationPhaseCountDesc:
+				phaseCounts[labels["phase"]] = dto.Gauge.GetValue()
+			case migrationAgeDesc:
+				Expect(labels["vmi"]).To(Equal("testvmi-" + labels["name"]))
+				ages[labels["name"]] = dto.Gauge.GetValue()
+			}
+		}
+		Expect(phaseCounts).To(Equal(map[string]float64{"pending": 2, "running": 1}))
+		Expect(ages).To(Equal(map[string]float64{"pending1": 60, "pending2": 60, "running": 3600}))
+	})
+
+	Context("finished migrations", func() {
+
+		counterValue := func(counter prometheus.Metric) float64 {
+			dto := &io_prometheus_client.Metric{}
+			Expect(counter.Write(dto)).To(Succeed())
+			return dto.Counter.GetValue()
+		}
+
+		histogramCount := func(result string) uint64 {
+			dto := &io_prometheus_client.Metric{}
+			Expect(migrationDuration.WithLabelValues(result).(prometheus.Histogram).Write(dto)).To(Succeed())
+			return dto.Histogram.GetSampleCount()
+		}
+
+		It("should count failed migrations by reason", func() {
+			failed := counterValue(migrationFailed.WithLabelValues("TargetPodShutdown"))
+			failedDurations := histogramCount("failed")
+
+			migration := newMigration("failed", k6tv1.MigrationFailed, time.Minute)
+			ObserveFinishedMigration(migration, nil, "TargetPodShutdown", now)
+
+			Expect(counterValue(migrationFailed.WithLabelValues("TargetPodShutdown"))).To(Equal(failed + 1))
+			Expect(histogramCount("failed")).To(Equal(failedDurations + 1))
+		})
+
+		It("should count succeeded migrations and the transferred data", func() {
+			succeeded := counterValue(migrationSucceeded)
+			transferred := counterValue(migrationDataTransferred)
+
+			migration := newMigration("succeeded", k6tv1.MigrationSucceeded, time.Minute)
+			vmi := k6tv1.NewMinimalVMI("testvmi-succeeded")
+			vmi.Status.MigrationState = &k6tv1.VirtualMachineInstanceMigrationState{
+				MigrationUID: migration.UID,
+				Completed:    true,
+				Progress:     &k6tv1.VirtualMachineInstanceMigrationProgress{TransferredBytes: 1024},
+			}
+			ObserveFinishedMigration(migration, vmi, "", now)
+
+			Expect(counterValue(migrationSucceeded)).To(Equal(succeeded + 1))
+			Expect(counterValue(migrationDataTransferred)).To(Equal(transferred + 1024))
+		})
+	})
+})
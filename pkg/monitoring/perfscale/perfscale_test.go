@@ -23,6 +23,8 @@ import (
 	"time"
 
 	"github.com/onsi/ginkgo/extensions/table"
+	"github.com/prometheus/client_golang/prometheus"
+	io_prometheus_client "github.com/prometheus/client_model/go"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	. "github.com/onsi/ginkgo"
@@ -89,6 +91,90 @@ var _ = Describe("VMI phase transition time histogram", func() {
 	})
 })
 
+var _ = Describe("VMI startup histograms", func() {
+	var fromCreation *prometheus.HistogramVec
+	var duration *prometheus.HistogramVec
+
+	BeforeEach(func() {
+		fromCreation = prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "from_creation", Buckets: phaseTransitionTimeBuckets()}, []string{"phase"})
+		duration = prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "duration", Buckets: phaseTransitionTimeBuckets()}, []string{"phase"})
+	})
+
+	observed := func(histogramVec *prometheus.HistogramVec, phase string) (uint64, float64) {
+		metric := &io_prometheus_client.Metric{}
+		Expect(histogramVec.WithLabelValues(phase).(prometheus.Metric).Write(metric)).To(Succeed())
+		return metric.Histogram.GetSampleCount(), metric.Histogram.GetSampleSum()
+	}
+
+	It("should observe the steps which were reached since the last update", func() {
+		creation := metav1.NewTime(time.Now().Add(-time.Minute))
+		at := func(seconds int) *metav1.Time {
+			t := metav1.NewTime(creation.Add(time.Duration(seconds) * time.Second))
+			return &t
+		}
+
+		oldVMI := &v1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: creation},
+			Status: v1.VirtualMachineInstanceStatus{
+				StartupTimestamps: &v1.VirtualMachineInstanceStartupTimestamps{
+					PodScheduled:     at(1),
+					ContainerStarted: at(3),
+				},
+			},
+		}
+		newVMI := oldVMI.DeepCopy()
+		newVMI.Status.StartupTimestamps.DomainDefined = at(4)
+		newVMI.Status.StartupTimestamps.DomainRunning = at(6)
+
+		updateVMIStartupHistogramVecs(fromCreation, duration, oldVMI, newVMI)
+
+		count, sum := observed(fromCreation, "DomainDefined")
+		Expect(count).To(Equal(uint64(1)))
+		Expect(sum).To(Equal(4.0))
+		count, sum = observed(fromCreation, "DomainRunning")
+		Expect(count).To(Equal(uint64(1)))
+		Expect(sum).To(Equal(6.0))
+		count, sum = observed(duration, "DomainDefined")
+		Expect(count).To(Equal(uint64(1)))
+		Expect(sum).To(Equal(1.0))
+		count, sum = observed(duration, "DomainRunning")
+		Expect(count).To(Equal(uint64(1)))
+		Expect(sum).To(Equal(2.0))
+
+		count, _ = observed(fromCreation, "PodScheduled")
+		Expect(count).To(BeZero())
+		count, _ = observed(fromCreation, "ContainerStarted")
+		Expect(count).To(BeZero())
+	})
+
+	It("should not observe a duration for the first step", func() {
+		newVMI := &v1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Now()},
+			Status: v1.VirtualMachineInstanceStatus{
+				StartupTimestamps: &v1.VirtualMachineInstanceStartupTimestamps{
+					PodScheduled: &metav1.Time{Time: time.Now().Add(2 * time.Second)},
+				},
+			},
+		}
+
+		updateVMIStartupHistogramVecs(fromCreation, duration, &v1.VirtualMachineInstance{}, newVMI)
+
+		count, _ := observed(fromCreation, "PodScheduled")
+		Expect(count).To(Equal(uint64(1)))
+		count, _ = observed(duration, "PodScheduled")
+		Expect(count).To(BeZero())
+	})
+
+	It("should ignore VMIs without startup timestamps", func() {
+		updateVMIStartupHistogramVecs(fromCreation, duration, nil, &v1.VirtualMachineInstance{})
+
+		for _, step := range startupSteps {
+			count, _ := observed(fromCreation, step.name)
+			Expect(count).To(BeZero())
+		}
+	})
+})
+
 func createVMISForPhaseTransitionTime(phase v1.VirtualMachineInstancePhase, oldPhase v1.VirtualMachineInstancePhase, offset float64, hasTransitionTime bool) *v1.VirtualMachineInstance {
 	now := metav1.NewTime(time.Now())
 	old := metav1.NewTime(now.Time.Add(-time.Duration(int64(offset)) * time.Millisecond))
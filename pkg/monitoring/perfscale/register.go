@@ -31,4 +31,6 @@ func RegisterPerfScaleMetrics(vmiInformer cache.SharedIndexInformer) {
 	prometheus.MustRegister(newVMIPhaseTransitionTimeHistogramVec(vmiInformer))
 	prometheus.MustRegister(newVMIPhaseTransitionTimeFromCreationHistogramVec(vmiInformer))
 	prometheus.MustRegister(newVMIPhaseTransitionTimeFromDeletionHistogramVec(vmiInformer))
+	startupFromCreation, startupDuration := newVMIStartupHistogramVecs(vmiInformer)
+	prometheus.MustRegister(startupFromCreation, startupDuration)
 }
@@ -0,0 +1,125 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package perfscale
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+)
+
+type startupStep struct {
+	name      string
+	timestamp func(timestamps *v1.VirtualMachineInstanceStartupTimestamps) *metav1.Time
+}
+
+// startupSteps are the steps of the start of a VMI, in the order in which they are passed
+var startupSteps = []startupStep{
+	{"PodScheduled", func(t *v1.VirtualMachineInstanceStartupTimestamps) *metav1.Time { return t.PodScheduled }},
+	{"ContainerStarted", func(t *v1.VirtualMachineInstanceStartupTimestamps) *metav1.Time { return t.ContainerStarted }},
+	{"DomainDefined", func(t *v1.VirtualMachineInstanceStartupTimestamps) *metav1.Time { return t.DomainDefined }},
+	{"DomainRunning", func(t *v1.VirtualMachineInstanceStartupTimestamps) *metav1.Time { return t.DomainRunning }},
+	{"GuestAgentConnected", func(t *v1.VirtualMachineInstanceStartupTimestamps) *metav1.Time { return t.GuestAgentConnected }},
+}
+
+func nonNegativeSeconds(from, to *metav1.Time) float64 {
+	diffSeconds := to.Time.Sub(from.Time).Seconds()
+
+	// the timestamps are taken by different components, make 0 the floor on clock skew
+	if diffSeconds < 0 {
+		diffSeconds = 0.0
+	}
+	return diffSeconds
+}
+
+// updateVMIStartupHistogramVecs observes the steps which were reached between the old and the new VMI.
+// A step is observed since the creation of the VMI and since the previous step which was reached.
+func updateVMIStartupHistogramVecs(fromCreation *prometheus.HistogramVec, duration *prometheus.HistogramVec, oldVMI *v1.VirtualMachineInstance, newVMI *v1.VirtualMachineInstance) {
+	if newVMI.Status.StartupTimestamps == nil {
+		return
+	}
+	oldTimestamps := &v1.VirtualMachineInstanceStartupTimestamps{}
+	if oldVMI != nil && oldVMI.Status.StartupTimestamps != nil {
+		oldTimestamps = oldVMI.Status.StartupTimestamps
+	}
+
+	var previous *metav1.Time
+	for _, step := range startupSteps {
+		current := step.timestamp(newVMI.Status.StartupTimestamps)
+		if current == nil {
+			continue
+		}
+
+		if step.timestamp(oldTimestamps) == nil {
+			histogram, err := fromCreation.GetMetricWithLabelValues(step.name)
+			if err != nil {
+				log.Log.Reason(err).Error("Failed to get a histogram for vmi startup times")
+				return
+			}
+			histogram.Observe(nonNegativeSeconds(&newVMI.CreationTimestamp, current))
+
+			if previous != nil {
+				histogram, err := duration.GetMetricWithLabelValues(step.name)
+				if err != nil {
+					log.Log.Reason(err).Error("Failed to get a histogram for vmi startup step durations")
+					return
+				}
+				histogram.Observe(nonNegativeSeconds(previous, current))
+			}
+		}
+		previous = current
+	}
+}
+
+func newVMIStartupHistogramVecs(informer cache.SharedIndexInformer) (*prometheus.HistogramVec, *prometheus.HistogramVec) {
+	fromCreation := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "kubevirt_vmi_startup_phase_time_from_creation_seconds",
+			Help:    "Time from the creation of a VMI until it passed a step of its start.",
+			Buckets: phaseTransitionTimeBuckets(),
+		},
+		[]string{
+			// step of the start
+			"phase",
+		},
+	)
+	duration := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "kubevirt_vmi_startup_phase_duration_seconds",
+			Help:    "Time from the previous step of the start of a VMI until it passed a step.",
+			Buckets: phaseTransitionTimeBuckets(),
+		},
+		[]string{
+			// step of the start
+			"phase",
+		},
+	)
+
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldVMI, newVMI interface{}) {
+			updateVMIStartupHistogramVecs(fromCreation, duration, oldVMI.(*v1.VirtualMachineInstance), newVMI.(*v1.VirtualMachineInstance))
+		},
+	})
+	return fromCreation, duration
+}
@@ -20,6 +20,7 @@
 package vmistats
 
 import (
+	"strconv"
 	"strings"
 
 	k8sv1 "k8s.io/api/core/v1"
@@ -47,6 +48,16 @@ var (
 		nil,
 	)
 
+	vmiInfoDesc = prometheus.NewDesc(
+		"kubevirt_vmi_info",
+		"Information about a VirtualMachineInstance, the value is always 1.",
+		[]string{
+			"node", "namespace", "name", "phase", "os", "workload", "flavor",
+			"guest_os_id", "guest_os_version_id", "eviction_strategy", "outdated",
+		},
+		nil,
+	)
+
 	vmiEvictionBlockerDesc = prometheus.NewDesc(
 		"kubevirt_vmi_non_evictable",
 		"Indication for a VirtualMachine that its eviction strategy is set to Live Migration but is not migratable.",
@@ -55,6 +66,38 @@ var (
 		},
 		nil,
 	)
+
+	migrationLabels = []string{
+		"node", "namespace", "name",
+	}
+
+	migrationDataProcessedDesc = prometheus.NewDesc(
+		"kubevirt_migrate_vmi_data_processed_bytes",
+		"The amount of data transferred to the target of a running VirtualMachineInstance migration in bytes.",
+		migrationLabels,
+		nil,
+	)
+
+	migrationMemoryRemainingDesc = prometheus.NewDesc(
+		"kubevirt_migrate_vmi_memory_remaining_bytes",
+		"The amount of guest memory which still has to be transferred by a running VirtualMachineInstance migration in bytes.",
+		migrationLabels,
+		nil,
+	)
+
+	migrationDirtyPageRateDesc = prometheus.NewDesc(
+		"kubevirt_migrate_vmi_dirty_page_rate",
+		"The rate at which the guest of a migrating VirtualMachineInstance dirties its memory in pages per second.",
+		migrationLabels,
+		nil,
+	)
+
+	migrationIterationDesc = prometheus.NewDesc(
+		"kubevirt_migrate_vmi_memory_iterations",
+		"The number of passes over the guest memory of a running VirtualMachineInstance migration.",
+		migrationLabels,
+		nil,
+	)
 )
 
 type vmiCountMetric struct {
@@ -172,7 +215,34 @@ func checkNonEvictableVMAndSetMetric(vmi *k6tv1.VirtualMachineInstance) float64
 
 func updateVMIMetrics(vmis []*k6tv1.VirtualMachineInstance, ch chan<- prometheus.Metric) {
 	for _, vmi := range vmis {
+		updateVMIInfo(vmi, ch)
 		updateVMIEvictionBlocker(vmi, ch)
+		updateVMIMigrationProgress(vmi, ch)
+	}
+}
+
+func updateVMIMigrationProgress(vmi *k6tv1.VirtualMachineInstance, ch chan<- prometheus.Metric) {
+	migrationState := vmi.Status.MigrationState
+	if migrationState == nil || migrationState.Completed || migrationState.Progress == nil {
+		return
+	}
+
+	progress := migrationState.Progress
+	for desc, value := range map[*prometheus.Desc]int64{
+		migrationDataProcessedDesc:   progress.TransferredBytes,
+		migrationMemoryRemainingDesc: progress.MemoryRemainingBytes,
+		migrationDirtyPageRateDesc:   progress.DirtyPageRate,
+		migrationIterationDesc:       progress.Iteration,
+	} {
+		mv, err := prometheus.NewConstMetric(
+			desc, prometheus.GaugeValue,
+			float64(value),
+			migrationState.SourceNode, vmi.Namespace, vmi.Name,
+		)
+		if err != nil {
+			continue
+		}
+		ch <- mv
 	}
 }
 
@@ -188,3 +258,32 @@ func updateVMIEvictionBlocker(vmi *k6tv1.VirtualMachineInstance, ch chan<- prome
 	ch <- mv
 
 }
+
+func updateVMIInfo(vmi *k6tv1.VirtualMachineInstance, ch chan<- prometheus.Metric) {
+	vmc := newVMICountMetric(vmi)
+
+	guestOSID := vmi.Status.GuestOSInfo.ID
+	if guestOSID == "" {
+		guestOSID = "<none>"
+	}
+	guestOSVersionID := vmi.Status.GuestOSInfo.VersionID
+	if guestOSVersionID == "" {
+		guestOSVersionID = "<none>"
+	}
+	evictionStrategy := "<none>"
+	if vmi.Spec.EvictionStrategy != nil {
+		evictionStrategy = string(*vmi.Spec.EvictionStrategy)
+	}
+	_, outdated := vmi.Labels[k6tv1.OutdatedLauncherImageLabel]
+
+	mv, err := prometheus.NewConstMetric(
+		vmiInfoDesc, prometheus.GaugeValue,
+		1.0,
+		vmc.NodeName, vmi.Namespace, vmi.Name, vmc.Phase, vmc.OS, vmc.Workload, vmc.Flavor,
+		guestOSID, guestOSVersionID, evictionStrategy, strconv.FormatBool(outdated),
+	)
+	if err != nil {
+		return
+	}
+	ch <- mv
+}
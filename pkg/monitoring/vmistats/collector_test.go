@@ -66,6 +66,139 @@ var _ = Describe("VMI Stats Collector", func() {
 			table.Entry("VMI Eviction policy is not set and vm migratable status is not known", nil, k8sv1.ConditionUnknown, 0.0),
 		)
 	})
+
+	Context("VMI migration progress", func() {
+
+		newMigratingVMI := func(completed bool) *k6tv1.VirtualMachineInstance {
+			return &k6tv1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test-ns",
+					Name:      "testvmi",
+				},
+				Status: k6tv1.VirtualMachineInstanceStatus{
+					NodeName: "testNode",
+					MigrationState: &k6tv1.VirtualMachineInstanceMigrationState{
+						SourceNode: "testNode",
+						Completed:  completed,
+						Progress: &k6tv1.VirtualMachineInstanceMigrationProgress{
+							TransferredBytes:     1024,
+							MemoryRemainingBytes: 2048,
+							DirtyPageRate:        300,
+							Iteration:            4,
+						},
+					},
+				},
+			}
+		}
+
+		It("should report the progress of a running migration", func() {
+			ch := make(chan prometheus.Metric, 4)
+			updateVMIMigrationProgress(newMigratingVMI(false), ch)
+			close(ch)
+
+			values := map[string]float64{}
+			for result := range ch {
+				dto := &io_prometheus_client.Metric{}
+				result.Write(dto)
+				values[result.Desc().String()] = dto.Gauge.GetValue()
+			}
+			Expect(values).To(HaveLen(4))
+			Expect(values).To(HaveKeyWithValue(migrationDataProcessedDesc.String(), 1024.0))
+			Expect(values).To(HaveKeyWithValue(migrationMemoryRemainingDesc.String(), 2048.0))
+			Expect(values).To(HaveKeyWithValue(migrationDirtyPageRateDesc.String(), 300.0))
+			Expect(values).To(HaveKeyWithValue(migrationIterationDesc.String(), 4.0))
+		})
+
+		It("should not report the progress of a completed migration", func() {
+			ch := make(chan prometheus.Metric, 4)
+			updateVMIMigrationProgress(newMigratingVMI(true), ch)
+			close(ch)
+			Expect(ch).To(BeEmpty())
+		})
+	})
+
+	Context("VMI info", func() {
+
+		infoLabels := func(vmi *k6tv1.VirtualMachineInstance) map[string]string {
+			ch := make(chan prometheus.Metric, 1)
+			updateVMIInfo(vmi, ch)
+			close(ch)
+
+			result := <-ch
+			Expect(result).ToNot(BeNil())
+			Expect(result.Desc().String()).To(ContainSubstring("kubevirt_vmi_info"))
+			dto := &io_prometheus_client.Metric{}
+			Expect(result.Write(dto)).To(Succeed())
+			Expect(dto.Gauge.GetValue()).To(BeEquivalentTo(1))
+
+			labels := map[string]string{}
+			for _, label := range dto.Label {
+				labels[label.GetName()] = label.GetValue()
+			}
+			return labels
+		}
+
+		It("should report the state of a VMI", func() {
+			liveMigrate := k6tv1.EvictionStrategyLiveMigrate
+			vmi := &k6tv1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test-ns",
+					Name:      "testvmi",
+					Annotations: map[string]string{
+						annotationPrefix + "os":       "fedora33",
+						annotationPrefix + "workload": "server",
+						annotationPrefix + "flavor":   "small",
+					},
+					Labels: map[string]string{
+						k6tv1.OutdatedLauncherImageLabel: "",
+					},
+				},
+				Spec: k6tv1.VirtualMachineInstanceSpec{
+					EvictionStrategy: &liveMigrate,
+				},
+				Status: k6tv1.VirtualMachineInstanceStatus{
+					Phase:    k6tv1.Running,
+					NodeName: "testNode",
+					GuestOSInfo: k6tv1.VirtualMachineInstanceGuestOSInfo{
+						ID:        "fedora",
+						VersionID: "33",
+					},
+				},
+			}
+
+			Expect(infoLabels(vmi)).To(Equal(map[string]string{
+				"node":                "testNode",
+				"namespace":           "test-ns",
+				"name":                "testvmi",
+				"phase":               "running",
+				"os":                  "fedora33",
+				"workload":            "server",
+				"flavor":              "small",
+				"guest_os_id":         "fedora",
+				"guest_os_version_id": "33",
+				"eviction_strategy":   "LiveMigrate",
+				"outdated":            "true",
+			}))
+		})
+
+		It("should report missing information as none", func() {
+			vmi := &k6tv1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test-ns",
+					Name:      "testvmi",
+				},
+				Status: k6tv1.VirtualMachineInstanceStatus{
+					Phase: k6tv1.Scheduling,
+				},
+			}
+
+			labels := infoLabels(vmi)
+			Expect(labels).To(HaveKeyWithValue("phase", "scheduling"))
+			Expect(labels).To(HaveKeyWithValue("guest_os_id", "<none>"))
+			Expect(labels).To(HaveKeyWithValue("eviction_strategy", "<none>"))
+			Expect(labels).To(HaveKeyWithValue("outdated", "false"))
+		})
+	})
 })
 
 func createVMISForEviction(evictionStrategy *k6tv1.EvictionStrategy, migratableCondStatus k8sv1.ConditionStatus) []*k6tv1.VirtualMachineInstance {
@@ -0,0 +1,101 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package vmstats
+
+import (
+	"time"
+
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	k6tv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+)
+
+var (
+	vmTimeToReadyDesc = prometheus.NewDesc(
+		"kubevirt_vm_time_to_ready_seconds",
+		"Time from the start request of the VirtualMachine to the guest becoming ready, for its latest start.",
+		[]string{
+			"namespace", "name",
+		},
+		nil,
+	)
+
+	vmUnplannedDowntimeDesc = prometheus.NewDesc(
+		"kubevirt_vm_unplanned_downtime_seconds_total",
+		"Cumulative time the VirtualMachine was down after its VirtualMachineInstance failed, until it was ready again or stopped.",
+		[]string{
+			"namespace", "name",
+		},
+		nil,
+	)
+)
+
+// VMSLOCollector reports the availability of VirtualMachines, which the VM controller
+// records in their status
+type VMSLOCollector struct {
+	vmInformer cache.SharedIndexInformer
+	now        func() time.Time
+}
+
+func SetupVMSLOCollector(vmInformer cache.SharedIndexInformer) {
+	log.Log.Infof("Starting vm slo collector")
+	prometheus.MustRegister(&VMSLOCollector{
+		vmInformer: vmInformer,
+		now:        time.Now,
+	})
+}
+
+func (co *VMSLOCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- vmTimeToReadyDesc
+	ch <- vmUnplannedDowntimeDesc
+}
+
+// Note that Collect could be called concurrently
+func (co *VMSLOCollector) Collect(ch chan<- prometheus.Metric) {
+	now := co.now()
+	for _, obj := range co.vmInformer.GetIndexer().List() {
+		vm := obj.(*k6tv1.VirtualMachine)
+		availability := vm.Status.Availability
+		if availability == nil {
+			continue
+		}
+		if availability.StartRequestTime != nil && availability.ReadyTime != nil {
+			timeToReady := secondsBetween(availability.StartRequestTime.Time, availability.ReadyTime.Time)
+			ch <- prometheus.MustNewConstMetric(vmTimeToReadyDesc, prometheus.GaugeValue, timeToReady, vm.Namespace, vm.Name)
+		}
+		downtime := float64(availability.UnplannedDowntimeSeconds)
+		if availability.FailureTime != nil {
+			downtime += secondsBetween(availability.FailureTime.Time, now)
+		}
+		ch <- prometheus.MustNewConstMetric(vmUnplannedDowntimeDesc, prometheus.CounterValue, downtime, vm.Namespace, vm.Name)
+	}
+}
+
+// secondsBetween returns the seconds from start to end, with 0 as floor to compensate time skew
+func secondsBetween(start time.Time, end time.Time) float64 {
+	diffSeconds := end.Sub(start).Seconds()
+	if diffSeconds < 0 {
+		return 0.0
+	}
+	return diffSeconds
+}
@@ -0,0 +1,124 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package vmstats
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	k6tv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("VM SLO Collector", func() {
+	var informer cache.SharedIndexInformer
+	var co *VMSLOCollector
+	var now time.Time
+	var start time.Time
+
+	timePtr := func(t time.Time) *metav1.Time {
+		metaTime := metav1.NewTime(t)
+		return &metaTime
+	}
+
+	addVM := func(availability *k6tv1.VirtualMachineAvailabilityStatus) {
+		vm := newVM()
+		vm.Status.Availability = availability
+		Expect(informer.GetIndexer().Add(vm)).To(Succeed())
+	}
+
+	collect := func() map[string]*io_prometheus_client.Metric {
+		ch := make(chan prometheus.Metric, 10)
+		co.Collect(ch)
+		close(ch)
+		metrics := make(map[string]*io_prometheus_client.Metric)
+		for metric := range ch {
+			dto := &io_prometheus_client.Metric{}
+			Expect(metric.Write(dto)).To(Succeed())
+			switch metric.Desc() {
+			case vmTimeToReadyDesc:
+				metrics["kubevirt_vm_time_to_ready_seconds"] = dto
+			case vmUnplannedDowntimeDesc:
+				metrics["kubevirt_vm_unplanned_downtime_seconds_total"] = dto
+			}
+		}
+		return metrics
+	}
+
+	BeforeEach(func() {
+		start = time.Now().Add(-time.Hour)
+		now = start
+		informer, _ = testutils.NewFakeInformerFor(&k6tv1.VirtualMachine{})
+		co = &VMSLOCollector{
+			vmInformer: informer,
+			now:        func() time.Time { return now },
+		}
+	})
+
+	It("should ignore VMs without an availability status", func() {
+		addVM(nil)
+		Expect(collect()).To(BeEmpty())
+	})
+
+	It("should not report the time to ready while the start is pending", func() {
+		addVM(&k6tv1.VirtualMachineAvailabilityStatus{StartRequestTime: timePtr(start)})
+		metrics := collect()
+		Expect(metrics).ToNot(HaveKey("kubevirt_vm_time_to_ready_seconds"))
+		Expect(metrics["kubevirt_vm_unplanned_downtime_seconds_total"].Counter.GetValue()).To(BeZero())
+	})
+
+	It("should report the time from the start request until the guest is ready", func() {
+		addVM(&k6tv1.VirtualMachineAvailabilityStatus{
+			StartRequestTime: timePtr(start),
+			ReadyTime:        timePtr(start.Add(42 * time.Second)),
+		})
+		metrics := collect()
+		Expect(metrics["kubevirt_vm_time_to_ready_seconds"].Gauge.GetValue()).To(BeEquivalentTo(42))
+		Expect(metrics["kubevirt_vm_time_to_ready_seconds"].Label).To(ContainElements(
+			&io_prometheus_client.LabelPair{Name: stringPtr("name"), Value: stringPtr("testvm")},
+			&io_prometheus_client.LabelPair{Name: stringPtr("namespace"), Value: stringPtr("default")},
+		))
+	})
+
+	It("should add an ongoing outage to the recorded downtime", func() {
+		addVM(&k6tv1.VirtualMachineAvailabilityStatus{
+			FailureTime:              timePtr(start),
+			UnplannedDowntimeSeconds: 80,
+		})
+		now = start.Add(time.Minute)
+		Expect(collect()["kubevirt_vm_unplanned_downtime_seconds_total"].Counter.GetValue()).To(BeEquivalentTo(140))
+	})
+})
+
+func newVM() *k6tv1.VirtualMachine {
+	return &k6tv1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "testvm", UID: "vm-uid"},
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
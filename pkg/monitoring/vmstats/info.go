@@ -0,0 +1,106 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package vmstats
+
+import (
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	k6tv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	annotationPrefix = "vm.kubevirt.io/"
+	noneLabelValue   = "<none>"
+)
+
+var vmInfoDesc = prometheus.NewDesc(
+	"kubevirt_vm_info",
+	"Information about a VirtualMachine, the value is always 1.",
+	[]string{
+		"namespace", "name", "status", "run_strategy", "os", "workload", "flavor", "eviction_strategy",
+	},
+	nil,
+)
+
+// VMInfoCollector reports the state of all VirtualMachines known to the informer
+type VMInfoCollector struct {
+	vmInformer cache.SharedIndexInformer
+}
+
+func SetupVMInfoCollector(vmInformer cache.SharedIndexInformer) {
+	log.Log.Infof("Starting vm info collector")
+	prometheus.MustRegister(&VMInfoCollector{vmInformer: vmInformer})
+}
+
+func (co *VMInfoCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- vmInfoDesc
+}
+
+// Note that Collect could be called concurrently
+func (co *VMInfoCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, obj := range co.vmInformer.GetIndexer().List() {
+		updateVMInfo(obj.(*k6tv1.VirtualMachine), ch)
+	}
+}
+
+func updateVMInfo(vm *k6tv1.VirtualMachine, ch chan<- prometheus.Metric) {
+	status := string(vm.Status.PrintableStatus)
+	if status == "" {
+		status = noneLabelValue
+	}
+	runStrategy := noneLabelValue
+	if strategy, err := vm.RunStrategy(); err == nil {
+		runStrategy = string(strategy)
+	}
+
+	// the common templates annotate the VMI template with the os, workload and flavor
+	var annotations map[string]string
+	evictionStrategy := noneLabelValue
+	if vm.Spec.Template != nil {
+		annotations = vm.Spec.Template.ObjectMeta.Annotations
+		if vm.Spec.Template.Spec.EvictionStrategy != nil {
+			evictionStrategy = string(*vm.Spec.Template.Spec.EvictionStrategy)
+		}
+	}
+
+	mv, err := prometheus.NewConstMetric(
+		vmInfoDesc, prometheus.GaugeValue,
+		1.0,
+		vm.Namespace, vm.Name, status, runStrategy,
+		annotationOrNone(annotations, "os"),
+		annotationOrNone(annotations, "workload"),
+		annotationOrNone(annotations, "flavor"),
+		evictionStrategy,
+	)
+	if err != nil {
+		return
+	}
+	ch <- mv
+}
+
+func annotationOrNone(annotations map[string]string, name string) string {
+	if val, ok := annotations[annotationPrefix+name]; ok {
+		return val
+	}
+	return noneLabelValue
+}
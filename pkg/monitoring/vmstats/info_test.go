@@ -0,0 +1,103 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package vmstats
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	k6tv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("VM Info Collector", func() {
+	var informer cache.SharedIndexInformer
+	var co *VMInfoCollector
+
+	collect := func() []map[string]string {
+		ch := make(chan prometheus.Metric, 10)
+		co.Collect(ch)
+		close(ch)
+		var metrics []map[string]string
+		for metric := range ch {
+			Expect(metric.Desc()).To(Equal(vmInfoDesc))
+			dto := &io_prometheus_client.Metric{}
+			Expect(metric.Write(dto)).To(Succeed())
+			Expect(dto.Gauge.GetValue()).To(BeEquivalentTo(1))
+			labels := map[string]string{}
+			for _, label := range dto.Label {
+				labels[label.GetName()] = label.GetValue()
+			}
+			metrics = append(metrics, labels)
+		}
+		return metrics
+	}
+
+	BeforeEach(func() {
+		informer, _ = testutils.NewFakeInformerFor(&k6tv1.VirtualMachine{})
+		co = &VMInfoCollector{vmInformer: informer}
+	})
+
+	It("should report the state of each VM", func() {
+		liveMigrate := k6tv1.EvictionStrategyLiveMigrate
+		running := true
+		vm := newVM()
+		vm.Spec.Running = &running
+		vm.Spec.Template = &k6tv1.VirtualMachineInstanceTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					annotationPrefix + "os":       "fedora33",
+					annotationPrefix + "workload": "server",
+					annotationPrefix + "flavor":   "small",
+				},
+			},
+			Spec: k6tv1.VirtualMachineInstanceSpec{
+				EvictionStrategy: &liveMigrate,
+			},
+		}
+		vm.Status.PrintableStatus = k6tv1.VirtualMachineStatusRunning
+		Expect(informer.GetIndexer().Add(vm)).To(Succeed())
+
+		Expect(collect()).To(ConsistOf(map[string]string{
+			"namespace":         "default",
+			"name":              "testvm",
+			"status":            "Running",
+			"run_strategy":      "Always",
+			"os":                "fedora33",
+			"workload":          "server",
+			"flavor":            "small",
+			"eviction_strategy": "LiveMigrate",
+		}))
+	})
+
+	It("should report missing information as none", func() {
+		Expect(informer.GetIndexer().Add(newVM())).To(Succeed())
+
+		metrics := collect()
+		Expect(metrics).To(HaveLen(1))
+		Expect(metrics[0]).To(HaveKeyWithValue("status", "<none>"))
+		Expect(metrics[0]).To(HaveKeyWithValue("os", "<none>"))
+		Expect(metrics[0]).To(HaveKeyWithValue("eviction_strategy", "<none>"))
+	})
+})
@@ -0,0 +1,88 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package prometheus_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+	"k8s.io/client-go/util/workqueue"
+
+	_ "kubevirt.io/kubevirt/pkg/monitoring/workqueue/prometheus"
+)
+
+var _ = Describe("Workqueue metrics", func() {
+
+	// metricFor returns the metric of the family with the given queue name, or nil
+	metricFor := func(family string, queueName string) *io_prometheus_client.Metric {
+		families, err := prometheus.DefaultGatherer.Gather()
+		Expect(err).ToNot(HaveOccurred())
+		for _, f := range families {
+			if f.GetName() != family {
+				continue
+			}
+			for _, m := range f.GetMetric() {
+				for _, l := range m.GetLabel() {
+					if l.GetName() == "name" && l.GetValue() == queueName {
+						return m
+					}
+				}
+			}
+		}
+		return nil
+	}
+
+	It("should report the metrics per queue name", func() {
+		vmiQueue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "test-controller-vmi")
+		defer vmiQueue.ShutDown()
+		vmQueue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "test-controller-vm")
+		defer vmQueue.ShutDown()
+
+		vmiQueue.Add("default/testvmi")
+		vmiQueue.Add("default/othervmi")
+		vmQueue.Add("default/testvm")
+
+		Expect(metricFor("kubevirt_workqueue_depth", "test-controller-vmi").GetGauge().GetValue()).To(Equal(2.0))
+		Expect(metricFor("kubevirt_workqueue_depth", "test-controller-vm").GetGauge().GetValue()).To(Equal(1.0))
+		Expect(metricFor("kubevirt_workqueue_adds_total", "test-controller-vmi").GetCounter().GetValue()).To(Equal(2.0))
+	})
+
+	It("should count the retries and observe the work duration", func() {
+		queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "test-controller-retries")
+		defer queue.ShutDown()
+
+		queue.Add("default/testvmi")
+		key, _ := queue.Get()
+		queue.AddRateLimited(key)
+		queue.Done(key)
+
+		Expect(metricFor("kubevirt_workqueue_retries_total", "test-controller-retries").GetCounter().GetValue()).To(Equal(1.0))
+		Expect(metricFor("kubevirt_workqueue_work_duration_seconds", "test-controller-retries").GetHistogram().GetSampleCount()).To(Equal(uint64(1)))
+	})
+
+	It("should not report queues without a name", func() {
+		queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		defer queue.ShutDown()
+		queue.Add("default/testvmi")
+
+		Expect(metricFor("kubevirt_workqueue_depth", "")).To(BeNil())
+	})
+})
@@ -26,6 +26,8 @@ import (
 	"net"
 	"os"
 	"os/exec"
+	"syscall"
+	"time"
 
 	"github.com/coreos/go-iptables/iptables"
 	lmf "github.com/subgraph/libmacouflage"
@@ -50,6 +52,8 @@ const (
 	randomMacGenerationAttempts = 10
 	allowForwarding             = 1
 	LibvirtUserAndGroupId       = "0"
+	// bandwidthLimitLatency bounds how long packets wait in the queue of a bandwidth limit before they are dropped
+	bandwidthLimitLatency = 25 * time.Millisecond
 )
 
 type NetworkHandler interface {
@@ -84,6 +88,8 @@ type NetworkHandler interface {
 	CreateTapDevice(tapName string, queueNumber uint32, launcherPID int, mtu int, tapOwner string) error
 	BindTapDeviceToBridge(tapName string, bridgeName string) error
 	DisableTXOffloadChecksum(ifaceName string) error
+	LimitLinkEgress(linkName string, rate uint64, burst uint32) error
+	LimitLinkIngress(linkName string, ifbName string, rate uint64, burst uint32) error
 }
 
 type NetworkUtilsHandler struct{}
@@ -431,6 +437,86 @@ func (h *NetworkUtilsHandler) BindTapDeviceToBridge(tapName string, bridgeName s
 	return nil
 }
 
+// LimitLinkEgress limits the traffic sent by the link to rate bytes per second, with bursts of up to burst bytes,
+// through a token bucket filter qdisc
+func (h *NetworkUtilsHandler) LimitLinkEgress(linkName string, rate uint64, burst uint32) error {
+	link, err := netlink.LinkByName(linkName)
+	if err != nil {
+		return fmt.Errorf("could not find link %s; %v", linkName, err)
+	}
+
+	qdisc := &netlink.Tbf{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: link.Attrs().Index,
+			Handle:    netlink.MakeHandle(1, 0),
+			Parent:    netlink.HANDLE_ROOT,
+		},
+		Rate:   rate,
+		Buffer: uint32(netlink.Xmittime(rate, burst)),
+		Limit:  uint32(float64(rate)*bandwidthLimitLatency.Seconds()) + burst,
+	}
+	if err := netlink.QdiscReplace(qdisc); err != nil {
+		return fmt.Errorf("failed to limit the bandwidth of link %s; %v", linkName, err)
+	}
+
+	log.Log.Infof("Limited the egress of link %s to %d bytes per second", linkName, rate)
+	return nil
+}
+
+// LimitLinkIngress limits the traffic received by the link to rate bytes per second, with bursts of up to burst bytes.
+// The received traffic is redirected to an ifb device of the given name, whose egress is limited instead.
+func (h *NetworkUtilsHandler) LimitLinkIngress(linkName string, ifbName string, rate uint64, burst uint32) error {
+	link, err := netlink.LinkByName(linkName)
+	if err != nil {
+		return fmt.Errorf("could not find link %s; %v", linkName, err)
+	}
+
+	ifb, err := netlink.LinkByName(ifbName)
+	if _, notFound := err.(netlink.LinkNotFoundError); notFound {
+		err = netlink.LinkAdd(&netlink.Ifb{LinkAttrs: netlink.LinkAttrs{Name: ifbName, MTU: link.Attrs().MTU}})
+		if err != nil {
+			return fmt.Errorf("failed to create ifb device %s; %v", ifbName, err)
+		}
+		ifb, err = netlink.LinkByName(ifbName)
+	}
+	if err != nil {
+		return fmt.Errorf("could not find ifb device %s; %v", ifbName, err)
+	}
+	if err := netlink.LinkSetUp(ifb); err != nil {
+		return fmt.Errorf("failed to bring ifb device %s up; %v", ifbName, err)
+	}
+	if err := h.LimitLinkEgress(ifbName, rate, burst); err != nil {
+		return err
+	}
+
+	ingress := &netlink.Ingress{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: link.Attrs().Index,
+			Handle:    netlink.MakeHandle(0xffff, 0),
+			Parent:    netlink.HANDLE_INGRESS,
+		},
+	}
+	if err := netlink.QdiscReplace(ingress); err != nil {
+		return fmt.Errorf("failed to add the ingress qdisc to link %s; %v", linkName, err)
+	}
+	redirect := &netlink.U32{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: link.Attrs().Index,
+			Parent:    ingress.Handle,
+			Priority:  1,
+			Protocol:  syscall.ETH_P_ALL,
+		},
+		ClassId:    netlink.MakeHandle(1, 1),
+		RedirIndex: ifb.Attrs().Index,
+	}
+	if err := netlink.FilterReplace(redirect); err != nil {
+		return fmt.Errorf("failed to redirect the ingress of link %s to ifb device %s; %v", linkName, ifbName, err)
+	}
+
+	log.Log.Infof("Limited the ingress of link %s to %d bytes per second", linkName, rate)
+	return nil
+}
+
 func (h *NetworkUtilsHandler) DisableTXOffloadChecksum(ifaceName string) error {
 	if err := link.EthtoolTXOff(ifaceName); err != nil {
 		log.Log.Reason(err).Errorf("Failed to set tx offload for interface %s off", ifaceName)
@@ -364,3 +364,23 @@ func (_m *MockNetworkHandler) DisableTXOffloadChecksum(ifaceName string) error {
 func (_mr *_MockNetworkHandlerRecorder) DisableTXOffloadChecksum(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "DisableTXOffloadChecksum", arg0)
 }
+
+func (_m *MockNetworkHandler) LimitLinkEgress(linkName string, rate uint64, burst uint32) error {
+	ret := _m.ctrl.Call(_m, "LimitLinkEgress", linkName, rate, burst)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) LimitLinkEgress(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "LimitLinkEgress", arg0, arg1, arg2)
+}
+
+func (_m *MockNetworkHandler) LimitLinkIngress(linkName string, ifbName string, rate uint64, burst uint32) error {
+	ret := _m.ctrl.Call(_m, "LimitLinkIngress", linkName, ifbName, rate, burst)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) LimitLinkIngress(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "LimitLinkIngress", arg0, arg1, arg2, arg3)
+}
@@ -28,6 +28,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/vishvananda/netlink"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/kubevirt/pkg/network/cache"
@@ -256,6 +257,35 @@ var _ = Describe("Bridge infrastructure configurator", func() {
 				Expect(bridgeConfigurator.PreparePodNetworkInterface()).To(Succeed())
 			})
 
+			It("network preparation limits the bandwidth of the interface", func() {
+				burst := resource.MustParse("512")
+				iface.Bandwidth = &v1.InterfaceBandwidth{
+					Ingress: &v1.BandwidthLimit{Rate: resource.MustParse("100M")},
+					Egress:  &v1.BandwidthLimit{Rate: resource.MustParse("1M"), Burst: &burst},
+				}
+				bridgeConfigurator := newMockedBridgeConfiguratorForPreparePhase(
+					vmi,
+					iface,
+					handler,
+					bridgeIfaceName,
+					launcherPID,
+					podLink,
+					podIP,
+					withOriginalPodLinkDown(podLink),
+					withCreatedInPodBridge(inPodBridge, bridgeIPAddr),
+					withLinkAsBridgePort(inPodBridge, podLinkAfterNameChange),
+					withPodPrimaryLinkSwapped(podLink, podLinkAfterNameChange, dummySwap, podIP),
+					withPodLinkRandomMac(podLinkAfterNameChange, mac),
+					withARPIgnore(),
+					withCreatedTapDevice(tapDeviceName, bridgeIfaceName, launcherPID, mtu, queueCount),
+					withLimitedLinkEgress(tapDeviceName, 12500000, 1250000),
+					withLimitedLinkIngress(tapDeviceName, "ifb"+tapDeviceName[3:], 125000, mtu),
+					withDisabledTxOffloadChecksum(bridgeIfaceName),
+					withLinkLearningOff(podLinkAfterNameChange),
+					withLinkUp(podLinkAfterNameChange))
+				Expect(bridgeConfigurator.PreparePodNetworkInterface()).To(Succeed())
+			})
+
 			It("network preparation fails when setting the link down errors", func() {
 				const errorString = "failed to set link down"
 				bridgeConfigurator := newMockedBridgeConfiguratorForPreparePhase(
@@ -816,6 +846,18 @@ func withCreatedTapDevice(tapDeviceName string, bridgeName string, launcherPID i
 	}
 }
 
+func withLimitedLinkEgress(linkName string, rate uint64, burst uint32) Option {
+	return func(handler *netdriver.MockNetworkHandler) {
+		handler.EXPECT().LimitLinkEgress(linkName, rate, burst)
+	}
+}
+
+func withLimitedLinkIngress(linkName string, ifbName string, rate uint64, burst uint32) Option {
+	return func(handler *netdriver.MockNetworkHandler) {
+		handler.EXPECT().LimitLinkIngress(linkName, ifbName, rate, burst)
+	}
+}
+
 func withErrorCreatingTapDevice(tapDeviceName string, mtu int, launcherPID int, queueCount uint32, errorString string) Option {
 	return func(handler *netdriver.MockNetworkHandler) {
 		handler.EXPECT().CreateTapDevice(
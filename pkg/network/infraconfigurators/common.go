@@ -22,11 +22,14 @@
 package infraconfigurators
 
 import (
+	"math"
+
 	"kubevirt.io/kubevirt/pkg/network/cache"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	netdriver "kubevirt.io/kubevirt/pkg/network/driver"
+	virtnetlink "kubevirt.io/kubevirt/pkg/network/link"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter"
 )
 
@@ -38,22 +41,61 @@ type PodNetworkInfraConfigurator interface {
 	GenerateNonRecoverableDHCPConfig() *cache.DHCPConfig
 }
 
-func createAndBindTapToBridge(handler netdriver.NetworkHandler, deviceName string, bridgeIfaceName string, launcherPID int, mtu int, tapOwner string, vmi *v1.VirtualMachineInstance) error {
-	err := handler.CreateTapDevice(deviceName, calculateNetworkQueues(vmi), launcherPID, mtu, tapOwner)
+func createAndBindTapToBridge(handler netdriver.NetworkHandler, deviceName string, bridgeIfaceName string, launcherPID int, mtu int, tapOwner string, vmi *v1.VirtualMachineInstance, vmiSpecIface *v1.Interface) error {
+	err := handler.CreateTapDevice(deviceName, converter.CalculateInterfaceQueues(vmi, vmiSpecIface), launcherPID, mtu, tapOwner)
 	if err != nil {
 		return err
 	}
 	return handler.BindTapDeviceToBridge(deviceName, bridgeIfaceName)
 }
 
-func calculateNetworkQueues(vmi *v1.VirtualMachineInstance) uint32 {
-	if isMultiqueue(vmi) {
-		return converter.CalculateNetworkQueues(vmi)
+// defaultBandwidthBurstDuration is the duration of traffic at the limited rate which can be sent at once by default
+const defaultBandwidthBurstDuration = 0.1
+
+// minDefaultBandwidthBurst is the lower bound of the default burst in bytes
+const minDefaultBandwidthBurst = 64 * 1024
+
+// limitBandwidth applies the bandwidth limits of the interface on its tap device, so that other traffic of
+// the pod is not affected: the traffic received by the guest is limited on the egress of the tap device,
+// the traffic sent by the guest on its ingress.
+func limitBandwidth(handler netdriver.NetworkHandler, vmiSpecIface *v1.Interface, tapDeviceName string, mtu int) error {
+	if vmiSpecIface.Bandwidth == nil {
+		return nil
+	}
+	if limit := vmiSpecIface.Bandwidth.Ingress; limit != nil {
+		rate, burst := bandwidthLimitToBytes(limit, mtu)
+		if err := handler.LimitLinkEgress(tapDeviceName, rate, burst); err != nil {
+			return err
+		}
 	}
-	return 0
+	if limit := vmiSpecIface.Bandwidth.Egress; limit != nil {
+		rate, burst := bandwidthLimitToBytes(limit, mtu)
+		if err := handler.LimitLinkIngress(tapDeviceName, virtnetlink.GenerateIfbDeviceName(tapDeviceName), rate, burst); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func isMultiqueue(vmi *v1.VirtualMachineInstance) bool {
-	return (vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue != nil) &&
-		(*vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue)
+// bandwidthLimitToBytes returns the rate in bytes per second and the burst in bytes of the limit.
+// The rate is rounded up, so that a positive rate never turns into 0, which means no limit.
+// The burst holds at least one packet of the MTU, otherwise no packet would ever pass.
+func bandwidthLimitToBytes(limit *v1.BandwidthLimit, mtu int) (uint64, uint32) {
+	rate := (uint64(limit.Rate.Value()) + 7) / 8
+	var burst uint64
+	if limit.Burst != nil {
+		burst = uint64(limit.Burst.Value())
+	} else {
+		burst = uint64(float64(rate) * defaultBandwidthBurstDuration)
+		if burst < minDefaultBandwidthBurst {
+			burst = minDefaultBandwidthBurst
+		}
+	}
+	if burst < uint64(mtu) {
+		burst = uint64(mtu)
+	}
+	if burst > math.MaxUint32 {
+		burst = math.MaxUint32
+	}
+	return rate, uint32(burst)
 }
@@ -0,0 +1,44 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package infraconfigurators
+
+import (
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Bandwidth limits", func() {
+	table.DescribeTable("should convert the limit to bytes", func(rate string, burst *resource.Quantity, expectedRate uint64, expectedBurst uint32) {
+		actualRate, actualBurst := bandwidthLimitToBytes(&v1.BandwidthLimit{Rate: resource.MustParse(rate), Burst: burst}, 1500)
+		Expect(actualRate).To(Equal(expectedRate))
+		Expect(actualBurst).To(Equal(expectedBurst))
+	},
+		table.Entry("with the default burst", "100M", nil, uint64(12500000), uint32(1250000)),
+		table.Entry("with the minimal default burst", "1M", nil, uint64(125000), uint32(minDefaultBandwidthBurst)),
+		table.Entry("with a burst of at least the MTU", "1M", resource.NewQuantity(512, resource.BinarySI), uint64(125000), uint32(1500)),
+		table.Entry("rounding a rate below a byte per second up", "1", nil, uint64(1), uint32(minDefaultBandwidthBurst)),
+		table.Entry("rounding a rate of a fraction of a byte per second up", "12", nil, uint64(2), uint32(minDefaultBandwidthBurst)),
+	)
+})
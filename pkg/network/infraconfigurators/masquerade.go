@@ -111,12 +111,17 @@ func (b *MasqueradePodNetworkConfigurator) PreparePodNetworkInterface() error {
 		tapOwner = strconv.Itoa(util.NonRootUID)
 	}
 	tapDeviceName := virtnetlink.GenerateTapDeviceName(b.podNicLink.Attrs().Name)
-	err := createAndBindTapToBridge(b.handler, tapDeviceName, b.bridgeInterfaceName, b.launcherPID, b.podNicLink.Attrs().MTU, tapOwner, b.vmi)
+	err := createAndBindTapToBridge(b.handler, tapDeviceName, b.bridgeInterfaceName, b.launcherPID, b.podNicLink.Attrs().MTU, tapOwner, b.vmi, b.vmiSpecIface)
 	if err != nil {
 		log.Log.Reason(err).Errorf("failed to create tap device named %s", tapDeviceName)
 		return err
 	}
 
+	if err := limitBandwidth(b.handler, b.vmiSpecIface, tapDeviceName, b.podNicLink.Attrs().MTU); err != nil {
+		log.Log.Reason(err).Errorf("failed to limit the bandwidth of interface %s", b.vmiSpecIface.Name)
+		return err
+	}
+
 	err = b.createNatRules(iptables.ProtocolIPv4)
 	if err != nil {
 		log.Log.Reason(err).Errorf("failed to create ipv4 nat rules for vm error: %v", err)
@@ -208,6 +213,7 @@ func (b *MasqueradePodNetworkConfigurator) createNatRules(protocol iptables.Prot
 	if b.handler.NftablesLoad(protocol) == nil {
 		return b.createNatRulesUsingNftables(protocol)
 	} else if b.handler.HasNatIptables(protocol) {
+		log.Log.V(4).Object(b.vmi).Info("nftables is not available, falling back to iptables for the nat rules")
 		return b.createNatRulesUsingIptables(protocol)
 	}
 	return fmt.Errorf("Couldn't configure ip nat rules")
@@ -244,17 +250,30 @@ func (b *MasqueradePodNetworkConfigurator) createNatRulesUsingIptables(protocol
 		return err
 	}
 
+	srcAddressesToSnat := strings.Join(b.srcAddressesToSnat(protocol), ",")
+	dstAddressesToDnat, err := b.dstAddressesToDnat(protocol)
+	if err != nil {
+		return err
+	}
+
 	if len(b.vmiSpecIface.Ports) == 0 {
-		err = b.handler.IptablesAppendRule(protocol, "nat", "KUBEVIRT_PREINBOUND",
-			"-j",
-			"DNAT",
-			"--to-destination", b.geVmIfaceIpByProtocol(protocol))
-		if err != nil {
-			return err
+		if istio.ProxyInjectionEnabled(b.vmi) {
+			err = b.skipForwardingForPortsUsingIptables(protocol, istio.ReservedPorts())
+			if err != nil {
+				return err
+			}
+		} else {
+			err = b.handler.IptablesAppendRule(protocol, "nat", "KUBEVIRT_PREINBOUND",
+				"-j",
+				"DNAT",
+				"--to-destination", b.geVmIfaceIpByProtocol(protocol))
+			if err != nil {
+				return err
+			}
 		}
 
 		err = b.handler.IptablesAppendRule(protocol, "nat", "KUBEVIRT_POSTINBOUND",
-			"--source", getLoopbackAdrress(protocol),
+			"--source", srcAddressesToSnat,
 			"-j",
 			"SNAT",
 			"--to-source", b.getGatewayByProtocol(protocol))
@@ -263,7 +282,7 @@ func (b *MasqueradePodNetworkConfigurator) createNatRulesUsingIptables(protocol
 		}
 
 		err = b.handler.IptablesAppendRule(protocol, "nat", "OUTPUT",
-			"--destination", getLoopbackAdrress(protocol),
+			"--destination", strings.Join(dstAddressesToDnat, ","),
 			"-j",
 			"DNAT",
 			"--to-destination", b.geVmIfaceIpByProtocol(protocol))
@@ -274,7 +293,7 @@ func (b *MasqueradePodNetworkConfigurator) createNatRulesUsingIptables(protocol
 		return nil
 	}
 
-	for _, port := range b.vmiSpecIface.Ports {
+	for _, port := range b.forwardedPorts() {
 		if port.Protocol == "" {
 			port.Protocol = "tcp"
 		}
@@ -284,7 +303,7 @@ func (b *MasqueradePodNetworkConfigurator) createNatRulesUsingIptables(protocol
 			strings.ToLower(port.Protocol),
 			"--dport",
 			strconv.Itoa(int(port.Port)),
-			"--source", getLoopbackAdrress(protocol),
+			"--source", srcAddressesToSnat,
 			"-j",
 			"SNAT",
 			"--to-source", b.getGatewayByProtocol(protocol))
@@ -292,16 +311,18 @@ func (b *MasqueradePodNetworkConfigurator) createNatRulesUsingIptables(protocol
 			return err
 		}
 
-		err = b.handler.IptablesAppendRule(protocol, "nat", "KUBEVIRT_PREINBOUND",
-			"-p",
-			strings.ToLower(port.Protocol),
-			"--dport",
-			strconv.Itoa(int(port.Port)),
-			"-j",
-			"DNAT",
-			"--to-destination", b.geVmIfaceIpByProtocol(protocol))
-		if err != nil {
-			return err
+		if !istio.ProxyInjectionEnabled(b.vmi) {
+			err = b.handler.IptablesAppendRule(protocol, "nat", "KUBEVIRT_PREINBOUND",
+				"-p",
+				strings.ToLower(port.Protocol),
+				"--dport",
+				strconv.Itoa(int(port.Port)),
+				"-j",
+				"DNAT",
+				"--to-destination", b.geVmIfaceIpByProtocol(protocol))
+			if err != nil {
+				return err
+			}
 		}
 
 		err = b.handler.IptablesAppendRule(protocol, "nat", "OUTPUT",
@@ -309,7 +330,7 @@ func (b *MasqueradePodNetworkConfigurator) createNatRulesUsingIptables(protocol
 			strings.ToLower(port.Protocol),
 			"--dport",
 			strconv.Itoa(int(port.Port)),
-			"--destination", getLoopbackAdrress(protocol),
+			"--destination", strings.Join(dstAddressesToDnat, ","),
 			"-j",
 			"DNAT",
 			"--to-destination", b.geVmIfaceIpByProtocol(protocol))
@@ -371,10 +392,12 @@ func (b *MasqueradePodNetworkConfigurator) createNatRulesUsingNftables(proto ipt
 		return err
 	}
 
-	addressesToDnat, err := b.getDstAddressesToDnat(proto)
+	srcAddressesToSnat := fmt.Sprintf("{ %s }", strings.Join(b.srcAddressesToSnat(proto), ", "))
+	dstAddressesToDnat, err := b.dstAddressesToDnat(proto)
 	if err != nil {
 		return err
 	}
+	addressesToDnat := fmt.Sprintf("{ %s }", strings.Join(dstAddressesToDnat, ", "))
 
 	if len(b.vmiSpecIface.Ports) == 0 {
 		if istio.ProxyInjectionEnabled(b.vmi) {
@@ -385,7 +408,7 @@ func (b *MasqueradePodNetworkConfigurator) createNatRulesUsingNftables(proto ipt
 		}
 
 		err = b.handler.NftablesAppendRule(proto, "nat", "KUBEVIRT_POSTINBOUND",
-			b.handler.GetNFTIPString(proto), "saddr", b.getSrcAddressesToSnat(proto),
+			b.handler.GetNFTIPString(proto), "saddr", srcAddressesToSnat,
 			"counter", "snat", "to", b.getGatewayByProtocol(proto))
 		if err != nil {
 			return err
@@ -409,16 +432,16 @@ func (b *MasqueradePodNetworkConfigurator) createNatRulesUsingNftables(proto ipt
 		return nil
 	}
 
-	for _, port := range b.vmiSpecIface.Ports {
-		if port.Protocol == "" {
-			port.Protocol = "tcp"
-		}
+	// a single rule per protocol matches all of its ports through an anonymous set, which
+	// keeps the number of rules every packet traverses independent of the number of ports
+	for _, group := range groupPortsByProtocol(b.forwardedPorts()) {
+		dports := fmt.Sprintf("{ %s }", strings.Join(group.ports, ", "))
 
 		err = b.handler.NftablesAppendRule(proto, "nat", "KUBEVIRT_POSTINBOUND",
-			strings.ToLower(port.Protocol),
+			group.protocol,
 			"dport",
-			strconv.Itoa(int(port.Port)),
-			b.handler.GetNFTIPString(proto), "saddr", b.getSrcAddressesToSnat(proto),
+			dports,
+			b.handler.GetNFTIPString(proto), "saddr", srcAddressesToSnat,
 			"counter", "snat", "to", b.getGatewayByProtocol(proto))
 		if err != nil {
 			return err
@@ -426,9 +449,9 @@ func (b *MasqueradePodNetworkConfigurator) createNatRulesUsingNftables(proto ipt
 
 		if !istio.ProxyInjectionEnabled(b.vmi) {
 			err = b.handler.NftablesAppendRule(proto, "nat", "KUBEVIRT_PREINBOUND",
-				strings.ToLower(port.Protocol),
+				group.protocol,
 				"dport",
-				strconv.Itoa(int(port.Port)),
+				dports,
 				"counter", "dnat", "to", b.geVmIfaceIpByProtocol(proto))
 			if err != nil {
 				return err
@@ -437,9 +460,9 @@ func (b *MasqueradePodNetworkConfigurator) createNatRulesUsingNftables(proto ipt
 
 		err = b.handler.NftablesAppendRule(proto, "nat", "output",
 			b.handler.GetNFTIPString(proto), "daddr", addressesToDnat,
-			strings.ToLower(port.Protocol),
+			group.protocol,
 			"dport",
-			strconv.Itoa(int(port.Port)),
+			dports,
 			"counter", "dnat", "to", b.geVmIfaceIpByProtocol(proto))
 		if err != nil {
 			return err
@@ -449,6 +472,39 @@ func (b *MasqueradePodNetworkConfigurator) createNatRulesUsingNftables(proto ipt
 	return nil
 }
 
+type protocolPorts struct {
+	protocol string
+	ports    []string
+}
+
+// groupPortsByProtocol groups the ports by their lower case protocol, which defaults to tcp.
+// The protocols and the ports keep the order of their first appearance.
+func groupPortsByProtocol(ports []v1.Port) []protocolPorts {
+	var groups []protocolPorts
+	indexes := map[string]int{}
+	seen := map[string]bool{}
+	for _, port := range ports {
+		protocol := strings.ToLower(port.Protocol)
+		if protocol == "" {
+			protocol = "tcp"
+		}
+		key := fmt.Sprintf("%s/%d", protocol, port.Port)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		index, ok := indexes[protocol]
+		if !ok {
+			index = len(groups)
+			indexes[protocol] = index
+			groups = append(groups, protocolPorts{protocol: protocol})
+		}
+		groups[index].ports = append(groups[index].ports, strconv.Itoa(int(port.Port)))
+	}
+	return groups
+}
+
 func (b *MasqueradePodNetworkConfigurator) skipForwardingForPortsUsingNftables(proto iptables.Protocol, ports []string) error {
 	if len(ports) == 0 {
 		return nil
@@ -483,24 +539,41 @@ func (b *MasqueradePodNetworkConfigurator) geVmIfaceIpByProtocol(proto iptables.
 	}
 }
 
-func (b *MasqueradePodNetworkConfigurator) getSrcAddressesToSnat(proto iptables.Protocol) string {
+func (b *MasqueradePodNetworkConfigurator) srcAddressesToSnat(proto iptables.Protocol) []string {
 	addresses := []string{getLoopbackAdrress(proto)}
 	if istio.ProxyInjectionEnabled(b.vmi) && proto == iptables.ProtocolIPv4 {
 		addresses = append(addresses, istio.GetLoopbackAddress())
 	}
-	return fmt.Sprintf("{ %s }", strings.Join(addresses, ", "))
+	return addresses
 }
 
-func (b *MasqueradePodNetworkConfigurator) getDstAddressesToDnat(proto iptables.Protocol) (string, error) {
+func (b *MasqueradePodNetworkConfigurator) dstAddressesToDnat(proto iptables.Protocol) ([]string, error) {
 	addresses := []string{getLoopbackAdrress(proto)}
 	if istio.ProxyInjectionEnabled(b.vmi) && proto == iptables.ProtocolIPv4 {
 		ipv4, _, err := b.handler.ReadIPAddressesFromLink(b.podNicLink.Attrs().Name)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 		addresses = append(addresses, ipv4)
 	}
-	return fmt.Sprintf("{ %s }", strings.Join(addresses, ", ")), nil
+	return addresses, nil
+}
+
+// forwardedPorts returns the ports of the interface which are forwarded to the VM. Inside a
+// service mesh the ports the proxy listens on stay with the proxy.
+func (b *MasqueradePodNetworkConfigurator) forwardedPorts() []v1.Port {
+	if !istio.ProxyInjectionEnabled(b.vmi) {
+		return b.vmiSpecIface.Ports
+	}
+	var ports []v1.Port
+	for _, port := range b.vmiSpecIface.Ports {
+		if istio.IsReservedPort(port.Port) {
+			log.Log.Object(b.vmi).Warningf("port %d is reserved for the istio proxy and is not forwarded to the VM", port.Port)
+			continue
+		}
+		ports = append(ports, port)
+	}
+	return ports
 }
 
 func getLoopbackAdrress(proto iptables.Protocol) string {
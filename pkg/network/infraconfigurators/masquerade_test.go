@@ -91,12 +91,34 @@ var _ = Describe("Masquerade infrastructure configurator", func() {
 		}
 		return vmi
 	}
+	newVMIWithDetectedIstioProxy := func(namespace string, name string, ports ...int) *v1.VirtualMachineInstance {
+		vmi := newVMIMasqueradeInterface(namespace, name, ports...)
+		vmi.Annotations = map[string]string{
+			istio.PROXY_INJECTED_ANNOTATION: "true",
+		}
+		return vmi
+	}
 	newVMIMasqueradeMigrateOverSockets := func(namespace string, name string, ports ...int) *v1.VirtualMachineInstance {
 		vmi := newVMIMasqueradeInterface(namespace, name, ports...)
 		vmi.Status.MigrationTransport = v1.MigrationTransportUnix
 		return vmi
 	}
 
+	table.DescribeTable("should group the ports by protocol", func(ports []v1.Port, expected []protocolPorts) {
+		Expect(groupPortsByProtocol(ports)).To(Equal(expected))
+	},
+		table.Entry("without ports", nil, nil),
+		table.Entry("with the default protocol",
+			[]v1.Port{{Port: 22}, {Port: 80, Protocol: "TCP"}},
+			[]protocolPorts{{protocol: "tcp", ports: []string{"22", "80"}}}),
+		table.Entry("with several protocols in the order of their first appearance",
+			[]v1.Port{{Port: 53, Protocol: "UDP"}, {Port: 22}, {Port: 123, Protocol: "udp"}},
+			[]protocolPorts{{protocol: "udp", ports: []string{"53", "123"}}, {protocol: "tcp", ports: []string{"22"}}}),
+		table.Entry("with duplicated ports",
+			[]v1.Port{{Port: 22}, {Port: 22, Protocol: "tcp"}, {Port: 22, Protocol: "udp"}},
+			[]protocolPorts{{protocol: "tcp", ports: []string{"22"}}, {protocol: "udp", ports: []string{"22"}}}),
+	)
+
 	Context("discover link information", func() {
 		const (
 			expectedVMInternalIPStr   = "10.0.2.2/24"
@@ -253,6 +275,18 @@ var _ = Describe("Masquerade infrastructure configurator", func() {
 				table.Entry("NFTables backend on an IPv4 cluster when using an ISTIO aware VMI",
 					newIstioAwareVMIWithSingleInterface(namespace, vmName),
 					mockNetfilterNFTables),
+				table.Entry("IPTables backend on an IPv4 cluster when using an ISTIO aware VMI",
+					newIstioAwareVMIWithSingleInterface(namespace, vmName),
+					mockNetfilterIPTables),
+				table.Entry("NFTables backend on an IPv4 cluster when the ISTIO proxy was detected on the pod",
+					newVMIWithDetectedIstioProxy(namespace, vmName),
+					mockNetfilterNFTables),
+				table.Entry("NFTables backend on an IPv4 cluster when using an ISTIO aware VMI with specific ports",
+					newIstioAwareVMIWithSingleInterface(namespace, vmName, istio.EnvoyHealthCheckPort, 18000),
+					mockNetfilterNFTables),
+				table.Entry("IPTables backend on an IPv4 cluster when using an ISTIO aware VMI with specific ports",
+					newIstioAwareVMIWithSingleInterface(namespace, vmName, istio.EnvoyHealthCheckPort, 18000),
+					mockNetfilterIPTables),
 				table.Entry("NFTables backend on a dual stack cluster",
 					newVMIMasqueradeInterface(namespace, vmName),
 					mockNetfilterNFTables,
@@ -277,6 +311,10 @@ var _ = Describe("Masquerade infrastructure configurator", func() {
 					newIstioAwareVMIWithSingleInterface(namespace, vmName),
 					mockNetfilterNFTables,
 					iptables.ProtocolIPv6),
+				table.Entry("IPTables backend on a dual stack cluster when using an ISTIO aware VMI",
+					newIstioAwareVMIWithSingleInterface(namespace, vmName),
+					mockNetfilterIPTables,
+					iptables.ProtocolIPv6),
 				table.Entry("NFTables backend on an IPv4 cluster with migration over sockets",
 					newVMIMasqueradeMigrateOverSockets(namespace, vmName, getReservedPortList(!migrationOverTCP)...),
 					mockNetfilterNFTables),
@@ -393,7 +431,7 @@ func getVMPrimaryInterfacePortList(vmi v1.VirtualMachineInstance) []int {
 func mockNetfilterIPTables(handler *netdriver.MockNetworkHandler, proto iptables.Protocol, nftIPString string, vmIP string, gwIP string, portList []int, vmiAnnotations map[string]string, isMigrationOverSockets bool) {
 	handler.EXPECT().NftablesLoad(proto).Return(fmt.Errorf("nft not found"))
 	handler.EXPECT().HasNatIptables(proto).Return(true)
-	mockIPTablesBackend(handler, proto, nftIPString, vmIP, gwIP, portList, isMigrationOverSockets)
+	mockIPTablesBackend(handler, proto, nftIPString, vmIP, gwIP, portList, vmiAnnotations, isMigrationOverSockets)
 }
 
 func mockNetfilterNFTables(handler *netdriver.MockNetworkHandler, proto iptables.Protocol, nftIPString string, vmIP string, gwIP string, portList []int, vmiAnnotations map[string]string, isMigrationOverSockets bool) {
@@ -419,7 +457,11 @@ func mockNFTablesFrontend(handler *netdriver.MockNetworkHandler, proto iptables.
 	}
 
 	if len(portList) > 0 {
-		mockNFTablesBackendSpecificPorts(handler, proto, nftIPString, vmIP, gwIP, portList)
+		if isIstioAware(vmiAnnotations) {
+			mockIstioNFTablesSpecificPorts(handler, proto, nftIPString, vmIP, gwIP, portList)
+		} else {
+			mockNFTablesBackendSpecificPorts(handler, proto, nftIPString, vmIP, gwIP, portList)
+		}
 	} else {
 		if isIstioAware(vmiAnnotations) {
 			mockIstioNetfilterCalls(handler, proto, nftIPString, vmIP, gwIP)
@@ -430,28 +472,35 @@ func mockNFTablesFrontend(handler *netdriver.MockNetworkHandler, proto iptables.
 }
 
 func mockNFTablesBackendSpecificPorts(handler *netdriver.MockNetworkHandler, proto iptables.Protocol, nftIpString string, vmIP string, gwIP string, portList []int) {
+	dports := nftPortSet(portList)
+	handler.EXPECT().NftablesAppendRule(proto, "nat",
+		"KUBEVIRT_POSTINBOUND",
+		"tcp",
+		"dport",
+		dports,
+		nftIpString, "saddr", "{ "+GetLoopbackAdrress(proto)+" }",
+		"counter", "snat", "to", gwIP).Return(nil)
+	handler.EXPECT().NftablesAppendRule(proto, "nat",
+		"KUBEVIRT_PREINBOUND",
+		"tcp",
+		"dport",
+		dports,
+		"counter", "dnat", "to", vmIP).Return(nil)
+	handler.EXPECT().NftablesAppendRule(proto, "nat",
+		"output",
+		nftIpString, "daddr", "{ "+GetLoopbackAdrress(proto)+" }",
+		"tcp",
+		"dport",
+		dports,
+		"counter", "dnat", "to", vmIP).Return(nil)
+}
+
+func nftPortSet(portList []int) string {
+	var ports []string
 	for _, port := range portList {
-		handler.EXPECT().NftablesAppendRule(proto, "nat",
-			"KUBEVIRT_POSTINBOUND",
-			"tcp",
-			"dport",
-			fmt.Sprintf("%d", port),
-			nftIpString, "saddr", "{ "+GetLoopbackAdrress(proto)+" }",
-			"counter", "snat", "to", gwIP).Return(nil)
-		handler.EXPECT().NftablesAppendRule(proto, "nat",
-			"KUBEVIRT_PREINBOUND",
-			"tcp",
-			"dport",
-			fmt.Sprintf("%d", port),
-			"counter", "dnat", "to", vmIP).Return(nil)
-		handler.EXPECT().NftablesAppendRule(proto, "nat",
-			"output",
-			nftIpString, "daddr", "{ "+GetLoopbackAdrress(proto)+" }",
-			"tcp",
-			"dport",
-			fmt.Sprintf("%d", port),
-			"counter", "dnat", "to", vmIP).Return(nil)
+		ports = append(ports, fmt.Sprintf("%d", port))
 	}
+	return fmt.Sprintf("{ %s }", strings.Join(ports, ", "))
 }
 
 func mockNFTablesBackendAllPorts(handler *netdriver.MockNetworkHandler, proto iptables.Protocol, nftIPString string, vmIP string, gwIP string) {
@@ -460,7 +509,7 @@ func mockNFTablesBackendAllPorts(handler *netdriver.MockNetworkHandler, proto ip
 	handler.EXPECT().NftablesAppendRule(proto, "nat", "output", nftIPString, "daddr", fmt.Sprintf("{ %s }", GetLoopbackAdrress(proto)), "counter", "dnat", "to", vmIP).Return(nil)
 }
 
-func mockIPTablesBackend(handler *netdriver.MockNetworkHandler, proto iptables.Protocol, nftIPString string, vmIP string, gwIP string, portList []int, isMigrationOverSockets bool) {
+func mockIPTablesBackend(handler *netdriver.MockNetworkHandler, proto iptables.Protocol, nftIPString string, vmIP string, gwIP string, portList []int, vmiAnnotations map[string]string, isMigrationOverSockets bool) {
 	handler.EXPECT().GetNFTIPString(proto).Return(nftIPString).AnyTimes()
 	handler.EXPECT().IptablesNewChain(proto, "nat", "KUBEVIRT_PREINBOUND").Return(nil)
 	handler.EXPECT().IptablesNewChain(proto, "nat", "KUBEVIRT_POSTINBOUND").Return(nil)
@@ -493,7 +542,9 @@ func mockIPTablesBackend(handler *netdriver.MockNetworkHandler, proto iptables.P
 		}
 	}
 
-	if len(portList) > 0 {
+	if isIstioAware(vmiAnnotations) {
+		mockIstioIPTablesCalls(handler, proto, vmIP, gwIP, portList)
+	} else if len(portList) > 0 {
 		mockIPTablesBackendSpecificPorts(handler, proto, vmIP, gwIP, portList)
 	} else {
 		mockIPTablesBackendAllPorts(handler, proto, vmIP, gwIP)
@@ -575,6 +626,68 @@ func mockIstioNetfilterCalls(handler *netdriver.MockNetworkHandler, proto iptabl
 		"counter", "dnat", "to", vmIP).Return(nil).Times(0)
 }
 
+func mockIstioNFTablesSpecificPorts(handler *netdriver.MockNetworkHandler, proto iptables.Protocol, nftIPString string, vmIP string, gwIP string, portList []int) {
+	podIP := netlink.Addr{IPNet: &net.IPNet{IP: net.ParseIP("10.35.0.2"), Mask: net.CIDRMask(24, 32)}}
+	srcAddressesToSnat := getSrcAddressesToSNAT(proto)
+	dstAddressesToDnat := getDstAddressesToDNAT(proto, podIP)
+	if proto == iptables.ProtocolIPv4 {
+		handler.EXPECT().ReadIPAddressesFromLink("eth0").Return(podIP.IP.String(), "", nil)
+	}
+	var forwardedPorts []int
+	for _, port := range portList {
+		if !istio.IsReservedPort(int32(port)) {
+			forwardedPorts = append(forwardedPorts, port)
+		}
+	}
+	handler.EXPECT().NftablesAppendRule(proto, "nat",
+		"KUBEVIRT_POSTINBOUND", "tcp", "dport", nftPortSet(forwardedPorts),
+		nftIPString, "saddr", fmt.Sprintf("{ %s }", strings.Join(srcAddressesToSnat, ", ")),
+		"counter", "snat", "to", gwIP).Return(nil)
+	handler.EXPECT().NftablesAppendRule(proto, "nat",
+		"output", nftIPString, "daddr", fmt.Sprintf("{ %s }", strings.Join(dstAddressesToDnat, ", ")),
+		"tcp", "dport", nftPortSet(forwardedPorts),
+		"counter", "dnat", "to", vmIP).Return(nil)
+}
+
+func mockIstioIPTablesCalls(handler *netdriver.MockNetworkHandler, proto iptables.Protocol, vmIP string, gwIP string, portList []int) {
+	podIP := netlink.Addr{IPNet: &net.IPNet{IP: net.ParseIP("10.35.0.2"), Mask: net.CIDRMask(24, 32)}}
+	srcAddressesToSnat := strings.Join(getSrcAddressesToSNAT(proto), ",")
+	dstAddressesToDnat := strings.Join(getDstAddressesToDNAT(proto, podIP), ",")
+	if proto == iptables.ProtocolIPv4 {
+		handler.EXPECT().ReadIPAddressesFromLink("eth0").Return(podIP.IP.String(), "", nil)
+	}
+
+	if len(portList) == 0 {
+		for _, chain := range []string{"OUTPUT", "KUBEVIRT_POSTINBOUND"} {
+			handler.EXPECT().IptablesAppendRule(proto, "nat", chain,
+				"-p", "tcp", "--match", "multiport",
+				"--dports", strings.Join(istio.ReservedPorts(), ","),
+				"--source", GetLoopbackAdrress(proto), "-j", "RETURN").Return(nil)
+		}
+		handler.EXPECT().IptablesAppendRule(proto, "nat",
+			"KUBEVIRT_POSTINBOUND", "--source", srcAddressesToSnat,
+			"-j", "SNAT", "--to-source", gwIP).Return(nil)
+		handler.EXPECT().IptablesAppendRule(proto, "nat",
+			"OUTPUT", "--destination", dstAddressesToDnat,
+			"-j", "DNAT", "--to-destination", vmIP).Return(nil)
+		return
+	}
+
+	for _, port := range portList {
+		if istio.IsReservedPort(int32(port)) {
+			continue
+		}
+		handler.EXPECT().IptablesAppendRule(proto, "nat",
+			"KUBEVIRT_POSTINBOUND", "-p", "tcp", "--dport", fmt.Sprintf("%d", port),
+			"--source", srcAddressesToSnat,
+			"-j", "SNAT", "--to-source", gwIP).Return(nil)
+		handler.EXPECT().IptablesAppendRule(proto, "nat",
+			"OUTPUT", "-p", "tcp", "--dport", fmt.Sprintf("%d", port),
+			"--destination", dstAddressesToDnat,
+			"-j", "DNAT", "--to-destination", vmIP).Return(nil)
+	}
+}
+
 func protocols(optionalIPProtocol ...iptables.Protocol) []iptables.Protocol {
 	return append(
 		[]iptables.Protocol{iptables.ProtocolIPv4},
@@ -595,7 +708,7 @@ func getReservedPortList(isMigrationOverSockets bool) []int {
 
 func isIstioAware(vmiAnnotations map[string]string) bool {
 	istioAnnotationValue, ok := vmiAnnotations[istio.ISTIO_INJECT_ANNOTATION]
-	return ok && strings.ToLower(istioAnnotationValue) == "true"
+	return ok && strings.ToLower(istioAnnotationValue) == "true" || vmiAnnotations[istio.PROXY_INJECTED_ANNOTATION] == "true"
 }
 
 func getSrcAddressesToSNAT(proto iptables.Protocol) []string {
@@ -21,4 +21,9 @@ package istio
 
 const (
 	ISTIO_INJECT_ANNOTATION = "sidecar.istio.io/inject"
+	// ISTIO_STATUS_ANNOTATION is added by the sidecar injector to every pod it injected the proxy into
+	ISTIO_STATUS_ANNOTATION = "sidecar.istio.io/status"
+	// PROXY_INJECTED_ANNOTATION is set by virt-controller on VMIs whose virt-launcher pod got the proxy
+	// injected without the inject annotation on the VMI, e.g. through the injection label of the namespace
+	PROXY_INJECTED_ANNOTATION = "kubevirt.io/istio-proxy-injected"
 )
@@ -42,3 +42,13 @@ func ReservedPorts() []string {
 		fmt.Sprint(EnvoyPrometheusTelemetryPort),
 	}
 }
+
+// IsReservedPort tells whether the proxy listens on the port, such ports are never forwarded to the VM
+func IsReservedPort(port int32) bool {
+	for _, reserved := range ReservedPorts() {
+		if reserved == fmt.Sprint(port) {
+			return true
+		}
+	}
+	return false
+}
@@ -22,12 +22,30 @@ package istio
 import (
 	"strings"
 
+	k8sv1 "k8s.io/api/core/v1"
+
 	v1 "kubevirt.io/client-go/api/v1"
 )
 
+const ProxyContainerName = "istio-proxy"
+
 func ProxyInjectionEnabled(vmi *v1.VirtualMachineInstance) bool {
-	if val, ok := vmi.GetAnnotations()[ISTIO_INJECT_ANNOTATION]; ok {
-		return strings.ToLower(val) == "true"
+	if val, ok := vmi.GetAnnotations()[ISTIO_INJECT_ANNOTATION]; ok && strings.ToLower(val) == "true" {
+		return true
+	}
+	return vmi.GetAnnotations()[PROXY_INJECTED_ANNOTATION] == "true"
+}
+
+// ProxyInjected tells whether the sidecar injector added the proxy to the pod, no matter if it was
+// requested on the VMI or enabled for the whole namespace
+func ProxyInjected(pod *k8sv1.Pod) bool {
+	if _, ok := pod.GetAnnotations()[ISTIO_STATUS_ANNOTATION]; ok {
+		return true
+	}
+	for _, container := range pod.Spec.Containers {
+		if container.Name == ProxyContainerName {
+			return true
+		}
 	}
 	return false
 }
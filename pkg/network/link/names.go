@@ -27,6 +27,11 @@ func GenerateTapDeviceName(podInterfaceName string) string {
 	return "tap" + podInterfaceName[3:]
 }
 
+// GenerateIfbDeviceName returns the name of the ifb device which limits the traffic the guest sends through the given tap device
+func GenerateIfbDeviceName(tapDeviceName string) string {
+	return "ifb" + tapDeviceName[3:]
+}
+
 func GenerateNewBridgedVmiInterfaceName(originalPodInterfaceName string) string {
 	return fmt.Sprintf("%s-nic", originalPodInterfaceName)
 
@@ -0,0 +1,149 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+// Package macpool hands out the MAC addresses of the range configured in the KubeVirt CR
+// to the interfaces of VirtualMachines without an explicit MAC address.
+package macpool
+
+import (
+	"fmt"
+	"net"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+)
+
+// MacAddressIndex is the informer index of VirtualMachines and VirtualMachineInstances by the MAC addresses of their interfaces
+const MacAddressIndex = "macAddress"
+
+// Pool is an inclusive range of unicast MAC addresses
+type Pool struct {
+	start, end uint64
+}
+
+// New validates the range and returns its pool. The range must not contain multicast addresses.
+func New(rangeStart, rangeEnd string) (*Pool, error) {
+	start, err := parse(rangeStart)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parse(rangeEnd)
+	if err != nil {
+		return nil, err
+	}
+	if start > end {
+		return nil, fmt.Errorf("rangeStart %s is after rangeEnd %s", rangeStart, rangeEnd)
+	}
+	for octet := start >> 40; octet <= end>>40; octet++ {
+		if octet&1 != 0 {
+			return nil, fmt.Errorf("range %s-%s contains multicast addresses", rangeStart, rangeEnd)
+		}
+	}
+	return &Pool{start: start, end: end}, nil
+}
+
+// Normalize returns the MAC address in the lower case, colon separated form of the pool
+func Normalize(mac string) (string, error) {
+	value, err := parse(mac)
+	if err != nil {
+		return "", err
+	}
+	return format(value), nil
+}
+
+// Allocate returns count addresses of the pool which are not used. The lowest free addresses
+// are handed out first, so that the addresses of deleted VirtualMachines are reused.
+func (p *Pool) Allocate(count int, isUsed func(mac string) bool) ([]string, error) {
+	var macs []string
+	for value := p.start; len(macs) < count; value++ {
+		if mac := format(value); !isUsed(mac) {
+			macs = append(macs, mac)
+		}
+		if value == p.end {
+			break
+		}
+	}
+	if len(macs) < count {
+		return nil, fmt.Errorf("the MAC address pool %s-%s is exhausted", format(p.start), format(p.end))
+	}
+	return macs, nil
+}
+
+// VirtualMachineMacAddresses indexes VirtualMachines by the MAC addresses of their template interfaces
+func VirtualMachineMacAddresses(obj interface{}) ([]string, error) {
+	vm := obj.(*virtv1.VirtualMachine)
+	if vm.Spec.Template == nil {
+		return nil, nil
+	}
+	var macs []string
+	for _, iface := range vm.Spec.Template.Spec.Domain.Devices.Interfaces {
+		macs = appendNormalized(macs, iface.MacAddress)
+	}
+	return macs, nil
+}
+
+// VirtualMachineInstanceMacAddresses indexes VirtualMachineInstances by the MAC addresses of their
+// interfaces, including the addresses they run with but which are not part of their spec
+func VirtualMachineInstanceMacAddresses(obj interface{}) ([]string, error) {
+	vmi := obj.(*virtv1.VirtualMachineInstance)
+	var macs []string
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		macs = appendNormalized(macs, iface.MacAddress)
+	}
+	for _, iface := range vmi.Status.Interfaces {
+		macs = appendNormalized(macs, iface.MAC)
+	}
+	return macs, nil
+}
+
+func appendNormalized(macs []string, mac string) []string {
+	normalized, err := Normalize(mac)
+	if err != nil {
+		return macs
+	}
+	for _, m := range macs {
+		if m == normalized {
+			return macs
+		}
+	}
+	return append(macs, normalized)
+}
+
+func parse(mac string) (uint64, error) {
+	hw, err := net.ParseMAC(mac)
+	if err != nil {
+		return 0, err
+	}
+	if len(hw) != 6 {
+		return 0, fmt.Errorf("MAC address %s is not a 48 bit address", mac)
+	}
+	var value uint64
+	for _, b := range hw {
+		value = value<<8 | uint64(b)
+	}
+	return value, nil
+}
+
+func format(value uint64) string {
+	hw := make(net.HardwareAddr, 6)
+	for i := 5; i >= 0; i-- {
+		hw[i] = byte(value)
+		value >>= 8
+	}
+	return hw.String()
+}
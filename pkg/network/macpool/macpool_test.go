@@ -0,0 +1,88 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package macpool
+
+import (
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("MAC address pool", func() {
+
+	isUsed := func(used ...string) func(string) bool {
+		return func(mac string) bool {
+			for _, u := range used {
+				if u == mac {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	It("should hand out the lowest free addresses", func() {
+		pool, err := New("02:00:00:00:00:fe", "02:00:00:00:01:ff")
+		Expect(err).ToNot(HaveOccurred())
+		macs, err := pool.Allocate(2, isUsed("02:00:00:00:00:fe"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(macs).To(Equal([]string{"02:00:00:00:00:ff", "02:00:00:00:01:00"}))
+	})
+
+	It("should fail when the pool is exhausted", func() {
+		pool, err := New("02:00:00:00:00:00", "02:00:00:00:00:01")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = pool.Allocate(2, isUsed("02:00:00:00:00:01"))
+		Expect(err).To(MatchError("the MAC address pool 02:00:00:00:00:00-02:00:00:00:00:01 is exhausted"))
+	})
+
+	It("should hand out the last address of the range", func() {
+		pool, err := New("fe:ff:ff:ff:ff:ff", "fe:ff:ff:ff:ff:ff")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pool.Allocate(1, isUsed())).To(Equal([]string{"fe:ff:ff:ff:ff:ff"}))
+	})
+
+	It("should normalize addresses", func() {
+		Expect(Normalize("02-AB-00-00-00-0A")).To(Equal("02:ab:00:00:00:0a"))
+	})
+
+	It("should index the MAC addresses a VMI runs with", func() {
+		vmi := virtv1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Devices.Interfaces = []virtv1.Interface{{Name: "default", MacAddress: "02-00-00-00-00-0A"}}
+		vmi.Status.Interfaces = []virtv1.VirtualMachineInstanceNetworkInterface{
+			{Name: "default", MAC: "02:00:00:00:00:0a"},
+			{Name: "secondary", MAC: "02:00:00:00:00:0b"},
+		}
+		Expect(VirtualMachineInstanceMacAddresses(vmi)).To(Equal([]string{"02:00:00:00:00:0a", "02:00:00:00:00:0b"}))
+	})
+
+	table.DescribeTable("should reject", func(start, end string) {
+		_, err := New(start, end)
+		Expect(err).To(HaveOccurred())
+	},
+		table.Entry("an invalid address", "02:00:00:00:00", "02:00:00:00:00:ff"),
+		table.Entry("an address which is not 48 bit long", "02:00:00:00:00:00:00:00", "02:00:00:00:00:ff"),
+		table.Entry("an inverted range", "02:00:00:00:00:ff", "02:00:00:00:00:00"),
+		table.Entry("a multicast range", "01:00:00:00:00:00", "01:00:00:00:00:ff"),
+		table.Entry("a range crossing multicast addresses", "02:ff:ff:ff:ff:00", "04:00:00:00:00:00"),
+	)
+})
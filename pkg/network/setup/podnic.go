@@ -167,8 +167,8 @@ func (l *podNIC) sortIPsBasedOnPrimaryIP(ipv4, ipv6 string) ([]string, error) {
 
 func (l *podNIC) PlugPhase1() error {
 
-	// There is nothing to plug for SR-IOV devices
-	if l.vmiSpecIface.SRIOV != nil {
+	// There is nothing to plug for SR-IOV and vhost-user devices
+	if l.vmiSpecIface.SRIOV != nil || l.vmiSpecIface.VhostUser != nil {
 		return nil
 	}
 
@@ -235,8 +235,8 @@ func (l *podNIC) PlugPhase1() error {
 func (l *podNIC) PlugPhase2(domain *api.Domain) error {
 	precond.MustNotBeNil(domain)
 
-	// There is nothing to plug for SR-IOV devices
-	if l.vmiSpecIface.SRIOV != nil {
+	// There is nothing to plug for SR-IOV and vhost-user devices
+	if l.vmiSpecIface.SRIOV != nil || l.vmiSpecIface.VhostUser != nil {
 		return nil
 	}
 
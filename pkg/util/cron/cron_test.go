@@ -0,0 +1,76 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package cron
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cron", func() {
+	// a Wednesday
+	now := time.Date(2021, time.September, 15, 10, 30, 0, 0, time.UTC)
+
+	table.DescribeTable("should find the next activation", func(spec string, expected time.Time) {
+		schedule, err := Parse(spec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(schedule.Next(now)).To(Equal(expected))
+	},
+		table.Entry("every minute", "* * * * *", time.Date(2021, time.September, 15, 10, 31, 0, 0, time.UTC)),
+		table.Entry("later the same day", "0 18 * * *", time.Date(2021, time.September, 15, 18, 0, 0, 0, time.UTC)),
+		table.Entry("on the next day", "0 8 * * *", time.Date(2021, time.September, 16, 8, 0, 0, 0, time.UTC)),
+		table.Entry("with a step", "*/20 * * * *", time.Date(2021, time.September, 15, 10, 40, 0, 0, time.UTC)),
+		table.Entry("on the next week day", "0 8 * * 1-5", time.Date(2021, time.September, 16, 8, 0, 0, 0, time.UTC)),
+		table.Entry("on sunday written as 7", "0 8 * * 7", time.Date(2021, time.September, 19, 8, 0, 0, 0, time.UTC)),
+		table.Entry("in the next month", "0 0 1 * *", time.Date(2021, time.October, 1, 0, 0, 0, 0, time.UTC)),
+		table.Entry("in the next year", "0 0 1 1 *", time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)),
+		table.Entry("on the day of month or the day of week", "0 0 20 * 5", time.Date(2021, time.September, 17, 0, 0, 0, 0, time.UTC)),
+		table.Entry("never", "0 0 30 2 *", time.Time{}),
+	)
+
+	It("should find the activation in the location of the time", func() {
+		location, err := time.LoadLocation("America/New_York")
+		Expect(err).ToNot(HaveOccurred())
+		schedule, err := Parse("0 8 * * *")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(schedule.Next(now.In(location)).UTC()).To(Equal(time.Date(2021, time.September, 15, 12, 0, 0, 0, time.UTC)))
+	})
+
+	It("should find the last activation", func() {
+		schedule, err := Parse("0 * * * *")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(schedule.Last(now.Add(-3*time.Hour), now)).To(Equal(time.Date(2021, time.September, 15, 10, 0, 0, 0, time.UTC)))
+		Expect(schedule.Last(now.Add(-20*time.Minute), now)).To(BeZero())
+	})
+
+	table.DescribeTable("should reject", func(spec string) {
+		_, err := Parse(spec)
+		Expect(err).To(HaveOccurred())
+	},
+		table.Entry("too few fields", "* * * *"),
+		table.Entry("an out of range value", "60 * * * *"),
+		table.Entry("an inverted range", "0 18-8 * * *"),
+		table.Entry("a zero step", "*/0 * * * *"),
+		table.Entry("a name", "0 8 * * mon"),
+	)
+})
@@ -0,0 +1,108 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package guestdefaults
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// The guest visible fields which are tracked in the AppliedGuestDefaultsAnnotation
+const (
+	MachineType      = "machineType"
+	CPUModel         = "cpuModel"
+	NetworkInterface = "networkInterface"
+)
+
+// Defaults holds the current cluster defaults of the guest visible fields
+type Defaults struct {
+	MachineType      string
+	CPUModel         string
+	NetworkInterface string
+}
+
+// MarkApplied records in the AppliedGuestDefaultsAnnotation that the field was set from the cluster defaults
+func MarkApplied(meta *metav1.ObjectMeta, field string) {
+	applied := Applied(meta)
+	for _, f := range applied {
+		if f == field {
+			return
+		}
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[v1.AppliedGuestDefaultsAnnotation] = strings.Join(append(applied, field), ",")
+}
+
+// Applied returns the fields which were set from the cluster defaults
+func Applied(meta *metav1.ObjectMeta) []string {
+	value, ok := meta.Annotations[v1.AppliedGuestDefaultsAnnotation]
+	if !ok || value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// Outdated returns the fields of the VMI which were set from cluster defaults which have changed since
+func Outdated(vmi *v1.VirtualMachineInstance, defaults Defaults) []string {
+	var outdated []string
+	for _, field := range Applied(&vmi.ObjectMeta) {
+		switch field {
+		case MachineType:
+			machineType := ""
+			if vmi.Spec.Domain.Machine != nil {
+				machineType = vmi.Spec.Domain.Machine.Type
+			}
+			if machineType != defaults.MachineType {
+				outdated = append(outdated, field)
+			}
+		case CPUModel:
+			cpuModel := ""
+			if vmi.Spec.Domain.CPU != nil {
+				cpuModel = vmi.Spec.Domain.CPU.Model
+			}
+			if cpuModel != defaults.CPUModel {
+				outdated = append(outdated, field)
+			}
+		case NetworkInterface:
+			interfaces := vmi.Spec.Domain.Devices.Interfaces
+			if len(interfaces) == 1 && bindingName(interfaces[0]) != defaults.NetworkInterface {
+				outdated = append(outdated, field)
+			}
+		}
+	}
+	return outdated
+}
+
+func bindingName(iface v1.Interface) string {
+	switch {
+	case iface.Bridge != nil:
+		return string(v1.BridgeInterface)
+	case iface.Masquerade != nil:
+		return string(v1.MasqueradeInterface)
+	case iface.Slirp != nil:
+		return string(v1.SlirpInterface)
+	}
+	return ""
+}
@@ -59,6 +59,5 @@ func MigrationFailed(vmi *v1.VirtualMachineInstance) bool {
 }
 
 func MigrationNeedsProtection(vmi *v1.VirtualMachineInstance) bool {
-	return vmi.Spec.EvictionStrategy != nil &&
-		*vmi.Spec.EvictionStrategy == v1.EvictionStrategyLiveMigrate
+	return vmi.IsEvictable() || (vmi.IsEvictableIfPossible() && vmi.IsMigratable())
 }
@@ -27,6 +27,9 @@ import (
 	"golang.org/x/net/context"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/util"
@@ -80,3 +83,16 @@ func CreateSocket(socketPath string) (net.Listener, error) {
 	}
 	return socket, nil
 }
+
+// RegisterHealthAndReflectionServers registers the standard gRPC health checking and
+// reflection services, so that the server can be probed by tools like grpc_health_probe
+// or grpcurl. All services which are already registered on the server are reported as serving.
+func RegisterHealthAndReflectionServers(server *grpc.Server) *health.Server {
+	healthServer := health.NewServer()
+	for service := range server.GetServiceInfo() {
+		healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+	return healthServer
+}
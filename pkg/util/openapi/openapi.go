@@ -17,6 +17,7 @@ import (
 	"k8s.io/kube-openapi/pkg/common"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	poolv1 "kubevirt.io/client-go/apis/pool/v1alpha1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 )
 
@@ -116,6 +117,12 @@ func createConfig() *common.Config {
 					m[k] = v
 				}
 			}
+			m3 := poolv1.GetOpenAPIDefinitions(ref)
+			for k, v := range m3 {
+				if _, ok := m[k]; !ok {
+					m[k] = v
+				}
+			}
 			return m
 		},
 
@@ -0,0 +1,61 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package qemulog
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// LogFile returns the location of the QEMU log of a domain inside the virt-launcher pod, which virtlogd writes
+func LogFile(domainName string, nonRoot bool) string {
+	logFile := fmt.Sprintf("%s.log", domainName)
+	if nonRoot {
+		return filepath.Join("/var", "run", "libvirt", "qemu", "log", logFile)
+	}
+	return filepath.Join("/var", "log", "libvirt", "qemu", logFile)
+}
+
+// TailFile returns the last lines of a file, oldest first
+func TailFile(path string, lines int) ([]string, error) {
+	// #nosec No risk for path injection. The path has a static basedir
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Tail(file, lines)
+}
+
+// Tail returns the last lines of a reader, oldest first
+func Tail(reader io.Reader, lines int) ([]string, error) {
+	var tail []string
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 1024), 512*1024)
+	for scanner.Scan() {
+		tail = append(tail, scanner.Text())
+		if len(tail) > lines {
+			tail = tail[1:]
+		}
+	}
+	return tail, scanner.Err()
+}
@@ -0,0 +1,11 @@
+package qemulog
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestQEMULog(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
@@ -0,0 +1,77 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package qemulog
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("QEMU log", func() {
+	var tmpDir string
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "logtail")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	writeLines := func(count int) string {
+		lines := []string{}
+		for i := 0; i < count; i++ {
+			lines = append(lines, fmt.Sprintf("line %d", i))
+		}
+		path := filepath.Join(tmpDir, "qemu.log")
+		Expect(ioutil.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644)).To(Succeed())
+		return path
+	}
+
+	It("should return all lines of a short file", func() {
+		Expect(TailFile(writeLines(2), 3)).To(Equal([]string{"line 0", "line 1"}))
+	})
+
+	It("should return the last lines, oldest first", func() {
+		Expect(TailFile(writeLines(7), 3)).To(Equal([]string{"line 4", "line 5", "line 6"}))
+	})
+
+	It("should fail if the file does not exist", func() {
+		_, err := TailFile(filepath.Join(tmpDir, "missing.log"), 3)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("should return the last lines of a reader", func() {
+		Expect(Tail(strings.NewReader("line 0\nline 1\nline 2"), 2)).To(Equal([]string{"line 1", "line 2"}))
+	})
+
+	It("should place the QEMU log of non-root VMIs in the run directory", func() {
+		Expect(LogFile("default_testvmi", false)).To(Equal("/var/log/libvirt/qemu/default_testvmi.log"))
+		Expect(LogFile("default_testvmi", true)).To(Equal("/var/run/libvirt/qemu/log/default_testvmi.log"))
+	})
+})
@@ -19,8 +19,91 @@
 
 package types
 
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
 type PatchOperation struct {
 	Op    string      `json:"op"`
 	Path  string      `json:"path"`
 	Value interface{} `json:"value,omitempty"`
 }
+
+// GeneratePatchOperations returns the JSON patch operations which turn before into after. Both are
+// compared in their serialized form, but the operations are computed against raw, the JSON which
+// before was decoded from. Fields which were not changed are therefore never touched, even if the
+// serialization normalizes them, like resource quantities, and the patch only covers the fields
+// which were actually set. Lists which differ are replaced as a whole.
+func GeneratePatchOperations(raw []byte, before, after interface{}) ([]PatchOperation, error) {
+	var rawValue, beforeValue, afterValue interface{}
+	if err := json.Unmarshal(raw, &rawValue); err != nil {
+		return nil, err
+	}
+	if err := roundTrip(before, &beforeValue); err != nil {
+		return nil, err
+	}
+	if err := roundTrip(after, &afterValue); err != nil {
+		return nil, err
+	}
+	return diff("", rawValue, beforeValue, afterValue), nil
+}
+
+func roundTrip(obj interface{}, value *interface{}) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, value)
+}
+
+func diff(path string, raw, before, after interface{}) []PatchOperation {
+	if reflect.DeepEqual(before, after) {
+		return nil
+	}
+
+	rawMap, rawIsMap := raw.(map[string]interface{})
+	beforeMap, beforeIsMap := before.(map[string]interface{})
+	afterMap, afterIsMap := after.(map[string]interface{})
+	if !rawIsMap || !beforeIsMap || !afterIsMap {
+		return []PatchOperation{{Op: "replace", Path: path, Value: after}}
+	}
+
+	var patch []PatchOperation
+	for _, key := range sortedKeys(afterMap) {
+		childPath := path + "/" + escapePathSegment(key)
+		rawChild, inRaw := rawMap[key]
+		if !inRaw {
+			if beforeChild, inBefore := beforeMap[key]; !inBefore || !reflect.DeepEqual(beforeChild, afterMap[key]) {
+				patch = append(patch, PatchOperation{Op: "add", Path: childPath, Value: afterMap[key]})
+			}
+			continue
+		}
+		patch = append(patch, diff(childPath, rawChild, beforeMap[key], afterMap[key])...)
+	}
+	for _, key := range sortedKeys(rawMap) {
+		if _, inAfter := afterMap[key]; inAfter {
+			continue
+		}
+		if _, inBefore := beforeMap[key]; inBefore {
+			patch = append(patch, PatchOperation{Op: "remove", Path: path + "/" + escapePathSegment(key)})
+		}
+	}
+	return patch
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// escapePathSegment escapes a key for a JSON pointer, see RFC 6901
+func escapePathSegment(segment string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(segment, "~", "~0"), "/", "~1")
+}
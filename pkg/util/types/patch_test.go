@@ -0,0 +1,114 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package types
+
+import (
+	"encoding/json"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Patch operations", func() {
+
+	const rawVM = `{
+  "apiVersion": "kubevirt.io/v1",
+  "kind": "VirtualMachine",
+  "metadata": {"name": "testvm", "labels": {"app": "test"}},
+  "spec": {
+    "running": false,
+    "template": {
+      "spec": {
+        "domain": {
+          "devices": {},
+          "resources": {"requests": {"memory": "1024Mi"}}
+        }
+      }
+    }
+  }
+}`
+
+	decode := func(raw []byte) *virtv1.VirtualMachine {
+		vm := &virtv1.VirtualMachine{}
+		Expect(json.Unmarshal(raw, vm)).To(Succeed())
+		return vm
+	}
+
+	apply := func(raw []byte, patch []PatchOperation) []byte {
+		patchBytes, err := json.Marshal(patch)
+		Expect(err).ToNot(HaveOccurred())
+		decoded, err := jsonpatch.DecodePatch(patchBytes)
+		Expect(err).ToNot(HaveOccurred())
+		patched, err := decoded.Apply(raw)
+		Expect(err).ToNot(HaveOccurred())
+		return patched
+	}
+
+	It("should not touch normalized fields of an unchanged object", func() {
+		vm := decode([]byte(rawVM))
+		patch, err := GeneratePatchOperations([]byte(rawVM), vm, vm.DeepCopy())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(patch).To(BeEmpty())
+	})
+
+	It("should only add the fields which were set", func() {
+		before := decode([]byte(rawVM))
+		after := before.DeepCopy()
+		after.Spec.Template.Spec.Domain.Machine = &virtv1.Machine{Type: "q35"}
+		after.Spec.Template.ObjectMeta.Annotations = map[string]string{"kubevirt.io/test": "true"}
+
+		patch, err := GeneratePatchOperations([]byte(rawVM), before, after)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(patch).To(ConsistOf(
+			PatchOperation{Op: "add", Path: "/spec/template/metadata", Value: map[string]interface{}{
+				"annotations":       map[string]interface{}{"kubevirt.io/test": "true"},
+				"creationTimestamp": nil,
+			}},
+			PatchOperation{Op: "add", Path: "/spec/template/spec/domain/machine", Value: map[string]interface{}{"type": "q35"}},
+		))
+
+		patched := apply([]byte(rawVM), patch)
+		Expect(string(patched)).To(ContainSubstring(`"1024Mi"`))
+		Expect(decode(patched).Spec).To(Equal(after.Spec))
+	})
+
+	It("should escape keys and replace and remove changed fields", func() {
+		before := decode([]byte(rawVM))
+		after := before.DeepCopy()
+		after.Labels = map[string]string{"kubevirt.io/test": "true"}
+		running := true
+		after.Spec.Running = &running
+
+		patch, err := GeneratePatchOperations([]byte(rawVM), before, after)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(patch).To(ConsistOf(
+			PatchOperation{Op: "add", Path: "/metadata/labels/kubevirt.io~1test", Value: "true"},
+			PatchOperation{Op: "remove", Path: "/metadata/labels/app"},
+			PatchOperation{Op: "replace", Path: "/spec/running", Value: true},
+		))
+
+		patched := decode(apply([]byte(rawVM), patch))
+		Expect(patched.Labels).To(Equal(after.Labels))
+		Expect(*patched.Spec.Running).To(BeTrue())
+	})
+})
@@ -21,9 +21,11 @@ package types
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/client-go/tools/cache"
 
 	virtv1 "kubevirt.io/client-go/api/v1"
@@ -57,6 +59,45 @@ func HasSharedAccessMode(accessModes []k8sv1.PersistentVolumeAccessMode) bool {
 	return false
 }
 
+// PVCNotSharedError is returned for a volume whose PVC can't be accessed by the source
+// and the target of a live migration at the same time
+type PVCNotSharedError struct {
+	VolumeName  string
+	ClaimName   string
+	AccessModes []k8sv1.PersistentVolumeAccessMode
+}
+
+func (e *PVCNotSharedError) Error() string {
+	return fmt.Sprintf("PVC %s of volume %s is not shared, live migration requires that all PVCs must be shared (using ReadWriteMany access mode), its access modes are %v", e.ClaimName, e.VolumeName, e.AccessModes)
+}
+
+// CheckPVCIsShared returns a PVCNotSharedError if the access modes of the PVC of a volume don't allow live migration
+func CheckPVCIsShared(volumeName string, claimName string, accessModes []k8sv1.PersistentVolumeAccessMode) error {
+	if HasSharedAccessMode(accessModes) {
+		return nil
+	}
+	return &PVCNotSharedError{VolumeName: volumeName, ClaimName: claimName, AccessModes: accessModes}
+}
+
+// GetDiskCapacity returns the capacity a disk image can use on a PVC, which is the capacity of the PVC
+// without its filesystem overhead, aligned down to MiB. It returns nil if the capacity is unknown.
+func GetDiskCapacity(pvcInfo *virtv1.PersistentVolumeClaimInfo) *resource.Quantity {
+	capacity, ok := pvcInfo.Capacity[k8sv1.ResourceStorage]
+	if !ok {
+		return nil
+	}
+	if pvcInfo.FilesystemOverhead == "" {
+		return &capacity
+	}
+	overhead, err := strconv.ParseFloat(pvcInfo.FilesystemOverhead, 64)
+	if err != nil || overhead < 0 || overhead >= 1 {
+		return &capacity
+	}
+	const alignment = 1024 * 1024
+	size := int64(float64(capacity.Value())*(1-overhead)) / alignment * alignment
+	return resource.NewQuantity(size, capacity.Format)
+}
+
 func IsPreallocated(annotations map[string]string) bool {
 	for a, value := range annotations {
 		if strings.Contains(a, "/storage.preallocation") && value == "true" {
@@ -75,6 +116,17 @@ func PVCNameFromVirtVolume(volume *virtv1.Volume) string {
 		return volume.DataVolume.Name
 	} else if volume.PersistentVolumeClaim != nil {
 		return volume.PersistentVolumeClaim.ClaimName
+	} else if volume.MemoryDump != nil {
+		return volume.MemoryDump.ClaimName
+	}
+	return ""
+}
+
+// ContainerDiskOverlayClaimName returns the name of the PVC the overlay of a containerDisk volume is stored on,
+// or an empty string if the volume has none.
+func ContainerDiskOverlayClaimName(volume *virtv1.Volume) string {
+	if volume.ContainerDisk != nil && volume.ContainerDisk.Overlay != nil {
+		return volume.ContainerDisk.Overlay.ClaimName
 	}
 	return ""
 }
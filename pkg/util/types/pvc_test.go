@@ -21,10 +21,14 @@ package types
 
 import (
 	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	kubev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
 )
 
 var _ = Describe("PVC utils test", func() {
@@ -98,4 +102,20 @@ var _ = Describe("PVC utils test", func() {
 		})
 	})
 
+	table.DescribeTable("GetDiskCapacity should", func(capacity, overhead string, expected string) {
+		pvcInfo := &virtv1.PersistentVolumeClaimInfo{
+			Capacity:           kubev1.ResourceList{kubev1.ResourceStorage: resource.MustParse(capacity)},
+			FilesystemOverhead: overhead,
+		}
+		expectedCapacity := resource.MustParse(expected)
+		Expect(GetDiskCapacity(pvcInfo).Value()).To(Equal(expectedCapacity.Value()))
+	},
+		table.Entry("return the capacity without an overhead", "10Gi", "", "10Gi"),
+		table.Entry("subtract the overhead and align to MiB", "10Gi", "0.055", "9676Mi"),
+		table.Entry("ignore an invalid overhead", "10Gi", "5%", "10Gi"),
+	)
+
+	It("GetDiskCapacity should return nil if the capacity is unknown", func() {
+		Expect(GetDiskCapacity(&virtv1.PersistentVolumeClaimInfo{FilesystemOverhead: "0.055"})).To(BeNil())
+	})
 })
@@ -0,0 +1,119 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1beta1"
+)
+
+// StorageCapabilitiesConfigMapName is the ConfigMap in the KubeVirt namespace in which virt-controller
+// publishes the StorageClassCapabilities of every StorageClass, keyed by the name of the StorageClass
+const StorageCapabilitiesConfigMapName = "kubevirt-storage-capabilities"
+
+// CloneStrategy is the way PersistentVolumeClaims of a StorageClass are cloned
+type CloneStrategy string
+
+const (
+	// CloneStrategySnapshot clones PersistentVolumeClaims through a VolumeSnapshot of the source
+	CloneStrategySnapshot CloneStrategy = "snapshot"
+	// CloneStrategyCopy copies the data of the source PersistentVolumeClaim with a pod
+	CloneStrategyCopy CloneStrategy = "copy"
+)
+
+// StorageClassCapabilities are the capabilities of a StorageClass which matter to VirtualMachines
+type StorageClassCapabilities struct {
+	// Provisioner is the provisioner of the StorageClass
+	Provisioner string `json:"provisioner"`
+	// ClaimPropertySets are the access modes and volume modes the StorageClass can provision.
+	// They are only known if CDI has detected them in the StorageProfile of the StorageClass.
+	ClaimPropertySets []cdiv1.ClaimPropertySet `json:"claimPropertySets,omitempty"`
+	// LiveMigration is true if the StorageClass can provision ReadWriteMany Block volumes, which
+	// VMs on block storage need to be live migrated. It is only known with the ClaimPropertySets.
+	LiveMigration *bool `json:"liveMigration,omitempty"`
+	// Snapshot is true if a VolumeSnapshotClass exists for the provisioner of the StorageClass
+	Snapshot bool `json:"snapshot"`
+	// CloneStrategy is the way CDI clones PersistentVolumeClaims of the StorageClass.
+	// It is only known if CDI reports it in the StorageProfile of the StorageClass.
+	CloneStrategy CloneStrategy `json:"cloneStrategy,omitempty"`
+}
+
+// SupportsClaimProperties returns true if the StorageClass can provision a PersistentVolumeClaim with all
+// of the given access modes in the given volume mode, or if its claim properties are not known
+func (c *StorageClassCapabilities) SupportsClaimProperties(accessModes []k8sv1.PersistentVolumeAccessMode, volumeMode k8sv1.PersistentVolumeMode) bool {
+	if len(c.ClaimPropertySets) == 0 {
+		return true
+	}
+	for _, set := range c.ClaimPropertySets {
+		setVolumeMode := k8sv1.PersistentVolumeFilesystem
+		if set.VolumeMode != nil {
+			setVolumeMode = *set.VolumeMode
+		}
+		if setVolumeMode == volumeMode && containsAccessModes(set.AccessModes, accessModes) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAccessModes(supported []k8sv1.PersistentVolumeAccessMode, requested []k8sv1.PersistentVolumeAccessMode) bool {
+	for _, r := range requested {
+		found := false
+		for _, s := range supported {
+			if s == r {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// GetStorageClassCapabilitiesFromStore returns the capabilities published for the given StorageClass,
+// or false if none are published for it. The store only holds the ConfigMap of the KubeVirt namespace.
+func GetStorageClassCapabilitiesFromStore(store cache.Store, storageClass string) (*StorageClassCapabilities, bool, error) {
+	for _, obj := range store.List() {
+		configMap, ok := obj.(*k8sv1.ConfigMap)
+		if !ok {
+			return nil, false, fmt.Errorf("this is not a ConfigMap! %v", obj)
+		}
+		if configMap.Name != StorageCapabilitiesConfigMapName {
+			continue
+		}
+		data, exists := configMap.Data[storageClass]
+		if !exists {
+			return nil, false, nil
+		}
+		capabilities := &StorageClassCapabilities{}
+		if err := json.Unmarshal([]byte(data), capabilities); err != nil {
+			return nil, false, fmt.Errorf("failed to parse the capabilities of storage class %s: %v", storageClass, err)
+		}
+		return capabilities, true, nil
+	}
+	return nil, false, nil
+}
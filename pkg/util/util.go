@@ -3,8 +3,11 @@ package util
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
+	k8sv1 "k8s.io/api/core/v1"
+
 	v1 "kubevirt.io/client-go/api/v1"
 )
 
@@ -14,6 +17,7 @@ const VirtShareDir = "/var/run/kubevirt"
 const VirtPrivateDir = "/var/run/kubevirt-private"
 const VirtLibDir = "/var/lib/kubevirt"
 const KubeletPodsDir = "/var/lib/kubelet/pods"
+const VhostUserSocketDir = VirtShareDir + "/vhostuser"
 const HostRootMount = "/proc/1/root/"
 const CPUManagerOS3Path = HostRootMount + "var/lib/origin/openshift.local.volumes/cpu_manager_state"
 const CPUManagerPath = HostRootMount + "var/lib/kubelet/cpu_manager_state"
@@ -36,6 +40,45 @@ func IsSRIOVVmi(vmi *v1.VirtualMachineInstance) bool {
 	return false
 }
 
+// HasLUNReservation checks if a LUN of the VMI passes SCSI persistent reservations to its device
+func HasLUNReservation(vmi *v1.VirtualMachineInstance) bool {
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.LUN != nil && disk.LUN.Reservation {
+			return true
+		}
+	}
+	return false
+}
+
+// Check if a VMI spec requests a vhost-user interface
+func IsVhostUserVmi(vmi *v1.VirtualMachineInstance) bool {
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.VhostUser != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// VhostUserSocketPath returns the path of the vhost-user socket of the given interface
+func VhostUserSocketPath(ifaceName string) string {
+	return filepath.Join(VhostUserSocketDir, ifaceName+".sock")
+}
+
+// IsSerialConsoleLogEnabled checks if the output of the auto-attached serial console of the VMI is logged
+func IsSerialConsoleLogEnabled(vmi *v1.VirtualMachineInstance) bool {
+	devices := vmi.Spec.Domain.Devices
+	if devices.AutoattachSerialConsole != nil && !*devices.AutoattachSerialConsole {
+		return false
+	}
+	return devices.LogSerialConsole != nil && *devices.LogSerialConsole
+}
+
+// SerialConsoleLogPath returns the path of the serial console log of the VMI inside the virt-launcher pod
+func SerialConsoleLogPath(vmi *v1.VirtualMachineInstance) string {
+	return filepath.Join(VirtPrivateDir, string(vmi.UID), "virt-serial0-log")
+}
+
 // Check if a VMI spec requests GPU
 func IsGPUVMI(vmi *v1.VirtualMachineInstance) bool {
 	if vmi.Spec.Domain.Devices.GPUs != nil && len(vmi.Spec.Domain.Devices.GPUs) != 0 {
@@ -73,10 +116,36 @@ func IsVFIOVMI(vmi *v1.VirtualMachineInstance) bool {
 	return false
 }
 
+// HasBurstableMemory checks whether the memory of a VMI may exceed its memory request and can be
+// swapped out. VFIO devices and hugepages lock the guest memory.
+func HasBurstableMemory(vmi *v1.VirtualMachineInstance) bool {
+	if IsVFIOVMI(vmi) {
+		return false
+	}
+	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.Hugepages != nil {
+		return false
+	}
+	resources := vmi.Spec.Domain.Resources
+	request, hasRequest := resources.Requests[k8sv1.ResourceMemory]
+	limit, hasLimit := resources.Limits[k8sv1.ResourceMemory]
+	return hasRequest && (!hasLimit || limit.Cmp(request) > 0)
+}
+
+// IsHypervPassthrough checks whether a VMI enables all the Hyper-V enlightenments supported by the host
+func IsHypervPassthrough(vmi *v1.VirtualMachineInstance) bool {
+	features := vmi.Spec.Domain.Features
+	return features != nil && features.HypervPassthrough != nil &&
+		(features.HypervPassthrough.Enabled == nil || *features.HypervPassthrough.Enabled)
+}
+
 // WantVirtioNetDevice checks whether a VMI references at least one "virtio" network interface.
 // Note that the reference can be explicit or implicit (unspecified nic models defaults to "virtio").
 func WantVirtioNetDevice(vmi *v1.VirtualMachineInstance) bool {
 	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		// vhost-user interfaces are served by the userspace dataplane
+		if iface.VhostUser != nil {
+			continue
+		}
 		if iface.Model == "" || iface.Model == "virtio" {
 			return true
 		}
@@ -131,3 +200,8 @@ func HasKernelBootContainerImage(vmi *v1.VirtualMachineInstance) bool {
 func HasHugePages(vmi *v1.VirtualMachineInstance) bool {
 	return vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.Hugepages != nil
 }
+
+// IsAutoAttachVSOCK checks if the VMI requests a VSOCK device
+func IsAutoAttachVSOCK(vmi *v1.VirtualMachineInstance) bool {
+	return vmi.Spec.Domain.Devices.AutoattachVSOCK != nil && *vmi.Spec.Domain.Devices.AutoattachVSOCK
+}
@@ -38,6 +38,7 @@ import (
 	flag "github.com/spf13/pflag"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/tools/cache"
 	certificate2 "k8s.io/client-go/util/certificate"
 	"k8s.io/client-go/util/flowcontrol"
@@ -61,8 +62,11 @@ import (
 	webhooksutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/rest"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	conversion_webhook "kubevirt.io/kubevirt/pkg/virt-api/webhooks/conversion-webhook"
 	mutating_webhook "kubevirt.io/kubevirt/pkg/virt-api/webhooks/mutating-webhook"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks/mutating-webhook/mutators"
 	validating_webhook "kubevirt.io/kubevirt/pkg/virt-api/webhooks/validating-webhook"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks/validating-webhook/admitters"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/components"
 	virtoperatorutils "kubevirt.io/kubevirt/pkg/virt-operator/util"
@@ -83,9 +87,14 @@ const (
 	defaultHandlerCertFilePath = "/etc/virt-handler/clientcertificates/tls.crt"
 	defaultHandlerKeyFilePath  = "/etc/virt-handler/clientcertificates/tls.key"
 
-	httpStatusNotFoundMessage     = "Not Found"
-	httpStatusBadRequestMessage   = "Bad Request"
-	httpStatusInternalServerError = "Internal Server Error"
+	httpStatusNotFoundMessage            = "Not Found"
+	httpStatusBadRequestMessage          = "Bad Request"
+	httpStatusInternalServerError        = "Internal Server Error"
+	httpStatusUnprocessableEntityMessage = "Unprocessable Entity"
+	httpStatusAcceptedMessage            = "Accepted"
+	httpStatusConflictMessage            = "Conflict"
+	httpStatusSwitchingProtocolsMessage  = "Switching Protocols"
+	httpStatusServiceUnavailableMessage  = "Service Unavailable"
 )
 
 type VirtApi interface {
@@ -126,8 +135,12 @@ type virtAPIApp struct {
 
 	// indicates if controllers were started with or without CDI/DataSource support
 	hasCDIDataSource bool
+	// the informers of the webhooks, which the expand-spec subresource shares
+	webhookInformers *webhooks.Informers
 	// the channel used to trigger re-initialization.
 	reInitChan chan string
+	// auditFilter writes the audit log of the subresources, it runs before the authorization
+	auditFilter restful.FilterFunction
 }
 
 var (
@@ -209,6 +222,20 @@ func (app *virtAPIApp) composeSubresources() {
 
 	var subwss []*restful.WebService
 
+	expandSpec := func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+		return mutators.ExpandVirtualMachineSpec(vm, app.clusterConfig, app.webhookInformers)
+	}
+	expandVMISpec := func(vmi *v1.VirtualMachineInstance) (*v1.VirtualMachineInstance, error) {
+		return mutators.ExpandVirtualMachineInstanceSpec(vmi, app.clusterConfig, app.webhookInformers)
+	}
+	validateVMISpec := func(vmi *v1.VirtualMachineInstance) []metav1.StatusCause {
+		return admitters.ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("spec"), &vmi.Spec, app.clusterConfig)
+	}
+
+	// one app audits the subresources of all versions, so that they share the audit log
+	auditApp := rest.NewSubresourceAPIApp(app.virtCli, app.consoleServerPort, app.handlerTLSConfiguration, app.clusterConfig, app.authorizor, expandSpec, expandVMISpec, validateVMISpec)
+	app.auditFilter = auditApp.AuditFilter
+
 	for _, version := range v1.SubresourceGroupVersions {
 		subresourcesvmGVR := schema.GroupVersionResource{Group: version.Group, Version: version.Version, Resource: "virtualmachines"}
 		subresourcesvmiGVR := schema.GroupVersionResource{Group: version.Group, Version: version.Version, Resource: "virtualmachineinstances"}
@@ -217,7 +244,7 @@ func (app *virtAPIApp) composeSubresources() {
 		subws.Doc(fmt.Sprintf("KubeVirt \"%s\" Subresource API.", version.Version))
 		subws.Path(rest.GroupVersionBasePath(version))
 
-		subresourceApp := rest.NewSubresourceAPIApp(app.virtCli, app.consoleServerPort, app.handlerTLSConfiguration, app.clusterConfig)
+		subresourceApp := rest.NewSubresourceAPIApp(app.virtCli, app.consoleServerPort, app.handlerTLSConfiguration, app.clusterConfig, app.authorizor, expandSpec, expandVMISpec, validateVMISpec)
 
 		restartRouteBuilder := subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("restart")).
 			To(subresourceApp.RestartVMRequestHandler).
@@ -225,9 +252,10 @@ func (app *virtAPIApp) composeSubresources() {
 			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
 			Operation(version.Version+"Restart").
 			Doc("Restart a VirtualMachine object.").
-			Returns(http.StatusOK, "OK", "").
-			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
-			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, "")
+			Returns(http.StatusAccepted, httpStatusAcceptedMessage, "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, metav1.Status{}).
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, metav1.Status{}).
+			Returns(http.StatusConflict, httpStatusConflictMessage, metav1.Status{})
 		restartRouteBuilder.ParameterNamed("body").Required(false)
 		subws.Route(restartRouteBuilder)
 
@@ -236,18 +264,33 @@ func (app *virtAPIApp) composeSubresources() {
 			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
 			Operation(version.Version+"Migrate").
 			Doc("Migrate a running VirtualMachine to another node.").
-			Returns(http.StatusOK, "OK", "").
-			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
-			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+			Returns(http.StatusAccepted, httpStatusAcceptedMessage, "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, metav1.Status{}).
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, metav1.Status{}).
+			Returns(http.StatusConflict, httpStatusConflictMessage, metav1.Status{}))
 
-		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("start")).
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("migratecancel")).
+			To(subresourceApp.MigrateCancelVMRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"MigrateCancel").
+			Doc("Abort the migrations of a VirtualMachine which are in progress.").
+			Returns(http.StatusAccepted, httpStatusAcceptedMessage, "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, metav1.Status{}).
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, metav1.Status{}).
+			Returns(http.StatusConflict, httpStatusConflictMessage, metav1.Status{}))
+
+		startRouteBuilder := subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("start")).
 			To(subresourceApp.StartVMRequestHandler).
+			Reads(v1.StartOptions{}).
 			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
 			Operation(version.Version+"Start").
 			Doc("Start a VirtualMachine object.").
-			Returns(http.StatusOK, "OK", "").
-			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
-			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+			Returns(http.StatusAccepted, httpStatusAcceptedMessage, "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, metav1.Status{}).
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, metav1.Status{}).
+			Returns(http.StatusConflict, httpStatusConflictMessage, metav1.Status{})
+		startRouteBuilder.ParameterNamed("body").Required(false)
+		subws.Route(startRouteBuilder)
 
 		stopRouteBuilder := subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("stop")).
 			To(subresourceApp.StopVMRequestHandler).
@@ -255,12 +298,52 @@ func (app *virtAPIApp) composeSubresources() {
 			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
 			Operation(version.Version+"Stop").
 			Doc("Stop a VirtualMachine object.").
-			Returns(http.StatusOK, "OK", "").
-			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
-			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, "")
+			Returns(http.StatusAccepted, httpStatusAcceptedMessage, "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, metav1.Status{}).
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, metav1.Status{}).
+			Returns(http.StatusConflict, httpStatusConflictMessage, metav1.Status{})
 		stopRouteBuilder.ParameterNamed("body").Required(false)
 		subws.Route(stopRouteBuilder)
 
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("rename")).
+			To(subresourceApp.RenameVMRequestHandler).
+			Reads(v1.RenameOptions{}).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"Rename").
+			Doc("Rename a stopped VirtualMachine object.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("move")).
+			To(subresourceApp.MoveVMRequestHandler).
+			Reads(v1.MoveOptions{}).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"Move").
+			Doc("Move a stopped VirtualMachine object and its volumes to another namespace.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("memorydump")).
+			To(subresourceApp.MemoryDumpVMRequestHandler).
+			Reads(v1.VirtualMachineMemoryDumpRequest{}).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"vm-memorydump").
+			Doc("Dump the memory of a running VirtualMachine object to a PVC.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("removememorydump")).
+			To(subresourceApp.RemoveMemoryDumpVMRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"vm-removememorydump").
+			Doc("Remove the memory dump PVC from a VirtualMachine object.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
 		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("freeze")).
 			To(subresourceApp.FreezeVMIRequestHandler).
 			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
@@ -277,35 +360,94 @@ func (app *virtAPIApp) composeSubresources() {
 			Returns(http.StatusOK, "OK", "").
 			Returns(http.StatusInternalServerError, httpStatusInternalServerError, ""))
 
-		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("pause")).
+		pauseRouteBuilder := subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("pause")).
 			To(subresourceApp.PauseVMIRequestHandler).
+			Reads(v1.PauseOptions{}).
 			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
 			Operation(version.Version+"Pause").
 			Doc("Pause a VirtualMachineInstance object.").
 			Returns(http.StatusOK, "OK", "").
-			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
-			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, metav1.Status{}).
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, metav1.Status{}).
+			Returns(http.StatusConflict, httpStatusConflictMessage, metav1.Status{})
+		pauseRouteBuilder.ParameterNamed("body").Required(false)
+		subws.Route(pauseRouteBuilder)
 
-		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("unpause")).
+		unpauseRouteBuilder := subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("unpause")).
 			To(subresourceApp.UnpauseVMIRequestHandler). // handles VMIs as well
+			Reads(v1.UnpauseOptions{}).
 			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
 			Operation(version.Version+"Unpause").
 			Doc("Unpause a VirtualMachineInstance object.").
 			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, metav1.Status{}).
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, metav1.Status{}).
+			Returns(http.StatusConflict, httpStatusConflictMessage, metav1.Status{})
+		unpauseRouteBuilder.ParameterNamed("body").Required(false)
+		subws.Route(unpauseRouteBuilder)
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("console")).
+			To(subresourceApp.ConsoleRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"Console").
+			Doc("Open a websocket connection to a serial console on the specified VirtualMachineInstance.").
+			Returns(http.StatusSwitchingProtocols, httpStatusSwitchingProtocolsMessage, "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, metav1.Status{}).
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, metav1.Status{}).
+			Returns(http.StatusConflict, httpStatusConflictMessage, metav1.Status{}))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("console")+rest.LogPath).
+			To(subresourceApp.ConsoleLogRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"ConsoleLog").
+			Produces("text/plain").
+			Doc("Get the serial console output of the specified VirtualMachineInstance, recorded since it started running on its node, if it has the kubevirt.io/serial-console-log annotation.").
+			Returns(http.StatusOK, "OK", "").
 			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
 			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
 
-		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("console")).
-			To(subresourceApp.ConsoleRequestHandler).
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("domainlog")).
+			To(subresourceApp.DomainLogRequestHandler).
 			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
-			Operation(version.Version + "Console").
-			Doc("Open a websocket connection to a serial console on the specified VirtualMachineInstance."))
+			Param(subws.QueryParameter("lines", "Number of lines from the end of the log to return, defaults to 100").DataType("integer")).
+			Operation(version.Version+"DomainLog").
+			Produces("text/plain").
+			Doc("Get the last lines of the QEMU log of the specified VirtualMachineInstance, which virtlogd writes.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, "").
+			Returns(http.StatusConflict, httpStatusConflictMessage, ""))
 
-		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("vnc")).
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("vnc")).
 			To(subresourceApp.VNCRequestHandler).
 			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
-			Operation(version.Version + "VNC").
-			Doc("Open a websocket connection to connect to VNC on the specified VirtualMachineInstance."))
+			Operation(version.Version+"VNC").
+			Doc("Open a websocket connection to connect to VNC on the specified VirtualMachineInstance.").
+			Returns(http.StatusSwitchingProtocols, httpStatusSwitchingProtocolsMessage, "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, metav1.Status{}).
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, metav1.Status{}).
+			Returns(http.StatusConflict, httpStatusConflictMessage, metav1.Status{}))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("vnc")+rest.ConsolePath).
+			To(subresourceApp.VNCConsoleRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"VNCConsole").
+			Produces("text/html").
+			Doc("Get a HTML page with a noVNC browser console connected to VNC on the specified VirtualMachineInstance.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, "").
+			Returns(http.StatusServiceUnavailable, httpStatusServiceUnavailableMessage, ""))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("vnc")+rest.ConsolePath+rest.NoVNCPath).
+			To(subresourceApp.NoVNCRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Param(subws.PathParameter("path", "Path of the noVNC module").DataType("string")).
+			Operation(version.Version+"NoVNC").
+			Produces("text/javascript").
+			Doc("Get a module of the noVNC which virt-api bundles for the browser console of the specified VirtualMachineInstance.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, ""))
 
 		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("usbredir")).
 			To(subresourceApp.USBRedirRequestHandler).
@@ -314,6 +456,16 @@ func (app *virtAPIApp) composeSubresources() {
 			Operation(version.Version + "usbredir").
 			Doc("Open a websocket connection to connect to USB device on the specified VirtualMachineInstance."))
 
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("vsock")).
+			To(subresourceApp.VSOCKRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Param(subws.QueryParameter("port", "The port in the guest to connect to").DataType("integer").Required(true)).
+			Operation(version.Version+"VSOCK").
+			Doc("Open a websocket connection to a port of the VSOCK device of the specified VirtualMachineInstance.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, ""))
+
 		// VMI endpoint
 		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR) + rest.SubResourcePath("portforward") + rest.PortPath).
 			To(subresourceApp.PortForwardRequestHandler(subresourceApp.FetchVirtualMachineInstance)).
@@ -368,6 +520,10 @@ func (app *virtAPIApp) composeSubresources() {
 			To(subresourceApp.DumpClusterProfilerHandler).
 			Operation(version.Version + "dump-cluster-profiler"))
 
+		subws.Route(subws.GET(rest.SubResourcePath("dump-cluster-profiler-archive")).Produces("application/gzip").
+			To(subresourceApp.DumpClusterProfilerArchiveHandler).
+			Operation(version.Version + "dump-cluster-profiler-archive"))
+
 		subws.Route(subws.GET(rest.SubResourcePath("guestfs")).Produces(restful.MIME_JSON).
 			To(app.GetGsInfo()).
 			Operation(version.Version+"Guestfs").
@@ -389,25 +545,44 @@ func (app *virtAPIApp) composeSubresources() {
 			Operation(version.Version+"Guestosinfo").
 			Doc("Get guest agent os information").
 			Writes(v1.VirtualMachineInstanceGuestAgentInfo{}).
-			Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceGuestAgentInfo{}))
+			Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceGuestAgentInfo{}).
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, metav1.Status{}).
+			Returns(http.StatusConflict, httpStatusConflictMessage, metav1.Status{}))
 
 		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("userlist")).
 			To(subresourceApp.UserList).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
 			Consumes(restful.MIME_JSON).
 			Produces(restful.MIME_JSON).
 			Operation(version.Version+"Userlist").
 			Doc("Get list of active users via guest agent").
 			Writes(v1.VirtualMachineInstanceGuestOSUserList{}).
-			Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceGuestOSUserList{}))
+			Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceGuestOSUserList{}).
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, metav1.Status{}).
+			Returns(http.StatusConflict, httpStatusConflictMessage, metav1.Status{}))
 
 		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("filesystemlist")).
 			To(subresourceApp.FilesystemList).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
 			Consumes(restful.MIME_JSON).
 			Produces(restful.MIME_JSON).
 			Operation(version.Version+"Filesystemlist").
 			Doc("Get list of active filesystems on guest machine via guest agent").
 			Writes(v1.VirtualMachineInstanceFileSystemList{}).
-			Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceFileSystemList{}))
+			Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceFileSystemList{}).
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, metav1.Status{}).
+			Returns(http.StatusConflict, httpStatusConflictMessage, metav1.Status{}))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("memorydump")).
+			To(subresourceApp.MemoryDumpRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Produces(restful.MIME_OCTET).
+			Operation(version.Version+"MemoryDump").
+			Doc("Capture a memory dump of the guest and stream it. Range requests with the ETag of the dump in If-Range resume an interrupted download.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusPartialContent, "Partial Content", "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusConflict, httpStatusConflictMessage, ""))
 
 		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("addvolume")).
 			To(subresourceApp.VMIAddVolumeRequestHandler).
@@ -427,6 +602,40 @@ func (app *virtAPIApp) composeSubresources() {
 			Returns(http.StatusOK, "OK", "").
 			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
 
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("mediachange")).
+			To(subresourceApp.VMIMediaChangeRequestHandler).
+			Reads(v1.MediaChangeOptions{}).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"vmi-mediachange").
+			Doc("Eject or insert the media of a CD-ROM of a running Virtual Machine Instance").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("guestexec")).
+			To(subresourceApp.GuestExecRequestHandler).
+			Reads(v1.GuestExecOptions{}).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Consumes(restful.MIME_JSON).
+			Produces(restful.MIME_JSON).
+			Operation(version.Version+"vmi-guestexec").
+			Doc("Run a command of the guestExecAllowList in the guest of a running Virtual Machine Instance through the guest agent").
+			Writes(v1.GuestExecResult{}).
+			Returns(http.StatusOK, "OK", v1.GuestExecResult{}).
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, "").
+			Returns(http.StatusForbidden, "Forbidden", ""))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("guestexec")).
+			To(subresourceApp.GuestExecStreamRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Param(subws.QueryParameter("command", "The path of the command in the guest").Required(true)).
+			Param(subws.QueryParameter("arg", "An argument of the command, can be repeated").AllowMultiple(true)).
+			Param(subws.QueryParameter("timeoutSeconds", "The time the command may run, defaults to 10 seconds").DataType("integer")).
+			Operation(version.Version+"vmi-guestexec-stream").
+			Doc("Open a websocket connection to run a command of the guestExecAllowList in the guest of a running Virtual Machine Instance, which may run longer than the request timeout.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, "").
+			Returns(http.StatusForbidden, "Forbidden", ""))
+
 		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("addvolume")).
 			To(subresourceApp.VMAddVolumeRequestHandler).
 			Reads(v1.AddVolumeOptions{}).
@@ -445,6 +654,37 @@ func (app *virtAPIApp) composeSubresources() {
 			Returns(http.StatusOK, "OK", "").
 			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
 
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("expand-spec")).
+			To(subresourceApp.ExpandSpecVMRequestHandler).
+			Produces(restful.MIME_JSON).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"vm-ExpandSpec").
+			Doc("Get the VirtualMachine object with the defaults and presets applied to its template.").
+			Returns(http.StatusOK, "OK", v1.VirtualMachine{}).
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.NamespacedBasePath()+rest.SubResourcePath("expand-vm-spec")).
+			To(subresourceApp.ExpandSpecRequestHandler).
+			Reads(v1.VirtualMachine{}).
+			Produces(restful.MIME_JSON).
+			Param(rest.NamespaceParam(subws)).
+			Operation(version.Version+"ExpandSpec").
+			Doc("Expand the template of the given VirtualMachine object with the defaults and presets, without creating it.").
+			Returns(http.StatusOK, "OK", v1.VirtualMachine{}).
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.NamespacedBasePath()+rest.SubResourcePath("preview-domain-xml")).
+			To(subresourceApp.PreviewDomainXMLRequestHandler).
+			Reads(v1.VirtualMachineInstance{}).
+			Produces("application/xml").
+			Param(rest.NamespaceParam(subws)).
+			Operation(version.Version+"PreviewDomainXML").
+			Doc("Return the libvirt domain XML which would be defined for the given VirtualMachineInstance object, without creating it.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, "").
+			Returns(http.StatusUnprocessableEntity, httpStatusUnprocessableEntityMessage, ""))
+
 		// Return empty api resource list.
 		// K8s expects to be able to retrieve a resource list for each aggregated
 		// app in order to discover what resources it provides. Without returning
@@ -503,6 +743,26 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachines/migrate",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachines/migratecancel",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachines/rename",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachines/move",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachines/memorydump",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachines/removememorydump",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachineinstances/guestosinfo",
 						Namespaced: true,
@@ -515,6 +775,26 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachineinstances/filesystemlist",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstances/memorydump",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/guestexec",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/domainlog",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/vsock",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/mediachange",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachineinstances/addvolume",
 						Namespaced: true,
@@ -523,6 +803,18 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachineinstances/removevolume",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachines/expand-spec",
+						Namespaced: true,
+					},
+					{
+						Name:       "expand-vm-spec",
+						Namespaced: true,
+					},
+					{
+						Name:       "preview-domain-xml",
+						Namespaced: true,
+					},
 				}
 
 				response.WriteAsJson(list)
@@ -615,6 +907,9 @@ func (app *virtAPIApp) Compose() {
 
 	restful.Filter(filter.RequestLoggingFilter())
 	restful.Filter(restful.OPTIONSFilter())
+	// the container filters run before the filters of the web services, the audit filter has to
+	// come before the authorization so that denied requests are audited as well
+	restful.Filter(app.auditFilter)
 	restful.Filter(func(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
 		allowed, reason, err := app.authorizor.Authorize(req)
 		if err != nil {
@@ -710,7 +1005,7 @@ func (app *virtAPIApp) prepareCertManager() {
 func (app *virtAPIApp) registerValidatingWebhooks(informers *webhooks.Informers) {
 
 	http.HandleFunc(components.VMICreateValidatePath, func(w http.ResponseWriter, r *http.Request) {
-		validating_webhook.ServeVMICreate(w, r, app.clusterConfig)
+		validating_webhook.ServeVMICreate(w, r, app.clusterConfig, informers)
 	})
 	http.HandleFunc(components.VMIUpdateValidatePath, func(w http.ResponseWriter, r *http.Request) {
 		validating_webhook.ServeVMIUpdate(w, r, app.clusterConfig)
@@ -721,6 +1016,9 @@ func (app *virtAPIApp) registerValidatingWebhooks(informers *webhooks.Informers)
 	http.HandleFunc(components.VMIRSValidatePath, func(w http.ResponseWriter, r *http.Request) {
 		validating_webhook.ServeVMIRS(w, r, app.clusterConfig)
 	})
+	http.HandleFunc(components.VMPoolValidatePath, func(w http.ResponseWriter, r *http.Request) {
+		validating_webhook.ServeVMPool(w, r, app.clusterConfig)
+	})
 	http.HandleFunc(components.VMIPresetValidatePath, func(w http.ResponseWriter, r *http.Request) {
 		validating_webhook.ServeVMIPreset(w, r)
 	})
@@ -747,7 +1045,7 @@ func (app *virtAPIApp) registerValidatingWebhooks(informers *webhooks.Informers)
 func (app *virtAPIApp) registerMutatingWebhook(informers *webhooks.Informers) {
 
 	http.HandleFunc(components.VMMutatePath, func(w http.ResponseWriter, r *http.Request) {
-		mutating_webhook.ServeVMs(w, r, app.clusterConfig)
+		mutating_webhook.ServeVMs(w, r, app.clusterConfig, informers)
 	})
 	http.HandleFunc(components.VMIMutatePath, func(w http.ResponseWriter, r *http.Request) {
 		mutating_webhook.ServeVMIs(w, r, app.clusterConfig, informers)
@@ -757,6 +1055,10 @@ func (app *virtAPIApp) registerMutatingWebhook(informers *webhooks.Informers) {
 	})
 }
 
+func (app *virtAPIApp) registerConversionWebhook() {
+	http.HandleFunc(components.CRDConversionPath, conversion_webhook.Serve)
+}
+
 func (app *virtAPIApp) setupTLS(k8sCAManager webhooksutils.ClientCAManager, kubevirtCAManager webhooksutils.ClientCAManager) {
 
 	// A VerifyClientCertIfGiven request means we're not guaranteed
@@ -886,7 +1188,10 @@ func (app *virtAPIApp) Run() {
 	vmiInformer := kubeInformerFactory.VMI()
 	vmiPresetInformer := kubeInformerFactory.VirtualMachinePreset()
 	namespaceLimitsInformer := kubeInformerFactory.LimitRanges()
+	namespaceInformer := kubeInformerFactory.Namespace()
 	vmRestoreInformer := kubeInformerFactory.VirtualMachineRestore()
+	pvcInformer := kubeInformerFactory.PersistentVolumeClaim()
+	storageCapabilitiesInformer := kubeInformerFactory.StorageCapabilitiesConfigMap()
 
 	stopChan := make(chan struct{}, 1)
 	defer close(stopChan)
@@ -918,16 +1223,21 @@ func (app *virtAPIApp) Run() {
 	kubeInformerFactory.WaitForCacheSync(stopChan)
 
 	webhookInformers := &webhooks.Informers{
-		VMIInformer:             vmiInformer,
-		VMIPresetInformer:       vmiPresetInformer,
-		NamespaceLimitsInformer: namespaceLimitsInformer,
-		VMRestoreInformer:       vmRestoreInformer,
-		DataSourceInformer:      dataSourceInformer,
+		VMIInformer:                 vmiInformer,
+		VMIPresetInformer:           vmiPresetInformer,
+		NamespaceLimitsInformer:     namespaceLimitsInformer,
+		NamespaceInformer:           namespaceInformer,
+		VMRestoreInformer:           vmRestoreInformer,
+		DataSourceInformer:          dataSourceInformer,
+		StorageCapabilitiesInformer: storageCapabilitiesInformer,
+		PVCInformer:                 pvcInformer,
 	}
+	app.webhookInformers = webhookInformers
 
 	// Build webhook subresources
 	app.registerMutatingWebhook(webhookInformers)
 	app.registerValidatingWebhooks(webhookInformers)
+	app.registerConversionWebhook()
 
 	go app.certmanager.Start()
 	go app.handlerCertManager.Start()
@@ -959,6 +1269,7 @@ func (app *virtAPIApp) configModificationCallback() {
 func (app *virtAPIApp) shouldChangeLogVerbosity() {
 	verbosity := app.clusterConfig.GetVirtAPIVerbosity(app.host)
 	log.Log.SetVerbosityLevel(int(verbosity))
+	log.SetLibraryJSONFormat(app.clusterConfig.LibraryLogsJSONEnabled())
 	log.Log.V(2).Infof("set log verbosity to %d", verbosity)
 }
 
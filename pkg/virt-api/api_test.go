@@ -21,12 +21,14 @@ package virt_api
 
 import (
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
 
 	restful "github.com/emicklei/go-restful"
+	"github.com/go-openapi/spec"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -37,7 +39,10 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/util"
 
+	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/util/openapi"
 	"kubevirt.io/kubevirt/pkg/virt-api/rest"
 )
 
@@ -60,6 +65,7 @@ var _ = Describe("Virt-api", func() {
 		Expect(err).ToNot(HaveOccurred())
 		app.virtCli, _ = kubecli.GetKubevirtClientFromFlags(server.URL(), "")
 		app.certsDirectory = tmpDir
+		app.clusterConfig, _, _, _ = testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{})
 
 		config, err := clientcmd.BuildConfigFromFlags(server.URL(), "")
 		Expect(err).ToNot(HaveOccurred())
@@ -226,6 +232,58 @@ var _ = Describe("Virt-api", func() {
 			// TODO: Check list
 		}, 5)
 
+		It("should describe the subresources in the OpenAPI spec", func() {
+			app.authorizor = authorizorMock
+			authorizorMock.EXPECT().
+				Authorize(gomock.Not(gomock.Nil())).
+				Return(true, "", nil).
+				AnyTimes()
+			app.Compose()
+			swagger := openapi.LoadOpenAPISpec(restful.RegisteredWebServices())
+			namePath := "/namespaces/{namespace:[a-z0-9][a-z0-9\\-]*}/%s/{name:[a-z0-9][a-z0-9\\-]*}/%s"
+			subresourcePath := func(resource, subresource string) spec.PathItem {
+				path, exists := swagger.Paths.Paths["/apis/subresources.kubevirt.io/v1"+fmt.Sprintf(namePath, resource, subresource)]
+				Expect(exists).To(BeTrue(), "missing subresource %s of %s", subresource, resource)
+				return path
+			}
+			paramNames := func(path spec.PathItem) []string {
+				var names []string
+				for _, param := range path.Parameters {
+					names = append(names, param.Name)
+				}
+				return names
+			}
+			statusRef := "#/definitions/k8s.io.apimachinery.pkg.apis.meta.v1.Status"
+
+			console := subresourcePath("virtualmachineinstances", "console")
+			Expect(paramNames(console)).To(ConsistOf("namespace", "name"))
+			Expect(console.Get.Responses.StatusCodeResponses).To(HaveKey(http.StatusSwitchingProtocols))
+			Expect(console.Get.Responses.StatusCodeResponses[http.StatusNotFound].Schema.Ref.String()).To(Equal(statusRef))
+
+			vnc := subresourcePath("virtualmachineinstances", "vnc")
+			Expect(vnc.Get.Responses.StatusCodeResponses).To(HaveKey(http.StatusSwitchingProtocols))
+
+			for _, subresource := range []string{"pause", "unpause"} {
+				path := subresourcePath("virtualmachineinstances", subresource)
+				Expect(path.Put.Responses.StatusCodeResponses[http.StatusConflict].Schema.Ref.String()).To(Equal(statusRef))
+			}
+
+			for _, subresource := range []string{"start", "stop", "restart", "migrate"} {
+				path := subresourcePath("virtualmachines", subresource)
+				Expect(path.Put.Responses.StatusCodeResponses).To(HaveKey(http.StatusAccepted), subresource)
+				Expect(path.Put.Responses.StatusCodeResponses[http.StatusConflict].Schema.Ref.String()).To(Equal(statusRef), subresource)
+			}
+			start := subresourcePath("virtualmachines", "start")
+			Expect(start.Put.Parameters).To(HaveLen(1))
+			Expect(start.Put.Parameters[0].Schema.Ref.String()).To(Equal("#/definitions/v1.StartOptions"))
+
+			for _, subresource := range []string{"guestosinfo", "userlist", "filesystemlist"} {
+				path := subresourcePath("virtualmachineinstances", subresource)
+				Expect(paramNames(path)).To(ConsistOf("namespace", "name"), subresource)
+				Expect(path.Get.Responses.StatusCodeResponses[http.StatusOK].Schema).ToNot(BeNil(), subresource)
+			}
+		})
+
 		It("should have default values for flags", func() {
 			app.AddFlags()
 			Expect(app.SwaggerUI).To(Equal("third_party/swagger-ui"))
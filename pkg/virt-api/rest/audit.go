@@ -0,0 +1,224 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	restful "github.com/emicklei/go-restful"
+	"github.com/gorilla/websocket"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+)
+
+type auditStage string
+
+const (
+	auditEventKind = "SubresourceAuditEvent"
+
+	// auditStageRequestReceived is recorded when a streaming session, like a console, is opened
+	auditStageRequestReceived auditStage = "RequestReceived"
+	// auditStageResponseComplete is recorded once a request was handled or a streaming session was closed
+	auditStageResponseComplete auditStage = "ResponseComplete"
+
+	// auditLogMaxSize is the size at which the file of the file sink is rotated
+	auditLogMaxSize = 100 * 1024 * 1024
+	// auditLogMaxBackups is the number of rotated files which are kept next to the file
+	auditLogMaxBackups = 5
+)
+
+// auditEvent records who accessed which subresource of which VM or VMI
+type auditEvent struct {
+	Kind                     string     `json:"kind"`
+	Stage                    auditStage `json:"stage"`
+	RequestReceivedTimestamp time.Time  `json:"requestReceivedTimestamp"`
+	StageTimestamp           time.Time  `json:"stageTimestamp"`
+	User                     string     `json:"user"`
+	Groups                   []string   `json:"groups,omitempty"`
+	SourceIPs                []string   `json:"sourceIPs,omitempty"`
+	Verb                     string     `json:"verb"`
+	Namespace                string     `json:"namespace"`
+	Resource                 string     `json:"resource"`
+	Name                     string     `json:"name"`
+	Subresource              string     `json:"subresource"`
+	ResponseCode             int        `json:"responseCode,omitempty"`
+}
+
+// auditLog writes audit events as JSON lines to the sink of the audit log configuration
+type auditLog struct {
+	lock sync.Mutex
+	// stdout is where the events of the stdout sink go, os.Stdout if nil
+	stdout io.Writer
+	path   string
+	file   *os.File
+	// size is the size of the file of the file sink
+	size int64
+}
+
+func (a *auditLog) write(config *v1.AuditLogConfiguration, event *auditEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if config.Sink != v1.AuditLogSinkFile {
+		out := a.stdout
+		if out == nil {
+			out = os.Stdout
+		}
+		_, err = out.Write(line)
+		return err
+	}
+
+	if a.file != nil && a.path == config.Path && a.size > 0 && a.size+int64(len(line)) > auditLogMaxSize {
+		if err := a.rotate(); err != nil {
+			return err
+		}
+	}
+	out, err := a.open(config.Path)
+	if err != nil {
+		return err
+	}
+	n, err := out.Write(line)
+	a.size += int64(n)
+	return err
+}
+
+// open returns the file of the file sink. The file is kept open until the path changes or it is rotated.
+func (a *auditLog) open(path string) (*os.File, error) {
+	if a.file != nil && a.path == path {
+		return a.file, nil
+	}
+	a.close()
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	a.file = file
+	a.path = path
+	a.size = info.Size()
+	return file, nil
+}
+
+func (a *auditLog) close() {
+	if a.file != nil {
+		a.file.Close()
+		a.file = nil
+	}
+}
+
+// rotate moves the file to path.1, shifts the older backups by one and drops the oldest one.
+// The file is opened again on the next write.
+func (a *auditLog) rotate() error {
+	a.close()
+	for i := auditLogMaxBackups - 1; i > 0; i-- {
+		err := os.Rename(fmt.Sprintf("%s.%d", a.path, i), fmt.Sprintf("%s.%d", a.path, i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(a.path, a.path+".1")
+}
+
+func (app *SubresourceAPIApp) newAuditEvent(request *restful.Request) *auditEvent {
+	event := &auditEvent{
+		Kind:                     auditEventKind,
+		RequestReceivedTimestamp: time.Now().UTC(),
+		User:                     app.requestUser(request),
+		Groups:                   app.requestGroups(request),
+		Verb:                     strings.ToLower(request.Request.Method),
+		Namespace:                request.PathParameter("namespace"),
+		Name:                     request.PathParameter("name"),
+	}
+	// X-Forwarded-For is not taken into account, the client can set it to anything
+	if host, _, err := net.SplitHostPort(request.Request.RemoteAddr); err == nil {
+		event.SourceIPs = []string{host}
+	} else if request.Request.RemoteAddr != "" {
+		event.SourceIPs = []string{request.Request.RemoteAddr}
+	}
+
+	// the route path looks like .../{resource}/{name}/{subresource}[/...]
+	segments := strings.Split(strings.Trim(request.SelectedRoutePath(), "/"), "/")
+	for i, segment := range segments {
+		if segment != "{name}" {
+			continue
+		}
+		if i > 0 {
+			event.Resource = segments[i-1]
+		}
+		if i+1 < len(segments) {
+			event.Subresource = segments[i+1]
+		}
+		break
+	}
+	return event
+}
+
+// AuditFilter writes an audit event for every request to a subresource of a VM or VMI, if the
+// audit log is configured. Streaming sessions get an additional event when they are opened.
+// It has to run before the authorization, so that denied requests are recorded with their response code.
+func (app *SubresourceAPIApp) AuditFilter(request *restful.Request, response *restful.Response, chain *restful.FilterChain) {
+	config := app.clusterConfig.GetAuditLogConfiguration()
+	if config == nil || request.PathParameter("name") == "" || !isSubresourceRoute(request) {
+		chain.ProcessFilter(request, response)
+		return
+	}
+
+	event := app.newAuditEvent(request)
+	if websocket.IsWebSocketUpgrade(request.Request) {
+		event.Stage = auditStageRequestReceived
+		event.StageTimestamp = event.RequestReceivedTimestamp
+		app.writeAuditEvent(config, event)
+	}
+
+	chain.ProcessFilter(request, response)
+
+	event.Stage = auditStageResponseComplete
+	event.StageTimestamp = time.Now().UTC()
+	event.ResponseCode = response.StatusCode()
+	app.writeAuditEvent(config, event)
+}
+
+func isSubresourceRoute(request *restful.Request) bool {
+	return strings.HasPrefix(request.SelectedRoutePath(), "/apis/"+v1.SubresourceGroupName+"/")
+}
+
+func (app *SubresourceAPIApp) writeAuditEvent(config *v1.AuditLogConfiguration, event *auditEvent) {
+	if err := app.auditLog.write(config, event); err != nil {
+		log.Log.Reason(err).Errorf("Failed to write the audit event of %s/%s of %s %s/%s by %s",
+			event.Resource, event.Subresource, event.Verb, event.Namespace, event.Name, event.User)
+	}
+}
@@ -0,0 +1,253 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	restful "github.com/emicklei/go-restful"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Audit log", func() {
+	var app *SubresourceAPIApp
+	var container *restful.Container
+	var stdout *bytes.Buffer
+
+	newApp := func(auditLog *v1.AuditLogConfiguration) {
+		config, _, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			AuditLog: auditLog,
+		})
+		app = &SubresourceAPIApp{clusterConfig: config}
+		app.auditLog.stdout = stdout
+
+		ws := new(restful.WebService)
+		ws.Path("/apis/subresources.kubevirt.io/v1")
+		ws.Route(ws.PUT("/namespaces/{namespace}/virtualmachineinstances/{name}/pause").
+			To(func(request *restful.Request, response *restful.Response) {
+				response.WriteHeader(http.StatusConflict)
+			}))
+		ws.Route(ws.GET("/namespaces/{namespace}/virtualmachineinstances/{name}/vnc/screenshot").
+			To(func(request *restful.Request, response *restful.Response) {
+				response.WriteHeader(http.StatusOK)
+			}))
+		ws.Route(ws.GET("/version").
+			To(func(request *restful.Request, response *restful.Response) {
+				response.WriteHeader(http.StatusOK)
+			}))
+		otherWs := new(restful.WebService)
+		otherWs.Path("/apis/kubevirt.io/v1")
+		otherWs.Route(otherWs.GET("/namespaces/{namespace}/virtualmachineinstances/{name}").
+			To(func(request *restful.Request, response *restful.Response) {
+				response.WriteHeader(http.StatusOK)
+			}))
+
+		// like in virt-api, the audit filter runs before the authorization
+		container = restful.NewContainer()
+		container.Filter(app.AuditFilter)
+		container.Filter(func(request *restful.Request, response *restful.Response, chain *restful.FilterChain) {
+			if request.Request.Header.Get(userHeader) == "mallory" {
+				response.WriteErrorString(http.StatusUnauthorized, "denied")
+				return
+			}
+			chain.ProcessFilter(request, response)
+		})
+		container.Add(ws)
+		container.Add(otherWs)
+	}
+
+	serveOther := func(path string) {
+		container.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/apis/kubevirt.io/v1"+path, nil))
+	}
+
+	serve := func(method, path string, headers map[string][]string) {
+		request := httptest.NewRequest(method, "/apis/subresources.kubevirt.io/v1"+path, nil)
+		for key, values := range headers {
+			request.Header[key] = values
+		}
+		container.ServeHTTP(httptest.NewRecorder(), request)
+	}
+
+	readEvents := func(log string) []auditEvent {
+		events := []auditEvent{}
+		scanner := bufio.NewScanner(strings.NewReader(log))
+		for scanner.Scan() {
+			event := auditEvent{}
+			Expect(json.Unmarshal(scanner.Bytes(), &event)).To(Succeed())
+			events = append(events, event)
+		}
+		return events
+	}
+
+	BeforeEach(func() {
+		stdout = &bytes.Buffer{}
+	})
+
+	It("should not write events if the audit log is not configured", func() {
+		newApp(nil)
+		serve(http.MethodPut, "/namespaces/default/virtualmachineinstances/testvmi/pause", nil)
+		Expect(stdout.Len()).To(BeZero())
+	})
+
+	It("should write an event with the user and the subresource to stdout", func() {
+		newApp(&v1.AuditLogConfiguration{})
+		serve(http.MethodPut, "/namespaces/default/virtualmachineinstances/testvmi/pause", map[string][]string{
+			userHeader:        {"alice"},
+			groupHeader:       {"admins", "system:authenticated"},
+			"X-Forwarded-For": {"10.0.0.1"},
+		})
+
+		events := readEvents(stdout.String())
+		Expect(events).To(HaveLen(1))
+		event := events[0]
+		Expect(event.Kind).To(Equal(auditEventKind))
+		Expect(event.Stage).To(Equal(auditStageResponseComplete))
+		Expect(event.User).To(Equal("alice"))
+		Expect(event.Groups).To(Equal([]string{"admins", "system:authenticated"}))
+		Expect(event.SourceIPs).To(Equal([]string{"192.0.2.1"}), "the address of the peer, and not X-Forwarded-For")
+		Expect(event.Verb).To(Equal("put"))
+		Expect(event.Namespace).To(Equal("default"))
+		Expect(event.Resource).To(Equal("virtualmachineinstances"))
+		Expect(event.Name).To(Equal("testvmi"))
+		Expect(event.Subresource).To(Equal("pause"))
+		Expect(event.ResponseCode).To(Equal(http.StatusConflict))
+	})
+
+	It("should take the user and the groups from the request headers of the authorizer", func() {
+		newApp(&v1.AuditLogConfiguration{})
+		authorizor := NewMockVirtApiAuthorizor(gomock.NewController(GinkgoT()))
+		authorizor.EXPECT().GetUserHeaders().Return([]string{userHeader, "X-Proxy-User"}).AnyTimes()
+		authorizor.EXPECT().GetGroupHeaders().Return([]string{groupHeader, "X-Proxy-Group"}).AnyTimes()
+		app.authorizor = authorizor
+		serve(http.MethodPut, "/namespaces/default/virtualmachineinstances/testvmi/pause", map[string][]string{
+			"X-Proxy-User":  {"alice"},
+			"X-Proxy-Group": {"admins"},
+		})
+
+		events := readEvents(stdout.String())
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].User).To(Equal("alice"))
+		Expect(events[0].Groups).To(Equal([]string{"admins"}))
+	})
+
+	It("should write an additional event when a streaming session is opened", func() {
+		newApp(&v1.AuditLogConfiguration{Sink: v1.AuditLogSinkStdout})
+		serve(http.MethodGet, "/namespaces/default/virtualmachineinstances/testvmi/vnc/screenshot", map[string][]string{
+			userHeader:   {"alice"},
+			"Connection": {"Upgrade"},
+			"Upgrade":    {"websocket"},
+		})
+
+		events := readEvents(stdout.String())
+		Expect(events).To(HaveLen(2))
+		Expect(events[0].Stage).To(Equal(auditStageRequestReceived))
+		Expect(events[1].Stage).To(Equal(auditStageResponseComplete))
+		for _, event := range events {
+			Expect(event.Subresource).To(Equal("vnc"))
+			Expect(event.User).To(Equal("alice"))
+		}
+	})
+
+	It("should not write events for requests which are not about a VM or VMI", func() {
+		newApp(&v1.AuditLogConfiguration{})
+		serve(http.MethodGet, "/version", nil)
+		Expect(stdout.Len()).To(BeZero())
+	})
+
+	It("should not write events for requests outside of the subresource group", func() {
+		newApp(&v1.AuditLogConfiguration{})
+		serveOther("/namespaces/default/virtualmachineinstances/testvmi")
+		Expect(stdout.Len()).To(BeZero())
+	})
+
+	It("should write an event for requests which the authorization denied", func() {
+		newApp(&v1.AuditLogConfiguration{})
+		serve(http.MethodGet, "/namespaces/default/virtualmachineinstances/testvmi/vnc/screenshot", map[string][]string{
+			userHeader: {"mallory"},
+		})
+
+		events := readEvents(stdout.String())
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].User).To(Equal("mallory"))
+		Expect(events[0].Subresource).To(Equal("vnc"))
+		Expect(events[0].ResponseCode).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should append the events to the configured file", func() {
+		dir, err := ioutil.TempDir("", "audit")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "audit.log")
+
+		newApp(&v1.AuditLogConfiguration{Sink: v1.AuditLogSinkFile, Path: path})
+		serve(http.MethodPut, "/namespaces/default/virtualmachineinstances/testvmi/pause", nil)
+		serve(http.MethodPut, "/namespaces/default/virtualmachineinstances/othervmi/pause", nil)
+
+		Expect(stdout.Len()).To(BeZero())
+		content, err := ioutil.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+		events := readEvents(string(content))
+		Expect(events).To(HaveLen(2))
+		Expect(events[0].Name).To(Equal("testvmi"))
+		Expect(events[1].Name).To(Equal("othervmi"))
+	})
+
+	It("should rotate the file once it reaches its maximum size", func() {
+		dir, err := ioutil.TempDir("", "audit")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "audit.log")
+		Expect(ioutil.WriteFile(path+".1", []byte("old\n"), 0600)).To(Succeed())
+
+		newApp(&v1.AuditLogConfiguration{Sink: v1.AuditLogSinkFile, Path: path})
+		serve(http.MethodPut, "/namespaces/default/virtualmachineinstances/testvmi/pause", nil)
+		app.auditLog.size = auditLogMaxSize - 10
+		serve(http.MethodPut, "/namespaces/default/virtualmachineinstances/othervmi/pause", nil)
+
+		content, err := ioutil.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+		events := readEvents(string(content))
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Name).To(Equal("othervmi"))
+
+		content, err = ioutil.ReadFile(path + ".1")
+		Expect(err).ToNot(HaveOccurred())
+		events = readEvents(string(content))
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Name).To(Equal("testvmi"))
+
+		content, err = ioutil.ReadFile(path + ".2")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("old\n"))
+	})
+})
@@ -45,6 +45,7 @@ const (
 
 type VirtApiAuthorizor interface {
 	Authorize(req *restful.Request) (bool, string, error)
+	AuthorizeResourceAccess(req *restful.Request, attributes *authorization.ResourceAttributes) (bool, string, error)
 	AddUserHeaders(header []string)
 	GetUserHeaders() []string
 	AddGroupHeaders(header []string)
@@ -140,23 +141,32 @@ func (a *authorizor) generateAccessReview(req *restful.Request) (*authorization.
 
 	// URL example
 	// /apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi/console
+	// or, for namespaced endpoints which are not about a single object
+	// /apis/subresources.kubevirt.io/v1alpha3/namespaces/default/expand-vm-spec
 	pathSplit := strings.Split(url.Path, "/")
-	if len(pathSplit) < 9 {
+	var resource, resourceName, subresource string
+	switch {
+	case len(pathSplit) >= 9:
+		resource = pathSplit[6]
+		resourceName = pathSplit[7]
+		subresource = pathSplit[8]
+		if resource != "virtualmachineinstances" && resource != "virtualmachines" {
+			return nil, fmt.Errorf("unknown resource type %s", resource)
+		}
+	case len(pathSplit) == 7 && pathSplit[4] == "namespaces":
+		resource = pathSplit[6]
+		if resource != "expand-vm-spec" && resource != "preview-domain-xml" {
+			return nil, fmt.Errorf("unknown resource type %s", resource)
+		}
+	default:
 		return nil, fmt.Errorf("unknown api endpoint %s", url.Path)
 	}
 
 	group := pathSplit[2]
 	version := pathSplit[3]
 	namespace := pathSplit[5]
-	resource := pathSplit[6]
-	resourceName := pathSplit[7]
-	subresource := pathSplit[8]
 	userExtras := a.getUserExtras(headers)
 
-	if resource != "virtualmachineinstances" && resource != "virtualmachines" {
-		return nil, fmt.Errorf("unknown resource type %s", resource)
-	}
-
 	userName, err := a.getUserName(headers)
 	if err != nil {
 		return nil, err
@@ -242,6 +252,7 @@ func isInfoOrHealthEndpoint(req *restful.Request) bool {
 		"start-cluster-profiler",
 		"stop-cluster-profiler",
 		"dump-cluster-profiler",
+		"dump-cluster-profiler-archive",
 	}
 
 	for _, endpoint := range noAuthEndpoints {
@@ -298,6 +309,43 @@ func (a *authorizor) Authorize(req *restful.Request) (bool, string, error) {
 	return false, result.Status.Reason, nil
 }
 
+// AuthorizeResourceAccess checks whether the user who sent the request is allowed to access the given
+// resource. It is used by subresources which act on further resources than the one in the request path.
+func (a *authorizor) AuthorizeResourceAccess(req *restful.Request, attributes *authorization.ResourceAttributes) (bool, string, error) {
+	if req.Request == nil {
+		return false, "empty http request", nil
+	}
+	headers := req.Request.Header
+
+	userName, err := a.getUserName(headers)
+	if err != nil {
+		return false, fmt.Sprintf("%v", err), nil
+	}
+	userGroups, err := a.getUserGroups(headers)
+	if err != nil {
+		return false, fmt.Sprintf("%v", err), nil
+	}
+
+	r := &authorization.SubjectAccessReview{}
+	r.Spec = authorization.SubjectAccessReviewSpec{
+		User:               userName,
+		Groups:             userGroups,
+		Extra:              a.getUserExtras(headers),
+		ResourceAttributes: attributes,
+	}
+
+	result, err := a.subjectAccessReview.Create(context.Background(), r, metav1.CreateOptions{})
+	if err != nil {
+		return false, "internal server error", err
+	}
+
+	if result.Status.Allowed {
+		return true, "", nil
+	}
+
+	return false, result.Status.Reason, nil
+}
+
 func NewAuthorizorFromConfig(config *restclient.Config) (VirtApiAuthorizor, error) {
 	client, err := authorizationclient.NewForConfig(config)
 	if err != nil {
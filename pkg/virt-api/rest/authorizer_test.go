@@ -30,6 +30,7 @@ import (
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/ghttp"
+	authorization "k8s.io/api/authorization/v1"
 	authorizationclient "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	"k8s.io/client-go/tools/clientcmd"
 )
@@ -158,6 +159,7 @@ var _ = Describe("Authorizer", func() {
 				table.Entry("start profiler", "/apis/subresources.kubevirt.io/start-cluster-profiler"),
 				table.Entry("stop profiler", "/apis/subresources.kubevirt.io/stop-cluster-profiler"),
 				table.Entry("dump profiler", "/apis/subresources.kubevirt.io/dump-cluster-profiler"),
+				table.Entry("dump profiler archive", "/apis/subresources.kubevirt.io/dump-cluster-profiler-archive"),
 			)
 
 			table.DescribeTable("should reject all users for unknown endpoint paths", func(path string) {
@@ -173,6 +175,25 @@ var _ = Describe("Authorizer", func() {
 				table.Entry("random2", "/1/2/3/4/5/6/7/8/9/0/1/2/3/4/5/6/7/8/9"),
 				table.Entry("no subresource provided", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
 				table.Entry("invalid resource type", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/madeupresource/testvmi/console"),
+				table.Entry("invalid namespaced endpoint", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/madethisup"),
+			)
+
+			table.DescribeTable("should review the access to namespaced endpoints without a name", func(resource string) {
+				req.Request.Method = http.MethodPut
+				req.Request.URL.Path = "/apis/subresources.kubevirt.io/v1/namespaces/default/" + resource
+
+				result, err := app.generateAccessReview(req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(*result.Spec.ResourceAttributes).To(Equal(authorization.ResourceAttributes{
+					Namespace: "default",
+					Verb:      "update",
+					Group:     "subresources.kubevirt.io",
+					Version:   "v1",
+					Resource:  resource,
+				}))
+			},
+				table.Entry("for expand-vm-spec", "expand-vm-spec"),
+				table.Entry("for preview-domain-xml", "preview-domain-xml"),
 			)
 		})
 
@@ -2,6 +2,9 @@ package rest
 
 import (
 	"fmt"
+	"io"
+	"net/http"
+	"time"
 
 	restful "github.com/emicklei/go-restful"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -12,6 +15,8 @@ import (
 	apimetrics "kubevirt.io/kubevirt/pkg/monitoring/api"
 )
 
+const consoleLogTimeout = 10 * time.Second
+
 func (app *SubresourceAPIApp) ConsoleRequestHandler(request *restful.Request, response *restful.Response) {
 	activeConnectionMetric := apimetrics.NewActiveConsoleConnection(request.PathParameter("namespace"), request.PathParameter("name"))
 	defer activeConnectionMetric.Dec()
@@ -19,14 +24,70 @@ func (app *SubresourceAPIApp) ConsoleRequestHandler(request *restful.Request, re
 	streamer := NewRawStreamer(
 		app.FetchVirtualMachineInstance,
 		validateVMIForConsole,
-		app.virtHandlerDialer(func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		app.recordingDialer(request, consoleSession, app.virtHandlerDialer(func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
 			return conn.ConsoleURI(vmi)
-		}),
+		})),
 	)
 
 	streamer.Handle(request, response)
 }
 
+// ConsoleLogRequestHandler returns the serial console output of the VMI, which virt-handler
+// records since the VMI started running on its node
+func (app *SubresourceAPIApp) ConsoleLogRequestHandler(request *restful.Request, response *restful.Response) {
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if statusErr := validateVMIForConsole(vmi); statusErr != nil {
+			return statusErr
+		}
+		if vmi.Status.Phase != v1.Running {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+		}
+		return nil
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.ConsoleLogURI(vmi)
+	}
+
+	_, url, _, statusErr := app.prepareConnection(request, validate, getURL)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	app.proxyPlainText(request, response, url, "console log")
+}
+
+// proxyPlainText streams the plain text which virt-handler returns for a GET request on url
+func (app *SubresourceAPIApp) proxyPlainText(request *restful.Request, response *restful.Response, url string, what string) {
+	handlerRequest, err := http.NewRequestWithContext(request.Request.Context(), http.MethodGet, url, nil)
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+	client := http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: app.handlerTLSConfiguration,
+		},
+		Timeout: consoleLogTimeout,
+	}
+	handlerResponse, err := client.Do(handlerRequest)
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+	defer handlerResponse.Body.Close()
+	if handlerResponse.StatusCode < 200 || handlerResponse.StatusCode > 299 {
+		writeError(errors.NewInternalError(fmt.Errorf("unexpected return code %s", handlerResponse.Status)), response)
+		return
+	}
+
+	response.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	response.WriteHeader(handlerResponse.StatusCode)
+	if _, err := io.Copy(response, handlerResponse.Body); err != nil {
+		log.Log.Reason(err).Errorf("error streaming the %s", what)
+	}
+}
+
 func validateVMIForConsole(vmi *v1.VirtualMachineInstance) *errors.StatusError {
 	if vmi.Spec.Domain.Devices.AutoattachSerialConsole != nil && *vmi.Spec.Domain.Devices.AutoattachSerialConsole == false {
 		err := fmt.Errorf("No serial consoles are present.")
@@ -31,6 +31,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	poolv1 "kubevirt.io/client-go/apis/pool/v1alpha1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 	mime "kubevirt.io/kubevirt/pkg/rest"
 )
@@ -48,6 +49,8 @@ func ComposeAPIDefinitions() []*restful.WebService {
 	vmscGVR := snapshotv1.SchemeGroupVersion.WithResource("virtualmachinesnapshotcontents")
 	vmrGVR := snapshotv1.SchemeGroupVersion.WithResource("virtualmachinerestores")
 
+	vmpGVR := poolv1.SchemeGroupVersion.WithResource("virtualmachinepools")
+
 	ws, err := GroupVersionProxyBase(v1.GroupVersion)
 	if err != nil {
 		panic(err)
@@ -112,7 +115,22 @@ func ComposeAPIDefinitions() []*restful.WebService {
 		panic(err)
 	}
 
-	return []*restful.WebService{ws, ws1, ws2, ws3}
+	ws4, err := GroupVersionProxyBase(schema.GroupVersion{Group: poolv1.SchemeGroupVersion.Group, Version: poolv1.SchemeGroupVersion.Version})
+	if err != nil {
+		panic(err)
+	}
+
+	ws4, err = GenericResourceProxy(ws4, vmpGVR, &poolv1.VirtualMachinePool{}, "VirtualMachinePool", &poolv1.VirtualMachinePoolList{})
+	if err != nil {
+		panic(err)
+	}
+
+	ws5, err := ResourceProxyAutodiscovery(vmpGVR)
+	if err != nil {
+		panic(err)
+	}
+
+	return []*restful.WebService{ws, ws1, ws2, ws3, ws4, ws5}
 }
 
 func GroupVersionProxyBase(gv schema.GroupVersion) (*restful.WebService, error) {
@@ -394,6 +412,10 @@ func GroupVersionBasePath(gvr schema.GroupVersion) string {
 	return fmt.Sprintf("/apis/%s/%s", gvr.Group, gvr.Version)
 }
 
+func NamespacedBasePath() string {
+	return "/namespaces/{namespace:[a-z0-9][a-z0-9\\-]*}"
+}
+
 func ResourceBasePath(gvr schema.GroupVersionResource) string {
 	return fmt.Sprintf("/namespaces/{namespace:[a-z0-9][a-z0-9\\-]*}/%s", gvr.Resource)
 }
@@ -414,6 +436,9 @@ const (
 	PortPath          = "/{port:[0-9]+}"
 	ProtocolParamName = "protocol"
 	ProtocolPath      = "/{protocol:tcp|udp}"
+	LogPath           = "/log"
+	ConsolePath       = "/console"
+	NoVNCPath         = "/novnc/{path:*}"
 )
 
 func PortForwardPortParameter(ws *restful.WebService) *restful.Parameter {
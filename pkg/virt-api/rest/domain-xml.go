@@ -0,0 +1,213 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"runtime"
+
+	restful "github.com/emicklei/go-restful"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	ephemeraldisk "kubevirt.io/kubevirt/pkg/ephemeral-disk"
+	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
+	"kubevirt.io/kubevirt/pkg/util/hardware"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/efi"
+)
+
+const (
+	// the defaults of virt-launcher, which the paths in the domain XML refer to
+	previewEphemeralDiskDir = "/var/run/kubevirt-ephemeral-disks/disk-data"
+	previewOVMFPath         = "/usr/share/OVMF"
+	// virt-handler inspects the images of container disks, most of them are qcow2 images
+	previewContainerDiskFormat = "qcow2"
+)
+
+// VMISpecExpander returns a copy of the VMI with all the defaults and presets applied, which the
+// mutating webhook would apply on creation
+type VMISpecExpander func(vmi *v1.VirtualMachineInstance) (*v1.VirtualMachineInstance, error)
+
+// VMISpecValidator returns the causes for which the validating webhook would reject the VMI
+type VMISpecValidator func(vmi *v1.VirtualMachineInstance) []metav1.StatusCause
+
+// PreviewDomainXMLRequestHandler returns the libvirt domain XML which virt-launcher would define
+// for the VMI in the request body, without creating the VMI
+func (app *SubresourceAPIApp) PreviewDomainXMLRequestHandler(request *restful.Request, response *restful.Response) {
+	namespace := request.PathParameter("namespace")
+
+	vmi := &v1.VirtualMachineInstance{}
+	if request.Request.Body == nil {
+		writeError(errors.NewBadRequest("Request with no body, a VirtualMachineInstance is expected as the request body"), response)
+		return
+	}
+	defer request.Request.Body.Close()
+	err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(vmi)
+	switch err {
+	case nil:
+		break
+	case io.EOF:
+		writeError(errors.NewBadRequest("Request with no body, a VirtualMachineInstance is expected as the request body"), response)
+		return
+	default:
+		writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+		return
+	}
+
+	if vmi.Namespace == "" {
+		vmi.Namespace = namespace
+	} else if vmi.Namespace != namespace {
+		writeError(errors.NewBadRequest(fmt.Sprintf("VirtualMachineInstance namespace %s does not match the namespace %s of the request", vmi.Namespace, namespace)), response)
+		return
+	}
+
+	expanded, err := app.expandVMISpec(vmi)
+	if err != nil {
+		writeError(errors.NewBadRequest(fmt.Sprintf("Unable to expand the spec of VirtualMachineInstance %s: %v", vmi.Name, err)), response)
+		return
+	}
+	vmi = expanded
+	if causes := app.validateVMISpec(vmi); len(causes) > 0 {
+		writeError(newInvalidVMIError(vmi.Name, causes), response)
+		return
+	}
+
+	domainXML, err := renderDomainXML(vmi, app.clusterConfig)
+	if err != nil {
+		writeError(errors.NewBadRequest(fmt.Sprintf("Unable to convert VirtualMachineInstance %s to a domain: %v", vmi.Name, err)), response)
+		return
+	}
+
+	response.Header().Set("Content-Type", "application/xml")
+	response.WriteHeader(http.StatusOK)
+	if _, err := response.Write(domainXML); err != nil {
+		log.Log.Reason(err).Error("error writing the domain XML")
+	}
+}
+
+func newInvalidVMIError(name string, causes []metav1.StatusCause) *errors.StatusError {
+	return &errors.StatusError{ErrStatus: metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    http.StatusUnprocessableEntity,
+		Reason:  metav1.StatusReasonInvalid,
+		Message: fmt.Sprintf("VirtualMachineInstance %s is invalid: %s", name, causes[0].Message),
+		Details: &metav1.StatusDetails{
+			Name:   name,
+			Group:  v1.GroupVersion.Group,
+			Kind:   v1.VirtualMachineInstanceGroupVersionKind.Kind,
+			Causes: causes,
+		},
+	}}
+}
+
+// renderDomainXML converts the VMI like virt-launcher does it. What depends on the node, like the
+// dedicated CPUs, the NUMA topology and the format of container disks, is filled with placeholders.
+// What virt-launcher reads from the pod, the resolv.conf and the volumes, is left out, the files of
+// virt-api must not end up in the domain.
+func renderDomainXML(vmi *v1.VirtualMachineInstance, clusterConfig *virtconfig.ClusterConfig) ([]byte, error) {
+	c := &converter.ConverterContext{
+		Architecture:          runtime.GOARCH,
+		VirtualMachine:        vmi,
+		AllowEmulation:        true,
+		EFIConfiguration:      previewEFIConfiguration(vmi),
+		UseVirtioTransitional: vmi.Spec.Domain.Devices.UseVirtioTransitional != nil && *vmi.Spec.Domain.Devices.UseVirtioTransitional,
+		EphemeraldiskCreator:  ephemeraldisk.NewEphemeralDiskCreator(previewEphemeralDiskDir),
+		MemBalloonStatsPeriod: uint(clusterConfig.GetMemBalloonStatsPeriod()),
+		DisksInfo:             map[string]*cmdv1.DiskInfo{},
+		SearchDomains: func() ([]string, error) {
+			return nil, nil
+		},
+		OptimalBlockIO: func(_ *api.Disk) (*api.BlockIO, error) {
+			return nil, nil
+		},
+	}
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.ContainerDisk != nil {
+			c.DisksInfo[volume.Name] = &cmdv1.DiskInfo{Format: previewContainerDiskFormat}
+		}
+	}
+	if smbios := clusterConfig.GetSMBIOS(); smbios != nil {
+		c.SMBios = &cmdv1.SMBios{
+			Family:       smbios.Family,
+			Product:      smbios.Product,
+			Manufacturer: smbios.Manufacturer,
+			Sku:          smbios.Sku,
+			Version:      smbios.Version,
+		}
+	}
+	if vmi.IsCPUDedicated() {
+		c.CPUSet, c.Topology = previewCPUTopology(vmi)
+	}
+
+	domain := &api.Domain{}
+	if err := converter.Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c); err != nil {
+		return nil, err
+	}
+	// virt-api has no access to /dev/kvm, the nodes have it unless emulation is allowed
+	if !clusterConfig.AllowEmulation() {
+		domain.Spec.Type = "kvm"
+	}
+	return xml.MarshalIndent(domain.Spec, "", "  ")
+}
+
+func previewEFIConfiguration(vmi *v1.VirtualMachineInstance) *converter.EFIConfiguration {
+	if !vmi.IsBootloaderEFI() {
+		return nil
+	}
+	secureBoot := vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBoot == nil || *vmi.Spec.Domain.Firmware.Bootloader.EFI.SecureBoot
+	conf := &converter.EFIConfiguration{
+		EFICode:      filepath.Join(previewOVMFPath, efi.EFICode),
+		EFIVars:      filepath.Join(previewOVMFPath, efi.EFIVars),
+		SecureLoader: secureBoot,
+	}
+	if runtime.GOARCH == "arm64" {
+		conf.EFICode = filepath.Join(previewOVMFPath, efi.EFICodeAARCH64)
+		conf.EFIVars = filepath.Join(previewOVMFPath, efi.EFIVarsAARCH64)
+	} else if secureBoot {
+		conf.EFICode = filepath.Join(previewOVMFPath, efi.EFICodeSecureBoot)
+		conf.EFIVars = filepath.Join(previewOVMFPath, efi.EFIVarsSecureBoot)
+	}
+	return conf
+}
+
+// previewCPUTopology returns as many host CPUs on a single NUMA cell as the VMI needs
+func previewCPUTopology(vmi *v1.VirtualMachineInstance) ([]int, *cmdv1.Topology) {
+	count := int(hardware.GetNumberOfVCPUs(vmi.Spec.Domain.CPU))
+	if vmi.Spec.Domain.CPU.IsolateEmulatorThread {
+		count++
+	}
+	cpuSet := []int{}
+	cell := &cmdv1.Cell{Id: 0}
+	for id := 0; id < count; id++ {
+		cpuSet = append(cpuSet, id)
+		cell.Cpus = append(cell.Cpus, &cmdv1.CPU{Id: uint32(id)})
+	}
+	return cpuSet, &cmdv1.Topology{NumaCells: []*cmdv1.Cell{cell}}
+}
@@ -0,0 +1,78 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	restful "github.com/emicklei/go-restful"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+)
+
+// domainLogLinesParam limits the domain log to its last lines
+const domainLogLinesParam = "lines"
+
+// DomainLogRequestHandler returns the last lines of the QEMU log of the VMI, which virtlogd writes. The log is
+// mostly needed when the domain does not start, so it is also available before the VMI is running.
+func (app *SubresourceAPIApp) DomainLogRequestHandler(request *restful.Request, response *restful.Response) {
+	vmi, statusErr := app.fetchAndValidateVirtualMachineInstance(request.PathParameter("namespace"), request.PathParameter("name"), validateVMIForDomainLog)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	lines := request.QueryParameter(domainLogLinesParam)
+	if lines != "" {
+		if n, err := strconv.Atoi(lines); err != nil || n <= 0 {
+			writeError(errors.NewBadRequest(fmt.Sprintf("%s must be a positive number", domainLogLinesParam)), response)
+			return
+		}
+	}
+
+	conn := kubecli.NewVirtHandlerClient(app.virtCli).Port(app.consoleServerPort).ForNode(vmi.Status.NodeName)
+	handlerURL, err := conn.DomainLogURI(vmi)
+	if err != nil {
+		statusErr = errors.NewBadRequest(err.Error())
+		log.Log.Object(vmi).Reason(statusErr).Error("Unable to retrieve target handler URL")
+		writeError(statusErr, response)
+		return
+	}
+	if lines != "" {
+		handlerURL = fmt.Sprintf("%s?%s=%s", handlerURL, domainLogLinesParam, url.QueryEscape(lines))
+	}
+
+	app.proxyPlainText(request, response, handlerURL, "domain log")
+}
+
+func validateVMIForDomainLog(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+	if vmi.IsFinal() {
+		return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is in %s status, the virt-launcher pod is gone", vmi.Status.Phase))
+	}
+	if vmi.Status.NodeName == "" {
+		return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not scheduled to a node yet"))
+	}
+	return nil
+}
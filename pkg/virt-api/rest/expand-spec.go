@@ -0,0 +1,97 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"fmt"
+	"io"
+
+	restful "github.com/emicklei/go-restful"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// SpecExpander returns a copy of the VirtualMachine whose template has all the defaults and
+// presets applied, which the VMI of the VirtualMachine would get
+type SpecExpander func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error)
+
+// ExpandSpecVMRequestHandler returns the expanded spec of an existing VirtualMachine
+func (app *SubresourceAPIApp) ExpandSpecVMRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	vm, statusErr := app.fetchVirtualMachine(name, namespace)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	app.writeExpandedSpec(vm, response)
+}
+
+// ExpandSpecRequestHandler returns the expanded spec of the VirtualMachine in the request body,
+// so that clients can preview a VirtualMachine before creating it
+func (app *SubresourceAPIApp) ExpandSpecRequestHandler(request *restful.Request, response *restful.Response) {
+	namespace := request.PathParameter("namespace")
+
+	vm := &v1.VirtualMachine{}
+	if request.Request.Body == nil {
+		writeError(errors.NewBadRequest("Request with no body, a VirtualMachine is expected as the request body"), response)
+		return
+	}
+	defer request.Request.Body.Close()
+	err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(vm)
+	switch err {
+	case nil:
+		break
+	case io.EOF:
+		writeError(errors.NewBadRequest("Request with no body, a VirtualMachine is expected as the request body"), response)
+		return
+	default:
+		writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+		return
+	}
+
+	if vm.Namespace == "" {
+		vm.Namespace = namespace
+	} else if vm.Namespace != namespace {
+		writeError(errors.NewBadRequest(fmt.Sprintf("VirtualMachine namespace %s does not match the namespace %s of the request", vm.Namespace, namespace)), response)
+		return
+	}
+
+	app.writeExpandedSpec(vm, response)
+}
+
+func (app *SubresourceAPIApp) writeExpandedSpec(vm *v1.VirtualMachine, response *restful.Response) {
+	if vm.Spec.Template == nil {
+		writeError(errors.NewBadRequest("VirtualMachine has no template to expand"), response)
+		return
+	}
+
+	expanded, err := app.expandSpec(vm)
+	if err != nil {
+		writeError(errors.NewBadRequest(fmt.Sprintf("Unable to expand the spec of VirtualMachine %s: %v", vm.Name, err)), response)
+		return
+	}
+	expanded.SetGroupVersionKind(v1.VirtualMachineGroupVersionKind)
+	response.WriteEntity(expanded)
+}
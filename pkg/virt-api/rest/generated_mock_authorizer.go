@@ -6,6 +6,7 @@ package rest
 import (
 	go_restful "github.com/emicklei/go-restful"
 	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/authorization/v1"
 )
 
 // Mock of VirtApiAuthorizor interface
@@ -41,6 +42,18 @@ func (_mr *_MockVirtApiAuthorizorRecorder) Authorize(arg0 interface{}) *gomock.C
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Authorize", arg0)
 }
 
+func (_m *MockVirtApiAuthorizor) AuthorizeResourceAccess(req *go_restful.Request, attributes *v1.ResourceAttributes) (bool, string, error) {
+	ret := _m.ctrl.Call(_m, "AuthorizeResourceAccess", req, attributes)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+func (_mr *_MockVirtApiAuthorizorRecorder) AuthorizeResourceAccess(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "AuthorizeResourceAccess", arg0, arg1)
+}
+
 func (_m *MockVirtApiAuthorizor) AddUserHeaders(header []string) {
 	_m.ctrl.Call(_m, "AddUserHeaders", header)
 }
@@ -0,0 +1,232 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	restful "github.com/emicklei/go-restful"
+	"github.com/gorilla/websocket"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/utils/pointer"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/controller"
+)
+
+const (
+	defaultGuestExecTimeoutSeconds = int32(10)
+	// maxGuestExecTimeoutSeconds keeps the request below the default request timeout of 60 seconds of the kube-apiserver
+	maxGuestExecTimeoutSeconds = int32(50)
+	// maxStreamingGuestExecTimeoutSeconds limits commands run over a websocket, which is not subject to the request timeout
+	maxStreamingGuestExecTimeoutSeconds = int32(600)
+	// guestExecConnectionTimeout is added to the timeout of the command for the connection to virt-handler
+	guestExecConnectionTimeout = 5 * time.Second
+
+	guestExecCommandParam = "command"
+	guestExecArgParam     = "arg"
+	guestExecTimeoutParam = "timeoutSeconds"
+)
+
+// GuestExecRequestHandler runs a command of the guestExecAllowList in the guest of a VMI through
+// the guest agent. Every request is logged with the user who sent it, to audit what ran in guests.
+func (app *SubresourceAPIApp) GuestExecRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+	user := app.requestUser(request)
+
+	if !app.clusterConfig.GuestExecEnabled() {
+		writeError(errors.NewBadRequest("Unable to run the command because the GuestExec feature gate is not enabled."), response)
+		return
+	}
+
+	opts := &v1.GuestExecOptions{}
+	if request.Request.Body != nil {
+		defer request.Request.Body.Close()
+		err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+		switch err {
+		case io.EOF, nil:
+			break
+		default:
+			writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+			return
+		}
+	} else {
+		writeError(errors.NewBadRequest("Request with no body, a command is expected as the request body"), response)
+		return
+	}
+
+	if statErr := app.validateGuestExecOptions(opts, maxGuestExecTimeoutSeconds); statErr != nil {
+		log.Log.Infof("Rejected guest exec of %q with args %q in VMI %s/%s by user %q: %s", opts.Command, opts.Args, namespace, name, user, statErr.ErrStatus.Message)
+		writeError(statErr, response)
+		return
+	}
+
+	vmi, url, conn, statErr := app.prepareConnection(request, validateVMIForGuestExec, getGuestExecURL)
+	if statErr != nil {
+		writeError(statErr, response)
+		return
+	}
+
+	result, err := app.runGuestExec(vmi, url, conn, opts, user)
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+	response.WriteEntity(result)
+}
+
+// GuestExecStreamRequestHandler runs a command of the guestExecAllowList like GuestExecRequestHandler,
+// but over a websocket. Since the kube-apiserver does not apply its request timeout to websockets,
+// the command may run for up to maxStreamingGuestExecTimeoutSeconds. The guest agent only returns
+// the output once the command exited, so the result is written as a single JSON message then.
+func (app *SubresourceAPIApp) GuestExecStreamRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+	user := app.requestUser(request)
+
+	if !app.clusterConfig.GuestExecEnabled() {
+		writeError(errors.NewBadRequest("Unable to run the command because the GuestExec feature gate is not enabled."), response)
+		return
+	}
+
+	opts, statErr := guestExecOptionsFromQuery(request)
+	if statErr == nil {
+		statErr = app.validateGuestExecOptions(opts, maxStreamingGuestExecTimeoutSeconds)
+	}
+	if statErr != nil {
+		log.Log.Infof("Rejected guest exec of %q with args %q in VMI %s/%s by user %q: %s", opts.Command, opts.Args, namespace, name, user, statErr.ErrStatus.Message)
+		writeError(statErr, response)
+		return
+	}
+
+	vmi, url, conn, statErr := app.prepareConnection(request, validateVMIForGuestExec, getGuestExecURL)
+	if statErr != nil {
+		writeError(statErr, response)
+		return
+	}
+
+	clientConn, err := clientConnectionUpgrade(request, response)
+	if err != nil {
+		writeError(errors.NewBadRequest(err.Error()), response)
+		return
+	}
+	defer clientConn.Close()
+
+	ctx, cancel := context.WithCancel(request.Request.Context())
+	defer cancel()
+	go keepAliveClientStream(ctx, clientConn, cancel)
+
+	result, err := app.runGuestExec(vmi, url, conn, opts, user)
+	if err != nil {
+		clientConn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseInternalServerErr, err.Error()), time.Now().Add(streamTimeout))
+		return
+	}
+	body, err := json.Marshal(result)
+	if err != nil {
+		clientConn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseInternalServerErr, err.Error()), time.Now().Add(streamTimeout))
+		return
+	}
+	if err := clientConn.WriteMessage(websocket.BinaryMessage, body); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to write the guest exec result to the client websocket connection")
+		return
+	}
+	clientConn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(streamTimeout))
+}
+
+func guestExecOptionsFromQuery(request *restful.Request) (*v1.GuestExecOptions, *errors.StatusError) {
+	query := request.Request.URL.Query()
+	opts := &v1.GuestExecOptions{
+		Command: query.Get(guestExecCommandParam),
+		Args:    query[guestExecArgParam],
+	}
+	if timeout := query.Get(guestExecTimeoutParam); timeout != "" {
+		timeoutSeconds, err := strconv.ParseInt(timeout, 10, 32)
+		if err != nil {
+			return opts, errors.NewBadRequest(fmt.Sprintf("%s must be a number", guestExecTimeoutParam))
+		}
+		opts.TimeoutSeconds = pointer.Int32Ptr(int32(timeoutSeconds))
+	}
+	return opts, nil
+}
+
+func validateVMIForGuestExec(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+	if vmi.Status.Phase != v1.Running {
+		return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+	}
+	condManager := controller.NewVirtualMachineInstanceConditionManager()
+	if !condManager.HasCondition(vmi, v1.VirtualMachineInstanceAgentConnected) {
+		return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI does not have guest agent connected"))
+	}
+	return nil
+}
+
+func getGuestExecURL(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+	return conn.GuestExecURI(vmi)
+}
+
+// runGuestExec runs the validated command through virt-handler and logs who ran what with which result
+func (app *SubresourceAPIApp) runGuestExec(vmi *v1.VirtualMachineInstance, url string, conn kubecli.VirtHandlerConn, opts *v1.GuestExecOptions, user string) (*v1.GuestExecResult, error) {
+	body, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Log.Object(vmi).Infof("User %q runs %q with args %q in the guest", user, opts.Command, opts.Args)
+	timeout := time.Duration(*opts.TimeoutSeconds)*time.Second + guestExecConnectionTimeout
+	resp, err := conn.Post(url, app.handlerTLSConfiguration, body, timeout)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to run %q for user %q in the guest", opts.Command, user)
+		return nil, err
+	}
+
+	result := &v1.GuestExecResult{}
+	if err := json.Unmarshal([]byte(resp), result); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("error unmarshalling guest exec response")
+		return nil, err
+	}
+	log.Log.Object(vmi).Infof("Command %q of user %q exited with %d", opts.Command, user, result.ExitCode)
+	return result, nil
+}
+
+func (app *SubresourceAPIApp) validateGuestExecOptions(opts *v1.GuestExecOptions, maxTimeoutSeconds int32) *errors.StatusError {
+	if opts.Command == "" {
+		return errors.NewBadRequest("GuestExecOptions requires command to be set")
+	}
+	if !app.clusterConfig.IsGuestExecCommandAllowed(opts.Command, opts.Args) {
+		return errors.NewForbidden(v1.Resource("virtualmachineinstances/guestexec"), opts.Command, fmt.Errorf("the command with these arguments is not part of the guestExecAllowList in kubevirt-config"))
+	}
+	if opts.TimeoutSeconds == nil {
+		timeout := defaultGuestExecTimeoutSeconds
+		opts.TimeoutSeconds = &timeout
+	}
+	if *opts.TimeoutSeconds < 1 || *opts.TimeoutSeconds > maxTimeoutSeconds {
+		return errors.NewBadRequest(fmt.Sprintf("GuestExecOptions requires timeoutSeconds to be between 1 and %d", maxTimeoutSeconds))
+	}
+	return nil
+}
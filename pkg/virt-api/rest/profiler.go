@@ -20,12 +20,17 @@
 package rest
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"path"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -183,17 +188,89 @@ func (app *SubresourceAPIApp) DumpClusterProfilerHandler(request *restful.Reques
 		response.WriteErrorString(http.StatusForbidden, "Unable to dump profiler results. \"ClusterProfiler\" feature gate must be enabled")
 		return
 	}
-	pods, err := app.getAllComponentPods()
+
+	results, err := app.dumpClusterProfiler()
 	if err != nil {
-		response.WriteErrorString(http.StatusInternalServerError, fmt.Sprintf("Internal error while looking up component pods for profiling: %v", err))
+		response.WriteErrorString(http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	if len(pods) == 0 {
-		response.WriteErrorString(http.StatusInternalServerError, "Internal error, no component pods found")
+	response.WriteAsJson(results)
+}
+
+// DumpClusterProfilerArchiveHandler returns the profiler results of all component pods as one
+// gzipped tar archive, with a directory per pod
+func (app *SubresourceAPIApp) DumpClusterProfilerArchiveHandler(request *restful.Request, response *restful.Response) {
+	if !app.clusterConfig.ClusterProfilerEnabled() {
+		response.WriteErrorString(http.StatusForbidden, "Unable to dump profiler results. \"ClusterProfiler\" feature gate must be enabled")
 		return
 	}
 
+	results, err := app.dumpClusterProfiler()
+	if err != nil {
+		response.WriteErrorString(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.AddHeader("Content-Type", "application/gzip")
+	response.AddHeader("Content-Disposition", "attachment; filename=\"cluster-profiler-results.tar.gz\"")
+	response.WriteHeader(http.StatusOK)
+	if err := writeClusterProfilerArchive(response, results); err != nil {
+		log.Log.Reason(err).Error("Failed to write the cluster profiler archive")
+	}
+}
+
+func writeClusterProfilerArchive(w io.Writer, results *v1.ClusterProfilerResults) error {
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	var components []string
+	for component := range results.ComponentResults {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+
+	now := time.Now()
+	for _, component := range components {
+		var files []string
+		for file := range results.ComponentResults[component].PprofData {
+			files = append(files, file)
+		}
+		sort.Strings(files)
+
+		for _, file := range files {
+			data := results.ComponentResults[component].PprofData[file]
+			header := &tar.Header{
+				Name:    path.Join("cluster-profiler-results", component, file),
+				Mode:    0644,
+				Size:    int64(len(data)),
+				ModTime: now,
+			}
+			if err := tarWriter.WriteHeader(header); err != nil {
+				return err
+			}
+			if _, err := tarWriter.Write(data); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+func (app *SubresourceAPIApp) dumpClusterProfiler() (*v1.ClusterProfilerResults, error) {
+	pods, err := app.getAllComponentPods()
+	if err != nil {
+		return nil, fmt.Errorf("Internal error while looking up component pods for profiling: %v", err)
+	}
+
+	if len(pods) == 0 {
+		return nil, fmt.Errorf("Internal error, no component pods found")
+	}
+
 	tr := &http.Transport{
 		TLSClientConfig: &tls.Config{
 			InsecureSkipVerify: true,
@@ -266,11 +343,10 @@ func (app *SubresourceAPIApp) DumpClusterProfilerHandler(request *restful.Reques
 	wg.Wait()
 	select {
 	case err := <-errorChan:
-		response.WriteErrorString(http.StatusInternalServerError, fmt.Sprintf("Internal error encountered: %v", err))
-		return
+		return nil, fmt.Errorf("Internal error encountered: %v", err)
 	default:
 		//no error
 	}
 
-	response.WriteAsJson(results)
+	return &results, nil
 }
@@ -20,9 +20,13 @@
 package rest
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"crypto/tls"
 	"flag"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -139,6 +143,7 @@ var _ = Describe("Cluster Profiler Subresources", func() {
 			table.Entry("start function", app.StartClusterProfilerHandler),
 			table.Entry("stop function", app.StopClusterProfilerHandler),
 			table.Entry("dump function", app.DumpClusterProfilerHandler),
+			table.Entry("dump archive function", app.DumpClusterProfilerArchiveHandler),
 		)
 		table.DescribeTable("should return successr when feature gate is enabled", func(fn func(*restful.Request, *restful.Response), cmd string) {
 
@@ -161,7 +166,41 @@ var _ = Describe("Cluster Profiler Subresources", func() {
 			table.Entry("start function", app.StartClusterProfilerHandler, "start"),
 			table.Entry("stop function", app.StopClusterProfilerHandler, "stop"),
 			table.Entry("dump function", app.DumpClusterProfilerHandler, "dump"),
+			table.Entry("dump archive function", app.DumpClusterProfilerArchiveHandler, "dump"),
 		)
+
+		It("should return the results of all components as archive", func() {
+			result := v1.ProfilerResult{
+				PprofData: map[string][]byte{
+					"cpu.pprof": []byte("cpu"),
+				},
+			}
+
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/dump-profiler"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, result),
+				),
+			)
+
+			enableFeatureGate(virtconfig.ClusterProfiler)
+			expectPodList()
+			app.DumpClusterProfilerArchiveHandler(request, response)
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(recorder.Header().Get("Content-Type")).To(Equal("application/gzip"))
+
+			gzipReader, err := gzip.NewReader(recorder.Body)
+			Expect(err).ToNot(HaveOccurred())
+			tarReader := tar.NewReader(gzipReader)
+			header, err := tarReader.Next()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(header.Name).To(Equal("cluster-profiler-results/virt-handler-123/cpu.pprof"))
+			data, err := ioutil.ReadAll(tarReader)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal("cpu"))
+			_, err = tarReader.Next()
+			Expect(err).To(Equal(io.EOF))
+		})
 	})
 
 	AfterEach(func() {
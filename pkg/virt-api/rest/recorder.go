@@ -0,0 +1,286 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	restful "github.com/emicklei/go-restful"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	clientutil "kubevirt.io/client-go/util"
+)
+
+type sessionKind string
+
+const (
+	consoleSession sessionKind = "console"
+	vncSession     sessionKind = "vnc"
+
+	recordingCredentialsTokenKey = "token"
+	recordingUploadTimeout       = 5 * time.Minute
+	// recordingMaxSize limits the temporary file of a recording, the rest of a longer session
+	// is not recorded
+	recordingMaxSize = 64 * 1024 * 1024
+)
+
+// recordingHeader is the header of an asciicast v2 recording
+type recordingHeader struct {
+	Version   int    `json:"version"`
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	Timestamp int64  `json:"timestamp"`
+	Title     string `json:"title,omitempty"`
+	// Encoding is set to base64 for binary streams like VNC
+	Encoding string `json:"encoding,omitempty"`
+}
+
+type uploadFunc func(recording *os.File, name string) error
+
+// sessionRecorder writes everything sent to and received from the virt-handler connection
+// as asciicast v2 events into a temporary file, which is uploaded in the background once the
+// session closes.
+type sessionRecorder struct {
+	lock      sync.Mutex
+	file      *os.File
+	size      int64
+	truncated bool
+	start     time.Time
+	binary    bool
+	name      string
+	upload    uploadFunc
+	closeOnce sync.Once
+}
+
+func newSessionRecorder(vmi *v1.VirtualMachineInstance, kind sessionKind, user string, upload uploadFunc) (*sessionRecorder, error) {
+	file, err := ioutil.TempFile("", fmt.Sprintf("%s-recording-", kind))
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	recorder := &sessionRecorder{
+		file:   file,
+		start:  start,
+		binary: kind == vncSession,
+		name:   fmt.Sprintf("%s/%s/%s-%d.cast", vmi.Namespace, vmi.Name, kind, start.UnixNano()),
+		upload: upload,
+	}
+	header := recordingHeader{
+		Version:   2,
+		Width:     80,
+		Height:    24,
+		Timestamp: start.Unix(),
+		Title:     fmt.Sprintf("%s session of %s/%s by %s", kind, vmi.Namespace, vmi.Name, user),
+	}
+	if recorder.binary {
+		header.Encoding = "base64"
+	}
+	if err := recorder.write(header); err != nil {
+		recorder.discard()
+		return nil, err
+	}
+	return recorder, nil
+}
+
+// write appends the value as a line to the recording, unless the recording would exceed recordingMaxSize
+func (r *sessionRecorder) write(v interface{}) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+	if r.size+int64(len(line)) > recordingMaxSize {
+		if !r.truncated {
+			r.truncated = true
+			log.Log.Warningf("Recording %s reached %d bytes, the rest of the session is not recorded", r.name, recordingMaxSize)
+		}
+		return nil
+	}
+	n, err := r.file.Write(line)
+	r.size += int64(n)
+	return err
+}
+
+func (r *sessionRecorder) record(eventType string, data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	payload := string(data)
+	if r.binary {
+		payload = base64.StdEncoding.EncodeToString(data)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	elapsed := time.Since(r.start).Seconds()
+	if err := r.write([]interface{}{elapsed, eventType, payload}); err != nil {
+		log.Log.Reason(err).Errorf("Failed to record %s", r.name)
+	}
+}
+
+// finish uploads the recording and removes the temporary file
+func (r *sessionRecorder) finish() {
+	r.closeOnce.Do(func() {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		defer r.discard()
+
+		if _, err := r.file.Seek(0, 0); err != nil {
+			log.Log.Reason(err).Errorf("Failed to read recording %s", r.name)
+			return
+		}
+		if err := r.upload(r.file, r.name); err != nil {
+			log.Log.Reason(err).Errorf("Failed to upload recording %s", r.name)
+			return
+		}
+		log.Log.V(4).Infof("Uploaded recording %s", r.name)
+	})
+}
+
+func (r *sessionRecorder) discard() {
+	r.file.Close()
+	os.Remove(r.file.Name())
+}
+
+// recordedConn records the output of the server as "o" and the input of the client as "i" events
+type recordedConn struct {
+	net.Conn
+	recorder *sessionRecorder
+}
+
+func (c *recordedConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	c.recorder.record("o", b[:n])
+	return n, err
+}
+
+func (c *recordedConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	c.recorder.record("i", b[:n])
+	return n, err
+}
+
+// Close closes the connection right away and uploads the recording in the background, the
+// session must not wait for the object store.
+func (c *recordedConn) Close() error {
+	err := c.Conn.Close()
+	go c.recorder.finish()
+	return err
+}
+
+// recordingDialer wraps the connections of the given dialer into a recordedConn, if the
+// recording of sessions of the given kind is configured.
+func (app *SubresourceAPIApp) recordingDialer(request *restful.Request, kind sessionKind, dial dialer) dialer {
+	if !app.clusterConfig.ConsoleRecordingEnabled() {
+		return dial
+	}
+	config := app.clusterConfig.GetConsoleRecordingConfiguration()
+	if config == nil || config.ObjectStoreURL == "" {
+		return dial
+	}
+	if kind == vncSession && (config.RecordVNC == nil || !*config.RecordVNC) {
+		return dial
+	}
+	user := app.requestUser(request)
+
+	return func(vmi *v1.VirtualMachineInstance) (net.Conn, *errors.StatusError) {
+		// do not let the user connect, if the session can't be recorded
+		recorder, err := newSessionRecorder(vmi, kind, user, app.recordingUploader(config))
+		if err != nil {
+			log.Log.Object(vmi).Reason(err).Errorf("Failed to start recording the %s session", kind)
+			return nil, errors.NewInternalError(fmt.Errorf("failed to start recording the %s session: %v", kind, err))
+		}
+		conn, statusErr := dial(vmi)
+		if statusErr != nil {
+			recorder.discard()
+			return nil, statusErr
+		}
+		return &recordedConn{Conn: conn, recorder: recorder}, nil
+	}
+}
+
+func (app *SubresourceAPIApp) recordingUploader(config *v1.ConsoleRecordingConfiguration) uploadFunc {
+	return func(recording *os.File, name string) error {
+		stat, err := recording.Stat()
+		if err != nil {
+			return err
+		}
+		url := strings.TrimSuffix(config.ObjectStoreURL, "/") + "/" + name
+		req, err := http.NewRequest(http.MethodPut, url, recording)
+		if err != nil {
+			return err
+		}
+		req.ContentLength = stat.Size()
+		req.Header.Set("Content-Type", "application/x-asciicast")
+
+		token, err := app.getRecordingToken()
+		if err != nil {
+			return err
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		client := &http.Client{Timeout: recordingUploadTimeout}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("object store responded with %s", resp.Status)
+		}
+		return nil
+	}
+}
+
+// getRecordingToken returns the upload token from the credentials secret, or nothing if there is no such secret
+func (app *SubresourceAPIApp) getRecordingToken() (string, error) {
+	namespace, err := clientutil.GetNamespace()
+	if err != nil {
+		return "", err
+	}
+	secretName := v1.ConsoleRecordingCredentialsSecretName
+	secret, err := app.virtCli.CoreV1().Secrets(namespace).Get(context.Background(), secretName, k8smetav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	token, ok := secret.Data[recordingCredentialsTokenKey]
+	if !ok {
+		return "", fmt.Errorf("secret %s has no %s key", secretName, recordingCredentialsTokenKey)
+	}
+	return strings.TrimSpace(string(token)), nil
+}
@@ -0,0 +1,220 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+
+	restful "github.com/emicklei/go-restful"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+type recording struct {
+	name    string
+	content string
+}
+
+var _ = Describe("Session recorder", func() {
+	var vmi *v1.VirtualMachineInstance
+	var uploaded chan recording
+
+	upload := func(file *os.File, name string) error {
+		content, err := ioutil.ReadAll(file)
+		Expect(err).ToNot(HaveOccurred())
+		uploaded <- recording{name: name, content: string(content)}
+		return nil
+	}
+
+	readEvents := func(recording string) (header recordingHeader, events [][]interface{}) {
+		scanner := bufio.NewScanner(strings.NewReader(recording))
+		Expect(scanner.Scan()).To(BeTrue())
+		Expect(json.Unmarshal(scanner.Bytes(), &header)).To(Succeed())
+		for scanner.Scan() {
+			var event []interface{}
+			Expect(json.Unmarshal(scanner.Bytes(), &event)).To(Succeed())
+			events = append(events, event)
+		}
+		return header, events
+	}
+
+	newRecordedPipe := func(kind sessionKind) (net.Conn, net.Conn) {
+		recorder, err := newSessionRecorder(vmi, kind, "admin", upload)
+		Expect(err).ToNot(HaveOccurred())
+		serverConn, serverPipe := net.Pipe()
+		return &recordedConn{Conn: serverConn, recorder: recorder}, serverPipe
+	}
+
+	BeforeEach(func() {
+		vmi = &v1.VirtualMachineInstance{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "testvmi"}}
+		uploaded = make(chan recording, 2)
+	})
+
+	It("should record and upload a console session", func() {
+		conn, server := newRecordedPipe(consoleSession)
+		go func() {
+			defer GinkgoRecover()
+			buf := make([]byte, 3)
+			_, err := server.Read(buf)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = server.Write([]byte("root"))
+			Expect(err).ToNot(HaveOccurred())
+		}()
+
+		_, err := conn.Write([]byte("\r\n\r"))
+		Expect(err).ToNot(HaveOccurred())
+		buf := make([]byte, 4)
+		_, err = conn.Read(buf)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(conn.Close()).To(Succeed())
+
+		var r recording
+		Eventually(uploaded).Should(Receive(&r))
+		Expect(r.name).To(HavePrefix("default/testvmi/console-"))
+		header, events := readEvents(r.content)
+		Expect(header.Version).To(Equal(2))
+		Expect(header.Title).To(ContainSubstring("by admin"))
+		Expect(header.Encoding).To(BeEmpty())
+		Expect(events).To(HaveLen(2))
+		Expect(events[0][1:]).To(Equal([]interface{}{"i", "\r\n\r"}))
+		Expect(events[1][1:]).To(Equal([]interface{}{"o", "root"}))
+	})
+
+	It("should encode VNC sessions in base64", func() {
+		conn, server := newRecordedPipe(vncSession)
+		go func() {
+			defer GinkgoRecover()
+			_, err := server.Write([]byte{0, 1, 255})
+			Expect(err).ToNot(HaveOccurred())
+		}()
+
+		buf := make([]byte, 3)
+		_, err := conn.Read(buf)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(conn.Close()).To(Succeed())
+		Expect(conn.Close()).To(Succeed())
+
+		var r recording
+		Eventually(uploaded).Should(Receive(&r))
+		header, events := readEvents(r.content)
+		Expect(header.Encoding).To(Equal("base64"))
+		Expect(events).To(HaveLen(1))
+		Expect(events[0][2]).To(Equal(base64.StdEncoding.EncodeToString([]byte{0, 1, 255})))
+		Consistently(uploaded).ShouldNot(Receive())
+	})
+
+	It("should stop recording once the recording reached its maximum size", func() {
+		recorder, err := newSessionRecorder(vmi, consoleSession, "admin", upload)
+		Expect(err).ToNot(HaveOccurred())
+		recorder.record("o", []byte("boot"))
+		recorder.size = recordingMaxSize - 10
+		recorder.record("o", []byte("login:"))
+		Expect(recorder.truncated).To(BeTrue())
+		recorder.finish()
+
+		var r recording
+		Expect(uploaded).To(Receive(&r))
+		_, events := readEvents(r.content)
+		Expect(events).To(HaveLen(1))
+		Expect(events[0][2]).To(Equal("boot"))
+	})
+
+	Context("recording dialer", func() {
+		var request *restful.Request
+		var dialed bool
+		var serverConn net.Conn
+
+		dial := func(vmi *v1.VirtualMachineInstance) (net.Conn, *errors.StatusError) {
+			dialed = true
+			return serverConn, nil
+		}
+
+		newApp := func(recording *v1.ConsoleRecordingConfiguration, featureGates ...string) *SubresourceAPIApp {
+			config, _, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+				DeveloperConfiguration: &v1.DeveloperConfiguration{FeatureGates: featureGates},
+				ConsoleRecording:       recording,
+			})
+			virtClient := kubecli.NewMockKubevirtClient(gomock.NewController(GinkgoT()))
+			virtClient.EXPECT().CoreV1().Return(fake.NewSimpleClientset(&k8sv1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "kubevirt", Name: v1.ConsoleRecordingCredentialsSecretName},
+				Data:       map[string][]byte{recordingCredentialsTokenKey: []byte("secret-token\n")},
+			}).CoreV1()).AnyTimes()
+			return &SubresourceAPIApp{clusterConfig: config, virtCli: virtClient}
+		}
+
+		BeforeEach(func() {
+			dialed = false
+			serverConn, _ = net.Pipe()
+			request = restful.NewRequest(httptest.NewRequest(http.MethodGet, "/console", nil))
+		})
+
+		It("should not record sessions if the feature gate is disabled", func() {
+			app := newApp(&v1.ConsoleRecordingConfiguration{ObjectStoreURL: "http://store"})
+			conn, err := app.recordingDialer(request, consoleSession, dial)(vmi)
+			Expect(err).To(BeNil())
+			Expect(conn).To(BeIdenticalTo(serverConn))
+		})
+
+		It("should not record VNC sessions unless requested", func() {
+			app := newApp(&v1.ConsoleRecordingConfiguration{ObjectStoreURL: "http://store"}, virtconfig.ConsoleRecordingGate)
+			conn, err := app.recordingDialer(request, vncSession, dial)(vmi)
+			Expect(err).To(BeNil())
+			Expect(conn).To(BeIdenticalTo(serverConn))
+		})
+
+		It("should upload recorded sessions to the object store", func() {
+			store := ghttp.NewServer()
+			defer store.Close()
+			store.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodPut, MatchRegexp("^/bucket/default/testvmi/console-[0-9]+.cast$")),
+				ghttp.VerifyContentType("application/x-asciicast"),
+				ghttp.VerifyHeaderKV("Authorization", "Bearer secret-token"),
+				ghttp.RespondWith(http.StatusOK, nil),
+			))
+
+			app := newApp(&v1.ConsoleRecordingConfiguration{ObjectStoreURL: store.URL() + "/bucket/"}, virtconfig.ConsoleRecordingGate)
+			conn, err := app.recordingDialer(request, consoleSession, dial)(vmi)
+			Expect(err).To(BeNil())
+			Expect(dialed).To(BeTrue())
+			Expect(conn).To(BeAssignableToTypeOf(&recordedConn{}))
+			Expect(conn.Close()).To(Succeed())
+			Eventually(store.ReceivedRequests).Should(HaveLen(1))
+		})
+	})
+})
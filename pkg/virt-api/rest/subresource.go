@@ -31,13 +31,16 @@ import (
 	"sync"
 
 	"github.com/emicklei/go-restful"
+	authorization "k8s.io/api/authorization/v1"
 	v12 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/json"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/yaml"
 
 	"kubevirt.io/kubevirt/pkg/util/status"
@@ -46,11 +49,15 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/controller"
+	pvctypes "kubevirt.io/kubevirt/pkg/util/types"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 const defaultProfilerComponentPort = 8443
 
+// cdiGroup is the API group of the CDI DataVolumes
+const cdiGroup = "cdi.kubevirt.io"
+
 type SubresourceAPIApp struct {
 	virtCli                 kubecli.KubevirtClient
 	consoleServerPort       int
@@ -59,9 +66,14 @@ type SubresourceAPIApp struct {
 	credentialsLock         *sync.Mutex
 	statusUpdater           *status.VMStatusUpdater
 	clusterConfig           *virtconfig.ClusterConfig
+	authorizor              VirtApiAuthorizor
+	auditLog                auditLog
+	expandSpec              SpecExpander
+	expandVMISpec           VMISpecExpander
+	validateVMISpec         VMISpecValidator
 }
 
-func NewSubresourceAPIApp(virtCli kubecli.KubevirtClient, consoleServerPort int, tlsConfiguration *tls.Config, clusterConfig *virtconfig.ClusterConfig) *SubresourceAPIApp {
+func NewSubresourceAPIApp(virtCli kubecli.KubevirtClient, consoleServerPort int, tlsConfiguration *tls.Config, clusterConfig *virtconfig.ClusterConfig, authorizor VirtApiAuthorizor, expandSpec SpecExpander, expandVMISpec VMISpecExpander, validateVMISpec VMISpecValidator) *SubresourceAPIApp {
 	return &SubresourceAPIApp{
 		virtCli:                 virtCli,
 		consoleServerPort:       consoleServerPort,
@@ -70,6 +82,10 @@ func NewSubresourceAPIApp(virtCli kubecli.KubevirtClient, consoleServerPort int,
 		handlerTLSConfiguration: tlsConfiguration,
 		statusUpdater:           status.NewVMStatusUpdater(virtCli),
 		clusterConfig:           clusterConfig,
+		authorizor:              authorizor,
+		expandSpec:              expandSpec,
+		expandVMISpec:           expandVMISpec,
+		validateVMISpec:         validateVMISpec,
 	}
 }
 
@@ -94,6 +110,36 @@ func (app *SubresourceAPIApp) prepareConnection(request *restful.Request, valida
 	return
 }
 
+// requestUser returns the user who sent the request, which the kube-apiserver passes on in one of the
+// request headers the authorizer inspects
+func (app *SubresourceAPIApp) requestUser(request *restful.Request) string {
+	headers := []string{userHeader}
+	if app.authorizor != nil {
+		headers = app.authorizor.GetUserHeaders()
+	}
+	for _, header := range headers {
+		if user := request.Request.Header.Get(header); user != "" {
+			return user
+		}
+	}
+	return ""
+}
+
+// requestGroups returns the groups of the user who sent the request, from the first of the group
+// headers of the authorizer which is set
+func (app *SubresourceAPIApp) requestGroups(request *restful.Request) []string {
+	headers := []string{groupHeader}
+	if app.authorizor != nil {
+		headers = app.authorizor.GetGroupHeaders()
+	}
+	for _, header := range headers {
+		if groups := request.Request.Header.Values(header); len(groups) > 0 {
+			return groups
+		}
+	}
+	return nil
+}
+
 func (app *SubresourceAPIApp) fetchAndValidateVirtualMachineInstance(namespace, vmiName string, validate validation) (vmi *v1.VirtualMachineInstance, statusError *errors.StatusError) {
 	vmi, statusError = app.FetchVirtualMachineInstance(namespace, vmiName)
 	if statusError != nil {
@@ -107,13 +153,17 @@ func (app *SubresourceAPIApp) fetchAndValidateVirtualMachineInstance(namespace,
 	return
 }
 
-func (app *SubresourceAPIApp) putRequestHandler(request *restful.Request, response *restful.Response, validate validation, getVirtHandlerURL URLResolver) {
+func (app *SubresourceAPIApp) putRequestHandler(request *restful.Request, response *restful.Response, validate validation, getVirtHandlerURL URLResolver, dryRun bool) {
 	_, url, conn, statusErr := app.prepareConnection(request, validate, getVirtHandlerURL)
 	if statusErr != nil {
 		writeError(statusErr, response)
 		return
 	}
 
+	if dryRun {
+		return
+	}
+
 	err := conn.Put(url, app.handlerTLSConfiguration)
 	if err != nil {
 		writeError(errors.NewInternalError(err), response)
@@ -260,6 +310,47 @@ func (app *SubresourceAPIApp) MigrateVMRequestHandler(request *restful.Request,
 	response.WriteHeader(http.StatusAccepted)
 }
 
+// MigrateCancelVMRequestHandler aborts the migrations of a VirtualMachine which are in progress by deleting them
+func (app *SubresourceAPIApp) MigrateCancelVMRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	if _, statusErr := app.fetchVirtualMachine(name, namespace); statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	migrations, err := app.virtCli.VirtualMachineInstanceMigration(namespace).List(&k8smetav1.ListOptions{
+		LabelSelector: labels.Set{v1.MigrationSelectorLabel: name}.String(),
+	})
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	inProgress := false
+	for _, migration := range migrations.Items {
+		if migration.IsFinal() {
+			continue
+		}
+		inProgress = true
+		if migration.DeletionTimestamp != nil {
+			continue
+		}
+		err := app.virtCli.VirtualMachineInstanceMigration(namespace).Delete(migration.Name, &k8smetav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			writeError(errors.NewInternalError(err), response)
+			return
+		}
+	}
+	if !inProgress {
+		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("VM has no migration in progress")), response)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
 func (app *SubresourceAPIApp) RestartVMRequestHandler(request *restful.Request, response *restful.Response) {
 	// RunStrategyHalted         -> doesn't make sense
 	// RunStrategyManual         -> send restart request
@@ -604,14 +695,311 @@ func (app *SubresourceAPIApp) StopVMRequestHandler(request *restful.Request, res
 	response.WriteHeader(http.StatusAccepted)
 }
 
+// RenameVMRequestHandler asks virt-controller to recreate a stopped VirtualMachine under a new name.
+func (app *SubresourceAPIApp) RenameVMRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	opts := &v1.RenameOptions{}
+	if request.Request.Body != nil {
+		defer request.Request.Body.Close()
+		err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+		switch err {
+		case io.EOF, nil:
+			break
+		default:
+			writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+			return
+		}
+	}
+
+	if opts.NewName == "" {
+		writeError(errors.NewBadRequest("RenameOptions requires newName to be set"), response)
+		return
+	}
+	if opts.NewName == name {
+		writeError(errors.NewBadRequest("The new name of the VM must differ from the current one"), response)
+		return
+	}
+	if errs := k8svalidation.IsDNS1123Label(opts.NewName); len(errs) != 0 {
+		writeError(errors.NewBadRequest(fmt.Sprintf("Invalid new name %s: %s", opts.NewName, strings.Join(errs, ", "))), response)
+		return
+	}
+
+	vm, statusErr := app.fetchVirtualMachine(name, namespace)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	// The renamed VM must not be started before the objects owned by the VM are handed over to it
+	runStrategy, err := vm.RunStrategy()
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+	if runStrategy != v1.RunStrategyHalted && runStrategy != v1.RunStrategyManual {
+		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support renaming the VM", runStrategy)), response)
+		return
+	}
+
+	vmi, err := app.virtCli.VirtualMachineInstance(namespace).Get(name, &k8smetav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+	if err == nil && !vmi.IsFinal() {
+		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("VM must be stopped to be renamed")), response)
+		return
+	}
+
+	_, err = app.virtCli.VirtualMachine(namespace).Get(opts.NewName, &k8smetav1.GetOptions{})
+	if err == nil {
+		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("a VM named %s already exists", opts.NewName)), response)
+		return
+	} else if !errors.IsNotFound(err) {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	bodyString, err := getChangeRequestJson(vm, v1.VirtualMachineStateChangeRequest{
+		Action: v1.RenameRequest,
+		Data:   map[string]string{v1.RenameRequestDataNewNameKey: opts.NewName},
+	})
+	if err != nil {
+		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, err), response)
+		return
+	}
+	log.Log.Object(vm).V(4).Infof("Patching VM status: %s", bodyString)
+	if err := app.statusUpdater.PatchStatus(vm, types.JSONPatchType, []byte(bodyString)); err != nil {
+		if strings.Contains(err.Error(), "jsonpatch test operation does not apply") {
+			writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, err), response)
+		} else {
+			writeError(errors.NewInternalError(err), response)
+		}
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+// MoveVMRequestHandler asks virt-controller to recreate a stopped VirtualMachine, together with clones
+// of its volumes, in another namespace.
+func (app *SubresourceAPIApp) MoveVMRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	opts := &v1.MoveOptions{}
+	if request.Request.Body != nil {
+		defer request.Request.Body.Close()
+		err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+		switch err {
+		case io.EOF, nil:
+			break
+		default:
+			writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+			return
+		}
+	}
+
+	if opts.NewNamespace == "" {
+		writeError(errors.NewBadRequest("MoveOptions requires newNamespace to be set"), response)
+		return
+	}
+	if opts.NewNamespace == namespace {
+		writeError(errors.NewBadRequest("The new namespace of the VM must differ from the current one"), response)
+		return
+	}
+	if errs := k8svalidation.IsDNS1123Label(opts.NewNamespace); len(errs) != 0 {
+		writeError(errors.NewBadRequest(fmt.Sprintf("Invalid new namespace %s: %s", opts.NewNamespace, strings.Join(errs, ", "))), response)
+		return
+	}
+
+	// virt-controller creates the VM and the clones of its volumes with its own permissions. Being allowed
+	// to move the VM away implies neither being allowed to create them in the target namespace, nor
+	// being allowed to clone the volumes, which CDI would check otherwise.
+	authorize := func(attributes *authorization.ResourceAttributes, what string) bool {
+		allowed, reason, err := app.authorizor.AuthorizeResourceAccess(request, attributes)
+		if err != nil {
+			writeError(errors.NewInternalError(err), response)
+			return false
+		}
+		if !allowed {
+			writeError(errors.NewForbidden(v1.Resource("virtualmachine"), name, fmt.Errorf("not allowed to %s: %s", what, reason)), response)
+			return false
+		}
+		return true
+	}
+	if !authorize(&authorization.ResourceAttributes{
+		Namespace: opts.NewNamespace,
+		Verb:      "create",
+		Group:     v1.GroupVersion.Group,
+		Resource:  "virtualmachines",
+	}, fmt.Sprintf("create VMs in namespace %s", opts.NewNamespace)) {
+		return
+	}
+	if !authorize(&authorization.ResourceAttributes{
+		Namespace: opts.NewNamespace,
+		Verb:      "create",
+		Group:     cdiGroup,
+		Resource:  "datavolumes",
+	}, fmt.Sprintf("create DataVolumes in namespace %s", opts.NewNamespace)) {
+		return
+	}
+
+	namespaces, err := app.virtCli.CoreV1().Namespaces().List(context.Background(), k8smetav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("metadata.name", opts.NewNamespace).String(),
+	})
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+	if len(namespaces.Items) == 0 {
+		writeError(errors.NewBadRequest(fmt.Sprintf("Namespace %s does not exist", opts.NewNamespace)), response)
+		return
+	}
+
+	vm, statusErr := app.fetchVirtualMachine(name, namespace)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	// The moved VM must not be started before its volumes are cloned to the new namespace
+	runStrategy, err := vm.RunStrategy()
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+	if runStrategy != v1.RunStrategyHalted && runStrategy != v1.RunStrategyManual {
+		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support moving the VM", runStrategy)), response)
+		return
+	}
+
+	vmi, err := app.virtCli.VirtualMachineInstance(namespace).Get(name, &k8smetav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+	if err == nil && !vmi.IsFinal() {
+		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("VM must be stopped to be moved")), response)
+		return
+	}
+
+	// the same check as CDI does for cross namespace clones
+	for _, claimName := range movedClaimNames(vm) {
+		if !authorize(&authorization.ResourceAttributes{
+			Namespace:   namespace,
+			Name:        claimName,
+			Verb:        "create",
+			Group:       cdiGroup,
+			Resource:    "datavolumes",
+			Subresource: "source",
+		}, fmt.Sprintf("clone PVC %s", claimName)) {
+			return
+		}
+	}
+
+	_, err = app.virtCli.VirtualMachine(opts.NewNamespace).Get(name, &k8smetav1.GetOptions{})
+	if err == nil {
+		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("a VM named %s already exists in namespace %s", name, opts.NewNamespace)), response)
+		return
+	} else if !errors.IsNotFound(err) {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	bodyString, err := getChangeRequestJson(vm, v1.VirtualMachineStateChangeRequest{
+		Action: v1.MoveRequest,
+		Data:   map[string]string{v1.MoveRequestDataNewNamespaceKey: opts.NewNamespace},
+	})
+	if err != nil {
+		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, err), response)
+		return
+	}
+	log.Log.Object(vm).V(4).Infof("Patching VM status: %s", bodyString)
+	if err := app.statusUpdater.PatchStatus(vm, types.JSONPatchType, []byte(bodyString)); err != nil {
+		if strings.Contains(err.Error(), "jsonpatch test operation does not apply") {
+			writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, err), response)
+		} else {
+			writeError(errors.NewInternalError(err), response)
+		}
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+// movedClaimNames returns the names of the PVCs which are cloned when the VM is moved
+func movedClaimNames(vm *v1.VirtualMachine) []string {
+	var names []string
+	seen := map[string]bool{}
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, template := range vm.Spec.DataVolumeTemplates {
+		add(template.Name)
+	}
+	if vm.Spec.Template == nil {
+		return names
+	}
+	for _, volume := range vm.Spec.Template.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil {
+			add(volume.PersistentVolumeClaim.ClaimName)
+		} else if volume.DataVolume != nil {
+			add(volume.DataVolume.Name)
+		}
+	}
+	return names
+}
+
+// decodeDryRun reads the dry run directive of the pause and unpause options in the request body
+func decodeDryRun(request *restful.Request, options interface{}, dryRunOf func() []string) (bool, *errors.StatusError) {
+	if request.Request.Body == nil {
+		return false, nil
+	}
+	err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(options)
+	switch err {
+	case io.EOF, nil:
+		break
+	default:
+		return false, errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err))
+	}
+
+	dryRun := false
+	for _, directive := range dryRunOf() {
+		if directive != k8smetav1.DryRunAll {
+			return false, errors.NewBadRequest(fmt.Sprintf("Invalid dryRun directive %s, only %s is supported", directive, k8smetav1.DryRunAll))
+		}
+		dryRun = true
+	}
+	return dryRun, nil
+}
+
+// isLivenessProbeSuspendedWhenPaused reports if the liveness probe is run by virt-probe, which
+// skips probing paused VMIs. HTTP and TCP probes are run by the kubelet and would fail.
+func isLivenessProbeSuspendedWhenPaused(vmi *v1.VirtualMachineInstance) bool {
+	probe := vmi.Spec.LivenessProbe
+	return probe == nil || probe.GuestAgentPing != nil || probe.Handler.Exec != nil
+}
+
 func (app *SubresourceAPIApp) PauseVMIRequestHandler(request *restful.Request, response *restful.Response) {
+	options := &v1.PauseOptions{}
+	dryRun, statusErr := decodeDryRun(request, options, func() []string { return options.DryRun })
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
 
 	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
 		if vmi.Status.Phase != v1.Running {
 			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VM is not running"))
 		}
-		if vmi.Spec.LivenessProbe != nil {
-			return errors.NewForbidden(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("Pausing VMIs with LivenessProbe is currently not supported"))
+		if !isLivenessProbeSuspendedWhenPaused(vmi) {
+			return errors.NewForbidden(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("Pausing VMIs with a HTTP or TCP LivenessProbe is currently not supported"))
 		}
 		condManager := controller.NewVirtualMachineInstanceConditionManager()
 		if condManager.HasCondition(vmi, v1.VirtualMachineInstancePaused) {
@@ -624,10 +1012,16 @@ func (app *SubresourceAPIApp) PauseVMIRequestHandler(request *restful.Request, r
 		return conn.PauseURI(vmi)
 	}
 
-	app.putRequestHandler(request, response, validate, getURL)
+	app.putRequestHandler(request, response, validate, getURL, dryRun)
 }
 
 func (app *SubresourceAPIApp) UnpauseVMIRequestHandler(request *restful.Request, response *restful.Response) {
+	options := &v1.UnpauseOptions{}
+	dryRun, statusErr := decodeDryRun(request, options, func() []string { return options.DryRun })
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
 
 	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
 		if vmi.Status.Phase != v1.Running {
@@ -642,7 +1036,7 @@ func (app *SubresourceAPIApp) UnpauseVMIRequestHandler(request *restful.Request,
 	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
 		return conn.UnpauseURI(vmi)
 	}
-	app.putRequestHandler(request, response, validate, getURL)
+	app.putRequestHandler(request, response, validate, getURL, dryRun)
 
 }
 
@@ -660,7 +1054,7 @@ func (app *SubresourceAPIApp) FreezeVMIRequestHandler(request *restful.Request,
 		return conn.FreezeURI(vmi)
 	}
 
-	app.putRequestHandler(request, response, validate, getURL)
+	app.putRequestHandler(request, response, validate, getURL, false)
 }
 
 func (app *SubresourceAPIApp) UnfreezeVMIRequestHandler(request *restful.Request, response *restful.Response) {
@@ -674,7 +1068,7 @@ func (app *SubresourceAPIApp) UnfreezeVMIRequestHandler(request *restful.Request
 	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
 		return conn.UnfreezeURI(vmi)
 	}
-	app.putRequestHandler(request, response, validate, getURL)
+	app.putRequestHandler(request, response, validate, getURL, false)
 
 }
 
@@ -864,6 +1258,64 @@ func (app *SubresourceAPIApp) FilesystemList(request *restful.Request, response
 	response.WriteEntity(filesystemList)
 }
 
+// headers which are passed through between the client and virt-handler when streaming a memory dump
+var memoryDumpRequestHeaders = []string{"Range", "If-Range"}
+var memoryDumpResponseHeaders = []string{"Accept-Ranges", "Content-Disposition", "Content-Length", "Content-Range", "Content-Type", "ETag", "Last-Modified"}
+
+// MemoryDumpRequestHandler captures a memory dump of the guest through virt-handler and streams it to the client.
+// Range requests resume the download of the previously captured dump.
+func (app *SubresourceAPIApp) MemoryDumpRequestHandler(request *restful.Request, response *restful.Response) {
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if vmi.Status.Phase != v1.Running {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+		}
+		return nil
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.MemoryDumpURI(vmi)
+	}
+
+	_, url, _, statusErr := app.prepareConnection(request, validate, getURL)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	handlerRequest, err := http.NewRequestWithContext(request.Request.Context(), http.MethodPut, url, nil)
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+	for _, header := range memoryDumpRequestHeaders {
+		if value := request.HeaderParameter(header); value != "" {
+			handlerRequest.Header.Set(header, value)
+		}
+	}
+
+	// no timeout, capturing and transferring the dump takes as long as the guest memory is big
+	client := http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: app.handlerTLSConfiguration,
+		},
+	}
+	handlerResponse, err := client.Do(handlerRequest)
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+	defer handlerResponse.Body.Close()
+
+	for _, header := range memoryDumpResponseHeaders {
+		if value := handlerResponse.Header.Get(header); value != "" {
+			response.Header().Set(header, value)
+		}
+	}
+	response.WriteHeader(handlerResponse.StatusCode)
+	if _, err := io.Copy(response, handlerResponse.Body); err != nil {
+		log.Log.Reason(err).Error("error streaming the memory dump")
+	}
+}
+
 func generateVMVolumeRequestPatch(vm *v1.VirtualMachine, volumeRequest *v1.VirtualMachineVolumeRequest) (string, error) {
 	verb := getPatchVerb(vm.Status.VolumeRequests)
 	vmCopy := vm.DeepCopy()
@@ -1177,6 +1629,195 @@ func (app *SubresourceAPIApp) vmVolumePatchStatus(name, namespace string, volume
 	return nil
 }
 
+// memoryDumpOverhead is the space needed on the memory dump PVC in addition to the guest memory
+var memoryDumpOverhead = resource.MustParse("100Mi")
+
+// MemoryDumpVMRequestHandler associates a PVC with the VM and dumps the memory of the running VMI into it.
+func (app *SubresourceAPIApp) MemoryDumpVMRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	if !app.clusterConfig.HotplugVolumesEnabled() {
+		writeError(errors.NewBadRequest("Unable to dump the memory because HotplugVolumes feature gate is not enabled."), response)
+		return
+	}
+
+	opts := &v1.VirtualMachineMemoryDumpRequest{}
+	if request.Request.Body != nil {
+		defer request.Request.Body.Close()
+		err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+		switch err {
+		case io.EOF, nil:
+			break
+		default:
+			writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+			return
+		}
+	} else {
+		writeError(errors.NewBadRequest("Request with no body, a claim name is expected as the request body"), response)
+		return
+	}
+
+	if opts.ClaimName == "" {
+		writeError(errors.NewBadRequest("VirtualMachineMemoryDumpRequest requires claimName to be set"), response)
+		return
+	}
+
+	vm, statusErr := app.fetchVirtualMachine(name, namespace)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	vmi, statusErr := app.FetchVirtualMachineInstance(namespace, name)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+	if !vmi.IsRunning() {
+		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("VM is not running")), response)
+		return
+	}
+
+	if statusErr := app.validateMemoryDumpClaim(vmi, opts.ClaimName); statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	patch, err := generateVMMemoryDumpRequestPatch(vm, &v1.VirtualMachineMemoryDumpRequest{
+		ClaimName: opts.ClaimName,
+		Phase:     v1.MemoryDumpAssociating,
+	})
+	if err != nil {
+		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, err), response)
+		return
+	}
+
+	if statusErr := app.patchMemoryDumpRequest(vm, patch); statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+// RemoveMemoryDumpVMRequestHandler dissociates the memory dump PVC from the VM.
+func (app *SubresourceAPIApp) RemoveMemoryDumpVMRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	if !app.clusterConfig.HotplugVolumesEnabled() {
+		writeError(errors.NewBadRequest("Unable to remove the memory dump because HotplugVolumes feature gate is not enabled."), response)
+		return
+	}
+
+	vm, statusErr := app.fetchVirtualMachine(name, namespace)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	existing := vm.Status.MemoryDumpRequest
+	if existing == nil {
+		writeError(errors.NewBadRequest("No memory dump is associated with the VM"), response)
+		return
+	}
+
+	memoryDumpRequest := existing.DeepCopy()
+	memoryDumpRequest.Remove = true
+	memoryDumpRequest.Phase = v1.MemoryDumpDissociating
+	patch, err := generateVMMemoryDumpRequestPatch(vm, memoryDumpRequest)
+	if err != nil {
+		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, err), response)
+		return
+	}
+
+	if statusErr := app.patchMemoryDumpRequest(vm, patch); statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+// validateMemoryDumpClaim verifies that the claim exists, is large enough to hold the guest memory
+// and is not used by the VMI for anything else.
+func (app *SubresourceAPIApp) validateMemoryDumpClaim(vmi *v1.VirtualMachineInstance, claimName string) *errors.StatusError {
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.MemoryDump == nil && pvctypes.PVCNameFromVirtVolume(&volume) == claimName {
+			return errors.NewConflict(v1.Resource("virtualmachine"), vmi.Name, fmt.Errorf("PVC %s is already used by volume %s", claimName, volume.Name))
+		}
+	}
+
+	pvc, err := app.virtCli.CoreV1().PersistentVolumeClaims(vmi.Namespace).Get(context.Background(), claimName, k8smetav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return errors.NewNotFound(v12.Resource("persistentvolumeclaim"), claimName)
+	} else if err != nil {
+		return errors.NewInternalError(err)
+	}
+
+	capacity, exists := pvc.Status.Capacity[v12.ResourceStorage]
+	if !exists {
+		capacity = pvc.Spec.Resources.Requests[v12.ResourceStorage]
+	}
+	required := memoryDumpSize(vmi)
+	if capacity.Cmp(required) < 0 {
+		return errors.NewBadRequest(fmt.Sprintf("PVC %s is too small to hold the memory dump, at least %s are required", claimName, required.String()))
+	}
+	return nil
+}
+
+// memoryDumpSize returns the space needed on a PVC to hold the memory dump of the VMI
+func memoryDumpSize(vmi *v1.VirtualMachineInstance) resource.Quantity {
+	size := vmi.Spec.Domain.Resources.Requests.Memory().DeepCopy()
+	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.Guest != nil {
+		size = vmi.Spec.Domain.Memory.Guest.DeepCopy()
+	}
+	size.Add(memoryDumpOverhead)
+	return size
+}
+
+func generateVMMemoryDumpRequestPatch(vm *v1.VirtualMachine, memoryDumpRequest *v1.VirtualMachineMemoryDumpRequest) (string, error) {
+	existing := vm.Status.MemoryDumpRequest
+	if existing != nil {
+		switch existing.Phase {
+		case v1.MemoryDumpAssociating, v1.MemoryDumpInProgress, v1.MemoryDumpUnmounting:
+			return "", fmt.Errorf("memory dump to PVC %s is in progress", existing.ClaimName)
+		case v1.MemoryDumpDissociating:
+			return "", fmt.Errorf("removal of the memory dump PVC %s is in progress", existing.ClaimName)
+		}
+		if existing.ClaimName != memoryDumpRequest.ClaimName {
+			return "", fmt.Errorf("PVC %s is associated with the VM for memory dumps, remove it first", existing.ClaimName)
+		}
+	}
+
+	newJson, err := json.Marshal(memoryDumpRequest)
+	if err != nil {
+		return "", err
+	}
+	if existing == nil {
+		return fmt.Sprintf(`[{ "op": "add", "path": "/status/memoryDumpRequest", "value": %s}]`, string(newJson)), nil
+	}
+	oldJson, err := json.Marshal(existing)
+	if err != nil {
+		return "", err
+	}
+	test := fmt.Sprintf(`{ "op": "test", "path": "/status/memoryDumpRequest", "value": %s}`, string(oldJson))
+	update := fmt.Sprintf(`{ "op": "replace", "path": "/status/memoryDumpRequest", "value": %s}`, string(newJson))
+	return fmt.Sprintf("[%s, %s]", test, update), nil
+}
+
+func (app *SubresourceAPIApp) patchMemoryDumpRequest(vm *v1.VirtualMachine, patch string) *errors.StatusError {
+	log.Log.Object(vm).V(4).Infof("Patching VM status: %s", patch)
+	if err := app.statusUpdater.PatchStatus(vm, types.JSONPatchType, []byte(patch)); err != nil {
+		if strings.Contains(err.Error(), "jsonpatch test operation does not apply") {
+			return errors.NewConflict(v1.Resource("virtualmachine"), vm.Name, err)
+		}
+		return errors.NewInternalError(fmt.Errorf("unable to patch vm status: %v", err))
+	}
+	return nil
+}
+
 // VMAddVolumeRequestHandler handles the subresource for hot plugging a volume and disk.
 func (app *SubresourceAPIApp) VMAddVolumeRequestHandler(request *restful.Request, response *restful.Response) {
 	app.addVolumeRequestHandler(request, response, false)
@@ -1196,3 +1837,182 @@ func (app *SubresourceAPIApp) VMIAddVolumeRequestHandler(request *restful.Reques
 func (app *SubresourceAPIApp) VMIRemoveVolumeRequestHandler(request *restful.Request, response *restful.Response) {
 	app.removeVolumeRequestHandler(request, response, true)
 }
+
+// applyMediaChange ejects or inserts the media of the CD-ROM selected by the options in the given spec
+func applyMediaChange(spec *v1.VirtualMachineInstanceSpec, opts *v1.MediaChangeOptions) error {
+	diskIndex := -1
+	for i, disk := range spec.Domain.Devices.Disks {
+		if disk.Name == opts.Name {
+			diskIndex = i
+			break
+		}
+	}
+	if diskIndex < 0 {
+		return fmt.Errorf("Unable to change the media of [%s] because the disk does not exist", opts.Name)
+	}
+	cdrom := spec.Domain.Devices.Disks[diskIndex].CDRom
+	if cdrom == nil {
+		return fmt.Errorf("Unable to change the media of [%s] because the disk is not a CD-ROM", opts.Name)
+	}
+
+	if opts.VolumeSource == nil {
+		cdrom.Tray = v1.TrayStateOpen
+		return nil
+	}
+
+	volumeSource := v1.VolumeSource{
+		PersistentVolumeClaim: opts.VolumeSource.PersistentVolumeClaim,
+		DataVolume:            opts.VolumeSource.DataVolume,
+	}
+	for i, volume := range spec.Volumes {
+		if volume.Name == opts.Name {
+			spec.Volumes[i].VolumeSource = volumeSource
+			cdrom.Tray = v1.TrayStateClosed
+			return nil
+		}
+	}
+	spec.Volumes = append(spec.Volumes, v1.Volume{Name: opts.Name, VolumeSource: volumeSource})
+	cdrom.Tray = v1.TrayStateClosed
+	return nil
+}
+
+func generateMediaChangePatch(specPath string, spec *v1.VirtualMachineInstanceSpec, opts *v1.MediaChangeOptions) (string, error) {
+	newSpec := spec.DeepCopy()
+	if err := applyMediaChange(newSpec, opts); err != nil {
+		return "", err
+	}
+
+	oldVolumesJson, err := json.Marshal(spec.Volumes)
+	if err != nil {
+		return "", err
+	}
+	newVolumesJson, err := json.Marshal(newSpec.Volumes)
+	if err != nil {
+		return "", err
+	}
+	oldDisksJson, err := json.Marshal(spec.Domain.Devices.Disks)
+	if err != nil {
+		return "", err
+	}
+	newDisksJson, err := json.Marshal(newSpec.Domain.Devices.Disks)
+	if err != nil {
+		return "", err
+	}
+
+	testVolumes := fmt.Sprintf(`{ "op": "test", "path": "%s/volumes", "value": %s}`, specPath, string(oldVolumesJson))
+	updateVolumes := fmt.Sprintf(`{ "op": "replace", "path": "%s/volumes", "value": %s}`, specPath, string(newVolumesJson))
+
+	testDisks := fmt.Sprintf(`{ "op": "test", "path": "%s/domain/devices/disks", "value": %s}`, specPath, string(oldDisksJson))
+	updateDisks := fmt.Sprintf(`{ "op": "replace", "path": "%s/domain/devices/disks", "value": %s}`, specPath, string(newDisksJson))
+
+	return fmt.Sprintf("[%s, %s, %s, %s]", testVolumes, testDisks, updateVolumes, updateDisks), nil
+}
+
+// VMIMediaChangeRequestHandler handles the subresource for ejecting or inserting the media of a CD-ROM.
+// The change is also applied to the template of the owning VirtualMachine, so that it survives restarts.
+func (app *SubresourceAPIApp) VMIMediaChangeRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	opts := &v1.MediaChangeOptions{}
+	if request.Request.Body != nil {
+		defer request.Request.Body.Close()
+		err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+		switch err {
+		case io.EOF, nil:
+			break
+		default:
+			writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+			return
+		}
+	} else {
+		writeError(errors.NewBadRequest("Request with no body, a CD-ROM name is expected as the request body"), response)
+		return
+	}
+
+	if opts.Name == "" {
+		writeError(errors.NewBadRequest("MediaChangeOptions requires name to be set"), response)
+		return
+	}
+	if opts.VolumeSource != nil {
+		if !app.clusterConfig.HotplugVolumesEnabled() {
+			writeError(errors.NewBadRequest("Unable to insert media because HotplugVolumes feature gate is not enabled."), response)
+			return
+		}
+		if opts.VolumeSource.DataVolume != nil {
+			opts.VolumeSource.DataVolume.Hotpluggable = true
+		} else if opts.VolumeSource.PersistentVolumeClaim != nil {
+			opts.VolumeSource.PersistentVolumeClaim.Hotpluggable = true
+		} else {
+			writeError(errors.NewBadRequest("MediaChangeOptions requires a PersistentVolumeClaim or DataVolume to insert"), response)
+			return
+		}
+	}
+
+	vmi, statErr := app.FetchVirtualMachineInstance(namespace, name)
+	if statErr != nil {
+		writeError(statErr, response)
+		return
+	}
+	if !vmi.IsRunning() {
+		writeError(errors.NewConflict(v1.Resource("virtualmachineinstance"), name, fmt.Errorf("VMI is not running")), response)
+		return
+	}
+
+	patch, err := generateMediaChangePatch("/spec", &vmi.Spec, opts)
+	if err != nil {
+		writeError(errors.NewBadRequest(err.Error()), response)
+		return
+	}
+	log.Log.Object(vmi).V(4).Infof("Patching VMI: %s", patch)
+	if _, err := app.virtCli.VirtualMachineInstance(namespace).Patch(name, types.JSONPatchType, []byte(patch)); err != nil {
+		log.Log.Object(vmi).V(1).Errorf("unable to patch vmi: %v", err)
+		if statErr, ok := err.(*errors.StatusError); ok && errors.IsInvalid(err) {
+			writeError(statErr, response)
+			return
+		}
+		writeError(errors.NewInternalError(fmt.Errorf("unable to patch vmi: %v", err)), response)
+		return
+	}
+
+	if owner := k8smetav1.GetControllerOf(vmi); owner != nil && owner.Kind == v1.VirtualMachineGroupVersionKind.Kind {
+		if statErr := app.vmMediaChangePatch(owner.Name, namespace, opts); statErr != nil {
+			writeError(statErr, response)
+			return
+		}
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+func (app *SubresourceAPIApp) vmMediaChangePatch(name, namespace string, opts *v1.MediaChangeOptions) *errors.StatusError {
+	vm, statErr := app.fetchVirtualMachine(name, namespace)
+	if statErr != nil {
+		return statErr
+	}
+	if vm.Spec.Template == nil {
+		return nil
+	}
+
+	// The media is part of the launcher pod once the VM is restarted, it does not need to be hotplugged
+	persistentOpts := opts.DeepCopy()
+	if persistentOpts.VolumeSource != nil {
+		if persistentOpts.VolumeSource.DataVolume != nil {
+			persistentOpts.VolumeSource.DataVolume.Hotpluggable = false
+		} else if persistentOpts.VolumeSource.PersistentVolumeClaim != nil {
+			persistentOpts.VolumeSource.PersistentVolumeClaim.Hotpluggable = false
+		}
+	}
+	patch, err := generateMediaChangePatch("/spec/template/spec", &vm.Spec.Template.Spec, persistentOpts)
+	if err != nil {
+		// The CD-ROM was not defined by the VM template, there is nothing to persist
+		log.Log.Object(vm).V(4).Infof("Not persisting the media change: %v", err)
+		return nil
+	}
+	log.Log.Object(vm).V(4).Infof("Patching VM: %s", patch)
+	if _, err := app.virtCli.VirtualMachine(namespace).Patch(name, types.JSONPatchType, []byte(patch)); err != nil {
+		log.Log.Object(vm).V(1).Errorf("unable to patch vm: %v", err)
+		return errors.NewInternalError(fmt.Errorf("unable to patch vm: %v", err))
+	}
+	return nil
+}
@@ -23,17 +23,22 @@ import (
 	"bytes"
 	"crypto/tls"
 	"encoding/json"
+	"encoding/xml"
 	"flag"
 	"fmt"
 	"io"
+	"io/fs"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strconv"
 	"strings"
 	"sync"
+	"testing/fstest"
 
 	"github.com/emicklei/go-restful"
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -41,15 +46,19 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/util/status"
 
+	authorization "k8s.io/api/authorization/v1"
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/utils/pointer"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 )
 
 const vmPathFormat = "/apis/kubevirt.io/%s/namespaces/%s/virtualmachines/%s"
@@ -144,7 +153,7 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 		)
 	}
 
-	expectVMI := func(running, paused bool) {
+	expectModifiedVMI := func(running, paused bool, modify func(vmi *v1.VirtualMachineInstance)) {
 		request.PathParameters()["name"] = "testvmi"
 		request.PathParameters()["namespace"] = "default"
 
@@ -171,6 +180,9 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 				},
 			}
 		}
+		if modify != nil {
+			modify(&vmi)
+		}
 
 		server.AppendHandlers(
 			ghttp.CombineHandlers(
@@ -182,6 +194,10 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 		expectHandlerPod()
 	}
 
+	expectVMI := func(running, paused bool) {
+		expectModifiedVMI(running, paused, nil)
+	}
+
 	Context("Subresource api", func() {
 		It("should find matching pod for running VirtualMachineInstance", func(done Done) {
 			vmi := v1.NewMinimalVMI("testvmi")
@@ -1354,239 +1370,761 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 		)
 	})
 
-	Context("Subresource api - MigrateVMRequestHandler", func() {
-		It("should fail if VirtualMachine not exists", func(done Done) {
-			request.PathParameters()["name"] = "testvm"
-			request.PathParameters()["namespace"] = "default"
+	Context("Subresource api - RenameVMRequestHandler", func() {
+		newRenameBody := func(opts *v1.RenameOptions) io.ReadCloser {
+			optsJson, _ := json.Marshal(opts)
+			return &readCloserWrapper{bytes.NewReader(optsJson)}
+		}
 
+		expectVM := func(runStrategy v1.VirtualMachineRunStrategy) {
 			server.AppendHandlers(
 				ghttp.CombineHandlers(
 					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
-					ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, newVirtualMachineWithRunStrategy(runStrategy)),
 				),
 			)
+		}
 
-			app.MigrateVMRequestHandler(request, response)
-
-			ExpectStatusErrorWithCode(recorder, http.StatusNotFound)
-			close(done)
-		}, 5)
+		expectVMI := func(phase v1.VirtualMachineInstancePhase) {
+			if phase == v1.VmPhaseUnset {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+						ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+					),
+				)
+				return
+			}
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, newVirtualMachineInstanceInPhase(phase)),
+				),
+			)
+		}
 
-		It("should fail if VirtualMachine is not running", func(done Done) {
+		BeforeEach(func() {
 			request.PathParameters()["name"] = "testvm"
 			request.PathParameters()["namespace"] = "default"
+		})
 
-			vm := v1.VirtualMachine{}
-
+		It("should request renaming a stopped VM", func() {
+			request.Request.Body = newRenameBody(&v1.RenameOptions{NewName: "newvm"})
+			expectVM(v1.RunStrategyHalted)
+			expectVMI(v1.VmPhaseUnset)
 			server.AppendHandlers(
 				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
-					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/newvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm/status"),
+					func(w http.ResponseWriter, r *http.Request) {
+						body, err := ioutil.ReadAll(r.Body)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(string(body)).To(ContainSubstring(`{"action":"Rename","data":{"newName":"newvm"}}`))
+					},
+					ghttp.RespondWithJSONEncoded(http.StatusOK, newVirtualMachineWithRunStrategy(v1.RunStrategyHalted)),
 				),
 			)
 
-			app.MigrateVMRequestHandler(request, response)
+			app.RenameVMRequestHandler(request, response)
 
-			status := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
-			Expect(status.Error()).To(ContainSubstring("VM is not running"))
-			close(done)
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
 		})
 
-		It("should fail if migration is not posted", func(done Done) {
-			request.PathParameters()["name"] = "testvm"
-			request.PathParameters()["namespace"] = "default"
+		table.DescribeTable("should reject an invalid new name", func(newName string) {
+			request.Request.Body = newRenameBody(&v1.RenameOptions{NewName: newName})
 
-			vm := v1.VirtualMachine{
-				Status: v1.VirtualMachineStatus{
-					Ready: true,
-				},
-			}
+			app.RenameVMRequestHandler(request, response)
 
-			server.AppendHandlers(
-				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
-					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
-				),
-			)
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		},
+			table.Entry("when it is empty", ""),
+			table.Entry("when it equals the current name", "testvm"),
+			table.Entry("when it is not a DNS label", "New_VM"),
+		)
+
+		It("should fail renaming a running VM", func() {
+			request.Request.Body = newRenameBody(&v1.RenameOptions{NewName: "newvm"})
+			expectVM(v1.RunStrategyManual)
+			expectVMI(v1.Running)
+
+			app.RenameVMRequestHandler(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+			Expect(statusErr.Error()).To(ContainSubstring("VM must be stopped to be renamed"))
+		})
+
+		It("should fail renaming a VM with RunStrategy Always", func() {
+			request.Request.Body = newRenameBody(&v1.RenameOptions{NewName: "newvm"})
+			expectVM(v1.RunStrategyAlways)
+
+			app.RenameVMRequestHandler(request, response)
 
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+			Expect(statusErr.Error()).To(ContainSubstring("Always does not support renaming the VM"))
+		})
+
+		It("should fail renaming a VM to the name of an existing VM", func() {
+			request.Request.Body = newRenameBody(&v1.RenameOptions{NewName: "newvm"})
+			expectVM(v1.RunStrategyHalted)
+			expectVMI(v1.VmPhaseUnset)
 			server.AppendHandlers(
 				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("POST", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstancemigrations"),
-					ghttp.RespondWithJSONEncoded(http.StatusInternalServerError, nil),
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/newvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, newMinimalVM("newvm")),
 				),
 			)
 
-			app.MigrateVMRequestHandler(request, response)
+			app.RenameVMRequestHandler(request, response)
 
-			ExpectStatusErrorWithCode(recorder, http.StatusInternalServerError)
-			close(done)
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+			Expect(statusErr.Error()).To(ContainSubstring("a VM named newvm already exists"))
 		})
+	})
 
-		It("should migrate VirtualMachine", func(done Done) {
-			request.PathParameters()["name"] = "testvm"
-			request.PathParameters()["namespace"] = "default"
+	Context("Subresource api - MoveVMRequestHandler", func() {
+		var authorizor *MockVirtApiAuthorizor
 
-			vm := v1.VirtualMachine{
-				Status: v1.VirtualMachineStatus{
-					Ready: true,
-				},
+		newMoveBody := func(opts *v1.MoveOptions) io.ReadCloser {
+			optsJson, _ := json.Marshal(opts)
+			return &readCloserWrapper{bytes.NewReader(optsJson)}
+		}
+
+		expectAuthorization := func(allowed bool) {
+			authorizor.EXPECT().AuthorizeResourceAccess(gomock.Any(), &authorization.ResourceAttributes{
+				Namespace: "tenant-b",
+				Verb:      "create",
+				Group:     v1.GroupVersion.Group,
+				Resource:  "virtualmachines",
+			}).Return(allowed, "", nil)
+			if allowed {
+				authorizor.EXPECT().AuthorizeResourceAccess(gomock.Any(), &authorization.ResourceAttributes{
+					Namespace: "tenant-b",
+					Verb:      "create",
+					Group:     "cdi.kubevirt.io",
+					Resource:  "datavolumes",
+				}).Return(true, "", nil)
+			}
+		}
+
+		expectCloneAuthorization := func(allowed bool) {
+			authorizor.EXPECT().AuthorizeResourceAccess(gomock.Any(), &authorization.ResourceAttributes{
+				Namespace:   "default",
+				Name:        "disk0",
+				Verb:        "create",
+				Group:       "cdi.kubevirt.io",
+				Resource:    "datavolumes",
+				Subresource: "source",
+			}).Return(allowed, "", nil)
+		}
+
+		expectNamespace := func(exists bool) {
+			namespaces := &k8sv1.NamespaceList{}
+			if exists {
+				namespaces.Items = []k8sv1.Namespace{{ObjectMeta: k8smetav1.ObjectMeta{Name: "tenant-b"}}}
 			}
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/namespaces", "fieldSelector=metadata.name%3Dtenant-b"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, namespaces),
+				),
+			)
+		}
 
+		expectVM := func(runStrategy v1.VirtualMachineRunStrategy) {
+			vm := newVirtualMachineWithRunStrategy(runStrategy)
+			vm.Spec.Template = &v1.VirtualMachineInstanceTemplateSpec{
+				Spec: v1.VirtualMachineInstanceSpec{
+					Volumes: []v1.Volume{{
+						Name: "disk0",
+						VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+							PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "disk0"},
+						}},
+					}},
+				},
+			}
 			server.AppendHandlers(
 				ghttp.CombineHandlers(
 					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
 					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
 				),
 			)
+		}
 
-			migration := v1.VirtualMachineInstanceMigration{}
-
+		expectNoVMI := func() {
 			server.AppendHandlers(
 				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("POST", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstancemigrations"),
-					ghttp.RespondWithJSONEncoded(http.StatusOK, migration),
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
 				),
 			)
+		}
 
-			app.MigrateVMRequestHandler(request, response)
-
-			Expect(response.Error()).ToNot(HaveOccurred())
-			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
-			close(done)
-		})
-	})
-
-	Context("Subresource api - Guest OS Info", func() {
-		type subRes func(request *restful.Request, response *restful.Response)
-
-		table.DescribeTable("should fail when the VMI does not exist", func(fn subRes) {
+		BeforeEach(func() {
+			authorizor = NewMockVirtApiAuthorizor(gomock.NewController(GinkgoT()))
+			app.authorizor = authorizor
 			request.PathParameters()["name"] = "testvm"
 			request.PathParameters()["namespace"] = "default"
+		})
 
+		It("should request moving a stopped VM", func() {
+			request.Request.Body = newMoveBody(&v1.MoveOptions{NewNamespace: "tenant-b"})
+			expectAuthorization(true)
+			expectNamespace(true)
+			expectVM(v1.RunStrategyHalted)
+			expectNoVMI()
+			expectCloneAuthorization(true)
 			server.AppendHandlers(
 				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/tenant-b/virtualmachines/testvm"),
 					ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
 				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm/status"),
+					func(w http.ResponseWriter, r *http.Request) {
+						body, err := ioutil.ReadAll(r.Body)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(string(body)).To(ContainSubstring(`{"action":"Move","data":{"newNamespace":"tenant-b"}}`))
+					},
+					ghttp.RespondWithJSONEncoded(http.StatusOK, newVirtualMachineWithRunStrategy(v1.RunStrategyHalted)),
+				),
 			)
 
-			fn(request, response)
+			app.MoveVMRequestHandler(request, response)
 
-			Expect(response.Error()).To(HaveOccurred(), "Response should indicate VM not found")
-			Expect(response.StatusCode()).To(Equal(http.StatusInternalServerError))
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+		})
 
+		table.DescribeTable("should reject an invalid new namespace", func(newNamespace string) {
+			request.Request.Body = newMoveBody(&v1.MoveOptions{NewNamespace: newNamespace})
+
+			app.MoveVMRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
 		},
-			table.Entry("for GuestOSInfo", app.GuestOSInfo),
-			table.Entry("for UserList", app.UserList),
-			table.Entry("for Filesystem", app.FilesystemList),
+			table.Entry("when it is empty", ""),
+			table.Entry("when it equals the current namespace", "default"),
+			table.Entry("when it is not a DNS label", "Tenant_B"),
 		)
 
-		table.DescribeTable("should fail when the VMI is not running", func(fn subRes) {
-			request.PathParameters()["name"] = "testvm"
-			request.PathParameters()["namespace"] = "default"
+		It("should fail if the user may not create VMs in the new namespace", func() {
+			request.Request.Body = newMoveBody(&v1.MoveOptions{NewNamespace: "tenant-b"})
+			expectAuthorization(false)
 
-			vmi := v1.VirtualMachineInstance{}
+			app.MoveVMRequestHandler(request, response)
 
-			server.AppendHandlers(
-				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
-					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
-				),
-			)
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusForbidden)
+			Expect(statusErr.Error()).To(ContainSubstring("not allowed to create VMs in namespace tenant-b"))
+		})
 
-			fn(request, response)
+		It("should fail if the user may not clone the volumes of the VM", func() {
+			request.Request.Body = newMoveBody(&v1.MoveOptions{NewNamespace: "tenant-b"})
+			expectAuthorization(true)
+			expectNamespace(true)
+			expectVM(v1.RunStrategyHalted)
+			expectNoVMI()
+			expectCloneAuthorization(false)
 
-			Expect(response.Error()).To(HaveOccurred())
-			Expect(response.StatusCode()).To(Equal(http.StatusInternalServerError))
-			Expect(response.Error().Error()).To(ContainSubstring("VMI is not running"))
-		},
-			table.Entry("for GuestOSInfo", app.GuestOSInfo),
-			table.Entry("for UserList", app.UserList),
-			table.Entry("for FilesystemList", app.FilesystemList),
-		)
+			app.MoveVMRequestHandler(request, response)
 
-		table.DescribeTable("should fail when VMI does not have agent connected", func(fn subRes) {
-			request.PathParameters()["name"] = "testvm"
-			request.PathParameters()["namespace"] = "default"
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusForbidden)
+			Expect(statusErr.Error()).To(ContainSubstring("not allowed to clone PVC disk0"))
+		})
 
-			vmi := v1.VirtualMachineInstance{
-				Status: v1.VirtualMachineInstanceStatus{
-					Phase:      v1.Running,
-					Conditions: []v1.VirtualMachineInstanceCondition{},
-				},
-			}
+		It("should fail if the new namespace does not exist", func() {
+			request.Request.Body = newMoveBody(&v1.MoveOptions{NewNamespace: "tenant-b"})
+			expectAuthorization(true)
+			expectNamespace(false)
+
+			app.MoveVMRequestHandler(request, response)
 
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			Expect(statusErr.Error()).To(ContainSubstring("Namespace tenant-b does not exist"))
+		})
+
+		It("should fail moving a VM with RunStrategy Always", func() {
+			request.Request.Body = newMoveBody(&v1.MoveOptions{NewNamespace: "tenant-b"})
+			expectAuthorization(true)
+			expectNamespace(true)
+			expectVM(v1.RunStrategyAlways)
+
+			app.MoveVMRequestHandler(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+			Expect(statusErr.Error()).To(ContainSubstring("Always does not support moving the VM"))
+		})
+
+		It("should fail moving a VM if a VM with its name exists in the new namespace", func() {
+			request.Request.Body = newMoveBody(&v1.MoveOptions{NewNamespace: "tenant-b"})
+			expectAuthorization(true)
+			expectNamespace(true)
+			expectVM(v1.RunStrategyHalted)
+			expectNoVMI()
+			expectCloneAuthorization(true)
 			server.AppendHandlers(
 				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
-					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/tenant-b/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, newMinimalVM("testvm")),
 				),
 			)
 
-			fn(request, response)
+			app.MoveVMRequestHandler(request, response)
 
-			Expect(response.Error()).To(HaveOccurred())
-			Expect(response.StatusCode()).To(Equal(http.StatusInternalServerError))
-			Expect(response.Error().Error()).To(ContainSubstring("VMI does not have guest agent connected"))
-		},
-			table.Entry("for GuestOSInfo", app.GuestOSInfo),
-			table.Entry("for UserList", app.UserList),
-			table.Entry("for FilesystemList", app.FilesystemList),
-		)
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+			Expect(statusErr.Error()).To(ContainSubstring("a VM named testvm already exists in namespace tenant-b"))
+		})
 	})
 
-	Context("StateChange JSON", func() {
-		It("should create a stop request if status exists", func() {
-			uid := uuid.NewUUID()
-			vm := newMinimalVM("testvm")
-			vm.Status.Created = true
-			stopRequest := v1.VirtualMachineStateChangeRequest{
-				Action: v1.StopRequest,
-				UID:    &uid,
+	Context("Subresource api - MigrateCancelVMRequestHandler", func() {
+		newMigrationWithPhase := func(name string, phase v1.VirtualMachineInstanceMigrationPhase) v1.VirtualMachineInstanceMigration {
+			return v1.VirtualMachineInstanceMigration{
+				ObjectMeta: k8smetav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec:       v1.VirtualMachineInstanceMigrationSpec{VMIName: "testvm"},
+				Status:     v1.VirtualMachineInstanceMigrationStatus{Phase: phase},
 			}
+		}
 
-			res, err := getChangeRequestJson(vm, stopRequest)
-			Expect(err).ToNot(HaveOccurred())
+		expectMigrations := func(migrations ...v1.VirtualMachineInstanceMigration) {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, newVirtualMachineWithRunStrategy(v1.RunStrategyAlways)),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstancemigrations", "labelSelector=kubevirt.io%2Fvmi-name%3Dtestvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, &v1.VirtualMachineInstanceMigrationList{Items: migrations}),
+				),
+			)
+		}
 
-			ref := fmt.Sprintf(`[{ "op": "test", "path": "/status/stateChangeRequests", "value": null}, { "op": "add", "path": "/status/stateChangeRequests", "value": [{"action":"Stop","uid":"%s"}]}]`, uid)
-			Expect(res).To(Equal(ref))
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
 		})
 
-		It("should create a stop request if status doesn't exist", func() {
-			uid := uuid.NewUUID()
-			vm := newMinimalVM("testvm")
-			stopRequest := v1.VirtualMachineStateChangeRequest{
-				Action: v1.StopRequest,
-				UID:    &uid,
-			}
+		It("should delete the migration in progress", func() {
+			expectMigrations(
+				newMigrationWithPhase("succeeded", v1.MigrationSucceeded),
+				newMigrationWithPhase("running", v1.MigrationRunning),
+			)
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstancemigrations/running"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+				),
+			)
 
-			res, err := getChangeRequestJson(vm, stopRequest)
-			Expect(err).ToNot(HaveOccurred())
+			app.MigrateCancelVMRequestHandler(request, response)
 
-			ref := fmt.Sprintf(`[{ "op": "add", "path": "/status", "value": {"stateChangeRequests":[{"action":"Stop","uid":"%s"}]}}]`, uid)
-			Expect(res).To(Equal(ref))
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
 		})
 
-		It("should create a restart request if status exists", func() {
-			uid := uuid.NewUUID()
-			vm := newMinimalVM("testvm")
-			vm.Status.Created = true
-			stopRequest := v1.VirtualMachineStateChangeRequest{
-				Action: v1.StopRequest,
-				UID:    &uid,
-			}
-			startRequest := v1.VirtualMachineStateChangeRequest{
-				Action: v1.StartRequest,
-			}
+		It("should fail if the VM has no migration in progress", func() {
+			expectMigrations(
+				newMigrationWithPhase("failed", v1.MigrationFailed),
+				newMigrationWithPhase("aborted", v1.MigrationAborted),
+			)
 
-			res, err := getChangeRequestJson(vm, stopRequest, startRequest)
-			Expect(err).ToNot(HaveOccurred())
+			app.MigrateCancelVMRequestHandler(request, response)
 
-			ref := fmt.Sprintf(`[{ "op": "test", "path": "/status/stateChangeRequests", "value": null}, { "op": "add", "path": "/status/stateChangeRequests", "value": [{"action":"Stop","uid":"%s"},{"action":"Start"}]}]`, uid)
-			Expect(res).To(Equal(ref))
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+			Expect(statusErr.Error()).To(ContainSubstring("VM has no migration in progress"))
 		})
+	})
 
-		It("should create a restart request if status doesn't exist", func() {
+	Context("Subresource api - memory dump to PVC", func() {
+		newMemoryDumpBody := func(claimName string) io.ReadCloser {
+			optsJson, _ := json.Marshal(&v1.VirtualMachineMemoryDumpRequest{ClaimName: claimName})
+			return &readCloserWrapper{bytes.NewReader(optsJson)}
+		}
+
+		expectVM := func(memoryDumpRequest *v1.VirtualMachineMemoryDumpRequest) {
+			vm := newMinimalVM("testvm")
+			vm.Namespace = "default"
+			vm.Status.MemoryDumpRequest = memoryDumpRequest
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+			)
+		}
+
+		expectRunningVMI := func() {
+			vmi := newVirtualMachineInstanceInPhase(v1.Running)
+			vmi.Name = "testvm"
+			vmi.Namespace = "default"
+			vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{
+				k8sv1.ResourceMemory: resource.MustParse("1Gi"),
+			}
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+		}
+
+		expectPVC := func(capacity string) {
+			pvc := &k8sv1.PersistentVolumeClaim{
+				ObjectMeta: k8smetav1.ObjectMeta{Name: "dumppvc", Namespace: "default"},
+				Status: k8sv1.PersistentVolumeClaimStatus{
+					Capacity: k8sv1.ResourceList{
+						k8sv1.ResourceStorage: resource.MustParse(capacity),
+					},
+				},
+			}
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/namespaces/default/persistentvolumeclaims/dumppvc"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, pvc),
+				),
+			)
+		}
+
+		expectPatch := func(substring string) {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm/status"),
+					func(w http.ResponseWriter, r *http.Request) {
+						body, err := ioutil.ReadAll(r.Body)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(string(body)).To(ContainSubstring(substring))
+					},
+					ghttp.RespondWithJSONEncoded(http.StatusOK, newMinimalVM("testvm")),
+				),
+			)
+		}
+
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
+		})
+
+		It("should request a memory dump to a PVC", func() {
+			enableFeatureGate(virtconfig.HotplugVolumesGate)
+			request.Request.Body = newMemoryDumpBody("dumppvc")
+			expectVM(nil)
+			expectRunningVMI()
+			expectPVC("2Gi")
+			expectPatch(`"op": "add", "path": "/status/memoryDumpRequest", "value": {"claimName":"dumppvc","phase":"Associating"}`)
+
+			app.MemoryDumpVMRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+		})
+
+		It("should request another memory dump to the associated PVC", func() {
+			enableFeatureGate(virtconfig.HotplugVolumesGate)
+			request.Request.Body = newMemoryDumpBody("dumppvc")
+			expectVM(&v1.VirtualMachineMemoryDumpRequest{ClaimName: "dumppvc", Phase: v1.MemoryDumpCompleted})
+			expectRunningVMI()
+			expectPVC("2Gi")
+			expectPatch(`"op": "replace", "path": "/status/memoryDumpRequest", "value": {"claimName":"dumppvc","phase":"Associating"}`)
+
+			app.MemoryDumpVMRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+		})
+
+		table.DescribeTable("should reject the memory dump request", func(enableGate bool, claimName string) {
+			if enableGate {
+				enableFeatureGate(virtconfig.HotplugVolumesGate)
+			}
+			request.Request.Body = newMemoryDumpBody(claimName)
+
+			app.MemoryDumpVMRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		},
+			table.Entry("when the HotplugVolumes feature gate is disabled", false, "dumppvc"),
+			table.Entry("when the claim name is missing", true, ""),
+		)
+
+		It("should reject a PVC which is too small for the memory dump", func() {
+			enableFeatureGate(virtconfig.HotplugVolumesGate)
+			request.Request.Body = newMemoryDumpBody("dumppvc")
+			expectVM(nil)
+			expectRunningVMI()
+			expectPVC("1Gi")
+
+			app.MemoryDumpVMRequestHandler(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			Expect(statusErr.Error()).To(ContainSubstring("too small to hold the memory dump"))
+		})
+
+		table.DescribeTable("should fail while another memory dump request is pending", func(existing *v1.VirtualMachineMemoryDumpRequest, message string) {
+			enableFeatureGate(virtconfig.HotplugVolumesGate)
+			request.Request.Body = newMemoryDumpBody("dumppvc")
+			expectVM(existing)
+			expectRunningVMI()
+			expectPVC("2Gi")
+
+			app.MemoryDumpVMRequestHandler(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+			Expect(statusErr.Error()).To(ContainSubstring(message))
+		},
+			table.Entry("when a memory dump is in progress", &v1.VirtualMachineMemoryDumpRequest{ClaimName: "dumppvc", Phase: v1.MemoryDumpInProgress}, "is in progress"),
+			table.Entry("when another PVC is associated", &v1.VirtualMachineMemoryDumpRequest{ClaimName: "otherpvc", Phase: v1.MemoryDumpCompleted}, "remove it first"),
+		)
+
+		It("should request removing the memory dump PVC", func() {
+			enableFeatureGate(virtconfig.HotplugVolumesGate)
+			expectVM(&v1.VirtualMachineMemoryDumpRequest{ClaimName: "dumppvc", Phase: v1.MemoryDumpCompleted})
+			expectPatch(`{"claimName":"dumppvc","phase":"Dissociating","remove":true}`)
+
+			app.RemoveMemoryDumpVMRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+		})
+
+		It("should fail removing the memory dump PVC if none is associated", func() {
+			enableFeatureGate(virtconfig.HotplugVolumesGate)
+			expectVM(nil)
+
+			app.RemoveMemoryDumpVMRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		})
+	})
+
+	Context("Subresource api - MigrateVMRequestHandler", func() {
+		It("should fail if VirtualMachine not exists", func(done Done) {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+				),
+			)
+
+			app.MigrateVMRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusNotFound)
+			close(done)
+		}, 5)
+
+		It("should fail if VirtualMachine is not running", func(done Done) {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
+
+			vm := v1.VirtualMachine{}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+			)
+
+			app.MigrateVMRequestHandler(request, response)
+
+			status := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+			Expect(status.Error()).To(ContainSubstring("VM is not running"))
+			close(done)
+		})
+
+		It("should fail if migration is not posted", func(done Done) {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
+
+			vm := v1.VirtualMachine{
+				Status: v1.VirtualMachineStatus{
+					Ready: true,
+				},
+			}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+			)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstancemigrations"),
+					ghttp.RespondWithJSONEncoded(http.StatusInternalServerError, nil),
+				),
+			)
+
+			app.MigrateVMRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusInternalServerError)
+			close(done)
+		})
+
+		It("should migrate VirtualMachine", func(done Done) {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
+
+			vm := v1.VirtualMachine{
+				Status: v1.VirtualMachineStatus{
+					Ready: true,
+				},
+			}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+			)
+
+			migration := v1.VirtualMachineInstanceMigration{}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstancemigrations"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, migration),
+				),
+			)
+
+			app.MigrateVMRequestHandler(request, response)
+
+			Expect(response.Error()).ToNot(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+			close(done)
+		})
+	})
+
+	Context("Subresource api - Guest OS Info", func() {
+		type subRes func(request *restful.Request, response *restful.Response)
+
+		table.DescribeTable("should fail when the VMI does not exist", func(fn subRes) {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+				),
+			)
+
+			fn(request, response)
+
+			Expect(response.Error()).To(HaveOccurred(), "Response should indicate VM not found")
+			Expect(response.StatusCode()).To(Equal(http.StatusInternalServerError))
+
+		},
+			table.Entry("for GuestOSInfo", app.GuestOSInfo),
+			table.Entry("for UserList", app.UserList),
+			table.Entry("for Filesystem", app.FilesystemList),
+		)
+
+		table.DescribeTable("should fail when the VMI is not running", func(fn subRes) {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
+
+			vmi := v1.VirtualMachineInstance{}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			fn(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusInternalServerError))
+			Expect(response.Error().Error()).To(ContainSubstring("VMI is not running"))
+		},
+			table.Entry("for GuestOSInfo", app.GuestOSInfo),
+			table.Entry("for UserList", app.UserList),
+			table.Entry("for FilesystemList", app.FilesystemList),
+		)
+
+		table.DescribeTable("should fail when VMI does not have agent connected", func(fn subRes) {
+			request.PathParameters()["name"] = "testvm"
+			request.PathParameters()["namespace"] = "default"
+
+			vmi := v1.VirtualMachineInstance{
+				Status: v1.VirtualMachineInstanceStatus{
+					Phase:      v1.Running,
+					Conditions: []v1.VirtualMachineInstanceCondition{},
+				},
+			}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			fn(request, response)
+
+			Expect(response.Error()).To(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusInternalServerError))
+			Expect(response.Error().Error()).To(ContainSubstring("VMI does not have guest agent connected"))
+		},
+			table.Entry("for GuestOSInfo", app.GuestOSInfo),
+			table.Entry("for UserList", app.UserList),
+			table.Entry("for FilesystemList", app.FilesystemList),
+		)
+	})
+
+	Context("StateChange JSON", func() {
+		It("should create a stop request if status exists", func() {
+			uid := uuid.NewUUID()
+			vm := newMinimalVM("testvm")
+			vm.Status.Created = true
+			stopRequest := v1.VirtualMachineStateChangeRequest{
+				Action: v1.StopRequest,
+				UID:    &uid,
+			}
+
+			res, err := getChangeRequestJson(vm, stopRequest)
+			Expect(err).ToNot(HaveOccurred())
+
+			ref := fmt.Sprintf(`[{ "op": "test", "path": "/status/stateChangeRequests", "value": null}, { "op": "add", "path": "/status/stateChangeRequests", "value": [{"action":"Stop","uid":"%s"}]}]`, uid)
+			Expect(res).To(Equal(ref))
+		})
+
+		It("should create a stop request if status doesn't exist", func() {
+			uid := uuid.NewUUID()
+			vm := newMinimalVM("testvm")
+			stopRequest := v1.VirtualMachineStateChangeRequest{
+				Action: v1.StopRequest,
+				UID:    &uid,
+			}
+
+			res, err := getChangeRequestJson(vm, stopRequest)
+			Expect(err).ToNot(HaveOccurred())
+
+			ref := fmt.Sprintf(`[{ "op": "add", "path": "/status", "value": {"stateChangeRequests":[{"action":"Stop","uid":"%s"}]}}]`, uid)
+			Expect(res).To(Equal(ref))
+		})
+
+		It("should create a restart request if status exists", func() {
+			uid := uuid.NewUUID()
+			vm := newMinimalVM("testvm")
+			vm.Status.Created = true
+			stopRequest := v1.VirtualMachineStateChangeRequest{
+				Action: v1.StopRequest,
+				UID:    &uid,
+			}
+			startRequest := v1.VirtualMachineStateChangeRequest{
+				Action: v1.StartRequest,
+			}
+
+			res, err := getChangeRequestJson(vm, stopRequest, startRequest)
+			Expect(err).ToNot(HaveOccurred())
+
+			ref := fmt.Sprintf(`[{ "op": "test", "path": "/status/stateChangeRequests", "value": null}, { "op": "add", "path": "/status/stateChangeRequests", "value": [{"action":"Stop","uid":"%s"},{"action":"Start"}]}]`, uid)
+			Expect(res).To(Equal(ref))
+		})
+
+		It("should create a restart request if status doesn't exist", func() {
 			uid := uuid.NewUUID()
 			vm := newMinimalVM("testvm")
 			stopRequest := v1.VirtualMachineStateChangeRequest{
@@ -1615,122 +2153,545 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			res, err := getChangeRequestJson(vm, startRequest)
 			Expect(err).ToNot(HaveOccurred())
 
-			ref := fmt.Sprintf(`[{ "op": "test", "path": "/status/stateChangeRequests", "value": null}, { "op": "add", "path": "/status/stateChangeRequests", "value": [{"action":"Start"}]}]`)
-			Expect(res).To(Equal(ref))
+			ref := fmt.Sprintf(`[{ "op": "test", "path": "/status/stateChangeRequests", "value": null}, { "op": "add", "path": "/status/stateChangeRequests", "value": [{"action":"Start"}]}]`)
+			Expect(res).To(Equal(ref))
+		})
+
+		It("should create a start request if status doesn't exist", func() {
+			vm := newMinimalVM("testvm")
+
+			startRequest := v1.VirtualMachineStateChangeRequest{
+				Action: v1.StartRequest,
+			}
+
+			res, err := getChangeRequestJson(vm, startRequest)
+			Expect(err).ToNot(HaveOccurred())
+
+			ref := fmt.Sprintf(`[{ "op": "add", "path": "/status", "value": {"stateChangeRequests":[{"action":"Start"}]}}]`)
+			Expect(res).To(Equal(ref))
+		})
+
+		It("should force a stop request to override", func() {
+			uid := uuid.NewUUID()
+			vm := newMinimalVM("testvm")
+			stopRequest := v1.VirtualMachineStateChangeRequest{
+				Action: v1.StopRequest,
+				UID:    &uid,
+			}
+			startRequest := v1.VirtualMachineStateChangeRequest{
+				Action: v1.StartRequest,
+			}
+			vm.Status.StateChangeRequests = append(vm.Status.StateChangeRequests, startRequest)
+
+			res, err := getChangeRequestJson(vm, stopRequest)
+			Expect(err).ToNot(HaveOccurred())
+
+			ref := fmt.Sprintf(`[{ "op": "test", "path": "/status/stateChangeRequests", "value": [{"action":"Start"}]}, { "op": "replace", "path": "/status/stateChangeRequests", "value": [{"action":"Stop","uid":"%s"}]}]`, uid)
+			Expect(res).To(Equal(ref))
+		})
+
+		It("should error on start request if other requests exist", func() {
+			uid := uuid.NewUUID()
+			vm := newMinimalVM("testvm")
+			stopRequest := v1.VirtualMachineStateChangeRequest{
+				Action: v1.StopRequest,
+				UID:    &uid,
+			}
+			startRequest := v1.VirtualMachineStateChangeRequest{
+				Action: v1.StartRequest,
+			}
+			vm.Status.StateChangeRequests = append(vm.Status.StateChangeRequests, stopRequest)
+
+			_, err := getChangeRequestJson(vm, startRequest)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should error on restart request if other requests exist", func() {
+			uid := uuid.NewUUID()
+			vm := newMinimalVM("testvm")
+			stopRequest := v1.VirtualMachineStateChangeRequest{
+				Action: v1.StopRequest,
+				UID:    &uid,
+			}
+			startRequest := v1.VirtualMachineStateChangeRequest{
+				Action: v1.StartRequest,
+			}
+			vm.Status.StateChangeRequests = append(vm.Status.StateChangeRequests, startRequest)
+
+			_, err := getChangeRequestJson(vm, stopRequest, startRequest)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("Freezing", func() {
+		It("Should freeze a running VMI", func() {
+
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/freeze"),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+			expectVMI(true, false)
+
+			app.FreezeVMIRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		})
+
+		It("Should fail freezing a not running VMI", func() {
+
+			expectVMI(false, false)
+
+			app.FreezeVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+
+		It("Should fail unfreezing a not running VMI", func() {
+
+			expectVMI(false, false)
+
+			app.UnfreezeVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+
+		It("Should unfreeze a running VMI", func() {
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/unfreeze"),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+			expectVMI(true, false)
+
+			app.UnfreezeVMIRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		})
+	})
+
+	Context("Memory dump", func() {
+		It("Should stream the memory dump of a running VMI", func() {
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/memorydump"),
+					ghttp.RespondWith(http.StatusOK, "dump", http.Header{
+						"Content-Disposition": []string{"attachment; filename=default-testvmi.memory.dump"},
+					}),
+				),
+			)
+			expectVMI(true, false)
+
+			app.MemoryDumpRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+			Expect(recorder.Header().Get("Content-Disposition")).To(Equal("attachment; filename=default-testvmi.memory.dump"))
+			Expect(recorder.Body.String()).To(Equal("dump"))
+		})
+
+		It("Should resume streaming the memory dump with a range request", func() {
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/memorydump"),
+					ghttp.VerifyHeaderKV("Range", "bytes=2-"),
+					ghttp.VerifyHeaderKV("If-Range", `"abc"`),
+					ghttp.RespondWith(http.StatusPartialContent, "mp", http.Header{
+						"ETag":          []string{`"abc"`},
+						"Content-Range": []string{"bytes 2-3/4"},
+					}),
+				),
+			)
+			expectVMI(true, false)
+			request.Request.Header = http.Header{
+				"Range":    []string{"bytes=2-"},
+				"If-Range": []string{`"abc"`},
+			}
+
+			app.MemoryDumpRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusPartialContent))
+			Expect(recorder.Header().Get("ETag")).To(Equal(`"abc"`))
+			Expect(recorder.Header().Get("Content-Range")).To(Equal("bytes 2-3/4"))
+			Expect(recorder.Body.String()).To(Equal("mp"))
+		})
+
+		It("Should fail streaming the memory dump of a not running VMI", func() {
+			expectVMI(false, false)
+
+			app.MemoryDumpRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+	})
+
+	Context("Console log", func() {
+		It("Should return the recorded console log of a running VMI", func() {
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/namespaces/default/virtualmachineinstances/testvmi/consolelog"),
+					ghttp.RespondWith(http.StatusOK, "login: "),
+				),
+			)
+			expectVMI(true, false)
+
+			app.ConsoleLogRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+			Expect(recorder.Body.String()).To(Equal("login: "))
+		})
+
+		It("Should fail returning the console log of a not running VMI", func() {
+			expectVMI(false, false)
+
+			app.ConsoleLogRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+	})
+
+	Context("Domain log", func() {
+		It("Should return the domain log of a scheduled VMI", func() {
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/namespaces/default/virtualmachineinstances/testvmi/domainlog", "lines=20"),
+					ghttp.RespondWith(http.StatusOK, "qemu-kvm: -device virtio-blk-pci: Property not found\n"),
+				),
+			)
+			expectModifiedVMI(true, false, func(vmi *v1.VirtualMachineInstance) {
+				vmi.Status.Phase = v1.Scheduled
+				vmi.Status.NodeName = "mynode"
+			})
+			request.Request.URL, _ = url.Parse("/domainlog?lines=20")
+
+			app.DomainLogRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+			Expect(recorder.Body.String()).To(ContainSubstring("Property not found"))
+		})
+
+		It("Should fail returning the domain log of a failed VMI", func() {
+			expectModifiedVMI(false, false, func(vmi *v1.VirtualMachineInstance) {
+				vmi.Status.NodeName = "mynode"
+			})
+
+			app.DomainLogRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+
+		It("Should fail returning the domain log of a VMI without a node", func() {
+			expectModifiedVMI(true, false, func(vmi *v1.VirtualMachineInstance) {
+				vmi.Status.Phase = v1.Pending
+			})
+
+			app.DomainLogRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+
+		It("Should fail on an invalid number of lines", func() {
+			expectModifiedVMI(true, false, func(vmi *v1.VirtualMachineInstance) {
+				vmi.Status.NodeName = "mynode"
+			})
+			request.Request.URL, _ = url.Parse("/domainlog?lines=-1")
+
+			app.DomainLogRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		})
+	})
+
+	Context("VSOCK", func() {
+		_true := true
+		vsockCID := uint32(3)
+
+		table.DescribeTable("should only connect to the VSOCK device of VMIs which request one", func(autoattach *bool, cid *uint32, allowed bool) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Domain.Devices.AutoattachVSOCK = autoattach
+			vmi.Status.VSOCKCID = cid
+			if allowed {
+				Expect(validateVMIForVSOCK(vmi)).To(BeNil())
+			} else {
+				Expect(validateVMIForVSOCK(vmi)).ToNot(BeNil())
+			}
+		},
+			table.Entry("with autoattachVSOCK and a CID", &_true, &vsockCID, true),
+			table.Entry("without autoattachVSOCK", nil, &vsockCID, false),
+			table.Entry("without a CID", &_true, nil, false),
+		)
+	})
+
+	Context("VNC console", func() {
+		var bundledNoVNCFiles fs.FS
+
+		BeforeEach(func() {
+			bundledNoVNCFiles = noVNCFiles
+			noVNCFiles = fstest.MapFS{
+				"core/rfb.js":       {Data: []byte("export default class RFB {}")},
+				"core/util/util.js": {Data: []byte("export const util = {};")},
+			}
+		})
+
+		AfterEach(func() {
+			noVNCFiles = bundledNoVNCFiles
+		})
+
+		It("Should serve a noVNC page connecting to the vnc subresource", func() {
+			expectVMI(true, false)
+
+			app.VNCConsoleRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+			Expect(recorder.Header().Get("Content-Type")).To(HavePrefix("text/html"))
+			Expect(recorder.Body.String()).To(ContainSubstring(`import RFB from "./console/novnc/core/rfb.js"`))
+			Expect(recorder.Body.String()).To(ContainSubstring(`wsProtocols: ["plain.kubevirt.io"]`))
+			Expect(recorder.Body.String()).To(ContainSubstring(`<title>default/testvmi</title>`))
+		})
+
+		It("Should only run the bundled noVNC and the page script", func() {
+			expectVMI(true, false)
+
+			app.VNCConsoleRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+			policy := recorder.Header().Get("Content-Security-Policy")
+			Expect(policy).To(MatchRegexp(`^script-src 'nonce-[A-Za-z0-9_-]+' 'self'; object-src 'none'; base-uri 'none'$`))
+			nonce := strings.TrimSuffix(strings.TrimPrefix(policy, "script-src 'nonce-"), "' 'self'; object-src 'none'; base-uri 'none'")
+			Expect(recorder.Body.String()).To(ContainSubstring(`<script type="module" nonce="` + nonce + `">`))
+		})
+
+		It("Should fail if virt-api was built without noVNC", func() {
+			noVNCFiles = fstest.MapFS{}
+
+			app.VNCConsoleRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusServiceUnavailable)
+		})
+
+		It("Should serve the bundled noVNC modules", func() {
+			request.Request.Method = http.MethodGet
+			request.PathParameters()["path"] = "core/util/util.js"
+
+			app.NoVNCRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+			Expect(recorder.Header().Get("Content-Type")).To(HavePrefix("text/javascript"))
+			Expect(recorder.Header().Get("X-Content-Type-Options")).To(Equal("nosniff"))
+			Expect(recorder.Body.String()).To(Equal("export const util = {};"))
+		})
+
+		table.DescribeTable("Should not serve anything but the noVNC modules", func(path string) {
+			request.Request.Method = http.MethodGet
+			request.PathParameters()["path"] = path
+
+			app.NoVNCRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusNotFound)
+		},
+			table.Entry("for a missing module", "core/missing.js"),
+			table.Entry("for a directory", "core"),
+			table.Entry("for a path outside of noVNC", "../vnc.go"),
+		)
+	})
+
+	Context("Media change", func() {
+		newMediaChangeBody := func(opts *v1.MediaChangeOptions) io.ReadCloser {
+			optsJson, _ := json.Marshal(opts)
+			return &readCloserWrapper{bytes.NewReader(optsJson)}
+		}
+
+		newCDRomVMI := func(phase v1.VirtualMachineInstancePhase) *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Namespace = "default"
+			vmi.Status.Phase = phase
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{Name: "cdrom", DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{}}},
+				{Name: "disk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}}},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{Name: "cdrom", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "iso",
+				}}}},
+				{Name: "disk", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "root",
+				}}}},
+			}
+			return vmi
+		}
+
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
 		})
 
-		It("should create a start request if status doesn't exist", func() {
-			vm := newMinimalVM("testvm")
+		table.DescribeTable("Should handle media change requests", func(opts *v1.MediaChangeOptions, phase v1.VirtualMachineInstancePhase, expectPatch bool, code int) {
+			enableFeatureGate(virtconfig.HotplugVolumesGate)
+			request.Request.Body = newMediaChangeBody(opts)
 
-			startRequest := v1.VirtualMachineStateChangeRequest{
-				Action: v1.StartRequest,
+			vmi := newCDRomVMI(phase)
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+			if expectPatch {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+					),
+				)
 			}
 
-			res, err := getChangeRequestJson(vm, startRequest)
-			Expect(err).ToNot(HaveOccurred())
+			app.VMIMediaChangeRequestHandler(request, response)
 
-			ref := fmt.Sprintf(`[{ "op": "add", "path": "/status", "value": {"stateChangeRequests":[{"action":"Start"}]}}]`)
-			Expect(res).To(Equal(ref))
-		})
+			Expect(response.StatusCode()).To(Equal(code))
+		},
+			table.Entry("ejecting from a running VMI", &v1.MediaChangeOptions{Name: "cdrom"}, v1.Running, true, http.StatusAccepted),
+			table.Entry("inserting into a running VMI", &v1.MediaChangeOptions{
+				Name:         "cdrom",
+				VolumeSource: &v1.HotplugVolumeSource{DataVolume: &v1.DataVolumeSource{Name: "new-iso"}},
+			}, v1.Running, true, http.StatusAccepted),
+			table.Entry("ejecting from a not running VMI", &v1.MediaChangeOptions{Name: "cdrom"}, v1.Failed, false, http.StatusConflict),
+			table.Entry("ejecting from a disk which is not a CD-ROM", &v1.MediaChangeOptions{Name: "disk"}, v1.Running, false, http.StatusBadRequest),
+			table.Entry("ejecting from a disk which does not exist", &v1.MediaChangeOptions{Name: "missing"}, v1.Running, false, http.StatusBadRequest),
+		)
 
-		It("should force a stop request to override", func() {
-			uid := uuid.NewUUID()
-			vm := newMinimalVM("testvm")
-			stopRequest := v1.VirtualMachineStateChangeRequest{
-				Action: v1.StopRequest,
-				UID:    &uid,
-			}
-			startRequest := v1.VirtualMachineStateChangeRequest{
-				Action: v1.StartRequest,
-			}
-			vm.Status.StateChangeRequests = append(vm.Status.StateChangeRequests, startRequest)
+		It("Should reject a media change request without a name", func() {
+			request.Request.Body = newMediaChangeBody(&v1.MediaChangeOptions{})
 
-			res, err := getChangeRequestJson(vm, stopRequest)
-			Expect(err).ToNot(HaveOccurred())
+			app.VMIMediaChangeRequestHandler(request, response)
 
-			ref := fmt.Sprintf(`[{ "op": "test", "path": "/status/stateChangeRequests", "value": [{"action":"Start"}]}, { "op": "replace", "path": "/status/stateChangeRequests", "value": [{"action":"Stop","uid":"%s"}]}]`, uid)
-			Expect(res).To(Equal(ref))
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
 		})
 
-		It("should error on start request if other requests exist", func() {
-			uid := uuid.NewUUID()
-			vm := newMinimalVM("testvm")
-			stopRequest := v1.VirtualMachineStateChangeRequest{
-				Action: v1.StopRequest,
-				UID:    &uid,
-			}
-			startRequest := v1.VirtualMachineStateChangeRequest{
-				Action: v1.StartRequest,
-			}
-			vm.Status.StateChangeRequests = append(vm.Status.StateChangeRequests, stopRequest)
+		It("Should reject inserting media without the HotplugVolumes feature gate", func() {
+			disableFeatureGates()
+			request.Request.Body = newMediaChangeBody(&v1.MediaChangeOptions{
+				Name:         "cdrom",
+				VolumeSource: &v1.HotplugVolumeSource{DataVolume: &v1.DataVolumeSource{Name: "new-iso"}},
+			})
 
-			_, err := getChangeRequestJson(vm, startRequest)
-			Expect(err).To(HaveOccurred())
+			app.VMIMediaChangeRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
 		})
 
-		It("should error on restart request if other requests exist", func() {
-			uid := uuid.NewUUID()
-			vm := newMinimalVM("testvm")
-			stopRequest := v1.VirtualMachineStateChangeRequest{
-				Action: v1.StopRequest,
-				UID:    &uid,
-			}
-			startRequest := v1.VirtualMachineStateChangeRequest{
-				Action: v1.StartRequest,
-			}
-			vm.Status.StateChangeRequests = append(vm.Status.StateChangeRequests, startRequest)
+		table.DescribeTable("Should generate expected media change patch", func(opts *v1.MediaChangeOptions, expectedPatch string) {
+			vmi := newCDRomVMI(v1.Running)
+			vmi.Spec.Domain.Devices.Disks = vmi.Spec.Domain.Devices.Disks[:1]
+			vmi.Spec.Volumes = vmi.Spec.Volumes[:1]
 
-			_, err := getChangeRequestJson(vm, stopRequest, startRequest)
-			Expect(err).To(HaveOccurred())
-		})
+			patch, err := generateMediaChangePatch("/spec", &vmi.Spec, opts)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(patch).To(Equal(expectedPatch))
+		},
+			table.Entry("eject", &v1.MediaChangeOptions{Name: "cdrom"},
+				`[{ "op": "test", "path": "/spec/volumes", "value": [{"name":"cdrom","persistentVolumeClaim":{"claimName":"iso"}}]}, { "op": "test", "path": "/spec/domain/devices/disks", "value": [{"name":"cdrom","cdrom":{}}]}, { "op": "replace", "path": "/spec/volumes", "value": [{"name":"cdrom","persistentVolumeClaim":{"claimName":"iso"}}]}, { "op": "replace", "path": "/spec/domain/devices/disks", "value": [{"name":"cdrom","cdrom":{"tray":"open"}}]}]`),
+			table.Entry("insert", &v1.MediaChangeOptions{Name: "cdrom", VolumeSource: &v1.HotplugVolumeSource{DataVolume: &v1.DataVolumeSource{Name: "new-iso", Hotpluggable: true}}},
+				`[{ "op": "test", "path": "/spec/volumes", "value": [{"name":"cdrom","persistentVolumeClaim":{"claimName":"iso"}}]}, { "op": "test", "path": "/spec/domain/devices/disks", "value": [{"name":"cdrom","cdrom":{}}]}, { "op": "replace", "path": "/spec/volumes", "value": [{"name":"cdrom","dataVolume":{"name":"new-iso","hotpluggable":true}}]}, { "op": "replace", "path": "/spec/domain/devices/disks", "value": [{"name":"cdrom","cdrom":{"tray":"closed"}}]}]`),
+		)
 	})
 
-	Context("Freezing", func() {
-		It("Should freeze a running VMI", func() {
-
-			backend.AppendHandlers(
-				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/freeze"),
-					ghttp.RespondWith(http.StatusOK, ""),
-				),
-			)
-			expectVMI(true, false)
+	Context("Guest exec", func() {
+		newGuestExecBody := func(opts *v1.GuestExecOptions) io.ReadCloser {
+			optsJson, _ := json.Marshal(opts)
+			return &readCloserWrapper{bytes.NewReader(optsJson)}
+		}
 
-			app.FreezeVMIRequestHandler(request, response)
+		allowGuestExec := func(commands ...v1.GuestExecCommand) {
+			kvConfig := kv.DeepCopy()
+			kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = []string{virtconfig.GuestExecGate}
+			kvConfig.Spec.Configuration.GuestExecAllowList = commands
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+		}
 
-			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+			authorizor := NewMockVirtApiAuthorizor(gomock.NewController(GinkgoT()))
+			authorizor.EXPECT().GetUserHeaders().Return([]string{"X-Remote-User"}).AnyTimes()
+			app.authorizor = authorizor
 		})
 
-		It("Should fail freezing a not running VMI", func() {
-
-			expectVMI(false, false)
+		It("Should reject commands without the GuestExec feature gate", func() {
+			disableFeatureGates()
+			request.Request.Body = newGuestExecBody(&v1.GuestExecOptions{Command: "/usr/bin/uptime"})
 
-			app.FreezeVMIRequestHandler(request, response)
+			app.GuestExecRequestHandler(request, response)
 
-			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
 		})
 
-		It("Should fail unfreezing a not running VMI", func() {
+		table.DescribeTable("Should reject invalid commands", func(opts *v1.GuestExecOptions, code int) {
+			allowGuestExec(v1.GuestExecCommand{Path: "/usr/bin/uptime", Args: []string{"-p|-s"}})
+			request.Request.Body = newGuestExecBody(opts)
 
-			expectVMI(false, false)
+			app.GuestExecRequestHandler(request, response)
 
-			app.UnfreezeVMIRequestHandler(request, response)
+			ExpectStatusErrorWithCode(recorder, code)
+		},
+			table.Entry("without a command", &v1.GuestExecOptions{}, http.StatusBadRequest),
+			table.Entry("which are not allowed", &v1.GuestExecOptions{Command: "/usr/bin/rm", Args: []string{"-p"}}, http.StatusForbidden),
+			table.Entry("with arguments which are not allowed", &v1.GuestExecOptions{Command: "/usr/bin/uptime", Args: []string{"-V"}}, http.StatusForbidden),
+			table.Entry("with additional arguments", &v1.GuestExecOptions{Command: "/usr/bin/uptime", Args: []string{"-p", "-s"}}, http.StatusForbidden),
+			table.Entry("with a too long timeout", &v1.GuestExecOptions{Command: "/usr/bin/uptime", Args: []string{"-p"}, TimeoutSeconds: pointer.Int32Ptr(60)}, http.StatusBadRequest),
+		)
 
-			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
-		})
+		table.DescribeTable("Should reject invalid commands to run over a websocket", func(query string, code int) {
+			allowGuestExec(v1.GuestExecCommand{Path: "/usr/bin/uptime", Args: []string{"-p|-s"}})
+			request.Request.URL = &url.URL{RawQuery: query}
 
-		It("Should unfreeze a running VMI", func() {
+			app.GuestExecStreamRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, code)
+		},
+			table.Entry("without a command", "", http.StatusBadRequest),
+			table.Entry("with arguments which are not allowed", "command=/usr/bin/uptime&arg=-V", http.StatusForbidden),
+			table.Entry("with an invalid timeout", "command=/usr/bin/uptime&arg=-p&timeoutSeconds=ten", http.StatusBadRequest),
+			table.Entry("with a too long timeout", "command=/usr/bin/uptime&arg=-p&timeoutSeconds=3600", http.StatusBadRequest),
+		)
+
+		It("Should run allowed commands in the guest", func() {
+			allowGuestExec(v1.GuestExecCommand{Path: "/usr/bin/uptime", Args: []string{"-p|-s"}})
+			request.Request.Body = newGuestExecBody(&v1.GuestExecOptions{Command: "/usr/bin/uptime", Args: []string{"-p"}})
+			response.SetRequestAccepts(restful.MIME_JSON)
+			expectModifiedVMI(running, false, func(vmi *v1.VirtualMachineInstance) {
+				vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+					{
+						Type:   v1.VirtualMachineInstanceAgentConnected,
+						Status: k8sv1.ConditionTrue,
+					},
+				}
+			})
 			backend.AppendHandlers(
 				ghttp.CombineHandlers(
-					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/unfreeze"),
-					ghttp.RespondWith(http.StatusOK, ""),
+					ghttp.VerifyRequest("POST", "/v1/namespaces/default/virtualmachineinstances/testvmi/guestexec"),
+					ghttp.VerifyJSONRepresenting(&v1.GuestExecOptions{Command: "/usr/bin/uptime", Args: []string{"-p"}, TimeoutSeconds: pointer.Int32Ptr(10)}),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, v1.GuestExecResult{ExitCode: 0, StdOut: "up 3 days"}),
 				),
 			)
-			expectVMI(true, false)
 
-			app.UnfreezeVMIRequestHandler(request, response)
+			app.GuestExecRequestHandler(request, response)
 
 			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+			result := &v1.GuestExecResult{}
+			Expect(json.NewDecoder(recorder.Body).Decode(result)).To(Succeed())
+			Expect(result.StdOut).To(Equal("up 3 days"))
+		})
+
+		It("Should take the user from the request headers of the authorizer", func() {
+			authorizor := NewMockVirtApiAuthorizor(gomock.NewController(GinkgoT()))
+			authorizor.EXPECT().GetUserHeaders().Return([]string{"X-Remote-User", "X-Proxy-User"}).AnyTimes()
+			app.authorizor = authorizor
+			request.Request.Header = http.Header{}
+			request.Request.Header.Set("X-Proxy-User", "admin")
+
+			Expect(app.requestUser(request)).To(Equal("admin"))
 		})
 	})
 
@@ -1799,6 +2760,75 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 
 			Expect(response.StatusCode()).To(Equal(http.StatusOK))
 		})
+
+		newPauseBody := func(opts interface{}) io.ReadCloser {
+			optsJson, _ := json.Marshal(opts)
+			return &readCloserWrapper{bytes.NewReader(optsJson)}
+		}
+
+		It("Should only validate pausing a VMI on a dry run", func() {
+			request.Request.Body = newPauseBody(&v1.PauseOptions{DryRun: []string{k8smetav1.DryRunAll}})
+			expectVMI(true, false)
+
+			app.PauseVMIRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+			Expect(backend.ReceivedRequests()).To(BeEmpty())
+		})
+
+		It("Should only validate unpausing a VMI on a dry run", func() {
+			request.Request.Body = newPauseBody(&v1.UnpauseOptions{DryRun: []string{k8smetav1.DryRunAll}})
+			expectVMI(true, true)
+
+			app.UnpauseVMIRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+			Expect(backend.ReceivedRequests()).To(BeEmpty())
+		})
+
+		It("Should fail a dry run pausing a paused VMI", func() {
+			request.Request.Body = newPauseBody(&v1.PauseOptions{DryRun: []string{k8smetav1.DryRunAll}})
+			expectVMI(true, true)
+
+			app.PauseVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+
+		It("Should reject an unknown dry run directive", func() {
+			request.Request.Body = newPauseBody(&v1.PauseOptions{DryRun: []string{"Some"}})
+
+			app.PauseVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		})
+
+		table.DescribeTable("Should validate the LivenessProbe of a VMI to pause", func(probe *v1.Probe, allowed bool) {
+			expectModifiedVMI(true, false, func(vmi *v1.VirtualMachineInstance) {
+				vmi.Spec.LivenessProbe = probe
+			})
+			if allowed {
+				backend.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/pause"),
+						ghttp.RespondWith(http.StatusOK, ""),
+					),
+				)
+			}
+
+			app.PauseVMIRequestHandler(request, response)
+
+			if allowed {
+				Expect(response.StatusCode()).To(Equal(http.StatusOK))
+			} else {
+				ExpectStatusErrorWithCode(recorder, http.StatusForbidden)
+			}
+		},
+			table.Entry("accept a guest agent ping", &v1.Probe{Handler: v1.Handler{GuestAgentPing: &v1.GuestAgentPing{}}}, true),
+			table.Entry("accept an exec probe", &v1.Probe{Handler: v1.Handler{Exec: &k8sv1.ExecAction{Command: []string{"true"}}}}, true),
+			table.Entry("reject a HTTP probe", &v1.Probe{Handler: v1.Handler{HTTPGet: &k8sv1.HTTPGetAction{Path: "/"}}}, false),
+			table.Entry("reject a TCP probe", &v1.Probe{Handler: v1.Handler{TCPSocket: &k8sv1.TCPSocketAction{}}}, false),
+		)
 	})
 
 	Context("Subresource api - start paused", func() {
@@ -1898,6 +2928,236 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 		)
 	})
 
+	Context("Subresource api - expand spec", func() {
+		var expandedWith *v1.VirtualMachine
+
+		newVMWithTemplate := func(namespace string) *v1.VirtualMachine {
+			vm := newMinimalVM("testvm")
+			vm.Namespace = namespace
+			vm.Spec.Template = &v1.VirtualMachineInstanceTemplateSpec{}
+			return vm
+		}
+
+		newVMBody := func(vm *v1.VirtualMachine) io.ReadCloser {
+			vmJson, _ := json.Marshal(vm)
+			return &readCloserWrapper{bytes.NewReader(vmJson)}
+		}
+
+		BeforeEach(func() {
+			expandedWith = nil
+			app.expandSpec = func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+				expandedWith = vm
+				expanded := vm.DeepCopy()
+				expanded.Spec.Template.Spec.Domain.Machine = &v1.Machine{Type: "q35"}
+				return expanded, nil
+			}
+			request.PathParameters()["namespace"] = "default"
+			response.SetRequestAccepts(restful.MIME_JSON)
+		})
+
+		It("should return the expanded spec of an existing VM", func() {
+			request.PathParameters()["name"] = "testvm"
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, newVMWithTemplate("default")),
+				),
+			)
+
+			app.ExpandSpecVMRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+			Expect(expandedWith.Name).To(Equal("testvm"))
+			vm := &v1.VirtualMachine{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), vm)).To(Succeed())
+			Expect(vm.Kind).To(Equal("VirtualMachine"))
+			Expect(vm.Spec.Template.Spec.Domain.Machine.Type).To(Equal("q35"))
+		})
+
+		It("should return the expanded spec of the VM in the request body", func() {
+			request.Request.Body = newVMBody(newVMWithTemplate(""))
+
+			app.ExpandSpecRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+			Expect(expandedWith.Namespace).To(Equal("default"))
+			vm := &v1.VirtualMachine{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), vm)).To(Succeed())
+			Expect(vm.Spec.Template.Spec.Domain.Machine.Type).To(Equal("q35"))
+		})
+
+		It("should fail if the request has no body", func() {
+			app.ExpandSpecRequestHandler(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			Expect(statusErr.Error()).To(ContainSubstring("Request with no body"))
+		})
+
+		It("should fail if the namespace of the VM does not match the request", func() {
+			request.Request.Body = newVMBody(newVMWithTemplate("other"))
+
+			app.ExpandSpecRequestHandler(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			Expect(statusErr.Error()).To(ContainSubstring("does not match"))
+			Expect(expandedWith).To(BeNil())
+		})
+
+		It("should fail if the VM has no template", func() {
+			vm := newVMWithTemplate("default")
+			vm.Spec.Template = nil
+			request.Request.Body = newVMBody(vm)
+
+			app.ExpandSpecRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			Expect(expandedWith).To(BeNil())
+		})
+
+		It("should fail if the spec can not be expanded", func() {
+			app.expandSpec = func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+				return nil, fmt.Errorf("no preset")
+			}
+			request.Request.Body = newVMBody(newVMWithTemplate("default"))
+
+			app.ExpandSpecRequestHandler(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			Expect(statusErr.Error()).To(ContainSubstring("no preset"))
+		})
+	})
+
+	Context("Subresource api - preview domain XML", func() {
+		var causes []k8smetav1.StatusCause
+
+		newVMIBody := func(vmi *v1.VirtualMachineInstance) io.ReadCloser {
+			vmiJson, _ := json.Marshal(vmi)
+			return &readCloserWrapper{bytes.NewReader(vmiJson)}
+		}
+
+		newVMI := func() *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{{
+				Name:       "rootdisk",
+				DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}},
+			}}
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name: "rootdisk",
+				VolumeSource: v1.VolumeSource{
+					ContainerDisk: &v1.ContainerDiskSource{Image: "quay.io/containerdisks/fedora:35"},
+				},
+			}}
+			return vmi
+		}
+
+		BeforeEach(func() {
+			causes = nil
+			app.expandVMISpec = func(vmi *v1.VirtualMachineInstance) (*v1.VirtualMachineInstance, error) {
+				expanded := vmi.DeepCopy()
+				expanded.Spec.Domain.Machine = &v1.Machine{Type: "q35"}
+				return expanded, nil
+			}
+			app.validateVMISpec = func(vmi *v1.VirtualMachineInstance) []k8smetav1.StatusCause {
+				return causes
+			}
+			request.PathParameters()["namespace"] = "default"
+		})
+
+		It("should return the domain XML of the expanded VMI", func() {
+			request.Request.Body = newVMIBody(newVMI())
+
+			app.PreviewDomainXMLRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+			Expect(recorder.Header().Get("Content-Type")).To(Equal("application/xml"))
+			domain := &api.DomainSpec{}
+			Expect(xml.Unmarshal(recorder.Body.Bytes(), domain)).To(Succeed())
+			Expect(domain.Name).To(Equal("default_testvmi"))
+			Expect(domain.Type).To(Equal("kvm"))
+			Expect(domain.OS.Type.Machine).To(Equal("q35"))
+			Expect(domain.Devices.Disks).To(HaveLen(1))
+			Expect(domain.Devices.Disks[0].Target.Bus).To(Equal("virtio"))
+		})
+
+		It("should pin dedicated CPUs to placeholder host CPUs", func() {
+			vmi := newVMI()
+			vmi.Spec.Domain.CPU = &v1.CPU{Cores: 2, DedicatedCPUPlacement: true}
+			request.Request.Body = newVMIBody(vmi)
+
+			app.PreviewDomainXMLRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+			domain := &api.DomainSpec{}
+			Expect(xml.Unmarshal(recorder.Body.Bytes(), domain)).To(Succeed())
+			Expect(domain.CPUTune.VCPUPin).To(HaveLen(2))
+		})
+
+		It("should not look up the search domains and the block sizes on the host of virt-api", func() {
+			vmi := newVMI()
+			vmi.Spec.Domain.Devices.Disks[0].BlockSize = &v1.BlockSize{MatchVolume: &v1.FeatureState{}}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+				Name:                   "default",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{Slirp: &v1.InterfaceSlirp{}},
+			}}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			request.Request.Body = newVMIBody(vmi)
+
+			app.PreviewDomainXMLRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+			domain := &api.DomainSpec{}
+			Expect(xml.Unmarshal(recorder.Body.Bytes(), domain)).To(Succeed())
+			Expect(domain.Devices.Disks[0].BlockIO).To(BeNil())
+			Expect(recorder.Body.String()).To(ContainSubstring("user,id=default"))
+			Expect(recorder.Body.String()).ToNot(ContainSubstring("dnssearch"))
+		})
+
+		It("should fail if the VMI is invalid", func() {
+			causes = []k8smetav1.StatusCause{{
+				Type:    k8smetav1.CauseTypeFieldValueInvalid,
+				Message: "fake cause",
+				Field:   "spec.domain",
+			}}
+			request.Request.Body = newVMIBody(newVMI())
+
+			app.PreviewDomainXMLRequestHandler(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusUnprocessableEntity)
+			Expect(statusErr.ErrStatus.Reason).To(Equal(k8smetav1.StatusReasonInvalid))
+			Expect(statusErr.ErrStatus.Details.Causes).To(Equal(causes))
+		})
+
+		It("should fail if the request has no body", func() {
+			app.PreviewDomainXMLRequestHandler(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			Expect(statusErr.Error()).To(ContainSubstring("Request with no body"))
+		})
+
+		It("should fail if the namespace of the VMI does not match the request", func() {
+			vmi := newVMI()
+			vmi.Namespace = "other"
+			request.Request.Body = newVMIBody(vmi)
+
+			app.PreviewDomainXMLRequestHandler(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			Expect(statusErr.Error()).To(ContainSubstring("does not match"))
+		})
+
+		It("should fail if the spec can not be expanded", func() {
+			app.expandVMISpec = func(vmi *v1.VirtualMachineInstance) (*v1.VirtualMachineInstance, error) {
+				return nil, fmt.Errorf("no preset")
+			}
+			request.Request.Body = newVMIBody(newVMI())
+
+			app.PreviewDomainXMLRequestHandler(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			Expect(statusErr.Error()).To(ContainSubstring("no preset"))
+		})
+	})
+
 	AfterEach(func() {
 		server.Close()
 		backend.Close()
@@ -1,17 +1,63 @@
 package rest
 
 import (
+	"bytes"
+	"crypto/rand"
+	"embed"
+	"encoding/base64"
 	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"time"
 
 	restful "github.com/emicklei/go-restful"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
+	"kubevirt.io/client-go/subresources"
 	apimetrics "kubevirt.io/kubevirt/pkg/monitoring/api"
 )
 
+//go:embed novnc
+var bundledNoVNC embed.FS
+
+// noVNCFiles are the noVNC modules which virt-api bundles, see hack/bump-novnc.sh
+var noVNCFiles fs.FS = mustSub(bundledNoVNC, "novnc")
+
+// noVNCModule is the module of noVNC, which the VNC console page imports
+const noVNCModule = "core/rfb.js"
+
+// vncConsolePage connects noVNC to the vnc subresource next to the page. Query parameters of the
+// page are passed on to the websocket, to keep working behind proxies which authenticate with them.
+// noVNC is served by virt-api next to the page, therefore only scripts of the API origin and the
+// inline script of the page run.
+var vncConsolePage = template.Must(template.New("vncconsole").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Namespace}}/{{.Name}}</title>
+<style>
+html, body, #screen { margin: 0; height: 100%; background: #282828; }
+</style>
+</head>
+<body>
+<div id="screen"></div>
+<script type="module" nonce="{{.Nonce}}">
+import RFB from "./console/novnc/core/rfb.js";
+const url = new URL("../vnc" + window.location.search, window.location.href);
+url.protocol = url.protocol === "https:" ? "wss:" : "ws:";
+const rfb = new RFB(document.getElementById("screen"), url.href, { wsProtocols: [{{.Protocol}}] });
+rfb.scaleViewport = true;
+rfb.addEventListener("disconnect", () => { document.title = {{.Name}} + " (disconnected)"; });
+</script>
+</body>
+</html>
+`))
+
 func (app *SubresourceAPIApp) VNCRequestHandler(request *restful.Request, response *restful.Response) {
 	activeConnectionMetric := apimetrics.NewActiveVNCConnection(request.PathParameter("namespace"), request.PathParameter("name"))
 	defer activeConnectionMetric.Dec()
@@ -19,14 +65,87 @@ func (app *SubresourceAPIApp) VNCRequestHandler(request *restful.Request, respon
 	streamer := NewRawStreamer(
 		app.FetchVirtualMachineInstance,
 		validateVMIForVNC,
-		app.virtHandlerDialer(func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		app.recordingDialer(request, vncSession, app.virtHandlerDialer(func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
 			return conn.VNCURI(vmi)
-		}),
+		})),
 	)
 
 	streamer.Handle(request, response)
 }
 
+// VNCConsoleRequestHandler serves a noVNC browser console for the VMI, so that UIs can embed it
+// without implementing the websocket handshake of the vnc subresource themselves
+func (app *SubresourceAPIApp) VNCConsoleRequestHandler(request *restful.Request, response *restful.Response) {
+	if _, err := fs.Stat(noVNCFiles, noVNCModule); err != nil {
+		writeError(errors.NewServiceUnavailable("the browser VNC console is not available, virt-api was built without noVNC"), response)
+		return
+	}
+
+	vmi, statusErr := app.FetchVirtualMachineInstance(request.PathParameter("namespace"), request.PathParameter("name"))
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+	if statusErr := validateVMIForVNC(vmi); statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	nonce, err := newScriptNonce()
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	response.Header().Set("Content-Type", "text/html; charset=utf-8")
+	response.Header().Set("Content-Security-Policy", fmt.Sprintf("script-src 'nonce-%s' 'self'; object-src 'none'; base-uri 'none'", nonce))
+	err = vncConsolePage.Execute(response, struct {
+		Namespace string
+		Name      string
+		Protocol  string
+		Nonce     string
+	}{
+		Namespace: vmi.Namespace,
+		Name:      vmi.Name,
+		Protocol:  subresources.PlainStreamProtocolName,
+		Nonce:     nonce,
+	})
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to render the VNC console")
+	}
+}
+
+// NoVNCRequestHandler serves the modules of the bundled noVNC to the VNC console page. They are
+// served below the vnc subresource, so that the same permissions apply to the page and to noVNC.
+func (app *SubresourceAPIApp) NoVNCRequestHandler(request *restful.Request, response *restful.Response) {
+	path := request.PathParameter("path")
+	data, err := fs.ReadFile(noVNCFiles, path)
+	if err != nil {
+		writeError(errors.NewNotFound(schema.GroupResource{Resource: "novnc"}, path), response)
+		return
+	}
+
+	response.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(response, request.Request, path, time.Time{}, bytes.NewReader(data))
+}
+
+func mustSub(fsys fs.FS, dir string) fs.FS {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
+
+// newScriptNonce returns a random nonce, which allows the inline script of the VNC console page
+func newScriptNonce() (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(nonce), nil
+}
+
 func validateVMIForVNC(vmi *v1.VirtualMachineInstance) *errors.StatusError {
 	// If there are no graphics devices present, we can't proceed
 	if vmi.Spec.Domain.Devices.AutoattachGraphicsDevice != nil && *vmi.Spec.Domain.Devices.AutoattachGraphicsDevice == false {
@@ -0,0 +1,67 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"fmt"
+	"strconv"
+
+	restful "github.com/emicklei/go-restful"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/util"
+)
+
+const vsockPortParam = "port"
+
+// VSOCKRequestHandler proxies a websocket connection to a port of the VSOCK device of a VMI
+func (app *SubresourceAPIApp) VSOCKRequestHandler(request *restful.Request, response *restful.Response) {
+	if !app.clusterConfig.VSOCKEnabled() {
+		writeError(errors.NewBadRequest("VSOCK feature gate is not enabled in kubevirt-config"), response)
+		return
+	}
+	port, err := strconv.ParseUint(request.QueryParameter(vsockPortParam), 10, 32)
+	if err != nil {
+		writeError(errors.NewBadRequest(fmt.Sprintf("invalid VSOCK port: %v", err)), response)
+		return
+	}
+
+	streamer := NewRawStreamer(
+		app.FetchVirtualMachineInstance,
+		validateVMIForVSOCK,
+		app.virtHandlerDialer(func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+			return conn.VSOCKURI(vmi, uint32(port))
+		}),
+	)
+
+	streamer.Handle(request, response)
+}
+
+func validateVMIForVSOCK(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+	if vmi.Status.Phase != v1.Running {
+		return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+	}
+	if !util.IsAutoAttachVSOCK(vmi) || vmi.Status.VSOCKCID == nil {
+		return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI does not have a VSOCK device"))
+	}
+	return nil
+}
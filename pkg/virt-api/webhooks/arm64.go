@@ -22,6 +22,7 @@ package webhooks
 
 import (
 	"fmt"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
@@ -32,14 +33,18 @@ import (
 var _false bool = false
 
 const (
-	defaultCPUModel = "host-passthrough"
+	defaultCPUModel           = "host-passthrough"
+	arm64CortexCPUModelPrefix = "cortex-"
 )
 
+func isArm64CPUModel(model string) bool {
+	return model == defaultCPUModel || strings.HasPrefix(model, arm64CortexCPUModelPrefix)
+}
+
 // verifyInvalidSetting verify if VMI spec contain unavailable setting for arm64, check following items:
 // 1. if setting bios boot
 // 2. if use uefi secure boot
-// 3. if set auto-attach graphics device
-// 4. if use host-model for cpu model
+// 3. if use a cpu model other than host-passthrough or a cortex model
 func verifyInvalidSetting(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (metav1.StatusCause, bool) {
 	if spec.Domain.Firmware != nil && spec.Domain.Firmware.Bootloader != nil {
 		if spec.Domain.Firmware.Bootloader.BIOS != nil {
@@ -62,17 +67,10 @@ func verifyInvalidSetting(field *k8sfield.Path, spec *v1.VirtualMachineInstanceS
 			}
 		}
 	}
-	if &spec.Domain.Devices != nil && spec.Domain.Devices.AutoattachGraphicsDevice != nil && *spec.Domain.Devices.AutoattachGraphicsDevice {
-		return metav1.StatusCause{
-			Type:    metav1.CauseTypeFieldValueNotSupported,
-			Message: "VGA device is not support by buildin qemu-kvm in virt-launcher image for Arm64, please disable autoattachGraphicsDevice",
-			Field:   field.Child("domain", "device", "autoattachgraphicsdevice").String(),
-		}, false
-	}
-	if spec.Domain.CPU != nil && (&spec.Domain.CPU.Model != nil) && spec.Domain.CPU.Model == "host-model" {
+	if spec.Domain.CPU != nil && spec.Domain.CPU.Model != "" && !isArm64CPUModel(spec.Domain.CPU.Model) {
 		return metav1.StatusCause{
 			Type:    metav1.CauseTypeFieldValueNotSupported,
-			Message: "Arm64 not support host model well",
+			Message: fmt.Sprintf("Arm64 only supports the %s cpu model and the %s* cpu models", defaultCPUModel, arm64CortexCPUModelPrefix),
 			Field:   field.Child("domain", "cpu", "model").String(),
 		}, false
 	}
@@ -94,16 +92,6 @@ func setDefaultCPUModel(vmi *v1.VirtualMachineInstance) {
 	}
 }
 
-// setDefaultDevices set AutoattachGraphicsDevice to false
-func setDefaultDevices(vmi *v1.VirtualMachineInstance) {
-	if &vmi.Spec.Domain.Devices == nil || vmi.Spec.Domain.Devices.AutoattachGraphicsDevice == nil {
-		if &vmi.Spec.Domain.Devices == nil {
-			vmi.Spec.Domain.Devices = v1.Devices{}
-		}
-		vmi.Spec.Domain.Devices.AutoattachGraphicsDevice = &_false
-	}
-}
-
 // setDefaultBootloader set default bootloader to uefi boot
 func setDefaultBootloader(vmi *v1.VirtualMachineInstance) {
 	if vmi.Spec.Domain.Firmware == nil || vmi.Spec.Domain.Firmware.Bootloader == nil {
@@ -132,7 +120,6 @@ func SetVirtualMachineInstanceArm64Defaults(vmi *v1.VirtualMachineInstance) erro
 	path := k8sfield.NewPath("spec")
 	if cause, ok := verifyInvalidSetting(path, &vmi.Spec); ok {
 		setDefaultCPUModel(vmi)
-		setDefaultDevices(vmi)
 		setDefaultBootloader(vmi)
 	} else {
 		return fmt.Errorf("%s", cause.Message)
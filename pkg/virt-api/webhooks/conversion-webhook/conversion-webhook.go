@@ -0,0 +1,142 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package conversion_webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+)
+
+// Converter converts an object of a kind to the given version of its group. The apiVersion of the
+// object is set to the target version afterwards.
+type Converter func(obj *unstructured.Unstructured, toVersion string) error
+
+// sameSchema converts between versions which share the same schema, only the apiVersion changes
+func sameSchema(_ *unstructured.Unstructured, _ string) error {
+	return nil
+}
+
+// converters holds the converter of every group kind whose CRD virt-operator switches to the webhook.
+// Converters for kinds whose schema differs between the versions have to be registered here
+// before such a version is served. The KubeVirt CRD is not part of the install strategy, it is
+// deployed with virt-operator, and never uses the webhook.
+var converters = map[schema.GroupKind]Converter{
+	{Group: v1.GroupName, Kind: "VirtualMachine"}:                   sameSchema,
+	{Group: v1.GroupName, Kind: "VirtualMachineInstance"}:           sameSchema,
+	{Group: v1.GroupName, Kind: "VirtualMachineInstancePreset"}:     sameSchema,
+	{Group: v1.GroupName, Kind: "VirtualMachineInstanceReplicaSet"}: sameSchema,
+	{Group: v1.GroupName, Kind: "VirtualMachineInstanceMigration"}:  sameSchema,
+}
+
+// Convert converts all objects of the request to the desired version
+func Convert(request *extv1.ConversionRequest) *extv1.ConversionResponse {
+	response := &extv1.ConversionResponse{UID: request.UID}
+
+	desired, err := schema.ParseGroupVersion(request.DesiredAPIVersion)
+	if err != nil {
+		return conversionFailure(response, err)
+	}
+
+	for _, raw := range request.Objects {
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(raw.Raw); err != nil {
+			return conversionFailure(response, err)
+		}
+		gvk := obj.GroupVersionKind()
+		if gvk.Group != desired.Group {
+			return conversionFailure(response, fmt.Errorf("can't convert %s from group %s to group %s", gvk.Kind, gvk.Group, desired.Group))
+		}
+		converter, exists := converters[gvk.GroupKind()]
+		if !exists {
+			return conversionFailure(response, fmt.Errorf("no conversion registered for %s", gvk.GroupKind()))
+		}
+		if gvk.Version != desired.Version {
+			if err := converter(obj, desired.Version); err != nil {
+				return conversionFailure(response, fmt.Errorf("failed to convert %s %s/%s to %s: %v", gvk.Kind, obj.GetNamespace(), obj.GetName(), desired.Version, err))
+			}
+			obj.SetAPIVersion(request.DesiredAPIVersion)
+		}
+
+		converted, err := obj.MarshalJSON()
+		if err != nil {
+			return conversionFailure(response, err)
+		}
+		response.ConvertedObjects = append(response.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+
+	response.Result = metav1.Status{Status: metav1.StatusSuccess}
+	return response
+}
+
+func conversionFailure(response *extv1.ConversionResponse, err error) *extv1.ConversionResponse {
+	log.Log.Reason(err).Error("CRD conversion failed")
+	response.ConvertedObjects = nil
+	response.Result = metav1.Status{
+		Status:  metav1.StatusFailure,
+		Message: err.Error(),
+	}
+	return response
+}
+
+// Serve handles the ConversionReviews of the API server
+func Serve(resp http.ResponseWriter, req *http.Request) {
+	if contentType := req.Header.Get("Content-Type"); contentType != "application/json" {
+		log.Log.Errorf("received a conversion review with content type %s, expect application/json", contentType)
+		resp.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		resp.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	review := &extv1.ConversionReview{}
+	if err := json.Unmarshal(body, review); err != nil || review.Request == nil {
+		log.Log.Reason(err).Error("failed to decode the conversion review")
+		resp.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	response := extv1.ConversionReview{
+		TypeMeta: review.TypeMeta,
+		Response: Convert(review.Request),
+	}
+	responseBytes, err := json.Marshal(response)
+	if err != nil {
+		log.Log.Reason(err).Errorf("failed json encode conversion response")
+		resp.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp.Header().Set("Content-Type", "application/json")
+	if _, err := resp.Write(responseBytes); err != nil {
+		log.Log.Reason(err).Errorf("failed to write conversion response")
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package conversion_webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Conversion webhook", func() {
+
+	newObject := func(apiVersion, kind, name string) runtime.RawExtension {
+		raw, err := json.Marshal(map[string]interface{}{
+			"apiVersion": apiVersion,
+			"kind":       kind,
+			"metadata":   map[string]interface{}{"name": name, "namespace": "default"},
+			"spec":       map[string]interface{}{"running": true},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		return runtime.RawExtension{Raw: raw}
+	}
+
+	decode := func(raw runtime.RawExtension) map[string]interface{} {
+		obj := map[string]interface{}{}
+		Expect(json.Unmarshal(raw.Raw, &obj)).To(Succeed())
+		return obj
+	}
+
+	It("should convert all objects to the desired version and keep their order", func() {
+		response := Convert(&extv1.ConversionRequest{
+			UID:               "1234",
+			DesiredAPIVersion: "kubevirt.io/v1",
+			Objects: []runtime.RawExtension{
+				newObject("kubevirt.io/v1alpha3", "VirtualMachine", "first"),
+				newObject("kubevirt.io/v1", "VirtualMachine", "second"),
+			},
+		})
+
+		Expect(response.UID).To(BeEquivalentTo("1234"))
+		Expect(response.Result.Status).To(Equal(metav1.StatusSuccess))
+		Expect(response.ConvertedObjects).To(HaveLen(2))
+		for i, name := range []string{"first", "second"} {
+			obj := decode(response.ConvertedObjects[i])
+			Expect(obj["apiVersion"]).To(Equal("kubevirt.io/v1"))
+			Expect(obj["metadata"]).To(HaveKeyWithValue("name", name))
+			Expect(obj["spec"]).To(HaveKeyWithValue("running", true))
+		}
+	})
+
+	It("should fail the conversion of unknown kinds", func() {
+		response := Convert(&extv1.ConversionRequest{
+			DesiredAPIVersion: "kubevirt.io/v1",
+			Objects:           []runtime.RawExtension{newObject("kubevirt.io/v1alpha3", "Unknown", "first")},
+		})
+		Expect(response.Result.Status).To(Equal(metav1.StatusFailure))
+		Expect(response.ConvertedObjects).To(BeEmpty())
+	})
+
+	It("should fail the conversion to another group", func() {
+		response := Convert(&extv1.ConversionRequest{
+			DesiredAPIVersion: "snapshot.kubevirt.io/v1alpha1",
+			Objects:           []runtime.RawExtension{newObject(v1.GroupVersion.String(), "VirtualMachine", "first")},
+		})
+		Expect(response.Result.Status).To(Equal(metav1.StatusFailure))
+	})
+
+	It("should answer conversion reviews", func() {
+		review := extv1.ConversionReview{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apiextensions.k8s.io/v1", Kind: "ConversionReview"},
+			Request: &extv1.ConversionRequest{
+				UID:               "1234",
+				DesiredAPIVersion: "kubevirt.io/v1alpha3",
+				Objects:           []runtime.RawExtension{newObject("kubevirt.io/v1", "VirtualMachineInstance", "first")},
+			},
+		}
+		body, err := json.Marshal(review)
+		Expect(err).ToNot(HaveOccurred())
+		request := httptest.NewRequest(http.MethodPost, "/kubevirt-conversion", bytes.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+
+		Serve(recorder, request)
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		response := extv1.ConversionReview{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &response)).To(Succeed())
+		Expect(response.Kind).To(Equal("ConversionReview"))
+		Expect(response.Response.UID).To(BeEquivalentTo("1234"))
+		Expect(response.Response.Result.Status).To(Equal(metav1.StatusSuccess))
+		Expect(decode(response.Response.ConvertedObjects[0])["apiVersion"]).To(Equal("kubevirt.io/v1alpha3"))
+	})
+})
@@ -0,0 +1,11 @@
+package conversion_webhook
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestConversionWebhook(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
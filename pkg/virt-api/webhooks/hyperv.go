@@ -178,7 +178,7 @@ func ValidateVirtualMachineInstanceHypervFeatureDependencies(field *k8sfield.Pat
 		}
 	}
 
-	if spec.Domain.Features != nil && spec.Domain.Features.Hyperv != nil && spec.Domain.Features.Hyperv.EVMCS != nil {
+	if spec.Domain.Features != nil && spec.Domain.Features.Hyperv != nil && isEVMCSEnabled(spec.Domain.Features.Hyperv) {
 		if spec.Domain.CPU == nil || spec.Domain.CPU.Features == nil || len(spec.Domain.CPU.Features) == 0 {
 			causes = append(causes, metav1.StatusCause{Type: metav1.CauseTypeFieldValueRequired, Message: "vmx cpu feature is required when evmcs is set", Field: "spec.domain.cpu.features"})
 		} else if spec.Domain.CPU != nil || spec.Domain.CPU.Features != nil {
@@ -207,13 +207,18 @@ func SetVirtualMachineInstanceHypervFeatureDependencies(vmi *v1.VirtualMachineIn
 	}
 
 	//Check if vmi has EVMCS feature enabled. If yes, we have to add vmx cpu feature
-	if vmi.Spec.Domain.Features != nil && vmi.Spec.Domain.Features.Hyperv != nil && vmi.Spec.Domain.Features.Hyperv.EVMCS != nil {
+	if vmi.Spec.Domain.Features != nil && vmi.Spec.Domain.Features.Hyperv != nil && isEVMCSEnabled(vmi.Spec.Domain.Features.Hyperv) {
 		setEVMCSDependency(vmi)
 	}
 
 	return nil
 }
 
+// isEVMCSEnabled checks whether evmcs is set and not explicitly disabled, like the domain converter does
+func isEVMCSEnabled(hyperv *v1.FeatureHyperv) bool {
+	return hyperv.EVMCS != nil && (hyperv.EVMCS.Enabled == nil || *hyperv.EVMCS.Enabled)
+}
+
 func setEVMCSDependency(vmi *v1.VirtualMachineInstance) {
 	vmxFeature := v1.CPUFeature{
 		Name:   nodelabellerutil.VmxFeature,
@@ -0,0 +1,113 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package mutators
+
+import (
+	"strings"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+)
+
+type dataVolumeDefaults struct {
+	storageClassName *string
+	volumeMode       *k8sv1.PersistentVolumeMode
+	accessModes      []k8sv1.PersistentVolumeAccessMode
+}
+
+// applyNamespaceDataVolumeDefaults copies the dataVolumeTemplate defaults of the namespace (if
+// exist) to the dataVolumeTemplates of the VM, without overriding anything set on the templates.
+func applyNamespaceDataVolumeDefaults(vm *v1.VirtualMachine, namespaceInformer cache.SharedIndexInformer) {
+	if namespaceInformer == nil || len(vm.Spec.DataVolumeTemplates) == 0 {
+		return
+	}
+
+	obj, exists, err := namespaceInformer.GetStore().GetByKey(vm.Namespace)
+	if err != nil || !exists {
+		return
+	}
+	defaults := dataVolumeDefaultsFromNamespace(obj.(*k8sv1.Namespace))
+	if defaults == nil {
+		return
+	}
+
+	log.Log.Object(vm).V(4).Info("Apply namespace dataVolumeTemplate defaults")
+	for i := range vm.Spec.DataVolumeTemplates {
+		spec := &vm.Spec.DataVolumeTemplates[i].Spec
+		if spec.PVC != nil {
+			applyDataVolumeDefaults(defaults, &spec.PVC.StorageClassName, &spec.PVC.VolumeMode, &spec.PVC.AccessModes)
+		}
+		if spec.Storage != nil {
+			applyDataVolumeDefaults(defaults, &spec.Storage.StorageClassName, &spec.Storage.VolumeMode, &spec.Storage.AccessModes)
+		}
+	}
+}
+
+func dataVolumeDefaultsFromNamespace(namespace *k8sv1.Namespace) *dataVolumeDefaults {
+	defaults := &dataVolumeDefaults{}
+	found := false
+
+	if storageClassName, ok := namespace.Annotations[v1.DataVolumeDefaultStorageClassAnnotation]; ok && storageClassName != "" {
+		defaults.storageClassName = &storageClassName
+		found = true
+	}
+	if volumeMode, ok := namespace.Annotations[v1.DataVolumeDefaultVolumeModeAnnotation]; ok {
+		switch mode := k8sv1.PersistentVolumeMode(volumeMode); mode {
+		case k8sv1.PersistentVolumeBlock, k8sv1.PersistentVolumeFilesystem:
+			defaults.volumeMode = &mode
+			found = true
+		default:
+			log.Log.Warningf("ignoring invalid volume mode %q on namespace %s", volumeMode, namespace.Name)
+		}
+	}
+	if accessModes, ok := namespace.Annotations[v1.DataVolumeDefaultAccessModesAnnotation]; ok {
+		for _, accessMode := range strings.Split(accessModes, ",") {
+			switch mode := k8sv1.PersistentVolumeAccessMode(strings.TrimSpace(accessMode)); mode {
+			case k8sv1.ReadWriteOnce, k8sv1.ReadOnlyMany, k8sv1.ReadWriteMany:
+				defaults.accessModes = append(defaults.accessModes, mode)
+			default:
+				log.Log.Warningf("ignoring invalid access mode %q on namespace %s", accessMode, namespace.Name)
+			}
+		}
+		found = found || len(defaults.accessModes) > 0
+	}
+
+	if !found {
+		return nil
+	}
+	return defaults
+}
+
+func applyDataVolumeDefaults(defaults *dataVolumeDefaults, storageClassName **string, volumeMode **k8sv1.PersistentVolumeMode, accessModes *[]k8sv1.PersistentVolumeAccessMode) {
+	if *storageClassName == nil && defaults.storageClassName != nil {
+		name := *defaults.storageClassName
+		*storageClassName = &name
+	}
+	if *volumeMode == nil && defaults.volumeMode != nil {
+		mode := *defaults.volumeMode
+		*volumeMode = &mode
+	}
+	if len(*accessModes) == 0 && len(defaults.accessModes) > 0 {
+		*accessModes = append([]k8sv1.PersistentVolumeAccessMode{}, defaults.accessModes...)
+	}
+}
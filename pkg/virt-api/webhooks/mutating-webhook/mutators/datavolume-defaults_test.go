@@ -0,0 +1,119 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package mutators
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1beta1"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Mutating Webhook Namespace DataVolume defaults", func() {
+	var vm *v1.VirtualMachine
+	var namespace *k8sv1.Namespace
+	var namespaceInformer cache.SharedIndexInformer
+
+	block := k8sv1.PersistentVolumeBlock
+	filesystem := k8sv1.PersistentVolumeFilesystem
+	localStorage := "local"
+
+	BeforeEach(func() {
+		vm = &v1.VirtualMachine{
+			ObjectMeta: k8smetav1.ObjectMeta{Name: "testvm", Namespace: "tenant"},
+			Spec: v1.VirtualMachineSpec{
+				DataVolumeTemplates: []v1.DataVolumeTemplateSpec{
+					{
+						ObjectMeta: k8smetav1.ObjectMeta{Name: "pvc-dv"},
+						Spec:       cdiv1.DataVolumeSpec{PVC: &k8sv1.PersistentVolumeClaimSpec{}},
+					},
+					{
+						ObjectMeta: k8smetav1.ObjectMeta{Name: "storage-dv"},
+						Spec:       cdiv1.DataVolumeSpec{Storage: &cdiv1.StorageSpec{}},
+					},
+				},
+			},
+		}
+		namespace = &k8sv1.Namespace{
+			ObjectMeta: k8smetav1.ObjectMeta{
+				Name: "tenant",
+				Annotations: map[string]string{
+					v1.DataVolumeDefaultStorageClassAnnotation: "rook-ceph-block",
+					v1.DataVolumeDefaultVolumeModeAnnotation:   "Block",
+					v1.DataVolumeDefaultAccessModesAnnotation:  "ReadWriteMany, ReadWriteOnce",
+				},
+			},
+		}
+		namespaceInformer, _ = testutils.NewFakeInformerFor(&k8sv1.Namespace{})
+		namespaceInformer.GetStore().Add(namespace)
+	})
+
+	It("should apply the namespace defaults to all dataVolumeTemplates", func() {
+		applyNamespaceDataVolumeDefaults(vm, namespaceInformer)
+
+		pvc := vm.Spec.DataVolumeTemplates[0].Spec.PVC
+		Expect(*pvc.StorageClassName).To(Equal("rook-ceph-block"))
+		Expect(*pvc.VolumeMode).To(Equal(block))
+		Expect(pvc.AccessModes).To(Equal([]k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteMany, k8sv1.ReadWriteOnce}))
+
+		storage := vm.Spec.DataVolumeTemplates[1].Spec.Storage
+		Expect(*storage.StorageClassName).To(Equal("rook-ceph-block"))
+		Expect(*storage.VolumeMode).To(Equal(block))
+		Expect(storage.AccessModes).To(Equal([]k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteMany, k8sv1.ReadWriteOnce}))
+	})
+
+	It("should not override values set on the dataVolumeTemplates", func() {
+		pvc := vm.Spec.DataVolumeTemplates[0].Spec.PVC
+		pvc.StorageClassName = &localStorage
+		pvc.VolumeMode = &filesystem
+		pvc.AccessModes = []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteOnce}
+
+		applyNamespaceDataVolumeDefaults(vm, namespaceInformer)
+
+		Expect(*pvc.StorageClassName).To(Equal(localStorage))
+		Expect(*pvc.VolumeMode).To(Equal(filesystem))
+		Expect(pvc.AccessModes).To(Equal([]k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteOnce}))
+	})
+
+	It("should ignore invalid defaults", func() {
+		namespace.Annotations = map[string]string{
+			v1.DataVolumeDefaultVolumeModeAnnotation:  "Raw",
+			v1.DataVolumeDefaultAccessModesAnnotation: "ReadWriteSometimes",
+		}
+
+		applyNamespaceDataVolumeDefaults(vm, namespaceInformer)
+
+		Expect(vm.Spec.DataVolumeTemplates[0].Spec.PVC).To(Equal(&k8sv1.PersistentVolumeClaimSpec{}))
+	})
+
+	It("should not change anything without namespace defaults", func() {
+		vm.Namespace = "other"
+
+		applyNamespaceDataVolumeDefaults(vm, namespaceInformer)
+
+		Expect(vm.Spec.DataVolumeTemplates[0].Spec.PVC).To(Equal(&k8sv1.PersistentVolumeClaimSpec{}))
+		Expect(vm.Spec.DataVolumeTemplates[1].Spec.Storage).To(Equal(&cdiv1.StorageSpec{}))
+	})
+})
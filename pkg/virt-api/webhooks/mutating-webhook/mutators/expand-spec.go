@@ -0,0 +1,84 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package mutators
+
+import (
+	"fmt"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+// ExpandVirtualMachineSpec returns a copy of the VM whose template has the defaults of the VM and
+// VMI mutating webhooks and the matching presets applied, which is what a VMI of the VM would get
+// if it was created now. The VM itself is left untouched.
+func ExpandVirtualMachineSpec(vm *v1.VirtualMachine, clusterConfig *virtconfig.ClusterConfig, informers *webhooks.Informers) (*v1.VirtualMachine, error) {
+	if vm.Spec.Template == nil {
+		return nil, fmt.Errorf("VirtualMachine %s has no template", vm.Name)
+	}
+
+	expanded := vm.DeepCopy()
+	vmMutator := &VMsMutator{
+		ClusterConfig:     clusterConfig,
+		NamespaceInformer: informers.NamespaceInformer,
+	}
+	vmMutator.setDefaultMachineType(expanded)
+	if expanded.CreationTimestamp.IsZero() {
+		applyNamespaceDataVolumeDefaults(expanded, informers.NamespaceInformer)
+	}
+
+	// the VMI is set up from the template like virt-controller does it
+	vmi := &v1.VirtualMachineInstance{
+		ObjectMeta: *expanded.Spec.Template.ObjectMeta.DeepCopy(),
+		Spec:       *expanded.Spec.Template.Spec.DeepCopy(),
+	}
+	vmi.Name = expanded.Name
+	vmi.GenerateName = ""
+	vmi.Namespace = expanded.Namespace
+
+	vmi, err := ExpandVirtualMachineInstanceSpec(vmi, clusterConfig, informers)
+	if err != nil {
+		return nil, err
+	}
+
+	expanded.Spec.Template.ObjectMeta.Labels = vmi.Labels
+	expanded.Spec.Template.ObjectMeta.Annotations = vmi.Annotations
+	expanded.Spec.Template.Spec = vmi.Spec
+	return expanded, nil
+}
+
+// ExpandVirtualMachineInstanceSpec returns a copy of the VMI with the matching presets and the
+// defaults of the VMI mutating webhook applied. The VMI itself is left untouched.
+func ExpandVirtualMachineInstanceSpec(vmi *v1.VirtualMachineInstance, clusterConfig *virtconfig.ClusterConfig, informers *webhooks.Informers) (*v1.VirtualMachineInstance, error) {
+	expanded := vmi.DeepCopy()
+	if err := applyPresets(expanded, informers.VMIPresetInformer); err != nil {
+		return nil, err
+	}
+	vmiMutator := &VMIsMutator{
+		ClusterConfig:           clusterConfig,
+		VMIPresetInformer:       informers.VMIPresetInformer,
+		NamespaceLimitsInformer: informers.NamespaceLimitsInformer,
+	}
+	if err := vmiMutator.applyDefaults(expanded); err != nil {
+		return nil, err
+	}
+	return expanded, nil
+}
@@ -0,0 +1,132 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package mutators
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+var _ = Describe("Expanding the spec of a VirtualMachine", func() {
+	var vm *v1.VirtualMachine
+	var informers *webhooks.Informers
+	var clusterConfig *virtconfig.ClusterConfig
+
+	BeforeEach(func() {
+		vm = &v1.VirtualMachine{
+			ObjectMeta: k8smetav1.ObjectMeta{
+				Name:      "testvm",
+				Namespace: "default",
+			},
+			Spec: v1.VirtualMachineSpec{
+				Template: &v1.VirtualMachineInstanceTemplateSpec{
+					ObjectMeta: k8smetav1.ObjectMeta{
+						Labels: map[string]string{"test": "test"},
+					},
+				},
+			},
+		}
+
+		presetInformer, _ := testutils.NewFakeInformerFor(&v1.VirtualMachineInstancePreset{})
+		presetInformer.GetIndexer().Add(&v1.VirtualMachineInstancePreset{
+			ObjectMeta: k8smetav1.ObjectMeta{
+				Name:      "test-preset",
+				Namespace: "default",
+			},
+			Spec: v1.VirtualMachineInstancePresetSpec{
+				Domain: &v1.DomainSpec{
+					CPU: &v1.CPU{Cores: 4},
+				},
+				Selector: k8smetav1.LabelSelector{MatchLabels: map[string]string{"test": "test"}},
+			},
+		})
+		namespaceLimitsInformer, _ := testutils.NewFakeInformerFor(&k8sv1.LimitRange{})
+		namespaceInformer, _ := testutils.NewFakeInformerFor(&k8sv1.Namespace{})
+		informers = &webhooks.Informers{
+			VMIPresetInformer:       presetInformer,
+			NamespaceLimitsInformer: namespaceLimitsInformer,
+			NamespaceInformer:       namespaceInformer,
+		}
+		clusterConfig, _, _, _ = testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			MachineType: "pc-q35-test",
+		})
+	})
+
+	It("should apply the defaults and presets to the template", func() {
+		expanded, err := ExpandVirtualMachineSpec(vm, clusterConfig, informers)
+		Expect(err).ToNot(HaveOccurred())
+
+		spec := expanded.Spec.Template.Spec
+		Expect(spec.Domain.Machine).To(Equal(&v1.Machine{Type: "pc-q35-test"}))
+		Expect(spec.Domain.CPU.Cores).To(Equal(uint32(4)))
+		Expect(spec.Domain.Devices.Interfaces).To(HaveLen(1))
+		Expect(spec.Networks).To(Equal([]v1.Network{*v1.DefaultPodNetwork()}))
+		Expect(expanded.Spec.Template.ObjectMeta.Labels).To(HaveKeyWithValue("test", "test"))
+		Expect(expanded.Spec.Template.ObjectMeta.Annotations).To(HaveKey("virtualmachinepreset.kubevirt.io/test-preset"))
+	})
+
+	It("should not modify the given VirtualMachine", func() {
+		original := vm.DeepCopy()
+		_, err := ExpandVirtualMachineSpec(vm, clusterConfig, informers)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(vm).To(Equal(original))
+	})
+
+	It("should keep what the template already sets", func() {
+		vm.Spec.Template.Spec.Domain.Machine = &v1.Machine{Type: "q35"}
+		vm.Spec.Template.Spec.Domain.CPU = &v1.CPU{Cores: 2}
+
+		expanded, err := ExpandVirtualMachineSpec(vm, clusterConfig, informers)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(expanded.Spec.Template.Spec.Domain.Machine.Type).To(Equal("q35"))
+		Expect(expanded.Spec.Template.Spec.Domain.CPU.Cores).To(Equal(uint32(2)))
+	})
+
+	It("should fail if the VirtualMachine has no template", func() {
+		vm.Spec.Template = nil
+		_, err := ExpandVirtualMachineSpec(vm, clusterConfig, informers)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should apply the defaults and presets to a VirtualMachineInstance", func() {
+		vmi := &v1.VirtualMachineInstance{
+			ObjectMeta: k8smetav1.ObjectMeta{
+				Name:      "testvmi",
+				Namespace: "default",
+				Labels:    map[string]string{"test": "test"},
+			},
+		}
+		original := vmi.DeepCopy()
+
+		expanded, err := ExpandVirtualMachineInstanceSpec(vmi, clusterConfig, informers)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(expanded.Spec.Domain.Machine).To(Equal(&v1.Machine{Type: "pc-q35-test"}))
+		Expect(expanded.Spec.Domain.CPU.Cores).To(Equal(uint32(4)))
+		Expect(expanded.Annotations).To(HaveKey("virtualmachinepreset.kubevirt.io/test-preset"))
+		Expect(vmi).To(Equal(original))
+	})
+})
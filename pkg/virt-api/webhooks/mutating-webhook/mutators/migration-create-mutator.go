@@ -51,6 +51,7 @@ func (mutator *MigrationCreateMutator) Mutate(ar *admissionv1.AdmissionReview) *
 	if err != nil {
 		return webhookutils.ToAdmissionResponseError(err)
 	}
+	original := migration.DeepCopy()
 
 	// Add our selector label
 	if migration.Labels == nil {
@@ -61,22 +62,12 @@ func (mutator *MigrationCreateMutator) Mutate(ar *admissionv1.AdmissionReview) *
 
 	// Add a finalizer
 	migration.Finalizers = append(migration.Finalizers, v1.VirtualMachineInstanceMigrationFinalizer)
-	var patch []utiltypes.PatchOperation
-	var value interface{}
 
-	value = migration.Spec
-	patch = append(patch, utiltypes.PatchOperation{
-		Op:    "replace",
-		Path:  "/spec",
-		Value: value,
-	})
-
-	value = migration.ObjectMeta
-	patch = append(patch, utiltypes.PatchOperation{
-		Op:    "replace",
-		Path:  "/metadata",
-		Value: value,
-	})
+	// Only patch the label and the finalizer, the rest of the request is left as it is
+	patch, err := utiltypes.GeneratePatchOperations(raw, original, &migration)
+	if err != nil {
+		return webhookutils.ToAdmissionResponseError(err)
+	}
 
 	patchBytes, err := json.Marshal(patch)
 	if err != nil {
@@ -22,6 +22,7 @@ package mutators
 import (
 	"encoding/json"
 
+	jsonpatch "github.com/evanphx/json-patch"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	admissionv1 "k8s.io/api/admission/v1"
@@ -29,7 +30,6 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	v1 "kubevirt.io/client-go/api/v1"
-	utiltypes "kubevirt.io/kubevirt/pkg/util/types"
 )
 
 var _ = Describe("VirtualMachineInstanceMigration Mutator", func() {
@@ -53,18 +53,16 @@ var _ = Describe("VirtualMachineInstanceMigration Mutator", func() {
 		resp := mutator.Mutate(ar)
 		Expect(resp.Allowed).To(BeTrue())
 
-		By("Getting the VMI spec from the response")
-		migrationSpec := &v1.VirtualMachineInstanceMigrationSpec{}
-		migrationMeta := &k8smetav1.ObjectMeta{}
-		patch := []utiltypes.PatchOperation{
-			{Value: migrationSpec},
-			{Value: migrationMeta},
-		}
-		err = json.Unmarshal(resp.Patch, &patch)
+		By("Applying the patch of the response to the Migration")
+		patch, err := jsonpatch.DecodePatch(resp.Patch)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(patch).NotTo(BeEmpty())
+		patchedBytes, err := patch.Apply(migrationBytes)
+		Expect(err).ToNot(HaveOccurred())
+		patchedMigration := &v1.VirtualMachineInstanceMigration{}
+		Expect(json.Unmarshal(patchedBytes, patchedMigration)).To(Succeed())
 
-		return migrationSpec, migrationMeta
+		return &patchedMigration.Spec, &patchedMigration.ObjectMeta
 	}
 
 	BeforeEach(func() {
@@ -22,9 +22,11 @@ import (
 	"encoding/json"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/client-go/tools/cache"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util/guestdefaults"
 	utiltypes "kubevirt.io/kubevirt/pkg/util/types"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
@@ -32,7 +34,8 @@ import (
 )
 
 type VMsMutator struct {
-	ClusterConfig *virtconfig.ClusterConfig
+	ClusterConfig     *virtconfig.ClusterConfig
+	NamespaceInformer cache.SharedIndexInformer
 }
 
 // until the minimum supported version is kubernetes 1.15 (see https://github.com/kubernetes/kubernetes/commit/c2fcdc818be1441dd788cae22648c04b1650d3af#diff-e057ec5b2ec27b4ba1e1a3915f715262)
@@ -65,23 +68,22 @@ func (mutator *VMsMutator) Mutate(ar *admissionv1.AdmissionReview) *admissionv1.
 
 	// Set VM defaults
 	log.Log.Object(&vm).V(4).Info("Apply defaults")
+	original := vm.DeepCopy()
 	mutator.setDefaultMachineType(&vm)
+	if ar.Request.Operation == admissionv1.Create {
+		applyNamespaceDataVolumeDefaults(&vm, mutator.NamespaceInformer)
+	}
 
-	var patch []utiltypes.PatchOperation
-	var value interface{}
-	value = vm.Spec
-	patch = append(patch, utiltypes.PatchOperation{
-		Op:    "replace",
-		Path:  "/spec",
-		Value: value,
-	})
-
-	value = vm.ObjectMeta
-	patch = append(patch, utiltypes.PatchOperation{
-		Op:    "replace",
-		Path:  "/metadata",
-		Value: value,
-	})
+	// Only patch the defaulted fields, so that server-side apply doesn't see changes to fields
+	// which the defaults don't cover, and so that GitOps tools don't fight the webhook over them
+	patch, err := utiltypes.GeneratePatchOperations(raw, original, &vm)
+	if err != nil {
+		log.Log.V(1).Warningf("vm-mutator: unable to generate the patch of the object in request")
+		return emptyValidResponse()
+	}
+	if len(patch) == 0 {
+		return emptyValidResponse()
+	}
 
 	patchBytes, err := json.Marshal(patch)
 	if err != nil {
@@ -107,8 +109,10 @@ func (mutator *VMsMutator) setDefaultMachineType(vm *v1.VirtualMachine) {
 	if machine := vm.Spec.Template.Spec.Domain.Machine; machine != nil {
 		if machine.Type == "" {
 			machine.Type = machineType
+			guestdefaults.MarkApplied(&vm.Spec.Template.ObjectMeta, guestdefaults.MachineType)
 		}
 	} else {
 		vm.Spec.Template.Spec.Domain.Machine = &v1.Machine{Type: machineType}
+		guestdefaults.MarkApplied(&vm.Spec.Template.ObjectMeta, guestdefaults.MachineType)
 	}
 }
@@ -21,6 +21,7 @@ package mutators
 import (
 	"encoding/json"
 
+	jsonpatch "github.com/evanphx/json-patch"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	admissionv1 "k8s.io/api/admission/v1"
@@ -60,17 +61,17 @@ var _ = Describe("VirtualMachine Mutator", func() {
 		Expect(resp.Allowed).To(BeTrue())
 
 		By("Getting the VM spec from the response")
-		vmSpec := &v1.VirtualMachineSpec{}
-		vmMeta := &k8smetav1.ObjectMeta{}
-		patch := []utiltypes.PatchOperation{
-			{Value: vmSpec},
-			{Value: vmMeta},
+		patchedBytes := vmBytes
+		if len(resp.Patch) > 0 {
+			patch, err := jsonpatch.DecodePatch(resp.Patch)
+			Expect(err).ToNot(HaveOccurred())
+			patchedBytes, err = patch.Apply(vmBytes)
+			Expect(err).ToNot(HaveOccurred())
 		}
-		err = json.Unmarshal(resp.Patch, &patch)
-		Expect(err).ToNot(HaveOccurred())
-		Expect(patch).NotTo(BeEmpty())
+		patchedVM := &v1.VirtualMachine{}
+		Expect(json.Unmarshal(patchedBytes, patchedVM)).To(Succeed())
 
-		return vmSpec, vmMeta
+		return &patchedVM.Spec, &patchedVM.ObjectMeta
 	}
 
 	BeforeEach(func() {
@@ -117,5 +118,53 @@ var _ = Describe("VirtualMachine Mutator", func() {
 
 		vmSpec, _ := getVMSpecMetaFromResponse()
 		Expect(vmSpec.Template.Spec.Domain.Machine.Type).To(Equal(vm.Spec.Template.Spec.Domain.Machine.Type))
+		Expect(vmSpec.Template.ObjectMeta.Annotations).ToNot(HaveKey(v1.AppliedGuestDefaultsAnnotation))
+	})
+
+	It("should not patch a VM whose defaults are already set", func() {
+		vm.Spec.Template.Spec.Domain.Machine = &v1.Machine{Type: "q35"}
+		vmBytes, err := json.Marshal(vm)
+		Expect(err).ToNot(HaveOccurred())
+
+		resp := mutator.Mutate(&admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+				Resource:  k8smetav1.GroupVersionResource{Group: v1.VirtualMachineGroupVersionKind.Group, Version: v1.VirtualMachineGroupVersionKind.Version, Resource: "virtualmachines"},
+				Object: runtime.RawExtension{
+					Raw: vmBytes,
+				},
+			},
+		})
+		Expect(resp.Allowed).To(BeTrue())
+		Expect(resp.Patch).To(BeEmpty())
+	})
+
+	It("should only patch the defaulted fields", func() {
+		vmBytes := []byte(`{"apiVersion":"kubevirt.io/v1","kind":"VirtualMachine","metadata":{"name":"testvm"},` +
+			`"spec":{"template":{"spec":{"domain":{"devices":{},"resources":{"requests":{"memory":"1024Mi"}}}}}}}`)
+
+		resp := mutator.Mutate(&admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Resource:  k8smetav1.GroupVersionResource{Group: v1.VirtualMachineGroupVersionKind.Group, Version: v1.VirtualMachineGroupVersionKind.Version, Resource: "virtualmachines"},
+				Object: runtime.RawExtension{
+					Raw: vmBytes,
+				},
+			},
+		})
+		Expect(resp.Allowed).To(BeTrue())
+
+		patch := []utiltypes.PatchOperation{}
+		Expect(json.Unmarshal(resp.Patch, &patch)).To(Succeed())
+		paths := []string{}
+		for _, operation := range patch {
+			paths = append(paths, operation.Path)
+		}
+		Expect(paths).To(ConsistOf("/spec/template/metadata", "/spec/template/spec/domain/machine"))
+	})
+
+	It("should track the defaulted machine type on the VM template", func() {
+		vmSpec, _ := getVMSpecMetaFromResponse()
+		Expect(vmSpec.Template.ObjectMeta.Annotations).To(HaveKeyWithValue(v1.AppliedGuestDefaultsAnnotation, "machineType"))
 	})
 })
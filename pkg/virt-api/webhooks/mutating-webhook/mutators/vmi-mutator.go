@@ -32,16 +32,21 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/pointer"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/guestdefaults"
 	utiltypes "kubevirt.io/kubevirt/pkg/util/types"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
+// virtioWinVolumeName is the name of the disk and volume of the virtio-win drivers
+const virtioWinVolumeName = "virtio-win"
+
 type VMIsMutator struct {
 	ClusterConfig           *virtconfig.ClusterConfig
 	VMIPresetInformer       cache.SharedIndexInformer
@@ -67,6 +72,8 @@ func (mutator *VMIsMutator) Mutate(ar *admissionv1.AdmissionReview) *admissionv1
 
 	// Patch the spec, metadata and status with defaults if we deal with a create operation
 	if ar.Request.Operation == admissionv1.Create {
+		original := newVMI.DeepCopy()
+
 		// Apply presets
 		err = applyPresets(newVMI, mutator.VMIPresetInformer)
 		if err != nil {
@@ -78,49 +85,18 @@ func (mutator *VMIsMutator) Mutate(ar *admissionv1.AdmissionReview) *admissionv1
 			}
 		}
 
-		// Apply namespace limits
-		applyNamespaceLimitRangeValues(newVMI, mutator.NamespaceLimitsInformer)
-
-		// Set VMI defaults
-		log.Log.Object(newVMI).V(4).Info("Apply defaults")
-		mutator.setDefaultCPUModel(newVMI)
-		mutator.setDefaultMachineType(newVMI)
-		mutator.setDefaultResourceRequests(newVMI)
-		mutator.setDefaultGuestCPUTopology(newVMI)
-		mutator.setDefaultPullPoliciesOnContainerDisks(newVMI)
-		err = mutator.setDefaultNetworkInterface(newVMI)
+		err = mutator.applyDefaults(newVMI)
 		if err != nil {
 			return webhookutils.ToAdmissionResponseError(err)
 		}
-		v1.SetObjectDefaults_VirtualMachineInstance(newVMI)
 
-		// In a future, yet undecided, release either libvirt or QEMU are going to check the hyperv dependencies, so we can get rid of this code.
-		// Until that time, we need to handle the hyperv deps to avoid obscure rejections from QEMU later on
-		log.Log.V(4).Info("Set HyperV dependencies")
-		err = webhooks.SetVirtualMachineInstanceHypervFeatureDependencies(newVMI)
-		if err != nil {
-			// HyperV is a special case. If our best-effort attempt fails, we should leave
-			// rejection to be performed later on in the validating webhook, and continue here.
-			// Please note this means that partial changes may have been performed.
-			// This is OK since each dependency must be atomic and independent (in ACID sense),
-			// so the VMI configuration is still legal.
-			log.Log.V(2).Infof("Failed to set HyperV dependencies: %s", err)
-		}
-
-		// Do some specific setting for Arm64 Arch. It should put before SetObjectDefaults_VirtualMachineInstance
-		if webhooks.IsARM64() {
-			log.Log.V(4).Info("Apply Arm64 specific setting")
-			err = webhooks.SetVirtualMachineInstanceArm64Defaults(newVMI)
-			if err != nil {
-				// if SetVirtualMachineInstanceArm64Defaults fails, it's due to a validation error, which will get caught in the validation webhook after mutation finishes.
-				log.Log.V(2).Infof("Failed to setting for Arm64: %s", err)
-			}
-		}
 		// Add foreground finalizer
 		newVMI.Finalizers = append(newVMI.Finalizers, v1.VirtualMachineInstanceFinalizer)
 
 		// Set the phase to pending to avoid blank status
 		newVMI.Status.Phase = v1.Pending
+		// The VSOCK CID is handed out by virt-controller, a CID chosen by the user could belong to another VMI
+		newVMI.Status.VSOCKCID = nil
 
 		now := metav1.NewTime(time.Now())
 		newVMI.Status.PhaseTransitionTimestamps = append(newVMI.Status.PhaseTransitionTimestamps, v1.VirtualMachineInstancePhaseTransitionTimestamp{
@@ -144,28 +120,12 @@ func (mutator *VMIsMutator) Mutate(ar *admissionv1.AdmissionReview) *admissionv1
 			}
 		}
 
-		var value interface{}
-		value = newVMI.Spec
-		patch = append(patch, utiltypes.PatchOperation{
-			Op:    "replace",
-			Path:  "/spec",
-			Value: value,
-		})
-
-		value = newVMI.ObjectMeta
-		patch = append(patch, utiltypes.PatchOperation{
-			Op:    "replace",
-			Path:  "/metadata",
-			Value: value,
-		})
-
-		value = newVMI.Status
-		patch = append(patch, utiltypes.PatchOperation{
-			Op:    "replace",
-			Path:  "/status",
-			Value: value,
-		})
-
+		// Only patch the defaulted fields, so that fields which were not touched keep the
+		// serialization of the request
+		patch, err = utiltypes.GeneratePatchOperations(ar.Request.Object.Raw, original, newVMI)
+		if err != nil {
+			return webhookutils.ToAdmissionResponseError(err)
+		}
 	} else if ar.Request.Operation == admissionv1.Update {
 		// Ignore status updates if they are not coming from our service accounts
 		// TODO: As soon as CRDs support field selectors we can remove this and just enable
@@ -195,6 +155,51 @@ func (mutator *VMIsMutator) Mutate(ar *admissionv1.AdmissionReview) *admissionv1
 	}
 }
 
+// applyDefaults applies the namespace limits and the cluster defaults to the spec of a new VMI
+func (mutator *VMIsMutator) applyDefaults(vmi *v1.VirtualMachineInstance) error {
+	// Apply namespace limits
+	applyNamespaceLimitRangeValues(vmi, mutator.NamespaceLimitsInformer)
+
+	// Set VMI defaults
+	log.Log.Object(vmi).V(4).Info("Apply defaults")
+	mutator.setDefaultCPUModel(vmi)
+	mutator.setDefaultMachineType(vmi)
+	mutator.setDefaultResourceRequests(vmi)
+	mutator.setDefaultGuestCPUTopology(vmi)
+	mutator.attachVirtioWinDrivers(vmi)
+	mutator.setDefaultPullPoliciesOnContainerDisks(vmi)
+	mutator.setDefaultDiskBus(vmi)
+	err := mutator.setDefaultNetworkInterface(vmi)
+	if err != nil {
+		return err
+	}
+	v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+
+	// In a future, yet undecided, release either libvirt or QEMU are going to check the hyperv dependencies, so we can get rid of this code.
+	// Until that time, we need to handle the hyperv deps to avoid obscure rejections from QEMU later on
+	log.Log.V(4).Info("Set HyperV dependencies")
+	err = webhooks.SetVirtualMachineInstanceHypervFeatureDependencies(vmi)
+	if err != nil {
+		// HyperV is a special case. If our best-effort attempt fails, we should leave
+		// rejection to be performed later on in the validating webhook, and continue here.
+		// Please note this means that partial changes may have been performed.
+		// This is OK since each dependency must be atomic and independent (in ACID sense),
+		// so the VMI configuration is still legal.
+		log.Log.V(2).Infof("Failed to set HyperV dependencies: %s", err)
+	}
+
+	// Do some specific setting for Arm64 Arch. It should put before SetObjectDefaults_VirtualMachineInstance
+	if webhooks.IsARM64() {
+		log.Log.V(4).Info("Apply Arm64 specific setting")
+		err = webhooks.SetVirtualMachineInstanceArm64Defaults(vmi)
+		if err != nil {
+			// if SetVirtualMachineInstanceArm64Defaults fails, it's due to a validation error, which will get caught in the validation webhook after mutation finishes.
+			log.Log.V(2).Infof("Failed to setting for Arm64: %s", err)
+		}
+	}
+	return nil
+}
+
 func (mutator *VMIsMutator) setDefaultNetworkInterface(obj *v1.VirtualMachineInstance) error {
 	autoAttach := obj.Spec.Domain.Devices.AutoattachPodInterface
 	if autoAttach != nil && *autoAttach == false {
@@ -221,6 +226,7 @@ func (mutator *VMIsMutator) setDefaultNetworkInterface(obj *v1.VirtualMachineIns
 		}
 
 		obj.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+		guestdefaults.MarkApplied(&obj.ObjectMeta, guestdefaults.NetworkInterface)
 	}
 	return nil
 }
@@ -235,6 +241,7 @@ func (mutator *VMIsMutator) setDefaultCPUModel(vmi *v1.VirtualMachineInstance) {
 			}
 			//set is as vmi cpu model
 			vmi.Spec.Domain.CPU.Model = defaultCPUModel
+			guestdefaults.MarkApplied(&vmi.ObjectMeta, guestdefaults.CPUModel)
 		}
 	}
 }
@@ -270,9 +277,11 @@ func (mutator *VMIsMutator) setDefaultMachineType(vmi *v1.VirtualMachineInstance
 	if machine := vmi.Spec.Domain.Machine; machine != nil {
 		if machine.Type == "" {
 			machine.Type = machineType
+			guestdefaults.MarkApplied(&vmi.ObjectMeta, guestdefaults.MachineType)
 		}
 	} else {
 		vmi.Spec.Domain.Machine = &v1.Machine{Type: machineType}
+		guestdefaults.MarkApplied(&vmi.ObjectMeta, guestdefaults.MachineType)
 	}
 }
 
@@ -288,6 +297,64 @@ func (mutator *VMIsMutator) setDefaultPullPoliciesOnContainerDisks(vmi *v1.Virtu
 	}
 }
 
+// attachVirtioWinDrivers attaches the virtio-win containerDisk of the cluster as a CD-ROM to
+// Windows VMIs, unless they already have it
+func (mutator *VMIsMutator) attachVirtioWinDrivers(vmi *v1.VirtualMachineInstance) {
+	image := mutator.ClusterConfig.GetVirtioWinContainerDiskImage()
+	if image == "" || !needsVirtioWinDrivers(vmi) {
+		return
+	}
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Name == virtioWinVolumeName || (volume.ContainerDisk != nil && volume.ContainerDisk.Image == image) {
+			return
+		}
+	}
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.Name == virtioWinVolumeName {
+			return
+		}
+	}
+
+	vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+		Name: virtioWinVolumeName,
+		DiskDevice: v1.DiskDevice{
+			// Windows has no virtio drivers until they are installed from this disk
+			CDRom: &v1.CDRomTarget{Bus: "sata"},
+		},
+	})
+	vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+		Name: virtioWinVolumeName,
+		VolumeSource: v1.VolumeSource{
+			ContainerDisk: &v1.ContainerDiskSource{Image: image},
+		},
+	})
+}
+
+func needsVirtioWinDrivers(vmi *v1.VirtualMachineInstance) bool {
+	switch vmi.Annotations[v1.VirtioWinDriversAnnotation] {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	return strings.HasPrefix(strings.ToLower(vmi.Labels[v1.OSLabel]), "win")
+}
+
+func (mutator *VMIsMutator) setDefaultDiskBus(vmi *v1.VirtualMachineInstance) {
+	bus := mutator.ClusterConfig.GetDefaultDiskBus()
+	if bus == "" {
+		return
+	}
+	for i := range vmi.Spec.Domain.Devices.Disks {
+		disk := &vmi.Spec.Domain.Devices.Disks[i].DiskDevice
+		// cdroms and luns keep the API default, since they can not use all buses
+		v1.SetDefaults_DiskDevice(disk)
+		if disk.Disk != nil && disk.Disk.Bus == "" {
+			disk.Disk.Bus = bus
+		}
+	}
+}
+
 func (mutator *VMIsMutator) setDefaultResourceRequests(vmi *v1.VirtualMachineInstance) {
 
 	resources := &vmi.Spec.Domain.Resources
@@ -321,11 +388,16 @@ func (mutator *VMIsMutator) setDefaultResourceRequests(vmi *v1.VirtualMachineIns
 				resources.Requests = k8sv1.ResourceList{}
 			}
 			overcommit := mutator.ClusterConfig.GetMemoryOvercommit()
-			if overcommit == 100 {
+			if overcommit == 100 || !canOvercommitMemory(vmi) {
 				resources.Requests[k8sv1.ResourceMemory] = *memory
 			} else {
 				value := (memory.Value() * int64(100)) / int64(overcommit)
 				resources.Requests[k8sv1.ResourceMemory] = *resource.NewQuantity(value, memory.Format)
+				// Free page reporting keeps the actual usage of the guest close to the reduced request
+				devices := &vmi.Spec.Domain.Devices
+				if devices.FreePageReporting == nil && (devices.AutoattachMemBalloon == nil || *devices.AutoattachMemBalloon) {
+					devices.FreePageReporting = pointer.BoolPtr(true)
+				}
 			}
 			memoryRequest := resources.Requests[k8sv1.ResourceMemory]
 			log.Log.Object(vmi).V(4).Infof("Set memory-request to %s as a result of memory-overcommit = %v%%", memoryRequest.String(), overcommit)
@@ -345,6 +417,22 @@ func (mutator *VMIsMutator) setDefaultResourceRequests(vmi *v1.VirtualMachineIns
 
 }
 
+// canOvercommitMemory returns false for VMIs whose memory can't be reclaimed. Requesting less memory
+// than they use would make them the first candidates for an eviction under memory pressure.
+func canOvercommitMemory(vmi *v1.VirtualMachineInstance) bool {
+	// VFIO pins the complete guest memory
+	if util.IsVFIOVMI(vmi) {
+		return false
+	}
+	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.Hugepages != nil {
+		return false
+	}
+	if vmi.Spec.Domain.CPU != nil && vmi.Spec.Domain.CPU.DedicatedCPUPlacement {
+		return false
+	}
+	return true
+}
+
 func canBeNonRoot(vmi *v1.VirtualMachineInstance) error {
 	// VirtioFS doesn't work with session mode
 	if util.IsVMIVirtiofsEnabled(vmi) {
@@ -354,5 +442,10 @@ func canBeNonRoot(vmi *v1.VirtualMachineInstance) error {
 	if util.IsSRIOVVmi(vmi) {
 		return fmt.Errorf("SRIOV doesn't work with nonroot")
 	}
+
+	// qemu-pr-helper needs CAP_SYS_RAWIO to issue persistent reservations
+	if util.HasLUNReservation(vmi) {
+		return fmt.Errorf("LUN reservations don't work with nonroot")
+	}
 	return nil
 }
@@ -25,6 +25,7 @@ import (
 	"reflect"
 	rt "runtime"
 
+	jsonpatch "github.com/evanphx/json-patch"
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -35,6 +36,7 @@ import (
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/pointer"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/kubevirt/pkg/testutils"
@@ -80,22 +82,27 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 		return mutator.Mutate(ar)
 	}
 
-	getVMISpecMetaFromResponse := func() (*v1.VirtualMachineInstanceSpec, *k8smetav1.ObjectMeta) {
+	getVMIFromResponse := func() *v1.VirtualMachineInstance {
+		vmiBytes, err := json.Marshal(vmi)
+		Expect(err).ToNot(HaveOccurred())
 		resp := admitVMI()
 		Expect(resp.Allowed).To(BeTrue())
 
-		By("Getting the VMI spec from the response")
-		vmiSpec := &v1.VirtualMachineInstanceSpec{}
-		vmiMeta := &k8smetav1.ObjectMeta{}
-		patch := []utiltypes.PatchOperation{
-			{Value: vmiSpec},
-			{Value: vmiMeta},
-		}
-		err := json.Unmarshal(resp.Patch, &patch)
+		By("Applying the patch of the response to the VMI")
+		patch, err := jsonpatch.DecodePatch(resp.Patch)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(patch).NotTo(BeEmpty())
+		patchedBytes, err := patch.Apply(vmiBytes)
+		Expect(err).ToNot(HaveOccurred())
+		patchedVMI := &v1.VirtualMachineInstance{}
+		Expect(json.Unmarshal(patchedBytes, patchedVMI)).To(Succeed())
 
-		return vmiSpec, vmiMeta
+		return patchedVMI
+	}
+
+	getVMISpecMetaFromResponse := func() (*v1.VirtualMachineInstanceSpec, *k8smetav1.ObjectMeta) {
+		patchedVMI := getVMIFromResponse()
+		return &patchedVMI.Spec, &patchedVMI.ObjectMeta
 	}
 
 	getVMIStatusFromResponse := func(oldVMI *v1.VirtualMachineInstance, newVMI *v1.VirtualMachineInstance, user string) *v1.VirtualMachineInstanceStatus {
@@ -233,6 +240,68 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 		Expect(vmiSpec.Domain.Resources.Requests.Cpu().String()).To(Equal(cpuRequestFromConfig))
 	})
 
+	It("should apply the configured default bus to disks on VMI create", func() {
+		mutator.ClusterConfig, _, _, _ = testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			DiskConfiguration:      &v1.DiskConfiguration{DefaultBus: "nvme"},
+			DeveloperConfiguration: &v1.DeveloperConfiguration{FeatureGates: []string{virtconfig.NVMeDiskBusGate}},
+		})
+		vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+			{Name: "implicit"},
+			{Name: "disk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}}},
+			{Name: "virtio", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}},
+			{Name: "cdrom", DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{}}},
+		}
+
+		vmiSpec, _ := getVMISpecMetaFromResponse()
+		Expect(vmiSpec.Domain.Devices.Disks[0].Disk.Bus).To(Equal("nvme"))
+		Expect(vmiSpec.Domain.Devices.Disks[1].Disk.Bus).To(Equal("nvme"))
+		Expect(vmiSpec.Domain.Devices.Disks[2].Disk.Bus).To(Equal("virtio"))
+		Expect(vmiSpec.Domain.Devices.Disks[3].CDRom.Bus).To(Equal("sata"))
+	})
+
+	It("should ignore the nvme default bus without the NVMeDiskBus feature gate", func() {
+		mutator.ClusterConfig, _, _, _ = testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			DiskConfiguration: &v1.DiskConfiguration{DefaultBus: "nvme"},
+		})
+		vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "implicit"}}
+
+		vmiSpec, _ := getVMISpecMetaFromResponse()
+		Expect(vmiSpec.Domain.Devices.Disks[0].Disk.Bus).To(Equal("sata"))
+	})
+
+	table.DescribeTable("should attach the virtio-win drivers on VMI create", func(labels, annotations map[string]string, volumes []v1.Volume, attached bool) {
+		mutator.ClusterConfig, _, _, _ = testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			DiskConfiguration: &v1.DiskConfiguration{VirtioWinContainerDiskImage: "registry:5000/virtio-win:v1"},
+		})
+		vmi.Labels = labels
+		vmi.Annotations = annotations
+		vmi.Spec.Volumes = volumes
+
+		vmiSpec, _ := getVMISpecMetaFromResponse()
+		found := false
+		for _, volume := range vmiSpec.Volumes {
+			if volume.ContainerDisk != nil && volume.ContainerDisk.Image == "registry:5000/virtio-win:v1" {
+				found = true
+				Expect(volume.Name).To(Equal("virtio-win"))
+				Expect(volume.ContainerDisk.ImagePullPolicy).To(Equal(k8sv1.PullIfNotPresent))
+			}
+		}
+		Expect(found).To(Equal(attached))
+		if attached {
+			disk := vmiSpec.Domain.Devices.Disks[len(vmiSpec.Domain.Devices.Disks)-1]
+			Expect(disk.Name).To(Equal("virtio-win"))
+			Expect(disk.CDRom).ToNot(BeNil())
+			Expect(disk.CDRom.Bus).To(Equal("sata"))
+		}
+	},
+		table.Entry("to Windows VMIs", map[string]string{v1.OSLabel: "win10"}, nil, nil, true),
+		table.Entry("to VMIs with the annotation", nil, map[string]string{v1.VirtioWinDriversAnnotation: "true"}, nil, true),
+		table.Entry("not to other VMIs", map[string]string{v1.OSLabel: "fedora27"}, nil, nil, false),
+		table.Entry("not to Windows VMIs which opted out", map[string]string{v1.OSLabel: "win10"}, map[string]string{v1.VirtioWinDriversAnnotation: "false"}, nil, false),
+		table.Entry("not to Windows VMIs which already have a virtio-win volume", map[string]string{v1.OSLabel: "win10"}, nil,
+			[]v1.Volume{{Name: "virtio-win", VolumeSource: v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{Image: "other/virtio-win"}}}}, false),
+	)
+
 	table.DescribeTable("it should", func(given []v1.Volume, expected []v1.Volume) {
 		vmi.Spec.Volumes = given
 		vmiSpec, _ := getVMISpecMetaFromResponse()
@@ -470,13 +539,51 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 		vmi.Spec.Domain.CPU = &v1.CPU{Model: "EPYC"}
 		vmi.Spec.Domain.Machine = &v1.Machine{Type: "q35"}
 
-		vmiSpec, _ := getVMISpecMetaFromResponse()
+		vmiSpec, vmiMeta := getVMISpecMetaFromResponse()
 		Expect(vmiSpec.Domain.CPU.Model).To(Equal(vmi.Spec.Domain.CPU.Model))
 		Expect(vmiSpec.Domain.Machine.Type).To(Equal(vmi.Spec.Domain.Machine.Type))
+		Expect(vmiMeta.Annotations[v1.AppliedGuestDefaultsAnnotation]).To(Equal("networkInterface"))
 		Expect(vmiSpec.Domain.Resources.Requests.Cpu()).To(Equal(vmi.Spec.Domain.Resources.Requests.Cpu()))
 		Expect(vmiSpec.Domain.Resources.Requests.Memory()).To(Equal(vmi.Spec.Domain.Resources.Requests.Memory()))
 	})
 
+	It("should track the guest visible defaults on VMI create", func() {
+		mutator.ClusterConfig, _, _, _ = testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			CPUModel: cpuModelFromConfig,
+		})
+		_, vmiMeta := getVMISpecMetaFromResponse()
+		Expect(vmiMeta.Annotations).To(HaveKeyWithValue(v1.AppliedGuestDefaultsAnnotation, "cpuModel,machineType,networkInterface"))
+	})
+
+	It("should not track the CPU model without a default CPU model", func() {
+		_, vmiMeta := getVMISpecMetaFromResponse()
+		Expect(vmiMeta.Annotations).To(HaveKeyWithValue(v1.AppliedGuestDefaultsAnnotation, "machineType,networkInterface"))
+	})
+
+	It("should drop a VSOCK CID set by the user on VMI create", func() {
+		cid := uint32(42)
+		vmi.Status.VSOCKCID = &cid
+		vmiStatus := &getVMIFromResponse().Status
+		Expect(vmiStatus.Phase).To(Equal(v1.Pending))
+		Expect(vmiStatus.VSOCKCID).To(BeNil())
+	})
+
+	It("should only patch the defaulted fields on VMI create", func() {
+		vmi.Spec.Domain.CPU = &v1.CPU{Model: "EPYC"}
+		vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{
+			k8sv1.ResourceMemory: resource.MustParse("1Gi"),
+		}
+		resp := admitVMI()
+		Expect(resp.Allowed).To(BeTrue())
+
+		patch := []utiltypes.PatchOperation{}
+		Expect(json.Unmarshal(resp.Patch, &patch)).To(Succeed())
+		Expect(patch).ToNot(BeEmpty())
+		for _, operation := range patch {
+			Expect(operation.Path).ToNot(BeElementOf("/spec", "/metadata", "/status", "/spec/domain/cpu/model", "/spec/domain/resources/requests/memory"))
+		}
+	})
+
 	It("should convert CPU requests to sockets", func() {
 		vmi.Spec.Domain.CPU = &v1.CPU{Model: "EPYC"}
 		vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{
@@ -514,8 +621,52 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 		vmiSpec, _ := getVMISpecMetaFromResponse()
 		Expect(vmiSpec.Domain.Memory.Guest.String()).To(Equal("3072M"))
 		Expect(vmiSpec.Domain.Resources.Requests.Memory().String()).To(Equal("2048M"))
+		Expect(vmiSpec.Domain.Devices.FreePageReporting).To(Equal(pointer.BoolPtr(true)))
+	})
+
+	It("should not enable free page reporting on overcommitted VMIs without memory balloon", func() {
+		mutator.NamespaceLimitsInformer, _ = testutils.NewFakeInformerFor(&k8sv1.LimitRange{})
+		testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{
+			Data: map[string]string{
+				virtconfig.MemoryOvercommitKey: "150",
+			},
+		})
+		guestMemory := resource.MustParse("3072M")
+		vmi.Spec.Domain.Memory = &v1.Memory{Guest: &guestMemory}
+		vmi.Spec.Domain.Devices.AutoattachMemBalloon = pointer.BoolPtr(false)
+		vmiSpec, _ := getVMISpecMetaFromResponse()
+		Expect(vmiSpec.Domain.Resources.Requests.Memory().String()).To(Equal("2048M"))
+		Expect(vmiSpec.Domain.Devices.FreePageReporting).To(BeNil())
 	})
 
+	table.DescribeTable("should not apply memory-overcommit to VMIs whose memory can't be reclaimed", func(setup func(*v1.VirtualMachineInstance)) {
+		mutator.NamespaceLimitsInformer, _ = testutils.NewFakeInformerFor(&k8sv1.LimitRange{})
+		testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{
+			Data: map[string]string{
+				virtconfig.MemoryOvercommitKey: "150",
+			},
+		})
+		guestMemory := resource.MustParse("3072M")
+		vmi.Spec.Domain.Memory = &v1.Memory{Guest: &guestMemory}
+		setup(vmi)
+		vmiSpec, _ := getVMISpecMetaFromResponse()
+		Expect(vmiSpec.Domain.Resources.Requests.Memory().String()).To(Equal("3072M"))
+		Expect(vmiSpec.Domain.Devices.FreePageReporting).To(BeNil())
+	},
+		table.Entry("with host devices", func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Domain.Devices.HostDevices = []v1.HostDevice{{Name: "dev", DeviceName: "example.org/dev"}}
+		}),
+		table.Entry("with GPUs", func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Domain.Devices.GPUs = []v1.GPU{{Name: "gpu", DeviceName: "example.org/gpu"}}
+		}),
+		table.Entry("with dedicated CPUs", func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Domain.CPU = &v1.CPU{DedicatedCPUPlacement: true}
+		}),
+		table.Entry("with hugepages", func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Domain.Memory.Hugepages = &v1.Hugepages{PageSize: "2Mi"}
+		}),
+	)
+
 	It("should apply memory-overcommit when hugepages are set and memory-request is not set", func() {
 		// no limits wanted on this test, to not copy the limit to requests
 		mutator.NamespaceLimitsInformer, _ = testutils.NewFakeInformerFor(&k8sv1.LimitRange{})
@@ -752,9 +903,9 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 
 	// Check following convert for ARM64
 	// 1. should convert CPU model to host-passthrough
-	// 2. should convert default AutoattachGraphicsDevice to false
+	// 2. should keep the default AutoattachGraphicsDevice, the converter attaches a virtio-gpu device
 	// 3. should convert default bootloader to UEFI non secureboot
-	It("should convert cpu model, AutoattachGraphicsDevice and UEFI boot on ARM64", func() {
+	It("should convert cpu model and UEFI boot on ARM64", func() {
 		// turn on arm validation/mutation
 		webhooks.Arch = "arm64"
 		defer func() {
@@ -762,7 +913,7 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 		}()
 		vmiSpec, _ := getVMISpecMetaFromResponse()
 		Expect(*(vmiSpec.Domain.Firmware.Bootloader.EFI.SecureBoot)).To(BeFalse())
-		Expect(*(vmiSpec.Domain.Devices.AutoattachGraphicsDevice)).To(BeFalse())
+		Expect(vmiSpec.Domain.Devices.AutoattachGraphicsDevice).To(BeNil())
 		Expect(vmiSpec.Domain.CPU.Model).To(Equal("host-passthrough"))
 	})
 
@@ -796,6 +947,13 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 				},
 			}, nil),
 
+		table.Entry("if hyperV contains a disabled EVMCS", v1.NewMinimalVMI("testvmi"),
+			&v1.FeatureHyperv{
+				EVMCS: &v1.FeatureState{
+					Enabled: &_false,
+				},
+			}, nil),
+
 		table.Entry("if hyperV does contain EVMCS", v1.NewMinimalVMI("testvmi"),
 			&v1.FeatureHyperv{
 				EVMCS: &v1.FeatureState{},
@@ -939,6 +1097,17 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 			Expect(resp.Allowed).To(BeFalse())
 			Expect(resp.Result.Message).To(And(ContainSubstring("VirtioFS"), ContainSubstring("nonroot")))
 		})
+
+		It("Should reject a vmi with a LUN reservation", func() {
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name:       "lun0",
+				DiskDevice: v1.DiskDevice{LUN: &v1.LunTarget{Bus: "scsi", Reservation: true}},
+			})
+
+			resp := admitVMI()
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(And(ContainSubstring("LUN reservations"), ContainSubstring("nonroot")))
+		})
 	})
 
 })
@@ -0,0 +1,62 @@
+/* Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021
+ *
+ */
+
+/*
+ * s390x utilities are in the webhooks package next to the utilities of the
+ * other architectures.
+ */
+package webhooks
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// ValidateVirtualMachineInstanceS390XSetting is validation function for validating-webhook, it checks following items:
+// 1. if use uefi boot
+// 2. if use usb input devices
+// 3. if use hyperv features
+func ValidateVirtualMachineInstanceS390XSetting(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	if spec.Domain.Firmware != nil && spec.Domain.Firmware.Bootloader != nil && spec.Domain.Firmware.Bootloader.EFI != nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: "S390x does not support uefi boot",
+			Field:   field.Child("domain", "firmware", "bootloader", "efi").String(),
+		})
+	}
+	for i, input := range spec.Domain.Devices.Inputs {
+		if input.Bus == "usb" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: "S390x does not support usb input devices",
+				Field:   field.Child("domain", "devices", "inputs").Index(i).Child("bus").String(),
+			})
+		}
+	}
+	if spec.Domain.Features != nil && (spec.Domain.Features.Hyperv != nil || spec.Domain.Features.HypervPassthrough != nil) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("S390x does not support %s", field.Child("domain", "features", "hyperv").String()),
+			Field:   field.Child("domain", "features", "hyperv").String(),
+		})
+	}
+	return causes
+}
@@ -77,11 +77,14 @@ var KubeVirtGroupVersionResource = metav1.GroupVersionResource{
 }
 
 type Informers struct {
-	VMIPresetInformer       cache.SharedIndexInformer
-	NamespaceLimitsInformer cache.SharedIndexInformer
-	VMIInformer             cache.SharedIndexInformer
-	VMRestoreInformer       cache.SharedIndexInformer
-	DataSourceInformer      cache.SharedIndexInformer
+	VMIPresetInformer           cache.SharedIndexInformer
+	NamespaceLimitsInformer     cache.SharedIndexInformer
+	NamespaceInformer           cache.SharedIndexInformer
+	VMIInformer                 cache.SharedIndexInformer
+	VMRestoreInformer           cache.SharedIndexInformer
+	DataSourceInformer          cache.SharedIndexInformer
+	StorageCapabilitiesInformer cache.SharedIndexInformer
+	PVCInformer                 cache.SharedIndexInformer
 }
 
 func IsKubeVirtServiceAccount(serviceAccount string) bool {
@@ -112,3 +115,10 @@ func IsPPC64() bool {
 	}
 	return false
 }
+
+func IsS390X() bool {
+	if Arch == "s390x" {
+		return true
+	}
+	return false
+}
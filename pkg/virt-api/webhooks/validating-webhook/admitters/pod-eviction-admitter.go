@@ -43,10 +43,14 @@ func (admitter *PodEvictionAdmitter) Admit(ar *admissionv1.AdmissionReview) *adm
 	if err != nil {
 		return denied(fmt.Sprintf("kubevirt failed getting the vmi: %s", err.Error()))
 	}
-	if !vmi.IsEvictable() {
+	if !vmi.IsEvictable() && !vmi.IsEvictableIfPossible() {
 		// we don't act on VMIs without an eviction strategy
 		return validating_webhooks.NewPassingAdmissionResponse()
 	} else if !vmi.IsMigratable() {
+		if vmi.IsEvictableIfPossible() {
+			// the vmi only wants to be migrated if possible, so it can be evicted
+			return validating_webhooks.NewPassingAdmissionResponse()
+		}
 		return denied(fmt.Sprintf(
 			"VMI %s is configured with an eviction strategy but is not live-migratable", vmi.Name))
 	}
@@ -264,6 +264,44 @@ var _ = Describe("Pod eviction admitter", func() {
 				table.Entry("and should not mark the VMI when in dry-run mode", true),
 			)
 
+			table.DescribeTable("Should not mark a VMI which is not live-migratable", func(strategy virtv1.EvictionStrategy, allowed bool) {
+				vmi.Spec.EvictionStrategy = &strategy
+				vmi.Status.Conditions[0].Status = k8sv1.ConditionFalse
+
+				By("Composing a dummy admission request on a virt-launcher pod")
+				pod := &k8sv1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testpod",
+						Namespace: testns,
+						Annotations: map[string]string{
+							virtv1.DomainAnnotation: vmi.Name,
+						},
+						Labels: map[string]string{
+							virtv1.AppLabel: "virt-launcher",
+						},
+					},
+				}
+
+				ar := &admissionv1.AdmissionReview{
+					Request: &admissionv1.AdmissionRequest{
+						Name:      pod.Name,
+						Namespace: pod.Namespace,
+					},
+				}
+
+				kubeClient.Fake.PrependReactor("get", "pods", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					return true, pod, nil
+				})
+
+				vmiClient.EXPECT().Get(vmi.Name, &metav1.GetOptions{}).Return(vmi, nil)
+
+				resp := podEvictionAdmitter.Admit(ar)
+				Expect(resp.Allowed).To(Equal(allowed))
+			},
+				table.Entry("and deny the eviction with the LiveMigrate strategy", virtv1.EvictionStrategyLiveMigrate, false),
+				table.Entry("and allow the eviction with the LiveMigrateIfPossible strategy", virtv1.EvictionStrategyLiveMigrateIfPossible, true),
+			)
+
 		})
 
 		Context("Not a virt launcher pod", func() {
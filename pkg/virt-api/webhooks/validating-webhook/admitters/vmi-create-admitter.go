@@ -32,6 +32,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/cache"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/kubevirt/pkg/hooks"
@@ -46,6 +47,9 @@ const (
 	arrayLenMax = 256
 	maxStrLen   = 256
 
+	// the boot menu timeout of libvirt is a 16 bit value
+	maxBootMenuTimeout = 65535
+
 	// cloudInitNetworkMaxLen and CloudInitUserMaxLen are being limited
 	// to 2K to allow scaling of config as edits will cause entire object
 	// to be distributed to large no of nodes. For larger than 2K, user should
@@ -62,6 +66,52 @@ const (
 var validInterfaceModels = map[string]*struct{}{"e1000": nil, "e1000e": nil, "ne2k_pci": nil, "pcnet": nil, "rtl8139": nil, "virtio": nil}
 var validIOThreadsPolicies = []v1.IOThreadsPolicy{v1.IOThreadsPolicyShared, v1.IOThreadsPolicyAuto}
 var validCPUFeaturePolicies = map[string]*struct{}{"": nil, "force": nil, "require": nil, "optional": nil, "disable": nil, "forbid": nil}
+var validSoundModels = map[string]*struct{}{"": nil, "ich9": nil, "ac97": nil}
+var validWatchdogActions = map[v1.WatchdogAction]*struct{}{"": nil, v1.WatchdogActionPoweroff: nil, v1.WatchdogActionReset: nil, v1.WatchdogActionShutdown: nil}
+var validWWN = regexp.MustCompile(`^[0-9A-Fa-f]{16}$`)
+
+// qemuArgValue matches the plain values which QEMU arguments may be passed, without commas, which
+// separate the options of an argument, and without anything QEMU would interpret as a path
+var qemuArgValue = regexp.MustCompile(`^[A-Za-z0-9 ._:@+-]*$`)
+
+// qemuArgSchema lists the options of the value of a QEMU argument and the values they accept
+type qemuArgSchema struct {
+	required []string
+	options  map[string]*regexp.Regexp
+}
+
+// qemuArgSchemas holds the QEMU arguments which can be allow-listed. Only the options listed here
+// are accepted in their values, options which reference files of the virt-launcher pod, like file=
+// or path=, never are. Allow-listed arguments without a schema are rejected.
+var qemuArgSchemas = map[string]qemuArgSchema{
+	"-fw_cfg": {
+		required: []string{"name", "string"},
+		options: map[string]*regexp.Regexp{
+			"name":   regexp.MustCompile(`^opt/[A-Za-z0-9._-]+(/[A-Za-z0-9._-]+)*$`),
+			"string": qemuArgValue,
+		},
+	},
+	"-smbios": {
+		required: []string{"type"},
+		options: map[string]*regexp.Regexp{
+			"type":         regexp.MustCompile(`^(0|1|2|3|4|11|17)$`),
+			"vendor":       qemuArgValue,
+			"version":      qemuArgValue,
+			"date":         qemuArgValue,
+			"release":      regexp.MustCompile(`^[0-9]+\.[0-9]+$`),
+			"manufacturer": qemuArgValue,
+			"product":      qemuArgValue,
+			"serial":       qemuArgValue,
+			"uuid":         regexp.MustCompile(`^[0-9A-Fa-f-]{36}$`),
+			"sku":          qemuArgValue,
+			"family":       qemuArgValue,
+			"asset":        qemuArgValue,
+			"location":     qemuArgValue,
+			"part":         qemuArgValue,
+			"value":        qemuArgValue,
+		},
+	},
+}
 
 var restriectedVmiLabels = map[string]bool{
 	v1.CreatedByLabel:               true,
@@ -80,6 +130,7 @@ const (
 
 type VMICreateAdmitter struct {
 	ClusterConfig *virtconfig.ClusterConfig
+	PVCInformer   cache.SharedIndexInformer
 }
 
 func (admitter *VMICreateAdmitter) Admit(ar *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
@@ -102,12 +153,20 @@ func (admitter *VMICreateAdmitter) Admit(ar *admissionv1.AdmissionReview) *admis
 		// Check if there is any unsupported setting if the arch is Arm64
 		causes = append(causes, webhooks.ValidateVirtualMachineInstanceArm64Setting(k8sfield.NewPath("spec"), &vmi.Spec)...)
 	}
+	if webhooks.IsS390X() {
+		// Check if there is any unsupported setting if the arch is s390x
+		causes = append(causes, webhooks.ValidateVirtualMachineInstanceS390XSetting(k8sfield.NewPath("spec"), &vmi.Spec)...)
+	}
 	if len(causes) > 0 {
 		return webhookutils.ToAdmissionResponse(causes)
 	}
 
 	reviewResponse := admissionv1.AdmissionResponse{}
 	reviewResponse.Allowed = true
+	reviewResponse.Warnings = WarnVirtualMachineInstanceSpec(k8sfield.NewPath("spec"), &vmi.Spec, admitter.ClusterConfig)
+	if admitter.PVCInformer != nil {
+		reviewResponse.Warnings = append(reviewResponse.Warnings, warnNotLiveMigratableVolumes(k8sfield.NewPath("spec"), ar.Request.Namespace, vmi, admitter.PVCInformer.GetStore())...)
+	}
 	return &reviewResponse
 }
 
@@ -175,16 +234,22 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 	}
 
 	causes = append(causes, validateNetworkInterfaceMultiqueue(field, vifMQ, isVirtioNicRequested)...)
+	causes = append(causes, validateDeviceQueues(field, spec)...)
+	causes = append(causes, validateVhostUserInterfaces(field, spec)...)
+	causes = append(causes, validateInterfaceBandwidth(field, spec)...)
 	causes = append(causes, validateNetworksAssignedToInterfaces(field, spec, networkInterfaceMap)...)
 
 	causes = append(causes, validateInputDevices(field, spec)...)
 	causes = append(causes, validateIOThreadsPolicy(field, spec)...)
 	causes = append(causes, validateProbe(field.Child("readinessProbe"), spec.ReadinessProbe)...)
 	causes = append(causes, validateProbe(field.Child("livenessProbe"), spec.LivenessProbe)...)
+	causes = append(causes, validateProbe(field.Child("startupProbe"), spec.StartupProbe)...)
+	causes = append(causes, validateStartupProbeSuccessThreshold(field.Child("startupProbe"), spec.StartupProbe)...)
 
 	if getNumberOfPodInterfaces(spec) < 1 {
 		causes = appendStatusCauseForProbeNotAllowedWithNoPodNetworkPresent(field.Child("readinessProbe"), spec.ReadinessProbe, causes)
 		causes = appendStatusCauseForProbeNotAllowedWithNoPodNetworkPresent(field.Child("livenessProbe"), spec.LivenessProbe, causes)
+		causes = appendStatusCauseForProbeNotAllowedWithNoPodNetworkPresent(field.Child("startupProbe"), spec.StartupProbe, causes)
 	}
 
 	causes = append(causes, validateDomainSpec(field.Child("domain"), &spec.Domain)...)
@@ -200,11 +265,17 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 	causes = append(causes, validateGPUsWithPassthroughEnabled(field, spec, config)...)
 	causes = append(causes, validateFilesystemsWithVirtIOFSEnabled(field, spec, config)...)
 	causes = append(causes, validateHostDevicesWithPassthroughEnabled(field, spec, config)...)
+	causes = append(causes, validateVSOCKWithFeatureGateEnabled(field, spec, config)...)
+	causes = append(causes, validateNVMeDisksWithFeatureGateEnabled(field, spec, config)...)
+	causes = append(causes, validateQEMUArgs(field.Child("domain", "qemuArgs"), spec.Domain.QEMUArgs, config)...)
+	causes = append(causes, validateFreePageReporting(field.Child("domain", "devices", "freePageReporting"), spec)...)
+	causes = append(causes, validateHypervPassthrough(field.Child("domain", "features"), spec)...)
+	causes = append(causes, validateLUNReservations(field.Child("domain", "devices", "disks"), spec, config)...)
 
 	return causes
 }
 
-func validateNetworksMatchInterfaces(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig, networkNameMap map[string]*v1.Network, bootOrderMap map[uint]bool) (networkInterfaceMap map[string]struct{}, vifMQ *bool, isVirtioNicRequested bool, causes []metav1.StatusCause, done bool) {
+func validateNetworksMatchInterfaces(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig, networkNameMap map[string]*v1.Network, bootOrderMap map[uint]string) (networkInterfaceMap map[string]struct{}, vifMQ *bool, isVirtioNicRequested bool, causes []metav1.StatusCause, done bool) {
 
 	done = false
 
@@ -268,6 +339,10 @@ func validateInterfaceNetworkBasics(field *k8sfield.Path, networkExists bool, id
 		causes = appendStatusCauseForMacvtapFeatureGateNotEnabled(field, causes, idx)
 	} else if iface.InterfaceBindingMethod.Macvtap != nil && networkData.NetworkSource.Multus == nil {
 		causes = appendStatusCauseForMacvtapOnlyAllowedWithMultus(field, causes, idx)
+	} else if iface.InterfaceBindingMethod.VhostUser != nil && !config.VhostUserEnabled() {
+		causes = appendStatusCauseForVhostUserFeatureGateNotEnabled(field, causes, idx)
+	} else if iface.InterfaceBindingMethod.VhostUser != nil && networkData.NetworkSource.Multus == nil {
+		causes = appendStatusCauseForVhostUserOnlyAllowedWithMultus(field, causes, idx)
 	}
 	return causes
 }
@@ -337,7 +412,7 @@ func validateInterfacePciAddress(field *k8sfield.Path, iface v1.Interface, idx i
 	return causes
 }
 
-func validateInterfaceBootOrder(field *k8sfield.Path, iface v1.Interface, idx int, bootOrderMap map[uint]bool) (causes []metav1.StatusCause) {
+func validateInterfaceBootOrder(field *k8sfield.Path, iface v1.Interface, idx int, bootOrderMap map[uint]string) (causes []metav1.StatusCause) {
 	if iface.BootOrder != nil {
 		order := *iface.BootOrder
 		// Verify boot order is greater than 0, if provided
@@ -349,14 +424,16 @@ func validateInterfaceBootOrder(field *k8sfield.Path, iface v1.Interface, idx in
 			})
 		} else {
 			// verify that there are no duplicate boot orders
-			if bootOrderMap[order] {
+			device := field.Child("domain", "devices", "interfaces").Index(idx)
+			if other, exists := bootOrderMap[order]; exists {
 				causes = append(causes, metav1.StatusCause{
 					Type:    metav1.CauseTypeFieldValueInvalid,
-					Message: fmt.Sprintf("Boot order for %s already set for a different device.", field.Child("domain", "devices", "interfaces").Index(idx).Child("bootOrder").String()),
-					Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("bootOrder").String(),
+					Message: fmt.Sprintf("Boot order for %s already set for a different device: %s.", device.Child("bootOrder").String(), other),
+					Field:   device.Child("bootOrder").String(),
 				})
+			} else {
+				bootOrderMap[order] = device.String()
 			}
-			bootOrderMap[order] = true
 		}
 	}
 	return causes
@@ -488,6 +565,24 @@ func appendStatusCauseForMacvtapFeatureGateNotEnabled(field *k8sfield.Path, caus
 	return causes
 }
 
+func appendStatusCauseForVhostUserOnlyAllowedWithMultus(field *k8sfield.Path, causes []metav1.StatusCause, idx int) []metav1.StatusCause {
+	causes = append(causes, metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: "VhostUser interface only implemented with Multus network",
+		Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("name").String(),
+	})
+	return causes
+}
+
+func appendStatusCauseForVhostUserFeatureGateNotEnabled(field *k8sfield.Path, causes []metav1.StatusCause, idx int) []metav1.StatusCause {
+	causes = append(causes, metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: "VhostUser feature gate is not enabled",
+		Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("name").String(),
+	})
+	return causes
+}
+
 func appendStatusCauseForBridgeNotEnabled(field *k8sfield.Path, causes []metav1.StatusCause, idx int) []metav1.StatusCause {
 	causes = append(causes, metav1.StatusCause{
 		Type:    metav1.CauseTypeFieldValueInvalid,
@@ -577,6 +672,140 @@ func validateNetworkInterfaceMultiqueue(field *k8sfield.Path, vifMQ *bool, isVir
 	return causes
 }
 
+// requestedVCPUs returns the number of vCPUs the guest will see, following the same
+// order of precedence as the domain conversion.
+func requestedVCPUs(spec *v1.VirtualMachineInstanceSpec) int64 {
+	if spec.Domain.CPU != nil {
+		if vcpus := hwutil.GetNumberOfVCPUs(spec.Domain.CPU); vcpus > 0 {
+			return vcpus
+		}
+	}
+	if cpuLimit, ok := spec.Domain.Resources.Limits[k8sv1.ResourceCPU]; ok && cpuLimit.Value() > 0 {
+		return cpuLimit.Value()
+	}
+	if cpuRequest, ok := spec.Domain.Resources.Requests[k8sv1.ResourceCPU]; ok && cpuRequest.Value() > 0 {
+		return cpuRequest.Value()
+	}
+	return 1
+}
+
+func validateDeviceQueues(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	vcpus := requestedVCPUs(spec)
+	validateQueueCount := func(queuesField *k8sfield.Path, queues uint32) {
+		if queues == 0 || int64(queues) > vcpus {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be between 1 and the number of vCPUs (%d)", queuesField.String(), vcpus),
+				Field:   queuesField.String(),
+			})
+		}
+	}
+
+	for idx, disk := range spec.Domain.Devices.Disks {
+		if disk.Queues == nil {
+			continue
+		}
+		queuesField := field.Child("domain", "devices", "disks").Index(idx).Child("queues")
+		if disk.Disk == nil || (disk.Disk.Bus != "" && disk.Disk.Bus != "virtio") {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is only supported for disks on the virtio bus", queuesField.String()),
+				Field:   queuesField.String(),
+			})
+			continue
+		}
+		validateQueueCount(queuesField, *disk.Queues)
+	}
+
+	for idx, iface := range spec.Domain.Devices.Interfaces {
+		if iface.Queues == nil {
+			continue
+		}
+		queuesField := field.Child("domain", "devices", "interfaces").Index(idx).Child("queues")
+		if (iface.Model != "" && iface.Model != "virtio") || iface.SRIOV != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is only supported for virtio interfaces", queuesField.String()),
+				Field:   queuesField.String(),
+			})
+			continue
+		}
+		validateQueueCount(queuesField, *iface.Queues)
+	}
+	return causes
+}
+
+// validateVhostUserInterfaces makes sure that the guest memory can be shared with the userspace
+// dataplane, which is only possible with hugepages, and that the interfaces use virtio.
+func validateVhostUserInterfaces(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	vhostUserRequested := false
+	for idx, iface := range spec.Domain.Devices.Interfaces {
+		if iface.VhostUser == nil {
+			continue
+		}
+		vhostUserRequested = true
+		if iface.Model != "" && iface.Model != "virtio" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: "VhostUser interface only supports the virtio model",
+				Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("model").String(),
+			})
+		}
+	}
+	if vhostUserRequested && (spec.Domain.Memory == nil || spec.Domain.Memory.Hugepages == nil) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "VhostUser interface requires hugepages backed guest memory",
+			Field:   field.Child("domain", "memory", "hugepages").String(),
+		})
+	}
+	return causes
+}
+
+// validateInterfaceBandwidth checks the bandwidth limits of the interfaces, which are applied on their tap devices
+func validateInterfaceBandwidth(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	for idx, iface := range spec.Domain.Devices.Interfaces {
+		if iface.Bandwidth == nil {
+			continue
+		}
+		bandwidthField := field.Child("domain", "devices", "interfaces").Index(idx).Child("bandwidth")
+		if iface.Bridge == nil && iface.Masquerade == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: "bandwidth limits are only supported on bridge and masquerade interfaces",
+				Field:   bandwidthField.String(),
+			})
+			continue
+		}
+		for _, direction := range []struct {
+			name  string
+			limit *v1.BandwidthLimit
+		}{
+			{"ingress", iface.Bandwidth.Ingress},
+			{"egress", iface.Bandwidth.Egress},
+		} {
+			if direction.limit == nil {
+				continue
+			}
+			if direction.limit.Rate.Sign() <= 0 {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: "the rate of a bandwidth limit must be positive",
+					Field:   bandwidthField.Child(direction.name, "rate").String(),
+				})
+			}
+			if direction.limit.Burst != nil && direction.limit.Burst.Sign() <= 0 {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: "the burst of a bandwidth limit must be positive",
+					Field:   bandwidthField.Child(direction.name, "burst").String(),
+				})
+			}
+		}
+	}
+	return causes
+}
+
 func validateNetworksAssignedToInterfaces(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, networkInterfaceMap map[string]struct{}) (causes []metav1.StatusCause) {
 	networkDuplicates := map[string]struct{}{}
 	for i, network := range spec.Networks {
@@ -684,6 +913,18 @@ func validateProbe(field *k8sfield.Path, probe *v1.Probe) (causes []metav1.Statu
 	return causes
 }
 
+// validateStartupProbeSuccessThreshold mirrors the pod validation, which only allows one success for startup probes
+func validateStartupProbeSuccessThreshold(field *k8sfield.Path, probe *v1.Probe) (causes []metav1.StatusCause) {
+	if probe != nil && probe.SuccessThreshold > 1 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be 1", field.Child("successThreshold")),
+			Field:   field.Child("successThreshold").String(),
+		})
+	}
+	return causes
+}
+
 func appendStatusCauseForProbeNotAllowedWithNoPodNetworkPresent(field *k8sfield.Path, probe *v1.Probe, causes []metav1.StatusCause) []metav1.StatusCause {
 	if probe == nil {
 		return causes
@@ -715,7 +956,8 @@ func validateLiveMigration(field *k8sfield.Path, spec *v1.VirtualMachineInstance
 			Field:   field.Child("evictionStrategy").String(),
 		})
 	} else if spec.EvictionStrategy != nil {
-		if *spec.EvictionStrategy != v1.EvictionStrategyLiveMigrate {
+		if *spec.EvictionStrategy != v1.EvictionStrategyLiveMigrate &&
+			*spec.EvictionStrategy != v1.EvictionStrategyLiveMigrateIfPossible {
 			causes = append(causes, metav1.StatusCause{
 				Type:    metav1.CauseTypeFieldValueInvalid,
 				Message: fmt.Sprintf("%s is set with an unrecognized option: %s", field.Child("evictionStrategy").String(), *spec.EvictionStrategy),
@@ -749,6 +991,33 @@ func validateFilesystemsWithVirtIOFSEnabled(field *k8sfield.Path, spec *v1.Virtu
 	return causes
 }
 
+func validateVSOCKWithFeatureGateEnabled(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	if spec.Domain.Devices.AutoattachVSOCK != nil && *spec.Domain.Devices.AutoattachVSOCK && !config.VSOCKEnabled() {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled in kubevirt-config", virtconfig.VSOCKGate),
+			Field:   field.Child("domain", "devices", "autoattachVSOCK").String(),
+		})
+	}
+	return causes
+}
+
+func validateNVMeDisksWithFeatureGateEnabled(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	if config.NVMeDiskBusEnabled() {
+		return causes
+	}
+	for idx, disk := range spec.Domain.Devices.Disks {
+		if disk.Disk != nil && disk.Disk.Bus == "nvme" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s feature gate is not enabled in kubevirt-config", virtconfig.NVMeDiskBusGate),
+				Field:   field.Child("domain", "devices", "disks").Index(idx).Child("disk", "bus").String(),
+			})
+		}
+	}
+	return causes
+}
+
 func validateHostDevicesWithPassthroughEnabled(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
 	if spec.Domain.Devices.HostDevices != nil && !config.HostDevicesPassthroughEnabled() {
 		causes = append(causes, metav1.StatusCause{
@@ -760,6 +1029,121 @@ func validateHostDevicesWithPassthroughEnabled(field *k8sfield.Path, spec *v1.Vi
 	return causes
 }
 
+func validateFreePageReporting(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	devices := spec.Domain.Devices
+	if devices.FreePageReporting == nil || !*devices.FreePageReporting {
+		return causes
+	}
+	if devices.AutoattachMemBalloon != nil && !*devices.AutoattachMemBalloon {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "free page reporting requires the memory balloon device",
+			Field:   field.String(),
+		})
+	}
+	if spec.Domain.Memory != nil && spec.Domain.Memory.Hugepages != nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "free page reporting can't be used together with hugepages",
+			Field:   field.String(),
+		})
+	}
+	return causes
+}
+
+func validateHypervPassthrough(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	features := spec.Domain.Features
+	if features == nil || features.HypervPassthrough == nil || features.Hyperv == nil {
+		return causes
+	}
+	return append(causes, metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: fmt.Sprintf("%s and %s can't be set together", field.Child("hypervPassthrough").String(), field.Child("hyperv").String()),
+		Field:   field.Child("hypervPassthrough").String(),
+	})
+}
+
+func validateLUNReservations(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	for idx, disk := range spec.Domain.Devices.Disks {
+		if disk.LUN == nil || !disk.LUN.Reservation {
+			continue
+		}
+		if !config.PersistentReservationEnabled() {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s feature gate is not enabled in kubevirt-config", virtconfig.PersistentReservationGate),
+				Field:   field.Index(idx).Child("lun", "reservation").String(),
+			})
+		} else if disk.LUN.Bus != "scsi" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s requires the scsi bus", field.Index(idx).Child("lun", "reservation").String()),
+				Field:   field.Index(idx).Child("lun", "reservation").String(),
+			})
+		}
+	}
+	return causes
+}
+
+func validateQEMUArgs(field *k8sfield.Path, qemuArgs []v1.QEMUArg, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	if len(qemuArgs) > 0 && !config.QEMUArgsEnabled() {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled in kubevirt-config", virtconfig.QEMUArgsGate),
+			Field:   field.String(),
+		})
+	}
+	for idx, arg := range qemuArgs {
+		schema, supported := qemuArgSchemas[arg.Name]
+		if !config.IsQEMUArgAllowed(arg.Name) || !supported {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("QEMU argument %q is not part of the qemuArgsAllowList in kubevirt-config or not supported", arg.Name),
+				Field:   field.Index(idx).Child("name").String(),
+			})
+			continue
+		}
+		if err := validateQEMUArgValue(schema, arg.Value); err != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("invalid value of QEMU argument %q: %v", arg.Name, err),
+				Field:   field.Index(idx).Child("value").String(),
+			})
+		}
+	}
+	return causes
+}
+
+// validateQEMUArgValue checks that the value of a QEMU argument is a comma separated list of the
+// options of its schema, e.g. "name=opt/com.example/config,string=value"
+func validateQEMUArgValue(schema qemuArgSchema, value string) error {
+	seen := map[string]bool{}
+	for _, option := range strings.Split(value, ",") {
+		parts := strings.SplitN(option, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("%q is not an option of the form key=value", option)
+		}
+		key, optionValue := parts[0], parts[1]
+		pattern, known := schema.options[key]
+		if !known {
+			return fmt.Errorf("option %q is not supported", key)
+		}
+		if seen[key] {
+			return fmt.Errorf("option %q is set more than once", key)
+		}
+		seen[key] = true
+		if !pattern.MatchString(optionValue) {
+			return fmt.Errorf("option %q has an invalid value %q", key, optionValue)
+		}
+	}
+	for _, key := range schema.required {
+		if !seen[key] {
+			return fmt.Errorf("option %q is required", key)
+		}
+	}
+	return nil
+}
+
 func appendStatusCauseForPodNetworkDefinedWithMultusDefaultNetworkDefined(field *k8sfield.Path, causes []metav1.StatusCause) []metav1.StatusCause {
 	return append(causes, metav1.StatusCause{
 		Type:    metav1.CauseTypeFieldValueInvalid,
@@ -798,6 +1182,7 @@ func validateNetworks(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec,
 			if network.NetworkSource.Multus.Default {
 				multusDefaultCount++
 			}
+			causes = append(causes, validateMultusIPRequests(field.Child("networks").Index(idx).Child("multus"), network.Multus)...)
 		}
 
 		causes = validateNetworkHasOnlyOneType(field, cniTypesCount, causes, idx)
@@ -811,6 +1196,37 @@ func validateNetworks(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec,
 	return podExists, multusDefaultCount, causes
 }
 
+// validateMultusIPRequests checks the static IP addresses and the IP pool requested for a Multus network
+func validateMultusIPRequests(field *k8sfield.Path, multus *v1.MultusNetwork) (causes []metav1.StatusCause) {
+	if len(multus.IPAddresses) == 0 && multus.IPPool == "" {
+		return nil
+	}
+	if multus.Default {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: "IP addresses and IP pools are not supported on the Multus default network",
+			Field:   field.String(),
+		})
+	}
+	if len(multus.IPAddresses) > 0 && multus.IPPool != "" {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "ipAddresses and ipPool are mutually exclusive",
+			Field:   field.String(),
+		})
+	}
+	for i, address := range multus.IPAddresses {
+		if _, _, err := net.ParseCIDR(address); err != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is not an IP address in CIDR notation", address),
+				Field:   field.Child("ipAddresses").Index(i).String(),
+			})
+		}
+	}
+	return causes
+}
+
 func appendStatusCauseForCNIPluginHasNoNetworkName(field *k8sfield.Path, incomingCauses []metav1.StatusCause, idx int) (causes []metav1.StatusCause) {
 	causes = append(incomingCauses, metav1.StatusCause{
 		Type:    metav1.CauseTypeFieldValueRequired,
@@ -837,9 +1253,14 @@ func validateNetworkHasOnlyOneType(field *k8sfield.Path, cniTypesCount int, caus
 	return causes
 }
 
-func validateBootOrder(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, volumeNameMap map[string]*v1.Volume) (bootOrderMap map[uint]bool, causes []metav1.StatusCause) {
-	// used to validate uniqueness of boot orders among disks and interfaces
-	bootOrderMap = make(map[uint]bool)
+func isHotpluggableVolume(volume *v1.Volume) bool {
+	return (volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.Hotpluggable) ||
+		(volume.DataVolume != nil && volume.DataVolume.Hotpluggable)
+}
+
+func validateBootOrder(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, volumeNameMap map[string]*v1.Volume) (bootOrderMap map[uint]string, causes []metav1.StatusCause) {
+	// used to validate uniqueness of boot orders among disks and interfaces, maps them to their device
+	bootOrderMap = make(map[uint]string)
 	// to perform as set of volume / fs names
 	diskAndFilesystemNames := make(map[string]struct{})
 
@@ -870,6 +1291,15 @@ func validateBootOrder(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec
 			})
 		}
 
+		// Verify that a memory dump volume is not used as a disk
+		if volumeExists && matchingVolume.MemoryDump != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("MemoryDump volume %s can not be used as a disk", disk.Name),
+				Field:   field.Child("domain", "devices", "disks").Index(idx).Child("name").String(),
+			})
+		}
+
 		// Verify that DownwardMetrics is mapped to disk
 		if volumeExists && matchingVolume.DownwardMetrics != nil {
 			if disk.Disk == nil {
@@ -890,25 +1320,50 @@ func validateBootOrder(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec
 		// verify that there are no duplicate boot orders
 		if disk.BootOrder != nil {
 			order := *disk.BootOrder
-			if bootOrderMap[order] {
+			device := field.Child("domain", "devices", "disks").Index(idx)
+			if other, exists := bootOrderMap[order]; exists {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("Boot order for %s already set for a different device: %s.", device.Child("bootOrder").String(), other),
+					Field:   device.Child("bootOrder").String(),
+				})
+			} else {
+				bootOrderMap[order] = device.String()
+			}
+
+			// hotpluggable volumes are attached after the firmware picked the boot device
+			if volumeExists && isHotpluggableVolume(matchingVolume) {
 				causes = append(causes, metav1.StatusCause{
 					Type:    metav1.CauseTypeFieldValueInvalid,
-					Message: fmt.Sprintf("Boot order for %s already set for a different device.", field.Child("domain", "devices", "disks").Index(idx).Child("bootOrder").String()),
-					Field:   field.Child("domain", "devices", "disks").Index(idx).Child("bootOrder").String(),
+					Message: fmt.Sprintf("%s can not be set for the hotpluggable volume %s, since it is not present at boot.", device.Child("bootOrder").String(), disk.Name),
+					Field:   device.Child("bootOrder").String(),
 				})
 			}
-			bootOrderMap[order] = true
 		}
 
 		diskAndFilesystemNames[disk.Name] = struct{}{}
 	}
 
-	for _, fs := range spec.Domain.Devices.Filesystems {
+	for idx, fs := range spec.Domain.Devices.Filesystems {
+		// Verify that the shared volume is a directory and not an image or a generated disk
+		if matchingVolume, volumeExists := volumeNameMap[fs.Name]; volumeExists &&
+			matchingVolume.PersistentVolumeClaim == nil && matchingVolume.DataVolume == nil &&
+			matchingVolume.ConfigMap == nil && matchingVolume.Secret == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s can only share a PersistentVolumeClaim, DataVolume, ConfigMap or Secret volume", field.Child("domain", "devices", "filesystems").Index(idx).Child("name").String()),
+				Field:   field.Child("domain", "devices", "filesystems").Index(idx).Child("name").String(),
+			})
+		}
 		diskAndFilesystemNames[fs.Name] = struct{}{}
 	}
 
 	// Validate that volumes match disks and filesystems correctly
 	for idx, volume := range spec.Volumes {
+		if volume.MemoryDump != nil {
+			// memory dump volumes are only mounted into the virt-launcher pod
+			continue
+		}
 		if _, matchingDiskExists := diskAndFilesystemNames[volume.Name]; !matchingDiskExists {
 			causes = append(causes, metav1.StatusCause{
 				Type:    metav1.CauseTypeFieldValueInvalid,
@@ -1585,6 +2040,30 @@ func validateFirmware(field *k8sfield.Path, firmware *v1.Firmware) []metav1.Stat
 	if firmware != nil {
 		causes = append(causes, validateBootloader(field.Child("bootloader"), firmware.Bootloader)...)
 		causes = append(causes, validateKernelBoot(field.Child("kernelBoot"), firmware.KernelBoot)...)
+		causes = append(causes, validateBootMenu(field.Child("bootMenu"), firmware)...)
+	}
+
+	return causes
+}
+
+func validateBootMenu(field *k8sfield.Path, firmware *v1.Firmware) (causes []metav1.StatusCause) {
+	if firmware.BootMenu == nil {
+		return
+	}
+
+	if firmware.BootMenu.Timeout != nil && *firmware.BootMenu.Timeout > maxBootMenuTimeout {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must not be greater than %d milliseconds.", field.Child("timeout").String(), maxBootMenuTimeout),
+			Field:   field.Child("timeout").String(),
+		})
+	}
+	if firmware.KernelBoot != nil && firmware.KernelBoot.Container != nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s can not be used with a kernel boot container, which does not boot from a device.", field.String()),
+			Field:   field.String(),
+		})
 	}
 
 	return causes
@@ -1771,6 +2250,24 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 			volumeSourceSetCount++
 		}
 		if volume.ContainerDisk != nil {
+			if overlay := volume.ContainerDisk.Overlay; overlay != nil {
+				if overlay.Capacity != nil && overlay.Capacity.Sign() <= 0 {
+					causes = append(causes, metav1.StatusCause{
+						Type:    metav1.CauseTypeFieldValueInvalid,
+						Message: fmt.Sprintf("%s must be positive", field.Index(idx).Child("containerDisk", "overlay", "capacity").String()),
+						Field:   field.Index(idx).Child("containerDisk", "overlay", "capacity").String(),
+					})
+				}
+				if overlay.ClaimName != "" {
+					for _, msg := range validation.IsDNS1123Subdomain(overlay.ClaimName) {
+						causes = append(causes, metav1.StatusCause{
+							Type:    metav1.CauseTypeFieldValueInvalid,
+							Message: fmt.Sprintf("%s is invalid: %s", field.Index(idx).Child("containerDisk", "overlay", "claimName").String(), msg),
+							Field:   field.Index(idx).Child("containerDisk", "overlay", "claimName").String(),
+						})
+					}
+				}
+			}
 			volumeSourceSetCount++
 		}
 		if volume.Ephemeral != nil {
@@ -1817,6 +2314,16 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 			downwardMetricVolumeCount++
 			volumeSourceSetCount++
 		}
+		if volume.MemoryDump != nil {
+			if volume.MemoryDump.ClaimName == "" {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueRequired,
+					Message: "MemoryDump 'claimName' must be set",
+					Field:   field.Index(idx).Child("memoryDump", "claimName").String(),
+				})
+			}
+			volumeSourceSetCount++
+		}
 
 		if volumeSourceSetCount != 1 {
 			causes = append(causes, metav1.StatusCause{
@@ -2030,6 +2537,48 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 func validateDevices(field *k8sfield.Path, devices *v1.Devices) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 	causes = append(causes, validateDisks(field.Child("disks"), devices.Disks)...)
+	causes = append(causes, validateWatchdog(field.Child("watchdog"), devices.Watchdog)...)
+	causes = append(causes, validateSoundDevice(field.Child("sound"), devices.Sound)...)
+	return causes
+}
+
+func validateWatchdog(field *k8sfield.Path, watchdog *v1.Watchdog) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	if watchdog == nil || watchdog.I6300ESB == nil {
+		return causes
+	}
+
+	action := watchdog.I6300ESB.Action
+	if _, ok := validWatchdogActions[action]; !ok {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s must be one of %s, %s or %s", field.Child("i6300esb", "action").String(), v1.WatchdogActionPoweroff, v1.WatchdogActionReset, v1.WatchdogActionShutdown),
+			Field:   field.Child("i6300esb", "action").String(),
+		})
+	}
+	return causes
+}
+
+func validateSoundDevice(field *k8sfield.Path, sound *v1.SoundDevice) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	if sound == nil {
+		return causes
+	}
+
+	if sound.Name == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("%s must not be empty", field.Child("name").String()),
+			Field:   field.Child("name").String(),
+		})
+	}
+	if _, ok := validSoundModels[sound.Model]; !ok {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s must be one of ich9 or ac97", field.Child("model").String()),
+			Field:   field.Child("model").String(),
+		})
+	}
 	return causes
 }
 
@@ -2048,6 +2597,64 @@ func getNumberOfPodInterfaces(spec *v1.VirtualMachineInstanceSpec) int {
 	return nPodInterfaces
 }
 
+// validateDiskIOTune checks that the I/O limits of a disk are positive and that total limits are not
+// combined with read or write limits of the same kind, which libvirt rejects
+func validateDiskIOTune(field *k8sfield.Path, ioTune *v1.DiskIOTune) (causes []metav1.StatusCause) {
+	for _, limit := range []struct {
+		name  string
+		isSet bool
+		sign  int
+	}{
+		{"totalBytesPerSec", ioTune.TotalBytesPerSec != nil, quantitySign(ioTune.TotalBytesPerSec)},
+		{"readBytesPerSec", ioTune.ReadBytesPerSec != nil, quantitySign(ioTune.ReadBytesPerSec)},
+		{"writeBytesPerSec", ioTune.WriteBytesPerSec != nil, quantitySign(ioTune.WriteBytesPerSec)},
+		{"totalIOPS", ioTune.TotalIOPS != nil, int64Sign(ioTune.TotalIOPS)},
+		{"readIOPS", ioTune.ReadIOPS != nil, int64Sign(ioTune.ReadIOPS)},
+		{"writeIOPS", ioTune.WriteIOPS != nil, int64Sign(ioTune.WriteIOPS)},
+	} {
+		if limit.isSet && limit.sign <= 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be positive", field.Child(limit.name).String()),
+				Field:   field.Child(limit.name).String(),
+			})
+		}
+	}
+
+	if ioTune.TotalBytesPerSec != nil && (ioTune.ReadBytesPerSec != nil || ioTune.WriteBytesPerSec != nil) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s can not be combined with readBytesPerSec or writeBytesPerSec", field.Child("totalBytesPerSec").String()),
+			Field:   field.Child("totalBytesPerSec").String(),
+		})
+	}
+	if ioTune.TotalIOPS != nil && (ioTune.ReadIOPS != nil || ioTune.WriteIOPS != nil) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s can not be combined with readIOPS or writeIOPS", field.Child("totalIOPS").String()),
+			Field:   field.Child("totalIOPS").String(),
+		})
+	}
+	return causes
+}
+
+func quantitySign(q *resource.Quantity) int {
+	if q == nil {
+		return 0
+	}
+	return q.Sign()
+}
+
+func int64Sign(i *int64) int {
+	switch {
+	case i == nil || *i == 0:
+		return 0
+	case *i < 0:
+		return -1
+	}
+	return 1
+}
+
 func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 	nameMap := make(map[string]int)
@@ -2145,6 +2752,10 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 			})
 		}
 
+		if disk.IOTune != nil {
+			causes = append(causes, validateDiskIOTune(field.Index(idx).Child("ioTune"), disk.IOTune)...)
+		}
+
 		// Verify bus is supported, if provided
 		if len(bus) > 0 {
 			if bus == "ide" {
@@ -2154,7 +2765,7 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 					Field:   field.Index(idx).Child(diskType, "bus").String(),
 				})
 			} else {
-				buses := []string{"virtio", "sata", "scsi"}
+				buses := []string{"virtio", "sata", "scsi", "nvme"}
 				validBus := false
 				for _, b := range buses {
 					if b == bus {
@@ -2191,6 +2802,24 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 					Field:   field.Child("domain", "devices", "disks").Index(idx).String(),
 				})
 			}
+
+			// NVMe is only emulated for disks, whose namespaces can not have dedicated IOThreads
+			if bus == "nvme" {
+				if diskType != "disk" {
+					causes = append(causes, metav1.StatusCause{
+						Type:    metav1.CauseTypeFieldValueInvalid,
+						Message: fmt.Sprintf("Bus type %s is only supported for disks", bus),
+						Field:   field.Index(idx).Child(diskType, "bus").String(),
+					})
+				}
+				if disk.DedicatedIOThread != nil && *disk.DedicatedIOThread {
+					causes = append(causes, metav1.StatusCause{
+						Type:    metav1.CauseTypeFieldValueNotSupported,
+						Message: "IOThreads are not supported for disks on a NVMe bus",
+						Field:   field.Child("domain", "devices", "disks").Index(idx).String(),
+					})
+				}
+			}
 		}
 
 		// Verify serial number is made up of valid characters for libvirt, if provided
@@ -2212,8 +2841,26 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 			})
 		}
 
+		// Verify the WWN is made up of 16 hexadecimal digits and its bus supports it, if provided
+		if disk.WWN != "" {
+			if !validWWN.MatchString(disk.WWN) {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s must be made up of 16 hexadecimal digits, if specified", field.Index(idx).Child("wwn").String()),
+					Field:   field.Index(idx).Child("wwn").String(),
+				})
+			}
+			if (diskType != "disk" && diskType != "cdrom") || (bus != "sata" && bus != "scsi") {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s is only supported for disks and cdroms on the sata or scsi bus", field.Index(idx).Child("wwn").String()),
+					Field:   field.Index(idx).Child("wwn").String(),
+				})
+			}
+		}
+
 		// Verify if cache mode is valid
-		if disk.Cache != "" && disk.Cache != v1.CacheNone && disk.Cache != v1.CacheWriteThrough {
+		if disk.Cache != "" && disk.Cache != v1.CacheNone && disk.Cache != v1.CacheWriteThrough && disk.Cache != v1.CacheWriteBack {
 			causes = append(causes, metav1.StatusCause{
 				Type:    metav1.CauseTypeFieldValueInvalid,
 				Message: fmt.Sprintf("%s has invalid value %s", field.Index(idx).Child("cache").String(), disk.Cache),
@@ -2230,6 +2877,24 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 			})
 		}
 
+		// Verify the discard mode is valid and only set on disks and luns
+		if disk.Discard != "" {
+			if disk.Discard != v1.DiscardUnmap && disk.Discard != v1.DiscardIgnore {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: fmt.Sprintf("%s has unsupported value %s. Supported values are: unmap, ignore.", field.Index(idx).Child("discard").String(), disk.Discard),
+					Field:   field.Index(idx).Child("discard").String(),
+				})
+			}
+			if diskType != "disk" && diskType != "lun" {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s is only supported for disks and luns", field.Index(idx).Child("discard").String()),
+					Field:   field.Index(idx).Child("discard").String(),
+				})
+			}
+		}
+
 		// Verify disk and volume name can be a valid container name since disk
 		// name can become a container name which will fail to schedule if invalid
 		errs := validation.IsDNS1123Label(disk.Name)
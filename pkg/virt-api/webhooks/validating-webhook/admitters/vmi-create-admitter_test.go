@@ -153,6 +153,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(resp).To(BeEmpty())
 		},
 			table.Entry("migration policy to be set", v1.EvictionStrategyLiveMigrate),
+			table.Entry("migration policy to be set to LiveMigrateIfPossible", v1.EvictionStrategyLiveMigrateIfPossible),
 		)
 
 		It("should block setting eviction policies if the feature gate is disabled", func() {
@@ -168,6 +169,53 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(resp).To(BeEmpty())
 		})
 
+		table.DescribeTable("should warn about the non-shared PVCs of a VMI which has to be live migrated", func(policy v1.EvictionStrategy, accessMode k8sv1.PersistentVolumeAccessMode, warned bool) {
+			pvcInformer, _ := testutils.NewFakeInformerFor(&k8sv1.PersistentVolumeClaim{})
+			pvcInformer.GetStore().Add(&k8sv1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "rootdv", Namespace: "default"},
+				Spec: k8sv1.PersistentVolumeClaimSpec{
+					AccessModes: []k8sv1.PersistentVolumeAccessMode{accessMode},
+				},
+			})
+			admitter := &VMICreateAdmitter{ClusterConfig: config, PVCInformer: pvcInformer}
+
+			vmi.Spec.EvictionStrategy = &policy
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "rootdisk"}}
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "rootdisk",
+					VolumeSource: v1.VolumeSource{
+						DataVolume: &v1.DataVolumeSource{Name: "rootdv"},
+					},
+				},
+			}
+			vmiBytes, _ := json.Marshal(&vmi)
+
+			ar := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Namespace: "default",
+					Resource:  webhooks.VirtualMachineInstanceGroupVersionResource,
+					Object: runtime.RawExtension{
+						Raw: vmiBytes,
+					},
+				},
+			}
+
+			resp := admitter.Admit(ar)
+			Expect(resp.Allowed).To(BeTrue())
+			if warned {
+				Expect(resp.Warnings).To(HaveLen(1))
+				Expect(resp.Warnings[0]).To(HavePrefix("spec.volumes[0]"))
+				Expect(resp.Warnings[0]).To(ContainSubstring("PVC rootdv of volume rootdisk is not shared"))
+			} else {
+				Expect(resp.Warnings).To(BeEmpty())
+			}
+		},
+			table.Entry("warn about a non-shared PVC with LiveMigrate", v1.EvictionStrategyLiveMigrate, k8sv1.ReadWriteOnce, true),
+			table.Entry("not warn about a shared PVC with LiveMigrate", v1.EvictionStrategyLiveMigrate, k8sv1.ReadWriteMany, false),
+			table.Entry("not warn about a non-shared PVC with LiveMigrateIfPossible", v1.EvictionStrategyLiveMigrateIfPossible, k8sv1.ReadWriteOnce, false),
+		)
+
 		It("should  not allow unknown eviction policies", func() {
 			policy := v1.EvictionStrategy("fantasy")
 			vmi.Spec.EvictionStrategy = &policy
@@ -294,6 +342,51 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(resp.Allowed).To(BeFalse())
 			Expect(resp.Result.Message).To(Equal(`spec.readinessProbe.tcpSocket is only allowed if the Pod Network is attached, spec.livenessProbe.httpGet is only allowed if the Pod Network is attached`))
 		})
+		It("should accept a startup probe executed in the guest without a Pod Network", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.StartupProbe = &v1.Probe{
+				InitialDelaySeconds: 2,
+				Handler: v1.Handler{
+					Exec: &k8sv1.ExecAction{Command: []string{"systemctl", "is-system-running"}},
+				},
+			}
+
+			vmiBytes, _ := json.Marshal(&vmi)
+
+			ar := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+					Object: runtime.RawExtension{
+						Raw: vmiBytes,
+					},
+				},
+			}
+			resp := vmiCreateAdmitter.Admit(ar)
+			Expect(resp.Allowed).To(BeTrue())
+		})
+		It("should reject a startup probe with a success threshold above one", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.StartupProbe = &v1.Probe{
+				SuccessThreshold: 2,
+				Handler: v1.Handler{
+					GuestAgentPing: &v1.GuestAgentPing{},
+				},
+			}
+
+			vmiBytes, _ := json.Marshal(&vmi)
+
+			ar := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+					Object: runtime.RawExtension{
+						Raw: vmiBytes,
+					},
+				},
+			}
+			resp := vmiCreateAdmitter.Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(Equal(`spec.startupProbe.successThreshold must be 1`))
+		})
 	})
 
 	It("should accept valid vmi spec on create", func() {
@@ -1031,8 +1124,64 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(len(causes)).To(Equal(1))
 			Expect(causes[0].Field).To(Equal("fake.domain.devices.disks[1].bootOrder"))
 			Expect(causes[0].Message).To(Equal("Boot order for " +
-				"fake.domain.devices.disks[1].bootOrder already set for a different device."))
+				"fake.domain.devices.disks[1].bootOrder already set for a different device: fake.domain.devices.disks[0]."))
+		})
+		It("should reject interfaces with the boot order of a disk", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			order := uint(1)
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "testvolume1", BootOrder: &order, DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}}})
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "testvolume1", VolumeSource: v1.VolumeSource{ContainerDisk: testutils.NewFakeContainerDiskSource()}})
+			iface := v1.DefaultBridgeNetworkInterface()
+			iface.BootOrder = &order
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*iface}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].bootOrder"))
+			Expect(causes[0].Message).To(ContainSubstring("already set for a different device: fake.domain.devices.disks[0]."))
+		})
+		It("should reject a boot order on a disk of a hotpluggable volume", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			order := uint(1)
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "hotplug", BootOrder: &order, DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "scsi"}}})
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "hotplug", VolumeSource: v1.VolumeSource{DataVolume: &v1.DataVolumeSource{Name: "dv", Hotpluggable: true}}})
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.disks[0].bootOrder"))
+			Expect(causes[0].Message).To(ContainSubstring("not present at boot"))
 		})
+		bootMenuTimeout := func(timeout uint32) *uint32 {
+			return &timeout
+		}
+		table.DescribeTable("should validate the boot menu", func(firmware *v1.Firmware, expectedField string) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Firmware = firmware
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			if expectedField == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+			}
+		},
+			table.Entry("accept a boot menu without a timeout", &v1.Firmware{BootMenu: &v1.BootMenu{}}, ""),
+			table.Entry("accept a boot menu with a timeout", &v1.Firmware{BootMenu: &v1.BootMenu{Timeout: bootMenuTimeout(10000)}}, ""),
+			table.Entry("reject a timeout above 65535", &v1.Firmware{BootMenu: &v1.BootMenu{Timeout: bootMenuTimeout(65536)}}, "fake.domain.firmware.bootMenu.timeout"),
+			table.Entry("reject a boot menu with a kernel boot container", &v1.Firmware{
+				BootMenu: &v1.BootMenu{},
+				KernelBoot: &v1.KernelBoot{Container: &v1.KernelBootContainer{
+					Image:      "someimage:v1.2.3.4",
+					KernelPath: "/boot/vmlinuz",
+				}},
+			}, "fake.domain.firmware.bootMenu"),
+		)
 		It("should reject interface lists with more than one interface with the same name", func() {
 			vm := v1.NewMinimalVMI("testvm")
 			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{
@@ -1226,6 +1375,32 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes).To(HaveLen(1))
 			Expect(causes[0].Field).To(Equal("fake.networks[0]"))
 		})
+		table.DescribeTable("should validate the IP requests of a Multus network", func(multus v1.MultusNetwork, expectedFields ...string) {
+			vm := v1.NewMinimalVMI("testvm")
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			vm.Spec.Networks = []v1.Network{
+				{
+					Name:          "default",
+					NetworkSource: v1.NetworkSource{Multus: &multus},
+				},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+			fields := []string{}
+			for _, cause := range causes {
+				fields = append(fields, cause.Field)
+			}
+			Expect(fields).To(Equal(append([]string{}, expectedFields...)))
+		},
+			table.Entry("accept static IP addresses", v1.MultusNetwork{NetworkName: "net1", IPAddresses: []string{"192.168.1.10/24", "fd10::10/64"}}),
+			table.Entry("accept an IP pool", v1.MultusNetwork{NetworkName: "net1", IPPool: "pool-a"}),
+			table.Entry("reject an address without a prefix length", v1.MultusNetwork{NetworkName: "net1", IPAddresses: []string{"192.168.1.10/24", "192.168.1.11"}},
+				"fake.networks[0].multus.ipAddresses[1]"),
+			table.Entry("reject addresses together with an IP pool", v1.MultusNetwork{NetworkName: "net1", IPAddresses: []string{"192.168.1.10/24"}, IPPool: "pool-a"},
+				"fake.networks[0].multus"),
+			table.Entry("reject an IP pool on the default network", v1.MultusNetwork{NetworkName: "net1", Default: true, IPPool: "pool-a"},
+				"fake.networks[0].multus"),
+		)
 		It("should allow multiple networks of same CNI type", func() {
 			vm := v1.NewMinimalVMI("testvm")
 			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{
@@ -1612,6 +1787,102 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
 			Expect(causes).To(HaveLen(0))
 		})
+		table.DescribeTable("should validate the bandwidth of an interface", func(iface v1.Interface, expectedFields ...string) {
+			vm := v1.NewMinimalVMI("testvm")
+			iface.Name = "default"
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{iface}
+			vm.Spec.Networks = []v1.Network{{
+				Name:          "default",
+				NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "net1"}},
+			}}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+			fields := []string{}
+			for _, cause := range causes {
+				fields = append(fields, cause.Field)
+			}
+			Expect(fields).To(Equal(append([]string{}, expectedFields...)))
+		},
+			table.Entry("accept limits on a bridge interface", v1.Interface{
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+				Bandwidth: &v1.InterfaceBandwidth{
+					Ingress: &v1.BandwidthLimit{Rate: resource.MustParse("100M")},
+					Egress:  &v1.BandwidthLimit{Rate: resource.MustParse("10M"), Burst: resource.NewQuantity(1024*1024, resource.BinarySI)},
+				},
+			}),
+			table.Entry("reject limits on a SR-IOV interface", v1.Interface{
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}},
+				Bandwidth: &v1.InterfaceBandwidth{
+					Ingress: &v1.BandwidthLimit{Rate: resource.MustParse("100M")},
+				},
+			}, "fake.domain.devices.interfaces[0].bandwidth"),
+			table.Entry("reject a zero rate and a negative burst", v1.Interface{
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+				Bandwidth: &v1.InterfaceBandwidth{
+					Ingress: &v1.BandwidthLimit{Rate: resource.MustParse("0")},
+					Egress:  &v1.BandwidthLimit{Rate: resource.MustParse("10M"), Burst: resource.NewQuantity(-1, resource.BinarySI)},
+				},
+			}, "fake.domain.devices.interfaces[0].bandwidth.ingress.rate", "fake.domain.devices.interfaces[0].bandwidth.egress.burst"),
+		)
+
+		Context("with a vhostuser interface", func() {
+			var vm *v1.VirtualMachineInstance
+
+			BeforeEach(func() {
+				vm = v1.NewMinimalVMI("testvm")
+				vm.Spec.Domain.Memory = &v1.Memory{Hugepages: &v1.Hugepages{PageSize: "2Mi"}}
+				vm.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+					Name: "default",
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{
+						VhostUser: &v1.InterfaceVhostUser{},
+					},
+				}}
+				vm.Spec.Networks = []v1.Network{
+					{
+						Name:          "default",
+						NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "dpdk"}},
+					},
+				}
+			})
+
+			It("should accept it on a multus network with hugepages when the feature is active", func() {
+				enableFeatureGate(virtconfig.VhostUserGate)
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+				Expect(causes).To(BeEmpty())
+			})
+
+			It("should reject it when the feature is inactive", func() {
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].name"))
+				Expect(causes[0].Message).To(Equal("VhostUser feature gate is not enabled"))
+			})
+
+			It("should reject it on a network different than multus", func() {
+				vm.Spec.Networks[0].NetworkSource = v1.NetworkSource{Pod: &v1.PodNetwork{}}
+				enableFeatureGate(virtconfig.VhostUserGate)
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].name"))
+				Expect(causes[0].Message).To(Equal("VhostUser interface only implemented with Multus network"))
+			})
+
+			It("should reject it without hugepages", func() {
+				vm.Spec.Domain.Memory = nil
+				enableFeatureGate(virtconfig.VhostUserGate)
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.memory.hugepages"))
+			})
+
+			It("should reject it with a model other than virtio", func() {
+				vm.Spec.Domain.Devices.Interfaces[0].Model = "e1000"
+				enableFeatureGate(virtconfig.VhostUserGate)
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].model"))
+			})
+		})
 		It("should reject port out of range", func() {
 			enableSlirpInterface()
 			vm := v1.NewMinimalVMI("testvm")
@@ -1890,6 +2161,170 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(len(causes)).To(Equal(0))
 		})
 
+		queueCount := func(queues uint32) *uint32 {
+			return &queues
+		}
+
+		table.DescribeTable("should validate per disk queues", func(disk v1.Disk, expectedField string) {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.CPU = &v1.CPU{Cores: 2, Sockets: 2}
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{disk}
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name: disk.Name,
+				VolumeSource: v1.VolumeSource{
+					ContainerDisk: testutils.NewFakeContainerDiskSource(),
+				},
+			}}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			if expectedField == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+			}
+		},
+			table.Entry("with a queue count up to the number of vCPUs",
+				v1.Disk{Name: "disk0", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}, Queues: queueCount(4)}, ""),
+			table.Entry("with the default bus",
+				v1.Disk{Name: "disk0", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}}, Queues: queueCount(1)}, ""),
+			table.Entry("with more queues than vCPUs",
+				v1.Disk{Name: "disk0", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}, Queues: queueCount(5)}, "fake.domain.devices.disks[0].queues"),
+			table.Entry("with zero queues",
+				v1.Disk{Name: "disk0", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}, Queues: queueCount(0)}, "fake.domain.devices.disks[0].queues"),
+			table.Entry("on a non virtio bus",
+				v1.Disk{Name: "disk0", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "sata"}}, Queues: queueCount(2)}, "fake.domain.devices.disks[0].queues"),
+			table.Entry("on a cdrom",
+				v1.Disk{Name: "disk0", DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: "sata"}}, Queues: queueCount(2)}, "fake.domain.devices.disks[0].queues"),
+		)
+
+		table.DescribeTable("should validate the WWN of a disk", func(diskDevice v1.DiskDevice, wwn string, expectedFields ...string) {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "disk0", DiskDevice: diskDevice, WWN: wwn}}
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name: "disk0",
+				VolumeSource: v1.VolumeSource{
+					ContainerDisk: testutils.NewFakeContainerDiskSource(),
+				},
+			}}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			fields := []string{}
+			for _, cause := range causes {
+				fields = append(fields, cause.Field)
+			}
+			Expect(fields).To(Equal(append([]string{}, expectedFields...)))
+		},
+			table.Entry("accept a disk on the scsi bus", v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "scsi"}}, "5000C50015EA71AC"),
+			table.Entry("accept a cdrom on the sata bus", v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: "sata"}}, "5000c50015ea71ac"),
+			table.Entry("reject a WWN with too few digits", v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "scsi"}}, "5000c500",
+				"fake.domain.devices.disks[0].wwn"),
+			table.Entry("reject a WWN with non hexadecimal digits", v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "scsi"}}, "5000c50015ea71ag",
+				"fake.domain.devices.disks[0].wwn"),
+			table.Entry("reject a disk on the virtio bus", v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}, "5000c50015ea71ac",
+				"fake.domain.devices.disks[0].wwn"),
+		)
+
+		Context("with a LUN reservation", func() {
+			newVMIWithLUN := func(bus string) *v1.VirtualMachineInstance {
+				vmi := v1.NewMinimalVMI("testvm")
+				vmi.Spec.Domain.Devices.Disks = []v1.Disk{{
+					Name:       "lun0",
+					DiskDevice: v1.DiskDevice{LUN: &v1.LunTarget{Bus: bus, Reservation: true}},
+				}}
+				vmi.Spec.Volumes = []v1.Volume{{
+					Name: "lun0",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+							PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "shared"},
+						},
+					},
+				}}
+				return vmi
+			}
+
+			It("should reject it without the feature gate", func() {
+				vmi := newVMIWithLUN("scsi")
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.disks[0].lun.reservation"))
+				Expect(causes[0].Message).To(ContainSubstring(virtconfig.PersistentReservationGate))
+			})
+
+			table.DescribeTable("with the feature gate", func(bus string, expectedFields ...string) {
+				enableFeatureGate(virtconfig.PersistentReservationGate)
+				vmi := newVMIWithLUN(bus)
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				fields := []string{}
+				for _, cause := range causes {
+					fields = append(fields, cause.Field)
+				}
+				Expect(fields).To(Equal(append([]string{}, expectedFields...)))
+			},
+				table.Entry("should accept the scsi bus", "scsi"),
+				table.Entry("should reject the sata bus", "sata", "fake.domain.devices.disks[0].lun.reservation"),
+			)
+		})
+
+		table.DescribeTable("should validate the I/O limits of a disk", func(ioTune v1.DiskIOTune, expectedFields ...string) {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "disk0", IOTune: &ioTune}}
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name: "disk0",
+				VolumeSource: v1.VolumeSource{
+					ContainerDisk: testutils.NewFakeContainerDiskSource(),
+				},
+			}}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			fields := []string{}
+			for _, cause := range causes {
+				fields = append(fields, cause.Field)
+			}
+			Expect(fields).To(Equal(append([]string{}, expectedFields...)))
+		},
+			table.Entry("accept read and write limits", v1.DiskIOTune{
+				ReadBytesPerSec:  resource.NewQuantity(100*1024*1024, resource.BinarySI),
+				WriteBytesPerSec: resource.NewQuantity(50*1024*1024, resource.BinarySI),
+				TotalIOPS:        pointer.Int64Ptr(1000),
+			}),
+			table.Entry("reject a zero limit", v1.DiskIOTune{ReadIOPS: pointer.Int64Ptr(0)},
+				"fake.domain.devices.disks[0].ioTune.readIOPS"),
+			table.Entry("reject a negative limit", v1.DiskIOTune{TotalBytesPerSec: resource.NewQuantity(-1, resource.BinarySI)},
+				"fake.domain.devices.disks[0].ioTune.totalBytesPerSec"),
+			table.Entry("reject a total limit combined with a read limit", v1.DiskIOTune{
+				TotalBytesPerSec: resource.NewQuantity(1024, resource.BinarySI),
+				ReadBytesPerSec:  resource.NewQuantity(1024, resource.BinarySI),
+			}, "fake.domain.devices.disks[0].ioTune.totalBytesPerSec"),
+			table.Entry("reject a total limit combined with a write limit", v1.DiskIOTune{
+				TotalIOPS: pointer.Int64Ptr(100),
+				WriteIOPS: pointer.Int64Ptr(100),
+			}, "fake.domain.devices.disks[0].ioTune.totalIOPS"),
+		)
+
+		table.DescribeTable("should validate per interface queues", func(model string, queues uint32, expectedField string) {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{k8sv1.ResourceCPU: resource.MustParse("2")}
+			nic := *v1.DefaultBridgeNetworkInterface()
+			nic.Model = model
+			nic.Queues = &queues
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{nic}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			if expectedField == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+			}
+		},
+			table.Entry("with a queue count up to the number of requested CPUs", "virtio", uint32(2), ""),
+			table.Entry("with the default model", "", uint32(1), ""),
+			table.Entry("with more queues than requested CPUs", "virtio", uint32(3), "fake.domain.devices.interfaces[0].queues"),
+			table.Entry("with a non virtio model", "e1000", uint32(1), "fake.domain.devices.interfaces[0].queues"),
+		)
+
 		It("should allow valid ioThreadsPolicy", func() {
 			vmi := v1.NewMinimalVMI("testvm")
 			var ioThreadPolicy v1.IOThreadsPolicy
@@ -1969,6 +2404,62 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
 			Expect(len(causes)).To(Equal(0))
 		})
+		It("should reject VSOCK devices when feature gate is disabled", func() {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.AutoattachVSOCK = pointer.BoolPtr(true)
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.autoattachVSOCK"))
+		})
+		It("should allow VSOCK devices when feature gate is enabled", func() {
+			enableFeatureGate(virtconfig.VSOCKGate)
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.AutoattachVSOCK = pointer.BoolPtr(true)
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject the nvme bus when feature gate is disabled", func() {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "disk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "nvme"}}}}
+			vmi.Spec.Volumes = []v1.Volume{{Name: "disk", VolumeSource: v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{Image: "fake"}}}}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.disks[0].disk.bus"))
+		})
+		It("should allow the nvme bus when feature gate is enabled", func() {
+			enableFeatureGate(virtconfig.NVMeDiskBusGate)
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "disk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "nvme"}}}}
+			vmi.Spec.Volumes = []v1.Volume{{Name: "disk", VolumeSource: v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{Image: "fake"}}}}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		table.DescribeTable("should validate the volume shared by a virtiofs filesystem", func(volumeSource v1.VolumeSource, expectedCauses int) {
+			enableFeatureGate(virtconfig.VirtIOFSGate)
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Filesystems = []v1.Filesystem{
+				{
+					Name:     "shared",
+					Virtiofs: &v1.FilesystemVirtiofs{},
+				},
+			}
+			vmi.Spec.Volumes = []v1.Volume{{Name: "shared", VolumeSource: volumeSource}}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(expectedCauses))
+			if expectedCauses > 0 {
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.filesystems[0].name"))
+			}
+		},
+			table.Entry("and accept a PVC", v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "claim"}}}, 0),
+			table.Entry("and accept a ConfigMap", v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: k8sv1.LocalObjectReference{Name: "config"}}}, 0),
+			table.Entry("and accept a Secret", v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "secret"}}, 0),
+			table.Entry("and reject a container disk", v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{Image: "image"}}, 1),
+		)
 		It("should accept legacy GPU devices if PermittedHostDevices aren't set", func() {
 			kvConfig := kv.DeepCopy()
 			kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = []string{virtconfig.GPUGate}
@@ -2612,6 +3103,45 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 		})
 	})
 
+	Context("with sound device", func() {
+		table.DescribeTable("should validate the sound device",
+			func(sound *v1.SoundDevice, expectedFields []string) {
+				causes := validateSoundDevice(k8sfield.NewPath("fake"), sound)
+				Expect(causes).To(HaveLen(len(expectedFields)))
+				for i, field := range expectedFields {
+					Expect(causes[i].Field).To(Equal(field))
+				}
+			},
+			table.Entry("and accept no sound device", nil, nil),
+			table.Entry("and accept a device without model", &v1.SoundDevice{Name: "audio"}, nil),
+			table.Entry("and accept the ich9 model", &v1.SoundDevice{Name: "audio", Model: "ich9"}, nil),
+			table.Entry("and accept the ac97 model", &v1.SoundDevice{Name: "audio", Model: "ac97"}, nil),
+			table.Entry("and reject an unknown model", &v1.SoundDevice{Name: "audio", Model: "sb16"}, []string{"fake.model"}),
+			table.Entry("and reject a device without name", &v1.SoundDevice{Model: "ich9"}, []string{"fake.name"}),
+		)
+	})
+
+	Context("with watchdog", func() {
+		table.DescribeTable("should validate the i6300esb action",
+			func(action v1.WatchdogAction, expectedCauses int) {
+				watchdog := &v1.Watchdog{
+					Name:           "watchdog",
+					WatchdogDevice: v1.WatchdogDevice{I6300ESB: &v1.I6300ESBWatchdog{Action: action}},
+				}
+				causes := validateWatchdog(k8sfield.NewPath("fake"), watchdog)
+				Expect(causes).To(HaveLen(expectedCauses))
+				if expectedCauses > 0 {
+					Expect(causes[0].Field).To(Equal("fake.i6300esb.action"))
+				}
+			},
+			table.Entry("and accept poweroff", v1.WatchdogActionPoweroff, 0),
+			table.Entry("and accept reset", v1.WatchdogActionReset, 0),
+			table.Entry("and accept shutdown", v1.WatchdogActionShutdown, 0),
+			table.Entry("and accept an empty action", v1.WatchdogAction(""), 0),
+			table.Entry("and reject an unknown action", v1.WatchdogAction("explode"), 1),
+		)
+	})
+
 	Context("with Disk", func() {
 		table.DescribeTable("should accept valid disks",
 			func(disk v1.Disk) {
@@ -2890,6 +3420,16 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes[0].Message).To(Equal("fake[0].cache has invalid value unspported"))
 		})
 
+		It("should accept disk with writeback cache mode", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "testdisk", Cache: v1.CacheWriteBack, DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{}}})
+
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			Expect(causes).To(BeEmpty())
+		})
+
 		It("should reject disk count > arrayLenMax", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			for i := 0; i <= arrayLenMax; i++ {
@@ -2961,6 +3501,61 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(len(causes)).To(Equal(0))
 		})
 
+		It("should reject unsupported discard modes and discard on cdroms", func() {
+			disks := []v1.Disk{
+				{
+					Name:       "unmap",
+					Discard:    v1.DiscardUnmap,
+					DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}},
+				},
+				{
+					Name:       "ignore",
+					Discard:    v1.DiscardIgnore,
+					DiskDevice: v1.DiskDevice{LUN: &v1.LunTarget{Bus: "scsi"}},
+				},
+				{
+					Name:       "unsupported",
+					Discard:    "zero",
+					DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}},
+				},
+				{
+					Name:       "cdrom",
+					Discard:    v1.DiscardUnmap,
+					DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: "sata"}},
+				},
+			}
+
+			causes := validateDisks(k8sfield.NewPath("fake"), disks)
+			Expect(causes).To(HaveLen(2))
+			Expect(causes[0].Field).To(Equal("fake[2].discard"))
+			Expect(causes[0].Type).To(Equal(metav1.CauseTypeFieldValueNotSupported))
+			Expect(causes[1].Field).To(Equal("fake[3].discard"))
+		})
+
+		It("should accept the nvme bus only for disks without DedicatedIOThread", func() {
+			_true := true
+			disks := []v1.Disk{
+				{
+					Name:       "disk",
+					DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "nvme"}},
+				},
+				{
+					Name:       "cdrom",
+					DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: "nvme"}},
+				},
+				{
+					Name:              "disk-with-dedicated-io-thread",
+					DedicatedIOThread: &_true,
+					DiskDevice:        v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "nvme"}},
+				},
+			}
+
+			causes := validateDisks(k8sfield.NewPath("fake"), disks)
+			Expect(causes).To(HaveLen(2))
+			Expect(causes[0].Field).To(Equal("fake[1].cdrom.bus"))
+			Expect(causes[1].Message).To(Equal("IOThreads are not supported for disks on a NVMe bus"))
+		})
+
 		It("Should reject disk with DedicatedIOThread and SATA bus", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			_true := true
@@ -3176,6 +3771,33 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes).To(HaveLen(1))
 			Expect(causes[0].Message).To(ContainSubstring("fake must have max one downwardMetric volume set"))
 		})
+		table.DescribeTable("should validate the overlay of a containerDisk", func(overlay v1.ContainerDiskOverlay, expectedFields ...string) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "containerdisk",
+				VolumeSource: v1.VolumeSource{
+					ContainerDisk: &v1.ContainerDiskSource{Image: "my-image", Overlay: &overlay},
+				},
+			})
+
+			causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+			fields := []string{}
+			for _, cause := range causes {
+				fields = append(fields, cause.Field)
+			}
+			Expect(fields).To(ConsistOf(expectedFields))
+		},
+			table.Entry("and accept a capacity and a claim name", v1.ContainerDiskOverlay{
+				Capacity:  resource.NewQuantity(20*1024*1024*1024, resource.BinarySI),
+				ClaimName: "overlay-pvc",
+			}),
+			table.Entry("and reject a zero capacity", v1.ContainerDiskOverlay{
+				Capacity: resource.NewQuantity(0, resource.BinarySI),
+			}, "fake[0].containerDisk.overlay.capacity"),
+			table.Entry("and reject an invalid claim name", v1.ContainerDiskOverlay{
+				ClaimName: "Overlay_PVC",
+			}, "fake[0].containerDisk.overlay.claimName"),
+		)
 		It("should reject hostDisk volumes if the feature gate is not enabled", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 
@@ -3449,22 +4071,183 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(len(causes)).To(Equal(1))
 		})
 
-		It("should reject enabling AutoattachGraphicsDevice", func() {
+		It("should accept enabling AutoattachGraphicsDevice", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 
 			_true := true
 			vmi.Spec.Domain.Devices.AutoattachGraphicsDevice = &_true
 
 			causes := webhooks.ValidateVirtualMachineInstanceArm64Setting(k8sfield.NewPath("spec"), &vmi.Spec)
-			Expect(len(causes)).To(Equal(1))
+			Expect(causes).To(BeEmpty())
 		})
 
-		It("should reject setting cpu model to host-model", func() {
+		table.DescribeTable("should validate the cpu model", func(model string, expectedCauses int) {
 			vmi := v1.NewMinimalVMI("testvmi")
-			vmi.Spec.Domain.CPU = &v1.CPU{Model: "host-model"}
+			vmi.Spec.Domain.CPU = &v1.CPU{Model: model}
 
 			causes := webhooks.ValidateVirtualMachineInstanceArm64Setting(k8sfield.NewPath("spec"), &vmi.Spec)
-			Expect(len(causes)).To(Equal(1))
+			Expect(causes).To(HaveLen(expectedCauses))
+		},
+			table.Entry("and accept host-passthrough", "host-passthrough", 0),
+			table.Entry("and accept a cortex model", "cortex-a72", 0),
+			table.Entry("and reject host-model", "host-model", 1),
+			table.Entry("and reject a x86 model", "Haswell", 1),
+		)
+	})
+	Context("with s390x specific settings", func() {
+		It("should accept the default settings", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+
+			causes := webhooks.ValidateVirtualMachineInstanceS390XSetting(k8sfield.NewPath("spec"), &vmi.Spec)
+			Expect(causes).To(BeEmpty())
+		})
+
+		It("should reject uefi boot", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Firmware = &v1.Firmware{Bootloader: &v1.Bootloader{EFI: &v1.EFI{}}}
+
+			causes := webhooks.ValidateVirtualMachineInstanceS390XSetting(k8sfield.NewPath("spec"), &vmi.Spec)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("spec.domain.firmware.bootloader.efi"))
+		})
+
+		It("should reject usb input devices", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Inputs = []v1.Input{{Name: "tablet", Type: "tablet", Bus: "usb"}}
+
+			causes := webhooks.ValidateVirtualMachineInstanceS390XSetting(k8sfield.NewPath("spec"), &vmi.Spec)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("spec.domain.devices.inputs[0].bus"))
+		})
+
+		It("should reject hyperv features", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Features = &v1.Features{Hyperv: &v1.FeatureHyperv{}}
+
+			causes := webhooks.ValidateVirtualMachineInstanceS390XSetting(k8sfield.NewPath("spec"), &vmi.Spec)
+			Expect(causes).To(HaveLen(1))
+		})
+	})
+	Context("with free page reporting", func() {
+		table.DescribeTable("should validate the memory configuration", func(setup func(*v1.VirtualMachineInstance), expectedCauses int) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.FreePageReporting = pointer.BoolPtr(true)
+			setup(vmi)
+
+			causes := validateFreePageReporting(k8sfield.NewPath("fake"), &vmi.Spec)
+			Expect(causes).To(HaveLen(expectedCauses))
+		},
+			table.Entry("and accept it with the memory balloon", func(vmi *v1.VirtualMachineInstance) {}, 0),
+			table.Entry("and reject it without the memory balloon", func(vmi *v1.VirtualMachineInstance) {
+				vmi.Spec.Domain.Devices.AutoattachMemBalloon = pointer.BoolPtr(false)
+			}, 1),
+			table.Entry("and reject it with hugepages", func(vmi *v1.VirtualMachineInstance) {
+				vmi.Spec.Domain.Memory = &v1.Memory{Hugepages: &v1.Hugepages{PageSize: "2Mi"}}
+			}, 1),
+		)
+	})
+
+	Context("with Hyper-V passthrough", func() {
+		It("should accept Hyper-V passthrough", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Features = &v1.Features{HypervPassthrough: &v1.HyperVPassthrough{}}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+
+		It("should reject Hyper-V passthrough together with explicit Hyper-V features", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Features = &v1.Features{
+				HypervPassthrough: &v1.HyperVPassthrough{},
+				Hyperv:            &v1.FeatureHyperv{Relaxed: &v1.FeatureState{}},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.features.hypervPassthrough"))
+		})
+	})
+
+	Context("with QEMU arguments", func() {
+		enableQEMUArgs := func(allowList ...string) {
+			kvConfig := kv.DeepCopy()
+			kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = []string{virtconfig.QEMUArgsGate}
+			kvConfig.Spec.Configuration.QEMUArgsAllowList = allowList
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+		}
+
+		It("should reject QEMU arguments when the feature gate is disabled", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.QEMUArgs = []v1.QEMUArg{{Name: "-fw_cfg", Value: "name=opt/com.example/config,string=value"}}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.qemuArgs"))
+		})
+
+		It("should accept QEMU arguments from the allow list", func() {
+			enableQEMUArgs("-fw_cfg")
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.QEMUArgs = []v1.QEMUArg{{Name: "-fw_cfg", Value: "name=opt/com.example/config,string=value"}}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+
+		It("should reject QEMU arguments which are not on the allow list", func() {
+			enableQEMUArgs("-fw_cfg")
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.QEMUArgs = []v1.QEMUArg{
+				{Name: "-fw_cfg", Value: "name=opt/com.example/config,string=value"},
+				{Name: "-device", Value: "pci-assign,host=01:00.0"},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Type).To(Equal(metav1.CauseTypeFieldValueNotSupported))
+			Expect(causes[0].Field).To(Equal("fake.domain.qemuArgs[1].name"))
+		})
+
+		It("should reject allow-listed QEMU arguments which are not supported", func() {
+			enableQEMUArgs("-chardev")
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.QEMUArgs = []v1.QEMUArg{{Name: "-chardev", Value: "file,id=c0,path=/var/run/kubevirt-private/secret"}}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Type).To(Equal(metav1.CauseTypeFieldValueNotSupported))
+			Expect(causes[0].Field).To(Equal("fake.domain.qemuArgs[0].name"))
+		})
+
+		table.DescribeTable("should reject values which do not match the schema of the QEMU argument", func(name, value string) {
+			enableQEMUArgs("-fw_cfg", "-smbios")
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.QEMUArgs = []v1.QEMUArg{{Name: name, Value: value}}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Type).To(Equal(metav1.CauseTypeFieldValueInvalid))
+			Expect(causes[0].Field).To(Equal("fake.domain.qemuArgs[0].value"))
+		},
+			table.Entry("with an additional argument", "-fw_cfg", "-device"),
+			table.Entry("with a file", "-fw_cfg", "name=opt/x,file=/var/run/kubevirt-private/secret"),
+			table.Entry("with a path in a value", "-fw_cfg", "name=opt/x,string=/etc/passwd"),
+			table.Entry("with a name outside of opt/", "-fw_cfg", "name=etc/boot-menu-wait,string=1"),
+			table.Entry("with an escaped comma", "-fw_cfg", "name=opt/x,string=a,,file=/etc/passwd"),
+			table.Entry("without a required option", "-fw_cfg", "name=opt/x"),
+			table.Entry("with a repeated option", "-fw_cfg", "name=opt/x,string=a,string=b"),
+			table.Entry("with an SMBIOS file", "-smbios", "file=/var/run/kubevirt-private/table"),
+			table.Entry("with an unknown SMBIOS type", "-smbios", "type=127,value=x"),
+		)
+
+		It("should accept SMBIOS fields", func() {
+			enableQEMUArgs("-smbios")
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.QEMUArgs = []v1.QEMUArg{{Name: "-smbios", Value: "type=1,manufacturer=Example Corp,serial=ABC-123"}}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
 		})
 	})
 })
@@ -78,11 +78,11 @@ func (admitter *VMIUpdateAdmitter) Admit(ar *admissionv1.AdmissionReview) *admis
 
 // admitHotplug compares the old and new volumes and disks, and ensures that they match and are valid.
 func admitHotplug(newVolumes, oldVolumes []v1.Volume, newDisks, oldDisks []v1.Disk, volumeStatuses []v1.VolumeStatus, newVMI *v1.VirtualMachineInstance, config *virtconfig.ClusterConfig) *admissionv1.AdmissionResponse {
-	if len(newVolumes) != len(newDisks) {
+	if diskVolumes := countDiskVolumes(newVolumes); diskVolumes != len(newDisks) {
 		return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
 			{
 				Type:    metav1.CauseTypeFieldValueInvalid,
-				Message: fmt.Sprintf("number of disks (%d) does not equal the number of volumes (%d)", len(newDisks), len(newVolumes)),
+				Message: fmt.Sprintf("number of disks (%d) does not equal the number of volumes (%d)", len(newDisks), diskVolumes),
 			},
 		})
 	}
@@ -112,10 +112,36 @@ func admitHotplug(newVolumes, oldVolumes []v1.Volume, newDisks, oldDisks []v1.Di
 	return nil
 }
 
+// countDiskVolumes counts the volumes which are backing a disk, memory dump volumes have no disk
+func countDiskVolumes(volumes []v1.Volume) int {
+	count := 0
+	for _, volume := range volumes {
+		if volume.MemoryDump == nil {
+			count++
+		}
+	}
+	return count
+}
+
 func verifyHotplugVolumes(newHotplugVolumeMap, oldHotplugVolumeMap map[string]v1.Volume, newDisks, oldDisks map[string]v1.Disk) *admissionv1.AdmissionResponse {
 	for k, v := range newHotplugVolumeMap {
+		if v.MemoryDump != nil {
+			// A memory dump volume has no disk, it can only be plugged and unplugged
+			if old, ok := oldHotplugVolumeMap[k]; ok && !reflect.DeepEqual(v, old) {
+				return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+					{
+						Type:    metav1.CauseTypeFieldValueInvalid,
+						Message: fmt.Sprintf("hotplug volume %s, changed", k),
+					},
+				})
+			}
+			continue
+		}
 		if _, ok := oldHotplugVolumeMap[k]; ok {
 			// New and old have same volume, ensure they are the same
+			if isCDRomMediaChange(v, oldHotplugVolumeMap[k], newDisks[k], oldDisks[k]) {
+				continue
+			}
 			if !reflect.DeepEqual(v, oldHotplugVolumeMap[k]) {
 				return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
 					{
@@ -196,6 +222,9 @@ func verifyPermanentVolumes(newPermanentVolumeMap, oldPermanentVolumeMap map[str
 				},
 			})
 		}
+		if isCDRomMediaChange(v, oldPermanentVolumeMap[k], newDisks[k], oldDisks[k]) {
+			continue
+		}
 		if !reflect.DeepEqual(v, oldPermanentVolumeMap[k]) {
 			return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
 				{
@@ -216,6 +245,20 @@ func verifyPermanentVolumes(newPermanentVolumeMap, oldPermanentVolumeMap map[str
 	return nil
 }
 
+// isCDRomMediaChange returns true if only the media of a CD-ROM changed. Either the tray
+// state changed or a PVC or DataVolume got inserted.
+func isCDRomMediaChange(newVolume, oldVolume v1.Volume, newDisk, oldDisk v1.Disk) bool {
+	if newDisk.CDRom == nil || oldDisk.CDRom == nil {
+		return false
+	}
+	newDiskWithoutTray := newDisk.DeepCopy()
+	newDiskWithoutTray.CDRom.Tray = oldDisk.CDRom.Tray
+	if !reflect.DeepEqual(*newDiskWithoutTray, oldDisk) {
+		return false
+	}
+	return reflect.DeepEqual(newVolume, oldVolume) || newVolume.PersistentVolumeClaim != nil || newVolume.DataVolume != nil
+}
+
 func getDiskMap(disks []v1.Disk) map[string]v1.Disk {
 	newDiskMap := make(map[string]v1.Disk, 0)
 	for _, disk := range disks {
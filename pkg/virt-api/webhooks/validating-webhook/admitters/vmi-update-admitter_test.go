@@ -327,6 +327,22 @@ var _ = Describe("Validating VMIUpdate Admitter", func() {
 		return res
 	}
 
+	makeCDRoms := func(tray v1.TrayState, indexes ...int) []v1.Disk {
+		res := make([]v1.Disk, 0)
+		for _, index := range indexes {
+			res = append(res, v1.Disk{
+				Name: fmt.Sprintf("volume-name-%d", index),
+				DiskDevice: v1.DiskDevice{
+					CDRom: &v1.CDRomTarget{
+						Bus:  "sata",
+						Tray: tray,
+					},
+				},
+			})
+		}
+		return res
+	}
+
 	makeDisksInvalidBusLastDisk := func(indexes ...int) []v1.Disk {
 		res := makeDisks(indexes...)
 		for i, index := range indexes {
@@ -478,6 +494,27 @@ var _ = Describe("Validating VMIUpdate Admitter", func() {
 			makeDisks(0),
 			makeStatus(1, 0),
 			makeExpected("hotplugged Disk volume-name-1 does not use a scsi bus", "")),
+		table.Entry("Should accept if the media of a CD-ROM is ejected",
+			makeVolumes(0),
+			makeVolumes(0),
+			makeCDRoms(v1.TrayStateOpen, 0),
+			makeCDRoms("", 0),
+			makeStatus(1, 0),
+			nil),
+		table.Entry("Should accept if a DataVolume is inserted into a CD-ROM",
+			makeVolumes(0),
+			makeInvalidVolumes(1, 0),
+			makeCDRoms(v1.TrayStateClosed, 0),
+			makeCDRoms(v1.TrayStateOpen, 0),
+			makeStatus(1, 0),
+			nil),
+		table.Entry("Should reject if the media of a CD-ROM is changed to a container disk",
+			makeInvalidVolumes(1, 0),
+			makeVolumes(0),
+			makeCDRoms("", 0),
+			makeCDRoms("", 0),
+			makeStatus(1, 0),
+			makeExpected("permanent volume volume-name-0, changed", "")),
 		table.Entry("Should reject if we add disk with invalid boot order",
 			makeVolumes(0, 1),
 			makeVolumes(0),
@@ -0,0 +1,105 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package admitters
+
+import (
+	"fmt"
+
+	k8sv1 "k8s.io/api/core/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	typesutil "kubevirt.io/kubevirt/pkg/util/types"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+// WarnVirtualMachineInstanceSpec returns admission warnings for settings which are accepted, but
+// are deprecated or have no effect. Unlike status causes, warnings never reject a request.
+func WarnVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) []string {
+	var warnings []string
+	warnings = append(warnings, warnIgnoredInterfacePorts(field, spec)...)
+	warnings = append(warnings, warnDeprecatedMachineType(field, spec, config)...)
+	return warnings
+}
+
+// warnIgnoredInterfacePorts warns about ports of interfaces which don't forward ports. Only the
+// masquerade and slirp bindings of the pod network forward ports, all other interfaces ignore them.
+func warnIgnoredInterfacePorts(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (warnings []string) {
+	podNetworks := map[string]bool{}
+	for _, network := range spec.Networks {
+		podNetworks[network.Name] = network.Pod != nil
+	}
+
+	for idx, iface := range spec.Domain.Devices.Interfaces {
+		if len(iface.Ports) == 0 {
+			continue
+		}
+		portsField := field.Child("domain", "devices", "interfaces").Index(idx).Child("ports").String()
+		if !podNetworks[iface.Name] {
+			warnings = append(warnings, fmt.Sprintf("%s is ignored, ports are only forwarded on the pod network", portsField))
+		} else if iface.Masquerade == nil && iface.Slirp == nil {
+			warnings = append(warnings, fmt.Sprintf("%s is ignored, ports are only forwarded by the masquerade and slirp bindings, use masquerade instead", portsField))
+		}
+	}
+	return warnings
+}
+
+func warnDeprecatedMachineType(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (warnings []string) {
+	if spec.Domain.Machine == nil || spec.Domain.Machine.Type == "" {
+		return nil
+	}
+	if !config.IsMachineTypeDeprecated(spec.Domain.Machine.Type) {
+		return nil
+	}
+	warning := fmt.Sprintf("%s: machine type %s is deprecated", field.Child("domain", "machine", "type").String(), spec.Domain.Machine.Type)
+	if machineType := config.GetMachineType(); machineType != "" && machineType != spec.Domain.Machine.Type {
+		warning = fmt.Sprintf("%s, use %s instead", warning, machineType)
+	}
+	return []string{warning}
+}
+
+// warnNotLiveMigratableVolumes warns about PVCs which the source and the target of a migration can't
+// access at the same time, if the VMI has to be live migrated when it is evicted. Such VMIs are still
+// admitted, they just can't be evicted from their node. PVCs which are not in the cache yet, e.g. of
+// DataVolumes which are still being created, are left to the LiveMigratable condition.
+func warnNotLiveMigratableVolumes(field *k8sfield.Path, namespace string, vmi *v1.VirtualMachineInstance, pvcStore cache.Store) (warnings []string) {
+	if !vmi.IsEvictable() {
+		return nil
+	}
+
+	for idx := range vmi.Spec.Volumes {
+		volume := &vmi.Spec.Volumes[idx]
+		claimName := typesutil.PVCNameFromVirtVolume(volume)
+		if claimName == "" {
+			continue
+		}
+		obj, exists, err := pvcStore.GetByKey(fmt.Sprintf("%s/%s", namespace, claimName))
+		if err != nil || !exists {
+			continue
+		}
+		pvc := obj.(*k8sv1.PersistentVolumeClaim)
+		if err := typesutil.CheckPVCIsShared(volume.Name, claimName, pvc.Spec.AccessModes); err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: evictionStrategy %s requires the VMI to be live migratable, it can't be evicted from its node: %v",
+				field.Child("volumes").Index(idx).String(), v1.EvictionStrategyLiveMigrate, err))
+		}
+	}
+	return warnings
+}
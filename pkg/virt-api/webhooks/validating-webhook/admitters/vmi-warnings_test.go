@@ -0,0 +1,101 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package admitters
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+)
+
+var _ = Describe("Admission warnings", func() {
+	config, _, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+		MachineType:            "pc-q35-rhel8.4.0",
+		DeprecatedMachineTypes: []string{"pc-q35-rhel7.*"},
+	})
+
+	newVMI := func(network v1.Network, iface v1.Interface) *v1.VirtualMachineInstance {
+		vmi := v1.NewMinimalVMI("testvmi")
+		iface.Name = "default"
+		network.Name = "default"
+		vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{iface}
+		vmi.Spec.Networks = []v1.Network{network}
+		return vmi
+	}
+	ports := []v1.Port{{Name: "http", Port: 80}}
+	podNetwork := v1.Network{NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}}}
+	multusNetwork := v1.Network{NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "net"}}}
+
+	table.DescribeTable("should warn about ports which are ignored", func(network v1.Network, iface v1.Interface, expected []string) {
+		vmi := newVMI(network, iface)
+		Expect(WarnVirtualMachineInstanceSpec(k8sfield.NewPath("spec"), &vmi.Spec, config)).To(Equal(expected))
+	},
+		table.Entry("not with masquerade", podNetwork,
+			v1.Interface{InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}, Ports: ports}, nil),
+		table.Entry("not with slirp", podNetwork,
+			v1.Interface{InterfaceBindingMethod: v1.InterfaceBindingMethod{Slirp: &v1.InterfaceSlirp{}}, Ports: ports}, nil),
+		table.Entry("not without ports", podNetwork,
+			v1.Interface{InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}}, nil),
+		table.Entry("with bridge", podNetwork,
+			v1.Interface{InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}, Ports: ports},
+			[]string{"spec.domain.devices.interfaces[0].ports is ignored, ports are only forwarded by the masquerade and slirp bindings, use masquerade instead"}),
+		table.Entry("on other networks than the pod network", multusNetwork,
+			v1.Interface{InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}, Ports: ports},
+			[]string{"spec.domain.devices.interfaces[0].ports is ignored, ports are only forwarded on the pod network"}),
+	)
+
+	table.DescribeTable("should warn about deprecated machine types", func(machineType string, expected []string) {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Machine = &v1.Machine{Type: machineType}
+		Expect(WarnVirtualMachineInstanceSpec(k8sfield.NewPath("spec"), &vmi.Spec, config)).To(Equal(expected))
+	},
+		table.Entry("with a deprecated machine type", "pc-q35-rhel7.6.0",
+			[]string{"spec.domain.machine.type: machine type pc-q35-rhel7.6.0 is deprecated, use pc-q35-rhel8.4.0 instead"}),
+		table.Entry("not with a supported machine type", "pc-q35-rhel8.4.0", nil),
+		table.Entry("not without a machine type", "", nil),
+	)
+
+	It("should return the warnings of an accepted VMI", func() {
+		vmi := newVMI(podNetwork, v1.Interface{InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}, Ports: ports})
+		vmiBytes, _ := json.Marshal(&vmi)
+		ar := &admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: vmiBytes,
+				},
+			},
+		}
+
+		resp := (&VMICreateAdmitter{ClusterConfig: config}).Admit(ar)
+		Expect(resp.Allowed).To(BeTrue())
+		Expect(resp.Warnings).To(HaveLen(1))
+		Expect(resp.Warnings[0]).To(ContainSubstring("spec.domain.devices.interfaces[0].ports"))
+	})
+})
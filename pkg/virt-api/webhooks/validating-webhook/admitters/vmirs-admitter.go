@@ -93,5 +93,41 @@ func ValidateVMIRSSpec(field *k8sfield.Path, spec *v1.VirtualMachineInstanceRepl
 		})
 	}
 
+	if spec.HeadlessService {
+		causes = append(causes, validateHeadlessServiceTemplate(field.Child("template"), spec.Template)...)
+	}
+
+	return causes
+}
+
+// validateHeadlessServiceTemplate checks that the VirtualMachineInstances of a template can get own DNS records
+// through a headless Service, which selects them by their labels and is named after their subdomain.
+func validateHeadlessServiceTemplate(field *k8sfield.Path, template *v1.VirtualMachineInstanceTemplateSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	if len(template.ObjectMeta.Labels) == 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "labels are required to select the virtual machine instances of the headless service.",
+			Field:   field.Child("metadata", "labels").String(),
+		})
+	}
+
+	if template.Spec.Subdomain == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "a subdomain is required to name the headless service.",
+			Field:   field.Child("spec", "subdomain").String(),
+		})
+	}
+
+	if template.Spec.Hostname != "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "a hostname is shared by all virtual machine instances and can't be used with a headless service.",
+			Field:   field.Child("spec", "hostname").String(),
+		})
+	}
+
 	return causes
 }
@@ -104,6 +104,22 @@ var _ = Describe("Validating VMIRS Admitter", func() {
 		}, []string{
 			"spec.selector",
 		}),
+		table.Entry("with a headless service and a hostname but without a subdomain", &v1.VirtualMachineInstanceReplicaSet{
+			Spec: v1.VirtualMachineInstanceReplicaSetSpec{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"match": "this"},
+				},
+				Template: func() *v1.VirtualMachineInstanceTemplateSpec {
+					template := newVirtualMachineBuilder().WithLabel("match", "this").BuildTemplate()
+					template.Spec.Hostname = "shared"
+					return template
+				}(),
+				HeadlessService: true,
+			},
+		}, []string{
+			"spec.template.spec.subdomain",
+			"spec.template.spec.hostname",
+		}),
 	)
 	It("should accept valid vmi spec", func() {
 		vmirs := &v1.VirtualMachineInstanceReplicaSet{
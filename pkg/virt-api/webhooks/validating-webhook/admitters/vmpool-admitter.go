@@ -0,0 +1,117 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package admitters
+
+import (
+	"encoding/json"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	poolv1 "kubevirt.io/client-go/apis/pool/v1alpha1"
+	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+// VMPoolAdmitter validates VirtualMachinePools
+type VMPoolAdmitter struct {
+	ClusterConfig *virtconfig.ClusterConfig
+}
+
+// Admit validates an AdmissionReview
+func (admitter *VMPoolAdmitter) Admit(ar *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
+	if ar.Request.Resource.Group != poolv1.SchemeGroupVersion.Group ||
+		ar.Request.Resource.Resource != "virtualmachinepools" {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("unexpected resource %+v", ar.Request.Resource))
+	}
+
+	if ar.Request.Operation == admissionv1.Create && !admitter.ClusterConfig.VMPoolEnabled() {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("VMPool feature gate not enabled"))
+	}
+
+	pool := poolv1.VirtualMachinePool{}
+	err := json.Unmarshal(ar.Request.Object.Raw, &pool)
+	if err != nil {
+		return webhookutils.ToAdmissionResponseError(err)
+	}
+
+	causes := ValidateVMPoolSpec(k8sfield.NewPath("spec"), &pool.Spec, admitter.ClusterConfig, ar.Request.UserInfo.Username)
+	if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
+	reviewResponse := admissionv1.AdmissionResponse{}
+	reviewResponse.Allowed = true
+	return &reviewResponse
+}
+
+func ValidateVMPoolSpec(field *k8sfield.Path, spec *poolv1.VirtualMachinePoolSpec, config *virtconfig.ClusterConfig, accountName string) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	if spec.VirtualMachineTemplate == nil {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "missing virtual machine template.",
+			Field:   field.Child("virtualMachineTemplate").String(),
+		})
+	}
+	causes = append(causes, ValidateVirtualMachineSpec(field.Child("virtualMachineTemplate", "spec"), &spec.VirtualMachineTemplate.Spec, config, accountName)...)
+
+	selector, err := metav1.LabelSelectorAsSelector(spec.Selector)
+	if err != nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: err.Error(),
+			Field:   field.Child("selector").String(),
+		})
+	} else if selector.Empty() || !selector.Matches(labels.Set(spec.VirtualMachineTemplate.ObjectMeta.Labels)) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "selector does not match labels.",
+			Field:   field.Child("selector").String(),
+		})
+	}
+
+	if spec.Replicas != nil && *spec.Replicas < 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "replicas must not be negative.",
+			Field:   field.Child("replicas").String(),
+		})
+	}
+
+	if spec.MaxUnavailable != nil && *spec.MaxUnavailable < 1 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "maxUnavailable must be at least 1.",
+			Field:   field.Child("maxUnavailable").String(),
+		})
+	}
+
+	// a missing template is reported by the virtual machine validation
+	if spec.HeadlessService && spec.VirtualMachineTemplate.Spec.Template != nil {
+		causes = append(causes, validateHeadlessServiceTemplate(field.Child("virtualMachineTemplate", "spec", "template"), spec.VirtualMachineTemplate.Spec.Template)...)
+	}
+
+	return causes
+}
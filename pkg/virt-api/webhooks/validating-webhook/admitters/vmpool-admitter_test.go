@@ -0,0 +1,154 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package admitters
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	poolv1 "kubevirt.io/client-go/apis/pool/v1alpha1"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+var _ = Describe("Validating VMPool Admitter", func() {
+	config, configMapInformer, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{})
+	poolAdmitter := &VMPoolAdmitter{ClusterConfig: config}
+
+	newPool := func() *poolv1.VirtualMachinePool {
+		vmi := v1.NewMinimalVMI("testvmi")
+		running := true
+		return &poolv1.VirtualMachinePool{
+			ObjectMeta: metav1.ObjectMeta{Name: "pool"},
+			Spec: poolv1.VirtualMachinePoolSpec{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "pool"},
+				},
+				VirtualMachineTemplate: &poolv1.VirtualMachineTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{"app": "pool"},
+					},
+					Spec: v1.VirtualMachineSpec{
+						Running: &running,
+						Template: &v1.VirtualMachineInstanceTemplateSpec{
+							Spec: vmi.Spec,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	admit := func(pool *poolv1.VirtualMachinePool) *admissionv1.AdmissionResponse {
+		poolBytes, _ := json.Marshal(pool)
+		ar := &admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Resource: metav1.GroupVersionResource{
+					Group:    poolv1.SchemeGroupVersion.Group,
+					Version:  poolv1.SchemeGroupVersion.Version,
+					Resource: "virtualmachinepools",
+				},
+				Object: runtime.RawExtension{
+					Raw: poolBytes,
+				},
+			},
+		}
+		return poolAdmitter.Admit(ar)
+	}
+
+	It("should reject pools without the feature gate", func() {
+		resp := admit(newPool())
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Message).To(Equal("VMPool feature gate not enabled"))
+	})
+
+	Context("with the feature gate enabled", func() {
+		BeforeEach(func() {
+			testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{
+				Data: map[string]string{virtconfig.FeatureGatesKey: virtconfig.VMPoolGate},
+			})
+		})
+
+		AfterEach(func() {
+			testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{})
+		})
+
+		It("should accept a valid pool", func() {
+			resp := admit(newPool())
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		table.DescribeTable("should reject", func(modify func(pool *poolv1.VirtualMachinePool), field string) {
+			pool := newPool()
+			modify(pool)
+			resp := admit(pool)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+		},
+			table.Entry("a missing template", func(pool *poolv1.VirtualMachinePool) {
+				pool.Spec.VirtualMachineTemplate = nil
+			}, "spec.virtualMachineTemplate"),
+			table.Entry("a template without a vmi template", func(pool *poolv1.VirtualMachinePool) {
+				pool.Spec.VirtualMachineTemplate.Spec.Template = nil
+			}, "spec.virtualMachineTemplate.spec.template"),
+			table.Entry("a selector which does not match the template labels", func(pool *poolv1.VirtualMachinePool) {
+				pool.Spec.Selector.MatchLabels = map[string]string{"app": "other"}
+			}, "spec.selector"),
+			table.Entry("an empty selector", func(pool *poolv1.VirtualMachinePool) {
+				pool.Spec.Selector = &metav1.LabelSelector{}
+			}, "spec.selector"),
+			table.Entry("negative replicas", func(pool *poolv1.VirtualMachinePool) {
+				replicas := int32(-1)
+				pool.Spec.Replicas = &replicas
+			}, "spec.replicas"),
+			table.Entry("a maxUnavailable below one", func(pool *poolv1.VirtualMachinePool) {
+				maxUnavailable := int32(0)
+				pool.Spec.MaxUnavailable = &maxUnavailable
+			}, "spec.maxUnavailable"),
+			table.Entry("a headless service without a subdomain", func(pool *poolv1.VirtualMachinePool) {
+				pool.Spec.HeadlessService = true
+				pool.Spec.VirtualMachineTemplate.Spec.Template.ObjectMeta.Labels = map[string]string{"app": "pool"}
+			}, "spec.virtualMachineTemplate.spec.template.spec.subdomain"),
+			table.Entry("a headless service without virtual machine instance labels", func(pool *poolv1.VirtualMachinePool) {
+				pool.Spec.HeadlessService = true
+				pool.Spec.VirtualMachineTemplate.Spec.Template.Spec.Subdomain = "pool"
+			}, "spec.virtualMachineTemplate.spec.template.metadata.labels"),
+		)
+
+		It("should accept a headless service", func() {
+			pool := newPool()
+			pool.Spec.HeadlessService = true
+			pool.Spec.VirtualMachineTemplate.Spec.Template.ObjectMeta.Labels = map[string]string{"app": "pool"}
+			pool.Spec.VirtualMachineTemplate.Spec.Template.Spec.Subdomain = "pool"
+			resp := admit(pool)
+			Expect(resp.Allowed).To(BeTrue())
+		})
+	})
+})
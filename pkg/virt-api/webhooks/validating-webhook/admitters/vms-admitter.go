@@ -24,9 +24,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	authv1 "k8s.io/api/authorization/v1"
+	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/tools/cache"
@@ -35,6 +39,7 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	cdiclone "kubevirt.io/containerized-data-importer/pkg/clone"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/util/cron"
 	migrationutil "kubevirt.io/kubevirt/pkg/util/migrations"
 	typesutil "kubevirt.io/kubevirt/pkg/util/types"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
@@ -46,11 +51,20 @@ var validRunStrategies = []v1.VirtualMachineRunStrategy{v1.RunStrategyHalted, v1
 
 type CloneAuthFunc func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error)
 
+// ServiceAuthFunc tells if the user may create Services in the namespace
+type ServiceAuthFunc func(namespace string, userInfo authenticationv1.UserInfo) (bool, string, error)
+
+// ListVMsFunc returns the VirtualMachines of the namespace
+type ListVMsFunc func(namespace string) ([]v1.VirtualMachine, error)
+
 type VMsAdmitter struct {
-	VMIInformer        cache.SharedIndexInformer
-	DataSourceInformer cache.SharedIndexInformer
-	ClusterConfig      *virtconfig.ClusterConfig
-	cloneAuthFunc      CloneAuthFunc
+	VMIInformer                 cache.SharedIndexInformer
+	DataSourceInformer          cache.SharedIndexInformer
+	StorageCapabilitiesInformer cache.SharedIndexInformer
+	ClusterConfig               *virtconfig.ClusterConfig
+	cloneAuthFunc               CloneAuthFunc
+	serviceAuthFunc             ServiceAuthFunc
+	listVMsFunc                 ListVMsFunc
 }
 
 type sarProxy struct {
@@ -61,16 +75,47 @@ func (p *sarProxy) Create(sar *authv1.SubjectAccessReview) (*authv1.SubjectAcces
 	return p.client.AuthorizationV1().SubjectAccessReviews().Create(context.Background(), sar, metav1.CreateOptions{})
 }
 
-func NewVMsAdmitter(clusterConfig *virtconfig.ClusterConfig, client kubecli.KubevirtClient, vmiInformer cache.SharedIndexInformer, dataSourceInformer cache.SharedIndexInformer) *VMsAdmitter {
+func NewVMsAdmitter(clusterConfig *virtconfig.ClusterConfig, client kubecli.KubevirtClient, vmiInformer cache.SharedIndexInformer, dataSourceInformer cache.SharedIndexInformer, storageCapabilitiesInformer cache.SharedIndexInformer) *VMsAdmitter {
 	proxy := &sarProxy{client: client}
 
 	return &VMsAdmitter{
-		VMIInformer:        vmiInformer,
-		DataSourceInformer: dataSourceInformer,
-		ClusterConfig:      clusterConfig,
+		VMIInformer:                 vmiInformer,
+		DataSourceInformer:          dataSourceInformer,
+		StorageCapabilitiesInformer: storageCapabilitiesInformer,
+		ClusterConfig:               clusterConfig,
 		cloneAuthFunc: func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error) {
 			return cdiclone.CanServiceAccountClonePVC(proxy, pvcNamespace, pvcName, saNamespace, saName)
 		},
+		serviceAuthFunc: func(namespace string, userInfo authenticationv1.UserInfo) (bool, string, error) {
+			extra := map[string]authv1.ExtraValue{}
+			for k, v := range userInfo.Extra {
+				extra[k] = authv1.ExtraValue(v)
+			}
+			sar, err := proxy.Create(&authv1.SubjectAccessReview{
+				Spec: authv1.SubjectAccessReviewSpec{
+					User:   userInfo.Username,
+					Groups: userInfo.Groups,
+					UID:    userInfo.UID,
+					Extra:  extra,
+					ResourceAttributes: &authv1.ResourceAttributes{
+						Namespace: namespace,
+						Verb:      "create",
+						Resource:  "services",
+					},
+				},
+			})
+			if err != nil {
+				return false, "", err
+			}
+			return sar.Status.Allowed, sar.Status.Reason, nil
+		},
+		listVMsFunc: func(namespace string) ([]v1.VirtualMachine, error) {
+			list, err := client.VirtualMachine(namespace).List(&metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return list.Items, nil
+		},
 	}
 }
 
@@ -106,6 +151,14 @@ func (admitter *VMsAdmitter) Admit(ar *admissionv1.AdmissionReview) *admissionv1
 		return webhookutils.ToAdmissionResponse(causes)
 	}
 
+	causes, err = admitter.authorizeLoadBalancer(ar.Request, &vm)
+	if err != nil {
+		return webhookutils.ToAdmissionResponseError(err)
+	}
+	if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
 	causes, err = admitter.validateVolumeRequests(&vm)
 	if err != nil {
 		return webhookutils.ToAdmissionResponseError(err)
@@ -113,6 +166,20 @@ func (admitter *VMsAdmitter) Admit(ar *admissionv1.AdmissionReview) *admissionv1
 		return webhookutils.ToAdmissionResponse(causes)
 	}
 
+	causes, err = admitter.validateStartDependencies(ar.Request, &vm)
+	if err != nil {
+		return webhookutils.ToAdmissionResponseError(err)
+	} else if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
+	causes, err = admitter.validateDataVolumeTemplateStorage(&vm)
+	if err != nil {
+		return webhookutils.ToAdmissionResponseError(err)
+	} else if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
 	causes = validateSnapshotStatus(ar.Request, &vm)
 	if len(causes) > 0 {
 		return webhookutils.ToAdmissionResponse(causes)
@@ -125,6 +192,9 @@ func (admitter *VMsAdmitter) Admit(ar *admissionv1.AdmissionReview) *admissionv1
 
 	reviewResponse := admissionv1.AdmissionResponse{}
 	reviewResponse.Allowed = true
+	if vm.Spec.Template != nil {
+		reviewResponse.Warnings = WarnVirtualMachineInstanceSpec(k8sfield.NewPath("spec", "template", "spec"), &vm.Spec.Template.Spec, admitter.ClusterConfig)
+	}
 	return &reviewResponse
 }
 
@@ -156,6 +226,168 @@ func (admitter *VMsAdmitter) AdmitStatus(ar *admissionv1.AdmissionReview) *admis
 	return &reviewResponse
 }
 
+// validateDataVolumeTemplateStorage rejects DataVolumeTemplates which request access modes and a volume
+// mode their StorageClass can't provision, according to the capabilities virt-controller publishes for it
+func (admitter *VMsAdmitter) validateDataVolumeTemplateStorage(vm *v1.VirtualMachine) ([]metav1.StatusCause, error) {
+	var causes []metav1.StatusCause
+
+	if admitter.StorageCapabilitiesInformer == nil {
+		return nil, nil
+	}
+
+	for idx, dataVolume := range vm.Spec.DataVolumeTemplates {
+		var storageClass *string
+		var accessModes []k8sv1.PersistentVolumeAccessMode
+		var volumeMode *k8sv1.PersistentVolumeMode
+		field := k8sfield.NewPath("spec", "dataVolumeTemplates").Index(idx).Child("spec")
+		if pvc := dataVolume.Spec.PVC; pvc != nil {
+			storageClass, accessModes, volumeMode = pvc.StorageClassName, pvc.AccessModes, pvc.VolumeMode
+			field = field.Child("pvc")
+			if volumeMode == nil {
+				filesystem := k8sv1.PersistentVolumeFilesystem
+				volumeMode = &filesystem
+			}
+		} else if storage := dataVolume.Spec.Storage; storage != nil {
+			// CDI fills in what is missing from the StorageProfile, which can always be provisioned
+			storageClass, accessModes, volumeMode = storage.StorageClassName, storage.AccessModes, storage.VolumeMode
+			field = field.Child("storage")
+		}
+		// the default storage class is not known here
+		if storageClass == nil || *storageClass == "" || len(accessModes) == 0 {
+			continue
+		}
+
+		capabilities, exists, err := typesutil.GetStorageClassCapabilitiesFromStore(admitter.StorageCapabilitiesInformer.GetStore(), *storageClass)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+
+		if volumeMode != nil {
+			if !capabilities.SupportsClaimProperties(accessModes, *volumeMode) {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: fmt.Sprintf("StorageClass %s does not support %s volumes with access modes %v", *storageClass, *volumeMode, accessModes),
+					Field:   field.Child("accessModes").String(),
+				})
+			}
+		} else if !capabilities.SupportsClaimProperties(accessModes, k8sv1.PersistentVolumeFilesystem) &&
+			!capabilities.SupportsClaimProperties(accessModes, k8sv1.PersistentVolumeBlock) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("StorageClass %s does not support volumes with access modes %v", *storageClass, accessModes),
+				Field:   field.Child("accessModes").String(),
+			})
+		}
+	}
+
+	return causes, nil
+}
+
+// authorizeLoadBalancer requires the user who requests or changes the load balancer of the VM to be allowed
+// to create Services, because virt-controller creates the Service of the load balancer with its own permissions
+func (admitter *VMsAdmitter) authorizeLoadBalancer(ar *admissionv1.AdmissionRequest, vm *v1.VirtualMachine) ([]metav1.StatusCause, error) {
+	if vm.Annotations[v1.LoadBalancerAnnotation] != "true" || admitter.serviceAuthFunc == nil {
+		return nil, nil
+	}
+	if ar.Operation == admissionv1.Update {
+		oldVM := v1.VirtualMachine{}
+		if err := json.Unmarshal(ar.OldObject.Raw, &oldVM); err != nil {
+			return nil, err
+		}
+		changed := false
+		for _, annotation := range []string{v1.LoadBalancerAnnotation, v1.LoadBalancerPortsAnnotation, v1.LoadBalancerIPAnnotation} {
+			if oldVM.Annotations[annotation] != vm.Annotations[annotation] {
+				changed = true
+			}
+		}
+		if !changed {
+			return nil, nil
+		}
+	}
+
+	namespace := vm.Namespace
+	if namespace == "" {
+		namespace = ar.Namespace
+	}
+	allowed, reason, err := admitter.serviceAuthFunc(namespace, ar.UserInfo)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("Authorization failed, the load balancer requires permission to create services: %s", reason),
+			Field:   k8sfield.NewPath("metadata", "annotations").Key(v1.LoadBalancerAnnotation).String(),
+		}}, nil
+	}
+	return nil, nil
+}
+
+// validateStartDependencies rejects start groups which make the VM wait for itself, through the
+// VMs it waits for, because none of these VMs would ever be started
+func (admitter *VMsAdmitter) validateStartDependencies(ar *admissionv1.AdmissionRequest, vm *v1.VirtualMachine) ([]metav1.StatusCause, error) {
+	_, isMember := vm.Labels[v1.StartGroupLabel]
+	if admitter.listVMsFunc == nil || !isMember || len(controller.StartAfterGroups(vm)) == 0 {
+		// only a VM which waits for others and is waited for can close a cycle
+		return nil, nil
+	}
+
+	namespace := vm.Namespace
+	if namespace == "" {
+		namespace = ar.Namespace
+	}
+	list, err := admitter.listVMsFunc(namespace)
+	if err != nil {
+		return nil, err
+	}
+	admitted := vm.DeepCopy()
+	admitted.Namespace = namespace
+	vms := []*v1.VirtualMachine{admitted}
+	for i := range list {
+		if list[i].Name != vm.Name {
+			vms = append(vms, &list[i])
+		}
+	}
+
+	if cycle := findStartDependencyCycle(admitted, vms); cycle != nil {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("The start groups wait for each other in a cycle: %s", strings.Join(cycle, " -> ")),
+			Field:   k8sfield.NewPath("metadata", "annotations").Key(v1.StartAfterAnnotation).String(),
+		}}, nil
+	}
+	return nil, nil
+}
+
+// findStartDependencyCycle returns the names of the VMs on a path from the VM through the VMs it
+// waits for back to the VM, or nil if there is none
+func findStartDependencyCycle(vm *v1.VirtualMachine, vms []*v1.VirtualMachine) []string {
+	visited := map[string]bool{}
+	var visit func(current *v1.VirtualMachine, path []string) []string
+	visit = func(current *v1.VirtualMachine, path []string) []string {
+		for _, dependency := range vms {
+			if !controller.IsStartDependency(current, dependency) {
+				continue
+			}
+			if dependency.Name == vm.Name {
+				return append(path, dependency.Name)
+			}
+			if visited[dependency.Name] {
+				continue
+			}
+			visited[dependency.Name] = true
+			if cycle := visit(dependency, append(path, dependency.Name)); cycle != nil {
+				return cycle
+			}
+		}
+		return nil
+	}
+	return visit(vm, []string{vm.Name})
+}
+
 func (admitter *VMsAdmitter) authorizeVirtualMachineSpec(ar *admissionv1.AdmissionRequest, vm *v1.VirtualMachine) ([]metav1.StatusCause, error) {
 	var causes []metav1.StatusCause
 
@@ -300,6 +532,61 @@ func ValidateVirtualMachineSpec(field *k8sfield.Path, spec *v1.VirtualMachineSpe
 		}
 	}
 
+	if spec.NodeRebootPolicy != nil &&
+		*spec.NodeRebootPolicy != v1.NodeRebootPolicyRestart &&
+		*spec.NodeRebootPolicy != v1.NodeRebootPolicyHold {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("Invalid NodeRebootPolicy (%s)", *spec.NodeRebootPolicy),
+			Field:   field.Child("nodeRebootPolicy").String(),
+		})
+	}
+
+	if spec.Schedule != nil {
+		causes = append(causes, validateVirtualMachineSchedule(field.Child("schedule"), spec.Schedule, config)...)
+	}
+
+	return causes
+}
+
+func validateVirtualMachineSchedule(field *k8sfield.Path, schedule *v1.VirtualMachineSchedule, config *virtconfig.ClusterConfig) []metav1.StatusCause {
+	if !config.VMScheduleEnabled() {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s feature gate is not enabled", virtconfig.VMScheduleGate),
+			Field:   field.String(),
+		}}
+	}
+
+	var causes []metav1.StatusCause
+	if schedule.Start == "" && schedule.Stop == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "a schedule needs a start or a stop expression",
+			Field:   field.String(),
+		})
+	}
+	for _, expr := range []struct{ name, value string }{{"start", schedule.Start}, {"stop", schedule.Stop}} {
+		if expr.value == "" {
+			continue
+		}
+		if _, err := cron.Parse(expr.value); err != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: err.Error(),
+				Field:   field.Child(expr.name).String(),
+			})
+		}
+	}
+	if schedule.TimeZone != "" {
+		if _, err := time.LoadLocation(schedule.TimeZone); err != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("unknown time zone %s", schedule.TimeZone),
+				Field:   field.Child("timeZone").String(),
+			})
+		}
+	}
 	return causes
 }
 
@@ -31,16 +31,19 @@ import (
 	. "github.com/onsi/gomega"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/pointer"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1beta1"
 	"kubevirt.io/kubevirt/pkg/testutils"
+	typesutil "kubevirt.io/kubevirt/pkg/util/types"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
@@ -51,6 +54,7 @@ var _ = Describe("Validating VM Admitter", func() {
 	var vmsAdmitter *VMsAdmitter
 	var vmiInformer cache.SharedIndexInformer
 	var dataSourceInformer cache.SharedIndexInformer
+	var storageCapabilitiesInformer cache.SharedIndexInformer
 
 	enableFeatureGate := func(featureGate string) {
 		testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{
@@ -66,11 +70,13 @@ var _ = Describe("Validating VM Admitter", func() {
 	BeforeEach(func() {
 		vmiInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
 		dataSourceInformer, _ = testutils.NewFakeInformerFor(&cdiv1.DataSource{})
+		storageCapabilitiesInformer, _ = testutils.NewFakeInformerFor(&k8sv1.ConfigMap{})
 		ctrl = gomock.NewController(GinkgoT())
 		vmsAdmitter = &VMsAdmitter{
-			DataSourceInformer: dataSourceInformer,
-			VMIInformer:        vmiInformer,
-			ClusterConfig:      config,
+			DataSourceInformer:          dataSourceInformer,
+			StorageCapabilitiesInformer: storageCapabilitiesInformer,
+			VMIInformer:                 vmiInformer,
+			ClusterConfig:               config,
 			cloneAuthFunc: func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error) {
 				return true, "", nil
 			},
@@ -712,6 +718,279 @@ var _ = Describe("Validating VM Admitter", func() {
 		Expect(resp.Allowed).To(BeTrue())
 	})
 
+	table.DescribeTable("should validate the DataVolumeTemplate against the capabilities of its storage class", func(spec cdiv1.DataVolumeSpec, expectedField string) {
+		Expect(storageCapabilitiesInformer.GetIndexer().Add(&k8sv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: typesutil.StorageCapabilitiesConfigMapName, Namespace: "kubevirt"},
+			Data: map[string]string{
+				"ceph":    `{"provisioner":"rbd.csi.ceph.com","claimPropertySets":[{"accessModes":["ReadWriteMany"],"volumeMode":"Block"},{"accessModes":["ReadWriteOnce"]}],"liveMigration":true,"snapshot":true}`,
+				"unknown": `{"provisioner":"unknown.csi","snapshot":false}`,
+			},
+		})).To(Succeed())
+
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+			Name: "testdisk",
+		})
+		vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+			Name: "testdisk",
+			VolumeSource: v1.VolumeSource{
+				DataVolume: &v1.DataVolumeSource{
+					Name: "dv1",
+				},
+			},
+		})
+		vm := &v1.VirtualMachine{
+			Spec: v1.VirtualMachineSpec{
+				Running: &notRunning,
+				Template: &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: vmi.Spec,
+				},
+				DataVolumeTemplates: []v1.DataVolumeTemplateSpec{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "dv1"},
+						Spec:       spec,
+					},
+				},
+			},
+		}
+		vmBytes, _ := json.Marshal(&vm)
+
+		ar := &admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				Resource: webhooks.VirtualMachineGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: vmBytes,
+				},
+			},
+		}
+
+		testutils.AddDataVolumeAPI(crdInformer)
+		resp := vmsAdmitter.Admit(ar)
+		if expectedField == "" {
+			Expect(resp.Allowed).To(BeTrue())
+		} else {
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal(expectedField))
+		}
+	},
+		table.Entry("accept supported access modes of a pvc", cdiv1.DataVolumeSpec{
+			PVC: &k8sv1.PersistentVolumeClaimSpec{
+				StorageClassName: pointer.StringPtr("ceph"),
+				AccessModes:      []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteOnce},
+			},
+		}, ""),
+		table.Entry("reject unsupported access modes of a filesystem pvc", cdiv1.DataVolumeSpec{
+			PVC: &k8sv1.PersistentVolumeClaimSpec{
+				StorageClassName: pointer.StringPtr("ceph"),
+				AccessModes:      []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteMany},
+			},
+		}, "spec.dataVolumeTemplates[0].spec.pvc.accessModes"),
+		table.Entry("accept storage with access modes supported in any volume mode", cdiv1.DataVolumeSpec{
+			Storage: &cdiv1.StorageSpec{
+				StorageClassName: pointer.StringPtr("ceph"),
+				AccessModes:      []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteMany},
+			},
+		}, ""),
+		table.Entry("reject storage with unsupported access modes", cdiv1.DataVolumeSpec{
+			Storage: &cdiv1.StorageSpec{
+				StorageClassName: pointer.StringPtr("ceph"),
+				AccessModes:      []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadOnlyMany},
+			},
+		}, "spec.dataVolumeTemplates[0].spec.storage.accessModes"),
+		table.Entry("accept storage classes without published capabilities", cdiv1.DataVolumeSpec{
+			PVC: &k8sv1.PersistentVolumeClaimSpec{
+				StorageClassName: pointer.StringPtr("local"),
+				AccessModes:      []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteMany},
+			},
+		}, ""),
+		table.Entry("accept storage classes whose claim properties CDI has not detected", cdiv1.DataVolumeSpec{
+			PVC: &k8sv1.PersistentVolumeClaimSpec{
+				StorageClassName: pointer.StringPtr("unknown"),
+				AccessModes:      []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteMany},
+			},
+		}, ""),
+	)
+
+	table.DescribeTable("should validate the NodeRebootPolicy", func(policy v1.NodeRebootPolicy, expectedCauses int) {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmSpec := &v1.VirtualMachineSpec{
+			Running:          &notRunning,
+			NodeRebootPolicy: &policy,
+			Template: &v1.VirtualMachineInstanceTemplateSpec{
+				Spec: vmi.Spec,
+			},
+		}
+
+		causes := ValidateVirtualMachineSpec(k8sfield.NewPath("spec"), vmSpec, config, "fake-account")
+		Expect(causes).To(HaveLen(expectedCauses))
+		if expectedCauses > 0 {
+			Expect(causes[0].Field).To(Equal("spec.nodeRebootPolicy"))
+		}
+	},
+		table.Entry("accept Restart", v1.NodeRebootPolicyRestart, 0),
+		table.Entry("accept Hold", v1.NodeRebootPolicyHold, 0),
+		table.Entry("reject an unknown policy", v1.NodeRebootPolicy("Ignore"), 1),
+	)
+
+	Context("with a load balancer", func() {
+		var authorized bool
+		var requestedBy string
+
+		newLoadBalancerRequest := func(operation admissionv1.Operation, oldIP, newIP string) *admissionv1.AdmissionRequest {
+			newVM := func(ip string) []byte {
+				vm := &v1.VirtualMachine{ObjectMeta: metav1.ObjectMeta{
+					Name:      "testvm",
+					Namespace: "default",
+					Annotations: map[string]string{
+						v1.LoadBalancerAnnotation:   "true",
+						v1.LoadBalancerIPAnnotation: ip,
+					},
+				}}
+				raw, _ := json.Marshal(vm)
+				return raw
+			}
+			request := &admissionv1.AdmissionRequest{
+				Operation: operation,
+				Namespace: "default",
+				UserInfo:  authenticationv1.UserInfo{Username: "tenant"},
+				Object:    runtime.RawExtension{Raw: newVM(newIP)},
+			}
+			if operation == admissionv1.Update {
+				request.OldObject = runtime.RawExtension{Raw: newVM(oldIP)}
+			}
+			return request
+		}
+
+		BeforeEach(func() {
+			requestedBy = ""
+			vmsAdmitter.serviceAuthFunc = func(namespace string, userInfo authenticationv1.UserInfo) (bool, string, error) {
+				Expect(namespace).To(Equal("default"))
+				requestedBy = userInfo.Username
+				return authorized, "forbidden", nil
+			}
+		})
+
+		table.DescribeTable("should require the user to be allowed to create services", func(request *admissionv1.AdmissionRequest, allowed bool, checked bool) {
+			authorized = allowed
+			vm := &v1.VirtualMachine{}
+			Expect(json.Unmarshal(request.Object.Raw, vm)).To(Succeed())
+
+			causes, err := vmsAdmitter.authorizeLoadBalancer(request, vm)
+			Expect(err).ToNot(HaveOccurred())
+			if checked {
+				Expect(requestedBy).To(Equal("tenant"))
+			} else {
+				Expect(requestedBy).To(BeEmpty())
+			}
+			if allowed || !checked {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Message).To(ContainSubstring("permission to create services"))
+			}
+		},
+			table.Entry("on create", newLoadBalancerRequest(admissionv1.Create, "", "192.0.2.1"), true, true),
+			table.Entry("and reject the creation otherwise", newLoadBalancerRequest(admissionv1.Create, "", "192.0.2.1"), false, true),
+			table.Entry("when the annotations change", newLoadBalancerRequest(admissionv1.Update, "192.0.2.1", "192.0.2.2"), false, true),
+			table.Entry("but not when the annotations did not change", newLoadBalancerRequest(admissionv1.Update, "192.0.2.1", "192.0.2.1"), false, false),
+		)
+	})
+
+	Context("with start groups", func() {
+		newVM := func(name string, group string, startAfter string) v1.VirtualMachine {
+			vm := v1.VirtualMachine{ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "default",
+				Labels:      map[string]string{},
+				Annotations: map[string]string{},
+			}}
+			if group != "" {
+				vm.Labels[v1.StartGroupLabel] = group
+			}
+			if startAfter != "" {
+				vm.Annotations[v1.StartAfterAnnotation] = startAfter
+			}
+			return vm
+		}
+
+		table.DescribeTable("should reject VMs which wait for themselves", func(vm v1.VirtualMachine, expectedCycle string, existing ...v1.VirtualMachine) {
+			vmsAdmitter.listVMsFunc = func(namespace string) ([]v1.VirtualMachine, error) {
+				Expect(namespace).To(Equal("default"))
+				return existing, nil
+			}
+
+			causes, err := vmsAdmitter.validateStartDependencies(&admissionv1.AdmissionRequest{Namespace: "default"}, &vm)
+			Expect(err).ToNot(HaveOccurred())
+			if expectedCycle == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("metadata.annotations[kubevirt.io/start-after]"))
+				Expect(causes[0].Message).To(ContainSubstring(expectedCycle))
+			}
+		},
+			table.Entry("through another VM", newVM("app", "app", "db"), "app -> db -> app",
+				newVM("db", "db", "app")),
+			table.Entry("through several VMs", newVM("app", "app", "db"), "app -> db -> cache -> app",
+				newVM("db", "db", "cache"), newVM("cache", "cache", "app")),
+			table.Entry("through a VM of its own group", newVM("app", "app", "app"), "app -> other -> app",
+				newVM("other", "app", "app")),
+			table.Entry("and accept dependencies without a cycle", newVM("app", "app", "db"), "",
+				newVM("db", "db", "cache"), newVM("cache", "cache", "")),
+			table.Entry("and accept a VM which is the only one of its group", newVM("app", "app", "app"), ""),
+			table.Entry("and accept the new version of an updated VM", newVM("app", "app", "db"), "",
+				newVM("app", "cache", ""), newVM("db", "db", "cache")),
+		)
+	})
+
+	Context("with a schedule", func() {
+		validateSchedule := func(schedule *v1.VirtualMachineSchedule) []metav1.StatusCause {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmSpec := &v1.VirtualMachineSpec{
+				Running:  &notRunning,
+				Schedule: schedule,
+				Template: &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: vmi.Spec,
+				},
+			}
+			return ValidateVirtualMachineSpec(k8sfield.NewPath("spec"), vmSpec, config, "fake-account")
+		}
+
+		It("should reject a schedule without the feature gate", func() {
+			causes := validateSchedule(&v1.VirtualMachineSchedule{Stop: "0 20 * * *"})
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("spec.schedule"))
+			Expect(causes[0].Message).To(ContainSubstring(virtconfig.VMScheduleGate))
+		})
+
+		Context("and the feature gate enabled", func() {
+			BeforeEach(func() {
+				enableFeatureGate(virtconfig.VMScheduleGate)
+			})
+
+			AfterEach(func() {
+				disableFeatureGates()
+			})
+
+			It("should accept a valid schedule", func() {
+				causes := validateSchedule(&v1.VirtualMachineSchedule{Start: "0 8 * * 1-5", Stop: "0 20 * * *", TimeZone: "Europe/Berlin"})
+				Expect(causes).To(BeEmpty())
+			})
+
+			table.DescribeTable("should reject", func(schedule *v1.VirtualMachineSchedule, field string) {
+				causes := validateSchedule(schedule)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(field))
+			},
+				table.Entry("a schedule without expressions", &v1.VirtualMachineSchedule{TimeZone: "UTC"}, "spec.schedule"),
+				table.Entry("an invalid start expression", &v1.VirtualMachineSchedule{Start: "0 25 * * *"}, "spec.schedule.start"),
+				table.Entry("an invalid stop expression", &v1.VirtualMachineSchedule{Stop: "0 20 * *"}, "spec.schedule.stop"),
+				table.Entry("an unknown time zone", &v1.VirtualMachineSchedule{Stop: "0 20 * * *", TimeZone: "Mars/Olympus"}, "spec.schedule.timeZone"),
+			)
+		})
+	})
+
 	It("should reject invalid DataVolumeTemplate with no Volume reference in VMI template", func() {
 		vmi := v1.NewMinimalVMI("testvmi")
 		vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
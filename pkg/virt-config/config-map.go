@@ -22,6 +22,7 @@ package virtconfig
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
@@ -273,6 +274,12 @@ func defaultClusterConfig(cpuArch string) *v1.KubeVirtConfiguration {
 				Burst: DefaultVirtWebhookClientBurst,
 			}}},
 		},
+		OperatorConfiguration: &v1.ReloadableComponentConfiguration{
+			RestClient: &v1.RESTClientConfiguration{RateLimiter: &v1.RateLimiter{TokenBucketRateLimiter: &v1.TokenBucketRateLimiter{
+				QPS:   DefaultVirtOperatorQPS,
+				Burst: DefaultVirtOperatorBurst,
+			}}},
+		},
 	}
 }
 
@@ -288,6 +295,8 @@ type ClusterConfig struct {
 	lastInvalidConfigResourceVersion string
 	lastValidConfigResourceVersion   string
 	configModifiedCallback           []ConfigModifiedFn
+	// deprecatedMachineTypes are the compiled DeprecatedMachineTypes of lastValidConfig
+	deprecatedMachineTypes []*regexp.Regexp
 }
 
 func (c *ClusterConfig) SetConfigModifiedCallback(cb ConfigModifiedFn) {
@@ -311,6 +320,7 @@ type migrationConfiguration struct {
 	UnsafeMigrationOverride           *bool              `json:"unsafeMigrationOverride,string,omitempty"`
 	AllowPostCopy                     *bool              `json:"allowPostCopy,string,omitempty"`
 	DisableTLS                        *bool              `json:"disableTLS,omitempty"`
+	ParallelMigrationThreads          *uint32            `json:"parallelMigrationThreads,omitempty"`
 }
 
 // setConfigFromConfigMap parses the provided config map and updates the provided config.
@@ -513,9 +523,56 @@ func setConfigFromKubeVirt(config *v1.KubeVirtConfiguration, kv *v1.KubeVirt) er
 		return err
 	}
 
+	if config.DeveloperConfiguration != nil && config.DeveloperConfiguration.MemoryOvercommit <= 0 {
+		return fmt.Errorf("invalid memoryOvercommit in KubeVirt CR: %d", config.DeveloperConfiguration.MemoryOvercommit)
+	}
+
+	if config.Swap != nil && config.Swap.Swappiness != nil && (*config.Swap.Swappiness < 0 || *config.Swap.Swappiness > 100) {
+		return fmt.Errorf("invalid swap.swappiness in KubeVirt CR: %d", *config.Swap.Swappiness)
+	}
+
+	if config.MigrationConfiguration != nil && config.MigrationConfiguration.ParallelMigrationThreads != nil {
+		if threads := *config.MigrationConfiguration.ParallelMigrationThreads; threads < 1 || threads > MaxParallelMigrationThreads {
+			return fmt.Errorf("invalid migrations.parallelMigrationThreads in KubeVirt CR: %d", threads)
+		}
+	}
+
+	if config.CrashLoopBackOff != nil {
+		if maxDelay := config.CrashLoopBackOff.MaxDelaySeconds; maxDelay != nil && *maxDelay < 1 {
+			return fmt.Errorf("invalid crashLoopBackOff.maxDelaySeconds in KubeVirt CR: %d", *maxDelay)
+		}
+		if resetAfter := config.CrashLoopBackOff.ResetAfterSeconds; resetAfter != nil && *resetAfter < 0 {
+			return fmt.Errorf("invalid crashLoopBackOff.resetAfterSeconds in KubeVirt CR: %d", *resetAfter)
+		}
+	}
+
+	if leaderElection := config.LeaderElection; leaderElection != nil {
+		if d := leaderElection.LeaseDuration; d != nil && d.Duration <= 0 {
+			return fmt.Errorf("invalid leaderElection.leaseDuration in KubeVirt CR: %s", d.Duration)
+		}
+		if d := leaderElection.RenewDeadline; d != nil && d.Duration <= 0 {
+			return fmt.Errorf("invalid leaderElection.renewDeadline in KubeVirt CR: %s", d.Duration)
+		}
+		if d := leaderElection.RetryPeriod; d != nil && d.Duration <= 0 {
+			return fmt.Errorf("invalid leaderElection.retryPeriod in KubeVirt CR: %s", d.Duration)
+		}
+	}
+
 	return nil
 }
 
+func compileDeprecatedMachineTypes(machineTypes []string) ([]*regexp.Regexp, error) {
+	var compiled []*regexp.Regexp
+	for _, machineType := range machineTypes {
+		re, err := regexp.Compile(machineType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid deprecatedMachineTypes: %v", err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
 // getCPUArchSpecificDefault get arch specific default config
 func getCPUArchSpecificDefault(cpuArch string) (string, string, []string) {
 	// get arch specific default config
@@ -526,6 +583,9 @@ func getCPUArchSpecificDefault(cpuArch string) (string, string, []string) {
 	case "ppc64le":
 		emulatedMachinesDefault := strings.Split(DefaultPPC64LEEmulatedMachines, ",")
 		return DefaultARCHOVMFPath, DefaultPPC64LEMachineType, emulatedMachinesDefault
+	case "s390x":
+		emulatedMachinesDefault := strings.Split(DefaultS390XEmulatedMachines, ",")
+		return DefaultARCHOVMFPath, DefaultS390XMachineType, emulatedMachinesDefault
 	default:
 		emulatedMachinesDefault := strings.Split(DefaultAMD64EmulatedMachines, ",")
 		return DefaultARCHOVMFPath, DefaultAMD64MachineType, emulatedMachinesDefault
@@ -579,6 +639,11 @@ func (c *ClusterConfig) GetConfig() (config *v1.KubeVirtConfiguration) {
 		err = setConfigFromKubeVirt(config, kv)
 	}
 
+	var deprecatedMachineTypes []*regexp.Regexp
+	if err == nil {
+		deprecatedMachineTypes, err = compileDeprecatedMachineTypes(config.DeprecatedMachineTypes)
+	}
+
 	if err != nil {
 		c.lastInvalidConfigResourceVersion = resourceVersion
 		log.DefaultLogger().Reason(err).Errorf("Invalid cluster config using '%s' resource version '%s', falling back to last good resource version '%s'", resourceType, resourceVersion, c.lastValidConfigResourceVersion)
@@ -588,6 +653,7 @@ func (c *ClusterConfig) GetConfig() (config *v1.KubeVirtConfiguration) {
 	log.DefaultLogger().Infof("Updating cluster config from %s to resource version '%s'", resourceType, resourceVersion)
 	c.lastValidConfigResourceVersion = resourceVersion
 	c.lastValidConfig = config
+	c.deprecatedMachineTypes = deprecatedMachineTypes
 	return c.lastValidConfig
 }
 
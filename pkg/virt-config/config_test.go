@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"strings"
 	"sync"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
@@ -132,6 +133,7 @@ var _ = Describe("ConfigMap", func() {
 		table.Entry("when unset, GetMachineType should return the default with amd64", "amd64", "", virtconfig.DefaultAMD64MachineType),
 		table.Entry("when unset, GetMachineType should return the default with arm64", "arm64", "", virtconfig.DefaultAARCH64MachineType),
 		table.Entry("when unset, GetMachineType should return the default with ppc64le", "ppc64le", "", virtconfig.DefaultPPC64LEMachineType),
+		table.Entry("when unset, GetMachineType should return the default with s390x", "s390x", "", virtconfig.DefaultS390XMachineType),
 	)
 
 	table.DescribeTable(" when cpuModel", func(value string, result string) {
@@ -165,6 +167,93 @@ var _ = Describe("ConfigMap", func() {
 		table.Entry("when unset, GetMemoryOvercommit should return the default", "", virtconfig.DefaultMemoryOvercommit),
 	)
 
+	It("should ignore a negative memoryOvercommit in the KubeVirt CR", func() {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			DeveloperConfiguration: &v1.DeveloperConfiguration{MemoryOvercommit: -50},
+		})
+		Expect(clusterConfig.GetMemoryOvercommit()).To(Equal(virtconfig.DefaultMemoryOvercommit))
+	})
+
+	table.DescribeTable("when deprecatedMachineTypes is set in the KubeVirt CR", func(deprecated []string, machineType string, isDeprecated bool) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			DeprecatedMachineTypes: deprecated,
+		})
+		Expect(clusterConfig.IsMachineTypeDeprecated(machineType)).To(Equal(isDeprecated))
+	},
+		table.Entry("should match a deprecated machine type", []string{"pc-q35-rhel7.*"}, "pc-q35-rhel7.6.0", true),
+		table.Entry("should not match other machine types", []string{"pc-q35-rhel7.*"}, "pc-q35-rhel8.4.0", false),
+		table.Entry("should ignore invalid patterns", []string{"pc-q35-rhel7.*", "("}, "pc-q35-rhel7.6.0", false),
+	)
+
+	table.DescribeTable("when the swappiness is set in the KubeVirt CR", func(swappiness int64, accepted bool) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			Swap: &v1.SwapConfiguration{Swappiness: &swappiness},
+		})
+		if accepted {
+			Expect(*clusterConfig.GetSwapConfiguration().Swappiness).To(Equal(swappiness))
+		} else {
+			Expect(clusterConfig.GetSwapConfiguration()).To(BeNil())
+		}
+	},
+		table.Entry("should accept a value between 0 and 100", int64(60), true),
+		table.Entry("should ignore a negative value", int64(-1), false),
+		table.Entry("should ignore a value above 100", int64(101), false),
+	)
+
+	table.DescribeTable("when the leader election is set in the KubeVirt CR", func(leaderElection *v1.LeaderElectionConfiguration, accepted bool) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			LeaderElection: leaderElection,
+		})
+		if accepted {
+			Expect(clusterConfig.GetConfig().LeaderElection).To(Equal(leaderElection))
+		} else {
+			Expect(clusterConfig.GetConfig().LeaderElection).To(BeNil())
+		}
+	},
+		table.Entry("should accept positive durations", &v1.LeaderElectionConfiguration{
+			LeaseDuration: &metav1.Duration{Duration: 30 * time.Second},
+			RenewDeadline: &metav1.Duration{Duration: 20 * time.Second},
+		}, true),
+		table.Entry("should ignore a zero lease duration", &v1.LeaderElectionConfiguration{
+			LeaseDuration: &metav1.Duration{},
+		}, false),
+		table.Entry("should ignore a negative retry period", &v1.LeaderElectionConfiguration{
+			RetryPeriod: &metav1.Duration{Duration: -time.Second},
+		}, false),
+	)
+
+	table.DescribeTable("when the crash loop backoff is set in the KubeVirt CR", func(maxDelaySeconds, resetAfterSeconds int64, expectedMaxDelay int, expectedResetAfter time.Duration) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			CrashLoopBackOff: &v1.CrashLoopBackOffConfiguration{
+				MaxDelaySeconds:   &maxDelaySeconds,
+				ResetAfterSeconds: &resetAfterSeconds,
+			},
+		})
+		Expect(clusterConfig.GetCrashLoopBackOffMaxDelaySeconds()).To(Equal(expectedMaxDelay))
+		Expect(clusterConfig.GetCrashLoopBackOffResetAfter()).To(Equal(expectedResetAfter))
+	},
+		table.Entry("should accept valid values", int64(60), int64(0), 60, time.Duration(0)),
+		table.Entry("should ignore a max delay below one second", int64(0), int64(60),
+			virtconfig.DefaultCrashLoopBackOffMaxDelaySeconds, virtconfig.DefaultCrashLoopBackOffResetAfterSeconds*time.Second),
+		table.Entry("should ignore a negative reset period", int64(60), int64(-1),
+			virtconfig.DefaultCrashLoopBackOffMaxDelaySeconds, virtconfig.DefaultCrashLoopBackOffResetAfterSeconds*time.Second),
+	)
+
+	table.DescribeTable("when the parallel migration threads are set in the KubeVirt CR", func(threads uint32, accepted bool) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			MigrationConfiguration: &v1.MigrationConfiguration{ParallelMigrationThreads: &threads},
+		})
+		if accepted {
+			Expect(*clusterConfig.GetMigrationConfiguration().ParallelMigrationThreads).To(Equal(threads))
+		} else {
+			Expect(clusterConfig.GetMigrationConfiguration().ParallelMigrationThreads).To(BeNil())
+		}
+	},
+		table.Entry("should accept a positive thread count", uint32(8), true),
+		table.Entry("should ignore zero threads", uint32(0), false),
+		table.Entry("should ignore more than 255 threads", uint32(256), false),
+	)
+
 	table.DescribeTable(" when emulatedMachines", func(cpuArch string, emuMachinesKey string, result []string) {
 		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigWithCPUArch(&kubev1.ConfigMap{
 			Data: map[string]string{
@@ -178,6 +267,7 @@ var _ = Describe("ConfigMap", func() {
 		table.Entry("when unset, GetEmulatedMachines should return the defaults with amd64", "amd64", "", strings.Split(virtconfig.DefaultAMD64EmulatedMachines, ",")),
 		table.Entry("when unset, GetEmulatedMachines should return the defaults with arm64", "arm64", "", strings.Split(virtconfig.DefaultAARCH64EmulatedMachines, ",")),
 		table.Entry("when unset, GetEmulatedMachines should return the defaults with ppc64le", "ppc64le", "", strings.Split(virtconfig.DefaultPPC64LEEmulatedMachines, ",")),
+		table.Entry("when unset, GetEmulatedMachines should return the defaults with s390x", "s390x", "", strings.Split(virtconfig.DefaultS390XEmulatedMachines, ",")),
 	)
 
 	table.DescribeTable(" when supportedGuestAgentVersions", func(value string, result []string) {
@@ -386,6 +476,19 @@ var _ = Describe("ConfigMap", func() {
 		}).Should(BeTrue())
 	})
 
+	It("should return the virt-operator verbosity with the node overrides", func() {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			DeveloperConfiguration: &v1.DeveloperConfiguration{
+				LogVerbosity: &v1.LogVerbosity{
+					VirtOperator:  4,
+					NodeVerbosity: map[string]uint{"node01": 6},
+				},
+			},
+		})
+		Expect(clusterConfig.GetVirtOperatorVerbosity("node02")).To(Equal(uint(4)))
+		Expect(clusterConfig.GetVirtOperatorVerbosity("node01")).To(Equal(uint(6)))
+	})
+
 	It("Should still get GetPermittedHostDevices after invalid update", func() {
 		expectedDevices := `{"pciHostDevices":[{"pciVendorSelector":"10DE:1EB8","resourceName":"nvidia.com/TU104GL_Tesla_T4"}],"mediatedDevices":[{"mdevNameSelector":"GRID T4-1Q","resourceName":"nvidia.com/GRID_T4-1Q"}]}`
 		invalidPermittedHostDevicesConfig := "something wrong"
@@ -531,4 +634,89 @@ var _ = Describe("ConfigMap", func() {
 		table.Entry("LiveMigration is open, SRIOVLiveMigration should be close",
 			virtconfig.LiveMigrationGate, true, false),
 	)
+
+	table.DescribeTable("when mediated devices configuration", func(nodeLabels map[string]string, expected []string) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			MediatedDevicesConfiguration: &v1.MediatedDevicesConfiguration{
+				MediatedDevicesTypes: []string{"nvidia-222"},
+				NodeMediatedDeviceTypes: []v1.NodeMediatedDeviceTypesConfig{
+					{
+						NodeSelector:         map[string]string{"gpu": "t4"},
+						MediatedDevicesTypes: []string{"nvidia-231", "nvidia-223"},
+					},
+					{
+						NodeSelector:         map[string]string{"zone": "a"},
+						MediatedDevicesTypes: []string{"nvidia-223", "i915-GVTg_V5_4"},
+					},
+				},
+			},
+		})
+		node := &kubev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node01", Labels: nodeLabels}}
+
+		Expect(clusterConfig.GetDesiredMDEVTypes(node)).To(Equal(expected))
+	},
+		table.Entry("matches no node selector, GetDesiredMDEVTypes should return the cluster wide types",
+			map[string]string{"gpu": "a100"}, []string{"nvidia-222"}),
+		table.Entry("matches one node selector, GetDesiredMDEVTypes should return its types",
+			map[string]string{"gpu": "t4"}, []string{"nvidia-231", "nvidia-223"}),
+		table.Entry("matches multiple node selectors, GetDesiredMDEVTypes should return the union of their types",
+			map[string]string{"gpu": "t4", "zone": "a"}, []string{"nvidia-231", "nvidia-223", "i915-GVTg_V5_4"}),
+	)
+
+	table.DescribeTable("when filesystem overhead configuration", func(overhead *v1.FilesystemOverhead, storageClass *string, expected string) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			FilesystemOverhead: overhead,
+		})
+
+		Expect(clusterConfig.GetFilesystemOverhead(storageClass)).To(Equal(expected))
+	},
+		table.Entry("is unset, GetFilesystemOverhead should return no overhead",
+			nil, pointer.StringPtr("fast"), ""),
+		table.Entry("has no global value, GetFilesystemOverhead should return no overhead",
+			&v1.FilesystemOverhead{StorageClass: map[string]string{"slow": "0.1"}}, pointer.StringPtr("fast"), ""),
+		table.Entry("has a global value, GetFilesystemOverhead should return it",
+			&v1.FilesystemOverhead{Global: "0.08", StorageClass: map[string]string{"slow": "0.1"}}, nil, "0.08"),
+		table.Entry("matches the storage class, GetFilesystemOverhead should return its value",
+			&v1.FilesystemOverhead{Global: "0.08", StorageClass: map[string]string{"fast": "0"}}, pointer.StringPtr("fast"), "0"),
+	)
+
+	table.DescribeTable("when disk configuration", func(diskConfig *v1.DiskConfiguration, storageClass *string, expected v1.DriverCache) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			DiskConfiguration: diskConfig,
+		})
+
+		Expect(clusterConfig.GetDiskCacheMode(storageClass)).To(Equal(expected))
+	},
+		table.Entry("is unset, GetDiskCacheMode should return no cache mode",
+			nil, pointer.StringPtr("fast"), v1.DriverCache("")),
+		table.Entry("has no storage class, GetDiskCacheMode should return no cache mode",
+			&v1.DiskConfiguration{StorageClassCacheModes: map[string]v1.DriverCache{"fast": v1.CacheWriteBack}}, nil, v1.DriverCache("")),
+		table.Entry("matches the storage class, GetDiskCacheMode should return its cache mode",
+			&v1.DiskConfiguration{StorageClassCacheModes: map[string]v1.DriverCache{"fast": v1.CacheWriteBack}}, pointer.StringPtr("fast"), v1.CacheWriteBack),
+	)
+
+	table.DescribeTable("when guest exec allow list", func(command string, args []string, expected bool) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			DeveloperConfiguration: &v1.DeveloperConfiguration{FeatureGates: []string{virtconfig.GuestExecGate}},
+			GuestExecAllowList: []v1.GuestExecCommand{
+				{Path: "/usr/bin/uptime"},
+				{Path: "/usr/bin/df", Args: []string{"-h", "/[a-z/]*"}},
+			},
+		})
+
+		Expect(clusterConfig.IsGuestExecCommandAllowed(command, args)).To(Equal(expected))
+	},
+		table.Entry("holds the command without args, IsGuestExecCommandAllowed should allow it without args",
+			"/usr/bin/uptime", nil, true),
+		table.Entry("holds the command without args, IsGuestExecCommandAllowed should reject args",
+			"/usr/bin/uptime", []string{"-p"}, false),
+		table.Entry("holds the command with matching args, IsGuestExecCommandAllowed should allow it",
+			"/usr/bin/df", []string{"-h", "/var/log"}, true),
+		table.Entry("holds the command with args, IsGuestExecCommandAllowed should reject partially matching args",
+			"/usr/bin/df", []string{"-h", "/var; rm -rf /"}, false),
+		table.Entry("holds the command with args, IsGuestExecCommandAllowed should reject additional args",
+			"/usr/bin/df", []string{"-h", "/var", "/tmp"}, false),
+		table.Entry("does not hold the command, IsGuestExecCommandAllowed should reject it",
+			"/bin/sh", []string{"-c", "uptime"}, false),
+	)
 })
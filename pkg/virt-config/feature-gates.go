@@ -43,6 +43,39 @@ const (
 	DownwardMetricsFeatureGate = "DownwardMetrics"
 	NonRoot                    = "NonRootExperimental"
 	ClusterProfiler            = "ClusterProfiler"
+	// ContainerDiskCacheGate enables a node-level read-only cache, shared by all VMIs which boot from
+	// containerDisks with the same content.
+	ContainerDiskCacheGate = "ContainerDiskCache"
+	VhostUserGate          = "VhostUser"
+	// ConsoleRecordingGate routes serial console and VNC sessions through a recording proxy in virt-api.
+	ConsoleRecordingGate = "ConsoleRecording"
+	// QEMUArgsGate allows VMIs to append the QEMU arguments of the allow list in the KubeVirt CR
+	// to the QEMU command line.
+	QEMUArgsGate = "QEMUArgs"
+	// VMSwapGate lets VMIs with Burstable memory use the swap of the node.
+	VMSwapGate = "VMSwap"
+	// VMPoolGate allows to create VirtualMachinePools, which stamp out numbered VMs from a template.
+	VMPoolGate = "VMPool"
+	// VMScheduleGate allows VMs to be started and stopped at the times of cron expressions.
+	VMScheduleGate = "VMSchedule"
+	// VMLoadBalancerGate lets virt-controller manage Services of type LoadBalancer for annotated VMs.
+	VMLoadBalancerGate = "VMLoadBalancer"
+	// PersistentReservationGate allows LUNs to pass SCSI-3 persistent reservations through qemu-pr-helper.
+	PersistentReservationGate = "PersistentReservation"
+	// ExpandDisksGate grows the disks of running VMIs when the capacity of their PVCs increases.
+	ExpandDisksGate = "ExpandDisks"
+	// VSOCKGate allows VMIs to autoattach a VSOCK device, which virt-handler can proxy connections to.
+	VSOCKGate = "VSOCK"
+	// GuestExecGate allows running the commands of the allow list in the KubeVirt CR in guests through the guest agent.
+	GuestExecGate = "GuestExec"
+	// CommonTemplatesGate lets virt-operator deploy VirtualMachine templates for common guest OSes.
+	CommonTemplatesGate = "CommonTemplates"
+	// CRDConversionWebhookGate lets virt-operator switch the CRDs with several versions to the conversion webhook of virt-api.
+	CRDConversionWebhookGate = "CRDConversionWebhook"
+	// NVMeDiskBusGate allows disks on an emulated NVMe controller, on the nodes whose QEMU supports it.
+	NVMeDiskBusGate = "NVMeDiskBus"
+	// CRDValidationRulesGate lets virt-operator add CEL validation rules to the VirtualMachineInstance specs in the CRDs.
+	CRDValidationRulesGate = "CRDValidationRules"
 )
 
 func (c *ClusterConfig) isFeatureGateEnabled(featureGate string) bool {
@@ -126,3 +159,67 @@ func (config *ClusterConfig) NonRootEnabled() bool {
 func (config *ClusterConfig) ClusterProfilerEnabled() bool {
 	return config.isFeatureGateEnabled(ClusterProfiler)
 }
+
+func (config *ClusterConfig) ContainerDiskCacheEnabled() bool {
+	return config.isFeatureGateEnabled(ContainerDiskCacheGate)
+}
+
+func (config *ClusterConfig) VhostUserEnabled() bool {
+	return config.isFeatureGateEnabled(VhostUserGate)
+}
+
+func (config *ClusterConfig) ConsoleRecordingEnabled() bool {
+	return config.isFeatureGateEnabled(ConsoleRecordingGate)
+}
+
+func (config *ClusterConfig) QEMUArgsEnabled() bool {
+	return config.isFeatureGateEnabled(QEMUArgsGate)
+}
+
+func (config *ClusterConfig) VMSwapEnabled() bool {
+	return config.isFeatureGateEnabled(VMSwapGate)
+}
+
+func (config *ClusterConfig) VMPoolEnabled() bool {
+	return config.isFeatureGateEnabled(VMPoolGate)
+}
+
+func (config *ClusterConfig) VMScheduleEnabled() bool {
+	return config.isFeatureGateEnabled(VMScheduleGate)
+}
+
+func (config *ClusterConfig) VMLoadBalancerEnabled() bool {
+	return config.isFeatureGateEnabled(VMLoadBalancerGate)
+}
+
+func (config *ClusterConfig) PersistentReservationEnabled() bool {
+	return config.isFeatureGateEnabled(PersistentReservationGate)
+}
+
+func (config *ClusterConfig) ExpandDisksEnabled() bool {
+	return config.isFeatureGateEnabled(ExpandDisksGate)
+}
+
+func (config *ClusterConfig) VSOCKEnabled() bool {
+	return config.isFeatureGateEnabled(VSOCKGate)
+}
+
+func (config *ClusterConfig) GuestExecEnabled() bool {
+	return config.isFeatureGateEnabled(GuestExecGate)
+}
+
+func (config *ClusterConfig) CommonTemplatesEnabled() bool {
+	return config.isFeatureGateEnabled(CommonTemplatesGate)
+}
+
+func (config *ClusterConfig) CRDConversionWebhookEnabled() bool {
+	return config.isFeatureGateEnabled(CRDConversionWebhookGate)
+}
+
+func (config *ClusterConfig) NVMeDiskBusEnabled() bool {
+	return config.isFeatureGateEnabled(NVMeDiskBusGate)
+}
+
+func (config *ClusterConfig) CRDValidationRulesEnabled() bool {
+	return config.isFeatureGateEnabled(CRDValidationRulesGate)
+}
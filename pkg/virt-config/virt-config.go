@@ -24,8 +24,12 @@ package virtconfig
 */
 
 import (
+	"regexp"
+	"time"
+
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
 
 	v1 "kubevirt.io/client-go/api/v1"
 )
@@ -36,16 +40,19 @@ const (
 	BandwithPerMigrationDefault                     = "0Mi"
 	MigrationAllowAutoConverge               bool   = false
 	MigrationAllowPostCopy                   bool   = false
+	MaxParallelMigrationThreads              uint32 = 255
 	MigrationProgressTimeout                 int64  = 150
 	MigrationCompletionTimeoutPerGiB         int64  = 800
 	DefaultAMD64MachineType                         = "q35"
 	DefaultPPC64LEMachineType                       = "pseries"
 	DefaultAARCH64MachineType                       = "virt"
+	DefaultS390XMachineType                         = "s390-ccw-virtio"
 	DefaultCPURequest                               = "100m"
 	DefaultMemoryOvercommit                         = 100
 	DefaultAMD64EmulatedMachines                    = "q35*,pc-q35*"
 	DefaultPPC64LEEmulatedMachines                  = "pseries*"
 	DefaultAARCH64EmulatedMachines                  = "virt*"
+	DefaultS390XEmulatedMachines                    = "s390-ccw-virtio*"
 	DefaultLessPVCSpaceToleration                   = 10
 	DefaultMinimumReservePVCBytes                   = 131072
 	DefaultNodeSelectors                            = ""
@@ -70,6 +77,8 @@ const (
 	DefaultVirtHandlerLogVerbosity                  = 2
 	DefaultVirtLauncherLogVerbosity                 = 2
 	DefaultVirtOperatorLogVerbosity                 = 2
+	DefaultCrashLoopBackOffMaxDelaySeconds          = 300
+	DefaultCrashLoopBackOffResetAfterSeconds        = 600
 
 	// Default REST configuration settings
 	DefaultVirtHandlerQPS         float32 = 5
@@ -80,6 +89,8 @@ const (
 	DefaultVirtAPIBurst                   = 10
 	DefaultVirtWebhookClientQPS           = 200
 	DefaultVirtWebhookClientBurst         = 400
+	DefaultVirtOperatorQPS        float32 = 5
+	DefaultVirtOperatorBurst              = 10
 )
 
 func IsAMD64(arch string) bool {
@@ -103,6 +114,13 @@ func IsPPC64(arch string) bool {
 	return false
 }
 
+func IsS390X(arch string) bool {
+	if arch == "s390x" {
+		return true
+	}
+	return false
+}
+
 func (c *ClusterConfig) GetMemBalloonStatsPeriod() uint32 {
 	return *c.GetConfig().MemBalloonStatsPeriod
 }
@@ -129,6 +147,20 @@ func (c *ClusterConfig) GetMachineType() string {
 	return c.GetConfig().MachineType
 }
 
+// IsMachineTypeDeprecated returns true if the machine type matches one of the deprecated machine types
+func (c *ClusterConfig) IsMachineTypeDeprecated(machineType string) bool {
+	// picks up a changed config, which compiles its deprecated machine types
+	c.GetConfig()
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, deprecated := range c.deprecatedMachineTypes {
+		if deprecated.MatchString(machineType) {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *ClusterConfig) GetCPUModel() string {
 	return c.GetConfig().CPUModel
 }
@@ -165,6 +197,10 @@ func (c *ClusterConfig) GetDefaultNetworkInterface() string {
 	return c.GetConfig().NetworkConfiguration.NetworkInterface
 }
 
+func (c *ClusterConfig) GetMacAddressPool() *v1.MacAddressPool {
+	return c.GetConfig().NetworkConfiguration.MacAddressPool
+}
+
 func (c *ClusterConfig) IsSlirpInterfaceEnabled() bool {
 	return *c.GetConfig().NetworkConfiguration.PermitSlirpInterface
 }
@@ -209,12 +245,153 @@ func (c *ClusterConfig) GetPermittedHostDevices() *v1.PermittedHostDevices {
 	return c.GetConfig().PermittedHostDevices
 }
 
-func (c *ClusterConfig) GetDesiredMDEVTypes(nodeName string) []string {
+func (c *ClusterConfig) GetConsoleRecordingConfiguration() *v1.ConsoleRecordingConfiguration {
+	return c.GetConfig().ConsoleRecording
+}
+
+func (c *ClusterConfig) GetAuditLogConfiguration() *v1.AuditLogConfiguration {
+	return c.GetConfig().AuditLog
+}
+
+func (c *ClusterConfig) GetSwapConfiguration() *v1.SwapConfiguration {
+	return c.GetConfig().Swap
+}
+
+// GetCrashLoopBackOffMaxDelaySeconds returns the maximum delay before a failed VMI is started again
+func (c *ClusterConfig) GetCrashLoopBackOffMaxDelaySeconds() int {
+	if config := c.GetConfig().CrashLoopBackOff; config != nil && config.MaxDelaySeconds != nil {
+		return int(*config.MaxDelaySeconds)
+	}
+	return DefaultCrashLoopBackOffMaxDelaySeconds
+}
+
+// GetCrashLoopBackOffResetAfter returns how long a VMI has to run until its failure no longer counts as a crash loop
+func (c *ClusterConfig) GetCrashLoopBackOffResetAfter() time.Duration {
+	if config := c.GetConfig().CrashLoopBackOff; config != nil && config.ResetAfterSeconds != nil {
+		return time.Duration(*config.ResetAfterSeconds) * time.Second
+	}
+	return DefaultCrashLoopBackOffResetAfterSeconds * time.Second
+}
+
+// GetFilesystemOverhead returns the fraction of a filesystem PVC of the given storage class reserved for the filesystem,
+// or an empty string if none is configured
+func (c *ClusterConfig) GetFilesystemOverhead(storageClass *string) string {
+	overhead := c.GetConfig().FilesystemOverhead
+	if overhead == nil {
+		return ""
+	}
+	if storageClass != nil {
+		if value, ok := overhead.StorageClass[*storageClass]; ok {
+			return value
+		}
+	}
+	return overhead.Global
+}
+
+// GetDiskCacheMode returns the cache mode of disks on PVCs of the given storage class which don't set one
+func (c *ClusterConfig) GetDiskCacheMode(storageClass *string) v1.DriverCache {
+	diskConfig := c.GetConfig().DiskConfiguration
+	if diskConfig == nil || storageClass == nil {
+		return ""
+	}
+	return diskConfig.StorageClassCacheModes[*storageClass]
+}
+
+// GetContainerDiskEphemeralStorageLimit returns the amount of data a VMI can write to each of its containerDisks,
+// or nil if it is unlimited
+func (c *ClusterConfig) GetContainerDiskEphemeralStorageLimit() *resource.Quantity {
+	if diskConfig := c.GetConfig().DiskConfiguration; diskConfig != nil {
+		return diskConfig.ContainerDiskEphemeralStorageLimit
+	}
+	return nil
+}
+
+// GetDefaultDiskBus returns the bus of the disks which don't set one, or an empty string
+// if the API default applies. The nvme bus is only returned while the NVMeDiskBus feature
+// gate is enabled.
+func (c *ClusterConfig) GetDefaultDiskBus() string {
+	diskConfig := c.GetConfig().DiskConfiguration
+	if diskConfig == nil || (diskConfig.DefaultBus == "nvme" && !c.NVMeDiskBusEnabled()) {
+		return ""
+	}
+	return diskConfig.DefaultBus
+}
+
+// GetVirtioWinContainerDiskImage returns the containerDisk image with the virtio-win drivers,
+// or an empty string if none is configured
+func (c *ClusterConfig) GetVirtioWinContainerDiskImage() string {
+	if diskConfig := c.GetConfig().DiskConfiguration; diskConfig != nil {
+		return diskConfig.VirtioWinContainerDiskImage
+	}
+	return ""
+}
+
+// IsQEMUArgAllowed returns true if VMIs may pass the QEMU argument with the given name
+func (c *ClusterConfig) IsQEMUArgAllowed(name string) bool {
+	if !c.QEMUArgsEnabled() {
+		return false
+	}
+	for _, allowed := range c.GetConfig().QEMUArgsAllowList {
+		if allowed == name {
+			return true
+		}
+	}
+	return false
+}
+
+// IsGuestExecCommandAllowed returns true if the command with the given path may be run in guests
+// with the given arguments
+func (c *ClusterConfig) IsGuestExecCommandAllowed(command string, args []string) bool {
+	if !c.GuestExecEnabled() {
+		return false
+	}
+	for _, allowed := range c.GetConfig().GuestExecAllowList {
+		if allowed.Path == command && guestExecArgsMatch(allowed.Args, args) {
+			return true
+		}
+	}
+	return false
+}
+
+func guestExecArgsMatch(patterns []string, args []string) bool {
+	if len(patterns) != len(args) {
+		return false
+	}
+	for i, pattern := range patterns {
+		// the whole argument has to match, not only a part of it
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil || !re.MatchString(args[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetDesiredMDEVTypes returns the mdev types of all node specific configurations matching the
+// node, in the order in which they are configured, or the cluster wide mdev types if none of
+// them matches.
+func (c *ClusterConfig) GetDesiredMDEVTypes(node *k8sv1.Node) []string {
 	mdevTypesConf := c.GetConfig().MediatedDevicesConfiguration
 	if mdevTypesConf == nil {
 		return []string{}
 	}
-	return mdevTypesConf.MediatedDevicesTypes
+
+	mdevTypes := []string{}
+	mdevTypesMap := map[string]struct{}{}
+	for _, nodeConfig := range mdevTypesConf.NodeMediatedDeviceTypes {
+		if labels.SelectorFromSet(nodeConfig.NodeSelector).Matches(labels.Set(node.Labels)) {
+			for _, mdevType := range nodeConfig.MediatedDevicesTypes {
+				if _, exists := mdevTypesMap[mdevType]; !exists {
+					mdevTypesMap[mdevType] = struct{}{}
+					mdevTypes = append(mdevTypes, mdevType)
+				}
+			}
+		}
+	}
+	if len(mdevTypes) == 0 {
+		return mdevTypesConf.MediatedDevicesTypes
+	}
+	return mdevTypes
 }
 
 func (c *ClusterConfig) GetVirtHandlerVerbosity(nodeName string) uint {
@@ -241,6 +418,20 @@ func (c *ClusterConfig) GetVirtControllerVerbosity(nodeName string) uint {
 	return logConf.VirtController
 }
 
+func (c *ClusterConfig) GetVirtOperatorVerbosity(nodeName string) uint {
+	logConf := c.GetConfig().DeveloperConfiguration.LogVerbosity
+	if level := logConf.NodeVerbosity[nodeName]; level != 0 {
+		return level
+	}
+	return logConf.VirtOperator
+}
+
+// LibraryLogsJSONEnabled returns true if the lines of the libraries used by the components are
+// written as JSON
+func (c *ClusterConfig) LibraryLogsJSONEnabled() bool {
+	return c.GetConfig().DeveloperConfiguration.LogFormat == v1.LogFormatJSON
+}
+
 func (c *ClusterConfig) GetVirtLauncherVerbosity() uint {
 	logConf := c.GetConfig().DeveloperConfiguration.LogVerbosity
 	return logConf.VirtLauncher
@@ -20,11 +20,15 @@
 package leaderelectionconfig
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/spf13/pflag"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/leaderelection"
 	rl "k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	v1 "kubevirt.io/client-go/api/v1"
 )
 
 const (
@@ -43,6 +47,43 @@ func DefaultLeaderElectionConfiguration() Configuration {
 	}
 }
 
+// Apply overrides the durations which are set in the KubeVirt CR. The configuration is not
+// changed if the result would be rejected by the leader elector.
+func (l *Configuration) Apply(config *v1.LeaderElectionConfiguration) error {
+	if config == nil {
+		return nil
+	}
+	applied := *l
+	if config.LeaseDuration != nil {
+		applied.LeaseDuration = *config.LeaseDuration
+	}
+	if config.RenewDeadline != nil {
+		applied.RenewDeadline = *config.RenewDeadline
+	}
+	if config.RetryPeriod != nil {
+		applied.RetryPeriod = *config.RetryPeriod
+	}
+	if err := applied.Validate(); err != nil {
+		return err
+	}
+	*l = applied
+	return nil
+}
+
+// Validate applies the same rules as the leader elector of client-go
+func (l *Configuration) Validate() error {
+	if l.LeaseDuration.Duration <= l.RenewDeadline.Duration {
+		return fmt.Errorf("leaseDuration %s must be greater than renewDeadline %s", l.LeaseDuration.Duration, l.RenewDeadline.Duration)
+	}
+	if l.RenewDeadline.Duration <= time.Duration(leaderelection.JitterFactor*float64(l.RetryPeriod.Duration)) {
+		return fmt.Errorf("renewDeadline %s must be greater than retryPeriod %s*%.1f", l.RenewDeadline.Duration, l.RetryPeriod.Duration, leaderelection.JitterFactor)
+	}
+	if l.RetryPeriod.Duration <= 0 {
+		return fmt.Errorf("retryPeriod %s must be greater than zero", l.RetryPeriod.Duration)
+	}
+	return nil
+}
+
 // BindFlags binds the common LeaderElectionCLIConfig flags
 func BindFlags(l *Configuration) {
 	pflag.DurationVar(&l.LeaseDuration.Duration, "leader-elect-lease-duration", l.LeaseDuration.Duration, ""+
@@ -0,0 +1,69 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package leaderelectionconfig_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+
+	"kubevirt.io/kubevirt/pkg/virt-controller/leaderelectionconfig"
+)
+
+var _ = Describe("Leader election configuration", func() {
+
+	It("should keep the defaults without a configuration in the KubeVirt CR", func() {
+		config := leaderelectionconfig.DefaultLeaderElectionConfiguration()
+		Expect(config.Apply(nil)).To(Succeed())
+		Expect(config).To(Equal(leaderelectionconfig.DefaultLeaderElectionConfiguration()))
+	})
+
+	It("should override the durations which are set in the KubeVirt CR", func() {
+		config := leaderelectionconfig.DefaultLeaderElectionConfiguration()
+		Expect(config.Apply(&v1.LeaderElectionConfiguration{
+			LeaseDuration: &metav1.Duration{Duration: 60 * time.Second},
+			RenewDeadline: &metav1.Duration{Duration: 40 * time.Second},
+		})).To(Succeed())
+
+		Expect(config.LeaseDuration.Duration).To(Equal(60 * time.Second))
+		Expect(config.RenewDeadline.Duration).To(Equal(40 * time.Second))
+		Expect(config.RetryPeriod.Duration).To(Equal(leaderelectionconfig.DefaultRetryPeriod))
+	})
+
+	It("should reject a renew deadline which is not less than the lease duration", func() {
+		config := leaderelectionconfig.DefaultLeaderElectionConfiguration()
+		Expect(config.Apply(&v1.LeaderElectionConfiguration{
+			RenewDeadline: &metav1.Duration{Duration: leaderelectionconfig.DefaultLeaseDuration},
+		})).ToNot(Succeed())
+		Expect(config).To(Equal(leaderelectionconfig.DefaultLeaderElectionConfiguration()))
+	})
+
+	It("should reject a retry period which is too close to the renew deadline", func() {
+		config := leaderelectionconfig.DefaultLeaderElectionConfiguration()
+		Expect(config.Apply(&v1.LeaderElectionConfiguration{
+			RetryPeriod: &metav1.Duration{Duration: 9 * time.Second},
+		})).ToNot(Succeed())
+		Expect(config).To(Equal(leaderelectionconfig.DefaultLeaderElectionConfiguration()))
+	})
+})
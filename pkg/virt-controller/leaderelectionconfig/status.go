@@ -0,0 +1,61 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package leaderelectionconfig
+
+import (
+	"encoding/json"
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/leaderelection"
+)
+
+// StatusPath is served next to the metrics of virt-controller and virt-operator
+const StatusPath = "/leader-election"
+
+// Status is the view of one instance on the leader election
+type Status struct {
+	// Identity is the identity of this instance
+	Identity string `json:"identity"`
+	// Leader is the identity of the leader, as last observed by this instance
+	Leader string `json:"leader"`
+	// IsLeader is true if this instance holds the lead
+	IsLeader      bool            `json:"isLeader"`
+	LeaseDuration metav1.Duration `json:"leaseDuration"`
+	RenewDeadline metav1.Duration `json:"renewDeadline"`
+	RetryPeriod   metav1.Duration `json:"retryPeriod"`
+}
+
+// NewStatusHandler returns a handler which reports the status of the leader election as JSON.
+// Instances which report different leaders point to a split brain.
+func NewStatusHandler(identity string, config Configuration, elector *leaderelection.LeaderElector) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		status := Status{
+			Identity:      identity,
+			Leader:        elector.GetLeader(),
+			IsLeader:      elector.IsLeader(),
+			LeaseDuration: config.LeaseDuration,
+			RenewDeadline: config.RenewDeadline,
+			RetryPeriod:   config.RetryPeriod,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(status)
+	})
+}
@@ -0,0 +1,112 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package leaderelectionconfig_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"kubevirt.io/kubevirt/pkg/virt-controller/leaderelectionconfig"
+)
+
+// heldLock is a lock which is held by another instance
+type heldLock struct {
+	identity string
+	holder   string
+}
+
+func (l *heldLock) Get(_ context.Context) (*resourcelock.LeaderElectionRecord, []byte, error) {
+	record := &resourcelock.LeaderElectionRecord{
+		HolderIdentity:       l.holder,
+		LeaseDurationSeconds: 15,
+		AcquireTime:          metav1.Now(),
+		RenewTime:            metav1.Now(),
+	}
+	raw, err := json.Marshal(record)
+	return record, raw, err
+}
+
+func (l *heldLock) Create(_ context.Context, _ resourcelock.LeaderElectionRecord) error {
+	return nil
+}
+
+func (l *heldLock) Update(_ context.Context, _ resourcelock.LeaderElectionRecord) error {
+	return nil
+}
+
+func (l *heldLock) RecordEvent(string) {}
+
+func (l *heldLock) Identity() string {
+	return l.identity
+}
+
+func (l *heldLock) Describe() string {
+	return "kubevirt/virt-controller"
+}
+
+var _ = Describe("Leader election status", func() {
+
+	It("should report the leader observed by a candidate", func() {
+		config := leaderelectionconfig.DefaultLeaderElectionConfiguration()
+		config.RetryPeriod = metav1.Duration{Duration: 10 * time.Millisecond}
+		elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+			Lock:          &heldLock{identity: "virt-controller-a", holder: "virt-controller-b"},
+			LeaseDuration: config.LeaseDuration.Duration,
+			RenewDeadline: config.RenewDeadline.Duration,
+			RetryPeriod:   config.RetryPeriod.Duration,
+			Callbacks: leaderelection.LeaderCallbacks{
+				OnStartedLeading: func(context.Context) {},
+				OnStoppedLeading: func() {},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go elector.Run(ctx)
+
+		handler := leaderelectionconfig.NewStatusHandler("virt-controller-a", config, elector)
+		getStatus := func() leaderelectionconfig.Status {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, leaderelectionconfig.StatusPath, nil))
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			status := leaderelectionconfig.Status{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &status)).To(Succeed())
+			return status
+		}
+
+		Eventually(func() string {
+			return getStatus().Leader
+		}).Should(Equal("virt-controller-b"))
+		status := getStatus()
+		Expect(status.Identity).To(Equal("virt-controller-a"))
+		Expect(status.IsLeader).To(BeFalse())
+		Expect(status.LeaseDuration.Duration).To(Equal(leaderelectionconfig.DefaultLeaseDuration))
+	})
+})
@@ -26,11 +26,16 @@ import (
 	v1 "kubevirt.io/client-go/api/v1"
 )
 
+// multusIPPoolArg is the CNI arg which carries the IP pool of a Multus network
+const multusIPPoolArg = "ipPool"
+
 type multusNetworkAnnotation struct {
-	InterfaceName string `json:"interface"`
-	Mac           string `json:"mac,omitempty"`
-	NetworkName   string `json:"name"`
-	Namespace     string `json:"namespace"`
+	InterfaceName string            `json:"interface"`
+	Mac           string            `json:"mac,omitempty"`
+	IPs           []string          `json:"ips,omitempty"`
+	CNIArgs       map[string]string `json:"cni-args,omitempty"`
+	NetworkName   string            `json:"name"`
+	Namespace     string            `json:"namespace"`
 }
 
 type multusNetworkAnnotationPool struct {
@@ -59,7 +64,7 @@ func generateMultusCNIAnnotation(vmi *v1.VirtualMachineInstance) (string, error)
 	multusNonDefaultNetworks := filterMultusNonDefaultNetworks(vmi.Spec.Networks)
 	for i, network := range multusNonDefaultNetworks {
 		multusNetworkAnnotationPool.add(
-			newMultusAnnotationData(vmi, network, fmt.Sprintf("net%d", i+1)))
+			newMultusAnnotationData(vmi, network, multusPodInterfaceName(i)))
 	}
 
 	if !multusNetworkAnnotationPool.isEmpty() {
@@ -68,6 +73,20 @@ func generateMultusCNIAnnotation(vmi *v1.VirtualMachineInstance) (string, error)
 	return "", nil
 }
 
+// MultusPodInterfaceNames maps the names of the Multus networks of the VMI, except for the default
+// network, to the names of their interfaces in the virt-launcher pod
+func MultusPodInterfaceNames(vmi *v1.VirtualMachineInstance) map[string]string {
+	names := map[string]string{}
+	for i, network := range filterMultusNonDefaultNetworks(vmi.Spec.Networks) {
+		names[network.Name] = multusPodInterfaceName(i)
+	}
+	return names
+}
+
+func multusPodInterfaceName(index int) string {
+	return fmt.Sprintf("net%d", index+1)
+}
+
 func filterMultusNonDefaultNetworks(networks []v1.Network) []v1.Network {
 	var multusNetworks []v1.Network
 	for _, network := range networks {
@@ -88,9 +107,15 @@ func newMultusAnnotationData(vmi *v1.VirtualMachineInstance, network v1.Network,
 	if multusIface != nil {
 		multusIfaceMac = multusIface.MacAddress
 	}
+	var cniArgs map[string]string
+	if network.Multus.IPPool != "" {
+		cniArgs = map[string]string{multusIPPoolArg: network.Multus.IPPool}
+	}
 	return multusNetworkAnnotation{
 		InterfaceName: podInterfaceName,
 		Mac:           multusIfaceMac,
+		IPs:           network.Multus.IPAddresses,
+		CNIArgs:       cniArgs,
 		Namespace:     namespace,
 		NetworkName:   networkName,
 	}
@@ -84,4 +84,30 @@ var _ = Describe("Multus annotations", func() {
 			Expect(multusAnnotationPool.toString()).To(BeIdenticalTo(expectedString))
 		})
 	})
+
+	Context("a multus annotation pool with static IP requests", func() {
+		BeforeEach(func() {
+			network.Multus.IPAddresses = []string{"192.168.1.10/24", "fd10::10/64"}
+			network.Multus.IPPool = "pool-a"
+			multusAnnotationPool = multusNetworkAnnotationPool{
+				pool: []multusNetworkAnnotation{
+					newMultusAnnotationData(&vmi, network, "net1"),
+				},
+			}
+		})
+
+		It("passes the IPs and the IP pool to the CNI plugin", func() {
+			expectedString := `[{"interface":"net1","ips":["192.168.1.10/24","fd10::10/64"],"cni-args":{"ipPool":"pool-a"},"name":"test1","namespace":"namespace1"}]`
+			Expect(multusAnnotationPool.toString()).To(BeIdenticalTo(expectedString))
+		})
+	})
+
+	It("names the pod interfaces of the Multus networks except for the default network", func() {
+		vmi.Spec.Networks = []v1.Network{
+			{Name: "default", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "default-net", Default: true}}},
+			{Name: "red", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "red-net"}}},
+			{Name: "blue", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "blue-net"}}},
+		}
+		Expect(MultusPodInterfaceNames(&vmi)).To(Equal(map[string]string{"red": "net1", "blue": "net2"}))
+	})
 })
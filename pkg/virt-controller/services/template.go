@@ -60,6 +60,7 @@ import (
 const KvmDevice = "devices.kubevirt.io/kvm"
 const TunDevice = "devices.kubevirt.io/tun"
 const VhostNetDevice = "devices.kubevirt.io/vhost-net"
+const VhostVsockDevice = "devices.kubevirt.io/vhost-vsock"
 
 const debugLogs = "debugLogs"
 const logVerbosity = "logVerbosity"
@@ -74,6 +75,7 @@ const (
 	CAP_NET_RAW          = "NET_RAW"
 	CAP_SYS_ADMIN        = "SYS_ADMIN"
 	CAP_SYS_NICE         = "SYS_NICE"
+	CAP_SYS_RAWIO        = "SYS_RAWIO"
 )
 
 // LibvirtStartupDelay is added to custom liveness and readiness probes initial delay value.
@@ -93,6 +95,9 @@ const MULTUS_DEFAULT_NETWORK_CNI_ANNOTATION = "v1.multus-cni.io/default-network"
 // Istio list of virtual interfaces whose inbound traffic (from VM) will be treated as outbound traffic in envoy
 const ISTIO_KUBEVIRT_ANNOTATION = "traffic.sidecar.istio.io/kubevirtInterfaces"
 
+// The descheduler only evicts pods with local storage, like virt-launcher pods, if they carry this annotation
+const DESCHEDULER_EVICT_ANNOTATION = "descheduler.alpha.kubernetes.io/evict"
+
 const VELERO_PREBACKUP_HOOK_CONTAINER_ANNOTATION = "pre.hook.backup.velero.io/container"
 const VELERO_PREBACKUP_HOOK_COMMAND_ANNOTATION = "pre.hook.backup.velero.io/command"
 const VELERO_POSTBACKUP_HOOK_CONTAINER_ANNOTATION = "post.hook.backup.velero.io/container"
@@ -101,6 +106,7 @@ const VELERO_POSTBACKUP_HOOK_COMMAND_ANNOTATION = "post.hook.backup.velero.io/co
 const ENV_VAR_LIBVIRT_DEBUG_LOGS = "LIBVIRT_DEBUG_LOGS"
 const ENV_VAR_VIRTIOFSD_DEBUG_LOGS = "VIRTIOFSD_DEBUG_LOGS"
 const ENV_VAR_VIRT_LAUNCHER_LOG_VERBOSITY = "VIRT_LAUNCHER_LOG_VERBOSITY"
+const ENV_VAR_VIRT_LAUNCHER_LOG_FORMAT = "VIRT_LAUNCHER_LOG_FORMAT"
 
 const ENV_VAR_POD_NAME = "POD_NAME"
 
@@ -154,6 +160,15 @@ func isFeatureStateEnabled(fs *v1.FeatureState) bool {
 	return fs != nil && fs.Enabled != nil && *fs.Enabled
 }
 
+const hypervBaseLabel = "base"
+
+// hypervPassthroughPreferredLabels are the enlightenment labels of the node-labeller
+// which are preferred when the host Hyper-V features are passed through.
+var hypervPassthroughPreferredLabels = []string{
+	"vpindex", "runtime", "reset", "synic", "synictimer",
+	"frequencies", "reenlightenment", "tlbflush", "ipi", "evmcs",
+}
+
 type hvFeatureLabel struct {
 	Feature *v1.FeatureState
 	Label   string
@@ -169,8 +184,7 @@ func makeHVFeatureLabelTable(vmi *v1.VirtualMachineInstance) []hvFeatureLabel {
 	// VPIndex, SyNIC: depend on both MSR and capability
 	// IPI, TLBFlush: depend on KVM Capabilities
 	// Runtime, Reset, SyNICTimer, Frequencies, Reenlightenment: depend on KVM MSRs availability
-	// EVMCS: depends on KVM capability, but the only way to know that is enable it, QEMU doesn't do
-	// any check before that, so we leave it out
+	// EVMCS: depends on a KVM capability which only exists on Intel hosts, see getHypervNodeSelectors
 	//
 	// see also https://schd.ws/hosted_files/devconfcz2019/cf/vkuznets_enlightening_kvm_devconf2019.pdf
 	// to learn about dependencies between enlightenments
@@ -226,6 +240,12 @@ func makeHVFeatureLabelTable(vmi *v1.VirtualMachineInstance) []hvFeatureLabel {
 
 func getHypervNodeSelectors(vmi *v1.VirtualMachineInstance) map[string]string {
 	nodeSelectors := make(map[string]string)
+	if util.IsHypervPassthrough(vmi) {
+		// With passthrough QEMU enables whatever the host offers, so only Hyper-V support itself
+		// is required. The single enlightenments are preferred by SetNodeAffinityForHypervPassthrough.
+		nodeSelectors[NFD_KVM_INFO_PREFIX+hypervBaseLabel] = "true"
+		return nodeSelectors
+	}
 	if vmi.Spec.Domain.Features == nil || vmi.Spec.Domain.Features.Hyperv == nil {
 		return nodeSelectors
 	}
@@ -237,13 +257,49 @@ func getHypervNodeSelectors(vmi *v1.VirtualMachineInstance) map[string]string {
 		}
 	}
 
-	if vmi.Spec.Domain.Features.Hyperv.EVMCS != nil {
+	if evmcs := vmi.Spec.Domain.Features.Hyperv.EVMCS; evmcs != nil {
 		nodeSelectors[v1.CPUModelVendorLabel+IntelVendorName] = "true"
+		if evmcs.Enabled == nil || *evmcs.Enabled {
+			nodeSelectors[NFD_KVM_INFO_PREFIX+"evmcs"] = "true"
+		}
 	}
 
 	return nodeSelectors
 }
 
+// SetNodeAffinityForHypervPassthrough prefers nodes which offer more Hyper-V enlightenments
+// for VMIs which pass the host Hyper-V features through, since the guest gets all of them.
+func SetNodeAffinityForHypervPassthrough(vmi *v1.VirtualMachineInstance, pod *k8sv1.Pod) {
+	if !util.IsHypervPassthrough(vmi) {
+		return
+	}
+
+	var preferred []k8sv1.PreferredSchedulingTerm
+	for _, label := range hypervPassthroughPreferredLabels {
+		preferred = append(preferred, k8sv1.PreferredSchedulingTerm{
+			Weight: 1,
+			Preference: k8sv1.NodeSelectorTerm{
+				MatchExpressions: []k8sv1.NodeSelectorRequirement{
+					{
+						Key:      NFD_KVM_INFO_PREFIX + label,
+						Operator: k8sv1.NodeSelectorOpIn,
+						Values:   []string{"true"},
+					},
+				},
+			},
+		})
+	}
+
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &k8sv1.Affinity{}
+	}
+	if pod.Spec.Affinity.NodeAffinity == nil {
+		pod.Spec.Affinity.NodeAffinity = &k8sv1.NodeAffinity{}
+	}
+	pod.Spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+		pod.Spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution, preferred...)
+}
+
 func CPUModelLabelFromCPUModel(vmi *v1.VirtualMachineInstance) (label string, err error) {
 	if vmi.Spec.Domain.CPU == nil || vmi.Spec.Domain.CPU.Model == "" {
 		err = fmt.Errorf("Cannot create CPU Model label, vmi spec is mising CPU model")
@@ -415,6 +471,13 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 	namespace := precond.MustNotBeEmpty(vmi.GetObjectMeta().GetNamespace())
 	nodeSelector := map[string]string{}
 
+	// The allow list may have changed since the VMI got admitted
+	for _, arg := range vmi.Spec.Domain.QEMUArgs {
+		if !t.clusterConfig.IsQEMUArgAllowed(arg.Name) {
+			return nil, fmt.Errorf("QEMU argument %s is not allowed by the KubeVirt configuration", arg.Name)
+		}
+	}
+
 	var volumes []k8sv1.Volume
 	var volumeDevices []k8sv1.VolumeDevice
 	var volumeMounts []k8sv1.VolumeMount
@@ -509,8 +572,29 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		},
 	})
 
+	// vhost-user sockets shared with the userspace dataplane
+	if util.IsVhostUserVmi(vmi) {
+		volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+			Name:      "vhostuser-sockets",
+			MountPath: util.VhostUserSocketDir,
+		})
+		volumes = append(volumes, k8sv1.Volume{
+			Name: "vhostuser-sockets",
+			VolumeSource: k8sv1.VolumeSource{
+				EmptyDir: &k8sv1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+
 	serviceAccountName := ""
 
+	reservationVolumes := map[string]bool{}
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.LUN != nil && disk.LUN.Reservation {
+			reservationVolumes[disk.Name] = true
+		}
+	}
+
 	for _, volume := range vmi.Spec.Volumes {
 		if hotplugVolumes[volume.Name] {
 			continue
@@ -522,13 +606,15 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		if volume.PersistentVolumeClaim != nil {
 			logger := log.DefaultLogger()
 			claimName := volume.PersistentVolumeClaim.ClaimName
-			_, exists, isBlock, err := types.IsPVCBlockFromStore(t.persistentVolumeClaimStore, namespace, claimName)
+			pvc, exists, isBlock, err := types.IsPVCBlockFromStore(t.persistentVolumeClaimStore, namespace, claimName)
 			if err != nil {
 				logger.Errorf("error getting PVC: %v", claimName)
 				return nil, err
 			} else if !exists {
 				logger.Errorf("didn't find PVC %v", claimName)
 				return nil, PvcNotFoundError{Reason: fmt.Sprintf("didn't find PVC %v", claimName)}
+			} else if reservationVolumes[volume.Name] && (!isBlock || !types.HasSharedAccessMode(pvc.Spec.AccessModes)) {
+				return nil, fmt.Errorf("the PVC %v of volume %s has to be a block volume with the ReadWriteMany access mode to pass persistent reservations", claimName, volume.Name)
 			} else if isBlock {
 				devicePath := filepath.Join(string(filepath.Separator), "dev", volume.Name)
 				device := k8sv1.VolumeDevice{
@@ -563,6 +649,28 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 				Name: volume.ContainerDisk.ImagePullSecret,
 			})
 		}
+		if volume.ContainerDisk != nil && volume.ContainerDisk.Overlay != nil && volume.ContainerDisk.Overlay.ClaimName != "" {
+			claimName := volume.ContainerDisk.Overlay.ClaimName
+			_, exists, isBlock, err := types.IsPVCBlockFromStore(t.persistentVolumeClaimStore, namespace, claimName)
+			if err != nil {
+				log.DefaultLogger().Errorf("error getting the overlay PVC: %v", claimName)
+				return nil, err
+			} else if !exists {
+				log.DefaultLogger().Errorf("didn't find the overlay PVC %v", claimName)
+				return nil, PvcNotFoundError{Reason: fmt.Sprintf("didn't find the overlay PVC %v", claimName)}
+			} else if isBlock {
+				return nil, fmt.Errorf("the overlay PVC %v of volume %s has to be a filesystem volume", claimName, volume.Name)
+			}
+			volumeMounts = append(volumeMounts, volumeMount)
+			volumes = append(volumes, k8sv1.Volume{
+				Name: volume.Name,
+				VolumeSource: k8sv1.VolumeSource{
+					PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+						ClaimName: claimName,
+					},
+				},
+			})
+		}
 		if volume.HostDisk != nil {
 			var hostPathType k8sv1.HostPathType
 
@@ -590,13 +698,15 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		if volume.DataVolume != nil {
 			logger := log.DefaultLogger()
 			claimName := volume.DataVolume.Name
-			_, exists, isBlock, err := types.IsPVCBlockFromStore(t.persistentVolumeClaimStore, namespace, claimName)
+			pvc, exists, isBlock, err := types.IsPVCBlockFromStore(t.persistentVolumeClaimStore, namespace, claimName)
 			if err != nil {
 				logger.Errorf("error getting PVC associated with DataVolume: %v", claimName)
 				return nil, err
 			} else if !exists {
 				logger.Errorf("didn't find PVC associated with DataVolume: %v", claimName)
 				return nil, PvcNotFoundError{Reason: fmt.Sprintf("didn't find PVC associated with DataVolume: %v", claimName)}
+			} else if reservationVolumes[volume.Name] && (!isBlock || !types.HasSharedAccessMode(pvc.Spec.AccessModes)) {
+				return nil, fmt.Errorf("the PVC %v of volume %s has to be a block volume with the ReadWriteMany access mode to pass persistent reservations", claimName, volume.Name)
 			} else if isBlock {
 				devicePath := filepath.Join(string(filepath.Separator), "dev", volume.Name)
 				device := k8sv1.VolumeDevice{
@@ -1148,13 +1258,19 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 	if vmi.Spec.ReadinessProbe != nil {
 		v1.SetDefaults_Probe(vmi.Spec.ReadinessProbe)
 		compute.ReadinessProbe = copyProbe(vmi.Spec.ReadinessProbe)
-		updateReadinessProbe(vmi, compute.ReadinessProbe)
+		updateProbe(vmi, vmi.Spec.ReadinessProbe, compute.ReadinessProbe, false)
 	}
 
 	if vmi.Spec.LivenessProbe != nil {
 		v1.SetDefaults_Probe(vmi.Spec.LivenessProbe)
 		compute.LivenessProbe = copyProbe(vmi.Spec.LivenessProbe)
-		updateLivenessProbe(vmi, compute.LivenessProbe)
+		updateProbe(vmi, vmi.Spec.LivenessProbe, compute.LivenessProbe, true)
+	}
+
+	if vmi.Spec.StartupProbe != nil {
+		v1.SetDefaults_Probe(vmi.Spec.StartupProbe)
+		compute.StartupProbe = copyProbe(vmi.Spec.StartupProbe)
+		updateProbe(vmi, vmi.Spec.StartupProbe, compute.StartupProbe, true)
 	}
 
 	for networkName, resourceName := range networkToResourceMap {
@@ -1173,6 +1289,10 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		compute.Env = append(compute.Env, k8sv1.EnvVar{Name: ENV_VAR_VIRT_LAUNCHER_LOG_VERBOSITY, Value: verbosityStr})
 	}
 
+	if t.clusterConfig.LibraryLogsJSONEnabled() {
+		compute.Env = append(compute.Env, k8sv1.EnvVar{Name: ENV_VAR_VIRT_LAUNCHER_LOG_FORMAT, Value: string(v1.LogFormatJSON)})
+	}
+
 	if labelValue, ok := vmi.Labels[debugLogs]; (ok && strings.EqualFold(labelValue, "true")) || virtLauncherLogVerbosity > EXT_LOG_VERBOSITY_THRESHOLD {
 		compute.Env = append(compute.Env, k8sv1.EnvVar{Name: ENV_VAR_LIBVIRT_DEBUG_LOGS, Value: "1"})
 	}
@@ -1218,7 +1338,9 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 	volumes = append(volumes, k8sv1.Volume{
 		Name: "ephemeral-disks",
 		VolumeSource: k8sv1.VolumeSource{
-			EmptyDir: &k8sv1.EmptyDirVolumeSource{},
+			EmptyDir: &k8sv1.EmptyDirVolumeSource{
+				SizeLimit: t.getEphemeralDisksSizeLimit(vmi),
+			},
 		},
 	})
 	volumes = append(volumes, k8sv1.Volume{
@@ -1257,6 +1379,13 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		}
 	}
 
+	// Only QEMU builds which emulate NVMe controllers list the bus in their domain capabilities
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.Disk != nil && disk.Disk.Bus == "nvme" {
+			nodeSelector[v1.DiskBusLabel+"nvme"] = "true"
+		}
+	}
+
 	if vmi.Status.TopologyHints != nil {
 		if vmi.Status.TopologyHints.TSCFrequency != nil {
 			nodeSelector[topology.ToTSCSchedulableLabel(*vmi.Status.TopologyHints.TSCFrequency)] = "true"
@@ -1311,6 +1440,10 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		containers = append(containers, sidecar)
 	}
 
+	if util.IsSerialConsoleLogEnabled(vmi) {
+		containers = append(containers, t.renderGuestConsoleLogContainer(vmi, imagePullPolicy, userId, nonRoot))
+	}
+
 	hostName := dns.SanitizeHostname(vmi)
 
 	podAnnotations, err := generatePodAnnotations(vmi)
@@ -1321,6 +1454,9 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		// mark pod as temp - only used for provisioning
 		podAnnotations[v1.EphemeralProvisioningObject] = "true"
 	}
+	if t.clusterConfig.VMSwapEnabled() && util.HasBurstableMemory(vmi) {
+		podAnnotations[v1.MemorySwapAnnotation] = "true"
+	}
 
 	var initContainers []k8sv1.Container
 
@@ -1436,6 +1572,10 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		SetNodeAffinityForForbiddenFeaturePolicy(vmi, &pod)
 	}
 
+	if t.clusterConfig.HypervStrictCheckEnabled() {
+		SetNodeAffinityForHypervPassthrough(vmi, &pod)
+	}
+
 	pod.Spec.Tolerations = vmi.Spec.Tolerations
 
 	pod.Spec.SchedulerName = vmi.Spec.SchedulerName
@@ -1470,6 +1610,9 @@ func validatePermittedHostDevices(spec *v1.VirtualMachineInstanceSpec, config *v
 		for _, dev := range hostDevs.MediatedDevices {
 			supportedHostDevicesMap[dev.ResourceName] = true
 		}
+		for _, dev := range hostDevs.USB {
+			supportedHostDevicesMap[dev.ResourceName] = true
+		}
 		for _, hostDev := range spec.Domain.Devices.GPUs {
 			if _, exist := supportedHostDevicesMap[hostDev.DeviceName]; !exist {
 				errors = append(errors, fmt.Sprintf("GPU %s is not permitted in permittedHostDevices configuration", hostDev.DeviceName))
@@ -1779,10 +1922,49 @@ func getRequiredCapabilities(vmi *v1.VirtualMachineInstance, config *virtconfig.
 		capabilities = append(capabilities, CAP_SYS_ADMIN)
 		capabilities = append(capabilities, getVirtiofsCapabilities()...)
 	}
+	// add CAP_SYS_RAWIO capability to allow qemu-pr-helper to issue persistent reservations
+	if util.HasLUNReservation(vmi) {
+		capabilities = append(capabilities, CAP_SYS_RAWIO)
+	}
 
 	return capabilities
 }
 
+// configDiskEphemeralStorage is the room left in the ephemeral disks directory for each cloud-init and ignition
+// disk, whose data comes from secrets and annotations of at most 1MiB each.
+var configDiskEphemeralStorage = resource.MustParse("4Mi")
+
+// getEphemeralDisksSizeLimit returns the limit of the overlays of the containerDisks of a VMI which are not
+// stored on a PVC, plus the room of its cloud-init and ignition disks, or nil if they are unlimited.
+// The overlays of ephemeral PVC volumes share the directory, so their VMIs are not limited.
+func (t *templateService) getEphemeralDisksSizeLimit(vmi *v1.VirtualMachineInstance) *resource.Quantity {
+	limit := t.clusterConfig.GetContainerDiskEphemeralStorageLimit()
+	if limit == nil {
+		return nil
+	}
+	sizeLimit := resource.NewQuantity(0, limit.Format)
+	configDisks := resource.NewQuantity(0, limit.Format)
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Ephemeral != nil {
+			return nil
+		}
+		if volume.ContainerDisk != nil && (volume.ContainerDisk.Overlay == nil || volume.ContainerDisk.Overlay.ClaimName == "") {
+			sizeLimit.Add(*limit)
+		}
+		if volume.CloudInitNoCloud != nil || volume.CloudInitConfigDrive != nil {
+			configDisks.Add(configDiskEphemeralStorage)
+		}
+	}
+	if sizeLimit.IsZero() {
+		return nil
+	}
+	if _, ok := vmi.Annotations[v1.IgnitionAnnotation]; ok {
+		configDisks.Add(configDiskEphemeralStorage)
+	}
+	sizeLimit.Add(*configDisks)
+	return sizeLimit
+}
+
 func getRequiredResources(vmi *v1.VirtualMachineInstance, allowEmulation bool) k8sv1.ResourceList {
 	res := k8sv1.ResourceList{}
 	if (len(vmi.Spec.Domain.Devices.Interfaces) > 0) ||
@@ -1798,6 +1980,9 @@ func getRequiredResources(vmi *v1.VirtualMachineInstance, allowEmulation bool) k
 		res[VhostNetDevice] = resource.MustParse("1")
 
 	}
+	if util.IsAutoAttachVSOCK(vmi) {
+		res[VhostVsockDevice] = resource.MustParse("1")
+	}
 	return res
 }
 
@@ -1906,7 +2091,8 @@ var virtProbeOverhead = resource.MustParse("10Mi")
 func addProbeOverheads(vmi *v1.VirtualMachineInstance, to *resource.Quantity) {
 	hasLiveness := addProbeOverhead(vmi.Spec.LivenessProbe, to)
 	hasReadiness := addProbeOverhead(vmi.Spec.ReadinessProbe, to)
-	if hasLiveness || hasReadiness {
+	hasStartup := addProbeOverhead(vmi.Spec.StartupProbe, to)
+	if hasLiveness || hasReadiness || hasStartup {
 		to.Add(virtProbeTotalAdditionalOverhead)
 	}
 }
@@ -1919,23 +2105,16 @@ func addProbeOverhead(probe *v1.Probe, to *resource.Quantity) bool {
 	return false
 }
 
-func updateReadinessProbe(vmi *v1.VirtualMachineInstance, computeProbe *k8sv1.Probe) {
-	if vmi.Spec.ReadinessProbe.GuestAgentPing != nil {
-		wrapGuestAgentPingWithVirtProbe(vmi, computeProbe)
-		computeProbe.InitialDelaySeconds = computeProbe.InitialDelaySeconds + LibvirtStartupDelay
-		return
-	}
-	wrapExecProbeWithVirtProbe(vmi, computeProbe)
-	computeProbe.InitialDelaySeconds = computeProbe.InitialDelaySeconds + LibvirtStartupDelay
-}
-
-func updateLivenessProbe(vmi *v1.VirtualMachineInstance, computeProbe *k8sv1.Probe) {
-	if vmi.Spec.LivenessProbe.GuestAgentPing != nil {
-		wrapGuestAgentPingWithVirtProbe(vmi, computeProbe)
+// updateProbe makes exec and guest agent probes run inside the guest through virt-probe.
+// Liveness and startup probes succeed while the guest is paused, so that it is not restarted,
+// readiness probes fail and the VMI is not ready while paused.
+func updateProbe(vmi *v1.VirtualMachineInstance, probe *v1.Probe, computeProbe *k8sv1.Probe, skipWhenPaused bool) {
+	if probe.GuestAgentPing != nil {
+		wrapGuestAgentPingWithVirtProbe(vmi, computeProbe, skipWhenPaused)
 		computeProbe.InitialDelaySeconds = computeProbe.InitialDelaySeconds + LibvirtStartupDelay
 		return
 	}
-	wrapExecProbeWithVirtProbe(vmi, computeProbe)
+	wrapExecProbeWithVirtProbe(vmi, computeProbe, skipWhenPaused)
 	computeProbe.InitialDelaySeconds = computeProbe.InitialDelaySeconds + LibvirtStartupDelay
 }
 
@@ -2014,6 +2193,40 @@ func getNetworkToResourceMap(virtClient kubecli.KubevirtClient, vmi *v1.VirtualM
 	return
 }
 
+// renderGuestConsoleLogContainer returns a container which streams the serial console log of the guest
+// to its stdout, so that the guest output can be retrieved with kubectl logs and by log aggregators
+func (t *templateService) renderGuestConsoleLogContainer(vmi *v1.VirtualMachineInstance, imagePullPolicy k8sv1.PullPolicy, userId int64, nonRoot bool) k8sv1.Container {
+	resources := k8sv1.ResourceRequirements{}
+	if vmi.IsCPUDedicated() || vmi.WantsToHaveQOSGuaranteed() {
+		resources.Limits = make(k8sv1.ResourceList)
+		resources.Limits[k8sv1.ResourceCPU] = resource.MustParse("15m")
+		resources.Limits[k8sv1.ResourceMemory] = resource.MustParse("60M")
+	}
+	container := k8sv1.Container{
+		Name:            "guest-console-log",
+		Image:           t.launcherImage,
+		ImagePullPolicy: imagePullPolicy,
+		Command:         []string{"/usr/bin/virt-tail"},
+		Args:            []string{"--logfile", util.SerialConsoleLogPath(vmi)},
+		Resources:       resources,
+		SecurityContext: &k8sv1.SecurityContext{
+			RunAsUser: &userId,
+		},
+		VolumeMounts: []k8sv1.VolumeMount{
+			{
+				Name:      "private",
+				MountPath: util.VirtPrivateDir,
+				ReadOnly:  true,
+			},
+		},
+	}
+	if nonRoot {
+		container.SecurityContext.RunAsGroup = &userId
+		container.SecurityContext.RunAsNonRoot = &nonRoot
+	}
+	return container
+}
+
 func NewTemplateService(launcherImage string,
 	launcherQemuTimeout int,
 	virtShareDir string,
@@ -2063,20 +2276,23 @@ func copyProbe(probe *v1.Probe) *k8sv1.Probe {
 	}
 }
 
-func wrapGuestAgentPingWithVirtProbe(vmi *v1.VirtualMachineInstance, probe *k8sv1.Probe) {
+func wrapGuestAgentPingWithVirtProbe(vmi *v1.VirtualMachineInstance, probe *k8sv1.Probe, skipWhenPaused bool) {
 	pingCommand := []string{
 		"virt-probe",
 		"--domainName", api.VMINamespaceKeyFunc(vmi),
 		"--timeoutSeconds", strconv.FormatInt(int64(probe.TimeoutSeconds), 10),
 		"--guestAgentPing",
 	}
+	if skipWhenPaused {
+		pingCommand = append(pingCommand, "--skipWhenPaused")
+	}
 	probe.Handler.Exec = &k8sv1.ExecAction{Command: pingCommand}
 	// we add 1s to the pod probe to compensate for the additional steps in probing
 	probe.TimeoutSeconds += 1
 	return
 }
 
-func wrapExecProbeWithVirtProbe(vmi *v1.VirtualMachineInstance, probe *k8sv1.Probe) {
+func wrapExecProbeWithVirtProbe(vmi *v1.VirtualMachineInstance, probe *k8sv1.Probe, skipWhenPaused bool) {
 	if probe == nil || probe.Handler.Exec == nil {
 		return
 	}
@@ -2090,9 +2306,11 @@ func wrapExecProbeWithVirtProbe(vmi *v1.VirtualMachineInstance, probe *k8sv1.Pro
 		"virt-probe",
 		"--domainName", api.VMINamespaceKeyFunc(vmi),
 		"--timeoutSeconds", strconv.FormatInt(int64(probe.TimeoutSeconds), 10),
-		"--command", originalCommand[0],
-		"--",
 	}
+	if skipWhenPaused {
+		wrappedCommand = append(wrappedCommand, "--skipWhenPaused")
+	}
+	wrappedCommand = append(wrappedCommand, "--command", originalCommand[0], "--")
 	wrappedCommand = append(wrappedCommand, originalCommand[1:]...)
 
 	probe.Handler.Exec.Command = wrappedCommand
@@ -2148,6 +2366,10 @@ func generatePodAnnotations(vmi *v1.VirtualMachineInstance) (map[string]string,
 	if HaveMasqueradeInterface(vmi.Spec.Domain.Devices.Interfaces) {
 		annotationsSet[ISTIO_KUBEVIRT_ANNOTATION] = "k6t-eth0"
 	}
+	if vmi.IsEvictable() || vmi.IsEvictableIfPossible() {
+		// the eviction strategy decides whether an eviction by the descheduler migrates the vmi
+		annotationsSet[DESCHEDULER_EVICT_ANNOTATION] = "true"
+	}
 	annotationsSet[VELERO_PREBACKUP_HOOK_CONTAINER_ANNOTATION] = "compute"
 	annotationsSet[VELERO_PREBACKUP_HOOK_COMMAND_ANNOTATION] = fmt.Sprintf(
 		"[\"/usr/bin/virt-freezer\", \"--freeze\", \"--name\", \"%s\", \"--namespace\", \"%s\"]",
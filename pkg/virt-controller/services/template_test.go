@@ -563,6 +563,27 @@ var _ = Describe("Template", func() {
 			})
 		})
 
+		table.DescribeTable("should pass the log format to virt-launcher", func(logFormat v1.LogFormat, expectedEnv bool) {
+			config, kvInformer, svc = configFactory(defaultArch)
+			kvConfig := kv.DeepCopy()
+			kvConfig.Spec.Configuration.DeveloperConfiguration.LogFormat = logFormat
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+
+			vmi := v1.VirtualMachineInstance{ObjectMeta: metav1.ObjectMeta{Name: "testvmi", Namespace: "default", UID: "1234"}}
+			pod, err := svc.RenderLaunchManifest(&vmi)
+			Expect(err).ToNot(HaveOccurred())
+			logFormatEnv := kubev1.EnvVar{Name: ENV_VAR_VIRT_LAUNCHER_LOG_FORMAT, Value: string(v1.LogFormatJSON)}
+			if expectedEnv {
+				Expect(pod.Spec.Containers[0].Env).To(ContainElement(logFormatEnv))
+			} else {
+				Expect(pod.Spec.Containers[0].Env).ToNot(ContainElement(logFormatEnv))
+			}
+		},
+			table.Entry("when the format is json", v1.LogFormatJSON, true),
+			table.Entry("not when the format is text", v1.LogFormatText, false),
+			table.Entry("not when no format is set", v1.LogFormat(""), false),
+		)
+
 		Context("with access credentials", func() {
 			It("should add volume with secret referenced by cloud-init user secret ref", func() {
 				config, kvInformer, svc = configFactory(defaultArch)
@@ -877,6 +898,68 @@ var _ = Describe("Template", func() {
 				Expect(ok).To(BeTrue())
 			})
 		})
+		Context("with vhostuser interfaces", func() {
+			It("should share a socket directory with the userspace dataplane", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+					Name:                   "dpdk",
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{VhostUser: &v1.InterfaceVhostUser{}},
+				}}
+				vmi.Spec.Networks = []v1.Network{{
+					Name:          "dpdk",
+					NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "test1"}},
+				}}
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(kubev1.VolumeMount{
+					Name:      "vhostuser-sockets",
+					MountPath: "/var/run/kubevirt/vhostuser",
+				}))
+				Expect(pod.Spec.Volumes).To(ContainElement(kubev1.Volume{
+					Name: "vhostuser-sockets",
+					VolumeSource: kubev1.VolumeSource{
+						EmptyDir: &kubev1.EmptyDirVolumeSource{},
+					},
+				}))
+			})
+		})
+		Context("with serial console log", func() {
+			It("should add a guest-console-log container streaming the serial console log", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				logSerialConsole := true
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.UID = "1234"
+				vmi.Spec.Domain.Devices.LogSerialConsole = &logSerialConsole
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.Containers).To(HaveLen(2))
+				container := pod.Spec.Containers[1]
+				Expect(container.Name).To(Equal("guest-console-log"))
+				Expect(container.Command).To(Equal([]string{"/usr/bin/virt-tail"}))
+				Expect(container.Args).To(Equal([]string{"--logfile", "/var/run/kubevirt-private/1234/virt-serial0-log"}))
+				Expect(container.VolumeMounts).To(ConsistOf(kubev1.VolumeMount{
+					Name:      "private",
+					MountPath: "/var/run/kubevirt-private",
+					ReadOnly:  true,
+				}))
+			})
+
+			It("should not add the guest-console-log container if the serial console is not attached", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				logSerialConsole := true
+				autoattachSerialConsole := false
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.Spec.Domain.Devices.LogSerialConsole = &logSerialConsole
+				vmi.Spec.Domain.Devices.AutoattachSerialConsole = &autoattachSerialConsole
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.Containers).To(HaveLen(1))
+			})
+		})
 		Context("with multus annotation", func() {
 			It("should add multus networks in the pod annotation", func() {
 				config, kvInformer, svc = configFactory(defaultArch)
@@ -1032,6 +1115,34 @@ var _ = Describe("Template", func() {
 				Expect(value).To(Equal("k6t-eth0"))
 			})
 		})
+		Context("with an eviction strategy", func() {
+			table.DescribeTable("should set the descheduler annotation", func(strategy *v1.EvictionStrategy, expected bool) {
+				config, kvInformer, svc = configFactory(defaultArch)
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						EvictionStrategy: strategy,
+						Domain:           v1.DomainSpec{},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+				if expected {
+					Expect(pod.Annotations).To(HaveKeyWithValue(DESCHEDULER_EVICT_ANNOTATION, "true"))
+				} else {
+					Expect(pod.Annotations).ToNot(HaveKey(DESCHEDULER_EVICT_ANNOTATION))
+				}
+			},
+				table.Entry("with LiveMigrate", evictionStrategy(v1.EvictionStrategyLiveMigrate), true),
+				table.Entry("with LiveMigrateIfPossible", evictionStrategy(v1.EvictionStrategyLiveMigrateIfPossible), true),
+				table.Entry("without an eviction strategy", nil, false),
+			)
+		})
 		Context("With Istio sidecar.istio.io/inject annotation", func() {
 			var (
 				vmi v1.VirtualMachineInstance
@@ -1165,6 +1276,18 @@ var _ = Describe("Template", func() {
 				}
 			})
 
+			It("should add node selector for the nvme disk bus to template", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+					{Name: "disk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "nvme"}}},
+				}
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.NodeSelector).To(HaveKeyWithValue(v1.DiskBusLabel+"nvme", "true"))
+			})
+
 			It("should add node selectors from kubevirt-config configMap", func() {
 				config, kvInformer, svc = configFactory(defaultArch)
 				kvConfig := kv.DeepCopy()
@@ -1301,9 +1424,55 @@ var _ = Describe("Template", func() {
 				Expect(pod.Spec.NodeSelector).Should(HaveKeyWithValue(NFD_KVM_INFO_PREFIX+"synictimer", "true"))
 				Expect(pod.Spec.NodeSelector).Should(HaveKeyWithValue(NFD_KVM_INFO_PREFIX+"frequencies", "true"))
 				Expect(pod.Spec.NodeSelector).Should(HaveKeyWithValue(NFD_KVM_INFO_PREFIX+"ipi", "true"))
+				Expect(pod.Spec.NodeSelector).Should(HaveKeyWithValue(NFD_KVM_INFO_PREFIX+"evmcs", "true"))
 				Expect(pod.Spec.NodeSelector).Should(HaveKeyWithValue(v1.CPUModelVendorLabel+IntelVendorName, "true"))
 			})
 
+			It("should require hyperv support and prefer nodes with more enlightenments if VMI passes hyperv through", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				enableFeatureGate(virtconfig.HypervStrictCheckGate)
+
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								DisableHotplug: true,
+							},
+							Features: &v1.Features{
+								HypervPassthrough: &v1.HyperVPassthrough{},
+							},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.NodeSelector).To(HaveKeyWithValue(NFD_KVM_INFO_PREFIX+"base", "true"))
+				Expect(pod.Spec.NodeSelector).To(Not(HaveKey(NFD_KVM_INFO_PREFIX + "synic")))
+				Expect(pod.Spec.Affinity).ToNot(BeNil())
+				Expect(pod.Spec.Affinity.NodeAffinity).ToNot(BeNil())
+				preferred := pod.Spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+				Expect(preferred).To(HaveLen(len(hypervPassthroughPreferredLabels)))
+				Expect(preferred).To(ContainElement(kubev1.PreferredSchedulingTerm{
+					Weight: 1,
+					Preference: kubev1.NodeSelectorTerm{
+						MatchExpressions: []kubev1.NodeSelectorRequirement{
+							{
+								Key:      NFD_KVM_INFO_PREFIX + "evmcs",
+								Operator: kubev1.NodeSelectorOpIn,
+								Values:   []string{"true"},
+							},
+						},
+					},
+				}))
+			})
+
 			It("should not add node selector for hyperv nodes if VMI requests hyperv features which do not depend on host kernel", func() {
 				config, kvInformer, svc = configFactory(defaultArch)
 				enableFeatureGate(virtconfig.HypervStrictCheckGate)
@@ -2789,6 +2958,119 @@ var _ = Describe("Template", func() {
 				Expect(readinessProbe.FailureThreshold).To(Equal(vmi.Spec.ReadinessProbe.FailureThreshold))
 			})
 
+			It("should execute an exec readiness probe inside the guest", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				vmi.Spec.ReadinessProbe = &v1.Probe{
+					InitialDelaySeconds: 2,
+					TimeoutSeconds:      3,
+					FailureThreshold:    30,
+					Handler: v1.Handler{
+						Exec: &kubev1.ExecAction{Command: []string{"systemctl", "is-system-running"}},
+					},
+				}
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				readinessProbe := pod.Spec.Containers[0].ReadinessProbe
+				Expect(readinessProbe).ToNot(BeNil())
+				Expect(readinessProbe.Handler.Exec.Command).To(Equal([]string{
+					"virt-probe",
+					"--domainName", "default_testvmi",
+					"--timeoutSeconds", "3",
+					"--command", "systemctl",
+					"--",
+					"is-system-running",
+				}))
+				Expect(readinessProbe.TimeoutSeconds).To(Equal(int32(4)))
+				Expect(readinessProbe.InitialDelaySeconds).To(Equal(int32(2) + LibvirtStartupDelay))
+				Expect(readinessProbe.FailureThreshold).To(Equal(int32(30)))
+				Expect(readinessProbe.SuccessThreshold).To(Equal(int32(1)))
+			})
+
+			It("should execute an exec startup probe inside the guest", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				vmi.Spec.StartupProbe = &v1.Probe{
+					InitialDelaySeconds: 2,
+					TimeoutSeconds:      3,
+					FailureThreshold:    30,
+					Handler: v1.Handler{
+						Exec: &kubev1.ExecAction{Command: []string{"systemctl", "is-system-running"}},
+					},
+				}
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				startupProbe := pod.Spec.Containers[0].StartupProbe
+				Expect(startupProbe).ToNot(BeNil())
+				Expect(startupProbe.Handler.Exec.Command).To(Equal([]string{
+					"virt-probe",
+					"--domainName", "default_testvmi",
+					"--timeoutSeconds", "3",
+					"--skipWhenPaused",
+					"--command", "systemctl",
+					"--",
+					"is-system-running",
+				}))
+				Expect(startupProbe.TimeoutSeconds).To(Equal(int32(4)))
+				Expect(startupProbe.InitialDelaySeconds).To(Equal(int32(2) + LibvirtStartupDelay))
+				Expect(startupProbe.FailureThreshold).To(Equal(int32(30)))
+				Expect(startupProbe.SuccessThreshold).To(Equal(int32(1)))
+			})
+
+			It("should only skip liveness and startup probes while the guest is paused", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				vmi.Spec.LivenessProbe = &v1.Probe{
+					Handler: v1.Handler{
+						Exec: &kubev1.ExecAction{Command: []string{"true"}},
+					},
+				}
+				vmi.Spec.ReadinessProbe = &v1.Probe{
+					Handler: v1.Handler{
+						GuestAgentPing: &v1.GuestAgentPing{},
+					},
+				}
+				vmi.Spec.StartupProbe = &v1.Probe{
+					Handler: v1.Handler{
+						GuestAgentPing: &v1.GuestAgentPing{},
+					},
+				}
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Containers[0].StartupProbe.Handler.Exec.Command).To(ContainElement("--skipWhenPaused"))
+				Expect(pod.Spec.Containers[0].LivenessProbe.Handler.Exec.Command).To(Equal([]string{
+					"virt-probe",
+					"--domainName", "default_testvmi",
+					"--timeoutSeconds", "1",
+					"--skipWhenPaused",
+					"--command", "true",
+					"--",
+				}))
+				Expect(pod.Spec.Containers[0].ReadinessProbe.Handler.Exec.Command).ToNot(ContainElement("--skipWhenPaused"))
+			})
+
+			It("should ping the guest agent in a startup probe", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				vmi.Spec.StartupProbe = &v1.Probe{
+					Handler: v1.Handler{
+						GuestAgentPing: &v1.GuestAgentPing{},
+					},
+				}
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				startupProbe := pod.Spec.Containers[0].StartupProbe
+				Expect(startupProbe).ToNot(BeNil())
+				Expect(startupProbe.Handler.Exec.Command).To(ContainElement("--guestAgentPing"))
+			})
+
+			It("should not set a startup probe on the pod, if no one was specified on the vmi", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.Containers[0].StartupProbe).To(BeNil())
+			})
+
 			It("should not set a readiness probe on the pod, if no one was specified on the vmi", func() {
 				config, kvInformer, svc = configFactory(defaultArch)
 				vmi.Spec.ReadinessProbe = nil
@@ -3133,6 +3415,64 @@ var _ = Describe("Template", func() {
 			Expect(false).To(BeTrue())
 		})
 
+		Context("with a LUN reservation", func() {
+			newVMIWithLUNReservation := func() *v1.VirtualMachineInstance {
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				vmi.Spec.Domain.Devices.Disks = []v1.Disk{{
+					Name:       "lun0",
+					DiskDevice: v1.DiskDevice{LUN: &v1.LunTarget{Bus: "scsi", Reservation: true}},
+				}}
+				vmi.Spec.Volumes = []v1.Volume{{
+					Name: "lun0",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+							PersistentVolumeClaimVolumeSource: kubev1.PersistentVolumeClaimVolumeSource{ClaimName: "shared"},
+						},
+					},
+				}}
+				return vmi
+			}
+
+			addPVC := func(namespace string, volumeMode kubev1.PersistentVolumeMode, accessMode kubev1.PersistentVolumeAccessMode) {
+				Expect(pvcCache.Add(&kubev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "shared"},
+					Spec: kubev1.PersistentVolumeClaimSpec{
+						AccessModes: []kubev1.PersistentVolumeAccessMode{accessMode},
+						VolumeMode:  &volumeMode,
+					},
+				})).To(Succeed())
+			}
+
+			It("should require SYS_RAWIO", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				vmi := newVMIWithLUNReservation()
+				addPVC(vmi.Namespace, kubev1.PersistentVolumeBlock, kubev1.ReadWriteMany)
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				for _, container := range pod.Spec.Containers {
+					if container.Name == "compute" {
+						Expect(container.SecurityContext.Capabilities.Add).To(ContainElement(kubev1.Capability(CAP_SYS_RAWIO)))
+						return
+					}
+				}
+				Expect(false).To(BeTrue())
+			})
+
+			table.DescribeTable("should reject a PVC which can't be shared by the cluster nodes", func(volumeMode kubev1.PersistentVolumeMode, accessMode kubev1.PersistentVolumeAccessMode) {
+				config, kvInformer, svc = configFactory(defaultArch)
+				vmi := newVMIWithLUNReservation()
+				addPVC(vmi.Namespace, volumeMode, accessMode)
+
+				_, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).To(MatchError(ContainSubstring("has to be a block volume with the ReadWriteMany access mode")))
+			},
+				table.Entry("with a filesystem volume", kubev1.PersistentVolumeFilesystem, kubev1.ReadWriteMany),
+				table.Entry("with the ReadWriteOnce access mode", kubev1.PersistentVolumeBlock, kubev1.ReadWriteOnce),
+			)
+		})
+
 		It("Should run as non-root except compute", func() {
 			vmi := newMinimalWithContainerDisk("ranom")
 
@@ -3207,6 +3547,182 @@ var _ = Describe("Template", func() {
 		})
 
 	})
+
+	Context("with QEMU arguments", func() {
+		newVMIWithQEMUArgs := func() *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMIWithNS("default", "testvmi")
+			vmi.Spec.Domain.QEMUArgs = []v1.QEMUArg{{Name: "-fw_cfg", Value: "name=opt/com.example/config,string=value"}}
+			return vmi
+		}
+
+		It("should render the pod if the QEMU arguments are allowed", func() {
+			config, kvInformer, svc = configFactory(defaultArch)
+			kvConfig := kv.DeepCopy()
+			kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = []string{virtconfig.QEMUArgsGate}
+			kvConfig.Spec.Configuration.QEMUArgsAllowList = []string{"-fw_cfg"}
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+
+			_, err := svc.RenderLaunchManifest(newVMIWithQEMUArgs())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should refuse to render the pod if a QEMU argument is no longer allowed", func() {
+			config, kvInformer, svc = configFactory(defaultArch)
+			enableFeatureGate(virtconfig.QEMUArgsGate)
+
+			_, err := svc.RenderLaunchManifest(newVMIWithQEMUArgs())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("-fw_cfg"))
+		})
+	})
+
+	Context("with a containerDisk ephemeral storage limit", func() {
+		getEphemeralDisksVolume := func(pod *kubev1.Pod) kubev1.Volume {
+			for _, volume := range pod.Spec.Volumes {
+				if volume.Name == "ephemeral-disks" {
+					return volume
+				}
+			}
+			Fail("ephemeral-disks volume not found")
+			return kubev1.Volume{}
+		}
+
+		table.DescribeTable("should limit the size of the ephemeral disks", func(volumes []v1.VolumeSource, expectedLimit string) {
+			config, kvInformer, svc = configFactory(defaultArch)
+			kvConfig := kv.DeepCopy()
+			limit := resource.MustParse("1Gi")
+			kvConfig.Spec.Configuration.DiskConfiguration = &v1.DiskConfiguration{
+				ContainerDiskEphemeralStorageLimit: &limit,
+			}
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			Expect(pvcCache.Add(&kubev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Namespace: vmi.Namespace, Name: "overlay-pvc"},
+			})).To(Succeed())
+			for i, source := range volumes {
+				name := fmt.Sprintf("volume%d", i)
+				vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{Name: name})
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{Name: name, VolumeSource: source})
+			}
+			pod, err := svc.RenderLaunchManifest(vmi)
+			Expect(err).ToNot(HaveOccurred())
+
+			sizeLimit := getEphemeralDisksVolume(pod).EmptyDir.SizeLimit
+			if expectedLimit == "" {
+				Expect(sizeLimit).To(BeNil())
+			} else {
+				Expect(sizeLimit).ToNot(BeNil())
+				Expect(sizeLimit.Cmp(resource.MustParse(expectedLimit))).To(Equal(0))
+			}
+		},
+			table.Entry("by the limit of every containerDisk", []v1.VolumeSource{
+				{ContainerDisk: &v1.ContainerDiskSource{Image: "my-image-1"}},
+				{ContainerDisk: &v1.ContainerDiskSource{Image: "my-image-2"}},
+			}, "2Gi"),
+			table.Entry("not without containerDisks", []v1.VolumeSource{
+				{EmptyDisk: &v1.EmptyDiskSource{Capacity: resource.MustParse("1Gi")}},
+			}, ""),
+			table.Entry("by the limit of every containerDisk without overlay PVC", []v1.VolumeSource{
+				{ContainerDisk: &v1.ContainerDiskSource{Image: "my-image-1"}},
+				{ContainerDisk: &v1.ContainerDiskSource{Image: "my-image-2", Overlay: &v1.ContainerDiskOverlay{ClaimName: "overlay-pvc"}}},
+			}, "1Gi"),
+			table.Entry("by the limit of every containerDisk and the room of every cloud-init disk", []v1.VolumeSource{
+				{ContainerDisk: &v1.ContainerDiskSource{Image: "my-image-1"}},
+				{CloudInitNoCloud: &v1.CloudInitNoCloudSource{UserData: "#cloud-config"}},
+				{CloudInitConfigDrive: &v1.CloudInitConfigDriveSource{UserData: "#cloud-config"}},
+			}, "1032Mi"),
+			table.Entry("not with ephemeral volumes", []v1.VolumeSource{
+				{ContainerDisk: &v1.ContainerDiskSource{Image: "my-image-1"}},
+				{Ephemeral: &v1.EphemeralVolumeSource{PersistentVolumeClaim: &kubev1.PersistentVolumeClaimVolumeSource{ClaimName: "claim"}}},
+			}, ""),
+		)
+
+		It("should mount the overlay PVC of a containerDisk", func() {
+			config, kvInformer, svc = configFactory(defaultArch)
+			vmi := v1.NewMinimalVMI("testvmi")
+			Expect(pvcCache.Add(&kubev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Namespace: vmi.Namespace, Name: "overlay-pvc"},
+			})).To(Succeed())
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name: "containerdisk",
+				VolumeSource: v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{
+					Image:   "my-image-1",
+					Overlay: &v1.ContainerDiskOverlay{ClaimName: "overlay-pvc"},
+				}},
+			}}
+			pod, err := svc.RenderLaunchManifest(vmi)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(pod.Spec.Volumes).To(ContainElement(kubev1.Volume{
+				Name: "containerdisk",
+				VolumeSource: kubev1.VolumeSource{
+					PersistentVolumeClaim: &kubev1.PersistentVolumeClaimVolumeSource{ClaimName: "overlay-pvc"},
+				},
+			}))
+			Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(kubev1.VolumeMount{
+				Name:      "containerdisk",
+				MountPath: "/var/run/kubevirt-private/vmi-disks/containerdisk",
+			}))
+		})
+
+		It("should not render the pod without the overlay PVC of a containerDisk", func() {
+			config, kvInformer, svc = configFactory(defaultArch)
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name: "containerdisk",
+				VolumeSource: v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{
+					Image:   "my-image-1",
+					Overlay: &v1.ContainerDiskOverlay{ClaimName: "missing-pvc"},
+				}},
+			}}
+			_, err := svc.RenderLaunchManifest(vmi)
+			Expect(err).To(BeAssignableToTypeOf(PvcNotFoundError{}))
+		})
+
+		It("should not limit the size of the ephemeral disks by default", func() {
+			config, kvInformer, svc = configFactory(defaultArch)
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name:         "containerdisk",
+				VolumeSource: v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{Image: "my-image-1"}},
+			}}
+			pod, err := svc.RenderLaunchManifest(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(getEphemeralDisksVolume(pod).EmptyDir.SizeLimit).To(BeNil())
+		})
+	})
+
+	Context("with VM swap", func() {
+		newVMIWithMemory := func(request, limit string) *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMIWithNS("default", "testvmi")
+			vmi.Spec.Domain.Resources.Requests = kubev1.ResourceList{kubev1.ResourceMemory: resource.MustParse(request)}
+			if limit != "" {
+				vmi.Spec.Domain.Resources.Limits = kubev1.ResourceList{kubev1.ResourceMemory: resource.MustParse(limit)}
+			}
+			return vmi
+		}
+
+		table.DescribeTable("should annotate the pod", func(gateEnabled bool, limit string, expectAnnotation bool) {
+			config, kvInformer, svc = configFactory(defaultArch)
+			if gateEnabled {
+				enableFeatureGate(virtconfig.VMSwapGate)
+			}
+
+			pod, err := svc.RenderLaunchManifest(newVMIWithMemory("1Gi", limit))
+			Expect(err).ToNot(HaveOccurred())
+			if expectAnnotation {
+				Expect(pod.Annotations).To(HaveKeyWithValue(v1.MemorySwapAnnotation, "true"))
+			} else {
+				Expect(pod.Annotations).ToNot(HaveKey(v1.MemorySwapAnnotation))
+			}
+		},
+			table.Entry("if the memory has no limit", true, "", true),
+			table.Entry("if the memory limit exceeds the request", true, "2Gi", true),
+			table.Entry("not if the memory limit equals the request", true, "1Gi", false),
+			table.Entry("not if the feature gate is disabled", false, "", false),
+		)
+	})
 })
 
 var _ = Describe("getResourceNameForNetwork", func() {
@@ -3332,3 +3848,7 @@ func validateAndExtractQemuTimeoutArg(args []string) string {
 
 	return timeoutString
 }
+
+func evictionStrategy(strategy v1.EvictionStrategy) *v1.EvictionStrategy {
+	return &strategy
+}
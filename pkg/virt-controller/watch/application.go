@@ -50,6 +50,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/healthz"
 	"kubevirt.io/kubevirt/pkg/monitoring/profiler"
 
+	poolv1 "kubevirt.io/client-go/apis/pool/v1alpha1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
@@ -58,8 +59,10 @@ import (
 	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
 	"kubevirt.io/kubevirt/pkg/controller"
 
+	"kubevirt.io/kubevirt/pkg/monitoring/migrationstats"
 	"kubevirt.io/kubevirt/pkg/monitoring/perfscale"
 	vmiprom "kubevirt.io/kubevirt/pkg/monitoring/vmistats" // import for prometheus metrics
+	"kubevirt.io/kubevirt/pkg/monitoring/vmstats"
 	"kubevirt.io/kubevirt/pkg/service"
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/webhooks"
@@ -122,11 +125,12 @@ var (
 type VirtControllerApp struct {
 	service.ServiceListen
 
-	clientSet       kubecli.KubevirtClient
-	templateService services.TemplateService
-	restClient      *clientrest.RESTClient
-	informerFactory controller.KubeInformerFactory
-	kvPodInformer   cache.SharedIndexInformer
+	clientSet         kubecli.KubevirtClient
+	templateService   services.TemplateService
+	restClient        *clientrest.RESTClient
+	informerFactory   controller.KubeInformerFactory
+	kvPodInformer     cache.SharedIndexInformer
+	kvServiceInformer cache.SharedIndexInformer
 
 	nodeInformer   cache.SharedIndexInformer
 	nodeController *NodeController
@@ -151,6 +155,15 @@ type VirtControllerApp struct {
 	vmController *VMController
 	vmInformer   cache.SharedIndexInformer
 
+	poolController *PoolController
+	poolInformer   cache.SharedIndexInformer
+
+	loadBalancerController *LoadBalancerController
+
+	storageCapabilitiesController *StorageCapabilitiesController
+	storageProfileInformer        cache.SharedIndexInformer
+	storageCapabilitiesInformer   cache.SharedIndexInformer
+
 	controllerRevisionInformer cache.SharedIndexInformer
 
 	dataVolumeInformer cache.SharedIndexInformer
@@ -204,6 +217,8 @@ type VirtControllerApp struct {
 	launcherSubGid                    int64
 	snapshotControllerThreads         int
 	restoreControllerThreads          int
+	poolControllerThreads             int
+	loadBalancerControllerThreads     int
 	snapshotControllerResyncPeriod    time.Duration
 
 	caConfigMapName          string
@@ -219,6 +234,7 @@ var _ service.Service = &VirtControllerApp{}
 func init() {
 	vsv1beta1.AddToScheme(scheme.Scheme)
 	snapshotv1.AddToScheme(scheme.Scheme)
+	poolv1.AddToScheme(scheme.Scheme)
 
 	prometheus.MustRegister(leaderGauge)
 	prometheus.MustRegister(readyGauge)
@@ -234,7 +250,7 @@ func Execute() {
 
 	app.readyChan = make(chan bool, 1)
 
-	log.InitializeLogging("virt-controller")
+	log.InitializeLibraryLogging("virt-controller")
 
 	app.reloadableRateLimiter = ratelimiter.NewReloadableRateLimiter(flowcontrol.NewTokenBucketRateLimiter(virtconfig.DefaultVirtControllerQPS, virtconfig.DefaultVirtControllerBurst))
 	clientConfig, err := kubecli.GetKubevirtClientConfig()
@@ -301,6 +317,7 @@ func Execute() {
 
 	app.vmiInformer = app.informerFactory.VMI()
 	app.kvPodInformer = app.informerFactory.KubeVirtPod()
+	app.kvServiceInformer = app.informerFactory.KubeVirtService()
 	app.nodeInformer = app.informerFactory.KubeVirtNode()
 
 	app.vmiCache = app.vmiInformer.GetStore()
@@ -315,6 +332,8 @@ func Execute() {
 
 	app.vmInformer = app.informerFactory.VirtualMachine()
 
+	app.poolInformer = app.informerFactory.VirtualMachinePool()
+
 	app.migrationInformer = app.informerFactory.VirtualMachineInstanceMigration()
 
 	app.controllerRevisionInformer = app.informerFactory.ControllerRevision()
@@ -323,22 +342,28 @@ func Execute() {
 	app.vmSnapshotContentInformer = app.informerFactory.VirtualMachineSnapshotContent()
 	app.vmRestoreInformer = app.informerFactory.VirtualMachineRestore()
 	app.storageClassInformer = app.informerFactory.StorageClass()
+	app.storageCapabilitiesInformer = app.informerFactory.StorageCapabilitiesConfigMap()
 	app.allPodInformer = app.informerFactory.Pod()
 
 	if app.hasCDI {
 		app.dataVolumeInformer = app.informerFactory.DataVolume()
+		app.storageProfileInformer = app.informerFactory.StorageProfile()
 		log.Log.Infof("CDI detected, DataVolume integration enabled")
 	} else {
 		// Add a dummy DataVolume informer in the event datavolume support
 		// is disabled. This lets the controller continue to work without
 		// requiring a separate branching code path.
 		app.dataVolumeInformer = app.informerFactory.DummyDataVolume()
+		app.storageProfileInformer = app.informerFactory.DummyStorageProfile()
 		log.Log.Infof("CDI not detected, DataVolume integration disabled")
 	}
 
 	app.initCommon()
 	app.initReplicaSet()
 	app.initVirtualMachines()
+	app.initPool()
+	app.initLoadBalancerController()
+	app.initStorageCapabilitiesController()
 	app.initDisruptionBudgetController()
 	app.initEvacuationController()
 	app.initSnapshotController()
@@ -377,6 +402,7 @@ func (app *VirtControllerApp) shouldChangeRateLimiter() {
 func (vca *VirtControllerApp) shouldChangeLogVerbosity() {
 	verbosity := vca.clusterConfig.GetVirtControllerVerbosity(vca.host)
 	log.Log.SetVerbosityLevel(int(verbosity))
+	log.SetLibraryJSONFormat(vca.clusterConfig.LibraryLogsJSONEnabled())
 	log.Log.V(2).Infof("set log verbosity to %d", verbosity)
 }
 
@@ -401,6 +427,10 @@ func (vca *VirtControllerApp) Run() {
 		}
 	}()
 
+	if err := vca.LeaderElection.Apply(vca.clusterConfig.GetConfig().LeaderElection); err != nil {
+		logger.Reason(err).Error("Ignoring the leader election configuration of the KubeVirt CR")
+	}
+
 	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, leaderelectionconfig.DefaultEndpointName)
 
 	rl, err := resourcelock.New(vca.LeaderElection.ResourceLock,
@@ -433,6 +463,8 @@ func (vca *VirtControllerApp) Run() {
 		golog.Fatal(err)
 	}
 
+	http.Handle(leaderelectionconfig.StatusPath, leaderelectionconfig.NewStatusHandler(vca.host, vca.LeaderElection, leaderElector))
+
 	readyGauge.Set(1)
 	leaderElector.Run(vca.ctx)
 	readyGauge.Set(0)
@@ -452,6 +484,9 @@ func (vca *VirtControllerApp) onStartedLeading() func(ctx context.Context) {
 
 		vmiprom.SetupVMICollector(vca.vmiInformer)
 		perfscale.RegisterPerfScaleMetrics(vca.vmiInformer)
+		vmstats.SetupVMSLOCollector(vca.vmInformer)
+		vmstats.SetupVMInfoCollector(vca.vmInformer)
+		migrationstats.SetupMigrationCollector(vca.migrationInformer)
 
 		go vca.evacuationController.Run(vca.evacuationControllerThreads, stop)
 		go vca.disruptionBudgetController.Run(vca.disruptionBudgetControllerThreads, stop)
@@ -459,6 +494,9 @@ func (vca *VirtControllerApp) onStartedLeading() func(ctx context.Context) {
 		go vca.vmiController.Run(vca.vmiControllerThreads, stop)
 		go vca.rsController.Run(vca.rsControllerThreads, stop)
 		go vca.vmController.Run(vca.vmControllerThreads, stop)
+		go vca.poolController.Run(vca.poolControllerThreads, stop)
+		go vca.loadBalancerController.Run(vca.loadBalancerControllerThreads, stop)
+		go vca.storageCapabilitiesController.Run(1, stop)
 		go vca.migrationController.Run(vca.migrationControllerThreads, stop)
 		go vca.snapshotController.Run(vca.snapshotControllerThreads, stop)
 		go vca.restoreController.Run(vca.restoreControllerThreads, stop)
@@ -472,7 +510,7 @@ func (vca *VirtControllerApp) onStartedLeading() func(ctx context.Context) {
 }
 
 func (vca *VirtControllerApp) getNewRecorder(namespace string, componentName string) record.EventRecorder {
-	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster := controller.NewEventBroadcaster()
 	eventBroadcaster.StartRecordingToSink(&k8coresv1.EventSinkImpl{Interface: vca.clientSet.CoreV1().Events(namespace)})
 	return eventBroadcaster.NewRecorder(scheme.Scheme, k8sv1.EventSource{Component: componentName})
 }
@@ -511,6 +549,7 @@ func (vca *VirtControllerApp) initCommon() {
 		vca.clientSet,
 		vca.dataVolumeInformer,
 		topologyHinter,
+		vca.clusterConfig,
 	)
 
 	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "node-controller")
@@ -533,7 +572,7 @@ func (vca *VirtControllerApp) initCommon() {
 
 func (vca *VirtControllerApp) initReplicaSet() {
 	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "virtualmachinereplicaset-controller")
-	vca.rsController = NewVMIReplicaSet(vca.vmiInformer, vca.rsInformer, recorder, vca.clientSet, controller.BurstReplicas)
+	vca.rsController = NewVMIReplicaSet(vca.vmiInformer, vca.rsInformer, vca.kvServiceInformer, recorder, vca.clientSet, controller.BurstReplicas)
 }
 
 func (vca *VirtControllerApp) initVirtualMachines() {
@@ -545,8 +584,24 @@ func (vca *VirtControllerApp) initVirtualMachines() {
 		vca.dataVolumeInformer,
 		vca.persistentVolumeClaimInformer,
 		vca.controllerRevisionInformer,
+		vca.nodeInformer,
 		recorder,
-		vca.clientSet)
+		vca.clientSet,
+		vca.clusterConfig)
+}
+
+func (vca *VirtControllerApp) initPool() {
+	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "virtualmachinepool-controller")
+	vca.poolController = NewPoolController(vca.vmInformer, vca.vmiInformer, vca.poolInformer, vca.kvServiceInformer, recorder, vca.clientSet, controller.BurstReplicas)
+}
+
+func (vca *VirtControllerApp) initLoadBalancerController() {
+	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "loadbalancer-controller")
+	vca.loadBalancerController = NewLoadBalancerController(vca.vmInformer, vca.vmiInformer, vca.kvServiceInformer, recorder, vca.clientSet, vca.clusterConfig)
+}
+
+func (vca *VirtControllerApp) initStorageCapabilitiesController() {
+	vca.storageCapabilitiesController = NewStorageCapabilitiesController(vca.storageClassInformer, vca.storageProfileInformer, vca.storageCapabilitiesInformer, vca.clientSet, vca.kubevirtNamespace)
 }
 
 func (vca *VirtControllerApp) initDisruptionBudgetController() {
@@ -688,6 +743,12 @@ func (vca *VirtControllerApp) AddFlags() {
 	flag.IntVar(&vca.vmControllerThreads, "vm-controller-threads", defaultControllerThreads,
 		"Number of goroutines to run for vm controller")
 
+	flag.IntVar(&vca.poolControllerThreads, "pool-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for virtual machine pool controller")
+
+	flag.IntVar(&vca.loadBalancerControllerThreads, "load-balancer-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for load balancer controller")
+
 	flag.IntVar(&vca.migrationControllerThreads, "migration-controller-threads", defaultControllerThreads,
 		"Number of goroutines to run for migration controller")
 
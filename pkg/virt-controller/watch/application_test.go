@@ -40,11 +40,13 @@ import (
 	k8sv1 "k8s.io/api/core/v1"
 	kubev1 "k8s.io/api/core/v1"
 	"k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/tools/record"
 
 	io_prometheus_client "github.com/prometheus/client_model/go"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	poolv1 "kubevirt.io/client-go/apis/pool/v1alpha1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 	"kubevirt.io/client-go/kubecli"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1beta1"
@@ -92,14 +94,17 @@ var _ = Describe("Application", func() {
 		crInformer, _ := testutils.NewFakeInformerFor(&appsv1.ControllerRevision{})
 		dataVolumeInformer, _ := testutils.NewFakeInformerFor(&cdiv1.DataVolume{})
 		rsInformer, _ := testutils.NewFakeInformerFor(&v1.VirtualMachineInstanceReplicaSet{})
+		poolInformer, _ := testutils.NewFakeInformerFor(&poolv1.VirtualMachinePool{})
 		storageClassInformer, _ := testutils.NewFakeInformerFor(&storagev1.StorageClass{})
 		crdInformer, _ := testutils.NewFakeInformerFor(&extv1.CustomResourceDefinition{})
 		vmRestoreInformer, _ := testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineRestore{})
 		dvInformer, _ := testutils.NewFakeInformerFor(&cdiv1.DataVolume{})
+		serviceInformer, _ := testutils.NewFakeInformerFor(&k8sv1.Service{})
 
 		var qemuGid int64 = 107
 
 		app.vmiInformer = vmiInformer
+		app.vmInformer = vmInformer
 		app.nodeTopologyUpdater = topologyUpdater
 		app.informerFactory = controller.NewKubeInformerFactory(nil, nil, nil, "test")
 		app.evacuationController = evacuation.NewEvacuationController(vmiInformer, migrationInformer, nodeInformer, podInformer, recorder, virtClient, config)
@@ -114,9 +119,15 @@ var _ = Describe("Application", func() {
 			virtClient,
 			dataVolumeInformer,
 			topology.NewTopologyHinter(&cache.FakeCustomStore{}, &cache.FakeCustomStore{}, "amd64", nil),
+			config,
 		)
-		app.rsController = NewVMIReplicaSet(vmiInformer, rsInformer, recorder, virtClient, uint(10))
-		app.vmController = NewVMController(vmiInformer, vmInformer, dataVolumeInformer, pvcInformer, crInformer, recorder, virtClient)
+		app.rsController = NewVMIReplicaSet(vmiInformer, rsInformer, serviceInformer, recorder, virtClient, uint(10))
+		app.vmController = NewVMController(vmiInformer, vmInformer, dataVolumeInformer, pvcInformer, crInformer, nodeInformer, recorder, virtClient, config)
+		app.poolController = NewPoolController(vmInformer, vmiInformer, poolInformer, serviceInformer, recorder, virtClient, uint(10))
+		app.loadBalancerController = NewLoadBalancerController(vmInformer, vmiInformer, serviceInformer, recorder, virtClient, config)
+		storageProfileInformer, _ := testutils.NewFakeInformerFor(&unstructured.Unstructured{})
+		storageCapabilitiesInformer, _ := testutils.NewFakeInformerFor(&k8sv1.ConfigMap{})
+		app.storageCapabilitiesController = NewStorageCapabilitiesController(storageClassInformer, storageProfileInformer, storageCapabilitiesInformer, virtClient, "test")
 		app.migrationController = NewMigrationController(services.NewTemplateService("a", 240, "b", "c", "d", "e", "f", "g", pvcInformer.GetStore(), virtClient, config, qemuGid),
 			vmiInformer,
 			podInformer,
@@ -279,6 +279,21 @@ var _ = Describe("Disruptionbudget", func() {
 			testutils.ExpectEvent(recorder, disruptionbudget.SuccessfulCreatePodDisruptionBudgetReason)
 		})
 
+		It("should add the pdb only for migratable VMIs with the LiveMigrateIfPossible strategy", func() {
+			strategy := v1.EvictionStrategyLiveMigrateIfPossible
+			vmi := newVirtualMachine()
+			vmi.Spec.EvictionStrategy = &strategy
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{{Type: v1.VirtualMachineInstanceIsMigratable, Status: corev1.ConditionFalse}}
+			addVirtualMachine(vmi)
+			controller.Execute()
+
+			vmi.Status.Conditions[0].Status = corev1.ConditionTrue
+			vmiFeeder.Modify(vmi)
+			shouldExpectPDBCreation(vmi.UID)
+			controller.Execute()
+			testutils.ExpectEvent(recorder, disruptionbudget.SuccessfulCreatePodDisruptionBudgetReason)
+		})
+
 		It("should recreate the pdb, if it disappears", func() {
 			vmi := newVirtualMachine()
 			vmi.Spec.EvictionStrategy = newEvictionStrategy()
@@ -3,6 +3,7 @@ package evacuation
 import (
 	"fmt"
 	"math"
+	"sort"
 	"sync"
 	"time"
 
@@ -404,7 +405,8 @@ func (c *EvacuationController) sync(node *k8sv1.Node, vmisOnNode []*virtv1.Virtu
 		return nil
 	}
 
-	// TODO: should the order be randomized?
+	// Evacuate critical VMIs first, best-effort VMIs wait for the next free spot
+	c.sortByPriority(migrationCandidates)
 	selectedCandidates := migrationCandidates[0:diff]
 
 	log.DefaultLogger().Infof("node: %v, migrations: %v, candidates: %v, selected: %v", node.Name, len(activeMigrations), len(migrationCandidates), len(selectedCandidates))
@@ -440,6 +442,26 @@ func (c *EvacuationController) sync(node *k8sv1.Node, vmisOnNode []*virtv1.Virtu
 	return nil
 }
 
+// sortByPriority orders the VMIs by the priority of their virt-launcher pods, highest first.
+// The priority is resolved by the scheduler from the priorityClassName of the VMI.
+func (c *EvacuationController) sortByPriority(vmis []*virtv1.VirtualMachineInstance) {
+	priorities := make(map[*virtv1.VirtualMachineInstance]int32, len(vmis))
+	for _, vmi := range vmis {
+		priorities[vmi] = c.podPriority(vmi)
+	}
+	sort.SliceStable(vmis, func(i, j int) bool {
+		return priorities[vmis[i]] > priorities[vmis[j]]
+	})
+}
+
+func (c *EvacuationController) podPriority(vmi *virtv1.VirtualMachineInstance) int32 {
+	pod, err := controller.CurrentVMIPod(vmi, c.vmiPodInformer)
+	if err != nil || pod == nil || pod.Spec.Priority == nil {
+		return 0
+	}
+	return *pod.Spec.Priority
+}
+
 func hasMigratedOnEviction(vmi *virtv1.VirtualMachineInstance) bool {
 	return vmi.Status.NodeName != vmi.Status.EvacuationNodeName
 }
@@ -479,12 +501,15 @@ func (c *EvacuationController) filterRunningNonMigratingVMIs(vmis []*virtv1.Virt
 		}
 
 		// does not want to migrate
-		if vmi.Spec.EvictionStrategy == nil || *vmi.Spec.EvictionStrategy != virtv1.EvictionStrategyLiveMigrate {
+		if !vmi.IsEvictable() && !vmi.IsEvictableIfPossible() {
 			continue
 		}
 		// can't migrate
 		if !controller.NewVirtualMachineInstanceConditionManager().HasConditionWithStatus(vmi, virtv1.VirtualMachineInstanceIsMigratable, k8sv1.ConditionTrue) {
-			nonMigrateable = append(nonMigrateable, vmi)
+			// it is fine to evict the vmi if it only wants to migrate when possible
+			if !vmi.IsEvictableIfPossible() {
+				nonMigrateable = append(nonMigrateable, vmi)
+			}
 			continue
 		}
 
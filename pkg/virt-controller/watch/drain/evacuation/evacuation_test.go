@@ -7,6 +7,7 @@ import (
 	v12 "k8s.io/api/core/v1"
 	v13 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
@@ -179,6 +180,30 @@ var _ = Describe("Evacuation", func() {
 			)
 		})
 
+		It("should migrate VMIs with the LiveMigrateIfPossible strategy only if they are migratable", func() {
+			node := newNode("testnode")
+			node1 := newNode("anothernode")
+			node.Spec.Taints = append(node.Spec.Taints, *newTaint())
+			addNode(node)
+			addNode(node1)
+
+			strategy := v1.EvictionStrategyLiveMigrateIfPossible
+			vmi := newVirtualMachine("testvm", node.Name)
+			vmi.Spec.EvictionStrategy = &strategy
+			vmiFeeder.Add(vmi)
+
+			vmi1 := newVirtualMachine("testvm1", node.Name)
+			vmi1.Spec.EvictionStrategy = &strategy
+			vmi1.Status.Conditions = []v1.VirtualMachineInstanceCondition{{Type: v1.VirtualMachineInstanceIsMigratable, Status: v12.ConditionFalse}}
+			vmiFeeder.Add(vmi1)
+
+			migrationInterface.EXPECT().Create(gomock.Any()).Return(&v1.VirtualMachineInstanceMigration{ObjectMeta: v13.ObjectMeta{Name: "something"}}, nil)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, evacuation.SuccessfulCreateVirtualMachineInstanceMigrationReason)
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
 		It("should not evict VMIs if 5 migrations are in progress", func() {
 			node := newNode("testnode")
 			node.Spec.Taints = append(node.Spec.Taints, *newTaint())
@@ -229,6 +254,45 @@ var _ = Describe("Evacuation", func() {
 			controller.Execute()
 			testutils.ExpectEvent(recorder, evacuation.SuccessfulCreateVirtualMachineInstanceMigrationReason)
 		})
+
+		It("should evacuate the VMI with the highest priority first", func() {
+			node := newNode("testnode")
+			node.Spec.Taints = append(node.Spec.Taints, *newTaint())
+			addNode(node)
+
+			priorities := map[string]int32{"besteffort": -10, "critical": 1000, "default": 0}
+			for name, priority := range priorities {
+				vmi := newVirtualMachine(name, node.Name)
+				vmi.UID = types.UID(name)
+				vmi.Spec.EvictionStrategy = newEvictionStrategy()
+				pod := newPod(vmi, name+"-pod", v12.PodRunning, true)
+				pod.Spec.NodeName = node.Name
+				podPriority := priority
+				pod.Spec.Priority = &podPriority
+				podSource.Add(pod)
+				vmiFeeder.Add(vmi)
+			}
+			// pods do not cause the queue to get added to
+			// we just use them for caching purposes
+			// so wait for cache to catch up with a brief sleep
+			time.Sleep(1 * time.Second)
+
+			// the running migrations leave one free spot
+			otherVMI := newVirtualMachine("othervm", node.Name)
+			otherVMI.Spec.EvictionStrategy = newEvictionStrategy()
+			vmiFeeder.Add(otherVMI)
+			migrationFeeder.Add(newMigration("mig1", "othervm", v1.MigrationRunning))
+			migrationFeeder.Add(newMigration("mig2", "othervm", v1.MigrationRunning))
+			migrationFeeder.Add(newMigration("mig3", "othervm", v1.MigrationRunning))
+			migrationFeeder.Add(newMigration("mig4", "othervm", v1.MigrationRunning))
+
+			migrationInterface.EXPECT().Create(gomock.Any()).Do(func(migration *v1.VirtualMachineInstanceMigration) {
+				Expect(migration.Spec.VMIName).To(Equal("critical"))
+			}).Return(&v1.VirtualMachineInstanceMigration{ObjectMeta: v13.ObjectMeta{Name: "something"}}, nil)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, evacuation.SuccessfulCreateVirtualMachineInstanceMigrationReason)
+		})
 	})
 
 	Context("VMIs marked for eviction", func() {
@@ -0,0 +1,173 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"context"
+	"fmt"
+
+	k8score "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+)
+
+// Reasons for headless service events
+const (
+	// SuccessfulCreateServiceReason is added in an event when the headless service of a replica set
+	// or a pool is successfully created.
+	SuccessfulCreateServiceReason = "SuccessfulCreateService"
+	// FailedCreateServiceReason is added in an event when the headless service of a replica set
+	// or a pool failed to be created, or a service with the same name which is not controlled by it exists.
+	FailedCreateServiceReason = "FailedCreateService"
+	// SuccessfulDeleteServiceReason is added in an event when a headless service which is no longer
+	// needed by a replica set or a pool is successfully deleted.
+	SuccessfulDeleteServiceReason = "SuccessfulDeleteService"
+)
+
+// headlessServiceAppLabelValue marks the services created by virt-controller, so that the
+// KubeVirtService informer picks them up
+const headlessServiceAppLabelValue = "headless-service"
+
+type headlessServiceOwner interface {
+	metav1.Object
+	runtime.Object
+}
+
+// headlessServiceControl keeps the headless services, which give VirtualMachineInstances predictable
+// DNS records through their hostname and subdomain, in sync for replica sets and pools
+type headlessServiceControl struct {
+	clientset       kubecli.KubevirtClient
+	serviceInformer cache.SharedIndexInformer
+	recorder        record.EventRecorder
+}
+
+// sync makes sure that the only service controlled by the owner is a headless service named after
+// the subdomain which selects the given labels. An empty subdomain removes all services of the owner.
+func (h *headlessServiceControl) sync(owner headlessServiceOwner, ownerRef metav1.OwnerReference, subdomain string, selector map[string]string) error {
+	services, err := h.listServicesOf(owner)
+	if err != nil {
+		return err
+	}
+
+	var current *k8score.Service
+	for _, service := range services {
+		if service.Name == subdomain {
+			current = service
+			continue
+		}
+		err := h.clientset.CoreV1().Services(service.Namespace).Delete(context.Background(), service.Name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		h.recorder.Eventf(owner, k8score.EventTypeNormal, SuccessfulDeleteServiceReason, "Deleted headless service %s", service.Name)
+	}
+
+	if subdomain == "" {
+		return nil
+	}
+
+	if current != nil {
+		if equality.Semantic.DeepEqual(current.Spec.Selector, selector) {
+			return nil
+		}
+		current = current.DeepCopy()
+		current.Spec.Selector = selector
+		_, err := h.clientset.CoreV1().Services(current.Namespace).Update(context.Background(), current, metav1.UpdateOptions{})
+		return err
+	}
+
+	service := newHeadlessService(owner, ownerRef, subdomain, selector)
+	_, err = h.clientset.CoreV1().Services(service.Namespace).Create(context.Background(), service, metav1.CreateOptions{})
+	if errors.IsAlreadyExists(err) {
+		// the service is not ours, don't fight with its owner over it
+		h.recorder.Eventf(owner, k8score.EventTypeWarning, FailedCreateServiceReason, "Service %s already exists and is not controlled by %s", subdomain, owner.GetName())
+		return nil
+	} else if err != nil {
+		h.recorder.Eventf(owner, k8score.EventTypeWarning, FailedCreateServiceReason, "Error creating headless service %s: %v", subdomain, err)
+		return err
+	}
+	h.recorder.Eventf(owner, k8score.EventTypeNormal, SuccessfulCreateServiceReason, "Created headless service %s", subdomain)
+	return nil
+}
+
+func (h *headlessServiceControl) listServicesOf(owner headlessServiceOwner) ([]*k8score.Service, error) {
+	objs, err := h.serviceInformer.GetIndexer().ByIndex(cache.NamespaceIndex, owner.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	var services []*k8score.Service
+	for _, obj := range objs {
+		service := obj.(*k8score.Service)
+		if ref := metav1.GetControllerOf(service); ref != nil && ref.UID == owner.GetUID() {
+			services = append(services, service)
+		}
+	}
+	return services, nil
+}
+
+func newHeadlessService(owner headlessServiceOwner, ownerRef metav1.OwnerReference, name string, selector map[string]string) *k8score.Service {
+	return &k8score.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: owner.GetNamespace(),
+			Labels: map[string]string{
+				virtv1.AppLabel:       headlessServiceAppLabelValue,
+				virtv1.CreatedByLabel: string(owner.GetUID()),
+			},
+			OwnerReferences: []metav1.OwnerReference{ownerRef},
+		},
+		Spec: k8score.ServiceSpec{
+			ClusterIP: k8score.ClusterIPNone,
+			Selector:  selector,
+			// VirtualMachineInstances are resolvable as soon as they are scheduled,
+			// independent of their readiness probes
+			PublishNotReadyAddresses: true,
+		},
+	}
+}
+
+// enqueueServiceOwner calls enqueue with the key of the owner of a headless service with the given kind
+func enqueueServiceOwner(obj interface{}, kind string, enqueue func(key string)) {
+	service, ok := obj.(*k8score.Service)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			log.Log.Reason(fmt.Errorf("couldn't get object from tombstone %+v", obj)).Error("Failed to process delete notification")
+			return
+		}
+		if service, ok = tombstone.Obj.(*k8score.Service); !ok {
+			log.Log.Reason(fmt.Errorf("tombstone contained object that is not a service %#v", obj)).Error("Failed to process delete notification")
+			return
+		}
+	}
+	ref := metav1.GetControllerOf(service)
+	if ref == nil || ref.Kind != kind {
+		return
+	}
+	enqueue(service.Namespace + "/" + ref.Name)
+}
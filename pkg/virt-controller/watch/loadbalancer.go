@@ -0,0 +1,435 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	k8score "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/controller"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+// Reasons for load balancer events
+const (
+	// SuccessfulCreateLoadBalancerReason is added in an event when the load balancer service of a
+	// virtual machine is successfully created.
+	SuccessfulCreateLoadBalancerReason = "SuccessfulCreateLoadBalancer"
+	// FailedCreateLoadBalancerReason is added in an event when the load balancer service of a virtual
+	// machine failed to be created, or a service with the same name which is not controlled by it exists.
+	FailedCreateLoadBalancerReason = "FailedCreateLoadBalancer"
+	// SuccessfulDeleteLoadBalancerReason is added in an event when the load balancer service of a
+	// virtual machine which is no longer annotated is successfully deleted.
+	SuccessfulDeleteLoadBalancerReason = "SuccessfulDeleteLoadBalancer"
+	// InvalidLoadBalancerReason is added in an event when the annotations of a virtual machine
+	// don't describe a load balancer service which can be created.
+	InvalidLoadBalancerReason = "InvalidLoadBalancer"
+)
+
+const (
+	// loadBalancerAppLabelValue marks the load balancer services created by virt-controller, so that
+	// the KubeVirtService informer picks them up
+	loadBalancerAppLabelValue = "load-balancer"
+	loadBalancerServiceSuffix = "-lb"
+	// maxLoadBalancerPorts bounds the port ranges of the ports annotation, cloud providers
+	// create one forwarding rule per port
+	maxLoadBalancerPorts = 100
+)
+
+// LoadBalancerController gives VirtualMachines annotated with kubevirt.io/load-balancer a dedicated
+// Service of type LoadBalancer. The Service is owned by the VirtualMachine and keeps its external IP
+// while the VirtualMachine is restarted, only its selector follows the current VirtualMachineInstance.
+type LoadBalancerController struct {
+	clientset       kubecli.KubevirtClient
+	Queue           workqueue.RateLimitingInterface
+	vmInformer      cache.SharedIndexInformer
+	vmiInformer     cache.SharedIndexInformer
+	serviceInformer cache.SharedIndexInformer
+	recorder        record.EventRecorder
+	clusterConfig   *virtconfig.ClusterConfig
+}
+
+func NewLoadBalancerController(vmInformer cache.SharedIndexInformer, vmiInformer cache.SharedIndexInformer, serviceInformer cache.SharedIndexInformer, recorder record.EventRecorder, clientset kubecli.KubevirtClient, clusterConfig *virtconfig.ClusterConfig) *LoadBalancerController {
+
+	c := &LoadBalancerController{
+		Queue:           workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-load-balancer"),
+		vmInformer:      vmInformer,
+		vmiInformer:     vmiInformer,
+		serviceInformer: serviceInformer,
+		recorder:        recorder,
+		clientset:       clientset,
+		clusterConfig:   clusterConfig,
+	}
+
+	c.vmInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueVirtualMachine,
+		DeleteFunc: c.enqueueVirtualMachine,
+		UpdateFunc: func(_, curr interface{}) { c.enqueueVirtualMachine(curr) },
+	})
+
+	c.vmiInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueVirtualMachineInstance,
+		DeleteFunc: c.enqueueVirtualMachineInstance,
+		UpdateFunc: func(_, curr interface{}) { c.enqueueVirtualMachineInstance(curr) },
+	})
+
+	c.serviceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueServiceOwner,
+		DeleteFunc: c.enqueueServiceOwner,
+		UpdateFunc: func(_, curr interface{}) { c.enqueueServiceOwner(curr) },
+	})
+
+	return c
+}
+
+func (c *LoadBalancerController) Run(threadiness int, stopCh <-chan struct{}) {
+	defer controller.HandlePanic()
+	defer c.Queue.ShutDown()
+	log.Log.Info("Starting load balancer controller.")
+
+	// Wait for cache sync before we start the controller
+	cache.WaitForCacheSync(stopCh, c.vmInformer.HasSynced, c.vmiInformer.HasSynced, c.serviceInformer.HasSynced)
+
+	// Start the actual work
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	log.Log.Info("Stopping load balancer controller.")
+}
+
+func (c *LoadBalancerController) runWorker() {
+	for c.Execute() {
+	}
+}
+
+func (c *LoadBalancerController) Execute() bool {
+	key, quit := c.Queue.Get()
+	if quit {
+		return false
+	}
+	defer c.Queue.Done(key)
+	if err := c.execute(key.(string)); err != nil {
+		log.Log.Reason(err).Infof("re-enqueuing load balancer of VirtualMachine %v", key)
+		c.Queue.AddRateLimited(key)
+	} else {
+		log.Log.V(4).Infof("processed load balancer of VirtualMachine %v", key)
+		c.Queue.Forget(key)
+	}
+	return true
+}
+
+func (c *LoadBalancerController) execute(key string) error {
+	if !c.clusterConfig.VMLoadBalancerEnabled() {
+		return nil
+	}
+
+	obj, exists, err := c.vmInformer.GetStore().GetByKey(key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		// the service is garbage collected through its owner reference
+		return nil
+	}
+	vm := obj.(*virtv1.VirtualMachine)
+
+	services, err := c.listServicesOf(vm)
+	if err != nil {
+		return err
+	}
+
+	var vmi *virtv1.VirtualMachineInstance
+	obj, exists, err = c.vmiInformer.GetStore().GetByKey(key)
+	if err != nil {
+		return err
+	}
+	if exists && metav1.IsControlledBy(obj.(*virtv1.VirtualMachineInstance), vm) {
+		vmi = obj.(*virtv1.VirtualMachineInstance)
+	}
+
+	var desired *k8score.Service
+	if vm.DeletionTimestamp == nil && vm.Annotations[virtv1.LoadBalancerAnnotation] == "true" {
+		desired, err = newLoadBalancerService(vm, vmi)
+		if err != nil {
+			// nothing changes until the virtual machine is updated
+			c.recorder.Eventf(vm, k8score.EventTypeWarning, InvalidLoadBalancerReason, "Invalid load balancer: %v", err)
+			return nil
+		}
+	}
+
+	var current *k8score.Service
+	for _, service := range services {
+		if desired != nil && service.Name == desired.Name {
+			current = service
+			continue
+		}
+		err := c.clientset.CoreV1().Services(service.Namespace).Delete(context.Background(), service.Name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		c.recorder.Eventf(vm, k8score.EventTypeNormal, SuccessfulDeleteLoadBalancerReason, "Deleted load balancer service %s", service.Name)
+	}
+
+	if desired == nil {
+		return nil
+	}
+
+	if current != nil {
+		return c.update(current, desired)
+	}
+
+	_, err = c.clientset.CoreV1().Services(desired.Namespace).Create(context.Background(), desired, metav1.CreateOptions{})
+	if errors.IsAlreadyExists(err) {
+		// the service is not ours, don't fight with its owner over it
+		c.recorder.Eventf(vm, k8score.EventTypeWarning, FailedCreateLoadBalancerReason, "Service %s already exists and is not controlled by %s", desired.Name, vm.Name)
+		return nil
+	} else if err != nil {
+		c.recorder.Eventf(vm, k8score.EventTypeWarning, FailedCreateLoadBalancerReason, "Error creating load balancer service %s: %v", desired.Name, err)
+		return err
+	}
+	c.recorder.Eventf(vm, k8score.EventTypeNormal, SuccessfulCreateLoadBalancerReason, "Created load balancer service %s", desired.Name)
+	return nil
+}
+
+// update brings the ports, the selector and the requested IP of the current service in line with
+// the desired service. The node ports which were already allocated are kept.
+func (c *LoadBalancerController) update(current *k8score.Service, desired *k8score.Service) error {
+	nodePorts := map[string]int32{}
+	for _, port := range current.Spec.Ports {
+		nodePorts[fmt.Sprintf("%d/%s", port.Port, port.Protocol)] = port.NodePort
+	}
+	for i, port := range desired.Spec.Ports {
+		desired.Spec.Ports[i].NodePort = nodePorts[fmt.Sprintf("%d/%s", port.Port, port.Protocol)]
+	}
+
+	if desired.Spec.Selector == nil {
+		// without an instance there are no endpoints anyway, keep the selector of the last instance
+		// instead of leaving stale endpoints behind an unmanaged service
+		desired.Spec.Selector = current.Spec.Selector
+	}
+
+	if current.Spec.Type == desired.Spec.Type &&
+		current.Spec.LoadBalancerIP == desired.Spec.LoadBalancerIP &&
+		equality.Semantic.DeepEqual(current.Spec.Selector, desired.Spec.Selector) &&
+		equality.Semantic.DeepEqual(current.Spec.Ports, desired.Spec.Ports) {
+		return nil
+	}
+
+	service := current.DeepCopy()
+	service.Spec.Type = desired.Spec.Type
+	service.Spec.LoadBalancerIP = desired.Spec.LoadBalancerIP
+	service.Spec.Selector = desired.Spec.Selector
+	service.Spec.Ports = desired.Spec.Ports
+	_, err := c.clientset.CoreV1().Services(service.Namespace).Update(context.Background(), service, metav1.UpdateOptions{})
+	return err
+}
+
+func newLoadBalancerService(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) (*k8score.Service, error) {
+	name := vm.Name + loadBalancerServiceSuffix
+	if errs := validation.IsDNS1035Label(name); len(errs) > 0 {
+		return nil, fmt.Errorf("service name %s is invalid: %s", name, strings.Join(errs, ", "))
+	}
+
+	var ports []k8score.ServicePort
+	if spec, ok := vm.Annotations[virtv1.LoadBalancerPortsAnnotation]; ok {
+		var err error
+		if ports, err = parseLoadBalancerPorts(spec); err != nil {
+			return nil, err
+		}
+	} else if vm.Spec.Template != nil {
+		ports = loadBalancerPortsOfInterfaces(vm.Spec.Template.Spec.Domain.Devices.Interfaces)
+	}
+	if len(ports) == 0 {
+		return nil, fmt.Errorf("no ports to expose, declare ports on the interfaces or set the %s annotation", virtv1.LoadBalancerPortsAnnotation)
+	}
+
+	// the selector is only set once the virtual machine has an instance, the launcher pod
+	// of the instance is selected through its unique created-by label
+	var selector map[string]string
+	if vmi != nil {
+		selector = map[string]string{virtv1.CreatedByLabel: string(vmi.UID)}
+	}
+
+	return &k8score.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: vm.Namespace,
+			Labels: map[string]string{
+				virtv1.AppLabel:       loadBalancerAppLabelValue,
+				virtv1.CreatedByLabel: string(vm.UID),
+			},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(vm, virtv1.VirtualMachineGroupVersionKind)},
+		},
+		Spec: k8score.ServiceSpec{
+			Type:           k8score.ServiceTypeLoadBalancer,
+			LoadBalancerIP: vm.Annotations[virtv1.LoadBalancerIPAnnotation],
+			Selector:       selector,
+			Ports:          ports,
+		},
+	}, nil
+}
+
+// parseLoadBalancerPorts parses a comma separated list of ports and port ranges with
+// an optional protocol, e.g. "22,80,8000-8010/UDP". The protocol defaults to TCP.
+func parseLoadBalancerPorts(spec string) ([]k8score.ServicePort, error) {
+	var ports []k8score.ServicePort
+	seen := map[string]bool{}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		protocol := k8score.ProtocolTCP
+		if i := strings.Index(entry, "/"); i >= 0 {
+			switch strings.ToUpper(entry[i+1:]) {
+			case string(k8score.ProtocolTCP):
+			case string(k8score.ProtocolUDP):
+				protocol = k8score.ProtocolUDP
+			case string(k8score.ProtocolSCTP):
+				protocol = k8score.ProtocolSCTP
+			default:
+				return nil, fmt.Errorf("invalid protocol in port %q", entry)
+			}
+			entry = entry[:i]
+		}
+
+		bounds := strings.SplitN(entry, "-", 2)
+		start, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q", entry)
+		}
+		end := start
+		if len(bounds) == 2 {
+			if end, err = strconv.Atoi(bounds[1]); err != nil {
+				return nil, fmt.Errorf("invalid port %q", entry)
+			}
+		}
+		if start < 1 || end > 65535 || start > end {
+			return nil, fmt.Errorf("port %q is out of the range 1-65535", entry)
+		}
+		if len(ports)+end-start+1 > maxLoadBalancerPorts {
+			return nil, fmt.Errorf("at most %d ports can be exposed", maxLoadBalancerPorts)
+		}
+
+		for port := start; port <= end; port++ {
+			ports = appendLoadBalancerPort(ports, seen, int32(port), protocol)
+		}
+	}
+	return ports, nil
+}
+
+// loadBalancerPortsOfInterfaces returns the ports declared on the interfaces of the guest
+func loadBalancerPortsOfInterfaces(interfaces []virtv1.Interface) []k8score.ServicePort {
+	var ports []k8score.ServicePort
+	seen := map[string]bool{}
+	for _, iface := range interfaces {
+		for _, port := range iface.Ports {
+			protocol := k8score.ProtocolTCP
+			if port.Protocol != "" {
+				protocol = k8score.Protocol(strings.ToUpper(port.Protocol))
+			}
+			ports = appendLoadBalancerPort(ports, seen, port.Port, protocol)
+		}
+	}
+	return ports
+}
+
+func appendLoadBalancerPort(ports []k8score.ServicePort, seen map[string]bool, port int32, protocol k8score.Protocol) []k8score.ServicePort {
+	name := fmt.Sprintf("%s-%d", strings.ToLower(string(protocol)), port)
+	if seen[name] {
+		return ports
+	}
+	seen[name] = true
+	return append(ports, k8score.ServicePort{
+		Name:       name,
+		Protocol:   protocol,
+		Port:       port,
+		TargetPort: intstr.FromInt(int(port)),
+	})
+}
+
+func (c *LoadBalancerController) listServicesOf(vm *virtv1.VirtualMachine) ([]*k8score.Service, error) {
+	objs, err := c.serviceInformer.GetIndexer().ByIndex(cache.NamespaceIndex, vm.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	var services []*k8score.Service
+	for _, obj := range objs {
+		service := obj.(*k8score.Service)
+		if service.Labels[virtv1.AppLabel] != loadBalancerAppLabelValue {
+			continue
+		}
+		if ref := metav1.GetControllerOf(service); ref != nil && ref.UID == vm.UID {
+			services = append(services, service)
+		}
+	}
+	return services, nil
+}
+
+func (c *LoadBalancerController) enqueueVirtualMachine(obj interface{}) {
+	key, err := controller.KeyFunc(obj)
+	if err != nil {
+		log.Log.Reason(err).Error("Failed to extract key from VirtualMachine.")
+		return
+	}
+	c.Queue.Add(key)
+}
+
+func (c *LoadBalancerController) enqueueVirtualMachineInstance(obj interface{}) {
+	vmi, ok := obj.(*virtv1.VirtualMachineInstance)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			log.Log.Reason(fmt.Errorf("couldn't get object from tombstone %+v", obj)).Error("Failed to process delete notification")
+			return
+		}
+		if vmi, ok = tombstone.Obj.(*virtv1.VirtualMachineInstance); !ok {
+			log.Log.Reason(fmt.Errorf("tombstone contained object that is not a VirtualMachineInstance %#v", obj)).Error("Failed to process delete notification")
+			return
+		}
+	}
+	ref := metav1.GetControllerOf(vmi)
+	if ref == nil || ref.Kind != virtv1.VirtualMachineGroupVersionKind.Kind {
+		return
+	}
+	c.Queue.Add(vmi.Namespace + "/" + ref.Name)
+}
+
+func (c *LoadBalancerController) enqueueServiceOwner(obj interface{}) {
+	enqueueServiceOwner(obj, virtv1.VirtualMachineGroupVersionKind.Kind, func(key string) {
+		c.Queue.Add(key)
+	})
+}
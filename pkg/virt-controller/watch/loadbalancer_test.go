@@ -0,0 +1,236 @@
+package watch
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	framework "k8s.io/client-go/tools/cache/testing"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+var _ = Describe("LoadBalancer", func() {
+
+	table.DescribeTable("should parse the ports annotation", func(spec string, expected []string) {
+		ports, err := parseLoadBalancerPorts(spec)
+		Expect(err).ToNot(HaveOccurred())
+		var names []string
+		for _, port := range ports {
+			names = append(names, port.Name)
+		}
+		Expect(names).To(Equal(expected))
+	},
+		table.Entry("with single ports", "22, 80", []string{"tcp-22", "tcp-80"}),
+		table.Entry("with a range", "8000-8002", []string{"tcp-8000", "tcp-8001", "tcp-8002"}),
+		table.Entry("with protocols", "53/udp,53/TCP,9/SCTP", []string{"udp-53", "tcp-53", "sctp-9"}),
+		table.Entry("with duplicates", "80,79-81", []string{"tcp-80", "tcp-79", "tcp-81"}),
+	)
+
+	table.DescribeTable("should reject the ports annotation", func(spec string) {
+		_, err := parseLoadBalancerPorts(spec)
+		Expect(err).To(HaveOccurred())
+	},
+		table.Entry("with an invalid port", "http"),
+		table.Entry("with an out of range port", "70000"),
+		table.Entry("with an inverted range", "90-80"),
+		table.Entry("with an unknown protocol", "80/ICMP"),
+		table.Entry("with too many ports", "1000-1100"),
+	)
+
+	Context("One valid LoadBalancer controller given", func() {
+
+		var ctrl *gomock.Controller
+		var vmSource *framework.FakeControllerSource
+		var vmInformer cache.SharedIndexInformer
+		var vmiInformer cache.SharedIndexInformer
+		var serviceInformer cache.SharedIndexInformer
+		var k8sClient *k8sfake.Clientset
+		var stop chan struct{}
+		var controller *LoadBalancerController
+		var recorder *record.FakeRecorder
+		var mockQueue *testutils.MockWorkQueue
+		var vm *v1.VirtualMachine
+		var vmi *v1.VirtualMachineInstance
+
+		syncCaches := func(stop chan struct{}) {
+			go vmInformer.Run(stop)
+			go vmiInformer.Run(stop)
+			go serviceInformer.Run(stop)
+			Expect(cache.WaitForCacheSync(stop, vmInformer.HasSynced, vmiInformer.HasSynced, serviceInformer.HasSynced)).To(BeTrue())
+		}
+
+		BeforeEach(func() {
+			stop = make(chan struct{})
+			ctrl = gomock.NewController(GinkgoT())
+			virtClient := kubecli.NewMockKubevirtClient(ctrl)
+
+			vmInformer, vmSource = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+			vmiInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
+			serviceInformer, _ = testutils.NewFakeInformerFor(&k8sv1.Service{})
+			recorder = record.NewFakeRecorder(100)
+			recorder.IncludeObject = true
+			k8sClient = k8sfake.NewSimpleClientset()
+			config, _, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+				DeveloperConfiguration: &v1.DeveloperConfiguration{
+					FeatureGates: []string{virtconfig.VMLoadBalancerGate},
+				},
+			})
+
+			controller = NewLoadBalancerController(vmInformer, vmiInformer, serviceInformer, recorder, virtClient, config)
+			// Wrap our workqueue to have a way to detect when we are done processing updates
+			mockQueue = testutils.NewMockWorkQueue(controller.Queue)
+			controller.Queue = mockQueue
+
+			virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
+			syncCaches(stop)
+
+			vm, vmi = DefaultVirtualMachine(true)
+			vmi.UID = "vmi-uid"
+			vm.Annotations = map[string]string{v1.LoadBalancerAnnotation: "true"}
+			vm.Spec.Template.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+				Name:  "default",
+				Ports: []v1.Port{{Port: 22}, {Port: 53, Protocol: "UDP"}},
+			}}
+		})
+
+		addVirtualMachine := func(vm *v1.VirtualMachine) {
+			mockQueue.ExpectAdds(1)
+			vmSource.Add(vm)
+			mockQueue.Wait()
+		}
+
+		addService := func(service *k8sv1.Service) {
+			Expect(serviceInformer.GetIndexer().Add(service)).To(Succeed())
+			_, err := k8sClient.CoreV1().Services(service.Namespace).Create(context.Background(), service, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		getService := func() (*k8sv1.Service, error) {
+			return k8sClient.CoreV1().Services(vm.Namespace).Get(context.Background(), vm.Name+"-lb", metav1.GetOptions{})
+		}
+
+		It("should create a load balancer for the ports of the interfaces", func() {
+			Expect(vmiInformer.GetIndexer().Add(vmi)).To(Succeed())
+			addVirtualMachine(vm)
+
+			controller.Execute()
+
+			service, err := getService()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(service.Spec.Type).To(Equal(k8sv1.ServiceTypeLoadBalancer))
+			Expect(service.Spec.Selector).To(Equal(map[string]string{v1.CreatedByLabel: "vmi-uid"}))
+			Expect(service.Spec.Ports).To(HaveLen(2))
+			Expect(service.Spec.Ports[0].Name).To(Equal("tcp-22"))
+			Expect(service.Spec.Ports[1].Name).To(Equal("udp-53"))
+			Expect(service.Spec.Ports[1].Protocol).To(Equal(k8sv1.ProtocolUDP))
+			Expect(metav1.IsControlledBy(service, vm)).To(BeTrue())
+			testutils.ExpectEvent(recorder, SuccessfulCreateLoadBalancerReason)
+		})
+
+		It("should create a load balancer for the ports annotation with the requested IP", func() {
+			vm.Annotations[v1.LoadBalancerPortsAnnotation] = "80,443"
+			vm.Annotations[v1.LoadBalancerIPAnnotation] = "203.0.113.10"
+			addVirtualMachine(vm)
+
+			controller.Execute()
+
+			service, err := getService()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(service.Spec.LoadBalancerIP).To(Equal("203.0.113.10"))
+			Expect(service.Spec.Selector).To(BeNil())
+			Expect(service.Spec.Ports).To(HaveLen(2))
+			Expect(service.Spec.Ports[0].Port).To(BeEquivalentTo(80))
+			Expect(service.Spec.Ports[1].Port).To(BeEquivalentTo(443))
+			testutils.ExpectEvent(recorder, SuccessfulCreateLoadBalancerReason)
+		})
+
+		It("should select the new instance and keep the allocated node ports", func() {
+			service, err := newLoadBalancerService(vm, nil)
+			Expect(err).ToNot(HaveOccurred())
+			service.Spec.Selector = map[string]string{v1.CreatedByLabel: "old-vmi-uid"}
+			service.Spec.Ports[0].NodePort = 30022
+			addService(service)
+			Expect(vmiInformer.GetIndexer().Add(vmi)).To(Succeed())
+			addVirtualMachine(vm)
+
+			controller.Execute()
+
+			service, err = getService()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(service.Spec.Selector).To(Equal(map[string]string{v1.CreatedByLabel: "vmi-uid"}))
+			Expect(service.Spec.Ports[0].NodePort).To(BeEquivalentTo(30022))
+		})
+
+		It("should keep the selector while the virtual machine has no instance", func() {
+			service, err := newLoadBalancerService(vm, vmi)
+			Expect(err).ToNot(HaveOccurred())
+			addService(service)
+			addVirtualMachine(vm)
+
+			controller.Execute()
+
+			service, err = getService()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(service.Spec.Selector).To(Equal(map[string]string{v1.CreatedByLabel: "vmi-uid"}))
+		})
+
+		It("should delete the load balancer when the annotation is removed", func() {
+			service, err := newLoadBalancerService(vm, vmi)
+			Expect(err).ToNot(HaveOccurred())
+			addService(service)
+			delete(vm.Annotations, v1.LoadBalancerAnnotation)
+			addVirtualMachine(vm)
+
+			controller.Execute()
+
+			_, err = getService()
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			testutils.ExpectEvent(recorder, SuccessfulDeleteLoadBalancerReason)
+		})
+
+		It("should not take over a service it does not control", func() {
+			addService(&k8sv1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: vm.Name + "-lb", Namespace: vm.Namespace},
+			})
+			addVirtualMachine(vm)
+
+			controller.Execute()
+
+			Expect(mockQueue.GetRateLimitedEnqueueCount()).To(Equal(0))
+			service, err := getService()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(service.OwnerReferences).To(BeEmpty())
+			testutils.ExpectEvent(recorder, FailedCreateLoadBalancerReason)
+		})
+
+		It("should report a virtual machine without ports", func() {
+			vm.Spec.Template.Spec.Domain.Devices.Interfaces = nil
+			addVirtualMachine(vm)
+
+			controller.Execute()
+
+			_, err := getService()
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			testutils.ExpectEvent(recorder, InvalidLoadBalancerReason)
+		})
+
+		AfterEach(func() {
+			close(stop)
+			// Ensure that we add checks for expected events to every test
+			Expect(recorder.Events).To(BeEmpty())
+			ctrl.Finish()
+		})
+	})
+})
@@ -30,6 +30,7 @@ import (
 
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
@@ -49,6 +50,7 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/monitoring/migrationstats"
 	kubevirttypes "kubevirt.io/kubevirt/pkg/util/types"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
 )
@@ -59,6 +61,20 @@ const (
 	failedUpdatePodDisruptionBudgetReason     = "FailedUpdate"
 )
 
+// reasons of failed migrations, reported in kubevirt_migrate_vmi_failed_total
+const (
+	migrationFailureVMIDoesNotExist          = "VMIDoesNotExist"
+	migrationFailureVMIShutdown              = "VMIShutdown"
+	migrationFailureTargetPodShutdown        = "TargetPodShutdown"
+	migrationFailureTargetPodRemoved         = "TargetPodRemoved"
+	migrationFailureAttachmentPodShutdown    = "TargetAttachmentPodShutdown"
+	migrationFailureStateCleared             = "MigrationStateCleared"
+	migrationFailureStateTakenOver           = "MigrationStateTakenOver"
+	migrationFailureSourceNodeReported       = "SourceNodeReportedFailure"
+	migrationFailureOtherMigrationInProgress = "OtherMigrationInProgress"
+	migrationFailurePVCNotShared             = "PVCNotShared"
+)
+
 type MigrationController struct {
 	templateService    services.TemplateService
 	clientset          kubecli.KubevirtClient
@@ -328,6 +344,29 @@ func (c *MigrationController) canMigrateVMI(migration *virtv1.VirtualMachineInst
 
 }
 
+// findNonSharedPVC returns the first PVC of the VMI which can't be accessed by the source
+// and the target pod at the same time. PVCs which are not in the cache are left to virt-handler.
+func (c *MigrationController) findNonSharedPVC(vmi *virtv1.VirtualMachineInstance) (*kubevirttypes.PVCNotSharedError, error) {
+	for i := range vmi.Spec.Volumes {
+		volume := &vmi.Spec.Volumes[i]
+		claimName := kubevirttypes.PVCNameFromVirtVolume(volume)
+		if claimName == "" {
+			continue
+		}
+		pvc, exists, _, err := kubevirttypes.IsPVCBlockFromStore(c.pvcInformer.GetStore(), vmi.Namespace, claimName)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+		if !kubevirttypes.HasSharedAccessMode(pvc.Spec.AccessModes) {
+			return &kubevirttypes.PVCNotSharedError{VolumeName: volume.Name, ClaimName: claimName, AccessModes: pvc.Spec.AccessModes}, nil
+		}
+	}
+	return nil, nil
+}
+
 func (c *MigrationController) updateStatus(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance, pods []*k8sv1.Pod) error {
 
 	var pod *k8sv1.Pod = nil
@@ -349,6 +388,9 @@ func (c *MigrationController) updateStatus(migration *virtv1.VirtualMachineInsta
 		}
 	}
 
+	// failureReason is reported in the metrics if the migration fails
+	failureReason := ""
+
 	// Remove the finalizer and conditions if the migration has already completed
 	if migration.IsFinal() {
 		controller.RemoveFinalizer(migrationCopy, virtv1.VirtualMachineInstanceMigrationFinalizer)
@@ -360,22 +402,35 @@ func (c *MigrationController) updateStatus(migration *virtv1.VirtualMachineInsta
 		// 3. Begin progressing migration state based on VMI's MigrationState status.
 	} else if vmi == nil {
 		migrationCopy.Status.Phase = virtv1.MigrationFailed
+		failureReason = migrationFailureVMIDoesNotExist
 		c.recorder.Eventf(migration, k8sv1.EventTypeWarning, FailedMigrationReason, "Migration failed because vmi does not exist.")
 		log.Log.Object(migration).Error("vmi does not exist")
 	} else if vmi.IsFinal() {
 		migrationCopy.Status.Phase = virtv1.MigrationFailed
+		failureReason = migrationFailureVMIShutdown
 		c.recorder.Eventf(migration, k8sv1.EventTypeWarning, FailedMigrationReason, "Migration failed vmi shutdown during migration.")
 		log.Log.Object(migration).Error("Unable to migrate vmi because vmi is shutdown.")
+	} else if migration.DeletionTimestamp != nil && !migration.TargetIsHandedOff() {
+		// virt-handler is not involved yet, there is nothing to abort on the nodes.
+		// The migration is aborted once handleTargetPodDeletion removed the target pod.
+		if !podExists {
+			migrationCopy.Status.Phase = virtv1.MigrationAborted
+			c.recorder.Eventf(migration, k8sv1.EventTypeNormal, AbortedMigrationReason, "Migration aborted before the target pod was handed off")
+			log.Log.Object(migration).Info("Migration deleted before the target pod was handed off.")
+		}
 	} else if podExists && podIsDown(pod) {
 		migrationCopy.Status.Phase = virtv1.MigrationFailed
+		failureReason = migrationFailureTargetPodShutdown
 		c.recorder.Eventf(migration, k8sv1.EventTypeWarning, FailedMigrationReason, "Migration failed because target pod shutdown during migration")
 		log.Log.Object(migration).Errorf("target pod %s/%s shutdown during migration", pod.Namespace, pod.Name)
 	} else if migration.TargetIsCreated() && !podExists {
 		migrationCopy.Status.Phase = virtv1.MigrationFailed
+		failureReason = migrationFailureTargetPodRemoved
 		c.recorder.Eventf(migration, k8sv1.EventTypeWarning, FailedMigrationReason, "Migration target pod was removed during active migration.")
 		log.Log.Object(migration).Error("target pod disappeared during migration")
 	} else if migration.TargetIsHandedOff() && vmi.Status.MigrationState == nil {
 		migrationCopy.Status.Phase = virtv1.MigrationFailed
+		failureReason = migrationFailureStateCleared
 		c.recorder.Eventf(migration, k8sv1.EventTypeWarning, FailedMigrationReason, "VMI's migration state was cleared during the active migration.")
 		log.Log.Object(migration).Error("vmi migration state cleared during migration")
 	} else if migration.TargetIsHandedOff() &&
@@ -383,13 +438,23 @@ func (c *MigrationController) updateStatus(migration *virtv1.VirtualMachineInsta
 		vmi.Status.MigrationState.MigrationUID != migration.UID {
 
 		migrationCopy.Status.Phase = virtv1.MigrationFailed
+		failureReason = migrationFailureStateTakenOver
 		c.recorder.Eventf(migration, k8sv1.EventTypeWarning, FailedMigrationReason, "VMI's migration state was taken over by another migration job during active migration.")
 		log.Log.Object(migration).Error("vmi's migration state was taken over by another migration object")
+	} else if vmi.Status.MigrationState != nil &&
+		vmi.Status.MigrationState.MigrationUID == migration.UID &&
+		vmi.Status.MigrationState.Failed &&
+		vmi.Status.MigrationState.AbortStatus == virtv1.MigrationAbortSucceeded {
+
+		migrationCopy.Status.Phase = virtv1.MigrationAborted
+		c.recorder.Eventf(migration, k8sv1.EventTypeNormal, AbortedMigrationReason, "Source node reported migration aborted")
+		log.Log.Object(migration).Infof("VMI %s/%s reported migration aborted.", vmi.Namespace, vmi.Name)
 	} else if vmi.Status.MigrationState != nil &&
 		vmi.Status.MigrationState.MigrationUID == migration.UID &&
 		vmi.Status.MigrationState.Failed {
 
 		migrationCopy.Status.Phase = virtv1.MigrationFailed
+		failureReason = migrationFailureSourceNodeReported
 		c.recorder.Eventf(migration, k8sv1.EventTypeWarning, FailedMigrationReason, "Source node reported migration failed")
 		log.Log.Object(migration).Errorf("VMI %s/%s reported migration failed.", vmi.Namespace, vmi.Name)
 	} else if migration.DeletionTimestamp != nil && !migration.IsFinal() &&
@@ -402,6 +467,7 @@ func (c *MigrationController) updateStatus(migration *virtv1.VirtualMachineInsta
 		migrationCopy.Status.Conditions = append(migrationCopy.Status.Conditions, condition)
 	} else if attachmentPodExists && podIsDown(attachmentPod) {
 		migrationCopy.Status.Phase = virtv1.MigrationFailed
+		failureReason = migrationFailureAttachmentPodShutdown
 		c.recorder.Eventf(migration, k8sv1.EventTypeWarning, FailedMigrationReason, "Migration failed because target attachment pod shutdown during migration")
 		log.Log.Object(migration).Errorf("target attachment pod %s/%s shutdown during migration", attachmentPod.Namespace, attachmentPod.Name)
 	} else {
@@ -412,15 +478,26 @@ func (c *MigrationController) updateStatus(migration *virtv1.VirtualMachineInsta
 			if err != nil {
 				return err
 			}
+			pvcNotShared, err := c.findNonSharedPVC(vmi)
+			if err != nil {
+				return err
+			}
 
-			if canMigrate {
-				migrationCopy.Status.Phase = virtv1.MigrationPending
-			} else {
+			if !canMigrate {
 				// can not migrate because there is an active migration already
 				// in progress for this VMI.
 				migrationCopy.Status.Phase = virtv1.MigrationFailed
+				failureReason = migrationFailureOtherMigrationInProgress
 				c.recorder.Eventf(migration, k8sv1.EventTypeWarning, FailedMigrationReason, "VMI is not eligible for migration because another migration job is in progress.")
 				log.Log.Object(migration).Error("Migration object ont eligible for migration because another job is in progress")
+			} else if pvcNotShared != nil {
+				// the target pod could not attach the PVC while the source pod still uses it
+				migrationCopy.Status.Phase = virtv1.MigrationFailed
+				failureReason = migrationFailurePVCNotShared
+				c.recorder.Eventf(migration, k8sv1.EventTypeWarning, FailedMigrationReason, "VMI is not eligible for migration: %v", pvcNotShared)
+				log.Log.Object(migration).Errorf("VMI is not eligible for migration: %v", pvcNotShared)
+			} else {
+				migrationCopy.Status.Phase = virtv1.MigrationPending
 			}
 		case virtv1.MigrationPending:
 			if podExists {
@@ -469,6 +546,9 @@ func (c *MigrationController) updateStatus(migration *virtv1.VirtualMachineInsta
 		if err != nil {
 			return err
 		}
+		if !migration.IsFinal() && migrationCopy.IsFinal() {
+			migrationstats.ObserveFinishedMigration(migrationCopy, vmi, failureReason, time.Now())
+		}
 	} else if !reflect.DeepEqual(migration.Finalizers, migrationCopy.Finalizers) {
 		_, err := c.clientset.VirtualMachineInstanceMigration(migrationCopy.Namespace).Update(migrationCopy)
 		if err != nil {
@@ -628,6 +708,24 @@ func (c *MigrationController) handleTargetPodHandoff(migration *virtv1.VirtualMa
 	return nil
 }
 
+// handleTargetPodDeletion removes the target pods of a migration which is aborted before they are handed off
+func (c *MigrationController) handleTargetPodDeletion(key string, migration *virtv1.VirtualMachineInstanceMigration, pods []*k8sv1.Pod) error {
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		c.podExpectations.ExpectDeletions(key, []string{controller.PodKey(pod)})
+		err := c.clientset.CoreV1().Pods(pod.Namespace).Delete(context.Background(), pod.Name, v1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			c.podExpectations.DeletionObserved(key, controller.PodKey(pod))
+			c.recorder.Eventf(migration, k8sv1.EventTypeWarning, FailedDeletePodReason, "Error deleting migration target pod: %v", err)
+			return fmt.Errorf("failed to delete migration target pod: %v", err)
+		}
+		c.recorder.Eventf(migration, k8sv1.EventTypeNormal, SuccessfulDeletePodReason, "Deleted migration target pod %s", pod.Name)
+	}
+	return nil
+}
+
 func (c *MigrationController) handleSignalMigrationAbort(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance) error {
 
 	vmiCopy := vmi.DeepCopy()
@@ -806,6 +904,10 @@ func (c *MigrationController) sync(key string, migration *virtv1.VirtualMachineI
 		return nil
 	}
 
+	if migration.DeletionTimestamp != nil && !migration.TargetIsHandedOff() {
+		return c.handleTargetPodDeletion(key, migration, pods)
+	}
+
 	canMigrate, err := c.canMigrateVMI(migration, vmi)
 	if err != nil {
 		return err
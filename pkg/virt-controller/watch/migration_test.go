@@ -181,6 +181,13 @@ var _ = Describe("Migration watcher", func() {
 		})
 	}
 
+	shouldExpectMigrationAbortedState := func(migration *v1.VirtualMachineInstanceMigration) {
+		migrationInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(arg interface{}) (interface{}, interface{}) {
+			Expect(arg.(*v1.VirtualMachineInstanceMigration).Status.Phase).To(Equal(v1.MigrationAborted))
+			return arg, nil
+		})
+	}
+
 	shouldExpectVirtualMachineInstancePatch := func(vmi *v1.VirtualMachineInstance, patch string) {
 		vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, []byte(patch)).Return(vmi, nil)
 	}
@@ -674,6 +681,33 @@ var _ = Describe("Migration watcher", func() {
 			table.Entry("in scheduling state", v1.MigrationScheduling),
 			table.Entry("in target ready state", v1.MigrationTargetReady),
 		)
+		It("the VMI has a PVC which is not shared", func() {
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "rootdisk",
+					VolumeSource: v1.VolumeSource{
+						DataVolume: &v1.DataVolumeSource{Name: "rootdv"},
+					},
+				},
+			}
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationPhaseUnset)
+			Expect(pvcInformer.GetIndexer().Add(&k8sv1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "rootdv", Namespace: vmi.Namespace},
+				Spec: k8sv1.PersistentVolumeClaimSpec{
+					AccessModes: []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteOnce},
+				},
+			})).To(Succeed())
+
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+
+			shouldExpectMigrationFailedState(migration)
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, FailedMigrationReason)
+		})
 	})
 	Context("Migration object ", func() {
 
@@ -923,6 +957,77 @@ var _ = Describe("Migration watcher", func() {
 			controller.Execute()
 			testutils.ExpectEvent(recorder, SuccessfulAbortMigrationReason)
 		})
+		It("should transition to aborted phase once the source node aborted the migration", func() {
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			vmi.Status.NodeName = "node02"
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationRunning)
+			migration.DeletionTimestamp = now()
+			pod := newTargetPodForVirtualMachine(vmi, migration, k8sv1.PodRunning)
+			pod.Spec.NodeName = "node01"
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+				MigrationUID:      migration.UID,
+				TargetNode:        "node01",
+				SourceNode:        "node02",
+				TargetNodeAddress: "10.10.10.10:1234",
+				StartTimestamp:    now(),
+				EndTimestamp:      now(),
+				AbortRequested:    true,
+				AbortStatus:       v1.MigrationAbortSucceeded,
+				Failed:            true,
+			}
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			podFeeder.Add(pod)
+
+			shouldExpectMigrationAbortedState(migration)
+			controller.Execute()
+			testutils.ExpectEvent(recorder, AbortedMigrationReason)
+		})
+		table.DescribeTable("should remove the target pod of a deleted migration before the target is handed off", func(phase v1.VirtualMachineInstanceMigrationPhase) {
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			migration := newMigration("testmigration", vmi.Name, phase)
+			migration.DeletionTimestamp = now()
+			pod := newTargetPodForVirtualMachine(vmi, migration, k8sv1.PodRunning)
+
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			podFeeder.Add(pod)
+
+			deleted := false
+			kubeClient.Fake.PrependReactor("delete", "pods", func(action testing.Action) (handled bool, obj k8sruntime.Object, err error) {
+				Expect(action.(testing.DeleteAction).GetName()).To(Equal(pod.Name))
+				deleted = true
+				return true, nil, nil
+			})
+
+			// the migration is aborted once the target pod is gone
+			controller.Execute()
+
+			Expect(deleted).To(BeTrue())
+			testutils.ExpectEvent(recorder, SuccessfulDeletePodReason)
+		},
+			table.Entry("in pending state", v1.MigrationPending),
+			table.Entry("in scheduling state", v1.MigrationScheduling),
+			table.Entry("in scheduled state", v1.MigrationScheduled),
+		)
+		table.DescribeTable("should abort a deleted migration once its target pod is removed", func(phase v1.VirtualMachineInstanceMigrationPhase) {
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			migration := newMigration("testmigration", vmi.Name, phase)
+			migration.DeletionTimestamp = now()
+
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+
+			shouldExpectMigrationAbortedState(migration)
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, AbortedMigrationReason)
+		},
+			table.Entry("in pending state", v1.MigrationPending),
+			table.Entry("in scheduling state", v1.MigrationScheduling),
+			table.Entry("in scheduled state", v1.MigrationScheduled),
+		)
 		table.DescribeTable("should finalize migration on VMI if target pod fails before migration starts", func(phase v1.VirtualMachineInstanceMigrationPhase, hasPod bool, podPhase k8sv1.PodPhase, initializeMigrationState bool) {
 			vmi := newVirtualMachine("testvmi", v1.Running)
 			vmi.Status.NodeName = "node02"
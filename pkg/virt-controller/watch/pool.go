@@ -0,0 +1,871 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	k8score "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+	poolv1 "kubevirt.io/client-go/apis/pool/v1alpha1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/controller"
+)
+
+// Reasons for pool events
+const (
+	// SuccessfulUpdateVirtualMachineReason is added in an event when a virtual machine of a pool
+	// is successfully updated to the current pool template.
+	SuccessfulUpdateVirtualMachineReason = "SuccessfulUpdate"
+	// FailedUpdateVirtualMachineReason is added in an event and in a pool condition
+	// when a virtual machine of a pool failed to be updated to the current pool template.
+	FailedUpdateVirtualMachineReason = "FailedUpdate"
+	// SuccessfulRestartVirtualMachineReason is added in an event when the instance of an updated
+	// virtual machine of a pool is deleted, so that it comes back with the current pool template.
+	SuccessfulRestartVirtualMachineReason = "SuccessfulRestart"
+	// FailedRestartVirtualMachineReason is added in an event and in a pool condition
+	// when the instance of an updated virtual machine of a pool failed to be deleted.
+	FailedRestartVirtualMachineReason = "FailedRestart"
+	// SuccessfulPausedPoolReason is added in an event when the pool discovered that it
+	// should be paused.
+	SuccessfulPausedPoolReason = "SuccessfulPaused"
+	// SuccessfulResumedPoolReason is added in an event when the pool discovered that it
+	// should be resumed.
+	SuccessfulResumedPoolReason = "SuccessfulResumed"
+)
+
+func NewPoolController(vmInformer cache.SharedIndexInformer, vmiInformer cache.SharedIndexInformer, poolInformer cache.SharedIndexInformer, serviceInformer cache.SharedIndexInformer, recorder record.EventRecorder, clientset kubecli.KubevirtClient, burstReplicas uint) *PoolController {
+
+	c := &PoolController{
+		Queue:           workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-pool"),
+		vmInformer:      vmInformer,
+		vmiInformer:     vmiInformer,
+		poolInformer:    poolInformer,
+		serviceInformer: serviceInformer,
+		recorder:        recorder,
+		clientset:       clientset,
+		expectations:    controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
+		burstReplicas:   burstReplicas,
+		headlessServices: &headlessServiceControl{
+			clientset:       clientset,
+			serviceInformer: serviceInformer,
+			recorder:        recorder,
+		},
+	}
+
+	c.poolInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addPool,
+		DeleteFunc: c.deletePool,
+		UpdateFunc: c.updatePool,
+	})
+
+	c.vmInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addVirtualMachine,
+		DeleteFunc: c.deleteVirtualMachine,
+		UpdateFunc: c.updateVirtualMachine,
+	})
+
+	c.vmiInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueVMIPool,
+		DeleteFunc: c.enqueueVMIPool,
+		UpdateFunc: func(_, curr interface{}) { c.enqueueVMIPool(curr) },
+	})
+
+	c.serviceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueServiceOwner,
+		DeleteFunc: c.enqueueServiceOwner,
+		UpdateFunc: func(_, curr interface{}) { c.enqueueServiceOwner(curr) },
+	})
+
+	return c
+}
+
+type PoolController struct {
+	clientset        kubecli.KubevirtClient
+	Queue            workqueue.RateLimitingInterface
+	vmInformer       cache.SharedIndexInformer
+	vmiInformer      cache.SharedIndexInformer
+	poolInformer     cache.SharedIndexInformer
+	serviceInformer  cache.SharedIndexInformer
+	recorder         record.EventRecorder
+	expectations     *controller.UIDTrackingControllerExpectations
+	burstReplicas    uint
+	headlessServices *headlessServiceControl
+}
+
+func (c *PoolController) Run(threadiness int, stopCh <-chan struct{}) {
+	defer controller.HandlePanic()
+	defer c.Queue.ShutDown()
+	log.Log.Info("Starting VirtualMachinePool controller.")
+
+	// Wait for cache sync before we start the controller
+	cache.WaitForCacheSync(stopCh, c.vmInformer.HasSynced, c.vmiInformer.HasSynced, c.poolInformer.HasSynced, c.serviceInformer.HasSynced)
+
+	// Start the actual work
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	log.Log.Info("Stopping VirtualMachinePool controller.")
+}
+
+func (c *PoolController) runWorker() {
+	for c.Execute() {
+	}
+}
+
+func (c *PoolController) Execute() bool {
+	key, quit := c.Queue.Get()
+	if quit {
+		return false
+	}
+	defer c.Queue.Done(key)
+	if err := c.execute(key.(string)); err != nil {
+		log.Log.Reason(err).Infof("re-enqueuing VirtualMachinePool %v", key)
+		c.Queue.AddRateLimited(key)
+	} else {
+		log.Log.V(4).Infof("processed VirtualMachinePool %v", key)
+		c.Queue.Forget(key)
+	}
+	return true
+}
+
+func (c *PoolController) execute(key string) error {
+
+	obj, exists, err := c.poolInformer.GetStore().GetByKey(key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		// the virtual machines are garbage collected through their owner references
+		c.expectations.DeleteExpectations(key)
+		return nil
+	}
+	pool := obj.(*poolv1.VirtualMachinePool)
+
+	logger := log.Log.Object(pool)
+
+	if pool.Spec.VirtualMachineTemplate == nil || pool.Spec.Selector == nil || len(pool.Spec.VirtualMachineTemplate.ObjectMeta.Labels) == 0 {
+		logger.Error("Invalid controller spec, will not re-enqueue.")
+		return nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(pool.Spec.Selector)
+	if err != nil {
+		logger.Reason(err).Error("Invalid selector on pool, will not re-enqueue.")
+		return nil
+	}
+
+	if !selector.Matches(labels.Set(pool.Spec.VirtualMachineTemplate.ObjectMeta.Labels)) {
+		logger.Error("Selector does not match template labels, will not re-enqueue.")
+		return nil
+	}
+
+	revision, err := poolRevision(pool)
+	if err != nil {
+		logger.Reason(err).Error("Failed to calculate the pool revision, will not re-enqueue.")
+		return nil
+	}
+
+	needsSync := c.expectations.SatisfiedExpectations(key)
+
+	vms, terminating, err := c.listVMsFromPool(pool)
+	if err != nil {
+		logger.Reason(err).Error("Failed to fetch vms for namespace from cache.")
+		return err
+	}
+
+	var syncErr error
+	syncReason := ""
+
+	if needsSync && !pool.Spec.Paused && pool.ObjectMeta.DeletionTimestamp == nil {
+		syncReason, syncErr = c.scale(pool, vms, terminating, revision)
+		if syncErr == nil && c.calcDiff(pool, vms) == 0 {
+			syncReason, syncErr = c.update(pool, vms, revision)
+		}
+	}
+
+	if syncErr != nil {
+		logger.Reason(syncErr).Error("Syncing the pool failed.")
+	}
+
+	var serviceErr error
+	if pool.ObjectMeta.DeletionTimestamp == nil {
+		subdomain, selector := poolHeadlessService(pool)
+		serviceErr = c.headlessServices.sync(pool, poolOwnerRef(pool), subdomain, selector)
+		if serviceErr != nil {
+			logger.Reason(serviceErr).Error("Syncing the headless service of the pool failed.")
+		}
+	}
+
+	err = c.updateStatus(pool.DeepCopy(), vms, revision, syncReason, syncErr)
+	if err != nil {
+		logger.Reason(err).Error("Updating the pool status failed.")
+		if syncErr == nil {
+			return err
+		}
+	}
+
+	if syncErr != nil {
+		return syncErr
+	}
+	return serviceErr
+}
+
+// poolHeadlessService returns the name and the selector of the headless service of the pool,
+// or an empty name if it should not have one
+func poolHeadlessService(pool *poolv1.VirtualMachinePool) (string, map[string]string) {
+	template := pool.Spec.VirtualMachineTemplate.Spec.Template
+	if !pool.Spec.HeadlessService || template == nil {
+		return "", nil
+	}
+	return template.Spec.Subdomain, template.ObjectMeta.Labels
+}
+
+// scale creates the virtual machines with the lowest free indexes when the pool has
+// too few replicas, and removes the ones with the highest indexes when it has too many.
+// The indexes of terminating virtual machines are not free until they are gone.
+func (c *PoolController) scale(pool *poolv1.VirtualMachinePool, vms []*virtv1.VirtualMachine, terminating []*virtv1.VirtualMachine, revision string) (string, error) {
+	diff := c.calcDiff(pool, vms)
+	if diff == 0 {
+		return "", nil
+	}
+
+	poolKey, err := controller.KeyFunc(pool)
+	if err != nil {
+		log.Log.Object(pool).Reason(err).Error("Failed to extract poolKey from pool.")
+		return "", nil
+	}
+
+	// Make sure that we don't overload the cluster
+	diff = limit(diff, c.burstReplicas)
+
+	if diff > 0 {
+		log.Log.V(4).Object(pool).Info("Delete excess VMs")
+		sort.SliceStable(vms, func(i, j int) bool {
+			return poolIndexOf(pool, vms[i]) > poolIndexOf(pool, vms[j])
+		})
+		deleteCandidates := vms[0:diff]
+		keys := []string{}
+		for _, vm := range deleteCandidates {
+			keys = append(keys, controller.VirtualMachineKey(vm))
+		}
+		c.expectations.ExpectDeletions(poolKey, keys)
+		for _, vm := range deleteCandidates {
+			err := c.clientset.VirtualMachine(pool.Namespace).Delete(vm.Name, &metav1.DeleteOptions{})
+			if err != nil {
+				// We can't observe a delete if it was not accepted by the server
+				c.expectations.DeletionObserved(poolKey, controller.VirtualMachineKey(vm))
+				c.recorder.Eventf(pool, k8score.EventTypeWarning, FailedDeleteVirtualMachineReason, "Error deleting virtual machine %s: %v", vm.Name, err)
+				return FailedDeleteVirtualMachineReason, err
+			}
+			c.recorder.Eventf(pool, k8score.EventTypeNormal, SuccessfulDeleteVirtualMachineReason, "Deleted virtual machine %s", vm.Name)
+		}
+		return "", nil
+	}
+
+	log.Log.V(4).Object(pool).Info("Add missing VMs")
+	taken := append(append([]*virtv1.VirtualMachine{}, vms...), terminating...)
+	indexes := freePoolIndexes(pool, taken, abs(diff))
+	c.expectations.ExpectCreations(poolKey, len(indexes))
+	for i, index := range indexes {
+		vm := newPoolVirtualMachine(pool, index, revision)
+		_, err := c.clientset.VirtualMachine(pool.Namespace).Create(vm)
+		if err != nil {
+			// We can't observe the creations which were not accepted by the server
+			c.expectations.LowerExpectations(poolKey, len(indexes)-i, 0)
+			c.recorder.Eventf(pool, k8score.EventTypeWarning, FailedCreateVirtualMachineReason, "Error creating virtual machine %s: %v", vm.Name, err)
+			return FailedCreateVirtualMachineReason, err
+		}
+		c.recorder.Eventf(pool, k8score.EventTypeNormal, SuccessfulCreateVirtualMachineReason, "Created virtual machine %s", vm.Name)
+	}
+	return "", nil
+}
+
+// update rolls the current pool template out to the virtual machines of the pool. Outdated
+// virtual machines are updated right away, but the instances of always running virtual
+// machines are only restarted as long as no more than maxUnavailable virtual machines of
+// the pool are unavailable. All other virtual machines pick the template up on their next start.
+func (c *PoolController) update(pool *poolv1.VirtualMachinePool, vms []*virtv1.VirtualMachine, revision string) (string, error) {
+	for _, vm := range vms {
+		if vm.Labels[poolv1.VirtualMachinePoolRevisionLabel] == revision {
+			continue
+		}
+		updated, err := updatedPoolVirtualMachine(vm, newPoolVirtualMachine(pool, poolIndexOf(pool, vm), revision))
+		if err != nil {
+			return FailedUpdateVirtualMachineReason, err
+		}
+		if _, err := c.clientset.VirtualMachine(pool.Namespace).Update(updated); err != nil {
+			c.recorder.Eventf(pool, k8score.EventTypeWarning, FailedUpdateVirtualMachineReason, "Error updating virtual machine %s: %v", vm.Name, err)
+			return FailedUpdateVirtualMachineReason, err
+		}
+		c.recorder.Eventf(pool, k8score.EventTypeNormal, SuccessfulUpdateVirtualMachineReason, "Updated virtual machine %s", vm.Name)
+	}
+
+	unavailable := 0
+	restartCandidates := []*virtv1.VirtualMachineInstance{}
+	for _, vm := range vms {
+		vmi, err := c.getVMI(vm)
+		if err != nil {
+			return "", err
+		}
+		if !c.isAvailable(vm, vmi) {
+			unavailable++
+		} else if vmi != nil && vmi.Labels[poolv1.VirtualMachinePoolRevisionLabel] != revision && isAlwaysRunning(vm) {
+			restartCandidates = append(restartCandidates, vmi)
+		}
+	}
+
+	maxUnavailable := 1
+	if pool.Spec.MaxUnavailable != nil {
+		maxUnavailable = int(*pool.Spec.MaxUnavailable)
+	}
+
+	for _, vmi := range restartCandidates {
+		if unavailable >= maxUnavailable {
+			break
+		}
+		err := c.clientset.VirtualMachineInstance(pool.Namespace).Delete(vmi.Name, &metav1.DeleteOptions{})
+		if err != nil {
+			c.recorder.Eventf(pool, k8score.EventTypeWarning, FailedRestartVirtualMachineReason, "Error restarting virtual machine %s: %v", vmi.Name, err)
+			return FailedRestartVirtualMachineReason, err
+		}
+		unavailable++
+		c.recorder.Eventf(pool, k8score.EventTypeNormal, SuccessfulRestartVirtualMachineReason, "Restarted virtual machine %s with the current pool template", vmi.Name)
+	}
+	return "", nil
+}
+
+// isAvailable returns false if the virtual machine is supposed to run but its instance is not ready
+func (c *PoolController) isAvailable(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) bool {
+	if vmi == nil {
+		runStrategy, err := vm.RunStrategy()
+		if err != nil {
+			return false
+		}
+		return runStrategy != virtv1.RunStrategyAlways && runStrategy != virtv1.RunStrategyRerunOnFailure
+	}
+	return vmi.DeletionTimestamp == nil && isVMIReady(vmi)
+}
+
+// isAlwaysRunning returns true if the vm controller brings the instance of the virtual machine back after it got deleted
+func isAlwaysRunning(vm *virtv1.VirtualMachine) bool {
+	runStrategy, err := vm.RunStrategy()
+	return err == nil && runStrategy == virtv1.RunStrategyAlways
+}
+
+func isVMIReady(vmi *virtv1.VirtualMachineInstance) bool {
+	return controller.NewVirtualMachineInstanceConditionManager().HasConditionWithStatus(vmi, virtv1.VirtualMachineInstanceConditionType(k8score.PodReady), k8score.ConditionTrue)
+}
+
+func (c *PoolController) getVMI(vm *virtv1.VirtualMachine) (*virtv1.VirtualMachineInstance, error) {
+	obj, exists, err := c.vmiInformer.GetStore().GetByKey(controller.VirtualMachineKey(vm))
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+	vmi := obj.(*virtv1.VirtualMachineInstance)
+	if !metav1.IsControlledBy(vmi, vm) {
+		return nil, nil
+	}
+	return vmi, nil
+}
+
+func (c *PoolController) calcDiff(pool *poolv1.VirtualMachinePool, vms []*virtv1.VirtualMachine) int {
+	wantedReplicas := int32(1)
+	if pool.Spec.Replicas != nil {
+		wantedReplicas = *pool.Spec.Replicas
+	}
+
+	return len(vms) - int(wantedReplicas)
+}
+
+// listVMsFromPool returns all VMs from the VirtualMachine cache which are controlled by the pool,
+// split into the ones which are not being deleted and the terminating ones
+func (c *PoolController) listVMsFromPool(pool *poolv1.VirtualMachinePool) ([]*virtv1.VirtualMachine, []*virtv1.VirtualMachine, error) {
+	objs, err := c.vmInformer.GetIndexer().ByIndex(cache.NamespaceIndex, pool.Namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	vms := []*virtv1.VirtualMachine{}
+	terminating := []*virtv1.VirtualMachine{}
+	for _, obj := range objs {
+		vm := obj.(*virtv1.VirtualMachine)
+		if !metav1.IsControlledBy(vm, pool) {
+			continue
+		}
+		if vm.DeletionTimestamp == nil {
+			vms = append(vms, vm)
+		} else {
+			terminating = append(terminating, vm)
+		}
+	}
+	return vms, terminating, nil
+}
+
+func (c *PoolController) hasCondition(pool *poolv1.VirtualMachinePool, cond poolv1.VirtualMachinePoolConditionType) bool {
+	for _, c := range pool.Status.Conditions {
+		if c.Type == cond {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *PoolController) removeCondition(pool *poolv1.VirtualMachinePool, cond poolv1.VirtualMachinePoolConditionType) {
+	var conds []poolv1.VirtualMachinePoolCondition
+	for _, c := range pool.Status.Conditions {
+		if c.Type == cond {
+			continue
+		}
+		conds = append(conds, c)
+	}
+	pool.Status.Conditions = conds
+}
+
+func (c *PoolController) updateStatus(pool *poolv1.VirtualMachinePool, vms []*virtv1.VirtualMachine, revision string, syncReason string, syncErr error) error {
+	labelSelector, err := metav1.LabelSelectorAsSelector(pool.Spec.Selector)
+	if err != nil {
+		return err
+	}
+
+	readyReplicas := int32(0)
+	updatedReplicas := int32(0)
+	for _, vm := range vms {
+		vmi, err := c.getVMI(vm)
+		if err != nil {
+			return err
+		}
+		if vmi != nil && isVMIReady(vmi) {
+			readyReplicas++
+		}
+		if vm.Labels[poolv1.VirtualMachinePoolRevisionLabel] == revision {
+			updatedReplicas++
+		}
+	}
+
+	pausedMatch := pool.Spec.Paused == c.hasCondition(pool, poolv1.VirtualMachinePoolReplicaPaused)
+
+	status := pool.Status.DeepCopy()
+	status.Replicas = int32(len(vms))
+	status.ReadyReplicas = readyReplicas
+	status.UpdatedReplicas = updatedReplicas
+	status.CurrentRevision = revision
+	status.LabelSelector = labelSelector.String()
+	pool.Status = *status
+
+	// Add/Remove Paused condition
+	c.checkPaused(pool)
+
+	// Add/Remove Failure condition if necessary
+	c.checkFailure(pool, syncReason, syncErr)
+
+	old, exists, err := c.poolInformer.GetStore().Get(pool)
+	if err != nil {
+		return err
+	}
+	if exists && equality.Semantic.DeepEqual(old.(*poolv1.VirtualMachinePool).Status, pool.Status) {
+		return nil
+	}
+
+	_, err = c.clientset.VirtualMachinePool(pool.Namespace).UpdateStatus(context.Background(), pool, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+	// Finally trigger resumed or paused events
+	if !pausedMatch {
+		if pool.Spec.Paused {
+			c.recorder.Eventf(pool, k8score.EventTypeNormal, SuccessfulPausedPoolReason, "Paused")
+		} else {
+			c.recorder.Eventf(pool, k8score.EventTypeNormal, SuccessfulResumedPoolReason, "Resumed")
+		}
+	}
+
+	return nil
+}
+
+func (c *PoolController) checkPaused(pool *poolv1.VirtualMachinePool) {
+	if pool.Spec.Paused && !c.hasCondition(pool, poolv1.VirtualMachinePoolReplicaPaused) {
+		pool.Status.Conditions = append(pool.Status.Conditions, poolv1.VirtualMachinePoolCondition{
+			Type:               poolv1.VirtualMachinePoolReplicaPaused,
+			Reason:             "Paused",
+			Message:            "Controller got paused",
+			LastTransitionTime: metav1.Now(),
+			Status:             k8score.ConditionTrue,
+		})
+	} else if !pool.Spec.Paused && c.hasCondition(pool, poolv1.VirtualMachinePoolReplicaPaused) {
+		c.removeCondition(pool, poolv1.VirtualMachinePoolReplicaPaused)
+	}
+}
+
+func (c *PoolController) checkFailure(pool *poolv1.VirtualMachinePool, syncReason string, syncErr error) {
+	if syncErr != nil && !c.hasCondition(pool, poolv1.VirtualMachinePoolReplicaFailure) {
+		pool.Status.Conditions = append(pool.Status.Conditions, poolv1.VirtualMachinePoolCondition{
+			Type:               poolv1.VirtualMachinePoolReplicaFailure,
+			Reason:             syncReason,
+			Message:            syncErr.Error(),
+			LastTransitionTime: metav1.Now(),
+			Status:             k8score.ConditionTrue,
+		})
+	} else if syncErr == nil && c.hasCondition(pool, poolv1.VirtualMachinePoolReplicaFailure) {
+		c.removeCondition(pool, poolv1.VirtualMachinePoolReplicaFailure)
+	}
+}
+
+// poolRevision hashes the virtual machine template of the pool. Virtual machines
+// which carry a different revision label are rolled to the current template.
+func poolRevision(pool *poolv1.VirtualMachinePool) (string, error) {
+	template, err := json.Marshal(pool.Spec.VirtualMachineTemplate)
+	if err != nil {
+		return "", err
+	}
+	hasher := fnv.New32a()
+	hasher.Write(template)
+	return fmt.Sprintf("%x", hasher.Sum32()), nil
+}
+
+// poolIndexOf extracts the index from the name of a virtual machine of the pool
+func poolIndexOf(pool *poolv1.VirtualMachinePool, vm *virtv1.VirtualMachine) int {
+	index, err := strconv.Atoi(strings.TrimPrefix(vm.Name, pool.Name+"-"))
+	if err != nil {
+		return -1
+	}
+	return index
+}
+
+// freePoolIndexes returns the count lowest indexes which are not taken by any virtual machine of the pool
+func freePoolIndexes(pool *poolv1.VirtualMachinePool, vms []*virtv1.VirtualMachine, count int) []int {
+	taken := map[int]bool{}
+	for _, vm := range vms {
+		taken[poolIndexOf(pool, vm)] = true
+	}
+	indexes := []int{}
+	for index := 0; len(indexes) < count; index++ {
+		if !taken[index] {
+			indexes = append(indexes, index)
+		}
+	}
+	return indexes
+}
+
+func newPoolVirtualMachine(pool *poolv1.VirtualMachinePool, index int, revision string) *virtv1.VirtualMachine {
+	template := pool.Spec.VirtualMachineTemplate.DeepCopy()
+
+	vm := &virtv1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%d", pool.Name, index),
+			Namespace: pool.Namespace,
+		},
+	}
+	vm.ObjectMeta.Labels = template.ObjectMeta.Labels
+	vm.ObjectMeta.Annotations = template.ObjectMeta.Annotations
+	vm.ObjectMeta.Labels[poolv1.VirtualMachinePoolRevisionLabel] = revision
+	vm.ObjectMeta.OwnerReferences = []metav1.OwnerReference{poolOwnerRef(pool)}
+	vm.Spec = template.Spec
+
+	if vm.Spec.Template != nil {
+		if vm.Spec.Template.ObjectMeta.Labels == nil {
+			vm.Spec.Template.ObjectMeta.Labels = map[string]string{}
+		}
+		vm.Spec.Template.ObjectMeta.Labels[poolv1.VirtualMachinePoolRevisionLabel] = revision
+		injectPoolIndex(&vm.Spec.Template.Spec, index)
+		uniquePoolDataVolumes(&vm.Spec, index)
+	}
+	return vm
+}
+
+// updatedPoolVirtualMachine merges the desired labels, annotations and spec of a virtual machine of
+// the pool into the existing one. What was added to the virtual machine outside of the template is
+// kept, like the labels and annotations of other tools, the fields the template does not set and the
+// MAC addresses assigned to its interfaces.
+func updatedPoolVirtualMachine(vm *virtv1.VirtualMachine, desired *virtv1.VirtualMachine) (*virtv1.VirtualMachine, error) {
+	updated := vm.DeepCopy()
+	updated.Labels = mergeStringMaps(vm.Labels, desired.Labels)
+	updated.Annotations = mergeStringMaps(vm.Annotations, desired.Annotations)
+
+	current, err := json.Marshal(vm.Spec)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := json.Marshal(desired.Spec)
+	if err != nil {
+		return nil, err
+	}
+	merged, err := jsonpatch.MergePatch(current, patch)
+	if err != nil {
+		return nil, err
+	}
+	updated.Spec = virtv1.VirtualMachineSpec{}
+	if err := json.Unmarshal(merged, &updated.Spec); err != nil {
+		return nil, err
+	}
+
+	if updated.Spec.Template != nil && vm.Spec.Template != nil {
+		macAddresses := map[string]string{}
+		for _, iface := range vm.Spec.Template.Spec.Domain.Devices.Interfaces {
+			macAddresses[iface.Name] = iface.MacAddress
+		}
+		interfaces := updated.Spec.Template.Spec.Domain.Devices.Interfaces
+		for i := range interfaces {
+			if interfaces[i].MacAddress == "" {
+				interfaces[i].MacAddress = macAddresses[interfaces[i].Name]
+			}
+		}
+	}
+	return updated, nil
+}
+
+func mergeStringMaps(current map[string]string, desired map[string]string) map[string]string {
+	if len(current) == 0 && len(desired) == 0 {
+		return current
+	}
+	merged := map[string]string{}
+	for key, value := range current {
+		merged[key] = value
+	}
+	for key, value := range desired {
+		merged[key] = value
+	}
+	return merged
+}
+
+// uniquePoolDataVolumes suffixes the data volume templates of the virtual machine with its
+// index, so that every virtual machine of the pool gets its own disks
+func uniquePoolDataVolumes(spec *virtv1.VirtualMachineSpec, index int) {
+	for i := range spec.DataVolumeTemplates {
+		dataVolume := &spec.DataVolumeTemplates[i]
+		name := fmt.Sprintf("%s-%d", dataVolume.Name, index)
+		for j := range spec.Template.Spec.Volumes {
+			volume := &spec.Template.Spec.Volumes[j]
+			if volume.DataVolume != nil && volume.DataVolume.Name == dataVolume.Name {
+				volume.DataVolume.Name = name
+			}
+			if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == dataVolume.Name {
+				volume.PersistentVolumeClaim.ClaimName = name
+			}
+		}
+		dataVolume.Name = name
+	}
+}
+
+// injectPoolIndex replaces the index placeholder in the cloud-init data of the virtual machine
+func injectPoolIndex(spec *virtv1.VirtualMachineInstanceSpec, index int) {
+	replace := func(data *string) {
+		*data = strings.ReplaceAll(*data, poolv1.VirtualMachinePoolIndexPlaceholder, strconv.Itoa(index))
+	}
+	for i := range spec.Volumes {
+		if source := spec.Volumes[i].CloudInitNoCloud; source != nil {
+			replace(&source.UserData)
+			replace(&source.NetworkData)
+		}
+		if source := spec.Volumes[i].CloudInitConfigDrive; source != nil {
+			replace(&source.UserData)
+			replace(&source.NetworkData)
+		}
+	}
+}
+
+func poolOwnerRef(pool *poolv1.VirtualMachinePool) metav1.OwnerReference {
+	t := true
+	gvk := poolv1.SchemeGroupVersion.WithKind("VirtualMachinePool")
+	return metav1.OwnerReference{
+		APIVersion:         gvk.GroupVersion().String(),
+		Kind:               gvk.Kind,
+		Name:               pool.ObjectMeta.Name,
+		UID:                pool.ObjectMeta.UID,
+		Controller:         &t,
+		BlockOwnerDeletion: &t,
+	}
+}
+
+// resolveControllerRef returns the pool referenced by a ControllerRef,
+// or nil if the ControllerRef could not be resolved to a matching pool.
+func (c *PoolController) resolveControllerRef(namespace string, controllerRef *metav1.OwnerReference) *poolv1.VirtualMachinePool {
+	if controllerRef.Kind != "VirtualMachinePool" {
+		return nil
+	}
+	pool, exists, err := c.poolInformer.GetStore().GetByKey(namespace + "/" + controllerRef.Name)
+	if err != nil || !exists {
+		return nil
+	}
+
+	if pool.(*poolv1.VirtualMachinePool).UID != controllerRef.UID {
+		// The pool we found with this Name is not the same one that the
+		// ControllerRef points to.
+		return nil
+	}
+	return pool.(*poolv1.VirtualMachinePool)
+}
+
+// When a vm is created, enqueue the pool that manages it and update its expectations.
+func (c *PoolController) addVirtualMachine(obj interface{}) {
+	vm := obj.(*virtv1.VirtualMachine)
+
+	if vm.DeletionTimestamp != nil {
+		c.deleteVirtualMachine(vm)
+		return
+	}
+
+	controllerRef := metav1.GetControllerOf(vm)
+	if controllerRef == nil {
+		return
+	}
+	pool := c.resolveControllerRef(vm.Namespace, controllerRef)
+	if pool == nil {
+		return
+	}
+	poolKey, err := controller.KeyFunc(pool)
+	if err != nil {
+		return
+	}
+	log.Log.V(4).Object(vm).Infof("VirtualMachine created")
+	c.expectations.CreationObserved(poolKey)
+	c.enqueuePool(pool)
+}
+
+func (c *PoolController) updateVirtualMachine(old, cur interface{}) {
+	curVM := cur.(*virtv1.VirtualMachine)
+	oldVM := old.(*virtv1.VirtualMachine)
+	if curVM.ResourceVersion == oldVM.ResourceVersion {
+		return
+	}
+
+	if curVM.DeletionTimestamp != nil {
+		c.deleteVirtualMachine(curVM)
+		return
+	}
+
+	if controllerRef := metav1.GetControllerOf(curVM); controllerRef != nil {
+		if pool := c.resolveControllerRef(curVM.Namespace, controllerRef); pool != nil {
+			c.enqueuePool(pool)
+		}
+	}
+}
+
+// When a vm is deleted, enqueue the pool that manages the vm and update its expectations.
+// obj could be an *virtv1.VirtualMachine, or a DeletionFinalStateUnknown marker item.
+func (c *PoolController) deleteVirtualMachine(obj interface{}) {
+	vm, ok := obj.(*virtv1.VirtualMachine)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			log.Log.Reason(fmt.Errorf("couldn't get object from tombstone %+v", obj)).Error("Failed to process delete notification")
+			return
+		}
+		vm, ok = tombstone.Obj.(*virtv1.VirtualMachine)
+		if !ok {
+			log.Log.Reason(fmt.Errorf("tombstone contained object that is not a vm %#v", obj)).Error("Failed to process delete notification")
+			return
+		}
+	}
+
+	controllerRef := metav1.GetControllerOf(vm)
+	if controllerRef == nil {
+		return
+	}
+	pool := c.resolveControllerRef(vm.Namespace, controllerRef)
+	if pool == nil {
+		return
+	}
+	poolKey, err := controller.KeyFunc(pool)
+	if err != nil {
+		return
+	}
+	c.expectations.DeletionObserved(poolKey, controller.VirtualMachineKey(vm))
+	c.enqueuePool(pool)
+}
+
+// enqueueVMIPool enqueues the pool which owns the virtual machine of the vmi, since
+// the readiness of its instances drives the status and the rolling update of a pool.
+func (c *PoolController) enqueueVMIPool(obj interface{}) {
+	vmi, ok := obj.(*virtv1.VirtualMachineInstance)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			return
+		}
+		vmi, ok = tombstone.Obj.(*virtv1.VirtualMachineInstance)
+		if !ok {
+			return
+		}
+	}
+
+	if _, isPoolMember := vmi.Labels[poolv1.VirtualMachinePoolRevisionLabel]; !isPoolMember {
+		return
+	}
+
+	obj, exists, err := c.vmInformer.GetStore().GetByKey(controller.VirtualMachineInstanceKey(vmi))
+	if err != nil || !exists {
+		return
+	}
+	vm := obj.(*virtv1.VirtualMachine)
+	if controllerRef := metav1.GetControllerOf(vm); controllerRef != nil {
+		if pool := c.resolveControllerRef(vm.Namespace, controllerRef); pool != nil {
+			c.enqueuePool(pool)
+		}
+	}
+}
+
+func (c *PoolController) enqueueServiceOwner(obj interface{}) {
+	enqueueServiceOwner(obj, "VirtualMachinePool", func(key string) {
+		c.Queue.Add(key)
+	})
+}
+
+func (c *PoolController) addPool(obj interface{}) {
+	c.enqueuePool(obj)
+}
+
+func (c *PoolController) deletePool(obj interface{}) {
+	c.enqueuePool(obj)
+}
+
+func (c *PoolController) updatePool(_, curr interface{}) {
+	c.enqueuePool(curr)
+}
+
+func (c *PoolController) enqueuePool(obj interface{}) {
+	key, err := controller.KeyFunc(obj)
+	if err != nil {
+		log.Log.Reason(err).Error("Failed to extract poolKey from pool.")
+		return
+	}
+	c.Queue.Add(key)
+}
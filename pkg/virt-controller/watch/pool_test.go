@@ -0,0 +1,425 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	framework "k8s.io/client-go/tools/cache/testing"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	poolv1 "kubevirt.io/client-go/apis/pool/v1alpha1"
+	kubevirtfake "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Pool", func() {
+
+	Context("One valid VirtualMachinePool controller given", func() {
+
+		var ctrl *gomock.Controller
+		var vmInterface *kubecli.MockVirtualMachineInterface
+		var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+		var poolClient *kubevirtfake.Clientset
+		var poolSource *framework.FakeControllerSource
+		var vmInformer cache.SharedIndexInformer
+		var vmiInformer cache.SharedIndexInformer
+		var poolInformer cache.SharedIndexInformer
+		var serviceInformer cache.SharedIndexInformer
+		var k8sClient *k8sfake.Clientset
+		var stop chan struct{}
+		var controller *PoolController
+		var recorder *record.FakeRecorder
+		var mockQueue *testutils.MockWorkQueue
+
+		syncCaches := func(stop chan struct{}) {
+			go vmInformer.Run(stop)
+			go vmiInformer.Run(stop)
+			go poolInformer.Run(stop)
+			go serviceInformer.Run(stop)
+			Expect(cache.WaitForCacheSync(stop, vmInformer.HasSynced, vmiInformer.HasSynced, poolInformer.HasSynced, serviceInformer.HasSynced)).To(BeTrue())
+		}
+
+		BeforeEach(func() {
+			stop = make(chan struct{})
+			ctrl = gomock.NewController(GinkgoT())
+			virtClient := kubecli.NewMockKubevirtClient(ctrl)
+			vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+			vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+			poolClient = kubevirtfake.NewSimpleClientset()
+
+			vmInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+			vmiInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
+			poolInformer, poolSource = testutils.NewFakeInformerFor(&poolv1.VirtualMachinePool{})
+			serviceInformer, _ = testutils.NewFakeInformerFor(&k8sv1.Service{})
+			recorder = record.NewFakeRecorder(100)
+			recorder.IncludeObject = true
+			k8sClient = k8sfake.NewSimpleClientset()
+
+			controller = NewPoolController(vmInformer, vmiInformer, poolInformer, serviceInformer, recorder, virtClient, uint(10))
+			// Wrap our workqueue to have a way to detect when we are done processing updates
+			mockQueue = testutils.NewMockWorkQueue(controller.Queue)
+			controller.Queue = mockQueue
+
+			virtClient.EXPECT().VirtualMachine(metav1.NamespaceDefault).Return(vmInterface).AnyTimes()
+			virtClient.EXPECT().VirtualMachineInstance(metav1.NamespaceDefault).Return(vmiInterface).AnyTimes()
+			virtClient.EXPECT().VirtualMachinePool(metav1.NamespaceDefault).Return(poolClient.PoolV1alpha1().VirtualMachinePools(metav1.NamespaceDefault)).AnyTimes()
+			virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
+			syncCaches(stop)
+		})
+
+		AfterEach(func() {
+			close(stop)
+		})
+
+		addPool := func(pool *poolv1.VirtualMachinePool) {
+			mockQueue.ExpectAdds(1)
+			poolSource.Add(pool)
+			mockQueue.Wait()
+		}
+
+		addVM := func(vm *v1.VirtualMachine) {
+			Expect(vmInformer.GetIndexer().Add(vm)).To(Succeed())
+		}
+
+		addReadyVMI := func(vm *v1.VirtualMachine) {
+			t := true
+			vmi := v1.NewMinimalVMIWithNS(vm.Namespace, vm.Name)
+			vmi.Labels = vm.Spec.Template.ObjectMeta.Labels
+			vmi.OwnerReferences = []metav1.OwnerReference{{Kind: "VirtualMachine", Name: vm.Name, UID: vm.UID, Controller: &t}}
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{{
+				Type:   v1.VirtualMachineInstanceConditionType(k8sv1.PodReady),
+				Status: k8sv1.ConditionTrue,
+			}}
+			Expect(vmiInformer.GetIndexer().Add(vmi)).To(Succeed())
+		}
+
+		expectStatusUpdate := func() {
+			poolClient.Fake.PrependReactor("update", "virtualmachinepools", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				update, ok := action.(testing.UpdateAction)
+				Expect(ok).To(BeTrue())
+				Expect(update.GetSubresource()).To(Equal("status"))
+				return true, update.GetObject(), nil
+			})
+		}
+
+		It("should create missing VMs with unique names and their pool index in the cloud-init data", func() {
+			pool := DefaultPool(3)
+			addPool(pool)
+
+			names := []string{}
+			vmInterface.EXPECT().Create(gomock.Any()).Times(3).DoAndReturn(func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+				names = append(names, vm.Name)
+				Expect(metav1.IsControlledBy(vm, pool)).To(BeTrue())
+				Expect(vm.Labels).To(HaveKeyWithValue("app", "pool"))
+				Expect(vm.Labels).To(HaveKey(poolv1.VirtualMachinePoolRevisionLabel))
+				Expect(vm.Spec.Template.Spec.Volumes[0].CloudInitNoCloud.UserData).To(Equal("hostname: " + vm.Name))
+				return vm, nil
+			})
+			expectStatusUpdate()
+
+			controller.Execute()
+
+			Expect(names).To(Equal([]string{"pool-0", "pool-1", "pool-2"}))
+			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+		})
+
+		It("should create a headless service selecting the virtual machine instances of the pool", func() {
+			pool := DefaultPool(0)
+			pool.Spec.HeadlessService = true
+			template := pool.Spec.VirtualMachineTemplate.Spec.Template
+			template.ObjectMeta.Labels = map[string]string{"app": "pool-vmi"}
+			template.Spec.Subdomain = "cluster"
+			addPool(pool)
+			expectStatusUpdate()
+
+			controller.Execute()
+
+			service, err := k8sClient.CoreV1().Services(pool.Namespace).Get(context.Background(), "cluster", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(service.Spec.ClusterIP).To(Equal(k8sv1.ClusterIPNone))
+			Expect(service.Spec.Selector).To(Equal(map[string]string{"app": "pool-vmi"}))
+			Expect(metav1.IsControlledBy(service, pool)).To(BeTrue())
+			testutils.ExpectEvent(recorder, SuccessfulCreateServiceReason)
+		})
+
+		It("should fill up the lowest free index first", func() {
+			pool := DefaultPool(2)
+			revision, err := poolRevision(pool)
+			Expect(err).ToNot(HaveOccurred())
+			addVM(newPoolVirtualMachine(pool, 1, revision))
+			addPool(pool)
+
+			vmInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+				Expect(vm.Name).To(Equal("pool-0"))
+				return vm, nil
+			})
+			expectStatusUpdate()
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+		})
+
+		It("should not reuse the index of a terminating VM", func() {
+			pool := DefaultPool(1)
+			revision, err := poolRevision(pool)
+			Expect(err).ToNot(HaveOccurred())
+			terminating := newPoolVirtualMachine(pool, 0, revision)
+			now := metav1.Now()
+			terminating.DeletionTimestamp = &now
+			addVM(terminating)
+			addPool(pool)
+
+			vmInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+				Expect(vm.Name).To(Equal("pool-1"))
+				return vm, nil
+			})
+			expectStatusUpdate()
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+		})
+
+		It("should give every VM its own data volumes", func() {
+			pool := DefaultPool(1)
+			pool.Spec.VirtualMachineTemplate.Spec.DataVolumeTemplates = []v1.DataVolumeTemplateSpec{{
+				ObjectMeta: metav1.ObjectMeta{Name: "rootdisk"},
+			}}
+			pool.Spec.VirtualMachineTemplate.Spec.Template.Spec.Volumes = append(pool.Spec.VirtualMachineTemplate.Spec.Template.Spec.Volumes, v1.Volume{
+				Name: "rootdisk",
+				VolumeSource: v1.VolumeSource{
+					DataVolume: &v1.DataVolumeSource{Name: "rootdisk"},
+				},
+			})
+			addPool(pool)
+
+			vmInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+				Expect(vm.Spec.DataVolumeTemplates[0].Name).To(Equal("rootdisk-0"))
+				Expect(vm.Spec.Template.Spec.Volumes[1].DataVolume.Name).To(Equal("rootdisk-0"))
+				return vm, nil
+			})
+			expectStatusUpdate()
+
+			controller.Execute()
+
+			Expect(pool.Spec.VirtualMachineTemplate.Spec.DataVolumeTemplates[0].Name).To(Equal("rootdisk"))
+			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+		})
+
+		It("should delete the VMs with the highest index first when scaling down", func() {
+			pool := DefaultPool(1)
+			revision, err := poolRevision(pool)
+			Expect(err).ToNot(HaveOccurred())
+			for i := 0; i < 3; i++ {
+				addVM(newPoolVirtualMachine(pool, i, revision))
+			}
+			addPool(pool)
+
+			vmInterface.EXPECT().Delete("pool-2", gomock.Any())
+			vmInterface.EXPECT().Delete("pool-1", gomock.Any())
+			expectStatusUpdate()
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulDeleteVirtualMachineReason)
+			testutils.ExpectEvent(recorder, SuccessfulDeleteVirtualMachineReason)
+		})
+
+		It("should update outdated VMs and only restart as many of them as may be unavailable", func() {
+			pool := DefaultPool(2)
+			running := true
+			pool.Spec.VirtualMachineTemplate.Spec.Running = &running
+			for i := 0; i < 2; i++ {
+				vm := newPoolVirtualMachine(pool, i, "outdated")
+				vm.UID = types.UID(vm.Name)
+				addVM(vm)
+				addReadyVMI(vm)
+			}
+			addPool(pool)
+
+			revision, err := poolRevision(pool)
+			Expect(err).ToNot(HaveOccurred())
+			vmInterface.EXPECT().Update(gomock.Any()).Times(2).DoAndReturn(func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+				Expect(vm.Labels).To(HaveKeyWithValue(poolv1.VirtualMachinePoolRevisionLabel, revision))
+				Expect(vm.Spec.Template.ObjectMeta.Labels).To(HaveKeyWithValue(poolv1.VirtualMachinePoolRevisionLabel, revision))
+				return vm, nil
+			})
+			vmiInterface.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(1)
+			expectStatusUpdate()
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulUpdateVirtualMachineReason)
+			testutils.ExpectEvent(recorder, SuccessfulUpdateVirtualMachineReason)
+			testutils.ExpectEvent(recorder, SuccessfulRestartVirtualMachineReason)
+		})
+
+		It("should keep what was added to a VM outside of the pool template when it is updated", func() {
+			pool := DefaultPool(1)
+			pool.Spec.VirtualMachineTemplate.ObjectMeta.Annotations = map[string]string{"template": "new"}
+			pool.Spec.VirtualMachineTemplate.Spec.Template.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "default"}}
+			vm := newPoolVirtualMachine(pool, 0, "outdated")
+			vm.Labels["backup"] = "daily"
+			vm.Annotations = map[string]string{"template": "old", "owner": "team-a"}
+			vm.Spec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress = "02:00:00:00:00:01"
+			vm.Spec.Template.Spec.Hostname = "custom"
+			addVM(vm)
+			addPool(pool)
+
+			vmInterface.EXPECT().Update(gomock.Any()).DoAndReturn(func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+				Expect(vm.Labels).To(HaveKeyWithValue("backup", "daily"))
+				Expect(vm.Labels).To(HaveKeyWithValue("app", "pool"))
+				Expect(vm.Annotations).To(Equal(map[string]string{"template": "new", "owner": "team-a"}))
+				Expect(vm.Spec.Template.Spec.Domain.Devices.Interfaces).To(HaveLen(1))
+				Expect(vm.Spec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress).To(Equal("02:00:00:00:00:01"))
+				Expect(vm.Spec.Template.Spec.Hostname).To(Equal("custom"))
+				return vm, nil
+			})
+			expectStatusUpdate()
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulUpdateVirtualMachineReason)
+		})
+
+		It("should not restart any VM while one of them is unavailable", func() {
+			pool := DefaultPool(2)
+			running := true
+			pool.Spec.VirtualMachineTemplate.Spec.Running = &running
+			revision, err := poolRevision(pool)
+			Expect(err).ToNot(HaveOccurred())
+			addVM(newPoolVirtualMachine(pool, 0, revision))
+			vm := newPoolVirtualMachine(pool, 1, revision)
+			vm.UID = types.UID(vm.Name)
+			addVM(vm)
+			vm = vm.DeepCopy()
+			vm.Spec.Template.ObjectMeta.Labels[poolv1.VirtualMachinePoolRevisionLabel] = "outdated"
+			addReadyVMI(vm)
+			addPool(pool)
+
+			expectStatusUpdate()
+
+			controller.Execute()
+
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("should report the replicas in the status", func() {
+			pool := DefaultPool(2)
+			revision, err := poolRevision(pool)
+			Expect(err).ToNot(HaveOccurred())
+			for i := 0; i < 2; i++ {
+				vm := newPoolVirtualMachine(pool, i, revision)
+				vm.UID = types.UID(vm.Name)
+				addVM(vm)
+				if i == 0 {
+					addReadyVMI(vm)
+				}
+			}
+			addPool(pool)
+
+			var updated *poolv1.VirtualMachinePool
+			poolClient.Fake.PrependReactor("update", "virtualmachinepools", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				updated = action.(testing.UpdateAction).GetObject().(*poolv1.VirtualMachinePool)
+				return true, updated, nil
+			})
+
+			controller.Execute()
+
+			Expect(updated).ToNot(BeNil())
+			Expect(updated.Status.Replicas).To(Equal(int32(2)))
+			Expect(updated.Status.ReadyReplicas).To(Equal(int32(1)))
+			Expect(updated.Status.UpdatedReplicas).To(Equal(int32(2)))
+			Expect(updated.Status.CurrentRevision).To(Equal(revision))
+			Expect(updated.Status.LabelSelector).To(Equal("app=pool"))
+		})
+
+		It("should not scale a paused pool and add the paused condition", func() {
+			pool := DefaultPool(3)
+			pool.Spec.Paused = true
+			addPool(pool)
+
+			var updated *poolv1.VirtualMachinePool
+			poolClient.Fake.PrependReactor("update", "virtualmachinepools", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				updated = action.(testing.UpdateAction).GetObject().(*poolv1.VirtualMachinePool)
+				return true, updated, nil
+			})
+
+			controller.Execute()
+
+			Expect(updated).ToNot(BeNil())
+			Expect(updated.Status.Conditions).To(HaveLen(1))
+			Expect(updated.Status.Conditions[0].Type).To(Equal(poolv1.VirtualMachinePoolReplicaPaused))
+			testutils.ExpectEvent(recorder, SuccessfulPausedPoolReason)
+		})
+
+		It("should add a failure condition if creating a VM fails", func() {
+			pool := DefaultPool(1)
+			addPool(pool)
+
+			vmInterface.EXPECT().Create(gomock.Any()).Return(nil, fmt.Errorf("failure"))
+
+			var updated *poolv1.VirtualMachinePool
+			poolClient.Fake.PrependReactor("update", "virtualmachinepools", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				updated = action.(testing.UpdateAction).GetObject().(*poolv1.VirtualMachinePool)
+				return true, updated, nil
+			})
+
+			controller.Execute()
+
+			Expect(updated).ToNot(BeNil())
+			Expect(updated.Status.Conditions).To(HaveLen(1))
+			Expect(updated.Status.Conditions[0].Type).To(Equal(poolv1.VirtualMachinePoolReplicaFailure))
+			Expect(updated.Status.Conditions[0].Reason).To(Equal(FailedCreateVirtualMachineReason))
+			testutils.ExpectEvent(recorder, FailedCreateVirtualMachineReason)
+		})
+	})
+})
+
+func DefaultPool(replicas int32) *poolv1.VirtualMachinePool {
+	running := false
+	return &poolv1.VirtualMachinePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: metav1.NamespaceDefault, ResourceVersion: "1", UID: "pool-uid"},
+		Spec: poolv1.VirtualMachinePoolSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "pool"},
+			},
+			VirtualMachineTemplate: &poolv1.VirtualMachineTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": "pool"},
+				},
+				Spec: v1.VirtualMachineSpec{
+					Running: &running,
+					Template: &v1.VirtualMachineInstanceTemplateSpec{
+						Spec: v1.VirtualMachineInstanceSpec{
+							Volumes: []v1.Volume{{
+								Name: "cloudinit",
+								VolumeSource: v1.VolumeSource{
+									CloudInitNoCloud: &v1.CloudInitNoCloudSource{
+										UserData: "hostname: pool-" + poolv1.VirtualMachinePoolIndexPlaceholder,
+									},
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
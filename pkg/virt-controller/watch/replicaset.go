@@ -65,17 +65,23 @@ const (
 	SuccessfulResumedReplicaSetReason = "SuccessfulResumed"
 )
 
-func NewVMIReplicaSet(vmiInformer cache.SharedIndexInformer, vmiRSInformer cache.SharedIndexInformer, recorder record.EventRecorder, clientset kubecli.KubevirtClient, burstReplicas uint) *VMIReplicaSet {
+func NewVMIReplicaSet(vmiInformer cache.SharedIndexInformer, vmiRSInformer cache.SharedIndexInformer, serviceInformer cache.SharedIndexInformer, recorder record.EventRecorder, clientset kubecli.KubevirtClient, burstReplicas uint) *VMIReplicaSet {
 
 	c := &VMIReplicaSet{
-		Queue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-replicaset"),
-		vmiInformer:   vmiInformer,
-		vmiRSInformer: vmiRSInformer,
-		recorder:      recorder,
-		clientset:     clientset,
-		expectations:  controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
-		burstReplicas: burstReplicas,
-		statusUpdater: status.NewVMIRSStatusUpdater(clientset),
+		Queue:           workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-replicaset"),
+		vmiInformer:     vmiInformer,
+		vmiRSInformer:   vmiRSInformer,
+		serviceInformer: serviceInformer,
+		recorder:        recorder,
+		clientset:       clientset,
+		expectations:    controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
+		burstReplicas:   burstReplicas,
+		statusUpdater:   status.NewVMIRSStatusUpdater(clientset),
+		headlessServices: &headlessServiceControl{
+			clientset:       clientset,
+			serviceInformer: serviceInformer,
+			recorder:        recorder,
+		},
 	}
 
 	c.vmiRSInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -90,18 +96,26 @@ func NewVMIReplicaSet(vmiInformer cache.SharedIndexInformer, vmiRSInformer cache
 		UpdateFunc: c.updateVirtualMachine,
 	})
 
+	c.serviceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueServiceOwner,
+		DeleteFunc: c.enqueueServiceOwner,
+		UpdateFunc: func(_, curr interface{}) { c.enqueueServiceOwner(curr) },
+	})
+
 	return c
 }
 
 type VMIReplicaSet struct {
-	clientset     kubecli.KubevirtClient
-	Queue         workqueue.RateLimitingInterface
-	vmiInformer   cache.SharedIndexInformer
-	vmiRSInformer cache.SharedIndexInformer
-	recorder      record.EventRecorder
-	expectations  *controller.UIDTrackingControllerExpectations
-	burstReplicas uint
-	statusUpdater *status.VMIRSStatusUpdater
+	clientset        kubecli.KubevirtClient
+	Queue            workqueue.RateLimitingInterface
+	vmiInformer      cache.SharedIndexInformer
+	vmiRSInformer    cache.SharedIndexInformer
+	serviceInformer  cache.SharedIndexInformer
+	recorder         record.EventRecorder
+	expectations     *controller.UIDTrackingControllerExpectations
+	burstReplicas    uint
+	statusUpdater    *status.VMIRSStatusUpdater
+	headlessServices *headlessServiceControl
 }
 
 func (c *VMIReplicaSet) Run(threadiness int, stopCh <-chan struct{}) {
@@ -110,7 +124,7 @@ func (c *VMIReplicaSet) Run(threadiness int, stopCh <-chan struct{}) {
 	log.Log.Info("Starting VirtualMachineInstanceReplicaSet controller.")
 
 	// Wait for cache sync before we start the controller
-	cache.WaitForCacheSync(stopCh, c.vmiInformer.HasSynced, c.vmiRSInformer.HasSynced)
+	cache.WaitForCacheSync(stopCh, c.vmiInformer.HasSynced, c.vmiRSInformer.HasSynced, c.serviceInformer.HasSynced)
 
 	// Start the actual work
 	for i := 0; i < threadiness; i++ {
@@ -228,12 +242,32 @@ func (c *VMIReplicaSet) execute(key string) error {
 		logger.Reason(err).Error("Scaling the replicaset failed.")
 	}
 
+	var serviceErr error
+	if rs.ObjectMeta.DeletionTimestamp == nil {
+		serviceErr = c.headlessServices.sync(rs, OwnerRef(rs), headlessServiceName(rs), rs.Spec.Template.ObjectMeta.Labels)
+		if serviceErr != nil {
+			logger.Reason(serviceErr).Error("Syncing the headless service of the replicaset failed.")
+		}
+	}
+
 	err = c.updateStatus(rs.DeepCopy(), activeVmis, scaleErr)
 	if err != nil {
 		logger.Reason(err).Error("Updating the replicaset status failed.")
 	}
 
-	return scaleErr
+	if scaleErr != nil {
+		return scaleErr
+	}
+	return serviceErr
+}
+
+// headlessServiceName returns the name of the headless service of the replica set,
+// or an empty string if it should not have one
+func headlessServiceName(rs *virtv1.VirtualMachineInstanceReplicaSet) string {
+	if !rs.Spec.HeadlessService {
+		return ""
+	}
+	return rs.Spec.Template.Spec.Subdomain
 }
 
 func (c *VMIReplicaSet) scale(rs *virtv1.VirtualMachineInstanceReplicaSet, vmis []*virtv1.VirtualMachineInstance) error {
@@ -579,6 +613,12 @@ func (c *VMIReplicaSet) enqueueReplicaSet(obj interface{}) {
 	c.Queue.Add(key)
 }
 
+func (c *VMIReplicaSet) enqueueServiceOwner(obj interface{}) {
+	enqueueServiceOwner(obj, virtv1.VirtualMachineInstanceReplicaSetGroupVersionKind.Kind, func(key string) {
+		c.Queue.Add(key)
+	})
+}
+
 func abs(x int) int {
 	if x < 0 {
 		return -x
@@ -1,6 +1,7 @@
 package watch
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/golang/mock/gomock"
@@ -8,7 +9,9 @@ import (
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/cache"
 	framework "k8s.io/client-go/tools/cache/testing"
 	"k8s.io/client-go/tools/record"
@@ -40,6 +43,8 @@ var _ = Describe("Replicaset", func() {
 		var rsSource *framework.FakeControllerSource
 		var vmiInformer cache.SharedIndexInformer
 		var rsInformer cache.SharedIndexInformer
+		var serviceInformer cache.SharedIndexInformer
+		var k8sClient *k8sfake.Clientset
 		var stop chan struct{}
 		var controller *VMIReplicaSet
 		var recorder *record.FakeRecorder
@@ -49,7 +54,8 @@ var _ = Describe("Replicaset", func() {
 		syncCaches := func(stop chan struct{}) {
 			go vmiInformer.Run(stop)
 			go rsInformer.Run(stop)
-			Expect(cache.WaitForCacheSync(stop, vmiInformer.HasSynced, rsInformer.HasSynced)).To(BeTrue())
+			go serviceInformer.Run(stop)
+			Expect(cache.WaitForCacheSync(stop, vmiInformer.HasSynced, rsInformer.HasSynced, serviceInformer.HasSynced)).To(BeTrue())
 		}
 
 		BeforeEach(func() {
@@ -61,10 +67,12 @@ var _ = Describe("Replicaset", func() {
 
 			vmiInformer, vmiSource = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
 			rsInformer, rsSource = testutils.NewFakeInformerFor(&v1.VirtualMachineInstanceReplicaSet{})
+			serviceInformer, _ = testutils.NewFakeInformerFor(&k8sv1.Service{})
 			recorder = record.NewFakeRecorder(100)
 			recorder.IncludeObject = true
+			k8sClient = k8sfake.NewSimpleClientset()
 
-			controller = NewVMIReplicaSet(vmiInformer, rsInformer, recorder, virtClient, uint(10))
+			controller = NewVMIReplicaSet(vmiInformer, rsInformer, serviceInformer, recorder, virtClient, uint(10))
 			// Wrap our workqueue to have a way to detect when we are done processing updates
 			mockQueue = testutils.NewMockWorkQueue(controller.Queue)
 			controller.Queue = mockQueue
@@ -73,6 +81,7 @@ var _ = Describe("Replicaset", func() {
 			// Set up mock client
 			virtClient.EXPECT().VirtualMachineInstance(metav1.NamespaceDefault).Return(vmiInterface).AnyTimes()
 			virtClient.EXPECT().ReplicaSet(metav1.NamespaceDefault).Return(rsInterface).AnyTimes()
+			virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
 			syncCaches(stop)
 		})
 
@@ -693,6 +702,76 @@ var _ = Describe("Replicaset", func() {
 			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
 		})
 
+		Context("with a headless service", func() {
+			var rs *v1.VirtualMachineInstanceReplicaSet
+
+			BeforeEach(func() {
+				rs, _ = DefaultReplicaSet(0)
+				rs.UID = "rs-uid"
+				rs.Spec.HeadlessService = true
+				rs.Spec.Template.Spec.Subdomain = "cluster"
+				rsInterface.EXPECT().UpdateStatus(gomock.Any()).AnyTimes()
+			})
+
+			It("should create a headless service named after the subdomain", func() {
+				addReplicaSet(rs)
+
+				controller.Execute()
+
+				service, err := k8sClient.CoreV1().Services(rs.Namespace).Get(context.Background(), "cluster", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(service.Spec.ClusterIP).To(Equal(k8sv1.ClusterIPNone))
+				Expect(service.Spec.Selector).To(Equal(rs.Spec.Template.ObjectMeta.Labels))
+				Expect(metav1.IsControlledBy(service, rs)).To(BeTrue())
+				testutils.ExpectEvent(recorder, SuccessfulCreateServiceReason)
+			})
+
+			It("should update the selector of its headless service", func() {
+				service := newHeadlessService(rs, OwnerRef(rs), "cluster", map[string]string{"old": "label"})
+				Expect(serviceInformer.GetIndexer().Add(service)).To(Succeed())
+				_, err := k8sClient.CoreV1().Services(rs.Namespace).Create(context.Background(), service, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				addReplicaSet(rs)
+
+				controller.Execute()
+
+				service, err = k8sClient.CoreV1().Services(rs.Namespace).Get(context.Background(), "cluster", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(service.Spec.Selector).To(Equal(rs.Spec.Template.ObjectMeta.Labels))
+			})
+
+			It("should delete its headless service when it is disabled", func() {
+				service := newHeadlessService(rs, OwnerRef(rs), "cluster", rs.Spec.Template.ObjectMeta.Labels)
+				Expect(serviceInformer.GetIndexer().Add(service)).To(Succeed())
+				_, err := k8sClient.CoreV1().Services(rs.Namespace).Create(context.Background(), service, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				rs.Spec.HeadlessService = false
+				addReplicaSet(rs)
+
+				controller.Execute()
+
+				_, err = k8sClient.CoreV1().Services(rs.Namespace).Get(context.Background(), "cluster", metav1.GetOptions{})
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+				testutils.ExpectEvent(recorder, SuccessfulDeleteServiceReason)
+			})
+
+			It("should not take over a service it does not control", func() {
+				_, err := k8sClient.CoreV1().Services(rs.Namespace).Create(context.Background(), &k8sv1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: rs.Namespace},
+				}, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				addReplicaSet(rs)
+
+				controller.Execute()
+
+				Expect(mockQueue.GetRateLimitedEnqueueCount()).To(Equal(0))
+				service, err := k8sClient.CoreV1().Services(rs.Namespace).Get(context.Background(), "cluster", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(service.OwnerReferences).To(BeEmpty())
+				testutils.ExpectEvent(recorder, FailedCreateServiceReason)
+			})
+		})
+
 		AfterEach(func() {
 			close(stop)
 			// Ensure that we add checks for expected events to every test
@@ -36,6 +36,7 @@ import (
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/controller"
+	kubevirttypes "kubevirt.io/kubevirt/pkg/util/types"
 )
 
 const (
@@ -400,7 +401,7 @@ func (t *vmRestoreTarget) Reconcile() (bool, error) {
 	}
 
 	for j, v := range snapshotVM.Spec.Template.Spec.Volumes {
-		if v.DataVolume != nil || v.PersistentVolumeClaim != nil {
+		if v.DataVolume != nil || v.PersistentVolumeClaim != nil || kubevirttypes.ContainerDiskOverlayClaimName(&v) != "" {
 			for k := range t.vmRestore.Status.Restores {
 				vr := &t.vmRestore.Status.Restores[k]
 				if vr.VolumeName != v.Name {
@@ -468,10 +469,14 @@ func (t *vmRestoreTarget) Reconcile() (bool, error) {
 						}
 						newVolumes[j] = nv
 					}
-				} else {
+				} else if v.PersistentVolumeClaim != nil {
 					nv := v.DeepCopy()
 					nv.PersistentVolumeClaim.ClaimName = vr.PersistentVolumeClaimName
 					newVolumes[j] = *nv
+				} else {
+					nv := v.DeepCopy()
+					nv.ContainerDisk.Overlay.ClaimName = vr.PersistentVolumeClaimName
+					newVolumes[j] = *nv
 				}
 			}
 		}
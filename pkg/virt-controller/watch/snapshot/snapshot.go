@@ -37,6 +37,7 @@ import (
 	"kubevirt.io/client-go/log"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1beta1"
 	"kubevirt.io/kubevirt/pkg/controller"
+	kubevirttypes "kubevirt.io/kubevirt/pkg/util/types"
 )
 
 const (
@@ -907,6 +908,8 @@ func getPVCsFromVolumes(volumes []kubevirtv1.Volume) map[string]string {
 		} else if volume.DataVolume != nil {
 			// TODO Change when PVC Renaming is merged.
 			pvcName = volume.DataVolume.Name
+		} else if claimName := kubevirttypes.ContainerDiskOverlayClaimName(&volume); claimName != "" {
+			pvcName = claimName
 		} else {
 			continue
 		}
@@ -1891,6 +1891,22 @@ var _ = Describe("Snapshot controlleer", func() {
 			)
 		})
 	})
+
+	It("should snapshot the overlay PVC of a containerDisk", func() {
+		vm := createVirtualMachine(testNamespace, vmName)
+		vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, v1.Volume{
+			Name: "containerdisk",
+			VolumeSource: v1.VolumeSource{
+				ContainerDisk: &v1.ContainerDiskSource{
+					Image:   "my-image",
+					Overlay: &v1.ContainerDiskOverlay{ClaimName: "overlay-pvc"},
+				},
+			},
+		})
+
+		pvcs := getPVCsFromVolumes(vm.Spec.Template.Spec.Volumes)
+		Expect(pvcs).To(HaveKeyWithValue("containerdisk", "overlay-pvc"))
+	})
 })
 
 func expectVMSnapshotUpdate(client *kubevirtfake.Clientset, vmSnapshot *snapshotv1.VirtualMachineSnapshot) {
@@ -0,0 +1,226 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	k8score "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1beta1"
+	"kubevirt.io/kubevirt/pkg/controller"
+	typesutil "kubevirt.io/kubevirt/pkg/util/types"
+)
+
+// storageCapabilitiesResyncPeriod is how often the capabilities are recomputed, to pick up
+// VolumeSnapshotClasses, which are not watched since their API is optional
+const storageCapabilitiesResyncPeriod = 5 * time.Minute
+
+// StorageCapabilitiesController publishes the capabilities of every StorageClass which matter to
+// VirtualMachines in a ConfigMap, which the VirtualMachine webhook validates DataVolumeTemplates
+// against. The access modes, volume modes and the clone strategy come from the StorageProfile of
+// CDI, snapshot support from the VolumeSnapshotClasses for the provisioner of the StorageClass.
+type StorageCapabilitiesController struct {
+	clientset              kubecli.KubevirtClient
+	Queue                  workqueue.RateLimitingInterface
+	storageClassInformer   cache.SharedIndexInformer
+	storageProfileInformer cache.SharedIndexInformer
+	configMapInformer      cache.SharedIndexInformer
+	namespace              string
+}
+
+func NewStorageCapabilitiesController(storageClassInformer cache.SharedIndexInformer, storageProfileInformer cache.SharedIndexInformer, configMapInformer cache.SharedIndexInformer, clientset kubecli.KubevirtClient, namespace string) *StorageCapabilitiesController {
+
+	c := &StorageCapabilitiesController{
+		Queue:                  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-storage-capabilities"),
+		storageClassInformer:   storageClassInformer,
+		storageProfileInformer: storageProfileInformer,
+		configMapInformer:      configMapInformer,
+		clientset:              clientset,
+		namespace:              namespace,
+	}
+
+	// all capabilities are published in a single config map, so every change enqueues the same key
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(_ interface{}) { c.enqueue() },
+		DeleteFunc: func(_ interface{}) { c.enqueue() },
+		UpdateFunc: func(_, _ interface{}) { c.enqueue() },
+	}
+	c.storageClassInformer.AddEventHandler(handler)
+	c.storageProfileInformer.AddEventHandler(handler)
+	c.configMapInformer.AddEventHandler(handler)
+
+	return c
+}
+
+func (c *StorageCapabilitiesController) enqueue() {
+	c.Queue.Add(c.namespace + "/" + typesutil.StorageCapabilitiesConfigMapName)
+}
+
+func (c *StorageCapabilitiesController) Run(threadiness int, stopCh <-chan struct{}) {
+	defer controller.HandlePanic()
+	defer c.Queue.ShutDown()
+	log.Log.Info("Starting storage capabilities controller.")
+
+	// Wait for cache sync before we start the controller
+	cache.WaitForCacheSync(stopCh, c.storageClassInformer.HasSynced, c.storageProfileInformer.HasSynced, c.configMapInformer.HasSynced)
+
+	go wait.Until(c.enqueue, storageCapabilitiesResyncPeriod, stopCh)
+
+	// Start the actual work
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	log.Log.Info("Stopping storage capabilities controller.")
+}
+
+func (c *StorageCapabilitiesController) runWorker() {
+	for c.Execute() {
+	}
+}
+
+func (c *StorageCapabilitiesController) Execute() bool {
+	key, quit := c.Queue.Get()
+	if quit {
+		return false
+	}
+	defer c.Queue.Done(key)
+	if err := c.execute(); err != nil {
+		log.Log.Reason(err).Infof("re-enqueuing storage capabilities %v", key)
+		c.Queue.AddRateLimited(key)
+	} else {
+		log.Log.V(4).Infof("processed storage capabilities %v", key)
+		c.Queue.Forget(key)
+	}
+	return true
+}
+
+func (c *StorageCapabilitiesController) execute() error {
+	snapshotDrivers, err := c.listSnapshotDrivers()
+	if err != nil {
+		return err
+	}
+
+	data := map[string]string{}
+	for _, obj := range c.storageClassInformer.GetStore().List() {
+		storageClass := obj.(*storagev1.StorageClass)
+		capabilities := &typesutil.StorageClassCapabilities{
+			Provisioner: storageClass.Provisioner,
+			Snapshot:    snapshotDrivers[storageClass.Provisioner],
+		}
+		if err := c.addStorageProfileCapabilities(capabilities, storageClass.Name); err != nil {
+			return err
+		}
+		raw, err := json.Marshal(capabilities)
+		if err != nil {
+			return err
+		}
+		data[storageClass.Name] = string(raw)
+	}
+
+	obj, exists, err := c.configMapInformer.GetStore().GetByKey(c.namespace + "/" + typesutil.StorageCapabilitiesConfigMapName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		configMap := &k8score.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      typesutil.StorageCapabilitiesConfigMapName,
+				Namespace: c.namespace,
+			},
+			Data: data,
+		}
+		_, err = c.clientset.CoreV1().ConfigMaps(c.namespace).Create(context.Background(), configMap, metav1.CreateOptions{})
+		if errors.IsAlreadyExists(err) {
+			// the informer has not seen it yet, its add event enqueues the update
+			return nil
+		}
+		return err
+	}
+
+	configMap := obj.(*k8score.ConfigMap)
+	if equality.Semantic.DeepEqual(configMap.Data, data) {
+		return nil
+	}
+	configMap = configMap.DeepCopy()
+	configMap.Data = data
+	_, err = c.clientset.CoreV1().ConfigMaps(c.namespace).Update(context.Background(), configMap, metav1.UpdateOptions{})
+	return err
+}
+
+// listSnapshotDrivers returns the drivers which have a VolumeSnapshotClass
+func (c *StorageCapabilitiesController) listSnapshotDrivers() (map[string]bool, error) {
+	drivers := map[string]bool{}
+	classes, err := c.clientset.KubernetesSnapshotClient().SnapshotV1beta1().VolumeSnapshotClasses().List(context.Background(), metav1.ListOptions{})
+	if errors.IsNotFound(err) {
+		// the snapshot API is not installed
+		return drivers, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, class := range classes.Items {
+		drivers[class.Driver] = true
+	}
+	return drivers, nil
+}
+
+// addStorageProfileCapabilities adds the claim properties and the clone strategy CDI reports in the
+// StorageProfile of the StorageClass, if there is one
+func (c *StorageCapabilitiesController) addStorageProfileCapabilities(capabilities *typesutil.StorageClassCapabilities, storageClass string) error {
+	// storage profiles are named after their storage class
+	obj, exists, err := c.storageProfileInformer.GetStore().GetByKey(storageClass)
+	if err != nil || !exists {
+		return err
+	}
+	unstructuredProfile := obj.(*unstructured.Unstructured)
+
+	profile := &cdiv1.StorageProfile{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredProfile.Object, profile); err != nil {
+		return err
+	}
+	if len(profile.Status.ClaimPropertySets) > 0 {
+		capabilities.ClaimPropertySets = profile.Status.ClaimPropertySets
+		liveMigration := capabilities.SupportsClaimProperties([]k8score.PersistentVolumeAccessMode{k8score.ReadWriteMany}, k8score.PersistentVolumeBlock)
+		capabilities.LiveMigration = &liveMigration
+	}
+
+	// the vendored CDI API does not know the clone strategy yet
+	cloneStrategy, _, err := unstructured.NestedString(unstructuredProfile.Object, "status", "cloneStrategy")
+	if err != nil {
+		return err
+	}
+	capabilities.CloneStrategy = typesutil.CloneStrategy(cloneStrategy)
+	return nil
+}
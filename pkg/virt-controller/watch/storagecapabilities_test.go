@@ -0,0 +1,156 @@
+package watch
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	vsv1beta1 "github.com/kubernetes-csi/external-snapshotter/v2/pkg/apis/volumesnapshot/v1beta1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	k8sv1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	"kubevirt.io/client-go/generated/external-snapshotter/clientset/versioned/fake"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	typesutil "kubevirt.io/kubevirt/pkg/util/types"
+)
+
+var _ = Describe("StorageCapabilities", func() {
+
+	const namespace = "kubevirt"
+
+	var ctrl *gomock.Controller
+	var storageClassInformer cache.SharedIndexInformer
+	var storageProfileInformer cache.SharedIndexInformer
+	var configMapInformer cache.SharedIndexInformer
+	var k8sClient *k8sfake.Clientset
+	var snapshotClient *fake.Clientset
+	var controller *StorageCapabilitiesController
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+
+		storageClassInformer, _ = testutils.NewFakeInformerFor(&storagev1.StorageClass{})
+		storageProfileInformer, _ = testutils.NewFakeInformerFor(&unstructured.Unstructured{})
+		configMapInformer, _ = testutils.NewFakeInformerFor(&k8sv1.ConfigMap{})
+		k8sClient = k8sfake.NewSimpleClientset()
+		snapshotClient = fake.NewSimpleClientset()
+		virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
+		virtClient.EXPECT().KubernetesSnapshotClient().Return(snapshotClient).AnyTimes()
+
+		controller = NewStorageCapabilitiesController(storageClassInformer, storageProfileInformer, configMapInformer, virtClient, namespace)
+
+		Expect(storageClassInformer.GetIndexer().Add(&storagev1.StorageClass{
+			ObjectMeta:  metav1.ObjectMeta{Name: "ceph"},
+			Provisioner: "rbd.csi.ceph.com",
+		})).To(Succeed())
+		Expect(storageClassInformer.GetIndexer().Add(&storagev1.StorageClass{
+			ObjectMeta:  metav1.ObjectMeta{Name: "local"},
+			Provisioner: "kubernetes.io/no-provisioner",
+		})).To(Succeed())
+		Expect(storageProfileInformer.GetIndexer().Add(newStorageProfile("ceph", map[string]interface{}{
+			"cloneStrategy": "snapshot",
+			"claimPropertySets": []interface{}{
+				map[string]interface{}{"accessModes": []interface{}{"ReadWriteMany"}, "volumeMode": "Block"},
+				map[string]interface{}{"accessModes": []interface{}{"ReadWriteOnce"}},
+			},
+		}))).To(Succeed())
+		Expect(storageProfileInformer.GetIndexer().Add(newStorageProfile("local", map[string]interface{}{
+			"claimPropertySets": []interface{}{
+				map[string]interface{}{"accessModes": []interface{}{"ReadWriteOnce"}},
+			},
+		}))).To(Succeed())
+		_, err := snapshotClient.SnapshotV1beta1().VolumeSnapshotClasses().Create(context.Background(), &vsv1beta1.VolumeSnapshotClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "ceph-snapshots"},
+			Driver:     "rbd.csi.ceph.com",
+		}, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	getCapabilities := func(storageClass string) *typesutil.StorageClassCapabilities {
+		configMap, err := k8sClient.CoreV1().ConfigMaps(namespace).Get(context.Background(), typesutil.StorageCapabilitiesConfigMapName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+		Expect(store.Add(configMap)).To(Succeed())
+		capabilities, exists, err := typesutil.GetStorageClassCapabilitiesFromStore(store, storageClass)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exists).To(BeTrue())
+		return capabilities
+	}
+
+	It("should publish the capabilities of every storage class", func() {
+		controller.enqueue()
+		controller.Execute()
+
+		ceph := getCapabilities("ceph")
+		Expect(ceph.Provisioner).To(Equal("rbd.csi.ceph.com"))
+		Expect(ceph.ClaimPropertySets).To(HaveLen(2))
+		Expect(ceph.SupportsClaimProperties([]k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteMany}, k8sv1.PersistentVolumeBlock)).To(BeTrue())
+		Expect(ceph.SupportsClaimProperties([]k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteMany}, k8sv1.PersistentVolumeFilesystem)).To(BeFalse())
+		Expect(ceph.LiveMigration).To(PointTo(BeTrue()))
+		Expect(ceph.Snapshot).To(BeTrue())
+		Expect(ceph.CloneStrategy).To(Equal(typesutil.CloneStrategySnapshot))
+
+		local := getCapabilities("local")
+		Expect(local.ClaimPropertySets).To(HaveLen(1))
+		Expect(local.LiveMigration).To(PointTo(BeFalse()))
+		Expect(local.Snapshot).To(BeFalse())
+		Expect(local.CloneStrategy).To(BeEmpty())
+	})
+
+	It("should not publish claim properties and a clone strategy without StorageProfiles", func() {
+		Expect(storageProfileInformer.GetIndexer().Delete(newStorageProfile("ceph", nil))).To(Succeed())
+		Expect(storageProfileInformer.GetIndexer().Delete(newStorageProfile("local", nil))).To(Succeed())
+		controller.enqueue()
+		controller.Execute()
+
+		ceph := getCapabilities("ceph")
+		Expect(ceph.Snapshot).To(BeTrue())
+		Expect(ceph.ClaimPropertySets).To(BeEmpty())
+		Expect(ceph.LiveMigration).To(BeNil())
+		Expect(ceph.CloneStrategy).To(BeEmpty())
+	})
+
+	It("should update the published capabilities when they change", func() {
+		Expect(configMapInformer.GetIndexer().Add(&k8sv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: typesutil.StorageCapabilitiesConfigMapName, Namespace: namespace},
+			Data:       map[string]string{"removed": "{}"},
+		})).To(Succeed())
+		_, err := k8sClient.CoreV1().ConfigMaps(namespace).Create(context.Background(), &k8sv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: typesutil.StorageCapabilitiesConfigMapName, Namespace: namespace},
+		}, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		controller.enqueue()
+		controller.Execute()
+
+		configMap, err := k8sClient.CoreV1().ConfigMaps(namespace).Get(context.Background(), typesutil.StorageCapabilitiesConfigMapName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(configMap.Data).To(HaveLen(2))
+		Expect(configMap.Data).To(HaveKey("ceph"))
+		Expect(configMap.Data).To(HaveKey("local"))
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+})
+
+func newStorageProfile(name string, status map[string]interface{}) *unstructured.Unstructured {
+	profile := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "cdi.kubevirt.io/v1beta1",
+		"kind":       "StorageProfile",
+		"metadata":   map[string]interface{}{"name": name},
+	}}
+	if status != nil {
+		profile.Object["status"] = status
+	}
+	return profile
+}
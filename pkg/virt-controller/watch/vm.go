@@ -26,7 +26,9 @@ import (
 	"math"
 	"math/rand"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"kubevirt.io/kubevirt/pkg/util/migrations"
@@ -50,8 +52,12 @@ import (
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1beta1"
 	cdiclone "kubevirt.io/containerized-data-importer/pkg/clone"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/network/macpool"
+	"kubevirt.io/kubevirt/pkg/util/cron"
+	"kubevirt.io/kubevirt/pkg/util/guestdefaults"
 	"kubevirt.io/kubevirt/pkg/util/status"
 	typesutil "kubevirt.io/kubevirt/pkg/util/types"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 type CloneAuthFunc func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error)
@@ -66,15 +72,57 @@ const (
 	failureDeletingVmiErrFormat           = "Failure attempting to delete VMI: %v"
 )
 
-const defaultMaxCrashLoopBackoffDelaySeconds = 300
+// moveCloneCheckInterval is how often the DataVolumes cloned for a VM move are checked for completion
+const moveCloneCheckInterval = 10 * time.Second
+
+// cdiBindImmediateAnnotation lets CDI clone into a WaitForFirstConsumer PVC without waiting for a consumer
+const cdiBindImmediateAnnotation = "cdi.kubevirt.io/storage.bind.immediate.requested"
+
+// missedScheduleWindow bounds how long ago a scheduled start or stop, which was missed, is still applied
+const missedScheduleWindow = 24 * time.Hour
+
+const (
+	// GuestDefaultsUpdateReason is added to the event when a VM is restarted to apply changed cluster defaults
+	GuestDefaultsUpdateReason = "GuestDefaultsUpdate"
+	// guestDefaultsChangedReason is the reason of the GuestDefaultsOutdated condition
+	guestDefaultsChangedReason = "GuestDefaultsChanged"
+	// guestDefaultsRestartBatchSize VMs are restarted for changed cluster defaults within
+	// guestDefaultsRestartBatchInterval at most, like the workload updater batches its evictions
+	guestDefaultsRestartBatchSize     = 10
+	guestDefaultsRestartBatchInterval = 60 * time.Second
+	// MachineTypeUpdateReason is added to the event when a deprecated machine type of a VM is updated
+	MachineTypeUpdateReason = "MachineTypeUpdate"
+	// machineTypeChangedReason is the reason of the RestartRequired condition
+	machineTypeChangedReason = "MachineTypeChanged"
+	// crashLoopBackOffReason is the reason of the CrashLoopBackOff condition
+	crashLoopBackOffReason = "CrashLoopBackOff"
+	// SuccessfulRenameVirtualMachineReason is added to the event when a VM is recreated under a new name
+	SuccessfulRenameVirtualMachineReason = "SuccessfulRename"
+	// SuccessfulMoveVirtualMachineReason is added to the event when a VM is recreated in another namespace
+	SuccessfulMoveVirtualMachineReason = "SuccessfulMove"
+	// FailedMoveVirtualMachineReason is added to the event and the MoveFailed condition when a move can never succeed
+	FailedMoveVirtualMachineReason = "FailedMove"
+	// NodeRebootReason is added to the event when the NodeRebootPolicy of a VM is applied
+	NodeRebootReason = "NodeReboot"
+	// WaitingForStartDependenciesReason is added to the event when the start of a VM is delayed until the VMs of its start groups are ready
+	WaitingForStartDependenciesReason = "WaitingForStartDependencies"
+	// ScheduledStartReason is added to the event when a VM is started by its schedule
+	ScheduledStartReason = "ScheduledStart"
+	// ScheduledStopReason is added to the event when a VM is stopped by its schedule
+	ScheduledStopReason = "ScheduledStop"
+	// FailedAllocateMacAddressReason is added to the event when the MAC address pool has no free addresses left for a VM
+	FailedAllocateMacAddressReason = "FailedAllocateMacAddress"
+)
 
 func NewVMController(vmiInformer cache.SharedIndexInformer,
 	vmInformer cache.SharedIndexInformer,
 	dataVolumeInformer cache.SharedIndexInformer,
 	pvcInformer cache.SharedIndexInformer,
 	crInformer cache.SharedIndexInformer,
+	nodeInformer cache.SharedIndexInformer,
 	recorder record.EventRecorder,
-	clientset kubecli.KubevirtClient) *VMController {
+	clientset kubecli.KubevirtClient,
+	clusterConfig *virtconfig.ClusterConfig) *VMController {
 
 	proxy := &sarProxy{client: clientset}
 
@@ -85,6 +133,7 @@ func NewVMController(vmiInformer cache.SharedIndexInformer,
 		dataVolumeInformer:     dataVolumeInformer,
 		pvcInformer:            pvcInformer,
 		crInformer:             crInformer,
+		nodeInformer:           nodeInformer,
 		recorder:               recorder,
 		clientset:              clientset,
 		expectations:           controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
@@ -92,9 +141,15 @@ func NewVMController(vmiInformer cache.SharedIndexInformer,
 		cloneAuthFunc: func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error) {
 			return cdiclone.CanServiceAccountClonePVC(proxy, pvcNamespace, pvcName, saNamespace, saName)
 		},
-		statusUpdater: status.NewVMStatusUpdater(clientset),
+		statusUpdater:       status.NewVMStatusUpdater(clientset),
+		clusterConfig:       clusterConfig,
+		pendingMacAddresses: map[string][]string{},
+		startDependencies:   map[string]string{},
 	}
 
+	// changed cluster defaults can outdate the guest defaults of all running VMs
+	c.clusterConfig.SetConfigModifiedCallback(c.enqueueAllVms)
+
 	c.vmInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.addVirtualMachine,
 		DeleteFunc: c.deleteVirtualMachine,
@@ -132,11 +187,23 @@ type VMController struct {
 	dataVolumeInformer     cache.SharedIndexInformer
 	pvcInformer            cache.SharedIndexInformer
 	crInformer             cache.SharedIndexInformer
+	nodeInformer           cache.SharedIndexInformer
 	recorder               record.EventRecorder
 	expectations           *controller.UIDTrackingControllerExpectations
 	dataVolumeExpectations *controller.UIDTrackingControllerExpectations
 	cloneAuthFunc          CloneAuthFunc
 	statusUpdater          *status.VMStatusUpdater
+	clusterConfig          *virtconfig.ClusterConfig
+	// pendingMacAddresses holds the MAC addresses allocated to a VM until they show up in the informer cache
+	pendingMacAddresses map[string][]string
+	macAddressesLock    sync.Mutex
+	// startDependencies holds the VMs each VM last waited for before it could be started
+	startDependencies     map[string]string
+	startDependenciesLock sync.Mutex
+	// guestDefaultsRestartBatch is the start of the current batch of restarts for changed cluster defaults
+	guestDefaultsRestartBatch      time.Time
+	guestDefaultsRestartBatchCount int
+	guestDefaultsRestartLock       sync.Mutex
 }
 
 func (c *VMController) Run(threadiness int, stopCh <-chan struct{}) {
@@ -145,7 +212,7 @@ func (c *VMController) Run(threadiness int, stopCh <-chan struct{}) {
 	log.Log.Info("Starting VirtualMachine controller.")
 
 	// Wait for cache sync before we start the controller
-	cache.WaitForCacheSync(stopCh, c.vmiInformer.HasSynced, c.vmInformer.HasSynced, c.dataVolumeInformer.HasSynced)
+	cache.WaitForCacheSync(stopCh, c.vmiInformer.HasSynced, c.vmInformer.HasSynced, c.dataVolumeInformer.HasSynced, c.nodeInformer.HasSynced)
 
 	// Start the actual work
 	for i := 0; i < threadiness; i++ {
@@ -190,6 +257,7 @@ func (c *VMController) execute(key string) error {
 	if !exists {
 		// nothing we need to do. It should always be possible to re-create this type of controller
 		c.expectations.DeleteExpectations(key)
+		c.setStartDependencies(key, nil)
 		return nil
 	}
 	vm := obj.(*virtv1.VirtualMachine)
@@ -273,6 +341,60 @@ func (c *VMController) execute(key string) error {
 
 	var createErr error
 
+	if c.needsSync(key) && vm.ObjectMeta.DeletionTimestamp == nil && hasRenameRequest(vm) {
+		renamed, err := c.handleVMRename(vm, vmi, dataVolumes)
+		if renamed {
+			// the VirtualMachine is gone, there is no status left to update
+			return nil
+		}
+		if err != nil {
+			logger.Reason(err).Error("Renaming the VirtualMachine failed.")
+			if statusErr := c.updateStatus(vm, vmi, err); statusErr != nil {
+				logger.Reason(statusErr).Error("Updating the VirtualMachine status failed.")
+			}
+			return err
+		}
+	}
+
+	if c.needsSync(key) && vm.ObjectMeta.DeletionTimestamp == nil && hasMoveRequest(vm) {
+		done, err := c.handleVMMove(vm, vmi)
+		if done {
+			// the VirtualMachine is gone, or the failed move was already recorded in its status
+			return nil
+		}
+		if err != nil {
+			logger.Reason(err).Error("Moving the VirtualMachine failed.")
+			if statusErr := c.updateStatus(vm, vmi, err); statusErr != nil {
+				logger.Reason(statusErr).Error("Updating the VirtualMachine status failed.")
+			}
+			return err
+		}
+	}
+
+	if c.needsSync(key) && vm.ObjectMeta.DeletionTimestamp == nil && vm.Spec.Schedule != nil {
+		updated, err := c.handleSchedule(vm, vmKey)
+		if err != nil {
+			logger.Reason(err).Error("Applying the VirtualMachine schedule failed.")
+			return err
+		}
+		if updated {
+			// the update of the VirtualMachine triggers the next sync
+			return nil
+		}
+	}
+
+	if c.needsSync(key) && vm.ObjectMeta.DeletionTimestamp == nil && c.clusterConfig.GetMacAddressPool() != nil {
+		updated, err := c.handleMacAddresses(vm, vmi, vmKey)
+		if err != nil {
+			logger.Reason(err).Error("Assigning MAC addresses to the VirtualMachine failed.")
+			return err
+		}
+		if updated {
+			// the update of the VirtualMachine triggers the next sync
+			return nil
+		}
+	}
+
 	// Scale up or down, if all expected creates and deletes were report by the listener
 	if c.needsSync(key) && vm.ObjectMeta.DeletionTimestamp == nil {
 		runStrategy, err := vm.RunStrategy()
@@ -296,6 +418,19 @@ func (c *VMController) execute(key string) error {
 
 			createErr = c.handleVolumeRequests(vm, vmi)
 		}
+
+		if c.needsSync(key) && createErr == nil {
+			createErr = c.handleMemoryDumpRequest(vm, vmi)
+		}
+
+		if c.needsSync(key) && createErr == nil {
+			createErr = c.handleGuestDefaultsUpdate(vm, vmi)
+		}
+
+		if c.needsSync(key) && createErr == nil {
+			// the status is updated on top of the updated VM, to not conflict with its new resource version
+			vm, createErr = c.handleDeprecatedMachineType(vm)
+		}
 	}
 
 	if createErr != nil {
@@ -542,6 +677,180 @@ func (c *VMController) handleVolumeRequests(vm *virtv1.VirtualMachine, vmi *virt
 	return nil
 }
 
+// handleMemoryDumpRequest hotplugs the PVC of a memory dump request to the VMI while the
+// memory dump is taken. The PVC stays associated with the VM until its removal is requested.
+func (c *VMController) handleMemoryDumpRequest(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
+	request := vm.Status.MemoryDumpRequest
+	if request == nil {
+		return nil
+	}
+
+	vmiHasVolume := false
+	if vmi != nil {
+		for _, volume := range vmi.Spec.Volumes {
+			if volume.Name == request.ClaimName {
+				vmiHasVolume = true
+				break
+			}
+		}
+	}
+
+	switch request.Phase {
+	case virtv1.MemoryDumpAssociating:
+		if vmi == nil || vmi.DeletionTimestamp != nil || !vmi.IsRunning() {
+			return nil
+		}
+		if err := c.updateMemoryDumpVolume(vm, request.ClaimName, true); err != nil {
+			return err
+		}
+		if !vmiHasVolume {
+			return c.patchVMIMemoryDumpVolume(vmi, request.ClaimName, true)
+		}
+	case virtv1.MemoryDumpUnmounting, virtv1.MemoryDumpFailed:
+		if vmiHasVolume && vmi.DeletionTimestamp == nil {
+			return c.patchVMIMemoryDumpVolume(vmi, request.ClaimName, false)
+		}
+	case virtv1.MemoryDumpDissociating:
+		if vmiHasVolume && vmi.DeletionTimestamp == nil {
+			if err := c.patchVMIMemoryDumpVolume(vmi, request.ClaimName, false); err != nil {
+				return err
+			}
+		}
+		return c.updateMemoryDumpVolume(vm, request.ClaimName, false)
+	}
+	return nil
+}
+
+func newMemoryDumpVolume(claimName string) virtv1.Volume {
+	return virtv1.Volume{
+		Name: claimName,
+		VolumeSource: virtv1.VolumeSource{
+			MemoryDump: &virtv1.MemoryDumpVolumeSource{
+				ClaimName: claimName,
+			},
+		},
+	}
+}
+
+func withoutMemoryDumpVolumes(volumes []virtv1.Volume) []virtv1.Volume {
+	var filtered []virtv1.Volume
+	for _, volume := range volumes {
+		if volume.MemoryDump == nil {
+			filtered = append(filtered, volume)
+		}
+	}
+	return filtered
+}
+
+// updateMemoryDumpVolume adds or removes the memory dump volume of claimName in the VM template
+func (c *VMController) updateMemoryDumpVolume(vm *virtv1.VirtualMachine, claimName string, add bool) error {
+	var volumes []virtv1.Volume
+	found := false
+	for _, volume := range vm.Spec.Template.Spec.Volumes {
+		if volume.MemoryDump != nil && volume.Name == claimName {
+			found = true
+			continue
+		}
+		volumes = append(volumes, volume)
+	}
+	if found == add {
+		return nil
+	}
+	if add {
+		volumes = append(volumes, newMemoryDumpVolume(claimName))
+	}
+
+	vmCopy := vm.DeepCopy()
+	vmCopy.Spec.Template.Spec.Volumes = volumes
+	_, err := c.clientset.VirtualMachine(vmCopy.Namespace).Update(vmCopy)
+	return err
+}
+
+// patchVMIMemoryDumpVolume hotplugs or unplugs the memory dump volume of claimName
+func (c *VMController) patchVMIMemoryDumpVolume(vmi *virtv1.VirtualMachineInstance, claimName string, add bool) error {
+	var volumes []virtv1.Volume
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Name != claimName {
+			volumes = append(volumes, volume)
+		}
+	}
+	if add {
+		volumes = append(volumes, newMemoryDumpVolume(claimName))
+	}
+
+	newVolumesJson, err := json.Marshal(volumes)
+	if err != nil {
+		return err
+	}
+	patch := fmt.Sprintf(`[{ "op": "add", "path": "/spec/volumes", "value": %s}]`, string(newVolumesJson))
+	if len(vmi.Spec.Volumes) > 0 {
+		oldVolumesJson, err := json.Marshal(vmi.Spec.Volumes)
+		if err != nil {
+			return err
+		}
+		patch = fmt.Sprintf(`[{ "op": "test", "path": "/spec/volumes", "value": %s}, { "op": "replace", "path": "/spec/volumes", "value": %s}]`, string(oldVolumesJson), string(newVolumesJson))
+	}
+
+	_, err = c.clientset.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, []byte(patch))
+	return err
+}
+
+// updateMemoryDumpRequest moves the memory dump request of the VM forward based on
+// the status of the memory dump volume reported on the VMI
+func updateMemoryDumpRequest(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
+	request := vm.Status.MemoryDumpRequest
+	if request == nil {
+		return
+	}
+
+	var volumeStatus *virtv1.VolumeStatus
+	if vmi != nil {
+		for i := range vmi.Status.VolumeStatus {
+			if vmi.Status.VolumeStatus[i].Name == request.ClaimName {
+				volumeStatus = &vmi.Status.VolumeStatus[i]
+				break
+			}
+		}
+	}
+
+	switch request.Phase {
+	case virtv1.MemoryDumpAssociating, virtv1.MemoryDumpInProgress:
+		if vmi == nil || vmi.IsFinal() {
+			request.Phase = virtv1.MemoryDumpFailed
+			request.Message = "VMI stopped before the memory dump completed"
+			return
+		}
+		if volumeStatus == nil || volumeStatus.MemoryDumpVolume == nil {
+			return
+		}
+		switch volumeStatus.Phase {
+		case virtv1.MemoryDumpVolumeInProgress:
+			request.Phase = virtv1.MemoryDumpInProgress
+			request.StartTimestamp = volumeStatus.MemoryDumpVolume.StartTimestamp
+		case virtv1.MemoryDumpVolumeCompleted:
+			request.Phase = virtv1.MemoryDumpUnmounting
+			request.StartTimestamp = volumeStatus.MemoryDumpVolume.StartTimestamp
+			request.EndTimestamp = volumeStatus.MemoryDumpVolume.EndTimestamp
+			fileName := volumeStatus.MemoryDumpVolume.TargetFileName
+			request.FileName = &fileName
+		case virtv1.MemoryDumpVolumeFailed:
+			request.Phase = virtv1.MemoryDumpFailed
+			request.Message = volumeStatus.Message
+		}
+	case virtv1.MemoryDumpUnmounting:
+		if volumeStatus == nil {
+			request.Phase = virtv1.MemoryDumpCompleted
+		}
+	case virtv1.MemoryDumpDissociating:
+		for _, volume := range vm.Spec.Template.Spec.Volumes {
+			if volume.Name == request.ClaimName {
+				return
+			}
+		}
+		vm.Status.MemoryDumpRequest = nil
+	}
+}
+
 func (c *VMController) startStop(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
 	runStrategy, err := vm.RunStrategy()
 	if err != nil {
@@ -577,6 +886,13 @@ func (c *VMController) startStop(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualM
 				log.Log.Object(vm).Infof("processing forced restart request for VMI with phase %s and VM runStrategy: %s", vmi.Status.Phase, runStrategy)
 			}
 
+			if !forceRestart && vmi.IsFinal() {
+				held, err := c.holdAfterNodeReboot(vm, vmi)
+				if err != nil || held {
+					return err
+				}
+			}
+
 			if forceRestart || vmi.IsFinal() {
 				log.Log.Object(vm).Infof("%s with VMI in phase %s and VM runStrategy: %s", stoppingVmMsg, vmi.Status.Phase, runStrategy)
 
@@ -619,6 +935,13 @@ func (c *VMController) startStop(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualM
 
 			}
 
+			if !forceStop && vmi.Status.Phase == virtv1.Failed {
+				held, err := c.holdAfterNodeReboot(vm, vmi)
+				if err != nil || held {
+					return err
+				}
+			}
+
 			if forceStop || vmi.Status.Phase == virtv1.Failed {
 				// For RerunOnFailure, this controller should only restart the VirtualMachineInstance
 				// if it failed.
@@ -711,6 +1034,64 @@ func (c *VMController) startStop(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualM
 	}
 }
 
+// failedByNodeReboot determines whether the VMI failed because the node it was
+// running on rebooted, which is revealed by a changed boot ID of the node.
+func (c *VMController) failedByNodeReboot(vmi *virtv1.VirtualMachineInstance) (bool, error) {
+	if vmi.Status.Phase != virtv1.Failed || vmi.Status.NodeName == "" || vmi.Status.NodeBootID == "" {
+		return false, nil
+	}
+	obj, exists, err := c.nodeInformer.GetStore().GetByKey(vmi.Status.NodeName)
+	if err != nil {
+		return false, err
+	} else if !exists {
+		// a removed node did not reboot
+		return false, nil
+	}
+	node := obj.(*k8score.Node)
+	return node.Status.NodeInfo.BootID != "" && node.Status.NodeInfo.BootID != vmi.Status.NodeBootID, nil
+}
+
+func hasNodeRebootPolicy(vm *virtv1.VirtualMachine, policy virtv1.NodeRebootPolicy) bool {
+	return vm.Spec.NodeRebootPolicy != nil && *vm.Spec.NodeRebootPolicy == policy
+}
+
+// holdAfterNodeReboot determines whether a failed VMI has to be kept instead of being
+// restarted by the RunStrategy, because its node rebooted and the VM asks to hold it.
+func (c *VMController) holdAfterNodeReboot(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) (bool, error) {
+	if !hasNodeRebootPolicy(vm, virtv1.NodeRebootPolicyHold) {
+		return false, nil
+	}
+	rebooted, err := c.failedByNodeReboot(vmi)
+	if err != nil {
+		log.Log.Object(vm).Reason(err).Errorf("Failed to detect whether node %s rebooted", vmi.Status.NodeName)
+		return false, err
+	}
+	if rebooted {
+		log.Log.Object(vm).Infof("Holding VMI which failed due to a reboot of node %s", vmi.Status.NodeName)
+		c.recorder.Eventf(vm, k8score.EventTypeNormal, NodeRebootReason, "Holding VMI which failed due to a reboot of node %s until the VM is restarted", vmi.Status.NodeName)
+	}
+	return rebooted, nil
+}
+
+// restartAfterNodeReboot determines whether a VMI of a VM with the Manual RunStrategy has
+// to be restarted, because its node rebooted and the VM asks to restart it.
+func (c *VMController) restartAfterNodeReboot(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) (bool, error) {
+	if vmi == nil || !hasNodeRebootPolicy(vm, virtv1.NodeRebootPolicyRestart) {
+		return false, nil
+	}
+	runStrategy, err := vm.RunStrategy()
+	if err != nil || runStrategy != virtv1.RunStrategyManual {
+		// the other RunStrategies decide on their own whether a failed VMI is restarted
+		return false, err
+	}
+	rebooted, err := c.failedByNodeReboot(vmi)
+	if err != nil {
+		log.Log.Object(vm).Reason(err).Errorf("Failed to detect whether node %s rebooted", vmi.Status.NodeName)
+		return false, err
+	}
+	return rebooted, nil
+}
+
 // isVMIStartExpected determines whether a VMI is expected to be started for this VM.
 func (c *VMController) isVMIStartExpected(vm *virtv1.VirtualMachine) bool {
 	vmKey, err := controller.KeyFunc(vm)
@@ -753,6 +1134,19 @@ func (c *VMController) startVMI(vm *virtv1.VirtualMachine) error {
 		return nil
 	}
 
+	pending, err := c.pendingStartDependencies(vm)
+	if err != nil {
+		return err
+	}
+	if c.setStartDependencies(vmKey, pending) {
+		c.recorder.Eventf(vm, k8score.EventTypeNormal, WaitingForStartDependenciesReason, "Waiting for the guest agents of the virtual machines %s to connect", strings.Join(pending, ", "))
+	}
+	if len(pending) > 0 {
+		// the VM is enqueued again once the guest agents of the VMs it waits for are connected
+		log.Log.Object(vm).V(4).Infof("Delaying start of VM until the guest agents of %s are connected", strings.Join(pending, ", "))
+		return nil
+	}
+
 	// start it
 	vmi := c.setupVMIFromVM(vm)
 	vmRevisionName, err := c.createVMRevision(vm)
@@ -780,6 +1174,85 @@ func (c *VMController) startVMI(vm *virtv1.VirtualMachine) error {
 	return nil
 }
 
+// pendingStartDependencies returns the names of the VirtualMachines of the start groups of the
+// VirtualMachine whose VirtualMachineInstances did not connect their guest agent yet. VirtualMachines
+// which are not going to run are not waited for.
+func (c *VMController) pendingStartDependencies(vm *virtv1.VirtualMachine) ([]string, error) {
+	if len(controller.StartAfterGroups(vm)) == 0 {
+		return nil, nil
+	}
+
+	vms, err := c.listControllerFromNamespace(vm.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	conditionManager := controller.NewVirtualMachineInstanceConditionManager()
+	var pending []string
+	for _, dependency := range vms {
+		if !controller.IsStartDependency(vm, dependency) {
+			continue
+		}
+
+		key, err := controller.KeyFunc(dependency)
+		if err != nil {
+			return nil, err
+		}
+		obj, exists, err := c.vmiInformer.GetStore().GetByKey(key)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			// a halted VM, or a manual one which nobody started, would block its dependents forever
+			runStrategy, err := dependency.RunStrategy()
+			if err != nil || runStrategy == virtv1.RunStrategyHalted || runStrategy == virtv1.RunStrategyManual {
+				continue
+			}
+			pending = append(pending, dependency.Name)
+		} else if !conditionManager.HasConditionWithStatus(obj.(*virtv1.VirtualMachineInstance), virtv1.VirtualMachineInstanceAgentConnected, k8score.ConditionTrue) {
+			pending = append(pending, dependency.Name)
+		}
+	}
+	sort.Strings(pending)
+	return pending, nil
+}
+
+// setStartDependencies records the VMs the VM waits for and tells if they changed since the last
+// time, so that the VM is only reported as waiting once and not on every resync
+func (c *VMController) setStartDependencies(vmKey string, pending []string) bool {
+	c.startDependenciesLock.Lock()
+	defer c.startDependenciesLock.Unlock()
+
+	if len(pending) == 0 {
+		delete(c.startDependencies, vmKey)
+		return false
+	}
+	joined := strings.Join(pending, ",")
+	if c.startDependencies[vmKey] == joined {
+		return false
+	}
+	c.startDependencies[vmKey] = joined
+	return true
+}
+
+// enqueueStartDependents wakes up the VirtualMachines which wait for the start group of the VirtualMachine
+func (c *VMController) enqueueStartDependents(vm *virtv1.VirtualMachine) {
+	if _, isMember := vm.Labels[virtv1.StartGroupLabel]; !isMember {
+		return
+	}
+
+	vms, err := c.listControllerFromNamespace(vm.Namespace)
+	if err != nil {
+		log.Log.Object(vm).Reason(err).Error("Failed to list the VMs waiting for its start group")
+		return
+	}
+	for _, dependent := range vms {
+		if controller.IsStartDependency(dependent, vm) {
+			c.enqueueVm(dependent)
+		}
+	}
+}
+
 // Returns in seconds how long to wait before trying to start the VM again.
 func calculateStartBackoffTime(failCount int, maxDelay int) int {
 	// The algorithm is designed to work well with a dynamic maxDelay
@@ -838,26 +1311,74 @@ func vmiFailedEarly(vmi *virtv1.VirtualMachineInstance) bool {
 	return true
 }
 
-// clear start failure tracking if...
-// 1. VMI exists and ever hit running phase
-// 2. run strategy is not set to automatically restart failed VMIs
-func shouldClearStartFailure(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) bool {
+// Reports how long the vmi has been in the running phase, or was until it finished
+func vmiRunningDuration(vmi *virtv1.VirtualMachineInstance) time.Duration {
+	if vmi == nil {
+		return 0
+	}
 
-	if wasVMIInRunningPhase(vmi) {
-		return true
+	var startedAt, finishedAt *v1.Time
+	for i, ts := range vmi.Status.PhaseTransitionTimestamps {
+		switch ts.Phase {
+		case virtv1.Running:
+			startedAt = &vmi.Status.PhaseTransitionTimestamps[i].PhaseTransitionTimestamp
+		case virtv1.Succeeded, virtv1.Failed:
+			finishedAt = &vmi.Status.PhaseTransitionTimestamps[i].PhaseTransitionTimestamp
+		}
 	}
 
+	if startedAt == nil {
+		return 0
+	}
+	if finishedAt == nil {
+		return time.Since(startedAt.Time)
+	}
+	return finishedAt.Sub(startedAt.Time)
+}
+
+// clear start failure tracking if...
+// 1. run strategy is not set to automatically restart failed VMIs
+// 2. run strategy is Always and the VMI ever hit running phase
+// 3. run strategy is RerunOnFailure and the VMI succeeded or runs longer than the reset period
+func (c *VMController) shouldClearStartFailure(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) bool {
 	runStrategy, err := vm.RunStrategy()
 	if err != nil {
 		log.Log.Object(vm).Errorf("Error fetching RunStrategy: %v", err)
-		return false
+		return wasVMIInRunningPhase(vmi)
+	}
+
+	switch runStrategy {
+	case virtv1.RunStrategyAlways:
+		return wasVMIInRunningPhase(vmi)
+	case virtv1.RunStrategyRerunOnFailure:
+		if vmi == nil {
+			return false
+		}
+		if vmi.Status.Phase == virtv1.Succeeded {
+			return true
+		}
+		// a VMI which keeps failing shortly after it started is still crash looping
+		return !vmi.IsFinal() && vmiRunningDuration(vmi) >= c.clusterConfig.GetCrashLoopBackOffResetAfter()
+	default:
+		return true
 	}
+}
 
-	if runStrategy != virtv1.RunStrategyAlways && runStrategy != virtv1.RunStrategyRerunOnFailure {
+// Reports if the failure of the vmi has to be delayed by a crash loop backoff before the vm restarts it
+func isCrashLoopFailure(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) bool {
+	if vmi == nil {
+		return false
+	}
+	if vmiFailedEarly(vmi) {
 		return true
 	}
 
-	return false
+	runStrategy, err := vm.RunStrategy()
+	if err != nil {
+		return false
+	}
+	// RerunOnFailure restarts VMIs which failed after running as well
+	return runStrategy == virtv1.RunStrategyRerunOnFailure && vmi.Status.Phase == virtv1.Failed
 }
 
 func startFailureBackoffTimeLeft(vm *virtv1.VirtualMachine) int64 {
@@ -877,13 +1398,13 @@ func startFailureBackoffTimeLeft(vm *virtv1.VirtualMachine) int64 {
 	return 0
 }
 
-func syncStartFailureStatus(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
-	if shouldClearStartFailure(vm, vmi) {
-		// if a vmi associated with the vm hits a running phase, then reset the start failure counter
+func (c *VMController) syncStartFailureStatus(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
+	if c.shouldClearStartFailure(vm, vmi) {
+		// if a vmi associated with the vm runs successfully, then reset the start failure counter
 		vm.Status.StartFailure = nil
 
-	} else if vmi != nil && vmiFailedEarly(vmi) {
-		// if the VMI failed without ever hitting running successfully,
+	} else if isCrashLoopFailure(vm, vmi) {
+		// if the VMI failed without running successfully,
 		// record this as a start failure so we can back off retrying
 		if vm.Status.StartFailure != nil && vm.Status.StartFailure.LastFailedVMIUID == vmi.UID {
 			// already counted this failure
@@ -891,12 +1412,14 @@ func syncStartFailureStatus(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachin
 		}
 		count := 1
 
-		if vm.Status.StartFailure != nil {
+		// a VMI which ran for longer than the reset period before failing starts a new crash loop
+		if vm.Status.StartFailure != nil && vmiRunningDuration(vmi) < c.clusterConfig.GetCrashLoopBackOffResetAfter() {
 			count = vm.Status.StartFailure.ConsecutiveFailCount + 1
 		}
+		vm.Status.RestartCount++
 
 		now := v1.NewTime(time.Now())
-		delaySeconds := calculateStartBackoffTime(count, defaultMaxCrashLoopBackoffDelaySeconds)
+		delaySeconds := calculateStartBackoffTime(count, c.clusterConfig.GetCrashLoopBackOffMaxDelaySeconds())
 		retryAfter := v1.NewTime(now.Time.Add(time.Duration(int64(delaySeconds)) * time.Second))
 
 		vm.Status.StartFailure = &virtv1.VirtualMachineStartFailure{
@@ -1025,24 +1548,440 @@ func (c *VMController) createVMRevision(vm *virtv1.VirtualMachine) (string, erro
 	return cr.Name, nil
 }
 
-// setupVMIfromVM creates a VirtualMachineInstance object from one VirtualMachine object.
-func (c *VMController) setupVMIFromVM(vm *virtv1.VirtualMachine) *virtv1.VirtualMachineInstance {
-
-	vmi := virtv1.NewVMIReferenceFromNameWithNS(vm.ObjectMeta.Namespace, "")
-	vmi.ObjectMeta = vm.Spec.Template.ObjectMeta
-	vmi.ObjectMeta.Name = vm.ObjectMeta.Name
-	vmi.ObjectMeta.GenerateName = ""
-	vmi.ObjectMeta.Namespace = vm.ObjectMeta.Namespace
-	vmi.Spec = vm.Spec.Template.Spec
+func hasRenameRequest(vm *virtv1.VirtualMachine) bool {
+	return len(vm.Status.StateChangeRequests) != 0 && vm.Status.StateChangeRequests[0].Action == virtv1.RenameRequest
+}
 
-	if hasStartPausedRequest(vm) {
-		strategy := virtv1.StartStrategyPaused
-		vmi.Spec.StartStrategy = &strategy
+// renamedVM returns a copy of the VirtualMachine which carries the new name
+func renamedVM(vm *virtv1.VirtualMachine, newName string) *virtv1.VirtualMachine {
+	newVM := &virtv1.VirtualMachine{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        newName,
+			Namespace:   vm.Namespace,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Spec: *vm.Spec.DeepCopy(),
+	}
+	for k, v := range vm.Labels {
+		newVM.Labels[k] = v
 	}
+	for k, v := range vm.Annotations {
+		newVM.Annotations[k] = v
+	}
+	newVM.Annotations[virtv1.RenamedFromAnnotation] = vm.Name
 
-	setupStableFirmwareUUID(vm, vmi)
+	// The firmware UUID is derived from the name unless it is set explicitly,
+	// pin it so that the guest does not notice the rename
+	pinFirmwareUUID(newVM, vm.Name)
+	return newVM
+}
 
-	// TODO check if vmi labels exist, and when make sure that they match. For now just override them
+func pinFirmwareUUID(vm *virtv1.VirtualMachine, name string) {
+	if vm.Spec.Template.Spec.Domain.Firmware == nil {
+		vm.Spec.Template.Spec.Domain.Firmware = &virtv1.Firmware{}
+	}
+	if vm.Spec.Template.Spec.Domain.Firmware.UUID == "" {
+		vm.Spec.Template.Spec.Domain.Firmware.UUID = types.UID(uuid.NewSHA1(firmwareUUIDns, []byte(name)).String())
+	}
+}
+
+func replaceOwnerReferencePatch(refs []v1.OwnerReference, oldOwner types.UID, newOwner *virtv1.VirtualMachine) ([]byte, error) {
+	newRefs := []v1.OwnerReference{}
+	for _, ref := range refs {
+		if ref.UID == oldOwner {
+			ref = *v1.NewControllerRef(newOwner, virtv1.VirtualMachineGroupVersionKind)
+		}
+		newRefs = append(newRefs, ref)
+	}
+	oldRefsJson, err := json.Marshal(refs)
+	if err != nil {
+		return nil, err
+	}
+	newRefsJson, err := json.Marshal(newRefs)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(fmt.Sprintf(`[{ "op": "test", "path": "/metadata/ownerReferences", "value": %s}, { "op": "replace", "path": "/metadata/ownerReferences", "value": %s}]`, oldRefsJson, newRefsJson)), nil
+}
+
+// handleVMRename recreates a stopped VirtualMachine under the requested name. The DataVolumes and
+// ControllerRevisions of the VirtualMachine are handed over to the new one before the old one is
+// deleted, so that they are not garbage collected. It returns true once the old VirtualMachine is deleted.
+func (c *VMController) handleVMRename(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance, dataVolumes []*cdiv1.DataVolume) (bool, error) {
+	if vmi != nil && !vmi.IsFinal() {
+		return false, fmt.Errorf("VM must be stopped to be renamed")
+	}
+	newName := vm.Status.StateChangeRequests[0].Data[virtv1.RenameRequestDataNewNameKey]
+	if newName == "" {
+		return false, fmt.Errorf("rename request has no new name")
+	}
+
+	newVM, err := c.clientset.VirtualMachine(vm.Namespace).Get(newName, &v1.GetOptions{})
+	if errors.IsNotFound(err) {
+		newVM, err = c.clientset.VirtualMachine(vm.Namespace).Create(renamedVM(vm, newName))
+	}
+	if err != nil {
+		return false, err
+	}
+	if newVM.Annotations[virtv1.RenamedFromAnnotation] != vm.Name {
+		return false, fmt.Errorf("unable to rename VM to %s, a VM with this name already exists", newName)
+	}
+
+	for _, dataVolume := range dataVolumes {
+		if !v1.IsControlledBy(dataVolume, vm) {
+			continue
+		}
+		patch, err := replaceOwnerReferencePatch(dataVolume.OwnerReferences, vm.UID, newVM)
+		if err != nil {
+			return false, err
+		}
+		_, err = c.clientset.CdiClient().CdiV1beta1().DataVolumes(vm.Namespace).Patch(context.Background(), dataVolume.Name, types.JSONPatchType, patch, v1.PatchOptions{})
+		if err != nil {
+			return false, err
+		}
+	}
+
+	keys, err := c.crInformer.GetIndexer().IndexKeys("vm", string(vm.UID))
+	if err != nil {
+		return false, err
+	}
+	for _, key := range keys {
+		storeObj, exists, err := c.crInformer.GetStore().GetByKey(key)
+		if !exists || err != nil {
+			return false, err
+		}
+		cr, ok := storeObj.(*appsv1.ControllerRevision)
+		if !ok {
+			return false, fmt.Errorf("unexpected resource %+v", storeObj)
+		}
+		patch, err := replaceOwnerReferencePatch(cr.OwnerReferences, vm.UID, newVM)
+		if err != nil {
+			return false, err
+		}
+		_, err = c.clientset.AppsV1().ControllerRevisions(vm.Namespace).Patch(context.Background(), cr.Name, types.JSONPatchType, patch, v1.PatchOptions{})
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err = c.clientset.VirtualMachine(vm.Namespace).Delete(vm.Name, &v1.DeleteOptions{Preconditions: &v1.Preconditions{UID: &vm.UID}})
+	if err != nil && !errors.IsNotFound(err) {
+		return false, err
+	}
+	c.recorder.Eventf(newVM, k8score.EventTypeNormal, SuccessfulRenameVirtualMachineReason, "Renamed VirtualMachine %s to %s", vm.Name, newVM.Name)
+	return true, nil
+}
+
+func hasMoveRequest(vm *virtv1.VirtualMachine) bool {
+	return len(vm.Status.StateChangeRequests) != 0 && vm.Status.StateChangeRequests[0].Action == virtv1.MoveRequest
+}
+
+// movedVM returns a copy of the VirtualMachine which lives in the new namespace. The guest visible
+// identity is pinned: the firmware UUID, and the MAC addresses the last VMI of the VM was assigned.
+// Without a VMI, the MAC addresses of the template are kept, which the MAC address pool fills in.
+func movedVM(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance, newNamespace string) *virtv1.VirtualMachine {
+	newVM := &virtv1.VirtualMachine{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        vm.Name,
+			Namespace:   newNamespace,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Spec: *vm.Spec.DeepCopy(),
+	}
+	for k, v := range vm.Labels {
+		newVM.Labels[k] = v
+	}
+	for k, v := range vm.Annotations {
+		newVM.Annotations[k] = v
+	}
+	newVM.Annotations[virtv1.MovedFromAnnotation] = vm.Namespace
+
+	pinFirmwareUUID(newVM, vm.Name)
+
+	if vmi != nil {
+		macs := map[string]string{}
+		for _, iface := range vmi.Status.Interfaces {
+			if iface.Name != "" && iface.MAC != "" {
+				macs[iface.Name] = iface.MAC
+			}
+		}
+		interfaces := newVM.Spec.Template.Spec.Domain.Devices.Interfaces
+		for i := range interfaces {
+			if interfaces[i].MacAddress == "" {
+				interfaces[i].MacAddress = macs[interfaces[i].Name]
+			}
+		}
+	}
+	return newVM
+}
+
+// movedDataVolume returns a DataVolume in the new namespace which clones the given PVC of the VM
+func movedDataVolume(vm *virtv1.VirtualMachine, meta v1.ObjectMeta, spec cdiv1.DataVolumeSpec, newNamespace string) *cdiv1.DataVolume {
+	dataVolume := &cdiv1.DataVolume{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        meta.Name,
+			Namespace:   newNamespace,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Spec: spec,
+	}
+	for k, v := range meta.Labels {
+		dataVolume.Labels[k] = v
+	}
+	for k, v := range meta.Annotations {
+		dataVolume.Annotations[k] = v
+	}
+	dataVolume.Annotations[virtv1.MovedFromAnnotation] = vm.Namespace
+	dataVolume.Annotations[virtv1.MovedFromUIDAnnotation] = string(vm.UID)
+	dataVolume.Annotations[cdiBindImmediateAnnotation] = "true"
+
+	dataVolume.Spec.SourceRef = nil
+	dataVolume.Spec.Source = &cdiv1.DataVolumeSource{
+		PVC: &cdiv1.DataVolumeSourcePVC{
+			Namespace: vm.Namespace,
+			Name:      meta.Name,
+		},
+	}
+	return dataVolume
+}
+
+// moveDataVolumes returns the clones of all volumes of the VM which are backed by PVCs. The clones of templated
+// DataVolumes keep their template spec, standalone PVCs and DataVolumes are cloned with the spec of their PVC.
+func (c *VMController) moveDataVolumes(vm *virtv1.VirtualMachine, newNamespace string) ([]*cdiv1.DataVolume, []string, error) {
+	var clones []*cdiv1.DataVolume
+	var standalone []string
+
+	templated := map[string]bool{}
+	for _, template := range vm.Spec.DataVolumeTemplates {
+		templated[template.Name] = true
+		clones = append(clones, movedDataVolume(vm, template.ObjectMeta, *template.Spec.DeepCopy(), newNamespace))
+	}
+
+	for _, volume := range vm.Spec.Template.Spec.Volumes {
+		var claimName string
+		switch {
+		case volume.PersistentVolumeClaim != nil:
+			claimName = volume.PersistentVolumeClaim.ClaimName
+		case volume.DataVolume != nil:
+			claimName = volume.DataVolume.Name
+		default:
+			continue
+		}
+		if templated[claimName] {
+			continue
+		}
+		templated[claimName] = true
+
+		obj, exists, err := c.pvcInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", vm.Namespace, claimName))
+		if err != nil {
+			return nil, nil, err
+		}
+		if !exists {
+			return nil, nil, fmt.Errorf("PVC %s of the VM does not exist", claimName)
+		}
+		pvc := obj.(*k8score.PersistentVolumeClaim)
+		spec := cdiv1.DataVolumeSpec{
+			PVC: &k8score.PersistentVolumeClaimSpec{
+				AccessModes:      pvc.Spec.AccessModes,
+				VolumeMode:       pvc.Spec.VolumeMode,
+				StorageClassName: pvc.Spec.StorageClassName,
+				Resources:        *pvc.Spec.Resources.DeepCopy(),
+			},
+		}
+		clones = append(clones, movedDataVolume(vm, v1.ObjectMeta{Name: claimName, Labels: pvc.Labels}, spec, newNamespace))
+		standalone = append(standalone, claimName)
+	}
+	return clones, standalone, nil
+}
+
+// handleVMMove recreates a stopped VirtualMachine in the requested namespace. Every PVC of the VirtualMachine is
+// cloned into the new namespace first, the new VirtualMachine adopts the clones of its templated DataVolumes.
+// The old VirtualMachine is deleted once all clones succeeded, which garbage collects its templated DataVolumes,
+// standalone PVCs are left in place. A move which can never succeed, because a clone failed, the VM was started
+// or the target is taken, is dropped with the MoveFailed condition. It returns true once the old VirtualMachine
+// is deleted or the failed move is recorded.
+func (c *VMController) handleVMMove(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) (bool, error) {
+	newNamespace := vm.Status.StateChangeRequests[0].Data[virtv1.MoveRequestDataNewNamespaceKey]
+	if newNamespace == "" {
+		return true, c.failVMMove(vm, newNamespace, "move request has no new namespace")
+	}
+	// starting the VM cancels the move
+	if vmi != nil && !vmi.IsFinal() {
+		return true, c.failVMMove(vm, newNamespace, "the VM was started while it was moved")
+	}
+
+	obj, newVMExists, err := c.vmInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", newNamespace, vm.Name))
+	if err != nil {
+		return false, err
+	}
+	var newVM *virtv1.VirtualMachine
+	if newVMExists {
+		newVM = obj.(*virtv1.VirtualMachine)
+		if newVM.Annotations[virtv1.MovedFromAnnotation] != vm.Namespace {
+			return true, c.failVMMove(vm, newNamespace, fmt.Sprintf("a VM named %s already exists in namespace %s", vm.Name, newNamespace))
+		}
+	}
+
+	clones, standalone, err := c.moveDataVolumes(vm, newNamespace)
+	if err != nil {
+		return false, err
+	}
+
+	cloned := true
+	for _, clone := range clones {
+		obj, exists, err := c.dataVolumeInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", newNamespace, clone.Name))
+		if err != nil {
+			return false, err
+		}
+		if !exists {
+			dataVolume, err := c.clientset.CdiClient().CdiV1beta1().DataVolumes(newNamespace).Create(context.Background(), clone, v1.CreateOptions{})
+			if err != nil && !errors.IsAlreadyExists(err) {
+				return false, err
+			} else if err == nil {
+				c.recorder.Eventf(vm, k8score.EventTypeNormal, SuccessfulDataVolumeCreateReason, "Created DataVolume %s/%s", newNamespace, dataVolume.Name)
+			}
+			// the clone shows up in the informer later
+			cloned = false
+			continue
+		}
+		dataVolume := obj.(*cdiv1.DataVolume)
+		if !isMovedDataVolumeOf(dataVolume, vm) {
+			return true, c.failVMMove(vm, newNamespace, fmt.Sprintf("a DataVolume named %s already exists in namespace %s", clone.Name, newNamespace))
+		}
+		if dataVolume.Status.Phase == cdiv1.Failed {
+			return true, c.failVMMove(vm, newNamespace, fmt.Sprintf("DataVolume %s/%s failed to clone the disk", newNamespace, dataVolume.Name))
+		}
+		if dataVolume.Status.Phase != cdiv1.Succeeded {
+			cloned = false
+		}
+	}
+	// the MAC addresses of the template are pinned once the MAC address pool filled them in
+	if vmi == nil && c.clusterConfig.GetMacAddressPool() != nil && len(interfacesWithoutMacAddress(vm)) != 0 {
+		cloned = false
+	}
+	if !cloned {
+		key, err := controller.KeyFunc(vm)
+		if err != nil {
+			return false, err
+		}
+		// the clones are not owned by the VM yet, their updates do not trigger a sync
+		c.Queue.AddAfter(key, moveCloneCheckInterval)
+		return false, nil
+	}
+
+	if !newVMExists {
+		newVM, err = c.clientset.VirtualMachine(newNamespace).Create(movedVM(vm, vmi, newNamespace))
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err = c.clientset.VirtualMachine(vm.Namespace).Delete(vm.Name, &v1.DeleteOptions{Preconditions: &v1.Preconditions{UID: &vm.UID}})
+	if err != nil && !errors.IsNotFound(err) {
+		return false, err
+	}
+	if len(standalone) != 0 {
+		c.recorder.Eventf(newVM, k8score.EventTypeNormal, SuccessfulMoveVirtualMachineReason, "Moved VirtualMachine from namespace %s, the standalone volumes %s were left in place", vm.Namespace, strings.Join(standalone, ", "))
+	} else {
+		c.recorder.Eventf(newVM, k8score.EventTypeNormal, SuccessfulMoveVirtualMachineReason, "Moved VirtualMachine from namespace %s", vm.Namespace)
+	}
+	return true, nil
+}
+
+// isMovedDataVolumeOf checks whether the DataVolume was cloned for a move of the VM
+func isMovedDataVolumeOf(dataVolume *cdiv1.DataVolume, vm *virtv1.VirtualMachine) bool {
+	return dataVolume.Annotations[virtv1.MovedFromAnnotation] == vm.Namespace &&
+		dataVolume.Annotations[virtv1.MovedFromUIDAnnotation] == string(vm.UID)
+}
+
+// movedClaimNames returns the names of the PVCs and DataVolumes of the VM which a move clones
+func movedClaimNames(vm *virtv1.VirtualMachine) []string {
+	var names []string
+	seen := map[string]bool{}
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, template := range vm.Spec.DataVolumeTemplates {
+		add(template.Name)
+	}
+	for _, volume := range vm.Spec.Template.Spec.Volumes {
+		switch {
+		case volume.PersistentVolumeClaim != nil:
+			add(volume.PersistentVolumeClaim.ClaimName)
+		case volume.DataVolume != nil:
+			add(volume.DataVolume.Name)
+		}
+	}
+	return names
+}
+
+// failVMMove removes the clones of a move which can never succeed, drops the move request and
+// records why it failed in the MoveFailed condition of the VM. Only the clones created for this
+// VM which were not adopted yet are removed.
+func (c *VMController) failVMMove(vm *virtv1.VirtualMachine, newNamespace string, reason string) error {
+	if newNamespace != "" {
+		for _, name := range movedClaimNames(vm) {
+			obj, exists, err := c.dataVolumeInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", newNamespace, name))
+			if err != nil {
+				return err
+			}
+			if !exists {
+				continue
+			}
+			dataVolume := obj.(*cdiv1.DataVolume)
+			if !isMovedDataVolumeOf(dataVolume, vm) || len(dataVolume.OwnerReferences) != 0 {
+				continue
+			}
+			err = c.clientset.CdiClient().CdiV1beta1().DataVolumes(newNamespace).Delete(context.Background(), dataVolume.Name, v1.DeleteOptions{})
+			if err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+
+	log.Log.Object(vm).Errorf("Moving the VirtualMachine to namespace %s failed: %s", newNamespace, reason)
+	c.recorder.Eventf(vm, k8score.EventTypeWarning, FailedMoveVirtualMachineReason, "Moving the VirtualMachine to namespace %s failed: %s", newNamespace, reason)
+
+	vmCopy := vm.DeepCopy()
+	vmCopy.Status.StateChangeRequests = vmCopy.Status.StateChangeRequests[1:]
+	controller.NewVirtualMachineConditionManager().RemoveCondition(vmCopy, virtv1.VirtualMachineMoveFailed)
+	vmCopy.Status.Conditions = append(vmCopy.Status.Conditions, virtv1.VirtualMachineCondition{
+		Type:               virtv1.VirtualMachineMoveFailed,
+		Status:             k8score.ConditionTrue,
+		LastProbeTime:      v1.Now(),
+		LastTransitionTime: v1.Now(),
+		Reason:             FailedMoveVirtualMachineReason,
+		Message:            reason,
+	})
+	return c.statusUpdater.UpdateStatus(vmCopy)
+}
+
+// setupVMIfromVM creates a VirtualMachineInstance object from one VirtualMachine object.
+func (c *VMController) setupVMIFromVM(vm *virtv1.VirtualMachine) *virtv1.VirtualMachineInstance {
+
+	vmi := virtv1.NewVMIReferenceFromNameWithNS(vm.ObjectMeta.Namespace, "")
+	vmi.ObjectMeta = vm.Spec.Template.ObjectMeta
+	vmi.ObjectMeta.Name = vm.ObjectMeta.Name
+	vmi.ObjectMeta.GenerateName = ""
+	vmi.ObjectMeta.Namespace = vm.ObjectMeta.Namespace
+	vmi.Spec = vm.Spec.Template.Spec
+	// memory dump volumes are only hotplugged while a memory dump is taken
+	vmi.Spec.Volumes = withoutMemoryDumpVolumes(vmi.Spec.Volumes)
+
+	if hasStartPausedRequest(vm) {
+		strategy := virtv1.StartStrategyPaused
+		vmi.Spec.StartStrategy = &strategy
+	}
+
+	setupStableFirmwareUUID(vm, vmi)
+
+	// TODO check if vmi labels exist, and when make sure that they match. For now just override them
 	vmi.ObjectMeta.Labels = vm.Spec.Template.ObjectMeta.Labels
 	vmi.ObjectMeta.OwnerReferences = []v1.OwnerReference{
 		*v1.NewControllerRef(vm, virtv1.VirtualMachineGroupVersionKind),
@@ -1245,6 +2184,11 @@ func (c *VMController) updateVirtualMachineInstance(old, cur interface{}) {
 		}
 		log.Log.V(4).Object(curVMI).Infof("VirtualMachineInstance updated")
 		c.enqueueVm(vm)
+		conditionManager := controller.NewVirtualMachineInstanceConditionManager()
+		if conditionManager.HasConditionWithStatus(curVMI, virtv1.VirtualMachineInstanceAgentConnected, k8score.ConditionTrue) &&
+			!conditionManager.HasConditionWithStatus(oldVMI, virtv1.VirtualMachineInstanceAgentConnected, k8score.ConditionTrue) {
+			c.enqueueStartDependents(vm)
+		}
 		// TODO: MinReadySeconds in the VirtualMachineInstance will generate an Available condition to be added in
 		// Update once we support the available conect on the rs
 		return
@@ -1412,10 +2356,19 @@ func (c *VMController) addVirtualMachine(obj interface{}) {
 
 func (c *VMController) deleteVirtualMachine(obj interface{}) {
 	c.enqueueVm(obj)
+	// its dependents don't wait for it anymore
+	if vm, ok := obj.(*virtv1.VirtualMachine); ok {
+		c.enqueueStartDependents(vm)
+	}
 }
 
-func (c *VMController) updateVirtualMachine(_, curr interface{}) {
+func (c *VMController) updateVirtualMachine(old, curr interface{}) {
 	c.enqueueVm(curr)
+	// its dependents don't wait for it anymore once it is halted
+	oldVM, currVM := old.(*virtv1.VirtualMachine), curr.(*virtv1.VirtualMachine)
+	if !reflect.DeepEqual(oldVM.Spec.Running, currVM.Spec.Running) || !reflect.DeepEqual(oldVM.Spec.RunStrategy, currVM.Spec.RunStrategy) {
+		c.enqueueStartDependents(currVM)
+	}
 }
 
 func (c *VMController) enqueueVm(obj interface{}) {
@@ -1428,6 +2381,12 @@ func (c *VMController) enqueueVm(obj interface{}) {
 	c.Queue.Add(key)
 }
 
+func (c *VMController) enqueueAllVms() {
+	for _, obj := range c.vmInformer.GetStore().List() {
+		c.enqueueVm(obj)
+	}
+}
+
 func (c *VMController) removeVMIFinalizer(vmi *virtv1.VirtualMachineInstance) error {
 	vmiCopy := vmi.DeepCopy()
 	controller.RemoveFinalizer(vmiCopy, virtv1.VirtualMachineControllerFinalizer)
@@ -1501,9 +2460,22 @@ func (c *VMController) updateStatus(vmOrig *virtv1.VirtualMachine, vmi *virtv1.V
 				log.Log.Object(vm).V(4).Infof("VMI exists. clearing start request")
 				clearChangeRequest = true
 			}
+		case virtv1.RenameRequest:
+			// If another VM took the new name in the meantime, the rename can never succeed
+			newName := stateChange.Data[virtv1.RenameRequestDataNewNameKey]
+			newVM, err := c.clientset.VirtualMachine(vm.ObjectMeta.Namespace).Get(newName, &v1.GetOptions{})
+			if err == nil && newVM.Annotations[virtv1.RenamedFromAnnotation] != vm.Name {
+				log.Log.Object(vm).Errorf("VM %s already exists. clearing rename request", newName)
+				clearChangeRequest = true
+			}
 		}
 	}
 
+	// a new move request replaces the outcome of the last one
+	if hasMoveRequest(vm) {
+		controller.NewVirtualMachineConditionManager().RemoveCondition(vm, virtv1.VirtualMachineMoveFailed)
+	}
+
 	if len(vm.Status.VolumeRequests) > 0 {
 		volumeMap := make(map[string]virtv1.Volume)
 		diskMap := make(map[string]virtv1.Disk)
@@ -1551,7 +2523,28 @@ func (c *VMController) updateStatus(vmOrig *virtv1.VirtualMachine, vmi *virtv1.V
 		vm.Status.StateChangeRequests = vm.Status.StateChangeRequests[1:]
 	}
 
-	syncStartFailureStatus(vm, vmi)
+	c.syncScheduleStatus(vm, vmi)
+
+	updateMemoryDumpRequest(vm, vmi)
+
+	// Restart a VMI which failed due to a node reboot the same way as a restart request does
+	if len(vm.Status.StateChangeRequests) == 0 {
+		restart, err := c.restartAfterNodeReboot(vm, vmi)
+		if err != nil {
+			return err
+		}
+		if restart {
+			log.Log.Object(vm).Infof("Restarting VMI which failed due to a reboot of node %s", vmi.Status.NodeName)
+			c.recorder.Eventf(vm, k8score.EventTypeNormal, NodeRebootReason, "Restarting VMI which failed due to a reboot of node %s", vmi.Status.NodeName)
+			vm.Status.StateChangeRequests = append(vm.Status.StateChangeRequests,
+				virtv1.VirtualMachineStateChangeRequest{Action: virtv1.StopRequest, UID: &vmi.UID},
+				virtv1.VirtualMachineStateChangeRequest{Action: virtv1.StartRequest})
+		}
+	}
+
+	c.syncStartFailureStatus(vm, vmi)
+
+	c.syncAvailabilityStatus(vm, vmi)
 
 	c.syncReadyConditionFromVMI(vm, vmi)
 
@@ -1562,19 +2555,24 @@ func (c *VMController) updateStatus(vmOrig *virtv1.VirtualMachine, vmi *virtv1.V
 		c.processFailure(vm, vmi, createErr)
 	}
 
-	// Add/Remove Paused condition (VMI paused by user)
+	// Add/Remove Paused condition, reflecting why the VMI is paused (by user or on IO errors)
 	vmiCondManager := controller.NewVirtualMachineInstanceConditionManager()
-	if vmiCondManager.HasCondition(vmi, virtv1.VirtualMachineInstancePaused) {
-		if !vmCondManager.HasCondition(vm, virtv1.VirtualMachinePaused) {
+	if vmiPaused := vmiCondManager.GetCondition(vmi, virtv1.VirtualMachineInstancePaused); vmiPaused != nil {
+		vmPaused := vmCondManager.GetCondition(vm, virtv1.VirtualMachinePaused)
+		if vmPaused == nil || vmPaused.Reason != vmiPaused.Reason || vmPaused.Message != vmiPaused.Message {
 			log.Log.Object(vm).V(3).Info("Adding paused condition")
-			now := v1.NewTime(time.Now())
+			vmCondManager.RemoveCondition(vm, virtv1.VirtualMachinePaused)
+			transitionTime := vmiPaused.LastTransitionTime
+			if transitionTime.IsZero() {
+				transitionTime = v1.NewTime(time.Now())
+			}
 			vm.Status.Conditions = append(vm.Status.Conditions, virtv1.VirtualMachineCondition{
 				Type:               virtv1.VirtualMachinePaused,
 				Status:             k8score.ConditionTrue,
-				LastProbeTime:      now,
-				LastTransitionTime: now,
-				Reason:             "PausedByUser",
-				Message:            "VMI was paused by user",
+				LastProbeTime:      v1.NewTime(time.Now()),
+				LastTransitionTime: transitionTime,
+				Reason:             vmiPaused.Reason,
+				Message:            vmiPaused.Message,
 			})
 		}
 	} else if vmCondManager.HasCondition(vm, virtv1.VirtualMachinePaused) {
@@ -1582,6 +2580,10 @@ func (c *VMController) updateStatus(vmOrig *virtv1.VirtualMachine, vmi *virtv1.V
 		vmCondManager.RemoveCondition(vm, virtv1.VirtualMachinePaused)
 	}
 
+	c.syncGuestDefaultsCondition(vm, vmi)
+	c.syncRestartRequiredCondition(vm, vmi)
+	c.syncCrashLoopBackOffCondition(vm, vmi)
+
 	c.setPrintableStatus(vm, vmi)
 
 	// only update if necessary
@@ -1839,6 +2841,467 @@ func (c *VMController) processFailure(vm *virtv1.VirtualMachine, vmi *virtv1.Vir
 	vmConditionManager.RemoveCondition(vm, virtv1.VirtualMachineFailure)
 }
 
+// outdatedGuestDefaults returns the guest visible fields of the VMI, which were set from
+// cluster defaults that have changed since the VMI was created
+func (c *VMController) outdatedGuestDefaults(vmi *virtv1.VirtualMachineInstance) []string {
+	if vmi == nil || vmi.IsFinal() || vmi.DeletionTimestamp != nil {
+		return nil
+	}
+	return guestdefaults.Outdated(vmi, guestdefaults.Defaults{
+		MachineType:      c.clusterConfig.GetMachineType(),
+		CPUModel:         c.clusterConfig.GetCPUModel(),
+		NetworkInterface: c.clusterConfig.GetDefaultNetworkInterface(),
+	})
+}
+
+func (c *VMController) syncGuestDefaultsCondition(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
+	vmCondManager := controller.NewVirtualMachineConditionManager()
+	outdated := c.outdatedGuestDefaults(vmi)
+	if len(outdated) == 0 {
+		if vmCondManager.HasCondition(vm, virtv1.VirtualMachineGuestDefaultsOutdated) {
+			log.Log.Object(vm).V(3).Info("Removing guest defaults outdated condition")
+			vmCondManager.RemoveCondition(vm, virtv1.VirtualMachineGuestDefaultsOutdated)
+		}
+		return
+	}
+
+	message := fmt.Sprintf("The VMI runs with outdated cluster defaults of: %s", strings.Join(outdated, ", "))
+	for _, cond := range vm.Status.Conditions {
+		if cond.Type == virtv1.VirtualMachineGuestDefaultsOutdated && cond.Message == message {
+			return
+		}
+	}
+
+	log.Log.Object(vm).V(3).Info("Adding guest defaults outdated condition")
+	vmCondManager.RemoveCondition(vm, virtv1.VirtualMachineGuestDefaultsOutdated)
+	now := v1.NewTime(time.Now())
+	vm.Status.Conditions = append(vm.Status.Conditions, virtv1.VirtualMachineCondition{
+		Type:               virtv1.VirtualMachineGuestDefaultsOutdated,
+		Status:             k8score.ConditionTrue,
+		LastProbeTime:      now,
+		LastTransitionTime: now,
+		Reason:             guestDefaultsChangedReason,
+		Message:            message,
+	})
+}
+
+// handleGuestDefaultsUpdate restarts running VMs with outdated guest defaults, if the cluster
+// is configured to do so and the VM does not opt out.
+func (c *VMController) handleGuestDefaultsUpdate(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
+	if c.clusterConfig.GetConfig().GuestDefaultsUpdateStrategy != virtv1.GuestDefaultsUpdateRestart ||
+		vm.Annotations[virtv1.GuestDefaultsUpdateOptOutAnnotation] == "true" {
+		return nil
+	}
+	// only VMs which get started again right away are restarted
+	runStrategy, err := vm.RunStrategy()
+	if err != nil {
+		return err
+	}
+	if runStrategy != virtv1.RunStrategyAlways || vmi == nil || !vmi.IsRunning() {
+		return nil
+	}
+	outdated := c.outdatedGuestDefaults(vmi)
+	if len(outdated) == 0 {
+		return nil
+	}
+
+	// The machine type was defaulted on the VM template, so it has to be updated there before
+	// restarting. The update triggers a new sync which restarts the VMI.
+	machineType := c.clusterConfig.GetMachineType()
+	template := vm.Spec.Template
+	for _, field := range guestdefaults.Applied(&template.ObjectMeta) {
+		if field == guestdefaults.MachineType && template.Spec.Domain.Machine != nil && template.Spec.Domain.Machine.Type != machineType {
+			vmCopy := vm.DeepCopy()
+			vmCopy.Spec.Template.Spec.Domain.Machine.Type = machineType
+			_, err := c.clientset.VirtualMachine(vmCopy.Namespace).Update(vmCopy)
+			return err
+		}
+	}
+
+	if wait := c.reserveGuestDefaultsRestart(); wait > 0 {
+		log.Log.Object(vm).V(3).Infof("Delaying the restart for the changed cluster defaults by %v", wait)
+		key, err := controller.KeyFunc(vm)
+		if err != nil {
+			return err
+		}
+		c.Queue.AddAfter(key, wait)
+		return nil
+	}
+
+	c.recorder.Eventf(vm, k8score.EventTypeNormal, GuestDefaultsUpdateReason, "Restarting the virtual machine to apply the changed cluster defaults of: %s", strings.Join(outdated, ", "))
+	return c.stopVMI(vm, vmi)
+}
+
+// reserveGuestDefaultsRestart reserves a restart for changed cluster defaults in the current batch.
+// It returns how long to wait for the next batch, if the current one is used up.
+func (c *VMController) reserveGuestDefaultsRestart() time.Duration {
+	c.guestDefaultsRestartLock.Lock()
+	defer c.guestDefaultsRestartLock.Unlock()
+
+	now := time.Now()
+	nextBatch := c.guestDefaultsRestartBatch.Add(guestDefaultsRestartBatchInterval)
+	if !now.Before(nextBatch) {
+		c.guestDefaultsRestartBatch = now
+		c.guestDefaultsRestartBatchCount = 0
+	} else if c.guestDefaultsRestartBatchCount >= guestDefaultsRestartBatchSize {
+		return nextBatch.Sub(now)
+	}
+	c.guestDefaultsRestartBatchCount++
+	return 0
+}
+
+// handleDeprecatedMachineType updates the machine type of VMs which use a deprecated machine type
+// to the default machine type. A running VMI keeps its machine type until the VM is restarted.
+// It returns the updated VM, or the given one if nothing was updated.
+func (c *VMController) handleDeprecatedMachineType(vm *virtv1.VirtualMachine) (*virtv1.VirtualMachine, error) {
+	template := vm.Spec.Template
+	if template == nil || template.Spec.Domain.Machine == nil || !c.clusterConfig.IsMachineTypeDeprecated(template.Spec.Domain.Machine.Type) {
+		return vm, nil
+	}
+	machineType := c.clusterConfig.GetMachineType()
+	if machineType == "" || c.clusterConfig.IsMachineTypeDeprecated(machineType) {
+		log.Log.Object(vm).V(3).Infof("Not updating the deprecated machine type %s, the default machine type %s is deprecated too", template.Spec.Domain.Machine.Type, machineType)
+		return vm, nil
+	}
+
+	vmCopy := vm.DeepCopy()
+	vmCopy.Spec.Template.Spec.Domain.Machine.Type = machineType
+	updatedVM, err := c.clientset.VirtualMachine(vmCopy.Namespace).Update(vmCopy)
+	if err != nil {
+		return vm, err
+	}
+	c.recorder.Eventf(vm, k8score.EventTypeNormal, MachineTypeUpdateReason, "Updated the deprecated machine type %s to %s, it applies on the next restart", template.Spec.Domain.Machine.Type, machineType)
+	return updatedVM, nil
+}
+
+func (c *VMController) syncRestartRequiredCondition(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
+	vmCondManager := controller.NewVirtualMachineConditionManager()
+	machineType, restartRequired := "", false
+	if vmi != nil && !vmi.IsFinal() && vmi.DeletionTimestamp == nil && vm.Spec.Template != nil &&
+		vm.Spec.Template.Spec.Domain.Machine != nil && vmi.Spec.Domain.Machine != nil {
+		machineType = vm.Spec.Template.Spec.Domain.Machine.Type
+		restartRequired = machineType != vmi.Spec.Domain.Machine.Type
+	}
+	if !restartRequired {
+		if vmCondManager.HasCondition(vm, virtv1.VirtualMachineRestartRequired) {
+			log.Log.Object(vm).V(3).Info("Removing restart required condition")
+			vmCondManager.RemoveCondition(vm, virtv1.VirtualMachineRestartRequired)
+		}
+		return
+	}
+
+	message := fmt.Sprintf("The VMI runs with machine type %s, restart the VM to apply machine type %s", vmi.Spec.Domain.Machine.Type, machineType)
+	for _, cond := range vm.Status.Conditions {
+		if cond.Type == virtv1.VirtualMachineRestartRequired && cond.Message == message {
+			return
+		}
+	}
+
+	log.Log.Object(vm).V(3).Info("Adding restart required condition")
+	vmCondManager.RemoveCondition(vm, virtv1.VirtualMachineRestartRequired)
+	now := v1.NewTime(time.Now())
+	vm.Status.Conditions = append(vm.Status.Conditions, virtv1.VirtualMachineCondition{
+		Type:               virtv1.VirtualMachineRestartRequired,
+		Status:             k8score.ConditionTrue,
+		LastProbeTime:      now,
+		LastTransitionTime: now,
+		Reason:             machineTypeChangedReason,
+		Message:            message,
+	})
+}
+
+func (c *VMController) syncCrashLoopBackOffCondition(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
+	vmCondManager := controller.NewVirtualMachineConditionManager()
+	if !c.isVirtualMachineStatusCrashLoopBackOff(vm, vmi) {
+		if vmCondManager.HasCondition(vm, virtv1.VirtualMachineCrashLoopBackOff) {
+			log.Log.Object(vm).V(3).Info("Removing crash loop backoff condition")
+			vmCondManager.RemoveCondition(vm, virtv1.VirtualMachineCrashLoopBackOff)
+		}
+		return
+	}
+
+	message := fmt.Sprintf("The VMI failed %d times in a row", vm.Status.StartFailure.ConsecutiveFailCount)
+	if retryAfter := vm.Status.StartFailure.RetryAfterTimestamp; retryAfter != nil {
+		message = fmt.Sprintf("%s, the next start is delayed until %s", message, retryAfter.UTC().Format(time.RFC3339))
+	}
+	for _, cond := range vm.Status.Conditions {
+		if cond.Type == virtv1.VirtualMachineCrashLoopBackOff && cond.Message == message {
+			return
+		}
+	}
+
+	log.Log.Object(vm).V(3).Info("Adding crash loop backoff condition")
+	vmCondManager.RemoveCondition(vm, virtv1.VirtualMachineCrashLoopBackOff)
+	now := v1.NewTime(time.Now())
+	vm.Status.Conditions = append(vm.Status.Conditions, virtv1.VirtualMachineCondition{
+		Type:               virtv1.VirtualMachineCrashLoopBackOff,
+		Status:             k8score.ConditionTrue,
+		LastProbeTime:      now,
+		LastTransitionTime: now,
+		Reason:             crashLoopBackOffReason,
+		Message:            message,
+	})
+}
+
+// scheduledAction is a start or stop of a VirtualMachineSchedule which is due
+type scheduledAction struct {
+	action virtv1.StateChangeRequestAction
+	time   time.Time
+}
+
+// evaluateSchedule returns the latest start or stop which is due since the last applied one,
+// together with the next scheduled start and stop
+func evaluateSchedule(vm *virtv1.VirtualMachine, now time.Time) (due *scheduledAction, nextStart, nextStop time.Time, err error) {
+	location := time.UTC
+	if vm.Spec.Schedule.TimeZone != "" {
+		if location, err = time.LoadLocation(vm.Spec.Schedule.TimeZone); err != nil {
+			return nil, nextStart, nextStop, err
+		}
+	}
+	now = now.In(location)
+
+	// schedules which were not observed yet don't apply past starts and stops
+	since := now
+	if vm.Status.Schedule != nil && vm.Status.Schedule.LastScheduleTime != nil {
+		since = vm.Status.Schedule.LastScheduleTime.Time.In(location)
+	}
+	if oldest := now.Add(-missedScheduleWindow); since.Before(oldest) {
+		since = oldest
+	}
+
+	for _, expr := range []struct {
+		spec   string
+		action virtv1.StateChangeRequestAction
+		next   *time.Time
+	}{
+		{vm.Spec.Schedule.Start, virtv1.StartRequest, &nextStart},
+		{vm.Spec.Schedule.Stop, virtv1.StopRequest, &nextStop},
+	} {
+		if expr.spec == "" {
+			continue
+		}
+		schedule, err := cron.Parse(expr.spec)
+		if err != nil {
+			return nil, nextStart, nextStop, err
+		}
+		if last := schedule.Last(since, now); !last.IsZero() && (due == nil || last.After(due.time)) {
+			due = &scheduledAction{action: expr.action, time: last}
+		}
+		*expr.next = schedule.Next(now)
+	}
+	return due, nextStart, nextStop, nil
+}
+
+// isScheduledActionApplied reports if the run strategy of the VM already reflects a scheduled start or stop
+func isScheduledActionApplied(runStrategy virtv1.VirtualMachineRunStrategy, action virtv1.StateChangeRequestAction) bool {
+	if action == virtv1.StartRequest {
+		return runStrategy != virtv1.RunStrategyHalted
+	}
+	return runStrategy == virtv1.RunStrategyHalted
+}
+
+// handleSchedule starts and stops VMs, which are not run manually, at the times of their schedule
+// by changing their run strategy, like the start and stop subresources do
+func (c *VMController) handleSchedule(vm *virtv1.VirtualMachine, vmKey string) (bool, error) {
+	now := time.Now()
+	due, nextStart, nextStop, err := evaluateSchedule(vm, now)
+	if err != nil {
+		// the schedule is validated on admission, retrying does not help
+		log.Log.Object(vm).Reason(err).Error("Invalid VirtualMachine schedule.")
+		return false, nil
+	}
+
+	for _, next := range []time.Time{nextStart, nextStop} {
+		if !next.IsZero() {
+			c.Queue.AddAfter(vmKey, next.Sub(now))
+		}
+	}
+
+	runStrategy, err := vm.RunStrategy()
+	if err != nil {
+		return false, err
+	}
+	// VMs which are run manually are started and stopped through state change requests in syncScheduleStatus
+	if due == nil || runStrategy == virtv1.RunStrategyManual || isScheduledActionApplied(runStrategy, due.action) {
+		return false, nil
+	}
+
+	running := due.action == virtv1.StartRequest
+	vmCopy := vm.DeepCopy()
+	if vmCopy.Spec.RunStrategy != nil {
+		newRunStrategy := virtv1.RunStrategyHalted
+		if running {
+			newRunStrategy = virtv1.RunStrategyAlways
+		}
+		vmCopy.Spec.RunStrategy = &newRunStrategy
+	} else {
+		vmCopy.Spec.Running = &running
+	}
+	if _, err := c.clientset.VirtualMachine(vmCopy.Namespace).Update(vmCopy); err != nil {
+		return false, err
+	}
+
+	if running {
+		c.recorder.Eventf(vm, k8score.EventTypeNormal, ScheduledStartReason, "Started the VM as scheduled at %s", due.time.Format(time.RFC3339))
+	} else {
+		c.recorder.Eventf(vm, k8score.EventTypeNormal, ScheduledStopReason, "Stopped the VM as scheduled at %s", due.time.Format(time.RFC3339))
+	}
+	return true, nil
+}
+
+// syncScheduleStatus records the applied starts and stops of the schedule of the VM. VMs
+// which are run manually are started and stopped here by state change requests.
+func (c *VMController) syncScheduleStatus(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
+	if vm.Spec.Schedule == nil {
+		vm.Status.Schedule = nil
+		return
+	}
+
+	now := time.Now()
+	due, nextStart, nextStop, err := evaluateSchedule(vm, now)
+	if err != nil {
+		return
+	}
+
+	if vm.Status.Schedule == nil {
+		vm.Status.Schedule = &virtv1.VirtualMachineScheduleStatus{}
+		setScheduleTime(&vm.Status.Schedule.LastScheduleTime, now.Truncate(time.Minute))
+	}
+	setScheduleTime(&vm.Status.Schedule.NextStartTime, nextStart)
+	setScheduleTime(&vm.Status.Schedule.NextStopTime, nextStop)
+
+	if due == nil {
+		return
+	}
+
+	runStrategy, err := vm.RunStrategy()
+	if err != nil {
+		return
+	}
+	if runStrategy == virtv1.RunStrategyManual {
+		if len(vm.Status.StateChangeRequests) != 0 {
+			// wait until the pending requests are processed
+			return
+		}
+		vmiActive := vmi != nil && !vmi.IsFinal()
+		switch {
+		case due.action == virtv1.StartRequest && vmi == nil:
+			vm.Status.StateChangeRequests = append(vm.Status.StateChangeRequests,
+				virtv1.VirtualMachineStateChangeRequest{Action: virtv1.StartRequest})
+		case due.action == virtv1.StartRequest && !vmiActive:
+			vm.Status.StateChangeRequests = append(vm.Status.StateChangeRequests,
+				virtv1.VirtualMachineStateChangeRequest{Action: virtv1.StopRequest, UID: &vmi.UID},
+				virtv1.VirtualMachineStateChangeRequest{Action: virtv1.StartRequest})
+		case due.action == virtv1.StopRequest && vmiActive:
+			vm.Status.StateChangeRequests = append(vm.Status.StateChangeRequests,
+				virtv1.VirtualMachineStateChangeRequest{Action: virtv1.StopRequest, UID: &vmi.UID})
+		}
+	} else if !isScheduledActionApplied(runStrategy, due.action) {
+		// handleSchedule did not change the run strategy yet
+		return
+	}
+
+	setScheduleTime(&vm.Status.Schedule.LastScheduleTime, due.time)
+}
+
+// setScheduleTime sets a time of the schedule status, keeping the time if it did not change to avoid needless updates
+func setScheduleTime(target **v1.Time, t time.Time) {
+	if t.IsZero() {
+		*target = nil
+	} else if *target == nil || !(*target).Time.Equal(t) {
+		scheduleTime := v1.NewTime(t)
+		*target = &scheduleTime
+	}
+}
+
+// isStartRequested determines whether the VM asks for a new VMI, while it has no active one
+func isStartRequested(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance, runStrategy virtv1.VirtualMachineRunStrategy) bool {
+	if len(vm.Status.StateChangeRequests) != 0 && vm.Status.StateChangeRequests[0].Action == virtv1.StartRequest {
+		return true
+	}
+	switch runStrategy {
+	case virtv1.RunStrategyAlways:
+		return true
+	case virtv1.RunStrategyRerunOnFailure:
+		return vmi == nil || vmi.Status.Phase == virtv1.Failed
+	}
+	return false
+}
+
+// syncAvailabilityStatus records when the latest start of the VM was requested, when its guest
+// became ready and for how long the VM was down after its VMIs failed. The VM SLO metrics are
+// derived from it, so that they survive restarts of virt-controller.
+func (c *VMController) syncAvailabilityStatus(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
+	runStrategy, err := vm.RunStrategy()
+	if err != nil {
+		return
+	}
+
+	availability := &virtv1.VirtualMachineAvailabilityStatus{}
+	if vm.Status.Availability != nil {
+		availability = vm.Status.Availability.DeepCopy()
+	}
+	now := v1.Now()
+	endOutage := func(end v1.Time) {
+		// compensate time skew between the failure and its end
+		if downtime := int64(end.Sub(availability.FailureTime.Time).Seconds()); downtime > 0 {
+			availability.UnplannedDowntimeSeconds += downtime
+		}
+		availability.FailureTime = nil
+	}
+
+	if vmi == nil || vmi.IsFinal() {
+		startPending := availability.StartRequestTime != nil && availability.ReadyTime == nil
+		if isStartRequested(vm, vmi, runStrategy) {
+			if !startPending {
+				availability.StartRequestTime = &now
+				availability.ReadyTime = nil
+			}
+		} else if startPending {
+			// the start was withdrawn before the guest became ready
+			availability.StartRequestTime = nil
+		}
+	}
+
+	if vmi != nil {
+		// a VMI which fails while it is deleted was stopped on purpose
+		if vmi.Status.Phase == virtv1.Failed && !vmi.IsMarkedForDeletion() && availability.FailureTime == nil {
+			failureTime := now
+			for _, transition := range vmi.Status.PhaseTransitionTimestamps {
+				if transition.Phase == virtv1.Failed {
+					failureTime = transition.PhaseTransitionTimestamp
+				}
+			}
+			availability.FailureTime = &failureTime
+		}
+
+		ready := controller.NewVirtualMachineInstanceConditionManager().GetCondition(vmi, virtv1.VirtualMachineInstanceReady)
+		if vmi.IsRunning() && ready != nil && ready.Status == k8score.ConditionTrue {
+			readyTime := ready.LastTransitionTime
+			if readyTime.IsZero() {
+				readyTime = now
+			}
+			if availability.StartRequestTime != nil && availability.ReadyTime == nil {
+				availability.ReadyTime = &readyTime
+			}
+			if availability.FailureTime != nil {
+				endOutage(readyTime)
+			}
+		}
+	}
+
+	// a stopped VM is not down unplanned anymore
+	if runStrategy == virtv1.RunStrategyHalted && availability.FailureTime != nil {
+		endOutage(now)
+	}
+
+	if *availability == (virtv1.VirtualMachineAvailabilityStatus{}) {
+		vm.Status.Availability = nil
+	} else {
+		vm.Status.Availability = availability
+	}
+}
+
 // resolveControllerRef returns the controller referenced by a ControllerRef,
 // or nil if the ControllerRef could not be resolved to a matching controller
 // of the correct Kind.
@@ -1863,3 +3326,140 @@ func (c *VMController) resolveControllerRef(namespace string, controllerRef *v1.
 	}
 	return vm.(*virtv1.VirtualMachine)
 }
+
+// interfacesWithoutMacAddress returns the indexes of the template interfaces of the VM which have no MAC address
+func interfacesWithoutMacAddress(vm *virtv1.VirtualMachine) []int {
+	var indexes []int
+	for i, iface := range vm.Spec.Template.Spec.Domain.Devices.Interfaces {
+		if iface.MacAddress == "" {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// isMacAddressUsed checks whether a VM or VMI other than the one of vmKey uses the MAC address,
+// or whether it is allocated to another VM whose update did not reach the informer cache yet.
+// The caller has to hold macAddressesLock.
+func (c *VMController) isMacAddressUsed(mac string, vmKey string) bool {
+	for _, informer := range []cache.SharedIndexInformer{c.vmInformer, c.vmiInformer} {
+		keys, err := informer.GetIndexer().IndexKeys(macpool.MacAddressIndex, mac)
+		if err != nil {
+			// without the index the address can't be proven to be free
+			return true
+		}
+		for _, key := range keys {
+			if key != vmKey {
+				return true
+			}
+		}
+	}
+
+	for key, macs := range c.pendingMacAddresses {
+		if key == vmKey {
+			continue
+		}
+		for _, pending := range macs {
+			if pending == mac {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// prunePendingMacAddresses drops the pending allocations which reached the informer cache.
+// The caller has to hold macAddressesLock.
+func (c *VMController) prunePendingMacAddresses() {
+	for key := range c.pendingMacAddresses {
+		obj, exists, err := c.vmInformer.GetStore().GetByKey(key)
+		if err == nil && (!exists || len(interfacesWithoutMacAddress(obj.(*virtv1.VirtualMachine))) == 0) {
+			delete(c.pendingMacAddresses, key)
+		}
+	}
+}
+
+// handleMacAddresses assigns MAC addresses to the template interfaces of the VM which have no MAC
+// address. Storing them in the VM keeps them stable across restarts. Interfaces of a running VMI
+// keep the address they run with, the others get addresses of the pool on the next start.
+func (c *VMController) handleMacAddresses(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance, vmKey string) (bool, error) {
+	indexes := interfacesWithoutMacAddress(vm)
+	if len(indexes) == 0 {
+		c.macAddressesLock.Lock()
+		delete(c.pendingMacAddresses, vmKey)
+		c.macAddressesLock.Unlock()
+		return false, nil
+	}
+
+	running := vmi != nil && !vmi.IsFinal() && vmi.DeletionTimestamp == nil
+	if running && vmi.Status.Phase != virtv1.Running {
+		// wait for the addresses the VMI starts with, the VMI update triggers the next sync
+		return false, nil
+	}
+
+	poolConfig := c.clusterConfig.GetMacAddressPool()
+	pool, err := macpool.New(poolConfig.RangeStart, poolConfig.RangeEnd)
+	if err != nil {
+		// the pool is validated on admission, retrying does not help
+		log.Log.Object(vm).Reason(err).Error("Invalid MAC address pool.")
+		return false, nil
+	}
+
+	c.macAddressesLock.Lock()
+	defer c.macAddressesLock.Unlock()
+	c.prunePendingMacAddresses()
+
+	macs := c.pendingMacAddresses[vmKey]
+	if len(macs) != len(indexes) {
+		macs = make([]string, len(indexes))
+		// the index does not tell the other interfaces of the VM itself apart
+		taken := map[string]bool{}
+		for _, iface := range vm.Spec.Template.Spec.Domain.Devices.Interfaces {
+			if mac, err := macpool.Normalize(iface.MacAddress); err == nil {
+				taken[mac] = true
+			}
+		}
+		isUsed := func(mac string) bool {
+			return taken[mac] || c.isMacAddressUsed(mac, vmKey)
+		}
+
+		missing := 0
+		for i, index := range indexes {
+			if running {
+				name := vm.Spec.Template.Spec.Domain.Devices.Interfaces[index].Name
+				for _, iface := range vmi.Status.Interfaces {
+					if mac, err := macpool.Normalize(iface.MAC); err == nil && iface.Name == name && !isUsed(mac) {
+						macs[i] = mac
+						taken[mac] = true
+						break
+					}
+				}
+			}
+			if macs[i] == "" {
+				missing++
+			}
+		}
+
+		allocated, err := pool.Allocate(missing, isUsed)
+		if err != nil {
+			// the VM still starts, with the MAC addresses chosen on every start
+			c.recorder.Eventf(vm, k8score.EventTypeWarning, FailedAllocateMacAddressReason, "Failed to allocate MAC addresses: %v", err)
+			return false, nil
+		}
+		for i := range macs {
+			if macs[i] == "" {
+				macs[i], allocated = allocated[0], allocated[1:]
+			}
+		}
+	}
+
+	vmCopy := vm.DeepCopy()
+	for i, index := range indexes {
+		vmCopy.Spec.Template.Spec.Domain.Devices.Interfaces[index].MacAddress = macs[i]
+	}
+	if _, err := c.clientset.VirtualMachine(vmCopy.Namespace).Update(vmCopy); err != nil {
+		return false, err
+	}
+	c.pendingMacAddresses[vmKey] = macs
+	return true, nil
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/pborman/uuid"
 	appsv1 "k8s.io/api/apps/v1"
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -28,7 +30,10 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1beta1"
 	virtcontroller "kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/network/macpool"
 	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/util/guestdefaults"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 var (
@@ -42,6 +47,7 @@ var _ = Describe("VirtualMachine", func() {
 
 		var ctrl *gomock.Controller
 		var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+		var virtClient *kubecli.MockKubevirtClient
 		var vmInterface *kubecli.MockVirtualMachineInterface
 		var vmiSource *framework.FakeControllerSource
 		var vmSource *framework.FakeControllerSource
@@ -51,6 +57,7 @@ var _ = Describe("VirtualMachine", func() {
 		var dataVolumeSource *framework.FakeControllerSource
 		var pvcInformer cache.SharedIndexInformer
 		var crInformer cache.SharedIndexInformer
+		var nodeInformer cache.SharedIndexInformer
 		var stop chan struct{}
 		var controller *VMController
 		var recorder *record.FakeRecorder
@@ -58,6 +65,8 @@ var _ = Describe("VirtualMachine", func() {
 		var vmiFeeder *testutils.VirtualMachineFeeder
 		var dataVolumeFeeder *testutils.DataVolumeFeeder
 		var cdiClient *cdifake.Clientset
+		var clusterConfig *virtconfig.ClusterConfig
+		var kvInformer cache.SharedIndexInformer
 		var k8sClient *k8sfake.Clientset
 
 		syncCaches := func(stop chan struct{}) {
@@ -70,13 +79,19 @@ var _ = Describe("VirtualMachine", func() {
 		BeforeEach(func() {
 			stop = make(chan struct{})
 			ctrl = gomock.NewController(GinkgoT())
-			virtClient := kubecli.NewMockKubevirtClient(ctrl)
+			virtClient = kubecli.NewMockKubevirtClient(ctrl)
 			vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
 			vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
 
 			dataVolumeInformer, dataVolumeSource = testutils.NewFakeInformerFor(&cdiv1.DataVolume{})
-			vmiInformer, vmiSource = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
-			vmInformer, vmSource = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
+			vmiInformer, vmiSource = testutils.NewFakeInformerWithIndexersFor(&v1.VirtualMachineInstance{}, cache.Indexers{
+				cache.NamespaceIndex:    cache.MetaNamespaceIndexFunc,
+				macpool.MacAddressIndex: macpool.VirtualMachineInstanceMacAddresses,
+			})
+			vmInformer, vmSource = testutils.NewFakeInformerWithIndexersFor(&v1.VirtualMachine{}, cache.Indexers{
+				cache.NamespaceIndex:    cache.MetaNamespaceIndexFunc,
+				macpool.MacAddressIndex: macpool.VirtualMachineMacAddresses,
+			})
 			pvcInformer, _ = testutils.NewFakeInformerFor(&k8sv1.PersistentVolumeClaim{})
 			crInformer, _ = testutils.NewFakeInformerWithIndexersFor(&appsv1.ControllerRevision{}, cache.Indexers{
 				"vm": func(obj interface{}) ([]string, error) {
@@ -89,10 +104,13 @@ var _ = Describe("VirtualMachine", func() {
 					return nil, nil
 				},
 			})
+			nodeInformer, _ = testutils.NewFakeInformerFor(&k8sv1.Node{})
 			recorder = record.NewFakeRecorder(100)
 			recorder.IncludeObject = true
 
-			controller = NewVMController(vmiInformer, vmInformer, dataVolumeInformer, pvcInformer, crInformer, recorder, virtClient)
+			clusterConfig, _, _, kvInformer = testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{})
+
+			controller = NewVMController(vmiInformer, vmInformer, dataVolumeInformer, pvcInformer, crInformer, nodeInformer, recorder, virtClient, clusterConfig)
 			// Wrap our workqueue to have a way to detect when we are done processing updates
 			mockQueue = testutils.NewMockWorkQueue(controller.Queue)
 			controller.Queue = mockQueue
@@ -256,6 +274,7 @@ var _ = Describe("VirtualMachine", func() {
 			})
 
 			vm.Status.PrintableStatus = v1.VirtualMachineStatusProvisioning
+			vm.Status.Availability = pendingStartAvailability()
 			addVirtualMachine(vm)
 
 			existingDataVolume := createDataVolumeManifest(&vm.Spec.DataVolumeTemplates[1], vm)
@@ -435,6 +454,157 @@ var _ = Describe("VirtualMachine", func() {
 			table.Entry("that is not running", false),
 		)
 
+		It("should hotplug the memory dump volume when a memory dump is requested", func() {
+			vm, vmi := DefaultVirtualMachine(true)
+			vm.Status.Created = true
+			vm.Status.Ready = true
+			vm.Status.MemoryDumpRequest = &v1.VirtualMachineMemoryDumpRequest{
+				ClaimName: "dumppvc",
+				Phase:     v1.MemoryDumpAssociating,
+			}
+
+			addVirtualMachine(vm)
+			vmi.Status.Phase = v1.Running
+			markAsReady(vmi)
+			vmiFeeder.Add(vmi)
+
+			vmInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				volumes := arg.(*v1.VirtualMachine).Spec.Template.Spec.Volumes
+				Expect(volumes[len(volumes)-1].MemoryDump).To(Equal(&v1.MemoryDumpVolumeSource{ClaimName: "dumppvc"}))
+			}).Return(nil, nil)
+			vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, gomock.Any()).Do(func(name string, patchType types.PatchType, body []byte) {
+				Expect(string(body)).To(ContainSubstring(`"memoryDump":{"claimName":"dumppvc"}`))
+			}).Return(vmi, nil)
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachine).Status.MemoryDumpRequest.Phase).To(Equal(v1.MemoryDumpAssociating))
+			}).Return(nil, nil)
+
+			controller.Execute()
+		})
+
+		It("should not start a VMI with the memory dump volume", func() {
+			vm, _ := DefaultVirtualMachine(true)
+			vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, v1.Volume{
+				Name: "dumppvc",
+				VolumeSource: v1.VolumeSource{
+					MemoryDump: &v1.MemoryDumpVolumeSource{ClaimName: "dumppvc"},
+				},
+			})
+
+			vmi := controller.setupVMIFromVM(vm)
+			for _, volume := range vmi.Spec.Volumes {
+				Expect(volume.MemoryDump).To(BeNil())
+			}
+			Expect(vm.Spec.Template.Spec.Volumes[len(vm.Spec.Template.Spec.Volumes)-1].MemoryDump).ToNot(BeNil())
+		})
+
+		It("should move the memory dump request to Unmounting once the memory dump completed", func() {
+			vm, vmi := DefaultVirtualMachine(true)
+			vm.Status.Created = true
+			vm.Status.Ready = true
+			vm.Status.MemoryDumpRequest = &v1.VirtualMachineMemoryDumpRequest{
+				ClaimName: "dumppvc",
+				Phase:     v1.MemoryDumpInProgress,
+			}
+			memoryDumpVolume := v1.Volume{
+				Name: "dumppvc",
+				VolumeSource: v1.VolumeSource{
+					MemoryDump: &v1.MemoryDumpVolumeSource{ClaimName: "dumppvc"},
+				},
+			}
+			vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, memoryDumpVolume)
+			now := metav1.Now()
+
+			addVirtualMachine(vm)
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, memoryDumpVolume)
+			vmi.Status.Phase = v1.Running
+			vmi.Status.VolumeStatus = []v1.VolumeStatus{
+				{
+					Name:  "dumppvc",
+					Phase: v1.MemoryDumpVolumeCompleted,
+					MemoryDumpVolume: &v1.DomainMemoryDumpInfo{
+						ClaimName:      "dumppvc",
+						StartTimestamp: &now,
+						EndTimestamp:   &now,
+						TargetFileName: "testvmi-dumppvc.memory.dump",
+					},
+				},
+			}
+			markAsReady(vmi)
+			vmiFeeder.Add(vmi)
+
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(arg interface{}) {
+				request := arg.(*v1.VirtualMachine).Status.MemoryDumpRequest
+				Expect(request.Phase).To(Equal(v1.MemoryDumpUnmounting))
+				Expect(request.EndTimestamp).ToNot(BeNil())
+				Expect(*request.FileName).To(Equal("testvmi-dumppvc.memory.dump"))
+			}).Return(nil, nil)
+
+			controller.Execute()
+		})
+
+		It("should fail the memory dump request if the VMI is not running", func() {
+			vm, _ := DefaultVirtualMachine(false)
+			vm.Status.MemoryDumpRequest = &v1.VirtualMachineMemoryDumpRequest{
+				ClaimName: "dumppvc",
+				Phase:     v1.MemoryDumpAssociating,
+			}
+
+			addVirtualMachine(vm)
+
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachine).Status.MemoryDumpRequest.Phase).To(Equal(v1.MemoryDumpFailed))
+			}).Return(nil, nil)
+
+			controller.Execute()
+		})
+
+		It("should dissociate the memory dump volume from the VM when its removal is requested", func() {
+			vm, _ := DefaultVirtualMachine(false)
+			vm.Status.MemoryDumpRequest = &v1.VirtualMachineMemoryDumpRequest{
+				ClaimName: "dumppvc",
+				Phase:     v1.MemoryDumpDissociating,
+				Remove:    true,
+			}
+			vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, v1.Volume{
+				Name: "dumppvc",
+				VolumeSource: v1.VolumeSource{
+					MemoryDump: &v1.MemoryDumpVolumeSource{ClaimName: "dumppvc"},
+				},
+			})
+
+			addVirtualMachine(vm)
+
+			vmInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				for _, volume := range arg.(*v1.VirtualMachine).Spec.Template.Spec.Volumes {
+					Expect(volume.MemoryDump).To(BeNil())
+				}
+			}).Return(nil, nil)
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(arg interface{}) {
+				// the request is only cleared once the removal of the volume is observed
+				Expect(arg.(*v1.VirtualMachine).Status.MemoryDumpRequest).ToNot(BeNil())
+			}).Return(nil, nil)
+
+			controller.Execute()
+		})
+
+		It("should clear the memory dump request once the volume is dissociated", func() {
+			vm, _ := DefaultVirtualMachine(false)
+			vm.Status.MemoryDumpRequest = &v1.VirtualMachineMemoryDumpRequest{
+				ClaimName: "dumppvc",
+				Phase:     v1.MemoryDumpDissociating,
+				Remove:    true,
+			}
+
+			addVirtualMachine(vm)
+
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachine).Status.MemoryDumpRequest).To(BeNil())
+			}).Return(nil, nil)
+
+			controller.Execute()
+		})
+
 		It("should not delete failed DataVolume for VirtualMachineInstance", func() {
 			vm, _ := DefaultVirtualMachine(true)
 			vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, v1.Volume{
@@ -724,6 +894,7 @@ var _ = Describe("VirtualMachine", func() {
 			})
 
 			vm.Status.PrintableStatus = v1.VirtualMachineStatusProvisioning
+			vm.Status.Availability = pendingStartAvailability()
 			addVirtualMachine(vm)
 
 			createCount := 0
@@ -755,6 +926,7 @@ var _ = Describe("VirtualMachine", func() {
 			})
 			vm.Spec.Template.Spec.PriorityClassName = vmPriorityClass
 			vm.Status.PrintableStatus = v1.VirtualMachineStatusProvisioning
+			vm.Status.Availability = pendingStartAvailability()
 			addVirtualMachine(vm)
 
 			createCount := 0
@@ -910,10 +1082,154 @@ var _ = Describe("VirtualMachine", func() {
 				table.Entry("rerunOnFailure", v1.RunStrategyRerunOnFailure),
 			)
 
+			Context("with runStrategy RerunOnFailure", func() {
+				newFailedVM := func(ranFor time.Duration) (*v1.VirtualMachine, *v1.VirtualMachineInstance) {
+					vm, vmi := DefaultVirtualMachine(true)
+					runStrategy := v1.RunStrategyRerunOnFailure
+					vm.Spec.Running = nil
+					vm.Spec.RunStrategy = &runStrategy
+					vm.Status.RestartCount = 1
+					vm.Status.StartFailure = &v1.VirtualMachineStartFailure{
+						LastFailedVMIUID:     "123",
+						ConsecutiveFailCount: 1,
+						RetryAfterTimestamp: &metav1.Time{
+							Time: time.Now().Add(-300 * time.Second),
+						},
+					}
+					vmi.UID = "456"
+					vmi.Status.Phase = v1.Failed
+					failedAt := metav1.Now()
+					vmi.Status.PhaseTransitionTimestamps = []v1.VirtualMachineInstancePhaseTransitionTimestamp{
+						{
+							Phase:                    v1.Running,
+							PhaseTransitionTimestamp: metav1.NewTime(failedAt.Add(-ranFor)),
+						},
+						{
+							Phase:                    v1.Failed,
+							PhaseTransitionTimestamp: failedAt,
+						},
+					}
+					return vm, vmi
+				}
+
+				expectFailureTracked := func(vmi *v1.VirtualMachineInstance, failCount int) {
+					vmiInterface.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+					vmInterface.EXPECT().UpdateStatus(gomock.Any()).Times(1).Do(func(arg interface{}) {
+						vm := arg.(*v1.VirtualMachine)
+						Expect(vm.Status.StartFailure).ToNot(BeNil())
+						Expect(vm.Status.StartFailure.LastFailedVMIUID).To(Equal(vmi.UID))
+						Expect(vm.Status.StartFailure.ConsecutiveFailCount).To(Equal(failCount))
+						Expect(vm.Status.RestartCount).To(Equal(2))
+						cond := virtcontroller.NewVirtualMachineConditionManager().GetCondition(vm, v1.VirtualMachineCrashLoopBackOff)
+						Expect(cond).ToNot(BeNil())
+						Expect(cond.Status).To(Equal(k8sv1.ConditionTrue))
+						Expect(cond.Message).To(ContainSubstring(fmt.Sprintf("failed %d times in a row", failCount)))
+					}).Return(nil, nil)
+					shouldExpectVMIFinalizerRemoval(vmi)
+				}
+
+				It("should track failures of VMIs which failed shortly after running", func() {
+					vm, vmi := newFailedVM(10 * time.Second)
+
+					addVirtualMachine(vm)
+					vmiFeeder.Add(vmi)
+					expectFailureTracked(vmi, 2)
+
+					controller.Execute()
+
+					testutils.ExpectEvent(recorder, SuccessfulDeleteVirtualMachineReason)
+				})
+
+				It("should start a new crash loop when the VMI failed after the reset period", func() {
+					vm, vmi := newFailedVM(time.Duration(virtconfig.DefaultCrashLoopBackOffResetAfterSeconds+10) * time.Second)
+
+					addVirtualMachine(vm)
+					vmiFeeder.Add(vmi)
+					expectFailureTracked(vmi, 1)
+
+					controller.Execute()
+
+					testutils.ExpectEvent(recorder, SuccessfulDeleteVirtualMachineReason)
+				})
+
+				It("should clear start failures when the VMI runs longer than the reset period", func() {
+					vm, vmi := newFailedVM(0)
+					vm.Status.Conditions = []v1.VirtualMachineCondition{
+						{Type: v1.VirtualMachineCrashLoopBackOff, Status: k8sv1.ConditionTrue},
+					}
+					vmi.Status.Phase = v1.Running
+					vmi.Status.PhaseTransitionTimestamps = []v1.VirtualMachineInstancePhaseTransitionTimestamp{
+						{
+							Phase:                    v1.Running,
+							PhaseTransitionTimestamp: metav1.NewTime(time.Now().Add(-time.Duration(virtconfig.DefaultCrashLoopBackOffResetAfterSeconds+10) * time.Second)),
+						},
+					}
+
+					addVirtualMachine(vm)
+					vmiFeeder.Add(vmi)
+
+					vmInterface.EXPECT().UpdateStatus(gomock.Any()).Times(1).Do(func(arg interface{}) {
+						vm := arg.(*v1.VirtualMachine)
+						Expect(vm.Status.StartFailure).To(BeNil())
+						Expect(vm.Status.RestartCount).To(Equal(1))
+						Expect(virtcontroller.NewVirtualMachineConditionManager().HasCondition(vm, v1.VirtualMachineCrashLoopBackOff)).To(BeFalse())
+					}).Return(nil, nil)
+
+					controller.Execute()
+				})
+
+				It("should keep start failures while the VMI runs shorter than the reset period", func() {
+					vm, vmi := newFailedVM(0)
+					vmi.Status.Phase = v1.Running
+					vmi.Status.PhaseTransitionTimestamps = []v1.VirtualMachineInstancePhaseTransitionTimestamp{
+						{
+							Phase:                    v1.Running,
+							PhaseTransitionTimestamp: metav1.Now(),
+						},
+					}
+
+					addVirtualMachine(vm)
+					vmiFeeder.Add(vmi)
+
+					vmInterface.EXPECT().UpdateStatus(gomock.Any()).Times(1).Do(func(arg interface{}) {
+						Expect(arg.(*v1.VirtualMachine).Status.StartFailure).ToNot(BeNil())
+					}).Return(nil, nil)
+
+					controller.Execute()
+				})
+
+				It("should cap the backoff delay with the configured maximum", func() {
+					maxDelaySeconds := int64(20)
+					testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, &v1.KubeVirt{
+						Spec: v1.KubeVirtSpec{Configuration: v1.KubeVirtConfiguration{
+							CrashLoopBackOff: &v1.CrashLoopBackOffConfiguration{MaxDelaySeconds: &maxDelaySeconds},
+						}},
+						Status: v1.KubeVirtStatus{Phase: v1.KubeVirtPhaseDeployed},
+					})
+					vm, vmi := newFailedVM(10 * time.Second)
+					vm.Status.StartFailure.ConsecutiveFailCount = 5
+
+					addVirtualMachine(vm)
+					vmiFeeder.Add(vmi)
+
+					vmiInterface.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+					vmInterface.EXPECT().UpdateStatus(gomock.Any()).Times(1).Do(func(arg interface{}) {
+						startFailure := arg.(*v1.VirtualMachine).Status.StartFailure
+						Expect(startFailure.ConsecutiveFailCount).To(Equal(6))
+						Expect(startFailure.RetryAfterTimestamp.Time).To(BeTemporally("<=", time.Now().Add(time.Duration(maxDelaySeconds)*time.Second)))
+					}).Return(nil, nil)
+					shouldExpectVMIFinalizerRemoval(vmi)
+
+					controller.Execute()
+
+					testutils.ExpectEvent(recorder, SuccessfulDeleteVirtualMachineReason)
+				})
+			})
+
 			table.DescribeTable("should calculated expected backoff delay", func(failCount, minExpectedDelay int, maxExpectedDelay int) {
 
 				for i := 0; i < 1000; i++ {
-					delay := calculateStartBackoffTime(failCount, defaultMaxCrashLoopBackoffDelaySeconds)
+					delay := calculateStartBackoffTime(failCount, virtconfig.DefaultCrashLoopBackOffMaxDelaySeconds)
 
 					if delay > maxExpectedDelay {
 						Expect(fmt.Errorf("delay: %d: failCount %d should not result in a delay greater than %d", delay, failCount, maxExpectedDelay)).To(BeNil())
@@ -1065,6 +1381,7 @@ var _ = Describe("VirtualMachine", func() {
 				vm.Spec.DataVolumeTemplates = append(vm.Spec.DataVolumeTemplates, *dv)
 
 				vm.Status.PrintableStatus = v1.VirtualMachineStatusProvisioning
+				vm.Status.Availability = pendingStartAvailability()
 				addVirtualMachine(vm)
 
 				createCount := 0
@@ -1200,197 +1517,1094 @@ var _ = Describe("VirtualMachine", func() {
 			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
 		})
 
-		It("should ignore the name of a VirtualMachineInstance templates", func() {
-			vm, vmi := DefaultVirtualMachineWithNames(true, "vmname", "vminame")
+		Context("with a schedule", func() {
+			newScheduledVM := func(running bool, schedule *v1.VirtualMachineSchedule) (*v1.VirtualMachine, *v1.VirtualMachineInstance) {
+				vm, vmi := DefaultVirtualMachine(running)
+				vm.Spec.Schedule = schedule
+				// a stop or start of every minute is due since the last applied one
+				lastScheduleTime := metav1.NewTime(time.Now().Add(-5 * time.Minute).Truncate(time.Minute))
+				vm.Status.Schedule = &v1.VirtualMachineScheduleStatus{LastScheduleTime: &lastScheduleTime}
+				markAsReady(vmi)
+				vmi.Status.Phase = v1.Running
+				return vm, vmi
+			}
 
-			addVirtualMachine(vm)
+			It("should only record past starts and stops after observing a new schedule", func() {
+				vm, vmi := DefaultVirtualMachine(true)
+				vm.Spec.Schedule = &v1.VirtualMachineSchedule{Start: "0 8 * * *", Stop: "* * * * *"}
+				markAsReady(vmi)
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
 
-			// expect creation called
-			vmiInterface.EXPECT().Create(gomock.Any()).Do(func(arg interface{}) {
-				Expect(arg.(*v1.VirtualMachineInstance).ObjectMeta.Name).To(Equal("vmname"))
-				Expect(arg.(*v1.VirtualMachineInstance).ObjectMeta.GenerateName).To(Equal(""))
-			}).Return(vmi, nil)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(arg interface{}) {
+					schedule := arg.(*v1.VirtualMachine).Status.Schedule
+					Expect(schedule).ToNot(BeNil())
+					Expect(schedule.LastScheduleTime).ToNot(BeNil())
+					Expect(schedule.NextStartTime.Time.UTC().Hour()).To(Equal(8))
+					Expect(schedule.NextStopTime.Time).To(BeTemporally("~", time.Now(), time.Minute))
+					Expect(arg.(*v1.VirtualMachine).Status.StateChangeRequests).To(BeEmpty())
+				}).Return(nil, nil)
 
-			// expect update status is called
-			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(arg interface{}) {
-				Expect(arg.(*v1.VirtualMachine).Status.Created).To(BeFalse())
-				Expect(arg.(*v1.VirtualMachine).Status.Ready).To(BeFalse())
-			}).Return(nil, nil)
+				controller.Execute()
+			})
 
-			controller.Execute()
+			It("should stop a running VM when a stop is due", func() {
+				vm, vmi := newScheduledVM(true, &v1.VirtualMachineSchedule{Stop: "* * * * *"})
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
 
-			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
-		})
+				vmInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+					Expect(*arg.(*v1.VirtualMachine).Spec.Running).To(BeFalse())
+				}).Return(nil, nil)
 
-		It("should update status to created if the vmi exists", func() {
-			vm, vmi := DefaultVirtualMachine(true)
-			vmi.Status.Phase = v1.Scheduled
+				controller.Execute()
 
-			addVirtualMachine(vm)
-			vmiFeeder.Add(vmi)
+				testutils.ExpectEvent(recorder, ScheduledStopReason)
+			})
 
-			// expect update status is called
-			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(arg interface{}) {
-				Expect(arg.(*v1.VirtualMachine).Status.Created).To(BeTrue())
-				Expect(arg.(*v1.VirtualMachine).Status.Ready).To(BeFalse())
-			}).Return(nil, nil)
+			It("should start a halted VM when a start is due", func() {
+				vm, _ := newScheduledVM(false, &v1.VirtualMachineSchedule{Start: "* * * * *", TimeZone: "America/New_York"})
+				runStrategy := v1.RunStrategyHalted
+				vm.Spec.Running = nil
+				vm.Spec.RunStrategy = &runStrategy
+				addVirtualMachine(vm)
 
-			controller.Execute()
-		})
+				vmInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+					Expect(*arg.(*v1.VirtualMachine).Spec.RunStrategy).To(Equal(v1.RunStrategyAlways))
+				}).Return(nil, nil)
 
-		It("should update status to created and ready when vmi is running and running", func() {
-			vm, vmi := DefaultVirtualMachine(true)
-			markAsReady(vmi)
+				controller.Execute()
 
-			addVirtualMachine(vm)
-			vmiFeeder.Add(vmi)
+				testutils.ExpectEvent(recorder, ScheduledStartReason)
+			})
 
-			// expect update status is called
-			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(arg interface{}) {
-				Expect(arg.(*v1.VirtualMachine).Status.Created).To(BeTrue())
-				Expect(arg.(*v1.VirtualMachine).Status.Ready).To(BeTrue())
-			}).Return(nil, nil)
+			It("should record a due stop once the VM is stopped", func() {
+				vm, _ := newScheduledVM(false, &v1.VirtualMachineSchedule{Stop: "* * * * *"})
+				lastScheduleTime := vm.Status.Schedule.LastScheduleTime.Time
+				addVirtualMachine(vm)
 
-			controller.Execute()
-		})
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(arg interface{}) {
+					Expect(arg.(*v1.VirtualMachine).Status.Schedule.LastScheduleTime.Time).To(BeTemporally(">", lastScheduleTime))
+				}).Return(nil, nil)
 
-		It("should have stable firmware UUIDs", func() {
-			vm1, _ := DefaultVirtualMachineWithNames(true, "testvm1", "testvmi1")
-			vmi1 := controller.setupVMIFromVM(vm1)
+				controller.Execute()
+			})
 
-			// intentionally use the same names
-			vm2, _ := DefaultVirtualMachineWithNames(true, "testvm1", "testvmi1")
-			vmi2 := controller.setupVMIFromVM(vm2)
-			Expect(vmi1.Spec.Domain.Firmware.UUID).To(Equal(vmi2.Spec.Domain.Firmware.UUID))
+			It("should not start a VM which was stopped since the last scheduled start", func() {
+				vm, _ := newScheduledVM(false, &v1.VirtualMachineSchedule{Start: "0 0 1 1 *"})
+				addVirtualMachine(vm)
 
-			// now we want different names
-			vm3, _ := DefaultVirtualMachineWithNames(true, "testvm3", "testvmi3")
-			vmi3 := controller.setupVMIFromVM(vm3)
-			Expect(vmi1.Spec.Domain.Firmware.UUID).NotTo(Equal(vmi3.Spec.Domain.Firmware.UUID))
-		})
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(arg interface{}) {
+					Expect(arg.(*v1.VirtualMachine).Status.Schedule.NextStartTime.Time.UTC().YearDay()).To(Equal(1))
+				}).Return(nil, nil)
 
-		It("should honour any firmware UUID present in the template", func() {
-			uid := uuid.NewRandom().String()
-			vm1, _ := DefaultVirtualMachineWithNames(true, "testvm1", "testvmi1")
-			vm1.Spec.Template.Spec.Domain.Firmware = &virtv1.Firmware{UUID: types.UID(uid)}
+				controller.Execute()
+			})
 
-			vmi1 := controller.setupVMIFromVM(vm1)
-			Expect(string(vmi1.Spec.Domain.Firmware.UUID)).To(Equal(uid))
-		})
+			It("should request to stop a manually run VM when a stop is due", func() {
+				vm, vmi := newScheduledVM(true, &v1.VirtualMachineSchedule{Stop: "* * * * *"})
+				runStrategy := v1.RunStrategyManual
+				vm.Spec.Running = nil
+				vm.Spec.RunStrategy = &runStrategy
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
 
-		It("should delete VirtualMachineInstance when stopped", func() {
-			vm, vmi := DefaultVirtualMachine(false)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(arg interface{}) {
+					requests := arg.(*v1.VirtualMachine).Status.StateChangeRequests
+					Expect(requests).To(HaveLen(1))
+					Expect(requests[0].Action).To(Equal(v1.StopRequest))
+					Expect(*requests[0].UID).To(Equal(vmi.UID))
+				}).Return(nil, nil)
 
-			addVirtualMachine(vm)
-			vmiFeeder.Add(vmi)
+				controller.Execute()
+			})
 
-			// vmInterface.EXPECT().Update(gomock.Any()).Return(vm, nil)
+			It("should drop the schedule status when the schedule is removed", func() {
+				vm, _ := newScheduledVM(false, nil)
+				addVirtualMachine(vm)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(arg interface{}) {
+					Expect(arg.(*v1.VirtualMachine).Status.Schedule).To(BeNil())
+				}).Return(nil, nil)
+
+				controller.Execute()
+			})
+		})
+
+		Context("with a MAC address pool", func() {
+			newVMWithInterfaces := func(name string, macs ...string) *v1.VirtualMachine {
+				vm, _ := DefaultVirtualMachineWithNames(false, name, name)
+				for i, mac := range macs {
+					vm.Spec.Template.Spec.Domain.Devices.Interfaces = append(vm.Spec.Template.Spec.Domain.Devices.Interfaces,
+						v1.Interface{Name: fmt.Sprintf("net%d", i), MacAddress: mac})
+				}
+				return vm
+			}
+
+			BeforeEach(func() {
+				testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, &v1.KubeVirt{
+					Spec: v1.KubeVirtSpec{Configuration: v1.KubeVirtConfiguration{
+						NetworkConfiguration: &v1.NetworkConfiguration{
+							MacAddressPool: &v1.MacAddressPool{RangeStart: "02:00:00:00:00:00", RangeEnd: "02:00:00:00:00:04"},
+						},
+					}},
+					Status: v1.KubeVirtStatus{Phase: v1.KubeVirtPhaseDeployed},
+				})
+			})
+
+			addVirtualMachineWithNeighbours := func(vm *v1.VirtualMachine, neighbours ...*v1.VirtualMachine) {
+				addVirtualMachine(vm)
+				for _, neighbour := range append(neighbours, newVMWithInterfaces("other", "02:00:00:00:00:00")) {
+					Expect(vmInformer.GetStore().Add(neighbour)).To(Succeed())
+				}
+				standaloneVMI := v1.NewMinimalVMI("standalone")
+				standaloneVMI.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "default", MacAddress: "02-00-00-00-00-01"}}
+				Expect(vmiInformer.GetStore().Add(standaloneVMI)).To(Succeed())
+			}
+
+			expectMacAddresses := func(macs ...string) {
+				vmInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+					var assigned []string
+					for _, iface := range arg.(*v1.VirtualMachine).Spec.Template.Spec.Domain.Devices.Interfaces {
+						assigned = append(assigned, iface.MacAddress)
+					}
+					Expect(assigned).To(Equal(macs))
+				}).Return(nil, nil)
+			}
+
+			It("should assign the lowest free addresses to the interfaces without a MAC address", func() {
+				addVirtualMachineWithNeighbours(newVMWithInterfaces("testvmi", "", "02:00:00:00:00:02", ""))
+
+				expectMacAddresses("02:00:00:00:00:03", "02:00:00:00:00:02", "02:00:00:00:00:04")
+
+				controller.Execute()
+			})
+
+			It("should assign the same addresses again while the cache is outdated", func() {
+				vm := newVMWithInterfaces("testvmi", "")
+				addVirtualMachineWithNeighbours(vm)
+				expectMacAddresses("02:00:00:00:00:02")
+				controller.Execute()
+
+				// another VM must not get the pending address
+				Expect(vmInformer.GetStore().Add(newVMWithInterfaces("third", ""))).To(Succeed())
+				key, err := virtcontroller.KeyFunc(vm)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(controller.isMacAddressUsed("02:00:00:00:00:02", "default/third")).To(BeTrue())
+				expectMacAddresses("02:00:00:00:00:02")
+				Expect(controller.execute(key)).To(Succeed())
+			})
+
+			It("should keep the addresses a running VMI was started with", func() {
+				vm := newVMWithInterfaces("testvmi", "", "")
+				vm.Spec.Running = &t
+				addVirtualMachineWithNeighbours(vm)
+				vmi := controller.setupVMIFromVM(vm)
+				vmi.Status.Phase = v1.Running
+				vmi.Status.Interfaces = []v1.VirtualMachineInstanceNetworkInterface{
+					{Name: "net0", MAC: "52:54:00:00:00:01"},
+					// used by another VM, a free address of the pool has to be assigned
+					{Name: "net1", MAC: "02:00:00:00:00:00"},
+				}
+				vmiFeeder.Add(vmi)
+
+				expectMacAddresses("52:54:00:00:00:01", "02:00:00:00:00:02")
+
+				controller.Execute()
+			})
+
+			It("should wait for the addresses of a starting VMI", func() {
+				vm := newVMWithInterfaces("testvmi", "")
+				vm.Spec.Running = &t
+				addVirtualMachineWithNeighbours(vm)
+				vmi := controller.setupVMIFromVM(vm)
+				vmi.Status.Phase = v1.Scheduling
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(nil, nil).AnyTimes()
+
+				controller.Execute()
+			})
+
+			It("should start the VM without assigned addresses when the pool is exhausted", func() {
+				addVirtualMachineWithNeighbours(newVMWithInterfaces("testvmi", "", "02:00:00:00:00:02", ""),
+					newVMWithInterfaces("third", "02:00:00:00:00:03"))
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(nil, nil).AnyTimes()
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, FailedAllocateMacAddressReason)
+			})
+		})
+
+		Context("with start groups", func() {
+			newDatabaseVM := func() (*v1.VirtualMachine, *v1.VirtualMachineInstance) {
+				vm, vmi := DefaultVirtualMachineWithNames(true, "database", "database")
+				vm.Labels = map[string]string{v1.StartGroupLabel: "db"}
+				return vm, vmi
+			}
+
+			newApplicationVM := func() (*v1.VirtualMachine, *v1.VirtualMachineInstance) {
+				vm, vmi := DefaultVirtualMachine(true)
+				vm.Annotations[v1.StartAfterAnnotation] = "db"
+				return vm, vmi
+			}
+
+			agentConnected := func(vmi *v1.VirtualMachineInstance) *v1.VirtualMachineInstance {
+				vmi = vmi.DeepCopy()
+				vmi.ResourceVersion = "2"
+				vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+					Type:   v1.VirtualMachineInstanceAgentConnected,
+					Status: k8sv1.ConditionTrue,
+				})
+				return vmi
+			}
+
+			It("should not start a VM before the guest agents of its start groups are connected", func() {
+				databaseVM, databaseVMI := newDatabaseVM()
+				vm, _ := newApplicationVM()
+				addVirtualMachine(vm)
+				Expect(vmInformer.GetIndexer().Add(databaseVM)).To(Succeed())
+				Expect(vmiInformer.GetIndexer().Add(databaseVMI)).To(Succeed())
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(nil, nil).AnyTimes()
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, WaitingForStartDependenciesReason)
+
+				// the VM is only reported once while it keeps waiting for the same VMs
+				key, err := virtcontroller.KeyFunc(vm)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(controller.execute(key)).To(Succeed())
+				Expect(recorder.Events).To(BeEmpty())
+			})
+
+			It("should not wait for halted VMs of its start groups", func() {
+				databaseVM, _ := newDatabaseVM()
+				halted := v1.RunStrategyHalted
+				databaseVM.Spec.Running = nil
+				databaseVM.Spec.RunStrategy = &halted
+				vm, vmi := newApplicationVM()
+				addVirtualMachine(vm)
+				Expect(vmInformer.GetIndexer().Add(databaseVM)).To(Succeed())
+
+				vmiInterface.EXPECT().Create(gomock.Any()).Return(vmi, nil)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(nil, nil)
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+			})
+
+			It("should enqueue the VMs waiting for a start group once a VM of the group is halted", func() {
+				databaseVM, _ := newDatabaseVM()
+				vm, _ := newApplicationVM()
+				syncCaches(stop)
+				Expect(vmInformer.GetIndexer().Add(databaseVM)).To(Succeed())
+				Expect(vmInformer.GetIndexer().Add(vm)).To(Succeed())
+
+				haltedVM := databaseVM.DeepCopy()
+				halted := v1.RunStrategyHalted
+				haltedVM.Spec.Running = nil
+				haltedVM.Spec.RunStrategy = &halted
+				mockQueue.ExpectAdds(2)
+				controller.updateVirtualMachine(databaseVM, haltedVM)
+				mockQueue.Wait()
+
+				Expect(mockQueue.Len()).To(Equal(2))
+			})
+
+			It("should start a VM once the guest agents of its start groups are connected", func() {
+				databaseVM, databaseVMI := newDatabaseVM()
+				vm, vmi := newApplicationVM()
+				addVirtualMachine(vm)
+				Expect(vmInformer.GetIndexer().Add(databaseVM)).To(Succeed())
+				Expect(vmiInformer.GetIndexer().Add(agentConnected(databaseVMI))).To(Succeed())
+
+				vmiInterface.EXPECT().Create(gomock.Any()).Return(vmi, nil)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(nil, nil)
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+			})
+
+			It("should enqueue the VMs waiting for a start group once a guest agent connects", func() {
+				databaseVM, databaseVMI := newDatabaseVM()
+				vm, _ := newApplicationVM()
+				otherVM, _ := DefaultVirtualMachineWithNames(true, "other", "other")
+				syncCaches(stop)
+				Expect(vmInformer.GetIndexer().Add(databaseVM)).To(Succeed())
+				Expect(vmInformer.GetIndexer().Add(vm)).To(Succeed())
+				Expect(vmInformer.GetIndexer().Add(otherVM)).To(Succeed())
+
+				mockQueue.ExpectAdds(2)
+				controller.updateVirtualMachineInstance(databaseVMI, agentConnected(databaseVMI))
+				mockQueue.Wait()
+
+				Expect(mockQueue.Len()).To(Equal(2))
+			})
+		})
+
+		It("should ignore the name of a VirtualMachineInstance templates", func() {
+			vm, vmi := DefaultVirtualMachineWithNames(true, "vmname", "vminame")
+
+			addVirtualMachine(vm)
+
+			// expect creation called
+			vmiInterface.EXPECT().Create(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachineInstance).ObjectMeta.Name).To(Equal("vmname"))
+				Expect(arg.(*v1.VirtualMachineInstance).ObjectMeta.GenerateName).To(Equal(""))
+			}).Return(vmi, nil)
+
+			// expect update status is called
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachine).Status.Created).To(BeFalse())
+				Expect(arg.(*v1.VirtualMachine).Status.Ready).To(BeFalse())
+			}).Return(nil, nil)
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+		})
+
+		It("should update status to created if the vmi exists", func() {
+			vm, vmi := DefaultVirtualMachine(true)
+			vmi.Status.Phase = v1.Scheduled
+
+			addVirtualMachine(vm)
+			vmiFeeder.Add(vmi)
+
+			// expect update status is called
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachine).Status.Created).To(BeTrue())
+				Expect(arg.(*v1.VirtualMachine).Status.Ready).To(BeFalse())
+			}).Return(nil, nil)
+
+			controller.Execute()
+		})
+
+		It("should update status to created and ready when vmi is running and running", func() {
+			vm, vmi := DefaultVirtualMachine(true)
+			markAsReady(vmi)
+
+			addVirtualMachine(vm)
+			vmiFeeder.Add(vmi)
+
+			// expect update status is called
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachine).Status.Created).To(BeTrue())
+				Expect(arg.(*v1.VirtualMachine).Status.Ready).To(BeTrue())
+			}).Return(nil, nil)
+
+			controller.Execute()
+		})
+
+		It("should have stable firmware UUIDs", func() {
+			vm1, _ := DefaultVirtualMachineWithNames(true, "testvm1", "testvmi1")
+			vmi1 := controller.setupVMIFromVM(vm1)
+
+			// intentionally use the same names
+			vm2, _ := DefaultVirtualMachineWithNames(true, "testvm1", "testvmi1")
+			vmi2 := controller.setupVMIFromVM(vm2)
+			Expect(vmi1.Spec.Domain.Firmware.UUID).To(Equal(vmi2.Spec.Domain.Firmware.UUID))
+
+			// now we want different names
+			vm3, _ := DefaultVirtualMachineWithNames(true, "testvm3", "testvmi3")
+			vmi3 := controller.setupVMIFromVM(vm3)
+			Expect(vmi1.Spec.Domain.Firmware.UUID).NotTo(Equal(vmi3.Spec.Domain.Firmware.UUID))
+		})
+
+		It("should honour any firmware UUID present in the template", func() {
+			uid := uuid.NewRandom().String()
+			vm1, _ := DefaultVirtualMachineWithNames(true, "testvm1", "testvmi1")
+			vm1.Spec.Template.Spec.Domain.Firmware = &virtv1.Firmware{UUID: types.UID(uid)}
+
+			vmi1 := controller.setupVMIFromVM(vm1)
+			Expect(string(vmi1.Spec.Domain.Firmware.UUID)).To(Equal(uid))
+		})
+
+		It("should delete VirtualMachineInstance when stopped", func() {
+			vm, vmi := DefaultVirtualMachine(false)
+
+			addVirtualMachine(vm)
+			vmiFeeder.Add(vmi)
+
+			// vmInterface.EXPECT().Update(gomock.Any()).Return(vm, nil)
 			vmiInterface.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
 
-			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Times(1).Return(vm, nil)
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Times(1).Return(vm, nil)
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulDeleteVirtualMachineReason)
+		})
+
+		It("should not delete the VirtualMachineInstance again if it is already marked for deletion", func() {
+			vm, vmi := DefaultVirtualMachine(false)
+			vmi.DeletionTimestamp = now()
+
+			addVirtualMachine(vm)
+			vmiFeeder.Add(vmi)
+
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Times(1).Return(vm, nil)
+
+			controller.Execute()
+		})
+
+		It("should ignore non-matching VMIs", func() {
+			vm, vmi := DefaultVirtualMachine(true)
+
+			nonMatchingVMI := v1.NewMinimalVMI("testvmi1")
+			nonMatchingVMI.ObjectMeta.Labels = map[string]string{"test": "test1"}
+
+			addVirtualMachine(vm)
+
+			// We still expect three calls to create VMIs, since VirtualMachineInstance does not meet the requirements
+			vmiSource.Add(nonMatchingVMI)
+
+			vmiInterface.EXPECT().Create(gomock.Any()).Return(vmi, nil)
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Times(2).Return(vm, nil)
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+		})
+
+		It("should detect that a VirtualMachineInstance already exists and adopt it", func() {
+			vm, vmi := DefaultVirtualMachine(true)
+			vmi.OwnerReferences = []metav1.OwnerReference{}
+
+			addVirtualMachine(vm)
+			vmiFeeder.Add(vmi)
+
+			vmInterface.EXPECT().Get(vm.ObjectMeta.Name, gomock.Any()).Return(vm, nil)
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil)
+			vmiInterface.EXPECT().Patch(vmi.ObjectMeta.Name, gomock.Any(), gomock.Any())
+
+			controller.Execute()
+		})
+
+		It("should detect that a DataVolume already exists and adopt it", func() {
+			vm, _ := DefaultVirtualMachine(false)
+			vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, v1.Volume{
+				Name: "test1",
+				VolumeSource: v1.VolumeSource{
+					DataVolume: &v1.DataVolumeSource{
+						Name: "dv1",
+					},
+				},
+			})
+
+			vm.Spec.DataVolumeTemplates = append(vm.Spec.DataVolumeTemplates, v1.DataVolumeTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "dv1",
+					Namespace: vm.Namespace,
+				},
+			})
+
+			addVirtualMachine(vm)
+
+			dv := createDataVolumeManifest(&vm.Spec.DataVolumeTemplates[0], vm)
+			dv.Status.Phase = cdiv1.Succeeded
+
+			orphanDV := dv.DeepCopy()
+			orphanDV.ObjectMeta.OwnerReferences = nil
+			dataVolumeInformer.GetStore().Add(orphanDV)
+
+			cdiClient.Fake.PrependReactor("patch", "datavolumes", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				patch, ok := action.(testing.PatchAction)
+				Expect(ok).To(BeTrue())
+				Expect(patch.GetName()).To(Equal(dv.Name))
+				Expect(patch.GetNamespace()).To(Equal(dv.Namespace))
+				Expect(string(patch.GetPatch())).To(ContainSubstring(string(vm.UID)))
+				Expect(string(patch.GetPatch())).To(ContainSubstring("ownerReferences"))
+				return true, dv, nil
+			})
+
+			vmInterface.EXPECT().Get(vm.ObjectMeta.Name, gomock.Any()).Return(vm, nil)
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil)
+
+			controller.Execute()
+		})
+
+		It("should detect that it has nothing to do beside updating the status", func() {
+			vm, vmi := DefaultVirtualMachine(true)
+
+			addVirtualMachine(vm)
+			vmiFeeder.Add(vmi)
+
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil)
+
+			controller.Execute()
+		})
+
+		Context("node reboot policy", func() {
+			rebootedVirtualMachine := func(runStrategy v1.VirtualMachineRunStrategy, policy v1.NodeRebootPolicy, nodeBootID string) (*v1.VirtualMachine, *v1.VirtualMachineInstance) {
+				vm, vmi := DefaultVirtualMachine(false)
+				vm.Spec.Running = nil
+				vm.Spec.RunStrategy = &runStrategy
+				vm.Spec.NodeRebootPolicy = &policy
+				vmi.UID = "123"
+				vmi.Status.Phase = v1.Failed
+				vmi.Status.NodeName = "node01"
+				vmi.Status.NodeBootID = "boot-before-reboot"
+
+				node := &k8sv1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: "node01"},
+					Status: k8sv1.NodeStatus{
+						NodeInfo: k8sv1.NodeSystemInfo{BootID: nodeBootID},
+					},
+				}
+				nodeInformer.GetStore().Add(node)
+				return vm, vmi
+			}
+
+			table.DescribeTable("should hold a VMI which failed due to a node reboot", func(runStrategy v1.VirtualMachineRunStrategy) {
+				vm, vmi := rebootedVirtualMachine(runStrategy, v1.NodeRebootPolicyHold, "boot-after-reboot")
+
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil)
+				shouldExpectVMIFinalizerRemoval(vmi)
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, NodeRebootReason)
+			},
+				table.Entry("with RunStrategy Always", v1.RunStrategyAlways),
+				table.Entry("with RunStrategy RerunOnFailure", v1.RunStrategyRerunOnFailure),
+			)
+
+			It("should not hold a failed VMI if the node did not reboot", func() {
+				vm, vmi := rebootedVirtualMachine(v1.RunStrategyAlways, v1.NodeRebootPolicyHold, "boot-before-reboot")
+
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmiInterface.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil)
+				shouldExpectVMIFinalizerRemoval(vmi)
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, SuccessfulDeleteVirtualMachineReason)
+			})
+
+			It("should request a restart of a VMI with RunStrategy Manual which failed due to a node reboot", func() {
+				vm, vmi := rebootedVirtualMachine(v1.RunStrategyManual, v1.NodeRebootPolicyRestart, "boot-after-reboot")
+
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					Expect(obj.(*v1.VirtualMachine).Status.StateChangeRequests).To(Equal([]v1.VirtualMachineStateChangeRequest{
+						{Action: v1.StopRequest, UID: &vmi.UID},
+						{Action: v1.StartRequest},
+					}))
+				}).Return(vm, nil)
+				shouldExpectVMIFinalizerRemoval(vmi)
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, NodeRebootReason)
+			})
+
+			It("should not restart a VMI with RunStrategy Manual which failed without a node reboot", func() {
+				vm, vmi := rebootedVirtualMachine(v1.RunStrategyManual, v1.NodeRebootPolicyRestart, "boot-before-reboot")
+
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					Expect(obj.(*v1.VirtualMachine).Status.StateChangeRequests).To(BeEmpty())
+				}).Return(vm, nil)
+				shouldExpectVMIFinalizerRemoval(vmi)
+
+				controller.Execute()
+			})
+		})
+
+		Context("availability status", func() {
+			timePtr := func(t time.Time) *metav1.Time {
+				metaTime := metav1.NewTime(t)
+				return &metaTime
+			}
+
+			withReadyCondition := func(vmi *v1.VirtualMachineInstance, ready time.Time) {
+				vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+					Type:               v1.VirtualMachineInstanceReady,
+					Status:             k8sv1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(ready),
+				})
+			}
+
+			expectAvailability := func(vm *v1.VirtualMachine, expected *v1.VirtualMachineAvailabilityStatus) {
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					Expect(obj.(*v1.VirtualMachine).Status.Availability).To(Equal(expected))
+				}).Return(vm, nil)
+			}
+
+			It("should record the start request of a VM", func() {
+				vm, vmi := DefaultVirtualMachine(true)
+
+				addVirtualMachine(vm)
+
+				vmiInterface.EXPECT().Create(gomock.Any()).Return(vmi, nil)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					availability := obj.(*v1.VirtualMachine).Status.Availability
+					Expect(availability).ToNot(BeNil())
+					Expect(availability.StartRequestTime).ToNot(BeNil())
+					Expect(availability.ReadyTime).To(BeNil())
+				}).Return(vm, nil)
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+			})
+
+			It("should record when the guest became ready after the start request", func() {
+				start := time.Now().Add(-time.Hour)
+				vm, vmi := DefaultVirtualMachine(true)
+				vm.Status.Availability = &v1.VirtualMachineAvailabilityStatus{StartRequestTime: timePtr(start)}
+				withReadyCondition(vmi, start.Add(42*time.Second))
+
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				expectAvailability(vm, &v1.VirtualMachineAvailabilityStatus{
+					StartRequestTime: timePtr(start),
+					ReadyTime:        timePtr(start.Add(42 * time.Second)),
+				})
+
+				controller.Execute()
+			})
+
+			It("should record the failure of a VMI", func() {
+				failed := time.Now().Add(-time.Minute)
+				vm, vmi := DefaultVirtualMachine(false)
+				vm.Spec.Running = nil
+				runStrategy := v1.RunStrategyManual
+				vm.Spec.RunStrategy = &runStrategy
+				vmi.Status.Phase = v1.Failed
+				vmi.Status.PhaseTransitionTimestamps = []v1.VirtualMachineInstancePhaseTransitionTimestamp{
+					{Phase: v1.Failed, PhaseTransitionTimestamp: metav1.NewTime(failed)},
+				}
+
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				expectAvailability(vm, &v1.VirtualMachineAvailabilityStatus{FailureTime: timePtr(failed)})
+				shouldExpectVMIFinalizerRemoval(vmi)
+
+				controller.Execute()
+			})
+
+			It("should not count a VMI which fails while it is deleted as downtime", func() {
+				vm, vmi := DefaultVirtualMachine(false)
+				vm.Spec.Running = nil
+				runStrategy := v1.RunStrategyManual
+				vm.Spec.RunStrategy = &runStrategy
+				vmi.Status.Phase = v1.Failed
+				vmi.DeletionTimestamp = timePtr(time.Now())
+
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				expectAvailability(vm, nil)
+				shouldExpectVMIFinalizerRemoval(vmi)
+
+				controller.Execute()
+			})
+
+			It("should accumulate the downtime until the restarted VMI is ready", func() {
+				restarted := time.Now().Add(-time.Hour)
+				vm, vmi := DefaultVirtualMachine(true)
+				vm.Status.Availability = &v1.VirtualMachineAvailabilityStatus{
+					StartRequestTime:         timePtr(restarted),
+					FailureTime:              timePtr(restarted.Add(-time.Minute)),
+					UnplannedDowntimeSeconds: 80,
+				}
+				withReadyCondition(vmi, restarted.Add(20*time.Second))
+
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				expectAvailability(vm, &v1.VirtualMachineAvailabilityStatus{
+					StartRequestTime:         timePtr(restarted),
+					ReadyTime:                timePtr(restarted.Add(20 * time.Second)),
+					UnplannedDowntimeSeconds: 160,
+				})
+
+				controller.Execute()
+			})
+
+			It("should end the outage when the VM gets stopped", func() {
+				vm, _ := DefaultVirtualMachine(false)
+				vm.Status.Availability = &v1.VirtualMachineAvailabilityStatus{
+					FailureTime: timePtr(time.Now().Add(-time.Minute)),
+				}
+
+				addVirtualMachine(vm)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					availability := obj.(*v1.VirtualMachine).Status.Availability
+					Expect(availability.FailureTime).To(BeNil())
+					Expect(availability.UnplannedDowntimeSeconds).To(BeNumerically(">=", 60))
+				}).Return(vm, nil)
+
+				controller.Execute()
+			})
+		})
+
+		Context("rename", func() {
+			newRenameRequest := func(vm *v1.VirtualMachine, newName string) {
+				vm.Status.StateChangeRequests = []v1.VirtualMachineStateChangeRequest{
+					{Action: v1.RenameRequest, Data: map[string]string{v1.RenameRequestDataNewNameKey: newName}},
+				}
+			}
+
+			It("should recreate the VM under the new name and hand over its DataVolumes and ControllerRevisions", func() {
+				vm, _ := DefaultVirtualMachine(false)
+				vm.Annotations["my"] = "annotation"
+				vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, v1.Volume{
+					Name: "dv1",
+					VolumeSource: v1.VolumeSource{
+						DataVolume: &v1.DataVolumeSource{Name: "dv1"},
+					},
+				})
+				vm.Spec.DataVolumeTemplates = []v1.DataVolumeTemplateSpec{{ObjectMeta: metav1.ObjectMeta{Name: "dv1"}}}
+				newRenameRequest(vm, "newvm")
+				addVirtualMachine(vm)
+
+				dataVolume := createDataVolumeManifest(&vm.Spec.DataVolumeTemplates[0], vm)
+				dataVolume.Namespace = "default"
+				dataVolumeFeeder.Add(dataVolume)
 
-			controller.Execute()
+				cr := &appsv1.ControllerRevision{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            getVMRevisionName(vm.UID, 1),
+						Namespace:       vm.Namespace,
+						OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(vm, v1.VirtualMachineGroupVersionKind)},
+					},
+				}
+				Expect(crInformer.GetStore().Add(cr)).To(Succeed())
+
+				vmInterface.EXPECT().Get("newvm", gomock.Any()).Return(nil, errors.NewNotFound(v1.Resource("virtualmachine"), "newvm"))
+				vmInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(obj interface{}) (*v1.VirtualMachine, error) {
+					newVM := obj.(*v1.VirtualMachine)
+					Expect(newVM.Name).To(Equal("newvm"))
+					Expect(newVM.Annotations).To(HaveKeyWithValue("my", "annotation"))
+					Expect(newVM.Annotations).To(HaveKeyWithValue(v1.RenamedFromAnnotation, vm.Name))
+					Expect(newVM.Spec.Template.Spec.Domain.Firmware.UUID).ToNot(BeEmpty())
+					Expect(newVM.Status.StateChangeRequests).To(BeEmpty())
+					newVM.UID = "new-uid"
+					return newVM, nil
+				})
 
-			testutils.ExpectEvent(recorder, SuccessfulDeleteVirtualMachineReason)
-		})
+				cdiClient.Fake.PrependReactor("patch", "datavolumes", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					patch, ok := action.(testing.PatchAction)
+					Expect(ok).To(BeTrue())
+					Expect(patch.GetName()).To(Equal("dv1"))
+					Expect(string(patch.GetPatch())).To(ContainSubstring(`"uid":"new-uid"`))
+					return true, nil, nil
+				})
+				crPatched := false
+				k8sClient.Fake.PrependReactor("patch", "controllerrevisions", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					patch, ok := action.(testing.PatchAction)
+					Expect(ok).To(BeTrue())
+					Expect(patch.GetName()).To(Equal(cr.Name))
+					Expect(string(patch.GetPatch())).To(ContainSubstring(`"uid":"new-uid"`))
+					crPatched = true
+					return true, nil, nil
+				})
+				vmInterface.EXPECT().Delete(vm.Name, gomock.Any()).Return(nil)
 
-		It("should not delete the VirtualMachineInstance again if it is already marked for deletion", func() {
-			vm, vmi := DefaultVirtualMachine(false)
-			vmi.DeletionTimestamp = now()
+				controller.Execute()
 
-			addVirtualMachine(vm)
-			vmiFeeder.Add(vmi)
+				Expect(crPatched).To(BeTrue())
+				testutils.ExpectEvent(recorder, SuccessfulRenameVirtualMachineReason)
+			})
 
-			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Times(1).Return(vm, nil)
+			It("should keep the firmware UUID of the VM", func() {
+				vm, _ := DefaultVirtualMachine(false)
+				newVM := renamedVM(vm, "newvm")
+				vmi := controller.setupVMIFromVM(vm)
+				Expect(newVM.Spec.Template.Spec.Domain.Firmware.UUID).To(Equal(vmi.Spec.Domain.Firmware.UUID))
+			})
 
-			controller.Execute()
+			It("should add a fail condition if the VM is still running", func() {
+				vm, vmi := DefaultVirtualMachine(false)
+				vm.Spec.Running = nil
+				runStrategy := v1.RunStrategyManual
+				vm.Spec.RunStrategy = &runStrategy
+				newRenameRequest(vm, "newvm")
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().Get("newvm", gomock.Any()).Return(nil, errors.NewNotFound(v1.Resource("virtualmachine"), "newvm"))
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					objVM := obj.(*v1.VirtualMachine)
+					cond := virtcontroller.NewVirtualMachineConditionManager().GetCondition(objVM, v1.VirtualMachineFailure)
+					Expect(cond).ToNot(BeNil())
+					Expect(cond.Message).To(Equal("VM must be stopped to be renamed"))
+					Expect(objVM.Status.StateChangeRequests).To(HaveLen(1))
+				}).Return(vm, nil)
+
+				controller.Execute()
+			})
+
+			It("should drop the rename request if another VM took the new name", func() {
+				vm, _ := DefaultVirtualMachine(false)
+				newRenameRequest(vm, "newvm")
+				addVirtualMachine(vm)
+
+				otherVM, _ := DefaultVirtualMachineWithNames(false, "newvm", "newvm")
+				vmInterface.EXPECT().Get("newvm", gomock.Any()).Return(otherVM, nil).Times(2)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					objVM := obj.(*v1.VirtualMachine)
+					Expect(objVM.Status.StateChangeRequests).To(BeEmpty())
+				}).Return(vm, nil)
+
+				controller.Execute()
+			})
 		})
 
-		It("should ignore non-matching VMIs", func() {
-			vm, vmi := DefaultVirtualMachine(true)
+		Context("move", func() {
+			var targetVMInterface *kubecli.MockVirtualMachineInterface
 
-			nonMatchingVMI := v1.NewMinimalVMI("testvmi1")
-			nonMatchingVMI.ObjectMeta.Labels = map[string]string{"test": "test1"}
+			newMoveRequest := func(vm *v1.VirtualMachine, newNamespace string) {
+				vm.Status.StateChangeRequests = []v1.VirtualMachineStateChangeRequest{
+					{Action: v1.MoveRequest, Data: map[string]string{v1.MoveRequestDataNewNamespaceKey: newNamespace}},
+				}
+			}
 
-			addVirtualMachine(vm)
+			newMovableVM := func() (*v1.VirtualMachine, *v1.VirtualMachineInstance) {
+				vm, vmi := DefaultVirtualMachine(false)
+				vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes,
+					v1.Volume{
+						Name:         "dv1",
+						VolumeSource: v1.VolumeSource{DataVolume: &v1.DataVolumeSource{Name: "dv1"}},
+					},
+					v1.Volume{
+						Name: "pvc1",
+						VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+							PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc1"},
+						}},
+					},
+				)
+				vm.Spec.DataVolumeTemplates = []v1.DataVolumeTemplateSpec{{
+					ObjectMeta: metav1.ObjectMeta{Name: "dv1"},
+					Spec: cdiv1.DataVolumeSpec{
+						Source: &cdiv1.DataVolumeSource{HTTP: &cdiv1.DataVolumeSourceHTTP{URL: "http://example.org/disk.img"}},
+					},
+				}}
+				newMoveRequest(vm, "tenant-b")
+
+				storageClass := "local"
+				Expect(pvcInformer.GetStore().Add(&k8sv1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{Name: "pvc1", Namespace: vm.Namespace},
+					Spec: k8sv1.PersistentVolumeClaimSpec{
+						AccessModes:      []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteOnce},
+						StorageClassName: &storageClass,
+						Resources: k8sv1.ResourceRequirements{
+							Requests: k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse("1Gi")},
+						},
+					},
+				})).To(Succeed())
+				return vm, vmi
+			}
 
-			// We still expect three calls to create VMIs, since VirtualMachineInstance does not meet the requirements
-			vmiSource.Add(nonMatchingVMI)
+			addSourceDataVolume := func(vm *v1.VirtualMachine) {
+				dataVolume := createDataVolumeManifest(&vm.Spec.DataVolumeTemplates[0], vm)
+				dataVolume.Namespace = vm.Namespace
+				dataVolume.Status.Phase = cdiv1.Succeeded
+				dataVolumeFeeder.Add(dataVolume)
+			}
 
-			vmiInterface.EXPECT().Create(gomock.Any()).Return(vmi, nil)
-			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Times(2).Return(vm, nil)
+			addClone := func(vm *v1.VirtualMachine, name string, phase cdiv1.DataVolumePhase) {
+				Expect(dataVolumeInformer.GetStore().Add(&cdiv1.DataVolume{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      name,
+						Namespace: "tenant-b",
+						Annotations: map[string]string{
+							v1.MovedFromAnnotation:    vm.Namespace,
+							v1.MovedFromUIDAnnotation: string(vm.UID),
+						},
+					},
+					Status: cdiv1.DataVolumeStatus{Phase: phase},
+				})).To(Succeed())
+			}
 
-			controller.Execute()
+			expectMoveFailed := func(message string) {
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).DoAndReturn(func(obj interface{}) (*v1.VirtualMachine, error) {
+					objVM := obj.(*v1.VirtualMachine)
+					Expect(objVM.Status.StateChangeRequests).To(BeEmpty())
+					cond := virtcontroller.NewVirtualMachineConditionManager().GetCondition(objVM, v1.VirtualMachineMoveFailed)
+					Expect(cond).ToNot(BeNil())
+					Expect(cond.Reason).To(Equal(FailedMoveVirtualMachineReason))
+					Expect(cond.Message).To(ContainSubstring(message))
+					return objVM, nil
+				})
+			}
 
-			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
-		})
+			BeforeEach(func() {
+				targetVMInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+				virtClient.EXPECT().VirtualMachine("tenant-b").Return(targetVMInterface).AnyTimes()
+			})
 
-		It("should detect that a VirtualMachineInstance already exists and adopt it", func() {
-			vm, vmi := DefaultVirtualMachine(true)
-			vmi.OwnerReferences = []metav1.OwnerReference{}
+			It("should clone the volumes of the VM to the new namespace and wait for the clones", func() {
+				vm, _ := newMovableVM()
+				addVirtualMachine(vm)
+				addSourceDataVolume(vm)
+
+				clones := map[string]*cdiv1.DataVolume{}
+				cdiClient.Fake.PrependReactor("create", "datavolumes", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					create := action.(testing.CreateAction)
+					Expect(create.GetNamespace()).To(Equal("tenant-b"))
+					dataVolume := create.GetObject().(*cdiv1.DataVolume)
+					clones[dataVolume.Name] = dataVolume
+					return true, dataVolume, nil
+				})
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil).AnyTimes()
 
-			addVirtualMachine(vm)
-			vmiFeeder.Add(vmi)
+				controller.Execute()
 
-			vmInterface.EXPECT().Get(vm.ObjectMeta.Name, gomock.Any()).Return(vm, nil)
-			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil)
-			vmiInterface.EXPECT().Patch(vmi.ObjectMeta.Name, gomock.Any(), gomock.Any())
+				Expect(clones).To(HaveLen(2))
+				for _, name := range []string{"dv1", "pvc1"} {
+					Expect(clones).To(HaveKey(name))
+					Expect(clones[name].Annotations).To(HaveKeyWithValue(v1.MovedFromAnnotation, vm.Namespace))
+					Expect(clones[name].Annotations).To(HaveKeyWithValue(v1.MovedFromUIDAnnotation, string(vm.UID)))
+					Expect(clones[name].Spec.Source.PVC).To(Equal(&cdiv1.DataVolumeSourcePVC{Namespace: vm.Namespace, Name: name}))
+					Expect(clones[name].OwnerReferences).To(BeEmpty())
+				}
+				Expect(*clones["pvc1"].Spec.PVC.StorageClassName).To(Equal("local"))
+				Expect(clones["pvc1"].Spec.PVC.Resources.Requests.Storage().String()).To(Equal("1Gi"))
+				Expect(mockQueue.GetAddAfterEnqueueCount()).To(Equal(1))
+				testutils.ExpectEvents(recorder, SuccessfulDataVolumeCreateReason, SuccessfulDataVolumeCreateReason)
+			})
 
-			controller.Execute()
-		})
+			It("should recreate the VM in the new namespace once the clones succeeded", func() {
+				vm, vmi := newMovableVM()
+				vm.Spec.Template.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "default"}}
+				vmi.Status.Phase = v1.Succeeded
+				vmi.Status.Interfaces = []v1.VirtualMachineInstanceNetworkInterface{{Name: "default", MAC: "de:ad:00:00:be:af"}}
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+				addSourceDataVolume(vm)
+				addClone(vm, "dv1", cdiv1.Succeeded)
+				addClone(vm, "pvc1", cdiv1.Succeeded)
+
+				targetVMInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(obj interface{}) (*v1.VirtualMachine, error) {
+					newVM := obj.(*v1.VirtualMachine)
+					Expect(newVM.Name).To(Equal(vm.Name))
+					Expect(newVM.Namespace).To(Equal("tenant-b"))
+					Expect(newVM.Annotations).To(HaveKeyWithValue(v1.MovedFromAnnotation, vm.Namespace))
+					Expect(newVM.Spec.Template.Spec.Domain.Firmware.UUID).To(Equal(controller.setupVMIFromVM(vm).Spec.Domain.Firmware.UUID))
+					Expect(newVM.Spec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress).To(Equal("de:ad:00:00:be:af"))
+					Expect(newVM.Status.StateChangeRequests).To(BeEmpty())
+					return newVM, nil
+				})
+				vmInterface.EXPECT().Delete(vm.Name, gomock.Any()).Return(nil)
 
-		It("should detect that a DataVolume already exists and adopt it", func() {
-			vm, _ := DefaultVirtualMachine(false)
-			vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, v1.Volume{
-				Name: "test1",
-				VolumeSource: v1.VolumeSource{
-					DataVolume: &v1.DataVolumeSource{
-						Name: "dv1",
-					},
-				},
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, SuccessfulMoveVirtualMachineReason)
 			})
 
-			vm.Spec.DataVolumeTemplates = append(vm.Spec.DataVolumeTemplates, v1.DataVolumeTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "dv1",
-					Namespace: vm.Namespace,
-				},
+			It("should fail the move if another VM took the name in the new namespace", func() {
+				vm, _ := DefaultVirtualMachine(false)
+				newMoveRequest(vm, "tenant-b")
+				addVirtualMachine(vm)
+
+				otherVM, _ := DefaultVirtualMachine(false)
+				otherVM.Namespace = "tenant-b"
+				Expect(vmInformer.GetStore().Add(otherVM)).To(Succeed())
+				expectMoveFailed("already exists in namespace tenant-b")
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, FailedMoveVirtualMachineReason)
 			})
 
-			addVirtualMachine(vm)
+			It("should fail the move and remove the clones if a clone failed", func() {
+				vm, _ := newMovableVM()
+				addVirtualMachine(vm)
+				addSourceDataVolume(vm)
+				addClone(vm, "dv1", cdiv1.Succeeded)
+				addClone(vm, "pvc1", cdiv1.Failed)
+
+				var deleted []string
+				cdiClient.Fake.PrependReactor("delete", "datavolumes", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					deleted = append(deleted, action.(testing.DeleteAction).GetName())
+					return true, nil, nil
+				})
+				expectMoveFailed("DataVolume tenant-b/pvc1 failed to clone the disk")
 
-			dv := createDataVolumeManifest(&vm.Spec.DataVolumeTemplates[0], vm)
-			dv.Status.Phase = cdiv1.Succeeded
+				controller.Execute()
 
-			orphanDV := dv.DeepCopy()
-			orphanDV.ObjectMeta.OwnerReferences = nil
-			dataVolumeInformer.GetStore().Add(orphanDV)
+				Expect(deleted).To(ConsistOf("dv1", "pvc1"))
+				testutils.ExpectEvent(recorder, FailedMoveVirtualMachineReason)
+			})
 
-			cdiClient.Fake.PrependReactor("patch", "datavolumes", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
-				patch, ok := action.(testing.PatchAction)
-				Expect(ok).To(BeTrue())
-				Expect(patch.GetName()).To(Equal(dv.Name))
-				Expect(patch.GetNamespace()).To(Equal(dv.Namespace))
-				Expect(string(patch.GetPatch())).To(ContainSubstring(string(vm.UID)))
-				Expect(string(patch.GetPatch())).To(ContainSubstring("ownerReferences"))
-				return true, dv, nil
+			It("should not remove the clones of other moves if a clone failed", func() {
+				vm, _ := newMovableVM()
+				addVirtualMachine(vm)
+				addSourceDataVolume(vm)
+				addClone(vm, "pvc1", cdiv1.Failed)
+
+				otherVM, _ := newMovableVM()
+				otherVM.UID = "other-vm-uid"
+				// cloned for another VM from the same namespace, and not a volume of this VM
+				addClone(otherVM, "other", cdiv1.Succeeded)
+				// a completed move of another VM with the same claim name
+				addClone(otherVM, "dv1", cdiv1.Succeeded)
+
+				var deleted []string
+				cdiClient.Fake.PrependReactor("delete", "datavolumes", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					deleted = append(deleted, action.(testing.DeleteAction).GetName())
+					return true, nil, nil
+				})
+				expectMoveFailed("a DataVolume named dv1 already exists in namespace tenant-b")
+
+				controller.Execute()
+
+				Expect(deleted).To(ConsistOf("pvc1"))
+				testutils.ExpectEvent(recorder, FailedMoveVirtualMachineReason)
 			})
 
-			vmInterface.EXPECT().Get(vm.ObjectMeta.Name, gomock.Any()).Return(vm, nil)
-			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil)
+			It("should cancel the move if the VM was started", func() {
+				vm, vmi := newMovableVM()
+				vmi.Status.Phase = v1.Running
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+				addSourceDataVolume(vm)
 
-			controller.Execute()
-		})
+				expectMoveFailed("the VM was started while it was moved")
 
-		It("should detect that it has nothing to do beside updating the status", func() {
-			vm, vmi := DefaultVirtualMachine(true)
+				controller.Execute()
 
-			addVirtualMachine(vm)
-			vmiFeeder.Add(vmi)
+				testutils.ExpectEvent(recorder, FailedMoveVirtualMachineReason)
+			})
 
-			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil)
+			It("should drop the MoveFailed condition once the VM is moved again", func() {
+				vm, _ := newMovableVM()
+				vm.Status.Conditions = []v1.VirtualMachineCondition{{Type: v1.VirtualMachineMoveFailed, Status: k8sv1.ConditionTrue}}
+				addVirtualMachine(vm)
+				addSourceDataVolume(vm)
 
-			controller.Execute()
+				cdiClient.Fake.PrependReactor("create", "datavolumes", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					return true, action.(testing.CreateAction).GetObject(), nil
+				})
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					Expect(virtcontroller.NewVirtualMachineConditionManager().HasCondition(obj.(*v1.VirtualMachine), v1.VirtualMachineMoveFailed)).To(BeFalse())
+				}).Return(vm, nil)
+
+				controller.Execute()
+			})
 		})
 
 		It("should add a fail condition if start up fails", func() {
@@ -1503,6 +2717,38 @@ var _ = Describe("VirtualMachine", func() {
 			controller.Execute()
 		})
 
+		It("should reflect why the VMI is paused in the paused condition", func() {
+			vm, vmi := DefaultVirtualMachine(true)
+			vm.Status.Conditions = append(vm.Status.Conditions, virtv1.VirtualMachineCondition{
+				Type:    virtv1.VirtualMachinePaused,
+				Status:  k8sv1.ConditionTrue,
+				Reason:  "PausedByUser",
+				Message: "VMI was paused by user",
+			})
+			addVirtualMachine(vm)
+
+			markAsReady(vmi)
+			vmi.Status.Conditions = append(vmi.Status.Conditions, virtv1.VirtualMachineInstanceCondition{
+				Type:    virtv1.VirtualMachineInstancePaused,
+				Status:  k8sv1.ConditionTrue,
+				Reason:  "PausedIOError",
+				Message: "VMI was paused, IO error",
+			})
+			vmiFeeder.Add(vmi)
+
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+				objVM := obj.(*v1.VirtualMachine)
+				Expect(objVM.Status.Conditions).To(HaveLen(2))
+				cond := virtcontroller.NewVirtualMachineConditionManager().
+					GetCondition(objVM, v1.VirtualMachinePaused)
+				Expect(cond).ToNot(BeNil())
+				Expect(cond.Reason).To(Equal("PausedIOError"))
+				Expect(cond.Message).To(Equal("VMI was paused, IO error"))
+			}).Return(vm, nil)
+
+			controller.Execute()
+		})
+
 		It("should remove paused condition", func() {
 			vm, vmi := DefaultVirtualMachine(true)
 			vm.Status.Conditions = append(vm.Status.Conditions, virtv1.VirtualMachineCondition{
@@ -1524,6 +2770,186 @@ var _ = Describe("VirtualMachine", func() {
 			controller.Execute()
 		})
 
+		Context("with guest defaults", func() {
+			updateClusterConfig := func(config v1.KubeVirtConfiguration) {
+				testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, &v1.KubeVirt{
+					Spec:   v1.KubeVirtSpec{Configuration: config},
+					Status: v1.KubeVirtStatus{Phase: v1.KubeVirtPhaseDeployed},
+				})
+			}
+
+			newVMWithDefaults := func() (*v1.VirtualMachine, *v1.VirtualMachineInstance) {
+				vm, vmi := DefaultVirtualMachine(true)
+				vm.Spec.Template.Spec.Domain.Machine = &v1.Machine{Type: "q35"}
+				guestdefaults.MarkApplied(&vm.Spec.Template.ObjectMeta, guestdefaults.MachineType)
+				vmi.Spec.Domain.Machine = &v1.Machine{Type: "q35"}
+				guestdefaults.MarkApplied(&vmi.ObjectMeta, guestdefaults.MachineType)
+				guestdefaults.MarkApplied(&vmi.ObjectMeta, guestdefaults.CPUModel)
+				return vm, vmi
+			}
+
+			It("should add the outdated condition if the cluster defaults changed", func() {
+				updateClusterConfig(v1.KubeVirtConfiguration{MachineType: "q35", CPUModel: "Haswell"})
+				vm, vmi := newVMWithDefaults()
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					objVM := obj.(*v1.VirtualMachine)
+					cond := virtcontroller.NewVirtualMachineConditionManager().
+						GetCondition(objVM, v1.VirtualMachineGuestDefaultsOutdated)
+					Expect(cond).ToNot(BeNil())
+					Expect(cond.Status).To(Equal(k8sv1.ConditionTrue))
+					Expect(cond.Message).To(HaveSuffix(": cpuModel"))
+				}).Return(vm, nil)
+
+				controller.Execute()
+			})
+
+			It("should remove the outdated condition if the defaults match again", func() {
+				updateClusterConfig(v1.KubeVirtConfiguration{MachineType: "q35"})
+				vm, vmi := newVMWithDefaults()
+				vm.Status.Conditions = append(vm.Status.Conditions, v1.VirtualMachineCondition{
+					Type:   v1.VirtualMachineGuestDefaultsOutdated,
+					Status: k8sv1.ConditionTrue,
+				})
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					objVM := obj.(*v1.VirtualMachine)
+					Expect(virtcontroller.NewVirtualMachineConditionManager().
+						HasCondition(objVM, v1.VirtualMachineGuestDefaultsOutdated)).To(BeFalse())
+				}).Return(vm, nil)
+
+				controller.Execute()
+			})
+
+			It("should restart the VMI if the restart strategy is configured", func() {
+				updateClusterConfig(v1.KubeVirtConfiguration{
+					MachineType:                 "q35",
+					CPUModel:                    "Haswell",
+					GuestDefaultsUpdateStrategy: v1.GuestDefaultsUpdateRestart,
+				})
+				vm, vmi := newVMWithDefaults()
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmiInterface.EXPECT().Delete(vmi.ObjectMeta.Name, gomock.Any()).Return(nil)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil)
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, GuestDefaultsUpdateReason)
+				testutils.ExpectEvent(recorder, SuccessfulDeleteVirtualMachineReason)
+			})
+
+			It("should delay the restart once the batch of restarts is used up", func() {
+				updateClusterConfig(v1.KubeVirtConfiguration{
+					MachineType:                 "q35",
+					CPUModel:                    "Haswell",
+					GuestDefaultsUpdateStrategy: v1.GuestDefaultsUpdateRestart,
+				})
+				vm, vmi := newVMWithDefaults()
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+				controller.guestDefaultsRestartBatch = time.Now()
+				controller.guestDefaultsRestartBatchCount = guestDefaultsRestartBatchSize
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil)
+
+				controller.Execute()
+
+				Expect(mockQueue.GetAddAfterEnqueueCount()).To(Equal(1))
+				Expect(recorder.Events).To(BeEmpty())
+			})
+
+			It("should update the machine type of the VM template before restarting the VMI", func() {
+				updateClusterConfig(v1.KubeVirtConfiguration{
+					MachineType:                 "pc-q35-rhel8.4.0",
+					GuestDefaultsUpdateStrategy: v1.GuestDefaultsUpdateRestart,
+				})
+				vm, vmi := newVMWithDefaults()
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().Update(gomock.Any()).Do(func(obj interface{}) {
+					objVM := obj.(*v1.VirtualMachine)
+					Expect(objVM.Spec.Template.Spec.Domain.Machine.Type).To(Equal("pc-q35-rhel8.4.0"))
+				}).Return(vm, nil)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil)
+
+				controller.Execute()
+			})
+
+			It("should update a deprecated machine type of the VM template", func() {
+				updateClusterConfig(v1.KubeVirtConfiguration{
+					MachineType:            "q35",
+					DeprecatedMachineTypes: []string{"pc-q35-rhel7.*"},
+				})
+				vm, vmi := DefaultVirtualMachine(true)
+				vm.Spec.Template.Spec.Domain.Machine = &v1.Machine{Type: "pc-q35-rhel7.6.0"}
+				vmi.Spec.Domain.Machine = &v1.Machine{Type: "pc-q35-rhel7.6.0"}
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				updatedVM := vm.DeepCopy()
+				updatedVM.ResourceVersion = "2"
+				updatedVM.Spec.Template.Spec.Domain.Machine.Type = "q35"
+				vmInterface.EXPECT().Update(gomock.Any()).Do(func(obj interface{}) {
+					objVM := obj.(*v1.VirtualMachine)
+					Expect(objVM.Spec.Template.Spec.Domain.Machine.Type).To(Equal("q35"))
+				}).Return(updatedVM, nil)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					objVM := obj.(*v1.VirtualMachine)
+					Expect(objVM.ResourceVersion).To(Equal("2"))
+					cond := virtcontroller.NewVirtualMachineConditionManager().
+						GetCondition(objVM, v1.VirtualMachineRestartRequired)
+					Expect(cond).ToNot(BeNil())
+				}).Return(updatedVM, nil)
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, MachineTypeUpdateReason)
+			})
+
+			It("should add the restart required condition if the machine type of the VMI differs", func() {
+				updateClusterConfig(v1.KubeVirtConfiguration{MachineType: "q35"})
+				vm, vmi := DefaultVirtualMachine(true)
+				vm.Spec.Template.Spec.Domain.Machine = &v1.Machine{Type: "q35"}
+				vmi.Spec.Domain.Machine = &v1.Machine{Type: "pc-q35-rhel7.6.0"}
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					objVM := obj.(*v1.VirtualMachine)
+					cond := virtcontroller.NewVirtualMachineConditionManager().
+						GetCondition(objVM, v1.VirtualMachineRestartRequired)
+					Expect(cond).ToNot(BeNil())
+					Expect(cond.Status).To(Equal(k8sv1.ConditionTrue))
+					Expect(cond.Reason).To(Equal(machineTypeChangedReason))
+				}).Return(vm, nil)
+
+				controller.Execute()
+			})
+
+			It("should not restart the VMI if the VM opts out", func() {
+				updateClusterConfig(v1.KubeVirtConfiguration{
+					MachineType:                 "q35",
+					CPUModel:                    "Haswell",
+					GuestDefaultsUpdateStrategy: v1.GuestDefaultsUpdateRestart,
+				})
+				vm, vmi := newVMWithDefaults()
+				vm.Annotations[v1.GuestDefaultsUpdateOptOutAnnotation] = "true"
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil)
+
+				controller.Execute()
+			})
+		})
+
 		It("should back off if a sync error occurs", func() {
 			vm, vmi := DefaultVirtualMachine(false)
 
@@ -1554,6 +2980,7 @@ var _ = Describe("VirtualMachine", func() {
 			annotations := map[string]string{"test": "test"}
 
 			vm.Status.PrintableStatus = v1.VirtualMachineStatusStarting
+			vm.Status.Availability = pendingStartAvailability()
 			addVirtualMachine(vm)
 
 			vmiInterface.EXPECT().Create(gomock.Any()).Do(func(obj interface{}) {
@@ -1569,6 +2996,7 @@ var _ = Describe("VirtualMachine", func() {
 			annotations := map[string]string{"kubevirt.io/ignitiondata": "test"}
 
 			vm.Status.PrintableStatus = v1.VirtualMachineStatusStarting
+			vm.Status.Availability = pendingStartAvailability()
 			addVirtualMachine(vm)
 
 			vmiInterface.EXPECT().Create(gomock.Any()).Do(func(obj interface{}) {
@@ -1584,6 +3012,7 @@ var _ = Describe("VirtualMachine", func() {
 			annotations := map[string]string{"cluster-autoscaler.kubernetes.io/safe-to-evict": "true"}
 
 			vm.Status.PrintableStatus = v1.VirtualMachineStatusStarting
+			vm.Status.Availability = pendingStartAvailability()
 			addVirtualMachine(vm)
 
 			vmiInterface.EXPECT().Create(gomock.Any()).Do(func(obj interface{}) {
@@ -2202,3 +3631,9 @@ func DefaultVirtualMachineWithNames(started bool, vmName string, vmiName string)
 func DefaultVirtualMachine(started bool) (*v1.VirtualMachine, *v1.VirtualMachineInstance) {
 	return DefaultVirtualMachineWithNames(started, "testvmi", "testvmi")
 }
+
+// pendingStartAvailability is the availability of a VM whose start was requested, but whose guest is not ready yet
+func pendingStartAvailability() *v1.VirtualMachineAvailabilityStatus {
+	now := metav1.Now()
+	return &v1.VirtualMachineAvailabilityStatus{StartRequestTime: &now}
+}
@@ -28,7 +28,9 @@ import (
 	"strings"
 	"time"
 
+	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -38,13 +40,17 @@ import (
 	"k8s.io/client-go/util/workqueue"
 
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/topology"
+	"kubevirt.io/kubevirt/pkg/virt-controller/watch/vsock"
 
 	virtv1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1beta1"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/network/istio"
+	"kubevirt.io/kubevirt/pkg/util"
 	kubevirttypes "kubevirt.io/kubevirt/pkg/util/types"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
 )
 
@@ -95,18 +101,25 @@ const (
 	FailedGuaranteePodResourcesReason = "FailedGuaranteeResources"
 	// FailedGatherhingClusterTopologyHints is added if the cluster topology hints can't be collected for a VMI by virt-controller
 	FailedGatherhingClusterTopologyHints = "FailedGatherhingClusterTopologyHints"
+	// FailedAllocatingVSOCKCIDReason is added if no CID can be allocated for the VSOCK device of a VMI
+	FailedAllocatingVSOCKCIDReason = "FailedAllocatingVSOCKCID"
 	// FailedPvcNotFoundReason is added in an event
 	// when a PVC for a volume was not found.
 	FailedPvcNotFoundReason = "FailedPvcNotFound"
 	// FailedDataVolumeNotFoundReason is added in an event
 	// when a DataVolume for a volume was not found.
 	FailedDataVolumeNotFoundReason = "FailedDataVolumeNotFound"
+	// FailedOverlayPVCInUseReason is added in a vmi controller condition
+	// when the overlay PVC of a containerDisk is used by another VMI.
+	FailedOverlayPVCInUseReason = "FailedOverlayPVCInUse"
 	// SuccessfulMigrationReason is added when a migration attempt completes successfully
 	SuccessfulMigrationReason = "SuccessfulMigration"
 	// FailedMigrationReason is added when a migration attempt fails
 	FailedMigrationReason = "FailedMigration"
 	// SuccessfulAbortMigrationReason is added when an attempt to abort migration completes successfully
 	SuccessfulAbortMigrationReason = "SuccessfulAbortMigration"
+	// AbortedMigrationReason is added when a migration is aborted because it was deleted
+	AbortedMigrationReason = "AbortedMigration"
 	// FailedAbortMigrationReason is added when an attempt to abort migration fails
 	FailedAbortMigrationReason = "FailedAbortMigration"
 	// MissingAttachmentPodReason is set when we have a hotplugged volume, but the attachment pod is missing
@@ -124,6 +137,10 @@ const (
 
 const failedToRenderLaunchManifestErrFormat = "failed to render launch manifest: %v"
 
+// multusNetworkStatusAnnotation is the pod annotation in which Multus reports the attached networks.
+// Older Multus versions use networkv1.NetworkStatusAnnot instead.
+const multusNetworkStatusAnnotation = "k8s.v1.cni.cncf.io/network-status"
+
 func NewVMIController(templateService services.TemplateService,
 	vmiInformer cache.SharedIndexInformer,
 	vmInformer cache.SharedIndexInformer,
@@ -133,6 +150,7 @@ func NewVMIController(templateService services.TemplateService,
 	clientset kubecli.KubevirtClient,
 	dataVolumeInformer cache.SharedIndexInformer,
 	topologyHinter topology.Hinter,
+	clusterConfig *virtconfig.ClusterConfig,
 ) *VMIController {
 
 	c := &VMIController{
@@ -148,6 +166,8 @@ func NewVMIController(templateService services.TemplateService,
 		vmiExpectations:    controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
 		dataVolumeInformer: dataVolumeInformer,
 		topologyHinter:     topologyHinter,
+		clusterConfig:      clusterConfig,
+		cidsMap:            vsock.NewCIDsMap(),
 	}
 
 	c.vmiInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -168,6 +188,10 @@ func NewVMIController(templateService services.TemplateService,
 		UpdateFunc: c.updateDataVolume,
 	})
 
+	c.pvcInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: c.updatePVC,
+	})
+
 	return c
 }
 
@@ -198,10 +222,12 @@ type VMIController struct {
 	podInformer        cache.SharedIndexInformer
 	pvcInformer        cache.SharedIndexInformer
 	topologyHinter     topology.Hinter
+	clusterConfig      *virtconfig.ClusterConfig
 	recorder           record.EventRecorder
 	podExpectations    *controller.UIDTrackingControllerExpectations
 	vmiExpectations    *controller.UIDTrackingControllerExpectations
 	dataVolumeInformer cache.SharedIndexInformer
+	cidsMap            vsock.Allocator
 }
 
 func (c *VMIController) Run(threadiness int, stopCh <-chan struct{}) {
@@ -212,6 +238,13 @@ func (c *VMIController) Run(threadiness int, stopCh <-chan struct{}) {
 	// Wait for cache sync before we start the pod controller
 	cache.WaitForCacheSync(stopCh, c.vmInformer.HasSynced, c.vmiInformer.HasSynced, c.podInformer.HasSynced, c.dataVolumeInformer.HasSynced)
 
+	// Sync the CIDs of the VSOCK devices which were allocated before
+	var vmis []*virtv1.VirtualMachineInstance
+	for _, obj := range c.vmiInformer.GetStore().List() {
+		vmis = append(vmis, obj.(*virtv1.VirtualMachineInstance))
+	}
+	c.cidsMap.Sync(vmis)
+
 	// Start the actual work
 	for i := 0; i < threadiness; i++ {
 		go wait.Until(c.runWorker, time.Second, stopCh)
@@ -407,6 +440,7 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 	}
 
 	c.syncReadyConditionFromPod(vmiCopy, pod)
+	c.syncDataVolumesReadyCondition(vmiCopy, dataVolumes)
 
 	switch {
 	case vmi.IsUnprocessed():
@@ -424,6 +458,14 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 					vmiCopy.Status.TopologyHints = topologyHints
 				}
 			}
+			if vmi.Status.VSOCKCID == nil && util.IsAutoAttachVSOCK(vmi) {
+				cid, err := c.cidsMap.Allocate(vmi)
+				if err != nil {
+					c.recorder.Eventf(vmi, k8sv1.EventTypeWarning, FailedAllocatingVSOCKCIDReason, err.Error())
+					return &syncErrorImpl{err, FailedAllocatingVSOCKCIDReason}
+				}
+				vmiCopy.Status.VSOCKCID = &cid
+			}
 			if hasWffcDataVolume {
 				condition := virtv1.VirtualMachineInstanceCondition{
 					Type:   virtv1.VirtualMachineInstanceProvisioning,
@@ -482,6 +524,8 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 				conditionManager.RemoveCondition(vmiCopy, virtv1.VirtualMachineInstanceConditionType(k8sv1.PodScheduled))
 			}
 
+			setStartupTimestampsFromPod(vmiCopy, pod)
+
 			if imageErr := checkForContainerImageError(pod); imageErr != nil {
 				// only overwrite syncErr if imageErr != nil
 				syncErr = imageErr
@@ -518,6 +562,14 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 					vmiCopy.Labels = map[string]string{}
 				}
 				vmiCopy.ObjectMeta.Labels[virtv1.NodeNameLabel] = pod.Spec.NodeName
+				// virt-handler has to keep the ports of the proxy out of the masquerade binding,
+				// also when the proxy was injected through the namespace instead of the VMI annotation
+				if istio.ProxyInjected(pod) && !istio.ProxyInjectionEnabled(vmiCopy) {
+					if vmiCopy.Annotations == nil {
+						vmiCopy.Annotations = map[string]string{}
+					}
+					vmiCopy.Annotations[istio.PROXY_INJECTED_ANNOTATION] = "true"
+				}
 				vmiCopy.Status.NodeName = pod.Spec.NodeName
 
 				// Set the VMI migration transport now before the VMI can be migrated
@@ -560,6 +612,7 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 		}
 
 		c.updateVolumeStatus(vmiCopy, pod)
+		c.updateMultusInterfaceIPs(vmiCopy, pod)
 
 		var foundImage string
 		for _, container := range pod.Spec.Containers {
@@ -675,6 +728,22 @@ func preparePatch(oldVMI, newVMI *virtv1.VirtualMachineInstance) ([]byte, error)
 		log.Log.V(3).Object(oldVMI).Infof("Patching VMI activePods")
 	}
 
+	if !reflect.DeepEqual(newVMI.Status.Interfaces, oldVMI.Status.Interfaces) {
+		newInterfaces, err := json.Marshal(newVMI.Status.Interfaces)
+		if err != nil {
+			return nil, err
+		}
+		oldInterfaces, err := json.Marshal(oldVMI.Status.Interfaces)
+		if err != nil {
+			return nil, err
+		}
+
+		patchOps = append(patchOps, fmt.Sprintf(`{ "op": "test", "path": "/status/interfaces", "value": %s }`, string(oldInterfaces)))
+		patchOps = append(patchOps, fmt.Sprintf(`{ "op": "replace", "path": "/status/interfaces", "value": %s }`, string(newInterfaces)))
+
+		log.Log.V(3).Object(oldVMI).Infof("Patching VMI interfaces")
+	}
+
 	if newVMI.Status.LauncherContainerImageVersion != oldVMI.Status.LauncherContainerImageVersion {
 		if oldVMI.Status.LauncherContainerImageVersion == "" {
 			patchOps = append(patchOps, fmt.Sprintf(`{ "op": "add", "path": "/status/launcherContainerImageVersion", "value": "%s" }`, newVMI.Status.LauncherContainerImageVersion))
@@ -710,6 +779,61 @@ func preparePatch(oldVMI, newVMI *virtv1.VirtualMachineInstance) ([]byte, error)
 	return controller.GeneratePatchBytes(patchOps), nil
 }
 
+// syncDataVolumesReadyCondition reports whether all DataVolumes of the VMI succeeded. VMIs without
+// DataVolumes don't get the condition.
+func (c *VMIController) syncDataVolumesReadyCondition(vmi *virtv1.VirtualMachineInstance, dataVolumes []*cdiv1.DataVolume) {
+	conditionManager := controller.NewVirtualMachineInstanceConditionManager()
+
+	dataVolumeNames := []string{}
+	for _, volume := range vmi.Spec.Volumes {
+		if name := c.getDataVolumeName(vmi.Namespace, volume); name != nil {
+			dataVolumeNames = append(dataVolumeNames, *name)
+		}
+	}
+	if len(dataVolumeNames) == 0 {
+		if conditionManager.HasCondition(vmi, virtv1.VirtualMachineInstanceDataVolumesReady) {
+			conditionManager.RemoveCondition(vmi, virtv1.VirtualMachineInstanceDataVolumesReady)
+		}
+		return
+	}
+
+	now := v1.Now()
+	condition := &virtv1.VirtualMachineInstanceCondition{
+		Type:               virtv1.VirtualMachineInstanceDataVolumesReady,
+		Status:             k8sv1.ConditionTrue,
+		Reason:             virtv1.DataVolumesReadyReason,
+		LastProbeTime:      now,
+		LastTransitionTime: now,
+	}
+	for _, name := range dataVolumeNames {
+		var dataVolume *cdiv1.DataVolume
+		for _, dv := range dataVolumes {
+			if dv.Name == name {
+				dataVolume = dv
+				break
+			}
+		}
+		if dataVolume == nil {
+			condition.Status = k8sv1.ConditionFalse
+			condition.Reason = virtv1.DataVolumesNotReadyReason
+			condition.Message = fmt.Sprintf("DataVolume %s does not exist", name)
+			continue
+		}
+		if dataVolume.Status.Phase == cdiv1.Failed {
+			condition.Status = k8sv1.ConditionFalse
+			condition.Reason = virtv1.DataVolumeFailedReason
+			condition.Message = fmt.Sprintf("DataVolume %s failed", name)
+			break
+		}
+		if dataVolume.Status.Phase != cdiv1.Succeeded && condition.Status == k8sv1.ConditionTrue {
+			condition.Status = k8sv1.ConditionFalse
+			condition.Reason = virtv1.DataVolumesNotReadyReason
+			condition.Message = fmt.Sprintf("DataVolume %s is in phase %s", name, dataVolume.Status.Phase)
+		}
+	}
+	conditionManager.UpdateCondition(vmi, condition)
+}
+
 func (c *VMIController) syncReadyConditionFromPod(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod) {
 	conditionManager := controller.NewVirtualMachineInstanceConditionManager()
 
@@ -790,6 +914,37 @@ func checkForContainerImageError(pod *k8sv1.Pod) syncError {
 	return nil
 }
 
+// setStartupTimestampsFromPod records when the pod was scheduled and when its compute container started.
+// Timestamps which were already recorded are kept.
+func setStartupTimestampsFromPod(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod) {
+	timestamps := vmi.Status.StartupTimestamps
+	if timestamps == nil {
+		timestamps = &virtv1.VirtualMachineInstanceStartupTimestamps{}
+	}
+
+	if timestamps.PodScheduled == nil {
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == k8sv1.PodScheduled && condition.Status == k8sv1.ConditionTrue && !condition.LastTransitionTime.IsZero() {
+				scheduled := condition.LastTransitionTime
+				timestamps.PodScheduled = &scheduled
+			}
+		}
+	}
+
+	if timestamps.ContainerStarted == nil {
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.Name == "compute" && containerStatus.State.Running != nil && !containerStatus.State.Running.StartedAt.IsZero() {
+				started := containerStatus.State.Running.StartedAt
+				timestamps.ContainerStarted = &started
+			}
+		}
+	}
+
+	if timestamps.PodScheduled != nil || timestamps.ContainerStarted != nil {
+		vmi.Status.StartupTimestamps = timestamps
+	}
+}
+
 // isPodReady treats the pod as ready to be handed over to virt-handler, as soon as all pods except
 // the compute pod are ready.
 func isPodReady(pod *k8sv1.Pod) bool {
@@ -805,7 +960,7 @@ func isPodReady(pod *k8sv1.Pod) bool {
 			if containerStatus.State.Running == nil {
 				return false
 			}
-		} else if containerStatus.Name != "istio-proxy" && containerStatus.Ready == false {
+		} else if containerStatus.Name != istio.ProxyContainerName && containerStatus.Ready == false {
 			// When using istio the istio-proxy container will not be ready
 			// until there is a service pointing to this pod.
 			// We need to start the VM anyway
@@ -910,12 +1065,19 @@ func (c *VMIController) sync(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod,
 			log.Log.V(3).Object(vmi).Infof("Delaying pod creation until topology hints are set")
 			return nil
 		}
+		if vmi.Status.VSOCKCID == nil && util.IsAutoAttachVSOCK(vmi) {
+			log.Log.V(3).Object(vmi).Infof("Delaying pod creation until the VSOCK CID is set")
+			return nil
+		}
 
 		// ensure that all dataVolumes associated with the VMI are ready before creating the pod
 		if !dataVolumesReady {
 			log.Log.V(3).Object(vmi).Infof("Delaying pod creation while DataVolume populates")
 			return nil
 		}
+		if err := c.checkOverlayClaimsNotInUse(vmi); err != nil {
+			return &syncErrorImpl{err, FailedOverlayPVCInUseReason}
+		}
 		var templatePod *k8sv1.Pod
 		var err error
 		if isWaitForFirstConsumer {
@@ -1066,6 +1228,29 @@ func (c *VMIController) updateDataVolume(old, cur interface{}) {
 		c.enqueueVirtualMachine(vmi)
 	}
 }
+
+// updatePVC enqueues the vmis of a PVC whose capacity changed, to report the new capacity in their volume status
+func (c *VMIController) updatePVC(old, cur interface{}) {
+	curPVC := cur.(*k8sv1.PersistentVolumeClaim)
+	oldPVC := old.(*k8sv1.PersistentVolumeClaim)
+	if curPVC.ResourceVersion == oldPVC.ResourceVersion {
+		return
+	}
+	if equality.Semantic.DeepEqual(curPVC.Status.Capacity, oldPVC.Status.Capacity) {
+		return
+	}
+
+	vmis, err := c.listVMIsMatchingPVC(curPVC.Namespace, curPVC.Name)
+	if err != nil {
+		log.Log.V(4).Object(curPVC).Errorf("Error encountered during pvc update: %v", err)
+		return
+	}
+	for _, vmi := range vmis {
+		log.Log.V(4).Object(curPVC).Infof("PVC capacity updated for vmi %s", vmi.Name)
+		c.enqueueVirtualMachine(vmi)
+	}
+}
+
 func (c *VMIController) deleteDataVolume(obj interface{}) {
 	dataVolume, ok := obj.(*cdiv1.DataVolume)
 	// When a delete is dropped, the relist will notice a dataVolume in the store not
@@ -1219,6 +1404,7 @@ func (c *VMIController) deleteVirtualMachineInstance(obj interface{}) {
 			return
 		}
 	}
+	c.cidsMap.Remove(controller.VirtualMachineInstanceKey(vmi))
 	c.lowerVMIExpectation(vmi)
 	c.enqueueVirtualMachine(vmi)
 }
@@ -1283,6 +1469,51 @@ func (c *VMIController) resolveControllerRef(namespace string, controllerRef *v1
 	return vmi.(*virtv1.VirtualMachineInstance)
 }
 
+// takes a namespace and returns all VMIs from the vmi cache which use the PVC in one of their volumes
+func (c *VMIController) listVMIsMatchingPVC(namespace string, pvcName string) ([]*virtv1.VirtualMachineInstance, error) {
+	objs, err := c.vmiInformer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
+	if err != nil {
+		return nil, err
+	}
+	vmis := []*virtv1.VirtualMachineInstance{}
+	for _, obj := range objs {
+		vmi := obj.(*virtv1.VirtualMachineInstance)
+		for i := range vmi.Spec.Volumes {
+			if kubevirttypes.PVCNameFromVirtVolume(&vmi.Spec.Volumes[i]) == pvcName ||
+				kubevirttypes.ContainerDiskOverlayClaimName(&vmi.Spec.Volumes[i]) == pvcName {
+				vmis = append(vmis, vmi)
+				break
+			}
+		}
+	}
+	return vmis, nil
+}
+
+// checkOverlayClaimsNotInUse returns an error if another VMI keeps the overlay of a containerDisk on one of the
+// overlay PVCs of the VMI. Of two VMIs which both wait for their pod, the older one gets the PVC.
+func (c *VMIController) checkOverlayClaimsNotInUse(vmi *virtv1.VirtualMachineInstance) error {
+	for i := range vmi.Spec.Volumes {
+		claimName := kubevirttypes.ContainerDiskOverlayClaimName(&vmi.Spec.Volumes[i])
+		if claimName == "" {
+			continue
+		}
+		vmis, err := c.listVMIsMatchingPVC(vmi.Namespace, claimName)
+		if err != nil {
+			return err
+		}
+		for _, other := range vmis {
+			if other.UID == vmi.UID || other.IsFinal() {
+				continue
+			}
+			if !other.IsUnprocessed() || other.CreationTimestamp.Before(&vmi.CreationTimestamp) ||
+				(other.CreationTimestamp.Equal(&vmi.CreationTimestamp) && other.Name < vmi.Name) {
+				return fmt.Errorf("the overlay PVC %s of volume %s is in use by VMI %s", claimName, vmi.Spec.Volumes[i].Name, other.Name)
+			}
+		}
+	}
+	return nil
+}
+
 // takes a namespace and returns all Pods from the pod cache which run in this namespace
 func (c *VMIController) listVMIsMatchingDataVolume(namespace string, dataVolumeName string) ([]*virtv1.VirtualMachineInstance, error) {
 	objs, err := c.vmiInformer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
@@ -1451,7 +1682,12 @@ func getHotplugVolumes(vmi *virtv1.VirtualMachineInstance, virtlauncherPod *k8sv
 		podVolumeMap[podVolume.Name] = podVolume
 	}
 	for _, vmiVolume := range vmiVolumes {
-		if _, ok := podVolumeMap[vmiVolume.Name]; !ok && (vmiVolume.DataVolume != nil || vmiVolume.PersistentVolumeClaim != nil) {
+		if kubevirttypes.PVCNameFromVirtVolume(&vmiVolume) == "" {
+			continue
+		}
+		// A volume which is backed by a different claim than in the pod got swapped, like the media of a CD-ROM
+		if podVolume, ok := podVolumeMap[vmiVolume.Name]; !ok || podVolume.PersistentVolumeClaim == nil ||
+			podVolume.PersistentVolumeClaim.ClaimName != kubevirttypes.PVCNameFromVirtVolume(&vmiVolume) {
 			hotplugVolumes = append(hotplugVolumes, vmiVolume.DeepCopy())
 		}
 	}
@@ -1600,7 +1836,9 @@ func (c *VMIController) podVolumesMatchesReadyVolumes(attachmentPod *k8sv1.Pod,
 		}
 	}
 	for _, volume := range volumes {
-		delete(podVolumeMap, volume.Name)
+		if podVolume, ok := podVolumeMap[volume.Name]; ok && podVolume.PersistentVolumeClaim.ClaimName == kubevirttypes.PVCNameFromVirtVolume(volume) {
+			delete(podVolumeMap, volume.Name)
+		}
 	}
 	return len(podVolumeMap) == 0
 }
@@ -1644,12 +1882,7 @@ func (c *VMIController) triggerHotplugPopulation(volume *virtv1.Volume, vmi *vir
 }
 
 func (c *VMIController) volumeReadyToAttachToNode(namespace string, volume virtv1.Volume, dataVolumes []*cdiv1.DataVolume) (bool, bool, error) {
-	name := ""
-	if volume.DataVolume != nil {
-		name = volume.DataVolume.Name
-	} else if volume.PersistentVolumeClaim != nil {
-		name = volume.PersistentVolumeClaim.ClaimName
-	}
+	name := kubevirttypes.PVCNameFromVirtVolume(&volume)
 
 	dataVolumeFunc := dataVolumeByNameFunc(c.dataVolumeInformer, dataVolumes)
 
@@ -1855,6 +2088,53 @@ func (c *VMIController) deleteOrphanedAttachmentPods(vmi *virtv1.VirtualMachineI
 	return nil
 }
 
+// updateMultusInterfaceIPs reports the IPs which Multus assigned on request to the interfaces of the
+// VMI, as long as the guest agent does not report any. They are read from the network-status
+// annotation of the virt-launcher pod.
+func (c *VMIController) updateMultusInterfaceIPs(vmi *virtv1.VirtualMachineInstance, virtlauncherPod *k8sv1.Pod) {
+	podInterfaceNames := services.MultusPodInterfaceNames(vmi)
+	networksByName := map[string]virtv1.Network{}
+	for _, network := range vmi.Spec.Networks {
+		networksByName[network.Name] = network
+	}
+
+	var networkStatus []networkv1.NetworkStatus
+	for i := range vmi.Status.Interfaces {
+		iface := &vmi.Status.Interfaces[i]
+		podInterfaceName, isMultus := podInterfaceNames[iface.Name]
+		if !isMultus || len(iface.IPs) > 0 || !hasMultusIPRequest(networksByName[iface.Name]) {
+			continue
+		}
+
+		if networkStatus == nil {
+			annotation, exists := virtlauncherPod.Annotations[multusNetworkStatusAnnotation]
+			if !exists {
+				annotation, exists = virtlauncherPod.Annotations[networkv1.NetworkStatusAnnot]
+			}
+			if !exists {
+				return
+			}
+			if err := json.Unmarshal([]byte(annotation), &networkStatus); err != nil {
+				log.Log.Object(vmi).Reason(err).Errorf("Failed to parse the network status of pod %s", virtlauncherPod.Name)
+				return
+			}
+		}
+
+		for _, status := range networkStatus {
+			if status.Interface == podInterfaceName && len(status.IPs) > 0 {
+				iface.IP = status.IPs[0]
+				iface.IPs = status.IPs
+				break
+			}
+		}
+	}
+}
+
+// hasMultusIPRequest reports if static IP addresses or an IP pool are requested for a Multus network
+func hasMultusIPRequest(network virtv1.Network) bool {
+	return network.Multus != nil && (len(network.Multus.IPAddresses) > 0 || network.Multus.IPPool != "")
+}
+
 func (c *VMIController) updateVolumeStatus(vmi *virtv1.VirtualMachineInstance, virtlauncherPod *k8sv1.Pod) error {
 	oldStatus := vmi.Status.DeepCopy().VolumeStatus
 	oldStatusMap := make(map[string]virtv1.VolumeStatus)
@@ -1911,13 +2191,22 @@ func (c *VMIController) updateVolumeStatus(vmi *virtv1.VirtualMachineInstance, v
 			}
 		}
 
-		if volume.VolumeSource.PersistentVolumeClaim != nil || volume.VolumeSource.DataVolume != nil {
+		if volume.VolumeSource.MemoryDump != nil && status.MemoryDumpVolume == nil {
+			status.MemoryDumpVolume = &virtv1.DomainMemoryDumpInfo{
+				ClaimName: volume.VolumeSource.MemoryDump.ClaimName,
+			}
+		}
+
+		if volume.VolumeSource.PersistentVolumeClaim != nil || volume.VolumeSource.DataVolume != nil ||
+			kubevirttypes.ContainerDiskOverlayClaimName(&vmi.Spec.Volumes[i]) != "" {
 
 			var pvcName string
 			if volume.VolumeSource.PersistentVolumeClaim != nil {
 				pvcName = volume.VolumeSource.PersistentVolumeClaim.ClaimName
 			} else if volume.VolumeSource.DataVolume != nil {
 				pvcName = volume.VolumeSource.DataVolume.Name
+			} else {
+				pvcName = kubevirttypes.ContainerDiskOverlayClaimName(&vmi.Spec.Volumes[i])
 			}
 
 			pvcInterface, pvcExists, _ := c.pvcInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", vmi.Namespace, pvcName))
@@ -1928,6 +2217,10 @@ func (c *VMIController) updateVolumeStatus(vmi *virtv1.VirtualMachineInstance, v
 					VolumeMode:   pvc.Spec.VolumeMode,
 					Capacity:     pvc.Status.Capacity,
 					Preallocated: kubevirttypes.IsPreallocated(pvc.ObjectMeta.Annotations),
+					CacheMode:    c.clusterConfig.GetDiskCacheMode(pvc.Spec.StorageClassName),
+				}
+				if pvc.Spec.VolumeMode == nil || *pvc.Spec.VolumeMode == k8sv1.PersistentVolumeFilesystem {
+					status.PersistentVolumeClaimInfo.FilesystemOverhead = c.clusterConfig.GetFilesystemOverhead(pvc.Spec.StorageClassName)
 				}
 			}
 		}
@@ -1976,14 +2269,7 @@ func (c *VMIController) findAttachmentPodByVolumeName(volumeName string, attachm
 }
 
 func (c *VMIController) getVolumePhaseMessageReason(volume *virtv1.Volume, namespace string) (virtv1.VolumePhase, string, string) {
-	claimName := ""
-	if volume.DataVolume != nil {
-		// Using fact that PVC name = DV name.
-		claimName = volume.DataVolume.Name
-	}
-	if volume.PersistentVolumeClaim != nil {
-		claimName = volume.PersistentVolumeClaim.ClaimName
-	}
+	claimName := kubevirttypes.PVCNameFromVirtVolume(volume)
 	pvcInterface, pvcExists, _ := c.pvcInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", namespace, claimName))
 	if !pvcExists {
 		return virtv1.VolumePending, FailedPvcNotFoundReason, "Unable to determine PVC name"
@@ -54,6 +54,7 @@ import (
 	fakenetworkclient "kubevirt.io/client-go/generated/network-attachment-definition-client/clientset/versioned/fake"
 	"kubevirt.io/client-go/kubecli"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1beta1"
+	"kubevirt.io/kubevirt/pkg/network/istio"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
 )
@@ -221,6 +222,7 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			virtClient,
 			dataVolumeInformer,
 			topology.NewTopologyHinter(&cache.FakeCustomStore{}, &cache.FakeCustomStore{}, "amd64", nil),
+			config,
 		)
 		// Wrap our workqueue to have a way to detect when we are done processing updates
 		mockQueue = testutils.NewMockWorkQueue(controller.Queue)
@@ -277,6 +279,7 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 
 			dataVolume := NewDv(vmi.Namespace, "test1", cdiv1.Succeeded)
 
+			setDataVolumesReadyCondition(vmi, k8sv1.ConditionTrue, v1.DataVolumesReadyReason)
 			addVirtualMachine(vmi)
 			dataVolumeFeeder.Add(dataVolume)
 			shouldExpectPodCreation(vmi.UID)
@@ -389,6 +392,7 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			// be in available
 			pvcInformer.GetIndexer().Add(dvPVC)
 
+			setDataVolumesReadyCondition(vmi, k8sv1.ConditionTrue, v1.DataVolumesReadyReason)
 			addVirtualMachine(vmi)
 			podFeeder.Add(pod)
 			addActivePods(vmi, pod.UID, "")
@@ -488,11 +492,67 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			dvPVC.Status.Phase = k8sv1.ClaimPending
 			pvcInformer.GetIndexer().Add(dvPVC)
 
+			setDataVolumesReadyCondition(vmi, k8sv1.ConditionFalse, v1.DataVolumesNotReadyReason)
 			addVirtualMachine(vmi)
 			dataVolumeFeeder.Add(dataVolume)
 
 			controller.Execute()
 		})
+
+		table.DescribeTable("should set the DataVolumesReady condition", func(phase cdiv1.DataVolumePhase, expectedStatus k8sv1.ConditionStatus, expectedReason string) {
+			vmi := NewPendingVirtualMachine("testvmi")
+
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name:         "test1",
+				VolumeSource: dvVolumeSource,
+			})
+
+			dataVolume := NewDv(vmi.Namespace, "test1", phase)
+			dvPVC := NewPvcWithOwner(vmi.Namespace, "test1", dataVolume.Name, &controllerOf)
+			if phase != cdiv1.Succeeded {
+				dvPVC.Status.Phase = k8sv1.ClaimPending
+			}
+			pvcInformer.GetIndexer().Add(dvPVC)
+
+			addVirtualMachine(vmi)
+			dataVolumeFeeder.Add(dataVolume)
+			if phase == cdiv1.Succeeded {
+				shouldExpectPodCreation(vmi.UID)
+			}
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachineInstance).Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras,
+					Fields{
+						"Type":   Equal(v1.VirtualMachineInstanceDataVolumesReady),
+						"Status": Equal(expectedStatus),
+						"Reason": Equal(expectedReason),
+					})))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+			if phase == cdiv1.Succeeded {
+				testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+			}
+		},
+			table.Entry("to true if all DataVolumes succeeded", cdiv1.Succeeded, k8sv1.ConditionTrue, v1.DataVolumesReadyReason),
+			table.Entry("to false if a DataVolume is still being imported", cdiv1.ImportInProgress, k8sv1.ConditionFalse, v1.DataVolumesNotReadyReason),
+			table.Entry("to false if a DataVolume failed", cdiv1.Failed, k8sv1.ConditionFalse, v1.DataVolumeFailedReason),
+		)
+
+		It("should remove the DataVolumesReady condition if the VMI has no DataVolumes", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			setDataVolumesReadyCondition(vmi, k8sv1.ConditionTrue, v1.DataVolumesReadyReason)
+
+			addVirtualMachine(vmi)
+			shouldExpectPodCreation(vmi.UID)
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachineInstance).Status.Conditions).ToNot(ContainElement(MatchFields(IgnoreExtras,
+					Fields{"Type": Equal(v1.VirtualMachineInstanceDataVolumesReady)})))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+		})
 	})
 
 	Context("On valid VirtualMachineInstance given with PVC source, ownedRef of DataVolume", func() {
@@ -517,6 +577,7 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 
 			dataVolume := NewDv(vmi.Namespace, "test1", cdiv1.Succeeded)
 
+			setDataVolumesReadyCondition(vmi, k8sv1.ConditionTrue, v1.DataVolumesReadyReason)
 			addVirtualMachine(vmi)
 			dataVolumeFeeder.Add(dataVolume)
 			shouldExpectPodCreation(vmi.UID)
@@ -624,6 +685,7 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			// be in available
 			pvcInformer.GetIndexer().Add(dvPVC)
 
+			setDataVolumesReadyCondition(vmi, k8sv1.ConditionTrue, v1.DataVolumesReadyReason)
 			addVirtualMachine(vmi)
 			podFeeder.Add(pod)
 			addActivePods(vmi, pod.UID, "")
@@ -653,6 +715,7 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 
 			dataVolume := NewDv(vmi.Namespace, "test1", cdiv1.Pending)
 
+			setDataVolumesReadyCondition(vmi, k8sv1.ConditionFalse, v1.DataVolumesNotReadyReason)
 			addVirtualMachine(vmi)
 			dataVolumeFeeder.Add(dataVolume)
 
@@ -801,6 +864,21 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 
 			controller.Execute()
 		})
+		It("should allocate a VSOCK CID before it creates the pod", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			autoattachVSOCK := true
+			vmi.Spec.Domain.Devices.AutoattachVSOCK = &autoattachVSOCK
+
+			addVirtualMachine(vmi)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachineInstance).Status.VSOCKCID).ToNot(BeNil())
+			}).Return(vmi, nil)
+
+			controller.Execute()
+
+			Expect(kubeClient.Actions()).To(BeEmpty())
+		})
 		It("should set an error condition if creating the pod fails", func() {
 			vmi := NewPendingVirtualMachine("testvmi")
 
@@ -823,6 +901,52 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 
 			testutils.ExpectEvent(recorder, FailedCreatePodReason)
 		})
+		table.DescribeTable("should only create the pod if no other VMI uses its containerDisk overlay PVC", func(otherPhase v1.VirtualMachineInstancePhase, expectPod bool) {
+			overlayVolume := v1.Volume{
+				Name: "containerdisk",
+				VolumeSource: v1.VolumeSource{
+					ContainerDisk: &v1.ContainerDiskSource{
+						Image:   "my-image",
+						Overlay: &v1.ContainerDiskOverlay{ClaimName: "overlay-pvc"},
+					},
+				},
+			}
+			other := NewPendingVirtualMachine("othervmi")
+			other.UID = "other-uid"
+			other.Status.Phase = otherPhase
+			other.Spec.Volumes = append(other.Spec.Volumes, overlayVolume)
+			Expect(vmiInformer.GetIndexer().Add(other)).To(Succeed())
+
+			vmi := NewPendingVirtualMachine("testvmi")
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, overlayVolume)
+			pvcInformer.GetIndexer().Add(NewPvc(vmi.Namespace, "overlay-pvc"))
+			addVirtualMachine(vmi)
+
+			if expectPod {
+				shouldExpectPodCreation(vmi.UID)
+			} else {
+				vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+					Expect(arg.(*v1.VirtualMachineInstance).Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras,
+						Fields{
+							"Type":    Equal(v1.VirtualMachineInstanceSynchronized),
+							"Status":  Equal(k8sv1.ConditionFalse),
+							"Reason":  Equal(FailedOverlayPVCInUseReason),
+							"Message": Equal("the overlay PVC overlay-pvc of volume containerdisk is in use by VMI othervmi"),
+						})))
+				}).Return(vmi, nil)
+			}
+
+			controller.Execute()
+
+			if expectPod {
+				testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+			} else {
+				Expect(kubeClient.Actions()).To(BeEmpty())
+			}
+		},
+			table.Entry("and not while another VMI runs with it", v1.Running, false),
+			table.Entry("and after the other VMI stopped", v1.Succeeded, true),
+		)
 		It("should back-off if a sync error occurs", func() {
 			vmi := NewPendingVirtualMachine("testvmi")
 
@@ -874,11 +998,11 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
 		})
 		table.DescribeTable("should create PodScheduled and Synchronized conditions exactly once each for repeated FailedPvcNotFoundReason/FailedDataVolumeNotFoundReason sync errors",
-			func(syncReason string, volumeSource v1.VolumeSource) {
+			func(syncReason string, volumeSource v1.VolumeSource, expectedConditions int) {
 
 				expectConditions := func(vmi *v1.VirtualMachineInstance) {
-					// PodScheduled and Synchronized (as well as Ready)
-					Expect(len(vmi.Status.Conditions)).To(Equal(3), "there should be exactly 3 conditions")
+					// PodScheduled and Synchronized (as well as Ready and, for DataVolumes, DataVolumesReady)
+					Expect(len(vmi.Status.Conditions)).To(Equal(expectedConditions), "there should be exactly %d conditions", expectedConditions)
 
 					getType := func(c v1.VirtualMachineInstanceCondition) string { return string(c.Type) }
 					getReason := func(c v1.VirtualMachineInstanceCondition) string { return c.Reason }
@@ -941,13 +1065,13 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 							ClaimName: "something",
 						},
 					},
-				}),
+				}, 3),
 			table.Entry("when DataVolume does not exist", FailedDataVolumeNotFoundReason,
 				v1.VolumeSource{
 					DataVolume: &v1.DataVolumeSource{
 						Name: "something",
 					},
-				}),
+				}, 4),
 		)
 
 		table.DescribeTable("should move the vmi to scheduling state if a pod exists", func(phase k8sv1.PodPhase, isReady bool) {
@@ -1099,6 +1223,26 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 				}, {Name: "istio-proxy", Ready: false}},
 			),
 		)
+		It("should mark the vmi when the istio proxy was injected into the pod", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			setReadyCondition(vmi, k8sv1.ConditionFalse, v1.GuestNotRunningReason)
+			vmi.Status.Phase = v1.Scheduling
+			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			pod.Annotations[istio.ISTIO_STATUS_ANNOTATION] = "{}"
+			pod.Status.ContainerStatuses = []k8sv1.ContainerStatus{{
+				Name: "compute", State: k8sv1.ContainerState{Running: &k8sv1.ContainerStateRunning{}},
+			}, {Name: istio.ProxyContainerName, Ready: false}}
+
+			addVirtualMachine(vmi)
+			podFeeder.Add(pod)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachineInstance).Status.Phase).To(Equal(v1.Scheduled))
+				Expect(arg.(*v1.VirtualMachineInstance).Annotations).To(HaveKeyWithValue(istio.PROXY_INJECTED_ANNOTATION, "true"))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+		})
 		table.DescribeTable("should not hand over pod to virt-handler if pod is ready and running", func(containerStatus []k8sv1.ContainerStatus) {
 			vmi := NewPendingVirtualMachine("testvmi")
 			setReadyCondition(vmi, k8sv1.ConditionFalse, v1.GuestNotRunningReason)
@@ -1161,21 +1305,27 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 					},
 				},
 			})
+			setDataVolumesReadyCondition(vmi, k8sv1.ConditionFalse, v1.DataVolumesNotReadyReason)
 			addVirtualMachine(vmi)
 			podFeeder.Add(pod)
 			podFeeder.Add(attachmentPod)
 
-			switch expectedPhase {
-			case v1.Scheduled:
-				shouldExpectVirtualMachineScheduledState(vmi)
-			case v1.Scheduling:
-				vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
-					Expect(arg.(*v1.VirtualMachineInstance).Status.Phase).To(Equal(v1.Scheduling))
-					Expect(arg.(*v1.VirtualMachineInstance).Status.Conditions).To(ConsistOf(MatchFields(IgnoreExtras,
-						Fields{"Type": Equal(v1.VirtualMachineInstanceReady)})))
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachineInstance).Status.Phase).To(Equal(expectedPhase))
+				Expect(arg.(*v1.VirtualMachineInstance).Status.Conditions).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"Type": Equal(v1.VirtualMachineInstanceReady)}),
+					MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(v1.VirtualMachineInstanceDataVolumesReady),
+						"Status": Equal(k8sv1.ConditionFalse),
+						"Reason": Equal(v1.DataVolumesNotReadyReason),
+					}),
+				))
+				if expectedPhase == v1.Scheduled {
+					Expect(len(arg.(*v1.VirtualMachineInstance).Status.PhaseTransitionTimestamps)).ToNot(Equal(0))
+				} else {
 					Expect(len(arg.(*v1.VirtualMachineInstance).Status.PhaseTransitionTimestamps)).To(Equal(0))
-				}).Return(vmi, nil)
-			}
+				}
+			}).Return(vmi, nil)
 
 			controller.Execute()
 
@@ -1334,6 +1484,50 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 
 			controller.Execute()
 		})
+		It("should record when the pod was scheduled and when the compute container started", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			setReadyCondition(vmi, k8sv1.ConditionFalse, v1.GuestNotRunningReason)
+			vmi.Status.Phase = v1.Scheduling
+			pod := NewPodForVirtualMachine(vmi, k8sv1.PodPending)
+			scheduled := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+			started := metav1.NewTime(time.Now().Truncate(time.Second))
+			pod.Status.Conditions = []k8sv1.PodCondition{
+				{Type: k8sv1.PodScheduled, Status: k8sv1.ConditionTrue, LastTransitionTime: scheduled},
+			}
+			pod.Status.ContainerStatuses[0].State.Running.StartedAt = started
+
+			addVirtualMachine(vmi)
+			podFeeder.Add(pod)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				timestamps := arg.(*v1.VirtualMachineInstance).Status.StartupTimestamps
+				Expect(timestamps).ToNot(BeNil())
+				Expect(*timestamps.PodScheduled).To(Equal(scheduled))
+				Expect(*timestamps.ContainerStarted).To(Equal(started))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+		})
+		It("should keep the recorded startup timestamps", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			setReadyCondition(vmi, k8sv1.ConditionFalse, v1.GuestNotRunningReason)
+			vmi.Status.Phase = v1.Scheduling
+			recorded := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+			vmi.Status.StartupTimestamps = &v1.VirtualMachineInstanceStartupTimestamps{PodScheduled: &recorded}
+			pod := NewPodForVirtualMachine(vmi, k8sv1.PodPending)
+			pod.Status.Conditions = []k8sv1.PodCondition{
+				{Type: k8sv1.PodScheduled, Status: k8sv1.ConditionTrue, LastTransitionTime: metav1.Now()},
+			}
+
+			addVirtualMachine(vmi)
+			podFeeder.Add(pod)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				Expect(*arg.(*v1.VirtualMachineInstance).Status.StartupTimestamps.PodScheduled).To(Equal(recorded))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+		})
 		It("should update the virtual machine to scheduled if pod is ready, triggered by pod change", func() {
 			vmi := NewPendingVirtualMachine("testvmi")
 			setReadyCondition(vmi, k8sv1.ConditionFalse, v1.GuestNotRunningReason)
@@ -1601,6 +1795,33 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			controller.Execute()
 		})
 
+		It("should report the IPs requested for a Multus network from the network status of the pod", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			setReadyCondition(vmi, k8sv1.ConditionFalse, v1.PodConditionMissingReason)
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Networks = []v1.Network{
+				*v1.DefaultPodNetwork(),
+				{Name: "multus", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "net1", IPAddresses: []string{"192.168.1.10/24"}}}},
+			}
+			vmi.Status.Interfaces = []v1.VirtualMachineInstanceNetworkInterface{
+				{Name: "default", MAC: "1c:ce:c0:01:be:e7", IP: "1.1.1.1", IPs: []string{"1.1.1.1"}},
+				{Name: "multus", MAC: "1c:ce:c0:01:be:e8"},
+			}
+			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			pod.Annotations[multusNetworkStatusAnnotation] = `[{"name":"kindnet","interface":"eth0","ips":["10.244.0.10"],"default":true},` +
+				`{"name":"default/net1","interface":"net1","ips":["192.168.1.10"],"mac":"1c:ce:c0:01:be:e8"}]`
+
+			addVirtualMachine(vmi)
+			addActivePods(vmi, pod.UID, "")
+			podFeeder.Add(pod)
+
+			patch := `[{ "op": "test", "path": "/status/interfaces", "value": [{"ipAddress":"1.1.1.1","mac":"1c:ce:c0:01:be:e7","name":"default","ipAddresses":["1.1.1.1"]},{"mac":"1c:ce:c0:01:be:e8","name":"multus"}] }, ` +
+				`{ "op": "replace", "path": "/status/interfaces", "value": [{"ipAddress":"1.1.1.1","mac":"1c:ce:c0:01:be:e7","name":"default","ipAddresses":["1.1.1.1"]},{"ipAddress":"192.168.1.10","mac":"1c:ce:c0:01:be:e8","name":"multus","ipAddresses":["192.168.1.10"]}] }]`
+			vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, []byte(patch)).Return(vmi, nil)
+
+			controller.Execute()
+		})
+
 		It("should not remove sync conditions from virt-handler if it is in scheduled state", func() {
 			vmi := NewPendingVirtualMachine("testvmi")
 			setReadyCondition(vmi, k8sv1.ConditionFalse, v1.GuestNotRunningReason)
@@ -2251,6 +2472,11 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			table.Entry("should return a volume if vmi has one more than virtlauncher", makeK8sVolumes(), makeVolumes(1), 1),
 			table.Entry("should return a volume if vmi has one more than virtlauncher, with matching volumes", makeK8sVolumes(1, 3), makeVolumes(1, 2, 3), 2),
 			table.Entry("should return multiple volumes if vmi has multiple more than virtlauncher, with matching volumes", makeK8sVolumes(1, 3), makeVolumes(1, 2, 3, 4, 5), 2, 4, 5),
+			table.Entry("should return a volume if its claim was swapped compared to virtlauncher", func() []k8sv1.Volume {
+				volumes := makeK8sVolumes(1, 2)
+				volumes[1].PersistentVolumeClaim.ClaimName = "previous-claim"
+				return volumes
+			}(), makeVolumes(1, 2), 2),
 		)
 
 		truncateSprintf := func(str string, args ...interface{}) string {
@@ -2284,6 +2510,61 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			return makeVolumeStatusesForUpdateWithMessage("test-pod", "abcd", v1.HotplugVolumeAttachedToNode, "Created hotplug attachment pod test-pod, for volume volume%d", SuccessfulCreatePodReason, indexes...)
 		}
 
+		table.DescribeTable("on a pvc update", func(capacity string, expectedLen int) {
+			vmi := NewPendingVirtualMachine("testvmi")
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name: "volume0",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+						ClaimName: "claim0",
+					}},
+				},
+			}}
+			Expect(vmiInformer.GetIndexer().Add(vmi)).To(Succeed())
+			oldPVC := NewHotplugPVC("claim0", vmi.Namespace, k8sv1.ClaimBound)
+			oldPVC.ResourceVersion = "1"
+			oldPVC.Status.Capacity = k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse("1Gi")}
+			curPVC := oldPVC.DeepCopy()
+			curPVC.ResourceVersion = "2"
+			curPVC.Status.Capacity = k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse(capacity)}
+
+			controller.updatePVC(oldPVC, curPVC)
+			Expect(mockQueue.Len()).To(Equal(expectedLen))
+		},
+			table.Entry("should enqueue the vmi if the capacity changed", "2Gi", 1),
+			table.Entry("should not enqueue the vmi if the capacity is unchanged", "1Gi", 0),
+		)
+
+		It("should track the overlay PVC of a containerDisk in the volume status", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name: "containerdisk",
+				VolumeSource: v1.VolumeSource{
+					ContainerDisk: &v1.ContainerDiskSource{
+						Image:   "my-image",
+						Overlay: &v1.ContainerDiskOverlay{ClaimName: "overlay-pvc"},
+					},
+				},
+			}}
+			pvc := NewPvc(vmi.Namespace, "overlay-pvc")
+			pvc.Spec.AccessModes = []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteOnce}
+			pvcInformer.GetIndexer().Add(pvc)
+
+			Expect(controller.updateVolumeStatus(vmi, NewPodForVirtualMachine(vmi, k8sv1.PodRunning))).To(Succeed())
+			Expect(vmi.Status.VolumeStatus).To(HaveLen(1))
+			Expect(vmi.Status.VolumeStatus[0].Name).To(Equal("containerdisk"))
+			Expect(vmi.Status.VolumeStatus[0].PersistentVolumeClaimInfo).ToNot(BeNil())
+			Expect(vmi.Status.VolumeStatus[0].PersistentVolumeClaimInfo.AccessModes).To(ConsistOf(k8sv1.ReadWriteOnce))
+
+			vmis, err := controller.listVMIsMatchingPVC(vmi.Namespace, "overlay-pvc")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vmis).To(BeEmpty())
+			Expect(vmiInformer.GetIndexer().Add(vmi)).To(Succeed())
+			vmis, err = controller.listVMIsMatchingPVC(vmi.Namespace, "overlay-pvc")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vmis).To(ConsistOf(vmi))
+		})
+
 		table.DescribeTable("updateVolumeStatus", func(oldStatus []v1.VolumeStatus, specVolumes []*v1.Volume, podIndexes []int, pvcIndexes []int, expectedStatus []v1.VolumeStatus, expectedEvents []string) {
 			vmi := NewPendingVirtualMachine("testvmi")
 			volumes := make([]v1.Volume, 0)
@@ -2604,6 +2885,15 @@ func NewPendingVirtualMachine(name string) *v1.VirtualMachineInstance {
 	return vmi
 }
 
+func setDataVolumesReadyCondition(vmi *v1.VirtualMachineInstance, status k8sv1.ConditionStatus, reason string) {
+	kvcontroller.NewVirtualMachineInstanceConditionManager().RemoveCondition(vmi, v1.VirtualMachineInstanceDataVolumesReady)
+	vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+		Type:   v1.VirtualMachineInstanceDataVolumesReady,
+		Status: status,
+		Reason: reason,
+	})
+}
+
 func setReadyCondition(vmi *v1.VirtualMachineInstance, status k8sv1.ConditionStatus, reason string) {
 	kvcontroller.NewVirtualMachineInstanceConditionManager().RemoveCondition(vmi, v1.VirtualMachineInstanceReady)
 	vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
@@ -0,0 +1,133 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package vsock
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/controller"
+)
+
+const (
+	// The CIDs 0, 1 and 2 are reserved for the hypervisor, the loopback and the host.
+	MinCID = uint32(3)
+	// The CID 2^32-1 is VMADDR_CID_ANY.
+	MaxCID = uint32(math.MaxUint32 - 1)
+)
+
+// Allocator hands out the guest CIDs of VSOCK devices. A CID is unique in the cluster, so that
+// it stays unique on the node of a VMI, also after the VMI migrated to another node.
+type Allocator interface {
+	// Sync registers the CIDs which are already in the status of the VMIs
+	Sync(vmis []*virtv1.VirtualMachineInstance)
+	// Allocate returns the CID of the VMI, a free one if it has none yet. A CID in the status of
+	// the VMI which was not handed out by the allocator is rejected.
+	Allocate(vmi *virtv1.VirtualMachineInstance) (uint32, error)
+	// Remove releases the CID of the VMI with the given key
+	Remove(key string)
+}
+
+type cidsMap struct {
+	mu      sync.Mutex
+	cids    map[string]uint32
+	reverse map[uint32]string
+	last    uint32
+}
+
+func NewCIDsMap() Allocator {
+	return &cidsMap{
+		cids:    map[string]uint32{},
+		reverse: map[uint32]string{},
+		last:    MinCID - 1,
+	}
+}
+
+func (m *cidsMap) Sync(vmis []*virtv1.VirtualMachineInstance) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, vmi := range vmis {
+		if vmi.Status.VSOCKCID == nil {
+			continue
+		}
+		key := controller.VirtualMachineInstanceKey(vmi)
+		cid := *vmi.Status.VSOCKCID
+		if owner, inUse := m.reverse[cid]; inUse && owner != key {
+			log.Log.Object(vmi).Errorf("VSOCK CID %d is already in use by VMI %s, not registering it", cid, owner)
+			continue
+		}
+		m.insert(key, cid)
+	}
+}
+
+func (m *cidsMap) Allocate(vmi *virtv1.VirtualMachineInstance) (uint32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := controller.VirtualMachineInstanceKey(vmi)
+	cid, exists := m.cids[key]
+	if vmi.Status.VSOCKCID != nil {
+		if !exists || cid != *vmi.Status.VSOCKCID {
+			return 0, fmt.Errorf("VSOCK CID %d was not allocated by virt-controller", *vmi.Status.VSOCKCID)
+		}
+		return cid, nil
+	}
+	if exists {
+		return cid, nil
+	}
+	if uint64(len(m.reverse)) > uint64(MaxCID-MinCID) {
+		return 0, fmt.Errorf("no free VSOCK CIDs left")
+	}
+	cid = m.last
+	for {
+		if cid >= MaxCID {
+			cid = MinCID
+		} else {
+			cid++
+		}
+		if _, inUse := m.reverse[cid]; !inUse {
+			break
+		}
+	}
+	m.insert(key, cid)
+	m.last = cid
+	return cid, nil
+}
+
+func (m *cidsMap) Remove(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if cid, exists := m.cids[key]; exists {
+		if m.reverse[cid] == key {
+			delete(m.reverse, cid)
+		}
+		delete(m.cids, key)
+	}
+}
+
+func (m *cidsMap) insert(key string, cid uint32) {
+	if old, exists := m.cids[key]; exists && old != cid {
+		delete(m.reverse, old)
+	}
+	m.cids[key] = cid
+	m.reverse[cid] = key
+}
@@ -0,0 +1,98 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package vsock
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("VSOCK CIDs", func() {
+
+	newVMI := func(name string, cid *uint32) *virtv1.VirtualMachineInstance {
+		return &virtv1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Status:     virtv1.VirtualMachineInstanceStatus{VSOCKCID: cid},
+		}
+	}
+
+	uint32Ptr := func(i uint32) *uint32 {
+		return &i
+	}
+
+	It("should allocate unique CIDs starting at the first CID which is not reserved", func() {
+		m := NewCIDsMap()
+		first, err := m.Allocate(newVMI("first", nil))
+		Expect(err).ToNot(HaveOccurred())
+		second, err := m.Allocate(newVMI("second", nil))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(first).To(Equal(MinCID))
+		Expect(second).To(Equal(MinCID + 1))
+	})
+
+	It("should return the same CID for the same VMI", func() {
+		m := NewCIDsMap()
+		cid, err := m.Allocate(newVMI("vmi", nil))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(m.Allocate(newVMI("vmi", nil))).To(Equal(cid))
+	})
+
+	It("should not hand out CIDs which are in the status of synced VMIs", func() {
+		m := NewCIDsMap()
+		m.Sync([]*virtv1.VirtualMachineInstance{newVMI("synced", uint32Ptr(MinCID)), newVMI("other", nil)})
+		Expect(m.Allocate(newVMI("vmi", nil))).To(Equal(MinCID + 1))
+		Expect(m.Allocate(newVMI("synced", nil))).To(Equal(MinCID))
+	})
+
+	It("should hand out released CIDs again once the range wrapped", func() {
+		m := NewCIDsMap().(*cidsMap)
+		m.last = MaxCID - 1
+		Expect(m.Allocate(newVMI("last", nil))).To(Equal(MaxCID))
+		m.Sync([]*virtv1.VirtualMachineInstance{newVMI("used", uint32Ptr(MinCID))})
+		Expect(m.Allocate(newVMI("vmi", nil))).To(Equal(MinCID + 1))
+
+		m.Remove("default/used")
+		m.last = MaxCID
+		Expect(m.Allocate(newVMI("again", nil))).To(Equal(MinCID))
+	})
+
+	It("should reject a CID in the status which it did not hand out", func() {
+		m := NewCIDsMap()
+		_, err := m.Allocate(newVMI("vmi", uint32Ptr(MinCID)))
+		Expect(err).To(HaveOccurred())
+
+		cid, err := m.Allocate(newVMI("victim", nil))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = m.Allocate(newVMI("attacker", uint32Ptr(cid)))
+		Expect(err).To(HaveOccurred())
+		Expect(m.Allocate(newVMI("victim", uint32Ptr(cid)))).To(Equal(cid))
+	})
+
+	It("should not let a synced VMI take over the CID of another VMI", func() {
+		m := NewCIDsMap()
+		m.Sync([]*virtv1.VirtualMachineInstance{newVMI("victim", uint32Ptr(MinCID)), newVMI("attacker", uint32Ptr(MinCID))})
+		m.Remove("default/attacker")
+		Expect(m.Allocate(newVMI("vmi", nil))).To(Equal(MinCID + 1))
+		Expect(m.Allocate(newVMI("victim", uint32Ptr(MinCID)))).To(Equal(MinCID))
+	})
+})
@@ -134,3 +134,28 @@ func newParser(isCgroup2UnifiedMode bool, procMount, cgroupMount string) Parser
 		procMount: procMount,
 	}
 }
+
+// ConfigureSwap lets the memory cgroup of the given process use swap. With cgroup v1 the
+// swappiness of the cgroup is set if one is given, with cgroup v2 the swap usage is unlimited.
+func ConfigureSwap(pid int, swappiness *int64) error {
+	return configureSwap(cgroups.IsCgroup2UnifiedMode(), procMountPoint, cgroupMountPoint, pid, swappiness)
+}
+
+func configureSwap(isCgroup2UnifiedMode bool, procMount, cgroupMount string, pid int, swappiness *int64) error {
+	slices, err := newParser(isCgroup2UnifiedMode, procMount, cgroupMount).Parse(pid)
+	if err != nil {
+		return err
+	}
+	slice, ok := slices["memory"]
+	if !ok {
+		return fmt.Errorf("memory controller not found for PID %d", pid)
+	}
+
+	if isCgroup2UnifiedMode {
+		return ioutil.WriteFile(filepath.Join(cgroupMount, slice, "memory.swap.max"), []byte("max"), 0644)
+	}
+	if swappiness == nil {
+		return nil
+	}
+	return ioutil.WriteFile(filepath.Join(cgroupMount, "memory", slice, "memory.swappiness"), []byte(strconv.FormatInt(*swappiness, 10)), 0644)
+}
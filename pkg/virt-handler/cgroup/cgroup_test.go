@@ -132,6 +132,17 @@ var _ = Describe("Cgroup", func() {
 				}
 			}
 		})
+
+		It("Should set the swappiness of the memory cgroup", func() {
+			path := filepath.Join(cgroupFS, "memory", procCgroupV1Data[2].slice)
+			Expect(os.MkdirAll(path, os.ModePerm)).To(Succeed())
+			swappiness := int64(30)
+			err := configureSwap(isCgroup2UnifiedMode, procFS, cgroupFS, os.Getpid(), &swappiness)
+			Expect(err).ToNot(HaveOccurred())
+			data, err := ioutil.ReadFile(filepath.Join(path, "memory.swappiness"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal("30"))
+		})
 	})
 
 	Context("With Control Group v2", func() {
@@ -174,5 +185,13 @@ var _ = Describe("Cgroup", func() {
 				Expect(slice).To(Equal(procCgroupV2Data[0].slice))
 			}
 		})
+
+		It("Should not limit the swap usage of the memory cgroup", func() {
+			err := configureSwap(isCgroup2UnifiedMode, procFS, cgroupFS, os.Getpid(), nil)
+			Expect(err).ToNot(HaveOccurred())
+			data, err := ioutil.ReadFile(filepath.Join(cgroupFS, procCgroupV2Data[0].slice, "memory.swap.max"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal("max"))
+		})
 	})
 })
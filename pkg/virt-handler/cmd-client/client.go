@@ -50,6 +50,7 @@ import (
 	com "kubevirt.io/kubevirt/pkg/handler-launcher-com"
 	"kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/info"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
+	grpcmetrics "kubevirt.io/kubevirt/pkg/monitoring/grpc/prometheus"
 	grpcutil "kubevirt.io/kubevirt/pkg/util/net/grpc"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
@@ -68,12 +69,13 @@ const StandardInitLauncherSocketFileName = "launcher-init-sock"
 const StandardLauncherUnresponsiveFileName = "launcher-unresponsive"
 
 type MigrationOptions struct {
-	Bandwidth               resource.Quantity
-	ProgressTimeout         int64
-	CompletionTimeoutPerGiB int64
-	UnsafeMigration         bool
-	AllowAutoConverge       bool
-	AllowPostCopy           bool
+	Bandwidth                resource.Quantity
+	ProgressTimeout          int64
+	CompletionTimeoutPerGiB  int64
+	UnsafeMigration          bool
+	AllowAutoConverge        bool
+	AllowPostCopy            bool
+	ParallelMigrationThreads uint32
 }
 
 type LauncherClient interface {
@@ -98,6 +100,7 @@ type LauncherClient interface {
 	Exec(string, string, []string, int32) (int, string, error)
 	Ping() error
 	GuestPing(string, int32) error
+	VirtualMachineMemoryDump(vmi *v1.VirtualMachineInstance, dumpPath string) error
 	Close()
 }
 
@@ -109,6 +112,8 @@ type VirtLauncherClient struct {
 const (
 	shortTimeout time.Duration = 5 * time.Second
 	longTimeout  time.Duration = 20 * time.Second
+	// dumping the guest memory takes as long as writing all of it to disk
+	memoryDumpTimeout time.Duration = 15 * time.Minute
 )
 
 func SetLegacyBaseDir(baseDir string) {
@@ -302,7 +307,12 @@ func NewClient(socketPath string) (LauncherClient, error) {
 
 	// create info client and find cmd version to use
 	infoClient := info.NewCmdInfoClient(conn)
-	return NewClientWithInfoClient(infoClient, conn)
+	client, err := NewClientWithInfoClient(infoClient, conn)
+	if err != nil {
+		return nil, err
+	}
+	grpcmetrics.MonitorConnectionState(conn, "cmd")
+	return client, nil
 }
 
 func NewClientWithInfoClient(infoClient info.CmdInfoClient, conn *grpc.ClientConn) (LauncherClient, error) {
@@ -686,3 +696,24 @@ func (c *VirtLauncherClient) GuestPing(domainName string, timeoutSeconds int32)
 	_, err := c.v1client.GuestPing(ctx, request)
 	return err
 }
+
+// VirtualMachineMemoryDump writes a memory only dump of the guest to dumpPath inside the launcher
+func (c *VirtLauncherClient) VirtualMachineMemoryDump(vmi *v1.VirtualMachineInstance, dumpPath string) error {
+	vmiJson, err := json.Marshal(vmi)
+	if err != nil {
+		return err
+	}
+
+	request := &cmdv1.MemoryDumpRequest{
+		Vmi: &cmdv1.VMI{
+			VmiJson: vmiJson,
+		},
+		DumpPath: dumpPath,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), memoryDumpTimeout)
+	defer cancel()
+	response, err := c.v1client.VirtualMachineMemoryDump(ctx, request)
+
+	return handleError(err, "MemoryDump", response)
+}
@@ -233,6 +233,22 @@ var _ = Describe("Virt remote commands", func() {
 				err := client.GuestPing(testDomainName, testTimeoutSeconds)
 				Expect(err).ToNot(HaveOccurred())
 			})
+			It("should pass the dump path to the memory dump command", func() {
+				vmi := v1.NewMinimalVMI("testvmi")
+				mockCmdClient.EXPECT().VirtualMachineMemoryDump(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, request *cmdv1.MemoryDumpRequest, _ ...grpc.CallOption) (*cmdv1.Response, error) {
+					Expect(request.DumpPath).To(Equal("/var/run/kubevirt-private/memory-dump/memory.dump"))
+					Expect(request.Vmi.VmiJson).ToNot(BeEmpty())
+					return &cmdv1.Response{Success: true}, nil
+				})
+				Expect(client.VirtualMachineMemoryDump(vmi, "/var/run/kubevirt-private/memory-dump/memory.dump")).To(Succeed())
+			})
+			It("should return the launcher error of a failed memory dump", func() {
+				vmi := v1.NewMinimalVMI("testvmi")
+				mockCmdClient.EXPECT().VirtualMachineMemoryDump(gomock.Any(), gomock.Any()).Return(&cmdv1.Response{Success: false, Message: "disk full"}, nil)
+				err := client.VirtualMachineMemoryDump(vmi, "/var/run/kubevirt-private/memory-dump/memory.dump")
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("disk full"))
+			})
 		})
 	})
 })
@@ -0,0 +1,328 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package container_disk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	diskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
+)
+
+const (
+	digestSeparator = "@sha256:"
+	cachedImageName = "disk.img"
+	cacheImagesDir  = "images"
+	cacheDigestsDir = "digests"
+	cacheRefsDir    = "refs"
+)
+
+// diskCache keeps one read-only copy of a containerDisk image per node. The copies are
+// stored by the sha256 of their content, so all VMIs which boot from the same disk bind mount
+// the same backing file, no matter which image or tag it came from. virt-launcher keeps
+// creating a private qcow2 overlay on top of it for every VMI.
+type diskCache struct {
+	baseDir string
+
+	// imageLocks holds one lock per cached image, so that copying one image does not block
+	// VMIs which use another one
+	imageLocks     map[string]*imageLock
+	imageLocksLock sync.Mutex
+}
+
+type imageLock struct {
+	sync.Mutex
+	waiters int
+}
+
+func newDiskCache(baseDir string) *diskCache {
+	return &diskCache{
+		baseDir:    baseDir,
+		imageLocks: map[string]*imageLock{},
+	}
+}
+
+// imageDigestKey returns the key under which the content digest of a digest pinned
+// containerDisk volume is remembered, so that the image doesn't have to be read again.
+// An empty key is returned for volumes which are referenced by tag, since a tag can point to
+// different content over time.
+func imageDigestKey(volume *v1.Volume) string {
+	if volume.ContainerDisk == nil {
+		return ""
+	}
+	idx := strings.LastIndex(volume.ContainerDisk.Image, digestSeparator)
+	if idx < 0 {
+		return ""
+	}
+	digest := volume.ContainerDisk.Image[idx+len(digestSeparator):]
+	if digest == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(digest + ":" + filepath.Clean("/"+volume.ContainerDisk.Path)))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *diskCache) imageDir(key string) string {
+	return filepath.Join(c.baseDir, cacheImagesDir, key)
+}
+
+func (c *diskCache) imagePath(key string) string {
+	return filepath.Join(c.imageDir(key), cachedImageName)
+}
+
+func (c *diskCache) refFile(key string, uid types.UID) string {
+	return filepath.Join(c.imageDir(key), cacheRefsDir, string(uid))
+}
+
+func (c *diskCache) digestFile(digestKey string) string {
+	return filepath.Join(c.baseDir, cacheDigestsDir, digestKey)
+}
+
+func (c *diskCache) lockImage(key string) func() {
+	c.imageLocksLock.Lock()
+	lock, ok := c.imageLocks[key]
+	if !ok {
+		lock = &imageLock{}
+		c.imageLocks[key] = lock
+	}
+	lock.waiters++
+	c.imageLocksLock.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		c.imageLocksLock.Lock()
+		defer c.imageLocksLock.Unlock()
+		lock.waiters--
+		if lock.waiters == 0 {
+			delete(c.imageLocks, key)
+		}
+	}
+}
+
+// Acquire returns the path of the cached copy of the disk of a containerDisk volume and
+// records that the VMI with the given uid is using it. The cache entry is populated from
+// sourceFile on first use.
+func (c *diskCache) Acquire(volume *v1.Volume, uid types.UID, sourceFile string) (string, error) {
+	if uid == "" {
+		return "", fmt.Errorf("unable to acquire a cached container disk without vmi uid")
+	}
+
+	digestKey := imageDigestKey(volume)
+	key := ""
+	if digestKey != "" {
+		// #nosec No risk for path injection. The key is a hex encoded sha256
+		if content, err := ioutil.ReadFile(c.digestFile(digestKey)); err == nil {
+			key = string(content)
+		} else if !os.IsNotExist(err) {
+			return "", err
+		}
+	}
+	if key == "" {
+		var err error
+		if key, err = fileDigest(sourceFile); err != nil {
+			return "", fmt.Errorf("failed to read container disk %s: %v", sourceFile, err)
+		}
+	}
+
+	unlock := c.lockImage(key)
+	defer unlock()
+
+	if err := os.MkdirAll(filepath.Join(c.imageDir(key), cacheRefsDir), 0750); err != nil {
+		return "", err
+	}
+
+	imagePath := c.imagePath(key)
+	exists, err := diskutils.FileExists(imagePath)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		if err := copyImage(sourceFile, imagePath); err != nil {
+			return "", fmt.Errorf("failed to populate container disk cache entry %s: %v", key, err)
+		}
+	}
+
+	f, err := os.Create(c.refFile(key, uid))
+	if err != nil {
+		return "", err
+	}
+	f.Close()
+
+	if digestKey != "" {
+		if err := writeFileAtomically(c.digestFile(digestKey), []byte(key)); err != nil {
+			return "", err
+		}
+	}
+
+	return imagePath, nil
+}
+
+// Release drops all references of the VMI with the given uid. Cached images without any
+// reference left are removed from the node.
+func (c *diskCache) Release(uid types.UID) error {
+	if uid == "" {
+		return nil
+	}
+
+	images, err := ioutil.ReadDir(filepath.Join(c.baseDir, cacheImagesDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, image := range images {
+		if err := c.release(image.Name(), uid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *diskCache) release(key string, uid types.UID) error {
+	unlock := c.lockImage(key)
+	defer unlock()
+
+	if err := os.Remove(c.refFile(key, uid)); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	refs, err := ioutil.ReadDir(filepath.Join(c.imageDir(key), cacheRefsDir))
+	if err != nil {
+		return err
+	}
+	if len(refs) > 0 {
+		return nil
+	}
+	if err := os.RemoveAll(c.imageDir(key)); err != nil {
+		return err
+	}
+	return c.removeDigests(key)
+}
+
+// removeDigests forgets the image digests which point to a removed image
+func (c *diskCache) removeDigests(key string) error {
+	digests, err := ioutil.ReadDir(filepath.Join(c.baseDir, cacheDigestsDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, digest := range digests {
+		digestFile := filepath.Join(c.baseDir, cacheDigestsDir, digest.Name())
+		// #nosec No risk for path injection. The file is read from the cache directory
+		if content, err := ioutil.ReadFile(digestFile); err == nil && string(content) == key {
+			if err := os.Remove(digestFile); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fileDigest returns the hex encoded sha256 of the content of a file
+func fileDigest(file string) (string, error) {
+	// #nosec No risk for path injection. The source is resolved from the containerDisk root mount
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func writeFileAtomically(file string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0750); err != nil {
+		return err
+	}
+	tmpFile := file + ".tmp"
+	if err := ioutil.WriteFile(tmpFile, content, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, file)
+}
+
+// copyImage copies the image to a temporary file first and renames it afterwards, so that a
+// partially written image never shows up in the cache.
+func copyImage(sourceFile, targetFile string) error {
+	// #nosec No risk for path injection. The source is resolved from the containerDisk root mount
+	source, err := os.Open(sourceFile)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	info, err := source.Stat()
+	if err != nil {
+		return err
+	}
+
+	tmpFile := targetFile + ".tmp"
+	target, err := os.OpenFile(tmpFile, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile)
+
+	if _, err := io.Copy(target, source); err != nil {
+		target.Close()
+		return err
+	}
+	if err := target.Sync(); err != nil {
+		target.Close()
+		return err
+	}
+	if err := target.Close(); err != nil {
+		return err
+	}
+
+	// keep the ownership of the image, qemu has to be able to read it
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		if err := os.Lchown(tmpFile, int(stat.Uid), int(stat.Gid)); err != nil && !os.IsPermission(err) {
+			return err
+		}
+	}
+	if err := os.Chmod(tmpFile, info.Mode().Perm()&0444); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile, targetFile)
+}
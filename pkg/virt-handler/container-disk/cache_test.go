@@ -0,0 +1,166 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package container_disk
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	diskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
+)
+
+var _ = Describe("ContainerDisk cache", func() {
+	var tmpDir string
+	var sourceFile string
+	var cache *diskCache
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "containerdiskcache")
+		Expect(err).ToNot(HaveOccurred())
+		sourceFile = filepath.Join(tmpDir, "source.img")
+		Expect(ioutil.WriteFile(sourceFile, []byte("disk content"), 0644)).To(Succeed())
+		cache = newDiskCache(filepath.Join(tmpDir, "cache"))
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	newVolume := func(image, path string) *v1.Volume {
+		return &v1.Volume{
+			Name: "disk",
+			VolumeSource: v1.VolumeSource{
+				ContainerDisk: &v1.ContainerDiskSource{
+					Image: image,
+					Path:  path,
+				},
+			},
+		}
+	}
+
+	const digestImage = "registry:5000/cirros@sha256:8e9f1ef580c3ee1fe9fbd0f18d2d03141692cb086d8e70d6701ce6ee2bd26be1"
+
+	imageExists := func(key string) bool {
+		exists, err := diskutils.FileExists(filepath.Join(tmpDir, "cache", cacheImagesDir, key))
+		Expect(err).ToNot(HaveOccurred())
+		return exists
+	}
+
+	table.DescribeTable("should only remember the digest of digest pinned images", func(image string, remembered bool) {
+		Expect(imageDigestKey(newVolume(image, "")) != "").To(Equal(remembered))
+	},
+		table.Entry("with a digest", digestImage, true),
+		table.Entry("with a tag", "registry:5000/cirros:latest", false),
+		table.Entry("without a tag", "registry:5000/cirros", false),
+	)
+
+	It("should use different digest keys for different paths in the same image", func() {
+		Expect(imageDigestKey(newVolume(digestImage, "/disk/a.img"))).ToNot(Equal(imageDigestKey(newVolume(digestImage, "/disk/b.img"))))
+		Expect(imageDigestKey(newVolume(digestImage, "disk/a.img"))).To(Equal(imageDigestKey(newVolume(digestImage, "/disk/a.img"))))
+	})
+
+	It("should share one read-only copy between VMIs and remove it with the last reference", func() {
+		key, err := fileDigest(sourceFile)
+		Expect(err).ToNot(HaveOccurred())
+
+		first, err := cache.Acquire(newVolume(digestImage, ""), "uid1", sourceFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(first).To(Equal(filepath.Join(tmpDir, "cache", cacheImagesDir, key, cachedImageName)))
+
+		content, err := ioutil.ReadFile(first)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("disk content"))
+		info, err := os.Stat(first)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0444)))
+
+		// the image of a known digest is neither read nor copied again
+		Expect(os.Remove(sourceFile)).To(Succeed())
+		second, err := cache.Acquire(newVolume(digestImage, ""), "uid2", sourceFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(second).To(Equal(first))
+
+		Expect(cache.Release("uid1")).To(Succeed())
+		Expect(imageExists(key)).To(BeTrue())
+
+		Expect(cache.Release("uid2")).To(Succeed())
+		Expect(imageExists(key)).To(BeFalse())
+		exists, err := diskutils.FileExists(cache.digestFile(imageDigestKey(newVolume(digestImage, ""))))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exists).To(BeFalse())
+
+		// releasing twice is fine
+		Expect(cache.Release("uid2")).To(Succeed())
+	})
+
+	It("should share the copy of images with the same content", func() {
+		otherSource := filepath.Join(tmpDir, "other.img")
+		Expect(ioutil.WriteFile(otherSource, []byte("disk content"), 0644)).To(Succeed())
+
+		first, err := cache.Acquire(newVolume("registry:5000/cirros:latest", ""), "uid1", sourceFile)
+		Expect(err).ToNot(HaveOccurred())
+		second, err := cache.Acquire(newVolume(digestImage, ""), "uid2", otherSource)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(second).To(Equal(first))
+	})
+
+	It("should keep different content apart", func() {
+		otherSource := filepath.Join(tmpDir, "other.img")
+		Expect(ioutil.WriteFile(otherSource, []byte("other content"), 0644)).To(Succeed())
+
+		first, err := cache.Acquire(newVolume("registry:5000/cirros:latest", ""), "uid1", sourceFile)
+		Expect(err).ToNot(HaveOccurred())
+		second, err := cache.Acquire(newVolume("registry:5000/fedora:latest", ""), "uid1", otherSource)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(second).ToNot(Equal(first))
+
+		// all references of a VMI are released at once
+		Expect(cache.Release("uid1")).To(Succeed())
+		Expect(ioutil.ReadDir(filepath.Join(tmpDir, "cache", cacheImagesDir))).To(BeEmpty())
+	})
+
+	It("should not leave a cache entry behind if the source can't be read", func() {
+		_, err := cache.Acquire(newVolume(digestImage, ""), "uid1", filepath.Join(tmpDir, "missing.img"))
+		Expect(err).To(HaveOccurred())
+		exists, err := diskutils.FileExists(filepath.Join(tmpDir, "cache", cacheImagesDir))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exists).To(BeFalse())
+	})
+
+	It("should serialize the users of the same image only", func() {
+		unlock := cache.lockImage("a")
+		done := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			cache.lockImage("b")()
+			close(done)
+		}()
+		Eventually(done).Should(BeClosed())
+		unlock()
+		Expect(cache.imageLocks).To(BeEmpty())
+	})
+})
@@ -35,6 +35,7 @@ type mounter struct {
 	socketPathGetter           containerdisk.SocketPathGetter
 	kernelBootSocketPathGetter containerdisk.KernelBootSocketPathGetter
 	clusterConfig              *virtconfig.ClusterConfig
+	diskCache                  *diskCache
 }
 
 type Mounter interface {
@@ -54,7 +55,7 @@ type vmiMountTargetRecord struct {
 	MountTargetEntries []vmiMountTargetEntry `json:"mountTargetEntries"`
 }
 
-func NewMounter(isoDetector isolation.PodIsolationDetector, mountStateDir string, clusterConfig *virtconfig.ClusterConfig) Mounter {
+func NewMounter(isoDetector isolation.PodIsolationDetector, mountStateDir string, cacheDir string, clusterConfig *virtconfig.ClusterConfig) Mounter {
 	return &mounter{
 		mountRecords:               make(map[types.UID]*vmiMountTargetRecord),
 		podIsolationDetector:       isoDetector,
@@ -63,6 +64,7 @@ func NewMounter(isoDetector isolation.PodIsolationDetector, mountStateDir string
 		socketPathGetter:           containerdisk.NewSocketPathGetter(""),
 		kernelBootSocketPathGetter: containerdisk.NewKernelBootSocketPathGetter(""),
 		clusterConfig:              clusterConfig,
+		diskCache:                  newDiskCache(cacheDir),
 	}
 }
 
@@ -250,14 +252,21 @@ func (m *mounter) MountAndVerify(vmi *v1.VirtualMachineInstance) (map[string]*co
 				if err != nil {
 					return nil, fmt.Errorf("failed to find a sourceFile in containerDisk %v: %v", volume.Name, err)
 				}
+				sourceFile = strings.TrimPrefix(sourceFile, nodeRes.MountRoot())
+				if m.clusterConfig.ContainerDiskCacheEnabled() {
+					sourceFile, err = m.diskCache.Acquire(&volume, vmi.UID, filepath.Join(nodeRes.MountRoot(), sourceFile))
+					if err != nil {
+						return nil, fmt.Errorf("failed to use the shared cache for containerDisk %v: %v", volume.Name, err)
+					}
+				}
 				f, err := os.Create(targetFile)
 				if err != nil {
 					return nil, fmt.Errorf("failed to create mount point target %v: %v", targetFile, err)
 				}
 				f.Close()
 
-				log.DefaultLogger().Object(vmi).Infof("Bind mounting container disk at %s to %s", strings.TrimPrefix(sourceFile, nodeRes.MountRoot()), targetFile)
-				out, err := virt_chroot.MountChroot(strings.TrimPrefix(sourceFile, nodeRes.MountRoot()), targetFile, true).CombinedOutput()
+				log.DefaultLogger().Object(vmi).Infof("Bind mounting container disk at %s to %s", sourceFile, targetFile)
+				out, err := virt_chroot.MountChroot(sourceFile, targetFile, true).CombinedOutput()
 				if err != nil {
 					return nil, fmt.Errorf("failed to bindmount containerDisk %v: %v : %v", volume.Name, string(out), err)
 				}
@@ -345,8 +354,12 @@ func (m *mounter) Unmount(vmi *v1.VirtualMachineInstance) error {
 					return fmt.Errorf("failed to unmount containerDisk %v: %v : %v", path, string(out), err)
 				}
 			}
-
 		}
+		// the cache is released even if it got disabled in the meantime
+		if err := m.diskCache.Release(vmi.UID); err != nil {
+			return fmt.Errorf("failed to release the shared container disk cache: %v", err)
+		}
+
 		err = m.deleteMountTargetRecord(vmi)
 		if err != nil {
 			return err
@@ -22,18 +22,23 @@ package device_manager
 import (
 	"math"
 	"os"
+	"reflect"
 	"strings"
 	"sync"
 	"time"
 
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+
 	"kubevirt.io/client-go/log"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 var permanentDevicePluginPaths = map[string]string{
-	"kvm":       "/dev/kvm",
-	"tun":       "/dev/net/tun",
-	"vhost-net": "/dev/vhost-net",
+	"kvm":         "/dev/kvm",
+	"tun":         "/dev/net/tun",
+	"vhost-net":   "/dev/vhost-net",
+	"vhost-vsock": "/dev/vhost-vsock",
 }
 
 type DeviceControllerInterface interface {
@@ -49,6 +54,7 @@ type DeviceController struct {
 	virtConfig         *virtconfig.ClusterConfig
 	stop               chan struct{}
 	mdevTypesManager   *MDEVTypesManager
+	nodeInformer       cache.SharedIndexInformer
 }
 
 type ControlledDevice struct {
@@ -67,7 +73,7 @@ func getPermanentHostDevicePlugins(maxDevices int, permissions string) map[strin
 	return ret
 }
 
-func NewDeviceController(host string, maxDevices int, permissions string, clusterConfig *virtconfig.ClusterConfig) *DeviceController {
+func NewDeviceController(host string, maxDevices int, permissions string, clusterConfig *virtconfig.ClusterConfig, nodeInformer cache.SharedIndexInformer) *DeviceController {
 	controller := &DeviceController{
 		devicePlugins:    getPermanentHostDevicePlugins(maxDevices, permissions),
 		host:             host,
@@ -75,6 +81,7 @@ func NewDeviceController(host string, maxDevices int, permissions string, cluste
 		backoff:          []time.Duration{1 * time.Second, 2 * time.Second, 5 * time.Second, 10 * time.Second},
 		virtConfig:       clusterConfig,
 		mdevTypesManager: NewMDEVTypesManager(),
+		nodeInformer:     nodeInformer,
 	}
 
 	return controller
@@ -182,6 +189,33 @@ func (c *DeviceController) updatePermittedHostDevicePlugins() (map[string]Contro
 				}
 			}
 		}
+		if len(hostDevs.USB) != 0 {
+			supportedUSBDeviceMap := make(map[string]string)
+			for _, usbDev := range hostDevs.USB {
+				log.Log.V(4).Infof("Permitted USB device in the cluster, resourceName: %s, externalProvider: %t",
+					usbDev.ResourceName,
+					usbDev.ExternalResourceProvider)
+				// do not add a device plugin for this resource if it's being provided via an external device plugin
+				if !usbDev.ExternalResourceProvider {
+					for _, selector := range usbDev.Selectors {
+						supportedUSBDeviceMap[strings.ToLower(selector.Vendor+":"+selector.Product)] = usbDev.ResourceName
+					}
+				}
+			}
+			usbHostDevices := discoverPermittedHostUSBDevices(supportedUSBDeviceMap)
+			for usbResourceName, usbDevices := range usbHostDevices {
+				log.Log.V(4).Infof("Discovered USB devices on the node, resourceName: %s", usbResourceName)
+				// add a device plugin only for new devices
+				if _, isRunning := c.devicePlugins[usbResourceName]; !isRunning {
+					devicePluginsToRun[usbResourceName] = ControlledDevice{
+						devicePlugin: NewUSBDevicePlugin(usbDevices, usbResourceName),
+						stopChan:     make(chan struct{}),
+					}
+				} else {
+					delete(devicePluginsToStop, usbResourceName)
+				}
+			}
+		}
 	}
 	return devicePluginsToRun, devicePluginsToStop
 }
@@ -196,13 +230,32 @@ func removeSelectorSpaces(selectorName string) string {
 }
 
 func (c *DeviceController) refreshMediatedDevicesTypes() {
-	nodeDesiredMdevTypesList := c.virtConfig.GetDesiredMDEVTypes(c.host)
-	err := c.mdevTypesManager.updateMDEVTypesConfiguration(nodeDesiredMdevTypesList)
+	// the desired mdev types depend on the node labels
+	obj, exists, err := c.nodeInformer.GetStore().GetByKey(c.host)
+	if err != nil {
+		log.Log.Reason(err).Errorf("failed to configure the desired mdev types: failed to get node %s", c.host)
+		return
+	}
+	if !exists {
+		log.Log.Errorf("failed to configure the desired mdev types: node %s does not exist", c.host)
+		return
+	}
+	nodeDesiredMdevTypesList := c.virtConfig.GetDesiredMDEVTypes(obj.(*k8sv1.Node))
+	err = c.mdevTypesManager.updateMDEVTypesConfiguration(nodeDesiredMdevTypesList)
 	if err != nil {
 		log.Log.Reason(err).Errorf("failed to configure the desired mdev types: %s", strings.Join(nodeDesiredMdevTypesList, ", "))
 	}
 }
 
+func (c *DeviceController) updateNodeFunc(old, new interface{}) {
+	oldNode := old.(*k8sv1.Node)
+	newNode := new.(*k8sv1.Node)
+	// the node selectors of the mdev types configuration only look at the labels
+	if !reflect.DeepEqual(oldNode.Labels, newNode.Labels) {
+		c.refreshMediatedDevicesTypes()
+	}
+}
+
 func (c *DeviceController) refreshPermittedDevices() {
 	logger := log.DefaultLogger()
 	debugDevAdded := []string{}
@@ -245,6 +298,10 @@ func (c *DeviceController) Run(stop chan struct{}) error {
 	}
 	c.virtConfig.SetConfigModifiedCallback(c.refreshMediatedDevicesTypes)
 	c.virtConfig.SetConfigModifiedCallback(c.refreshPermittedDevices)
+	c.nodeInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: c.updateNodeFunc,
+	})
+	c.refreshMediatedDevicesTypes()
 	c.refreshPermittedDevices()
 
 	// keep running until stop
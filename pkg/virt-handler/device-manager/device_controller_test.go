@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
 
 	"kubevirt.io/kubevirt/pkg/testutils"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -58,6 +59,7 @@ var _ = Describe("Device Controller", func() {
 	var fakeConfigMap *virtconfig.ClusterConfig
 	var mockPCI *MockDeviceHandler
 	var ctrl *gomock.Controller
+	var nodeInformer cache.SharedIndexInformer
 
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
@@ -72,6 +74,7 @@ var _ = Describe("Device Controller", func() {
 		fakeConfigMap, _, _, _ = testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{
 			Data: map[string]string{virtconfig.PermittedHostDevicesKey: permittedDevices},
 		})
+		nodeInformer, _ = testutils.NewFakeInformerFor(&k8sv1.Node{})
 		Expect(fakeConfigMap.GetPermittedHostDevices()).ToNot(BeNil())
 		workDir, err = ioutil.TempDir("", "kubevirt-test")
 		Expect(err).ToNot(HaveOccurred())
@@ -90,7 +93,7 @@ var _ = Describe("Device Controller", func() {
 
 	Context("Basic Tests", func() {
 		It("Should indicate if node has device", func() {
-			deviceController := NewDeviceController(host, 10, "rw", fakeConfigMap)
+			deviceController := NewDeviceController(host, 10, "rw", fakeConfigMap, nodeInformer)
 			devicePath := path.Join(workDir, "fake-device")
 			res := deviceController.NodeHasDevice(devicePath)
 			Expect(res).To(BeFalse())
@@ -126,7 +129,7 @@ var _ = Describe("Device Controller", func() {
 		})
 
 		It("should start the device plugin immediately without delays", func() {
-			deviceController := NewDeviceController(host, 10, "rw", fakeConfigMap)
+			deviceController := NewDeviceController(host, 10, "rw", fakeConfigMap, nodeInformer)
 			deviceController.backoff = []time.Duration{10 * time.Millisecond, 10 * time.Second}
 			// New device controllers include the permanent device plugins, we don't want those
 			deviceController.devicePlugins = make(map[string]ControlledDevice)
@@ -144,7 +147,7 @@ var _ = Describe("Device Controller", func() {
 		It("should restart the device plugin with delays if it returns errors", func() {
 			plugin2 = NewFakePlugin("fake-device2", devicePath2)
 			plugin2.Error = fmt.Errorf("failing")
-			deviceController := NewDeviceController(host, 10, "rw", fakeConfigMap)
+			deviceController := NewDeviceController(host, 10, "rw", fakeConfigMap, nodeInformer)
 			deviceController.backoff = []time.Duration{10 * time.Millisecond, 300 * time.Millisecond}
 			// New device controllers include the permanent device plugins, we don't want those
 			deviceController.devicePlugins = make(map[string]ControlledDevice)
@@ -160,7 +163,7 @@ var _ = Describe("Device Controller", func() {
 		})
 
 		It("Should not block on other plugins", func() {
-			deviceController := NewDeviceController(host, 10, "rw", fakeConfigMap)
+			deviceController := NewDeviceController(host, 10, "rw", fakeConfigMap, nodeInformer)
 			// New device controllers include the permanent device plugins, we don't want those
 			deviceController.devicePlugins = make(map[string]ControlledDevice)
 			deviceController.devicePlugins[deviceName1] = ControlledDevice{
@@ -188,7 +191,7 @@ var _ = Describe("Device Controller", func() {
 		It("should remove all device plugins if permittedHostDevices is removed from the CR", func() {
 			emptyConfigMap, _, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{})
 			Expect(emptyConfigMap.GetPermittedHostDevices()).To(BeNil())
-			deviceController := NewDeviceController(host, 10, "rw", emptyConfigMap)
+			deviceController := NewDeviceController(host, 10, "rw", emptyConfigMap, nodeInformer)
 			// New device controllers include the permanent device plugins, we don't want those
 			deviceController.devicePlugins = make(map[string]ControlledDevice)
 			deviceController.devicePlugins[deviceName1] = ControlledDevice{
@@ -14,6 +14,7 @@ import (
 
 	v1 "kubevirt.io/client-go/api/v1"
 
+	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/yaml"
 
@@ -183,7 +184,8 @@ var _ = Describe("Mediated Device", func() {
 			fakeClusterConfig, _, _, kvInformer := testutils.NewFakeClusterConfigUsingKV(kv)
 
 			By("creating an empty device controller")
-			deviceController := NewDeviceController("master", 10, "rw", fakeClusterConfig)
+			nodeInformer, _ := testutils.NewFakeInformerFor(&k8sv1.Node{})
+			deviceController := NewDeviceController("master", 10, "rw", fakeClusterConfig, nodeInformer)
 			deviceController.devicePlugins = make(map[string]ControlledDevice)
 
 			By("adding a host device to the cluster config")
@@ -0,0 +1,78 @@
+package device_manager
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/kubevirt/pkg/util"
+	pluginapi "kubevirt.io/kubevirt/pkg/virt-handler/device-manager/deviceplugin/v1beta1"
+)
+
+var _ = Describe("USB Device", func() {
+	var fakeSysfs string
+	var originalUSBBasePath string
+
+	createFakeUSBDevice := func(name string, attributes map[string]string) {
+		devicePath := filepath.Join(fakeSysfs, name)
+		Expect(os.MkdirAll(devicePath, 0755)).To(Succeed())
+		for attribute, value := range attributes {
+			Expect(ioutil.WriteFile(filepath.Join(devicePath, attribute), []byte(value+"\n"), 0644)).To(Succeed())
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		fakeSysfs, err = ioutil.TempDir("", "usb")
+		Expect(err).ToNot(HaveOccurred())
+		originalUSBBasePath = usbBasePath
+		usbBasePath = fakeSysfs
+
+		createFakeUSBDevice("1-1", map[string]string{"idVendor": "046D", "idProduct": "c52b", "busnum": "1", "devnum": "4"})
+		createFakeUSBDevice("1-2", map[string]string{"idVendor": "046d", "idProduct": "c52b", "busnum": "1", "devnum": "12"})
+		createFakeUSBDevice("2-1", map[string]string{"idVendor": "1050", "idProduct": "0407", "busnum": "2", "devnum": "3"})
+		// interfaces do not have a vendor and product and must be ignored
+		createFakeUSBDevice("1-1:1.0", map[string]string{"bInterfaceClass": "03"})
+	})
+
+	AfterEach(func() {
+		usbBasePath = originalUSBBasePath
+		os.RemoveAll(fakeSysfs)
+	})
+
+	It("should discover the permitted USB devices grouped by resource name", func() {
+		usbDevices := discoverPermittedHostUSBDevices(map[string]string{"046d:c52b": "example.org/receiver"})
+		Expect(usbDevices).To(HaveLen(1))
+		Expect(usbDevices["example.org/receiver"]).To(ConsistOf(
+			&USBDevice{vendor: "046d", product: "c52b", bus: 1, device: 4},
+			&USBDevice{vendor: "046d", product: "c52b", bus: 1, device: 12},
+		))
+	})
+
+	It("should not discover devices which are not permitted", func() {
+		Expect(discoverPermittedHostUSBDevices(map[string]string{"dead:beef": "example.org/deadbeef"})).To(BeEmpty())
+	})
+
+	It("should allocate the device nodes and expose their bus and device numbers", func() {
+		usbDevices := discoverPermittedHostUSBDevices(map[string]string{"1050:0407": "example.org/yubikey"})
+		dpi := NewUSBDevicePlugin(usbDevices["example.org/yubikey"], "example.org/yubikey")
+		Expect(dpi.devs).To(HaveLen(1))
+		Expect(dpi.devs[0].ID).To(Equal("usb-002-003"))
+
+		resp, err := dpi.Allocate(nil, &pluginapi.AllocateRequest{
+			ContainerRequests: []*pluginapi.ContainerAllocateRequest{{DevicesIDs: []string{"usb-002-003"}}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.ContainerResponses).To(HaveLen(1))
+		Expect(resp.ContainerResponses[0].Devices).To(ConsistOf(&pluginapi.DeviceSpec{
+			HostPath:      "/dev/bus/usb/002/003",
+			ContainerPath: "/dev/bus/usb/002/003",
+			Permissions:   "mrw",
+		}))
+		envVar := util.ResourceNameToEnvVar(USB_RESOURCE_PREFIX, "example.org/yubikey")
+		Expect(resp.ContainerResponses[0].Envs).To(HaveKeyWithValue(envVar, "2:3"))
+	})
+})
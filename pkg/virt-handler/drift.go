@@ -0,0 +1,203 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package virthandler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	k8sv1 "k8s.io/api/core/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+// libvirt aligns the guest memory, so smaller differences are not reported as drift
+const memoryDriftTolerance = 1024 * 1024
+
+var memoryUnits = map[string]uint64{
+	"":      1024,
+	"b":     1,
+	"bytes": 1,
+	"KB":    1000,
+	"k":     1024,
+	"KiB":   1024,
+	"MB":    1000 * 1000,
+	"M":     1024 * 1024,
+	"MiB":   1024 * 1024,
+	"GB":    1000 * 1000 * 1000,
+	"G":     1024 * 1024 * 1024,
+	"GiB":   1024 * 1024 * 1024,
+	"TB":    1000 * 1000 * 1000 * 1000,
+	"T":     1024 * 1024 * 1024 * 1024,
+	"TiB":   1024 * 1024 * 1024 * 1024,
+}
+
+// detectDomainDrift compares the running domain with the VMI spec and returns the VMI spec
+// fields which are not reflected by the domain. Devices which are in the middle of a hotplug
+// operation are not taken into account.
+func detectDomainDrift(vmi *v1.VirtualMachineInstance, domain *api.Domain) []string {
+	var drifted []string
+
+	drifted = append(drifted, detectCPUDrift(vmi, domain)...)
+	drifted = append(drifted, detectMemoryDrift(vmi, domain)...)
+	drifted = append(drifted, detectDiskDrift(vmi, domain)...)
+	drifted = append(drifted, detectInterfaceDrift(vmi, domain)...)
+
+	return drifted
+}
+
+func detectCPUDrift(vmi *v1.VirtualMachineInstance, domain *api.Domain) (drifted []string) {
+	cpu := vmi.Spec.Domain.CPU
+	if cpu == nil || domain.Spec.CPU.Topology == nil {
+		return nil
+	}
+	topology := domain.Spec.CPU.Topology
+	if cpu.Sockets != 0 && cpu.Sockets != topology.Sockets {
+		drifted = append(drifted, "spec.domain.cpu.sockets")
+	}
+	if cpu.Cores != 0 && cpu.Cores != topology.Cores {
+		drifted = append(drifted, "spec.domain.cpu.cores")
+	}
+	if cpu.Threads != 0 && cpu.Threads != topology.Threads {
+		drifted = append(drifted, "spec.domain.cpu.threads")
+	}
+	return drifted
+}
+
+func detectMemoryDrift(vmi *v1.VirtualMachineInstance, domain *api.Domain) []string {
+	field := "spec.domain.memory.guest"
+	expected := vmi.Spec.Domain.Memory
+	var guestMemory int64
+	if expected != nil && expected.Guest != nil {
+		guestMemory = expected.Guest.Value()
+	} else if limit, ok := vmi.Spec.Domain.Resources.Limits[k8sv1.ResourceMemory]; ok {
+		field = "spec.domain.resources.limits.memory"
+		guestMemory = limit.Value()
+	} else if request, ok := vmi.Spec.Domain.Resources.Requests[k8sv1.ResourceMemory]; ok {
+		field = "spec.domain.resources.requests.memory"
+		guestMemory = request.Value()
+	}
+
+	unit, known := memoryUnits[domain.Spec.Memory.Unit]
+	if guestMemory <= 0 || domain.Spec.Memory.Value == 0 || !known {
+		return nil
+	}
+
+	diff := int64(domain.Spec.Memory.Value*unit) - guestMemory
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff >= memoryDriftTolerance {
+		return []string{field}
+	}
+	return nil
+}
+
+func detectDiskDrift(vmi *v1.VirtualMachineInstance, domain *api.Domain) (drifted []string) {
+	// hotplug volumes which are not ready yet, or which are being removed, are still in transition
+	inTransition := map[string]bool{}
+	for _, status := range vmi.Status.VolumeStatus {
+		if status.HotplugVolume != nil && status.Phase != v1.VolumeReady {
+			inTransition[status.Name] = true
+		}
+	}
+	for _, volume := range vmi.Spec.Volumes {
+		if isHotplugVolumeSource(&volume) && !hasVolumeStatus(vmi, volume.Name) {
+			inTransition[volume.Name] = true
+		}
+	}
+
+	domainDisks := map[string]bool{}
+	for _, disk := range domain.Spec.Devices.Disks {
+		if disk.Alias != nil {
+			domainDisks[disk.Alias.GetName()] = true
+		}
+	}
+	specDisks := map[string]bool{}
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		specDisks[disk.Name] = true
+		if !domainDisks[disk.Name] && !inTransition[disk.Name] {
+			drifted = append(drifted, fmt.Sprintf("spec.domain.devices.disks[name=%s]", disk.Name))
+		}
+	}
+	var unexpected []string
+	for name := range domainDisks {
+		if !specDisks[name] && !inTransition[name] && !hasVolumeStatus(vmi, name) {
+			unexpected = append(unexpected, fmt.Sprintf("domain.devices.disks[name=%s]", name))
+		}
+	}
+	sort.Strings(unexpected)
+
+	return append(drifted, unexpected...)
+}
+
+func detectInterfaceDrift(vmi *v1.VirtualMachineInstance, domain *api.Domain) (drifted []string) {
+	domainInterfaces := map[string]bool{}
+	for _, iface := range domain.Spec.Devices.Interfaces {
+		if iface.Alias != nil {
+			domainInterfaces[iface.Alias.GetName()] = true
+		}
+	}
+	specInterfaces := map[string]bool{}
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		// SR-IOV interfaces are attached as host devices
+		if iface.SRIOV != nil {
+			continue
+		}
+		specInterfaces[iface.Name] = true
+		if !domainInterfaces[iface.Name] {
+			drifted = append(drifted, fmt.Sprintf("spec.domain.devices.interfaces[name=%s]", iface.Name))
+		}
+	}
+	var unexpected []string
+	for name := range domainInterfaces {
+		if !specInterfaces[name] {
+			unexpected = append(unexpected, fmt.Sprintf("domain.devices.interfaces[name=%s]", name))
+		}
+	}
+	sort.Strings(unexpected)
+
+	return append(drifted, unexpected...)
+}
+
+func isHotplugVolumeSource(volume *v1.Volume) bool {
+	return (volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.Hotpluggable) ||
+		(volume.DataVolume != nil && volume.DataVolume.Hotpluggable)
+}
+
+func hasVolumeStatus(vmi *v1.VirtualMachineInstance, name string) bool {
+	for _, status := range vmi.Status.VolumeStatus {
+		if status.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func newDriftCondition(drifted []string) *v1.VirtualMachineInstanceCondition {
+	return &v1.VirtualMachineInstanceCondition{
+		Type:    v1.VirtualMachineInstanceDriftDetected,
+		Status:  k8sv1.ConditionTrue,
+		Reason:  v1.DomainDriftedReason,
+		Message: fmt.Sprintf("The running domain differs from the VMI: %s", strings.Join(drifted, ", ")),
+	}
+}
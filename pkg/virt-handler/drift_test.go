@@ -0,0 +1,109 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package virthandler
+
+import (
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+var _ = Describe("Domain drift", func() {
+	var vmi *v1.VirtualMachineInstance
+	var domain *api.Domain
+
+	BeforeEach(func() {
+		vmi = v1.NewMinimalVMI("testvmi")
+		guest := resource.MustParse("1Gi")
+		vmi.Spec.Domain.Memory = &v1.Memory{Guest: &guest}
+		vmi.Spec.Domain.CPU = &v1.CPU{Sockets: 1, Cores: 2, Threads: 1}
+		vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "rootdisk"}}
+		vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "default"}}
+
+		domain = api.NewMinimalDomain("testvmi")
+		domain.Spec.Memory = api.Memory{Value: 1048576, Unit: "KiB"}
+		domain.Spec.CPU.Topology = &api.CPUTopology{Sockets: 1, Cores: 2, Threads: 1}
+		domain.Spec.Devices.Disks = []api.Disk{{Alias: api.NewUserDefinedAlias("rootdisk")}}
+		domain.Spec.Devices.Interfaces = []api.Interface{{Alias: api.NewUserDefinedAlias("default")}}
+	})
+
+	It("should not report drift if the domain matches the VMI", func() {
+		Expect(detectDomainDrift(vmi, domain)).To(BeEmpty())
+	})
+
+	It("should tolerate memory alignment done by libvirt", func() {
+		domain.Spec.Memory = api.Memory{Value: 1048576 + 512, Unit: "KiB"}
+		Expect(detectDomainDrift(vmi, domain)).To(BeEmpty())
+	})
+
+	table.DescribeTable("should report differing fields", func(modify func(*api.Domain), expected ...string) {
+		modify(domain)
+		Expect(detectDomainDrift(vmi, domain)).To(Equal(expected))
+	},
+		table.Entry("with different cores", func(d *api.Domain) {
+			d.Spec.CPU.Topology.Cores = 4
+		}, "spec.domain.cpu.cores"),
+		table.Entry("with different memory", func(d *api.Domain) {
+			d.Spec.Memory = api.Memory{Value: 2, Unit: "GiB"}
+		}, "spec.domain.memory.guest"),
+		table.Entry("with a missing disk", func(d *api.Domain) {
+			d.Spec.Devices.Disks = nil
+		}, "spec.domain.devices.disks[name=rootdisk]"),
+		table.Entry("with an unexpected disk", func(d *api.Domain) {
+			d.Spec.Devices.Disks = append(d.Spec.Devices.Disks, api.Disk{Alias: api.NewUserDefinedAlias("manual")})
+		}, "domain.devices.disks[name=manual]"),
+		table.Entry("with a missing interface", func(d *api.Domain) {
+			d.Spec.Devices.Interfaces = nil
+		}, "spec.domain.devices.interfaces[name=default]"),
+		table.Entry("with an unexpected interface", func(d *api.Domain) {
+			d.Spec.Devices.Interfaces = append(d.Spec.Devices.Interfaces, api.Interface{Alias: api.NewUserDefinedAlias("manual")})
+		}, "domain.devices.interfaces[name=manual]"),
+	)
+
+	It("should ignore hotplug volumes which are still being attached or detached", func() {
+		vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{Name: "attaching"})
+		vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+			Name: "attaching",
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{Hotpluggable: true},
+			},
+		})
+		domain.Spec.Devices.Disks = append(domain.Spec.Devices.Disks, api.Disk{Alias: api.NewUserDefinedAlias("detaching")})
+		vmi.Status.VolumeStatus = []v1.VolumeStatus{{
+			Name:          "detaching",
+			Phase:         v1.HotplugVolumeDetaching,
+			HotplugVolume: &v1.HotplugVolumeStatus{},
+		}}
+		Expect(detectDomainDrift(vmi, domain)).To(BeEmpty())
+	})
+
+	It("should ignore SR-IOV interfaces", func() {
+		vmi.Spec.Domain.Devices.Interfaces = append(vmi.Spec.Domain.Devices.Interfaces, v1.Interface{
+			Name:                   "sriov",
+			InterfaceBindingMethod: v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}},
+		})
+		Expect(detectDomainDrift(vmi, domain)).To(BeEmpty())
+	})
+})
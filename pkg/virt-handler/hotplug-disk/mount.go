@@ -245,6 +245,11 @@ func (m *volumeMounter) mountHotplugVolume(vmi *v1.VirtualMachineInstance, volum
 			if err := m.mountBlockHotplugVolume(vmi, volumeName, sourceUID, record); err != nil {
 				return err
 			}
+		} else if isMemoryDumpVolume(vmi, volumeName) {
+			logger.V(4).Infof("Mounting memory dump volume: %s", volumeName)
+			if err := m.mountMemoryDumpHotplugVolume(vmi, volumeName, sourceUID, record); err != nil {
+				return err
+			}
 		} else {
 			logger.V(4).Infof("Mounting file system volume: %s", volumeName)
 			if err := m.mountFileSystemHotplugVolume(vmi, volumeName, sourceUID, record); err != nil {
@@ -588,6 +593,47 @@ func (m *volumeMounter) mountFileSystemHotplugVolume(vmi *v1.VirtualMachineInsta
 	return nil
 }
 
+// mountMemoryDumpHotplugVolume bind mounts the whole file system of the volume, the memory dump is written
+// into it as a new file.
+func (m *volumeMounter) mountMemoryDumpHotplugVolume(vmi *v1.VirtualMachineInstance, volume string, sourceUID types.UID, record *vmiMountTargetRecord) error {
+	virtlauncherUID := m.findVirtlauncherUID(vmi)
+	if virtlauncherUID == "" {
+		// This is not the node the pod is running on.
+		return nil
+	}
+	targetDir, err := m.hotplugDiskManager.GetFileSystemDirectoryTargetPathFromHostView(virtlauncherUID, volume, true)
+	if err != nil {
+		return err
+	}
+
+	if isMounted, err := isMounted(targetDir); err != nil {
+		return fmt.Errorf("failed to determine if %s is already mounted: %v", targetDir, err)
+	} else if !isMounted {
+		sourcePath, err := m.getSourcePodFilePath(sourceUID, vmi, volume)
+		if err != nil {
+			log.DefaultLogger().V(3).Infof("Error getting source path: %v", err)
+			// The volume might not be mounted on the node yet, see mountFileSystemHotplugVolume
+			return nil
+		}
+		if err := m.writePathToMountRecord(targetDir, vmi, record); err != nil {
+			return err
+		}
+		if out, err := mountCommand(sourcePath, targetDir); err != nil {
+			return fmt.Errorf("failed to bindmount memory dump volume %v: %v : %v", volume, string(out), err)
+		}
+	}
+	return nil
+}
+
+func isMemoryDumpVolume(vmi *v1.VirtualMachineInstance, volumeName string) bool {
+	for _, volumeStatus := range vmi.Status.VolumeStatus {
+		if volumeStatus.Name == volumeName {
+			return volumeStatus.MemoryDumpVolume != nil
+		}
+	}
+	return false
+}
+
 func (m *volumeMounter) findVirtlauncherUID(vmi *v1.VirtualMachineInstance) (uid types.UID) {
 	cnt := 0
 	for podUID := range vmi.Status.ActivePods {
@@ -671,6 +717,12 @@ func (m *volumeMounter) Unmount(vmi *v1.VirtualMachineInstance) error {
 			if m.isBlockVolume(volumeStatus.HotplugVolume.AttachPodUID, volumeStatus.Name) {
 				path := filepath.Join(basePath, volumeStatus.Name)
 				currentHotplugPaths[path] = virtlauncherUID
+			} else if volumeStatus.MemoryDumpVolume != nil {
+				path, err := m.hotplugDiskManager.GetFileSystemDirectoryTargetPathFromHostView(virtlauncherUID, volumeStatus.Name, false)
+				if err != nil {
+					return err
+				}
+				currentHotplugPaths[path] = virtlauncherUID
 			} else {
 				path, err := m.hotplugDiskManager.GetFileSystemDiskTargetPathFromHostView(virtlauncherUID, volumeStatus.Name, false)
 				if err != nil {
@@ -801,5 +853,8 @@ func (m *volumeMounter) IsMounted(vmi *v1.VirtualMachineInstance, volume string,
 		isBlockExists, _ := isBlockDevice(deviceName)
 		return isBlockExists, nil
 	}
+	if isMemoryDumpVolume(vmi, volume) {
+		return isMounted(filepath.Join(targetPath, volume))
+	}
 	return isMounted(filepath.Join(targetPath, fmt.Sprintf("%s.img", volume)))
 }
@@ -0,0 +1,205 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package virthandler
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/controller"
+	virtutil "kubevirt.io/kubevirt/pkg/util"
+	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+)
+
+const (
+	//MemoryDumpInProgressReason is the reason set when the memory dump is written to the volume
+	MemoryDumpInProgressReason = "MemoryDumpInProgress"
+	//MemoryDumpCompletedReason is the reason set when the memory dump has been written to the volume
+	MemoryDumpCompletedReason = "MemoryDumpCompleted"
+	//MemoryDumpFailedReason is the reason set when the memory dump could not be written to the volume
+	MemoryDumpFailedReason = "MemoryDumpFailed"
+)
+
+type memoryDumpRecord struct {
+	startTimestamp metav1.Time
+	endTimestamp   *metav1.Time
+	fileName       string
+	err            error
+}
+
+// memoryDumps tracks the memory dumps which are written to hotplugged memory dump volumes
+type memoryDumps struct {
+	lock    sync.Mutex
+	records map[string]*memoryDumpRecord
+}
+
+func newMemoryDumps() *memoryDumps {
+	return &memoryDumps{
+		records: make(map[string]*memoryDumpRecord),
+	}
+}
+
+func memoryDumpKey(vmi *v1.VirtualMachineInstance, volumeName string) string {
+	return fmt.Sprintf("%s/%s", vmi.UID, volumeName)
+}
+
+func (m *memoryDumps) get(vmi *v1.VirtualMachineInstance, volumeName string) (memoryDumpRecord, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	record, exists := m.records[memoryDumpKey(vmi, volumeName)]
+	if !exists {
+		return memoryDumpRecord{}, false
+	}
+	return *record, true
+}
+
+// start registers a new memory dump, it returns false if a memory dump was already taken for the volume
+func (m *memoryDumps) start(vmi *v1.VirtualMachineInstance, volumeName string) (*memoryDumpRecord, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	key := memoryDumpKey(vmi, volumeName)
+	if _, exists := m.records[key]; exists {
+		return nil, false
+	}
+	now := metav1.Now()
+	record := &memoryDumpRecord{
+		startTimestamp: now,
+		fileName:       fmt.Sprintf("%s-%s-%s.memory.dump", vmi.Name, volumeName, now.UTC().Format("20060102-150405")),
+	}
+	m.records[key] = record
+	return record, true
+}
+
+func (m *memoryDumps) finish(record *memoryDumpRecord, err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	now := metav1.Now()
+	record.endTimestamp = &now
+	record.err = err
+}
+
+// forget drops the records of all volumes of the VMI which are not in volumeNames
+func (m *memoryDumps) forget(vmi *v1.VirtualMachineInstance, volumeNames map[string]struct{}) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	prefix := fmt.Sprintf("%s/", vmi.UID)
+	for key := range m.records {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if _, exists := volumeNames[strings.TrimPrefix(key, prefix)]; !exists {
+			delete(m.records, key)
+		}
+	}
+}
+
+// memoryDumpPathOnGuest returns the location of the memory dump on the volume inside the virt-launcher pod
+func memoryDumpPathOnGuest(volumeName, fileName string) string {
+	return filepath.Join(virtutil.VirtShareDir, "hotplug-disks", volumeName, fileName)
+}
+
+// handleMemoryDumps triggers a memory dump for every memory dump volume which got mounted into the
+// virt-launcher pod. The memory dump is taken in the background, the VMI is enqueued once it finished.
+func (d *VirtualMachineController) handleMemoryDumps(vmi *v1.VirtualMachineInstance, client cmdclient.LauncherClient) {
+	volumeNames := make(map[string]struct{})
+	for _, volumeStatus := range vmi.Status.VolumeStatus {
+		if volumeStatus.MemoryDumpVolume == nil {
+			continue
+		}
+		volumeNames[volumeStatus.Name] = struct{}{}
+		if volumeStatus.Phase != v1.HotplugVolumeMounted {
+			continue
+		}
+		record, started := d.memoryDumps.start(vmi, volumeStatus.Name)
+		if !started {
+			continue
+		}
+		go func(vmi *v1.VirtualMachineInstance, volumeName string) {
+			log.Log.Object(vmi).Infof("Writing memory dump to volume %s", volumeName)
+			err := client.VirtualMachineMemoryDump(vmi, memoryDumpPathOnGuest(volumeName, record.fileName))
+			if err != nil {
+				log.Log.Object(vmi).Reason(err).Errorf("Failed to write memory dump to volume %s", volumeName)
+			}
+			d.memoryDumps.finish(record, err)
+			d.Queue.Add(controller.VirtualMachineInstanceKey(vmi))
+		}(vmi.DeepCopy(), volumeStatus.Name)
+	}
+	d.memoryDumps.forget(vmi, volumeNames)
+}
+
+// updateMemoryDumpVolumeStatus reports the progress of the memory dump taken for a memory dump volume
+func (d *VirtualMachineController) updateMemoryDumpVolumeStatus(vmi *v1.VirtualMachineInstance, volumeStatus v1.VolumeStatus) v1.VolumeStatus {
+	record, exists := d.memoryDumps.get(vmi, volumeStatus.Name)
+	if !exists {
+		if volumeStatus.Phase == v1.MemoryDumpVolumeInProgress {
+			return interruptedMemoryDumpVolumeStatus(volumeStatus)
+		}
+		return volumeStatus
+	}
+	if !canUpdateMemoryDumpPhase(volumeStatus.Phase) {
+		return volumeStatus
+	}
+
+	memoryDumpInfo := volumeStatus.MemoryDumpVolume.DeepCopy()
+	memoryDumpInfo.StartTimestamp = &record.startTimestamp
+	memoryDumpInfo.TargetFileName = record.fileName
+	switch {
+	case record.endTimestamp == nil:
+		volumeStatus.Phase = v1.MemoryDumpVolumeInProgress
+		volumeStatus.Message = fmt.Sprintf("Memory dump of volume %s is in progress", volumeStatus.Name)
+		volumeStatus.Reason = MemoryDumpInProgressReason
+	case record.err != nil:
+		memoryDumpInfo.EndTimestamp = record.endTimestamp
+		volumeStatus.Phase = v1.MemoryDumpVolumeFailed
+		volumeStatus.Message = fmt.Sprintf("Memory dump to volume %s failed: %v", volumeStatus.Name, record.err)
+		volumeStatus.Reason = MemoryDumpFailedReason
+	default:
+		memoryDumpInfo.EndTimestamp = record.endTimestamp
+		volumeStatus.Phase = v1.MemoryDumpVolumeCompleted
+		volumeStatus.Message = fmt.Sprintf("Memory dump to volume %s has completed successfully", volumeStatus.Name)
+		volumeStatus.Reason = MemoryDumpCompletedReason
+	}
+	volumeStatus.MemoryDumpVolume = memoryDumpInfo
+	return volumeStatus
+}
+
+// interruptedMemoryDumpVolumeStatus fails a memory dump which is in progress according to the status
+// but is not tracked, because virt-handler restarted while it was written. Waiting for the dump
+// would block the volume forever, since nothing reports its end anymore.
+func interruptedMemoryDumpVolumeStatus(volumeStatus v1.VolumeStatus) v1.VolumeStatus {
+	now := metav1.Now()
+	memoryDumpInfo := volumeStatus.MemoryDumpVolume.DeepCopy()
+	memoryDumpInfo.EndTimestamp = &now
+	volumeStatus.MemoryDumpVolume = memoryDumpInfo
+	volumeStatus.Phase = v1.MemoryDumpVolumeFailed
+	volumeStatus.Message = fmt.Sprintf("Memory dump to volume %s was interrupted by a restart of virt-handler", volumeStatus.Name)
+	volumeStatus.Reason = MemoryDumpFailedReason
+	return volumeStatus
+}
+
+func canUpdateMemoryDumpPhase(currentPhase v1.VolumePhase) bool {
+	return currentPhase == v1.HotplugVolumeMounted || currentPhase == v1.MemoryDumpVolumeInProgress
+}
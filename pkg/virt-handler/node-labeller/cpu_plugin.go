@@ -84,7 +84,7 @@ func (n *NodeLabeller) getHostCpuModel() hostCPUModel {
 	return n.hostCPUModel
 }
 
-//loadDomCapabilities loads info about cpu models, which can host emulate
+//loadDomCapabilities loads info about cpu models and disk buses, which can host emulate
 func (n *NodeLabeller) loadDomCapabilities() error {
 	hostDomCapabilities, err := n.getDomCapabilities()
 	if err != nil {
@@ -123,6 +123,14 @@ func (n *NodeLabeller) loadDomCapabilities() error {
 
 	n.hostCapabilities.items = usableModels
 
+	diskBuses := make([]string, 0)
+	for _, enum := range hostDomCapabilities.Devices.Disk.Enum {
+		if enum.Name == "bus" {
+			diskBuses = append(diskBuses, enum.Value...)
+		}
+	}
+	n.diskBuses = diskBuses
+
 	return nil
 }
 
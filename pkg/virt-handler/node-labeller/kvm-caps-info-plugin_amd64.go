@@ -33,6 +33,7 @@ const int CapHypervTlbflush = KVM_CAP_HYPERV_TLBFLUSH;
 const int CapHypervSendIPI = KVM_CAP_HYPERV_SEND_IPI;
 const int CapHypervSynic = KVM_CAP_HYPERV_SYNIC;
 const int CapHypervSynic2 = KVM_CAP_HYPERV_SYNIC2;
+const int CapHypervEnlightenedVMCS = KVM_CAP_HYPERV_ENLIGHTENED_VMCS;
 __u32 msr_list_get(void* data, int index) {
 	struct kvm_msr_list *msrs = (struct kvm_msr_list*)data;
 	return msrs->indices[index];
@@ -107,6 +108,10 @@ var CapsDesc = []capability{
 		MSR:       HV_X64_MSR_SCONTROL,
 		Name:      "synic2",
 	},
+	{
+		Extension: uintptr(C.CapHypervEnlightenedVMCS),
+		Name:      "evmcs",
+	},
 	{
 		MSR:  HV_X64_MSR_TSC_FREQUENCY,
 		Name: "frequencies",
@@ -38,7 +38,25 @@ type cpuInfo struct {
 
 //HostDomCapabilities represents structure for parsing output of virsh capabilities
 type HostDomCapabilities struct {
-	CPU CPU `xml:"cpu"`
+	CPU     CPU     `xml:"cpu"`
+	Devices Devices `xml:"devices"`
+}
+
+//Devices represents the devices which the host can emulate
+type Devices struct {
+	Disk DeviceEnums `xml:"disk"`
+}
+
+//DeviceEnums represents the supported values of the attributes of a device
+type DeviceEnums struct {
+	Supported string `xml:"supported,attr"`
+	Enum      []Enum `xml:"enum"`
+}
+
+//Enum represents the supported values of an attribute
+type Enum struct {
+	Name  string   `xml:"name,attr"`
+	Value []string `xml:"value"`
 }
 
 //CPU represents slice of cpu modes
@@ -59,6 +59,7 @@ type NodeLabeller struct {
 	domCapabilitiesFileName string
 	capabilities            *api.Capabilities
 	hostCPUModel            hostCPUModel
+	diskBuses               []string
 }
 
 func NewNodeLabeller(clusterConfig *virtconfig.ClusterConfig, clientset kubecli.KubevirtClient, host, namespace string) (*NodeLabeller, error) {
@@ -236,7 +237,7 @@ func (n *NodeLabeller) loadHypervFeatures() {
 	n.hypervFeatures.items = getCapLabels()
 }
 
-// prepareLabels converts cpu models, features, hyperv features to map[string]string format
+// prepareLabels converts cpu models, features, hyperv features, disk buses to map[string]string format
 // e.g. "cpu-feature.node.kubevirt.io/Penryn": "true"
 func (n *NodeLabeller) prepareLabels(cpuModels []string, cpuFeatures cpuFeatures, hostCpuModel hostCPUModel) map[string]string {
 	newLabels := make(map[string]string)
@@ -263,6 +264,10 @@ func (n *NodeLabeller) prepareLabels(cpuModels []string, cpuFeatures cpuFeatures
 		newLabels[kubevirtv1.HostModelRequiredFeaturesLabel+feature] = "true"
 	}
 
+	for _, bus := range n.diskBuses {
+		newLabels[kubevirtv1.DiskBusLabel+bus] = "true"
+	}
+
 	newLabels[kubevirtv1.CPUModelVendorLabel+n.cpuModelVendor] = "true"
 	newLabels[kubevirtv1.HostModelCPULabel+hostCpuModel.name] = "true"
 
@@ -290,7 +295,8 @@ func (n *NodeLabeller) removeLabellerLabels(node *v1.Node) {
 			strings.Contains(label, kubevirtv1.CPUFeatureLabel) ||
 			strings.Contains(label, kubevirtv1.CPUModelLabel) ||
 			strings.Contains(label, kubevirtv1.CPUTimerLabel) ||
-			strings.Contains(label, kubevirtv1.HypervLabel) {
+			strings.Contains(label, kubevirtv1.HypervLabel) ||
+			strings.Contains(label, kubevirtv1.DiskBusLabel) {
 			delete(node.Labels, label)
 		}
 	}
@@ -117,6 +117,11 @@ var _ = Describe("Node-labeller ", func() {
 		res := nlController.execute()
 		Expect(res).To(BeTrue())
 	})
+	It("should add the supported disk buses", func() {
+		expectNodePatch(kubevirtv1.DiskBusLabel + "nvme")
+		res := nlController.execute()
+		Expect(res).To(BeTrue())
+	})
 	It("should add host cpu required features", func() {
 		expectNodePatch(kubevirtv1.HostModelRequiredFeaturesLabel)
 		res := nlController.execute()
@@ -133,6 +133,7 @@ func RunServer(virtShareDir string, stopChan chan struct{}, c chan watch.Event,
 	// register more versions as soon as needed
 	// and add them to info.go
 	notifyv1.RegisterNotifyServer(grpcServer, notifyServer)
+	healthServer := grpcutil.RegisterHealthAndReflectionServers(grpcServer)
 
 	sockFile := filepath.Join(virtShareDir, "domain-notify.sock")
 	sock, err := grpcutil.CreateSocket(sockFile)
@@ -156,6 +157,7 @@ func RunServer(virtShareDir string, stopChan chan struct{}, c chan watch.Event,
 	case <-done:
 		log.Log.Info("notify server done")
 	case <-stopChan:
+		healthServer.Shutdown()
 		grpcServerStop(grpcServer)
 	}
 
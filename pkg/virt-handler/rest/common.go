@@ -22,8 +22,12 @@ package rest
 import (
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
 
 	"github.com/emicklei/go-restful"
+	"golang.org/x/sys/unix"
 
 	"k8s.io/client-go/tools/cache"
 
@@ -41,3 +45,67 @@ func getVMI(request *restful.Request, vmiInformer cache.SharedIndexInformer) (*v
 	}
 	return vmiObj.(*v1.VirtualMachineInstance), 0, nil
 }
+
+// openInLauncherRoot opens a regular file of the virt-launcher pod with the given pid for reading.
+// The path is resolved as if the root of the pod was the root of the filesystem and symlinks in
+// the last path element are not followed, so that the pod can't point virt-handler at host files.
+func openInLauncherRoot(pid int, path string) (*os.File, error) {
+	root, err := openLauncherRoot(pid)
+	if err != nil {
+		return nil, err
+	}
+	defer root.Close()
+
+	fd, err := unix.Openat2(int(root.Fd()), path, &unix.OpenHow{
+		Flags:   unix.O_RDONLY | unix.O_NOFOLLOW | unix.O_NONBLOCK | unix.O_CLOEXEC,
+		Resolve: unix.RESOLVE_IN_ROOT | unix.RESOLVE_NO_MAGICLINKS,
+	})
+	if err != nil {
+		return nil, &os.PathError{Op: "openat2", Path: path, Err: err}
+	}
+	file := os.NewFile(uintptr(fd), path)
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		file.Close()
+		return nil, fmt.Errorf("%s is not a regular file", path)
+	}
+	return file, nil
+}
+
+// removeInLauncherRoot removes a file of the virt-launcher pod with the given pid. Its directory
+// is resolved the same way as in openInLauncherRoot and a symlink is removed itself instead of its target.
+func removeInLauncherRoot(pid int, path string) error {
+	root, err := openLauncherRoot(pid)
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+
+	dirFd, err := unix.Openat2(int(root.Fd()), filepath.Dir(path), &unix.OpenHow{
+		Flags:   unix.O_PATH | unix.O_DIRECTORY | unix.O_CLOEXEC,
+		Resolve: unix.RESOLVE_IN_ROOT | unix.RESOLVE_NO_MAGICLINKS,
+	})
+	if err != nil {
+		return &os.PathError{Op: "openat2", Path: filepath.Dir(path), Err: err}
+	}
+	defer unix.Close(dirFd)
+
+	if err := unix.Unlinkat(dirFd, filepath.Base(path), 0); err != nil {
+		return &os.PathError{Op: "unlinkat", Path: path, Err: err}
+	}
+	return nil
+}
+
+func openLauncherRoot(pid int) (*os.File, error) {
+	rootPath := filepath.Join("/proc", strconv.Itoa(pid), "root")
+	fd, err := unix.Open(rootPath, unix.O_PATH|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: rootPath, Err: err}
+	}
+	return os.NewFile(uintptr(fd), rootPath), nil
+}
@@ -48,6 +48,8 @@ type ConsoleHandler struct {
 	vmiInformer          cache.SharedIndexInformer
 	usbredir             map[types.UID]UsbredirHandlerVMI
 	usbredirLock         *sync.Mutex
+	consoleLogs          map[types.UID]*consoleLog
+	consoleLogLock       *sync.Mutex
 }
 
 type UsbredirHandlerVMI struct {
@@ -55,7 +57,7 @@ type UsbredirHandlerVMI struct {
 }
 
 func NewConsoleHandler(podIsolationDetector isolation.PodIsolationDetector, vmiInformer cache.SharedIndexInformer) *ConsoleHandler {
-	return &ConsoleHandler{
+	handler := &ConsoleHandler{
 		podIsolationDetector: podIsolationDetector,
 		serialStopChans:      make(map[types.UID](chan struct{})),
 		vncStopChans:         make(map[types.UID](chan struct{})),
@@ -64,7 +66,15 @@ func NewConsoleHandler(podIsolationDetector isolation.PodIsolationDetector, vmiI
 		usbredirLock:         &sync.Mutex{},
 		vmiInformer:          vmiInformer,
 		usbredir:             make(map[types.UID]UsbredirHandlerVMI),
+		consoleLogs:          make(map[types.UID]*consoleLog),
+		consoleLogLock:       &sync.Mutex{},
 	}
+	vmiInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    handler.addVMI,
+		UpdateFunc: handler.updateVMI,
+		DeleteFunc: handler.deleteVMI,
+	})
+	return handler
 }
 
 func (t *ConsoleHandler) USBRedirHandler(request *restful.Request, response *restful.Response) {
@@ -127,7 +137,7 @@ func (t *ConsoleHandler) USBRedirHandler(request *restful.Request, response *res
 		usbHandler := t.usbredir[uid]
 		delete(usbHandler.stopChans, slotId)
 	}()
-	t.stream(vmi, request, response, unixSocketPath, stopChan)
+	t.stream(vmi, request, response, unixSocketPath, stopChan, nil)
 }
 
 func (t *ConsoleHandler) VNCHandler(request *restful.Request, response *restful.Response) {
@@ -146,7 +156,7 @@ func (t *ConsoleHandler) VNCHandler(request *restful.Request, response *restful.
 	uid := vmi.GetUID()
 	stopChn := newStopChan(uid, t.vncLock, t.vncStopChans)
 	defer deleteStopChan(uid, stopChn, t.vncLock, t.vncStopChans)
-	t.stream(vmi, request, response, unixSocketPath, stopChn)
+	t.stream(vmi, request, response, unixSocketPath, stopChn, nil)
 }
 
 func (t *ConsoleHandler) SerialHandler(request *restful.Request, response *restful.Response) {
@@ -156,7 +166,7 @@ func (t *ConsoleHandler) SerialHandler(request *restful.Request, response *restf
 		response.WriteError(code, err)
 		return
 	}
-	unixSocketPath, err := t.getUnixSocketPath(vmi, "virt-serial0")
+	unixSocketPath, err := t.getUnixSocketPath(vmi, serialSocket)
 	if err != nil {
 		log.Log.Object(vmi).Reason(err).Error("Failed finding unix socket for serial console")
 		response.WriteError(http.StatusBadRequest, err)
@@ -164,8 +174,12 @@ func (t *ConsoleHandler) SerialHandler(request *restful.Request, response *restf
 	}
 	uid := vmi.GetUID()
 	stopCh := newStopChan(uid, t.serialLock, t.serialStopChans)
-	defer deleteStopChan(uid, stopCh, t.serialLock, t.serialStopChans)
-	t.stream(vmi, request, response, unixSocketPath, stopCh)
+	stopped := t.stream(vmi, request, response, unixSocketPath, stopCh, t.sessionConsoleLog(vmi))
+	deleteStopChan(uid, stopCh, t.serialLock, t.serialStopChans)
+	if !stopped {
+		// keep recording until the next session connects
+		t.startSerialConsoleRecording(vmi)
+	}
 }
 
 func newStopChan(uid types.UID, lock *sync.Mutex, stopChans map[types.UID](chan struct{})) chan struct{} {
@@ -205,13 +219,15 @@ func (t *ConsoleHandler) getUnixSocketPath(vmi *v1.VirtualMachineInstance, socke
 	return socketPath, nil
 }
 
-func (t *ConsoleHandler) stream(vmi *v1.VirtualMachineInstance, request *restful.Request, response *restful.Response, unixSocketPath string, stopCh chan struct{}) {
+// stream proxies the client websocket connection to the unix socket. The output of the unix socket is
+// additionally written to record, if set. It returns true, if the stream was stopped by stopCh.
+func (t *ConsoleHandler) stream(vmi *v1.VirtualMachineInstance, request *restful.Request, response *restful.Response, unixSocketPath string, stopCh chan struct{}, record io.Writer) bool {
 	var upgrader = kubecli.NewUpgrader()
 	clientSocket, err := upgrader.Upgrade(response.ResponseWriter, request.Request, nil)
 	if err != nil {
 		log.Log.Object(vmi).Reason(err).Error("Failed to upgrade client websocket connection")
 		response.WriteError(http.StatusInternalServerError, err)
-		return
+		return false
 	}
 	defer clientSocket.Close()
 
@@ -222,15 +238,20 @@ func (t *ConsoleHandler) stream(vmi *v1.VirtualMachineInstance, request *restful
 	if err != nil {
 		log.Log.Object(vmi).Reason(err).Errorf("failed to dial unix socket %s", unixSocketPath)
 		response.WriteHeader(http.StatusInternalServerError)
-		return
+		return false
 	}
 	defer fd.Close()
 
 	log.Log.Object(vmi).Infof("Connected to %s", unixSocketPath)
 
+	var src io.Reader = fd
+	if record != nil {
+		src = io.TeeReader(fd, record)
+	}
+
 	errCh := make(chan error, 2)
 	go func() {
-		_, err := kubecli.CopyTo(clientSocket, fd)
+		_, err := kubecli.CopyTo(clientSocket, src)
 		log.Log.Object(vmi).Reason(err).Error("error encountered reading from unix socket")
 		errCh <- err
 	}()
@@ -243,11 +264,12 @@ func (t *ConsoleHandler) stream(vmi *v1.VirtualMachineInstance, request *restful
 
 	select {
 	case <-stopCh:
-		break
+		return true
 	case err := <-errCh:
 		if err != nil && err != io.EOF {
 			log.Log.Object(vmi).Reason(err).Error("Error in proxing websocket and unix socket")
 			response.WriteHeader(http.StatusInternalServerError)
 		}
 	}
+	return false
 }
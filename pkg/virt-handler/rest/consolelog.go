@@ -0,0 +1,223 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/emicklei/go-restful"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	// consoleLogSize bounds the serial console output kept per VMI
+	consoleLogSize = 512 * 1024
+	serialSocket   = "virt-serial0"
+)
+
+// consoleLog is a ring buffer which holds the latest serial console output of a VMI,
+// it grows with the output until it reaches its size
+type consoleLog struct {
+	lock sync.Mutex
+	buf  []byte
+	size int
+	pos  int
+}
+
+func newConsoleLog(size int) *consoleLog {
+	return &consoleLog{size: size}
+}
+
+func (l *consoleLog) Write(p []byte) (int, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	n := len(p)
+	if free := l.size - len(l.buf); free > 0 {
+		if n <= free {
+			l.buf = append(l.buf, p...)
+			return n, nil
+		}
+		l.buf = append(l.buf, p[:free]...)
+		p = p[free:]
+	}
+	// the buffer is full, overwrite the oldest output
+	if len(p) >= l.size {
+		copy(l.buf, p[len(p)-l.size:])
+		l.pos = 0
+		return n, nil
+	}
+	copied := copy(l.buf[l.pos:], p)
+	copy(l.buf, p[copied:])
+	l.pos = (l.pos + len(p)) % l.size
+	return n, nil
+}
+
+// Bytes returns the recorded output, oldest output first
+func (l *consoleLog) Bytes() []byte {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return append(append([]byte{}, l.buf[l.pos:]...), l.buf[:l.pos]...)
+}
+
+func (t *ConsoleHandler) getConsoleLog(uid types.UID) *consoleLog {
+	t.consoleLogLock.Lock()
+	defer t.consoleLogLock.Unlock()
+	if _, exists := t.consoleLogs[uid]; !exists {
+		t.consoleLogs[uid] = newConsoleLog(consoleLogSize)
+	}
+	return t.consoleLogs[uid]
+}
+
+func (t *ConsoleHandler) addVMI(obj interface{}) {
+	vmi, ok := obj.(*v1.VirtualMachineInstance)
+	if !ok {
+		return
+	}
+	t.startSerialConsoleRecording(vmi)
+}
+
+func (t *ConsoleHandler) updateVMI(_, newObj interface{}) {
+	t.addVMI(newObj)
+}
+
+func (t *ConsoleHandler) deleteVMI(obj interface{}) {
+	vmi, ok := obj.(*v1.VirtualMachineInstance)
+	if !ok {
+		return
+	}
+	t.serialLock.Lock()
+	if stopCh, exists := t.serialStopChans[vmi.UID]; exists {
+		delete(t.serialStopChans, vmi.UID)
+		close(stopCh)
+	}
+	t.serialLock.Unlock()
+
+	t.consoleLogLock.Lock()
+	defer t.consoleLogLock.Unlock()
+	delete(t.consoleLogs, vmi.UID)
+}
+
+// startSerialConsoleRecording records the serial console output of a running VMI in the background,
+// unless a session or a recording is already connected to the serial console. The recording starts
+// as soon as the VMI runs on this node, so that the boot messages are kept before anyone attaches.
+func (t *ConsoleHandler) startSerialConsoleRecording(vmi *v1.VirtualMachineInstance) {
+	if vmi.Status.Phase != v1.Running || !recordsSerialConsole(vmi) {
+		return
+	}
+	uid := vmi.GetUID()
+	t.serialLock.Lock()
+	if _, connected := t.serialStopChans[uid]; connected {
+		t.serialLock.Unlock()
+		return
+	}
+	stopCh := make(chan struct{})
+	t.serialStopChans[uid] = stopCh
+	t.serialLock.Unlock()
+
+	go t.recordSerialConsole(vmi, t.getConsoleLog(uid), stopCh)
+}
+
+// recordSerialConsole records the serial console output of the VMI, until a session connects to
+// the serial console or the VMI goes away
+func (t *ConsoleHandler) recordSerialConsole(vmi *v1.VirtualMachineInstance, consoleLog *consoleLog, stopCh chan struct{}) {
+	uid := vmi.GetUID()
+	defer deleteStopChan(uid, stopCh, t.serialLock, t.serialStopChans)
+
+	unixSocketPath, err := t.getUnixSocketPath(vmi, serialSocket)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed finding unix socket for recording the serial console")
+		return
+	}
+	fd, err := net.Dial("unix", unixSocketPath)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("failed to dial unix socket %s for recording", unixSocketPath)
+		return
+	}
+	defer fd.Close()
+
+	log.Log.Object(vmi).V(4).Infof("Recording the serial console in the background")
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(consoleLog, fd)
+		errCh <- err
+	}()
+
+	select {
+	case <-stopCh:
+		log.Log.Object(vmi).V(4).Infof("Stopped the background recording of the serial console")
+	case err := <-errCh:
+		if err != nil {
+			log.Log.Object(vmi).Reason(err).Error("error encountered recording the serial console")
+		}
+	}
+}
+
+// recordsSerialConsole returns true if the VMI has a serial console and opted in to recording it
+func recordsSerialConsole(vmi *v1.VirtualMachineInstance) bool {
+	autoattach := vmi.Spec.Domain.Devices.AutoattachSerialConsole
+	return (autoattach == nil || *autoattach) && vmi.Annotations[v1.SerialConsoleLogAnnotation] == "true"
+}
+
+// sessionConsoleLog returns the console log which the output of a serial console session is recorded in
+// as well, or nil if the VMI does not record its serial console
+func (t *ConsoleHandler) sessionConsoleLog(vmi *v1.VirtualMachineInstance) io.Writer {
+	if !recordsSerialConsole(vmi) {
+		return nil
+	}
+	return t.getConsoleLog(vmi.GetUID())
+}
+
+// SerialLogHandler returns the recorded serial console output of the VMI
+func (t *ConsoleHandler) SerialLogHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, t.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+
+	if !recordsSerialConsole(vmi) {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("the serial console of the VMI is not recorded, set the %s annotation to \"true\" to record it", v1.SerialConsoleLogAnnotation))
+		return
+	}
+
+	t.consoleLogLock.Lock()
+	consoleLog, exists := t.consoleLogs[vmi.GetUID()]
+	t.consoleLogLock.Unlock()
+	if !exists {
+		response.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	response.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	response.WriteHeader(http.StatusOK)
+	if _, err := response.Write(consoleLog.Bytes()); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to write the console log")
+	}
+}
@@ -0,0 +1,78 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Console log", func() {
+
+	write := func(log *consoleLog, data string) {
+		n, err := log.Write([]byte(data))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(n).To(Equal(len(data)))
+	}
+
+	It("should be empty before anything is written", func() {
+		Expect(newConsoleLog(4).Bytes()).To(BeEmpty())
+	})
+
+	table.DescribeTable("should keep the latest output", func(expected string, writes ...string) {
+		log := newConsoleLog(4)
+		for _, data := range writes {
+			write(log, data)
+		}
+		Expect(string(log.Bytes())).To(Equal(expected))
+	},
+		table.Entry("while it is not full", "abc", "a", "bc"),
+		table.Entry("when it is exactly full", "abcd", "ab", "cd"),
+		table.Entry("when a write overflows it", "cdef", "abc", "def"),
+		table.Entry("when a write wraps around the end", "efgh", "abc", "d", "efgh"),
+		table.Entry("when it wraps around several times", "hijk", "abc", "de", "fg", "hi", "jk"),
+		table.Entry("when a single write is larger than the log", "fghi", "ab", "cdefghi"),
+		table.Entry("after a write larger than the log", "ghij", "abcdefg", "hij"),
+	)
+
+	It("should not return its internal buffer", func() {
+		log := newConsoleLog(4)
+		write(log, "abcd")
+		recorded := log.Bytes()
+		write(log, "ef")
+		Expect(string(recorded)).To(Equal("abcd"))
+		Expect(string(log.Bytes())).To(Equal("cdef"))
+	})
+
+	table.DescribeTable("should only record the serial console of VMIs which opted in", func(annotations map[string]string, autoattach *bool, expected bool) {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Annotations = annotations
+		vmi.Spec.Domain.Devices.AutoattachSerialConsole = autoattach
+		Expect(recordsSerialConsole(vmi)).To(Equal(expected))
+	},
+		table.Entry("with the annotation", map[string]string{v1.SerialConsoleLogAnnotation: "true"}, nil, true),
+		table.Entry("not without the annotation", nil, nil, false),
+		table.Entry("not with the annotation set to false", map[string]string{v1.SerialConsoleLogAnnotation: "false"}, nil, false),
+		table.Entry("not without a serial console", map[string]string{v1.SerialConsoleLogAnnotation: "true"}, &[]bool{false}[0], false),
+	)
+})
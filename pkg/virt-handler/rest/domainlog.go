@@ -0,0 +1,113 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/emicklei/go-restful"
+	"k8s.io/client-go/tools/cache"
+
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/qemulog"
+	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+const (
+	// DomainLogLinesParam limits the domain log to its last lines
+	DomainLogLinesParam = "lines"
+	// defaultDomainLogLines is the number of lines returned without the lines parameter
+	defaultDomainLogLines = 100
+)
+
+type DomainLogHandler struct {
+	podIsolationDetector isolation.PodIsolationDetector
+	vmiInformer          cache.SharedIndexInformer
+}
+
+func NewDomainLogHandler(podIsolationDetector isolation.PodIsolationDetector, vmiInformer cache.SharedIndexInformer) *DomainLogHandler {
+	return &DomainLogHandler{
+		podIsolationDetector: podIsolationDetector,
+		vmiInformer:          vmiInformer,
+	}
+}
+
+// DomainLogHandler returns the last lines of the QEMU log of the domain, which libvirt writes
+// when it starts the domain and which holds the errors QEMU reported
+func (h *DomainLogHandler) DomainLogHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, h.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+
+	lines := defaultDomainLogLines
+	if param := request.QueryParameter(DomainLogLinesParam); param != "" {
+		lines, err = strconv.Atoi(param)
+		if err != nil || lines <= 0 {
+			response.WriteError(http.StatusBadRequest, fmt.Errorf("%s must be a positive number", DomainLogLinesParam))
+			return
+		}
+	}
+
+	result, err := h.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to detect the virt-launcher pod")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	tail, err := tailFile(result.Pid(), qemulog.LogFile(api.VMINamespaceKeyFunc(vmi), util.IsNonRootVMI(vmi)), lines)
+	if os.IsNotExist(err) {
+		response.WriteHeader(http.StatusNoContent)
+		return
+	} else if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to read the domain log")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	var data []byte
+	if len(tail) > 0 {
+		data = []byte(strings.Join(tail, "\n") + "\n")
+	}
+	response.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	response.WriteHeader(http.StatusOK)
+	if _, err := response.Write(data); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to write the domain log")
+	}
+}
+
+// tailFile returns the last lines of a file of the virt-launcher pod with the given pid
+func tailFile(pid int, path string, lines int) ([]string, error) {
+	file, err := openInLauncherRoot(pid, path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return qemulog.Tail(file, lines)
+}
@@ -19,14 +19,17 @@
 package rest
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/emicklei/go-restful"
 
 	"k8s.io/client-go/tools/cache"
 
+	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 )
 
 type LifecycleHandler struct {
@@ -168,17 +171,21 @@ func (lh *LifecycleHandler) GetGuestInfo(request *restful.Request, response *res
 	if err != nil {
 		log.Log.Object(vmi).Reason(err).Error("Failed to detect cmd client")
 		response.WriteError(http.StatusInternalServerError, err)
+		return
 	}
 	client, err := cmdclient.NewClient(sockFile)
 	if err != nil {
 		log.Log.Object(vmi).Reason(err).Error("Failed to connect cmd client")
 		response.WriteError(http.StatusInternalServerError, err)
+		return
 	}
+	defer client.Close()
 
 	guestInfo, err := client.GetGuestInfo()
 	if err != nil {
 		log.Log.Object(vmi).Reason(err).Error("Failed to get guest info")
 		response.WriteError(http.StatusInternalServerError, err)
+		return
 	}
 
 	log.Log.Object(vmi).Infof("returning guestinfo :%v", guestInfo)
@@ -199,17 +206,21 @@ func (lh *LifecycleHandler) GetUsers(request *restful.Request, response *restful
 	if err != nil {
 		log.Log.Object(vmi).Reason(err).Error("Failed to detect cmd client")
 		response.WriteError(http.StatusInternalServerError, err)
+		return
 	}
 	client, err := cmdclient.NewClient(sockFile)
 	if err != nil {
 		log.Log.Object(vmi).Reason(err).Error("Failed to connect cmd client")
 		response.WriteError(http.StatusInternalServerError, err)
+		return
 	}
+	defer client.Close()
 
 	userList, err := client.GetUsers()
 	if err != nil {
 		log.Log.Object(vmi).Reason(err).Error("Failed to get user list")
 		response.WriteError(http.StatusInternalServerError, err)
+		return
 	}
 
 	response.WriteEntity(userList)
@@ -229,18 +240,71 @@ func (lh *LifecycleHandler) GetFilesystems(request *restful.Request, response *r
 	if err != nil {
 		log.Log.Object(vmi).Reason(err).Error("Failed to detect cmd client")
 		response.WriteError(http.StatusInternalServerError, err)
+		return
 	}
 	client, err := cmdclient.NewClient(sockFile)
 	if err != nil {
 		log.Log.Object(vmi).Reason(err).Error("Failed to connect cmd client")
 		response.WriteError(http.StatusInternalServerError, err)
+		return
 	}
+	defer client.Close()
 
 	fsList, err := client.GetFilesystems()
 	if err != nil {
-		log.Log.Object(vmi).Reason(err).Error("Failed to get guest info")
+		log.Log.Object(vmi).Reason(err).Error("Failed to get filesystem list")
 		response.WriteError(http.StatusInternalServerError, err)
+		return
 	}
 
 	response.WriteEntity(fsList)
 }
+
+// GuestExecHandler runs a command in the guest through the guest agent. The command is
+// validated against the allow list by virt-api.
+func (lh *LifecycleHandler) GuestExecHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, lh.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+
+	opts := &v1.GuestExecOptions{}
+	if err := request.ReadEntity(opts); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to read the guest exec options")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	if opts.Command == "" || opts.TimeoutSeconds == nil {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("a command and a timeout are required"))
+		return
+	}
+
+	sockFile, err := cmdclient.FindSocketOnHost(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to detect cmd client")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	client, err := cmdclient.NewClient(sockFile)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to connect cmd client")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	defer client.Close()
+
+	log.Log.Object(vmi).Infof("Running %s in the guest", opts.Command)
+	exitCode, stdOut, err := client.Exec(api.VMINamespaceKeyFunc(vmi), opts.Command, opts.Args, *opts.TimeoutSeconds)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to run %s in the guest", opts.Command)
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteEntity(v1.GuestExecResult{
+		ExitCode: int32(exitCode),
+		StdOut:   stdOut,
+	})
+}
@@ -0,0 +1,237 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/emicklei/go-restful"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util"
+	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
+)
+
+const memoryDumpFileName = "memory.dump"
+
+type MemoryDumpHandler struct {
+	podIsolationDetector isolation.PodIsolationDetector
+	vmiInformer          cache.SharedIndexInformer
+	dumpLock             *sync.Mutex
+	dumping              map[types.UID]struct{}
+}
+
+func NewMemoryDumpHandler(podIsolationDetector isolation.PodIsolationDetector, vmiInformer cache.SharedIndexInformer) *MemoryDumpHandler {
+	return &MemoryDumpHandler{
+		podIsolationDetector: podIsolationDetector,
+		vmiInformer:          vmiInformer,
+		dumpLock:             &sync.Mutex{},
+		dumping:              make(map[types.UID]struct{}),
+	}
+}
+
+// memoryDumpPathInLauncher returns the location of the memory dump inside the virt-launcher pod
+func memoryDumpPathInLauncher(vmi *v1.VirtualMachineInstance) string {
+	return filepath.Join(util.VirtPrivateDir, string(vmi.GetUID()), memoryDumpFileName)
+}
+
+// MemoryDumpHandler captures a memory dump of a VMI and streams it. The dump stays in the virt-launcher
+// pod until it was streamed to its end, so that an interrupted download can be resumed with a Range
+// request whose If-Range header carries the ETag of the dump. Any other request captures a new dump,
+// which replaces the previous one, so that at most one dump per VMI takes space in the virt-launcher pod.
+func (h *MemoryDumpHandler) MemoryDumpHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, h.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+
+	h.dumpLock.Lock()
+	if _, exists := h.dumping[vmi.UID]; exists {
+		h.dumpLock.Unlock()
+		response.WriteError(http.StatusConflict, fmt.Errorf("a memory dump of VMI %s/%s is already in progress", vmi.Namespace, vmi.Name))
+		return
+	}
+	h.dumping[vmi.UID] = struct{}{}
+	h.dumpLock.Unlock()
+	defer func() {
+		h.dumpLock.Lock()
+		delete(h.dumping, vmi.UID)
+		h.dumpLock.Unlock()
+	}()
+
+	result, err := h.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to detect the virt-launcher pod")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	pid := result.Pid()
+
+	dump, err := resumableMemoryDump(request.Request, pid, memoryDumpPathInLauncher(vmi))
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to open the memory dump")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	if dump == nil {
+		// the new dump is always streamed completely
+		request.Request.Header.Del("Range")
+		request.Request.Header.Del("If-Range")
+		dump, err = h.captureMemoryDump(vmi, pid)
+		if err != nil {
+			log.Log.Object(vmi).Reason(err).Error("Failed to capture the memory dump")
+			response.WriteError(http.StatusInternalServerError, err)
+			return
+		}
+	}
+	defer dump.Close()
+
+	info, err := dump.Stat()
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to stat the memory dump")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.Header().Set("ETag", memoryDumpETag(info))
+	response.Header().Set("Content-Type", "application/octet-stream")
+	response.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s-%s.%s", vmi.Namespace, vmi.Name, memoryDumpFileName))
+	content := &memoryDumpReader{ReadSeeker: dump}
+	writer := &memoryDumpWriter{ResponseWriter: response.ResponseWriter}
+	http.ServeContent(writer, request.Request, memoryDumpFileName, info.ModTime(), content)
+	if writer.err != nil {
+		log.Log.Object(vmi).Reason(writer.err).Info("Streaming the memory dump was interrupted, keeping it to resume the download")
+		return
+	}
+
+	if content.offset >= info.Size() {
+		if err := removeInLauncherRoot(pid, memoryDumpPathInLauncher(vmi)); err != nil && !os.IsNotExist(err) {
+			log.Log.Object(vmi).Reason(err).Error("Failed to remove the memory dump")
+		}
+	}
+}
+
+// memoryDumpETag identifies a captured memory dump. It stays the same for all requests which stream
+// the same dump and changes whenever a new dump is captured.
+func memoryDumpETag(info os.FileInfo) string {
+	return fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size())
+}
+
+// resumableMemoryDump returns the previously captured memory dump if the request resumes its
+// download, or nil if a new dump has to be captured
+func resumableMemoryDump(request *http.Request, pid int, dumpPath string) (*os.File, error) {
+	ifRange := request.Header.Get("If-Range")
+	if request.Header.Get("Range") == "" || ifRange == "" {
+		return nil, nil
+	}
+
+	dump, err := openInLauncherRoot(pid, dumpPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	info, err := dump.Stat()
+	if err != nil {
+		dump.Close()
+		return nil, err
+	}
+	if memoryDumpETag(info) != ifRange {
+		dump.Close()
+		return nil, nil
+	}
+	return dump, nil
+}
+
+// captureMemoryDump asks virt-launcher to write a new memory dump, replacing the previous one, and returns it opened
+func (h *MemoryDumpHandler) captureMemoryDump(vmi *v1.VirtualMachineInstance, pid int) (*os.File, error) {
+	dumpPath := memoryDumpPathInLauncher(vmi)
+	if err := removeInLauncherRoot(pid, dumpPath); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	sockFile, err := cmdclient.FindSocketOnHost(vmi)
+	if err != nil {
+		return nil, err
+	}
+	client, err := cmdclient.NewClient(sockFile)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	log.Log.Object(vmi).Info("Capturing memory dump")
+	if err := client.VirtualMachineMemoryDump(vmi, dumpPath); err != nil {
+		removeInLauncherRoot(pid, dumpPath)
+		return nil, err
+	}
+
+	dump, err := openInLauncherRoot(pid, dumpPath)
+	if err != nil {
+		removeInLauncherRoot(pid, dumpPath)
+		return nil, err
+	}
+	return dump, nil
+}
+
+// memoryDumpReader tracks how far the memory dump was read
+type memoryDumpReader struct {
+	io.ReadSeeker
+	offset int64
+}
+
+func (r *memoryDumpReader) Read(p []byte) (int, error) {
+	n, err := r.ReadSeeker.Read(p)
+	r.offset += int64(n)
+	return n, err
+}
+
+func (r *memoryDumpReader) Seek(offset int64, whence int) (int64, error) {
+	pos, err := r.ReadSeeker.Seek(offset, whence)
+	if err == nil {
+		r.offset = pos
+	}
+	return pos, err
+}
+
+// memoryDumpWriter records if streaming the memory dump to the client failed
+type memoryDumpWriter struct {
+	http.ResponseWriter
+	err error
+}
+
+func (w *memoryDumpWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	if err != nil && w.err == nil {
+		w.err = err
+	}
+	return n, err
+}
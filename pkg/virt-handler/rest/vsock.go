@@ -0,0 +1,117 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/emicklei/go-restful"
+	"golang.org/x/sys/unix"
+	"k8s.io/client-go/tools/cache"
+
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util"
+)
+
+// VSOCKPortParam is the query parameter which selects the port in the guest to connect to
+const VSOCKPortParam = "port"
+
+type VSOCKHandler struct {
+	vmiInformer cache.SharedIndexInformer
+}
+
+func NewVSOCKHandler(vmiInformer cache.SharedIndexInformer) *VSOCKHandler {
+	return &VSOCKHandler{
+		vmiInformer: vmiInformer,
+	}
+}
+
+// VSOCKHandler connects to a port of the VSOCK device of a VMI and proxies the connection
+// over the client websocket.
+func (h *VSOCKHandler) VSOCKHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, h.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+	if !util.IsAutoAttachVSOCK(vmi) || vmi.Status.VSOCKCID == nil {
+		err := fmt.Errorf("VMI has no VSOCK device")
+		log.Log.Object(vmi).Reason(err).Error("Failed to connect to VSOCK")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	port, err := strconv.ParseUint(request.QueryParameter(VSOCKPortParam), 10, 32)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Invalid VSOCK port")
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("invalid VSOCK port: %v", err))
+		return
+	}
+
+	conn, err := dialVSOCK(*vmi.Status.VSOCKCID, uint32(port))
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to connect to VSOCK port %d", port)
+		response.WriteError(http.StatusServiceUnavailable, err)
+		return
+	}
+	defer conn.Close()
+
+	upgrader := kubecli.NewUpgrader()
+	clientSocket, err := upgrader.Upgrade(response.ResponseWriter, request.Request, nil)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to upgrade client websocket connection")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	defer clientSocket.Close()
+
+	log.Log.Object(vmi).Infof("Connected to VSOCK port %d", port)
+
+	errCh := make(chan error, 2)
+	go func() {
+		_, err := kubecli.CopyTo(clientSocket, conn)
+		errCh <- err
+	}()
+	go func() {
+		_, err := kubecli.CopyFrom(conn, clientSocket)
+		errCh <- err
+	}()
+
+	if err := <-errCh; err != nil && err != io.EOF {
+		log.Log.Object(vmi).Reason(err).Error("Error in proxing websocket and VSOCK")
+	}
+}
+
+func dialVSOCK(cid uint32, port uint32) (io.ReadWriteCloser, error) {
+	fd, err := unix.Socket(unix.AF_VSOCK, unix.SOCK_STREAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create VSOCK socket: %v", err)
+	}
+	if err := unix.Connect(fd, &unix.SockaddrVM{CID: cid, Port: port}); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("failed to connect to VSOCK %d:%d: %v", cid, port, err)
+	}
+	return os.NewFile(uintptr(fd), fmt.Sprintf("vsock:%d:%d", cid, port)), nil
+}
@@ -0,0 +1,49 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package virthandler
+
+import (
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	virtutil "kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/virt-handler/cgroup"
+)
+
+// configureSwap lets the memory cgroup of the virt-launcher pod use swap if the VMI has Burstable memory.
+// Failures are only logged, the VMI keeps running without swap.
+func (d *VirtualMachineController) configureSwap(vmi *v1.VirtualMachineInstance) {
+	if !d.clusterConfig.VMSwapEnabled() || !virtutil.HasBurstableMemory(vmi) {
+		return
+	}
+
+	res, err := d.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Warning("failed to detect the virt-launcher process, swap is not configured")
+		return
+	}
+
+	var swappiness *int64
+	if swapConfig := d.clusterConfig.GetSwapConfiguration(); swapConfig != nil {
+		swappiness = swapConfig.Swappiness
+	}
+	if err := cgroup.ConfigureSwap(res.Pid(), swappiness); err != nil {
+		log.Log.Object(vmi).Reason(err).Warning("failed to configure swap for the virt-launcher pod")
+	}
+}
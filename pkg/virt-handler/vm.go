@@ -24,6 +24,7 @@ import (
 	goerror "errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net"
 	"os"
 	"path"
@@ -108,6 +109,12 @@ const (
 	VMIGracefulShutdown = "Signaled Graceful Shutdown"
 	//VMISignalDeletion is the reason set when the VMI has signal deletion
 	VMISignalDeletion = "Signaled Deletion"
+	//VMIAgentConnected is the reason set when the guest agent connected
+	VMIAgentConnected = "The guest agent connected."
+	//VMIAgentDisconnected is the reason set when the guest agent disconnected
+	VMIAgentDisconnected = "The guest agent disconnected."
+	//VMIPausedIOError is the reason set when the domain was paused because of an IO error
+	VMIPausedIOError = "The VirtualMachineInstance was paused because of an IO error."
 )
 
 var RequiredGuestAgentCommands = []string{
@@ -136,6 +143,18 @@ var PasswordRelatedGuestAgentCommands = []string{
 	"guest-set-user-password",
 }
 
+// nodeBootIDPath holds the boot ID of the node, which changes on every reboot
+var nodeBootIDPath = "/proc/sys/kernel/random/boot_id"
+
+func readNodeBootID() string {
+	bootID, err := ioutil.ReadFile(nodeBootIDPath)
+	if err != nil {
+		log.Log.Reason(err).Warning("Failed to read the boot ID of the node, node reboots will not be detected")
+		return ""
+	}
+	return strings.TrimSpace(string(bootID))
+}
+
 func NewController(
 	recorder record.EventRecorder,
 	clientset kubecli.KubevirtClient,
@@ -147,6 +166,7 @@ func NewController(
 	vmiTargetInformer cache.SharedIndexInformer,
 	domainInformer cache.SharedInformer,
 	gracefulShutdownInformer cache.SharedIndexInformer,
+	nodeInformer cache.SharedIndexInformer,
 	watchdogTimeoutSeconds int,
 	maxDevices int,
 	clusterConfig *virtconfig.ClusterConfig,
@@ -172,13 +192,15 @@ func NewController(
 		watchdogTimeoutSeconds:      watchdogTimeoutSeconds,
 		migrationProxy:              migrationProxy,
 		podIsolationDetector:        podIsolationDetector,
-		containerDiskMounter:        container_disk.NewMounter(podIsolationDetector, virtPrivateDir+"/container-disk-mount-state", clusterConfig),
+		containerDiskMounter:        container_disk.NewMounter(podIsolationDetector, virtPrivateDir+"/container-disk-mount-state", util.VirtLibDir+"/container-disk-cache", clusterConfig),
 		hotplugVolumeMounter:        hotplug_volume.NewVolumeMounter(podIsolationDetector, virtPrivateDir+"/hotplug-volume-mount-state"),
 		clusterConfig:               clusterConfig,
 		networkCacheStoreFactory:    netcache.NewInterfaceCacheFactory(),
 		virtLauncherFSRunDirPattern: "/proc/%d/root/var/run",
 		capabilities:                capabilities,
 		vmiExpectations:             controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
+		nodeBootID:                  readNodeBootID(),
+		memoryDumps:                 newMemoryDumps(),
 	}
 
 	vmiSourceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -223,7 +245,7 @@ func NewController(
 		permissions = "rwm"
 	}
 
-	c.deviceManagerController = device_manager.NewDeviceController(c.host, maxDevices, permissions, clusterConfig)
+	c.deviceManagerController = device_manager.NewDeviceController(c.host, maxDevices, permissions, clusterConfig, nodeInformer)
 	c.heartBeat = heartbeat.NewHeartBeat(clientset.CoreV1(), c.deviceManagerController, clusterConfig, host)
 
 	return c
@@ -267,6 +289,8 @@ type VirtualMachineController struct {
 	heartBeat                   *heartbeat.HeartBeat
 	capabilities                *nodelabellerapi.Capabilities
 	vmiExpectations             *controller.UIDTrackingControllerExpectations
+	nodeBootID                  string
+	memoryDumps                 *memoryDumps
 }
 
 type virtLauncherCriticalNetworkError struct {
@@ -567,7 +591,8 @@ func canUpdateToMounted(currentPhase v1.VolumePhase) bool {
 }
 
 func canUpdateToUnmounted(currentPhase v1.VolumePhase) bool {
-	return currentPhase == v1.VolumeReady || currentPhase == v1.HotplugVolumeMounted || currentPhase == v1.HotplugVolumeAttachedToNode
+	return currentPhase == v1.VolumeReady || currentPhase == v1.HotplugVolumeMounted || currentPhase == v1.HotplugVolumeAttachedToNode ||
+		currentPhase == v1.MemoryDumpVolumeInProgress || currentPhase == v1.MemoryDumpVolumeCompleted || currentPhase == v1.MemoryDumpVolumeFailed
 }
 
 func (d *VirtualMachineController) setMigrationProgressStatus(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
@@ -600,6 +625,15 @@ func (d *VirtualMachineController) setMigrationProgressStatus(vmi *v1.VirtualMac
 	vmi.Status.MigrationState.Completed = migrationMetadata.Completed
 	vmi.Status.MigrationState.Failed = migrationMetadata.Failed
 	vmi.Status.MigrationState.Mode = migrationMetadata.Mode
+	if progress := domain.Status.MigrationProgress; progress != nil {
+		vmi.Status.MigrationState.Progress = &v1.VirtualMachineInstanceMigrationProgress{
+			UpdateTimestamp:      progress.UpdateTimestamp,
+			TransferredBytes:     int64(progress.DataProcessed),
+			MemoryRemainingBytes: int64(progress.MemRemaining),
+			DirtyPageRate:        int64(progress.MemDirtyRate),
+			Iteration:            int64(progress.MemIteration),
+		}
+	}
 }
 
 func (d *VirtualMachineController) migrationSourceUpdateVMIStatus(origVMI *v1.VirtualMachineInstance, domain *api.Domain) error {
@@ -666,6 +700,8 @@ func (d *VirtualMachineController) migrationSourceUpdateVMIStatus(origVMI *v1.Vi
 		delete(vmi.Labels, v1.OutdatedLauncherImageLabel)
 		vmi.Status.LauncherContainerImageVersion = ""
 		vmi.Status.NodeName = migrationHost
+		// the target node records its own boot ID once it owns the VMI
+		vmi.Status.NodeBootID = ""
 		// clean the evacuation node name since have already migrated to a new node
 		vmi.Status.EvacuationNodeName = ""
 		vmi.Status.MigrationState.Completed = true
@@ -810,8 +846,10 @@ func (d *VirtualMachineController) updateVolumeStatusesFromDomain(vmi *v1.Virtua
 
 	if len(vmi.Status.VolumeStatus) > 0 {
 		diskDeviceMap := make(map[string]string)
+		diskStatusMap := make(map[string]*v1.VolumeDiskStatus)
 		for _, disk := range domain.Spec.Devices.Disks {
 			diskDeviceMap[disk.Alias.GetName()] = disk.Target.Device
+			diskStatusMap[disk.Alias.GetName()] = getVolumeDiskStatus(disk)
 		}
 		specVolumeMap := make(map[string]v1.Volume)
 		for _, volume := range vmi.Spec.Volumes {
@@ -823,11 +861,15 @@ func (d *VirtualMachineController) updateVolumeStatusesFromDomain(vmi *v1.Virtua
 		for _, volumeStatus := range vmi.Status.VolumeStatus {
 			if _, ok := diskDeviceMap[volumeStatus.Name]; ok {
 				volumeStatus.Target = diskDeviceMap[volumeStatus.Name]
+				volumeStatus.Disk = diskStatusMap[volumeStatus.Name]
 			}
 			if volumeStatus.HotplugVolume != nil {
 				hasHotplug = true
 				volumeStatus, needsRefresh = d.updateHotplugVolumeStatus(vmi, volumeStatus, specVolumeMap)
 			}
+			if volumeStatus.MemoryDumpVolume != nil {
+				volumeStatus = d.updateMemoryDumpVolumeStatus(vmi, volumeStatus)
+			}
 			newStatuses = append(newStatuses, volumeStatus)
 			newStatusMap[volumeStatus.Name] = volumeStatus
 		}
@@ -843,6 +885,22 @@ func (d *VirtualMachineController) updateVolumeStatusesFromDomain(vmi *v1.Virtua
 	return hasHotplug
 }
 
+// getVolumeDiskStatus reports how a disk of the domain is attached to the guest
+func getVolumeDiskStatus(disk api.Disk) *v1.VolumeDiskStatus {
+	status := &v1.VolumeDiskStatus{
+		Type: disk.Type,
+	}
+	if disk.Driver != nil {
+		status.Format = disk.Driver.Type
+		status.Cache = v1.DriverCache(disk.Driver.Cache)
+		status.IO = disk.Driver.IO
+	}
+	if disk.BackingStore != nil && disk.BackingStore.Format != nil {
+		status.BackingFormat = disk.BackingStore.Format.Type
+	}
+	return status
+}
+
 func (d *VirtualMachineController) updateGuestInfoFromDomain(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
 
 	if domain == nil {
@@ -858,6 +916,23 @@ func (d *VirtualMachineController) updateGuestInfoFromDomain(vmi *v1.VirtualMach
 		vmi.Status.GuestOSInfo.KernelVersion = domain.Status.OSInfo.KernelVersion
 		vmi.Status.GuestOSInfo.ID = domain.Status.OSInfo.Id
 	}
+
+	if domain.Status.Hostname != "" {
+		vmi.Status.GuestHostname = domain.Status.Hostname
+	}
+
+	if domain.Status.Filesystems != nil {
+		fsFreeSpace := make([]v1.VirtualMachineInstanceFileSystemFreeSpace, 0, len(domain.Status.Filesystems))
+		for _, fs := range domain.Status.Filesystems {
+			fsFreeSpace = append(fsFreeSpace, v1.VirtualMachineInstanceFileSystemFreeSpace{
+				MountPoint:     fs.Mountpoint,
+				FileSystemType: fs.Type,
+				FreeBytes:      int64(fs.TotalBytes - fs.UsedBytes),
+				TotalBytes:     int64(fs.TotalBytes),
+			})
+		}
+		vmi.Status.FSFreeSpace = fsFreeSpace
+	}
 }
 
 func (d *VirtualMachineController) updateInterfacesFromDomain(vmi *v1.VirtualMachineInstance, domain *api.Domain) error {
@@ -1059,6 +1134,27 @@ func (d *VirtualMachineController) updateLiveMigrationConditions(vmi *v1.Virtual
 	}
 }
 
+// updateStartupTimestamps records when the domain was defined, when it was running and when the guest agent
+// connected for the first time. virt-controller records the first timestamps when it schedules the VMI, VMIs
+// without them were started before the timestamps were introduced and are left alone.
+func (d *VirtualMachineController) updateStartupTimestamps(vmi *v1.VirtualMachineInstance, domain *api.Domain, condManager *controller.VirtualMachineInstanceConditionManager) {
+	timestamps := vmi.Status.StartupTimestamps
+	if domain == nil || timestamps == nil {
+		return
+	}
+
+	now := metav1.Now()
+	if timestamps.DomainDefined == nil {
+		timestamps.DomainDefined = &now
+	}
+	if timestamps.DomainRunning == nil && vmi.IsRunning() {
+		timestamps.DomainRunning = &now
+	}
+	if timestamps.GuestAgentConnected == nil && condManager.HasCondition(vmi, v1.VirtualMachineInstanceAgentConnected) {
+		timestamps.GuestAgentConnected = &now
+	}
+}
+
 func (d *VirtualMachineController) updateGuestAgentConditions(vmi *v1.VirtualMachineInstance, domain *api.Domain, condManager *controller.VirtualMachineInstanceConditionManager) error {
 
 	// Update the condition when GA is connected
@@ -1083,6 +1179,7 @@ func (d *VirtualMachineController) updateGuestAgentConditions(vmi *v1.VirtualMac
 			Type:          v1.VirtualMachineInstanceAgentConnected,
 			LastProbeTime: metav1.Now(),
 			Status:        k8sv1.ConditionTrue,
+			Reason:        v1.AgentChannelConnectedReason,
 		}
 		vmi.Status.Conditions = append(vmi.Status.Conditions, agentCondition)
 	case !channelConnected:
@@ -1144,6 +1241,37 @@ func (d *VirtualMachineController) updatePausedConditions(vmi *v1.VirtualMachine
 	}
 }
 
+func (d *VirtualMachineController) updateDriftConditions(vmi *v1.VirtualMachineInstance, domain *api.Domain, condManager *controller.VirtualMachineInstanceConditionManager) {
+
+	// The domain is only compared with the VMI spec while it is running on this node
+	if domain == nil || vmi.Status.Phase != v1.Running || migrations.IsMigrating(vmi) {
+		return
+	}
+
+	drifted := detectDomainDrift(vmi, domain)
+	if len(drifted) == 0 {
+		if condManager.HasCondition(vmi, v1.VirtualMachineInstanceDriftDetected) {
+			log.Log.Object(vmi).V(3).Info("Removing drift detected condition")
+			condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceDriftDetected)
+		}
+		return
+	}
+
+	condition := newDriftCondition(drifted)
+	existing := condManager.GetCondition(vmi, v1.VirtualMachineInstanceDriftDetected)
+	if existing != nil && existing.Message == condition.Message {
+		return
+	}
+	log.Log.Object(vmi).Warning(condition.Message)
+	condition.LastProbeTime = metav1.Now()
+	condition.LastTransitionTime = metav1.Now()
+	if existing != nil {
+		condition.LastTransitionTime = existing.LastTransitionTime
+	}
+	condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceDriftDetected)
+	vmi.Status.Conditions = append(vmi.Status.Conditions, *condition)
+}
+
 func (d *VirtualMachineController) updateFSFreezeStatus(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
 
 	if domain == nil || domain.Status.FSFreezeStatus.Status == "" {
@@ -1241,6 +1369,12 @@ func (d *VirtualMachineController) updateVMIStatus(origVMI *v1.VirtualMachineIns
 		return err
 	}
 
+	// Record the boot of the node the domain runs on, this allows virt-controller
+	// to detect that the VMI failed because the node rebooted
+	if vmi.IsRunning() && d.nodeBootID != "" {
+		vmi.Status.NodeBootID = d.nodeBootID
+	}
+
 	// Update conditions on VMI Status
 	d.updateAccessCredentialConditions(vmi, domain, condManager)
 	d.updateLiveMigrationConditions(vmi, condManager)
@@ -1249,6 +1383,8 @@ func (d *VirtualMachineController) updateVMIStatus(origVMI *v1.VirtualMachineIns
 		return err
 	}
 	d.updatePausedConditions(vmi, domain, condManager)
+	d.updateDriftConditions(vmi, domain, condManager)
+	d.updateStartupTimestamps(vmi, domain, condManager)
 
 	// Handle sync error
 	if _, ok := syncError.(*virtLauncherCriticalNetworkError); ok {
@@ -1259,7 +1395,7 @@ func (d *VirtualMachineController) updateVMIStatus(origVMI *v1.VirtualMachineIns
 		log.Log.Errorf("virt-launcher does not support the Secure Boot setting. Updating VMI %s status to Failed", vmi.Name)
 		vmi.Status.Phase = v1.Failed
 	}
-	condManager.CheckFailure(vmi, syncError, "Synchronizing with the Domain failed.")
+	condManager.CheckFailure(vmi, syncError, v1.SynchronizationFailedReason)
 
 	controller.SetVMIPhaseTransitionTimestamp(origVMI, vmi)
 
@@ -1285,10 +1421,30 @@ func (d *VirtualMachineController) updateVMIStatus(origVMI *v1.VirtualMachineIns
 			d.recorder.Event(vmi, k8sv1.EventTypeWarning, v1.Stopped.String(), VMICrashed)
 		}
 	}
+	d.recordConditionEvents(vmi, &v1.VirtualMachineInstance{Status: oldStatus}, condManager)
 
 	return nil
 }
 
+// recordConditionEvents records an event on the VMI when the guest agent connects or disconnects and
+// when the domain gets paused because of an IO error
+func (d *VirtualMachineController) recordConditionEvents(vmi *v1.VirtualMachineInstance, oldVMI *v1.VirtualMachineInstance, condManager *controller.VirtualMachineInstanceConditionManager) {
+	hadAgent := condManager.HasCondition(oldVMI, v1.VirtualMachineInstanceAgentConnected)
+	hasAgent := condManager.HasCondition(vmi, v1.VirtualMachineInstanceAgentConnected)
+	switch {
+	case hasAgent && !hadAgent:
+		d.recorder.Event(vmi, k8sv1.EventTypeNormal, v1.AgentConnected.String(), VMIAgentConnected)
+	case !hasAgent && hadAgent:
+		d.recorder.Event(vmi, k8sv1.EventTypeNormal, v1.AgentDisconnected.String(), VMIAgentDisconnected)
+	}
+
+	wasPausedIOError := condManager.HasConditionWithStatusAndReason(oldVMI, v1.VirtualMachineInstancePaused, k8sv1.ConditionTrue, v1.PausedIOErrorReason)
+	isPausedIOError := condManager.HasConditionWithStatusAndReason(vmi, v1.VirtualMachineInstancePaused, k8sv1.ConditionTrue, v1.PausedIOErrorReason)
+	if isPausedIOError && !wasPausedIOError {
+		d.recorder.Event(vmi, k8sv1.EventTypeWarning, v1.IOError.String(), VMIPausedIOError)
+	}
+}
+
 func _guestAgentCommandSubsetSupported(requiredCommands []string, commands []v1.GuestAgentCommandInfo) bool {
 	var found bool
 	for _, cmd := range requiredCommands {
@@ -1357,7 +1513,7 @@ func calculatePausedCondition(vmi *v1.VirtualMachineInstance, reason api.StateCh
 			Status:             k8sv1.ConditionTrue,
 			LastProbeTime:      now,
 			LastTransitionTime: now,
-			Reason:             "PausedByUser",
+			Reason:             v1.PausedByUserReason,
 			Message:            "VMI was paused by user",
 		})
 	case api.ReasonPausedIOError:
@@ -1368,7 +1524,7 @@ func calculatePausedCondition(vmi *v1.VirtualMachineInstance, reason api.StateCh
 			Status:             k8sv1.ConditionTrue,
 			LastProbeTime:      now,
 			LastTransitionTime: now,
-			Reason:             "PausedIOError",
+			Reason:             v1.PausedIOErrorReason,
 			Message:            "VMI was paused, IO error",
 		})
 	default:
@@ -1388,6 +1544,10 @@ func newNonMigratableCondition(msg string, reason string) *v1.VirtualMachineInst
 func (d *VirtualMachineController) calculateLiveMigrationCondition(vmi *v1.VirtualMachineInstance) (*v1.VirtualMachineInstanceCondition, bool) {
 	isBlockMigration, err := d.checkVolumesForMigration(vmi)
 	if err != nil {
+		var pvcNotShared *pvctypes.PVCNotSharedError
+		if goerror.As(err, &pvcNotShared) {
+			return newNonMigratableCondition(err.Error(), v1.VirtualMachineInstanceReasonPVCNotMigratable), isBlockMigration
+		}
 		return newNonMigratableCondition(err.Error(), v1.VirtualMachineInstanceReasonDisksNotMigratable), isBlockMigration
 	}
 
@@ -1404,6 +1564,10 @@ func (d *VirtualMachineController) calculateLiveMigrationCondition(vmi *v1.Virtu
 		return newNonMigratableCondition("VMI uses virtiofs", v1.VirtualMachineInstanceReasonVirtIOFSNotMigratable), isBlockMigration
 	}
 
+	if util.IsHypervPassthrough(vmi) {
+		return newNonMigratableCondition("VMI uses hyperv passthrough", v1.VirtualMachineInstanceReasonHypervPassthroughNotMigratable), isBlockMigration
+	}
+
 	return &v1.VirtualMachineInstanceCondition{
 		Type:   v1.VirtualMachineInstanceIsMigratable,
 		Status: k8sv1.ConditionTrue,
@@ -1944,6 +2108,7 @@ func (d *VirtualMachineController) processVmCleanup(vmi *v1.VirtualMachineInstan
 	if err := d.hotplugVolumeMounter.UnmountAll(vmi); err != nil {
 		return err
 	}
+	d.memoryDumps.forget(vmi, nil)
 
 	d.clearPodNetworkPhase1(vmi)
 
@@ -2326,8 +2491,12 @@ func (d *VirtualMachineController) checkVolumesForMigration(vmi *v1.VirtualMachi
 
 			if !ok || volumeStatus.PersistentVolumeClaimInfo == nil {
 				return true, fmt.Errorf("cannot migrate VMI: Unable to determine if PVC %v is shared, live migration requires that all PVCs must be shared (using ReadWriteMany access mode)", claimName)
-			} else if !pvctypes.HasSharedAccessMode(volumeStatus.PersistentVolumeClaimInfo.AccessModes) {
-				return true, fmt.Errorf("cannot migrate VMI: PVC %v is not shared, live migration requires that all PVCs must be shared (using ReadWriteMany access mode)", claimName)
+			} else if err := pvctypes.CheckPVCIsShared(volume.Name, claimName, volumeStatus.PersistentVolumeClaimInfo.AccessModes); err != nil {
+				return true, fmt.Errorf("cannot migrate VMI: %w", err)
+			} else if getDiskCacheMode(vmi, volume.Name, volumeStatus.PersistentVolumeClaimInfo) == v1.CacheWriteBack &&
+				!*d.clusterConfig.GetMigrationConfiguration().UnsafeMigrationOverride {
+				// libvirt refuses to migrate disks on shared storage while the source host may still cache their writes
+				return true, fmt.Errorf("cannot migrate VMI: disk %s uses the writeback cache mode", volume.Name)
 			}
 
 		} else if volSrc.HostDisk != nil {
@@ -2335,6 +2504,8 @@ func (d *VirtualMachineController) checkVolumesForMigration(vmi *v1.VirtualMachi
 			if !shared {
 				return true, fmt.Errorf("cannot migrate VMI with non-shared HostDisk")
 			}
+		} else if volSrc.ContainerDisk != nil && volSrc.ContainerDisk.Overlay != nil && volSrc.ContainerDisk.Overlay.ClaimName != "" {
+			return true, fmt.Errorf("cannot migrate VMI: containerDisk %s keeps its overlay on PVC %s", volume.Name, volSrc.ContainerDisk.Overlay.ClaimName)
 		} else {
 			blockMigrate = true
 		}
@@ -2342,6 +2513,16 @@ func (d *VirtualMachineController) checkVolumesForMigration(vmi *v1.VirtualMachi
 	return
 }
 
+// getDiskCacheMode returns the cache mode of the disk of a volume, or the default cache mode of its PVC if the disk doesn't set one
+func getDiskCacheMode(vmi *v1.VirtualMachineInstance, volumeName string, pvcInfo *v1.PersistentVolumeClaimInfo) v1.DriverCache {
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.Name == volumeName && disk.Cache != "" {
+			return disk.Cache
+		}
+	}
+	return pvcInfo.CacheMode
+}
+
 func (d *VirtualMachineController) isMigrationSource(vmi *v1.VirtualMachineInstance) bool {
 
 	if vmi.Status.MigrationState != nil &&
@@ -2466,6 +2647,9 @@ func (d *VirtualMachineController) vmUpdateHelperMigrationSource(origVMI *v1.Vir
 			AllowAutoConverge:       *migrationConfiguration.AllowAutoConverge,
 			AllowPostCopy:           *migrationConfiguration.AllowPostCopy,
 		}
+		if migrationConfiguration.ParallelMigrationThreads != nil {
+			options.ParallelMigrationThreads = *migrationConfiguration.ParallelMigrationThreads
+		}
 
 		err = client.MigrateVirtualMachine(vmi, options)
 		if err != nil {
@@ -2531,6 +2715,8 @@ func (d *VirtualMachineController) vmUpdateHelperMigrationTarget(origVMI *v1.Vir
 		}
 	}
 
+	d.configureSwap(vmi)
+
 	// configure network inside virt-launcher compute container
 	criticalNetworkError, err := d.setPodNetworkPhase1(vmi)
 	if err != nil {
@@ -2547,6 +2733,12 @@ func (d *VirtualMachineController) vmUpdateHelperMigrationTarget(origVMI *v1.Vir
 		return fmt.Errorf("failed to set up file ownership for /dev/kvm: %v", err)
 	}
 
+	if virtutil.IsAutoAttachVSOCK(vmi) {
+		if err := d.claimDeviceOwnership(vmi, "vhost-vsock"); err != nil {
+			return fmt.Errorf("failed to set up file ownership for /dev/vhost-vsock: %v", err)
+		}
+	}
+
 	res, err := d.podIsolationDetector.Detect(vmi)
 	if err != nil {
 		return err
@@ -2642,6 +2834,12 @@ func (d *VirtualMachineController) vmUpdateHelperDefault(origVMI *v1.VirtualMach
 			return fmt.Errorf("failed to set up file ownership for /dev/kvm: %v", err)
 		}
 
+		if virtutil.IsAutoAttachVSOCK(vmi) {
+			if err := d.claimDeviceOwnership(vmi, "vhost-vsock"); err != nil {
+				return fmt.Errorf("failed to set up file ownership for /dev/vhost-vsock: %v", err)
+			}
+		}
+
 		res, err := d.podIsolationDetector.Detect(vmi)
 		if err != nil {
 			return err
@@ -2668,6 +2866,8 @@ func (d *VirtualMachineController) vmUpdateHelperDefault(origVMI *v1.VirtualMach
 		if err != nil {
 			return fmt.Errorf("failed to adjust resources: %v", err)
 		}
+
+		d.configureSwap(vmi)
 	} else if vmi.IsRunning() {
 		if err := d.hotplugVolumeMounter.Mount(vmi); err != nil {
 			return err
@@ -2678,6 +2878,7 @@ func (d *VirtualMachineController) vmUpdateHelperDefault(origVMI *v1.VirtualMach
 	period := d.clusterConfig.GetMemBalloonStatsPeriod()
 
 	options := virtualMachineOptions(smbios, period, preallocatedVolumes, d.capabilities, disksInfo)
+	options.ExpandDisksEnabled = d.clusterConfig.ExpandDisksEnabled()
 
 	err = client.SyncVirtualMachine(vmi, options)
 	if err != nil {
@@ -2693,6 +2894,7 @@ func (d *VirtualMachineController) vmUpdateHelperDefault(origVMI *v1.VirtualMach
 		if err := d.hotplugVolumeMounter.Unmount(vmi); err != nil {
 			return err
 		}
+		d.handleMemoryDumps(vmi, client)
 	}
 	return nil
 }
@@ -62,6 +62,7 @@ import (
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/precond"
+	virtcontroller "kubevirt.io/kubevirt/pkg/controller"
 	diskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	virtcache "kubevirt.io/kubevirt/pkg/virt-handler/cache"
@@ -86,6 +87,7 @@ var _ = Describe("VirtualMachineInstance", func() {
 	var domainSource *framework.FakeControllerSource
 	var domainInformer cache.SharedIndexInformer
 	var gracefulShutdownInformer cache.SharedIndexInformer
+	var nodeInformer cache.SharedIndexInformer
 	var mockQueue *testutils.MockWorkQueue
 	var mockWatchdog *MockWatchdog
 	var mockGracefulShutdown *MockGracefulShutdown
@@ -162,6 +164,7 @@ var _ = Describe("VirtualMachineInstance", func() {
 		vmiTargetInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
 		domainInformer, domainSource = testutils.NewFakeInformerFor(&api.Domain{})
 		gracefulShutdownInformer, _ = testutils.NewFakeInformerFor(&api.Domain{})
+		nodeInformer, _ = testutils.NewFakeInformerFor(&k8sv1.Node{})
 		recorder = record.NewFakeRecorder(100)
 		recorder.IncludeObject = true
 
@@ -187,6 +190,9 @@ var _ = Describe("VirtualMachineInstance", func() {
 		mockContainerDiskMounter = container_disk.NewMockMounter(ctrl)
 		mockHotplugVolumeMounter = hotplug_volume.NewMockVolumeMounter(ctrl)
 
+		// tests which care about the boot ID of the node provide their own
+		nodeBootIDPath = filepath.Join(shareDir, "boot_id")
+
 		migrationProxy := migrationproxy.NewMigrationProxyManager(tlsConfig, tlsConfig, config)
 		controller = NewController(recorder,
 			virtClient,
@@ -198,6 +204,7 @@ var _ = Describe("VirtualMachineInstance", func() {
 			vmiTargetInformer,
 			domainInformer,
 			gracefulShutdownInformer,
+			nodeInformer,
 			1,
 			10,
 			config,
@@ -713,6 +720,35 @@ var _ = Describe("VirtualMachineInstance", func() {
 			testutils.ExpectEvent(recorder, VMIStarted)
 		})
 
+		It("should record the boot ID of the node on a running VMI", func() {
+			Expect(ioutil.WriteFile(nodeBootIDPath, []byte("0b6b0e5a-9c51-4a42-a0a4-3c3d4c6b40a1\n"), 0644)).To(Succeed())
+			controller.nodeBootID = readNodeBootID()
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi = addActivePods(vmi, podTestUUID, host)
+
+			mockWatchdog.CreateFile(vmi)
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).DoAndReturn(func(obj interface{}) (*v1.VirtualMachineInstance, error) {
+				vmi := obj.(*v1.VirtualMachineInstance)
+				Expect(vmi.Status.NodeBootID).To(Equal("0b6b0e5a-9c51-4a42-a0a4-3c3d4c6b40a1"))
+				return vmi, nil
+			})
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			mockHotplugVolumeMounter.EXPECT().Unmount(gomock.Any()).Return(nil)
+			mockHotplugVolumeMounter.EXPECT().Mount(gomock.Any()).Return(nil)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, VMIDefined)
+		})
+
 		It("should add guest agent condition when sees the channel connected", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.UID = vmiTestUUID
@@ -767,6 +803,48 @@ var _ = Describe("VirtualMachineInstance", func() {
 
 			controller.Execute()
 			testutils.ExpectEvent(recorder, VMIDefined)
+			testutils.ExpectEvent(recorder, v1.AgentConnected.String())
+		})
+
+		It("should record the startup timestamps of the domain once", func() {
+			condManager := virtcontroller.NewVirtualMachineInstanceConditionManager()
+			recorded := metav1.NewTime(time.Now().Add(-time.Hour))
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Status.Phase = v1.Running
+			vmi.Status.StartupTimestamps = &v1.VirtualMachineInstanceStartupTimestamps{DomainDefined: &recorded}
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{Type: v1.VirtualMachineInstanceAgentConnected, Status: k8sv1.ConditionTrue},
+			}
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+
+			controller.updateStartupTimestamps(vmi, domain, condManager)
+
+			Expect(*vmi.Status.StartupTimestamps.DomainDefined).To(Equal(recorded))
+			Expect(vmi.Status.StartupTimestamps.DomainRunning).ToNot(BeNil())
+			Expect(vmi.Status.StartupTimestamps.GuestAgentConnected).ToNot(BeNil())
+		})
+
+		It("should not record startup timestamps for VMIs which were started before they were introduced", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Status.Phase = v1.Running
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+
+			controller.updateStartupTimestamps(vmi, domain, virtcontroller.NewVirtualMachineInstanceConditionManager())
+
+			Expect(vmi.Status.StartupTimestamps).To(BeNil())
+		})
+
+		It("should record an event when the domain gets paused because of an IO error", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{Type: v1.VirtualMachineInstancePaused, Status: k8sv1.ConditionTrue, Reason: v1.PausedIOErrorReason},
+			}
+
+			controller.recordConditionEvents(vmi, v1.NewMinimalVMI("testvmi"), virtcontroller.NewVirtualMachineInstanceConditionManager())
+			testutils.ExpectEvent(recorder, v1.IOError.String())
+
+			controller.recordConditionEvents(vmi, vmi.DeepCopy(), virtcontroller.NewVirtualMachineInstanceConditionManager())
+			Expect(recorder.Events).To(BeEmpty())
 		})
 
 		It("should maintain unsupported user agent condition when it's already set", func() {
@@ -877,6 +955,7 @@ var _ = Describe("VirtualMachineInstance", func() {
 
 			controller.Execute()
 			testutils.ExpectEvent(recorder, VMIDefined)
+			testutils.ExpectEvent(recorder, v1.AgentDisconnected.String())
 		})
 
 		It("should add access credential synced condition when credentials report success", func() {
@@ -1316,6 +1395,35 @@ var _ = Describe("VirtualMachineInstance", func() {
 				Expect(hasHotplug).To(BeFalse())
 			})
 
+			It("should report the effective disk configuration of the domain", func() {
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.UID = vmiTestUUID
+				vmi.Status.Phase = v1.Running
+				vmi.Status.VolumeStatus = append(vmi.Status.VolumeStatus, v1.VolumeStatus{
+					Name: "test",
+				})
+				domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+				domain.Status.Status = api.Running
+				domain.Spec.Devices.Disks = append(domain.Spec.Devices.Disks, api.Disk{
+					Type:   "block",
+					Alias:  api.NewUserDefinedAlias("test"),
+					Target: api.DiskTarget{Device: "vda"},
+					Driver: &api.DiskDriver{
+						Type:  "raw",
+						Cache: string(v1.CacheNone),
+						IO:    v1.IONative,
+					},
+				})
+				controller.updateVolumeStatusesFromDomain(vmi, domain)
+				Expect(vmi.Status.VolumeStatus[0].Target).To(Equal("vda"))
+				Expect(vmi.Status.VolumeStatus[0].Disk).To(Equal(&v1.VolumeDiskStatus{
+					Type:   "block",
+					Format: "raw",
+					Cache:  v1.CacheNone,
+					IO:     v1.IONative,
+				}))
+			})
+
 			It("should have hashotplug true with hotplugged volumes", func() {
 				vmi := v1.NewMinimalVMI("testvmi")
 				vmi.UID = vmiTestUUID
@@ -1448,6 +1556,74 @@ var _ = Describe("VirtualMachineInstance", func() {
 				controller.updateVolumeStatusesFromDomain(vmi, domain)
 			})
 
+			It("should report the progress of a memory dump on a memory dump volume", func() {
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.UID = vmiTestUUID
+				vmi.Status.Phase = v1.Running
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+					Name: "dump",
+					VolumeSource: v1.VolumeSource{
+						MemoryDump: &v1.MemoryDumpVolumeSource{ClaimName: "dump"},
+					},
+				})
+				vmi.Status.VolumeStatus = append(vmi.Status.VolumeStatus, v1.VolumeStatus{
+					Name:  "dump",
+					Phase: v1.HotplugVolumeMounted,
+					HotplugVolume: &v1.HotplugVolumeStatus{
+						AttachPodName: "testpod",
+						AttachPodUID:  "1234",
+					},
+					MemoryDumpVolume: &v1.DomainMemoryDumpInfo{ClaimName: "dump"},
+				})
+				domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+				domain.Status.Status = api.Running
+				record, started := controller.memoryDumps.start(vmi, "dump")
+				Expect(started).To(BeTrue())
+
+				mockHotplugVolumeMounter.EXPECT().IsMounted(vmi, "dump", gomock.Any()).Return(true, nil).Times(2)
+				controller.updateVolumeStatusesFromDomain(vmi, domain)
+				Expect(vmi.Status.VolumeStatus[0].Phase).To(Equal(v1.MemoryDumpVolumeInProgress))
+				Expect(vmi.Status.VolumeStatus[0].MemoryDumpVolume.StartTimestamp).ToNot(BeNil())
+				Expect(vmi.Status.VolumeStatus[0].MemoryDumpVolume.TargetFileName).To(Equal(record.fileName))
+				testutils.ExpectEvent(recorder, MemoryDumpInProgressReason)
+
+				controller.memoryDumps.finish(record, nil)
+				controller.updateVolumeStatusesFromDomain(vmi, domain)
+				Expect(vmi.Status.VolumeStatus[0].Phase).To(Equal(v1.MemoryDumpVolumeCompleted))
+				Expect(vmi.Status.VolumeStatus[0].MemoryDumpVolume.EndTimestamp).ToNot(BeNil())
+				testutils.ExpectEvent(recorder, MemoryDumpCompletedReason)
+			})
+
+			It("should fail a memory dump which was in progress before virt-handler restarted", func() {
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.UID = vmiTestUUID
+				vmi.Status.Phase = v1.Running
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+					Name: "dump",
+					VolumeSource: v1.VolumeSource{
+						MemoryDump: &v1.MemoryDumpVolumeSource{ClaimName: "dump"},
+					},
+				})
+				vmi.Status.VolumeStatus = append(vmi.Status.VolumeStatus, v1.VolumeStatus{
+					Name:  "dump",
+					Phase: v1.MemoryDumpVolumeInProgress,
+					HotplugVolume: &v1.HotplugVolumeStatus{
+						AttachPodName: "testpod",
+						AttachPodUID:  "1234",
+					},
+					MemoryDumpVolume: &v1.DomainMemoryDumpInfo{ClaimName: "dump", StartTimestamp: &metav1.Time{}},
+				})
+				domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+				domain.Status.Status = api.Running
+
+				mockHotplugVolumeMounter.EXPECT().IsMounted(vmi, "dump", gomock.Any()).Return(true, nil)
+				controller.updateVolumeStatusesFromDomain(vmi, domain)
+				Expect(vmi.Status.VolumeStatus[0].Phase).To(Equal(v1.MemoryDumpVolumeFailed))
+				Expect(vmi.Status.VolumeStatus[0].Reason).To(Equal(MemoryDumpFailedReason))
+				Expect(vmi.Status.VolumeStatus[0].MemoryDumpVolume.EndTimestamp).ToNot(BeNil())
+				testutils.ExpectEvent(recorder, MemoryDumpFailedReason)
+			})
+
 			It("generateEventsForVolumeStatusChange should not modify arguments", func() {
 				vmi := v1.NewMinimalVMI("testvmi")
 				vmi.UID = vmiTestUUID
@@ -1752,6 +1928,43 @@ var _ = Describe("VirtualMachineInstance", func() {
 			testutils.ExpectEvent(recorder, VMIAbortingMigration)
 		}, 3)
 
+		It("should report the progress of a running migration", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.Status.Phase = v1.Running
+			vmi.Status.NodeName = host
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+				TargetNode:        "othernode",
+				TargetNodeAddress: "127.0.0.1:12345",
+				SourceNode:        host,
+				MigrationUID:      "123",
+			}
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+			now := metav1.Time{Time: time.Unix(time.Now().UTC().Unix(), 0)}
+			domain.Spec.Metadata.KubeVirt.Migration = &api.MigrationMetadata{
+				UID:            "123",
+				StartTimestamp: &now,
+			}
+			domain.Status.MigrationProgress = &api.MigrationProgress{
+				UpdateTimestamp: &now,
+				DataProcessed:   1024,
+				MemRemaining:    2048,
+				MemDirtyRate:    300,
+				MemIteration:    4,
+			}
+
+			controller.setMigrationProgressStatus(vmi, domain)
+			Expect(vmi.Status.MigrationState.Progress).To(Equal(&v1.VirtualMachineInstanceMigrationProgress{
+				UpdateTimestamp:      &now,
+				TransferredBytes:     1024,
+				MemoryRemainingBytes: 2048,
+				DirtyPageRate:        300,
+				Iteration:            4,
+			}))
+		})
+
 		It("Handoff domain to other node after completed migration", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.UID = vmiTestUUID
@@ -1963,7 +2176,41 @@ var _ = Describe("VirtualMachineInstance", func() {
 
 			blockMigrate, err := controller.checkVolumesForMigration(vmi)
 			Expect(blockMigrate).To(BeTrue())
-			Expect(err).To(Equal(fmt.Errorf("cannot migrate VMI: PVC testblock is not shared, live migration requires that all PVCs must be shared (using ReadWriteMany access mode)")))
+			Expect(err).To(MatchError("cannot migrate VMI: PVC testblock of volume myvolume is not shared, live migration requires that all PVCs must be shared (using ReadWriteMany access mode), its access modes are [ReadWriteOnce]"))
+		})
+		It("should fail migration for shared PVCs with the writeback cache mode", func() {
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "myvolume",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "testblock",
+						}},
+					},
+				},
+			}
+			vmi.Status.VolumeStatus = []v1.VolumeStatus{
+				{
+					Name: "myvolume",
+					PersistentVolumeClaimInfo: &v1.PersistentVolumeClaimInfo{
+						AccessModes: testBlockPvc.Spec.AccessModes,
+						VolumeMode:  testBlockPvc.Spec.VolumeMode,
+						CacheMode:   v1.CacheWriteBack,
+					},
+				},
+			}
+
+			blockMigrate, err := controller.checkVolumesForMigration(vmi)
+			Expect(blockMigrate).To(BeTrue())
+			Expect(err).To(MatchError("cannot migrate VMI: disk myvolume uses the writeback cache mode"))
+
+			By("letting the cache mode of the disk override the one of the PVC")
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "myvolume", Cache: v1.CacheNone}}
+			blockMigrate, err = controller.checkVolumesForMigration(vmi)
+			Expect(blockMigrate).To(BeFalse())
+			Expect(err).ToNot(HaveOccurred())
 		})
 		It("should fail migration for non-shared data volume PVCs", func() {
 
@@ -2002,7 +2249,34 @@ var _ = Describe("VirtualMachineInstance", func() {
 
 			blockMigrate, err := controller.checkVolumesForMigration(vmi)
 			Expect(blockMigrate).To(BeTrue())
-			Expect(err).To(Equal(fmt.Errorf("cannot migrate VMI: PVC testblock is not shared, live migration requires that all PVCs must be shared (using ReadWriteMany access mode)")))
+			Expect(err).To(MatchError("cannot migrate VMI: PVC testblock of volume myvolume is not shared, live migration requires that all PVCs must be shared (using ReadWriteMany access mode), its access modes are [ReadWriteOnce]"))
+		})
+		It("should report the non-shared PVC as the reason the VMI is not migratable", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "myvolume",
+					VolumeSource: v1.VolumeSource{
+						DataVolume: &v1.DataVolumeSource{
+							Name: "testblock",
+						},
+					},
+				},
+			}
+			vmi.Status.VolumeStatus = []v1.VolumeStatus{
+				{
+					Name: "myvolume",
+					PersistentVolumeClaimInfo: &v1.PersistentVolumeClaimInfo{
+						AccessModes: []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteOnce},
+					},
+				},
+			}
+
+			condition, isBlockMigration := controller.calculateLiveMigrationCondition(vmi)
+			Expect(isBlockMigration).To(BeTrue())
+			Expect(condition.Status).To(Equal(k8sv1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(v1.VirtualMachineInstanceReasonPVCNotMigratable))
+			Expect(condition.Message).To(ContainSubstring("PVC testblock of volume myvolume is not shared"))
 		})
 		It("should be allowed to migrate a mix of shared and non-shared disks", func() {
 
@@ -2196,6 +2470,24 @@ var _ = Describe("VirtualMachineInstance", func() {
 			Expect(blockMigrate).To(BeTrue())
 			Expect(err).To(Equal(fmt.Errorf("cannot migrate VMI with non-shared HostDisk")))
 		})
+		It("should not be allowed to live-migrate a containerDisk with an overlay on a PVC", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "myvolume",
+					VolumeSource: v1.VolumeSource{
+						ContainerDisk: &v1.ContainerDiskSource{
+							Image:   "my-image",
+							Overlay: &v1.ContainerDiskOverlay{ClaimName: "overlay-pvc"},
+						},
+					},
+				},
+			}
+
+			blockMigrate, err := controller.checkVolumesForMigration(vmi)
+			Expect(blockMigrate).To(BeTrue())
+			Expect(err).To(MatchError("cannot migrate VMI: containerDisk myvolume keeps its overlay on PVC overlay-pvc"))
+		})
 		table.DescribeTable("when host model labels", func(toDefineHostModelLabels bool) {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.Spec.Domain.CPU = &v1.CPU{Model: v1.CPUModeHostModel}
@@ -2244,6 +2536,18 @@ var _ = Describe("VirtualMachineInstance", func() {
 			Expect(condition.Reason).To(Equal(v1.VirtualMachineInstanceReasonVirtIOFSNotMigratable))
 		})
 
+		It("should not be allowed to live-migrate if the VMI uses hyperv passthrough", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Features = &v1.Features{
+				HypervPassthrough: &v1.HyperVPassthrough{},
+			}
+
+			condition, _ := controller.calculateLiveMigrationCondition(vmi)
+			Expect(condition.Type).To(Equal(v1.VirtualMachineInstanceIsMigratable))
+			Expect(condition.Status).To(Equal(k8sv1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(v1.VirtualMachineInstanceReasonHypervPassthroughNotMigratable))
+		})
+
 		Context("with network configuration", func() {
 			It("should block migration for bridge binding assigned to the pod network", func() {
 				vmi := v1.NewMinimalVMI("testvmi")
@@ -2692,6 +2996,35 @@ var _ = Describe("VirtualMachineInstance", func() {
 			testutils.ExpectEvent(recorder, VMIStarted)
 		})
 
+		It("should update Guest hostname and filesystem free space in VMI status", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Scheduled
+
+			mockWatchdog.CreateFile(vmi)
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+			domain.Status.Hostname = "testvmi.example.org"
+			domain.Status.Filesystems = []api.Filesystem{
+				{Name: "sda1", Mountpoint: "/", Type: "xfs", UsedBytes: 1024, TotalBytes: 4096},
+			}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				status := arg.(*v1.VirtualMachineInstance).Status
+				Expect(status.GuestHostname).To(Equal("testvmi.example.org"))
+				Expect(status.FSFreeSpace).To(Equal([]v1.VirtualMachineInstanceFileSystemFreeSpace{
+					{MountPoint: "/", FileSystemType: "xfs", FreeBytes: 3072, TotalBytes: 4096},
+				}))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, VMIStarted)
+		})
+
 		It("should update Guest FSFreeze Status in VMI status if fs frozen", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.UID = vmiTestUUID
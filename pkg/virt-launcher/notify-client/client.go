@@ -220,7 +220,8 @@ func newWatchEventError(err error) watch.Event {
 }
 
 func eventCallback(c cli.Connection, domain *api.Domain, libvirtEvent libvirtEvent, client *Notifier, events chan watch.Event,
-	interfaceStatus []api.InterfaceStatus, osInfo *api.GuestOSInfo, vmi *v1.VirtualMachineInstance, fsFreezeStatus *api.FSFreeze) {
+	interfaceStatus []api.InterfaceStatus, osInfo *api.GuestOSInfo, vmi *v1.VirtualMachineInstance, fsFreezeStatus *api.FSFreeze,
+	hostname *string, filesystems []api.Filesystem, migrationProgress *api.MigrationProgress) {
 	d, err := c.LookupDomainByName(util.DomainFromNamespaceName(domain.ObjectMeta.Namespace, domain.ObjectMeta.Name))
 	if err != nil {
 		if !domainerrors.IsNotFound(err) {
@@ -327,6 +328,18 @@ func eventCallback(c cli.Connection, domain *api.Domain, libvirtEvent libvirtEve
 			domain.Status.FSFreezeStatus = *fsFreezeStatus
 		}
 
+		if hostname != nil {
+			domain.Status.Hostname = *hostname
+		}
+
+		if filesystems != nil {
+			domain.Status.Filesystems = filesystems
+		}
+
+		if migrationProgress != nil {
+			domain.Status.MigrationProgress = migrationProgress
+		}
+
 		err := client.SendDomainEvent(watch.Event{Type: watch.Modified, Object: domain})
 		if err != nil {
 			log.Log.Reason(err).Error("Could not send domain notify event.")
@@ -389,11 +402,14 @@ func (n *Notifier) StartDomainNotifier(
 		var interfaceStatuses []api.InterfaceStatus
 		var guestOsInfo *api.GuestOSInfo
 		var fsFreezeStatus *api.FSFreeze
+		var guestHostname *string
+		var guestFilesystems []api.Filesystem
+		var migrationProgress *api.MigrationProgress
 		for {
 			select {
 			case event := <-eventChan:
 				domainCache = util.NewDomainFromName(event.Domain, vmi.UID)
-				eventCallback(domainConn, domainCache, event, n, deleteNotificationSent, interfaceStatuses, guestOsInfo, vmi, fsFreezeStatus)
+				eventCallback(domainConn, domainCache, event, n, deleteNotificationSent, interfaceStatuses, guestOsInfo, vmi, fsFreezeStatus, guestHostname, guestFilesystems, migrationProgress)
 				log.Log.Infof("Domain name event: %v", domainCache.Spec.Name)
 				if event.AgentEvent != nil {
 					if event.AgentEvent.State == libvirt.CONNECT_DOMAIN_EVENT_AGENT_LIFECYCLE_STATE_CONNECTED {
@@ -406,12 +422,22 @@ func (n *Notifier) StartDomainNotifier(
 				interfaceStatuses = agentUpdate.DomainInfo.Interfaces
 				guestOsInfo = agentUpdate.DomainInfo.OSInfo
 				fsFreezeStatus = agentUpdate.DomainInfo.FSFreezeStatus
+				// The hostname and the filesystems change rarely, keep them for events of other commands
+				switch agentUpdate.Type {
+				case agentpoller.GET_HOSTNAME:
+					guestHostname = agentUpdate.DomainInfo.Hostname
+				case agentpoller.GET_FILESYSTEM:
+					guestFilesystems = agentUpdate.DomainInfo.Filesystems
+				}
 				if interfaceStatuses != nil {
 					interfaceStatuses = agentpoller.MergeAgentStatusesWithDomainData(domainCache.Spec.Devices.Interfaces, interfaceStatuses)
 				}
 
 				eventCallback(domainConn, domainCache, libvirtEvent{}, n, deleteNotificationSent,
-					interfaceStatuses, guestOsInfo, vmi, fsFreezeStatus)
+					interfaceStatuses, guestOsInfo, vmi, fsFreezeStatus, guestHostname, guestFilesystems, migrationProgress)
+			case migrationProgress = <-agentStore.MigrationProgressUpdated:
+				eventCallback(domainConn, domainCache, libvirtEvent{}, n, deleteNotificationSent,
+					interfaceStatuses, guestOsInfo, vmi, fsFreezeStatus, guestHostname, guestFilesystems, migrationProgress)
 			case <-reconnectChan:
 				n.SendDomainEvent(newWatchEventError(fmt.Errorf("Libvirt reconnect, domain %s", domainName)))
 			}
@@ -62,13 +62,17 @@ type AgentUpdatedEvent struct {
 type AsyncAgentStore struct {
 	store        sync.Map
 	AgentUpdated chan AgentUpdatedEvent
+	// MigrationProgressUpdated passes the progress of the outgoing migration from the migration
+	// monitor to the domain notifier, nil clears it. The progress is no agent data and not stored.
+	MigrationProgressUpdated chan *api.MigrationProgress
 }
 
 // NewAsyncAgentStore creates new agent store
 func NewAsyncAgentStore() AsyncAgentStore {
 	return AsyncAgentStore{
-		store:        sync.Map{},
-		AgentUpdated: make(chan AgentUpdatedEvent, 10),
+		store:                    sync.Map{},
+		AgentUpdated:             make(chan AgentUpdatedEvent, 10),
+		MigrationProgressUpdated: make(chan *api.MigrationProgress, 10),
 	}
 }
 
@@ -94,6 +98,11 @@ func (s *AsyncAgentStore) Store(key AgentCommand, value interface{}) {
 		case GET_FSFREEZE_STATUS:
 			status := value.(api.FSFreeze)
 			domainInfo.FSFreezeStatus = &status
+		case GET_HOSTNAME:
+			hostname := value.(string)
+			domainInfo.Hostname = &hostname
+		case GET_FILESYSTEM:
+			domainInfo.Filesystems = value.([]api.Filesystem)
 		}
 
 		s.AgentUpdated <- AgentUpdatedEvent{
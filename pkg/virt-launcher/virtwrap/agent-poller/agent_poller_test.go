@@ -114,6 +114,30 @@ var _ = Describe("Qemu agent poller", func() {
 			Expect(agentStore.AgentUpdated).ToNot(Receive())
 		})
 
+		It("should fire an event for a new hostname", func() {
+			var agentStore = NewAsyncAgentStore()
+			hostname := "testvmi.example.org"
+			agentStore.Store(GET_HOSTNAME, hostname)
+
+			Expect(agentStore.AgentUpdated).To(Receive(Equal(AgentUpdatedEvent{
+				Type:       GET_HOSTNAME,
+				DomainInfo: api.DomainGuestInfo{Hostname: &hostname},
+			})))
+		})
+
+		It("should fire an event for new filesystem usage", func() {
+			var agentStore = NewAsyncAgentStore()
+			filesystems := []api.Filesystem{
+				{Name: "sda1", Mountpoint: "/", Type: "xfs", UsedBytes: 1024, TotalBytes: 4096},
+			}
+			agentStore.Store(GET_FILESYSTEM, filesystems)
+
+			Expect(agentStore.AgentUpdated).To(Receive(Equal(AgentUpdatedEvent{
+				Type:       GET_FILESYSTEM,
+				DomainInfo: api.DomainGuestInfo{Filesystems: filesystems},
+			})))
+		})
+
 		It("should report nil slice when no interfaces exists", func() {
 			var agentStore = NewAsyncAgentStore()
 			interfacesStatus := agentStore.GetInterfaceStatus()
@@ -40,14 +40,23 @@ func GuestExec(virConn cli.Connection, domName string, command string, args []st
 	stdOut := ""
 	argsStr := ""
 	for _, arg := range args {
+		// quote the arguments as JSON strings, so that they can not alter the agent command
+		quotedArg, err := json.Marshal(arg)
+		if err != nil {
+			return "", err
+		}
 		if argsStr == "" {
-			argsStr = fmt.Sprintf("\"%s\"", arg)
+			argsStr = string(quotedArg)
 		} else {
-			argsStr = argsStr + fmt.Sprintf(", \"%s\"", arg)
+			argsStr = argsStr + ", " + string(quotedArg)
 		}
 	}
+	quotedCommand, err := json.Marshal(command)
+	if err != nil {
+		return "", err
+	}
 
-	cmdExec := fmt.Sprintf(`{"execute": "guest-exec", "arguments": { "path": "%s", "arg": [ %s ], "capture-output":true } }`, command, argsStr)
+	cmdExec := fmt.Sprintf(`{"execute": "guest-exec", "arguments": { "path": %s, "arg": [ %s ], "capture-output":true } }`, quotedCommand, argsStr)
 	output, err := virConn.QemuAgentCommand(cmdExec, domName)
 	if err != nil {
 		return "", err
@@ -231,6 +231,22 @@ func (in *BootOrder) DeepCopy() *BootOrder {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CID) DeepCopyInto(out *CID) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CID.
+func (in *CID) DeepCopy() *CID {
+	if in == nil {
+		return nil
+	}
+	out := new(CID)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CPU) DeepCopyInto(out *CPU) {
 	*out = *in
@@ -724,6 +740,18 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 		*out = make([]RedirectedDevice, len(*in))
 		copy(*out, *in)
 	}
+	if in.SoundCards != nil {
+		in, out := &in.SoundCards, &out.SoundCards
+		*out = make([]SoundCard, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VSOCK != nil {
+		in, out := &in.VSOCK, &out.VSOCK
+		*out = new(VSOCK)
+		**out = **in
+	}
 	return
 }
 
@@ -782,6 +810,11 @@ func (in *Disk) DeepCopyInto(out *Disk) {
 		*out = new(BlockIO)
 		**out = **in
 	}
+	if in.IOTune != nil {
+		in, out := &in.IOTune, &out.IOTune
+		*out = new(IOTune)
+		**out = **in
+	}
 	return
 }
 
@@ -866,6 +899,11 @@ func (in *DiskSource) DeepCopyInto(out *DiskSource) {
 		*out = new(DiskSourceHost)
 		**out = **in
 	}
+	if in.Reservations != nil {
+		in, out := &in.Reservations, &out.Reservations
+		*out = new(Reservations)
+		**out = **in
+	}
 	return
 }
 
@@ -959,6 +997,16 @@ func (in *DomainGuestInfo) DeepCopyInto(out *DomainGuestInfo) {
 		*out = new(FSFreeze)
 		**out = **in
 	}
+	if in.Hostname != nil {
+		in, out := &in.Hostname, &out.Hostname
+		*out = new(string)
+		**out = **in
+	}
+	if in.Filesystems != nil {
+		in, out := &in.Filesystems, &out.Filesystems
+		*out = make([]Filesystem, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1089,6 +1137,16 @@ func (in *DomainStatus) DeepCopyInto(out *DomainStatus) {
 	}
 	out.OSInfo = in.OSInfo
 	out.FSFreezeStatus = in.FSFreezeStatus
+	if in.Filesystems != nil {
+		in, out := &in.Filesystems, &out.Filesystems
+		*out = make([]Filesystem, len(*in))
+		copy(*out, *in)
+	}
+	if in.MigrationProgress != nil {
+		in, out := &in.MigrationProgress, &out.MigrationProgress
+		*out = new(MigrationProgress)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1765,6 +1823,22 @@ func (in *IOThreads) DeepCopy() *IOThreads {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IOTune) DeepCopyInto(out *IOTune) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IOTune.
+func (in *IOTune) DeepCopy() *IOTune {
+	if in == nil {
+		return nil
+	}
+	out := new(IOTune)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Input) DeepCopyInto(out *Input) {
 	*out = *in
@@ -2225,6 +2299,26 @@ func (in *MigrationMetadata) DeepCopy() *MigrationMetadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationProgress) DeepCopyInto(out *MigrationProgress) {
+	*out = *in
+	if in.UpdateTimestamp != nil {
+		in, out := &in.UpdateTimestamp, &out.UpdateTimestamp
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationProgress.
+func (in *MigrationProgress) DeepCopy() *MigrationProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Model) DeepCopyInto(out *Model) {
 	*out = *in
@@ -2444,6 +2538,22 @@ func (in *RedirectedDeviceSource) DeepCopy() *RedirectedDeviceSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Reservations) DeepCopyInto(out *Reservations) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Reservations.
+func (in *Reservations) DeepCopy() *Reservations {
+	if in == nil {
+		return nil
+	}
+	out := new(Reservations)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Resource) DeepCopyInto(out *Resource) {
 	*out = *in
@@ -2597,6 +2707,11 @@ func (in *Serial) DeepCopyInto(out *Serial) {
 		*out = new(SerialSource)
 		**out = **in
 	}
+	if in.Log != nil {
+		in, out := &in.Log, &out.Log
+		*out = new(SerialLog)
+		**out = **in
+	}
 	if in.Alias != nil {
 		in, out := &in.Alias, &out.Alias
 		*out = new(Alias)
@@ -2615,6 +2730,22 @@ func (in *Serial) DeepCopy() *Serial {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SerialLog) DeepCopyInto(out *SerialLog) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SerialLog.
+func (in *SerialLog) DeepCopy() *SerialLog {
+	if in == nil {
+		return nil
+	}
+	out := new(SerialLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SerialSource) DeepCopyInto(out *SerialSource) {
 	*out = *in
@@ -2652,6 +2783,27 @@ func (in *SerialTarget) DeepCopy() *SerialTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SoundCard) DeepCopyInto(out *SoundCard) {
+	*out = *in
+	if in.Alias != nil {
+		in, out := &in.Alias, &out.Alias
+		*out = new(Alias)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SoundCard.
+func (in *SoundCard) DeepCopy() *SoundCard {
+	if in == nil {
+		return nil
+	}
+	out := new(SoundCard)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Stats) DeepCopyInto(out *Stats) {
 	*out = *in
@@ -2789,6 +2941,22 @@ func (in *VCPU) DeepCopy() *VCPU {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSOCK) DeepCopyInto(out *VSOCK) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSOCK.
+func (in *VSOCK) DeepCopy() *VSOCK {
+	if in == nil {
+		return nil
+	}
+	out := new(VSOCK)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Video) DeepCopyInto(out *Video) {
 	*out = *in
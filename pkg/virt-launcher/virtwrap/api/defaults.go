@@ -29,6 +29,13 @@ func (d *Defaulter) IsARM64() bool {
 	return false
 }
 
+func (d *Defaulter) IsS390X() bool {
+	if d.Architecture == "s390x" {
+		return true
+	}
+	return false
+}
+
 func (d *Defaulter) SetDefaults_Devices(devices *Devices) {
 
 }
@@ -41,6 +48,8 @@ func (d *Defaulter) SetDefaults_OSType(ostype *OSType) {
 			ostype.Arch = "ppc64le"
 		} else if d.IsARM64() {
 			ostype.Arch = "aarch64"
+		} else if d.IsS390X() {
+			ostype.Arch = "s390x"
 		} else {
 			ostype.Arch = "x86_64"
 		}
@@ -53,6 +62,8 @@ func (d *Defaulter) SetDefaults_OSType(ostype *OSType) {
 			ostype.Machine = "pseries"
 		} else if d.IsARM64() {
 			ostype.Machine = "virt"
+		} else if d.IsS390X() {
+			ostype.Machine = "s390-ccw-virtio"
 		} else {
 			ostype.Machine = "q35"
 		}
@@ -110,6 +110,18 @@ type DomainStatus struct {
 	Interfaces     []InterfaceStatus
 	OSInfo         GuestOSInfo
 	FSFreezeStatus FSFreeze
+	Hostname       string
+	Filesystems    []Filesystem
+	// MigrationProgress is the progress of the running outgoing migration
+	MigrationProgress *MigrationProgress
+}
+
+type MigrationProgress struct {
+	UpdateTimestamp *metav1.Time
+	DataProcessed   uint64
+	MemRemaining    uint64
+	MemDirtyRate    uint64
+	MemIteration    uint64
 }
 
 type DomainSysInfo struct {
@@ -165,6 +177,8 @@ type DomainGuestInfo struct {
 	Interfaces     []InterfaceStatus
 	OSInfo         *GuestOSInfo
 	FSFreezeStatus *FSFreeze
+	Hostname       *string
+	Filesystems    []Filesystem
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -282,6 +296,7 @@ type Features struct {
 }
 
 type FeatureHyperv struct {
+	Mode            string            `xml:"mode,attr,omitempty"`
 	Relaxed         *FeatureState     `xml:"relaxed,omitempty"`
 	VAPIC           *FeatureState     `xml:"vapic,omitempty"`
 	Spinlocks       *FeatureSpinlocks `xml:"spinlocks,omitempty"`
@@ -298,6 +313,8 @@ type FeatureHyperv struct {
 	EVMCS           *FeatureState     `xml:"evmcs,omitempty"`
 }
 
+const HypervModePassthrough = "passthrough"
+
 type FeatureSpinlocks struct {
 	State   string  `xml:"state,attr,omitempty"`
 	Retries *uint32 `xml:"retries,attr,omitempty"`
@@ -441,6 +458,8 @@ type Devices struct {
 	Rng         *Rng               `xml:"rng,omitempty"`
 	Filesystems []FilesystemDevice `xml:"filesystem,omitempty"`
 	Redirs      []RedirectedDevice `xml:"redirdev,omitempty"`
+	SoundCards  []SoundCard        `xml:"sound,omitempty"`
+	VSOCK       *VSOCK             `xml:"vsock,omitempty"`
 }
 
 // RedirectedDevice describes a device to be redirected
@@ -512,6 +531,8 @@ type HostDevice struct {
 	Managed   string           `xml:"managed,attr"`
 	Mode      string           `xml:"mode,attr,omitempty"`
 	Model     string           `xml:"model,attr,omitempty"`
+	Display   string           `xml:"display,attr,omitempty"`
+	RamFB     string           `xml:"ramfb,attr,omitempty"`
 	Address   *Address         `xml:"address,emitempty"`
 	Alias     *Alias           `xml:"alias,omitempty"`
 }
@@ -553,6 +574,7 @@ type Disk struct {
 	Source       DiskSource    `xml:"source"`
 	Target       DiskTarget    `xml:"target"`
 	Serial       string        `xml:"serial,omitempty"`
+	WWN          string        `xml:"wwn,omitempty"`
 	Driver       *DiskDriver   `xml:"driver,omitempty"`
 	ReadOnly     *ReadOnly     `xml:"readonly,omitempty"`
 	Auth         *DiskAuth     `xml:"auth,omitempty"`
@@ -562,6 +584,17 @@ type Disk struct {
 	Address      *Address      `xml:"address,omitempty"`
 	Model        string        `xml:"model,attr,omitempty"`
 	BlockIO      *BlockIO      `xml:"blockio,omitempty"`
+	IOTune       *IOTune       `xml:"iotune,omitempty"`
+}
+
+// IOTune throttles the I/O of a disk, values of zero are unlimited
+type IOTune struct {
+	TotalBytesSec uint64 `xml:"total_bytes_sec,omitempty"`
+	ReadBytesSec  uint64 `xml:"read_bytes_sec,omitempty"`
+	WriteBytesSec uint64 `xml:"write_bytes_sec,omitempty"`
+	TotalIopsSec  uint64 `xml:"total_iops_sec,omitempty"`
+	ReadIopsSec   uint64 `xml:"read_iops_sec,omitempty"`
+	WriteIopsSec  uint64 `xml:"write_iops_sec,omitempty"`
 }
 
 type DiskAuth struct {
@@ -584,6 +617,13 @@ type DiskSource struct {
 	Protocol      string          `xml:"protocol,attr,omitempty"`
 	Name          string          `xml:"name,attr,omitempty"`
 	Host          *DiskSourceHost `xml:"host,omitempty"`
+	Reservations  *Reservations   `xml:"reservations,omitempty"`
+}
+
+// Reservations enables SCSI persistent reservations, with managed set to yes
+// libvirt starts a qemu-pr-helper for the domain
+type Reservations struct {
+	Managed string `xml:"managed,attr"`
 }
 
 type DiskTarget struct {
@@ -631,6 +671,7 @@ type Serial struct {
 	Type   string        `xml:"type,attr"`
 	Target *SerialTarget `xml:"target,omitempty"`
 	Source *SerialSource `xml:"source,omitempty"`
+	Log    *SerialLog    `xml:"log,omitempty"`
 	Alias  *Alias        `xml:"alias,omitempty"`
 }
 
@@ -643,6 +684,11 @@ type SerialSource struct {
 	Path string `xml:"path,attr,omitempty"`
 }
 
+type SerialLog struct {
+	File   string `xml:"file,attr,omitempty"`
+	Append string `xml:"append,attr,omitempty"`
+}
+
 // END Serial -----------------------------
 
 // BEGIN Console -----------------------------
@@ -687,7 +733,7 @@ type Interface struct {
 }
 
 type InterfaceDriver struct {
-	Name   string `xml:"name,attr"`
+	Name   string `xml:"name,attr,omitempty"`
 	Queues *uint  `xml:"queues,attr,omitempty"`
 }
 
@@ -715,6 +761,8 @@ type FilterRef struct {
 }
 
 type InterfaceSource struct {
+	Type    string   `xml:"type,attr,omitempty"`
+	Path    string   `xml:"path,attr,omitempty"`
 	Network string   `xml:"network,attr,omitempty"`
 	Device  string   `xml:"dev,attr,omitempty"`
 	Bridge  string   `xml:"bridge,attr,omitempty"`
@@ -830,8 +878,8 @@ type Boot struct {
 }
 
 type BootMenu struct {
-	Enabled bool  `xml:"enabled,attr"`
-	Timeout *uint `xml:"timeout,attr,omitempty"`
+	Enable  string `xml:"enable,attr"`
+	Timeout *uint  `xml:"timeout,attr,omitempty"`
 }
 
 type Loader struct {
@@ -944,6 +992,7 @@ type Address struct {
 	Target     string `xml:"target,attr,omitempty"`
 	Unit       string `xml:"unit,attr,omitempty"`
 	UUID       string `xml:"uuid,attr,omitempty"`
+	Device     string `xml:"device,attr,omitempty"`
 }
 
 //END Video -------------------
@@ -953,9 +1002,27 @@ type Stats struct {
 }
 
 type MemBalloon struct {
-	Model   string   `xml:"model,attr"`
-	Stats   *Stats   `xml:"stats,omitempty"`
-	Address *Address `xml:"address,emitempty"`
+	Model             string   `xml:"model,attr"`
+	FreePageReporting string   `xml:"freePageReporting,attr,omitempty"`
+	Stats             *Stats   `xml:"stats,omitempty"`
+	Address           *Address `xml:"address,emitempty"`
+}
+
+// VSOCK is a virtio socket between the host and the guest
+// See: https://libvirt.org/formatdomain.html#vsock
+type VSOCK struct {
+	Model string `xml:"model,attr"`
+	CID   CID    `xml:"cid"`
+}
+
+type CID struct {
+	Auto    string `xml:"auto,attr"`
+	Address uint32 `xml:"address,attr,omitempty"`
+}
+
+type SoundCard struct {
+	Alias *Alias `xml:"alias,omitempty"`
+	Model string `xml:"model,attr"`
 }
 
 type Watchdog struct {
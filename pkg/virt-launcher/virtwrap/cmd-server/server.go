@@ -208,6 +208,23 @@ func (l *Launcher) PauseVirtualMachine(_ context.Context, request *cmdv1.VMIRequ
 	return response, nil
 }
 
+func (l *Launcher) VirtualMachineMemoryDump(_ context.Context, request *cmdv1.MemoryDumpRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if err := l.domainManager.MemoryDump(vmi, request.DumpPath); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to dump the memory of vmi")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Infof("Dumped the memory of vmi to %s", request.DumpPath)
+	return response, nil
+}
+
 func (l *Launcher) UnpauseVirtualMachine(_ context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
 	vmi, response := getVMIFromRequest(request.Vmi)
 	if !response.Success {
@@ -533,6 +550,7 @@ func RunServer(socketPath string,
 	// register more versions as soon as needed
 	// and add them to info.go
 	cmdv1.RegisterCmdServer(grpcServer, server)
+	healthServer := grpcutil.RegisterHealthAndReflectionServers(grpcServer)
 
 	sock, err := grpcutil.CreateSocket(socketPath)
 	if err != nil {
@@ -545,6 +563,7 @@ func RunServer(socketPath string,
 		select {
 		case <-stopChan:
 			log.Log.Info("stopping cmd server")
+			healthServer.Shutdown()
 			stopped := make(chan struct{})
 			go func() {
 				grpcServer.Stop()
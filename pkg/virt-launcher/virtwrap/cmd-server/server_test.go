@@ -30,10 +30,13 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/info"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
+	grpcutil "kubevirt.io/kubevirt/pkg/util/net/grpc"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/agent"
@@ -53,6 +56,7 @@ var _ = Describe("Virt remote commands", func() {
 	var stopped bool
 	var allowEmulation bool
 	var options *ServerOptions
+	var socketPath string
 
 	BeforeEach(func() {
 		stop = make(chan struct{})
@@ -63,7 +67,7 @@ var _ = Describe("Virt remote commands", func() {
 		ctrl = gomock.NewController(GinkgoT())
 		domainManager = virtwrap.NewMockDomainManager(ctrl)
 
-		socketPath := filepath.Join(shareDir, "server.sock")
+		socketPath = filepath.Join(shareDir, "server.sock")
 
 		allowEmulation = true
 		options = NewServerOptions(allowEmulation)
@@ -82,6 +86,39 @@ var _ = Describe("Virt remote commands", func() {
 	})
 
 	Context("server", func() {
+		It("should report the cmd server as serving to health checks", func() {
+			conn, err := grpcutil.DialSocket(socketPath)
+			Expect(err).ToNot(HaveOccurred())
+			defer conn.Close()
+
+			healthClient := healthpb.NewHealthClient(conn)
+			for _, service := range []string{"", "kubevirt.cmd.v1.Cmd", "kubevirt.cmd.info.CmdInfo"} {
+				resp, err := healthClient.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(resp.Status).To(Equal(healthpb.HealthCheckResponse_SERVING))
+			}
+		})
+
+		It("should list the cmd server services via reflection", func() {
+			conn, err := grpcutil.DialSocket(socketPath)
+			Expect(err).ToNot(HaveOccurred())
+			defer conn.Close()
+
+			stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stream.Send(&rpb.ServerReflectionRequest{
+				MessageRequest: &rpb.ServerReflectionRequest_ListServices{},
+			})).To(Succeed())
+			resp, err := stream.Recv()
+			Expect(err).ToNot(HaveOccurred())
+
+			services := []string{}
+			for _, service := range resp.GetListServicesResponse().Service {
+				services = append(services, service.Name)
+			}
+			Expect(services).To(ContainElements("kubevirt.cmd.v1.Cmd", "grpc.health.v1.Health"))
+		})
+
 		It("should start a vmi", func() {
 			vmi := v1.NewVMIReferenceFromName("testvmi")
 			domain := api.NewMinimalDomain("testvmi")
@@ -127,6 +164,13 @@ var _ = Describe("Virt remote commands", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		It("should dump the memory of a vmi", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().MemoryDump(vmi, "/var/run/kubevirt-private/memory.dump")
+			err := client.VirtualMachineMemoryDump(vmi, "/var/run/kubevirt-private/memory.dump")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
 		It("should unpause a vmi", func() {
 			vmi := v1.NewVMIReferenceFromName("testvmi")
 			domainManager.EXPECT().UnpauseVMI(vmi)
@@ -117,6 +117,12 @@ type ConverterContext struct {
 	EphemeraldiskCreator  ephemeraldisk.EphemeralDiskCreatorInterface
 	VolumesDiscardIgnore  []string
 	Topology              *cmdv1.Topology
+	// SearchDomains returns the DNS search domains which are passed to slirp networks. The search
+	// domains of the resolv.conf of the pod are used if it is nil.
+	SearchDomains func() ([]string, error)
+	// OptimalBlockIO returns the block sizes of disks which match the block sizes of their volume.
+	// The volume is inspected if it is nil.
+	OptimalBlockIO func(disk *api.Disk) (*api.BlockIO, error)
 }
 
 func contains(volumes []string, name string) bool {
@@ -128,6 +134,18 @@ func contains(volumes []string, name string) bool {
 	return false
 }
 
+// getDefaultCacheMode returns the cache mode configured for the storage class of the PVC behind a disk
+func getDefaultCacheMode(c *ConverterContext, diskName string) v1.DriverCache {
+	status, exists := c.PermanentVolumes[diskName]
+	if !exists {
+		status, exists = c.HotplugVolumes[diskName]
+	}
+	if !exists || status.PersistentVolumeClaimInfo == nil {
+		return ""
+	}
+	return status.PersistentVolumeClaimInfo.CacheMode
+}
+
 func isPPC64(arch string) bool {
 	if arch == "ppc64le" {
 		return true
@@ -142,6 +160,20 @@ func isARM64(arch string) bool {
 	return false
 }
 
+func isGraphicsDeviceAutoattached(vmi *v1.VirtualMachineInstance) bool {
+	return vmi.Spec.Domain.Devices.AutoattachGraphicsDevice == nil || *vmi.Spec.Domain.Devices.AutoattachGraphicsDevice
+}
+
+// nvmeDevicePrefix is the name of the emulated NVMe controller, followed by the number of the namespace
+const nvmeDevicePrefix = "nvme0n"
+
+func isS390X(arch string) bool {
+	if arch == "s390x" {
+		return true
+	}
+	return false
+}
+
 func Convert_v1_Disk_To_api_Disk(c *ConverterContext, diskDevice *v1.Disk, disk *api.Disk, prefixMap map[string]deviceNamer, numQueues *uint) error {
 	if diskDevice.Disk != nil {
 		var unit int
@@ -176,11 +208,15 @@ func Convert_v1_Disk_To_api_Disk(c *ConverterContext, diskDevice *v1.Disk, disk
 		}
 		disk.ReadOnly = toApiReadOnly(diskDevice.Disk.ReadOnly)
 		disk.Serial = diskDevice.Serial
+		disk.WWN = diskDevice.WWN
 	} else if diskDevice.LUN != nil {
 		disk.Device = "lun"
 		disk.Target.Bus = diskDevice.LUN.Bus
 		disk.Target.Device, _ = makeDeviceName(diskDevice.Name, diskDevice.LUN.Bus, prefixMap)
 		disk.ReadOnly = toApiReadOnly(diskDevice.LUN.ReadOnly)
+		if diskDevice.LUN.Reservation {
+			disk.Source.Reservations = &api.Reservations{Managed: "yes"}
+		}
 	} else if diskDevice.Floppy != nil {
 		disk.Device = "floppy"
 		disk.Target.Bus = "fdc"
@@ -192,6 +228,7 @@ func Convert_v1_Disk_To_api_Disk(c *ConverterContext, diskDevice *v1.Disk, disk
 		disk.Target.Tray = string(diskDevice.CDRom.Tray)
 		disk.Target.Bus = diskDevice.CDRom.Bus
 		disk.Target.Device, _ = makeDeviceName(diskDevice.Name, diskDevice.CDRom.Bus, prefixMap)
+		disk.WWN = diskDevice.WWN
 		if diskDevice.CDRom.ReadOnly != nil {
 			disk.ReadOnly = toApiReadOnly(*diskDevice.CDRom.ReadOnly)
 		} else {
@@ -204,11 +241,18 @@ func Convert_v1_Disk_To_api_Disk(c *ConverterContext, diskDevice *v1.Disk, disk
 		IO:          diskDevice.IO,
 		ErrorPolicy: "stop",
 	}
+	if disk.Driver.Cache == "" {
+		disk.Driver.Cache = string(getDefaultCacheMode(c, diskDevice.Name))
+	}
 	if diskDevice.Disk != nil || diskDevice.LUN != nil {
 		if !contains(c.VolumesDiscardIgnore, diskDevice.Name) {
 			disk.Driver.Discard = "unmap"
 		}
 	}
+	if diskDevice.Queues != nil {
+		queues := uint(*diskDevice.Queues)
+		numQueues = &queues
+	}
 	if numQueues != nil && disk.Target.Bus == "virtio" {
 		disk.Driver.Queues = numQueues
 	}
@@ -216,10 +260,37 @@ func Convert_v1_Disk_To_api_Disk(c *ConverterContext, diskDevice *v1.Disk, disk
 	if diskDevice.BootOrder != nil {
 		disk.BootOrder = &api.BootOrder{Order: *diskDevice.BootOrder}
 	}
+	disk.IOTune = toApiIOTune(diskDevice.IOTune)
 
 	return nil
 }
 
+func toApiIOTune(ioTune *v1.DiskIOTune) *api.IOTune {
+	if ioTune == nil {
+		return nil
+	}
+	bytesPerSec := func(q *resource.Quantity) uint64 {
+		if q == nil {
+			return 0
+		}
+		return uint64(q.Value())
+	}
+	iops := func(i *int64) uint64 {
+		if i == nil {
+			return 0
+		}
+		return uint64(*i)
+	}
+	return &api.IOTune{
+		TotalBytesSec: bytesPerSec(ioTune.TotalBytesPerSec),
+		ReadBytesSec:  bytesPerSec(ioTune.ReadBytesPerSec),
+		WriteBytesSec: bytesPerSec(ioTune.WriteBytesPerSec),
+		TotalIopsSec:  iops(ioTune.TotalIOPS),
+		ReadIopsSec:   iops(ioTune.ReadIOPS),
+		WriteIopsSec:  iops(ioTune.WriteIOPS),
+	}
+}
+
 type DirectIOChecker interface {
 	CheckBlockDevice(path string) (bool, error)
 	CheckFile(path string) (bool, error)
@@ -265,7 +336,7 @@ func (c *directIOChecker) check(path string, flags int) (bool, error) {
 	return true, nil
 }
 
-func Convert_v1_BlockSize_To_api_BlockIO(source *v1.Disk, disk *api.Disk) error {
+func Convert_v1_BlockSize_To_api_BlockIO(source *v1.Disk, disk *api.Disk, c *ConverterContext) error {
 	if source.BlockSize == nil {
 		return nil
 	}
@@ -276,7 +347,11 @@ func Convert_v1_BlockSize_To_api_BlockIO(source *v1.Disk, disk *api.Disk) error
 			PhysicalBlockSize: blockSize.Physical,
 		}
 	} else if matchFeature := source.BlockSize.MatchVolume; matchFeature != nil && (matchFeature.Enabled == nil || *matchFeature.Enabled) {
-		blockIO, err := getOptimalBlockIO(disk)
+		getBlockIO := getOptimalBlockIO
+		if c.OptimalBlockIO != nil {
+			getBlockIO = c.OptimalBlockIO
+		}
+		blockIO, err := getBlockIO(disk)
 		if err != nil {
 			return fmt.Errorf("failed to configure disk with block size detection enabled: %v", err)
 		}
@@ -474,7 +549,7 @@ func makeDeviceName(diskName, bus string, prefixMap map[string]deviceNamer) (str
 	deviceNamer := prefixMap[prefix]
 	if name, ok := deviceNamer.getExistingVolumeValue(diskName); ok {
 		for i := 0; i < 26*26*26; i++ {
-			calculatedName := FormatDeviceName(prefix, i)
+			calculatedName := formatDeviceName(prefix, i)
 			if calculatedName == name {
 				return name, i
 			}
@@ -484,7 +559,7 @@ func makeDeviceName(diskName, bus string, prefixMap map[string]deviceNamer) (str
 	}
 	// Name not found yet, generate next new one.
 	for i := 0; i < 26*26*26; i++ {
-		name := FormatDeviceName(prefix, i)
+		name := formatDeviceName(prefix, i)
 		if _, ok := deviceNamer.getExistingTargetValue(name); !ok {
 			deviceNamer.existingNameMap[diskName] = name
 			deviceNamer.usedDeviceMap[name] = diskName
@@ -494,6 +569,14 @@ func makeDeviceName(diskName, bus string, prefixMap map[string]deviceNamer) (str
 	return "", 0
 }
 
+// formatDeviceName names NVMe namespaces by their number, which starts at 1, and all other devices like the kernel
+func formatDeviceName(prefix string, index int) string {
+	if prefix == nvmeDevicePrefix {
+		return prefix + strconv.Itoa(index+1)
+	}
+	return FormatDeviceName(prefix, index)
+}
+
 // port of http://elixir.free-electrons.com/linux/v4.15/source/drivers/scsi/sd.c#L3211
 func FormatDeviceName(prefix string, index int) string {
 	base := int('z' - 'a' + 1)
@@ -783,7 +866,7 @@ func Convert_v1_EmptyDiskSource_To_api_Disk(volumeName string, _ *v1.EmptyDiskSo
 	return nil
 }
 
-func Convert_v1_ContainerDiskSource_To_api_Disk(volumeName string, _ *v1.ContainerDiskSource, disk *api.Disk, c *ConverterContext, diskIndex int) error {
+func Convert_v1_ContainerDiskSource_To_api_Disk(volumeName string, containerDisk *v1.ContainerDiskSource, disk *api.Disk, c *ConverterContext, diskIndex int) error {
 	if disk.Type == "lun" {
 		return fmt.Errorf("device %s is of type lun. Not compatible with a file based disk", disk.Alias.GetName())
 	}
@@ -791,7 +874,11 @@ func Convert_v1_ContainerDiskSource_To_api_Disk(volumeName string, _ *v1.Contain
 	disk.Driver.Type = "qcow2"
 	disk.Driver.ErrorPolicy = "stop"
 	disk.Driver.Discard = "unmap"
-	disk.Source.File = c.EphemeraldiskCreator.GetFilePath(volumeName)
+	if containerDisk.Overlay != nil && containerDisk.Overlay.ClaimName != "" {
+		disk.Source.File = c.EphemeraldiskCreator.GetPVCFilePath(volumeName)
+	} else {
+		disk.Source.File = c.EphemeraldiskCreator.GetFilePath(volumeName)
+	}
 	disk.BackingStore = &api.BackingStore{
 		Format: &api.BackingStoreFormat{},
 		Source: &api.DiskSource{},
@@ -970,7 +1057,8 @@ func convertFeatureState(source *v1.FeatureState) *api.FeatureState {
 }
 
 func Convert_v1_Features_To_api_Features(source *v1.Features, features *api.Features, c *ConverterContext) error {
-	if source.ACPI.Enabled == nil || *source.ACPI.Enabled {
+	// s390x has no ACPI
+	if (source.ACPI.Enabled == nil || *source.ACPI.Enabled) && !isS390X(c.Architecture) {
 		features.ACPI = &api.FeatureEnabled{}
 	}
 	if source.SMM != nil {
@@ -990,6 +1078,11 @@ func Convert_v1_Features_To_api_Features(source *v1.Features, features *api.Feat
 			return nil
 		}
 	}
+	if source.HypervPassthrough != nil && (source.HypervPassthrough.Enabled == nil || *source.HypervPassthrough.Enabled) {
+		features.Hyperv = &api.FeatureHyperv{
+			Mode: api.HypervModePassthrough,
+		}
+	}
 	if source.KVM != nil {
 		features.KVM = &api.FeatureKVM{
 			Hidden: &api.FeatureState{
@@ -1060,7 +1153,9 @@ func ConvertV1ToAPIBalloning(source *v1.Devices, ballooning *api.MemBalloon, c *
 		if c.MemBalloonStatsPeriod != 0 {
 			ballooning.Stats = &api.Stats{Period: c.MemBalloonStatsPeriod}
 		}
-
+		if source != nil && source.FreePageReporting != nil && *source.FreePageReporting {
+			ballooning.FreePageReporting = "on"
+		}
 	}
 }
 
@@ -1166,6 +1261,14 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 			}
 		}
 
+		if bootMenu := vmi.Spec.Domain.Firmware.BootMenu; bootMenu != nil {
+			domain.Spec.OS.BootMenu = &api.BootMenu{Enable: "yes"}
+			if bootMenu.Timeout != nil {
+				timeout := uint(*bootMenu.Timeout)
+				domain.Spec.OS.BootMenu.Timeout = &timeout
+			}
+		}
+
 		if len(vmi.Spec.Domain.Firmware.Serial) > 0 {
 			domain.Spec.SysInfo.System = append(domain.Spec.SysInfo.System, api.Entry{Name: "serial", Value: string(vmi.Spec.Domain.Firmware.Serial)})
 		}
@@ -1224,7 +1327,8 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 	// SMBios option does not work in Power, attempting to set it will result in the following error message:
 	// "Option not supported for this target" issued by qemu-system-ppc64, so don't set it in case GOARCH is ppc64le
 	// ARM64 use UEFI boot by default, set SMBios is unnecessory.
-	if !isPPC64(c.Architecture) && !isARM64(c.Architecture) {
+	// s390x has no SMBIOS.
+	if !isPPC64(c.Architecture) && !isARM64(c.Architecture) && !isS390X(c.Architecture) {
 		domain.Spec.OS.SMBios = &api.SMBios{
 			Mode: "sysinfo",
 		}
@@ -1268,8 +1372,8 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 			isMemfdRequired = true
 		}
 	}
-	// virtiofs require shared access
-	if util.IsVMIVirtiofsEnabled(vmi) {
+	// virtiofs and vhost-user require shared access
+	if util.IsVMIVirtiofsEnabled(vmi) || util.IsVhostUserVmi(vmi) {
 		if domain.Spec.MemoryBacking == nil {
 			domain.Spec.MemoryBacking = &api.MemoryBacking{}
 		}
@@ -1393,8 +1497,12 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		if err != nil {
 			return err
 		}
+		// An explicit discard mode wins over the default of the volume
+		if disk.Discard != "" {
+			newDisk.Driver.Discard = string(disk.Discard)
+		}
 
-		if err := Convert_v1_BlockSize_To_api_BlockIO(&disk, &newDisk); err != nil {
+		if err := Convert_v1_BlockSize_To_api_BlockIO(&disk, &newDisk, c); err != nil {
 			return err
 		}
 
@@ -1425,6 +1533,10 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		// if len(c.PermanentVolumes) == 0, it means the vmi is not ready yet, add all disks
 		if _, ok := c.PermanentVolumes[disk.Name]; ok || len(c.PermanentVolumes) == 0 || (hpOk && (hpStatus.Phase == v1.HotplugVolumeMounted || hpStatus.Phase == v1.VolumeReady)) {
 			domain.Spec.Devices.Disks = append(domain.Spec.Devices.Disks, newDisk)
+		} else if disk.CDRom != nil {
+			// The new media of the CD-ROM is not mounted yet, keep the drive empty until it is
+			newDisk.Source = api.DiskSource{}
+			domain.Spec.Devices.Disks = append(domain.Spec.Devices.Disks, newDisk)
 		}
 	}
 	// Handle virtioFS
@@ -1457,9 +1569,16 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 			if volume == nil {
 				return fmt.Errorf("No matching volume with name %s found", fs.Name)
 			}
-			volDir, _ := filepath.Split(GetFilesystemVolumePath(volume.Name))
 			newFS.Source = &api.FilesystemSource{}
-			newFS.Source.Dir = volDir
+			switch {
+			case volume.ConfigMap != nil:
+				newFS.Source.Dir = config.GetConfigMapSourcePath(volume.Name)
+			case volume.Secret != nil:
+				newFS.Source.Dir = config.GetSecretSourcePath(volume.Name)
+			default:
+				volDir, _ := filepath.Split(GetFilesystemVolumePath(volume.Name))
+				newFS.Source.Dir = volDir
+			}
 			domain.Spec.Devices.Filesystems = append(domain.Spec.Devices.Filesystems, newFS)
 		}
 	}
@@ -1473,6 +1592,28 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		domain.Spec.Devices.Watchdog = newWatchdog
 	}
 
+	if vmi.Spec.Domain.Devices.Sound != nil {
+		// Default is ich9
+		model := "ich9"
+		if vmi.Spec.Domain.Devices.Sound.Model == "ac97" {
+			model = "ac97"
+		}
+		domain.Spec.Devices.SoundCards = []api.SoundCard{
+			{Model: model, Alias: api.NewUserDefinedAlias(vmi.Spec.Domain.Devices.Sound.Name)},
+		}
+	}
+
+	if util.IsAutoAttachVSOCK(vmi) && vmi.Status.VSOCKCID != nil {
+		domain.Spec.Devices.VSOCK = &api.VSOCK{
+			// The CID is unique in the cluster, libvirt must not pick one
+			Model: translateModel(c, "virtio"),
+			CID: api.CID{
+				Auto:    "no",
+				Address: *vmi.Status.VSOCKCID,
+			},
+		}
+	}
+
 	if vmi.Spec.Domain.Devices.Rng != nil {
 		newRng := &api.Rng{}
 		err := Convert_v1_Rng_To_api_Rng(vmi.Spec.Domain.Devices.Rng, newRng, c)
@@ -1499,6 +1640,12 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		domain.Spec.Devices.Inputs = inputDevices
 	}
 
+	for _, hostDevice := range c.GenericHostDevices {
+		if hostDevice.Type == "usb" {
+			isUSBDevicePresent = true
+		}
+	}
+
 	isUSBRedirEnabled, err := Convert_v1_Usbredir_To_api_Usbredir(vmi, &domain.Spec.Devices, c)
 	if err != nil {
 		return err
@@ -1507,12 +1654,18 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 	domain.Spec.Devices.Ballooning = &api.MemBalloon{}
 	ConvertV1ToAPIBalloning(&vmi.Spec.Domain.Devices, domain.Spec.Devices.Ballooning, c)
 
-	//usb controller is turned on, only when user specify input device with usb bus,
-	//otherwise it is turned off
+	//usb controller is turned on, only when user specify input device with usb bus
+	//or a USB host device, otherwise it is turned off
 	//In ppc64le usb devices like mouse / keyboard are set by default,
 	//so we can't disable the controller otherwise we run into the following error:
 	//"unsupported configuration: USB is disabled for this domain, but USB devices are present in the domain XML"
-	if !isUSBDevicePresent && !isUSBRedirEnabled && c.Architecture != "ppc64le" {
+	// aarch64 has no PS/2, so the graphics device comes with USB input devices
+	if isARM64(c.Architecture) && isGraphicsDeviceAutoattached(vmi) {
+		isUSBDevicePresent = true
+	}
+
+	//s390x has no USB, so the controller is always turned off
+	if (!isUSBDevicePresent && !isUSBRedirEnabled && c.Architecture != "ppc64le") || isS390X(c.Architecture) {
 		// disable usb controller
 		domain.Spec.Devices.Controllers = append(domain.Spec.Devices.Controllers, api.Controller{
 			Type:  "usb",
@@ -1542,6 +1695,14 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		domain.Spec.Devices.Controllers = append(domain.Spec.Devices.Controllers, scsiController)
 	}
 
+	if needsNVMeController(vmi) {
+		// All NVMe disks are namespaces of a single emulated controller
+		domain.Spec.Devices.Controllers = append(domain.Spec.Devices.Controllers, api.Controller{
+			Type:  "nvme",
+			Index: "0",
+		})
+	}
+
 	if vmi.Spec.Domain.Clock != nil {
 		clock := vmi.Spec.Domain.Clock
 		newClock := &api.Clock{}
@@ -1660,6 +1821,10 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 
 		var serialPort uint = 0
 		var serialType string = "serial"
+		// s390x has no serial ports, the console is attached to the service-call logical processor
+		if isS390X(c.Architecture) {
+			serialType = "sclp"
+		}
 		domain.Spec.Devices.Consoles = []api.Console{
 			{
 				Type: "pty",
@@ -1682,9 +1847,17 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 				},
 			},
 		}
+
+		if util.IsSerialConsoleLogEnabled(vmi) {
+			// virtlogd writes the log and rotates it according to its max_size
+			domain.Spec.Devices.Serials[0].Log = &api.SerialLog{
+				File:   util.SerialConsoleLogPath(vmi),
+				Append: "on",
+			}
+		}
 	}
 
-	if vmi.Spec.Domain.Devices.AutoattachGraphicsDevice == nil || *vmi.Spec.Domain.Devices.AutoattachGraphicsDevice == true {
+	if isGraphicsDeviceAutoattached(vmi) {
 		var heads uint = 1
 		var vram uint = 16384
 		domain.Spec.Devices.Video = []api.Video{
@@ -1696,6 +1869,21 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 				},
 			},
 		}
+		// aarch64 and s390x have no VGA
+		if isARM64(c.Architecture) || isS390X(c.Architecture) {
+			domain.Spec.Devices.Video[0].Model = api.VideoModel{
+				Type:  "virtio",
+				Heads: &heads,
+			}
+		}
+		if isARM64(c.Architecture) {
+			if len(vmi.Spec.Domain.Devices.Inputs) == 0 {
+				domain.Spec.Devices.Inputs = append(domain.Spec.Devices.Inputs,
+					api.Input{Type: "tablet", Bus: "usb", Alias: api.NewUserDefinedAlias("default-tablet")})
+			}
+			domain.Spec.Devices.Inputs = append(domain.Spec.Devices.Inputs,
+				api.Input{Type: "keyboard", Bus: "usb", Alias: api.NewUserDefinedAlias("default-keyboard")})
+		}
 		domain.Spec.Devices.Graphics = []api.Graphics{
 			{
 				Listen: &api.GraphicsListen{
@@ -1723,6 +1911,14 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		domain.Spec.QEMUCmd.QEMUArg = append(domain.Spec.QEMUCmd.QEMUArg, api.Arg{Value: fmt.Sprintf("name=opt/com.coreos/config,file=%s", ignitionpath)})
 	}
 
+	for _, arg := range vmi.Spec.Domain.QEMUArgs {
+		initializeQEMUCmdAndQEMUArg(domain)
+		domain.Spec.QEMUCmd.QEMUArg = append(domain.Spec.QEMUCmd.QEMUArg, api.Arg{Value: arg.Name})
+		if arg.Value != "" {
+			domain.Spec.QEMUCmd.QEMUArg = append(domain.Spec.QEMUCmd.QEMUArg, api.Arg{Value: arg.Value})
+		}
+	}
+
 	if val := vmi.Annotations[v1.PlacePCIDevicesOnRootComplex]; val == "true" {
 		if err := PlacePCIDevicesOnRootComplex(&domain.Spec); err != nil {
 			return err
@@ -1930,6 +2126,15 @@ func needsSCSIControler(vmi *v1.VirtualMachineInstance) bool {
 	return !vmi.Spec.Domain.Devices.DisableHotplug
 }
 
+func needsNVMeController(vmi *v1.VirtualMachineInstance) bool {
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.Disk != nil && disk.Disk.Bus == "nvme" {
+			return true
+		}
+	}
+	return false
+}
+
 func getPrefixFromBus(bus string) string {
 	switch bus {
 	case "virtio":
@@ -1938,6 +2143,8 @@ func getPrefixFromBus(bus string) string {
 		return "sd"
 	case "fdc":
 		return "fd"
+	case "nvme":
+		return nvmeDevicePrefix
 	default:
 		log.Log.Errorf("Unrecognized bus '%s'", bus)
 		return ""
@@ -149,6 +149,108 @@ var _ = Describe("Converter", func() {
 			Expect(xml).To(Equal(expectedXML))
 		})
 
+		It("should throttle the disk I/O if requested", func() {
+			readBytes := resource.MustParse("100Mi")
+			writeIOPS := int64(500)
+			v1Disk := &v1.Disk{
+				IOTune: &v1.DiskIOTune{
+					ReadBytesPerSec: &readBytes,
+					WriteIOPS:       &writeIOPS,
+				},
+			}
+			xml := diskToDiskXML(v1Disk)
+			expectedXML := `<Disk device="" type="">
+  <source></source>
+  <target></target>
+  <driver error_policy="stop" name="qemu" type=""></driver>
+  <alias name="ua-"></alias>
+  <iotune>
+    <read_bytes_sec>104857600</read_bytes_sec>
+    <write_iops_sec>500</write_iops_sec>
+  </iotune>
+</Disk>`
+			Expect(xml).To(Equal(expectedXML))
+		})
+
+		It("should set the WWN of a scsi disk", func() {
+			v1Disk := &v1.Disk{
+				Name: "mydisk",
+				DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{Bus: "scsi"},
+				},
+				WWN: "5000c50015ea71ac",
+			}
+			xml := diskToDiskXML(v1Disk)
+			Expect(xml).To(ContainSubstring(`<wwn>5000c50015ea71ac</wwn>`))
+		})
+
+		table.DescribeTable("should set the cache mode of a disk", func(cache v1.DriverCache, expectedCache string) {
+			v1Disk := &v1.Disk{
+				Name:  "mydisk",
+				Cache: cache,
+				DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{Bus: "virtio"},
+				},
+			}
+			c := &ConverterContext{
+				PermanentVolumes: map[string]v1.VolumeStatus{
+					"mydisk": {
+						Name: "mydisk",
+						PersistentVolumeClaimInfo: &v1.PersistentVolumeClaimInfo{
+							CacheMode: v1.CacheWriteBack,
+						},
+					},
+				},
+			}
+			apiDisk := &api.Disk{}
+			Expect(Convert_v1_Disk_To_api_Disk(c, v1Disk, apiDisk, map[string]deviceNamer{}, nil)).To(Succeed())
+			Expect(apiDisk.Driver.Cache).To(Equal(expectedCache))
+		},
+			table.Entry("from the storage class of its PVC", v1.DriverCache(""), "writeback"),
+			table.Entry("from the disk before the storage class", v1.CacheWriteThrough, "writethrough"),
+		)
+
+		table.DescribeTable("should place the overlay of a containerDisk", func(overlay *v1.ContainerDiskOverlay, expectedFile string) {
+			volume := &v1.Volume{
+				Name: "containerdisk",
+				VolumeSource: v1.VolumeSource{
+					ContainerDisk: &v1.ContainerDiskSource{Image: "my-image", Overlay: overlay},
+				},
+			}
+			c := &ConverterContext{
+				EphemeraldiskCreator: EphemeralDiskImageCreator,
+				DisksInfo:            map[string]*cmdv1.DiskInfo{"containerdisk": {Format: "qcow2"}},
+			}
+			disk := &api.Disk{Driver: &api.DiskDriver{}}
+			Expect(Convert_v1_Volume_To_api_Disk(volume, disk, c, 0)).To(Succeed())
+			Expect(disk.Source.File).To(Equal(expectedFile))
+			Expect(disk.BackingStore.Format.Type).To(Equal("qcow2"))
+		},
+			table.Entry("in the ephemeral disks by default", nil,
+				"/var/run/libvirt/kubevirt-ephemeral-disk/containerdisk/disk.qcow2"),
+			table.Entry("on its PVC", &v1.ContainerDiskOverlay{ClaimName: "overlay-pvc"},
+				"/var/run/libvirt/kubevirt-ephemeral-disk/pvc/containerdisk/disk.qcow2"),
+		)
+
+		It("should let libvirt manage the persistent reservations of a LUN", func() {
+			v1Disk := &v1.Disk{
+				Name: "mylun",
+				DiskDevice: v1.DiskDevice{
+					LUN: &v1.LunTarget{Bus: "scsi", Reservation: true},
+				},
+			}
+			xml := diskToDiskXML(v1Disk)
+			expectedXML := `<Disk device="lun" type="">
+  <source>
+    <reservations managed="yes"></reservations>
+  </source>
+  <target bus="scsi" dev="sda"></target>
+  <driver error_policy="stop" name="qemu" type="" discard="unmap"></driver>
+  <alias name="ua-mylun"></alias>
+</Disk>`
+			Expect(xml).To(Equal(expectedXML))
+		})
+
 		It("Should omit boot order when not provided", func() {
 			kubevirtDisk := &v1.Disk{
 				Name: "mydisk",
@@ -183,7 +285,7 @@ var _ = Describe("Converter", func() {
   <blockio logical_block_size="1234" physical_block_size="1234"></blockio>
 </Disk>`
 			libvirtDisk := &api.Disk{}
-			err := Convert_v1_BlockSize_To_api_BlockIO(kubevirtDisk, libvirtDisk)
+			err := Convert_v1_BlockSize_To_api_BlockIO(kubevirtDisk, libvirtDisk, &ConverterContext{})
 			Expect(err).ToNot(HaveOccurred())
 			data, err := xml.MarshalIndent(libvirtDisk, "", "  ")
 			Expect(err).ToNot(HaveOccurred())
@@ -992,10 +1094,10 @@ var _ = Describe("Converter", func() {
     <channel type="unix">
       <target name="org.qemu.guest_agent.0" type="virtio"></target>
     </channel>
-    <controller type="usb" index="0" model="none"></controller>
+    <controller type="usb" index="0" model="qemu-xhci"></controller>
     <controller type="virtio-serial" index="0" model="virtio-non-transitional"></controller>
     <video>
-      <model type="vga" heads="1" vram="16384"></model>
+      <model type="virtio" heads="1"></model>
     </video>
     <graphics type="vnc">
       <listen type="socket" socket="/var/run/kubevirt-private/f4686d2c-6e8d-4335-b8fd-81bee22f4814/virt-vnc"></listen>
@@ -1102,6 +1204,9 @@ var _ = Describe("Converter", func() {
     <input type="tablet" bus="virtio" model="virtio">
       <alias name="ua-tablet0"></alias>
     </input>
+    <input type="keyboard" bus="usb">
+      <alias name="ua-default-keyboard"></alias>
+    </input>
     <serial type="unix">
       <target port="0"></target>
       <source mode="bind" path="/var/run/kubevirt-private/f4686d2c-6e8d-4335-b8fd-81bee22f4814/virt-serial0"></source>
@@ -1445,6 +1550,34 @@ var _ = Describe("Converter", func() {
 			table.Entry("for arm64", "arm64", convertedDomainarm64),
 		)
 
+		It("should use the s390x specific devices and features", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			c.Architecture = "s390x"
+			domain := vmiToDomain(vmi, c)
+
+			Expect(domain.Spec.OS.Type.Arch).To(Equal("s390x"))
+			Expect(domain.Spec.OS.Type.Machine).To(Equal("s390-ccw-virtio"))
+			Expect(domain.Spec.OS.SMBios).To(BeNil())
+			Expect(domain.Spec.Features.ACPI).To(BeNil())
+			Expect(*domain.Spec.Devices.Consoles[0].Target.Type).To(Equal("sclp"))
+			Expect(domain.Spec.Devices.Video[0].Model.Type).To(Equal("virtio"))
+			Expect(domain.Spec.Devices.Controllers).To(ContainElement(api.Controller{Type: "usb", Index: "0", Model: "none"}))
+		})
+
+		It("should attach a virtio-gpu device with USB input devices on arm64", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Domain.Devices.Inputs = nil
+			c.Architecture = "arm64"
+			domain := vmiToDomain(vmi, c)
+
+			Expect(domain.Spec.Devices.Video[0].Model.Type).To(Equal("virtio"))
+			Expect(domain.Spec.Devices.Inputs).To(ConsistOf(
+				api.Input{Type: "tablet", Bus: "usb", Alias: api.NewUserDefinedAlias("default-tablet")},
+				api.Input{Type: "keyboard", Bus: "usb", Alias: api.NewUserDefinedAlias("default-keyboard")},
+			))
+			Expect(domain.Spec.Devices.Controllers).To(ContainElement(api.Controller{Type: "usb", Index: "0", Model: "qemu-xhci"}))
+		})
+
 		table.DescribeTable("should be converted to a libvirt Domain", func(arch string, domain string, period uint) {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 			vmi.Spec.Domain.Devices.Rng = &v1.Rng{}
@@ -1704,6 +1837,30 @@ var _ = Describe("Converter", func() {
 			}))
 		})
 
+		It("should apply the discard mode of a disk over the default of its volume", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Domain.Devices.Disks[0].Discard = v1.DiscardIgnore
+			vmi.Spec.Domain.Devices.Disks[1].Discard = v1.DiscardUnmap
+			c.VolumesDiscardIgnore = []string{vmi.Spec.Domain.Devices.Disks[1].Name}
+			dom := vmiToDomain(vmi, c)
+			Expect(dom.Spec.Devices.Disks[0].Driver.Discard).To(Equal("ignore"))
+			Expect(dom.Spec.Devices.Disks[1].Driver.Discard).To(Equal("unmap"))
+		})
+
+		It("should add a NVMe controller if a NVMe disk is present", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Domain.Devices.Disks[0].Disk.Bus = "nvme"
+			dom := &api.Domain{}
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, dom, c)).To(Succeed())
+			Expect(dom.Spec.Devices.Disks[0].Target.Bus).To(Equal("nvme"))
+			Expect(dom.Spec.Devices.Disks[0].Target.Device).To(Equal("nvme0n1"))
+			Expect(dom.Spec.Devices.Disks[0].Model).To(BeEmpty())
+			Expect(dom.Spec.Devices.Controllers).To(ContainElement(api.Controller{
+				Type:  "nvme",
+				Index: "0",
+			}))
+		})
+
 		It("should not add a virtio-scsi controller if no scsi disk is present", func() {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 			vmi.Spec.Domain.Devices.Disks[0].Disk.Bus = "sata"
@@ -1793,6 +1950,18 @@ var _ = Describe("Converter", func() {
 			}))
 		})
 
+		It("should enable the usb controller when a USB host device is present", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			c.GenericHostDevices = []api.HostDevice{{Type: "usb", Mode: "subsystem"}}
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.HostDevices).To(HaveLen(1))
+			Expect(domain.Spec.Devices.Controllers).To(ContainElement(api.Controller{
+				Type:  "usb",
+				Index: "0",
+				Model: "qemu-xhci",
+			}))
+		})
+
 		It("should select explicitly chosen network model", func() {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 			vmi.Spec.Domain.Devices.Interfaces[0].Model = "e1000"
@@ -1913,6 +2082,22 @@ var _ = Describe("Converter", func() {
 			Expect(domainSpec.Devices.Rng).ToNot(BeNil())
 		})
 
+		It("should not add a VSOCK device when autoattachVSOCK is not set", func() {
+			domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+			Expect(domainSpec.Devices.VSOCK).To(BeNil())
+		})
+
+		It("should add a VSOCK device with the CID from the status", func() {
+			vmi.Spec.Domain.Devices.AutoattachVSOCK = True()
+			cid := uint32(100)
+			vmi.Status.VSOCKCID = &cid
+			domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+			Expect(domainSpec.Devices.VSOCK).ToNot(BeNil())
+			Expect(domainSpec.Devices.VSOCK.Model).To(Equal("virtio-non-transitional"))
+			Expect(domainSpec.Devices.VSOCK.CID.Auto).To(Equal("no"))
+			Expect(domainSpec.Devices.VSOCK.CID.Address).To(Equal(uint32(100)))
+		})
+
 		table.DescribeTable("Validate that QEMU SeaBios debug logs are ",
 			func(toDefineVerbosityEnvVariable bool, virtLauncherLogVerbosity int, shouldEnableDebugLogs bool) {
 
@@ -1957,6 +2142,28 @@ var _ = Describe("Converter", func() {
 			table.Entry("disabled - virtLauncherLogVerbosity variable is not defined", false, -1, false),
 		)
 
+		It("should append the QEMU arguments of the vmi to the QEMU command line", func() {
+			vmi.Spec.Domain.QEMUArgs = []v1.QEMUArg{
+				{Name: "-fw_cfg", Value: "name=opt/com.example/config,string=value"},
+				{Name: "-no-hpet"},
+			}
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.QEMUCmd).ToNot(BeNil())
+			Expect(domain.Spec.QEMUCmd.QEMUArg).To(ContainElements(
+				api.Arg{Value: "-fw_cfg"},
+				api.Arg{Value: "name=opt/com.example/config,string=value"},
+				api.Arg{Value: "-no-hpet"},
+			))
+		})
+
+		It("should enable free page reporting on the memory balloon when requested", func() {
+			_true := true
+			vmi.Spec.Domain.Devices.FreePageReporting = &_true
+			domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+			Expect(domainSpec.Devices.Ballooning).ToNot(BeNil())
+			Expect(domainSpec.Devices.Ballooning.FreePageReporting).To(Equal("on"))
+		})
+
 	})
 	Context("Network convert", func() {
 		var vmi *v1.VirtualMachineInstance
@@ -2284,6 +2491,41 @@ var _ = Describe("Converter", func() {
 			Expect(domain.Spec.Devices.Interfaces[0].BootOrder.Order).To(Equal(lastToBoot), "the interface whose boot order is higher should be the last to boot")
 			Expect(domain.Spec.Devices.Interfaces[1].BootOrder.Order).To(Equal(firstToBoot), "the interface whose boot order is lower should be the first to boot")
 		})
+		It("Should create a vhostuser interface with shared memory for a multus network", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			networkName := "net1"
+			vmi.Spec.Domain.Memory = &v1.Memory{Hugepages: &v1.Hugepages{PageSize: "2Mi"}}
+			vmi.Spec.Networks = []v1.Network{{
+				Name: networkName,
+				NetworkSource: v1.NetworkSource{
+					Multus: &v1.MultusNetwork{NetworkName: "dpdk"},
+				},
+			}}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+				Name:                   networkName,
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{VhostUser: &v1.InterfaceVhostUser{}},
+			}}
+
+			domain := vmiToDomain(vmi, c)
+			Expect(domain).NotTo(BeNil(), "domain should not be nil")
+			Expect(domain.Spec.Devices.Interfaces).To(HaveLen(1))
+			iface := domain.Spec.Devices.Interfaces[0]
+			Expect(iface.Type).To(Equal("vhostuser"))
+			Expect(iface.Source).To(Equal(api.InterfaceSource{Type: "unix", Path: "/var/run/kubevirt/vhostuser/net1.sock", Mode: "server"}))
+			Expect(iface.Driver).To(BeNil())
+			Expect(domain.Spec.MemoryBacking.Access).To(Equal(&api.MemoryBackingAccess{Mode: "shared"}))
+		})
+		Specify("vhostuser interface binding must be used on a multus network", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+				Name:                   "default",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{VhostUser: &v1.InterfaceVhostUser{}},
+			}}
+
+			domain := &api.Domain{}
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c)).To(HaveOccurred(), "conversion should fail because a vhostuser interface requires a multus network attachment")
+		})
 		Specify("macvtap interface binding must be used on a multus network", func() {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 			name1 := "net1"
@@ -2350,6 +2592,86 @@ var _ = Describe("Converter", func() {
 		)
 	})
 
+	Context("sound device", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+		})
+
+		It("should not add a sound card if none is requested", func() {
+			domain := vmiToDomain(vmi, &ConverterContext{AllowEmulation: true})
+			Expect(domain.Spec.Devices.SoundCards).To(BeEmpty())
+		})
+
+		table.DescribeTable("should convert the sound device", func(model string, expectedModel string) {
+			vmi.Spec.Domain.Devices.Sound = &v1.SoundDevice{Name: "audio", Model: model}
+			domain := vmiToDomain(vmi, &ConverterContext{AllowEmulation: true})
+			Expect(domain.Spec.Devices.SoundCards).To(ConsistOf(api.SoundCard{
+				Model: expectedModel,
+				Alias: api.NewUserDefinedAlias("audio"),
+			}))
+		},
+			table.Entry("and default to ich9", "", "ich9"),
+			table.Entry("with the ich9 model", "ich9", "ich9"),
+			table.Entry("with the ac97 model", "ac97", "ac97"),
+		)
+	})
+
+	Context("virtiofs filesystem", func() {
+		table.DescribeTable("should share the volume from", func(volumeSource v1.VolumeSource, expectedDir string) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Filesystems = []v1.Filesystem{
+				{Name: "shared", Virtiofs: &v1.FilesystemVirtiofs{}},
+			}
+			vmi.Spec.Volumes = []v1.Volume{{Name: "shared", VolumeSource: volumeSource}}
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			domain := vmiToDomain(vmi, &ConverterContext{AllowEmulation: true})
+			Expect(domain.Spec.Devices.Filesystems).To(HaveLen(1))
+			Expect(domain.Spec.Devices.Filesystems[0].Driver.Type).To(Equal("virtiofs"))
+			Expect(domain.Spec.Devices.Filesystems[0].Target.Dir).To(Equal("shared"))
+			Expect(domain.Spec.Devices.Filesystems[0].Source.Dir).To(Equal(expectedDir))
+		},
+			table.Entry("a PVC",
+				v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "claim"}}},
+				"/var/run/kubevirt-private/vmi-disks/shared/"),
+			table.Entry("a ConfigMap",
+				v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: k8sv1.LocalObjectReference{Name: "config"}}},
+				"/var/run/kubevirt-private/config-map/shared"),
+			table.Entry("a Secret",
+				v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "secret"}},
+				"/var/run/kubevirt-private/secret/shared"),
+		)
+	})
+
+	Context("watchdog device", func() {
+		table.DescribeTable("should convert the i6300esb action", func(action v1.WatchdogAction) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Watchdog = &v1.Watchdog{
+				Name:           "mywatchdog",
+				WatchdogDevice: v1.WatchdogDevice{I6300ESB: &v1.I6300ESBWatchdog{Action: action}},
+			}
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			domain := vmiToDomain(vmi, &ConverterContext{AllowEmulation: true})
+			Expect(domain.Spec.Devices.Watchdog).To(Equal(&api.Watchdog{
+				Model:  "i6300esb",
+				Action: string(action),
+				Alias:  api.NewUserDefinedAlias("mywatchdog"),
+			}))
+		},
+			table.Entry("poweroff", v1.WatchdogActionPoweroff),
+			table.Entry("reset", v1.WatchdogActionReset),
+			table.Entry("shutdown", v1.WatchdogActionShutdown),
+		)
+
+		It("should fail if no watchdog type is specified", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Watchdog = &v1.Watchdog{Name: "mywatchdog"}
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, &api.Domain{}, &ConverterContext{AllowEmulation: true})).ToNot(Succeed())
+		})
+	})
+
 	Context("HyperV features", func() {
 		table.DescribeTable("should convert hyperv features", func(hyperV *v1.FeatureHyperv, result *api.FeatureHyperv) {
 			vmi := v1.VirtualMachineInstance{
@@ -2402,6 +2724,16 @@ var _ = Describe("Converter", func() {
 				VAPIC: &api.FeatureState{State: "on"},
 			}),
 		)
+
+		It("should convert hyperv passthrough to the passthrough mode", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Features = &v1.Features{
+				HypervPassthrough: &v1.HyperVPassthrough{},
+			}
+
+			domain := vmiToDomain(vmi, &ConverterContext{AllowEmulation: true})
+			Expect(domain.Spec.Features.Hyperv).To(Equal(&api.FeatureHyperv{Mode: api.HypervModePassthrough}))
+		})
 	})
 
 	Context("serial console", func() {
@@ -2438,6 +2770,31 @@ var _ = Describe("Converter", func() {
 			table.Entry("and add the serial console if it is set to true", True(), 1),
 			table.Entry("and not add the serial console if it is set to false", False(), 0),
 		)
+
+		table.DescribeTable("should check logSerialConsole", func(logSerialConsole *bool, expectedLog *api.SerialLog) {
+			vmi := v1.VirtualMachineInstance{
+				ObjectMeta: k8smeta.ObjectMeta{
+					Name:      "testvmi",
+					Namespace: "default",
+					UID:       "1234",
+				},
+				Spec: v1.VirtualMachineInstanceSpec{
+					Domain: v1.DomainSpec{
+						Devices: v1.Devices{
+							LogSerialConsole: logSerialConsole,
+						},
+					},
+				},
+			}
+			domain := vmiToDomain(&vmi, &ConverterContext{AllowEmulation: true})
+			Expect(domain.Spec.Devices.Serials).To(HaveLen(1))
+			Expect(domain.Spec.Devices.Serials[0].Log).To(Equal(expectedLog))
+		},
+			table.Entry("and not log the serial console if it is not set", nil, nil),
+			table.Entry("and not log the serial console if it is set to false", False(), nil),
+			table.Entry("and log the serial console if it is set to true", True(),
+				&api.SerialLog{File: "/var/run/kubevirt-private/1234/virt-serial0-log", Append: "on"}),
+		)
 	})
 
 	Context("IOThreads", func() {
@@ -2698,6 +3055,30 @@ var _ = Describe("Converter", func() {
 			Expect(*(domain.Spec.Devices.Disks[0].Driver.Queues)).To(Equal(expectedQueues),
 				"expected number of queues to equal number of requested vCPUs")
 		})
+
+		It("should prefer the queue count of the disk over the multiQueue setting", func() {
+			var expectedQueues uint = 1
+			vmi.Spec.Domain.CPU = &v1.CPU{
+				Cores: 2,
+			}
+			queues := uint32(expectedQueues)
+			vmi.Spec.Domain.Devices.Disks[0].Queues = &queues
+
+			domain := vmiToDomain(vmi, &ConverterContext{AllowEmulation: true, SMBios: &cmdv1.SMBios{}})
+			Expect(*(domain.Spec.Devices.Disks[0].Driver.Queues)).To(Equal(expectedQueues),
+				"expected number of queues to equal the queues of the disk")
+		})
+
+		It("should assign the queue count of the disk without the multiQueue setting", func() {
+			var expectedQueues uint = 2
+			vmi.Spec.Domain.Devices.BlockMultiQueue = nil
+			queues := uint32(expectedQueues)
+			vmi.Spec.Domain.Devices.Disks[0].Queues = &queues
+
+			domain := vmiToDomain(vmi, &ConverterContext{AllowEmulation: true, SMBios: &cmdv1.SMBios{}})
+			Expect(*(domain.Spec.Devices.Disks[0].Driver.Queues)).To(Equal(expectedQueues),
+				"expected number of queues to equal the queues of the disk")
+		})
 	})
 	Context("Correctly handle iothreads with dedicated cpus", func() {
 		var vmi *v1.VirtualMachineInstance
@@ -2846,6 +3227,36 @@ var _ = Describe("Converter", func() {
 				"expected number of queues to equal number of requested vCPUs")
 		})
 
+		It("should prefer the queue count of the interface over the multiqueue setting", func() {
+			var expectedQueues uint = 1
+			vmi.Spec.Domain.CPU = &v1.CPU{
+				Cores: 2,
+			}
+			queues := uint32(expectedQueues)
+			vmi.Spec.Domain.Devices.Interfaces[0].Queues = &queues
+
+			domain := vmiToDomain(vmi, &ConverterContext{AllowEmulation: true})
+			Expect(*(domain.Spec.Devices.Interfaces[0].Driver.Queues)).To(Equal(expectedQueues),
+				"expected number of queues to equal the queues of the interface")
+		})
+
+		It("should assign the queue count of the interface without the multiqueue setting", func() {
+			var expectedQueues uint = 2
+			vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue = nil
+			queues := uint32(expectedQueues)
+			vmi.Spec.Domain.Devices.Interfaces[0].Queues = &queues
+
+			domain := vmiToDomain(vmi, &ConverterContext{AllowEmulation: true})
+			Expect(*(domain.Spec.Devices.Interfaces[0].Driver.Queues)).To(Equal(expectedQueues),
+				"expected number of queues to equal the queues of the interface")
+		})
+
+		It("should not assign queues without the multiqueue setting", func() {
+			vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue = nil
+			domain := vmiToDomain(vmi, &ConverterContext{AllowEmulation: true})
+			Expect(domain.Spec.Devices.Interfaces[0].Driver).To(BeNil())
+		})
+
 		It("should not assign queues to a non-virtio devices", func() {
 			vmi.Spec.Domain.Devices.Interfaces[0].Model = "e1000"
 			domain := vmiToDomain(vmi, &ConverterContext{AllowEmulation: true})
@@ -2949,6 +3360,23 @@ var _ = Describe("Converter", func() {
 			table.Entry("should not use SecureBoot", False(), "OVMF_CODE.fd", "OVMF_VARS.fd"),
 			table.Entry("should not use SecureBoot when OVMF_CODE.fd not present", True(), "OVMF_CODE.secboot.fd", "OVMF_VARS.fd"),
 		)
+
+		It("should not enable the boot menu by default", func() {
+			vmi.Spec.Domain.Firmware = &v1.Firmware{}
+			domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+			Expect(domainSpec.OS.BootMenu).To(BeNil())
+		})
+
+		It("should enable the boot menu with its timeout", func() {
+			timeout := uint32(5000)
+			vmi.Spec.Domain.Firmware = &v1.Firmware{
+				BootMenu: &v1.BootMenu{Timeout: &timeout},
+			}
+			domainSpec := vmiToDomainXMLToDomainSpec(vmi, c)
+			Expect(domainSpec.OS.BootMenu).ToNot(BeNil())
+			Expect(domainSpec.OS.BootMenu.Enable).To(Equal("yes"))
+			Expect(*domainSpec.OS.BootMenu.Timeout).To(Equal(uint(5000)))
+		})
 	})
 
 	Context("Kernel Boot", func() {
@@ -3055,6 +3483,26 @@ var _ = Describe("Converter", func() {
 			Expect(len(domain.Spec.Devices.Controllers)).To(Equal(2))
 		})
 
+		It("should keep a CD-ROM empty until its new media is mounted", func() {
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{Name: "cdrom", DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{Tray: v1.TrayStateClosed}}},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{Name: "cdrom", VolumeSource: v1.VolumeSource{DataVolume: &v1.DataVolumeSource{Name: "test-fs-dv", Hotpluggable: true}}},
+			}
+			c.PermanentVolumes = map[string]v1.VolumeStatus{"rootdisk": {Name: "rootdisk"}}
+
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Disks).To(HaveLen(1))
+			Expect(domain.Spec.Devices.Disks[0].Device).To(Equal("cdrom"))
+			Expect(domain.Spec.Devices.Disks[0].Source).To(Equal(api.DiskSource{}))
+
+			c.HotplugVolumes = map[string]v1.VolumeStatus{"cdrom": {Name: "cdrom", Phase: v1.HotplugVolumeMounted}}
+			domain = vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Disks).To(HaveLen(1))
+			Expect(domain.Spec.Devices.Disks[0].Source.File).To(Equal("/var/run/kubevirt/hotplug-disks/cdrom.img"))
+		})
+
 		table.DescribeTable("should convert",
 			func(converterFunc ConverterFunc, volumeName string, isBlockMode bool, ignoreDiscard bool) {
 				expectedDisk := &api.Disk{}
@@ -3129,6 +3577,20 @@ var _ = Describe("disk device naming", func() {
 		Expect(res).To(Equal("sda"))
 		Expect(index).To(Equal(0))
 	})
+
+	It("makeDeviceName should number NVMe namespaces from 1", func() {
+		prefixMap := make(map[string]deviceNamer)
+		res, index := makeDeviceName("test1", "nvme", prefixMap)
+		Expect(res).To(Equal("nvme0n1"))
+		Expect(index).To(Equal(0))
+		res, index = makeDeviceName("test2", "nvme", prefixMap)
+		Expect(res).To(Equal("nvme0n2"))
+		Expect(index).To(Equal(1))
+		By("verifying existing returns correct value")
+		res, index = makeDeviceName("test2", "nvme", prefixMap)
+		Expect(res).To(Equal("nvme0n2"))
+		Expect(index).To(Equal(1))
+	})
 })
 
 var _ = Describe("direct IO checker", func() {
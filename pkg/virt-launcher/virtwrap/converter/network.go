@@ -30,6 +30,7 @@ import (
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter/vcpu"
 
+	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/net/dns"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device"
@@ -64,15 +65,12 @@ func createDomainInterfaces(vmi *v1.VirtualMachineInstance, domain *api.Domain,
 
 		// if AllowEmulation unset and at least one NIC model is virtio,
 		// /dev/vhost-net must be present as we should have asked for it.
-		var virtioNetMQRequested bool
-		if mq := vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue; mq != nil {
-			virtioNetMQRequested = *mq
-		}
-		if ifaceType == "virtio" && virtioNetProhibited {
+		if ifaceType == "virtio" && virtioNetProhibited && iface.VhostUser == nil {
 			return nil, fmt.Errorf("In-kernel virtio-net device emulation '/dev/vhost-net' not present")
-		} else if ifaceType == "virtio" && virtioNetMQRequested {
-			queueCount := uint(CalculateNetworkQueues(vmi))
-			domainIface.Driver = &api.InterfaceDriver{Name: "vhost", Queues: &queueCount}
+		} else if ifaceType == "virtio" && iface.VhostUser == nil {
+			if queueCount := uint(CalculateInterfaceQueues(vmi, &vmi.Spec.Domain.Devices.Interfaces[i])); queueCount > 0 {
+				domainIface.Driver = &api.InterfaceDriver{Name: "vhost", Queues: &queueCount}
+			}
 		}
 
 		// Add a pciAddress if specified
@@ -104,7 +102,7 @@ func createDomainInterfaces(vmi *v1.VirtualMachineInstance, domain *api.Domain,
 
 			// TODO: (seba) Need to change this if multiple interface can be connected to the same network
 			// append the ports from all the interfaces connected to the same network
-			err := createSlirpNetwork(iface, *net, domain)
+			err := createSlirpNetwork(iface, *net, domain, c)
 			if err != nil {
 				return nil, err
 			}
@@ -119,6 +117,26 @@ func createDomainInterfaces(vmi *v1.VirtualMachineInstance, domain *api.Domain,
 			} else {
 				domainIface.Rom = &api.Rom{Enabled: "no"}
 			}
+		} else if iface.VhostUser != nil {
+			if net.Multus == nil {
+				return nil, fmt.Errorf("vhostuser interface %s requires Multus meta-cni", iface.Name)
+			}
+
+			// QEMU owns the socket, the userspace dataplane connects to it
+			domainIface.Type = "vhostuser"
+			domainIface.Source = api.InterfaceSource{
+				Type: "unix",
+				Path: util.VhostUserSocketPath(iface.Name),
+				Mode: "server",
+			}
+			if queueCount := uint(CalculateInterfaceQueues(vmi, &vmi.Spec.Domain.Devices.Interfaces[i])); queueCount > 0 {
+				domainIface.Driver = &api.InterfaceDriver{Queues: &queueCount}
+			}
+			if iface.BootOrder != nil {
+				domainIface.BootOrder = &api.BootOrder{Order: *iface.BootOrder}
+			} else {
+				domainIface.Rom = &api.Rom{Enabled: "no"}
+			}
 		}
 		domainInterfaces = append(domainInterfaces, domainIface)
 	}
@@ -161,7 +179,7 @@ func indexNetworksByName(networks []v1.Network) map[string]*v1.Network {
 	return netsByName
 }
 
-func createSlirpNetwork(iface v1.Interface, network v1.Network, domain *api.Domain) error {
+func createSlirpNetwork(iface v1.Interface, network v1.Network, domain *api.Domain, c *ConverterContext) error {
 	qemuArg := api.Arg{Value: fmt.Sprintf("user,id=%s", iface.Name)}
 
 	err := configVMCIDR(&qemuArg, network)
@@ -169,7 +187,7 @@ func createSlirpNetwork(iface v1.Interface, network v1.Network, domain *api.Doma
 		return err
 	}
 
-	err = configDNSSearchName(&qemuArg)
+	err = configDNSSearchName(&qemuArg, c)
 	if err != nil {
 		return err
 	}
@@ -196,6 +214,23 @@ func CalculateNetworkQueues(vmi *v1.VirtualMachineInstance) uint32 {
 	return queueNumber
 }
 
+// CalculateInterfaceQueues returns the number of queues of the given interface. The queue count
+// of the interface takes precedence over networkInterfaceMultiqueue. Zero means that multiqueue
+// is not requested.
+func CalculateInterfaceQueues(vmi *v1.VirtualMachineInstance, iface *v1.Interface) uint32 {
+	if iface.Queues != nil {
+		if *iface.Queues > multiQueueMaxQueues {
+			log.Log.V(3).Infof("Capped the number of queues to be the current maximum of tap device queues: %d", multiQueueMaxQueues)
+			return multiQueueMaxQueues
+		}
+		return *iface.Queues
+	}
+	if mq := vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue; mq != nil && *mq {
+		return CalculateNetworkQueues(vmi)
+	}
+	return 0
+}
+
 func configPortForward(qemuArg *api.Arg, iface v1.Interface) error {
 	if iface.Ports == nil {
 		return nil
@@ -241,8 +276,14 @@ func configVMCIDR(qemuArg *api.Arg, network v1.Network) error {
 	return nil
 }
 
-func configDNSSearchName(qemuArg *api.Arg) error {
-	_, dnsDoms, err := GetResolvConfDetailsFromPod()
+func configDNSSearchName(qemuArg *api.Arg, c *ConverterContext) error {
+	var dnsDoms []string
+	var err error
+	if c.SearchDomains != nil {
+		dnsDoms, err = c.SearchDomains()
+	} else {
+		_, dnsDoms, err = GetResolvConfDetailsFromPod()
+	}
 	if err != nil {
 		return err
 	}
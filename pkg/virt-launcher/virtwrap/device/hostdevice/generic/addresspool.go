@@ -27,6 +27,7 @@ import (
 const (
 	pciResourcePrefix  = "PCI_RESOURCE"
 	mdevResourcePrefix = "MDEV_PCI_RESOURCE"
+	usbResourcePrefix  = "USB_RESOURCE"
 )
 
 // NewPCIAddressPool creates a PCI address pool based on the provided list of host-devices and
@@ -41,6 +42,12 @@ func NewMDEVAddressPool(hostDevises []v1.HostDevice) *hostdevice.AddressPool {
 	return hostdevice.NewAddressPool(mdevResourcePrefix, extractResources(hostDevises))
 }
 
+// NewUSBAddressPool creates a USB address pool based on the provided list of host-devices and
+// the environment variables that describe the resource.
+func NewUSBAddressPool(hostDevises []v1.HostDevice) *hostdevice.AddressPool {
+	return hostdevice.NewAddressPool(usbResourcePrefix, extractResources(hostDevises))
+}
+
 func extractResources(hostDevises []v1.HostDevice) []string {
 	var resourceSet = make(map[string]struct{})
 	for _, hostDevice := range hostDevises {
@@ -40,6 +40,7 @@ type envData struct {
 const (
 	pciResourcePrefix  = "PCI_RESOURCE"
 	mdevResourcePrefix = "MDEV_PCI_RESOURCE"
+	usbResourcePrefix  = "USB_RESOURCE"
 
 	hostdevName0 = "hostdev_name0"
 	hostdevName1 = "hostdev_name1"
@@ -54,6 +55,9 @@ const (
 
 	hostdevMDEVAddress0 = "123456789-0"
 	hostdevMDEVAddress1 = "123456789-1"
+
+	hostdevUSBAddress0 = "1:4"
+	hostdevUSBAddress1 = "1:12"
 )
 
 var _ = Describe("Generic Address Pool", func() {
@@ -70,6 +74,7 @@ var _ = Describe("Generic Address Pool", func() {
 		},
 		table.Entry("PCI", generic.NewPCIAddressPool),
 		table.Entry("MDEV", generic.NewMDEVAddressPool),
+		table.Entry("USB", generic.NewUSBAddressPool),
 	)
 
 	table.DescribeTable("creates an empty pool when no resources are specified",
@@ -80,6 +85,7 @@ var _ = Describe("Generic Address Pool", func() {
 		},
 		table.Entry("PCI", generic.NewPCIAddressPool),
 		table.Entry("MDEV", generic.NewMDEVAddressPool),
+		table.Entry("USB", generic.NewUSBAddressPool),
 	)
 
 	table.DescribeTable("succeeds to pop 2 addresses from same resource",
@@ -94,6 +100,7 @@ var _ = Describe("Generic Address Pool", func() {
 		},
 		table.Entry("PCI", generic.NewPCIAddressPool, pciResourcePrefix, hostdevPCIAddress0, hostdevPCIAddress1),
 		table.Entry("MDEV", generic.NewMDEVAddressPool, mdevResourcePrefix, hostdevMDEVAddress0, hostdevMDEVAddress1),
+		table.Entry("USB", generic.NewUSBAddressPool, usbResourcePrefix, hostdevUSBAddress0, hostdevUSBAddress1),
 	)
 
 	table.DescribeTable("succeeds to pop 2 addresses from two resources",
@@ -114,6 +121,7 @@ var _ = Describe("Generic Address Pool", func() {
 		},
 		table.Entry("PCI", generic.NewPCIAddressPool, pciResourcePrefix, hostdevPCIAddress0, hostdevPCIAddress1),
 		table.Entry("MDEV", generic.NewMDEVAddressPool, mdevResourcePrefix, hostdevMDEVAddress0, hostdevMDEVAddress1),
+		table.Entry("USB", generic.NewUSBAddressPool, usbResourcePrefix, hostdevUSBAddress0, hostdevUSBAddress1),
 	)
 })
 
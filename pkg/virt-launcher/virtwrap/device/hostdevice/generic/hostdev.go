@@ -32,12 +32,14 @@ const (
 )
 
 func CreateHostDevices(vmiHostDevices []v1.HostDevice) ([]api.HostDevice, error) {
-	return CreateHostDevicesFromPools(vmiHostDevices, NewPCIAddressPool(vmiHostDevices), NewMDEVAddressPool(vmiHostDevices))
+	return CreateHostDevicesFromPools(vmiHostDevices,
+		NewPCIAddressPool(vmiHostDevices), NewMDEVAddressPool(vmiHostDevices), NewUSBAddressPool(vmiHostDevices))
 }
 
-func CreateHostDevicesFromPools(vmiHostDevices []v1.HostDevice, pciAddressPool, mdevAddressPool hostdevice.AddressPooler) ([]api.HostDevice, error) {
+func CreateHostDevicesFromPools(vmiHostDevices []v1.HostDevice, pciAddressPool, mdevAddressPool, usbAddressPool hostdevice.AddressPooler) ([]api.HostDevice, error) {
 	pciPool := hostdevice.NewBestEffortAddressPool(pciAddressPool)
 	mdevPool := hostdevice.NewBestEffortAddressPool(mdevAddressPool)
+	usbPool := hostdevice.NewBestEffortAddressPool(usbAddressPool)
 
 	hostDevicesMetaData := createHostDevicesMetadata(vmiHostDevices)
 	pciHostDevices, err := hostdevice.CreatePCIHostDevices(hostDevicesMetaData, pciPool)
@@ -49,7 +51,13 @@ func CreateHostDevicesFromPools(vmiHostDevices []v1.HostDevice, pciAddressPool,
 		return nil, fmt.Errorf("failed to creade generic host-devices: %v", err)
 	}
 
+	usbHostDevices, err := hostdevice.CreateUSBHostDevices(hostDevicesMetaData, usbPool)
+	if err != nil {
+		return nil, fmt.Errorf("failed to creade generic host-devices: %v", err)
+	}
+
 	hostDevices := append(pciHostDevices, mdevHostDevices...)
+	hostDevices = append(hostDevices, usbHostDevices...)
 
 	if err := validateCreationOfAllDevices(vmiHostDevices, hostDevices); err != nil {
 		return nil, fmt.Errorf("failed to creade generic host-devices: %v", err)
@@ -57,7 +57,7 @@ var _ = Describe("Generic HostDevice", func() {
 		mdevPool := newAddressPoolStub()
 		mdevPool.AddResource(hostdevResource1, hostdevPCIAddress1)
 
-		_, err := generic.CreateHostDevicesFromPools(vmi.Spec.Domain.Devices.HostDevices, pciPool, mdevPool)
+		_, err := generic.CreateHostDevicesFromPools(vmi.Spec.Domain.Devices.HostDevices, pciPool, mdevPool, newAddressPoolStub())
 		Expect(err).To(HaveOccurred())
 	})
 
@@ -71,7 +71,7 @@ var _ = Describe("Generic HostDevice", func() {
 		mdevPool := newAddressPoolStub()
 		mdevPool.AddResource(hostdevResource1, hostdevMDEVAddress1)
 
-		hostDevices, err := generic.CreateHostDevicesFromPools(vmi.Spec.Domain.Devices.HostDevices, pciPool, mdevPool)
+		hostDevices, err := generic.CreateHostDevicesFromPools(vmi.Spec.Domain.Devices.HostDevices, pciPool, mdevPool, newAddressPoolStub())
 		Expect(err).NotTo(HaveOccurred())
 
 		hostPCIAddress := api.Address{Type: "pci", Domain: "0x0000", Bus: "0x81", Slot: "0x01", Function: "0x0"}
@@ -93,6 +93,32 @@ var _ = Describe("Generic HostDevice", func() {
 
 		Expect(hostDevices, err).To(Equal([]api.HostDevice{expectHostDevice0, expectHostDevice1}))
 	})
+
+	It("creates a USB device", func() {
+		vmi.Spec.Domain.Devices.HostDevices = []v1.HostDevice{{DeviceName: hostdevResource0, Name: hostdevName0}}
+		usbPool := newAddressPoolStub()
+		usbPool.AddResource(hostdevResource0, hostdevUSBAddress0)
+
+		hostDevices, err := generic.CreateHostDevicesFromPools(vmi.Spec.Domain.Devices.HostDevices, newAddressPoolStub(), newAddressPoolStub(), usbPool)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(hostDevices).To(Equal([]api.HostDevice{{
+			Alias:   api.NewUserDefinedAlias(generic.AliasPrefix + hostdevName0),
+			Source:  api.HostDeviceSource{Address: &api.Address{Bus: "1", Device: "4"}},
+			Type:    "usb",
+			Mode:    "subsystem",
+			Managed: "no",
+		}}))
+	})
+
+	It("fails to create a USB device given an invalid address", func() {
+		vmi.Spec.Domain.Devices.HostDevices = []v1.HostDevice{{DeviceName: hostdevResource0, Name: hostdevName0}}
+		usbPool := newAddressPoolStub()
+		usbPool.AddResource(hostdevResource0, "1-4")
+
+		_, err := generic.CreateHostDevicesFromPools(vmi.Spec.Domain.Devices.HostDevices, newAddressPoolStub(), newAddressPoolStub(), usbPool)
+		Expect(err).To(HaveOccurred())
+	})
 })
 
 type stubAddressPool struct {
@@ -65,11 +65,35 @@ func createHostDevicesMetadata(vmiGPUs []v1.GPU) []hostdevice.HostDeviceMetaData
 			AliasPrefix:  AliasPrefix,
 			Name:         dev.Name,
 			ResourceName: dev.DeviceName,
+			DecorateHook: newVGPUDisplayDecorator(dev.VirtualGPUOptions),
 		})
 	}
 	return hostDevicesMetaData
 }
 
+// newVGPUDisplayDecorator returns a hook which configures the display of mediated devices.
+// PCI devices are left untouched, since they do not support these options.
+func newVGPUDisplayDecorator(options *v1.VGPUOptions) func(hostDevice *api.HostDevice) error {
+	if options == nil || options.Display == nil {
+		return nil
+	}
+	display := options.Display
+	return func(hostDevice *api.HostDevice) error {
+		if hostDevice.Type != "mdev" {
+			return nil
+		}
+		if display.Enabled != nil && !*display.Enabled {
+			hostDevice.Display = "off"
+			return nil
+		}
+		hostDevice.Display = "on"
+		if display.RamFB == nil || display.RamFB.Enabled == nil || *display.RamFB.Enabled {
+			hostDevice.RamFB = "on"
+		}
+		return nil
+	}
+}
+
 // validateCreationOfAllDevices validates that all specified GPU/s have a matching host-device.
 // On validation failure, an error is returned.
 // The validation assumes that the assignment of a device to a specified GPU is correct,
@@ -23,8 +23,11 @@ import (
 	"fmt"
 
 	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 
+	"k8s.io/utils/pointer"
+
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device/hostdevice/gpu"
@@ -93,6 +96,51 @@ var _ = Describe("GPU HostDevice", func() {
 
 		Expect(hostDevices, err).To(Equal([]api.HostDevice{expectHostDevice0, expectHostDevice1}))
 	})
+
+	Context("with vGPU display options", func() {
+		var pciPool, mdevPool *stubAddressPool
+
+		BeforeEach(func() {
+			pciPool = newAddressPoolStub()
+			pciPool.AddResource(gpuResource0, gpuPCIAddress0)
+			mdevPool = newAddressPoolStub()
+			mdevPool.AddResource(gpuResource1, gpuMDEVAddress1)
+		})
+
+		table.DescribeTable("configures the display of mediated devices", func(display *v1.VGPUDisplayOptions, expectedDisplay, expectedRamFB string) {
+			vmi.Spec.Domain.Devices.GPUs = []v1.GPU{{
+				DeviceName:        gpuResource1,
+				Name:              gpuName1,
+				VirtualGPUOptions: &v1.VGPUOptions{Display: display},
+			}}
+
+			hostDevices, err := gpu.CreateHostDevicesFromPools(vmi.Spec.Domain.Devices.GPUs, pciPool, mdevPool)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hostDevices).To(HaveLen(1))
+			Expect(hostDevices[0].Display).To(Equal(expectedDisplay))
+			Expect(hostDevices[0].RamFB).To(Equal(expectedRamFB))
+		},
+			table.Entry("with no display options", nil, "", ""),
+			table.Entry("with defaults", &v1.VGPUDisplayOptions{}, "on", "on"),
+			table.Entry("with the display disabled", &v1.VGPUDisplayOptions{Enabled: pointer.BoolPtr(false)}, "off", ""),
+			table.Entry("with the boot framebuffer disabled",
+				&v1.VGPUDisplayOptions{RamFB: &v1.FeatureState{Enabled: pointer.BoolPtr(false)}}, "on", ""),
+		)
+
+		It("ignores the display options of PCI devices", func() {
+			vmi.Spec.Domain.Devices.GPUs = []v1.GPU{{
+				DeviceName:        gpuResource0,
+				Name:              gpuName0,
+				VirtualGPUOptions: &v1.VGPUOptions{Display: &v1.VGPUDisplayOptions{}},
+			}}
+
+			hostDevices, err := gpu.CreateHostDevicesFromPools(vmi.Spec.Domain.Devices.GPUs, pciPool, mdevPool)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hostDevices).To(HaveLen(1))
+			Expect(hostDevices[0].Display).To(BeEmpty())
+			Expect(hostDevices[0].RamFB).To(BeEmpty())
+		})
+	})
 })
 
 type stubAddressPool struct {
@@ -21,6 +21,7 @@ package hostdevice
 
 import (
 	"fmt"
+	"strings"
 
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
@@ -51,6 +52,10 @@ func CreateMDEVHostDevices(hostDevicesData []HostDeviceMetaData, mdevAddrPool Ad
 	return createHostDevices(hostDevicesData, mdevAddrPool, createMDEVHostDevice)
 }
 
+func CreateUSBHostDevices(hostDevicesData []HostDeviceMetaData, usbAddrPool AddressPooler) ([]api.HostDevice, error) {
+	return createHostDevices(hostDevicesData, usbAddrPool, createUSBHostDevice)
+}
+
 func createHostDevices(hostDevicesData []HostDeviceMetaData, addrPool AddressPooler, createHostDev createHostDevice) ([]api.HostDevice, error) {
 	var hostDevices []api.HostDevice
 
@@ -109,3 +114,24 @@ func createMDEVHostDevice(hostDeviceData HostDeviceMetaData, mdevUUID string) (*
 	}
 	return domainHostDevice, nil
 }
+
+// createUSBHostDevice creates a USB host-device from its bus:device address, e.g. 1:4
+func createUSBHostDevice(hostDeviceData HostDeviceMetaData, hostUSBAddress string) (*api.HostDevice, error) {
+	address := strings.Split(hostUSBAddress, ":")
+	if len(address) != 2 || address[0] == "" || address[1] == "" {
+		return nil, fmt.Errorf("failed to create USB device for %s: invalid address %s", hostDeviceData.Name, hostUSBAddress)
+	}
+	domainHostDevice := &api.HostDevice{
+		Alias: api.NewUserDefinedAlias(hostDeviceData.AliasPrefix + hostDeviceData.Name),
+		Source: api.HostDeviceSource{
+			Address: &api.Address{
+				Bus:    address[0],
+				Device: address[1],
+			},
+		},
+		Type:    "usb",
+		Mode:    "subsystem",
+		Managed: "no",
+	}
+	return domainHostDevice, nil
+}
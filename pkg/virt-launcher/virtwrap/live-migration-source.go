@@ -45,6 +45,9 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/util"
 )
 
+// The interval in which the progress of a running migration is posted with the domain status
+const migrationProgressReportInterval = 5 * time.Second
+
 // Only used for testing, migration proxy ports are 'well-known' ports and should not be randomized in production
 var osChosenMigrationProxyPort = false
 
@@ -66,8 +69,11 @@ type migrationMonitor struct {
 
 	start              int64
 	lastProgressUpdate int64
+	lastProgressReport int64
 	progressWatermark  int64
 	remainingData      int64
+	// progress is the last progress of the migration which was reported with the domain status
+	progress *api.MigrationProgress
 
 	progressTimeout          int64
 	acceptableCompletionTime int64
@@ -79,7 +85,7 @@ type inflightMigrationAborted struct {
 	abortStatus v1.MigrationAbortStatus
 }
 
-func generateMigrationFlags(isBlockMigration, isUnsafeMigration, allowAutoConverge, allowPostyCopy, migratePaused bool) libvirt.DomainMigrateFlags {
+func generateMigrationFlags(isBlockMigration, isUnsafeMigration, allowAutoConverge, allowPostyCopy, migratePaused, parallelMigration bool) libvirt.DomainMigrateFlags {
 	migrateFlags := libvirt.MIGRATE_LIVE | libvirt.MIGRATE_PEER2PEER | libvirt.MIGRATE_PERSIST_DEST
 
 	if isBlockMigration {
@@ -97,6 +103,9 @@ func generateMigrationFlags(isBlockMigration, isUnsafeMigration, allowAutoConver
 	if migratePaused {
 		migrateFlags |= libvirt.MIGRATE_PAUSED
 	}
+	if parallelMigration {
+		migrateFlags |= libvirt.MIGRATE_PARALLEL
+	}
 
 	return migrateFlags
 
@@ -633,6 +642,11 @@ func (m *migrationMonitor) startMonitor() {
 
 	m.start = time.Now().UTC().UnixNano()
 	m.lastProgressUpdate = m.start
+	m.lastProgressReport = m.start
+
+	// a previous migration may have left its progress behind, and the progress is only valid while the migration runs
+	m.setMigrationProgress(nil)
+	defer m.setMigrationProgress(nil)
 
 	logger := log.Log.Object(vmi)
 
@@ -680,6 +694,7 @@ func (m *migrationMonitor) startMonitor() {
 				m.l.setMigrationResult(vmi, true, aborted.message, aborted.abortStatus)
 				return
 			}
+			m.reportMigrationProgress(stats)
 		case libvirt.DOMAIN_JOB_NONE:
 			completedJobInfo = m.determineNonRunningMigrationStatus(dom)
 		case libvirt.DOMAIN_JOB_COMPLETED:
@@ -698,6 +713,33 @@ func (m *migrationMonitor) startMonitor() {
 	}
 }
 
+// reportMigrationProgress posts the progress of the migration with the domain status, without redefining the domain
+func (m *migrationMonitor) reportMigrationProgress(stats *libvirt.DomainJobInfo) {
+	now := time.Now().UTC().UnixNano()
+	if now-m.lastProgressReport < migrationProgressReportInterval.Nanoseconds() {
+		return
+	}
+	m.lastProgressReport = now
+
+	updateTimestamp := metav1.Now()
+	m.setMigrationProgress(&api.MigrationProgress{
+		UpdateTimestamp: &updateTimestamp,
+		DataProcessed:   stats.DataProcessed,
+		MemRemaining:    stats.MemRemaining,
+		MemDirtyRate:    stats.MemDirtyRate,
+		MemIteration:    stats.MemIteration,
+	})
+}
+
+// setMigrationProgress keeps the progress of the migration and passes it to the domain notifier, nil clears it
+func (m *migrationMonitor) setMigrationProgress(progress *api.MigrationProgress) {
+	m.progress = progress
+	if m.l.agentData == nil {
+		return
+	}
+	m.l.agentData.MigrationProgressUpdated <- progress
+}
+
 func (l *LibvirtDomainManager) asyncMigrationAbort(vmi *v1.VirtualMachineInstance) {
 	go func(l *LibvirtDomainManager, vmi *v1.VirtualMachineInstance) {
 
@@ -726,6 +768,11 @@ func (l *LibvirtDomainManager) asyncMigrationAbort(vmi *v1.VirtualMachineInstanc
 	}(l, vmi)
 }
 
+// multifd channels can't be used together with post copy
+func isParallelMigration(options *cmdclient.MigrationOptions) bool {
+	return options.ParallelMigrationThreads > 0 && !options.AllowPostCopy
+}
+
 func isBlockMigration(vmi *v1.VirtualMachineInstance) bool {
 	return (vmi.Status.MigrationMethod == v1.BlockMigration)
 }
@@ -777,6 +824,11 @@ func generateMigrationParams(dom cli.VirDomain, vmi *v1.VirtualMachineInstance,
 		PersistXMLSet: true,
 	}
 
+	if isParallelMigration(options) {
+		params.ParallelConnections = int(options.ParallelMigrationThreads)
+		params.ParallelConnectionsSet = true
+	}
+
 	copyDisks := getDiskTargetsForMigration(dom, vmi)
 	if len(copyDisks) != 0 {
 		params.MigrateDisks = copyDisks
@@ -807,7 +859,7 @@ func (l *LibvirtDomainManager) migrateHelper(vmi *v1.VirtualMachineInstance, opt
 	if err != nil {
 		return fmt.Errorf("failed to retrive domain state")
 	}
-	migrateFlags := generateMigrationFlags(isBlockMigration(vmi), options.UnsafeMigration, options.AllowAutoConverge, options.AllowPostCopy, migratePaused)
+	migrateFlags := generateMigrationFlags(isBlockMigration(vmi), options.UnsafeMigration, options.AllowAutoConverge, options.AllowPostCopy, migratePaused, isParallelMigration(options))
 
 	// anything that modifies the domain needs to be performed with the domainModifyLock held
 	// The domain params and unHotplug need to be performed in a critical section together.
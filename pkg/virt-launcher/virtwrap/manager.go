@@ -34,6 +34,7 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device/hostdevice/generic"
@@ -64,6 +65,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/ignition"
 	netsetup "kubevirt.io/kubevirt/pkg/network/setup"
 	kutil "kubevirt.io/kubevirt/pkg/util"
+	pvctypes "kubevirt.io/kubevirt/pkg/util/types"
 	accesscredentials "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/access-credentials"
 	agentpoller "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/agent-poller"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
@@ -108,6 +110,7 @@ type DomainManager interface {
 	GetGuestOSInfo() *api.GuestOSInfo
 	Exec(string, string, []string, int32) (string, error)
 	GuestPing(string) error
+	MemoryDump(vmi *v1.VirtualMachineInstance, dumpPath string) error
 }
 
 type LibvirtDomainManager struct {
@@ -131,6 +134,8 @@ type LibvirtDomainManager struct {
 	ephemeralDiskCreator     ephemeraldisk.EphemeralDiskCreatorInterface
 	directIOChecker          converter.DirectIOChecker
 	disksInfo                map[string]*cmdv1.DiskInfo
+	// closed once the Shutdown hook sidecars were notified, guarded by domainModifyLock
+	shutdownHooksDone chan struct{}
 }
 
 type hostDeviceTypePrefix struct {
@@ -343,6 +348,36 @@ func (l *LibvirtDomainManager) GuestPing(domainName string) error {
 	return err
 }
 
+// MemoryDump writes a memory only ELF dump of a running domain to dumpPath.
+// The dump is captured next to dumpPath first and only moved into place once
+// it is complete, so that virt-handler never streams a partial dump.
+func (l *LibvirtDomainManager) MemoryDump(vmi *v1.VirtualMachineInstance, dumpPath string) error {
+	logger := log.Log.Object(vmi)
+
+	domName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		if domainerrors.IsNotFound(err) {
+			return fmt.Errorf("Domain not found.")
+		}
+		logger.Reason(err).Error("Getting the domain failed during memory dump.")
+		return err
+	}
+	defer dom.Free()
+
+	if err := os.MkdirAll(filepath.Dir(dumpPath), 0755); err != nil {
+		return err
+	}
+	partialDumpPath := dumpPath + ".partial"
+	defer os.Remove(partialDumpPath)
+
+	if err := dom.CoreDumpWithFormat(partialDumpPath, libvirt.DOMAIN_CORE_DUMP_FORMAT_RAW, libvirt.DUMP_MEMORY_ONLY); err != nil {
+		logger.Reason(err).Error("Dumping the domain memory failed.")
+		return err
+	}
+	return os.Rename(partialDumpPath, dumpPath)
+}
+
 func getVMIEphemeralDisksTotalSize() *resource.Quantity {
 	var baseDir = "/var/run/kubevirt-ephemeral-disks/"
 	totalSize := int64(0)
@@ -834,6 +869,13 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, allowEmul
 		if err != nil {
 			logger.Reason(err).
 				Errorf("Failed to start VirtualMachineInstance with flags %v.", createFlags)
+			// attach what QEMU logged, users see the error in the Synchronized condition
+			// and in the SyncFailed event of the VMI and don't need to look into the pod logs
+			if tail, tailErr := util.TailQEMULog(api.VMINamespaceKeyFunc(vmi), kutil.IsNonRootVMI(vmi), util.QEMULogTailLines); tailErr != nil {
+				logger.Reason(tailErr).Error("Failed to read the QEMU log.")
+			} else if len(tail) > 0 {
+				err = fmt.Errorf("%v, last lines of the QEMU log:\n%s", err, strings.Join(tail, "\n"))
+			}
 			return nil, err
 		}
 		logger.Info("Domain started.")
@@ -864,6 +906,29 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, allowEmul
 		return nil, err
 	}
 
+	// Look up all the CD-ROMs with changed media
+	for _, cdrom := range getChangedMediaCDRoms(oldSpec.Devices.Disks, domain.Spec.Devices.Disks) {
+		if file := getSourceFile(cdrom); file != "" {
+			allowInsert, err := checkIfDiskReadyToUse(file)
+			if err != nil {
+				return nil, err
+			}
+			if !allowInsert {
+				continue
+			}
+		}
+		logger.V(1).Infof("Changing media of CD-ROM %s to %q", cdrom.Alias.GetName(), getSourceFile(cdrom))
+		cdromBytes, err := xml.Marshal(cdrom)
+		if err != nil {
+			logger.Reason(err).Error("marshalling changed CD-ROM failed")
+			return nil, err
+		}
+		err = dom.UpdateDeviceFlags(strings.ToLower(string(cdromBytes)), libvirt.DOMAIN_DEVICE_MODIFY_LIVE)
+		if err != nil {
+			logger.Reason(err).Error("changing media")
+			return nil, err
+		}
+	}
 	//Look up all the disks to detach
 	for _, detachDisk := range getDetachedDisks(oldSpec.Devices.Disks, domain.Spec.Devices.Disks) {
 		logger.V(1).Infof("Detaching disk %s, target %s", detachDisk.Alias.GetName(), detachDisk.Target.Device)
@@ -900,10 +965,93 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, allowEmul
 		}
 	}
 
+	if options != nil && options.ExpandDisksEnabled {
+		expandDisks(vmi, dom, oldSpec.Devices.Disks)
+	}
+
 	// TODO: check if VirtualMachineInstance Spec and Domain Spec are equal or if we have to sync
 	return &oldSpec, nil
 }
 
+// expandDisks grows the disks of PVCs whose capacity increased, so the guest sees the larger
+// disk right away. The disks never shrink. A disk which fails to expand does not keep the
+// others from expanding, it is retried on the next sync.
+func expandDisks(vmi *v1.VirtualMachineInstance, dom cli.VirDomain, disks []api.Disk) {
+	disksByAlias := map[string]api.Disk{}
+	for _, disk := range disks {
+		if disk.Alias != nil {
+			disksByAlias[disk.Alias.GetName()] = disk
+		}
+	}
+
+	for _, volumeStatus := range vmi.Status.VolumeStatus {
+		if volumeStatus.PersistentVolumeClaimInfo == nil {
+			continue
+		}
+		capacity := pvctypes.GetDiskCapacity(volumeStatus.PersistentVolumeClaimInfo)
+		if capacity == nil {
+			continue
+		}
+		disk, ok := disksByAlias[volumeStatus.Name]
+		if !ok || disk.ReadOnly != nil || disk.Driver == nil || disk.Driver.Type != "raw" {
+			continue
+		}
+
+		info, err := dom.GetBlockInfo(disk.Target.Device, 0)
+		if err != nil {
+			log.Log.Object(vmi).Reason(err).Errorf("Failed to get the size of disk %s", disk.Target.Device)
+			continue
+		}
+		size, err := expandedDiskSize(disk, info, capacity.Value())
+		if err != nil {
+			log.Log.Object(vmi).Reason(err).Errorf("Failed to get the expanded size of disk %s", disk.Target.Device)
+			continue
+		}
+		if size <= info.Capacity {
+			continue
+		}
+
+		log.Log.Object(vmi).Infof("Expanding disk %s from %d to %d bytes", disk.Target.Device, info.Capacity, size)
+		if err := dom.BlockResize(disk.Target.Device, size, libvirt.DOMAIN_BLOCK_RESIZE_BYTES); err != nil {
+			log.Log.Object(vmi).Reason(err).Errorf("Failed to expand disk %s", disk.Target.Device)
+		}
+	}
+}
+
+// expandedDiskSize returns the size a disk can grow to, aligned down to MiB. A block device is limited by
+// its own size, a disk image by the PVC capacity and the space left on the filesystem of the PVC.
+func expandedDiskSize(disk api.Disk, info *libvirt.DomainBlockInfo, capacity int64) (uint64, error) {
+	const alignment = 1024 * 1024
+
+	size := info.Physical
+	if disk.Type == "file" {
+		size = uint64(capacity)
+		var stat syscall.Stat_t
+		if err := syscall.Stat(disk.Source.File, &stat); err != nil {
+			return 0, err
+		}
+		free, err := availableFilesystemBytes(filepath.Dir(disk.Source.File))
+		if err != nil {
+			return 0, err
+		}
+		if available := uint64(stat.Blocks)*512 + free; available < size {
+			size = available
+		}
+	}
+	return size / alignment * alignment, nil
+}
+
+var availableFilesystemBytes = availableFilesystemBytesFunc
+
+// availableFilesystemBytesFunc returns the space which is left for unprivileged users on the filesystem of a path
+func availableFilesystemBytesFunc(path string) (uint64, error) {
+	var statfs syscall.Statfs_t
+	if err := syscall.Statfs(path, &statfs); err != nil {
+		return 0, err
+	}
+	return statfs.Bavail * uint64(statfs.Bsize), nil
+}
+
 func getSourceFile(disk api.Disk) string {
 	file := disk.Source.File
 	if disk.Source.File == "" {
@@ -945,6 +1093,34 @@ func checkIfDiskReadyToUseFunc(filename string) (bool, error) {
 	return true, nil
 }
 
+// getChangedMediaCDRoms returns the CD-ROMs whose media has to be changed. The media of
+// CD-ROMs with an open tray is ejected.
+func getChangedMediaCDRoms(oldDisks, newDisks []api.Disk) []api.Disk {
+	newCDRomMap := make(map[string]api.Disk)
+	for _, disk := range newDisks {
+		if disk.Device == "cdrom" && disk.Alias != nil {
+			newCDRomMap[disk.Alias.GetName()] = disk
+		}
+	}
+	res := make([]api.Disk, 0)
+	for _, oldDisk := range oldDisks {
+		if oldDisk.Device != "cdrom" || oldDisk.Alias == nil {
+			continue
+		}
+		newDisk, ok := newCDRomMap[oldDisk.Alias.GetName()]
+		if !ok {
+			continue
+		}
+		if newDisk.Target.Tray == string(v1.TrayStateOpen) {
+			newDisk.Source = api.DiskSource{}
+		}
+		if getSourceFile(oldDisk) != getSourceFile(newDisk) {
+			res = append(res, newDisk)
+		}
+	}
+	return res
+}
+
 func getDetachedDisks(oldDisks, newDisks []api.Disk) []api.Disk {
 	newDiskMap := make(map[string]api.Disk)
 	for _, disk := range newDisks {
@@ -955,6 +1131,10 @@ func getDetachedDisks(oldDisks, newDisks []api.Disk) []api.Disk {
 	}
 	res := make([]api.Disk, 0)
 	for _, oldDisk := range oldDisks {
+		if oldDisk.Device == "cdrom" {
+			// The media of CD-ROMs is changed instead
+			continue
+		}
 		if _, ok := newDiskMap[getSourceFile(oldDisk)]; !ok {
 			// This disk got detached, add it to the list
 			res = append(res, oldDisk)
@@ -973,6 +1153,10 @@ func getAttachedDisks(oldDisks, newDisks []api.Disk) []api.Disk {
 	}
 	res := make([]api.Disk, 0)
 	for _, newDisk := range newDisks {
+		if newDisk.Device == "cdrom" {
+			// The media of CD-ROMs is changed instead
+			continue
+		}
 		if _, ok := oldDiskMap[getSourceFile(newDisk)]; !ok {
 			// This disk got attached, add it to the list
 			res = append(res, newDisk)
@@ -1231,14 +1415,8 @@ func (l *LibvirtDomainManager) SignalShutdownVMI(vmi *v1.VirtualMachineInstance)
 			return err
 		}
 
-		err = dom.ShutdownFlags(libvirt.DOMAIN_SHUTDOWN_ACPI_POWER_BTN)
-		if err != nil {
-			log.Log.Object(vmi).Reason(err).Error("Signalling graceful shutdown failed.")
-			return err
-		}
-		log.Log.Object(vmi).Infof("Signaled graceful shutdown for %s", vmi.GetObjectMeta().GetName())
-
 		if domSpec.Metadata.KubeVirt.GracePeriod.DeletionTimestamp == nil {
+			// The grace period starts before the hook sidecars are notified, so that they can't extend it
 			now := metav1.Now()
 			domSpec.Metadata.KubeVirt.GracePeriod.DeletionTimestamp = &now
 			d, err := l.setDomainSpecWithHooks(vmi, domSpec)
@@ -1247,7 +1425,39 @@ func (l *LibvirtDomainManager) SignalShutdownVMI(vmi *v1.VirtualMachineInstance)
 				return err
 			}
 			defer d.Free()
+
+			// Give hook sidecars the chance to act before the guest is signaled, only once per shutdown
+			// and without holding the lock, the guest is signaled once they responded or the grace period passed
+			gracePeriod := time.Duration(domSpec.Metadata.KubeVirt.GracePeriod.DeletionGracePeriodSeconds) * time.Second
+			hooksDone := make(chan struct{})
+			l.shutdownHooksDone = hooksDone
+			go func() {
+				if err := hooks.GetManager().Shutdown(vmi, gracePeriod); err != nil {
+					log.Log.Object(vmi).Reason(err).Error("Shutdown hook failed, continuing with the graceful shutdown")
+				}
+				close(hooksDone)
+				if err := l.SignalShutdownVMI(vmi); err != nil {
+					log.Log.Object(vmi).Reason(err).Error("Signalling graceful shutdown after the shutdown hooks failed.")
+				}
+			}()
+			return nil
+		}
+
+		if l.shutdownHooksDone != nil {
+			select {
+			case <-l.shutdownHooksDone:
+			default:
+				log.Log.Object(vmi).Info("Waiting for the shutdown hooks before signalling graceful shutdown")
+				return nil
+			}
 		}
+
+		err = dom.ShutdownFlags(libvirt.DOMAIN_SHUTDOWN_ACPI_POWER_BTN)
+		if err != nil {
+			log.Log.Object(vmi).Reason(err).Error("Signalling graceful shutdown failed.")
+			return err
+		}
+		log.Log.Object(vmi).Infof("Signaled graceful shutdown for %s", vmi.GetObjectMeta().GetName())
 	}
 
 	return nil
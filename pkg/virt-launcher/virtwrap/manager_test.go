@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"time"
@@ -299,6 +300,42 @@ var _ = Describe("Manager", func() {
 			err := manager.PauseVMI(vmi)
 			Expect(err).To(BeNil())
 		})
+		It("should dump the memory of a VirtualMachineInstance", func() {
+			// Make sure that we always free the domain after use
+			mockDomain.EXPECT().Free()
+			vmi := newVMI(testNamespace, testVmName)
+			dumpDir, err := ioutil.TempDir("", "memorydump")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(dumpDir)
+			dumpPath := filepath.Join(dumpDir, vmi.Name, "memory.dump")
+
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().CoreDumpWithFormat(dumpPath+".partial", libvirt.DOMAIN_CORE_DUMP_FORMAT_RAW, libvirt.DUMP_MEMORY_ONLY).DoAndReturn(func(to string, _ libvirt.DomainCoreDumpFormat, _ libvirt.DomainCoreDumpFlags) error {
+				return ioutil.WriteFile(to, []byte("ELF"), 0644)
+			})
+			manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock)
+
+			Expect(manager.MemoryDump(vmi, dumpPath)).To(Succeed())
+			Expect(ioutil.ReadFile(dumpPath)).To(Equal([]byte("ELF")))
+			Expect(dumpPath + ".partial").ToNot(BeAnExistingFile())
+		})
+		It("should keep the previous memory dump if dumping fails", func() {
+			// Make sure that we always free the domain after use
+			mockDomain.EXPECT().Free()
+			vmi := newVMI(testNamespace, testVmName)
+			dumpDir, err := ioutil.TempDir("", "memorydump")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(dumpDir)
+			dumpPath := filepath.Join(dumpDir, "memory.dump")
+			Expect(ioutil.WriteFile(dumpPath, []byte("previous"), 0644)).To(Succeed())
+
+			mockConn.EXPECT().LookupDomainByName(testDomainName).Return(mockDomain, nil)
+			mockDomain.EXPECT().CoreDumpWithFormat(dumpPath+".partial", libvirt.DOMAIN_CORE_DUMP_FORMAT_RAW, libvirt.DUMP_MEMORY_ONLY).Return(fmt.Errorf("no space left on device"))
+			manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock)
+
+			Expect(manager.MemoryDump(vmi, dumpPath)).ToNot(Succeed())
+			Expect(ioutil.ReadFile(dumpPath)).To(Equal([]byte("previous")))
+		})
 		It("should not try to pause a paused VirtualMachineInstance", func() {
 			// Make sure that we always free the domain after use
 			mockDomain.EXPECT().Free()
@@ -910,6 +947,48 @@ var _ = Describe("Manager", func() {
 
 			manager.SignalShutdownVMI(vmi)
 		})
+
+		It("Should record the grace period start before signalling graceful shutdown", func() {
+			mockDomain.EXPECT().Free().AnyTimes()
+
+			vmi := newVMI(testNamespace, testVmName)
+			domainSpec := expectIsolationDetectionForVMI(vmi)
+
+			xml, err := xml.MarshalIndent(domainSpec, "", "\t")
+			Expect(err).To(BeNil())
+
+			mockDomain.EXPECT().GetState().AnyTimes().Return(libvirt.DOMAIN_RUNNING, 1, nil)
+			mockConn.EXPECT().LookupDomainByName(testDomainName).AnyTimes().Return(mockDomain, nil)
+			mockDomain.EXPECT().GetXMLDesc(gomock.Eq(libvirt.DomainXMLFlags(0))).AnyTimes().Return(string(xml), nil)
+			mockDomain.EXPECT().
+				GetMetadata(libvirt.DOMAIN_METADATA_ELEMENT, "http://kubevirt.io", libvirt.DOMAIN_AFFECT_CONFIG).
+				Times(1).
+				Return(`<kubevirt><graceperiod><deletionGracePeriodSeconds>3600</deletionGracePeriodSeconds><markedForGracefulShutdown>true</markedForGracefulShutdown></graceperiod></kubevirt>`, nil)
+			mockDomain.EXPECT().
+				GetMetadata(libvirt.DOMAIN_METADATA_ELEMENT, "http://kubevirt.io", libvirt.DOMAIN_AFFECT_CONFIG).
+				AnyTimes().
+				Return(`<kubevirt><graceperiod><deletionGracePeriodSeconds>3600</deletionGracePeriodSeconds><deletionTimestamp>2021-03-11T09:08:20.144606353Z</deletionTimestamp><markedForGracefulShutdown>true</markedForGracefulShutdown></graceperiod></kubevirt>`, nil)
+
+			recorded := make(chan struct{})
+			signaled := make(chan struct{})
+			mockConn.EXPECT().DomainDefineXML(gomock.Any()).Times(1).DoAndReturn(func(xml string) (cli.VirDomain, error) {
+				close(recorded)
+				return mockDomain, nil
+			})
+			mockDomain.EXPECT().ShutdownFlags(libvirt.DOMAIN_SHUTDOWN_ACPI_POWER_BTN).Times(1).DoAndReturn(func(_ libvirt.DomainShutdownFlags) error {
+				select {
+				case <-recorded:
+				default:
+					Fail("the guest was signaled before the grace period start was recorded")
+				}
+				close(signaled)
+				return nil
+			})
+			manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, nil, "/usr/share/OVMF", ephemeralDiskCreatorMock)
+
+			Expect(manager.SignalShutdownVMI(vmi)).To(Succeed())
+			Eventually(signaled).Should(BeClosed())
+		})
 	})
 	Context("test migration monitor", func() {
 		It("migration should be canceled if it's not progressing", func() {
@@ -1528,8 +1607,9 @@ var _ = Describe("Manager", func() {
 			allowAutoConverge := migrationType == "autoConverge"
 			migrationMode := migrationType == "postCopy"
 			isVmiPaused := migrationType == "paused"
+			parallelMigration := migrationType == "parallel"
 
-			flags := generateMigrationFlags(isBlockMigration, isUnsafeMigration, allowAutoConverge, migrationMode, isVmiPaused)
+			flags := generateMigrationFlags(isBlockMigration, isUnsafeMigration, allowAutoConverge, migrationMode, isVmiPaused, parallelMigration)
 			expectedMigrateFlags := libvirt.MIGRATE_LIVE | libvirt.MIGRATE_PEER2PEER | libvirt.MIGRATE_PERSIST_DEST
 
 			if isBlockMigration {
@@ -1546,6 +1626,9 @@ var _ = Describe("Manager", func() {
 			if migrationType == "paused" {
 				expectedMigrateFlags |= libvirt.MIGRATE_PAUSED
 			}
+			if migrationType == "parallel" {
+				expectedMigrateFlags |= libvirt.MIGRATE_PARALLEL
+			}
 			Expect(flags).To(Equal(expectedMigrateFlags))
 		},
 		table.Entry("with block migration", "block"),
@@ -1554,6 +1637,7 @@ var _ = Describe("Manager", func() {
 		table.Entry("migration auto converge", "autoConverge"),
 		table.Entry("migration using postcopy", "postCopy"),
 		table.Entry("migration of paused vmi", "paused"),
+		table.Entry("parallel migration", "parallel"),
 	)
 
 	table.DescribeTable("on successful list all domains",
@@ -2046,6 +2130,136 @@ var _ = Describe("getDetachedDisks", func() {
 	)
 })
 
+var _ = Describe("getChangedMediaCDRoms", func() {
+	newCDRom := func(file, tray string) api.Disk {
+		return api.Disk{
+			Device: "cdrom",
+			Alias:  api.NewUserDefinedAlias("cdrom"),
+			Source: api.DiskSource{File: file},
+			Target: api.DiskTarget{Tray: tray},
+		}
+	}
+
+	table.DescribeTable("should return the correct values", func(oldDisks, newDisks, expected []api.Disk) {
+		res := getChangedMediaCDRoms(oldDisks, newDisks)
+		Expect(res).To(Equal(expected))
+	},
+		table.Entry("be empty with empty old and new",
+			[]api.Disk{},
+			[]api.Disk{},
+			[]api.Disk{}),
+		table.Entry("be empty with the media unchanged",
+			[]api.Disk{newCDRom("file", "")},
+			[]api.Disk{newCDRom("file", "")},
+			[]api.Disk{}),
+		table.Entry("contain a CD-ROM with an empty source if the tray is opened",
+			[]api.Disk{newCDRom("file", "")},
+			[]api.Disk{newCDRom("file", "open")},
+			[]api.Disk{newCDRom("", "open")}),
+		table.Entry("contain a CD-ROM with the new source if the media is swapped",
+			[]api.Disk{newCDRom("file", "")},
+			[]api.Disk{newCDRom("file2", "closed")},
+			[]api.Disk{newCDRom("file2", "closed")}),
+		table.Entry("be empty if the disk is not a CD-ROM",
+			[]api.Disk{{Device: "disk", Alias: api.NewUserDefinedAlias("disk"), Source: api.DiskSource{File: "file"}}},
+			[]api.Disk{{Device: "disk", Alias: api.NewUserDefinedAlias("disk"), Source: api.DiskSource{File: "file2"}}},
+			[]api.Disk{}),
+	)
+})
+
+var _ = Describe("expandDisks", func() {
+	var ctrl *gomock.Controller
+	var mockDomain *cli.MockVirDomain
+	var vmi *v1.VirtualMachineInstance
+
+	newDisk := func(name, diskType string) api.Disk {
+		return api.Disk{
+			Device: "disk",
+			Type:   diskType,
+			Alias:  api.NewUserDefinedAlias(name),
+			Target: api.DiskTarget{Device: "vd" + name},
+			Driver: &api.DiskDriver{Type: "raw"},
+		}
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockDomain = cli.NewMockVirDomain(ctrl)
+		vmi = v1.NewMinimalVMI("testvmi")
+		vmi.Status.VolumeStatus = []v1.VolumeStatus{{
+			Name: "a",
+			PersistentVolumeClaimInfo: &v1.PersistentVolumeClaimInfo{
+				Capacity: k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse("2Gi")},
+			},
+		}}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		availableFilesystemBytes = availableFilesystemBytesFunc
+	})
+
+	newDiskImage := func() (api.Disk, func()) {
+		dir, err := ioutil.TempDir("", "expand")
+		Expect(err).ToNot(HaveOccurred())
+		disk := newDisk("a", "file")
+		disk.Source.File = filepath.Join(dir, "disk.img")
+		Expect(ioutil.WriteFile(disk.Source.File, []byte{}, 0644)).To(Succeed())
+		return disk, func() { os.RemoveAll(dir) }
+	}
+
+	It("should grow a block disk to the size of the device", func() {
+		mockDomain.EXPECT().GetBlockInfo("vda", uint32(0)).Return(&libvirt.DomainBlockInfo{Capacity: 1 << 30, Physical: 2 << 30}, nil)
+		mockDomain.EXPECT().BlockResize("vda", uint64(2<<30), libvirt.DOMAIN_BLOCK_RESIZE_BYTES).Return(nil)
+		expandDisks(vmi, mockDomain, []api.Disk{newDisk("a", "block")})
+	})
+
+	It("should grow a disk image to the capacity of the PVC", func() {
+		disk, cleanup := newDiskImage()
+		defer cleanup()
+		availableFilesystemBytes = func(path string) (uint64, error) {
+			Expect(path).To(Equal(filepath.Dir(disk.Source.File)))
+			return 10 << 30, nil
+		}
+
+		mockDomain.EXPECT().GetBlockInfo("vda", uint32(0)).Return(&libvirt.DomainBlockInfo{Capacity: 1 << 30}, nil)
+		mockDomain.EXPECT().BlockResize("vda", uint64(2<<30), libvirt.DOMAIN_BLOCK_RESIZE_BYTES).Return(nil)
+		expandDisks(vmi, mockDomain, []api.Disk{disk})
+	})
+
+	It("should grow a disk image only as far as the filesystem has space left", func() {
+		disk, cleanup := newDiskImage()
+		defer cleanup()
+		availableFilesystemBytes = func(string) (uint64, error) {
+			return 1536<<20 + 1, nil
+		}
+
+		mockDomain.EXPECT().GetBlockInfo("vda", uint32(0)).Return(&libvirt.DomainBlockInfo{Capacity: 1 << 30}, nil)
+		mockDomain.EXPECT().BlockResize("vda", uint64(1536<<20), libvirt.DOMAIN_BLOCK_RESIZE_BYTES).Return(nil)
+		expandDisks(vmi, mockDomain, []api.Disk{disk})
+	})
+
+	It("should not shrink a disk", func() {
+		mockDomain.EXPECT().GetBlockInfo("vda", uint32(0)).Return(&libvirt.DomainBlockInfo{Capacity: 2 << 30, Physical: 2 << 30}, nil)
+		expandDisks(vmi, mockDomain, []api.Disk{newDisk("a", "block")})
+	})
+
+	It("should expand the other disks if one of them fails", func() {
+		vmi.Status.VolumeStatus = append(vmi.Status.VolumeStatus, v1.VolumeStatus{
+			Name:                      "b",
+			PersistentVolumeClaimInfo: vmi.Status.VolumeStatus[0].PersistentVolumeClaimInfo,
+		})
+		mockDomain.EXPECT().GetBlockInfo("vda", uint32(0)).Return(nil, fmt.Errorf("error"))
+		mockDomain.EXPECT().GetBlockInfo("vdb", uint32(0)).Return(&libvirt.DomainBlockInfo{Capacity: 1 << 30, Physical: 2 << 30}, nil)
+		mockDomain.EXPECT().BlockResize("vdb", uint64(2<<30), libvirt.DOMAIN_BLOCK_RESIZE_BYTES).Return(nil)
+		expandDisks(vmi, mockDomain, []api.Disk{newDisk("a", "block"), newDisk("b", "block")})
+	})
+
+	It("should ignore disks without a PVC", func() {
+		expandDisks(vmi, mockDomain, []api.Disk{newDisk("b", "block")})
+	})
+})
+
 var _ = Describe("migratableDomXML", func() {
 	var ctrl *gomock.Controller
 	var mockDomain *cli.MockVirDomain
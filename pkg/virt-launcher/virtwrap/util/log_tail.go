@@ -0,0 +1,32 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package util
+
+import "kubevirt.io/kubevirt/pkg/util/qemulog"
+
+// QEMULogTailLines is the number of lines of the QEMU log which are attached to the error of a
+// domain which failed to start
+const QEMULogTailLines = 20
+
+// TailQEMULog returns the last lines of the QEMU log of a domain, oldest first. The log is read
+// from disk when it is needed, since QEMU keeps writing to it after virtlogd created it.
+func TailQEMULog(domainName string, nonRoot bool, lines int) ([]string, error) {
+	return qemulog.TailFile(qemulog.LogFile(domainName, nonRoot), lines)
+}
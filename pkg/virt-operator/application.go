@@ -48,6 +48,7 @@ import (
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -58,6 +59,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/monitoring/profiler"
 	"kubevirt.io/kubevirt/pkg/service"
 	clusterutil "kubevirt.io/kubevirt/pkg/util/cluster"
+	"kubevirt.io/kubevirt/pkg/util/ratelimiter"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-controller/leaderelectionconfig"
 	install "kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/install"
@@ -85,6 +87,7 @@ type VirtOperatorApp struct {
 	kubeVirtRecorder   record.EventRecorder
 
 	operatorNamespace string
+	host              string
 
 	kubeVirtInformer cache.SharedIndexInformer
 	kubeVirtCache    cache.Store
@@ -97,6 +100,8 @@ type VirtOperatorApp struct {
 	operatorCertManager certificate.Manager
 
 	clusterConfig *virtconfig.ClusterConfig
+
+	reloadableRateLimiter *ratelimiter.ReloadableRateLimiter
 }
 
 var (
@@ -130,7 +135,7 @@ func Execute() {
 
 	service.Setup(&app)
 
-	log.InitializeLogging("virt-operator")
+	log.InitializeLibraryLogging("virt-operator")
 
 	err = util.VerifyEnv()
 	if err != nil {
@@ -149,8 +154,13 @@ func Execute() {
 
 	app.aggregatorClient = aggregatorclient.NewForConfigOrDie(config)
 
-	app.clientSet, err = kubecli.GetKubevirtClient()
-
+	app.reloadableRateLimiter = ratelimiter.NewReloadableRateLimiter(flowcontrol.NewTokenBucketRateLimiter(virtconfig.DefaultVirtOperatorQPS, virtconfig.DefaultVirtOperatorBurst))
+	clientConfig, err := kubecli.GetKubevirtClientConfig()
+	if err != nil {
+		panic(err)
+	}
+	clientConfig.RateLimiter = app.reloadableRateLimiter
+	app.clientSet, err = kubecli.GetKubevirtClientFromRESTConfig(clientConfig)
 	if err != nil {
 		golog.Fatal(err)
 	}
@@ -164,6 +174,11 @@ func Execute() {
 		golog.Fatalf("Error searching for namespace: %v", err)
 	}
 
+	app.host, err = os.Hostname()
+	if err != nil {
+		golog.Fatalf("unable to get hostname: %v", err)
+	}
+
 	if *dumpInstallStrategy {
 		err = install.DumpInstallStrategyToConfigMap(app.clientSet, app.operatorNamespace)
 		if err != nil {
@@ -282,17 +297,38 @@ func Execute() {
 		app.informerFactory.CRD(),
 		app.informerFactory.KubeVirt(),
 		app.operatorNamespace)
+	app.clusterConfig.SetConfigModifiedCallback(app.shouldChangeRateLimiter)
+	app.clusterConfig.SetConfigModifiedCallback(app.shouldChangeLogVerbosity)
 
 	app.Run()
 }
 
+// Update virt-operator log verbosity on relevant config changes
+func (app *VirtOperatorApp) shouldChangeLogVerbosity() {
+	verbosity := app.clusterConfig.GetVirtOperatorVerbosity(app.host)
+	log.Log.SetVerbosityLevel(int(verbosity))
+	log.SetLibraryJSONFormat(app.clusterConfig.LibraryLogsJSONEnabled())
+	log.Log.V(2).Infof("set log verbosity to %d", verbosity)
+}
+
+// Update virt-operator rate limiter
+func (app *VirtOperatorApp) shouldChangeRateLimiter() {
+	config := app.clusterConfig.GetConfig()
+	qps := config.OperatorConfiguration.RestClient.RateLimiter.TokenBucketRateLimiter.QPS
+	burst := config.OperatorConfiguration.RestClient.RateLimiter.TokenBucketRateLimiter.Burst
+	app.reloadableRateLimiter.Set(flowcontrol.NewTokenBucketRateLimiter(qps, burst))
+	log.Log.V(2).Infof("setting rate limiter to %v QPS and %v Burst", qps, burst)
+}
+
 func (app *VirtOperatorApp) Run() {
 	promTLSConfig := webhooks.SetupPromTLS(app.operatorCertManager)
 
+	// the leader election status is added to the metrics mux once the leader elector exists
+	metricsMux := http.NewServeMux()
+
 	go func() {
 
-		mux := http.NewServeMux()
-		mux.Handle("/metrics", promhttp.Handler())
+		metricsMux.Handle("/metrics", promhttp.Handler())
 
 		webService := new(restful.WebService)
 		webService.Path("/").Consumes(restful.MIME_JSON).Produces(restful.MIME_JSON)
@@ -303,12 +339,12 @@ func (app *VirtOperatorApp) Run() {
 		webService.Route(webService.GET("/dump-profiler").To(componentProfiler.HandleDumpProfiler).Doc("dump profiler results endpoint"))
 
 		restfulContainer := restful.NewContainer()
-		restfulContainer.ServeMux = mux
+		restfulContainer.ServeMux = metricsMux
 		restfulContainer.Add(webService)
 
 		server := http.Server{
 			Addr:      app.ServiceListen.Address(),
-			Handler:   mux,
+			Handler:   metricsMux,
 			TLSConfig: promTLSConfig,
 		}
 		if err := server.ListenAndServeTLS("", ""); err != nil {
@@ -323,18 +359,13 @@ func (app *VirtOperatorApp) Run() {
 
 	recorder := app.getNewRecorder(k8sv1.NamespaceAll, endpointName)
 
-	id, err := os.Hostname()
-	if err != nil {
-		golog.Fatalf("unable to get hostname: %v", err)
-	}
-
 	rl, err := resourcelock.New(app.LeaderElection.ResourceLock,
 		app.operatorNamespace,
 		endpointName,
 		app.clientSet.CoreV1(),
 		app.clientSet.CoordinationV1(),
 		resourcelock.ResourceLockConfig{
-			Identity:      id,
+			Identity:      app.host,
 			EventRecorder: recorder,
 		})
 	if err != nil {
@@ -345,7 +376,14 @@ func (app *VirtOperatorApp) Run() {
 
 	stop := ctx.Done()
 	app.informerFactory.Start(stop)
-	cache.WaitForCacheSync(stop, apiAuthConfig.HasSynced)
+	cache.WaitForCacheSync(stop, apiAuthConfig.HasSynced,
+		app.informerFactory.ConfigMap().HasSynced,
+		app.informerFactory.CRD().HasSynced,
+		app.kubeVirtInformer.HasSynced)
+
+	if err := app.LeaderElection.Apply(app.clusterConfig.GetConfig().LeaderElection); err != nil {
+		log.Log.Reason(err).Error("Ignoring the leader election configuration of the KubeVirt CR")
+	}
 
 	go app.operatorCertManager.Start()
 
@@ -396,6 +434,8 @@ func (app *VirtOperatorApp) Run() {
 		golog.Fatal(err)
 	}
 
+	metricsMux.Handle(leaderelectionconfig.StatusPath, leaderelectionconfig.NewStatusHandler(app.host, app.LeaderElection, leaderElector))
+
 	readyGauge.Set(1)
 	log.Log.Infof("Attempting to acquire leader status")
 	leaderElector.Run(ctx)
@@ -404,7 +444,7 @@ func (app *VirtOperatorApp) Run() {
 }
 
 func (app *VirtOperatorApp) getNewRecorder(namespace string, componentName string) record.EventRecorder {
-	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster := controller.NewEventBroadcaster()
 	eventBroadcaster.StartRecordingToSink(&k8coresv1.EventSinkImpl{Interface: app.clientSet.CoreV1().Events(namespace)})
 	return eventBroadcaster.NewRecorder(scheme.Scheme, k8sv1.EventSource{Component: componentName})
 }
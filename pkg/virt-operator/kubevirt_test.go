@@ -78,8 +78,8 @@ const (
 
 	NAMESPACE = "kubevirt-test"
 
-	resourceCount = 53
-	patchCount    = 34
+	resourceCount = 54
+	patchCount    = 35
 	updateCount   = 20
 )
 
@@ -1088,7 +1088,7 @@ func (k *KubeVirtTestData) addAll(config *util.KubeVirtDeploymentConfig, kv *v1.
 		components.NewVirtualMachineInstanceCrd, components.NewPresetCrd, components.NewReplicaSetCrd,
 		components.NewVirtualMachineCrd, components.NewVirtualMachineInstanceMigrationCrd,
 		components.NewVirtualMachineSnapshotCrd, components.NewVirtualMachineSnapshotContentCrd,
-		components.NewVirtualMachineRestoreCrd,
+		components.NewVirtualMachineRestoreCrd, components.NewVirtualMachinePoolCrd,
 	}
 	for _, f := range functions {
 		crd, err := f()
@@ -2058,7 +2058,7 @@ var _ = Describe("KubeVirt Operator", func() {
 			Expect(len(kvTestData.controller.stores.ClusterRoleBindingCache.List())).To(Equal(5))
 			Expect(len(kvTestData.controller.stores.RoleCache.List())).To(Equal(3))
 			Expect(len(kvTestData.controller.stores.RoleBindingCache.List())).To(Equal(3))
-			Expect(len(kvTestData.controller.stores.CrdCache.List())).To(Equal(8))
+			Expect(len(kvTestData.controller.stores.CrdCache.List())).To(Equal(9))
 			Expect(len(kvTestData.controller.stores.ServiceCache.List())).To(Equal(3))
 			Expect(len(kvTestData.controller.stores.DeploymentCache.List())).To(Equal(1))
 			Expect(len(kvTestData.controller.stores.DaemonSetCache.List())).To(Equal(0))
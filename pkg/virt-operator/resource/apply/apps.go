@@ -90,7 +90,7 @@ func (r *Reconciler) syncDaemonSet(daemonSet *appsv1.DaemonSet) error {
 	injectOperatorMetadata(kv, &daemonSet.Spec.Template.ObjectMeta, imageTag, imageRegistry, id, false)
 	injectPlacementMetadata(kv.Spec.Workloads, &daemonSet.Spec.Template.Spec)
 
-	if daemonSet.GetName() == "virt-handler" {
+	if daemonSet.GetLabels()[v1.AppLabel] == components.VirtHandlerName {
 		setMaxDevices(r.kv, daemonSet)
 	}
 
@@ -615,6 +615,51 @@ func (r *Reconciler) createOrUpdateKubeVirtCAConfigMap(queue workqueue.RateLimit
 	return []byte(configMap.Data[components.CABundleKey]), nil
 }
 
+// createOrUpdateConfigMaps creates or updates the ConfigMaps of the install strategy, which are
+// not managed along with the certificates, like the common templates
+func (r *Reconciler) createOrUpdateConfigMaps() error {
+	version, imageRegistry, id := getTargetVersionRegistryID(r.kv)
+
+	for _, configMap := range r.targetStrategy.ConfigMaps() {
+		if configMap.Name == components.KubeVirtCASecretName {
+			continue
+		}
+		configMap = configMap.DeepCopy()
+		injectOperatorMetadata(r.kv, &configMap.ObjectMeta, version, imageRegistry, id, true)
+
+		obj, exists, _ := r.stores.ConfigMapCache.Get(configMap)
+		if !exists {
+			r.expectations.ConfigMap.RaiseExpectations(r.kvKey, 1, 0)
+			_, err := r.clientset.CoreV1().ConfigMaps(configMap.Namespace).Create(context.Background(), configMap, metav1.CreateOptions{})
+			if err != nil {
+				r.expectations.ConfigMap.LowerExpectations(r.kvKey, 1, 0)
+				return fmt.Errorf("unable to create configMap %+v: %v", configMap, err)
+			}
+			continue
+		}
+
+		existing := obj.(*corev1.ConfigMap)
+		modified := resourcemerge.BoolPtr(false)
+		resourcemerge.EnsureObjectMeta(modified, &existing.DeepCopy().ObjectMeta, configMap.ObjectMeta)
+		if !*modified && reflect.DeepEqual(existing.Data, configMap.Data) {
+			log.Log.V(4).Infof("configMap %v is up-to-date", configMap.GetName())
+			continue
+		}
+
+		ops, err := createConfigMapPatch(configMap)
+		if err != nil {
+			return err
+		}
+		_, err = r.clientset.CoreV1().ConfigMaps(configMap.Namespace).Patch(context.Background(), configMap.Name, types.JSONPatchType, generatePatchBytes(ops), metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("unable to patch configMap %+v: %v", configMap, err)
+		}
+		log.Log.V(2).Infof("configMap %v updated", configMap.GetName())
+	}
+
+	return nil
+}
+
 func createConfigMapPatch(configMap *corev1.ConfigMap) ([]string, error) {
 	// Patch if old version
 	var ops []string
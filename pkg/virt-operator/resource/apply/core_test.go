@@ -36,6 +36,7 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/kubevirt/pkg/certificates/triple"
 	"kubevirt.io/kubevirt/pkg/certificates/triple/cert"
+	"kubevirt.io/kubevirt/pkg/controller"
 	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/components"
 	"kubevirt.io/kubevirt/pkg/virt-operator/util"
 
@@ -260,6 +261,66 @@ var _ = Describe("Apply", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(patched).To(BeTrue())
 		})
+
+		Context("common templates", func() {
+			var template *corev1.ConfigMap
+			var r *Reconciler
+
+			BeforeEach(func() {
+				templates, err := components.NewCommonTemplateConfigMaps(Namespace)
+				Expect(err).ToNot(HaveOccurred())
+				template = templates[0]
+				expectations.ConfigMap = controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectationsWithName("ConfigMap"))
+				config := getConfig("fake-registry", "v9.9.9")
+				r = &Reconciler{
+					kv:             kv,
+					targetStrategy: loadTargetStrategy(template, config, stores),
+					stores:         stores,
+					clientset:      clientset,
+					expectations:   expectations,
+				}
+			})
+
+			It("should create missing templates", func() {
+				created := false
+				coreclientset.Fake.PrependReactor("create", "configmaps", func(action testing.Action) (handled bool, ret runtime.Object, err error) {
+					configMap := action.(testing.CreateAction).GetObject().(*corev1.ConfigMap)
+					Expect(configMap.Name).To(Equal(template.Name))
+					Expect(configMap.Data).To(Equal(template.Data))
+					created = true
+					return true, configMap, nil
+				})
+
+				Expect(r.createOrUpdateConfigMaps()).To(Succeed())
+				Expect(created).To(BeTrue())
+			})
+
+			It("should not patch up-to-date templates", func() {
+				existing := template.DeepCopy()
+				version, imageRegistry, id := getTargetVersionRegistryID(kv)
+				injectOperatorMetadata(kv, &existing.ObjectMeta, version, imageRegistry, id, true)
+				stores.ConfigMapCache.Add(existing)
+
+				Expect(r.createOrUpdateConfigMaps()).To(Succeed())
+			})
+
+			It("should patch templates of an older version", func() {
+				existing := template.DeepCopy()
+				version, imageRegistry, id := getTargetVersionRegistryID(kv)
+				injectOperatorMetadata(kv, &existing.ObjectMeta, version, imageRegistry, id, true)
+				existing.Data[components.CommonTemplateKey] = "outdated"
+				stores.ConfigMapCache.Add(existing)
+
+				patched := false
+				coreclientset.Fake.PrependReactor("patch", "configmaps", func(action testing.Action) (handled bool, ret runtime.Object, err error) {
+					patched = true
+					return true, &corev1.ConfigMap{}, nil
+				})
+
+				Expect(r.createOrUpdateConfigMaps()).To(Succeed())
+				Expect(patched).To(BeTrue())
+			})
+		})
 	})
 
 	Context("should reconcile service account", func() {
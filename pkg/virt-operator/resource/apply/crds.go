@@ -1,18 +1,25 @@
 package apply
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/openshift/library-go/pkg/operator/resource/resourcemerge"
 
+	corev1 "k8s.io/api/core/v1"
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
+	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/components"
 )
 
 func getSubresourcesForVersion(crd *extv1.CustomResourceDefinition, version string) *extv1.CustomResourceSubresources {
@@ -44,12 +51,17 @@ func needsSubresourceStatusDisable(crdTargetVersion *extv1.CustomResourceDefinit
 		(crdTargetVersion.Subresources != nil && crdTargetVersion.Subresources.Status != nil)
 }
 
-func patchCRD(client clientset.Interface, crd *extv1.CustomResourceDefinition, ops []string) (*extv1.CustomResourceDefinition, error) {
+func patchCRD(client clientset.Interface, crd *extv1.CustomResourceDefinition, ops []string, validationRules bool) (*extv1.CustomResourceDefinition, error) {
 	name := crd.GetName()
 	newSpec, err := json.Marshal(crd.Spec)
 	if err != nil {
 		return nil, err
 	}
+	if validationRules {
+		if newSpec, err = components.AddValidationRules(newSpec); err != nil {
+			return nil, err
+		}
+	}
 
 	ops = append(ops, fmt.Sprintf(replaceSpecPatchTemplate, string(newSpec)))
 
@@ -80,6 +92,9 @@ func (r *Reconciler) createOrUpdateCrd(crd *extv1.CustomResourceDefinition) erro
 
 	crd = crd.DeepCopy()
 	injectOperatorMetadata(r.kv, &crd.ObjectMeta, version, imageRegistry, id, true)
+	validationRules := crdValidationRulesEnabled(r.kv)
+	// the validation rules are not part of the cached CRDs, the annotation tells if they have to be added or removed
+	crd.Annotations[components.ValidationRulesAnnotation] = strconv.FormatBool(validationRules)
 	obj, exists, _ := r.stores.CrdCache.Get(crd)
 	if !exists {
 		// Create non existent
@@ -89,6 +104,12 @@ func (r *Reconciler) createOrUpdateCrd(crd *extv1.CustomResourceDefinition) erro
 			r.expectations.Crd.LowerExpectations(r.kvKey, 1, 0)
 			return fmt.Errorf("unable to create crd %+v: %v", crd, err)
 		}
+		// the typed client can't send the validation rules, they are patched in
+		if validationRules {
+			if createdCRD, err = patchCRD(client, crd, []string{}, true); err != nil {
+				return err
+			}
+		}
 
 		SetGeneration(&r.kv.Status.Generations, createdCRD)
 		log.Log.V(2).Infof("crd %v created", crd.GetName())
@@ -112,6 +133,10 @@ func (r *Reconciler) createOrUpdateCrd(crd *extv1.CustomResourceDefinition) erro
 			crd.Spec.Versions[i].Subresources.Status = nil
 		}
 	}
+	// the conversion webhook is only switched on and off after the control plane rolled over
+	if usesConversionWebhook(cachedCrd) && crdConversionWebhookEnabled(r.kv) {
+		crd.Spec.Conversion = cachedCrd.Spec.Conversion.DeepCopy()
+	}
 	// Add Labels and Annotations Patches
 	var ops []string
 	labelAnnotationPatch, err := createLabelsAndAnnotationsPatch(&crd.ObjectMeta)
@@ -119,7 +144,7 @@ func (r *Reconciler) createOrUpdateCrd(crd *extv1.CustomResourceDefinition) erro
 		return err
 	}
 	ops = append(ops, labelAnnotationPatch...)
-	if crd, err = patchCRD(client, crd, ops); err != nil {
+	if crd, err = patchCRD(client, crd, ops, validationRules); err != nil {
 		return err
 	}
 
@@ -158,7 +183,7 @@ func (r *Reconciler) rolloutNonCompatibleCRDChange(crd *extv1.CustomResourceDefi
 			return nil
 		}
 		// enable the status subresources now, in case that they were disabled before
-		if _, err := patchCRD(client, crd, []string{}); err != nil {
+		if _, err := patchCRD(client, crd, []string{}, crdValidationRulesEnabled(r.kv)); err != nil {
 			return err
 		}
 
@@ -168,3 +193,114 @@ func (r *Reconciler) rolloutNonCompatibleCRDChange(crd *extv1.CustomResourceDefi
 	log.Log.V(4).Infof("crd %v is up-to-date", crd.GetName())
 	return nil
 }
+
+func crdConversionWebhookEnabled(kv *v1.KubeVirt) bool {
+	if devConfig := kv.Spec.Configuration.DeveloperConfiguration; devConfig != nil {
+		for _, featureGate := range devConfig.FeatureGates {
+			if featureGate == virtconfig.CRDConversionWebhookGate {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func crdValidationRulesEnabled(kv *v1.KubeVirt) bool {
+	if devConfig := kv.Spec.Configuration.DeveloperConfiguration; devConfig != nil {
+		for _, featureGate := range devConfig.FeatureGates {
+			if featureGate == virtconfig.CRDValidationRulesGate {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func usesConversionWebhook(crd *extv1.CustomResourceDefinition) bool {
+	return crd.Spec.Conversion != nil && crd.Spec.Conversion.Strategy == extv1.WebhookConverter
+}
+
+// conversionMatches compares the conversion of a CRD with the desired one, ignoring the fields
+// which the API server defaults
+func conversionMatches(current, desired *extv1.CustomResourceConversion) bool {
+	if current == nil || current.Strategy != desired.Strategy {
+		return current == nil && desired.Strategy == extv1.NoneConverter
+	}
+	if desired.Strategy != extv1.WebhookConverter {
+		return true
+	}
+	if current.Webhook == nil || current.Webhook.ClientConfig == nil || current.Webhook.ClientConfig.Service == nil {
+		return false
+	}
+	currentConfig, desiredConfig := current.Webhook.ClientConfig, desired.Webhook.ClientConfig
+	return currentConfig.Service.Namespace == desiredConfig.Service.Namespace &&
+		currentConfig.Service.Name == desiredConfig.Service.Name &&
+		equality.Semantic.DeepEqual(currentConfig.Service.Path, desiredConfig.Service.Path) &&
+		bytes.Equal(currentConfig.CABundle, desiredConfig.CABundle)
+}
+
+// syncCRDConversionWebhooks switches the CRDs with several versions to the conversion webhook of
+// virt-api if the CRDConversionWebhook feature gate is enabled, and back to no conversion otherwise.
+// This must only happen after the control plane rolled over, because the API server can't serve
+// the objects in a version other than the stored one, if the webhook is not reachable.
+func (r *Reconciler) syncCRDConversionWebhooks() error {
+	caBundle, err := r.getKubeVirtCABundle()
+	if err != nil {
+		return err
+	}
+	for _, crd := range r.targetStrategy.CRDs() {
+		if err := r.syncCRDConversionWebhook(crd, caBundle); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) syncCRDConversionWebhook(crd *extv1.CustomResourceDefinition, caBundle []byte) error {
+	if len(crd.Spec.Versions) < 2 {
+		return nil
+	}
+	obj, exists, err := r.stores.CrdCache.Get(crd)
+	if err != nil || !exists {
+		return err
+	}
+	cachedCrd := obj.(*extv1.CustomResourceDefinition)
+
+	desired := &extv1.CustomResourceConversion{Strategy: extv1.NoneConverter}
+	if crdConversionWebhookEnabled(r.kv) {
+		desired = components.NewCRDConversionWebhook(r.kv.Namespace, caBundle)
+	}
+	if conversionMatches(cachedCrd.Spec.Conversion, desired) {
+		log.Log.V(4).Infof("conversion of crd %v is up-to-date", crd.GetName())
+		return nil
+	}
+
+	// only the conversion is replaced, the cached CRD does not hold the validation rules
+	conversion, err := json.Marshal(desired)
+	if err != nil {
+		return err
+	}
+	ops := []string{fmt.Sprintf(`{ "op": "replace", "path": "/spec/conversion", "value": %s }`, string(conversion))}
+	crd, err = r.clientset.ExtensionsClient().ApiextensionsV1().CustomResourceDefinitions().Patch(context.Background(), crd.Name, types.JSONPatchType, generatePatchBytes(ops), metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to patch the conversion of crd %s: %v", cachedCrd.Name, err)
+	}
+	SetGeneration(&r.kv.Status.Generations, crd)
+	log.Log.V(2).Infof("conversion of crd %v set to %s", crd.GetName(), desired.Strategy)
+	return nil
+}
+
+// getKubeVirtCABundle returns the CA bundle of the KubeVirt CA ConfigMap
+func (r *Reconciler) getKubeVirtCABundle() ([]byte, error) {
+	configMap := findRequiredCAConfigMap(r.targetStrategy.ConfigMaps())
+	if configMap == nil {
+		return nil, fmt.Errorf("the install strategy has no KubeVirt CA ConfigMap")
+	}
+	obj, exists, err := r.stores.ConfigMapCache.Get(configMap)
+	if err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, fmt.Errorf("KubeVirt CA ConfigMap %s/%s does not exist yet", configMap.Namespace, configMap.Name)
+	}
+	return []byte(obj.(*corev1.ConfigMap).Data[components.CABundleKey]), nil
+}
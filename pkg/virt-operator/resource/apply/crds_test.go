@@ -18,6 +18,9 @@ import (
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/controller"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/components"
 	"kubevirt.io/kubevirt/pkg/virt-operator/util"
 )
 
@@ -167,4 +170,202 @@ var _ = Describe("Apply CRDs", func() {
 
 		Expect(r.rolloutNonCompatibleCRDChanges()).To(Succeed())
 	})
+
+	Context("conversion webhook", func() {
+		caBundle := []byte("ca-bundle")
+
+		newMultiVersionCRD := func() *extv1.CustomResourceDefinition {
+			return &extv1.CustomResourceDefinition{
+				TypeMeta: v12.TypeMeta{
+					APIVersion: extv1.SchemeGroupVersion.String(),
+					Kind:       "CustomResourceDefinition",
+				},
+				ObjectMeta: v12.ObjectMeta{
+					Name: "test",
+				},
+				Spec: extv1.CustomResourceDefinitionSpec{
+					Versions: []extv1.CustomResourceDefinitionVersion{
+						{Name: "v1", Served: true},
+						{Name: "v1alpha3", Served: true, Storage: true},
+					},
+				},
+			}
+		}
+
+		enableFeatureGate := func() {
+			kv.Namespace = Namespace
+			kv.Spec.Configuration.DeveloperConfiguration = &v1.DeveloperConfiguration{
+				FeatureGates: []string{virtconfig.CRDConversionWebhookGate},
+			}
+		}
+
+		expectConversionPatch := func(cachedCrd *extv1.CustomResourceDefinition, expectedStrategy extv1.ConversionStrategyType) *bool {
+			patched := false
+			extClient.Fake.PrependReactor("patch", "customresourcedefinitions", func(action testing.Action) (handled bool, ret runtime.Object, err error) {
+				a := action.(testing.PatchActionImpl)
+				patch, err := jsonpatch.DecodePatch(a.Patch)
+				Expect(err).ToNot(HaveOccurred())
+				obj, err := json.Marshal(cachedCrd)
+				Expect(err).ToNot(HaveOccurred())
+				obj, err = patch.Apply(obj)
+				Expect(err).ToNot(HaveOccurred())
+				crd := &extv1.CustomResourceDefinition{}
+				Expect(json.Unmarshal(obj, crd)).To(Succeed())
+				Expect(crd.Spec.Conversion.Strategy).To(Equal(expectedStrategy))
+				if expectedStrategy == extv1.WebhookConverter {
+					Expect(crd.Spec.Conversion.Webhook.ClientConfig.CABundle).To(Equal(caBundle))
+					Expect(crd.Spec.Conversion.Webhook.ClientConfig.Service.Name).To(Equal(components.VirtApiServiceName))
+					Expect(crd.Spec.Conversion.Webhook.ClientConfig.Service.Namespace).To(Equal(Namespace))
+					Expect(*crd.Spec.Conversion.Webhook.ClientConfig.Service.Path).To(Equal(components.CRDConversionPath))
+				}
+				patched = true
+				return true, crd, nil
+			})
+			return &patched
+		}
+
+		newReconciler := func(crd *extv1.CustomResourceDefinition) *Reconciler {
+			return &Reconciler{
+				kv:             kv,
+				targetStrategy: loadTargetStrategy(crd, config, stores),
+				stores:         stores,
+				clientset:      clientset,
+				expectations:   expectations,
+			}
+		}
+
+		It("should switch CRDs with several versions to the conversion webhook if the feature gate is enabled", func() {
+			enableFeatureGate()
+			crd := newMultiVersionCRD()
+			cachedCrd := crd.DeepCopy()
+			cachedCrd.Spec.Conversion = &extv1.CustomResourceConversion{Strategy: extv1.NoneConverter}
+			stores.CrdCache.Add(cachedCrd)
+			patched := expectConversionPatch(cachedCrd, extv1.WebhookConverter)
+
+			Expect(newReconciler(crd).syncCRDConversionWebhook(crd, caBundle)).To(Succeed())
+			Expect(*patched).To(BeTrue())
+		})
+
+		It("should not patch CRDs whose conversion webhook is up-to-date", func() {
+			enableFeatureGate()
+			crd := newMultiVersionCRD()
+			cachedCrd := crd.DeepCopy()
+			cachedCrd.Spec.Conversion = components.NewCRDConversionWebhook(Namespace, caBundle)
+			port := int32(443)
+			cachedCrd.Spec.Conversion.Webhook.ClientConfig.Service.Port = &port
+			stores.CrdCache.Add(cachedCrd)
+
+			Expect(newReconciler(crd).syncCRDConversionWebhook(crd, caBundle)).To(Succeed())
+		})
+
+		It("should not touch CRDs with a single version", func() {
+			enableFeatureGate()
+			crd := newMultiVersionCRD()
+			crd.Spec.Versions = crd.Spec.Versions[1:]
+			stores.CrdCache.Add(crd.DeepCopy())
+
+			Expect(newReconciler(crd).syncCRDConversionWebhook(crd, caBundle)).To(Succeed())
+		})
+
+		It("should switch back to no conversion if the feature gate is disabled", func() {
+			crd := newMultiVersionCRD()
+			cachedCrd := crd.DeepCopy()
+			cachedCrd.Spec.Conversion = components.NewCRDConversionWebhook(Namespace, caBundle)
+			stores.CrdCache.Add(cachedCrd)
+			patched := expectConversionPatch(cachedCrd, extv1.NoneConverter)
+
+			Expect(newReconciler(crd).syncCRDConversionWebhook(crd, caBundle)).To(Succeed())
+			Expect(*patched).To(BeTrue())
+		})
+
+		It("should keep the conversion webhook when CRDs are updated", func() {
+			enableFeatureGate()
+			crd := newMultiVersionCRD()
+			cachedCrd := crd.DeepCopy()
+			cachedCrd.Spec.Conversion = components.NewCRDConversionWebhook(Namespace, caBundle)
+			stores.CrdCache.Add(cachedCrd)
+			patched := expectConversionPatch(cachedCrd, extv1.WebhookConverter)
+
+			Expect(newReconciler(crd).createOrUpdateCrds()).To(Succeed())
+			Expect(*patched).To(BeTrue())
+		})
+	})
+
+	Context("validation rules", func() {
+
+		newVMICRD := func() *extv1.CustomResourceDefinition {
+			crd, err := components.NewVirtualMachineInstanceCrd()
+			Expect(err).ToNot(HaveOccurred())
+			return crd
+		}
+
+		enableFeatureGate := func() {
+			kv.Spec.Configuration.DeveloperConfiguration = &v1.DeveloperConfiguration{
+				FeatureGates: []string{virtconfig.CRDValidationRulesGate},
+			}
+		}
+
+		// expectSpecPatch returns the spec of the VMIs in the schema of the first version, as it is patched
+		expectSpecPatch := func(expectedAnnotation string) *map[string]interface{} {
+			vmiSpec := map[string]interface{}{}
+			extClient.Fake.PrependReactor("patch", "customresourcedefinitions", func(action testing.Action) (handled bool, ret runtime.Object, err error) {
+				a := action.(testing.PatchActionImpl)
+				ops := []map[string]interface{}{}
+				Expect(json.Unmarshal(a.Patch, &ops)).To(Succeed())
+				for _, op := range ops {
+					if op["path"] == "/metadata/annotations" {
+						annotations := op["value"].(map[string]interface{})
+						Expect(annotations[components.ValidationRulesAnnotation]).To(Equal(expectedAnnotation))
+					}
+					if op["path"] == "/spec" {
+						versions := op["value"].(map[string]interface{})["versions"].([]interface{})
+						schema := versions[0].(map[string]interface{})["schema"].(map[string]interface{})["openAPIV3Schema"].(map[string]interface{})
+						vmiSpec = schema["properties"].(map[string]interface{})["spec"].(map[string]interface{})
+					}
+				}
+				return true, newVMICRD(), nil
+			})
+			return &vmiSpec
+		}
+
+		newReconciler := func(crd *extv1.CustomResourceDefinition) *Reconciler {
+			return &Reconciler{
+				kv:             kv,
+				targetStrategy: loadTargetStrategy(crd, config, stores),
+				stores:         stores,
+				clientset:      clientset,
+				expectations:   expectations,
+			}
+		}
+
+		It("should patch the validation rules into created CRDs if the feature gate is enabled", func() {
+			enableFeatureGate()
+			expectations.Crd = controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectationsWithName("Crd"))
+			crd := newVMICRD()
+			created := false
+			extClient.Fake.PrependReactor("create", "customresourcedefinitions", func(action testing.Action) (handled bool, ret runtime.Object, err error) {
+				created = true
+				createdCRD := action.(testing.CreateAction).GetObject().(*extv1.CustomResourceDefinition)
+				Expect(createdCRD.Annotations[components.ValidationRulesAnnotation]).To(Equal("true"))
+				return true, createdCRD, nil
+			})
+			vmiSpec := expectSpecPatch("true")
+
+			Expect(newReconciler(crd).createOrUpdateCrds()).To(Succeed())
+			Expect(created).To(BeTrue())
+			Expect((*vmiSpec)["x-kubernetes-validations"]).To(HaveLen(len(components.VMISpecValidationRules)))
+		})
+
+		It("should remove the validation rules if the feature gate is disabled", func() {
+			crd := newVMICRD()
+			cachedCrd := crd.DeepCopy()
+			cachedCrd.Annotations = map[string]string{components.ValidationRulesAnnotation: "true"}
+			stores.CrdCache.Add(cachedCrd)
+			vmiSpec := expectSpecPatch("false")
+
+			Expect(newReconciler(crd).createOrUpdateCrds()).To(Succeed())
+			Expect(*vmiSpec).ToNot(BeEmpty())
+			Expect(*vmiSpec).ToNot(HaveKey("x-kubernetes-validations"))
+		})
+	})
 })
@@ -562,6 +562,11 @@ func (r *Reconciler) Sync(queue workqueue.RateLimitingInterface) (bool, error) {
 		return false, err
 	}
 
+	err = r.createOrUpdateConfigMaps()
+	if err != nil {
+		return false, err
+	}
+
 	if infrastructureRolledOver {
 		err = r.removeKvServiceAccountsFromDefaultSCC(r.kv.Namespace)
 		if err != nil {
@@ -598,6 +603,12 @@ func (r *Reconciler) Sync(queue workqueue.RateLimitingInterface) (bool, error) {
 		return false, err
 	}
 
+	// the conversion webhook of virt-api is only available once it rolled over
+	err = r.syncCRDConversionWebhooks()
+	if err != nil {
+		return false, err
+	}
+
 	// -------- CLEAN UP OLD UNUSED OBJECTS --------
 	// outdated webhooks can potentially block deletes of other objects during the cleanup and need to be removed first
 	err = r.deleteObjectsNotInInstallStrategy()
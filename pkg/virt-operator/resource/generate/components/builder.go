@@ -0,0 +1,293 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package components
+
+import (
+	"runtime"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+)
+
+const (
+	defaultImageRegistry = "quay.io/kubevirt"
+	defaultVerbosity     = "2"
+	defaultServingPort   = 8443
+)
+
+// Option customizes the manifests generated by the Build* functions of this package.
+//
+// The Build* functions and the With* options are the supported API for operators which embed
+// KubeVirt and generate its manifests themselves. They follow semantic versioning: within a major
+// version, existing functions and options keep their signature and behaviour, new customizations
+// are added as new options.
+type Option func(*builderOptions)
+
+type certificateMount struct {
+	secretName string
+	mountPath  string
+}
+
+type imageOverride struct {
+	repository  string
+	imagePrefix string
+}
+
+type builderOptions struct {
+	repository      string
+	imagePrefix     string
+	version         string
+	launcherVersion string
+	productName     string
+	productVersion  string
+	pullPolicy      corev1.PullPolicy
+	verbosity       string
+	extraEnv        map[string]string
+	replicas        *int32
+	placement       *virtv1.NodePlacement
+	servingPort     int32
+	certificates    []certificateMount
+	arch            string
+	targetArch      bool
+	archImages      map[string]imageOverride
+}
+
+func newBuilderOptions(opts []Option) *builderOptions {
+	options := &builderOptions{
+		repository:  defaultImageRegistry,
+		version:     "latest",
+		pullPolicy:  corev1.PullIfNotPresent,
+		verbosity:   defaultVerbosity,
+		servingPort: defaultServingPort,
+		arch:        runtime.GOARCH,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if image, exists := options.archImages[options.arch]; exists {
+		options.repository = image.repository
+		options.imagePrefix = image.imagePrefix
+	}
+	if options.launcherVersion == "" {
+		options.launcherVersion = options.version
+	}
+	return options
+}
+
+// WithImage sets the registry and the image name prefix of the KubeVirt images.
+// The registry defaults to quay.io/kubevirt and the prefix to none.
+func WithImage(repository string, imagePrefix string) Option {
+	return func(options *builderOptions) {
+		options.repository = repository
+		options.imagePrefix = imagePrefix
+	}
+}
+
+// WithVersion sets the tag or the digest of the component images. Defaults to latest.
+func WithVersion(version string) Option {
+	return func(options *builderOptions) {
+		options.version = version
+	}
+}
+
+// WithLauncherVersion sets the tag or the digest of the virt-launcher image, if it differs from the one given by WithVersion.
+func WithLauncherVersion(version string) Option {
+	return func(options *builderOptions) {
+		options.launcherVersion = version
+	}
+}
+
+// WithProduct labels the generated objects with the name and the version of the product embedding KubeVirt.
+func WithProduct(name string, version string) Option {
+	return func(options *builderOptions) {
+		options.productName = name
+		options.productVersion = version
+	}
+}
+
+// WithImagePullPolicy sets the pull policy of the component images. Defaults to IfNotPresent.
+func WithImagePullPolicy(pullPolicy corev1.PullPolicy) Option {
+	return func(options *builderOptions) {
+		options.pullPolicy = pullPolicy
+	}
+}
+
+// WithVerbosity sets the log verbosity of the components. Defaults to 2.
+func WithVerbosity(verbosity string) Option {
+	return func(options *builderOptions) {
+		options.verbosity = verbosity
+	}
+}
+
+// WithExtraEnv adds environment variables to the component containers.
+func WithExtraEnv(env map[string]string) Option {
+	return func(options *builderOptions) {
+		options.extraEnv = env
+	}
+}
+
+// WithReplicas sets the replicas of deployments. It has no effect on daemonsets.
+func WithReplicas(replicas int32) Option {
+	return func(options *builderOptions) {
+		options.replicas = &replicas
+	}
+}
+
+// WithNodePlacement adds the node selectors and the tolerations of the placement to the component pods.
+// The affinity of the placement replaces the default affinity of the components, if set.
+func WithNodePlacement(placement *virtv1.NodePlacement) Option {
+	return func(options *builderOptions) {
+		options.placement = placement
+	}
+}
+
+// WithServingPort sets the port the components serve their API, metrics and health endpoints on. Defaults to 8443.
+func WithServingPort(port int32) Option {
+	return func(options *builderOptions) {
+		options.servingPort = port
+	}
+}
+
+// WithCertificateSecret mounts an additional certificate secret read-only into the component containers.
+func WithCertificateSecret(secretName string, mountPath string) Option {
+	return func(options *builderOptions) {
+		options.certificates = append(options.certificates, certificateMount{secretName: secretName, mountPath: mountPath})
+	}
+}
+
+// WithArchitecture generates the components for the nodes of the given architecture, like "amd64" or "s390x",
+// and schedules them to those nodes only. Defaults to the architecture of the caller, without a node selector.
+func WithArchitecture(arch string) Option {
+	return func(options *builderOptions) {
+		options.arch = arch
+		options.targetArch = true
+	}
+}
+
+// WithArchitectureImage sets the registry and the image name prefix of the KubeVirt images for the given
+// architecture. It replaces WithImage when the components are generated for that architecture.
+func WithArchitectureImage(arch string, repository string, imagePrefix string) Option {
+	return func(options *builderOptions) {
+		if options.archImages == nil {
+			options.archImages = make(map[string]imageOverride)
+		}
+		options.archImages[arch] = imageOverride{repository: repository, imagePrefix: imagePrefix}
+	}
+}
+
+// BuildApiServerDeployment returns the virt-api deployment customized by the given options.
+func BuildApiServerDeployment(namespace string, opts ...Option) (*appsv1.Deployment, error) {
+	o := newBuilderOptions(opts)
+	deployment, err := NewApiServerDeployment(namespace, o.repository, o.imagePrefix, o.version, o.productName, o.productVersion, o.pullPolicy, o.verbosity, o.extraEnv)
+	if err != nil {
+		return nil, err
+	}
+	o.applyToDeployment(deployment)
+	return deployment, nil
+}
+
+// BuildControllerDeployment returns the virt-controller deployment customized by the given options.
+func BuildControllerDeployment(namespace string, opts ...Option) (*appsv1.Deployment, error) {
+	o := newBuilderOptions(opts)
+	deployment, err := NewControllerDeployment(namespace, o.repository, o.imagePrefix, o.version, o.launcherVersion, o.productName, o.productVersion, o.pullPolicy, o.verbosity, o.extraEnv)
+	if err != nil {
+		return nil, err
+	}
+	o.applyToDeployment(deployment)
+	return deployment, nil
+}
+
+// BuildHandlerDaemonSet returns the virt-handler daemonset customized by the given options.
+func BuildHandlerDaemonSet(namespace string, opts ...Option) (*appsv1.DaemonSet, error) {
+	o := newBuilderOptions(opts)
+	daemonset, err := newHandlerDaemonSet(namespace, o.repository, o.imagePrefix, o.version, o.launcherVersion, o.productName, o.productVersion, o.pullPolicy, o.verbosity, o.extraEnv, o.arch)
+	if err != nil {
+		return nil, err
+	}
+	o.applyToPodSpec(&daemonset.Spec.Template.Spec)
+	return daemonset, nil
+}
+
+// BuildApiServerService returns the virt-api service, targeting the port given by WithServingPort.
+func BuildApiServerService(namespace string, opts ...Option) *corev1.Service {
+	o := newBuilderOptions(opts)
+	service := NewApiServerService(namespace)
+	service.Spec.Ports[0].TargetPort.IntVal = o.servingPort
+	return service
+}
+
+func (o *builderOptions) applyToDeployment(deployment *appsv1.Deployment) {
+	if o.replicas != nil {
+		deployment.Spec.Replicas = int32Ptr(*o.replicas)
+	}
+	o.applyToPodSpec(&deployment.Spec.Template.Spec)
+}
+
+func (o *builderOptions) applyToPodSpec(spec *corev1.PodSpec) {
+	if o.targetArch {
+		if spec.NodeSelector == nil {
+			spec.NodeSelector = make(map[string]string)
+		}
+		spec.NodeSelector[corev1.LabelArchStable] = o.arch
+	}
+
+	if o.placement != nil {
+		if len(o.placement.NodeSelector) > 0 && spec.NodeSelector == nil {
+			spec.NodeSelector = make(map[string]string)
+		}
+		for key, value := range o.placement.NodeSelector {
+			spec.NodeSelector[key] = value
+		}
+		spec.Tolerations = append(spec.Tolerations, o.placement.Tolerations...)
+		if o.placement.Affinity != nil {
+			spec.Affinity = o.placement.Affinity.DeepCopy()
+		}
+	}
+
+	for _, certificate := range o.certificates {
+		attachCertificateSecret(spec, certificate.secretName, certificate.mountPath)
+	}
+
+	if o.servingPort != defaultServingPort {
+		setServingPort(&spec.Containers[0], o.servingPort)
+	}
+}
+
+// setServingPort moves everything the container serves on the default port to the given port
+func setServingPort(container *corev1.Container, port int32) {
+	for i, arg := range container.Command {
+		if arg == "--port" && i+1 < len(container.Command) {
+			container.Command[i+1] = strconv.Itoa(int(port))
+		}
+	}
+	for i := range container.Ports {
+		if container.Ports[i].ContainerPort == defaultServingPort {
+			container.Ports[i].ContainerPort = port
+		}
+	}
+	for _, probe := range []*corev1.Probe{container.LivenessProbe, container.ReadinessProbe} {
+		if probe != nil && probe.HTTPGet != nil && probe.HTTPGet.Port.IntValue() == defaultServingPort {
+			probe.HTTPGet.Port.IntVal = port
+		}
+	}
+}
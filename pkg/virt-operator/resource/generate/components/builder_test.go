@@ -0,0 +1,98 @@
+package components
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	v12 "k8s.io/api/core/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Builder", func() {
+
+	It("should generate the same virt-api deployment as NewApiServerDeployment by default", func() {
+		expected, err := NewApiServerDeployment("kubevirt", defaultImageRegistry, "", "latest", "", "", v12.PullIfNotPresent, defaultVerbosity, nil)
+		Expect(err).ToNot(HaveOccurred())
+		deployment, err := BuildApiServerDeployment("kubevirt")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(deployment).To(Equal(expected))
+	})
+
+	It("should set the images, the replicas and the product labels", func() {
+		deployment, err := BuildControllerDeployment("kubevirt",
+			WithImage("registry.example.org/kv", "kv-"),
+			WithVersion("v1.0.0"),
+			WithProduct("product", "1.2.3"),
+			WithReplicas(3),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*deployment.Spec.Replicas).To(Equal(int32(3)))
+		Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(Equal("registry.example.org/kv/kv-virt-controller:v1.0.0"))
+		Expect(deployment.Labels).To(HaveKeyWithValue(v1.AppVersionLabel, "1.2.3"))
+	})
+
+	It("should apply the node placement to the pods", func() {
+		affinity := &v12.Affinity{NodeAffinity: &v12.NodeAffinity{}}
+		toleration := v12.Toleration{Key: "example.org/dedicated", Operator: v12.TolerationOpExists}
+		daemonset, err := BuildHandlerDaemonSet("kubevirt", WithNodePlacement(&v1.NodePlacement{
+			NodeSelector: map[string]string{"example.org/virt": "true"},
+			Tolerations:  []v12.Toleration{toleration},
+			Affinity:     affinity,
+		}))
+		Expect(err).ToNot(HaveOccurred())
+		spec := daemonset.Spec.Template.Spec
+		Expect(spec.NodeSelector).To(HaveKeyWithValue("example.org/virt", "true"))
+		Expect(spec.Tolerations).To(ContainElement(toleration))
+		Expect(spec.Affinity).To(Equal(affinity))
+	})
+
+	It("should move the serving port of virt-api", func() {
+		deployment, err := BuildApiServerDeployment("kubevirt", WithServingPort(9443))
+		Expect(err).ToNot(HaveOccurred())
+		container := deployment.Spec.Template.Spec.Containers[0]
+		Expect(container.Command).To(ContainElements("--port", "9443"))
+		Expect(container.Command).ToNot(ContainElement("8443"))
+		for _, port := range container.Ports {
+			Expect(port.ContainerPort).To(Equal(int32(9443)))
+		}
+		Expect(container.ReadinessProbe.HTTPGet.Port.IntValue()).To(Equal(9443))
+
+		service := BuildApiServerService("kubevirt", WithServingPort(9443))
+		Expect(service.Spec.Ports[0].TargetPort.IntValue()).To(Equal(9443))
+	})
+
+	It("should generate the components for the given architecture", func() {
+		daemonset, err := BuildHandlerDaemonSet("kubevirt",
+			WithImage("registry.example.org/kv", ""),
+			WithArchitectureImage("s390x", "registry.example.org/kv-s390x", ""),
+			WithArchitecture("s390x"),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(daemonset.Name).To(Equal("virt-handler-s390x"))
+		spec := daemonset.Spec.Template.Spec
+		Expect(spec.NodeSelector).To(HaveKeyWithValue(v12.LabelArchStable, "s390x"))
+		Expect(spec.Containers[0].Image).To(Equal("registry.example.org/kv-s390x/virt-handler:latest"))
+		// the node-labeller only supports amd64
+		Expect(spec.InitContainers).To(BeEmpty())
+
+		daemonset, err = BuildHandlerDaemonSet("kubevirt",
+			WithImage("registry.example.org/kv", ""),
+			WithArchitectureImage("s390x", "registry.example.org/kv-s390x", ""),
+			WithArchitecture("amd64"),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(daemonset.Name).To(Equal(VirtHandlerName))
+		spec = daemonset.Spec.Template.Spec
+		Expect(spec.NodeSelector).To(HaveKeyWithValue(v12.LabelArchStable, "amd64"))
+		Expect(spec.Containers[0].Image).To(Equal("registry.example.org/kv/virt-handler:latest"))
+		Expect(spec.InitContainers).To(HaveLen(1))
+	})
+
+	It("should mount additional certificate secrets", func() {
+		deployment, err := BuildApiServerDeployment("kubevirt", WithCertificateSecret("custom-ca", "/etc/custom-ca"))
+		Expect(err).ToNot(HaveOccurred())
+		spec := deployment.Spec.Template.Spec
+		Expect(spec.Volumes[len(spec.Volumes)-1].Secret.SecretName).To(Equal("custom-ca"))
+		Expect(spec.Containers[0].VolumeMounts).To(ContainElement(v12.VolumeMount{Name: "custom-ca", ReadOnly: true, MountPath: "/etc/custom-ca"}))
+	})
+})
@@ -29,12 +29,17 @@ import (
 	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
 
 	virtv1 "kubevirt.io/client-go/api/v1"
+	poolv1 "kubevirt.io/client-go/apis/pool/v1alpha1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 )
 
 const (
 	creationTimestampJSONPath = ".metadata.creationTimestamp"
 	errorMessageJSONPath      = ".status.error.message"
+
+	// VMISpecMaxListItems is the number of disks, interfaces, volumes and networks a VirtualMachineInstance
+	// spec may have at most, the limit the VirtualMachineInstance webhook enforces as well
+	VMISpecMaxListItems = 256
 )
 
 var (
@@ -46,6 +51,7 @@ var (
 	KUBEVIRT                         = "kubevirts." + virtv1.KubeVirtGroupVersionKind.Group
 	VIRTUALMACHINESNAPSHOT           = "virtualmachinesnapshots." + snapshotv1.SchemeGroupVersion.Group
 	VIRTUALMACHINESNAPSHOTCONTENT    = "virtualmachinesnapshotcontents." + snapshotv1.SchemeGroupVersion.Group
+	VIRTUALMACHINEPOOL               = "virtualmachinepools." + poolv1.SchemeGroupVersion.Group
 	PreserveUnknownFieldsFalse       = false
 )
 
@@ -96,12 +102,73 @@ func patchValidation(crd *extv1.CustomResourceDefinition, version *extv1.CustomR
 	if err != nil {
 		return fmt.Errorf("Could not decode validation for %s, %v", name, err)
 	}
+	if crvalidation.OpenAPIV3Schema != nil {
+		addStructuralConstraints(crvalidation.OpenAPIV3Schema)
+	}
 	if err = addFieldsToVersion(version, &crvalidation); err != nil {
 		return err
 	}
 	return nil
 }
 
+// addStructuralConstraints adds the constraints, which the API server can enforce on its own, to every
+// VirtualMachineInstance spec in the schema, so that they are rejected even if the webhooks of virt-api
+// are not reachable: boot orders must be greater than zero, and there may be no more disks, interfaces,
+// volumes and networks than the webhooks allow. The latter also bounds the cost of the validation rules.
+// The device lists are not turned into list maps keyed by their name, since that would change how
+// server-side apply merges them. Unique names are only enforced by the webhooks.
+func addStructuralConstraints(schema *extv1.JSONSchemaProps) {
+	for name, property := range schema.Properties {
+		addStructuralConstraints(&property)
+		schema.Properties[name] = property
+	}
+	if schema.Items != nil && schema.Items.Schema != nil {
+		addStructuralConstraints(schema.Items.Schema)
+	}
+
+	domain, isVMISpec := schema.Properties["domain"]
+	if !isVMISpec {
+		return
+	}
+	devices, hasDevices := domain.Properties["devices"]
+	if !hasDevices {
+		return
+	}
+	for _, list := range []string{"disks", "interfaces"} {
+		addBootOrderMinimum(&devices, list)
+		addMaxItems(&devices, list)
+	}
+	domain.Properties["devices"] = devices
+	schema.Properties["domain"] = domain
+	for _, list := range []string{"volumes", "networks"} {
+		addMaxItems(schema, list)
+	}
+}
+
+func addBootOrderMinimum(devices *extv1.JSONSchemaProps, list string) {
+	property, exists := devices.Properties[list]
+	if !exists || property.Items == nil || property.Items.Schema == nil {
+		return
+	}
+	items := property.Items.Schema
+	if bootOrder, exists := items.Properties["bootOrder"]; exists {
+		minimum := float64(1)
+		bootOrder.Minimum = &minimum
+		items.Properties["bootOrder"] = bootOrder
+	}
+	devices.Properties[list] = property
+}
+
+func addMaxItems(schema *extv1.JSONSchemaProps, list string) {
+	property, exists := schema.Properties[list]
+	if !exists {
+		return
+	}
+	maxItems := int64(VMISpecMaxListItems)
+	property.MaxItems = &maxItems
+	schema.Properties[list] = property
+}
+
 func patchValidationForAllVersions(crd *extv1.CustomResourceDefinition) error {
 	for i := range crd.Spec.Versions {
 		if err := patchValidation(crd, &crd.Spec.Versions[i]); err != nil {
@@ -111,6 +178,26 @@ func patchValidationForAllVersions(crd *extv1.CustomResourceDefinition) error {
 	return nil
 }
 
+// NewCRDConversionWebhook returns a conversion strategy which lets the conversion webhook of virt-api
+// convert the objects of a CRD between its versions
+func NewCRDConversionWebhook(namespace string, caBundle []byte) *extv1.CustomResourceConversion {
+	path := CRDConversionPath
+	return &extv1.CustomResourceConversion{
+		Strategy: extv1.WebhookConverter,
+		Webhook: &extv1.WebhookConversion{
+			ClientConfig: &extv1.WebhookClientConfig{
+				Service: &extv1.ServiceReference{
+					Namespace: namespace,
+					Name:      VirtApiServiceName,
+					Path:      &path,
+				},
+				CABundle: caBundle,
+			},
+			ConversionReviewVersions: []string{"v1"},
+		},
+	}
+}
+
 func newBlankCrd() *extv1.CustomResourceDefinition {
 	return &extv1.CustomResourceDefinition{
 		TypeMeta: metav1.TypeMeta{
@@ -480,6 +567,58 @@ func NewVirtualMachineRestoreCrd() (*extv1.CustomResourceDefinition, error) {
 	return crd, nil
 }
 
+func NewVirtualMachinePoolCrd() (*extv1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+	labelSelector := ".status.labelSelector"
+
+	crd.ObjectMeta.Name = VIRTUALMACHINEPOOL
+	crd.Spec = extv1.CustomResourceDefinitionSpec{
+		Group: poolv1.SchemeGroupVersion.Group,
+		Versions: []extv1.CustomResourceDefinitionVersion{
+			{
+				Name:    poolv1.SchemeGroupVersion.Version,
+				Served:  true,
+				Storage: true,
+			},
+		},
+		Scope: "Namespaced",
+		Names: extv1.CustomResourceDefinitionNames{
+			Plural:     "virtualmachinepools",
+			Singular:   "virtualmachinepool",
+			Kind:       "VirtualMachinePool",
+			ShortNames: []string{"vmpool", "vmpools"},
+			Categories: []string{
+				"all",
+			},
+		},
+	}
+	err := addFieldsToAllVersions(crd,
+		[]extv1.CustomResourceColumnDefinition{
+			{Name: "Desired", Type: "integer", JSONPath: ".spec.replicas",
+				Description: "Number of desired VirtualMachines"},
+			{Name: "Current", Type: "integer", JSONPath: ".status.replicas",
+				Description: "Number of managed and not deleted VirtualMachines"},
+			{Name: "Ready", Type: "integer", JSONPath: ".status.readyReplicas",
+				Description: "Number of managed VirtualMachines which are ready to receive traffic"},
+			{Name: "Age", Type: "date", JSONPath: creationTimestampJSONPath},
+		}, &extv1.CustomResourceSubresources{
+			Scale: &extv1.CustomResourceSubresourceScale{
+				SpecReplicasPath:   ".spec.replicas",
+				StatusReplicasPath: ".status.replicas",
+				LabelSelectorPath:  &labelSelector,
+			},
+			Status: &extv1.CustomResourceSubresourceStatus{},
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = patchValidationForAllVersions(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
 // Used by manifest generation
 func NewKubeVirtCR(namespace string, pullPolicy corev1.PullPolicy, featureGates string) *virtv1.KubeVirt {
 	cr := &virtv1.KubeVirt{
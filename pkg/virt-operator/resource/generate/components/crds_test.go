@@ -1,9 +1,12 @@
 package components
 
 import (
+	"encoding/json"
+
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 )
 
@@ -25,6 +28,7 @@ var _ = Describe("CRDs", func() {
 		table.Entry("for KV", NewKubeVirtCrd),
 		table.Entry("for VMSNAPSHOT", NewVirtualMachineSnapshotCrd),
 		table.Entry("for VMSNAPSHOTCONTENT", NewVirtualMachineSnapshotContentCrd),
+		table.Entry("for VMPOOL", NewVirtualMachinePoolCrd),
 	)
 
 	It("DataVolumeTemplates should have nullable a XPreserveUnknownFields on metadata", func() {
@@ -87,4 +91,82 @@ var _ = Describe("CRDs", func() {
 			Expect(*metadata.XPreserveUnknownFields).To(BeTrue())
 		}
 	})
+
+	table.DescribeTable("Should add structural constraints to the VMI spec", func(crdFunc func() (*extv1.CustomResourceDefinition, error), path ...string) {
+		crd, err := crdFunc()
+		Expect(err).NotTo(HaveOccurred())
+		for i := range crd.Spec.Versions {
+			vmiSpec := *crd.Spec.Versions[i].Schema.OpenAPIV3Schema
+			for _, property := range path {
+				vmiSpec = vmiSpec.Properties[property]
+			}
+			devices := vmiSpec.Properties["domain"].Properties["devices"]
+
+			// server-side apply keeps replacing the lists as a whole
+			for _, list := range []extv1.JSONSchemaProps{devices.Properties["disks"], devices.Properties["interfaces"], vmiSpec.Properties["volumes"], vmiSpec.Properties["networks"]} {
+				Expect(list.XListType).To(BeNil())
+			}
+			for _, list := range []extv1.JSONSchemaProps{devices.Properties["disks"], devices.Properties["interfaces"]} {
+				bootOrder := list.Items.Schema.Properties["bootOrder"]
+				Expect(bootOrder.Minimum).ToNot(BeNil())
+				Expect(*bootOrder.Minimum).To(Equal(float64(1)))
+			}
+			for _, list := range []extv1.JSONSchemaProps{devices.Properties["disks"], devices.Properties["interfaces"], vmiSpec.Properties["volumes"], vmiSpec.Properties["networks"]} {
+				Expect(list.MaxItems).To(PointTo(Equal(int64(VMISpecMaxListItems))))
+			}
+		}
+	},
+		table.Entry("for VMI", NewVirtualMachineInstanceCrd, "spec"),
+		table.Entry("for VM", NewVirtualMachineCrd, "spec", "template", "spec"),
+		table.Entry("for VMIRS", NewReplicaSetCrd, "spec", "template", "spec"),
+		table.Entry("for VMPOOL", NewVirtualMachinePoolCrd, "spec", "virtualMachineTemplate", "spec", "template", "spec"),
+	)
+
+	table.DescribeTable("Should add the validation rules to the VMI spec", func(crdFunc func() (*extv1.CustomResourceDefinition, error), path ...string) {
+		crd, err := crdFunc()
+		Expect(err).NotTo(HaveOccurred())
+		spec, err := json.Marshal(crd.Spec)
+		Expect(err).NotTo(HaveOccurred())
+		spec, err = AddValidationRules(spec)
+		Expect(err).NotTo(HaveOccurred())
+
+		patched := &struct {
+			Versions []struct {
+				Schema struct {
+					OpenAPIV3Schema map[string]interface{} `json:"openAPIV3Schema"`
+				} `json:"schema"`
+			} `json:"versions"`
+		}{}
+		Expect(json.Unmarshal(spec, patched)).To(Succeed())
+		Expect(patched.Versions).To(HaveLen(len(crd.Spec.Versions)))
+		for _, version := range patched.Versions {
+			vmiSpec := version.Schema.OpenAPIV3Schema
+			for _, property := range path {
+				vmiSpec = vmiSpec["properties"].(map[string]interface{})[property].(map[string]interface{})
+			}
+			rules := vmiSpec[validationRulesField].([]interface{})
+			Expect(rules).To(HaveLen(len(VMISpecValidationRules)))
+			for i, rule := range rules {
+				Expect(rule).To(HaveKeyWithValue("rule", VMISpecValidationRules[i].Rule))
+				Expect(rule).To(HaveKeyWithValue("message", VMISpecValidationRules[i].Message))
+			}
+			// the rules are only added to VMI specs
+			Expect(version.Schema.OpenAPIV3Schema).ToNot(HaveKey(validationRulesField))
+		}
+	},
+		table.Entry("for VMI", NewVirtualMachineInstanceCrd, "spec"),
+		table.Entry("for VM", NewVirtualMachineCrd, "spec", "template", "spec"),
+		table.Entry("for VMIRS", NewReplicaSetCrd, "spec", "template", "spec"),
+		table.Entry("for VMPOOL", NewVirtualMachinePoolCrd, "spec", "virtualMachineTemplate", "spec", "template", "spec"),
+	)
+
+	It("Should not add the validation rules to presets", func() {
+		crd, err := NewPresetCrd()
+		Expect(err).NotTo(HaveOccurred())
+		spec, err := json.Marshal(crd.Spec)
+		Expect(err).NotTo(HaveOccurred())
+		patched, err := AddValidationRules(spec)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(patched)).ToNot(ContainSubstring(validationRulesField))
+	})
 })
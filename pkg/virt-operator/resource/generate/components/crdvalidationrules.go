@@ -0,0 +1,95 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// validationRulesField is the field of a schema which holds its CEL validation rules. The vendored
+// apiextensions API does not know it yet, so the rules are added to the marshalled spec of the CRD.
+const validationRulesField = "x-kubernetes-validations"
+
+// ValidationRulesAnnotation tells if virt-operator added the validation rules to a CRD
+const ValidationRulesAnnotation = "kubevirt.io/crd-validation-rules"
+
+// ValidationRule is a CEL validation rule, which the API server evaluates against the object of a schema
+type ValidationRule struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// bootOrders are the boot orders of all disks and interfaces of a VirtualMachineInstance spec
+const bootOrders = "(has(self.domain.devices.disks) ? self.domain.devices.disks.filter(d, has(d.bootOrder)).map(d, d.bootOrder) : []) + " +
+	"(has(self.domain.devices.interfaces) ? self.domain.devices.interfaces.filter(i, has(i.bootOrder)).map(i, i.bootOrder) : [])"
+
+// VMISpecValidationRules are the rules of every VirtualMachineInstance spec, which relate several fields to
+// each other. The webhooks of virt-api check the same. The rules need the quantity library of CEL.
+var VMISpecValidationRules = []ValidationRule{
+	{
+		Rule: "!has(self.domain.memory) || !has(self.domain.memory.guest) || !has(self.domain.resources) || " +
+			"!has(self.domain.resources.limits) || !('memory' in self.domain.resources.limits) || " +
+			"quantity(string(self.domain.resources.limits['memory'])).isZero() || " +
+			"quantity(string(self.domain.memory.guest)).compareTo(quantity(string(self.domain.resources.limits['memory']))) <= 0",
+		Message: "domain.memory.guest must be equal to or less than the memory limit domain.resources.limits.memory",
+	},
+	{
+		Rule:    fmt.Sprintf("%s.all(o, %s.filter(p, p == o).size() == 1)", bootOrders, bootOrders),
+		Message: "the boot orders of the disks and interfaces in domain.devices must be unique",
+	},
+}
+
+// AddValidationRules adds the VMISpecValidationRules to every VirtualMachineInstance spec in the schemas of
+// the marshalled spec of a CRD
+func AddValidationRules(crdSpec []byte) ([]byte, error) {
+	spec := map[string]interface{}{}
+	if err := json.Unmarshal(crdSpec, &spec); err != nil {
+		return nil, err
+	}
+	versions, _ := spec["versions"].([]interface{})
+	for _, version := range versions {
+		version, _ := version.(map[string]interface{})
+		schema, _ := version["schema"].(map[string]interface{})
+		if openAPIV3Schema, ok := schema["openAPIV3Schema"].(map[string]interface{}); ok {
+			addValidationRules(openAPIV3Schema)
+		}
+	}
+	return json.Marshal(spec)
+}
+
+func addValidationRules(schema map[string]interface{}) {
+	properties, _ := schema["properties"].(map[string]interface{})
+	for _, property := range properties {
+		if property, ok := property.(map[string]interface{}); ok {
+			addValidationRules(property)
+		}
+	}
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		addValidationRules(items)
+	}
+
+	// presets only hold a part of a spec, which is validated once it is applied to a VirtualMachineInstance
+	_, hasDomain := properties["domain"]
+	_, hasVolumes := properties["volumes"]
+	if hasDomain && hasVolumes {
+		schema[validationRulesField] = VMISpecValidationRules
+	}
+}
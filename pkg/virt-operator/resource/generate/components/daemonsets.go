@@ -21,6 +21,20 @@ const (
 )
 
 func NewHandlerDaemonSet(namespace string, repository string, imagePrefix string, version string, launcherVersion string, productName string, productVersion string, pullPolicy corev1.PullPolicy, verbosity string, extraEnv map[string]string) (*appsv1.DaemonSet, error) {
+	return newHandlerDaemonSet(namespace, repository, imagePrefix, version, launcherVersion, productName, productVersion, pullPolicy, verbosity, extraEnv, runtime.GOARCH)
+}
+
+// HandlerDaemonSetName returns the name of the virt-handler daemonset for the nodes of the given architecture.
+// The daemonset for the architecture of virt-operator keeps the plain name.
+func HandlerDaemonSetName(arch string) string {
+	if arch == runtime.GOARCH {
+		return VirtHandlerName
+	}
+	return fmt.Sprintf("%s-%s", VirtHandlerName, arch)
+}
+
+// newHandlerDaemonSet returns the virt-handler daemonset for the nodes of the given architecture
+func newHandlerDaemonSet(namespace string, repository string, imagePrefix string, version string, launcherVersion string, productName string, productVersion string, pullPolicy corev1.PullPolicy, verbosity string, extraEnv map[string]string, arch string) (*appsv1.DaemonSet, error) {
 
 	deploymentName := VirtHandlerName
 	imageName := fmt.Sprintf("%s%s", imagePrefix, deploymentName)
@@ -37,7 +51,7 @@ func NewHandlerDaemonSet(namespace string, repository string, imagePrefix string
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: namespace,
-			Name:      VirtHandlerName,
+			Name:      HandlerDaemonSetName(arch),
 			Labels: map[string]string{
 				virtv1.AppLabel: VirtHandlerName,
 			},
@@ -68,7 +82,7 @@ func NewHandlerDaemonSet(namespace string, repository string, imagePrefix string
 	pod.HostPID = true
 
 	// nodelabeller currently only support x86
-	if virtconfig.IsAMD64(runtime.GOARCH) {
+	if virtconfig.IsAMD64(arch) {
 		launcherVersion = AddVersionSeparatorPrefix(launcherVersion)
 		pod.InitContainers = []corev1.Container{
 			{
@@ -150,6 +150,12 @@ func NewPrometheusRuleSpec(ns string, workloadUpdatesEnabled bool) *v1.Prometheu
 							fmt.Sprintf("sum(kubevirt_virt_controller_ready{namespace='%s'})", ns),
 						),
 					},
+					{
+						Record: "kubevirt_virt_controller_leading_total",
+						Expr: intstr.FromString(
+							fmt.Sprintf("sum(kubevirt_virt_controller_leading{namespace='%s'})", ns),
+						),
+					},
 					{
 						Alert: "LowReadyVirtControllersCount",
 						Expr:  intstr.FromString("kubevirt_virt_controller_ready_total <  kubevirt_virt_controller_up_total"),
@@ -174,6 +180,30 @@ func NewPrometheusRuleSpec(ns string, workloadUpdatesEnabled bool) *v1.Prometheu
 							"severity": "critical",
 						},
 					},
+					{
+						Alert: "NoLeadingVirtController",
+						Expr:  intstr.FromString("kubevirt_virt_controller_ready_total > 0 and kubevirt_virt_controller_leading_total == 0"),
+						For:   "5m",
+						Annotations: map[string]string{
+							"summary":     "No leading virt-controller was detected for the last 5 min.",
+							"runbook_url": runbookUrlBasePath + "NoLeadingVirtController",
+						},
+						Labels: map[string]string{
+							"severity": "critical",
+						},
+					},
+					{
+						Alert: "MultipleLeadingVirtControllers",
+						Expr:  intstr.FromString("kubevirt_virt_controller_leading_total > 1"),
+						For:   "5m",
+						Annotations: map[string]string{
+							"summary":     "More than one virt-controller is leading, the leader election may be split.",
+							"runbook_url": runbookUrlBasePath + "MultipleLeadingVirtControllers",
+						},
+						Labels: map[string]string{
+							"severity": "critical",
+						},
+					},
 					{
 						Alert: "VirtControllerDown",
 						Expr:  intstr.FromString("kubevirt_virt_controller_up_total == 0"),
@@ -372,10 +402,34 @@ func NewPrometheusRuleSpec(ns string, workloadUpdatesEnabled bool) *v1.Prometheu
 							"severity": "critical",
 						},
 					},
+					{
+						Alert: "MultipleLeadingVirtOperators",
+						Expr:  intstr.FromString("kubevirt_virt_operator_leading_total > 1"),
+						For:   "5m",
+						Annotations: map[string]string{
+							"summary":     "More than one virt-operator is leading, the leader election may be split.",
+							"runbook_url": runbookUrlBasePath + "MultipleLeadingVirtOperators",
+						},
+						Labels: map[string]string{
+							"severity": "critical",
+						},
+					},
 					{
 						Record: "kubevirt_virt_handler_up_total",
 						Expr:   intstr.FromString(fmt.Sprintf("sum(up{pod=~'virt-handler-.*', namespace='%s'})", ns)),
 					},
+					{
+						Alert: "VirtHandlerDown",
+						Expr:  intstr.FromString("kubevirt_virt_handler_up_total == 0"),
+						For:   "5m",
+						Annotations: map[string]string{
+							"summary":     "All virt-handler servers are down, no VirtualMachineInstance can be started or managed.",
+							"runbook_url": runbookUrlBasePath + "VirtHandlerDown",
+						},
+						Labels: map[string]string{
+							"severity": "critical",
+						},
+					},
 					{
 						Alert: "VirtHandlerDaemonSetRolloutFailing",
 						Expr: intstr.FromString(
@@ -531,6 +585,31 @@ func NewPrometheusRuleSpec(ns string, workloadUpdatesEnabled bool) *v1.Prometheu
 							"severity": "warning",
 						},
 					},
+					{
+						Alert: "VMIMigrationStuck",
+						Expr:  intstr.FromString("kubevirt_migrate_vmi_age_seconds > 3600"),
+						For:   "5m",
+						Annotations: map[string]string{
+							"description": "Migration {{ $labels.name }} of VMI {{ $labels.vmi }} in namespace {{ $labels.namespace }} did not finish within an hour, it is in phase {{ $labels.phase }}",
+							"summary":     "A VirtualMachineInstance migration did not finish within an hour",
+							"runbook_url": runbookUrlBasePath + "VMIMigrationStuck",
+						},
+						Labels: map[string]string{
+							"severity": "warning",
+						},
+					},
+					{
+						Alert: "VMIMigrationsFailing",
+						Expr:  intstr.FromString("sum(increase(kubevirt_migrate_vmi_failed_total[1h])) >= 3"),
+						For:   "5m",
+						Annotations: map[string]string{
+							"summary":     "Three or more VirtualMachineInstance migrations failed in the last hour",
+							"runbook_url": runbookUrlBasePath + "VMIMigrationsFailing",
+						},
+						Labels: map[string]string{
+							"severity": "warning",
+						},
+					},
 					{
 						Alert: "KubeVirtComponentExceedsRequestedMemory",
 						Expr:  intstr.FromString(fmt.Sprintf(`((kube_pod_container_resource_requests{namespace="%s",container=~"virt-controller|virt-api|virt-handler|virt-operator",resource="memory"}) - on(pod) group_left(node) container_memory_usage_bytes{namespace="%s"}) < 0`, ns, ns)),
@@ -0,0 +1,239 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package components
+
+import (
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+const (
+	// CommonTemplateConfigMapPrefix prefixes the names of the ConfigMaps of the common templates
+	CommonTemplateConfigMapPrefix = "common-template-"
+	// CommonTemplateKey is the key of the VirtualMachine manifest in the ConfigMap of a common template
+	CommonTemplateKey = "virtualmachine.yaml"
+
+	CommonTemplateTypeLabel       = "template.kubevirt.io/type"
+	CommonTemplateTypeBase        = "base"
+	CommonTemplateOSLabelPrefix   = "os.template.kubevirt.io/"
+	CommonTemplateWorkloadPrefix  = "workload.template.kubevirt.io/"
+	CommonTemplateFlavorPrefix    = "flavor.template.kubevirt.io/"
+	commonTemplateCloudInitConfig = `#cloud-config
+password: changeme
+chpasswd: { expire: True }
+`
+)
+
+// commonTemplate describes a VirtualMachine preset for a guest OS
+type commonTemplate struct {
+	os       string
+	workload string
+	flavor   string
+	cores    uint32
+	memory   string
+	windows  bool
+	// containerDisk is the image of the root disk, a PVC with the name of the OS is used if empty
+	containerDisk string
+}
+
+func (t commonTemplate) name() string {
+	return fmt.Sprintf("%s-%s-%s", t.os, t.workload, t.flavor)
+}
+
+var commonTemplates = []commonTemplate{
+	{os: "fedora", workload: "server", flavor: "small", cores: 1, memory: "2Gi", containerDisk: "quay.io/containerdisks/fedora:35"},
+	{os: "centos-stream8", workload: "server", flavor: "small", cores: 1, memory: "2Gi", containerDisk: "quay.io/containerdisks/centos-stream:8"},
+	{os: "ubuntu", workload: "server", flavor: "small", cores: 1, memory: "2Gi", containerDisk: "quay.io/containerdisks/ubuntu:20.04"},
+	{os: "windows10", workload: "desktop", flavor: "medium", cores: 2, memory: "4Gi", windows: true},
+	{os: "windows2019", workload: "server", flavor: "medium", cores: 2, memory: "4Gi", windows: true},
+}
+
+// CommonTemplateConfigMapNames returns the names of the ConfigMaps of all common templates
+func CommonTemplateConfigMapNames() []string {
+	names := []string{}
+	for _, template := range commonTemplates {
+		names = append(names, CommonTemplateConfigMapPrefix+template.name())
+	}
+	return names
+}
+
+// NewCommonTemplateConfigMaps returns a ConfigMap with the VirtualMachine manifest of every common
+// template. The templates are part of the install strategy, so they are updated with KubeVirt.
+func NewCommonTemplateConfigMaps(namespace string) ([]*k8sv1.ConfigMap, error) {
+	configMaps := []*k8sv1.ConfigMap{}
+	for _, template := range commonTemplates {
+		manifest, err := yaml.Marshal(newCommonTemplateVirtualMachine(template))
+		if err != nil {
+			return nil, err
+		}
+		configMaps = append(configMaps, &k8sv1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ConfigMap",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      CommonTemplateConfigMapPrefix + template.name(),
+				Namespace: namespace,
+				Labels:    commonTemplateLabels(template),
+			},
+			Data: map[string]string{
+				CommonTemplateKey: string(manifest),
+			},
+		})
+	}
+	return configMaps, nil
+}
+
+func commonTemplateLabels(template commonTemplate) map[string]string {
+	return map[string]string{
+		v1.ManagedByLabel:                                v1.ManagedByLabelOperatorValue,
+		CommonTemplateTypeLabel:                          CommonTemplateTypeBase,
+		CommonTemplateOSLabelPrefix + template.os:        "true",
+		CommonTemplateWorkloadPrefix + template.workload: "true",
+		CommonTemplateFlavorPrefix + template.flavor:     "true",
+	}
+}
+
+func newCommonTemplateVirtualMachine(template commonTemplate) *v1.VirtualMachine {
+	running := false
+	vmLabels := map[string]string{
+		CommonTemplateOSLabelPrefix + template.os:        "true",
+		CommonTemplateWorkloadPrefix + template.workload: "true",
+		CommonTemplateFlavorPrefix + template.flavor:     "true",
+	}
+
+	spec := v1.VirtualMachineInstanceSpec{
+		Domain: v1.DomainSpec{
+			CPU: &v1.CPU{
+				Sockets: 1,
+				Cores:   template.cores,
+				Threads: 1,
+			},
+			Resources: v1.ResourceRequirements{
+				Requests: k8sv1.ResourceList{
+					k8sv1.ResourceMemory: resource.MustParse(template.memory),
+				},
+			},
+			Devices: v1.Devices{
+				Interfaces: []v1.Interface{*v1.DefaultMasqueradeNetworkInterface()},
+			},
+		},
+		Networks: []v1.Network{*v1.DefaultPodNetwork()},
+	}
+
+	if template.windows {
+		addWindowsDefaults(&spec)
+		spec.Volumes = []v1.Volume{{
+			Name: "rootdisk",
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+					PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: template.os},
+				},
+			},
+		}}
+	} else {
+		spec.Domain.Devices.Disks = []v1.Disk{
+			{Name: "rootdisk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}},
+			{Name: "cloudinitdisk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}},
+		}
+		spec.Volumes = []v1.Volume{
+			{
+				Name: "rootdisk",
+				VolumeSource: v1.VolumeSource{
+					ContainerDisk: &v1.ContainerDiskSource{Image: template.containerDisk},
+				},
+			},
+			{
+				Name: "cloudinitdisk",
+				VolumeSource: v1.VolumeSource{
+					CloudInitNoCloud: &v1.CloudInitNoCloudSource{UserData: commonTemplateCloudInitConfig},
+				},
+			},
+		}
+	}
+
+	return &v1.VirtualMachine{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.GroupVersion.String(),
+			Kind:       "VirtualMachine",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: template.name() + "-",
+			Labels:       vmLabels,
+		},
+		Spec: v1.VirtualMachineSpec{
+			Running: &running,
+			Template: &v1.VirtualMachineInstanceTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: vmLabels,
+				},
+				Spec: spec,
+			},
+		},
+	}
+}
+
+// addWindowsDefaults sets the Hyper-V enlightenments, clock and devices which Windows guests
+// need without additional drivers
+func addWindowsDefaults(spec *v1.VirtualMachineInstanceSpec) {
+	enabled := &v1.FeatureState{}
+	spinlocks := uint32(8191)
+	spec.Domain.Features = &v1.Features{
+		ACPI: v1.FeatureState{},
+		APIC: &v1.FeatureAPIC{},
+		Hyperv: &v1.FeatureHyperv{
+			Relaxed:   enabled,
+			VAPIC:     enabled,
+			Spinlocks: &v1.FeatureSpinlocks{Retries: &spinlocks},
+			VPIndex:   enabled,
+			Runtime:   enabled,
+			SyNIC:     enabled,
+			SyNICTimer: &v1.SyNICTimer{
+				Direct: enabled,
+			},
+			Frequencies:     enabled,
+			Reenlightenment: enabled,
+			TLBFlush:        enabled,
+			IPI:             enabled,
+		},
+	}
+	spec.Domain.Clock = &v1.Clock{
+		ClockOffset: v1.ClockOffset{UTC: &v1.ClockOffsetUTC{}},
+		Timer: &v1.Timer{
+			HPET:   &v1.HPETTimer{Enabled: boolPtr(false)},
+			PIT:    &v1.PITTimer{TickPolicy: v1.PITTickPolicyDelay},
+			RTC:    &v1.RTCTimer{TickPolicy: v1.RTCTickPolicyCatchup},
+			Hyperv: &v1.HypervTimer{},
+		},
+	}
+	spec.Domain.Devices.Disks = []v1.Disk{
+		{Name: "rootdisk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "sata"}}},
+	}
+	spec.Domain.Devices.Inputs = []v1.Input{
+		{Name: "tablet", Type: "tablet", Bus: "usb"},
+	}
+	spec.Domain.Devices.Interfaces[0].Model = "e1000e"
+}
@@ -0,0 +1,84 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package components
+
+import (
+	"strings"
+
+	"github.com/ghodss/yaml"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Common templates", func() {
+
+	It("should hold a stopped VirtualMachine in every ConfigMap", func() {
+		configMaps, err := NewCommonTemplateConfigMaps("kubevirt")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(configMaps).To(HaveLen(len(CommonTemplateConfigMapNames())))
+
+		for i, configMap := range configMaps {
+			Expect(configMap.Name).To(Equal(CommonTemplateConfigMapNames()[i]))
+			Expect(configMap.Namespace).To(Equal("kubevirt"))
+			Expect(configMap.Labels).To(HaveKeyWithValue(CommonTemplateTypeLabel, CommonTemplateTypeBase))
+			Expect(configMap.Labels).To(HaveKeyWithValue(v1.ManagedByLabel, v1.ManagedByLabelOperatorValue))
+
+			manifest := configMap.Data[CommonTemplateKey]
+			// the install strategy separates its objects with ---
+			Expect(manifest).ToNot(ContainSubstring("---"))
+
+			vm := &v1.VirtualMachine{}
+			Expect(yaml.Unmarshal([]byte(manifest), vm)).To(Succeed())
+			Expect(vm.Kind).To(Equal("VirtualMachine"))
+			Expect(vm.GenerateName).To(Equal(strings.TrimPrefix(configMap.Name, CommonTemplateConfigMapPrefix) + "-"))
+			Expect(*vm.Spec.Running).To(BeFalse())
+			volumes := []string{}
+			for _, volume := range vm.Spec.Template.Spec.Volumes {
+				volumes = append(volumes, volume.Name)
+			}
+			Expect(vm.Spec.Template.Spec.Domain.Devices.Disks).ToNot(BeEmpty())
+			for _, disk := range vm.Spec.Template.Spec.Domain.Devices.Disks {
+				Expect(volumes).To(ContainElement(disk.Name))
+			}
+		}
+	})
+
+	It("should boot Windows from a PVC with Hyper-V enlightenments", func() {
+		configMaps, err := NewCommonTemplateConfigMaps("kubevirt")
+		Expect(err).ToNot(HaveOccurred())
+
+		found := false
+		for _, configMap := range configMaps {
+			if configMap.Name != CommonTemplateConfigMapPrefix+"windows10-desktop-medium" {
+				continue
+			}
+			found = true
+			vm := &v1.VirtualMachine{}
+			Expect(yaml.Unmarshal([]byte(configMap.Data[CommonTemplateKey]), vm)).To(Succeed())
+			spec := vm.Spec.Template.Spec
+			Expect(spec.Domain.Features.Hyperv).ToNot(BeNil())
+			Expect(spec.Domain.Clock.Timer.Hyperv).ToNot(BeNil())
+			Expect(spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("windows10"))
+		}
+		Expect(found).To(BeTrue())
+	})
+})
@@ -544,6 +544,38 @@ var CRDsValidation map[string]string = map[string]string{
                       type: object
                   type: object
               type: object
+            auditLog:
+              description: AuditLog enables the audit log of virt-api, which records
+                who accessed which subresource, like the console, of which VirtualMachine
+                or VirtualMachineInstance.
+              properties:
+                path:
+                  description: Path is the file which the audit events are appended
+                    to, if the sink is "file".
+                  type: string
+                sink:
+                  description: Sink is where the audit events are written to, either
+                    "stdout" or "file". Defaults to "stdout".
+                  type: string
+              type: object
+            consoleRecording:
+              description: ConsoleRecordingConfiguration holds the options for recording
+                serial console and VNC sessions
+              properties:
+                objectStoreURL:
+                  description: ObjectStoreURL is the base URL of a bucket in an object
+                    store. Every recorded session is uploaded to it with a HTTP PUT
+                    request once the session is closed. If the kubevirt-console-recording-credentials
+                    secret exists in the KubeVirt install namespace, its "token" key
+                    is sent as bearer token.
+                  type: string
+                recordVNC:
+                  description: RecordVNC enables the recording of VNC sessions. Only
+                    serial console sessions are recorded by default.
+                  type: boolean
+              required:
+              - objectStoreURL
+              type: object
             controllerConfiguration:
               description: ReloadableComponentConfiguration holds all generic k8s
                 configuration options which can be reloaded by components without
@@ -583,8 +615,33 @@ var CRDsValidation map[string]string = map[string]string{
               - type: string
               pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
               x-kubernetes-int-or-string: true
+            crashLoopBackOff:
+              description: CrashLoopBackOff configures the delay before VirtualMachines,
+                whose VirtualMachineInstances keep failing, are started again.
+              properties:
+                maxDelaySeconds:
+                  description: MaxDelaySeconds caps the delay before a failed VirtualMachineInstance
+                    is started again. Defaults to 300.
+                  format: int64
+                  type: integer
+                resetAfterSeconds:
+                  description: ResetAfterSeconds is the time a VirtualMachineInstance
+                    of a VirtualMachine with the RerunOnFailure run strategy has to
+                    run until its failures are no longer counted as a crash loop.
+                    Defaults to 600.
+                  format: int64
+                  type: integer
+              type: object
             defaultRuntimeClass:
               type: string
+            deprecatedMachineTypes:
+              description: DeprecatedMachineTypes holds the machine types which VirtualMachines
+                should no longer use, matched like emulatedMachines. VirtualMachines
+                with a deprecated machine type are updated to machineType, which applies
+                on their next restart.
+              items:
+                type: string
+              type: array
             developerConfiguration:
               description: DeveloperConfiguration holds developer options
               properties:
@@ -607,6 +664,12 @@ var CRDsValidation map[string]string = map[string]string{
                   items:
                     type: string
                   type: array
+                logFormat:
+                  description: LogFormat is the format of the lines of the libraries
+                    used by the components, like client-go. With json they are written
+                    as JSON like the lines of the components, with text they keep
+                    their own format. Defaults to text.
+                  type: string
                 logVerbosity:
                   description: LogVerbosity sets log verbosity level of  various components
                   properties:
@@ -649,10 +712,87 @@ var CRDsValidation map[string]string = map[string]string{
                     available.
                   type: boolean
               type: object
+            disks:
+              description: DiskConfiguration holds the cluster wide defaults and limits
+                of disks.
+              properties:
+                containerDiskEphemeralStorageLimit:
+                  anyOf:
+                  - type: integer
+                  - type: string
+                  description: ContainerDiskEphemeralStorageLimit is the amount of
+                    data a VirtualMachineInstance can write to each of its containerDisks.
+                    virt-launcher pods which write more are evicted.
+                  pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                  x-kubernetes-int-or-string: true
+                defaultBus:
+                  description: 'DefaultBus is the bus of the disks which don''t set
+                    one. Supported values are: virtio, sata, scsi, nvme. Defaults
+                    to sata.'
+                  type: string
+                storageClassCacheModes:
+                  additionalProperties:
+                    type: string
+                  description: 'StorageClassCacheModes maps the names of storage classes
+                    to the cache mode of the disks on their PersistentVolumeClaims
+                    which don''t set a cache mode. Supported values are: none, writethrough,
+                    writeback. Disks with writeback can not be live migrated.'
+                  type: object
+                virtioWinContainerDiskImage:
+                  description: VirtioWinContainerDiskImage is a containerDisk image
+                    with the virtio-win drivers. It is attached as a CD-ROM to Windows
+                    VirtualMachineInstances, so the drivers of the virtio devices
+                    can be installed during the Windows setup.
+                  type: string
+              type: object
             emulatedMachines:
               items:
                 type: string
               type: array
+            filesystemOverhead:
+              description: FilesystemOverhead is the fraction of filesystem PersistentVolumeClaims
+                which disk images leave free for the filesystem itself.
+              properties:
+                global:
+                  description: Global is the overhead of the storage classes without
+                    an entry in StorageClass. Defaults to no overhead.
+                  type: string
+                storageClass:
+                  additionalProperties:
+                    type: string
+                  description: StorageClass maps the names of storage classes to their
+                    overhead.
+                  type: object
+              type: object
+            guestDefaultsUpdateStrategy:
+              description: GuestDefaultsUpdateStrategy defines how VirtualMachines,
+                which run with guest visible cluster defaults which have changed since
+                they were started, are updated.
+              type: string
+            guestExecAllowList:
+              description: GuestExecAllowList holds the commands, with the arguments
+                they may be run with, which may be run in the guests of VirtualMachineInstances
+                through the guestexec subresource. Requires the GuestExec feature
+                gate.
+              items:
+                description: GuestExecCommand is a command of the guestExecAllowList
+                properties:
+                  args:
+                    description: Args are regular expressions for the arguments of
+                      the command. The command can only be run with one argument per
+                      expression, which has to match the expression completely. Without
+                      args the command can only be run without arguments.
+                    items:
+                      type: string
+                    type: array
+                  path:
+                    description: Path is the path of the command in the guest, like
+                      "/usr/bin/uptime".
+                    type: string
+                required:
+                - path
+                type: object
+              type: array
             handlerConfiguration:
               description: ReloadableComponentConfiguration holds all generic k8s
                 configuration options which can be reloaded by components without
@@ -688,6 +828,25 @@ var CRDsValidation map[string]string = map[string]string{
               description: PullPolicy describes a policy for if/when to pull a container
                 image
               type: string
+            leaderElection:
+              description: LeaderElection configures the leader election of virt-controller
+                and virt-operator. It is applied when they start.
+              properties:
+                leaseDuration:
+                  description: LeaseDuration is the time which candidates wait after
+                    the last renewal of the leader, until they try to take the lead.
+                    Defaults to 15s.
+                  type: string
+                renewDeadline:
+                  description: RenewDeadline is the time which the leader tries to
+                    renew its lease, until it gives up the lead. Must be less than
+                    the lease duration. Defaults to 10s.
+                  type: string
+                retryPeriod:
+                  description: RetryPeriod is the time between two attempts to take
+                    or to renew the lead. Defaults to 2s.
+                  type: string
+              type: object
             machineType:
               type: string
             mediatedDevicesConfiguration:
@@ -699,6 +858,30 @@ var CRDsValidation map[string]string = map[string]string{
                     type: string
                   type: array
                   x-kubernetes-list-type: atomic
+                nodeMediatedDeviceTypes:
+                  description: NodeMediatedDeviceTypes overrides the mediatedDevicesTypes
+                    on the nodes matching one or more of their node selectors.
+                  items:
+                    description: NodeMediatedDeviceTypesConfig holds information about
+                      MDEV types to be defined on the nodes matching the NodeSelector
+                    properties:
+                      mediatedDevicesTypes:
+                        items:
+                          type: string
+                        type: array
+                        x-kubernetes-list-type: atomic
+                      nodeSelector:
+                        additionalProperties:
+                          type: string
+                        description: NodeSelector must match the labels of a node
+                          for the mediated device types to be defined on it.
+                        type: object
+                    required:
+                    - mediatedDevicesTypes
+                    - nodeSelector
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
               type: object
             memBalloonStatsPeriod:
               format: int32
@@ -723,6 +906,12 @@ var CRDsValidation map[string]string = map[string]string{
                   type: boolean
                 nodeDrainTaintKey:
                   type: string
+                parallelMigrationThreads:
+                  description: ParallelMigrationThreads is the number of parallel
+                    multifd channels used to transfer the guest memory. Parallel migrations
+                    are not combined with post copy migrations.
+                  format: int32
+                  type: integer
                 parallelMigrationsPerCluster:
                   format: int32
                   type: integer
@@ -742,6 +931,24 @@ var CRDsValidation map[string]string = map[string]string{
               properties:
                 defaultNetworkInterface:
                   type: string
+                macAddressPool:
+                  description: MacAddressPool is the range of MAC addresses which
+                    virt-controller assigns to the interfaces of VirtualMachines without
+                    an explicit MAC address. The addresses are stored in the VirtualMachine
+                    and survive restarts and migrations.
+                  properties:
+                    rangeEnd:
+                      description: RangeEnd is the last MAC address of the pool, e.g.
+                        "02:00:00:ff:ff:ff"
+                      type: string
+                    rangeStart:
+                      description: RangeStart is the first MAC address of the pool,
+                        e.g. "02:00:00:00:00:00"
+                      type: string
+                  required:
+                  - rangeEnd
+                  - rangeStart
+                  type: object
                 permitBridgeInterfaceOnPodNetwork:
                   type: boolean
                 permitSlirpInterface:
@@ -751,6 +958,37 @@ var CRDsValidation map[string]string = map[string]string{
               additionalProperties:
                 type: boolean
               type: object
+            operatorConfiguration:
+              description: ReloadableComponentConfiguration holds all generic k8s
+                configuration options which can be reloaded by components without
+                requiring a restart.
+              properties:
+                restClient:
+                  description: RestClient can be used to tune certain aspects of the
+                    k8s client in use.
+                  properties:
+                    rateLimiter:
+                      description: RateLimiter allows selecting and configuring different
+                        rate limiters for the k8s client.
+                      properties:
+                        tokenBucketRateLimiter:
+                          properties:
+                            burst:
+                              description: Maximum burst for throttle. If it's zero,
+                                the component default will be used
+                              type: integer
+                            qps:
+                              description: QPS indicates the maximum QPS to the apiserver
+                                from this client. If it's zero, the component default
+                                will be used
+                              type: number
+                          required:
+                          - burst
+                          - qps
+                          type: object
+                      type: object
+                  type: object
+              type: object
             ovmfPath:
               type: string
             permittedHostDevices:
@@ -800,7 +1038,53 @@ var CRDsValidation map[string]string = map[string]string{
                     type: object
                   type: array
                   x-kubernetes-list-type: atomic
+                usb:
+                  items:
+                    description: USBHostDevice represents a host USB device allowed
+                      for passthrough
+                    properties:
+                      externalResourceProvider:
+                        description: If true, KubeVirt will leave the allocation and
+                          monitoring to an external device plugin
+                        type: boolean
+                      resourceName:
+                        description: The name of the resource that is representing
+                          the device. Exposed by a device plugin and requested by
+                          VMs. Typically of the form vendor.com/product_name
+                        type: string
+                      selectors:
+                        description: The list of vendor:product selectors of the USB
+                          devices which are exposed with this resource
+                        items:
+                          description: USBSelector selects USB devices by their vendor
+                            and product IDs
+                          properties:
+                            product:
+                              description: The product ID of the USB device, e.g.
+                                c52b
+                              type: string
+                            vendor:
+                              description: The vendor ID of the USB device, e.g. 046d
+                              type: string
+                          required:
+                          - product
+                          - vendor
+                          type: object
+                        type: array
+                        x-kubernetes-list-type: atomic
+                    required:
+                    - resourceName
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
               type: object
+            qemuArgsAllowList:
+              description: QEMUArgsAllowList holds the names of the QEMU arguments,
+                like "-fw_cfg", which VirtualMachineInstances may append to the QEMU
+                command line. Requires the QEMUArgs feature gate.
+              items:
+                type: string
+              type: array
             selinuxLauncherType:
               type: string
             smbios:
@@ -821,6 +1105,17 @@ var CRDsValidation map[string]string = map[string]string{
               items:
                 type: string
               type: array
+            swap:
+              description: Swap configures the swap usage of VirtualMachineInstances
+                with Burstable memory. Requires the VMSwap feature gate.
+              properties:
+                swappiness:
+                  description: Swappiness of the memory cgroup, between 0 and 100.
+                    It is only supported with cgroup v1, the swappiness of the node
+                    applies with cgroup v2.
+                  format: int64
+                  type: integer
+              type: object
             virtualMachineInstancesPerNode:
               type: integer
             webhookConfiguration:
@@ -2865,6 +3160,11 @@ var CRDsValidation map[string]string = map[string]string{
             - spec
             type: object
           type: array
+        nodeRebootPolicy:
+          description: NodeRebootPolicy controls what happens to a VirtualMachineInstance
+            which was running on a node that rebooted. Defaults to the behavior of
+            the RunStrategy.
+          type: string
         runStrategy:
           description: Running state indicates the requested running state of the
             VirtualMachineInstance mutually exclusive with Running
@@ -2873,6 +3173,24 @@ var CRDsValidation map[string]string = map[string]string{
           description: Running controls whether the associatied VirtualMachineInstance
             is created or not Mutually exclusive with RunStrategy
           type: boolean
+        schedule:
+          description: Schedule starts and stops the VirtualMachine at recurring times
+          properties:
+            start:
+              description: Start is a cron expression in the five field format "minute
+                hour day-of-month month day-of-week", e.g. "0 8 * * 1-5", at whose
+                times the VirtualMachine is started
+              type: string
+            stop:
+              description: Stop is a cron expression in the five field format "minute
+                hour day-of-month month day-of-week", e.g. "0 20 * * *", at whose
+                times the VirtualMachine is stopped
+              type: string
+            timeZone:
+              description: TimeZone is the name of the IANA time zone the cron expressions
+                are evaluated in, e.g. "Europe/Berlin". Defaults to UTC.
+              type: string
+          type: object
         template:
           description: Template is the direct specification of VirtualMachineInstance
           properties:
@@ -3840,9 +4158,17 @@ var CRDsValidation map[string]string = map[string]string{
                             or not. Serial console access will not be available if
                             set to false. Defaults to true.
                           type: boolean
+                        autoattachVSOCK:
+                          description: Whether to attach a VSOCK device to the vmi,
+                            through which the host can talk to processes in the guest
+                            without any network. The CID of the device is reported
+                            in the status of the vmi. Defaults to false.
+                          type: boolean
                         blockMultiQueue:
                           description: Whether or not to enable virtio multi-queue
-                            for block devices. Defaults to false.
+                            for block devices. The number of queues equals the number
+                            of vCPUs, unless a disk sets its own queue count. Defaults
+                            to false.
                           type: boolean
                         clientPassthrough:
                           description: To configure and access client devices such
@@ -3895,7 +4221,7 @@ var CRDsValidation map[string]string = map[string]string{
                               cache:
                                 description: 'Cache specifies which kvm disk cache
                                   mode should be used. Supported values are: CacheNone,
-                                  CacheWriteThrough.'
+                                  CacheWriteThrough, CacheWriteBack.'
                                 type: string
                               cdrom:
                                 description: Attach a volume as a cdrom to the vmi.
@@ -3919,13 +4245,20 @@ var CRDsValidation map[string]string = map[string]string{
                                   should have an exclusive IO Thread. Enabling this
                                   implies useIOThreads = true. Defaults to false.
                                 type: boolean
+                              discard:
+                                description: 'Discard specifies if discard requests
+                                  of the guest are passed to the storage of the disk.
+                                  Supported values are: unmap, ignore. Defaults to
+                                  unmap, or to ignore for preallocated volumes. Not
+                                  supported for cdroms and floppies.'
+                                type: string
                               disk:
                                 description: Attach a volume as a disk to the vmi.
                                 properties:
                                   bus:
                                     description: 'Bus indicates the type of disk device
                                       to emulate. supported values: virtio, sata,
-                                      scsi.'
+                                      scsi, nvme.'
                                     type: string
                                   pciAddress:
                                     description: 'If specified, the virtual disk will
@@ -3953,6 +4286,50 @@ var CRDsValidation map[string]string = map[string]string{
                                   should be used. Supported values are: native, default,
                                   threads.'
                                 type: string
+                              ioTune:
+                                description: IOTune limits the throughput and the
+                                  IOPS of the disk.
+                                properties:
+                                  readBytesPerSec:
+                                    anyOf:
+                                    - type: integer
+                                    - type: string
+                                    description: ReadBytesPerSec limits the bytes
+                                      read per second.
+                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                    x-kubernetes-int-or-string: true
+                                  readIOPS:
+                                    description: ReadIOPS limits the read operations
+                                      per second.
+                                    format: int64
+                                    type: integer
+                                  totalBytesPerSec:
+                                    anyOf:
+                                    - type: integer
+                                    - type: string
+                                    description: TotalBytesPerSec limits the bytes
+                                      read and written per second, e.g. 100Mi.
+                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                    x-kubernetes-int-or-string: true
+                                  totalIOPS:
+                                    description: TotalIOPS limits the read and write
+                                      operations per second.
+                                    format: int64
+                                    type: integer
+                                  writeBytesPerSec:
+                                    anyOf:
+                                    - type: integer
+                                    - type: string
+                                    description: WriteBytesPerSec limits the bytes
+                                      written per second.
+                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                    x-kubernetes-int-or-string: true
+                                  writeIOPS:
+                                    description: WriteIOPS limits the write operations
+                                      per second.
+                                    format: int64
+                                    type: integer
+                                type: object
                               lun:
                                 description: Attach a volume as a LUN to the vmi.
                                 properties:
@@ -3964,10 +4341,23 @@ var CRDsValidation map[string]string = map[string]string{
                                   readonly:
                                     description: ReadOnly. Defaults to false.
                                     type: boolean
+                                  reservation:
+                                    description: Reservation lets the guest issue
+                                      SCSI-3 persistent reservation commands to the
+                                      LUN, e.g. for the shared disks of failover clusters.
+                                      Requires the scsi bus. Defaults to false.
+                                    type: boolean
                                 type: object
                               name:
                                 description: Name is the device name
                                 type: string
+                              queues:
+                                description: Queues sets the number of virtio-blk
+                                  queues of this disk, overriding blockMultiQueue.
+                                  Only allowed for disks on the virtio bus. Must not
+                                  exceed the number of vCPUs.
+                                format: int32
+                                type: integer
                               serial:
                                 description: Serial provides the ability to specify
                                   a serial number for the disk device.
@@ -3976,6 +4366,11 @@ var CRDsValidation map[string]string = map[string]string{
                                 description: If specified, disk address and its tag
                                   will be provided to the guest via config drive metadata
                                 type: string
+                              wwn:
+                                description: WWN is the World Wide Name of the disk
+                                  device, made up of 16 hexadecimal digits. Only allowed
+                                  for disks and cdroms on the sata or scsi bus.
+                                type: string
                             required:
                             - name
                             type: object
@@ -3997,6 +4392,12 @@ var CRDsValidation map[string]string = map[string]string{
                             type: object
                           type: array
                           x-kubernetes-list-type: atomic
+                        freePageReporting:
+                          description: Whether the guest reports the memory pages
+                            it freed to the host through the Memory balloon device,
+                            so that they can be reclaimed. Defaults to true if the
+                            memory of the vmi is overcommitted.
+                          type: boolean
                         gpus:
                           description: Whether to attach a GPU device to the vmi.
                           items:
@@ -4007,6 +4408,33 @@ var CRDsValidation map[string]string = map[string]string{
                                 description: Name of the GPU device as exposed by
                                   a device plugin
                                 type: string
+                              virtualGPUOptions:
+                                description: VirtualGPUOptions configures vGPU (mediated
+                                  device) specific options. It is ignored for GPUs
+                                  which are passed through as PCI devices.
+                                properties:
+                                  display:
+                                    description: Display configures the display adapter
+                                      backed by the vGPU.
+                                    properties:
+                                      enabled:
+                                        description: Enabled determines if a display
+                                          adapter backed by the vGPU should be enabled
+                                          or disabled on the guest. Defaults to true.
+                                        type: boolean
+                                      ramFB:
+                                        description: RamFB enables a boot framebuffer
+                                          which is used until the guest OS loads a
+                                          real GPU driver. Defaults to true.
+                                        properties:
+                                          enabled:
+                                            description: Enabled determines if the
+                                              feature should be enabled or disabled
+                                              on the guest. Defaults to true.
+                                            type: boolean
+                                        type: object
+                                    type: object
+                                type: object
                             required:
                             - deviceName
                             - name
@@ -4054,6 +4482,61 @@ var CRDsValidation map[string]string = map[string]string{
                             are added to the vmi.
                           items:
                             properties:
+                              bandwidth:
+                                description: Bandwidth limits the traffic of the interface.
+                                  Only allowed for bridge and masquerade interfaces.
+                                properties:
+                                  egress:
+                                    description: Egress limits the traffic sent by
+                                      the guest.
+                                    properties:
+                                      burst:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Burst is the amount of bytes
+                                          which can exceed the rate at once, e.g.
+                                          1Mi. Defaults to the traffic of 100ms at
+                                          the rate, but at least 64Ki.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      rate:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Rate is the average rate in bits
+                                          per second, e.g. 100M.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                    required:
+                                    - rate
+                                    type: object
+                                  ingress:
+                                    description: Ingress limits the traffic received
+                                      by the guest.
+                                    properties:
+                                      burst:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Burst is the amount of bytes
+                                          which can exceed the rate at once, e.g.
+                                          1Mi. Defaults to the traffic of 100ms at
+                                          the rate, but at least 64Ki.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      rate:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Rate is the average rate in bits
+                                          per second, e.g. 100M.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                    required:
+                                    - rate
+                                    type: object
+                                type: object
                               bootOrder:
                                 description: BootOrder is an integer value > 0, used
                                   to determine ordering of boot devices. Lower values
@@ -4154,6 +4637,13 @@ var CRDsValidation map[string]string = map[string]string{
                                   - port
                                   type: object
                                 type: array
+                              queues:
+                                description: Queues sets the number of vhost queues
+                                  of this interface, overriding networkInterfaceMultiqueue.
+                                  Only allowed for virtio interfaces. Must not exceed
+                                  the number of vCPUs.
+                                format: int32
+                                type: integer
                               slirp:
                                 type: object
                               sriov:
@@ -4163,21 +4653,52 @@ var CRDsValidation map[string]string = map[string]string{
                                   address and its tag will be provided to the guest
                                   via config drive
                                 type: string
+                              vhostuser:
+                                description: InterfaceVhostUser connects the interface
+                                  to a userspace dataplane (e.g. OVS-DPDK or VPP)
+                                  over a vhost-user socket. QEMU creates the socket
+                                  in server mode, named after the interface, in the
+                                  vhostuser directory shared by the virt-launcher
+                                  pod, and the dataplane connects to it. Requires
+                                  a Multus network and hugepages backed guest memory.
+                                type: object
                             required:
                             - name
                             type: object
                           type: array
+                        logSerialConsole:
+                          description: Whether to log the output of the auto-attached
+                            serial console to the guest-console-log container of the
+                            virt-launcher pod, where it can be read with kubectl logs.
+                            Not relevant if autoattachSerialConsole is false. Defaults
+                            to false.
+                          type: boolean
                         networkInterfaceMultiqueue:
                           description: If specified, virtual network interfaces configured
                             with a virtio bus will also enable the vhost multiqueue
                             feature for network devices. The number of queues created
                             depends on additional factors of the VirtualMachineInstance,
-                            like the number of guest CPUs.
+                            like the number of guest CPUs. Interfaces which set their
+                            own queue count are not affected.
                           type: boolean
                         rng:
                           description: Whether to have random number generator from
                             host
                           type: object
+                        sound:
+                          description: Whether to emulate a sound device.
+                          properties:
+                            model:
+                              description: 'We only support ich9 or ac97. If SoundDevice
+                                is not set: No sound card is emulated. If SoundDevice
+                                is set but Model is not: ich9'
+                              type: string
+                            name:
+                              description: User's defined name for this sound device
+                              type: string
+                          required:
+                          - name
+                          type: object
                         useVirtioTransitional:
                           description: Fall back to legacy virtio 0.9 support if virtio
                             bus is selected on devices. This is helpful for old machines
@@ -4397,6 +4918,17 @@ var CRDsValidation map[string]string = map[string]string{
                                   type: boolean
                               type: object
                           type: object
+                        hypervPassthrough:
+                          description: HypervPassthrough enables all the Hyper-V enlightenments
+                            which are supported by the host. It can't be combined
+                            with hyperv. VMIs with Hyper-V passthrough are not live
+                            migratable.
+                          properties:
+                            enabled:
+                              description: Enabled determines if the Hyper-V passthrough
+                                mode should be used. Defaults to true.
+                              type: boolean
+                          type: object
                         kvm:
                           description: Configure how KVM presence is exposed to the
                             guest.
@@ -4429,6 +4961,19 @@ var CRDsValidation map[string]string = map[string]string{
                     firmware:
                       description: Firmware.
                       properties:
+                        bootMenu:
+                          description: BootMenu shows a menu while booting, in which
+                            the device to boot from can be picked in the console,
+                            instead of booting the devices in their boot order.
+                          properties:
+                            timeout:
+                              description: Timeout is how long the boot menu waits
+                                for a pick, in milliseconds, before it boots the first
+                                device of the boot order. At most 65535. Defaults
+                                to the timeout of the firmware.
+                              format: int32
+                              type: integer
+                          type: object
                         bootloader:
                           description: Settings to control the bootloader that is
                             used.
@@ -4535,6 +5080,25 @@ var CRDsValidation map[string]string = map[string]string{
                               type: string
                           type: object
                       type: object
+                    qemuArgs:
+                      description: QEMUArgs are appended to the QEMU command line
+                        of the vmi. Requires the QEMUArgs feature gate, only arguments
+                        which are allowed in the KubeVirt configuration are accepted.
+                      items:
+                        description: QEMUArg is an argument which is appended to the
+                          QEMU command line.
+                        properties:
+                          name:
+                            description: Name of the argument, including the leading
+                              dash, e.g. "-fw_cfg".
+                            type: string
+                          value:
+                            description: Value of the argument, e.g. "name=opt/com.example/config,string=value".
+                            type: string
+                        required:
+                        - name
+                        type: object
+                      type: array
                     resources:
                       description: Resources describes the Compute Resources required
                         by this vmi.
@@ -4573,7 +5137,8 @@ var CRDsValidation map[string]string = map[string]string{
                 evictionStrategy:
                   description: EvictionStrategy can be set to "LiveMigrate" if the
                     VirtualMachineInstance should be migrated instead of shut-off
-                    in case of a node drain.
+                    in case of a node drain, or to "LiveMigrateIfPossible" if it should
+                    be migrated when it is live-migratable and evicted otherwise.
                   type: string
                 hostname:
                   description: Specifies the hostname of the vmi If not specified,
@@ -4719,6 +5284,21 @@ var CRDsValidation map[string]string = map[string]string{
                             description: Select the default network and add it to
                               the multus-cni.io/default-network annotation.
                             type: boolean
+                          ipAddresses:
+                            description: IPAddresses requests static IP addresses
+                              in CIDR notation, e.g. 192.168.1.10/24, for the interface.
+                              They are passed to the "ips" capability of the CNI plugin.
+                              Not supported on the default network.
+                            items:
+                              type: string
+                            type: array
+                            x-kubernetes-list-type: atomic
+                          ipPool:
+                            description: IPPool references a pool of the IPAM plugin
+                              of the network from which the addresses of the interface
+                              are assigned. It is passed as the "ipPool" CNI arg.
+                              Not supported on the default network.
+                            type: string
                           networkName:
                             description: 'References to a NetworkAttachmentDefinition
                               CRD object. Format: <networkName>, <namespace>/<networkName>.
@@ -4757,7 +5337,8 @@ var CRDsValidation map[string]string = map[string]string{
                 priorityClassName:
                   description: If specified, indicates the pod's priority. If not
                     specified, the pod priority will be default or zero if there is
-                    no default.
+                    no default. VMIs with a higher priority are evacuated first when
+                    a node is drained.
                   type: string
                 readinessProbe:
                   description: 'Periodic probe of VirtualMachineInstance service readiness.
@@ -4893,33 +5474,159 @@ var CRDsValidation map[string]string = map[string]string{
                   description: StartStrategy can be set to "Paused" if Virtual Machine
                     should be started in paused state.
                   type: string
-                subdomain:
-                  description: If specified, the fully qualified vmi hostname will
-                    be "<hostname>.<subdomain>.<pod namespace>.svc.<cluster domain>".
-                    If not specified, the vmi will not have a domainname at all. The
-                    DNS entry will resolve to the vmi, no matter if the vmi itself
-                    can pick up a hostname.
-                  type: string
-                terminationGracePeriodSeconds:
-                  description: Grace period observed after signalling a VirtualMachineInstance
-                    to stop after which the VirtualMachineInstance is force terminated.
-                  format: int64
-                  type: integer
-                tolerations:
-                  description: If toleration is specified, obey all the toleration
-                    rules.
-                  items:
-                    description: The pod this Toleration is attached to tolerates
-                      any taint that matches the triple <key,value,effect> using the
-                      matching operator <operator>.
-                    properties:
-                      effect:
-                        description: Effect indicates the taint effect to match. Empty
-                          means match all taint effects. When specified, allowed values
-                          are NoSchedule, PreferNoSchedule and NoExecute.
-                        type: string
-                      key:
-                        description: Key is the taint key that the toleration applies
+                startupProbe:
+                  description: 'Probe indicating that the VirtualMachineInstance has
+                    successfully started. Liveness and readiness probes are not executed
+                    until it succeeds. VirtualmachineInstances will be stopped if
+                    the probe fails. Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                  properties:
+                    exec:
+                      description: One and only one of the following should be specified.
+                        Exec specifies the action to take, it will be executed on
+                        the guest through the qemu-guest-agent. If the guest agent
+                        is not available, this probe will fail.
+                      properties:
+                        command:
+                          description: Command is the command line to execute inside
+                            the container, the working directory for the command  is
+                            root ('/') in the container's filesystem. The command
+                            is simply exec'd, it is not run inside a shell, so traditional
+                            shell instructions ('|', etc) won't work. To use a shell,
+                            you need to explicitly call out to that shell. Exit status
+                            of 0 is treated as live/healthy and non-zero is unhealthy.
+                          items:
+                            type: string
+                          type: array
+                      type: object
+                    failureThreshold:
+                      description: Minimum consecutive failures for the probe to be
+                        considered failed after having succeeded. Defaults to 3. Minimum
+                        value is 1.
+                      format: int32
+                      type: integer
+                    guestAgentPing:
+                      description: GuestAgentPing contacts the qemu-guest-agent for
+                        availability checks.
+                      type: object
+                    httpGet:
+                      description: HTTPGet specifies the http request to perform.
+                      properties:
+                        host:
+                          description: Host name to connect to, defaults to the pod
+                            IP. You probably want to set "Host" in httpHeaders instead.
+                          type: string
+                        httpHeaders:
+                          description: Custom headers to set in the request. HTTP
+                            allows repeated headers.
+                          items:
+                            description: HTTPHeader describes a custom header to be
+                              used in HTTP probes
+                            properties:
+                              name:
+                                description: The header field name
+                                type: string
+                              value:
+                                description: The header field value
+                                type: string
+                            required:
+                            - name
+                            - value
+                            type: object
+                          type: array
+                        path:
+                          description: Path to access on the HTTP server.
+                          type: string
+                        port:
+                          anyOf:
+                          - type: integer
+                          - type: string
+                          description: Name or number of the port to access on the
+                            container. Number must be in the range 1 to 65535. Name
+                            must be an IANA_SVC_NAME.
+                          x-kubernetes-int-or-string: true
+                        scheme:
+                          description: Scheme to use for connecting to the host. Defaults
+                            to HTTP.
+                          type: string
+                      required:
+                      - port
+                      type: object
+                    initialDelaySeconds:
+                      description: 'Number of seconds after the VirtualMachineInstance
+                        has started before liveness probes are initiated. More info:
+                        https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                      format: int32
+                      type: integer
+                    periodSeconds:
+                      description: How often (in seconds) to perform the probe. Default
+                        to 10 seconds. Minimum value is 1.
+                      format: int32
+                      type: integer
+                    successThreshold:
+                      description: Minimum consecutive successes for the probe to
+                        be considered successful after having failed. Defaults to
+                        1. Must be 1 for liveness. Minimum value is 1.
+                      format: int32
+                      type: integer
+                    tcpSocket:
+                      description: 'TCPSocket specifies an action involving a TCP
+                        port. TCP hooks not yet supported TODO: implement a realistic
+                        TCP lifecycle hook'
+                      properties:
+                        host:
+                          description: 'Optional: Host name to connect to, defaults
+                            to the pod IP.'
+                          type: string
+                        port:
+                          anyOf:
+                          - type: integer
+                          - type: string
+                          description: Number or name of the port to access on the
+                            container. Number must be in the range 1 to 65535. Name
+                            must be an IANA_SVC_NAME.
+                          x-kubernetes-int-or-string: true
+                      required:
+                      - port
+                      type: object
+                    timeoutSeconds:
+                      description: 'Number of seconds after which the probe times
+                        out. For exec probes the timeout fails the probe but does
+                        not terminate the command running on the guest. This means
+                        a blocking command can result in an increasing load on the
+                        guest. A small buffer will be added to the resulting workload
+                        exec probe to compensate for delays caused by the qemu guest
+                        exec mechanism. Defaults to 1 second. Minimum value is 1.
+                        More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                      format: int32
+                      type: integer
+                  type: object
+                subdomain:
+                  description: If specified, the fully qualified vmi hostname will
+                    be "<hostname>.<subdomain>.<pod namespace>.svc.<cluster domain>".
+                    If not specified, the vmi will not have a domainname at all. The
+                    DNS entry will resolve to the vmi, no matter if the vmi itself
+                    can pick up a hostname.
+                  type: string
+                terminationGracePeriodSeconds:
+                  description: Grace period observed after signalling a VirtualMachineInstance
+                    to stop after which the VirtualMachineInstance is force terminated.
+                  format: int64
+                  type: integer
+                tolerations:
+                  description: If toleration is specified, obey all the toleration
+                    rules.
+                  items:
+                    description: The pod this Toleration is attached to tolerates
+                      any taint that matches the triple <key,value,effect> using the
+                      matching operator <operator>.
+                    properties:
+                      effect:
+                        description: Effect indicates the taint effect to match. Empty
+                          means match all taint effects. When specified, allowed values
+                          are NoSchedule, PreferNoSchedule and NoExecute.
+                        type: string
+                      key:
+                        description: Key is the taint key that the toleration applies
                           to. Empty means match all taint keys. If the key is empty,
                           operator must be Exists; this combination means to match
                           all values and all keys.
@@ -5076,6 +5783,28 @@ var CRDsValidation map[string]string = map[string]string{
                               registry secret required to pull the image. The secret
                               must already exist.
                             type: string
+                          overlay:
+                            description: Overlay configures the writable copy-on-write
+                              overlay, which uses the image as its backing file. By
+                              default the overlay is stored in an emptyDir and has
+                              the size of the image.
+                            properties:
+                              capacity:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Capacity is the size of the disk the
+                                  guest sees, which has to be at least the size of
+                                  the image. Defaults to the size of the image.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              claimName:
+                                description: ClaimName is the name of a filesystem
+                                  PersistentVolumeClaim in the namespace of the VirtualMachineInstance
+                                  to store the overlay on, which keeps the writes
+                                  of the guest across restarts. Defaults to an emptyDir.
+                                type: string
+                            type: object
                           path:
                             description: Path defines the path to disk file in the
                               container
@@ -5249,6 +5978,18 @@ var CRDsValidation map[string]string = map[string]string{
                         - path
                         - type
                         type: object
+                      memoryDump:
+                        description: MemoryDump is a PersistentVolumeClaim which is
+                          hotplugged to the virt-launcher pod to receive a memory
+                          dump of the VirtualMachineInstance.
+                        properties:
+                          claimName:
+                            description: ClaimName is the name of a PersistentVolumeClaim
+                              in the same namespace as the VirtualMachineInstance.
+                            type: string
+                        required:
+                        - claimName
+                        type: object
                       name:
                         description: 'Volume''s name. Must be a DNS_LABEL and unique
                           within the vmi. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
@@ -5344,6 +6085,32 @@ var CRDsValidation map[string]string = map[string]string{
       description: Status holds the current state of the controller and brief information
         about its associated VirtualMachineInstance
       properties:
+        availability:
+          description: Availability records the starts and the unplanned downtime
+            of the VM
+          nullable: true
+          properties:
+            failureTime:
+              description: FailureTime is the time the VMI failed, it is only set
+                while the resulting downtime is ongoing
+              format: date-time
+              type: string
+            readyTime:
+              description: ReadyTime is the time the guest became ready after the
+                latest start request
+              format: date-time
+              type: string
+            startRequestTime:
+              description: StartRequestTime is the time the latest start of the VM
+                was requested
+              format: date-time
+              type: string
+            unplannedDowntimeSeconds:
+              description: UnplannedDowntimeSeconds is the cumulative downtime of
+                the VM after its VMIs failed, without the ongoing downtime
+              format: int64
+              type: integer
+          type: object
         conditions:
           description: Hold the state information of the VirtualMachine and its VirtualMachineInstance
           items:
@@ -5374,6 +6141,39 @@ var CRDsValidation map[string]string = map[string]string{
           description: Created indicates if the virtual machine is created in the
             cluster
           type: boolean
+        memoryDumpRequest:
+          description: MemoryDumpRequest tracks the memory dump request of the VM
+            and the PersistentVolumeClaim it is associated with
+          nullable: true
+          properties:
+            claimName:
+              description: ClaimName is the name of the PersistentVolumeClaim the
+                memory dump is written to
+              type: string
+            endTimestamp:
+              description: EndTimestamp is the time when the memory dump completed
+              format: date-time
+              type: string
+            fileName:
+              description: FileName is the name of the memory dump file on the PersistentVolumeClaim
+              type: string
+            message:
+              description: Message is a detailed message about a failed memory dump
+              type: string
+            phase:
+              description: Phase is the phase of the memory dump
+              type: string
+            remove:
+              description: Remove requests to dissociate the PersistentVolumeClaim
+                from the VM
+              type: boolean
+            startTimestamp:
+              description: StartTimestamp is the time when the memory dump started
+              format: date-time
+              type: string
+          required:
+          - claimName
+          type: object
         printableStatus:
           description: PrintableStatus is a human readable, high-level representation
             of the status of the virtual machine
@@ -5381,10 +6181,32 @@ var CRDsValidation map[string]string = map[string]string{
         ready:
           description: Ready indicates if the virtual machine is running and ready
           type: boolean
+        restartCount:
+          description: RestartCount is the number of failed VMIs which were started
+            again after a crash loop backoff
+          type: integer
         restoreInProgress:
           description: RestoreInProgress is the name of the VirtualMachineRestore
             currently executing
           type: string
+        schedule:
+          description: Schedule reports the scheduled starts and stops of the VM
+          nullable: true
+          properties:
+            lastScheduleTime:
+              description: LastScheduleTime is the time of the last scheduled start
+                or stop which was applied
+              format: date-time
+              type: string
+            nextStartTime:
+              description: NextStartTime is the time of the next scheduled start
+              format: date-time
+              type: string
+            nextStopTime:
+              description: NextStopTime is the time of the next scheduled stop
+              format: date-time
+              type: string
+          type: object
         snapshotInProgress:
           description: SnapshotInProgress is the name of the VirtualMachineSnapshot
             currently executing
@@ -5476,7 +6298,8 @@ var CRDsValidation map[string]string = map[string]string{
                         type: integer
                       cache:
                         description: 'Cache specifies which kvm disk cache mode should
-                          be used. Supported values are: CacheNone, CacheWriteThrough.'
+                          be used. Supported values are: CacheNone, CacheWriteThrough,
+                          CacheWriteBack.'
                         type: string
                       cdrom:
                         description: Attach a volume as a cdrom to the vmi.
@@ -5499,12 +6322,18 @@ var CRDsValidation map[string]string = map[string]string{
                           have an exclusive IO Thread. Enabling this implies useIOThreads
                           = true. Defaults to false.
                         type: boolean
+                      discard:
+                        description: 'Discard specifies if discard requests of the
+                          guest are passed to the storage of the disk. Supported values
+                          are: unmap, ignore. Defaults to unmap, or to ignore for
+                          preallocated volumes. Not supported for cdroms and floppies.'
+                        type: string
                       disk:
                         description: Attach a volume as a disk to the vmi.
                         properties:
                           bus:
                             description: 'Bus indicates the type of disk device to
-                              emulate. supported values: virtio, sata, scsi.'
+                              emulate. supported values: virtio, sata, scsi, nvme.'
                             type: string
                           pciAddress:
                             description: 'If specified, the virtual disk will be placed
@@ -5531,6 +6360,49 @@ var CRDsValidation map[string]string = map[string]string{
                         description: 'IO specifies which QEMU disk IO mode should
                           be used. Supported values are: native, default, threads.'
                         type: string
+                      ioTune:
+                        description: IOTune limits the throughput and the IOPS of
+                          the disk.
+                        properties:
+                          readBytesPerSec:
+                            anyOf:
+                            - type: integer
+                            - type: string
+                            description: ReadBytesPerSec limits the bytes read per
+                              second.
+                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                            x-kubernetes-int-or-string: true
+                          readIOPS:
+                            description: ReadIOPS limits the read operations per second.
+                            format: int64
+                            type: integer
+                          totalBytesPerSec:
+                            anyOf:
+                            - type: integer
+                            - type: string
+                            description: TotalBytesPerSec limits the bytes read and
+                              written per second, e.g. 100Mi.
+                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                            x-kubernetes-int-or-string: true
+                          totalIOPS:
+                            description: TotalIOPS limits the read and write operations
+                              per second.
+                            format: int64
+                            type: integer
+                          writeBytesPerSec:
+                            anyOf:
+                            - type: integer
+                            - type: string
+                            description: WriteBytesPerSec limits the bytes written
+                              per second.
+                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                            x-kubernetes-int-or-string: true
+                          writeIOPS:
+                            description: WriteIOPS limits the write operations per
+                              second.
+                            format: int64
+                            type: integer
+                        type: object
                       lun:
                         description: Attach a volume as a LUN to the vmi.
                         properties:
@@ -5541,10 +6413,22 @@ var CRDsValidation map[string]string = map[string]string{
                           readonly:
                             description: ReadOnly. Defaults to false.
                             type: boolean
+                          reservation:
+                            description: Reservation lets the guest issue SCSI-3 persistent
+                              reservation commands to the LUN, e.g. for the shared
+                              disks of failover clusters. Requires the scsi bus. Defaults
+                              to false.
+                            type: boolean
                         type: object
                       name:
                         description: Name is the device name
                         type: string
+                      queues:
+                        description: Queues sets the number of virtio-blk queues of
+                          this disk, overriding blockMultiQueue. Only allowed for
+                          disks on the virtio bus. Must not exceed the number of vCPUs.
+                        format: int32
+                        type: integer
                       serial:
                         description: Serial provides the ability to specify a serial
                           number for the disk device.
@@ -5553,6 +6437,11 @@ var CRDsValidation map[string]string = map[string]string{
                         description: If specified, disk address and its tag will be
                           provided to the guest via config drive metadata
                         type: string
+                      wwn:
+                        description: WWN is the World Wide Name of the disk device,
+                          made up of 16 hexadecimal digits. Only allowed for disks
+                          and cdroms on the sata or scsi bus.
+                        type: string
                     required:
                     - name
                     type: object
@@ -6560,9 +7449,16 @@ var CRDsValidation map[string]string = map[string]string{
                     Serial console access will not be available if set to false. Defaults
                     to true.
                   type: boolean
+                autoattachVSOCK:
+                  description: Whether to attach a VSOCK device to the vmi, through
+                    which the host can talk to processes in the guest without any
+                    network. The CID of the device is reported in the status of the
+                    vmi. Defaults to false.
+                  type: boolean
                 blockMultiQueue:
                   description: Whether or not to enable virtio multi-queue for block
-                    devices. Defaults to false.
+                    devices. The number of queues equals the number of vCPUs, unless
+                    a disk sets its own queue count. Defaults to false.
                   type: boolean
                 clientPassthrough:
                   description: To configure and access client devices such as redirecting
@@ -6611,7 +7507,8 @@ var CRDsValidation map[string]string = map[string]string{
                         type: integer
                       cache:
                         description: 'Cache specifies which kvm disk cache mode should
-                          be used. Supported values are: CacheNone, CacheWriteThrough.'
+                          be used. Supported values are: CacheNone, CacheWriteThrough,
+                          CacheWriteBack.'
                         type: string
                       cdrom:
                         description: Attach a volume as a cdrom to the vmi.
@@ -6634,12 +7531,18 @@ var CRDsValidation map[string]string = map[string]string{
                           have an exclusive IO Thread. Enabling this implies useIOThreads
                           = true. Defaults to false.
                         type: boolean
+                      discard:
+                        description: 'Discard specifies if discard requests of the
+                          guest are passed to the storage of the disk. Supported values
+                          are: unmap, ignore. Defaults to unmap, or to ignore for
+                          preallocated volumes. Not supported for cdroms and floppies.'
+                        type: string
                       disk:
                         description: Attach a volume as a disk to the vmi.
                         properties:
                           bus:
                             description: 'Bus indicates the type of disk device to
-                              emulate. supported values: virtio, sata, scsi.'
+                              emulate. supported values: virtio, sata, scsi, nvme.'
                             type: string
                           pciAddress:
                             description: 'If specified, the virtual disk will be placed
@@ -6666,6 +7569,49 @@ var CRDsValidation map[string]string = map[string]string{
                         description: 'IO specifies which QEMU disk IO mode should
                           be used. Supported values are: native, default, threads.'
                         type: string
+                      ioTune:
+                        description: IOTune limits the throughput and the IOPS of
+                          the disk.
+                        properties:
+                          readBytesPerSec:
+                            anyOf:
+                            - type: integer
+                            - type: string
+                            description: ReadBytesPerSec limits the bytes read per
+                              second.
+                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                            x-kubernetes-int-or-string: true
+                          readIOPS:
+                            description: ReadIOPS limits the read operations per second.
+                            format: int64
+                            type: integer
+                          totalBytesPerSec:
+                            anyOf:
+                            - type: integer
+                            - type: string
+                            description: TotalBytesPerSec limits the bytes read and
+                              written per second, e.g. 100Mi.
+                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                            x-kubernetes-int-or-string: true
+                          totalIOPS:
+                            description: TotalIOPS limits the read and write operations
+                              per second.
+                            format: int64
+                            type: integer
+                          writeBytesPerSec:
+                            anyOf:
+                            - type: integer
+                            - type: string
+                            description: WriteBytesPerSec limits the bytes written
+                              per second.
+                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                            x-kubernetes-int-or-string: true
+                          writeIOPS:
+                            description: WriteIOPS limits the write operations per
+                              second.
+                            format: int64
+                            type: integer
+                        type: object
                       lun:
                         description: Attach a volume as a LUN to the vmi.
                         properties:
@@ -6676,10 +7622,22 @@ var CRDsValidation map[string]string = map[string]string{
                           readonly:
                             description: ReadOnly. Defaults to false.
                             type: boolean
+                          reservation:
+                            description: Reservation lets the guest issue SCSI-3 persistent
+                              reservation commands to the LUN, e.g. for the shared
+                              disks of failover clusters. Requires the scsi bus. Defaults
+                              to false.
+                            type: boolean
                         type: object
                       name:
                         description: Name is the device name
                         type: string
+                      queues:
+                        description: Queues sets the number of virtio-blk queues of
+                          this disk, overriding blockMultiQueue. Only allowed for
+                          disks on the virtio bus. Must not exceed the number of vCPUs.
+                        format: int32
+                        type: integer
                       serial:
                         description: Serial provides the ability to specify a serial
                           number for the disk device.
@@ -6688,6 +7646,11 @@ var CRDsValidation map[string]string = map[string]string{
                         description: If specified, disk address and its tag will be
                           provided to the guest via config drive metadata
                         type: string
+                      wwn:
+                        description: WWN is the World Wide Name of the disk device,
+                          made up of 16 hexadecimal digits. Only allowed for disks
+                          and cdroms on the sata or scsi bus.
+                        type: string
                     required:
                     - name
                     type: object
@@ -6709,6 +7672,11 @@ var CRDsValidation map[string]string = map[string]string{
                     type: object
                   type: array
                   x-kubernetes-list-type: atomic
+                freePageReporting:
+                  description: Whether the guest reports the memory pages it freed
+                    to the host through the Memory balloon device, so that they can
+                    be reclaimed. Defaults to true if the memory of the vmi is overcommitted.
+                  type: boolean
                 gpus:
                   description: Whether to attach a GPU device to the vmi.
                   items:
@@ -6719,6 +7687,33 @@ var CRDsValidation map[string]string = map[string]string{
                         description: Name of the GPU device as exposed by a device
                           plugin
                         type: string
+                      virtualGPUOptions:
+                        description: VirtualGPUOptions configures vGPU (mediated device)
+                          specific options. It is ignored for GPUs which are passed
+                          through as PCI devices.
+                        properties:
+                          display:
+                            description: Display configures the display adapter backed
+                              by the vGPU.
+                            properties:
+                              enabled:
+                                description: Enabled determines if a display adapter
+                                  backed by the vGPU should be enabled or disabled
+                                  on the guest. Defaults to true.
+                                type: boolean
+                              ramFB:
+                                description: RamFB enables a boot framebuffer which
+                                  is used until the guest OS loads a real GPU driver.
+                                  Defaults to true.
+                                properties:
+                                  enabled:
+                                    description: Enabled determines if the feature
+                                      should be enabled or disabled on the guest.
+                                      Defaults to true.
+                                    type: boolean
+                                type: object
+                            type: object
+                        type: object
                     required:
                     - deviceName
                     - name
@@ -6766,10 +7761,62 @@ var CRDsValidation map[string]string = map[string]string{
                     to the vmi.
                   items:
                     properties:
-                      bootOrder:
-                        description: BootOrder is an integer value > 0, used to determine
-                          ordering of boot devices. Lower values take precedence.
-                          Each interface or disk that has a boot order must have a
+                      bandwidth:
+                        description: Bandwidth limits the traffic of the interface.
+                          Only allowed for bridge and masquerade interfaces.
+                        properties:
+                          egress:
+                            description: Egress limits the traffic sent by the guest.
+                            properties:
+                              burst:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Burst is the amount of bytes which can
+                                  exceed the rate at once, e.g. 1Mi. Defaults to the
+                                  traffic of 100ms at the rate, but at least 64Ki.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              rate:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Rate is the average rate in bits per
+                                  second, e.g. 100M.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                            required:
+                            - rate
+                            type: object
+                          ingress:
+                            description: Ingress limits the traffic received by the
+                              guest.
+                            properties:
+                              burst:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Burst is the amount of bytes which can
+                                  exceed the rate at once, e.g. 1Mi. Defaults to the
+                                  traffic of 100ms at the rate, but at least 64Ki.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              rate:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Rate is the average rate in bits per
+                                  second, e.g. 100M.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                            required:
+                            - rate
+                            type: object
+                        type: object
+                      bootOrder:
+                        description: BootOrder is an integer value > 0, used to determine
+                          ordering of boot devices. Lower values take precedence.
+                          Each interface or disk that has a boot order must have a
                           unique value. Interfaces without a boot order are not tried.
                         type: integer
                       bridge:
@@ -6862,6 +7909,12 @@ var CRDsValidation map[string]string = map[string]string{
                           - port
                           type: object
                         type: array
+                      queues:
+                        description: Queues sets the number of vhost queues of this
+                          interface, overriding networkInterfaceMultiqueue. Only allowed
+                          for virtio interfaces. Must not exceed the number of vCPUs.
+                        format: int32
+                        type: integer
                       slirp:
                         type: object
                       sriov:
@@ -6870,20 +7923,48 @@ var CRDsValidation map[string]string = map[string]string{
                         description: If specified, the virtual network interface address
                           and its tag will be provided to the guest via config drive
                         type: string
+                      vhostuser:
+                        description: InterfaceVhostUser connects the interface to
+                          a userspace dataplane (e.g. OVS-DPDK or VPP) over a vhost-user
+                          socket. QEMU creates the socket in server mode, named after
+                          the interface, in the vhostuser directory shared by the
+                          virt-launcher pod, and the dataplane connects to it. Requires
+                          a Multus network and hugepages backed guest memory.
+                        type: object
                     required:
                     - name
                     type: object
                   type: array
+                logSerialConsole:
+                  description: Whether to log the output of the auto-attached serial
+                    console to the guest-console-log container of the virt-launcher
+                    pod, where it can be read with kubectl logs. Not relevant if autoattachSerialConsole
+                    is false. Defaults to false.
+                  type: boolean
                 networkInterfaceMultiqueue:
                   description: If specified, virtual network interfaces configured
                     with a virtio bus will also enable the vhost multiqueue feature
                     for network devices. The number of queues created depends on additional
                     factors of the VirtualMachineInstance, like the number of guest
-                    CPUs.
+                    CPUs. Interfaces which set their own queue count are not affected.
                   type: boolean
                 rng:
                   description: Whether to have random number generator from host
                   type: object
+                sound:
+                  description: Whether to emulate a sound device.
+                  properties:
+                    model:
+                      description: 'We only support ich9 or ac97. If SoundDevice is
+                        not set: No sound card is emulated. If SoundDevice is set
+                        but Model is not: ich9'
+                      type: string
+                    name:
+                      description: User's defined name for this sound device
+                      type: string
+                  required:
+                  - name
+                  type: object
                 useVirtioTransitional:
                   description: Fall back to legacy virtio 0.9 support if virtio bus
                     is selected on devices. This is helpful for old machines like
@@ -7081,6 +8162,16 @@ var CRDsValidation map[string]string = map[string]string{
                           type: boolean
                       type: object
                   type: object
+                hypervPassthrough:
+                  description: HypervPassthrough enables all the Hyper-V enlightenments
+                    which are supported by the host. It can't be combined with hyperv.
+                    VMIs with Hyper-V passthrough are not live migratable.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the Hyper-V passthrough mode
+                        should be used. Defaults to true.
+                      type: boolean
+                  type: object
                 kvm:
                   description: Configure how KVM presence is exposed to the guest.
                   properties:
@@ -7112,6 +8203,18 @@ var CRDsValidation map[string]string = map[string]string{
             firmware:
               description: Firmware.
               properties:
+                bootMenu:
+                  description: BootMenu shows a menu while booting, in which the device
+                    to boot from can be picked in the console, instead of booting
+                    the devices in their boot order.
+                  properties:
+                    timeout:
+                      description: Timeout is how long the boot menu waits for a pick,
+                        in milliseconds, before it boots the first device of the boot
+                        order. At most 65535. Defaults to the timeout of the firmware.
+                      format: int32
+                      type: integer
+                  type: object
                 bootloader:
                   description: Settings to control the bootloader that is used.
                   properties:
@@ -7210,6 +8313,25 @@ var CRDsValidation map[string]string = map[string]string{
                       type: string
                   type: object
               type: object
+            qemuArgs:
+              description: QEMUArgs are appended to the QEMU command line of the vmi.
+                Requires the QEMUArgs feature gate, only arguments which are allowed
+                in the KubeVirt configuration are accepted.
+              items:
+                description: QEMUArg is an argument which is appended to the QEMU
+                  command line.
+                properties:
+                  name:
+                    description: Name of the argument, including the leading dash,
+                      e.g. "-fw_cfg".
+                    type: string
+                  value:
+                    description: Value of the argument, e.g. "name=opt/com.example/config,string=value".
+                    type: string
+                required:
+                - name
+                type: object
+              type: array
             resources:
               description: Resources describes the Compute Resources required by this
                 vmi.
@@ -7246,7 +8368,9 @@ var CRDsValidation map[string]string = map[string]string{
           type: object
         evictionStrategy:
           description: EvictionStrategy can be set to "LiveMigrate" if the VirtualMachineInstance
-            should be migrated instead of shut-off in case of a node drain.
+            should be migrated instead of shut-off in case of a node drain, or to
+            "LiveMigrateIfPossible" if it should be migrated when it is live-migratable
+            and evicted otherwise.
           type: string
         hostname:
           description: Specifies the hostname of the vmi If not specified, the hostname
@@ -7384,6 +8508,21 @@ var CRDsValidation map[string]string = map[string]string{
                     description: Select the default network and add it to the multus-cni.io/default-network
                       annotation.
                     type: boolean
+                  ipAddresses:
+                    description: IPAddresses requests static IP addresses in CIDR
+                      notation, e.g. 192.168.1.10/24, for the interface. They are
+                      passed to the "ips" capability of the CNI plugin. Not supported
+                      on the default network.
+                    items:
+                      type: string
+                    type: array
+                    x-kubernetes-list-type: atomic
+                  ipPool:
+                    description: IPPool references a pool of the IPAM plugin of the
+                      network from which the addresses of the interface are assigned.
+                      It is passed as the "ipPool" CNI arg. Not supported on the default
+                      network.
+                    type: string
                   networkName:
                     description: 'References to a NetworkAttachmentDefinition CRD
                       object. Format: <networkName>, <namespace>/<networkName>. If
@@ -7420,7 +8559,8 @@ var CRDsValidation map[string]string = map[string]string{
           type: object
         priorityClassName:
           description: If specified, indicates the pod's priority. If not specified,
-            the pod priority will be default or zero if there is no default.
+            the pod priority will be default or zero if there is no default. VMIs
+            with a higher priority are evacuated first when a node is drained.
           type: string
         readinessProbe:
           description: 'Periodic probe of VirtualMachineInstance service readiness.
@@ -7549,6 +8689,126 @@ var CRDsValidation map[string]string = map[string]string{
           description: StartStrategy can be set to "Paused" if Virtual Machine should
             be started in paused state.
           type: string
+        startupProbe:
+          description: 'Probe indicating that the VirtualMachineInstance has successfully
+            started. Liveness and readiness probes are not executed until it succeeds.
+            VirtualmachineInstances will be stopped if the probe fails. Cannot be
+            updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+          properties:
+            exec:
+              description: One and only one of the following should be specified.
+                Exec specifies the action to take, it will be executed on the guest
+                through the qemu-guest-agent. If the guest agent is not available,
+                this probe will fail.
+              properties:
+                command:
+                  description: Command is the command line to execute inside the container,
+                    the working directory for the command  is root ('/') in the container's
+                    filesystem. The command is simply exec'd, it is not run inside
+                    a shell, so traditional shell instructions ('|', etc) won't work.
+                    To use a shell, you need to explicitly call out to that shell.
+                    Exit status of 0 is treated as live/healthy and non-zero is unhealthy.
+                  items:
+                    type: string
+                  type: array
+              type: object
+            failureThreshold:
+              description: Minimum consecutive failures for the probe to be considered
+                failed after having succeeded. Defaults to 3. Minimum value is 1.
+              format: int32
+              type: integer
+            guestAgentPing:
+              description: GuestAgentPing contacts the qemu-guest-agent for availability
+                checks.
+              type: object
+            httpGet:
+              description: HTTPGet specifies the http request to perform.
+              properties:
+                host:
+                  description: Host name to connect to, defaults to the pod IP. You
+                    probably want to set "Host" in httpHeaders instead.
+                  type: string
+                httpHeaders:
+                  description: Custom headers to set in the request. HTTP allows repeated
+                    headers.
+                  items:
+                    description: HTTPHeader describes a custom header to be used in
+                      HTTP probes
+                    properties:
+                      name:
+                        description: The header field name
+                        type: string
+                      value:
+                        description: The header field value
+                        type: string
+                    required:
+                    - name
+                    - value
+                    type: object
+                  type: array
+                path:
+                  description: Path to access on the HTTP server.
+                  type: string
+                port:
+                  anyOf:
+                  - type: integer
+                  - type: string
+                  description: Name or number of the port to access on the container.
+                    Number must be in the range 1 to 65535. Name must be an IANA_SVC_NAME.
+                  x-kubernetes-int-or-string: true
+                scheme:
+                  description: Scheme to use for connecting to the host. Defaults
+                    to HTTP.
+                  type: string
+              required:
+              - port
+              type: object
+            initialDelaySeconds:
+              description: 'Number of seconds after the VirtualMachineInstance has
+                started before liveness probes are initiated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+              format: int32
+              type: integer
+            periodSeconds:
+              description: How often (in seconds) to perform the probe. Default to
+                10 seconds. Minimum value is 1.
+              format: int32
+              type: integer
+            successThreshold:
+              description: Minimum consecutive successes for the probe to be considered
+                successful after having failed. Defaults to 1. Must be 1 for liveness.
+                Minimum value is 1.
+              format: int32
+              type: integer
+            tcpSocket:
+              description: 'TCPSocket specifies an action involving a TCP port. TCP
+                hooks not yet supported TODO: implement a realistic TCP lifecycle
+                hook'
+              properties:
+                host:
+                  description: 'Optional: Host name to connect to, defaults to the
+                    pod IP.'
+                  type: string
+                port:
+                  anyOf:
+                  - type: integer
+                  - type: string
+                  description: Number or name of the port to access on the container.
+                    Number must be in the range 1 to 65535. Name must be an IANA_SVC_NAME.
+                  x-kubernetes-int-or-string: true
+              required:
+              - port
+              type: object
+            timeoutSeconds:
+              description: 'Number of seconds after which the probe times out. For
+                exec probes the timeout fails the probe but does not terminate the
+                command running on the guest. This means a blocking command can result
+                in an increasing load on the guest. A small buffer will be added to
+                the resulting workload exec probe to compensate for delays caused
+                by the qemu guest exec mechanism. Defaults to 1 second. Minimum value
+                is 1. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+              format: int32
+              type: integer
+          type: object
         subdomain:
           description: If specified, the fully qualified vmi hostname will be "<hostname>.<subdomain>.<pod
             namespace>.svc.<cluster domain>". If not specified, the vmi will not have
@@ -7721,6 +8981,27 @@ var CRDsValidation map[string]string = map[string]string{
                     description: ImagePullSecret is the name of the Docker registry
                       secret required to pull the image. The secret must already exist.
                     type: string
+                  overlay:
+                    description: Overlay configures the writable copy-on-write overlay,
+                      which uses the image as its backing file. By default the overlay
+                      is stored in an emptyDir and has the size of the image.
+                    properties:
+                      capacity:
+                        anyOf:
+                        - type: integer
+                        - type: string
+                        description: Capacity is the size of the disk the guest sees,
+                          which has to be at least the size of the image. Defaults
+                          to the size of the image.
+                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                        x-kubernetes-int-or-string: true
+                      claimName:
+                        description: ClaimName is the name of a filesystem PersistentVolumeClaim
+                          in the namespace of the VirtualMachineInstance to store
+                          the overlay on, which keeps the writes of the guest across
+                          restarts. Defaults to an emptyDir.
+                        type: string
+                    type: object
                   path:
                     description: Path defines the path to disk file in the container
                     type: string
@@ -7886,6 +9167,17 @@ var CRDsValidation map[string]string = map[string]string{
                 - path
                 - type
                 type: object
+              memoryDump:
+                description: MemoryDump is a PersistentVolumeClaim which is hotplugged
+                  to the virt-launcher pod to receive a memory dump of the VirtualMachineInstance.
+                properties:
+                  claimName:
+                    description: ClaimName is the name of a PersistentVolumeClaim
+                      in the same namespace as the VirtualMachineInstance.
+                    type: string
+                required:
+                - claimName
+                type: object
               name:
                 description: 'Volume''s name. Must be a DNS_LABEL and unique within
                   the vmi. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
@@ -7974,6 +9266,11 @@ var CRDsValidation map[string]string = map[string]string{
       description: Status is the high level overview of how the VirtualMachineInstance
         is doing. It contains information available to controllers and users.
       properties:
+        VSOCKCID:
+          description: VSOCKCID is the guest context ID of the VSOCK device, unique
+            in the cluster. It is only set when autoattachVSOCK is enabled.
+          format: int32
+          type: integer
         activePods:
           additionalProperties:
             type: string
@@ -8012,10 +9309,38 @@ var CRDsValidation map[string]string = map[string]string{
             meant to be used by KubeVirt core components only and can't be set or
             modified by users.
           type: string
+        fsFreeSpace:
+          description: FSFreeSpace is the free space of the guest filesystems reported
+            by the guest agent
+          items:
+            description: VirtualMachineInstanceFileSystemFreeSpace represents the
+              free space of a guest filesystem
+            properties:
+              fileSystemType:
+                type: string
+              freeBytes:
+                format: int64
+                type: integer
+              mountPoint:
+                type: string
+              totalBytes:
+                format: int64
+                type: integer
+            required:
+            - fileSystemType
+            - freeBytes
+            - mountPoint
+            - totalBytes
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
         fsFreezeStatus:
           description: FSFreezeStatus is the state of the fs of the guest it can be
             either frozen or thawed
           type: string
+        guestHostname:
+          description: GuestHostname is the hostname reported by the guest agent
+          type: string
         guestOSInfo:
           description: Guest OS Information
           properties:
@@ -8106,6 +9431,37 @@ var CRDsValidation map[string]string = map[string]string{
               description: Lets us know if the vmi is currently running pre or post
                 copy migration
               type: string
+            progress:
+              description: The progress of the migration, updated periodically while
+                the migration is running
+              properties:
+                dirtyPageRate:
+                  description: The rate at which the guest dirties its memory in pages
+                    per second
+                  format: int64
+                  type: integer
+                iteration:
+                  description: The number of passes over the guest memory, a high
+                    count means that the guest dirties its memory faster than it can
+                    be transferred
+                  format: int64
+                  type: integer
+                memoryRemainingBytes:
+                  description: The amount of guest memory which still has to be transferred
+                    in bytes
+                  format: int64
+                  type: integer
+                transferredBytes:
+                  description: The amount of data transferred to the target so far
+                    in bytes
+                  format: int64
+                  type: integer
+                updateTimestamp:
+                  description: The time the progress was last updated
+                  format: date-time
+                  nullable: true
+                  type: string
+              type: object
             sourceNode:
               description: The source node that the VMI originated on
               type: string
@@ -8139,6 +9495,11 @@ var CRDsValidation map[string]string = map[string]string{
         migrationTransport:
           description: This represents the migration transport
           type: string
+        nodeBootID:
+          description: NodeBootID is the boot ID of the node the VirtualMachineInstance
+            is currently running on. It allows detecting whether the node rebooted
+            while the VirtualMachineInstance was running.
+          type: string
         nodeName:
           description: NodeName is the name where the VirtualMachineInstance is currently
             running.
@@ -8177,6 +9538,36 @@ var CRDsValidation map[string]string = map[string]string{
           description: A brief CamelCase message indicating details about why the
             VMI is in this state. e.g. 'NodeUnresponsive'
           type: string
+        startupTimestamps:
+          description: StartupTimestamps holds the times at which the VirtualMachineInstance
+            passed the steps of its start
+          properties:
+            containerStarted:
+              description: ContainerStarted is the time the compute container of
+                the virt-launcher pod started
+              format: date-time
+              type: string
+            domainDefined:
+              description: DomainDefined is the time virt-handler saw the domain
+                defined in libvirt
+              format: date-time
+              type: string
+            domainRunning:
+              description: DomainRunning is the time virt-handler saw the domain
+                running
+              format: date-time
+              type: string
+            guestAgentConnected:
+              description: GuestAgentConnected is the time the guest agent connected
+                for the first time
+              format: date-time
+              type: string
+            podScheduled:
+              description: PodScheduled is the time the virt-launcher pod was scheduled
+                to a node
+              format: date-time
+              type: string
+          type: object
         topologyHints:
           properties:
             tscFrequency:
@@ -8193,6 +9584,29 @@ var CRDsValidation map[string]string = map[string]string{
             description: VolumeStatus represents information about the status of volumes
               attached to the VirtualMachineInstance.
             properties:
+              disk:
+                description: Disk is the effective configuration of the disk of the
+                  volume in the domain
+                properties:
+                  backingFormat:
+                    description: BackingFormat is the format of the backing image
+                      of the disk, if it has one
+                    type: string
+                  cache:
+                    description: Cache is the effective cache mode of the disk
+                    type: string
+                  format:
+                    description: 'Format is the format of the disk image, eg: raw
+                      or qcow2'
+                    type: string
+                  io:
+                    description: IO is the effective IO mode of the disk
+                    type: string
+                  type:
+                    description: Type is the type of the disk source, either block
+                      or file
+                    type: string
+                type: object
               hotplugVolume:
                 description: If the volume is hotplug, this will contain the hotplug
                   status.
@@ -8206,6 +9620,27 @@ var CRDsValidation map[string]string = map[string]string{
                       the volume to the node.
                     type: string
                 type: object
+              memoryDumpVolume:
+                description: If the volume is a memory dump volume, this will contain
+                  the memory dump info.
+                properties:
+                  claimName:
+                    description: ClaimName is the name of the PersistentVolumeClaim
+                      the memory dump is written to
+                    type: string
+                  endTimestamp:
+                    description: EndTimestamp is the time when the memory dump completed
+                    format: date-time
+                    type: string
+                  startTimestamp:
+                    description: StartTimestamp is the time when the memory dump started
+                    format: date-time
+                    type: string
+                  targetFileName:
+                    description: TargetFileName is the name of the memory dump file
+                      on the PersistentVolumeClaim
+                    type: string
+                type: object
               message:
                 description: Message is a detailed message about the current hotplug
                   volume phase
@@ -8224,6 +9659,10 @@ var CRDsValidation map[string]string = map[string]string{
                       type: string
                     type: array
                     x-kubernetes-list-type: atomic
+                  cacheMode:
+                    description: CacheMode is the cache mode of the disks on the PVC
+                      which don't set one, as configured for its storage class
+                    type: string
                   capacity:
                     additionalProperties:
                       anyOf:
@@ -8234,6 +9673,10 @@ var CRDsValidation map[string]string = map[string]string{
                     description: Capacity represents the capacity set on the corresponding
                       PVC spec
                     type: object
+                  filesystemOverhead:
+                    description: FilesystemOverhead is the fraction of a filesystem
+                      PVC which the disk image leaves free for the filesystem
+                    type: string
                   preallocated:
                     description: Preallocated indicates if the PVC's storage is preallocated
                       or not
@@ -8546,9 +9989,16 @@ var CRDsValidation map[string]string = map[string]string{
                     Serial console access will not be available if set to false. Defaults
                     to true.
                   type: boolean
+                autoattachVSOCK:
+                  description: Whether to attach a VSOCK device to the vmi, through
+                    which the host can talk to processes in the guest without any
+                    network. The CID of the device is reported in the status of the
+                    vmi. Defaults to false.
+                  type: boolean
                 blockMultiQueue:
                   description: Whether or not to enable virtio multi-queue for block
-                    devices. Defaults to false.
+                    devices. The number of queues equals the number of vCPUs, unless
+                    a disk sets its own queue count. Defaults to false.
                   type: boolean
                 clientPassthrough:
                   description: To configure and access client devices such as redirecting
@@ -8597,7 +10047,8 @@ var CRDsValidation map[string]string = map[string]string{
                         type: integer
                       cache:
                         description: 'Cache specifies which kvm disk cache mode should
-                          be used. Supported values are: CacheNone, CacheWriteThrough.'
+                          be used. Supported values are: CacheNone, CacheWriteThrough,
+                          CacheWriteBack.'
                         type: string
                       cdrom:
                         description: Attach a volume as a cdrom to the vmi.
@@ -8620,12 +10071,18 @@ var CRDsValidation map[string]string = map[string]string{
                           have an exclusive IO Thread. Enabling this implies useIOThreads
                           = true. Defaults to false.
                         type: boolean
+                      discard:
+                        description: 'Discard specifies if discard requests of the
+                          guest are passed to the storage of the disk. Supported values
+                          are: unmap, ignore. Defaults to unmap, or to ignore for
+                          preallocated volumes. Not supported for cdroms and floppies.'
+                        type: string
                       disk:
                         description: Attach a volume as a disk to the vmi.
                         properties:
                           bus:
                             description: 'Bus indicates the type of disk device to
-                              emulate. supported values: virtio, sata, scsi.'
+                              emulate. supported values: virtio, sata, scsi, nvme.'
                             type: string
                           pciAddress:
                             description: 'If specified, the virtual disk will be placed
@@ -8652,6 +10109,49 @@ var CRDsValidation map[string]string = map[string]string{
                         description: 'IO specifies which QEMU disk IO mode should
                           be used. Supported values are: native, default, threads.'
                         type: string
+                      ioTune:
+                        description: IOTune limits the throughput and the IOPS of
+                          the disk.
+                        properties:
+                          readBytesPerSec:
+                            anyOf:
+                            - type: integer
+                            - type: string
+                            description: ReadBytesPerSec limits the bytes read per
+                              second.
+                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                            x-kubernetes-int-or-string: true
+                          readIOPS:
+                            description: ReadIOPS limits the read operations per second.
+                            format: int64
+                            type: integer
+                          totalBytesPerSec:
+                            anyOf:
+                            - type: integer
+                            - type: string
+                            description: TotalBytesPerSec limits the bytes read and
+                              written per second, e.g. 100Mi.
+                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                            x-kubernetes-int-or-string: true
+                          totalIOPS:
+                            description: TotalIOPS limits the read and write operations
+                              per second.
+                            format: int64
+                            type: integer
+                          writeBytesPerSec:
+                            anyOf:
+                            - type: integer
+                            - type: string
+                            description: WriteBytesPerSec limits the bytes written
+                              per second.
+                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                            x-kubernetes-int-or-string: true
+                          writeIOPS:
+                            description: WriteIOPS limits the write operations per
+                              second.
+                            format: int64
+                            type: integer
+                        type: object
                       lun:
                         description: Attach a volume as a LUN to the vmi.
                         properties:
@@ -8662,10 +10162,22 @@ var CRDsValidation map[string]string = map[string]string{
                           readonly:
                             description: ReadOnly. Defaults to false.
                             type: boolean
+                          reservation:
+                            description: Reservation lets the guest issue SCSI-3 persistent
+                              reservation commands to the LUN, e.g. for the shared
+                              disks of failover clusters. Requires the scsi bus. Defaults
+                              to false.
+                            type: boolean
                         type: object
                       name:
                         description: Name is the device name
                         type: string
+                      queues:
+                        description: Queues sets the number of virtio-blk queues of
+                          this disk, overriding blockMultiQueue. Only allowed for
+                          disks on the virtio bus. Must not exceed the number of vCPUs.
+                        format: int32
+                        type: integer
                       serial:
                         description: Serial provides the ability to specify a serial
                           number for the disk device.
@@ -8674,6 +10186,11 @@ var CRDsValidation map[string]string = map[string]string{
                         description: If specified, disk address and its tag will be
                           provided to the guest via config drive metadata
                         type: string
+                      wwn:
+                        description: WWN is the World Wide Name of the disk device,
+                          made up of 16 hexadecimal digits. Only allowed for disks
+                          and cdroms on the sata or scsi bus.
+                        type: string
                     required:
                     - name
                     type: object
@@ -8695,6 +10212,11 @@ var CRDsValidation map[string]string = map[string]string{
                     type: object
                   type: array
                   x-kubernetes-list-type: atomic
+                freePageReporting:
+                  description: Whether the guest reports the memory pages it freed
+                    to the host through the Memory balloon device, so that they can
+                    be reclaimed. Defaults to true if the memory of the vmi is overcommitted.
+                  type: boolean
                 gpus:
                   description: Whether to attach a GPU device to the vmi.
                   items:
@@ -8705,8 +10227,35 @@ var CRDsValidation map[string]string = map[string]string{
                         description: Name of the GPU device as exposed by a device
                           plugin
                         type: string
-                    required:
-                    - deviceName
+                      virtualGPUOptions:
+                        description: VirtualGPUOptions configures vGPU (mediated device)
+                          specific options. It is ignored for GPUs which are passed
+                          through as PCI devices.
+                        properties:
+                          display:
+                            description: Display configures the display adapter backed
+                              by the vGPU.
+                            properties:
+                              enabled:
+                                description: Enabled determines if a display adapter
+                                  backed by the vGPU should be enabled or disabled
+                                  on the guest. Defaults to true.
+                                type: boolean
+                              ramFB:
+                                description: RamFB enables a boot framebuffer which
+                                  is used until the guest OS loads a real GPU driver.
+                                  Defaults to true.
+                                properties:
+                                  enabled:
+                                    description: Enabled determines if the feature
+                                      should be enabled or disabled on the guest.
+                                      Defaults to true.
+                                    type: boolean
+                                type: object
+                            type: object
+                        type: object
+                    required:
+                    - deviceName
                     - name
                     type: object
                   type: array
@@ -8752,6 +10301,58 @@ var CRDsValidation map[string]string = map[string]string{
                     to the vmi.
                   items:
                     properties:
+                      bandwidth:
+                        description: Bandwidth limits the traffic of the interface.
+                          Only allowed for bridge and masquerade interfaces.
+                        properties:
+                          egress:
+                            description: Egress limits the traffic sent by the guest.
+                            properties:
+                              burst:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Burst is the amount of bytes which can
+                                  exceed the rate at once, e.g. 1Mi. Defaults to the
+                                  traffic of 100ms at the rate, but at least 64Ki.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              rate:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Rate is the average rate in bits per
+                                  second, e.g. 100M.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                            required:
+                            - rate
+                            type: object
+                          ingress:
+                            description: Ingress limits the traffic received by the
+                              guest.
+                            properties:
+                              burst:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Burst is the amount of bytes which can
+                                  exceed the rate at once, e.g. 1Mi. Defaults to the
+                                  traffic of 100ms at the rate, but at least 64Ki.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              rate:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Rate is the average rate in bits per
+                                  second, e.g. 100M.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                            required:
+                            - rate
+                            type: object
+                        type: object
                       bootOrder:
                         description: BootOrder is an integer value > 0, used to determine
                           ordering of boot devices. Lower values take precedence.
@@ -8848,6 +10449,12 @@ var CRDsValidation map[string]string = map[string]string{
                           - port
                           type: object
                         type: array
+                      queues:
+                        description: Queues sets the number of vhost queues of this
+                          interface, overriding networkInterfaceMultiqueue. Only allowed
+                          for virtio interfaces. Must not exceed the number of vCPUs.
+                        format: int32
+                        type: integer
                       slirp:
                         type: object
                       sriov:
@@ -8856,20 +10463,48 @@ var CRDsValidation map[string]string = map[string]string{
                         description: If specified, the virtual network interface address
                           and its tag will be provided to the guest via config drive
                         type: string
+                      vhostuser:
+                        description: InterfaceVhostUser connects the interface to
+                          a userspace dataplane (e.g. OVS-DPDK or VPP) over a vhost-user
+                          socket. QEMU creates the socket in server mode, named after
+                          the interface, in the vhostuser directory shared by the
+                          virt-launcher pod, and the dataplane connects to it. Requires
+                          a Multus network and hugepages backed guest memory.
+                        type: object
                     required:
                     - name
                     type: object
                   type: array
+                logSerialConsole:
+                  description: Whether to log the output of the auto-attached serial
+                    console to the guest-console-log container of the virt-launcher
+                    pod, where it can be read with kubectl logs. Not relevant if autoattachSerialConsole
+                    is false. Defaults to false.
+                  type: boolean
                 networkInterfaceMultiqueue:
                   description: If specified, virtual network interfaces configured
                     with a virtio bus will also enable the vhost multiqueue feature
                     for network devices. The number of queues created depends on additional
                     factors of the VirtualMachineInstance, like the number of guest
-                    CPUs.
+                    CPUs. Interfaces which set their own queue count are not affected.
                   type: boolean
                 rng:
                   description: Whether to have random number generator from host
                   type: object
+                sound:
+                  description: Whether to emulate a sound device.
+                  properties:
+                    model:
+                      description: 'We only support ich9 or ac97. If SoundDevice is
+                        not set: No sound card is emulated. If SoundDevice is set
+                        but Model is not: ich9'
+                      type: string
+                    name:
+                      description: User's defined name for this sound device
+                      type: string
+                  required:
+                  - name
+                  type: object
                 useVirtioTransitional:
                   description: Fall back to legacy virtio 0.9 support if virtio bus
                     is selected on devices. This is helpful for old machines like
@@ -9067,6 +10702,16 @@ var CRDsValidation map[string]string = map[string]string{
                           type: boolean
                       type: object
                   type: object
+                hypervPassthrough:
+                  description: HypervPassthrough enables all the Hyper-V enlightenments
+                    which are supported by the host. It can't be combined with hyperv.
+                    VMIs with Hyper-V passthrough are not live migratable.
+                  properties:
+                    enabled:
+                      description: Enabled determines if the Hyper-V passthrough mode
+                        should be used. Defaults to true.
+                      type: boolean
+                  type: object
                 kvm:
                   description: Configure how KVM presence is exposed to the guest.
                   properties:
@@ -9098,6 +10743,18 @@ var CRDsValidation map[string]string = map[string]string{
             firmware:
               description: Firmware.
               properties:
+                bootMenu:
+                  description: BootMenu shows a menu while booting, in which the device
+                    to boot from can be picked in the console, instead of booting
+                    the devices in their boot order.
+                  properties:
+                    timeout:
+                      description: Timeout is how long the boot menu waits for a pick,
+                        in milliseconds, before it boots the first device of the boot
+                        order. At most 65535. Defaults to the timeout of the firmware.
+                      format: int32
+                      type: integer
+                  type: object
                 bootloader:
                   description: Settings to control the bootloader that is used.
                   properties:
@@ -9196,6 +10853,25 @@ var CRDsValidation map[string]string = map[string]string{
                       type: string
                   type: object
               type: object
+            qemuArgs:
+              description: QEMUArgs are appended to the QEMU command line of the vmi.
+                Requires the QEMUArgs feature gate, only arguments which are allowed
+                in the KubeVirt configuration are accepted.
+              items:
+                description: QEMUArg is an argument which is appended to the QEMU
+                  command line.
+                properties:
+                  name:
+                    description: Name of the argument, including the leading dash,
+                      e.g. "-fw_cfg".
+                    type: string
+                  value:
+                    description: Value of the argument, e.g. "name=opt/com.example/config,string=value".
+                    type: string
+                required:
+                - name
+                type: object
+              type: array
             resources:
               description: Resources describes the Compute Resources required by this
                 vmi.
@@ -9294,6 +10970,12 @@ var CRDsValidation map[string]string = map[string]string{
       description: VirtualMachineInstance Spec contains the VirtualMachineInstance
         specification.
       properties:
+        headlessService:
+          description: Indicates that a headless Service named after the subdomain
+            of the template is created for the VirtualMachineInstances of the replica
+            set. Together with the subdomain it gives every VirtualMachineInstance
+            the DNS name <hostname>.<subdomain>.<namespace>.svc.
+          type: boolean
         paused:
           description: Indicates that the replica set is paused.
           type: boolean
@@ -10309,9 +11991,17 @@ var CRDsValidation map[string]string = map[string]string{
                             or not. Serial console access will not be available if
                             set to false. Defaults to true.
                           type: boolean
+                        autoattachVSOCK:
+                          description: Whether to attach a VSOCK device to the vmi,
+                            through which the host can talk to processes in the guest
+                            without any network. The CID of the device is reported
+                            in the status of the vmi. Defaults to false.
+                          type: boolean
                         blockMultiQueue:
                           description: Whether or not to enable virtio multi-queue
-                            for block devices. Defaults to false.
+                            for block devices. The number of queues equals the number
+                            of vCPUs, unless a disk sets its own queue count. Defaults
+                            to false.
                           type: boolean
                         clientPassthrough:
                           description: To configure and access client devices such
@@ -10364,7 +12054,7 @@ var CRDsValidation map[string]string = map[string]string{
                               cache:
                                 description: 'Cache specifies which kvm disk cache
                                   mode should be used. Supported values are: CacheNone,
-                                  CacheWriteThrough.'
+                                  CacheWriteThrough, CacheWriteBack.'
                                 type: string
                               cdrom:
                                 description: Attach a volume as a cdrom to the vmi.
@@ -10388,13 +12078,20 @@ var CRDsValidation map[string]string = map[string]string{
                                   should have an exclusive IO Thread. Enabling this
                                   implies useIOThreads = true. Defaults to false.
                                 type: boolean
+                              discard:
+                                description: 'Discard specifies if discard requests
+                                  of the guest are passed to the storage of the disk.
+                                  Supported values are: unmap, ignore. Defaults to
+                                  unmap, or to ignore for preallocated volumes. Not
+                                  supported for cdroms and floppies.'
+                                type: string
                               disk:
                                 description: Attach a volume as a disk to the vmi.
                                 properties:
                                   bus:
                                     description: 'Bus indicates the type of disk device
                                       to emulate. supported values: virtio, sata,
-                                      scsi.'
+                                      scsi, nvme.'
                                     type: string
                                   pciAddress:
                                     description: 'If specified, the virtual disk will
@@ -10422,6 +12119,50 @@ var CRDsValidation map[string]string = map[string]string{
                                   should be used. Supported values are: native, default,
                                   threads.'
                                 type: string
+                              ioTune:
+                                description: IOTune limits the throughput and the
+                                  IOPS of the disk.
+                                properties:
+                                  readBytesPerSec:
+                                    anyOf:
+                                    - type: integer
+                                    - type: string
+                                    description: ReadBytesPerSec limits the bytes
+                                      read per second.
+                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                    x-kubernetes-int-or-string: true
+                                  readIOPS:
+                                    description: ReadIOPS limits the read operations
+                                      per second.
+                                    format: int64
+                                    type: integer
+                                  totalBytesPerSec:
+                                    anyOf:
+                                    - type: integer
+                                    - type: string
+                                    description: TotalBytesPerSec limits the bytes
+                                      read and written per second, e.g. 100Mi.
+                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                    x-kubernetes-int-or-string: true
+                                  totalIOPS:
+                                    description: TotalIOPS limits the read and write
+                                      operations per second.
+                                    format: int64
+                                    type: integer
+                                  writeBytesPerSec:
+                                    anyOf:
+                                    - type: integer
+                                    - type: string
+                                    description: WriteBytesPerSec limits the bytes
+                                      written per second.
+                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                    x-kubernetes-int-or-string: true
+                                  writeIOPS:
+                                    description: WriteIOPS limits the write operations
+                                      per second.
+                                    format: int64
+                                    type: integer
+                                type: object
                               lun:
                                 description: Attach a volume as a LUN to the vmi.
                                 properties:
@@ -10433,10 +12174,23 @@ var CRDsValidation map[string]string = map[string]string{
                                   readonly:
                                     description: ReadOnly. Defaults to false.
                                     type: boolean
+                                  reservation:
+                                    description: Reservation lets the guest issue
+                                      SCSI-3 persistent reservation commands to the
+                                      LUN, e.g. for the shared disks of failover clusters.
+                                      Requires the scsi bus. Defaults to false.
+                                    type: boolean
                                 type: object
                               name:
                                 description: Name is the device name
                                 type: string
+                              queues:
+                                description: Queues sets the number of virtio-blk
+                                  queues of this disk, overriding blockMultiQueue.
+                                  Only allowed for disks on the virtio bus. Must not
+                                  exceed the number of vCPUs.
+                                format: int32
+                                type: integer
                               serial:
                                 description: Serial provides the ability to specify
                                   a serial number for the disk device.
@@ -10445,6 +12199,11 @@ var CRDsValidation map[string]string = map[string]string{
                                 description: If specified, disk address and its tag
                                   will be provided to the guest via config drive metadata
                                 type: string
+                              wwn:
+                                description: WWN is the World Wide Name of the disk
+                                  device, made up of 16 hexadecimal digits. Only allowed
+                                  for disks and cdroms on the sata or scsi bus.
+                                type: string
                             required:
                             - name
                             type: object
@@ -10466,6 +12225,12 @@ var CRDsValidation map[string]string = map[string]string{
                             type: object
                           type: array
                           x-kubernetes-list-type: atomic
+                        freePageReporting:
+                          description: Whether the guest reports the memory pages
+                            it freed to the host through the Memory balloon device,
+                            so that they can be reclaimed. Defaults to true if the
+                            memory of the vmi is overcommitted.
+                          type: boolean
                         gpus:
                           description: Whether to attach a GPU device to the vmi.
                           items:
@@ -10476,6 +12241,33 @@ var CRDsValidation map[string]string = map[string]string{
                                 description: Name of the GPU device as exposed by
                                   a device plugin
                                 type: string
+                              virtualGPUOptions:
+                                description: VirtualGPUOptions configures vGPU (mediated
+                                  device) specific options. It is ignored for GPUs
+                                  which are passed through as PCI devices.
+                                properties:
+                                  display:
+                                    description: Display configures the display adapter
+                                      backed by the vGPU.
+                                    properties:
+                                      enabled:
+                                        description: Enabled determines if a display
+                                          adapter backed by the vGPU should be enabled
+                                          or disabled on the guest. Defaults to true.
+                                        type: boolean
+                                      ramFB:
+                                        description: RamFB enables a boot framebuffer
+                                          which is used until the guest OS loads a
+                                          real GPU driver. Defaults to true.
+                                        properties:
+                                          enabled:
+                                            description: Enabled determines if the
+                                              feature should be enabled or disabled
+                                              on the guest. Defaults to true.
+                                            type: boolean
+                                        type: object
+                                    type: object
+                                type: object
                             required:
                             - deviceName
                             - name
@@ -10523,6 +12315,61 @@ var CRDsValidation map[string]string = map[string]string{
                             are added to the vmi.
                           items:
                             properties:
+                              bandwidth:
+                                description: Bandwidth limits the traffic of the interface.
+                                  Only allowed for bridge and masquerade interfaces.
+                                properties:
+                                  egress:
+                                    description: Egress limits the traffic sent by
+                                      the guest.
+                                    properties:
+                                      burst:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Burst is the amount of bytes
+                                          which can exceed the rate at once, e.g.
+                                          1Mi. Defaults to the traffic of 100ms at
+                                          the rate, but at least 64Ki.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      rate:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Rate is the average rate in bits
+                                          per second, e.g. 100M.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                    required:
+                                    - rate
+                                    type: object
+                                  ingress:
+                                    description: Ingress limits the traffic received
+                                      by the guest.
+                                    properties:
+                                      burst:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Burst is the amount of bytes
+                                          which can exceed the rate at once, e.g.
+                                          1Mi. Defaults to the traffic of 100ms at
+                                          the rate, but at least 64Ki.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      rate:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Rate is the average rate in bits
+                                          per second, e.g. 100M.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                    required:
+                                    - rate
+                                    type: object
+                                type: object
                               bootOrder:
                                 description: BootOrder is an integer value > 0, used
                                   to determine ordering of boot devices. Lower values
@@ -10623,6 +12470,13 @@ var CRDsValidation map[string]string = map[string]string{
                                   - port
                                   type: object
                                 type: array
+                              queues:
+                                description: Queues sets the number of vhost queues
+                                  of this interface, overriding networkInterfaceMultiqueue.
+                                  Only allowed for virtio interfaces. Must not exceed
+                                  the number of vCPUs.
+                                format: int32
+                                type: integer
                               slirp:
                                 type: object
                               sriov:
@@ -10632,21 +12486,52 @@ var CRDsValidation map[string]string = map[string]string{
                                   address and its tag will be provided to the guest
                                   via config drive
                                 type: string
+                              vhostuser:
+                                description: InterfaceVhostUser connects the interface
+                                  to a userspace dataplane (e.g. OVS-DPDK or VPP)
+                                  over a vhost-user socket. QEMU creates the socket
+                                  in server mode, named after the interface, in the
+                                  vhostuser directory shared by the virt-launcher
+                                  pod, and the dataplane connects to it. Requires
+                                  a Multus network and hugepages backed guest memory.
+                                type: object
                             required:
                             - name
                             type: object
                           type: array
+                        logSerialConsole:
+                          description: Whether to log the output of the auto-attached
+                            serial console to the guest-console-log container of the
+                            virt-launcher pod, where it can be read with kubectl logs.
+                            Not relevant if autoattachSerialConsole is false. Defaults
+                            to false.
+                          type: boolean
                         networkInterfaceMultiqueue:
                           description: If specified, virtual network interfaces configured
                             with a virtio bus will also enable the vhost multiqueue
                             feature for network devices. The number of queues created
                             depends on additional factors of the VirtualMachineInstance,
-                            like the number of guest CPUs.
+                            like the number of guest CPUs. Interfaces which set their
+                            own queue count are not affected.
                           type: boolean
                         rng:
                           description: Whether to have random number generator from
                             host
                           type: object
+                        sound:
+                          description: Whether to emulate a sound device.
+                          properties:
+                            model:
+                              description: 'We only support ich9 or ac97. If SoundDevice
+                                is not set: No sound card is emulated. If SoundDevice
+                                is set but Model is not: ich9'
+                              type: string
+                            name:
+                              description: User's defined name for this sound device
+                              type: string
+                          required:
+                          - name
+                          type: object
                         useVirtioTransitional:
                           description: Fall back to legacy virtio 0.9 support if virtio
                             bus is selected on devices. This is helpful for old machines
@@ -10866,6 +12751,17 @@ var CRDsValidation map[string]string = map[string]string{
                                   type: boolean
                               type: object
                           type: object
+                        hypervPassthrough:
+                          description: HypervPassthrough enables all the Hyper-V enlightenments
+                            which are supported by the host. It can't be combined
+                            with hyperv. VMIs with Hyper-V passthrough are not live
+                            migratable.
+                          properties:
+                            enabled:
+                              description: Enabled determines if the Hyper-V passthrough
+                                mode should be used. Defaults to true.
+                              type: boolean
+                          type: object
                         kvm:
                           description: Configure how KVM presence is exposed to the
                             guest.
@@ -10898,6 +12794,19 @@ var CRDsValidation map[string]string = map[string]string{
                     firmware:
                       description: Firmware.
                       properties:
+                        bootMenu:
+                          description: BootMenu shows a menu while booting, in which
+                            the device to boot from can be picked in the console,
+                            instead of booting the devices in their boot order.
+                          properties:
+                            timeout:
+                              description: Timeout is how long the boot menu waits
+                                for a pick, in milliseconds, before it boots the first
+                                device of the boot order. At most 65535. Defaults
+                                to the timeout of the firmware.
+                              format: int32
+                              type: integer
+                          type: object
                         bootloader:
                           description: Settings to control the bootloader that is
                             used.
@@ -11004,6 +12913,25 @@ var CRDsValidation map[string]string = map[string]string{
                               type: string
                           type: object
                       type: object
+                    qemuArgs:
+                      description: QEMUArgs are appended to the QEMU command line
+                        of the vmi. Requires the QEMUArgs feature gate, only arguments
+                        which are allowed in the KubeVirt configuration are accepted.
+                      items:
+                        description: QEMUArg is an argument which is appended to the
+                          QEMU command line.
+                        properties:
+                          name:
+                            description: Name of the argument, including the leading
+                              dash, e.g. "-fw_cfg".
+                            type: string
+                          value:
+                            description: Value of the argument, e.g. "name=opt/com.example/config,string=value".
+                            type: string
+                        required:
+                        - name
+                        type: object
+                      type: array
                     resources:
                       description: Resources describes the Compute Resources required
                         by this vmi.
@@ -11042,7 +12970,8 @@ var CRDsValidation map[string]string = map[string]string{
                 evictionStrategy:
                   description: EvictionStrategy can be set to "LiveMigrate" if the
                     VirtualMachineInstance should be migrated instead of shut-off
-                    in case of a node drain.
+                    in case of a node drain, or to "LiveMigrateIfPossible" if it should
+                    be migrated when it is live-migratable and evicted otherwise.
                   type: string
                 hostname:
                   description: Specifies the hostname of the vmi If not specified,
@@ -11188,6 +13117,21 @@ var CRDsValidation map[string]string = map[string]string{
                             description: Select the default network and add it to
                               the multus-cni.io/default-network annotation.
                             type: boolean
+                          ipAddresses:
+                            description: IPAddresses requests static IP addresses
+                              in CIDR notation, e.g. 192.168.1.10/24, for the interface.
+                              They are passed to the "ips" capability of the CNI plugin.
+                              Not supported on the default network.
+                            items:
+                              type: string
+                            type: array
+                            x-kubernetes-list-type: atomic
+                          ipPool:
+                            description: IPPool references a pool of the IPAM plugin
+                              of the network from which the addresses of the interface
+                              are assigned. It is passed as the "ipPool" CNI arg.
+                              Not supported on the default network.
+                            type: string
                           networkName:
                             description: 'References to a NetworkAttachmentDefinition
                               CRD object. Format: <networkName>, <namespace>/<networkName>.
@@ -11226,7 +13170,8 @@ var CRDsValidation map[string]string = map[string]string{
                 priorityClassName:
                   description: If specified, indicates the pod's priority. If not
                     specified, the pod priority will be default or zero if there is
-                    no default.
+                    no default. VMIs with a higher priority are evacuated first when
+                    a node is drained.
                   type: string
                 readinessProbe:
                   description: 'Periodic probe of VirtualMachineInstance service readiness.
@@ -11362,85 +13307,211 @@ var CRDsValidation map[string]string = map[string]string{
                   description: StartStrategy can be set to "Paused" if Virtual Machine
                     should be started in paused state.
                   type: string
-                subdomain:
-                  description: If specified, the fully qualified vmi hostname will
-                    be "<hostname>.<subdomain>.<pod namespace>.svc.<cluster domain>".
-                    If not specified, the vmi will not have a domainname at all. The
-                    DNS entry will resolve to the vmi, no matter if the vmi itself
-                    can pick up a hostname.
-                  type: string
-                terminationGracePeriodSeconds:
-                  description: Grace period observed after signalling a VirtualMachineInstance
-                    to stop after which the VirtualMachineInstance is force terminated.
-                  format: int64
-                  type: integer
-                tolerations:
-                  description: If toleration is specified, obey all the toleration
-                    rules.
-                  items:
-                    description: The pod this Toleration is attached to tolerates
-                      any taint that matches the triple <key,value,effect> using the
-                      matching operator <operator>.
-                    properties:
-                      effect:
-                        description: Effect indicates the taint effect to match. Empty
-                          means match all taint effects. When specified, allowed values
-                          are NoSchedule, PreferNoSchedule and NoExecute.
-                        type: string
-                      key:
-                        description: Key is the taint key that the toleration applies
-                          to. Empty means match all taint keys. If the key is empty,
-                          operator must be Exists; this combination means to match
-                          all values and all keys.
-                        type: string
-                      operator:
-                        description: Operator represents a key's relationship to the
-                          value. Valid operators are Exists and Equal. Defaults to
-                          Equal. Exists is equivalent to wildcard for value, so that
-                          a pod can tolerate all taints of a particular category.
-                        type: string
-                      tolerationSeconds:
-                        description: TolerationSeconds represents the period of time
-                          the toleration (which must be of effect NoExecute, otherwise
-                          this field is ignored) tolerates the taint. By default,
-                          it is not set, which means tolerate the taint forever (do
-                          not evict). Zero and negative values will be treated as
-                          0 (evict immediately) by the system.
-                        format: int64
-                        type: integer
-                      value:
-                        description: Value is the taint value the toleration matches
-                          to. If the operator is Exists, the value should be empty,
-                          otherwise just a regular string.
-                        type: string
-                    type: object
-                  type: array
-                volumes:
-                  description: List of volumes that can be mounted by disks belonging
-                    to the vmi.
-                  items:
-                    description: Volume represents a named volume in a vmi.
-                    properties:
-                      cloudInitConfigDrive:
-                        description: 'CloudInitConfigDrive represents a cloud-init
-                          Config Drive user-data source. The Config Drive data will
-                          be added as a disk to the vmi. A proper cloud-init installation
-                          is required inside the guest. More info: https://cloudinit.readthedocs.io/en/latest/topics/datasources/configdrive.html'
-                        properties:
-                          networkData:
-                            description: NetworkData contains config drive inline
-                              cloud-init networkdata.
-                            type: string
-                          networkDataBase64:
-                            description: NetworkDataBase64 contains config drive cloud-init
-                              networkdata as a base64 encoded string.
+                startupProbe:
+                  description: 'Probe indicating that the VirtualMachineInstance has
+                    successfully started. Liveness and readiness probes are not executed
+                    until it succeeds. VirtualmachineInstances will be stopped if
+                    the probe fails. Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                  properties:
+                    exec:
+                      description: One and only one of the following should be specified.
+                        Exec specifies the action to take, it will be executed on
+                        the guest through the qemu-guest-agent. If the guest agent
+                        is not available, this probe will fail.
+                      properties:
+                        command:
+                          description: Command is the command line to execute inside
+                            the container, the working directory for the command  is
+                            root ('/') in the container's filesystem. The command
+                            is simply exec'd, it is not run inside a shell, so traditional
+                            shell instructions ('|', etc) won't work. To use a shell,
+                            you need to explicitly call out to that shell. Exit status
+                            of 0 is treated as live/healthy and non-zero is unhealthy.
+                          items:
                             type: string
-                          networkDataSecretRef:
-                            description: NetworkDataSecretRef references a k8s secret
-                              that contains config drive networkdata.
+                          type: array
+                      type: object
+                    failureThreshold:
+                      description: Minimum consecutive failures for the probe to be
+                        considered failed after having succeeded. Defaults to 3. Minimum
+                        value is 1.
+                      format: int32
+                      type: integer
+                    guestAgentPing:
+                      description: GuestAgentPing contacts the qemu-guest-agent for
+                        availability checks.
+                      type: object
+                    httpGet:
+                      description: HTTPGet specifies the http request to perform.
+                      properties:
+                        host:
+                          description: Host name to connect to, defaults to the pod
+                            IP. You probably want to set "Host" in httpHeaders instead.
+                          type: string
+                        httpHeaders:
+                          description: Custom headers to set in the request. HTTP
+                            allows repeated headers.
+                          items:
+                            description: HTTPHeader describes a custom header to be
+                              used in HTTP probes
                             properties:
                               name:
-                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                description: The header field name
+                                type: string
+                              value:
+                                description: The header field value
+                                type: string
+                            required:
+                            - name
+                            - value
+                            type: object
+                          type: array
+                        path:
+                          description: Path to access on the HTTP server.
+                          type: string
+                        port:
+                          anyOf:
+                          - type: integer
+                          - type: string
+                          description: Name or number of the port to access on the
+                            container. Number must be in the range 1 to 65535. Name
+                            must be an IANA_SVC_NAME.
+                          x-kubernetes-int-or-string: true
+                        scheme:
+                          description: Scheme to use for connecting to the host. Defaults
+                            to HTTP.
+                          type: string
+                      required:
+                      - port
+                      type: object
+                    initialDelaySeconds:
+                      description: 'Number of seconds after the VirtualMachineInstance
+                        has started before liveness probes are initiated. More info:
+                        https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                      format: int32
+                      type: integer
+                    periodSeconds:
+                      description: How often (in seconds) to perform the probe. Default
+                        to 10 seconds. Minimum value is 1.
+                      format: int32
+                      type: integer
+                    successThreshold:
+                      description: Minimum consecutive successes for the probe to
+                        be considered successful after having failed. Defaults to
+                        1. Must be 1 for liveness. Minimum value is 1.
+                      format: int32
+                      type: integer
+                    tcpSocket:
+                      description: 'TCPSocket specifies an action involving a TCP
+                        port. TCP hooks not yet supported TODO: implement a realistic
+                        TCP lifecycle hook'
+                      properties:
+                        host:
+                          description: 'Optional: Host name to connect to, defaults
+                            to the pod IP.'
+                          type: string
+                        port:
+                          anyOf:
+                          - type: integer
+                          - type: string
+                          description: Number or name of the port to access on the
+                            container. Number must be in the range 1 to 65535. Name
+                            must be an IANA_SVC_NAME.
+                          x-kubernetes-int-or-string: true
+                      required:
+                      - port
+                      type: object
+                    timeoutSeconds:
+                      description: 'Number of seconds after which the probe times
+                        out. For exec probes the timeout fails the probe but does
+                        not terminate the command running on the guest. This means
+                        a blocking command can result in an increasing load on the
+                        guest. A small buffer will be added to the resulting workload
+                        exec probe to compensate for delays caused by the qemu guest
+                        exec mechanism. Defaults to 1 second. Minimum value is 1.
+                        More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                      format: int32
+                      type: integer
+                  type: object
+                subdomain:
+                  description: If specified, the fully qualified vmi hostname will
+                    be "<hostname>.<subdomain>.<pod namespace>.svc.<cluster domain>".
+                    If not specified, the vmi will not have a domainname at all. The
+                    DNS entry will resolve to the vmi, no matter if the vmi itself
+                    can pick up a hostname.
+                  type: string
+                terminationGracePeriodSeconds:
+                  description: Grace period observed after signalling a VirtualMachineInstance
+                    to stop after which the VirtualMachineInstance is force terminated.
+                  format: int64
+                  type: integer
+                tolerations:
+                  description: If toleration is specified, obey all the toleration
+                    rules.
+                  items:
+                    description: The pod this Toleration is attached to tolerates
+                      any taint that matches the triple <key,value,effect> using the
+                      matching operator <operator>.
+                    properties:
+                      effect:
+                        description: Effect indicates the taint effect to match. Empty
+                          means match all taint effects. When specified, allowed values
+                          are NoSchedule, PreferNoSchedule and NoExecute.
+                        type: string
+                      key:
+                        description: Key is the taint key that the toleration applies
+                          to. Empty means match all taint keys. If the key is empty,
+                          operator must be Exists; this combination means to match
+                          all values and all keys.
+                        type: string
+                      operator:
+                        description: Operator represents a key's relationship to the
+                          value. Valid operators are Exists and Equal. Defaults to
+                          Equal. Exists is equivalent to wildcard for value, so that
+                          a pod can tolerate all taints of a particular category.
+                        type: string
+                      tolerationSeconds:
+                        description: TolerationSeconds represents the period of time
+                          the toleration (which must be of effect NoExecute, otherwise
+                          this field is ignored) tolerates the taint. By default,
+                          it is not set, which means tolerate the taint forever (do
+                          not evict). Zero and negative values will be treated as
+                          0 (evict immediately) by the system.
+                        format: int64
+                        type: integer
+                      value:
+                        description: Value is the taint value the toleration matches
+                          to. If the operator is Exists, the value should be empty,
+                          otherwise just a regular string.
+                        type: string
+                    type: object
+                  type: array
+                volumes:
+                  description: List of volumes that can be mounted by disks belonging
+                    to the vmi.
+                  items:
+                    description: Volume represents a named volume in a vmi.
+                    properties:
+                      cloudInitConfigDrive:
+                        description: 'CloudInitConfigDrive represents a cloud-init
+                          Config Drive user-data source. The Config Drive data will
+                          be added as a disk to the vmi. A proper cloud-init installation
+                          is required inside the guest. More info: https://cloudinit.readthedocs.io/en/latest/topics/datasources/configdrive.html'
+                        properties:
+                          networkData:
+                            description: NetworkData contains config drive inline
+                              cloud-init networkdata.
+                            type: string
+                          networkDataBase64:
+                            description: NetworkDataBase64 contains config drive cloud-init
+                              networkdata as a base64 encoded string.
+                            type: string
+                          networkDataSecretRef:
+                            description: NetworkDataSecretRef references a k8s secret
+                              that contains config drive networkdata.
+                            properties:
+                              name:
+                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
                                   TODO: Add other useful fields. apiVersion, kind,
                                   uid?'
                                 type: string
@@ -11545,6 +13616,28 @@ var CRDsValidation map[string]string = map[string]string{
                               registry secret required to pull the image. The secret
                               must already exist.
                             type: string
+                          overlay:
+                            description: Overlay configures the writable copy-on-write
+                              overlay, which uses the image as its backing file. By
+                              default the overlay is stored in an emptyDir and has
+                              the size of the image.
+                            properties:
+                              capacity:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Capacity is the size of the disk the
+                                  guest sees, which has to be at least the size of
+                                  the image. Defaults to the size of the image.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              claimName:
+                                description: ClaimName is the name of a filesystem
+                                  PersistentVolumeClaim in the namespace of the VirtualMachineInstance
+                                  to store the overlay on, which keeps the writes
+                                  of the guest across restarts. Defaults to an emptyDir.
+                                type: string
+                            type: object
                           path:
                             description: Path defines the path to disk file in the
                               container
@@ -11718,6 +13811,18 @@ var CRDsValidation map[string]string = map[string]string{
                         - path
                         - type
                         type: object
+                      memoryDump:
+                        description: MemoryDump is a PersistentVolumeClaim which is
+                          hotplugged to the virt-launcher pod to receive a memory
+                          dump of the VirtualMachineInstance.
+                        properties:
+                          claimName:
+                            description: ClaimName is the name of a PersistentVolumeClaim
+                              in the same namespace as the VirtualMachineInstance.
+                            type: string
+                        required:
+                        - claimName
+                        type: object
                       name:
                         description: 'Volume''s name. Must be a DNS_LABEL and unique
                           within the vmi. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
@@ -11780,43 +13885,3804 @@ var CRDsValidation map[string]string = map[string]string{
                               Sysprep answer file named autounattend.xml that should
                               be attached as disk of CDROM type.
                             properties:
-                              name:
-                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
-                                  TODO: Add other useful fields. apiVersion, kind,
-                                  uid?'
+                              name:
+                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                  TODO: Add other useful fields. apiVersion, kind,
+                                  uid?'
+                                type: string
+                            type: object
+                          secret:
+                            description: Secret references a k8s Secret that contains
+                              Sysprep answer file named autounattend.xml that should
+                              be attached as disk of CDROM type.
+                            properties:
+                              name:
+                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                  TODO: Add other useful fields. apiVersion, kind,
+                                  uid?'
+                                type: string
+                            type: object
+                        type: object
+                    required:
+                    - name
+                    type: object
+                  type: array
+              required:
+              - domain
+              type: object
+          type: object
+      required:
+      - selector
+      - template
+      type: object
+    status:
+      description: Status is the high level overview of how the VirtualMachineInstance
+        is doing. It contains information available to controllers and users.
+      nullable: true
+      properties:
+        conditions:
+          items:
+            properties:
+              lastProbeTime:
+                format: date-time
+                nullable: true
+                type: string
+              lastTransitionTime:
+                format: date-time
+                nullable: true
+                type: string
+              message:
+                type: string
+              reason:
+                type: string
+              status:
+                type: string
+              type:
+                type: string
+            required:
+            - status
+            - type
+            type: object
+          type: array
+        labelSelector:
+          description: Canonical form of the label selector for HPA which consumes
+            it through the scale subresource.
+          type: string
+        readyReplicas:
+          description: The number of ready replicas for this replica set.
+          format: int32
+          type: integer
+        replicas:
+          description: Total number of non-terminated pods targeted by this deployment
+            (their labels match the selector).
+          format: int32
+          type: integer
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"virtualmachinepool": `openAPIV3Schema:
+  description: VirtualMachinePool manages a set of VirtualMachines stamped out from
+    the same template. Every VirtualMachine gets a unique name in the form <pool name>-<index>.
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation
+        of an object. Servers should convert recognized schemas to the latest internal
+        value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object
+        represents. Servers may infer this from the endpoint the client submits requests
+        to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: VirtualMachinePoolSpec is the spec for a VirtualMachinePool resource
+      properties:
+        headlessService:
+          description: Indicates that a headless Service named after the subdomain
+            of the VirtualMachineInstance template is created for the VirtualMachines
+            of the pool. Together with the subdomain it gives every VirtualMachine
+            the DNS name <pool name>-<index>.<subdomain>.<namespace>.svc.
+          type: boolean
+        maxUnavailable:
+          description: The maximum number of VirtualMachines which can be unavailable
+            while they are restarted to pick up a change of the template. Defaults
+            to 1.
+          format: int32
+          type: integer
+        paused:
+          description: Indicates that the pool is paused.
+          type: boolean
+        replicas:
+          description: Number of desired VirtualMachines. This is a pointer to distinguish
+            between explicit zero and not specified. Defaults to 1.
+          format: int32
+          type: integer
+        selector:
+          description: Label selector for VirtualMachines. It must match the labels
+            of the template. The pool only manages the VirtualMachines it created,
+            existing VirtualMachines are not adopted.
+          properties:
+            matchExpressions:
+              description: matchExpressions is a list of label selector requirements.
+                The requirements are ANDed.
+              items:
+                description: A label selector requirement is a selector that contains
+                  values, a key, and an operator that relates the key and values.
+                properties:
+                  key:
+                    description: key is the label key that the selector applies to.
+                    type: string
+                  operator:
+                    description: operator represents a key's relationship to a set
+                      of values. Valid operators are In, NotIn, Exists and DoesNotExist.
+                    type: string
+                  values:
+                    description: values is an array of string values. If the operator
+                      is In or NotIn, the values array must be non-empty. If the operator
+                      is Exists or DoesNotExist, the values array must be empty. This
+                      array is replaced during a strategic merge patch.
+                    items:
+                      type: string
+                    type: array
+                required:
+                - key
+                - operator
+                type: object
+              type: array
+            matchLabels:
+              additionalProperties:
+                type: string
+              description: matchLabels is a map of {key,value} pairs. A single {key,value}
+                in the matchLabels map is equivalent to an element of matchExpressions,
+                whose key field is "key", the operator is "In", and the values array
+                contains only "value". The requirements are ANDed.
+              type: object
+          type: object
+        virtualMachineTemplate:
+          description: Template describes the VirtualMachines that will be created.
+          properties:
+            metadata:
+              nullable: true
+              type: object
+              x-kubernetes-preserve-unknown-fields: true
+            spec:
+              description: VirtualMachineSpec contains the VirtualMachine specification.
+              properties:
+                dataVolumeTemplates:
+                  description: dataVolumeTemplates is a list of dataVolumes that the
+                    VirtualMachineInstance template can reference. DataVolumes in
+                    this list are dynamically created for the VirtualMachine and are
+                    tied to the VirtualMachine's life-cycle.
+                  items:
+                    nullable: true
+                    properties:
+                      apiVersion:
+                        description: 'APIVersion defines the versioned schema of this
+                          representation of an object. Servers should convert recognized
+                          schemas to the latest internal value, and may reject unrecognized
+                          values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+                        type: string
+                      kind:
+                        description: 'Kind is a string value representing the REST
+                          resource this object represents. Servers may infer this
+                          from the endpoint the client submits requests to. Cannot
+                          be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+                        type: string
+                      metadata:
+                        nullable: true
+                        type: object
+                        x-kubernetes-preserve-unknown-fields: true
+                      spec:
+                        description: DataVolumeSpec contains the DataVolume specification.
+                        properties:
+                          checkpoints:
+                            description: Checkpoints is a list of DataVolumeCheckpoints,
+                              representing stages in a multistage import.
+                            items:
+                              description: DataVolumeCheckpoint defines a stage in
+                                a warm migration.
+                              properties:
+                                current:
+                                  description: Current is the identifier of the snapshot
+                                    created for this checkpoint.
+                                  type: string
+                                previous:
+                                  description: Previous is the identifier of the snapshot
+                                    from the previous checkpoint.
+                                  type: string
+                              required:
+                              - current
+                              - previous
+                              type: object
+                            type: array
+                          contentType:
+                            description: 'DataVolumeContentType options: "kubevirt",
+                              "archive"'
+                            enum:
+                            - kubevirt
+                            - archive
+                            type: string
+                          finalCheckpoint:
+                            description: FinalCheckpoint indicates whether the current
+                              DataVolumeCheckpoint is the final checkpoint.
+                            type: boolean
+                          preallocation:
+                            description: Preallocation controls whether storage for
+                              DataVolumes should be allocated in advance.
+                            type: boolean
+                          priorityClassName:
+                            description: PriorityClassName for Importer, Cloner and
+                              Uploader pod
+                            type: string
+                          pvc:
+                            description: PVC is the PVC specification
+                            properties:
+                              accessModes:
+                                description: 'AccessModes contains the desired access
+                                  modes the volume should have. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#access-modes-1'
+                                items:
+                                  type: string
+                                type: array
+                              dataSource:
+                                description: 'This field can be used to specify either:
+                                  * An existing VolumeSnapshot object (snapshot.storage.k8s.io/VolumeSnapshot)
+                                  * An existing PVC (PersistentVolumeClaim) * An existing
+                                  custom resource that implements data population
+                                  (Alpha) In order to use custom resource types that
+                                  implement data population, the AnyVolumeDataSource
+                                  feature gate must be enabled. If the provisioner
+                                  or an external controller can support the specified
+                                  data source, it will create a new volume based on
+                                  the contents of the specified data source.'
+                                properties:
+                                  apiGroup:
+                                    description: APIGroup is the group for the resource
+                                      being referenced. If APIGroup is not specified,
+                                      the specified Kind must be in the core API group.
+                                      For any other third-party types, APIGroup is
+                                      required.
+                                    type: string
+                                  kind:
+                                    description: Kind is the type of resource being
+                                      referenced
+                                    type: string
+                                  name:
+                                    description: Name is the name of resource being
+                                      referenced
+                                    type: string
+                                required:
+                                - kind
+                                - name
+                                type: object
+                              resources:
+                                description: 'Resources represents the minimum resources
+                                  the volume should have. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#resources'
+                                properties:
+                                  limits:
+                                    additionalProperties:
+                                      anyOf:
+                                      - type: integer
+                                      - type: string
+                                      pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                      x-kubernetes-int-or-string: true
+                                    description: 'Limits describes the maximum amount
+                                      of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/'
+                                    type: object
+                                  requests:
+                                    additionalProperties:
+                                      anyOf:
+                                      - type: integer
+                                      - type: string
+                                      pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                      x-kubernetes-int-or-string: true
+                                    description: 'Requests describes the minimum amount
+                                      of compute resources required. If Requests is
+                                      omitted for a container, it defaults to Limits
+                                      if that is explicitly specified, otherwise to
+                                      an implementation-defined value. More info:
+                                      https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/'
+                                    type: object
+                                type: object
+                              selector:
+                                description: A label query over volumes to consider
+                                  for binding.
+                                properties:
+                                  matchExpressions:
+                                    description: matchExpressions is a list of label
+                                      selector requirements. The requirements are
+                                      ANDed.
+                                    items:
+                                      description: A label selector requirement is
+                                        a selector that contains values, a key, and
+                                        an operator that relates the key and values.
+                                      properties:
+                                        key:
+                                          description: key is the label key that the
+                                            selector applies to.
+                                          type: string
+                                        operator:
+                                          description: operator represents a key's
+                                            relationship to a set of values. Valid
+                                            operators are In, NotIn, Exists and DoesNotExist.
+                                          type: string
+                                        values:
+                                          description: values is an array of string
+                                            values. If the operator is In or NotIn,
+                                            the values array must be non-empty. If
+                                            the operator is Exists or DoesNotExist,
+                                            the values array must be empty. This array
+                                            is replaced during a strategic merge patch.
+                                          items:
+                                            type: string
+                                          type: array
+                                      required:
+                                      - key
+                                      - operator
+                                      type: object
+                                    type: array
+                                  matchLabels:
+                                    additionalProperties:
+                                      type: string
+                                    description: matchLabels is a map of {key,value}
+                                      pairs. A single {key,value} in the matchLabels
+                                      map is equivalent to an element of matchExpressions,
+                                      whose key field is "key", the operator is "In",
+                                      and the values array contains only "value".
+                                      The requirements are ANDed.
+                                    type: object
+                                type: object
+                              storageClassName:
+                                description: 'Name of the StorageClass required by
+                                  the claim. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#class-1'
+                                type: string
+                              volumeMode:
+                                description: volumeMode defines what type of volume
+                                  is required by the claim. Value of Filesystem is
+                                  implied when not included in claim spec.
+                                type: string
+                              volumeName:
+                                description: VolumeName is the binding reference to
+                                  the PersistentVolume backing this claim.
+                                type: string
+                            type: object
+                          source:
+                            description: Source is the src of the data for the requested
+                              DataVolume
+                            properties:
+                              blank:
+                                description: DataVolumeBlankImage provides the parameters
+                                  to create a new raw blank image for the PVC
+                                type: object
+                              http:
+                                description: DataVolumeSourceHTTP can be either an
+                                  http or https endpoint, with an optional basic auth
+                                  user name and password, and an optional configmap
+                                  containing additional CAs
+                                properties:
+                                  certConfigMap:
+                                    description: CertConfigMap is a configmap reference,
+                                      containing a Certificate Authority(CA) public
+                                      key, and a base64 encoded pem certificate
+                                    type: string
+                                  secretRef:
+                                    description: SecretRef A Secret reference, the
+                                      secret should contain accessKeyId (user name)
+                                      base64 encoded, and secretKey (password) also
+                                      base64 encoded
+                                    type: string
+                                  url:
+                                    description: URL is the URL of the http(s) endpoint
+                                    type: string
+                                required:
+                                - url
+                                type: object
+                              imageio:
+                                description: DataVolumeSourceImageIO provides the
+                                  parameters to create a Data Volume from an imageio
+                                  source
+                                properties:
+                                  certConfigMap:
+                                    description: CertConfigMap provides a reference
+                                      to the CA cert
+                                    type: string
+                                  diskId:
+                                    description: DiskID provides id of a disk to be
+                                      imported
+                                    type: string
+                                  secretRef:
+                                    description: SecretRef provides the secret reference
+                                      needed to access the ovirt-engine
+                                    type: string
+                                  url:
+                                    description: URL is the URL of the ovirt-engine
+                                    type: string
+                                required:
+                                - diskId
+                                - url
+                                type: object
+                              pvc:
+                                description: DataVolumeSourcePVC provides the parameters
+                                  to create a Data Volume from an existing PVC
+                                properties:
+                                  name:
+                                    description: The name of the source PVC
+                                    type: string
+                                  namespace:
+                                    description: The namespace of the source PVC
+                                    type: string
+                                required:
+                                - name
+                                - namespace
+                                type: object
+                              registry:
+                                description: DataVolumeSourceRegistry provides the
+                                  parameters to create a Data Volume from an registry
+                                  source
+                                properties:
+                                  certConfigMap:
+                                    description: CertConfigMap provides a reference
+                                      to the Registry certs
+                                    type: string
+                                  secretRef:
+                                    description: SecretRef provides the secret reference
+                                      needed to access the Registry source
+                                    type: string
+                                  url:
+                                    description: URL is the url of the Docker registry
+                                      source
+                                    type: string
+                                required:
+                                - url
+                                type: object
+                              s3:
+                                description: DataVolumeSourceS3 provides the parameters
+                                  to create a Data Volume from an S3 source
+                                properties:
+                                  certConfigMap:
+                                    description: CertConfigMap is a configmap reference,
+                                      containing a Certificate Authority(CA) public
+                                      key, and a base64 encoded pem certificate
+                                    type: string
+                                  secretRef:
+                                    description: SecretRef provides the secret reference
+                                      needed to access the S3 source
+                                    type: string
+                                  url:
+                                    description: URL is the url of the S3 source
+                                    type: string
+                                required:
+                                - url
+                                type: object
+                              upload:
+                                description: DataVolumeSourceUpload provides the parameters
+                                  to create a Data Volume by uploading the source
+                                type: object
+                              vddk:
+                                description: DataVolumeSourceVDDK provides the parameters
+                                  to create a Data Volume from a Vmware source
+                                properties:
+                                  backingFile:
+                                    description: BackingFile is the path to the virtual
+                                      hard disk to migrate from vCenter/ESXi
+                                    type: string
+                                  secretRef:
+                                    description: SecretRef provides a reference to
+                                      a secret containing the username and password
+                                      needed to access the vCenter or ESXi host
+                                    type: string
+                                  thumbprint:
+                                    description: Thumbprint is the certificate thumbprint
+                                      of the vCenter or ESXi host
+                                    type: string
+                                  url:
+                                    description: URL is the URL of the vCenter or
+                                      ESXi host with the VM to migrate
+                                    type: string
+                                  uuid:
+                                    description: UUID is the UUID of the virtual machine
+                                      that the backing file is attached to in vCenter/ESXi
+                                    type: string
+                                type: object
+                            type: object
+                          sourceRef:
+                            description: SourceRef is an indirect reference to the
+                              source of data for the requested DataVolume
+                            properties:
+                              kind:
+                                description: The kind of the source reference, currently
+                                  only "DataSource" is supported
+                                type: string
+                              name:
+                                description: The name of the source reference
+                                type: string
+                              namespace:
+                                description: The namespace of the source reference,
+                                  defaults to the DataVolume namespace
+                                type: string
+                            required:
+                            - kind
+                            - name
+                            type: object
+                          storage:
+                            description: Storage is the requested storage specification
+                            properties:
+                              accessModes:
+                                description: 'AccessModes contains the desired access
+                                  modes the volume should have. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#access-modes-1'
+                                items:
+                                  type: string
+                                type: array
+                              dataSource:
+                                description: 'This field can be used to specify either:
+                                  * An existing VolumeSnapshot object (snapshot.storage.k8s.io/VolumeSnapshot)
+                                  * An existing PVC (PersistentVolumeClaim) * An existing
+                                  custom resource that implements data population
+                                  (Alpha) In order to use custom resource types that
+                                  implement data population, the AnyVolumeDataSource
+                                  feature gate must be enabled. If the provisioner
+                                  or an external controller can support the specified
+                                  data source, it will create a new volume based on
+                                  the contents of the specified data source.'
+                                properties:
+                                  apiGroup:
+                                    description: APIGroup is the group for the resource
+                                      being referenced. If APIGroup is not specified,
+                                      the specified Kind must be in the core API group.
+                                      For any other third-party types, APIGroup is
+                                      required.
+                                    type: string
+                                  kind:
+                                    description: Kind is the type of resource being
+                                      referenced
+                                    type: string
+                                  name:
+                                    description: Name is the name of resource being
+                                      referenced
+                                    type: string
+                                required:
+                                - kind
+                                - name
+                                type: object
+                              resources:
+                                description: 'Resources represents the minimum resources
+                                  the volume should have. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#resources'
+                                properties:
+                                  limits:
+                                    additionalProperties:
+                                      anyOf:
+                                      - type: integer
+                                      - type: string
+                                      pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                      x-kubernetes-int-or-string: true
+                                    description: 'Limits describes the maximum amount
+                                      of compute resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/'
+                                    type: object
+                                  requests:
+                                    additionalProperties:
+                                      anyOf:
+                                      - type: integer
+                                      - type: string
+                                      pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                      x-kubernetes-int-or-string: true
+                                    description: 'Requests describes the minimum amount
+                                      of compute resources required. If Requests is
+                                      omitted for a container, it defaults to Limits
+                                      if that is explicitly specified, otherwise to
+                                      an implementation-defined value. More info:
+                                      https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/'
+                                    type: object
+                                type: object
+                              selector:
+                                description: A label query over volumes to consider
+                                  for binding.
+                                properties:
+                                  matchExpressions:
+                                    description: matchExpressions is a list of label
+                                      selector requirements. The requirements are
+                                      ANDed.
+                                    items:
+                                      description: A label selector requirement is
+                                        a selector that contains values, a key, and
+                                        an operator that relates the key and values.
+                                      properties:
+                                        key:
+                                          description: key is the label key that the
+                                            selector applies to.
+                                          type: string
+                                        operator:
+                                          description: operator represents a key's
+                                            relationship to a set of values. Valid
+                                            operators are In, NotIn, Exists and DoesNotExist.
+                                          type: string
+                                        values:
+                                          description: values is an array of string
+                                            values. If the operator is In or NotIn,
+                                            the values array must be non-empty. If
+                                            the operator is Exists or DoesNotExist,
+                                            the values array must be empty. This array
+                                            is replaced during a strategic merge patch.
+                                          items:
+                                            type: string
+                                          type: array
+                                      required:
+                                      - key
+                                      - operator
+                                      type: object
+                                    type: array
+                                  matchLabels:
+                                    additionalProperties:
+                                      type: string
+                                    description: matchLabels is a map of {key,value}
+                                      pairs. A single {key,value} in the matchLabels
+                                      map is equivalent to an element of matchExpressions,
+                                      whose key field is "key", the operator is "In",
+                                      and the values array contains only "value".
+                                      The requirements are ANDed.
+                                    type: object
+                                type: object
+                              storageClassName:
+                                description: 'Name of the StorageClass required by
+                                  the claim. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#class-1'
+                                type: string
+                              volumeMode:
+                                description: volumeMode defines what type of volume
+                                  is required by the claim. Value of Filesystem is
+                                  implied when not included in claim spec.
+                                type: string
+                              volumeName:
+                                description: VolumeName is the binding reference to
+                                  the PersistentVolume backing this claim.
+                                type: string
+                            type: object
+                        type: object
+                      status:
+                        description: DataVolumeTemplateDummyStatus is here simply
+                          for backwards compatibility with a previous API.
+                        nullable: true
+                        type: object
+                    required:
+                    - spec
+                    type: object
+                  type: array
+                nodeRebootPolicy:
+                  description: NodeRebootPolicy controls what happens to a VirtualMachineInstance
+                    which was running on a node that rebooted. Defaults to the behavior
+                    of the RunStrategy.
+                  type: string
+                runStrategy:
+                  description: Running state indicates the requested running state
+                    of the VirtualMachineInstance mutually exclusive with Running
+                  type: string
+                running:
+                  description: Running controls whether the associatied VirtualMachineInstance
+                    is created or not Mutually exclusive with RunStrategy
+                  type: boolean
+                schedule:
+                  description: Schedule starts and stops the VirtualMachine at recurring
+                    times
+                  properties:
+                    start:
+                      description: Start is a cron expression in the five field format
+                        "minute hour day-of-month month day-of-week", e.g. "0 8 *
+                        * 1-5", at whose times the VirtualMachine is started
+                      type: string
+                    stop:
+                      description: Stop is a cron expression in the five field format
+                        "minute hour day-of-month month day-of-week", e.g. "0 20 *
+                        * *", at whose times the VirtualMachine is stopped
+                      type: string
+                    timeZone:
+                      description: TimeZone is the name of the IANA time zone the
+                        cron expressions are evaluated in, e.g. "Europe/Berlin". Defaults
+                        to UTC.
+                      type: string
+                  type: object
+                template:
+                  description: Template is the direct specification of VirtualMachineInstance
+                  properties:
+                    metadata:
+                      nullable: true
+                      type: object
+                      x-kubernetes-preserve-unknown-fields: true
+                    spec:
+                      description: VirtualMachineInstance Spec contains the VirtualMachineInstance
+                        specification.
+                      properties:
+                        accessCredentials:
+                          description: Specifies a set of public keys to inject into
+                            the vm guest
+                          items:
+                            description: AccessCredential represents a credential
+                              source that can be used to authorize remote access to
+                              the vm guest Only one of its members may be specified.
+                            properties:
+                              sshPublicKey:
+                                description: SSHPublicKey represents the source and
+                                  method of applying a ssh public key into a guest
+                                  virtual machine.
+                                properties:
+                                  propagationMethod:
+                                    description: PropagationMethod represents how
+                                      the public key is injected into the vm guest.
+                                    properties:
+                                      configDrive:
+                                        description: ConfigDrivePropagation means
+                                          that the ssh public keys are injected into
+                                          the VM using metadata using the configDrive
+                                          cloud-init provider
+                                        type: object
+                                      qemuGuestAgent:
+                                        description: QemuGuestAgentAccessCredentailPropagation
+                                          means ssh public keys are dynamically injected
+                                          into the vm at runtime via the qemu guest
+                                          agent. This feature requires the qemu guest
+                                          agent to be running within the guest.
+                                        properties:
+                                          users:
+                                            description: Users represents a list of
+                                              guest users that should have the ssh
+                                              public keys added to their authorized_keys
+                                              file.
+                                            items:
+                                              type: string
+                                            type: array
+                                            x-kubernetes-list-type: set
+                                        required:
+                                        - users
+                                        type: object
+                                    type: object
+                                  source:
+                                    description: Source represents where the public
+                                      keys are pulled from
+                                    properties:
+                                      secret:
+                                        description: Secret means that the access
+                                          credential is pulled from a kubernetes secret
+                                        properties:
+                                          secretName:
+                                            description: SecretName represents the
+                                              name of the secret in the VMI's namespace
+                                            type: string
+                                        required:
+                                        - secretName
+                                        type: object
+                                    type: object
+                                required:
+                                - propagationMethod
+                                - source
+                                type: object
+                              userPassword:
+                                description: UserPassword represents the source and
+                                  method for applying a guest user's password
+                                properties:
+                                  propagationMethod:
+                                    description: propagationMethod represents how
+                                      the user passwords are injected into the vm
+                                      guest.
+                                    properties:
+                                      qemuGuestAgent:
+                                        description: QemuGuestAgentAccessCredentailPropagation
+                                          means passwords are dynamically injected
+                                          into the vm at runtime via the qemu guest
+                                          agent. This feature requires the qemu guest
+                                          agent to be running within the guest.
+                                        type: object
+                                    type: object
+                                  source:
+                                    description: Source represents where the user
+                                      passwords are pulled from
+                                    properties:
+                                      secret:
+                                        description: Secret means that the access
+                                          credential is pulled from a kubernetes secret
+                                        properties:
+                                          secretName:
+                                            description: SecretName represents the
+                                              name of the secret in the VMI's namespace
+                                            type: string
+                                        required:
+                                        - secretName
+                                        type: object
+                                    type: object
+                                required:
+                                - propagationMethod
+                                - source
+                                type: object
+                            type: object
+                          type: array
+                          x-kubernetes-list-type: atomic
+                        affinity:
+                          description: If affinity is specifies, obey all the affinity
+                            rules
+                          properties:
+                            nodeAffinity:
+                              description: Describes node affinity scheduling rules
+                                for the pod.
+                              properties:
+                                preferredDuringSchedulingIgnoredDuringExecution:
+                                  description: The scheduler will prefer to schedule
+                                    pods to nodes that satisfy the affinity expressions
+                                    specified by this field, but it may choose a node
+                                    that violates one or more of the expressions.
+                                    The node that is most preferred is the one with
+                                    the greatest sum of weights, i.e. for each node
+                                    that meets all of the scheduling requirements
+                                    (resource request, requiredDuringScheduling affinity
+                                    expressions, etc.), compute a sum by iterating
+                                    through the elements of this field and adding
+                                    "weight" to the sum if the node matches the corresponding
+                                    matchExpressions; the node(s) with the highest
+                                    sum are the most preferred.
+                                  items:
+                                    description: An empty preferred scheduling term
+                                      matches all objects with implicit weight 0 (i.e.
+                                      it's a no-op). A null preferred scheduling term
+                                      matches no objects (i.e. is also a no-op).
+                                    properties:
+                                      preference:
+                                        description: A node selector term, associated
+                                          with the corresponding weight.
+                                        properties:
+                                          matchExpressions:
+                                            description: A list of node selector requirements
+                                              by node's labels.
+                                            items:
+                                              description: A node selector requirement
+                                                is a selector that contains values,
+                                                a key, and an operator that relates
+                                                the key and values.
+                                              properties:
+                                                key:
+                                                  description: The label key that
+                                                    the selector applies to.
+                                                  type: string
+                                                operator:
+                                                  description: Represents a key's
+                                                    relationship to a set of values.
+                                                    Valid operators are In, NotIn,
+                                                    Exists, DoesNotExist. Gt, and
+                                                    Lt.
+                                                  type: string
+                                                values:
+                                                  description: An array of string
+                                                    values. If the operator is In
+                                                    or NotIn, the values array must
+                                                    be non-empty. If the operator
+                                                    is Exists or DoesNotExist, the
+                                                    values array must be empty. If
+                                                    the operator is Gt or Lt, the
+                                                    values array must have a single
+                                                    element, which will be interpreted
+                                                    as an integer. This array is replaced
+                                                    during a strategic merge patch.
+                                                  items:
+                                                    type: string
+                                                  type: array
+                                              required:
+                                              - key
+                                              - operator
+                                              type: object
+                                            type: array
+                                          matchFields:
+                                            description: A list of node selector requirements
+                                              by node's fields.
+                                            items:
+                                              description: A node selector requirement
+                                                is a selector that contains values,
+                                                a key, and an operator that relates
+                                                the key and values.
+                                              properties:
+                                                key:
+                                                  description: The label key that
+                                                    the selector applies to.
+                                                  type: string
+                                                operator:
+                                                  description: Represents a key's
+                                                    relationship to a set of values.
+                                                    Valid operators are In, NotIn,
+                                                    Exists, DoesNotExist. Gt, and
+                                                    Lt.
+                                                  type: string
+                                                values:
+                                                  description: An array of string
+                                                    values. If the operator is In
+                                                    or NotIn, the values array must
+                                                    be non-empty. If the operator
+                                                    is Exists or DoesNotExist, the
+                                                    values array must be empty. If
+                                                    the operator is Gt or Lt, the
+                                                    values array must have a single
+                                                    element, which will be interpreted
+                                                    as an integer. This array is replaced
+                                                    during a strategic merge patch.
+                                                  items:
+                                                    type: string
+                                                  type: array
+                                              required:
+                                              - key
+                                              - operator
+                                              type: object
+                                            type: array
+                                        type: object
+                                      weight:
+                                        description: Weight associated with matching
+                                          the corresponding nodeSelectorTerm, in the
+                                          range 1-100.
+                                        format: int32
+                                        type: integer
+                                    required:
+                                    - preference
+                                    - weight
+                                    type: object
+                                  type: array
+                                requiredDuringSchedulingIgnoredDuringExecution:
+                                  description: If the affinity requirements specified
+                                    by this field are not met at scheduling time,
+                                    the pod will not be scheduled onto the node. If
+                                    the affinity requirements specified by this field
+                                    cease to be met at some point during pod execution
+                                    (e.g. due to an update), the system may or may
+                                    not try to eventually evict the pod from its node.
+                                  properties:
+                                    nodeSelectorTerms:
+                                      description: Required. A list of node selector
+                                        terms. The terms are ORed.
+                                      items:
+                                        description: A null or empty node selector
+                                          term matches no objects. The requirements
+                                          of them are ANDed. The TopologySelectorTerm
+                                          type implements a subset of the NodeSelectorTerm.
+                                        properties:
+                                          matchExpressions:
+                                            description: A list of node selector requirements
+                                              by node's labels.
+                                            items:
+                                              description: A node selector requirement
+                                                is a selector that contains values,
+                                                a key, and an operator that relates
+                                                the key and values.
+                                              properties:
+                                                key:
+                                                  description: The label key that
+                                                    the selector applies to.
+                                                  type: string
+                                                operator:
+                                                  description: Represents a key's
+                                                    relationship to a set of values.
+                                                    Valid operators are In, NotIn,
+                                                    Exists, DoesNotExist. Gt, and
+                                                    Lt.
+                                                  type: string
+                                                values:
+                                                  description: An array of string
+                                                    values. If the operator is In
+                                                    or NotIn, the values array must
+                                                    be non-empty. If the operator
+                                                    is Exists or DoesNotExist, the
+                                                    values array must be empty. If
+                                                    the operator is Gt or Lt, the
+                                                    values array must have a single
+                                                    element, which will be interpreted
+                                                    as an integer. This array is replaced
+                                                    during a strategic merge patch.
+                                                  items:
+                                                    type: string
+                                                  type: array
+                                              required:
+                                              - key
+                                              - operator
+                                              type: object
+                                            type: array
+                                          matchFields:
+                                            description: A list of node selector requirements
+                                              by node's fields.
+                                            items:
+                                              description: A node selector requirement
+                                                is a selector that contains values,
+                                                a key, and an operator that relates
+                                                the key and values.
+                                              properties:
+                                                key:
+                                                  description: The label key that
+                                                    the selector applies to.
+                                                  type: string
+                                                operator:
+                                                  description: Represents a key's
+                                                    relationship to a set of values.
+                                                    Valid operators are In, NotIn,
+                                                    Exists, DoesNotExist. Gt, and
+                                                    Lt.
+                                                  type: string
+                                                values:
+                                                  description: An array of string
+                                                    values. If the operator is In
+                                                    or NotIn, the values array must
+                                                    be non-empty. If the operator
+                                                    is Exists or DoesNotExist, the
+                                                    values array must be empty. If
+                                                    the operator is Gt or Lt, the
+                                                    values array must have a single
+                                                    element, which will be interpreted
+                                                    as an integer. This array is replaced
+                                                    during a strategic merge patch.
+                                                  items:
+                                                    type: string
+                                                  type: array
+                                              required:
+                                              - key
+                                              - operator
+                                              type: object
+                                            type: array
+                                        type: object
+                                      type: array
+                                  required:
+                                  - nodeSelectorTerms
+                                  type: object
+                              type: object
+                            podAffinity:
+                              description: Describes pod affinity scheduling rules
+                                (e.g. co-locate this pod in the same node, zone, etc.
+                                as some other pod(s)).
+                              properties:
+                                preferredDuringSchedulingIgnoredDuringExecution:
+                                  description: The scheduler will prefer to schedule
+                                    pods to nodes that satisfy the affinity expressions
+                                    specified by this field, but it may choose a node
+                                    that violates one or more of the expressions.
+                                    The node that is most preferred is the one with
+                                    the greatest sum of weights, i.e. for each node
+                                    that meets all of the scheduling requirements
+                                    (resource request, requiredDuringScheduling affinity
+                                    expressions, etc.), compute a sum by iterating
+                                    through the elements of this field and adding
+                                    "weight" to the sum if the node has pods which
+                                    matches the corresponding podAffinityTerm; the
+                                    node(s) with the highest sum are the most preferred.
+                                  items:
+                                    description: The weights of all of the matched
+                                      WeightedPodAffinityTerm fields are added per-node
+                                      to find the most preferred node(s)
+                                    properties:
+                                      podAffinityTerm:
+                                        description: Required. A pod affinity term,
+                                          associated with the corresponding weight.
+                                        properties:
+                                          labelSelector:
+                                            description: A label query over a set
+                                              of resources, in this case pods.
+                                            properties:
+                                              matchExpressions:
+                                                description: matchExpressions is a
+                                                  list of label selector requirements.
+                                                  The requirements are ANDed.
+                                                items:
+                                                  description: A label selector requirement
+                                                    is a selector that contains values,
+                                                    a key, and an operator that relates
+                                                    the key and values.
+                                                  properties:
+                                                    key:
+                                                      description: key is the label
+                                                        key that the selector applies
+                                                        to.
+                                                      type: string
+                                                    operator:
+                                                      description: operator represents
+                                                        a key's relationship to a
+                                                        set of values. Valid operators
+                                                        are In, NotIn, Exists and
+                                                        DoesNotExist.
+                                                      type: string
+                                                    values:
+                                                      description: values is an array
+                                                        of string values. If the operator
+                                                        is In or NotIn, the values
+                                                        array must be non-empty. If
+                                                        the operator is Exists or
+                                                        DoesNotExist, the values array
+                                                        must be empty. This array
+                                                        is replaced during a strategic
+                                                        merge patch.
+                                                      items:
+                                                        type: string
+                                                      type: array
+                                                  required:
+                                                  - key
+                                                  - operator
+                                                  type: object
+                                                type: array
+                                              matchLabels:
+                                                additionalProperties:
+                                                  type: string
+                                                description: matchLabels is a map
+                                                  of {key,value} pairs. A single {key,value}
+                                                  in the matchLabels map is equivalent
+                                                  to an element of matchExpressions,
+                                                  whose key field is "key", the operator
+                                                  is "In", and the values array contains
+                                                  only "value". The requirements are
+                                                  ANDed.
+                                                type: object
+                                            type: object
+                                          namespaces:
+                                            description: namespaces specifies which
+                                              namespaces the labelSelector applies
+                                              to (matches against); null or empty
+                                              list means "this pod's namespace"
+                                            items:
+                                              type: string
+                                            type: array
+                                          topologyKey:
+                                            description: This pod should be co-located
+                                              (affinity) or not co-located (anti-affinity)
+                                              with the pods matching the labelSelector
+                                              in the specified namespaces, where co-located
+                                              is defined as running on a node whose
+                                              value of the label with key topologyKey
+                                              matches that of any node on which any
+                                              of the selected pods is running. Empty
+                                              topologyKey is not allowed.
+                                            type: string
+                                        required:
+                                        - topologyKey
+                                        type: object
+                                      weight:
+                                        description: weight associated with matching
+                                          the corresponding podAffinityTerm, in the
+                                          range 1-100.
+                                        format: int32
+                                        type: integer
+                                    required:
+                                    - podAffinityTerm
+                                    - weight
+                                    type: object
+                                  type: array
+                                requiredDuringSchedulingIgnoredDuringExecution:
+                                  description: If the affinity requirements specified
+                                    by this field are not met at scheduling time,
+                                    the pod will not be scheduled onto the node. If
+                                    the affinity requirements specified by this field
+                                    cease to be met at some point during pod execution
+                                    (e.g. due to a pod label update), the system may
+                                    or may not try to eventually evict the pod from
+                                    its node. When there are multiple elements, the
+                                    lists of nodes corresponding to each podAffinityTerm
+                                    are intersected, i.e. all terms must be satisfied.
+                                  items:
+                                    description: Defines a set of pods (namely those
+                                      matching the labelSelector relative to the given
+                                      namespace(s)) that this pod should be co-located
+                                      (affinity) or not co-located (anti-affinity)
+                                      with, where co-located is defined as running
+                                      on a node whose value of the label with key
+                                      <topologyKey> matches that of any node on which
+                                      a pod of the set of pods is running
+                                    properties:
+                                      labelSelector:
+                                        description: A label query over a set of resources,
+                                          in this case pods.
+                                        properties:
+                                          matchExpressions:
+                                            description: matchExpressions is a list
+                                              of label selector requirements. The
+                                              requirements are ANDed.
+                                            items:
+                                              description: A label selector requirement
+                                                is a selector that contains values,
+                                                a key, and an operator that relates
+                                                the key and values.
+                                              properties:
+                                                key:
+                                                  description: key is the label key
+                                                    that the selector applies to.
+                                                  type: string
+                                                operator:
+                                                  description: operator represents
+                                                    a key's relationship to a set
+                                                    of values. Valid operators are
+                                                    In, NotIn, Exists and DoesNotExist.
+                                                  type: string
+                                                values:
+                                                  description: values is an array
+                                                    of string values. If the operator
+                                                    is In or NotIn, the values array
+                                                    must be non-empty. If the operator
+                                                    is Exists or DoesNotExist, the
+                                                    values array must be empty. This
+                                                    array is replaced during a strategic
+                                                    merge patch.
+                                                  items:
+                                                    type: string
+                                                  type: array
+                                              required:
+                                              - key
+                                              - operator
+                                              type: object
+                                            type: array
+                                          matchLabels:
+                                            additionalProperties:
+                                              type: string
+                                            description: matchLabels is a map of {key,value}
+                                              pairs. A single {key,value} in the matchLabels
+                                              map is equivalent to an element of matchExpressions,
+                                              whose key field is "key", the operator
+                                              is "In", and the values array contains
+                                              only "value". The requirements are ANDed.
+                                            type: object
+                                        type: object
+                                      namespaces:
+                                        description: namespaces specifies which namespaces
+                                          the labelSelector applies to (matches against);
+                                          null or empty list means "this pod's namespace"
+                                        items:
+                                          type: string
+                                        type: array
+                                      topologyKey:
+                                        description: This pod should be co-located
+                                          (affinity) or not co-located (anti-affinity)
+                                          with the pods matching the labelSelector
+                                          in the specified namespaces, where co-located
+                                          is defined as running on a node whose value
+                                          of the label with key topologyKey matches
+                                          that of any node on which any of the selected
+                                          pods is running. Empty topologyKey is not
+                                          allowed.
+                                        type: string
+                                    required:
+                                    - topologyKey
+                                    type: object
+                                  type: array
+                              type: object
+                            podAntiAffinity:
+                              description: Describes pod anti-affinity scheduling
+                                rules (e.g. avoid putting this pod in the same node,
+                                zone, etc. as some other pod(s)).
+                              properties:
+                                preferredDuringSchedulingIgnoredDuringExecution:
+                                  description: The scheduler will prefer to schedule
+                                    pods to nodes that satisfy the anti-affinity expressions
+                                    specified by this field, but it may choose a node
+                                    that violates one or more of the expressions.
+                                    The node that is most preferred is the one with
+                                    the greatest sum of weights, i.e. for each node
+                                    that meets all of the scheduling requirements
+                                    (resource request, requiredDuringScheduling anti-affinity
+                                    expressions, etc.), compute a sum by iterating
+                                    through the elements of this field and adding
+                                    "weight" to the sum if the node has pods which
+                                    matches the corresponding podAffinityTerm; the
+                                    node(s) with the highest sum are the most preferred.
+                                  items:
+                                    description: The weights of all of the matched
+                                      WeightedPodAffinityTerm fields are added per-node
+                                      to find the most preferred node(s)
+                                    properties:
+                                      podAffinityTerm:
+                                        description: Required. A pod affinity term,
+                                          associated with the corresponding weight.
+                                        properties:
+                                          labelSelector:
+                                            description: A label query over a set
+                                              of resources, in this case pods.
+                                            properties:
+                                              matchExpressions:
+                                                description: matchExpressions is a
+                                                  list of label selector requirements.
+                                                  The requirements are ANDed.
+                                                items:
+                                                  description: A label selector requirement
+                                                    is a selector that contains values,
+                                                    a key, and an operator that relates
+                                                    the key and values.
+                                                  properties:
+                                                    key:
+                                                      description: key is the label
+                                                        key that the selector applies
+                                                        to.
+                                                      type: string
+                                                    operator:
+                                                      description: operator represents
+                                                        a key's relationship to a
+                                                        set of values. Valid operators
+                                                        are In, NotIn, Exists and
+                                                        DoesNotExist.
+                                                      type: string
+                                                    values:
+                                                      description: values is an array
+                                                        of string values. If the operator
+                                                        is In or NotIn, the values
+                                                        array must be non-empty. If
+                                                        the operator is Exists or
+                                                        DoesNotExist, the values array
+                                                        must be empty. This array
+                                                        is replaced during a strategic
+                                                        merge patch.
+                                                      items:
+                                                        type: string
+                                                      type: array
+                                                  required:
+                                                  - key
+                                                  - operator
+                                                  type: object
+                                                type: array
+                                              matchLabels:
+                                                additionalProperties:
+                                                  type: string
+                                                description: matchLabels is a map
+                                                  of {key,value} pairs. A single {key,value}
+                                                  in the matchLabels map is equivalent
+                                                  to an element of matchExpressions,
+                                                  whose key field is "key", the operator
+                                                  is "In", and the values array contains
+                                                  only "value". The requirements are
+                                                  ANDed.
+                                                type: object
+                                            type: object
+                                          namespaces:
+                                            description: namespaces specifies which
+                                              namespaces the labelSelector applies
+                                              to (matches against); null or empty
+                                              list means "this pod's namespace"
+                                            items:
+                                              type: string
+                                            type: array
+                                          topologyKey:
+                                            description: This pod should be co-located
+                                              (affinity) or not co-located (anti-affinity)
+                                              with the pods matching the labelSelector
+                                              in the specified namespaces, where co-located
+                                              is defined as running on a node whose
+                                              value of the label with key topologyKey
+                                              matches that of any node on which any
+                                              of the selected pods is running. Empty
+                                              topologyKey is not allowed.
+                                            type: string
+                                        required:
+                                        - topologyKey
+                                        type: object
+                                      weight:
+                                        description: weight associated with matching
+                                          the corresponding podAffinityTerm, in the
+                                          range 1-100.
+                                        format: int32
+                                        type: integer
+                                    required:
+                                    - podAffinityTerm
+                                    - weight
+                                    type: object
+                                  type: array
+                                requiredDuringSchedulingIgnoredDuringExecution:
+                                  description: If the anti-affinity requirements specified
+                                    by this field are not met at scheduling time,
+                                    the pod will not be scheduled onto the node. If
+                                    the anti-affinity requirements specified by this
+                                    field cease to be met at some point during pod
+                                    execution (e.g. due to a pod label update), the
+                                    system may or may not try to eventually evict
+                                    the pod from its node. When there are multiple
+                                    elements, the lists of nodes corresponding to
+                                    each podAffinityTerm are intersected, i.e. all
+                                    terms must be satisfied.
+                                  items:
+                                    description: Defines a set of pods (namely those
+                                      matching the labelSelector relative to the given
+                                      namespace(s)) that this pod should be co-located
+                                      (affinity) or not co-located (anti-affinity)
+                                      with, where co-located is defined as running
+                                      on a node whose value of the label with key
+                                      <topologyKey> matches that of any node on which
+                                      a pod of the set of pods is running
+                                    properties:
+                                      labelSelector:
+                                        description: A label query over a set of resources,
+                                          in this case pods.
+                                        properties:
+                                          matchExpressions:
+                                            description: matchExpressions is a list
+                                              of label selector requirements. The
+                                              requirements are ANDed.
+                                            items:
+                                              description: A label selector requirement
+                                                is a selector that contains values,
+                                                a key, and an operator that relates
+                                                the key and values.
+                                              properties:
+                                                key:
+                                                  description: key is the label key
+                                                    that the selector applies to.
+                                                  type: string
+                                                operator:
+                                                  description: operator represents
+                                                    a key's relationship to a set
+                                                    of values. Valid operators are
+                                                    In, NotIn, Exists and DoesNotExist.
+                                                  type: string
+                                                values:
+                                                  description: values is an array
+                                                    of string values. If the operator
+                                                    is In or NotIn, the values array
+                                                    must be non-empty. If the operator
+                                                    is Exists or DoesNotExist, the
+                                                    values array must be empty. This
+                                                    array is replaced during a strategic
+                                                    merge patch.
+                                                  items:
+                                                    type: string
+                                                  type: array
+                                              required:
+                                              - key
+                                              - operator
+                                              type: object
+                                            type: array
+                                          matchLabels:
+                                            additionalProperties:
+                                              type: string
+                                            description: matchLabels is a map of {key,value}
+                                              pairs. A single {key,value} in the matchLabels
+                                              map is equivalent to an element of matchExpressions,
+                                              whose key field is "key", the operator
+                                              is "In", and the values array contains
+                                              only "value". The requirements are ANDed.
+                                            type: object
+                                        type: object
+                                      namespaces:
+                                        description: namespaces specifies which namespaces
+                                          the labelSelector applies to (matches against);
+                                          null or empty list means "this pod's namespace"
+                                        items:
+                                          type: string
+                                        type: array
+                                      topologyKey:
+                                        description: This pod should be co-located
+                                          (affinity) or not co-located (anti-affinity)
+                                          with the pods matching the labelSelector
+                                          in the specified namespaces, where co-located
+                                          is defined as running on a node whose value
+                                          of the label with key topologyKey matches
+                                          that of any node on which any of the selected
+                                          pods is running. Empty topologyKey is not
+                                          allowed.
+                                        type: string
+                                    required:
+                                    - topologyKey
+                                    type: object
+                                  type: array
+                              type: object
+                          type: object
+                        dnsConfig:
+                          description: Specifies the DNS parameters of a pod. Parameters
+                            specified here will be merged to the generated DNS configuration
+                            based on DNSPolicy.
+                          properties:
+                            nameservers:
+                              description: A list of DNS name server IP addresses.
+                                This will be appended to the base nameservers generated
+                                from DNSPolicy. Duplicated nameservers will be removed.
+                              items:
+                                type: string
+                              type: array
+                            options:
+                              description: A list of DNS resolver options. This will
+                                be merged with the base options generated from DNSPolicy.
+                                Duplicated entries will be removed. Resolution options
+                                given in Options will override those that appear in
+                                the base DNSPolicy.
+                              items:
+                                description: PodDNSConfigOption defines DNS resolver
+                                  options of a pod.
+                                properties:
+                                  name:
+                                    description: Required.
+                                    type: string
+                                  value:
+                                    type: string
+                                type: object
+                              type: array
+                            searches:
+                              description: A list of DNS search domains for host-name
+                                lookup. This will be appended to the base search paths
+                                generated from DNSPolicy. Duplicated search paths
+                                will be removed.
+                              items:
+                                type: string
+                              type: array
+                          type: object
+                        dnsPolicy:
+                          description: Set DNS policy for the pod. Defaults to "ClusterFirst".
+                            Valid values are 'ClusterFirstWithHostNet', 'ClusterFirst',
+                            'Default' or 'None'. DNS parameters given in DNSConfig
+                            will be merged with the policy selected with DNSPolicy.
+                            To have DNS options set along with hostNetwork, you have
+                            to specify DNS policy explicitly to 'ClusterFirstWithHostNet'.
+                          type: string
+                        domain:
+                          description: Specification of the desired behavior of the
+                            VirtualMachineInstance on the host.
+                          properties:
+                            chassis:
+                              description: Chassis specifies the chassis info passed
+                                to the domain.
+                              properties:
+                                asset:
+                                  type: string
+                                manufacturer:
+                                  type: string
+                                serial:
+                                  type: string
+                                sku:
+                                  type: string
+                                version:
+                                  type: string
+                              type: object
+                            clock:
+                              description: Clock sets the clock and timers of the
+                                vmi.
+                              properties:
+                                timer:
+                                  description: Timer specifies whih timers are attached
+                                    to the vmi.
+                                  properties:
+                                    hpet:
+                                      description: HPET (High Precision Event Timer)
+                                        - multiple timers with periodic interrupts.
+                                      properties:
+                                        present:
+                                          description: Enabled set to false makes
+                                            sure that the machine type or a preset
+                                            can't add the timer. Defaults to true.
+                                          type: boolean
+                                        tickPolicy:
+                                          description: TickPolicy determines what
+                                            happens when QEMU misses a deadline for
+                                            injecting a tick to the guest. One of
+                                            "delay", "catchup", "merge", "discard".
+                                          type: string
+                                      type: object
+                                    hyperv:
+                                      description: Hyperv (Hypervclock) - lets guests
+                                        read the host’s wall clock time (paravirtualized).
+                                        For windows guests.
+                                      properties:
+                                        present:
+                                          description: Enabled set to false makes
+                                            sure that the machine type or a preset
+                                            can't add the timer. Defaults to true.
+                                          type: boolean
+                                      type: object
+                                    kvm:
+                                      description: "KVM \t(KVM clock) - lets guests
+                                        read the host’s wall clock time (paravirtualized).
+                                        For linux guests."
+                                      properties:
+                                        present:
+                                          description: Enabled set to false makes
+                                            sure that the machine type or a preset
+                                            can't add the timer. Defaults to true.
+                                          type: boolean
+                                      type: object
+                                    pit:
+                                      description: PIT (Programmable Interval Timer)
+                                        - a timer with periodic interrupts.
+                                      properties:
+                                        present:
+                                          description: Enabled set to false makes
+                                            sure that the machine type or a preset
+                                            can't add the timer. Defaults to true.
+                                          type: boolean
+                                        tickPolicy:
+                                          description: TickPolicy determines what
+                                            happens when QEMU misses a deadline for
+                                            injecting a tick to the guest. One of
+                                            "delay", "catchup", "discard".
+                                          type: string
+                                      type: object
+                                    rtc:
+                                      description: RTC (Real Time Clock) - a continuously
+                                        running timer with periodic interrupts.
+                                      properties:
+                                        present:
+                                          description: Enabled set to false makes
+                                            sure that the machine type or a preset
+                                            can't add the timer. Defaults to true.
+                                          type: boolean
+                                        tickPolicy:
+                                          description: TickPolicy determines what
+                                            happens when QEMU misses a deadline for
+                                            injecting a tick to the guest. One of
+                                            "delay", "catchup".
+                                          type: string
+                                        track:
+                                          description: Track the guest or the wall
+                                            clock.
+                                          type: string
+                                      type: object
+                                  type: object
+                                timezone:
+                                  description: Timezone sets the guest clock to the
+                                    specified timezone. Zone name follows the TZ environment
+                                    variable format (e.g. 'America/New_York').
+                                  type: string
+                                utc:
+                                  description: UTC sets the guest clock to UTC on
+                                    each boot. If an offset is specified, guest changes
+                                    to the clock will be kept during reboots and are
+                                    not reset.
+                                  properties:
+                                    offsetSeconds:
+                                      description: OffsetSeconds specifies an offset
+                                        in seconds, relative to UTC. If set, guest
+                                        changes to the clock will be kept during reboots
+                                        and not reset.
+                                      type: integer
+                                  type: object
+                              type: object
+                            cpu:
+                              description: CPU allow specified the detailed CPU topology
+                                inside the vmi.
+                              properties:
+                                cores:
+                                  description: Cores specifies the number of cores
+                                    inside the vmi. Must be a value greater or equal
+                                    1.
+                                  format: int32
+                                  type: integer
+                                dedicatedCpuPlacement:
+                                  description: DedicatedCPUPlacement requests the
+                                    scheduler to place the VirtualMachineInstance
+                                    on a node with enough dedicated pCPUs and pin
+                                    the vCPUs to it.
+                                  type: boolean
+                                features:
+                                  description: Features specifies the CPU features
+                                    list inside the VMI.
+                                  items:
+                                    description: CPUFeature allows specifying a CPU
+                                      feature.
+                                    properties:
+                                      name:
+                                        description: Name of the CPU feature
+                                        type: string
+                                      policy:
+                                        description: 'Policy is the CPU feature attribute
+                                          which can have the following attributes:
+                                          force    - The virtual CPU will claim the
+                                          feature is supported regardless of it being
+                                          supported by host CPU. require  - Guest
+                                          creation will fail unless the feature is
+                                          supported by the host CPU or the hypervisor
+                                          is able to emulate it. optional - The feature
+                                          will be supported by virtual CPU if and
+                                          only if it is supported by host CPU. disable  -
+                                          The feature will not be supported by virtual
+                                          CPU. forbid   - Guest creation will fail
+                                          if the feature is supported by host CPU.
+                                          Defaults to require'
+                                        type: string
+                                    required:
+                                    - name
+                                    type: object
+                                  type: array
+                                isolateEmulatorThread:
+                                  description: IsolateEmulatorThread requests one
+                                    more dedicated pCPU to be allocated for the VMI
+                                    to place the emulator thread on it.
+                                  type: boolean
+                                model:
+                                  description: Model specifies the CPU model inside
+                                    the VMI. List of available models https://github.com/libvirt/libvirt/tree/master/src/cpu_map.
+                                    It is possible to specify special cases like "host-passthrough"
+                                    to get the same CPU as the node and "host-model"
+                                    to get CPU closest to the node one. Defaults to
+                                    host-model.
+                                  type: string
+                                numa:
+                                  description: NUMA allows specifying settings for
+                                    the guest NUMA topology
+                                  properties:
+                                    guestMappingPassthrough:
+                                      description: GuestMappingPassthrough will create
+                                        an efficient guest topology based on host
+                                        CPUs exclusively assigned to a pod. The created
+                                        topology ensures that memory and CPUs on the
+                                        virtual numa nodes never cross boundaries
+                                        of host numa nodes.
+                                      type: object
+                                  type: object
+                                sockets:
+                                  description: Sockets specifies the number of sockets
+                                    inside the vmi. Must be a value greater or equal
+                                    1.
+                                  format: int32
+                                  type: integer
+                                threads:
+                                  description: Threads specifies the number of threads
+                                    inside the vmi. Must be a value greater or equal
+                                    1.
+                                  format: int32
+                                  type: integer
+                              type: object
+                            devices:
+                              description: Devices allows adding disks, network interfaces,
+                                and others
+                              properties:
+                                autoattachGraphicsDevice:
+                                  description: Whether to attach the default graphics
+                                    device or not. VNC will not be available if set
+                                    to false. Defaults to true.
+                                  type: boolean
+                                autoattachMemBalloon:
+                                  description: Whether to attach the Memory balloon
+                                    device with default period. Period can be adjusted
+                                    in virt-config. Defaults to true.
+                                  type: boolean
+                                autoattachPodInterface:
+                                  description: Whether to attach a pod network interface.
+                                    Defaults to true.
+                                  type: boolean
+                                autoattachSerialConsole:
+                                  description: Whether to attach the default serial
+                                    console or not. Serial console access will not
+                                    be available if set to false. Defaults to true.
+                                  type: boolean
+                                autoattachVSOCK:
+                                  description: Whether to attach a VSOCK device to
+                                    the vmi, through which the host can talk to processes
+                                    in the guest without any network. The CID of the
+                                    device is reported in the status of the vmi. Defaults
+                                    to false.
+                                  type: boolean
+                                blockMultiQueue:
+                                  description: Whether or not to enable virtio multi-queue
+                                    for block devices. The number of queues equals
+                                    the number of vCPUs, unless a disk sets its own
+                                    queue count. Defaults to false.
+                                  type: boolean
+                                clientPassthrough:
+                                  description: To configure and access client devices
+                                    such as redirecting USB
+                                  type: object
+                                disableHotplug:
+                                  description: DisableHotplug disabled the ability
+                                    to hotplug disks.
+                                  type: boolean
+                                disks:
+                                  description: Disks describes disks, cdroms, floppy
+                                    and luns which are connected to the vmi.
+                                  items:
+                                    properties:
+                                      blockSize:
+                                        description: If specified, the virtual disk
+                                          will be presented with the given block sizes.
+                                        properties:
+                                          custom:
+                                            description: CustomBlockSize represents
+                                              the desired logical and physical block
+                                              size for a VM disk.
+                                            properties:
+                                              logical:
+                                                type: integer
+                                              physical:
+                                                type: integer
+                                            required:
+                                            - logical
+                                            - physical
+                                            type: object
+                                          matchVolume:
+                                            description: Represents if a feature is
+                                              enabled or disabled.
+                                            properties:
+                                              enabled:
+                                                description: Enabled determines if
+                                                  the feature should be enabled or
+                                                  disabled on the guest. Defaults
+                                                  to true.
+                                                type: boolean
+                                            type: object
+                                        type: object
+                                      bootOrder:
+                                        description: BootOrder is an integer value
+                                          > 0, used to determine ordering of boot
+                                          devices. Lower values take precedence. Each
+                                          disk or interface that has a boot order
+                                          must have a unique value. Disks without
+                                          a boot order are not tried if a disk with
+                                          a boot order exists.
+                                        type: integer
+                                      cache:
+                                        description: 'Cache specifies which kvm disk
+                                          cache mode should be used. Supported values
+                                          are: CacheNone, CacheWriteThrough, CacheWriteBack.'
+                                        type: string
+                                      cdrom:
+                                        description: Attach a volume as a cdrom to
+                                          the vmi.
+                                        properties:
+                                          bus:
+                                            description: 'Bus indicates the type of
+                                              disk device to emulate. supported values:
+                                              virtio, sata, scsi.'
+                                            type: string
+                                          readonly:
+                                            description: ReadOnly. Defaults to true.
+                                            type: boolean
+                                          tray:
+                                            description: Tray indicates if the tray
+                                              of the device is open or closed. Allowed
+                                              values are "open" and "closed". Defaults
+                                              to closed.
+                                            type: string
+                                        type: object
+                                      dedicatedIOThread:
+                                        description: dedicatedIOThread indicates this
+                                          disk should have an exclusive IO Thread.
+                                          Enabling this implies useIOThreads = true.
+                                          Defaults to false.
+                                        type: boolean
+                                      discard:
+                                        description: 'Discard specifies if discard
+                                          requests of the guest are passed to the
+                                          storage of the disk. Supported values are:
+                                          unmap, ignore. Defaults to unmap, or to
+                                          ignore for preallocated volumes. Not supported
+                                          for cdroms and floppies.'
+                                        type: string
+                                      disk:
+                                        description: Attach a volume as a disk to
+                                          the vmi.
+                                        properties:
+                                          bus:
+                                            description: 'Bus indicates the type of
+                                              disk device to emulate. supported values:
+                                              virtio, sata, scsi, nvme.'
+                                            type: string
+                                          pciAddress:
+                                            description: 'If specified, the virtual
+                                              disk will be placed on the guests pci
+                                              address with the specified PCI address.
+                                              For example: 0000:81:01.10'
+                                            type: string
+                                          readonly:
+                                            description: ReadOnly. Defaults to false.
+                                            type: boolean
+                                        type: object
+                                      floppy:
+                                        description: Attach a volume as a floppy to
+                                          the vmi.
+                                        properties:
+                                          readonly:
+                                            description: ReadOnly. Defaults to false.
+                                            type: boolean
+                                          tray:
+                                            description: Tray indicates if the tray
+                                              of the device is open or closed. Allowed
+                                              values are "open" and "closed". Defaults
+                                              to closed.
+                                            type: string
+                                        type: object
+                                      io:
+                                        description: 'IO specifies which QEMU disk
+                                          IO mode should be used. Supported values
+                                          are: native, default, threads.'
+                                        type: string
+                                      ioTune:
+                                        description: IOTune limits the throughput
+                                          and the IOPS of the disk.
+                                        properties:
+                                          readBytesPerSec:
+                                            anyOf:
+                                            - type: integer
+                                            - type: string
+                                            description: ReadBytesPerSec limits the
+                                              bytes read per second.
+                                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                            x-kubernetes-int-or-string: true
+                                          readIOPS:
+                                            description: ReadIOPS limits the read
+                                              operations per second.
+                                            format: int64
+                                            type: integer
+                                          totalBytesPerSec:
+                                            anyOf:
+                                            - type: integer
+                                            - type: string
+                                            description: TotalBytesPerSec limits the
+                                              bytes read and written per second, e.g.
+                                              100Mi.
+                                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                            x-kubernetes-int-or-string: true
+                                          totalIOPS:
+                                            description: TotalIOPS limits the read
+                                              and write operations per second.
+                                            format: int64
+                                            type: integer
+                                          writeBytesPerSec:
+                                            anyOf:
+                                            - type: integer
+                                            - type: string
+                                            description: WriteBytesPerSec limits the
+                                              bytes written per second.
+                                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                            x-kubernetes-int-or-string: true
+                                          writeIOPS:
+                                            description: WriteIOPS limits the write
+                                              operations per second.
+                                            format: int64
+                                            type: integer
+                                        type: object
+                                      lun:
+                                        description: Attach a volume as a LUN to the
+                                          vmi.
+                                        properties:
+                                          bus:
+                                            description: 'Bus indicates the type of
+                                              disk device to emulate. supported values:
+                                              virtio, sata, scsi.'
+                                            type: string
+                                          readonly:
+                                            description: ReadOnly. Defaults to false.
+                                            type: boolean
+                                          reservation:
+                                            description: Reservation lets the guest
+                                              issue SCSI-3 persistent reservation
+                                              commands to the LUN, e.g. for the shared
+                                              disks of failover clusters. Requires
+                                              the scsi bus. Defaults to false.
+                                            type: boolean
+                                        type: object
+                                      name:
+                                        description: Name is the device name
+                                        type: string
+                                      queues:
+                                        description: Queues sets the number of virtio-blk
+                                          queues of this disk, overriding blockMultiQueue.
+                                          Only allowed for disks on the virtio bus.
+                                          Must not exceed the number of vCPUs.
+                                        format: int32
+                                        type: integer
+                                      serial:
+                                        description: Serial provides the ability to
+                                          specify a serial number for the disk device.
+                                        type: string
+                                      tag:
+                                        description: If specified, disk address and
+                                          its tag will be provided to the guest via
+                                          config drive metadata
+                                        type: string
+                                      wwn:
+                                        description: WWN is the World Wide Name of
+                                          the disk device, made up of 16 hexadecimal
+                                          digits. Only allowed for disks and cdroms
+                                          on the sata or scsi bus.
+                                        type: string
+                                    required:
+                                    - name
+                                    type: object
+                                  type: array
+                                filesystems:
+                                  description: Filesystems describes filesystem which
+                                    is connected to the vmi.
+                                  items:
+                                    properties:
+                                      name:
+                                        description: Name is the device name
+                                        type: string
+                                      virtiofs:
+                                        description: Virtiofs is supported
+                                        type: object
+                                    required:
+                                    - name
+                                    - virtiofs
+                                    type: object
+                                  type: array
+                                  x-kubernetes-list-type: atomic
+                                freePageReporting:
+                                  description: Whether the guest reports the memory
+                                    pages it freed to the host through the Memory
+                                    balloon device, so that they can be reclaimed.
+                                    Defaults to true if the memory of the vmi is overcommitted.
+                                  type: boolean
+                                gpus:
+                                  description: Whether to attach a GPU device to the
+                                    vmi.
+                                  items:
+                                    properties:
+                                      deviceName:
+                                        type: string
+                                      name:
+                                        description: Name of the GPU device as exposed
+                                          by a device plugin
+                                        type: string
+                                      virtualGPUOptions:
+                                        description: VirtualGPUOptions configures
+                                          vGPU (mediated device) specific options.
+                                          It is ignored for GPUs which are passed
+                                          through as PCI devices.
+                                        properties:
+                                          display:
+                                            description: Display configures the display
+                                              adapter backed by the vGPU.
+                                            properties:
+                                              enabled:
+                                                description: Enabled determines if
+                                                  a display adapter backed by the
+                                                  vGPU should be enabled or disabled
+                                                  on the guest. Defaults to true.
+                                                type: boolean
+                                              ramFB:
+                                                description: RamFB enables a boot
+                                                  framebuffer which is used until
+                                                  the guest OS loads a real GPU driver.
+                                                  Defaults to true.
+                                                properties:
+                                                  enabled:
+                                                    description: Enabled determines
+                                                      if the feature should be enabled
+                                                      or disabled on the guest. Defaults
+                                                      to true.
+                                                    type: boolean
+                                                type: object
+                                            type: object
+                                        type: object
+                                    required:
+                                    - deviceName
+                                    - name
+                                    type: object
+                                  type: array
+                                  x-kubernetes-list-type: atomic
+                                hostDevices:
+                                  description: Whether to attach a host device to
+                                    the vmi.
+                                  items:
+                                    properties:
+                                      deviceName:
+                                        description: DeviceName is the resource name
+                                          of the host device exposed by a device plugin
+                                        type: string
+                                      name:
+                                        type: string
+                                    required:
+                                    - deviceName
+                                    - name
+                                    type: object
+                                  type: array
+                                  x-kubernetes-list-type: atomic
+                                inputs:
+                                  description: Inputs describe input devices
+                                  items:
+                                    properties:
+                                      bus:
+                                        description: 'Bus indicates the bus of input
+                                          device to emulate. Supported values: virtio,
+                                          usb.'
+                                        type: string
+                                      name:
+                                        description: Name is the device name
+                                        type: string
+                                      type:
+                                        description: 'Type indicated the type of input
+                                          device. Supported values: tablet.'
+                                        type: string
+                                    required:
+                                    - name
+                                    - type
+                                    type: object
+                                  type: array
+                                interfaces:
+                                  description: Interfaces describe network interfaces
+                                    which are added to the vmi.
+                                  items:
+                                    properties:
+                                      bandwidth:
+                                        description: Bandwidth limits the traffic
+                                          of the interface. Only allowed for bridge
+                                          and masquerade interfaces.
+                                        properties:
+                                          egress:
+                                            description: Egress limits the traffic
+                                              sent by the guest.
+                                            properties:
+                                              burst:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: Burst is the amount of
+                                                  bytes which can exceed the rate
+                                                  at once, e.g. 1Mi. Defaults to the
+                                                  traffic of 100ms at the rate, but
+                                                  at least 64Ki.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                              rate:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: Rate is the average rate
+                                                  in bits per second, e.g. 100M.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                            required:
+                                            - rate
+                                            type: object
+                                          ingress:
+                                            description: Ingress limits the traffic
+                                              received by the guest.
+                                            properties:
+                                              burst:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: Burst is the amount of
+                                                  bytes which can exceed the rate
+                                                  at once, e.g. 1Mi. Defaults to the
+                                                  traffic of 100ms at the rate, but
+                                                  at least 64Ki.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                              rate:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: Rate is the average rate
+                                                  in bits per second, e.g. 100M.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                            required:
+                                            - rate
+                                            type: object
+                                        type: object
+                                      bootOrder:
+                                        description: BootOrder is an integer value
+                                          > 0, used to determine ordering of boot
+                                          devices. Lower values take precedence. Each
+                                          interface or disk that has a boot order
+                                          must have a unique value. Interfaces without
+                                          a boot order are not tried.
+                                        type: integer
+                                      bridge:
+                                        type: object
+                                      dhcpOptions:
+                                        description: If specified the network interface
+                                          will pass additional DHCP options to the
+                                          VMI
+                                        properties:
+                                          bootFileName:
+                                            description: If specified will pass option
+                                              67 to interface's DHCP server
+                                            type: string
+                                          ntpServers:
+                                            description: If specified will pass the
+                                              configured NTP server to the VM via
+                                              DHCP option 042.
+                                            items:
+                                              type: string
+                                            type: array
+                                          privateOptions:
+                                            description: 'If specified will pass extra
+                                              DHCP options for private use, range:
+                                              224-254'
+                                            items:
+                                              description: DHCPExtraOptions defines
+                                                Extra DHCP options for a VM.
+                                              properties:
+                                                option:
+                                                  description: Option is an Integer
+                                                    value from 224-254 Required.
+                                                  type: integer
+                                                value:
+                                                  description: Value is a String value
+                                                    for the Option provided Required.
+                                                  type: string
+                                              required:
+                                              - option
+                                              - value
+                                              type: object
+                                            type: array
+                                          tftpServerName:
+                                            description: If specified will pass option
+                                              66 to interface's DHCP server
+                                            type: string
+                                        type: object
+                                      macAddress:
+                                        description: 'Interface MAC address. For example:
+                                          de:ad:00:00:be:af or DE-AD-00-00-BE-AF.'
+                                        type: string
+                                      macvtap:
+                                        type: object
+                                      masquerade:
+                                        type: object
+                                      model:
+                                        description: 'Interface model. One of: e1000,
+                                          e1000e, ne2k_pci, pcnet, rtl8139, virtio.
+                                          Defaults to virtio. TODO:(ihar) switch to
+                                          enums once opengen-api supports them. See:
+                                          https://github.com/kubernetes/kube-openapi/issues/51'
+                                        type: string
+                                      name:
+                                        description: Logical name of the interface
+                                          as well as a reference to the associated
+                                          networks. Must match the Name of a Network.
+                                        type: string
+                                      pciAddress:
+                                        description: 'If specified, the virtual network
+                                          interface will be placed on the guests pci
+                                          address with the specified PCI address.
+                                          For example: 0000:81:01.10'
+                                        type: string
+                                      ports:
+                                        description: List of ports to be forwarded
+                                          to the virtual machine.
+                                        items:
+                                          description: Port repesents a port to expose
+                                            from the virtual machine. Default protocol
+                                            TCP. The port field is mandatory
+                                          properties:
+                                            name:
+                                              description: If specified, this must
+                                                be an IANA_SVC_NAME and unique within
+                                                the pod. Each named port in a pod
+                                                must have a unique name. Name for
+                                                the port that can be referred to by
+                                                services.
+                                              type: string
+                                            port:
+                                              description: Number of port to expose
+                                                for the virtual machine. This must
+                                                be a valid port number, 0 < x < 65536.
+                                              format: int32
+                                              type: integer
+                                            protocol:
+                                              description: Protocol for port. Must
+                                                be UDP or TCP. Defaults to "TCP".
+                                              type: string
+                                          required:
+                                          - port
+                                          type: object
+                                        type: array
+                                      queues:
+                                        description: Queues sets the number of vhost
+                                          queues of this interface, overriding networkInterfaceMultiqueue.
+                                          Only allowed for virtio interfaces. Must
+                                          not exceed the number of vCPUs.
+                                        format: int32
+                                        type: integer
+                                      slirp:
+                                        type: object
+                                      sriov:
+                                        type: object
+                                      tag:
+                                        description: If specified, the virtual network
+                                          interface address and its tag will be provided
+                                          to the guest via config drive
+                                        type: string
+                                      vhostuser:
+                                        description: InterfaceVhostUser connects the
+                                          interface to a userspace dataplane (e.g.
+                                          OVS-DPDK or VPP) over a vhost-user socket.
+                                          QEMU creates the socket in server mode,
+                                          named after the interface, in the vhostuser
+                                          directory shared by the virt-launcher pod,
+                                          and the dataplane connects to it. Requires
+                                          a Multus network and hugepages backed guest
+                                          memory.
+                                        type: object
+                                    required:
+                                    - name
+                                    type: object
+                                  type: array
+                                logSerialConsole:
+                                  description: Whether to log the output of the auto-attached
+                                    serial console to the guest-console-log container
+                                    of the virt-launcher pod, where it can be read
+                                    with kubectl logs. Not relevant if autoattachSerialConsole
+                                    is false. Defaults to false.
+                                  type: boolean
+                                networkInterfaceMultiqueue:
+                                  description: If specified, virtual network interfaces
+                                    configured with a virtio bus will also enable
+                                    the vhost multiqueue feature for network devices.
+                                    The number of queues created depends on additional
+                                    factors of the VirtualMachineInstance, like the
+                                    number of guest CPUs. Interfaces which set their
+                                    own queue count are not affected.
+                                  type: boolean
+                                rng:
+                                  description: Whether to have random number generator
+                                    from host
+                                  type: object
+                                sound:
+                                  description: Whether to emulate a sound device.
+                                  properties:
+                                    model:
+                                      description: 'We only support ich9 or ac97.
+                                        If SoundDevice is not set: No sound card is
+                                        emulated. If SoundDevice is set but Model
+                                        is not: ich9'
+                                      type: string
+                                    name:
+                                      description: User's defined name for this sound
+                                        device
+                                      type: string
+                                  required:
+                                  - name
+                                  type: object
+                                useVirtioTransitional:
+                                  description: Fall back to legacy virtio 0.9 support
+                                    if virtio bus is selected on devices. This is
+                                    helpful for old machines like CentOS6 or RHEL6
+                                    which do not understand virtio_non_transitional
+                                    (virtio 1.0).
+                                  type: boolean
+                                watchdog:
+                                  description: Watchdog describes a watchdog device
+                                    which can be added to the vmi.
+                                  properties:
+                                    i6300esb:
+                                      description: i6300esb watchdog device.
+                                      properties:
+                                        action:
+                                          description: The action to take. Valid values
+                                            are poweroff, reset, shutdown. Defaults
+                                            to reset.
+                                          type: string
+                                      type: object
+                                    name:
+                                      description: Name of the watchdog.
+                                      type: string
+                                  required:
+                                  - name
+                                  type: object
+                              type: object
+                            features:
+                              description: Features like acpi, apic, hyperv, smm.
+                              properties:
+                                acpi:
+                                  description: ACPI enables/disables ACPI inside the
+                                    guest. Defaults to enabled.
+                                  properties:
+                                    enabled:
+                                      description: Enabled determines if the feature
+                                        should be enabled or disabled on the guest.
+                                        Defaults to true.
+                                      type: boolean
+                                  type: object
+                                apic:
+                                  description: Defaults to the machine type setting.
+                                  properties:
+                                    enabled:
+                                      description: Enabled determines if the feature
+                                        should be enabled or disabled on the guest.
+                                        Defaults to true.
+                                      type: boolean
+                                    endOfInterrupt:
+                                      description: EndOfInterrupt enables the end
+                                        of interrupt notification in the guest. Defaults
+                                        to false.
+                                      type: boolean
+                                  type: object
+                                hyperv:
+                                  description: Defaults to the machine type setting.
+                                  properties:
+                                    evmcs:
+                                      description: EVMCS Speeds up L2 vmexits, but
+                                        disables other virtualization features. Requires
+                                        vapic. Defaults to the machine type setting.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature
+                                            should be enabled or disabled on the guest.
+                                            Defaults to true.
+                                          type: boolean
+                                      type: object
+                                    frequencies:
+                                      description: Frequencies improves the TSC clock
+                                        source handling for Hyper-V on KVM. Defaults
+                                        to the machine type setting.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature
+                                            should be enabled or disabled on the guest.
+                                            Defaults to true.
+                                          type: boolean
+                                      type: object
+                                    ipi:
+                                      description: IPI improves performances in overcommited
+                                        environments. Requires vpindex. Defaults to
+                                        the machine type setting.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature
+                                            should be enabled or disabled on the guest.
+                                            Defaults to true.
+                                          type: boolean
+                                      type: object
+                                    reenlightenment:
+                                      description: Reenlightenment enables the notifications
+                                        on TSC frequency changes. Defaults to the
+                                        machine type setting.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature
+                                            should be enabled or disabled on the guest.
+                                            Defaults to true.
+                                          type: boolean
+                                      type: object
+                                    relaxed:
+                                      description: Relaxed instructs the guest OS
+                                        to disable watchdog timeouts. Defaults to
+                                        the machine type setting.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature
+                                            should be enabled or disabled on the guest.
+                                            Defaults to true.
+                                          type: boolean
+                                      type: object
+                                    reset:
+                                      description: Reset enables Hyperv reboot/reset
+                                        for the vmi. Requires synic. Defaults to the
+                                        machine type setting.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature
+                                            should be enabled or disabled on the guest.
+                                            Defaults to true.
+                                          type: boolean
+                                      type: object
+                                    runtime:
+                                      description: Runtime improves the time accounting
+                                        to improve scheduling in the guest. Defaults
+                                        to the machine type setting.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature
+                                            should be enabled or disabled on the guest.
+                                            Defaults to true.
+                                          type: boolean
+                                      type: object
+                                    spinlocks:
+                                      description: Spinlocks allows to configure the
+                                        spinlock retry attempts.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature
+                                            should be enabled or disabled on the guest.
+                                            Defaults to true.
+                                          type: boolean
+                                        spinlocks:
+                                          description: Retries indicates the number
+                                            of retries. Must be a value greater or
+                                            equal 4096. Defaults to 4096.
+                                          format: int32
+                                          type: integer
+                                      type: object
+                                    synic:
+                                      description: SyNIC enables the Synthetic Interrupt
+                                        Controller. Defaults to the machine type setting.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature
+                                            should be enabled or disabled on the guest.
+                                            Defaults to true.
+                                          type: boolean
+                                      type: object
+                                    synictimer:
+                                      description: SyNICTimer enables Synthetic Interrupt
+                                        Controller Timers, reducing CPU load. Defaults
+                                        to the machine type setting.
+                                      properties:
+                                        direct:
+                                          description: Represents if a feature is
+                                            enabled or disabled.
+                                          properties:
+                                            enabled:
+                                              description: Enabled determines if the
+                                                feature should be enabled or disabled
+                                                on the guest. Defaults to true.
+                                              type: boolean
+                                          type: object
+                                        enabled:
+                                          type: boolean
+                                      type: object
+                                    tlbflush:
+                                      description: TLBFlush improves performances
+                                        in overcommited environments. Requires vpindex.
+                                        Defaults to the machine type setting.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature
+                                            should be enabled or disabled on the guest.
+                                            Defaults to true.
+                                          type: boolean
+                                      type: object
+                                    vapic:
+                                      description: VAPIC improves the paravirtualized
+                                        handling of interrupts. Defaults to the machine
+                                        type setting.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature
+                                            should be enabled or disabled on the guest.
+                                            Defaults to true.
+                                          type: boolean
+                                      type: object
+                                    vendorid:
+                                      description: VendorID allows setting the hypervisor
+                                        vendor id. Defaults to the machine type setting.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature
+                                            should be enabled or disabled on the guest.
+                                            Defaults to true.
+                                          type: boolean
+                                        vendorid:
+                                          description: VendorID sets the hypervisor
+                                            vendor id, visible to the vmi. String
+                                            up to twelve characters.
+                                          type: string
+                                      type: object
+                                    vpindex:
+                                      description: VPIndex enables the Virtual Processor
+                                        Index to help windows identifying virtual
+                                        processors. Defaults to the machine type setting.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the feature
+                                            should be enabled or disabled on the guest.
+                                            Defaults to true.
+                                          type: boolean
+                                      type: object
+                                  type: object
+                                hypervPassthrough:
+                                  description: HypervPassthrough enables all the Hyper-V
+                                    enlightenments which are supported by the host.
+                                    It can't be combined with hyperv. VMIs with Hyper-V
+                                    passthrough are not live migratable.
+                                  properties:
+                                    enabled:
+                                      description: Enabled determines if the Hyper-V
+                                        passthrough mode should be used. Defaults
+                                        to true.
+                                      type: boolean
+                                  type: object
+                                kvm:
+                                  description: Configure how KVM presence is exposed
+                                    to the guest.
+                                  properties:
+                                    hidden:
+                                      description: Hide the KVM hypervisor from standard
+                                        MSR based discovery. Defaults to false
+                                      type: boolean
+                                  type: object
+                                pvspinlock:
+                                  description: Notify the guest that the host supports
+                                    paravirtual spinlocks. For older kernels this
+                                    feature should be explicitly disabled.
+                                  properties:
+                                    enabled:
+                                      description: Enabled determines if the feature
+                                        should be enabled or disabled on the guest.
+                                        Defaults to true.
+                                      type: boolean
+                                  type: object
+                                smm:
+                                  description: SMM enables/disables System Management
+                                    Mode. TSEG not yet implemented.
+                                  properties:
+                                    enabled:
+                                      description: Enabled determines if the feature
+                                        should be enabled or disabled on the guest.
+                                        Defaults to true.
+                                      type: boolean
+                                  type: object
+                              type: object
+                            firmware:
+                              description: Firmware.
+                              properties:
+                                bootMenu:
+                                  description: BootMenu shows a menu while booting,
+                                    in which the device to boot from can be picked
+                                    in the console, instead of booting the devices
+                                    in their boot order.
+                                  properties:
+                                    timeout:
+                                      description: Timeout is how long the boot menu
+                                        waits for a pick, in milliseconds, before
+                                        it boots the first device of the boot order.
+                                        At most 65535. Defaults to the timeout of
+                                        the firmware.
+                                      format: int32
+                                      type: integer
+                                  type: object
+                                bootloader:
+                                  description: Settings to control the bootloader
+                                    that is used.
+                                  properties:
+                                    bios:
+                                      description: If set (default), BIOS will be
+                                        used.
+                                      properties:
+                                        useSerial:
+                                          description: If set, the BIOS output will
+                                            be transmitted over serial
+                                          type: boolean
+                                      type: object
+                                    efi:
+                                      description: If set, EFI will be used instead
+                                        of BIOS.
+                                      properties:
+                                        secureBoot:
+                                          description: If set, SecureBoot will be
+                                            enabled and the OVMF roms will be swapped
+                                            for SecureBoot-enabled ones. Requires
+                                            SMM to be enabled. Defaults to true
+                                          type: boolean
+                                      type: object
+                                  type: object
+                                kernelBoot:
+                                  description: Settings to set the kernel for booting.
+                                  properties:
+                                    container:
+                                      description: Container defines the container
+                                        that containes kernel artifacts
+                                      properties:
+                                        image:
+                                          description: Image that container initrd
+                                            / kernel files.
+                                          type: string
+                                        imagePullPolicy:
+                                          description: 'Image pull policy. One of
+                                            Always, Never, IfNotPresent. Defaults
+                                            to Always if :latest tag is specified,
+                                            or IfNotPresent otherwise. Cannot be updated.
+                                            More info: https://kubernetes.io/docs/concepts/containers/images#updating-images'
+                                          type: string
+                                        imagePullSecret:
+                                          description: ImagePullSecret is the name
+                                            of the Docker registry secret required
+                                            to pull the image. The secret must already
+                                            exist.
+                                          type: string
+                                        initrdPath:
+                                          description: the fully-qualified path to
+                                            the ramdisk image in the host OS
+                                          type: string
+                                        kernelPath:
+                                          description: The fully-qualified path to
+                                            the kernel image in the host OS
+                                          type: string
+                                      required:
+                                      - image
+                                      type: object
+                                    kernelArgs:
+                                      description: Arguments to be passed to the kernel
+                                        at boot time
+                                      type: string
+                                  type: object
+                                serial:
+                                  description: The system-serial-number in SMBIOS
+                                  type: string
+                                uuid:
+                                  description: UUID reported by the vmi bios. Defaults
+                                    to a random generated uid.
+                                  type: string
+                              type: object
+                            ioThreadsPolicy:
+                              description: 'Controls whether or not disks will share
+                                IOThreads. Omitting IOThreadsPolicy disables use of
+                                IOThreads. One of: shared, auto'
+                              type: string
+                            machine:
+                              description: Machine type.
+                              properties:
+                                type:
+                                  description: QEMU machine type is the actual chipset
+                                    of the VirtualMachineInstance.
+                                  type: string
+                              type: object
+                            memory:
+                              description: Memory allow specifying the VMI memory
+                                features.
+                              properties:
+                                guest:
+                                  anyOf:
+                                  - type: integer
+                                  - type: string
+                                  description: Guest allows to specifying the amount
+                                    of memory which is visible inside the Guest OS.
+                                    The Guest must lie between Requests and Limits
+                                    from the resources section. Defaults to the requested
+                                    memory in the resources section if not specified.
+                                  pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                  x-kubernetes-int-or-string: true
+                                hugepages:
+                                  description: Hugepages allow to use hugepages for
+                                    the VirtualMachineInstance instead of regular
+                                    memory.
+                                  properties:
+                                    pageSize:
+                                      description: PageSize specifies the hugepage
+                                        size, for x86_64 architecture valid values
+                                        are 1Gi and 2Mi.
+                                      type: string
+                                  type: object
+                              type: object
+                            qemuArgs:
+                              description: QEMUArgs are appended to the QEMU command
+                                line of the vmi. Requires the QEMUArgs feature gate,
+                                only arguments which are allowed in the KubeVirt configuration
+                                are accepted.
+                              items:
+                                description: QEMUArg is an argument which is appended
+                                  to the QEMU command line.
+                                properties:
+                                  name:
+                                    description: Name of the argument, including the
+                                      leading dash, e.g. "-fw_cfg".
+                                    type: string
+                                  value:
+                                    description: Value of the argument, e.g. "name=opt/com.example/config,string=value".
+                                    type: string
+                                required:
+                                - name
+                                type: object
+                              type: array
+                            resources:
+                              description: Resources describes the Compute Resources
+                                required by this vmi.
+                              properties:
+                                limits:
+                                  additionalProperties:
+                                    anyOf:
+                                    - type: integer
+                                    - type: string
+                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                    x-kubernetes-int-or-string: true
+                                  description: Limits describes the maximum amount
+                                    of compute resources allowed. Valid resource keys
+                                    are "memory" and "cpu".
+                                  type: object
+                                overcommitGuestOverhead:
+                                  description: Don't ask the scheduler to take the
+                                    guest-management overhead into account. Instead
+                                    put the overhead only into the container's memory
+                                    limit. This can lead to crashes if all memory
+                                    is in use on a node. Defaults to false.
+                                  type: boolean
+                                requests:
+                                  additionalProperties:
+                                    anyOf:
+                                    - type: integer
+                                    - type: string
+                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                    x-kubernetes-int-or-string: true
+                                  description: Requests is a description of the initial
+                                    vmi resources. Valid resource keys are "memory"
+                                    and "cpu".
+                                  type: object
+                              type: object
+                          required:
+                          - devices
+                          type: object
+                        evictionStrategy:
+                          description: EvictionStrategy can be set to "LiveMigrate"
+                            if the VirtualMachineInstance should be migrated instead
+                            of shut-off in case of a node drain, or to "LiveMigrateIfPossible"
+                            if it should be migrated when it is live-migratable and
+                            evicted otherwise.
+                          type: string
+                        hostname:
+                          description: Specifies the hostname of the vmi If not specified,
+                            the hostname will be set to the name of the vmi, if dhcp
+                            or cloud-init is configured properly.
+                          type: string
+                        livenessProbe:
+                          description: 'Periodic probe of VirtualMachineInstance liveness.
+                            VirtualmachineInstances will be stopped if the probe fails.
+                            Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                          properties:
+                            exec:
+                              description: One and only one of the following should
+                                be specified. Exec specifies the action to take, it
+                                will be executed on the guest through the qemu-guest-agent.
+                                If the guest agent is not available, this probe will
+                                fail.
+                              properties:
+                                command:
+                                  description: Command is the command line to execute
+                                    inside the container, the working directory for
+                                    the command  is root ('/') in the container's
+                                    filesystem. The command is simply exec'd, it is
+                                    not run inside a shell, so traditional shell instructions
+                                    ('|', etc) won't work. To use a shell, you need
+                                    to explicitly call out to that shell. Exit status
+                                    of 0 is treated as live/healthy and non-zero is
+                                    unhealthy.
+                                  items:
+                                    type: string
+                                  type: array
+                              type: object
+                            failureThreshold:
+                              description: Minimum consecutive failures for the probe
+                                to be considered failed after having succeeded. Defaults
+                                to 3. Minimum value is 1.
+                              format: int32
+                              type: integer
+                            guestAgentPing:
+                              description: GuestAgentPing contacts the qemu-guest-agent
+                                for availability checks.
+                              type: object
+                            httpGet:
+                              description: HTTPGet specifies the http request to perform.
+                              properties:
+                                host:
+                                  description: Host name to connect to, defaults to
+                                    the pod IP. You probably want to set "Host" in
+                                    httpHeaders instead.
+                                  type: string
+                                httpHeaders:
+                                  description: Custom headers to set in the request.
+                                    HTTP allows repeated headers.
+                                  items:
+                                    description: HTTPHeader describes a custom header
+                                      to be used in HTTP probes
+                                    properties:
+                                      name:
+                                        description: The header field name
+                                        type: string
+                                      value:
+                                        description: The header field value
+                                        type: string
+                                    required:
+                                    - name
+                                    - value
+                                    type: object
+                                  type: array
+                                path:
+                                  description: Path to access on the HTTP server.
+                                  type: string
+                                port:
+                                  anyOf:
+                                  - type: integer
+                                  - type: string
+                                  description: Name or number of the port to access
+                                    on the container. Number must be in the range
+                                    1 to 65535. Name must be an IANA_SVC_NAME.
+                                  x-kubernetes-int-or-string: true
+                                scheme:
+                                  description: Scheme to use for connecting to the
+                                    host. Defaults to HTTP.
+                                  type: string
+                              required:
+                              - port
+                              type: object
+                            initialDelaySeconds:
+                              description: 'Number of seconds after the VirtualMachineInstance
+                                has started before liveness probes are initiated.
+                                More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                              format: int32
+                              type: integer
+                            periodSeconds:
+                              description: How often (in seconds) to perform the probe.
+                                Default to 10 seconds. Minimum value is 1.
+                              format: int32
+                              type: integer
+                            successThreshold:
+                              description: Minimum consecutive successes for the probe
+                                to be considered successful after having failed. Defaults
+                                to 1. Must be 1 for liveness. Minimum value is 1.
+                              format: int32
+                              type: integer
+                            tcpSocket:
+                              description: 'TCPSocket specifies an action involving
+                                a TCP port. TCP hooks not yet supported TODO: implement
+                                a realistic TCP lifecycle hook'
+                              properties:
+                                host:
+                                  description: 'Optional: Host name to connect to,
+                                    defaults to the pod IP.'
+                                  type: string
+                                port:
+                                  anyOf:
+                                  - type: integer
+                                  - type: string
+                                  description: Number or name of the port to access
+                                    on the container. Number must be in the range
+                                    1 to 65535. Name must be an IANA_SVC_NAME.
+                                  x-kubernetes-int-or-string: true
+                              required:
+                              - port
+                              type: object
+                            timeoutSeconds:
+                              description: 'Number of seconds after which the probe
+                                times out. For exec probes the timeout fails the probe
+                                but does not terminate the command running on the
+                                guest. This means a blocking command can result in
+                                an increasing load on the guest. A small buffer will
+                                be added to the resulting workload exec probe to compensate
+                                for delays caused by the qemu guest exec mechanism.
+                                Defaults to 1 second. Minimum value is 1. More info:
+                                https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                              format: int32
+                              type: integer
+                          type: object
+                        networks:
+                          description: List of networks that can be attached to a
+                            vm's virtual interface.
+                          items:
+                            description: Network represents a network type and a resource
+                              that should be connected to the vm.
+                            properties:
+                              multus:
+                                description: Represents the multus cni network.
+                                properties:
+                                  default:
+                                    description: Select the default network and add
+                                      it to the multus-cni.io/default-network annotation.
+                                    type: boolean
+                                  ipAddresses:
+                                    description: IPAddresses requests static IP addresses
+                                      in CIDR notation, e.g. 192.168.1.10/24, for
+                                      the interface. They are passed to the "ips"
+                                      capability of the CNI plugin. Not supported
+                                      on the default network.
+                                    items:
+                                      type: string
+                                    type: array
+                                    x-kubernetes-list-type: atomic
+                                  ipPool:
+                                    description: IPPool references a pool of the IPAM
+                                      plugin of the network from which the addresses
+                                      of the interface are assigned. It is passed
+                                      as the "ipPool" CNI arg. Not supported on the
+                                      default network.
+                                    type: string
+                                  networkName:
+                                    description: 'References to a NetworkAttachmentDefinition
+                                      CRD object. Format: <networkName>, <namespace>/<networkName>.
+                                      If namespace is not specified, VMI namespace
+                                      is assumed.'
+                                    type: string
+                                required:
+                                - networkName
+                                type: object
+                              name:
+                                description: 'Network name. Must be a DNS_LABEL and
+                                  unique within the vm. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
+                                type: string
+                              pod:
+                                description: Represents the stock pod network interface.
+                                properties:
+                                  vmIPv6NetworkCIDR:
+                                    description: IPv6 CIDR for the vm network. Defaults
+                                      to fd10:0:2::/120 if not specified.
+                                    type: string
+                                  vmNetworkCIDR:
+                                    description: CIDR for vm network. Default 10.0.2.0/24
+                                      if not specified.
+                                    type: string
+                                type: object
+                            required:
+                            - name
+                            type: object
+                          type: array
+                        nodeSelector:
+                          additionalProperties:
+                            type: string
+                          description: 'NodeSelector is a selector which must be true
+                            for the vmi to fit on a node. Selector which must match
+                            a node''s labels for the vmi to be scheduled on that node.
+                            More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/'
+                          type: object
+                        priorityClassName:
+                          description: If specified, indicates the pod's priority.
+                            If not specified, the pod priority will be default or
+                            zero if there is no default. VMIs with a higher priority
+                            are evacuated first when a node is drained.
+                          type: string
+                        readinessProbe:
+                          description: 'Periodic probe of VirtualMachineInstance service
+                            readiness. VirtualmachineInstances will be removed from
+                            service endpoints if the probe fails. Cannot be updated.
+                            More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                          properties:
+                            exec:
+                              description: One and only one of the following should
+                                be specified. Exec specifies the action to take, it
+                                will be executed on the guest through the qemu-guest-agent.
+                                If the guest agent is not available, this probe will
+                                fail.
+                              properties:
+                                command:
+                                  description: Command is the command line to execute
+                                    inside the container, the working directory for
+                                    the command  is root ('/') in the container's
+                                    filesystem. The command is simply exec'd, it is
+                                    not run inside a shell, so traditional shell instructions
+                                    ('|', etc) won't work. To use a shell, you need
+                                    to explicitly call out to that shell. Exit status
+                                    of 0 is treated as live/healthy and non-zero is
+                                    unhealthy.
+                                  items:
+                                    type: string
+                                  type: array
+                              type: object
+                            failureThreshold:
+                              description: Minimum consecutive failures for the probe
+                                to be considered failed after having succeeded. Defaults
+                                to 3. Minimum value is 1.
+                              format: int32
+                              type: integer
+                            guestAgentPing:
+                              description: GuestAgentPing contacts the qemu-guest-agent
+                                for availability checks.
+                              type: object
+                            httpGet:
+                              description: HTTPGet specifies the http request to perform.
+                              properties:
+                                host:
+                                  description: Host name to connect to, defaults to
+                                    the pod IP. You probably want to set "Host" in
+                                    httpHeaders instead.
+                                  type: string
+                                httpHeaders:
+                                  description: Custom headers to set in the request.
+                                    HTTP allows repeated headers.
+                                  items:
+                                    description: HTTPHeader describes a custom header
+                                      to be used in HTTP probes
+                                    properties:
+                                      name:
+                                        description: The header field name
+                                        type: string
+                                      value:
+                                        description: The header field value
+                                        type: string
+                                    required:
+                                    - name
+                                    - value
+                                    type: object
+                                  type: array
+                                path:
+                                  description: Path to access on the HTTP server.
+                                  type: string
+                                port:
+                                  anyOf:
+                                  - type: integer
+                                  - type: string
+                                  description: Name or number of the port to access
+                                    on the container. Number must be in the range
+                                    1 to 65535. Name must be an IANA_SVC_NAME.
+                                  x-kubernetes-int-or-string: true
+                                scheme:
+                                  description: Scheme to use for connecting to the
+                                    host. Defaults to HTTP.
+                                  type: string
+                              required:
+                              - port
+                              type: object
+                            initialDelaySeconds:
+                              description: 'Number of seconds after the VirtualMachineInstance
+                                has started before liveness probes are initiated.
+                                More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                              format: int32
+                              type: integer
+                            periodSeconds:
+                              description: How often (in seconds) to perform the probe.
+                                Default to 10 seconds. Minimum value is 1.
+                              format: int32
+                              type: integer
+                            successThreshold:
+                              description: Minimum consecutive successes for the probe
+                                to be considered successful after having failed. Defaults
+                                to 1. Must be 1 for liveness. Minimum value is 1.
+                              format: int32
+                              type: integer
+                            tcpSocket:
+                              description: 'TCPSocket specifies an action involving
+                                a TCP port. TCP hooks not yet supported TODO: implement
+                                a realistic TCP lifecycle hook'
+                              properties:
+                                host:
+                                  description: 'Optional: Host name to connect to,
+                                    defaults to the pod IP.'
+                                  type: string
+                                port:
+                                  anyOf:
+                                  - type: integer
+                                  - type: string
+                                  description: Number or name of the port to access
+                                    on the container. Number must be in the range
+                                    1 to 65535. Name must be an IANA_SVC_NAME.
+                                  x-kubernetes-int-or-string: true
+                              required:
+                              - port
+                              type: object
+                            timeoutSeconds:
+                              description: 'Number of seconds after which the probe
+                                times out. For exec probes the timeout fails the probe
+                                but does not terminate the command running on the
+                                guest. This means a blocking command can result in
+                                an increasing load on the guest. A small buffer will
+                                be added to the resulting workload exec probe to compensate
+                                for delays caused by the qemu guest exec mechanism.
+                                Defaults to 1 second. Minimum value is 1. More info:
+                                https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                              format: int32
+                              type: integer
+                          type: object
+                        schedulerName:
+                          description: If specified, the VMI will be dispatched by
+                            specified scheduler. If not specified, the VMI will be
+                            dispatched by default scheduler.
+                          type: string
+                        startStrategy:
+                          description: StartStrategy can be set to "Paused" if Virtual
+                            Machine should be started in paused state.
+                          type: string
+                        startupProbe:
+                          description: 'Probe indicating that the VirtualMachineInstance
+                            has successfully started. Liveness and readiness probes
+                            are not executed until it succeeds. VirtualmachineInstances
+                            will be stopped if the probe fails. Cannot be updated.
+                            More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                          properties:
+                            exec:
+                              description: One and only one of the following should
+                                be specified. Exec specifies the action to take, it
+                                will be executed on the guest through the qemu-guest-agent.
+                                If the guest agent is not available, this probe will
+                                fail.
+                              properties:
+                                command:
+                                  description: Command is the command line to execute
+                                    inside the container, the working directory for
+                                    the command  is root ('/') in the container's
+                                    filesystem. The command is simply exec'd, it is
+                                    not run inside a shell, so traditional shell instructions
+                                    ('|', etc) won't work. To use a shell, you need
+                                    to explicitly call out to that shell. Exit status
+                                    of 0 is treated as live/healthy and non-zero is
+                                    unhealthy.
+                                  items:
+                                    type: string
+                                  type: array
+                              type: object
+                            failureThreshold:
+                              description: Minimum consecutive failures for the probe
+                                to be considered failed after having succeeded. Defaults
+                                to 3. Minimum value is 1.
+                              format: int32
+                              type: integer
+                            guestAgentPing:
+                              description: GuestAgentPing contacts the qemu-guest-agent
+                                for availability checks.
+                              type: object
+                            httpGet:
+                              description: HTTPGet specifies the http request to perform.
+                              properties:
+                                host:
+                                  description: Host name to connect to, defaults to
+                                    the pod IP. You probably want to set "Host" in
+                                    httpHeaders instead.
+                                  type: string
+                                httpHeaders:
+                                  description: Custom headers to set in the request.
+                                    HTTP allows repeated headers.
+                                  items:
+                                    description: HTTPHeader describes a custom header
+                                      to be used in HTTP probes
+                                    properties:
+                                      name:
+                                        description: The header field name
+                                        type: string
+                                      value:
+                                        description: The header field value
+                                        type: string
+                                    required:
+                                    - name
+                                    - value
+                                    type: object
+                                  type: array
+                                path:
+                                  description: Path to access on the HTTP server.
+                                  type: string
+                                port:
+                                  anyOf:
+                                  - type: integer
+                                  - type: string
+                                  description: Name or number of the port to access
+                                    on the container. Number must be in the range
+                                    1 to 65535. Name must be an IANA_SVC_NAME.
+                                  x-kubernetes-int-or-string: true
+                                scheme:
+                                  description: Scheme to use for connecting to the
+                                    host. Defaults to HTTP.
+                                  type: string
+                              required:
+                              - port
+                              type: object
+                            initialDelaySeconds:
+                              description: 'Number of seconds after the VirtualMachineInstance
+                                has started before liveness probes are initiated.
+                                More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                              format: int32
+                              type: integer
+                            periodSeconds:
+                              description: How often (in seconds) to perform the probe.
+                                Default to 10 seconds. Minimum value is 1.
+                              format: int32
+                              type: integer
+                            successThreshold:
+                              description: Minimum consecutive successes for the probe
+                                to be considered successful after having failed. Defaults
+                                to 1. Must be 1 for liveness. Minimum value is 1.
+                              format: int32
+                              type: integer
+                            tcpSocket:
+                              description: 'TCPSocket specifies an action involving
+                                a TCP port. TCP hooks not yet supported TODO: implement
+                                a realistic TCP lifecycle hook'
+                              properties:
+                                host:
+                                  description: 'Optional: Host name to connect to,
+                                    defaults to the pod IP.'
+                                  type: string
+                                port:
+                                  anyOf:
+                                  - type: integer
+                                  - type: string
+                                  description: Number or name of the port to access
+                                    on the container. Number must be in the range
+                                    1 to 65535. Name must be an IANA_SVC_NAME.
+                                  x-kubernetes-int-or-string: true
+                              required:
+                              - port
+                              type: object
+                            timeoutSeconds:
+                              description: 'Number of seconds after which the probe
+                                times out. For exec probes the timeout fails the probe
+                                but does not terminate the command running on the
+                                guest. This means a blocking command can result in
+                                an increasing load on the guest. A small buffer will
+                                be added to the resulting workload exec probe to compensate
+                                for delays caused by the qemu guest exec mechanism.
+                                Defaults to 1 second. Minimum value is 1. More info:
+                                https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                              format: int32
+                              type: integer
+                          type: object
+                        subdomain:
+                          description: If specified, the fully qualified vmi hostname
+                            will be "<hostname>.<subdomain>.<pod namespace>.svc.<cluster
+                            domain>". If not specified, the vmi will not have a domainname
+                            at all. The DNS entry will resolve to the vmi, no matter
+                            if the vmi itself can pick up a hostname.
+                          type: string
+                        terminationGracePeriodSeconds:
+                          description: Grace period observed after signalling a VirtualMachineInstance
+                            to stop after which the VirtualMachineInstance is force
+                            terminated.
+                          format: int64
+                          type: integer
+                        tolerations:
+                          description: If toleration is specified, obey all the toleration
+                            rules.
+                          items:
+                            description: The pod this Toleration is attached to tolerates
+                              any taint that matches the triple <key,value,effect>
+                              using the matching operator <operator>.
+                            properties:
+                              effect:
+                                description: Effect indicates the taint effect to
+                                  match. Empty means match all taint effects. When
+                                  specified, allowed values are NoSchedule, PreferNoSchedule
+                                  and NoExecute.
+                                type: string
+                              key:
+                                description: Key is the taint key that the toleration
+                                  applies to. Empty means match all taint keys. If
+                                  the key is empty, operator must be Exists; this
+                                  combination means to match all values and all keys.
+                                type: string
+                              operator:
+                                description: Operator represents a key's relationship
+                                  to the value. Valid operators are Exists and Equal.
+                                  Defaults to Equal. Exists is equivalent to wildcard
+                                  for value, so that a pod can tolerate all taints
+                                  of a particular category.
+                                type: string
+                              tolerationSeconds:
+                                description: TolerationSeconds represents the period
+                                  of time the toleration (which must be of effect
+                                  NoExecute, otherwise this field is ignored) tolerates
+                                  the taint. By default, it is not set, which means
+                                  tolerate the taint forever (do not evict). Zero
+                                  and negative values will be treated as 0 (evict
+                                  immediately) by the system.
+                                format: int64
+                                type: integer
+                              value:
+                                description: Value is the taint value the toleration
+                                  matches to. If the operator is Exists, the value
+                                  should be empty, otherwise just a regular string.
                                 type: string
                             type: object
-                          secret:
-                            description: Secret references a k8s Secret that contains
-                              Sysprep answer file named autounattend.xml that should
-                              be attached as disk of CDROM type.
+                          type: array
+                        volumes:
+                          description: List of volumes that can be mounted by disks
+                            belonging to the vmi.
+                          items:
+                            description: Volume represents a named volume in a vmi.
                             properties:
+                              cloudInitConfigDrive:
+                                description: 'CloudInitConfigDrive represents a cloud-init
+                                  Config Drive user-data source. The Config Drive
+                                  data will be added as a disk to the vmi. A proper
+                                  cloud-init installation is required inside the guest.
+                                  More info: https://cloudinit.readthedocs.io/en/latest/topics/datasources/configdrive.html'
+                                properties:
+                                  networkData:
+                                    description: NetworkData contains config drive
+                                      inline cloud-init networkdata.
+                                    type: string
+                                  networkDataBase64:
+                                    description: NetworkDataBase64 contains config
+                                      drive cloud-init networkdata as a base64 encoded
+                                      string.
+                                    type: string
+                                  networkDataSecretRef:
+                                    description: NetworkDataSecretRef references a
+                                      k8s secret that contains config drive networkdata.
+                                    properties:
+                                      name:
+                                        description: 'Name of the referent. More info:
+                                          https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                          TODO: Add other useful fields. apiVersion,
+                                          kind, uid?'
+                                        type: string
+                                    type: object
+                                  secretRef:
+                                    description: UserDataSecretRef references a k8s
+                                      secret that contains config drive userdata.
+                                    properties:
+                                      name:
+                                        description: 'Name of the referent. More info:
+                                          https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                          TODO: Add other useful fields. apiVersion,
+                                          kind, uid?'
+                                        type: string
+                                    type: object
+                                  userData:
+                                    description: UserData contains config drive inline
+                                      cloud-init userdata.
+                                    type: string
+                                  userDataBase64:
+                                    description: UserDataBase64 contains config drive
+                                      cloud-init userdata as a base64 encoded string.
+                                    type: string
+                                type: object
+                              cloudInitNoCloud:
+                                description: 'CloudInitNoCloud represents a cloud-init
+                                  NoCloud user-data source. The NoCloud data will
+                                  be added as a disk to the vmi. A proper cloud-init
+                                  installation is required inside the guest. More
+                                  info: http://cloudinit.readthedocs.io/en/latest/topics/datasources/nocloud.html'
+                                properties:
+                                  networkData:
+                                    description: NetworkData contains NoCloud inline
+                                      cloud-init networkdata.
+                                    type: string
+                                  networkDataBase64:
+                                    description: NetworkDataBase64 contains NoCloud
+                                      cloud-init networkdata as a base64 encoded string.
+                                    type: string
+                                  networkDataSecretRef:
+                                    description: NetworkDataSecretRef references a
+                                      k8s secret that contains NoCloud networkdata.
+                                    properties:
+                                      name:
+                                        description: 'Name of the referent. More info:
+                                          https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                          TODO: Add other useful fields. apiVersion,
+                                          kind, uid?'
+                                        type: string
+                                    type: object
+                                  secretRef:
+                                    description: UserDataSecretRef references a k8s
+                                      secret that contains NoCloud userdata.
+                                    properties:
+                                      name:
+                                        description: 'Name of the referent. More info:
+                                          https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                          TODO: Add other useful fields. apiVersion,
+                                          kind, uid?'
+                                        type: string
+                                    type: object
+                                  userData:
+                                    description: UserData contains NoCloud inline
+                                      cloud-init userdata.
+                                    type: string
+                                  userDataBase64:
+                                    description: UserDataBase64 contains NoCloud cloud-init
+                                      userdata as a base64 encoded string.
+                                    type: string
+                                type: object
+                              configMap:
+                                description: 'ConfigMapSource represents a reference
+                                  to a ConfigMap in the same namespace. More info:
+                                  https://kubernetes.io/docs/tasks/configure-pod-container/configure-pod-configmap/'
+                                properties:
+                                  name:
+                                    description: 'Name of the referent. More info:
+                                      https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                      TODO: Add other useful fields. apiVersion, kind,
+                                      uid?'
+                                    type: string
+                                  optional:
+                                    description: Specify whether the ConfigMap or
+                                      it's keys must be defined
+                                    type: boolean
+                                  volumeLabel:
+                                    description: The volume label of the resulting
+                                      disk inside the VMI. Different bootstrapping
+                                      mechanisms require different values. Typical
+                                      values are "cidata" (cloud-init), "config-2"
+                                      (cloud-init) or "OEMDRV" (kickstart).
+                                    type: string
+                                type: object
+                              containerDisk:
+                                description: 'ContainerDisk references a docker image,
+                                  embedding a qcow or raw disk. More info: https://kubevirt.gitbooks.io/user-guide/registry-disk.html'
+                                properties:
+                                  image:
+                                    description: Image is the name of the image with
+                                      the embedded disk.
+                                    type: string
+                                  imagePullPolicy:
+                                    description: 'Image pull policy. One of Always,
+                                      Never, IfNotPresent. Defaults to Always if :latest
+                                      tag is specified, or IfNotPresent otherwise.
+                                      Cannot be updated. More info: https://kubernetes.io/docs/concepts/containers/images#updating-images'
+                                    type: string
+                                  imagePullSecret:
+                                    description: ImagePullSecret is the name of the
+                                      Docker registry secret required to pull the
+                                      image. The secret must already exist.
+                                    type: string
+                                  overlay:
+                                    description: Overlay configures the writable copy-on-write
+                                      overlay, which uses the image as its backing
+                                      file. By default the overlay is stored in an
+                                      emptyDir and has the size of the image.
+                                    properties:
+                                      capacity:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: Capacity is the size of the disk
+                                          the guest sees, which has to be at least
+                                          the size of the image. Defaults to the size
+                                          of the image.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      claimName:
+                                        description: ClaimName is the name of a filesystem
+                                          PersistentVolumeClaim in the namespace of
+                                          the VirtualMachineInstance to store the
+                                          overlay on, which keeps the writes of the
+                                          guest across restarts. Defaults to an emptyDir.
+                                        type: string
+                                    type: object
+                                  path:
+                                    description: Path defines the path to disk file
+                                      in the container
+                                    type: string
+                                required:
+                                - image
+                                type: object
+                              dataVolume:
+                                description: DataVolume represents the dynamic creation
+                                  a PVC for this volume as well as the process of
+                                  populating that PVC with a disk image.
+                                properties:
+                                  hotpluggable:
+                                    description: Hotpluggable indicates whether the
+                                      volume can be hotplugged and hotunplugged.
+                                    type: boolean
+                                  name:
+                                    description: Name represents the name of the DataVolume
+                                      in the same namespace
+                                    type: string
+                                required:
+                                - name
+                                type: object
+                              downwardAPI:
+                                description: DownwardAPI represents downward API about
+                                  the pod that should populate this volume
+                                properties:
+                                  fields:
+                                    description: Fields is a list of downward API
+                                      volume file
+                                    items:
+                                      description: DownwardAPIVolumeFile represents
+                                        information to create the file containing
+                                        the pod field
+                                      properties:
+                                        fieldRef:
+                                          description: 'Required: Selects a field
+                                            of the pod: only annotations, labels,
+                                            name and namespace are supported.'
+                                          properties:
+                                            apiVersion:
+                                              description: Version of the schema the
+                                                FieldPath is written in terms of,
+                                                defaults to "v1".
+                                              type: string
+                                            fieldPath:
+                                              description: Path of the field to select
+                                                in the specified API version.
+                                              type: string
+                                          required:
+                                          - fieldPath
+                                          type: object
+                                        mode:
+                                          description: 'Optional: mode bits used to
+                                            set permissions on this file, must be
+                                            an octal value between 0000 and 0777 or
+                                            a decimal value between 0 and 511. YAML
+                                            accepts both octal and decimal values,
+                                            JSON requires decimal values for mode
+                                            bits. If not specified, the volume defaultMode
+                                            will be used. This might be in conflict
+                                            with other options that affect the file
+                                            mode, like fsGroup, and the result can
+                                            be other mode bits set.'
+                                          format: int32
+                                          type: integer
+                                        path:
+                                          description: 'Required: Path is  the relative
+                                            path name of the file to be created. Must
+                                            not be absolute or contain the ''..''
+                                            path. Must be utf-8 encoded. The first
+                                            item of the relative path must not start
+                                            with ''..'''
+                                          type: string
+                                        resourceFieldRef:
+                                          description: 'Selects a resource of the
+                                            container: only resources limits and requests
+                                            (limits.cpu, limits.memory, requests.cpu
+                                            and requests.memory) are currently supported.'
+                                          properties:
+                                            containerName:
+                                              description: 'Container name: required
+                                                for volumes, optional for env vars'
+                                              type: string
+                                            divisor:
+                                              anyOf:
+                                              - type: integer
+                                              - type: string
+                                              description: Specifies the output format
+                                                of the exposed resources, defaults
+                                                to "1"
+                                              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                              x-kubernetes-int-or-string: true
+                                            resource:
+                                              description: 'Required: resource to
+                                                select'
+                                              type: string
+                                          required:
+                                          - resource
+                                          type: object
+                                      required:
+                                      - path
+                                      type: object
+                                    type: array
+                                  volumeLabel:
+                                    description: The volume label of the resulting
+                                      disk inside the VMI. Different bootstrapping
+                                      mechanisms require different values. Typical
+                                      values are "cidata" (cloud-init), "config-2"
+                                      (cloud-init) or "OEMDRV" (kickstart).
+                                    type: string
+                                type: object
+                              downwardMetrics:
+                                description: DownwardMetrics adds a very small disk
+                                  to VMIs which contains a limited view of host and
+                                  guest metrics. The disk content is compatible with
+                                  vhostmd (https://github.com/vhostmd/vhostmd) and
+                                  vm-dump-metrics.
+                                type: object
+                              emptyDisk:
+                                description: 'EmptyDisk represents a temporary disk
+                                  which shares the vmis lifecycle. More info: https://kubevirt.gitbooks.io/user-guide/disks-and-volumes.html'
+                                properties:
+                                  capacity:
+                                    anyOf:
+                                    - type: integer
+                                    - type: string
+                                    description: Capacity of the sparse disk.
+                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                    x-kubernetes-int-or-string: true
+                                required:
+                                - capacity
+                                type: object
+                              ephemeral:
+                                description: Ephemeral is a special volume source
+                                  that "wraps" specified source and provides copy-on-write
+                                  image on top of it.
+                                properties:
+                                  persistentVolumeClaim:
+                                    description: 'PersistentVolumeClaimVolumeSource
+                                      represents a reference to a PersistentVolumeClaim
+                                      in the same namespace. Directly attached to
+                                      the vmi via qemu. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                                    properties:
+                                      claimName:
+                                        description: 'ClaimName is the name of a PersistentVolumeClaim
+                                          in the same namespace as the pod using this
+                                          volume. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                                        type: string
+                                      readOnly:
+                                        description: Will force the ReadOnly setting
+                                          in VolumeMounts. Default false.
+                                        type: boolean
+                                    required:
+                                    - claimName
+                                    type: object
+                                type: object
+                              hostDisk:
+                                description: HostDisk represents a disk created on
+                                  the cluster level
+                                properties:
+                                  capacity:
+                                    anyOf:
+                                    - type: integer
+                                    - type: string
+                                    description: Capacity of the sparse disk
+                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                    x-kubernetes-int-or-string: true
+                                  path:
+                                    description: The path to HostDisk image located
+                                      on the cluster
+                                    type: string
+                                  shared:
+                                    description: Shared indicate whether the path
+                                      is shared between nodes
+                                    type: boolean
+                                  type:
+                                    description: Contains information if disk.img
+                                      exists or should be created allowed options
+                                      are 'Disk' and 'DiskOrCreate'
+                                    type: string
+                                required:
+                                - path
+                                - type
+                                type: object
+                              memoryDump:
+                                description: MemoryDump is a PersistentVolumeClaim
+                                  which is hotplugged to the virt-launcher pod to
+                                  receive a memory dump of the VirtualMachineInstance.
+                                properties:
+                                  claimName:
+                                    description: ClaimName is the name of a PersistentVolumeClaim
+                                      in the same namespace as the VirtualMachineInstance.
+                                    type: string
+                                required:
+                                - claimName
+                                type: object
                               name:
-                                description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
-                                  TODO: Add other useful fields. apiVersion, kind,
-                                  uid?'
+                                description: 'Volume''s name. Must be a DNS_LABEL
+                                  and unique within the vmi. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
                                 type: string
+                              persistentVolumeClaim:
+                                description: 'PersistentVolumeClaimVolumeSource represents
+                                  a reference to a PersistentVolumeClaim in the same
+                                  namespace. Directly attached to the vmi via qemu.
+                                  More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                                properties:
+                                  claimName:
+                                    description: 'ClaimName is the name of a PersistentVolumeClaim
+                                      in the same namespace as the pod using this
+                                      volume. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                                    type: string
+                                  hotpluggable:
+                                    description: Hotpluggable indicates whether the
+                                      volume can be hotplugged and hotunplugged.
+                                    type: boolean
+                                  readOnly:
+                                    description: Will force the ReadOnly setting in
+                                      VolumeMounts. Default false.
+                                    type: boolean
+                                required:
+                                - claimName
+                                type: object
+                              secret:
+                                description: 'SecretVolumeSource represents a reference
+                                  to a secret data in the same namespace. More info:
+                                  https://kubernetes.io/docs/concepts/configuration/secret/'
+                                properties:
+                                  optional:
+                                    description: Specify whether the Secret or it's
+                                      keys must be defined
+                                    type: boolean
+                                  secretName:
+                                    description: 'Name of the secret in the pod''s
+                                      namespace to use. More info: https://kubernetes.io/docs/concepts/storage/volumes#secret'
+                                    type: string
+                                  volumeLabel:
+                                    description: The volume label of the resulting
+                                      disk inside the VMI. Different bootstrapping
+                                      mechanisms require different values. Typical
+                                      values are "cidata" (cloud-init), "config-2"
+                                      (cloud-init) or "OEMDRV" (kickstart).
+                                    type: string
+                                type: object
+                              serviceAccount:
+                                description: 'ServiceAccountVolumeSource represents
+                                  a reference to a service account. There can only
+                                  be one volume of this type! More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
+                                properties:
+                                  serviceAccountName:
+                                    description: 'Name of the service account in the
+                                      pod''s namespace to use. More info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/'
+                                    type: string
+                                type: object
+                              sysprep:
+                                description: Represents a Sysprep volume source.
+                                properties:
+                                  configMap:
+                                    description: ConfigMap references a ConfigMap
+                                      that contains Sysprep answer file named autounattend.xml
+                                      that should be attached as disk of CDROM type.
+                                    properties:
+                                      name:
+                                        description: 'Name of the referent. More info:
+                                          https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                          TODO: Add other useful fields. apiVersion,
+                                          kind, uid?'
+                                        type: string
+                                    type: object
+                                  secret:
+                                    description: Secret references a k8s Secret that
+                                      contains Sysprep answer file named autounattend.xml
+                                      that should be attached as disk of CDROM type.
+                                    properties:
+                                      name:
+                                        description: 'Name of the referent. More info:
+                                          https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                                          TODO: Add other useful fields. apiVersion,
+                                          kind, uid?'
+                                        type: string
+                                    type: object
+                                type: object
+                            required:
+                            - name
                             type: object
-                        type: object
-                    required:
-                    - name
-                    type: object
-                  type: array
+                          type: array
+                      required:
+                      - domain
+                      type: object
+                  type: object
               required:
-              - domain
+              - template
               type: object
           type: object
       required:
       - selector
-      - template
+      - virtualMachineTemplate
       type: object
     status:
-      description: Status is the high level overview of how the VirtualMachineInstance
-        is doing. It contains information available to controllers and users.
+      description: VirtualMachinePoolStatus is the status for a VirtualMachinePool
+        resource
       nullable: true
       properties:
         conditions:
           items:
+            description: VirtualMachinePoolCondition represents the state of a VirtualMachinePool
             properties:
               lastProbeTime:
                 format: date-time
@@ -11833,23 +17699,33 @@ var CRDsValidation map[string]string = map[string]string{
               status:
                 type: string
               type:
+                description: VirtualMachinePoolConditionType is the type of a VirtualMachinePool
+                  condition
                 type: string
             required:
             - status
             - type
             type: object
           type: array
+        currentRevision:
+          description: The revision of the template the VirtualMachines of this pool
+            are updated to.
+          type: string
         labelSelector:
           description: Canonical form of the label selector for HPA which consumes
             it through the scale subresource.
           type: string
         readyReplicas:
-          description: The number of ready replicas for this replica set.
+          description: The number of VirtualMachines of this pool which are ready.
           format: int32
           type: integer
         replicas:
-          description: Total number of non-terminated pods targeted by this deployment
-            (their labels match the selector).
+          description: Total number of VirtualMachines targeted by this pool.
+          format: int32
+          type: integer
+        updatedReplicas:
+          description: The number of VirtualMachines of this pool which run the current
+            revision of the template.
           format: int32
           type: integer
       type: object
@@ -12642,6 +18518,11 @@ var CRDsValidation map[string]string = map[string]string{
                         - spec
                         type: object
                       type: array
+                    nodeRebootPolicy:
+                      description: NodeRebootPolicy controls what happens to a VirtualMachineInstance
+                        which was running on a node that rebooted. Defaults to the
+                        behavior of the RunStrategy.
+                      type: string
                     runStrategy:
                       description: Running state indicates the requested running state
                         of the VirtualMachineInstance mutually exclusive with Running
@@ -12650,6 +18531,26 @@ var CRDsValidation map[string]string = map[string]string{
                       description: Running controls whether the associatied VirtualMachineInstance
                         is created or not Mutually exclusive with RunStrategy
                       type: boolean
+                    schedule:
+                      description: Schedule starts and stops the VirtualMachine at
+                        recurring times
+                      properties:
+                        start:
+                          description: Start is a cron expression in the five field
+                            format "minute hour day-of-month month day-of-week", e.g.
+                            "0 8 * * 1-5", at whose times the VirtualMachine is started
+                          type: string
+                        stop:
+                          description: Stop is a cron expression in the five field
+                            format "minute hour day-of-month month day-of-week", e.g.
+                            "0 20 * * *", at whose times the VirtualMachine is stopped
+                          type: string
+                        timeZone:
+                          description: TimeZone is the name of the IANA time zone
+                            the cron expressions are evaluated in, e.g. "Europe/Berlin".
+                            Defaults to UTC.
+                          type: string
+                      type: object
                     template:
                       description: Template is the direct specification of VirtualMachineInstance
                       properties:
@@ -13750,10 +19651,19 @@ var CRDsValidation map[string]string = map[string]string{
                                         not be available if set to false. Defaults
                                         to true.
                                       type: boolean
+                                    autoattachVSOCK:
+                                      description: Whether to attach a VSOCK device
+                                        to the vmi, through which the host can talk
+                                        to processes in the guest without any network.
+                                        The CID of the device is reported in the status
+                                        of the vmi. Defaults to false.
+                                      type: boolean
                                     blockMultiQueue:
                                       description: Whether or not to enable virtio
-                                        multi-queue for block devices. Defaults to
-                                        false.
+                                        multi-queue for block devices. The number
+                                        of queues equals the number of vCPUs, unless
+                                        a disk sets its own queue count. Defaults
+                                        to false.
                                       type: boolean
                                     clientPassthrough:
                                       description: To configure and access client
@@ -13811,7 +19721,8 @@ var CRDsValidation map[string]string = map[string]string{
                                           cache:
                                             description: 'Cache specifies which kvm
                                               disk cache mode should be used. Supported
-                                              values are: CacheNone, CacheWriteThrough.'
+                                              values are: CacheNone, CacheWriteThrough,
+                                              CacheWriteBack.'
                                             type: string
                                           cdrom:
                                             description: Attach a volume as a cdrom
@@ -13839,6 +19750,14 @@ var CRDsValidation map[string]string = map[string]string{
                                               Thread. Enabling this implies useIOThreads
                                               = true. Defaults to false.
                                             type: boolean
+                                          discard:
+                                            description: 'Discard specifies if discard
+                                              requests of the guest are passed to
+                                              the storage of the disk. Supported values
+                                              are: unmap, ignore. Defaults to unmap,
+                                              or to ignore for preallocated volumes.
+                                              Not supported for cdroms and floppies.'
+                                            type: string
                                           disk:
                                             description: Attach a volume as a disk
                                               to the vmi.
@@ -13846,7 +19765,7 @@ var CRDsValidation map[string]string = map[string]string{
                                               bus:
                                                 description: 'Bus indicates the type
                                                   of disk device to emulate. supported
-                                                  values: virtio, sata, scsi.'
+                                                  values: virtio, sata, scsi, nvme.'
                                                 type: string
                                               pciAddress:
                                                 description: 'If specified, the virtual
@@ -13879,6 +19798,51 @@ var CRDsValidation map[string]string = map[string]string{
                                               disk IO mode should be used. Supported
                                               values are: native, default, threads.'
                                             type: string
+                                          ioTune:
+                                            description: IOTune limits the throughput
+                                              and the IOPS of the disk.
+                                            properties:
+                                              readBytesPerSec:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: ReadBytesPerSec limits
+                                                  the bytes read per second.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                              readIOPS:
+                                                description: ReadIOPS limits the read
+                                                  operations per second.
+                                                format: int64
+                                                type: integer
+                                              totalBytesPerSec:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: TotalBytesPerSec limits
+                                                  the bytes read and written per second,
+                                                  e.g. 100Mi.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                              totalIOPS:
+                                                description: TotalIOPS limits the
+                                                  read and write operations per second.
+                                                format: int64
+                                                type: integer
+                                              writeBytesPerSec:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: WriteBytesPerSec limits
+                                                  the bytes written per second.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                              writeIOPS:
+                                                description: WriteIOPS limits the
+                                                  write operations per second.
+                                                format: int64
+                                                type: integer
+                                            type: object
                                           lun:
                                             description: Attach a volume as a LUN
                                               to the vmi.
@@ -13892,10 +19856,26 @@ var CRDsValidation map[string]string = map[string]string{
                                                 description: ReadOnly. Defaults to
                                                   false.
                                                 type: boolean
+                                              reservation:
+                                                description: Reservation lets the
+                                                  guest issue SCSI-3 persistent reservation
+                                                  commands to the LUN, e.g. for the
+                                                  shared disks of failover clusters.
+                                                  Requires the scsi bus. Defaults
+                                                  to false.
+                                                type: boolean
                                             type: object
                                           name:
                                             description: Name is the device name
                                             type: string
+                                          queues:
+                                            description: Queues sets the number of
+                                              virtio-blk queues of this disk, overriding
+                                              blockMultiQueue. Only allowed for disks
+                                              on the virtio bus. Must not exceed the
+                                              number of vCPUs.
+                                            format: int32
+                                            type: integer
                                           serial:
                                             description: Serial provides the ability
                                               to specify a serial number for the disk
@@ -13906,6 +19886,12 @@ var CRDsValidation map[string]string = map[string]string{
                                               and its tag will be provided to the
                                               guest via config drive metadata
                                             type: string
+                                          wwn:
+                                            description: WWN is the World Wide Name
+                                              of the disk device, made up of 16 hexadecimal
+                                              digits. Only allowed for disks and cdroms
+                                              on the sata or scsi bus.
+                                            type: string
                                         required:
                                         - name
                                         type: object
@@ -13927,6 +19913,13 @@ var CRDsValidation map[string]string = map[string]string{
                                         type: object
                                       type: array
                                       x-kubernetes-list-type: atomic
+                                    freePageReporting:
+                                      description: Whether the guest reports the memory
+                                        pages it freed to the host through the Memory
+                                        balloon device, so that they can be reclaimed.
+                                        Defaults to true if the memory of the vmi
+                                        is overcommitted.
+                                      type: boolean
                                     gpus:
                                       description: Whether to attach a GPU device
                                         to the vmi.
@@ -13938,6 +19931,38 @@ var CRDsValidation map[string]string = map[string]string{
                                             description: Name of the GPU device as
                                               exposed by a device plugin
                                             type: string
+                                          virtualGPUOptions:
+                                            description: VirtualGPUOptions configures
+                                              vGPU (mediated device) specific options.
+                                              It is ignored for GPUs which are passed
+                                              through as PCI devices.
+                                            properties:
+                                              display:
+                                                description: Display configures the
+                                                  display adapter backed by the vGPU.
+                                                properties:
+                                                  enabled:
+                                                    description: Enabled determines
+                                                      if a display adapter backed
+                                                      by the vGPU should be enabled
+                                                      or disabled on the guest. Defaults
+                                                      to true.
+                                                    type: boolean
+                                                  ramFB:
+                                                    description: RamFB enables a boot
+                                                      framebuffer which is used until
+                                                      the guest OS loads a real GPU
+                                                      driver. Defaults to true.
+                                                    properties:
+                                                      enabled:
+                                                        description: Enabled determines
+                                                          if the feature should be
+                                                          enabled or disabled on the
+                                                          guest. Defaults to true.
+                                                        type: boolean
+                                                    type: object
+                                                type: object
+                                            type: object
                                         required:
                                         - deviceName
                                         - name
@@ -13988,6 +20013,66 @@ var CRDsValidation map[string]string = map[string]string{
                                         which are added to the vmi.
                                       items:
                                         properties:
+                                          bandwidth:
+                                            description: Bandwidth limits the traffic
+                                              of the interface. Only allowed for bridge
+                                              and masquerade interfaces.
+                                            properties:
+                                              egress:
+                                                description: Egress limits the traffic
+                                                  sent by the guest.
+                                                properties:
+                                                  burst:
+                                                    anyOf:
+                                                    - type: integer
+                                                    - type: string
+                                                    description: Burst is the amount
+                                                      of bytes which can exceed the
+                                                      rate at once, e.g. 1Mi. Defaults
+                                                      to the traffic of 100ms at the
+                                                      rate, but at least 64Ki.
+                                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                    x-kubernetes-int-or-string: true
+                                                  rate:
+                                                    anyOf:
+                                                    - type: integer
+                                                    - type: string
+                                                    description: Rate is the average
+                                                      rate in bits per second, e.g.
+                                                      100M.
+                                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                    x-kubernetes-int-or-string: true
+                                                required:
+                                                - rate
+                                                type: object
+                                              ingress:
+                                                description: Ingress limits the traffic
+                                                  received by the guest.
+                                                properties:
+                                                  burst:
+                                                    anyOf:
+                                                    - type: integer
+                                                    - type: string
+                                                    description: Burst is the amount
+                                                      of bytes which can exceed the
+                                                      rate at once, e.g. 1Mi. Defaults
+                                                      to the traffic of 100ms at the
+                                                      rate, but at least 64Ki.
+                                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                    x-kubernetes-int-or-string: true
+                                                  rate:
+                                                    anyOf:
+                                                    - type: integer
+                                                    - type: string
+                                                    description: Rate is the average
+                                                      rate in bits per second, e.g.
+                                                      100M.
+                                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                    x-kubernetes-int-or-string: true
+                                                required:
+                                                - rate
+                                                type: object
+                                            type: object
                                           bootOrder:
                                             description: BootOrder is an integer value
                                               > 0, used to determine ordering of boot
@@ -14099,6 +20184,14 @@ var CRDsValidation map[string]string = map[string]string{
                                               - port
                                               type: object
                                             type: array
+                                          queues:
+                                            description: Queues sets the number of
+                                              vhost queues of this interface, overriding
+                                              networkInterfaceMultiqueue. Only allowed
+                                              for virtio interfaces. Must not exceed
+                                              the number of vCPUs.
+                                            format: int32
+                                            type: integer
                                           slirp:
                                             type: object
                                           sriov:
@@ -14109,22 +20202,58 @@ var CRDsValidation map[string]string = map[string]string{
                                               will be provided to the guest via config
                                               drive
                                             type: string
+                                          vhostuser:
+                                            description: InterfaceVhostUser connects
+                                              the interface to a userspace dataplane
+                                              (e.g. OVS-DPDK or VPP) over a vhost-user
+                                              socket. QEMU creates the socket in server
+                                              mode, named after the interface, in
+                                              the vhostuser directory shared by the
+                                              virt-launcher pod, and the dataplane
+                                              connects to it. Requires a Multus network
+                                              and hugepages backed guest memory.
+                                            type: object
                                         required:
                                         - name
                                         type: object
                                       type: array
+                                    logSerialConsole:
+                                      description: Whether to log the output of the
+                                        auto-attached serial console to the guest-console-log
+                                        container of the virt-launcher pod, where
+                                        it can be read with kubectl logs. Not relevant
+                                        if autoattachSerialConsole is false. Defaults
+                                        to false.
+                                      type: boolean
                                     networkInterfaceMultiqueue:
                                       description: If specified, virtual network interfaces
                                         configured with a virtio bus will also enable
                                         the vhost multiqueue feature for network devices.
                                         The number of queues created depends on additional
                                         factors of the VirtualMachineInstance, like
-                                        the number of guest CPUs.
+                                        the number of guest CPUs. Interfaces which
+                                        set their own queue count are not affected.
                                       type: boolean
                                     rng:
                                       description: Whether to have random number generator
                                         from host
                                       type: object
+                                    sound:
+                                      description: Whether to emulate a sound device.
+                                      properties:
+                                        model:
+                                          description: 'We only support ich9 or ac97.
+                                            If SoundDevice is not set: No sound card
+                                            is emulated. If SoundDevice is set but
+                                            Model is not: ich9'
+                                          type: string
+                                        name:
+                                          description: User's defined name for this
+                                            sound device
+                                          type: string
+                                      required:
+                                      - name
+                                      type: object
                                     useVirtioTransitional:
                                       description: Fall back to legacy virtio 0.9
                                         support if virtio bus is selected on devices.
@@ -14359,6 +20488,19 @@ var CRDsValidation map[string]string = map[string]string{
                                               type: boolean
                                           type: object
                                       type: object
+                                    hypervPassthrough:
+                                      description: HypervPassthrough enables all the
+                                        Hyper-V enlightenments which are supported
+                                        by the host. It can't be combined with hyperv.
+                                        VMIs with Hyper-V passthrough are not live
+                                        migratable.
+                                      properties:
+                                        enabled:
+                                          description: Enabled determines if the Hyper-V
+                                            passthrough mode should be used. Defaults
+                                            to true.
+                                          type: boolean
+                                      type: object
                                     kvm:
                                       description: Configure how KVM presence is exposed
                                         to the guest.
@@ -14395,6 +20537,21 @@ var CRDsValidation map[string]string = map[string]string{
                                 firmware:
                                   description: Firmware.
                                   properties:
+                                    bootMenu:
+                                      description: BootMenu shows a menu while booting,
+                                        in which the device to boot from can be picked
+                                        in the console, instead of booting the devices
+                                        in their boot order.
+                                      properties:
+                                        timeout:
+                                          description: Timeout is how long the boot
+                                            menu waits for a pick, in milliseconds,
+                                            before it boots the first device of the
+                                            boot order. At most 65535. Defaults to
+                                            the timeout of the firmware.
+                                          format: int32
+                                          type: integer
+                                      type: object
                                     bootloader:
                                       description: Settings to control the bootloader
                                         that is used.
@@ -14511,6 +20668,26 @@ var CRDsValidation map[string]string = map[string]string{
                                           type: string
                                       type: object
                                   type: object
+                                qemuArgs:
+                                  description: QEMUArgs are appended to the QEMU command
+                                    line of the vmi. Requires the QEMUArgs feature
+                                    gate, only arguments which are allowed in the
+                                    KubeVirt configuration are accepted.
+                                  items:
+                                    description: QEMUArg is an argument which is appended
+                                      to the QEMU command line.
+                                    properties:
+                                      name:
+                                        description: Name of the argument, including
+                                          the leading dash, e.g. "-fw_cfg".
+                                        type: string
+                                      value:
+                                        description: Value of the argument, e.g. "name=opt/com.example/config,string=value".
+                                        type: string
+                                    required:
+                                    - name
+                                    type: object
+                                  type: array
                                 resources:
                                   description: Resources describes the Compute Resources
                                     required by this vmi.
@@ -14552,7 +20729,9 @@ var CRDsValidation map[string]string = map[string]string{
                             evictionStrategy:
                               description: EvictionStrategy can be set to "LiveMigrate"
                                 if the VirtualMachineInstance should be migrated instead
-                                of shut-off in case of a node drain.
+                                of shut-off in case of a node drain, or to "LiveMigrateIfPossible"
+                                if it should be migrated when it is live-migratable
+                                and evicted otherwise.
                               type: string
                             hostname:
                               description: Specifies the hostname of the vmi If not
@@ -14708,6 +20887,23 @@ var CRDsValidation map[string]string = map[string]string{
                                           add it to the multus-cni.io/default-network
                                           annotation.
                                         type: boolean
+                                      ipAddresses:
+                                        description: IPAddresses requests static IP
+                                          addresses in CIDR notation, e.g. 192.168.1.10/24,
+                                          for the interface. They are passed to the
+                                          "ips" capability of the CNI plugin. Not
+                                          supported on the default network.
+                                        items:
+                                          type: string
+                                        type: array
+                                        x-kubernetes-list-type: atomic
+                                      ipPool:
+                                        description: IPPool references a pool of the
+                                          IPAM plugin of the network from which the
+                                          addresses of the interface are assigned.
+                                          It is passed as the "ipPool" CNI arg. Not
+                                          supported on the default network.
+                                        type: string
                                       networkName:
                                         description: 'References to a NetworkAttachmentDefinition
                                           CRD object. Format: <networkName>, <namespace>/<networkName>.
@@ -14749,7 +20945,8 @@ var CRDsValidation map[string]string = map[string]string{
                             priorityClassName:
                               description: If specified, indicates the pod's priority.
                                 If not specified, the pod priority will be default
-                                or zero if there is no default.
+                                or zero if there is no default. VMIs with a higher
+                                priority are evacuated first when a node is drained.
                               type: string
                             readinessProbe:
                               description: 'Periodic probe of VirtualMachineInstance
@@ -14894,6 +21091,141 @@ var CRDsValidation map[string]string = map[string]string{
                               description: StartStrategy can be set to "Paused" if
                                 Virtual Machine should be started in paused state.
                               type: string
+                            startupProbe:
+                              description: 'Probe indicating that the VirtualMachineInstance
+                                has successfully started. Liveness and readiness probes
+                                are not executed until it succeeds. VirtualmachineInstances
+                                will be stopped if the probe fails. Cannot be updated.
+                                More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                              properties:
+                                exec:
+                                  description: One and only one of the following should
+                                    be specified. Exec specifies the action to take,
+                                    it will be executed on the guest through the qemu-guest-agent.
+                                    If the guest agent is not available, this probe
+                                    will fail.
+                                  properties:
+                                    command:
+                                      description: Command is the command line to
+                                        execute inside the container, the working
+                                        directory for the command  is root ('/') in
+                                        the container's filesystem. The command is
+                                        simply exec'd, it is not run inside a shell,
+                                        so traditional shell instructions ('|', etc)
+                                        won't work. To use a shell, you need to explicitly
+                                        call out to that shell. Exit status of 0 is
+                                        treated as live/healthy and non-zero is unhealthy.
+                                      items:
+                                        type: string
+                                      type: array
+                                  type: object
+                                failureThreshold:
+                                  description: Minimum consecutive failures for the
+                                    probe to be considered failed after having succeeded.
+                                    Defaults to 3. Minimum value is 1.
+                                  format: int32
+                                  type: integer
+                                guestAgentPing:
+                                  description: GuestAgentPing contacts the qemu-guest-agent
+                                    for availability checks.
+                                  type: object
+                                httpGet:
+                                  description: HTTPGet specifies the http request
+                                    to perform.
+                                  properties:
+                                    host:
+                                      description: Host name to connect to, defaults
+                                        to the pod IP. You probably want to set "Host"
+                                        in httpHeaders instead.
+                                      type: string
+                                    httpHeaders:
+                                      description: Custom headers to set in the request.
+                                        HTTP allows repeated headers.
+                                      items:
+                                        description: HTTPHeader describes a custom
+                                          header to be used in HTTP probes
+                                        properties:
+                                          name:
+                                            description: The header field name
+                                            type: string
+                                          value:
+                                            description: The header field value
+                                            type: string
+                                        required:
+                                        - name
+                                        - value
+                                        type: object
+                                      type: array
+                                    path:
+                                      description: Path to access on the HTTP server.
+                                      type: string
+                                    port:
+                                      anyOf:
+                                      - type: integer
+                                      - type: string
+                                      description: Name or number of the port to access
+                                        on the container. Number must be in the range
+                                        1 to 65535. Name must be an IANA_SVC_NAME.
+                                      x-kubernetes-int-or-string: true
+                                    scheme:
+                                      description: Scheme to use for connecting to
+                                        the host. Defaults to HTTP.
+                                      type: string
+                                  required:
+                                  - port
+                                  type: object
+                                initialDelaySeconds:
+                                  description: 'Number of seconds after the VirtualMachineInstance
+                                    has started before liveness probes are initiated.
+                                    More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                                  format: int32
+                                  type: integer
+                                periodSeconds:
+                                  description: How often (in seconds) to perform the
+                                    probe. Default to 10 seconds. Minimum value is
+                                    1.
+                                  format: int32
+                                  type: integer
+                                successThreshold:
+                                  description: Minimum consecutive successes for the
+                                    probe to be considered successful after having
+                                    failed. Defaults to 1. Must be 1 for liveness.
+                                    Minimum value is 1.
+                                  format: int32
+                                  type: integer
+                                tcpSocket:
+                                  description: 'TCPSocket specifies an action involving
+                                    a TCP port. TCP hooks not yet supported TODO:
+                                    implement a realistic TCP lifecycle hook'
+                                  properties:
+                                    host:
+                                      description: 'Optional: Host name to connect
+                                        to, defaults to the pod IP.'
+                                      type: string
+                                    port:
+                                      anyOf:
+                                      - type: integer
+                                      - type: string
+                                      description: Number or name of the port to access
+                                        on the container. Number must be in the range
+                                        1 to 65535. Name must be an IANA_SVC_NAME.
+                                      x-kubernetes-int-or-string: true
+                                  required:
+                                  - port
+                                  type: object
+                                timeoutSeconds:
+                                  description: 'Number of seconds after which the
+                                    probe times out. For exec probes the timeout fails
+                                    the probe but does not terminate the command running
+                                    on the guest. This means a blocking command can
+                                    result in an increasing load on the guest. A small
+                                    buffer will be added to the resulting workload
+                                    exec probe to compensate for delays caused by
+                                    the qemu guest exec mechanism. Defaults to 1 second.
+                                    Minimum value is 1. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
+                                  format: int32
+                                  type: integer
+                              type: object
                             subdomain:
                               description: If specified, the fully qualified vmi hostname
                                 will be "<hostname>.<subdomain>.<pod namespace>.svc.<cluster
@@ -15102,6 +21434,32 @@ var CRDsValidation map[string]string = map[string]string{
                                           the Docker registry secret required to pull
                                           the image. The secret must already exist.
                                         type: string
+                                      overlay:
+                                        description: Overlay configures the writable
+                                          copy-on-write overlay, which uses the image
+                                          as its backing file. By default the overlay
+                                          is stored in an emptyDir and has the size
+                                          of the image.
+                                        properties:
+                                          capacity:
+                                            anyOf:
+                                            - type: integer
+                                            - type: string
+                                            description: Capacity is the size of the
+                                              disk the guest sees, which has to be
+                                              at least the size of the image. Defaults
+                                              to the size of the image.
+                                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                            x-kubernetes-int-or-string: true
+                                          claimName:
+                                            description: ClaimName is the name of
+                                              a filesystem PersistentVolumeClaim in
+                                              the namespace of the VirtualMachineInstance
+                                              to store the overlay on, which keeps
+                                              the writes of the guest across restarts.
+                                              Defaults to an emptyDir.
+                                            type: string
+                                        type: object
                                       path:
                                         description: Path defines the path to disk
                                           file in the container
@@ -15292,6 +21650,18 @@ var CRDsValidation map[string]string = map[string]string{
                                     - path
                                     - type
                                     type: object
+                                  memoryDump:
+                                    description: MemoryDump is a PersistentVolumeClaim
+                                      which is hotplugged to the virt-launcher pod
+                                      to receive a memory dump of the VirtualMachineInstance.
+                                    properties:
+                                      claimName:
+                                        description: ClaimName is the name of a PersistentVolumeClaim
+                                          in the same namespace as the VirtualMachineInstance.
+                                        type: string
+                                    required:
+                                    - claimName
+                                    type: object
                                   name:
                                     description: 'Volume''s name. Must be a DNS_LABEL
                                       and unique within the vmi. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
@@ -15396,6 +21766,33 @@ var CRDsValidation map[string]string = map[string]string{
                   description: Status holds the current state of the controller and
                     brief information about its associated VirtualMachineInstance
                   properties:
+                    availability:
+                      description: Availability records the starts and the unplanned
+                        downtime of the VM
+                      nullable: true
+                      properties:
+                        failureTime:
+                          description: FailureTime is the time the VMI failed, it
+                            is only set while the resulting downtime is ongoing
+                          format: date-time
+                          type: string
+                        readyTime:
+                          description: ReadyTime is the time the guest became ready
+                            after the latest start request
+                          format: date-time
+                          type: string
+                        startRequestTime:
+                          description: StartRequestTime is the time the latest start
+                            of the VM was requested
+                          format: date-time
+                          type: string
+                        unplannedDowntimeSeconds:
+                          description: UnplannedDowntimeSeconds is the cumulative
+                            downtime of the VM after its VMIs failed, without the
+                            ongoing downtime
+                          format: int64
+                          type: integer
+                      type: object
                     conditions:
                       description: Hold the state information of the VirtualMachine
                         and its VirtualMachineInstance
@@ -15428,6 +21825,43 @@ var CRDsValidation map[string]string = map[string]string{
                       description: Created indicates if the virtual machine is created
                         in the cluster
                       type: boolean
+                    memoryDumpRequest:
+                      description: MemoryDumpRequest tracks the memory dump request
+                        of the VM and the PersistentVolumeClaim it is associated with
+                      nullable: true
+                      properties:
+                        claimName:
+                          description: ClaimName is the name of the PersistentVolumeClaim
+                            the memory dump is written to
+                          type: string
+                        endTimestamp:
+                          description: EndTimestamp is the time when the memory dump
+                            completed
+                          format: date-time
+                          type: string
+                        fileName:
+                          description: FileName is the name of the memory dump file
+                            on the PersistentVolumeClaim
+                          type: string
+                        message:
+                          description: Message is a detailed message about a failed
+                            memory dump
+                          type: string
+                        phase:
+                          description: Phase is the phase of the memory dump
+                          type: string
+                        remove:
+                          description: Remove requests to dissociate the PersistentVolumeClaim
+                            from the VM
+                          type: boolean
+                        startTimestamp:
+                          description: StartTimestamp is the time when the memory
+                            dump started
+                          format: date-time
+                          type: string
+                      required:
+                      - claimName
+                      type: object
                     printableStatus:
                       description: PrintableStatus is a human readable, high-level
                         representation of the status of the virtual machine
@@ -15436,10 +21870,35 @@ var CRDsValidation map[string]string = map[string]string{
                       description: Ready indicates if the virtual machine is running
                         and ready
                       type: boolean
+                    restartCount:
+                      description: RestartCount is the number of failed VMIs which
+                        were started again after a crash loop backoff
+                      type: integer
                     restoreInProgress:
                       description: RestoreInProgress is the name of the VirtualMachineRestore
                         currently executing
                       type: string
+                    schedule:
+                      description: Schedule reports the scheduled starts and stops
+                        of the VM
+                      nullable: true
+                      properties:
+                        lastScheduleTime:
+                          description: LastScheduleTime is the time of the last scheduled
+                            start or stop which was applied
+                          format: date-time
+                          type: string
+                        nextStartTime:
+                          description: NextStartTime is the time of the next scheduled
+                            start
+                          format: date-time
+                          type: string
+                        nextStopTime:
+                          description: NextStopTime is the time of the next scheduled
+                            stop
+                          format: date-time
+                          type: string
+                      type: object
                     snapshotInProgress:
                       description: SnapshotInProgress is the name of the VirtualMachineSnapshot
                         currently executing
@@ -15540,7 +21999,7 @@ var CRDsValidation map[string]string = map[string]string{
                                   cache:
                                     description: 'Cache specifies which kvm disk cache
                                       mode should be used. Supported values are: CacheNone,
-                                      CacheWriteThrough.'
+                                      CacheWriteThrough, CacheWriteBack.'
                                     type: string
                                   cdrom:
                                     description: Attach a volume as a cdrom to the
@@ -15566,6 +22025,13 @@ var CRDsValidation map[string]string = map[string]string{
                                       this implies useIOThreads = true. Defaults to
                                       false.
                                     type: boolean
+                                  discard:
+                                    description: 'Discard specifies if discard requests
+                                      of the guest are passed to the storage of the
+                                      disk. Supported values are: unmap, ignore. Defaults
+                                      to unmap, or to ignore for preallocated volumes.
+                                      Not supported for cdroms and floppies.'
+                                    type: string
                                   disk:
                                     description: Attach a volume as a disk to the
                                       vmi.
@@ -15573,7 +22039,7 @@ var CRDsValidation map[string]string = map[string]string{
                                       bus:
                                         description: 'Bus indicates the type of disk
                                           device to emulate. supported values: virtio,
-                                          sata, scsi.'
+                                          sata, scsi, nvme.'
                                         type: string
                                       pciAddress:
                                         description: 'If specified, the virtual disk
@@ -15603,6 +22069,50 @@ var CRDsValidation map[string]string = map[string]string{
                                       mode should be used. Supported values are: native,
                                       default, threads.'
                                     type: string
+                                  ioTune:
+                                    description: IOTune limits the throughput and
+                                      the IOPS of the disk.
+                                    properties:
+                                      readBytesPerSec:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: ReadBytesPerSec limits the bytes
+                                          read per second.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      readIOPS:
+                                        description: ReadIOPS limits the read operations
+                                          per second.
+                                        format: int64
+                                        type: integer
+                                      totalBytesPerSec:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: TotalBytesPerSec limits the bytes
+                                          read and written per second, e.g. 100Mi.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      totalIOPS:
+                                        description: TotalIOPS limits the read and
+                                          write operations per second.
+                                        format: int64
+                                        type: integer
+                                      writeBytesPerSec:
+                                        anyOf:
+                                        - type: integer
+                                        - type: string
+                                        description: WriteBytesPerSec limits the bytes
+                                          written per second.
+                                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                        x-kubernetes-int-or-string: true
+                                      writeIOPS:
+                                        description: WriteIOPS limits the write operations
+                                          per second.
+                                        format: int64
+                                        type: integer
+                                    type: object
                                   lun:
                                     description: Attach a volume as a LUN to the vmi.
                                     properties:
@@ -15614,10 +22124,24 @@ var CRDsValidation map[string]string = map[string]string{
                                       readonly:
                                         description: ReadOnly. Defaults to false.
                                         type: boolean
+                                      reservation:
+                                        description: Reservation lets the guest issue
+                                          SCSI-3 persistent reservation commands to
+                                          the LUN, e.g. for the shared disks of failover
+                                          clusters. Requires the scsi bus. Defaults
+                                          to false.
+                                        type: boolean
                                     type: object
                                   name:
                                     description: Name is the device name
                                     type: string
+                                  queues:
+                                    description: Queues sets the number of virtio-blk
+                                      queues of this disk, overriding blockMultiQueue.
+                                      Only allowed for disks on the virtio bus. Must
+                                      not exceed the number of vCPUs.
+                                    format: int32
+                                    type: integer
                                   serial:
                                     description: Serial provides the ability to specify
                                       a serial number for the disk device.
@@ -15627,6 +22151,12 @@ var CRDsValidation map[string]string = map[string]string{
                                       tag will be provided to the guest via config
                                       drive metadata
                                     type: string
+                                  wwn:
+                                    description: WWN is the World Wide Name of the
+                                      disk device, made up of 16 hexadecimal digits.
+                                      Only allowed for disks and cdroms on the sata
+                                      or scsi bus.
+                                    type: string
                                 required:
                                 - name
                                 type: object
@@ -27,6 +27,8 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
+	goruntime "runtime"
+	"sort"
 	"strings"
 
 	promv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
@@ -374,7 +376,7 @@ func GenerateCurrentInstallStrategy(config *operatorutil.KubeVirtDeploymentConfi
 		components.NewVirtualMachineInstanceCrd, components.NewPresetCrd, components.NewReplicaSetCrd,
 		components.NewVirtualMachineCrd, components.NewVirtualMachineInstanceMigrationCrd,
 		components.NewVirtualMachineSnapshotCrd, components.NewVirtualMachineSnapshotContentCrd,
-		components.NewVirtualMachineRestoreCrd,
+		components.NewVirtualMachineRestoreCrd, components.NewVirtualMachinePoolCrd,
 	}
 	for _, f := range functions {
 		crd, err := f()
@@ -389,6 +391,9 @@ func GenerateCurrentInstallStrategy(config *operatorutil.KubeVirtDeploymentConfi
 	rbaclist = append(rbaclist, rbac.GetAllApiServer(config.GetNamespace())...)
 	rbaclist = append(rbaclist, rbac.GetAllController(config.GetNamespace())...)
 	rbaclist = append(rbaclist, rbac.GetAllHandler(config.GetNamespace())...)
+	if config.CommonTemplatesEnabled() {
+		rbaclist = append(rbaclist, rbac.GetAllCommonTemplates(config.GetNamespace(), components.CommonTemplateConfigMapNames())...)
+	}
 
 	if monitorNamespace != "" {
 
@@ -446,16 +451,25 @@ func GenerateCurrentInstallStrategy(config *operatorutil.KubeVirtDeploymentConfi
 	strategy.validatingWebhookConfigurations = append(strategy.validatingWebhookConfigurations, components.NewVirtAPIValidatingWebhookConfiguration(config.GetNamespace()))
 	strategy.mutatingWebhookConfigurations = append(strategy.mutatingWebhookConfigurations, components.NewVirtAPIMutatingWebhookConfiguration(config.GetNamespace()))
 
+	componentOptions := []components.Option{
+		components.WithImage(config.GetImageRegistry(), config.GetImagePrefix()),
+		components.WithLauncherVersion(config.GetLauncherVersion()),
+		components.WithProduct(productName, productVersion),
+		components.WithImagePullPolicy(config.GetImagePullPolicy()),
+		components.WithVerbosity(config.GetVerbosity()),
+		components.WithExtraEnv(config.GetExtraEnv()),
+	}
+
 	strategy.services = append(strategy.services, components.NewPrometheusService(config.GetNamespace()))
-	strategy.services = append(strategy.services, components.NewApiServerService(config.GetNamespace()))
+	strategy.services = append(strategy.services, components.BuildApiServerService(config.GetNamespace(), componentOptions...))
 	strategy.services = append(strategy.services, components.NewOperatorWebhookService(operatorNamespace))
-	apiDeployment, err := components.NewApiServerDeployment(config.GetNamespace(), config.GetImageRegistry(), config.GetImagePrefix(), config.GetApiVersion(), productName, productVersion, config.GetImagePullPolicy(), config.GetVerbosity(), config.GetExtraEnv())
+	apiDeployment, err := components.BuildApiServerDeployment(config.GetNamespace(), append(componentOptions, components.WithVersion(config.GetApiVersion()))...)
 	if err != nil {
 		return nil, fmt.Errorf("error generating virt-apiserver deployment %v", err)
 	}
 	strategy.deployments = append(strategy.deployments, apiDeployment)
 
-	controller, err := components.NewControllerDeployment(config.GetNamespace(), config.GetImageRegistry(), config.GetImagePrefix(), config.GetControllerVersion(), config.GetLauncherVersion(), productName, productVersion, config.GetImagePullPolicy(), config.GetVerbosity(), config.GetExtraEnv())
+	controller, err := components.BuildControllerDeployment(config.GetNamespace(), append(componentOptions, components.WithVersion(config.GetControllerVersion()))...)
 	if err != nil {
 		return nil, fmt.Errorf("error generating virt-controller deployment %v", err)
 	}
@@ -463,21 +477,65 @@ func GenerateCurrentInstallStrategy(config *operatorutil.KubeVirtDeploymentConfi
 
 	strategy.configMaps = append(strategy.configMaps, components.NewKubeVirtCAConfigMap(operatorNamespace))
 
-	handler, err := components.NewHandlerDaemonSet(config.GetNamespace(), config.GetImageRegistry(), config.GetImagePrefix(), config.GetHandlerVersion(), config.GetLauncherVersion(), productName, productVersion, config.GetImagePullPolicy(), config.GetVerbosity(), config.GetExtraEnv())
+	handlers, err := buildHandlerDaemonSets(config, append(componentOptions, components.WithVersion(config.GetHandlerVersion())))
 	if err != nil {
 		return nil, fmt.Errorf("error generating virt-handler deployment %v", err)
 	}
 
-	strategy.daemonSets = append(strategy.daemonSets, handler)
+	strategy.daemonSets = append(strategy.daemonSets, handlers...)
 	strategy.sccs = append(strategy.sccs, components.GetAllSCC(config.GetNamespace())...)
 	strategy.apiServices = components.NewVirtAPIAPIServices(config.GetNamespace())
 	strategy.certificateSecrets = components.NewCertSecrets(config.GetNamespace(), operatorNamespace)
 	strategy.certificateSecrets = append(strategy.certificateSecrets, components.NewCACertSecret(operatorNamespace))
 	strategy.configMaps = append(strategy.configMaps, components.NewKubeVirtCAConfigMap(operatorNamespace))
 
+	if config.CommonTemplatesEnabled() {
+		templates, err := components.NewCommonTemplateConfigMaps(config.GetNamespace())
+		if err != nil {
+			return nil, fmt.Errorf("error generating the common templates %v", err)
+		}
+		strategy.configMaps = append(strategy.configMaps, templates...)
+	}
+
 	return strategy, nil
 }
 
+// buildHandlerDaemonSets returns a single virt-handler daemonset, unless image registries per
+// architecture are configured. Then each architecture gets its own daemonset, which only runs on
+// the nodes of that architecture and pulls the images from the registry of that architecture.
+func buildHandlerDaemonSets(config *operatorutil.KubeVirtDeploymentConfig, options []components.Option) ([]*appsv1.DaemonSet, error) {
+	archImageRegistries := config.GetArchImageRegistries()
+	if len(archImageRegistries) == 0 {
+		handler, err := components.BuildHandlerDaemonSet(config.GetNamespace(), options...)
+		if err != nil {
+			return nil, err
+		}
+		return []*appsv1.DaemonSet{handler}, nil
+	}
+
+	archs := []string{goruntime.GOARCH}
+	for arch := range archImageRegistries {
+		if arch != goruntime.GOARCH {
+			archs = append(archs, arch)
+		}
+	}
+	sort.Strings(archs[1:])
+
+	var handlers []*appsv1.DaemonSet
+	for _, arch := range archs {
+		archOptions := append([]components.Option{}, options...)
+		if registry, exists := archImageRegistries[arch]; exists {
+			archOptions = append(archOptions, components.WithArchitectureImage(arch, registry, config.GetImagePrefix()))
+		}
+		handler, err := components.BuildHandlerDaemonSet(config.GetNamespace(), append(archOptions, components.WithArchitecture(arch))...)
+		if err != nil {
+			return nil, err
+		}
+		handlers = append(handlers, handler)
+	}
+	return handlers, nil
+}
+
 func mostRecentConfigMap(configMaps []*corev1.ConfigMap) *corev1.ConfigMap {
 	var configMap *corev1.ConfigMap
 	// choose the most recent configmap if multiple match.
@@ -20,7 +20,9 @@
 package install
 
 import (
+	"os"
 	"reflect"
+	goruntime "runtime"
 	"strings"
 
 	"github.com/onsi/ginkgo/extensions/table"
@@ -40,7 +42,9 @@ import (
 
 	v1 "kubevirt.io/client-go/api/v1"
 
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	//"kubevirt.io/kubevirt/pkg/virt-operator/resource/apply"
+	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/components"
 	"kubevirt.io/kubevirt/pkg/virt-operator/util"
 )
 
@@ -115,6 +119,74 @@ var _ = Describe("Install Strategy", func() {
 			}
 
 		})
+		It("a virt-handler daemonset per architecture with an image registry", func() {
+			Expect(os.Setenv(util.ArchImageRegistryEnvPrefix+"S390X", "registry.example.org/kv-s390x")).To(Succeed())
+			archConfig := getConfig("fake-registry", "v9.9.9")
+			Expect(os.Unsetenv(util.ArchImageRegistryEnvPrefix + "S390X")).To(Succeed())
+			Expect(archConfig.GetDeploymentID()).ToNot(Equal(config.GetDeploymentID()))
+
+			strategy, err := GenerateCurrentInstallStrategy(archConfig, "openshift-monitoring", namespace)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(strategy.DaemonSets()).To(HaveLen(2))
+
+			handler := strategy.DaemonSets()[0]
+			Expect(handler.Name).To(Equal(components.VirtHandlerName))
+			Expect(handler.Spec.Template.Spec.NodeSelector).To(HaveKeyWithValue(corev1.LabelArchStable, goruntime.GOARCH))
+			Expect(handler.Spec.Template.Spec.Containers[0].Image).To(HavePrefix("fake-registry/"))
+
+			handler = strategy.DaemonSets()[1]
+			Expect(handler.Name).To(Equal("virt-handler-s390x"))
+			Expect(handler.Spec.Template.Spec.NodeSelector).To(HaveKeyWithValue(corev1.LabelArchStable, "s390x"))
+			Expect(handler.Spec.Template.Spec.Containers[0].Image).To(HavePrefix("registry.example.org/kv-s390x/"))
+		})
+
+		It("common templates only if the feature gate is enabled", func() {
+			strategy, err := GenerateCurrentInstallStrategy(config, "openshift-monitoring", namespace)
+			Expect(err).ToNot(HaveOccurred())
+			for _, configMap := range strategy.configMaps {
+				Expect(configMap.Name).ToNot(HavePrefix(components.CommonTemplateConfigMapPrefix))
+			}
+
+			templatesConfig := util.GetTargetConfigFromKV(&v1.KubeVirt{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+				},
+				Spec: v1.KubeVirtSpec{
+					ImageRegistry: "fake-registry",
+					ImageTag:      "v9.9.9",
+					Configuration: v1.KubeVirtConfiguration{
+						DeveloperConfiguration: &v1.DeveloperConfiguration{
+							FeatureGates: []string{virtconfig.CommonTemplatesGate},
+						},
+					},
+				},
+			})
+			Expect(templatesConfig.GetDeploymentID()).ToNot(Equal(config.GetDeploymentID()))
+			strategy, err = GenerateCurrentInstallStrategy(templatesConfig, "openshift-monitoring", namespace)
+			Expect(err).ToNot(HaveOccurred())
+
+			// the templates have to survive the conversion to and from the install strategy
+			newStrategy, err := loadInstallStrategyFromBytes(string(dumpInstallStrategyToBytes(strategy)))
+			Expect(err).ToNot(HaveOccurred())
+			templates := map[string]string{}
+			for _, configMap := range newStrategy.configMaps {
+				if strings.HasPrefix(configMap.Name, components.CommonTemplateConfigMapPrefix) {
+					templates[configMap.Name] = configMap.Data[components.CommonTemplateKey]
+				}
+			}
+			Expect(templates).To(HaveLen(len(components.CommonTemplateConfigMapNames())))
+			for _, configMap := range strategy.configMaps {
+				if strings.HasPrefix(configMap.Name, components.CommonTemplateConfigMapPrefix) {
+					Expect(templates[configMap.Name]).To(Equal(configMap.Data[components.CommonTemplateKey]))
+				}
+			}
+
+			roles := []string{}
+			for _, role := range newStrategy.roles {
+				roles = append(roles, role.Name)
+			}
+			Expect(roles).To(ContainElement("kubevirt-common-templates-reader"))
+		})
 		It("latest install strategy with lossless byte conversion.", func() {
 			strategy, err := GenerateCurrentInstallStrategy(config, "openshift-monitoring", namespace)
 			Expect(err).ToNot(HaveOccurred())
@@ -80,6 +80,17 @@ func newApiServerClusterRole() *rbacv1.ClusterRole {
 					"get", "list", "delete", "patch",
 				},
 			},
+			{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"persistentvolumeclaims",
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
 			{
 				APIGroups: []string{
 					"kubevirt.io",
@@ -111,7 +122,7 @@ func newApiServerClusterRole() *rbacv1.ClusterRole {
 					"virtualmachineinstancemigrations",
 				},
 				Verbs: []string{
-					"create", "get", "list", "watch", "patch",
+					"create", "get", "list", "watch", "patch", "delete",
 				},
 			},
 			{
@@ -147,6 +158,17 @@ func newApiServerClusterRole() *rbacv1.ClusterRole {
 					"watch", "list",
 				},
 			},
+			{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"namespaces",
+				},
+				Verbs: []string{
+					"watch", "list",
+				},
+			},
 			{
 				APIGroups: []string{
 					"apiextensions.k8s.io",
@@ -279,6 +301,20 @@ func newApiServerRole(namespace string) *rbacv1.Role {
 					"get", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"secrets",
+				},
+				ResourceNames: []string{
+					virtv1.ConsoleRecordingCredentialsSecretName,
+				},
+				Verbs: []string{
+					"get",
+				},
+			},
 		},
 	}
 }
@@ -127,6 +127,9 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					"virtualmachineinstances/guestosinfo",
 					"virtualmachineinstances/filesystemlist",
 					"virtualmachineinstances/userlist",
+					"virtualmachineinstances/vsock",
+					"virtualmachineinstances/domainlog",
+					"virtualmachineinstances/guestexec",
 				},
 				Verbs: []string{
 					"get",
@@ -141,8 +144,11 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					"virtualmachineinstances/unpause",
 					"virtualmachineinstances/addvolume",
 					"virtualmachineinstances/removevolume",
+					"virtualmachineinstances/mediachange",
 					"virtualmachineinstances/freeze",
 					"virtualmachineinstances/unfreeze",
+					"virtualmachineinstances/guestexec",
+					"virtualmachineinstances/memorydump",
 				},
 				Verbs: []string{
 					"update",
@@ -156,6 +162,34 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					"virtualmachines/start",
 					"virtualmachines/stop",
 					"virtualmachines/restart",
+					"virtualmachines/migratecancel",
+					"virtualmachines/rename",
+					"virtualmachines/move",
+					"virtualmachines/memorydump",
+					"virtualmachines/removememorydump",
+				},
+				Verbs: []string{
+					"update",
+				},
+			},
+			{
+				APIGroups: []string{
+					"subresources.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachines/expand-spec",
+				},
+				Verbs: []string{
+					"get",
+				},
+			},
+			{
+				APIGroups: []string{
+					"subresources.kubevirt.io",
+				},
+				Resources: []string{
+					"expand-vm-spec",
+					"preview-domain-xml",
 				},
 				Verbs: []string{
 					"update",
@@ -189,6 +223,17 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					"get", "delete", "create", "update", "patch", "list", "watch", "deletecollection",
 				},
 			},
+			{
+				APIGroups: []string{
+					"pool.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachinepools",
+				},
+				Verbs: []string{
+					"get", "delete", "create", "update", "patch", "list", "watch", "deletecollection",
+				},
+			},
 		},
 	}
 }
@@ -217,6 +262,8 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					"virtualmachineinstances/guestosinfo",
 					"virtualmachineinstances/filesystemlist",
 					"virtualmachineinstances/userlist",
+					"virtualmachineinstances/vsock",
+					"virtualmachineinstances/domainlog",
 				},
 				Verbs: []string{
 					"get",
@@ -231,8 +278,10 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					"virtualmachineinstances/unpause",
 					"virtualmachineinstances/addvolume",
 					"virtualmachineinstances/removevolume",
+					"virtualmachineinstances/mediachange",
 					"virtualmachineinstances/freeze",
 					"virtualmachineinstances/unfreeze",
+					"virtualmachineinstances/memorydump",
 				},
 				Verbs: []string{
 					"update",
@@ -246,6 +295,34 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					"virtualmachines/start",
 					"virtualmachines/stop",
 					"virtualmachines/restart",
+					"virtualmachines/migratecancel",
+					"virtualmachines/rename",
+					"virtualmachines/move",
+					"virtualmachines/memorydump",
+					"virtualmachines/removememorydump",
+				},
+				Verbs: []string{
+					"update",
+				},
+			},
+			{
+				APIGroups: []string{
+					"subresources.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachines/expand-spec",
+				},
+				Verbs: []string{
+					"get",
+				},
+			},
+			{
+				APIGroups: []string{
+					"subresources.kubevirt.io",
+				},
+				Resources: []string{
+					"expand-vm-spec",
+					"preview-domain-xml",
 				},
 				Verbs: []string{
 					"update",
@@ -279,6 +356,17 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					"get", "delete", "create", "update", "patch", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					"pool.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachinepools",
+				},
+				Verbs: []string{
+					"get", "delete", "create", "update", "patch", "list", "watch",
+				},
+			},
 			{
 				APIGroups: []string{
 					"kubevirt.io",
@@ -321,6 +409,29 @@ func newViewClusterRole() *rbacv1.ClusterRole {
 					"get",
 				},
 			},
+			{
+				APIGroups: []string{
+					"subresources.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachines/expand-spec",
+				},
+				Verbs: []string{
+					"get",
+				},
+			},
+			{
+				APIGroups: []string{
+					"subresources.kubevirt.io",
+				},
+				Resources: []string{
+					"expand-vm-spec",
+					"preview-domain-xml",
+				},
+				Verbs: []string{
+					"update",
+				},
+			},
 			{
 				APIGroups: []string{
 					"kubevirt.io",
@@ -349,6 +460,17 @@ func newViewClusterRole() *rbacv1.ClusterRole {
 					"get", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					"pool.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachinepools",
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
 		},
 	}
 }
@@ -82,7 +82,7 @@ func newControllerClusterRole() *rbacv1.ClusterRole {
 					"",
 				},
 				Resources: []string{
-					"pods", "configmaps", "endpoints",
+					"pods", "configmaps", "endpoints", "services",
 				},
 				Verbs: []string{
 					"get", "list", "watch", "delete", "update", "create",
@@ -155,6 +155,7 @@ func newControllerClusterRole() *rbacv1.ClusterRole {
 					"list",
 					"create",
 					"delete",
+					"patch",
 				},
 			},
 			{
@@ -179,6 +180,19 @@ func newControllerClusterRole() *rbacv1.ClusterRole {
 					"*",
 				},
 			},
+			{
+				APIGroups: []string{
+					"pool.kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachinepools",
+					"virtualmachinepools/finalizers",
+					"virtualmachinepools/status",
+				},
+				Verbs: []string{
+					"*",
+				},
+			},
 			{
 				APIGroups: []string{
 					"kubevirt.io",
@@ -35,6 +35,7 @@ import (
 
 	v1 "kubevirt.io/client-go/api/v1"
 	clientutil "kubevirt.io/client-go/util"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 const (
@@ -65,6 +66,9 @@ const (
 	// lookup key in AdditionalProperties
 	AdditionalPropertiesWorkloadUpdatesEnabled = "WorkloadUpdatesEnabled"
 
+	// lookup key in AdditionalProperties
+	AdditionalPropertiesCommonTemplatesEnabled = "CommonTemplatesEnabled"
+
 	// account to use if one is not explicitly named
 	DefaultMonitorAccount = "prometheus-k8s"
 
@@ -79,6 +83,10 @@ const (
 	// #nosec 101, the variable is not holding any credential
 	// Prefix for env vars that will be passed along
 	PassthroughEnvPrefix = "KV_IO_EXTRA_ENV_"
+
+	// Prefix for env vars which set the image registry of the nodes of an architecture,
+	// e.g. KV_IO_ARCH_IMAGE_REGISTRY_S390X=quay.io/kubevirt-s390x
+	ArchImageRegistryEnvPrefix = "KV_IO_ARCH_IMAGE_REGISTRY_"
 )
 
 // DefaultMonitorNamespaces holds a set of well known prometheus-operator namespaces.
@@ -112,6 +120,9 @@ type KubeVirtDeploymentConfig struct {
 
 	// environment variables from virt-operator to pass along
 	PassthroughEnvVars map[string]string `json:"passthroughEnvVars,omitempty" optional:"true"`
+
+	// image registries per node architecture, virt-handler is deployed once per architecture if set
+	ArchImageRegistries map[string]string `json:"archImageRegistries,omitempty" optional:"true"`
 }
 
 func GetConfigFromEnv() (*KubeVirtDeploymentConfig, error) {
@@ -149,6 +160,13 @@ func GetTargetConfigFromKV(kv *v1.KubeVirt) *KubeVirtDeploymentConfig {
 	if len(kv.Spec.WorkloadUpdateStrategy.WorkloadUpdateMethods) > 0 {
 		additionalProperties[AdditionalPropertiesWorkloadUpdatesEnabled] = ""
 	}
+	if devConfig := kv.Spec.Configuration.DeveloperConfiguration; devConfig != nil {
+		for _, featureGate := range devConfig.FeatureGates {
+			if featureGate == virtconfig.CommonTemplatesGate {
+				additionalProperties[AdditionalPropertiesCommonTemplatesEnabled] = ""
+			}
+		}
+	}
 	// don't use status.target* here, as that is always set, but we need to know if it was set by the spec and with that
 	// overriding shasums from env vars
 	return getConfig(kv.Spec.ImageRegistry,
@@ -230,8 +248,9 @@ func getConfig(registry, tag, namespace string, additionalProperties map[string]
 	}
 
 	passthroughEnv := GetPassthroughEnv()
+	archImageRegistries := GetArchImageRegistries()
 
-	config := newDeploymentConfigWithTag(registry, imagePrefix, tag, namespace, additionalProperties, passthroughEnv)
+	config := newDeploymentConfigWithTag(registry, imagePrefix, tag, namespace, additionalProperties, passthroughEnv, archImageRegistries)
 	if skipShasums {
 		return config
 	}
@@ -244,7 +263,7 @@ func getConfig(registry, tag, namespace string, additionalProperties map[string]
 	gsSha := os.Getenv(GsEnvShasumName)
 	kubeVirtVersion := os.Getenv(KubeVirtVersionEnvName)
 	if operatorSha != "" && apiSha != "" && controllerSha != "" && handlerSha != "" && launcherSha != "" && kubeVirtVersion != "" {
-		config = newDeploymentConfigWithShasums(registry, imagePrefix, kubeVirtVersion, operatorSha, apiSha, controllerSha, handlerSha, launcherSha, gsSha, namespace, additionalProperties, passthroughEnv)
+		config = newDeploymentConfigWithShasums(registry, imagePrefix, kubeVirtVersion, operatorSha, apiSha, controllerSha, handlerSha, launcherSha, gsSha, namespace, additionalProperties, passthroughEnv, archImageRegistries)
 	}
 
 	return config
@@ -292,7 +311,23 @@ func GetPassthroughEnv() map[string]string {
 	return passthroughEnv
 }
 
-func newDeploymentConfigWithTag(registry, imagePrefix, tag, namespace string, kvSpec, passthroughEnv map[string]string) *KubeVirtDeploymentConfig {
+// GetArchImageRegistries returns the image registries per node architecture, keyed by the
+// architecture in the format of the kubernetes.io/arch node label
+func GetArchImageRegistries() map[string]string {
+	archImageRegistries := map[string]string{}
+
+	for _, env := range os.Environ() {
+		if strings.HasPrefix(env, ArchImageRegistryEnvPrefix) {
+			split := strings.SplitN(env, "=", 2)
+			arch := strings.ToLower(strings.TrimPrefix(split[0], ArchImageRegistryEnvPrefix))
+			archImageRegistries[arch] = split[1]
+		}
+	}
+
+	return archImageRegistries
+}
+
+func newDeploymentConfigWithTag(registry, imagePrefix, tag, namespace string, kvSpec, passthroughEnv, archImageRegistries map[string]string) *KubeVirtDeploymentConfig {
 	c := &KubeVirtDeploymentConfig{
 		Registry:             registry,
 		ImagePrefix:          imagePrefix,
@@ -300,12 +335,13 @@ func newDeploymentConfigWithTag(registry, imagePrefix, tag, namespace string, kv
 		Namespace:            namespace,
 		AdditionalProperties: kvSpec,
 		PassthroughEnvVars:   passthroughEnv,
+		ArchImageRegistries:  archImageRegistries,
 	}
 	c.generateInstallStrategyID()
 	return c
 }
 
-func newDeploymentConfigWithShasums(registry, imagePrefix, kubeVirtVersion, operatorSha, apiSha, controllerSha, handlerSha, launcherSha, gsSha, namespace string, additionalProperties, passthroughEnv map[string]string) *KubeVirtDeploymentConfig {
+func newDeploymentConfigWithShasums(registry, imagePrefix, kubeVirtVersion, operatorSha, apiSha, controllerSha, handlerSha, launcherSha, gsSha, namespace string, additionalProperties, passthroughEnv, archImageRegistries map[string]string) *KubeVirtDeploymentConfig {
 	c := &KubeVirtDeploymentConfig{
 		Registry:             registry,
 		ImagePrefix:          imagePrefix,
@@ -319,6 +355,7 @@ func newDeploymentConfigWithShasums(registry, imagePrefix, kubeVirtVersion, oper
 		Namespace:            namespace,
 		AdditionalProperties: additionalProperties,
 		PassthroughEnvVars:   passthroughEnv,
+		ArchImageRegistries:  archImageRegistries,
 	}
 	c.generateInstallStrategyID()
 	return c
@@ -375,6 +412,10 @@ func (c *KubeVirtDeploymentConfig) GetExtraEnv() map[string]string {
 	return c.PassthroughEnvVars
 }
 
+func (c *KubeVirtDeploymentConfig) GetArchImageRegistries() map[string]string {
+	return c.ArchImageRegistries
+}
+
 func (c *KubeVirtDeploymentConfig) UseShasums() bool {
 	return c.VirtOperatorSha != "" && c.VirtApiSha != "" && c.VirtControllerSha != "" && c.VirtHandlerSha != "" && c.VirtLauncherSha != ""
 }
@@ -410,6 +451,11 @@ func (c *KubeVirtDeploymentConfig) WorkloadUpdatesEnabled() bool {
 	return enabled
 }
 
+func (c *KubeVirtDeploymentConfig) CommonTemplatesEnabled() bool {
+	_, enabled := c.AdditionalProperties[AdditionalPropertiesCommonTemplatesEnabled]
+	return enabled
+}
+
 func (c *KubeVirtDeploymentConfig) GetMonitorNamespaces() []string {
 	p := c.AdditionalProperties[AdditionalPropertiesMonitorNamespace]
 	if p == "" {
@@ -135,6 +135,16 @@ var _ = Describe("Operator Config", func() {
 			false, false),
 	)
 
+	Describe("GetArchImageRegistries()", func() {
+		It("should return the image registries keyed by the lower case architecture", func() {
+			key := fmt.Sprintf("%s%s", ArchImageRegistryEnvPrefix, "S390X")
+			Expect(os.Setenv(key, "registry.example.org/kv-s390x")).To(Succeed())
+			defer os.Unsetenv(key)
+
+			Expect(GetArchImageRegistries()).To(Equal(map[string]string{"s390x": "registry.example.org/kv-s390x"}))
+		})
+	})
+
 	Describe("GetPassthroughEnv()", func() {
 		It("should eturn environment variables matching the passthrough prefix (and only those vars)", func() {
 			realKey := rand.String(10)
@@ -23,7 +23,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"reflect"
+	"regexp"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -31,11 +33,14 @@ import (
 
 	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/network/macpool"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	validating_webhooks "kubevirt.io/kubevirt/pkg/util/webhooks/validating-webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-operator/resource/apply"
 )
 
@@ -67,6 +72,34 @@ func (admitter *KubeVirtUpdateAdmitter) Admit(ar *admissionv1.AdmissionReview) *
 	results = append(results, validateCustomizeComponents(newKV.Spec.CustomizeComponents)...)
 	results = append(results, validateCertificates(newKV.Spec.CertificateRotationStrategy.SelfSigned)...)
 
+	// existing CRs were not validated before, they must not get stuck on unrelated updates
+	if newKV.Spec.Configuration.PermittedHostDevices != nil &&
+		!reflect.DeepEqual(currKV.Spec.Configuration.PermittedHostDevices, newKV.Spec.Configuration.PermittedHostDevices) {
+		results = append(results, validatePermittedHostDevices(newKV.Spec.Configuration.PermittedHostDevices)...)
+	}
+
+	if networkConfig := newKV.Spec.Configuration.NetworkConfiguration; networkConfig != nil && networkConfig.MacAddressPool != nil {
+		results = append(results, validateMacAddressPool(networkConfig.MacAddressPool)...)
+	}
+
+	if newKV.Spec.Configuration.FilesystemOverhead != nil {
+		results = append(results, validateFilesystemOverhead(newKV.Spec.Configuration.FilesystemOverhead)...)
+	}
+
+	if newKV.Spec.Configuration.DiskConfiguration != nil {
+		results = append(results, validateDiskConfiguration(newKV.Spec.Configuration.DiskConfiguration, newKV.Spec.Configuration.DeveloperConfiguration)...)
+	}
+
+	if newKV.Spec.Configuration.AuditLog != nil {
+		results = append(results, validateAuditLog(newKV.Spec.Configuration.AuditLog)...)
+	}
+
+	results = append(results, validateGuestExecAllowList(newKV.Spec.Configuration.GuestExecAllowList)...)
+
+	if newKV.Spec.Configuration.DeveloperConfiguration != nil {
+		results = append(results, validateLogFormat(newKV.Spec.Configuration.DeveloperConfiguration.LogFormat)...)
+	}
+
 	if !reflect.DeepEqual(currKV.Spec.Infra, newKV.Spec.Infra) {
 		if newKV.Spec.Infra != nil && newKV.Spec.Infra.NodePlacement != nil {
 			results = append(results,
@@ -129,6 +162,253 @@ func validateCustomizeComponents(customization v1.CustomizeComponents) []metav1.
 	return statuses
 }
 
+var pciVendorSelectorRegex = regexp.MustCompile(`^[0-9a-fA-F]{4}:[0-9a-fA-F]{4}$`)
+var usbIDRegex = regexp.MustCompile(`^[0-9a-fA-F]{4}$`)
+
+func validateMacAddressPool(pool *v1.MacAddressPool) []metav1.StatusCause {
+	if _, err := macpool.New(pool.RangeStart, pool.RangeEnd); err != nil {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("invalid MAC address pool: %v", err),
+			Field:   "spec.configuration.network.macAddressPool",
+		}}
+	}
+	return nil
+}
+
+// filesystemOverheadRegex matches a fraction from 0 up to, but excluding, 1 with at most 3 decimals
+var filesystemOverheadRegex = regexp.MustCompile(`^0(\.[0-9]{1,3})?$`)
+
+func validateFilesystemOverhead(overhead *v1.FilesystemOverhead) []metav1.StatusCause {
+	statuses := []metav1.StatusCause{}
+	const field = "spec.configuration.filesystemOverhead"
+
+	validateValue := func(value, path string) {
+		if !filesystemOverheadRegex.MatchString(value) {
+			statuses = append(statuses, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("filesystem overhead %q must be a fraction below 1, e.g. 0.055", value),
+				Field:   path,
+			})
+		}
+	}
+
+	if overhead.Global != "" {
+		validateValue(overhead.Global, field+".global")
+	}
+	for storageClass, value := range overhead.StorageClass {
+		validateValue(value, fmt.Sprintf("%s.storageClass[%s]", field, storageClass))
+	}
+
+	return statuses
+}
+
+func validateDiskConfiguration(diskConfig *v1.DiskConfiguration, developerConfig *v1.DeveloperConfiguration) []metav1.StatusCause {
+	statuses := []metav1.StatusCause{}
+	const field = "spec.configuration.disks"
+
+	for storageClass, mode := range diskConfig.StorageClassCacheModes {
+		if mode != v1.CacheNone && mode != v1.CacheWriteThrough && mode != v1.CacheWriteBack {
+			statuses = append(statuses, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("cache mode %q is not one of %s, %s or %s", mode, v1.CacheNone, v1.CacheWriteThrough, v1.CacheWriteBack),
+				Field:   fmt.Sprintf("%s.storageClassCacheModes[%s]", field, storageClass),
+			})
+		}
+	}
+
+	if limit := diskConfig.ContainerDiskEphemeralStorageLimit; limit != nil && limit.Sign() <= 0 {
+		statuses = append(statuses, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("containerDiskEphemeralStorageLimit %s must be positive", limit.String()),
+			Field:   field + ".containerDiskEphemeralStorageLimit",
+		})
+	}
+
+	switch diskConfig.DefaultBus {
+	case "", "virtio", "sata", "scsi":
+	case "nvme":
+		if !hasFeatureGate(developerConfig, virtconfig.NVMeDiskBusGate) {
+			statuses = append(statuses, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("defaultBus nvme requires the %s feature gate", virtconfig.NVMeDiskBusGate),
+				Field:   field + ".defaultBus",
+			})
+		}
+	default:
+		statuses = append(statuses, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("defaultBus %q is not one of virtio, sata, scsi or nvme", diskConfig.DefaultBus),
+			Field:   field + ".defaultBus",
+		})
+	}
+
+	return statuses
+}
+
+func hasFeatureGate(developerConfig *v1.DeveloperConfiguration, featureGate string) bool {
+	if developerConfig == nil {
+		return false
+	}
+	for _, fg := range developerConfig.FeatureGates {
+		if fg == featureGate {
+			return true
+		}
+	}
+	return false
+}
+
+func validateLogFormat(logFormat v1.LogFormat) []metav1.StatusCause {
+	switch logFormat {
+	case "", v1.LogFormatText, v1.LogFormatJSON:
+		return nil
+	}
+	return []metav1.StatusCause{{
+		Type:    metav1.CauseTypeFieldValueNotSupported,
+		Message: fmt.Sprintf("log format %q is not one of %s or %s", logFormat, v1.LogFormatText, v1.LogFormatJSON),
+		Field:   "spec.configuration.developerConfiguration.logFormat",
+	}}
+}
+
+func validateAuditLog(auditLog *v1.AuditLogConfiguration) []metav1.StatusCause {
+	const field = "spec.configuration.auditLog"
+
+	switch auditLog.Sink {
+	case "", v1.AuditLogSinkStdout:
+	case v1.AuditLogSinkFile:
+		if !filepath.IsAbs(auditLog.Path) {
+			return []metav1.StatusCause{{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("the path %q of the audit log file must be absolute", auditLog.Path),
+				Field:   field + ".path",
+			}}
+		}
+	default:
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("sink %q is not one of %s or %s", auditLog.Sink, v1.AuditLogSinkStdout, v1.AuditLogSinkFile),
+			Field:   field + ".sink",
+		}}
+	}
+	return nil
+}
+
+func validateGuestExecAllowList(allowList []v1.GuestExecCommand) []metav1.StatusCause {
+	statuses := []metav1.StatusCause{}
+	const field = "spec.configuration.guestExecAllowList"
+
+	for i, command := range allowList {
+		if !filepath.IsAbs(command.Path) {
+			statuses = append(statuses, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("the path %q of the command must be absolute", command.Path),
+				Field:   fmt.Sprintf("%s[%d].path", field, i),
+			})
+		}
+		for j, arg := range command.Args {
+			if _, err := regexp.Compile(arg); err != nil {
+				statuses = append(statuses, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("the argument %q is not a valid regular expression: %v", arg, err),
+					Field:   fmt.Sprintf("%s[%d].args[%d]", field, i, j),
+				})
+			}
+		}
+	}
+
+	return statuses
+}
+
+func validatePermittedHostDevices(hostDevs *v1.PermittedHostDevices) []metav1.StatusCause {
+	statuses := []metav1.StatusCause{}
+	const field = "spec.configuration.permittedHostDevices"
+
+	// every resource name gets its own device plugin, so it can only be advertised for a single selector.
+	// Resources of external device plugins are not advertised by KubeVirt, one of them can provide
+	// devices of several selectors.
+	type resourceNameUse struct {
+		path     string
+		external bool
+	}
+	resourceNames := map[string]resourceNameUse{}
+	validateResourceName := func(resourceName, path string, external bool) {
+		if errs := validation.IsQualifiedName(resourceName); len(errs) > 0 {
+			statuses = append(statuses, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("resourceName %q is invalid: %s", resourceName, errs[0]),
+				Field:   path,
+			})
+			return
+		}
+		if other, exists := resourceNames[resourceName]; exists && !(external && other.external) {
+			statuses = append(statuses, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("resourceName %q is already used by %s", resourceName, other.path),
+				Field:   path,
+			})
+			return
+		} else if exists {
+			return
+		}
+		resourceNames[resourceName] = resourceNameUse{path: path, external: external}
+	}
+
+	for i, pciDev := range hostDevs.PciHostDevices {
+		path := fmt.Sprintf("%s.pciHostDevices[%d]", field, i)
+		if !pciVendorSelectorRegex.MatchString(pciDev.PCIVendorSelector) {
+			statuses = append(statuses, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("pciVendorSelector %q must be of the form vendor_id:product_id, e.g. 10de:1eb8", pciDev.PCIVendorSelector),
+				Field:   path + ".pciVendorSelector",
+			})
+		}
+		validateResourceName(pciDev.ResourceName, path+".resourceName", pciDev.ExternalResourceProvider)
+	}
+
+	for i, mdev := range hostDevs.MediatedDevices {
+		path := fmt.Sprintf("%s.mediatedDevices[%d]", field, i)
+		if mdev.MDEVNameSelector == "" {
+			statuses = append(statuses, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: "mdevNameSelector must not be empty",
+				Field:   path + ".mdevNameSelector",
+			})
+		}
+		validateResourceName(mdev.ResourceName, path+".resourceName", mdev.ExternalResourceProvider)
+	}
+
+	for i, usbDev := range hostDevs.USB {
+		path := fmt.Sprintf("%s.usb[%d]", field, i)
+		if len(usbDev.Selectors) == 0 {
+			statuses = append(statuses, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: "at least one selector is required",
+				Field:   path + ".selectors",
+			})
+		}
+		for j, selector := range usbDev.Selectors {
+			selectorPath := fmt.Sprintf("%s.selectors[%d]", path, j)
+			if !usbIDRegex.MatchString(selector.Vendor) {
+				statuses = append(statuses, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("vendor %q must be a 4 digit hexadecimal ID, e.g. 046d", selector.Vendor),
+					Field:   selectorPath + ".vendor",
+				})
+			}
+			if !usbIDRegex.MatchString(selector.Product) {
+				statuses = append(statuses, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("product %q must be a 4 digit hexadecimal ID, e.g. c52b", selector.Product),
+					Field:   selectorPath + ".product",
+				})
+			}
+		}
+		validateResourceName(usbDev.ResourceName, path+".resourceName", usbDev.ExternalResourceProvider)
+	}
+
+	return statuses
+}
+
 func validateCertificates(certConfig *v1.KubeVirtSelfSignConfiguration) []metav1.StatusCause {
 	statuses := []metav1.StatusCause{}
 
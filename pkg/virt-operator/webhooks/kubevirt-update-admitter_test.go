@@ -20,11 +20,18 @@
 package webhooks
 
 import (
+	"encoding/json"
+
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 var _ = Describe("Validating KubeVirtUpdate Admitter", func() {
@@ -64,4 +71,238 @@ var _ = Describe("Validating KubeVirtUpdate Admitter", func() {
 			},
 		}, 0),
 	)
+
+	table.DescribeTable("test validatePermittedHostDevices", func(hostDevs v1.PermittedHostDevices, expectedFields ...string) {
+		causes := validatePermittedHostDevices(&hostDevs)
+		fields := []string{}
+		for _, cause := range causes {
+			fields = append(fields, cause.Field)
+		}
+		Expect(fields).To(Equal(append([]string{}, expectedFields...)))
+	},
+		table.Entry("valid devices accepted", v1.PermittedHostDevices{
+			PciHostDevices: []v1.PciHostDevice{
+				{PCIVendorSelector: "10DE:1EB8", ResourceName: "nvidia.com/TU104GL_Tesla_T4"},
+				{PCIVendorSelector: "8086:0b2b", ResourceName: "intel.com/fpga", ExternalResourceProvider: true},
+			},
+			MediatedDevices: []v1.MediatedHostDevice{
+				{MDEVNameSelector: "GRID T4-1Q", ResourceName: "nvidia.com/GRID_T4-1Q"},
+			},
+			USB: []v1.USBHostDevice{
+				{Selectors: []v1.USBSelector{{Vendor: "1050", Product: "0407"}, {Vendor: "1050", Product: "0402"}}, ResourceName: "yubico.com/yubikey"},
+			},
+		}),
+		table.Entry("invalid pci vendor selector rejected", v1.PermittedHostDevices{
+			PciHostDevices: []v1.PciHostDevice{
+				{PCIVendorSelector: "10de", ResourceName: "nvidia.com/gpu"},
+			},
+		}, "spec.configuration.permittedHostDevices.pciHostDevices[0].pciVendorSelector"),
+		table.Entry("invalid resource name rejected", v1.PermittedHostDevices{
+			PciHostDevices: []v1.PciHostDevice{
+				{PCIVendorSelector: "10de:1eb8", ResourceName: "nvidia.com/tesla t4"},
+			},
+		}, "spec.configuration.permittedHostDevices.pciHostDevices[0].resourceName"),
+		table.Entry("empty mdev selector rejected", v1.PermittedHostDevices{
+			MediatedDevices: []v1.MediatedHostDevice{
+				{ResourceName: "nvidia.com/GRID_T4-1Q"},
+			},
+		}, "spec.configuration.permittedHostDevices.mediatedDevices[0].mdevNameSelector"),
+		table.Entry("duplicate resource names rejected", v1.PermittedHostDevices{
+			PciHostDevices: []v1.PciHostDevice{
+				{PCIVendorSelector: "10de:1eb8", ResourceName: "nvidia.com/gpu"},
+			},
+			MediatedDevices: []v1.MediatedHostDevice{
+				{MDEVNameSelector: "GRID T4-1Q", ResourceName: "nvidia.com/gpu"},
+			},
+		}, "spec.configuration.permittedHostDevices.mediatedDevices[0].resourceName"),
+		table.Entry("duplicate resource names of external providers accepted", v1.PermittedHostDevices{
+			PciHostDevices: []v1.PciHostDevice{
+				{PCIVendorSelector: "10de:1eb8", ResourceName: "nvidia.com/gpu", ExternalResourceProvider: true},
+				{PCIVendorSelector: "10de:1db6", ResourceName: "nvidia.com/gpu", ExternalResourceProvider: true},
+			},
+		}),
+		table.Entry("duplicate resource names of an external provider and KubeVirt rejected", v1.PermittedHostDevices{
+			PciHostDevices: []v1.PciHostDevice{
+				{PCIVendorSelector: "10de:1eb8", ResourceName: "nvidia.com/gpu", ExternalResourceProvider: true},
+				{PCIVendorSelector: "10de:1db6", ResourceName: "nvidia.com/gpu"},
+			},
+		}, "spec.configuration.permittedHostDevices.pciHostDevices[1].resourceName"),
+		table.Entry("usb device without selectors rejected", v1.PermittedHostDevices{
+			USB: []v1.USBHostDevice{
+				{ResourceName: "yubico.com/yubikey"},
+			},
+		}, "spec.configuration.permittedHostDevices.usb[0].selectors"),
+		table.Entry("invalid usb selector rejected", v1.PermittedHostDevices{
+			USB: []v1.USBHostDevice{
+				{Selectors: []v1.USBSelector{{Vendor: "1050:0407"}}, ResourceName: "yubico.com/yubikey"},
+			},
+		}, "spec.configuration.permittedHostDevices.usb[0].selectors[0].vendor",
+			"spec.configuration.permittedHostDevices.usb[0].selectors[0].product"),
+	)
+
+	table.DescribeTable("test validateMacAddressPool", func(pool v1.MacAddressPool, expectedCauses int) {
+		causes := validateMacAddressPool(&pool)
+		Expect(causes).To(HaveLen(expectedCauses))
+	},
+		table.Entry("valid range accepted", v1.MacAddressPool{RangeStart: "02:00:00:00:00:00", RangeEnd: "02:00:00:ff:ff:ff"}, 0),
+		table.Entry("invalid address rejected", v1.MacAddressPool{RangeStart: "02:00:00:00:00", RangeEnd: "02:00:00:ff:ff:ff"}, 1),
+		table.Entry("inverted range rejected", v1.MacAddressPool{RangeStart: "02:00:00:ff:ff:ff", RangeEnd: "02:00:00:00:00:00"}, 1),
+		table.Entry("multicast range rejected", v1.MacAddressPool{RangeStart: "01:00:00:00:00:00", RangeEnd: "01:00:00:ff:ff:ff"}, 1),
+	)
+
+	table.DescribeTable("test validateFilesystemOverhead", func(overhead v1.FilesystemOverhead, expectedFields ...string) {
+		causes := validateFilesystemOverhead(&overhead)
+		fields := []string{}
+		for _, cause := range causes {
+			fields = append(fields, cause.Field)
+		}
+		Expect(fields).To(ConsistOf(expectedFields))
+	},
+		table.Entry("valid overheads accepted", v1.FilesystemOverhead{
+			Global:       "0.055",
+			StorageClass: map[string]string{"fast": "0", "slow": "0.1"},
+		}),
+		table.Entry("percentages rejected", v1.FilesystemOverhead{Global: "5%"}, "spec.configuration.filesystemOverhead.global"),
+		table.Entry("overheads of 1 rejected", v1.FilesystemOverhead{
+			StorageClass: map[string]string{"fast": "1"},
+		}, "spec.configuration.filesystemOverhead.storageClass[fast]"),
+		table.Entry("negative overheads rejected", v1.FilesystemOverhead{
+			StorageClass: map[string]string{"fast": "-0.1"},
+		}, "spec.configuration.filesystemOverhead.storageClass[fast]"),
+	)
+
+	table.DescribeTable("test validateDiskConfiguration", func(diskConfig v1.DiskConfiguration, expectedFields ...string) {
+		causes := validateDiskConfiguration(&diskConfig, &v1.DeveloperConfiguration{FeatureGates: []string{virtconfig.NVMeDiskBusGate}})
+		fields := []string{}
+		for _, cause := range causes {
+			fields = append(fields, cause.Field)
+		}
+		Expect(fields).To(ConsistOf(expectedFields))
+	},
+		table.Entry("valid configuration accepted", v1.DiskConfiguration{
+			StorageClassCacheModes: map[string]v1.DriverCache{
+				"local": v1.CacheNone, "nfs": v1.CacheWriteThrough, "ceph": v1.CacheWriteBack,
+			},
+			ContainerDiskEphemeralStorageLimit: resource.NewQuantity(1024*1024*1024, resource.BinarySI),
+			DefaultBus:                         "nvme",
+		}),
+		table.Entry("unknown cache mode rejected", v1.DiskConfiguration{
+			StorageClassCacheModes: map[string]v1.DriverCache{"nfs": "unsafe"},
+		}, "spec.configuration.disks.storageClassCacheModes[nfs]"),
+		table.Entry("zero containerDisk limit rejected", v1.DiskConfiguration{
+			ContainerDiskEphemeralStorageLimit: resource.NewQuantity(0, resource.BinarySI),
+		}, "spec.configuration.disks.containerDiskEphemeralStorageLimit"),
+		table.Entry("unknown default bus rejected", v1.DiskConfiguration{
+			DefaultBus: "ide",
+		}, "spec.configuration.disks.defaultBus"),
+	)
+
+	It("should reject the nvme default bus without the NVMeDiskBus feature gate", func() {
+		causes := validateDiskConfiguration(&v1.DiskConfiguration{DefaultBus: "nvme"}, nil)
+		Expect(causes).To(HaveLen(1))
+		Expect(causes[0].Field).To(Equal("spec.configuration.disks.defaultBus"))
+	})
+
+	table.DescribeTable("test validateAuditLog", func(auditLog v1.AuditLogConfiguration, expectedFields ...string) {
+		causes := validateAuditLog(&auditLog)
+		fields := []string{}
+		for _, cause := range causes {
+			fields = append(fields, cause.Field)
+		}
+		Expect(fields).To(ConsistOf(expectedFields))
+	},
+		table.Entry("default sink accepted", v1.AuditLogConfiguration{}),
+		table.Entry("stdout sink accepted", v1.AuditLogConfiguration{Sink: v1.AuditLogSinkStdout}),
+		table.Entry("file sink with an absolute path accepted", v1.AuditLogConfiguration{
+			Sink: v1.AuditLogSinkFile, Path: "/var/log/kubevirt/audit.log",
+		}),
+		table.Entry("file sink without a path rejected", v1.AuditLogConfiguration{
+			Sink: v1.AuditLogSinkFile,
+		}, "spec.configuration.auditLog.path"),
+		table.Entry("file sink with a relative path rejected", v1.AuditLogConfiguration{
+			Sink: v1.AuditLogSinkFile, Path: "audit.log",
+		}, "spec.configuration.auditLog.path"),
+		table.Entry("unknown sink rejected", v1.AuditLogConfiguration{
+			Sink: "syslog",
+		}, "spec.configuration.auditLog.sink"),
+	)
+
+	table.DescribeTable("test validateLogFormat", func(logFormat v1.LogFormat, expectedCauses int) {
+		Expect(validateLogFormat(logFormat)).To(HaveLen(expectedCauses))
+	},
+		table.Entry("default format accepted", v1.LogFormat(""), 0),
+		table.Entry("text format accepted", v1.LogFormatText, 0),
+		table.Entry("json format accepted", v1.LogFormatJSON, 0),
+		table.Entry("unknown format rejected", v1.LogFormat("logfmt"), 1),
+	)
+
+	table.DescribeTable("test validateGuestExecAllowList", func(allowList []v1.GuestExecCommand, expectedFields ...string) {
+		causes := validateGuestExecAllowList(allowList)
+		fields := []string{}
+		for _, cause := range causes {
+			fields = append(fields, cause.Field)
+		}
+		Expect(fields).To(ConsistOf(expectedFields))
+	},
+		table.Entry("commands with and without args accepted", []v1.GuestExecCommand{
+			{Path: "/usr/bin/uptime"},
+			{Path: "/usr/bin/df", Args: []string{"-h", "/[a-z/]*"}},
+		}),
+		table.Entry("relative path rejected", []v1.GuestExecCommand{
+			{Path: "/usr/bin/uptime"},
+			{Path: "df"},
+		}, "spec.configuration.guestExecAllowList[1].path"),
+		table.Entry("invalid argument pattern rejected", []v1.GuestExecCommand{
+			{Path: "/usr/bin/df", Args: []string{"-h", "/[a-z"}},
+		}, "spec.configuration.guestExecAllowList[0].args[1]"),
+	)
+
+	Context("with an update", func() {
+		admit := func(oldKV, newKV *v1.KubeVirt) *admissionv1.AdmissionResponse {
+			oldBytes, err := json.Marshal(oldKV)
+			Expect(err).ToNot(HaveOccurred())
+			newBytes, err := json.Marshal(newKV)
+			Expect(err).ToNot(HaveOccurred())
+			return NewKubeVirtUpdateAdmitter(nil).Admit(&admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Operation: admissionv1.Update,
+					Resource:  KubeVirtGroupVersionResource,
+					Object:    runtime.RawExtension{Raw: newBytes},
+					OldObject: runtime.RawExtension{Raw: oldBytes},
+				},
+			})
+		}
+
+		newKubeVirt := func() *v1.KubeVirt {
+			return &v1.KubeVirt{
+				TypeMeta:   metav1.TypeMeta{APIVersion: v1.GroupVersion.String(), Kind: "KubeVirt"},
+				ObjectMeta: metav1.ObjectMeta{Name: "kubevirt", Namespace: "kubevirt"},
+				Spec: v1.KubeVirtSpec{
+					Configuration: v1.KubeVirtConfiguration{
+						PermittedHostDevices: &v1.PermittedHostDevices{
+							PciHostDevices: []v1.PciHostDevice{
+								{PCIVendorSelector: "10de", ResourceName: "nvidia.com/gpu"},
+							},
+						},
+					},
+				},
+			}
+		}
+
+		It("should accept unrelated changes of a CR with invalid permitted host devices", func() {
+			oldKV := newKubeVirt()
+			newKV := newKubeVirt()
+			newKV.Spec.ImagePullPolicy = "Always"
+
+			Expect(admit(oldKV, newKV).Allowed).To(BeTrue())
+		})
+
+		It("should reject changed permitted host devices which are invalid", func() {
+			oldKV := newKubeVirt()
+			newKV := newKubeVirt()
+			newKV.Spec.Configuration.PermittedHostDevices.PciHostDevices[0].ResourceName = "nvidia.com/tesla"
+
+			Expect(admit(oldKV, newKV).Allowed).To(BeFalse())
+		})
+	})
 })
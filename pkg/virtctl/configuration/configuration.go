@@ -72,6 +72,10 @@ func (c *command) run() error {
 			hostDeviceList = append(hostDeviceList, hd.ResourceName)
 		}
 
+		for _, hd := range kv.Spec.Configuration.PermittedHostDevices.USB {
+			hostDeviceList = append(hostDeviceList, hd.ResourceName)
+		}
+
 		for _, hd := range kv.Spec.Configuration.PermittedHostDevices.MediatedDevices {
 			gpuDeviceList = append(gpuDeviceList, hd.ResourceName)
 		}
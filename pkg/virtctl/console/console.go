@@ -35,7 +35,10 @@ import (
 	"kubevirt.io/kubevirt/pkg/virtctl/utils"
 )
 
-var timeout int
+var (
+	timeout int
+	showLog bool
+)
 
 func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	cmd := &cobra.Command{
@@ -50,6 +53,7 @@ func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	}
 
 	cmd.Flags().IntVar(&timeout, "timeout", 5, "The number of minutes to wait for the virtual machine instance to be ready.")
+	cmd.Flags().BoolVar(&showLog, "show-log", false, "Print the console output recorded since the virtual machine instance started before connecting, it is only recorded for virtual machine instances with the kubevirt.io/serial-console-log annotation.")
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
@@ -62,7 +66,9 @@ func usage() string {
 	usage := `  # Connect to the console on VirtualMachineInstance 'myvmi':
   {{ProgramName}} console myvmi
   # Configure one minute timeout (default 5 minutes)
-  {{ProgramName}} console --timeout=1 myvmi`
+  {{ProgramName}} console --timeout=1 myvmi
+  # Print the output missed before connecting, for example the boot messages
+  {{ProgramName}} console --show-log myvmi`
 
 	return usage
 }
@@ -80,6 +86,14 @@ func (c *Console) Run(args []string) error {
 		return err
 	}
 
+	if showLog {
+		consoleLog, err := virtCli.VirtualMachineInstance(namespace).ConsoleLog(vmi)
+		if err != nil {
+			return fmt.Errorf("can't fetch the console log of %s: %v", vmi, err)
+		}
+		os.Stdout.Write(consoleLog)
+	}
+
 	stdinReader, stdinWriter := io.Pipe()
 	stdoutReader, stdoutWriter := io.Pipe()
 
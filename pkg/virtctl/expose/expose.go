@@ -43,6 +43,8 @@ var strServiceType string
 var portName string
 var strIPFamily string
 var strIPFamilyPolicy string
+var strPorts []string
+var strSelector string
 
 // NewExposeCommand generates a new "expose" command
 func NewExposeCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
@@ -51,8 +53,8 @@ func NewExposeCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 		Short: "Expose a virtual machine instance, virtual machine, or virtual machine instance replica set as a new service.",
 		Long: `Looks up a virtual machine instance, virtual machine or virtual machine instance replica set by name and use its selector as the selector for a new service on the specified port.
 A virtual machine instance replica set will be exposed as a service only if its selector is convertible to a selector that service supports, i.e. when the selector contains only the matchLabels component.
-Note that if no port is specified via --port and the exposed resource has multiple ports, all will be re-used by the new service.
-Also if no labels are specified, the new service will re-use the labels from the resource it exposes.
+Note that if no port is specified via --port or --ports and the exposed resource has multiple ports, all will be re-used by the new service.
+Also if no selector is specified via --selector, the new service will select the labels of the virtual machine instances of the resource it exposes.
 
 Possible types are (case insensitive, both single and plurant forms):
 
@@ -77,7 +79,9 @@ virtualmachineinstance (vmi), virtualmachine (vm), virtualmachineinstancereplica
 	cmd.Flags().StringVar(&strServiceType, "type", "ClusterIP", "Type for this service: ClusterIP, NodePort, or LoadBalancer.")
 	cmd.Flags().StringVar(&portName, "port-name", "", "Name of the port. Optional.")
 	cmd.Flags().StringVar(&strIPFamily, "ip-family", "IPv4", "IP family over which the service will be exposed. Valid values are 'IPv4', 'IPv6', 'IPv4,IPv6' or 'IPv6,IPv4'")
-	cmd.Flags().StringVar(&strIPFamilyPolicy, "ip-family-policy", "", "IP family policy defines whether the service can use IPv4, IPv6, or both. Valid values are 'SingleStack', 'PreferDualStack' or 'RequireDualStack'")
+	cmd.Flags().StringVar(&strIPFamilyPolicy, "ip-family-policy", "", "IP family policy defines whether the service can use IPv4, IPv6, or both. Valid values are 'SingleStack', 'PreferDualStack' or 'RequireDualStack'. Defaults to 'PreferDualStack' if two IP families are given.")
+	cmd.Flags().StringSliceVar(&strPorts, "ports", nil, "Comma separated list of ports that the service should serve on, in the form port[:target-port][/protocol], e.g. '53/UDP,53/TCP,80:8080'. The protocol defaults to --protocol. Can't be combined with --port.")
+	cmd.Flags().StringVar(&strSelector, "selector", "", "Comma separated list of key=value labels used as the selector of the service instead of the labels of the exposed resource.")
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 
 	return cmd
@@ -91,7 +95,13 @@ func usage() string {
   {{ProgramName}} expose vmirs myvmirs --name=vmirs-service
 
   # Expose port 8080 as port 80 from a virtual machine instance replicaset on a service:
-  {{ProgramName}} expose vmirs myvmirs --port=80 --target-port=8080 --name=vmirs-service`
+  {{ProgramName}} expose vmirs myvmirs --port=80 --target-port=8080 --name=vmirs-service
+
+  # Expose DNS over UDP and TCP of a virtual machine on a dual-stack service:
+  {{ProgramName}} expose vm myvm --ports=53/UDP,53/TCP --ip-family=IPv4,IPv6 --name=vm-dns
+
+  # Expose all virtual machines labeled with app=web on a service:
+  {{ProgramName}} expose vm myvm --port=80 --selector=app=web --name=web`
 	return usage
 }
 
@@ -103,7 +113,6 @@ func (o *Command) RunE(args []string) error {
 	vmName := args[1]
 
 	// these are used to convert the flag values into service spec values
-	var protocol v1.Protocol
 	var targetPort intstr.IntOrString
 	var serviceType v1.ServiceType
 
@@ -111,13 +120,9 @@ func (o *Command) RunE(args []string) error {
 	targetPort = intstr.Parse(strTargetPort)
 
 	// convert from string to the protocol enum
-	switch strProtocol {
-	case "TCP":
-		protocol = v1.ProtocolTCP
-	case "UDP":
-		protocol = v1.ProtocolUDP
-	default:
-		return fmt.Errorf("unknown protocol: %s", strProtocol)
+	protocol, err := convertProtocol(strProtocol)
+	if err != nil {
+		return err
 	}
 
 	// convert from string to the service type enum
@@ -144,6 +149,36 @@ func (o *Command) RunE(args []string) error {
 		return err
 	}
 
+	// a service with two IP families is dual-stack, which the default SingleStack policy doesn't allow
+	if len(ipFamilies) > 1 {
+		switch ipFamilyPolicy {
+		case "":
+			ipFamilyPolicy = v1.IPFamilyPolicyPreferDualStack
+		case v1.IPFamilyPolicySingleStack:
+			return fmt.Errorf("IPFamilyPolicy %s can't be used with multiple ip families", ipFamilyPolicy)
+		}
+	}
+
+	if port != 0 && len(strPorts) > 0 {
+		return fmt.Errorf("--port and --ports can't be used together")
+	}
+
+	var explicitPorts []v1.ServicePort
+	if len(strPorts) > 0 {
+		explicitPorts, err = parsePorts(strPorts, protocol)
+		if err != nil {
+			return err
+		}
+	}
+
+	var explicitSelector map[string]string
+	if strSelector != "" {
+		explicitSelector, err = parseSelector(strSelector)
+		if err != nil {
+			return err
+		}
+	}
+
 	// get the namespace
 	namespace, _, err := o.clientConfig.Namespace()
 	if err != nil {
@@ -199,11 +234,17 @@ func (o *Command) RunE(args []string) error {
 		return fmt.Errorf("unsupported resource type: %s", vmType)
 	}
 
+	if explicitSelector != nil {
+		serviceSelector = explicitSelector
+	}
+
 	if len(serviceSelector) == 0 {
 		return fmt.Errorf("missing label information for %s: %s", vmType, vmName)
 	}
 
-	if port == 0 && len(ports) == 0 {
+	if explicitPorts != nil {
+		ports = explicitPorts
+	} else if port == 0 && len(ports) == 0 {
 		return fmt.Errorf("couldn't find port via --port flag or introspection")
 	} else if port != 0 {
 		ports = []v1.ServicePort{{Name: portName, Protocol: protocol, Port: port, TargetPort: targetPort}}
@@ -276,6 +317,66 @@ func (o *Command) RunE(args []string) error {
 	return nil
 }
 
+func convertProtocol(strProtocol string) (v1.Protocol, error) {
+	switch strings.ToUpper(strProtocol) {
+	case "TCP":
+		return v1.ProtocolTCP, nil
+	case "UDP":
+		return v1.ProtocolUDP, nil
+	default:
+		return "", fmt.Errorf("unknown protocol: %s", strProtocol)
+	}
+}
+
+// parsePorts converts a list of port[:target-port][/protocol] entries to service ports.
+// Every port is named, since services with more than one port require it.
+func parsePorts(strPorts []string, defaultProtocol v1.Protocol) ([]v1.ServicePort, error) {
+	ports := []v1.ServicePort{}
+	for i, strPort := range strPorts {
+		servicePort := v1.ServicePort{Name: fmt.Sprintf("port-%d", i+1), Protocol: defaultProtocol}
+
+		if idx := strings.Index(strPort, "/"); idx >= 0 {
+			protocol, err := convertProtocol(strPort[idx+1:])
+			if err != nil {
+				return nil, err
+			}
+			servicePort.Protocol = protocol
+			strPort = strPort[:idx]
+		}
+
+		if idx := strings.Index(strPort, ":"); idx >= 0 {
+			servicePort.TargetPort = intstr.Parse(strPort[idx+1:])
+			strPort = strPort[:idx]
+		}
+
+		number, err := strconv.ParseInt(strPort, 10, 32)
+		if err != nil || number < 1 || number > 65535 {
+			return nil, fmt.Errorf("invalid port: %s", strPort)
+		}
+		servicePort.Port = int32(number)
+
+		for _, other := range ports {
+			if other.Port == servicePort.Port && other.Protocol == servicePort.Protocol {
+				return nil, fmt.Errorf("duplicate port: %d/%s", servicePort.Port, servicePort.Protocol)
+			}
+		}
+		ports = append(ports, servicePort)
+	}
+	return ports, nil
+}
+
+func parseSelector(strSelector string) (map[string]string, error) {
+	selector := map[string]string{}
+	for _, pair := range strings.Split(strSelector, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid selector: %s, expected key=value pairs", strSelector)
+		}
+		selector[parts[0]] = parts[1]
+	}
+	return selector, nil
+}
+
 func convertIPFamily(strIPFamily string) ([]v1.IPFamily, error) {
 	switch strings.ToLower(strIPFamily) {
 	case "ipv4":
@@ -5,11 +5,13 @@ import (
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	k8sv1 "k8s.io/api/core/v1"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/version"
 	fakediscovery "k8s.io/client-go/discovery/fake"
 	fakedynamic "k8s.io/client-go/dynamic/fake"
@@ -341,6 +343,70 @@ var _ = Describe("Expose", func() {
 				})
 			})
 		})
+		Context("with k8s > 1.19 and multiple ports", func() {
+			BeforeEach(func() {
+				kubeclient.Fake.PrependReactor("get", "version", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					return true, nil, nil
+				})
+				discovery.FakedServerVersion = &version.Info{Major: "1", Minor: "20"}
+			})
+
+			It("should create a port for every entry of a mixed UDP and TCP list", func() {
+				cmd := tests.NewRepeatableVirtctlCommand(expose.COMMAND_EXPOSE, "vm", vmName, "--name", "my-service",
+					"--ports", "53/UDP,53/tcp,80:8080")
+				Expect(cmd()).To(Succeed())
+				Expect(obtainedService.Spec.Ports).To(Equal([]k8sv1.ServicePort{
+					{Name: "port-1", Protocol: k8sv1.ProtocolUDP, Port: 53},
+					{Name: "port-2", Protocol: k8sv1.ProtocolTCP, Port: 53},
+					{Name: "port-3", Protocol: k8sv1.ProtocolTCP, Port: 80, TargetPort: intstr.FromInt(8080)},
+				}))
+			})
+
+			It("should use the protocol flag as the default protocol of the list", func() {
+				cmd := tests.NewRepeatableVirtctlCommand(expose.COMMAND_EXPOSE, "vm", vmName, "--name", "my-service",
+					"--ports", "5353,80/TCP", "--protocol", "UDP")
+				Expect(cmd()).To(Succeed())
+				Expect(obtainedService.Spec.Ports[0].Protocol).To(Equal(k8sv1.ProtocolUDP))
+				Expect(obtainedService.Spec.Ports[1].Protocol).To(Equal(k8sv1.ProtocolTCP))
+			})
+
+			table.DescribeTable("should fail", func(args ...string) {
+				cmd := tests.NewRepeatableVirtctlCommand(append([]string{expose.COMMAND_EXPOSE, "vm", vmName, "--name", "my-service"}, args...)...)
+				Expect(cmd()).ToNot(Succeed())
+			},
+				table.Entry("with --port and --ports", "--port", "80", "--ports", "81"),
+				table.Entry("with an invalid port", "--ports", "http"),
+				table.Entry("with an out of range port", "--ports", "70000"),
+				table.Entry("with an unknown protocol", "--ports", "80/ICMP"),
+				table.Entry("with a duplicate port", "--ports", "80,80/TCP"),
+			)
+
+			It("should make a service with two IP families dual-stack", func() {
+				cmd := tests.NewRepeatableVirtctlCommand(expose.COMMAND_EXPOSE, "vmi", vmName, "--name", "my-service",
+					"--ports", "53/UDP,53/TCP", "--ip-family", "ipv4,ipv6")
+				Expect(cmd()).To(Succeed())
+				Expect(*obtainedService.Spec.IPFamilyPolicy).To(Equal(k8sv1.IPFamilyPolicyPreferDualStack))
+			})
+
+			It("should fail with two IP families and a SingleStack policy", func() {
+				cmd := tests.NewRepeatableVirtctlCommand(expose.COMMAND_EXPOSE, "vmi", vmName, "--name", "my-service",
+					"--port", "53", "--ip-family", "ipv4,ipv6", "--ip-family-policy", "SingleStack")
+				Expect(cmd()).ToNot(Succeed())
+			})
+
+			It("should select the labels of the selector flag", func() {
+				cmd := tests.NewRepeatableVirtctlCommand(expose.COMMAND_EXPOSE, "vm", vmName, "--name", "my-service",
+					"--port", "80", "--selector", "app=web,tier=frontend")
+				Expect(cmd()).To(Succeed())
+				Expect(obtainedService.Spec.Selector).To(Equal(map[string]string{"app": "web", "tier": "frontend"}))
+			})
+
+			It("should fail with an invalid selector", func() {
+				cmd := tests.NewRepeatableVirtctlCommand(expose.COMMAND_EXPOSE, "vm", vmName, "--name", "my-service",
+					"--port", "80", "--selector", "app")
+				Expect(cmd()).ToNot(Succeed())
+			})
+		})
 		Context("with k8s <= 1.19", func() {
 			var obtainedUnstructured *unstructured.Unstructured
 			BeforeEach(func() {
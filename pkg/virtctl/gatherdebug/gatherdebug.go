@@ -0,0 +1,451 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package gatherdebug
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	k8sv1 "k8s.io/api/core/v1"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/remotecommand"
+	"sigs.k8s.io/yaml"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	COMMAND_GATHER_DEBUG = "gather-debug"
+
+	outputArg        = "output"
+	allNamespacesArg = "all-namespaces"
+	logLinesArg      = "log-lines"
+
+	// archiveRoot is the directory in the archive which holds all collected files
+	archiveRoot = "kubevirt-debug"
+	// launcherContainer is the container of virt-launcher pods which runs libvirt and QEMU
+	launcherContainer = "compute"
+)
+
+var (
+	output        string
+	allNamespaces bool
+	logLines      int64
+
+	// components are the values of the kubevirt.io label of the pods of the control plane
+	components = []string{"virt-operator", "virt-api", "virt-controller", "virt-handler"}
+
+	// ExecInContainer runs a command in a container of a pod and returns its stdout, it is a variable
+	// so that tests can replace it
+	ExecInContainer = execInContainer
+)
+
+func NewGatherDebugCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gather-debug",
+		Short: "Collect KubeVirt debug information into an archive.",
+		Long: `Collects the KubeVirt CRs, the logs of the KubeVirt components, the nodes with their capabilities and, for the VMs of the namespace or of all namespaces,
+the VMs, VMIs, migrations, events, virt-launcher logs, domain logs and domain XMLs into a gzipped tar archive, which can be attached to bug reports.
+The VMs and VMIs are collected with their complete spec, including the cloud-init user data, which may hold passwords or keys. Check the archive before sharing it.
+Data which can not be collected, for example because of missing permissions, is listed in the errors.txt file of the archive.`,
+		Args:    templates.ExactArgs(COMMAND_GATHER_DEBUG, 0),
+		Example: usage(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := command{clientConfig: clientConfig}
+			return c.run(cmd)
+		},
+	}
+	cmd.Flags().StringVarP(&output, outputArg, "o", "", "path of the archive, defaults to kubevirt-debug-<timestamp>.tar.gz.")
+	cmd.Flags().BoolVarP(&allNamespaces, allNamespacesArg, "A", false, "collect the VMs of all namespaces instead of the current namespace.")
+	cmd.Flags().Int64Var(&logLines, logLinesArg, 0, "number of lines collected from the end of each log, 0 collects the complete logs.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	usage := `  # Collect the debug information of the VMs in the current namespace:
+  {{ProgramName}} gather-debug
+
+  # Collect the debug information of all namespaces into debug.tar.gz:
+  {{ProgramName}} gather-debug --all-namespaces --output debug.tar.gz`
+	return usage
+}
+
+type command struct {
+	clientConfig clientcmd.ClientConfig
+}
+
+func (c *command) run(cmd *cobra.Command) error {
+	namespace, _, err := c.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+	if allNamespaces {
+		namespace = k8smetav1.NamespaceAll
+	}
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(c.clientConfig)
+	if err != nil {
+		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
+	}
+
+	archivePath := output
+	if archivePath == "" {
+		archivePath = fmt.Sprintf("kubevirt-debug-%s.tar.gz", time.Now().UTC().Format("20060102-150405"))
+	}
+	file, err := os.Create(archivePath)
+	if err != nil {
+		return fmt.Errorf("Error creating the archive %s: %v", archivePath, err)
+	}
+	defer file.Close()
+
+	gzipWriter := gzip.NewWriter(file)
+	g := &gatherer{
+		virtClient:   virtClient,
+		clientConfig: c.clientConfig,
+		namespace:    namespace,
+		archive:      tar.NewWriter(gzipWriter),
+	}
+	if err := g.gather(); err != nil {
+		return fmt.Errorf("Error writing the archive %s: %v", archivePath, err)
+	}
+	if err := g.archive.Close(); err != nil {
+		return fmt.Errorf("Error writing the archive %s: %v", archivePath, err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return fmt.Errorf("Error writing the archive %s: %v", archivePath, err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Wrote the debug information to %s\n", archivePath)
+	if len(g.errors) > 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "%d items could not be collected, see %s/errors.txt in the archive\n", len(g.errors), archiveRoot)
+	}
+	return nil
+}
+
+// gatherer writes the collected information into the archive. Errors of the cluster are recorded
+// in errors.txt of the archive, only errors writing the archive abort the collection.
+type gatherer struct {
+	virtClient   kubecli.KubevirtClient
+	clientConfig clientcmd.ClientConfig
+	namespace    string
+	archive      *tar.Writer
+	errors       []string
+}
+
+func (g *gatherer) gather() error {
+	steps := []func() error{
+		g.gatherKubeVirt,
+		g.gatherNodes,
+		g.gatherVMs,
+		g.gatherMigrations,
+		g.gatherVMIs,
+		g.gatherEvents,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+	if len(g.errors) == 0 {
+		return nil
+	}
+	return g.add("errors.txt", []byte(strings.Join(g.errors, "\n")+"\n"))
+}
+
+func (g *gatherer) recordError(format string, args ...interface{}) {
+	g.errors = append(g.errors, fmt.Sprintf(format, args...))
+}
+
+func (g *gatherer) add(name string, data []byte) error {
+	header := &tar.Header{
+		Name:    path.Join(archiveRoot, name),
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}
+	if err := g.archive.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := g.archive.Write(data)
+	return err
+}
+
+func (g *gatherer) addYAML(name string, obj interface{}) error {
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		g.recordError("%s: %v", name, err)
+		return nil
+	}
+	return g.add(name, data)
+}
+
+// gatherKubeVirt collects the KubeVirt CRs and the logs of the components in their namespaces
+func (g *gatherer) gatherKubeVirt() error {
+	kvs, err := g.virtClient.KubeVirt(k8smetav1.NamespaceAll).List(&k8smetav1.ListOptions{})
+	if err != nil {
+		g.recordError("KubeVirt CRs: %v", err)
+		return nil
+	}
+	if err := g.addYAML("cluster/kubevirt.yaml", kvs); err != nil {
+		return err
+	}
+
+	selector := fmt.Sprintf("%s in (%s)", v1.AppLabel, strings.Join(components, ","))
+	for _, kv := range kvs.Items {
+		pods, err := g.virtClient.CoreV1().Pods(kv.Namespace).List(context.Background(), k8smetav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			g.recordError("pods of the KubeVirt components in %s: %v", kv.Namespace, err)
+			continue
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			for _, container := range pod.Spec.Containers {
+				name := path.Join("cluster", "logs", pod.Namespace, pod.Name, container.Name+".log")
+				if err := g.addLog(name, pod, container.Name); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// gatherNodes collects the nodes, their labels hold the capabilities which the node labeller detected
+func (g *gatherer) gatherNodes() error {
+	nodes, err := g.virtClient.CoreV1().Nodes().List(context.Background(), k8smetav1.ListOptions{})
+	if err != nil {
+		g.recordError("nodes: %v", err)
+		return nil
+	}
+	return g.addYAML("cluster/nodes.yaml", nodes)
+}
+
+func (g *gatherer) gatherVMs() error {
+	vms, err := g.virtClient.VirtualMachine(g.namespace).List(&k8smetav1.ListOptions{})
+	if err != nil {
+		g.recordError("VMs: %v", err)
+		return nil
+	}
+	byNamespace := map[string][]v1.VirtualMachine{}
+	for _, vm := range vms.Items {
+		byNamespace[vm.Namespace] = append(byNamespace[vm.Namespace], vm)
+	}
+	for namespace, items := range byNamespace {
+		if err := g.addYAML(path.Join("namespaces", namespace, "virtualmachines.yaml"), &v1.VirtualMachineList{Items: items}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *gatherer) gatherMigrations() error {
+	migrations, err := g.virtClient.VirtualMachineInstanceMigration(g.namespace).List(&k8smetav1.ListOptions{})
+	if err != nil {
+		g.recordError("migrations: %v", err)
+		return nil
+	}
+	byNamespace := map[string][]v1.VirtualMachineInstanceMigration{}
+	for _, migration := range migrations.Items {
+		byNamespace[migration.Namespace] = append(byNamespace[migration.Namespace], migration)
+	}
+	for namespace, items := range byNamespace {
+		if err := g.addYAML(path.Join("namespaces", namespace, "virtualmachineinstancemigrations.yaml"), &v1.VirtualMachineInstanceMigrationList{Items: items}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// gatherVMIs collects the VMIs, the logs of their virt-launcher pods, their domain logs and their domain XMLs
+func (g *gatherer) gatherVMIs() error {
+	vmis, err := g.virtClient.VirtualMachineInstance(g.namespace).List(&k8smetav1.ListOptions{})
+	if err != nil {
+		g.recordError("VMIs: %v", err)
+		return nil
+	}
+	byNamespace := map[string][]v1.VirtualMachineInstance{}
+	for _, vmi := range vmis.Items {
+		byNamespace[vmi.Namespace] = append(byNamespace[vmi.Namespace], vmi)
+	}
+
+	for namespace, items := range byNamespace {
+		if err := g.addYAML(path.Join("namespaces", namespace, "virtualmachineinstances.yaml"), &v1.VirtualMachineInstanceList{Items: items}); err != nil {
+			return err
+		}
+
+		launchers := map[string]*k8sv1.Pod{}
+		pods, err := g.virtClient.CoreV1().Pods(namespace).List(context.Background(), k8smetav1.ListOptions{LabelSelector: v1.AppLabel + "=virt-launcher"})
+		if err != nil {
+			g.recordError("virt-launcher pods in %s: %v", namespace, err)
+		} else {
+			for i := range pods.Items {
+				launchers[pods.Items[i].Labels[v1.CreatedByLabel]] = &pods.Items[i]
+			}
+		}
+
+		for i := range items {
+			if err := g.gatherVMI(&items[i], launchers[string(items[i].UID)]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (g *gatherer) gatherVMI(vmi *v1.VirtualMachineInstance, launcher *k8sv1.Pod) error {
+	dir := path.Join("namespaces", vmi.Namespace, "vmis", vmi.Name)
+
+	if launcher != nil {
+		if err := g.addLog(path.Join(dir, "virt-launcher.log"), launcher, launcherContainer); err != nil {
+			return err
+		}
+	}
+
+	if !vmi.IsFinal() && vmi.Status.NodeName != "" {
+		domainLog, err := g.virtClient.VirtualMachineInstance(vmi.Namespace).DomainLog(vmi.Name, int(logLines))
+		if err != nil {
+			g.recordError("domain log of VMI %s/%s: %v", vmi.Namespace, vmi.Name, err)
+		} else if err := g.add(path.Join(dir, "domain.log"), domainLog); err != nil {
+			return err
+		}
+
+		if launcher != nil {
+			domainXML, err := ExecInContainer(g.clientConfig, launcher, launcherContainer, dumpXMLCommand(vmi))
+			if err == nil {
+				return g.add(path.Join(dir, "domain.xml"), domainXML)
+			}
+			g.recordError("domain XML of VMI %s/%s, collecting a preview instead: %v", vmi.Namespace, vmi.Name, err)
+		}
+	}
+
+	// virt-api renders the domain XML from the spec, it is not the live domain of the node
+	preview := &v1.VirtualMachineInstance{
+		ObjectMeta: k8smetav1.ObjectMeta{
+			Name:        vmi.Name,
+			Namespace:   vmi.Namespace,
+			Labels:      vmi.Labels,
+			Annotations: vmi.Annotations,
+		},
+		Spec: vmi.Spec,
+	}
+	domainXML, err := g.virtClient.VirtualMachineInstance(vmi.Namespace).PreviewDomainXML(preview)
+	if err != nil {
+		g.recordError("domain XML preview of VMI %s/%s: %v", vmi.Namespace, vmi.Name, err)
+		return nil
+	}
+	return g.add(path.Join(dir, "domain-preview.xml"), domainXML)
+}
+
+// dumpXMLCommand returns the virsh command which prints the live domain of the VMI in its compute container
+func dumpXMLCommand(vmi *v1.VirtualMachineInstance) []string {
+	command := []string{"virsh"}
+	if util.IsNonRootVMI(vmi) {
+		command = append(command, "-c", "qemu+unix:///session?socket=/var/run/libvirt/libvirt-sock")
+	}
+	return append(command, "dumpxml", vmi.Namespace+"_"+vmi.Name)
+}
+
+func execInContainer(clientConfig clientcmd.ClientConfig, pod *k8sv1.Pod, container string, command []string) ([]byte, error) {
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(clientConfig)
+	if err != nil {
+		return nil, err
+	}
+	req := virtClient.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(pod.Name).
+		Namespace(pod.Namespace).
+		SubResource("exec").
+		VersionedParams(&k8sv1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+	exec, err := remotecommand.NewSPDYExecutor(config, "POST", req.URL())
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := exec.Stream(remotecommand.StreamOptions{Stdout: &stdout, Stderr: &stderr}); err != nil {
+		return nil, fmt.Errorf("%v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// gatherEvents collects the events of the KubeVirt objects and of the KubeVirt pods
+func (g *gatherer) gatherEvents() error {
+	events, err := g.virtClient.CoreV1().Events(g.namespace).List(context.Background(), k8smetav1.ListOptions{})
+	if err != nil {
+		g.recordError("events: %v", err)
+		return nil
+	}
+	byNamespace := map[string][]k8sv1.Event{}
+	for i := range events.Items {
+		if !isKubeVirtEvent(&events.Items[i]) {
+			continue
+		}
+		byNamespace[events.Items[i].Namespace] = append(byNamespace[events.Items[i].Namespace], events.Items[i])
+	}
+	for namespace, items := range byNamespace {
+		if err := g.addYAML(path.Join("namespaces", namespace, "events.yaml"), &k8sv1.EventList{Items: items}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isKubeVirtEvent(event *k8sv1.Event) bool {
+	involved := event.InvolvedObject
+	if strings.HasPrefix(involved.APIVersion, v1.GroupName+"/") {
+		return true
+	}
+	return involved.Kind == "Pod" && strings.HasPrefix(involved.Name, "virt-")
+}
+
+func (g *gatherer) addLog(name string, pod *k8sv1.Pod, container string) error {
+	options := &k8sv1.PodLogOptions{Container: container}
+	if logLines > 0 {
+		options.TailLines = &logLines
+	}
+	data, err := g.virtClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, options).DoRaw(context.Background())
+	if err != nil {
+		g.recordError("log of container %s of pod %s/%s: %v", container, pod.Namespace, pod.Name, err)
+		return nil
+	}
+	return g.add(name, data)
+}
@@ -0,0 +1,11 @@
+package gatherdebug_test
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestGatherDebug(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
@@ -0,0 +1,203 @@
+package gatherdebug_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/clientcmd"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/gatherdebug"
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("GatherDebug", func() {
+
+	var ctrl *gomock.Controller
+	var kvInterface *kubecli.MockKubeVirtInterface
+	var vmInterface *kubecli.MockVirtualMachineInterface
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+	var migrationInterface *kubecli.MockVirtualMachineInstanceMigrationInterface
+	var kubeClient *fake.Clientset
+	var archive string
+	var execCommands [][]string
+	var execErr error
+
+	newPod := func(namespace, name string, labels map[string]string, container string) *k8sv1.Pod {
+		return &k8sv1.Pod{
+			ObjectMeta: k8smetav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+			Spec:       k8sv1.PodSpec{Containers: []k8sv1.Container{{Name: container}}},
+		}
+	}
+
+	expectVMClients := func(namespace string) {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(namespace).Return(vmInterface).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstanceMigration(namespace).Return(migrationInterface).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(namespace).Return(vmiInterface).AnyTimes()
+		// the VMIs are accessed in their namespace
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).AnyTimes()
+	}
+
+	readArchive := func() map[string]string {
+		file, err := os.Open(archive)
+		Expect(err).ToNot(HaveOccurred())
+		defer file.Close()
+		gzipReader, err := gzip.NewReader(file)
+		Expect(err).ToNot(HaveOccurred())
+
+		files := map[string]string{}
+		tarReader := tar.NewReader(gzipReader)
+		for {
+			header, err := tarReader.Next()
+			if err != nil {
+				break
+			}
+			data, err := ioutil.ReadAll(tarReader)
+			Expect(err).ToNot(HaveOccurred())
+			files[header.Name] = string(data)
+		}
+		return files
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		kvInterface = kubecli.NewMockKubeVirtInterface(ctrl)
+		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+		migrationInterface = kubecli.NewMockVirtualMachineInstanceMigrationInterface(ctrl)
+
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.UID = "vmi-uid"
+		vmi.Status.Phase = v1.Running
+		vmi.Status.NodeName = "node01"
+		vm := kubecli.NewMinimalVM("testvm")
+		vm.Namespace = k8smetav1.NamespaceDefault
+
+		kubeClient = fake.NewSimpleClientset(
+			newPod("kubevirt", "virt-handler-abcde", map[string]string{v1.AppLabel: "virt-handler"}, "virt-handler"),
+			newPod("kubevirt", "unrelated", map[string]string{}, "unrelated"),
+			newPod(k8smetav1.NamespaceDefault, "virt-launcher-testvmi-abcde", map[string]string{v1.AppLabel: "virt-launcher", v1.CreatedByLabel: "vmi-uid"}, "compute"),
+			&k8sv1.Node{ObjectMeta: k8smetav1.ObjectMeta{Name: "node01", Labels: map[string]string{"cpu-model.node.kubevirt.io/Haswell": "true"}}},
+			&k8sv1.Event{
+				ObjectMeta:     k8smetav1.ObjectMeta{Namespace: k8smetav1.NamespaceDefault, Name: "testvmi.1"},
+				InvolvedObject: k8sv1.ObjectReference{APIVersion: "kubevirt.io/v1", Kind: "VirtualMachineInstance", Name: "testvmi"},
+				Reason:         "SyncFailed",
+			},
+			&k8sv1.Event{
+				ObjectMeta:     k8smetav1.ObjectMeta{Namespace: k8smetav1.NamespaceDefault, Name: "unrelated.1"},
+				InvolvedObject: k8sv1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "unrelated"},
+				Reason:         "ScalingReplicaSet",
+			},
+		)
+
+		kubecli.MockKubevirtClientInstance.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+		kubecli.MockKubevirtClientInstance.EXPECT().KubeVirt(k8smetav1.NamespaceAll).Return(kvInterface).AnyTimes()
+
+		kvInterface.EXPECT().List(gomock.Any()).Return(&v1.KubeVirtList{Items: []v1.KubeVirt{{ObjectMeta: k8smetav1.ObjectMeta{Namespace: "kubevirt", Name: "kubevirt"}}}}, nil).AnyTimes()
+		vmInterface.EXPECT().List(gomock.Any()).Return(&v1.VirtualMachineList{Items: []v1.VirtualMachine{*vm}}, nil).AnyTimes()
+		migrationInterface.EXPECT().List(gomock.Any()).Return(&v1.VirtualMachineInstanceMigrationList{}, nil).AnyTimes()
+		vmiInterface.EXPECT().List(gomock.Any()).Return(&v1.VirtualMachineInstanceList{Items: []v1.VirtualMachineInstance{*vmi}}, nil).AnyTimes()
+
+		execCommands = nil
+		execErr = nil
+		gatherdebug.ExecInContainer = func(_ clientcmd.ClientConfig, pod *k8sv1.Pod, container string, command []string) ([]byte, error) {
+			Expect(pod.Name).To(Equal("virt-launcher-testvmi-abcde"))
+			Expect(container).To(Equal("compute"))
+			execCommands = append(execCommands, command)
+			if execErr != nil {
+				return nil, execErr
+			}
+			return []byte("<domain type='kvm'></domain>"), nil
+		}
+
+		tmpDir, err := ioutil.TempDir("", "gatherdebug")
+		Expect(err).ToNot(HaveOccurred())
+		archive = filepath.Join(tmpDir, "debug.tar.gz")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(filepath.Dir(archive))
+	})
+
+	It("should collect the debug information of the namespace", func() {
+		expectVMClients(k8smetav1.NamespaceDefault)
+		vmiInterface.EXPECT().DomainLog("testvmi", 0).Return([]byte("qemu-kvm: terminating on signal 15\n"), nil)
+
+		cmd := tests.NewRepeatableVirtctlCommand(gatherdebug.COMMAND_GATHER_DEBUG, "--output", archive)
+		Expect(cmd()).To(Succeed())
+
+		files := readArchive()
+		Expect(files).To(HaveKey("kubevirt-debug/cluster/kubevirt.yaml"))
+		Expect(files).To(HaveKeyWithValue("kubevirt-debug/cluster/logs/kubevirt/virt-handler-abcde/virt-handler.log", "fake logs"))
+		Expect(files).ToNot(HaveKey("kubevirt-debug/cluster/logs/kubevirt/unrelated/unrelated.log"))
+		Expect(files["kubevirt-debug/cluster/nodes.yaml"]).To(ContainSubstring("cpu-model.node.kubevirt.io/Haswell"))
+		Expect(files["kubevirt-debug/namespaces/default/virtualmachines.yaml"]).To(ContainSubstring("name: testvm"))
+		Expect(files["kubevirt-debug/namespaces/default/virtualmachineinstances.yaml"]).To(ContainSubstring("name: testvmi"))
+		Expect(files).To(HaveKeyWithValue("kubevirt-debug/namespaces/default/vmis/testvmi/virt-launcher.log", "fake logs"))
+		Expect(files).To(HaveKeyWithValue("kubevirt-debug/namespaces/default/vmis/testvmi/domain.log", "qemu-kvm: terminating on signal 15\n"))
+		Expect(files).To(HaveKeyWithValue("kubevirt-debug/namespaces/default/vmis/testvmi/domain.xml", "<domain type='kvm'></domain>"))
+		Expect(files).ToNot(HaveKey("kubevirt-debug/namespaces/default/vmis/testvmi/domain-preview.xml"))
+		Expect(execCommands).To(Equal([][]string{{"virsh", "dumpxml", "default_testvmi"}}))
+		Expect(files["kubevirt-debug/namespaces/default/events.yaml"]).To(ContainSubstring("SyncFailed"))
+		Expect(files["kubevirt-debug/namespaces/default/events.yaml"]).ToNot(ContainSubstring("ScalingReplicaSet"))
+		Expect(files).ToNot(HaveKey("kubevirt-debug/errors.txt"))
+	})
+
+	It("should collect a preview of the domain XML if the live domain can not be dumped", func() {
+		expectVMClients(k8smetav1.NamespaceDefault)
+		execErr = fmt.Errorf("pods/exec is forbidden")
+		vmiInterface.EXPECT().DomainLog("testvmi", 0).Return([]byte{}, nil)
+		vmiInterface.EXPECT().PreviewDomainXML(gomock.Any()).DoAndReturn(func(vmi *v1.VirtualMachineInstance) ([]byte, error) {
+			Expect(vmi.Name).To(Equal("testvmi"))
+			Expect(vmi.Status).To(Equal(v1.VirtualMachineInstanceStatus{}))
+			return []byte("<domain></domain>"), nil
+		})
+
+		cmd := tests.NewRepeatableVirtctlCommand(gatherdebug.COMMAND_GATHER_DEBUG, "--output", archive)
+		Expect(cmd()).To(Succeed())
+
+		files := readArchive()
+		Expect(files).ToNot(HaveKey("kubevirt-debug/namespaces/default/vmis/testvmi/domain.xml"))
+		Expect(files).To(HaveKeyWithValue("kubevirt-debug/namespaces/default/vmis/testvmi/domain-preview.xml", "<domain></domain>"))
+		Expect(files["kubevirt-debug/errors.txt"]).To(ContainSubstring("domain XML of VMI default/testvmi, collecting a preview instead: pods/exec is forbidden"))
+	})
+
+	It("should collect the VMs of all namespaces", func() {
+		expectVMClients(k8smetav1.NamespaceAll)
+		vmiInterface.EXPECT().DomainLog("testvmi", 0).Return([]byte{}, nil)
+
+		cmd := tests.NewRepeatableVirtctlCommand(gatherdebug.COMMAND_GATHER_DEBUG, "--all-namespaces", "--output", archive)
+		Expect(cmd()).To(Succeed())
+
+		Expect(readArchive()).To(HaveKey("kubevirt-debug/namespaces/default/virtualmachineinstances.yaml"))
+	})
+
+	It("should record what could not be collected", func() {
+		expectVMClients(k8smetav1.NamespaceDefault)
+		vmiInterface.EXPECT().DomainLog("testvmi", 0).Return(nil, fmt.Errorf("virt-handler is not reachable"))
+		execErr = fmt.Errorf("virsh is not reachable")
+		vmiInterface.EXPECT().PreviewDomainXML(gomock.Any()).Return(nil, fmt.Errorf("forbidden"))
+
+		cmd := tests.NewRepeatableVirtctlCommand(gatherdebug.COMMAND_GATHER_DEBUG, "--output", archive)
+		Expect(cmd()).To(Succeed())
+
+		files := readArchive()
+		Expect(files).ToNot(HaveKey("kubevirt-debug/namespaces/default/vmis/testvmi/domain.log"))
+		Expect(files["kubevirt-debug/errors.txt"]).To(ContainSubstring("domain log of VMI default/testvmi: virt-handler is not reachable"))
+		Expect(files["kubevirt-debug/errors.txt"]).To(ContainSubstring("domain XML preview of VMI default/testvmi: forbidden"))
+	})
+})
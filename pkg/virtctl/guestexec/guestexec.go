@@ -0,0 +1,104 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package guestexec
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	COMMAND_GUESTEXEC = "guestexec"
+
+	timeoutArg = "timeout"
+
+	// maxRequestTimeoutSeconds is the longest timeout virt-api accepts for the guestexec request,
+	// longer running commands are run over a websocket
+	maxRequestTimeoutSeconds = 50
+)
+
+var timeoutSeconds int32
+
+func NewGuestExecCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "guestexec (VMI) -- (COMMAND) [ARGS...]",
+		Short: "Run a command in the guest of a running VMI through the guest agent.",
+		Long: `Runs a command in the guest of a running virtual machine instance through the guest agent and prints its output.
+Only the commands of the guestExecAllowList in the KubeVirt configuration can be run.`,
+		Args:    cobra.MinimumNArgs(2),
+		Example: usage(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := command{clientConfig: clientConfig}
+			return c.run(cmd, args[0], args[1], args[2:])
+		},
+	}
+	cmd.Flags().Int32Var(&timeoutSeconds, timeoutArg, 0, "seconds the command may run, defaults to 10, at most 600.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	usage := `  # Print the uptime of the virtual machine instance 'myvmi':
+  {{ProgramName}} guestexec myvmi -- /usr/bin/uptime -p`
+	return usage
+}
+
+type command struct {
+	clientConfig clientcmd.ClientConfig
+}
+
+func (c *command) run(cmd *cobra.Command, vmiName string, guestCommand string, guestArgs []string) error {
+	namespace, _, err := c.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(c.clientConfig)
+	if err != nil {
+		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
+	}
+
+	opts := &v1.GuestExecOptions{
+		Command: guestCommand,
+		Args:    guestArgs,
+	}
+	if timeoutSeconds > 0 {
+		opts.TimeoutSeconds = &timeoutSeconds
+	}
+	var result *v1.GuestExecResult
+	if timeoutSeconds > maxRequestTimeoutSeconds {
+		result, err = virtClient.VirtualMachineInstance(namespace).GuestExecStream(vmiName, opts)
+	} else {
+		result, err = virtClient.VirtualMachineInstance(namespace).GuestExec(vmiName, opts)
+	}
+	if err != nil {
+		return fmt.Errorf("Error running %s in VirtualMachineInstance %s: %v", guestCommand, vmiName, err)
+	}
+	fmt.Fprint(cmd.OutOrStdout(), result.StdOut)
+	if result.ExitCode != 0 {
+		return fmt.Errorf("%s exited with %d", guestCommand, result.ExitCode)
+	}
+	return nil
+}
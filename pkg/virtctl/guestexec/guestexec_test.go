@@ -0,0 +1,81 @@
+package guestexec_test
+
+import (
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/guestexec"
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("GuestExec", func() {
+
+	const vmiName = "testvmi"
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+	var ctrl *gomock.Controller
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+	})
+
+	It("should fail without a command", func() {
+		cmd := tests.NewRepeatableVirtctlCommand(guestexec.COMMAND_GUESTEXEC, vmiName)
+		Expect(cmd()).ToNot(Succeed())
+	})
+
+	It("should run the command with its arguments", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+		vmiInterface.EXPECT().GuestExec(vmiName, &v1.GuestExecOptions{
+			Command:        "/usr/bin/uptime",
+			Args:           []string{"-p"},
+			TimeoutSeconds: pointer.Int32Ptr(20),
+		}).Return(&v1.GuestExecResult{StdOut: "up 1 minute\n"}, nil).Times(1)
+
+		cmd := tests.NewRepeatableVirtctlCommand(guestexec.COMMAND_GUESTEXEC, vmiName, "--timeout=20", "--", "/usr/bin/uptime", "-p")
+		Expect(cmd()).To(Succeed())
+	})
+
+	It("should run long running commands over a websocket", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+		vmiInterface.EXPECT().GuestExecStream(vmiName, &v1.GuestExecOptions{
+			Command:        "/usr/bin/fstrim",
+			Args:           []string{"-a"},
+			TimeoutSeconds: pointer.Int32Ptr(300),
+		}).Return(&v1.GuestExecResult{}, nil).Times(1)
+
+		cmd := tests.NewRepeatableVirtctlCommand(guestexec.COMMAND_GUESTEXEC, vmiName, "--timeout=300", "--", "/usr/bin/fstrim", "-a")
+		Expect(cmd()).To(Succeed())
+	})
+
+	It("should fail if the command exits with a non zero code", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+		vmiInterface.EXPECT().GuestExec(vmiName, &v1.GuestExecOptions{Command: "/usr/bin/false", Args: []string{}}).
+			Return(&v1.GuestExecResult{ExitCode: 1}, nil).Times(1)
+
+		cmd := tests.NewRepeatableVirtctlCommand(guestexec.COMMAND_GUESTEXEC, vmiName, "--", "/usr/bin/false")
+		err := cmd()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("exited with 1"))
+	})
+
+	It("should report a failed request", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+		vmiInterface.EXPECT().GuestExec(vmiName, gomock.Any()).Return(nil, fmt.Errorf("not allowed")).Times(1)
+
+		cmd := tests.NewRepeatableVirtctlCommand(guestexec.COMMAND_GUESTEXEC, vmiName, "--", "/bin/sh")
+		err := cmd()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("not allowed"))
+	})
+})
@@ -60,9 +60,9 @@ type guestfsCommand struct {
 // NewGuestfsShellCommand returns a cobra.Command for starting libguestfs-tool pod and attach it to a pvc
 func NewGuestfsShellCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "guestfs",
+		Use:     "guestfs (PVC)",
 		Short:   "Start a shell into the libguestfs pod",
-		Long:    `Create a pod with libguestfs-tools, mount the pvc and attach a shell to it. The pvc is mounted under the /disks directory inside the pod for filesystem-based pvcs, or as /dev/vda for block-based pvcs`,
+		Long:    `Create a pod with libguestfs-tools, mount the pvc and attach a shell to it. The pvc is mounted under the /disk directory inside the pod for filesystem-based pvcs, or as /dev/vda for block-based pvcs`,
 		Args:    cobra.ExactArgs(1),
 		Example: usage(),
 		RunE: func(cmd *cobra.Command, args []string) error {
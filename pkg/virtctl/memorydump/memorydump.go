@@ -0,0 +1,134 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package memorydump
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	COMMAND_MEMORYDUMP = "memory-dump"
+	COMMAND_GET        = "get"
+	COMMAND_REMOVE     = "remove"
+
+	claimNameArg = "claim-name"
+)
+
+var claimName string
+
+func NewMemoryDumpCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "memory-dump get|remove (VM)",
+		Short: "Dump the memory of a running VM to a PVC, or remove the PVC from the VM.",
+		Long: `Dumps the memory of a running virtual machine to a PVC, the PVC stays associated with the virtual machine until it gets removed.
+The memory dump can be used for crash analysis of the guest.`,
+		Example: usage(),
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Fprint(cmd.OutOrStderr(), cmd.UsageString())
+		},
+	}
+
+	getCmd := &cobra.Command{
+		Use:     "get (VM)",
+		Short:   "Dump the memory of a running VM to a PVC.",
+		Args:    templates.ExactArgs(COMMAND_GET, 1),
+		Example: usage(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := command{clientConfig: clientConfig}
+			return c.get(args[0])
+		},
+	}
+	getCmd.Flags().StringVar(&claimName, claimNameArg, "", "name of the PVC the memory dump is written to.")
+	getCmd.MarkFlagRequired(claimNameArg)
+	getCmd.SetUsageTemplate(templates.UsageTemplate())
+
+	removeCmd := &cobra.Command{
+		Use:     "remove (VM)",
+		Short:   "Remove the memory dump PVC from a VM.",
+		Args:    templates.ExactArgs(COMMAND_REMOVE, 1),
+		Example: usage(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := command{clientConfig: clientConfig}
+			return c.remove(args[0])
+		},
+	}
+	removeCmd.SetUsageTemplate(templates.UsageTemplate())
+
+	cmd.AddCommand(getCmd, removeCmd)
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	usage := `  # Dump the memory of a running virtual machine called 'myvm' to the PVC 'mypvc':
+  {{ProgramName}} memory-dump get myvm --claim-name=mypvc
+
+  # Remove the memory dump PVC from the virtual machine called 'myvm':
+  {{ProgramName}} memory-dump remove myvm`
+	return usage
+}
+
+type command struct {
+	clientConfig clientcmd.ClientConfig
+}
+
+func (c *command) virtClient() (kubecli.KubevirtClient, string, error) {
+	namespace, _, err := c.clientConfig.Namespace()
+	if err != nil {
+		return nil, "", err
+	}
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(c.clientConfig)
+	if err != nil {
+		return nil, "", fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
+	}
+	return virtClient, namespace, nil
+}
+
+func (c *command) get(vmName string) error {
+	virtClient, namespace, err := c.virtClient()
+	if err != nil {
+		return err
+	}
+	err = virtClient.VirtualMachine(namespace).MemoryDump(vmName, &v1.VirtualMachineMemoryDumpRequest{ClaimName: claimName})
+	if err != nil {
+		return fmt.Errorf("Error dumping the memory of VirtualMachine %s to PVC %s: %v", vmName, claimName, err)
+	}
+	fmt.Printf("Successfully submitted memory dump request of VM %s to PVC %s\n", vmName, claimName)
+	return nil
+}
+
+func (c *command) remove(vmName string) error {
+	virtClient, namespace, err := c.virtClient()
+	if err != nil {
+		return err
+	}
+	if err := virtClient.VirtualMachine(namespace).RemoveMemoryDump(vmName); err != nil {
+		return fmt.Errorf("Error removing the memory dump PVC from VirtualMachine %s: %v", vmName, err)
+	}
+	fmt.Printf("Successfully submitted remove memory dump request of VM %s\n", vmName)
+	return nil
+}
@@ -0,0 +1,11 @@
+package memorydump_test
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestMemoryDump(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
@@ -0,0 +1,66 @@
+package memorydump_test
+
+import (
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/memorydump"
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("MemoryDump", func() {
+
+	const vmName = "testvm"
+	var vmInterface *kubecli.MockVirtualMachineInterface
+	var ctrl *gomock.Controller
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+	})
+
+	It("should fail without the VM name", func() {
+		cmd := tests.NewRepeatableVirtctlCommand(memorydump.COMMAND_MEMORYDUMP, memorydump.COMMAND_GET, "--claim-name=dumppvc")
+		Expect(cmd()).ToNot(Succeed())
+	})
+
+	It("should fail without the claim name", func() {
+		cmd := tests.NewRepeatableVirtctlCommand(memorydump.COMMAND_MEMORYDUMP, memorydump.COMMAND_GET, vmName)
+		Expect(cmd()).ToNot(Succeed())
+	})
+
+	It("should request a memory dump to the PVC", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+		vmInterface.EXPECT().MemoryDump(vmName, &v1.VirtualMachineMemoryDumpRequest{ClaimName: "dumppvc"}).Return(nil).Times(1)
+
+		cmd := tests.NewRepeatableVirtctlCommand(memorydump.COMMAND_MEMORYDUMP, memorydump.COMMAND_GET, vmName, "--claim-name=dumppvc")
+		Expect(cmd()).To(Succeed())
+	})
+
+	It("should report a failed memory dump request", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+		vmInterface.EXPECT().MemoryDump(vmName, gomock.Any()).Return(fmt.Errorf("memory dump is in progress")).Times(1)
+
+		cmd := tests.NewRepeatableVirtctlCommand(memorydump.COMMAND_MEMORYDUMP, memorydump.COMMAND_GET, vmName, "--claim-name=dumppvc")
+		err := cmd()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("memory dump is in progress"))
+	})
+
+	It("should remove the memory dump PVC", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+		vmInterface.EXPECT().RemoveMemoryDump(vmName).Return(nil).Times(1)
+
+		cmd := tests.NewRepeatableVirtctlCommand(memorydump.COMMAND_MEMORYDUMP, memorydump.COMMAND_REMOVE, vmName)
+		Expect(cmd()).To(Succeed())
+	})
+})
@@ -44,6 +44,8 @@ const (
 	ARG_VMI_LONG    = "virtualmachineinstance"
 )
 
+var dryRun bool
+
 func NewPauseCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "pause vm|vmi (VM)|(VMI)",
@@ -61,6 +63,7 @@ Second argument is the name of the resource.`,
 			return c.Run(args)
 		},
 	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "--dry-run=false: If true, only validate that the virtual machine can be paused, without pausing it.")
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
@@ -82,13 +85,16 @@ Second argument is the name of the resource.`,
 			return c.Run(args)
 		},
 	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "--dry-run=false: If true, only validate that the virtual machine can be unpaused, without unpausing it.")
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
 
 func usage(cmd string) string {
 	usage := fmt.Sprintf("  # %s a virtualmachine called 'myvm':\n", strings.Title(cmd))
-	usage += fmt.Sprintf("  {{ProgramName}} %s vm myvm", cmd)
+	usage += fmt.Sprintf("  {{ProgramName}} %s vm myvm\n\n", cmd)
+	usage += fmt.Sprintf("  # Check whether a virtualmachine called 'myvm' can be %sd:\n", cmd)
+	usage += fmt.Sprintf("  {{ProgramName}} %s vm myvm --dry-run", cmd)
 	return usage
 }
 
@@ -110,6 +116,11 @@ func (vc *VirtCommand) Run(args []string) error {
 		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
 	}
 
+	var dryRunOption []string
+	if dryRun {
+		dryRunOption = []string{v1.DryRunAll}
+	}
+
 	switch vc.command {
 	case COMMAND_PAUSE:
 		switch resourceType {
@@ -119,7 +130,7 @@ func (vc *VirtCommand) Run(args []string) error {
 				return fmt.Errorf("Error getting VirtualMachine %s: %v", resourceName, err)
 			}
 			vmiName := vm.Name
-			err = virtClient.VirtualMachineInstance(namespace).Pause(vmiName)
+			err = virtClient.VirtualMachineInstance(namespace).Pause(vmiName, &kubevirtV1.PauseOptions{DryRun: dryRunOption})
 			if err != nil {
 				if errors.IsNotFound(err) {
 					runningStrategy, err := vm.RunStrategy()
@@ -134,13 +145,13 @@ func (vc *VirtCommand) Run(args []string) error {
 				}
 				return fmt.Errorf("Error pausing VirutalMachineInstance %s: %v", vmiName, err)
 			}
-			fmt.Printf("VMI %s was scheduled to %s\n", vmiName, vc.command)
+			vc.printResult(vmiName)
 		case ARG_VMI_LONG, ARG_VMI_SHORT:
-			err = virtClient.VirtualMachineInstance(namespace).Pause(resourceName)
+			err = virtClient.VirtualMachineInstance(namespace).Pause(resourceName, &kubevirtV1.PauseOptions{DryRun: dryRunOption})
 			if err != nil {
 				return fmt.Errorf("Error pausing VirtualMachineInstance %s: %v", resourceName, err)
 			}
-			fmt.Printf("VMI %s was scheduled to %s\n", resourceName, vc.command)
+			vc.printResult(resourceName)
 		}
 	case COMMAND_UNPAUSE:
 		switch resourceType {
@@ -150,18 +161,26 @@ func (vc *VirtCommand) Run(args []string) error {
 				return fmt.Errorf("Error getting VirtualMachine %s: %v", resourceName, err)
 			}
 			vmiName := vm.Name
-			err = virtClient.VirtualMachineInstance(namespace).Unpause(vmiName)
+			err = virtClient.VirtualMachineInstance(namespace).Unpause(vmiName, &kubevirtV1.UnpauseOptions{DryRun: dryRunOption})
 			if err != nil {
 				return fmt.Errorf("Error unpausing VirtualMachineInstance %s: %v", vmiName, err)
 			}
-			fmt.Printf("VMI %s was scheduled to %s\n", vmiName, vc.command)
+			vc.printResult(vmiName)
 		case ARG_VMI_LONG, ARG_VMI_SHORT:
-			err = virtClient.VirtualMachineInstance(namespace).Unpause(resourceName)
+			err = virtClient.VirtualMachineInstance(namespace).Unpause(resourceName, &kubevirtV1.UnpauseOptions{DryRun: dryRunOption})
 			if err != nil {
 				return fmt.Errorf("Error unpausing VirtualMachineInstance %s: %v", resourceName, err)
 			}
-			fmt.Printf("VMI %s was scheduled to %s\n", resourceName, vc.command)
+			vc.printResult(resourceName)
 		}
 	}
 	return nil
 }
+
+func (vc *VirtCommand) printResult(vmiName string) {
+	if dryRun {
+		fmt.Printf("VMI %s can be %sd (dry run)\n", vmiName, vc.command)
+		return
+	}
+	fmt.Printf("VMI %s was scheduled to %s\n", vmiName, vc.command)
+}
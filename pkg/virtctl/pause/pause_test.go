@@ -40,7 +40,7 @@ var _ = Describe("Pausing", func() {
 		vmi := v1.NewMinimalVMI(vmName)
 
 		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
-		vmiInterface.EXPECT().Pause(vmi.Name).Return(nil).Times(1)
+		vmiInterface.EXPECT().Pause(vmi.Name, &v1.PauseOptions{}).Return(nil).Times(1)
 
 		cmd := tests.NewVirtctlCommand(pause.COMMAND_PAUSE, "vmi", vmName)
 		Expect(cmd.Execute()).To(BeNil())
@@ -50,7 +50,7 @@ var _ = Describe("Pausing", func() {
 		vmi := v1.NewMinimalVMI(vmName)
 
 		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
-		vmiInterface.EXPECT().Unpause(vmi.Name).Return(nil).Times(1)
+		vmiInterface.EXPECT().Unpause(vmi.Name, &v1.UnpauseOptions{}).Return(nil).Times(1)
 
 		cmd := tests.NewVirtctlCommand(pause.COMMAND_UNPAUSE, "vmi", vmName)
 		Expect(cmd.Execute()).To(BeNil())
@@ -67,7 +67,7 @@ var _ = Describe("Pausing", func() {
 		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
 
 		vmInterface.EXPECT().Get(vm.Name, &k8smetav1.GetOptions{}).Return(vm, nil).Times(1)
-		vmiInterface.EXPECT().Pause(vm.Name).Return(nil).Times(1)
+		vmiInterface.EXPECT().Pause(vm.Name, &v1.PauseOptions{}).Return(nil).Times(1)
 
 		cmd := tests.NewVirtctlCommand(pause.COMMAND_PAUSE, "vm", vmName)
 		Expect(cmd.Execute()).To(BeNil())
@@ -84,12 +84,33 @@ var _ = Describe("Pausing", func() {
 		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
 
 		vmInterface.EXPECT().Get(vm.Name, &k8smetav1.GetOptions{}).Return(vm, nil).Times(1)
-		vmiInterface.EXPECT().Unpause(vm.Name).Return(nil).Times(1)
+		vmiInterface.EXPECT().Unpause(vm.Name, &v1.UnpauseOptions{}).Return(nil).Times(1)
 
 		cmd := tests.NewVirtctlCommand(pause.COMMAND_UNPAUSE, "vm", vmName)
 		Expect(cmd.Execute()).To(BeNil())
 	})
 
+	It("should pass the dry run option when pausing a VM", func() {
+		vm := kubecli.NewMinimalVM(vmName)
+
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+
+		vmInterface.EXPECT().Get(vm.Name, &k8smetav1.GetOptions{}).Return(vm, nil).Times(1)
+		vmiInterface.EXPECT().Pause(vm.Name, &v1.PauseOptions{DryRun: []string{k8smetav1.DryRunAll}}).Return(nil).Times(1)
+
+		cmd := tests.NewVirtctlCommand(pause.COMMAND_PAUSE, "vm", vmName, "--dry-run")
+		Expect(cmd.Execute()).To(BeNil())
+	})
+
+	It("should pass the dry run option when unpausing a VMI", func() {
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8smetav1.NamespaceDefault).Return(vmiInterface).Times(1)
+		vmiInterface.EXPECT().Unpause(vmName, &v1.UnpauseOptions{DryRun: []string{k8smetav1.DryRunAll}}).Return(nil).Times(1)
+
+		cmd := tests.NewVirtctlCommand(pause.COMMAND_UNPAUSE, "vmi", vmName, "--dry-run")
+		Expect(cmd.Execute()).To(BeNil())
+	})
+
 	AfterEach(func() {
 		ctrl.Finish()
 	})
@@ -38,17 +38,22 @@ import (
 )
 
 const (
-	COMMAND_START        = "start"
-	COMMAND_STOP         = "stop"
-	COMMAND_RESTART      = "restart"
-	COMMAND_MIGRATE      = "migrate"
-	COMMAND_GUESTOSINFO  = "guestosinfo"
-	COMMAND_USERLIST     = "userlist"
-	COMMAND_FSLIST       = "fslist"
-	COMMAND_ADDVOLUME    = "addvolume"
-	COMMAND_REMOVEVOLUME = "removevolume"
+	COMMAND_START          = "start"
+	COMMAND_STOP           = "stop"
+	COMMAND_RESTART        = "restart"
+	COMMAND_MIGRATE        = "migrate"
+	COMMAND_MIGRATE_CANCEL = "migrate-cancel"
+	COMMAND_RENAME         = "rename"
+	COMMAND_MOVE           = "move"
+	COMMAND_GUESTOSINFO    = "guestosinfo"
+	COMMAND_USERLIST       = "userlist"
+	COMMAND_FSLIST         = "fslist"
+	COMMAND_ADDVOLUME      = "addvolume"
+	COMMAND_REMOVEVOLUME   = "removevolume"
+	COMMAND_MEDIACHANGE    = "mediachange"
 
 	volumeNameArg         = "volume-name"
+	diskNameArg           = "disk"
 	notDefinedGracePeriod = -1
 )
 
@@ -59,6 +64,8 @@ var (
 	serial       string
 	persist      bool
 	startPaused  bool
+	diskName     string
+	eject        bool
 )
 
 func NewStartCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
@@ -127,6 +134,51 @@ func NewMigrateCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	return cmd
 }
 
+func NewMigrateCancelCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "migrate-cancel (VM)",
+		Short:   "Cancel the migration of a virtual machine.",
+		Example: usageMigrateCancel(),
+		Args:    templates.ExactArgs("migrate-cancel", 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{command: COMMAND_MIGRATE_CANCEL, clientConfig: clientConfig}
+			return c.Run(args)
+		},
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func NewRenameCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rename (VM) (NEW_NAME)",
+		Short:   "Rename a stopped virtual machine.",
+		Example: usageRename(),
+		Args:    templates.ExactArgs("rename", 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{command: COMMAND_RENAME, clientConfig: clientConfig}
+			return c.Run(args)
+		},
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func NewMoveCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "move (VM) (NAMESPACE)",
+		Short:   "Move a stopped virtual machine and its volumes to another namespace.",
+		Example: usageMove(),
+		Args:    templates.ExactArgs("move", 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{command: COMMAND_MOVE, clientConfig: clientConfig}
+			return c.Run(args)
+		},
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
 func NewGuestOsInfoCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "guestosinfo (VMI)",
@@ -210,6 +262,25 @@ func NewRemoveVolumeCommand(clientConfig clientcmd.ClientConfig) *cobra.Command
 	return cmd
 }
 
+func NewMediaChangeCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "mediachange VMI",
+		Short:   "eject or insert the media of a CD-ROM of a running VM",
+		Example: usageMediaChange(),
+		Args:    templates.ExactArgs("mediachange", 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{command: COMMAND_MEDIACHANGE, clientConfig: clientConfig}
+			return c.Run(args)
+		},
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	cmd.Flags().StringVar(&diskName, diskNameArg, "", "name of the CD-ROM in the disks section of spec")
+	cmd.MarkFlagRequired(diskNameArg)
+	cmd.Flags().StringVar(&volumeName, volumeNameArg, "", "name of the DataVolume or PersistentVolumeClaim to insert")
+	cmd.Flags().BoolVar(&eject, "eject", false, "if set, the media of the CD-ROM is ejected")
+	return cmd
+}
+
 func getVolumeSourceFromVolume(volumeName, namespace string, virtClient kubecli.KubevirtClient) (*v1.HotplugVolumeSource, error) {
 	//Check if data volume exists.
 	_, err := virtClient.CdiClient().CdiV1beta1().DataVolumes(namespace).Get(context.TODO(), volumeName, metav1.GetOptions{})
@@ -254,6 +325,24 @@ func usage(cmd string) string {
 	return usage
 }
 
+func usageMigrateCancel() string {
+	usage := `  # Cancel the migration of a virtual machine called 'myvm':
+  {{ProgramName}} migrate-cancel myvm`
+	return usage
+}
+
+func usageRename() string {
+	usage := `  # Rename a stopped virtual machine called 'myvm' to 'newvm':
+  {{ProgramName}} rename myvm newvm`
+	return usage
+}
+
+func usageMove() string {
+	usage := `  # Move a stopped virtual machine called 'myvm' and its volumes to the namespace 'tenant-b':
+  {{ProgramName}} move myvm tenant-b`
+	return usage
+}
+
 func usageAddVolume() string {
 	usage := `  #Dynamically attach a volume to a running VM.
   {{ProgramName}} addvolume fedora-dv --volume-name=example-dv
@@ -277,6 +366,36 @@ func usageRemoveVolume() string {
 	return usage
 }
 
+func usageMediaChange() string {
+	usage := `  #Eject the media of a CD-ROM of a running VM.
+  {{ProgramName}} mediachange fedora-vm --disk=cdrom --eject
+
+  #Insert a volume into a CD-ROM of a running VM.
+  {{ProgramName}} mediachange fedora-vm --disk=cdrom --volume-name=installer-iso
+  `
+	return usage
+}
+
+func mediaChange(vmiName, diskName, volumeName, namespace string, virtClient kubecli.KubevirtClient) error {
+	if eject == (volumeName != "") {
+		return fmt.Errorf("either --eject or --%s must be set", volumeNameArg)
+	}
+	mediaChangeOptions := &v1.MediaChangeOptions{
+		Name: diskName,
+	}
+	if !eject {
+		volumeSource, err := getVolumeSourceFromVolume(volumeName, namespace, virtClient)
+		if err != nil {
+			return fmt.Errorf("error changing media, %v", err)
+		}
+		mediaChangeOptions.VolumeSource = volumeSource
+	}
+	if err := virtClient.VirtualMachineInstance(namespace).MediaChange(vmiName, mediaChangeOptions); err != nil {
+		return fmt.Errorf("error changing media, %v", err)
+	}
+	return nil
+}
+
 func addVolume(vmiName, volumeName, namespace string, virtClient kubecli.KubevirtClient) error {
 	volumeSource, err := getVolumeSourceFromVolume(volumeName, namespace, virtClient)
 	if err != nil {
@@ -393,6 +512,21 @@ func (o *Command) Run(args []string) error {
 		if err != nil {
 			return fmt.Errorf("Error migrating VirtualMachine %v", err)
 		}
+	case COMMAND_MIGRATE_CANCEL:
+		err = virtClient.VirtualMachine(namespace).MigrateCancel(vmiName)
+		if err != nil {
+			return fmt.Errorf("Error canceling the migration of VirtualMachine %v", err)
+		}
+	case COMMAND_RENAME:
+		err = virtClient.VirtualMachine(namespace).Rename(vmiName, &v1.RenameOptions{NewName: args[1]})
+		if err != nil {
+			return fmt.Errorf("Error renaming VirtualMachine %v", err)
+		}
+	case COMMAND_MOVE:
+		err = virtClient.VirtualMachine(namespace).Move(vmiName, &v1.MoveOptions{NewNamespace: args[1]})
+		if err != nil {
+			return fmt.Errorf("Error moving VirtualMachine %v", err)
+		}
 	case COMMAND_GUESTOSINFO:
 		guestosinfo, err := virtClient.VirtualMachineInstance(namespace).GuestOsInfo(vmiName)
 		if err != nil {
@@ -436,6 +570,8 @@ func (o *Command) Run(args []string) error {
 		return addVolume(args[0], volumeName, namespace, virtClient)
 	case COMMAND_REMOVEVOLUME:
 		return removeVolume(args[0], volumeName, namespace, virtClient)
+	case COMMAND_MEDIACHANGE:
+		return mediaChange(args[0], diskName, volumeName, namespace, virtClient)
 	}
 
 	fmt.Printf("VM %s was scheduled to %s\n", vmiName, o.command)
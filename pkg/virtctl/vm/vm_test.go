@@ -62,6 +62,18 @@ var _ = Describe("VirtualMachine", func() {
 			cmd := tests.NewRepeatableVirtctlCommand("migrate")
 			Expect(cmd()).NotTo(BeNil())
 		})
+		It("should fail a migrate-cancel", func() {
+			cmd := tests.NewRepeatableVirtctlCommand("migrate-cancel")
+			Expect(cmd()).NotTo(BeNil())
+		})
+		It("should fail a rename without a new name", func() {
+			cmd := tests.NewRepeatableVirtctlCommand("rename", vmName)
+			Expect(cmd()).NotTo(BeNil())
+		})
+		It("should fail a move without a namespace", func() {
+			cmd := tests.NewRepeatableVirtctlCommand("move", vmName)
+			Expect(cmd()).NotTo(BeNil())
+		})
 	})
 
 	Context("should patch VM", func() {
@@ -167,6 +179,36 @@ var _ = Describe("VirtualMachine", func() {
 		})
 	})
 
+	Context("with migrate-cancel VM cmd", func() {
+		It("should cancel the migration of the vm", func() {
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+			vmInterface.EXPECT().MigrateCancel(vmName).Return(nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand("migrate-cancel", vmName)
+			Expect(cmd.Execute()).To(BeNil())
+		})
+	})
+
+	Context("with rename VM cmd", func() {
+		It("should rename vm", func() {
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+			vmInterface.EXPECT().Rename(vmName, &v1.RenameOptions{NewName: "newvm"}).Return(nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand("rename", vmName, "newvm")
+			Expect(cmd.Execute()).To(BeNil())
+		})
+	})
+
+	Context("with move VM cmd", func() {
+		It("should move vm", func() {
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+			vmInterface.EXPECT().Move(vmName, &v1.MoveOptions{NewNamespace: "tenant-b"}).Return(nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand("move", vmName, "tenant-b")
+			Expect(cmd.Execute()).To(BeNil())
+		})
+	})
+
 	Context("with restart VM cmd", func() {
 		It("should restart vm", func() {
 			vm := kubecli.NewMinimalVM(vmName)
@@ -439,6 +481,54 @@ var _ = Describe("VirtualMachine", func() {
 		})
 	})
 
+	Context("media change", func() {
+		table.DescribeTable("should fail with missing required or invalid parameters", func(errorString string, args ...string) {
+			cmd := tests.NewRepeatableVirtctlCommand(append([]string{"mediachange"}, args...)...)
+			res := cmd()
+			Expect(res).NotTo(BeNil())
+			Expect(res.Error()).To(ContainSubstring(errorString))
+		},
+			table.Entry("no args", "argument validation failed"),
+			table.Entry("missing required disk", "required flag(s)", "testvmi", "--eject"),
+			table.Entry("neither eject nor volume-name", "either --eject or --volume-name must be set", "testvmi", "--disk=cdrom"),
+			table.Entry("both eject and volume-name", "either --eject or --volume-name must be set", "testvmi", "--disk=cdrom", "--eject", "--volume-name=testvolume"),
+		)
+
+		It("should eject the media", func() {
+			kubecli.MockKubevirtClientInstance.
+				EXPECT().
+				VirtualMachineInstance(k8smetav1.NamespaceDefault).
+				Return(vmiInterface).
+				Times(1)
+			vmiInterface.EXPECT().MediaChange("testvmi", &v1.MediaChangeOptions{Name: "cdrom"}).Return(nil)
+
+			cmd := tests.NewVirtctlCommand("mediachange", "testvmi", "--disk=cdrom", "--eject")
+			Expect(cmd.Execute()).To(Succeed())
+		})
+
+		It("should insert a PersistentVolumeClaim", func() {
+			coreClient := fake.NewSimpleClientset(&corev1.PersistentVolumeClaim{
+				ObjectMeta: k8smetav1.ObjectMeta{Name: "testvolume", Namespace: k8smetav1.NamespaceDefault},
+			})
+			kubecli.MockKubevirtClientInstance.EXPECT().CdiClient().Return(cdifake.NewSimpleClientset())
+			kubecli.MockKubevirtClientInstance.EXPECT().CoreV1().Return(coreClient.CoreV1())
+			kubecli.MockKubevirtClientInstance.
+				EXPECT().
+				VirtualMachineInstance(k8smetav1.NamespaceDefault).
+				Return(vmiInterface).
+				Times(1)
+			vmiInterface.EXPECT().MediaChange("testvmi", gomock.Any()).DoAndReturn(func(arg0, arg1 interface{}) interface{} {
+				options := arg1.(*v1.MediaChangeOptions)
+				Expect(options.Name).To(Equal("cdrom"))
+				Expect(options.VolumeSource.PersistentVolumeClaim.ClaimName).To(Equal("testvolume"))
+				return nil
+			})
+
+			cmd := tests.NewVirtctlCommand("mediachange", "testvmi", "--disk=cdrom", "--volume-name=testvolume")
+			Expect(cmd.Execute()).To(Succeed())
+		})
+	})
+
 	AfterEach(func() {
 		ctrl.Finish()
 	})
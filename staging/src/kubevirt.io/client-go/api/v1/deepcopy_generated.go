@@ -95,6 +95,22 @@ func (in *AddVolumeOptions) DeepCopy() *AddVolumeOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogConfiguration) DeepCopyInto(out *AuditLogConfiguration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogConfiguration.
+func (in *AuditLogConfiguration) DeepCopy() *AuditLogConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthorizedKeysFile) DeepCopyInto(out *AuthorizedKeysFile) {
 	*out = *in
@@ -132,6 +148,28 @@ func (in *BIOS) DeepCopy() *BIOS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BandwidthLimit) DeepCopyInto(out *BandwidthLimit) {
+	*out = *in
+	out.Rate = in.Rate.DeepCopy()
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BandwidthLimit.
+func (in *BandwidthLimit) DeepCopy() *BandwidthLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(BandwidthLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BlockSize) DeepCopyInto(out *BlockSize) {
 	*out = *in
@@ -158,6 +196,27 @@ func (in *BlockSize) DeepCopy() *BlockSize {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootMenu) DeepCopyInto(out *BootMenu) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(uint32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootMenu.
+func (in *BootMenu) DeepCopy() *BootMenu {
+	if in == nil {
+		return nil
+	}
+	out := new(BootMenu)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Bootloader) DeepCopyInto(out *Bootloader) {
 	*out = *in
@@ -508,9 +567,56 @@ func (in *ConfigMapVolumeSource) DeepCopy() *ConfigMapVolumeSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsoleRecordingConfiguration) DeepCopyInto(out *ConsoleRecordingConfiguration) {
+	*out = *in
+	if in.RecordVNC != nil {
+		in, out := &in.RecordVNC, &out.RecordVNC
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsoleRecordingConfiguration.
+func (in *ConsoleRecordingConfiguration) DeepCopy() *ConsoleRecordingConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsoleRecordingConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerDiskOverlay) DeepCopyInto(out *ContainerDiskOverlay) {
+	*out = *in
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerDiskOverlay.
+func (in *ContainerDiskOverlay) DeepCopy() *ContainerDiskOverlay {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerDiskOverlay)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerDiskSource) DeepCopyInto(out *ContainerDiskSource) {
 	*out = *in
+	if in.Overlay != nil {
+		in, out := &in.Overlay, &out.Overlay
+		*out = new(ContainerDiskOverlay)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -524,6 +630,32 @@ func (in *ContainerDiskSource) DeepCopy() *ContainerDiskSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrashLoopBackOffConfiguration) DeepCopyInto(out *CrashLoopBackOffConfiguration) {
+	*out = *in
+	if in.MaxDelaySeconds != nil {
+		in, out := &in.MaxDelaySeconds, &out.MaxDelaySeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ResetAfterSeconds != nil {
+		in, out := &in.ResetAfterSeconds, &out.ResetAfterSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrashLoopBackOffConfiguration.
+func (in *CrashLoopBackOffConfiguration) DeepCopy() *CrashLoopBackOffConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(CrashLoopBackOffConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomBlockSize) DeepCopyInto(out *CustomBlockSize) {
 	*out = *in
@@ -770,11 +902,21 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.LogSerialConsole != nil {
+		in, out := &in.LogSerialConsole, &out.LogSerialConsole
+		*out = new(bool)
+		**out = **in
+	}
 	if in.AutoattachMemBalloon != nil {
 		in, out := &in.AutoattachMemBalloon, &out.AutoattachMemBalloon
 		*out = new(bool)
 		**out = **in
 	}
+	if in.FreePageReporting != nil {
+		in, out := &in.FreePageReporting, &out.FreePageReporting
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Rng != nil {
 		in, out := &in.Rng, &out.Rng
 		*out = new(Rng)
@@ -793,7 +935,9 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 	if in.GPUs != nil {
 		in, out := &in.GPUs, &out.GPUs
 		*out = make([]GPU, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Filesystems != nil {
 		in, out := &in.Filesystems, &out.Filesystems
@@ -812,6 +956,16 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 		*out = new(ClientPassthroughDevices)
 		**out = **in
 	}
+	if in.Sound != nil {
+		in, out := &in.Sound, &out.Sound
+		*out = new(SoundDevice)
+		**out = **in
+	}
+	if in.AutoattachVSOCK != nil {
+		in, out := &in.AutoattachVSOCK, &out.AutoattachVSOCK
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -844,6 +998,16 @@ func (in *Disk) DeepCopyInto(out *Disk) {
 		*out = new(BlockSize)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Queues != nil {
+		in, out := &in.Queues, &out.Queues
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.IOTune != nil {
+		in, out := &in.IOTune, &out.IOTune
+		*out = new(DiskIOTune)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -857,6 +1021,34 @@ func (in *Disk) DeepCopy() *Disk {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskConfiguration) DeepCopyInto(out *DiskConfiguration) {
+	*out = *in
+	if in.StorageClassCacheModes != nil {
+		in, out := &in.StorageClassCacheModes, &out.StorageClassCacheModes
+		*out = make(map[string]DriverCache, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ContainerDiskEphemeralStorageLimit != nil {
+		in, out := &in.ContainerDiskEphemeralStorageLimit, &out.ContainerDiskEphemeralStorageLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskConfiguration.
+func (in *DiskConfiguration) DeepCopy() *DiskConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiskDevice) DeepCopyInto(out *DiskDevice) {
 	*out = *in
@@ -893,6 +1085,52 @@ func (in *DiskDevice) DeepCopy() *DiskDevice {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskIOTune) DeepCopyInto(out *DiskIOTune) {
+	*out = *in
+	if in.TotalBytesPerSec != nil {
+		in, out := &in.TotalBytesPerSec, &out.TotalBytesPerSec
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ReadBytesPerSec != nil {
+		in, out := &in.ReadBytesPerSec, &out.ReadBytesPerSec
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.WriteBytesPerSec != nil {
+		in, out := &in.WriteBytesPerSec, &out.WriteBytesPerSec
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.TotalIOPS != nil {
+		in, out := &in.TotalIOPS, &out.TotalIOPS
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ReadIOPS != nil {
+		in, out := &in.ReadIOPS, &out.ReadIOPS
+		*out = new(int64)
+		**out = **in
+	}
+	if in.WriteIOPS != nil {
+		in, out := &in.WriteIOPS, &out.WriteIOPS
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskIOTune.
+func (in *DiskIOTune) DeepCopy() *DiskIOTune {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskIOTune)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiskTarget) DeepCopyInto(out *DiskTarget) {
 	*out = *in
@@ -930,6 +1168,30 @@ func (in *DiskVerification) DeepCopy() *DiskVerification {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainMemoryDumpInfo) DeepCopyInto(out *DomainMemoryDumpInfo) {
+	*out = *in
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTimestamp != nil {
+		in, out := &in.EndTimestamp, &out.EndTimestamp
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainMemoryDumpInfo.
+func (in *DomainMemoryDumpInfo) DeepCopy() *DomainMemoryDumpInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainMemoryDumpInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DomainSpec) DeepCopyInto(out *DomainSpec) {
 	*out = *in
@@ -975,6 +1237,11 @@ func (in *DomainSpec) DeepCopyInto(out *DomainSpec) {
 		*out = new(Chassis)
 		**out = **in
 	}
+	if in.QEMUArgs != nil {
+		in, out := &in.QEMUArgs, &out.QEMUArgs
+		*out = make([]QEMUArg, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1291,6 +1558,11 @@ func (in *Features) DeepCopyInto(out *Features) {
 		*out = new(FeatureHyperv)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HypervPassthrough != nil {
+		in, out := &in.HypervPassthrough, &out.HypervPassthrough
+		*out = new(HyperVPassthrough)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SMM != nil {
 		in, out := &in.SMM, &out.SMM
 		*out = new(FeatureState)
@@ -1340,6 +1612,29 @@ func (in *Filesystem) DeepCopy() *Filesystem {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemOverhead) DeepCopyInto(out *FilesystemOverhead) {
+	*out = *in
+	if in.StorageClass != nil {
+		in, out := &in.StorageClass, &out.StorageClass
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemOverhead.
+func (in *FilesystemOverhead) DeepCopy() *FilesystemOverhead {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemOverhead)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FilesystemVirtiofs) DeepCopyInto(out *FilesystemVirtiofs) {
 	*out = *in
@@ -1369,6 +1664,11 @@ func (in *Firmware) DeepCopyInto(out *Firmware) {
 		*out = new(KernelBoot)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.BootMenu != nil {
+		in, out := &in.BootMenu, &out.BootMenu
+		*out = new(BootMenu)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1438,6 +1738,11 @@ func (in *FloppyTarget) DeepCopy() *FloppyTarget {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GPU) DeepCopyInto(out *GPU) {
 	*out = *in
+	if in.VirtualGPUOptions != nil {
+		in, out := &in.VirtualGPUOptions, &out.VirtualGPUOptions
+		*out = new(VGPUOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1499,6 +1804,69 @@ func (in *GuestAgentPing) DeepCopy() *GuestAgentPing {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestExecCommand) DeepCopyInto(out *GuestExecCommand) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestExecCommand.
+func (in *GuestExecCommand) DeepCopy() *GuestExecCommand {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestExecCommand)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestExecOptions) DeepCopyInto(out *GuestExecOptions) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestExecOptions.
+func (in *GuestExecOptions) DeepCopy() *GuestExecOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestExecOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestExecResult) DeepCopyInto(out *GuestExecResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestExecResult.
+func (in *GuestExecResult) DeepCopy() *GuestExecResult {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestExecResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HPETTimer) DeepCopyInto(out *HPETTimer) {
 	*out = *in
@@ -1653,7 +2021,7 @@ func (in *Hugepages) DeepCopy() *Hugepages {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HypervTimer) DeepCopyInto(out *HypervTimer) {
+func (in *HyperVPassthrough) DeepCopyInto(out *HyperVPassthrough) {
 	*out = *in
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
@@ -1663,8 +2031,29 @@ func (in *HypervTimer) DeepCopyInto(out *HypervTimer) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HypervTimer.
-func (in *HypervTimer) DeepCopy() *HypervTimer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HyperVPassthrough.
+func (in *HyperVPassthrough) DeepCopy() *HyperVPassthrough {
+	if in == nil {
+		return nil
+	}
+	out := new(HyperVPassthrough)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HypervTimer) DeepCopyInto(out *HypervTimer) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HypervTimer.
+func (in *HypervTimer) DeepCopy() *HypervTimer {
 	if in == nil {
 		return nil
 	}
@@ -1724,6 +2113,16 @@ func (in *Interface) DeepCopyInto(out *Interface) {
 		*out = new(DHCPOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Queues != nil {
+		in, out := &in.Queues, &out.Queues
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Bandwidth != nil {
+		in, out := &in.Bandwidth, &out.Bandwidth
+		*out = new(InterfaceBandwidth)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1737,6 +2136,32 @@ func (in *Interface) DeepCopy() *Interface {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceBandwidth) DeepCopyInto(out *InterfaceBandwidth) {
+	*out = *in
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(BandwidthLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = new(BandwidthLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceBandwidth.
+func (in *InterfaceBandwidth) DeepCopy() *InterfaceBandwidth {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceBandwidth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterfaceBindingMethod) DeepCopyInto(out *InterfaceBindingMethod) {
 	*out = *in
@@ -1765,6 +2190,11 @@ func (in *InterfaceBindingMethod) DeepCopyInto(out *InterfaceBindingMethod) {
 		*out = new(InterfaceMacvtap)
 		**out = **in
 	}
+	if in.VhostUser != nil {
+		in, out := &in.VhostUser, &out.VhostUser
+		*out = new(InterfaceVhostUser)
+		**out = **in
+	}
 	return
 }
 
@@ -1858,6 +2288,22 @@ func (in *InterfaceSlirp) DeepCopy() *InterfaceSlirp {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceVhostUser) DeepCopyInto(out *InterfaceVhostUser) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceVhostUser.
+func (in *InterfaceVhostUser) DeepCopy() *InterfaceVhostUser {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceVhostUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KVMTimer) DeepCopyInto(out *KVMTimer) {
 	*out = *in
@@ -2068,6 +2514,63 @@ func (in *KubeVirtConfiguration) DeepCopyInto(out *KubeVirtConfiguration) {
 		*out = new(ReloadableComponentConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OperatorConfiguration != nil {
+		in, out := &in.OperatorConfiguration, &out.OperatorConfiguration
+		*out = new(ReloadableComponentConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConsoleRecording != nil {
+		in, out := &in.ConsoleRecording, &out.ConsoleRecording
+		*out = new(ConsoleRecordingConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.QEMUArgsAllowList != nil {
+		in, out := &in.QEMUArgsAllowList, &out.QEMUArgsAllowList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Swap != nil {
+		in, out := &in.Swap, &out.Swap
+		*out = new(SwapConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeprecatedMachineTypes != nil {
+		in, out := &in.DeprecatedMachineTypes, &out.DeprecatedMachineTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CrashLoopBackOff != nil {
+		in, out := &in.CrashLoopBackOff, &out.CrashLoopBackOff
+		*out = new(CrashLoopBackOffConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FilesystemOverhead != nil {
+		in, out := &in.FilesystemOverhead, &out.FilesystemOverhead
+		*out = new(FilesystemOverhead)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DiskConfiguration != nil {
+		in, out := &in.DiskConfiguration, &out.DiskConfiguration
+		*out = new(DiskConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GuestExecAllowList != nil {
+		in, out := &in.GuestExecAllowList, &out.GuestExecAllowList
+		*out = make([]GuestExecCommand, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AuditLog != nil {
+		in, out := &in.AuditLog, &out.AuditLog
+		*out = new(AuditLogConfiguration)
+		**out = **in
+	}
+	if in.LeaderElection != nil {
+		in, out := &in.LeaderElection, &out.LeaderElection
+		*out = new(LeaderElectionConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2249,6 +2752,37 @@ func (in *KubeVirtWorkloadUpdateStrategy) DeepCopy() *KubeVirtWorkloadUpdateStra
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LeaderElectionConfiguration) DeepCopyInto(out *LeaderElectionConfiguration) {
+	*out = *in
+	if in.LeaseDuration != nil {
+		in, out := &in.LeaseDuration, &out.LeaseDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RenewDeadline != nil {
+		in, out := &in.RenewDeadline, &out.RenewDeadline
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RetryPeriod != nil {
+		in, out := &in.RetryPeriod, &out.RetryPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeaderElectionConfiguration.
+func (in *LeaderElectionConfiguration) DeepCopy() *LeaderElectionConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(LeaderElectionConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LogVerbosity) DeepCopyInto(out *LogVerbosity) {
 	*out = *in
@@ -2288,6 +2822,22 @@ func (in *LunTarget) DeepCopy() *LunTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MacAddressPool) DeepCopyInto(out *MacAddressPool) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MacAddressPool.
+func (in *MacAddressPool) DeepCopy() *MacAddressPool {
+	if in == nil {
+		return nil
+	}
+	out := new(MacAddressPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Machine) DeepCopyInto(out *Machine) {
 	*out = *in
@@ -2304,6 +2854,27 @@ func (in *Machine) DeepCopy() *Machine {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MediaChangeOptions) DeepCopyInto(out *MediaChangeOptions) {
+	*out = *in
+	if in.VolumeSource != nil {
+		in, out := &in.VolumeSource, &out.VolumeSource
+		*out = new(HotplugVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MediaChangeOptions.
+func (in *MediaChangeOptions) DeepCopy() *MediaChangeOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(MediaChangeOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MediatedDevicesConfiguration) DeepCopyInto(out *MediatedDevicesConfiguration) {
 	*out = *in
@@ -2312,6 +2883,13 @@ func (in *MediatedDevicesConfiguration) DeepCopyInto(out *MediatedDevicesConfigu
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.NodeMediatedDeviceTypes != nil {
+		in, out := &in.NodeMediatedDeviceTypes, &out.NodeMediatedDeviceTypes
+		*out = make([]NodeMediatedDeviceTypesConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -2367,6 +2945,22 @@ func (in *Memory) DeepCopy() *Memory {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryDumpVolumeSource) DeepCopyInto(out *MemoryDumpVolumeSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryDumpVolumeSource.
+func (in *MemoryDumpVolumeSource) DeepCopy() *MemoryDumpVolumeSource {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryDumpVolumeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MigrationConfiguration) DeepCopyInto(out *MigrationConfiguration) {
 	*out = *in
@@ -2420,6 +3014,11 @@ func (in *MigrationConfiguration) DeepCopyInto(out *MigrationConfiguration) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ParallelMigrationThreads != nil {
+		in, out := &in.ParallelMigrationThreads, &out.ParallelMigrationThreads
+		*out = new(uint32)
+		**out = **in
+	}
 	return
 }
 
@@ -2433,9 +3032,31 @@ func (in *MigrationConfiguration) DeepCopy() *MigrationConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MoveOptions) DeepCopyInto(out *MoveOptions) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MoveOptions.
+func (in *MoveOptions) DeepCopy() *MoveOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(MoveOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MultusNetwork) DeepCopyInto(out *MultusNetwork) {
 	*out = *in
+	if in.IPAddresses != nil {
+		in, out := &in.IPAddresses, &out.IPAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -2516,6 +3137,11 @@ func (in *NetworkConfiguration) DeepCopyInto(out *NetworkConfiguration) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.MacAddressPool != nil {
+		in, out := &in.MacAddressPool, &out.MacAddressPool
+		*out = new(MacAddressPool)
+		**out = **in
+	}
 	return
 }
 
@@ -2540,7 +3166,7 @@ func (in *NetworkSource) DeepCopyInto(out *NetworkSource) {
 	if in.Multus != nil {
 		in, out := &in.Multus, &out.Multus
 		*out = new(MultusNetwork)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
@@ -2555,6 +3181,34 @@ func (in *NetworkSource) DeepCopy() *NetworkSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeMediatedDeviceTypesConfig) DeepCopyInto(out *NodeMediatedDeviceTypesConfig) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MediatedDevicesTypes != nil {
+		in, out := &in.MediatedDevicesTypes, &out.MediatedDevicesTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMediatedDeviceTypesConfig.
+func (in *NodeMediatedDeviceTypesConfig) DeepCopy() *NodeMediatedDeviceTypesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeMediatedDeviceTypesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodePlacement) DeepCopyInto(out *NodePlacement) {
 	*out = *in
@@ -2611,6 +3265,28 @@ func (in *PITTimer) DeepCopy() *PITTimer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PauseOptions) DeepCopyInto(out *PauseOptions) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PauseOptions.
+func (in *PauseOptions) DeepCopy() *PauseOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PauseOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PciHostDevice) DeepCopyInto(out *PciHostDevice) {
 	*out = *in
@@ -2640,6 +3316,13 @@ func (in *PermittedHostDevices) DeepCopyInto(out *PermittedHostDevices) {
 		*out = make([]MediatedHostDevice, len(*in))
 		copy(*out, *in)
 	}
+	if in.USB != nil {
+		in, out := &in.USB, &out.USB
+		*out = make([]USBHostDevice, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -2783,6 +3466,22 @@ func (in *ProfilerResult) DeepCopy() *ProfilerResult {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QEMUArg) DeepCopyInto(out *QEMUArg) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QEMUArg.
+func (in *QEMUArg) DeepCopy() *QEMUArg {
+	if in == nil {
+		return nil
+	}
+	out := new(QEMUArg)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *QemuGuestAgentSSHPublicKeyAccessCredentialPropagation) DeepCopyInto(out *QemuGuestAgentSSHPublicKeyAccessCredentialPropagation) {
 	*out = *in
@@ -2920,6 +3619,23 @@ func (in *RemoveVolumeOptions) DeepCopy() *RemoveVolumeOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RenameOptions) DeepCopyInto(out *RenameOptions) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RenameOptions.
+func (in *RenameOptions) DeepCopy() *RenameOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(RenameOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceRequirements) DeepCopyInto(out *ResourceRequirements) {
 	*out = *in
@@ -3106,6 +3822,22 @@ func (in *ServiceAccountVolumeSource) DeepCopy() *ServiceAccountVolumeSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SoundDevice) DeepCopyInto(out *SoundDevice) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SoundDevice.
+func (in *SoundDevice) DeepCopy() *SoundDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(SoundDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StartOptions) DeepCopyInto(out *StartOptions) {
 	*out = *in
@@ -3145,6 +3877,27 @@ func (in *StopOptions) DeepCopy() *StopOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwapConfiguration) DeepCopyInto(out *SwapConfiguration) {
+	*out = *in
+	if in.Swappiness != nil {
+		in, out := &in.Swappiness, &out.Swappiness
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwapConfiguration.
+func (in *SwapConfiguration) DeepCopy() *SwapConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(SwapConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyNICTimer) DeepCopyInto(out *SyNICTimer) {
 	*out = *in
@@ -3275,6 +4028,65 @@ func (in *TopologyHints) DeepCopy() *TopologyHints {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *USBHostDevice) DeepCopyInto(out *USBHostDevice) {
+	*out = *in
+	if in.Selectors != nil {
+		in, out := &in.Selectors, &out.Selectors
+		*out = make([]USBSelector, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new USBHostDevice.
+func (in *USBHostDevice) DeepCopy() *USBHostDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(USBHostDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *USBSelector) DeepCopyInto(out *USBSelector) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new USBSelector.
+func (in *USBSelector) DeepCopy() *USBSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(USBSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnpauseOptions) DeepCopyInto(out *UnpauseOptions) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnpauseOptions.
+func (in *UnpauseOptions) DeepCopy() *UnpauseOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(UnpauseOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserPasswordAccessCredential) DeepCopyInto(out *UserPasswordAccessCredential) {
 	*out = *in
@@ -3335,6 +4147,53 @@ func (in *UserPasswordAccessCredentialSource) DeepCopy() *UserPasswordAccessCred
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VGPUDisplayOptions) DeepCopyInto(out *VGPUDisplayOptions) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RamFB != nil {
+		in, out := &in.RamFB, &out.RamFB
+		*out = new(FeatureState)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VGPUDisplayOptions.
+func (in *VGPUDisplayOptions) DeepCopy() *VGPUDisplayOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(VGPUDisplayOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VGPUOptions) DeepCopyInto(out *VGPUOptions) {
+	*out = *in
+	if in.Display != nil {
+		in, out := &in.Display, &out.Display
+		*out = new(VGPUDisplayOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VGPUOptions.
+func (in *VGPUOptions) DeepCopy() *VGPUOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(VGPUOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMISelector) DeepCopyInto(out *VMISelector) {
 	*out = *in
@@ -3379,6 +4238,34 @@ func (in *VirtualMachine) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineAvailabilityStatus) DeepCopyInto(out *VirtualMachineAvailabilityStatus) {
+	*out = *in
+	if in.StartRequestTime != nil {
+		in, out := &in.StartRequestTime, &out.StartRequestTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ReadyTime != nil {
+		in, out := &in.ReadyTime, &out.ReadyTime
+		*out = (*in).DeepCopy()
+	}
+	if in.FailureTime != nil {
+		in, out := &in.FailureTime, &out.FailureTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineAvailabilityStatus.
+func (in *VirtualMachineAvailabilityStatus) DeepCopy() *VirtualMachineAvailabilityStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineAvailabilityStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineCondition) DeepCopyInto(out *VirtualMachineCondition) {
 	*out = *in
@@ -3459,6 +4346,22 @@ func (in *VirtualMachineInstanceFileSystem) DeepCopy() *VirtualMachineInstanceFi
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceFileSystemFreeSpace) DeepCopyInto(out *VirtualMachineInstanceFileSystemFreeSpace) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceFileSystemFreeSpace.
+func (in *VirtualMachineInstanceFileSystemFreeSpace) DeepCopy() *VirtualMachineInstanceFileSystemFreeSpace {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceFileSystemFreeSpace)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstanceFileSystemInfo) DeepCopyInto(out *VirtualMachineInstanceFileSystemInfo) {
 	*out = *in
@@ -3723,6 +4626,26 @@ func (in *VirtualMachineInstanceMigrationList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceMigrationProgress) DeepCopyInto(out *VirtualMachineInstanceMigrationProgress) {
+	*out = *in
+	if in.UpdateTimestamp != nil {
+		in, out := &in.UpdateTimestamp, &out.UpdateTimestamp
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceMigrationProgress.
+func (in *VirtualMachineInstanceMigrationProgress) DeepCopy() *VirtualMachineInstanceMigrationProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceMigrationProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstanceMigrationSpec) DeepCopyInto(out *VirtualMachineInstanceMigrationSpec) {
 	*out = *in
@@ -3757,6 +4680,11 @@ func (in *VirtualMachineInstanceMigrationState) DeepCopyInto(out *VirtualMachine
 			(*out)[key] = val
 		}
 	}
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(VirtualMachineInstanceMigrationProgress)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -4101,6 +5029,11 @@ func (in *VirtualMachineInstanceSpec) DeepCopyInto(out *VirtualMachineInstanceSp
 		*out = new(Probe)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(Probe)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Networks != nil {
 		in, out := &in.Networks, &out.Networks
 		*out = make([]Network, len(*in))
@@ -4133,6 +5066,42 @@ func (in *VirtualMachineInstanceSpec) DeepCopy() *VirtualMachineInstanceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceStartupTimestamps) DeepCopyInto(out *VirtualMachineInstanceStartupTimestamps) {
+	*out = *in
+	if in.PodScheduled != nil {
+		in, out := &in.PodScheduled, &out.PodScheduled
+		*out = (*in).DeepCopy()
+	}
+	if in.ContainerStarted != nil {
+		in, out := &in.ContainerStarted, &out.ContainerStarted
+		*out = (*in).DeepCopy()
+	}
+	if in.DomainDefined != nil {
+		in, out := &in.DomainDefined, &out.DomainDefined
+		*out = (*in).DeepCopy()
+	}
+	if in.DomainRunning != nil {
+		in, out := &in.DomainRunning, &out.DomainRunning
+		*out = (*in).DeepCopy()
+	}
+	if in.GuestAgentConnected != nil {
+		in, out := &in.GuestAgentConnected, &out.GuestAgentConnected
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceStartupTimestamps.
+func (in *VirtualMachineInstanceStartupTimestamps) DeepCopy() *VirtualMachineInstanceStartupTimestamps {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceStartupTimestamps)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstanceStatus) DeepCopyInto(out *VirtualMachineInstanceStatus) {
 	*out = *in
@@ -4158,6 +5127,11 @@ func (in *VirtualMachineInstanceStatus) DeepCopyInto(out *VirtualMachineInstance
 		}
 	}
 	out.GuestOSInfo = in.GuestOSInfo
+	if in.FSFreeSpace != nil {
+		in, out := &in.FSFreeSpace, &out.FSFreeSpace
+		*out = make([]VirtualMachineInstanceFileSystemFreeSpace, len(*in))
+		copy(*out, *in)
+	}
 	if in.MigrationState != nil {
 		in, out := &in.MigrationState, &out.MigrationState
 		*out = new(VirtualMachineInstanceMigrationState)
@@ -4187,6 +5161,16 @@ func (in *VirtualMachineInstanceStatus) DeepCopyInto(out *VirtualMachineInstance
 		*out = new(TopologyHints)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.VSOCKCID != nil {
+		in, out := &in.VSOCKCID, &out.VSOCKCID
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.StartupTimestamps != nil {
+		in, out := &in.StartupTimestamps, &out.StartupTimestamps
+		*out = new(VirtualMachineInstanceStartupTimestamps)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -4251,6 +5235,79 @@ func (in *VirtualMachineList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineMemoryDumpRequest) DeepCopyInto(out *VirtualMachineMemoryDumpRequest) {
+	*out = *in
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTimestamp != nil {
+		in, out := &in.EndTimestamp, &out.EndTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.FileName != nil {
+		in, out := &in.FileName, &out.FileName
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineMemoryDumpRequest.
+func (in *VirtualMachineMemoryDumpRequest) DeepCopy() *VirtualMachineMemoryDumpRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineMemoryDumpRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineSchedule) DeepCopyInto(out *VirtualMachineSchedule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSchedule.
+func (in *VirtualMachineSchedule) DeepCopy() *VirtualMachineSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineScheduleStatus) DeepCopyInto(out *VirtualMachineScheduleStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextStartTime != nil {
+		in, out := &in.NextStartTime, &out.NextStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextStopTime != nil {
+		in, out := &in.NextStopTime, &out.NextStopTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineScheduleStatus.
+func (in *VirtualMachineScheduleStatus) DeepCopy() *VirtualMachineScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineSpec) DeepCopyInto(out *VirtualMachineSpec) {
 	*out = *in
@@ -4264,6 +5321,16 @@ func (in *VirtualMachineSpec) DeepCopyInto(out *VirtualMachineSpec) {
 		*out = new(VirtualMachineRunStrategy)
 		**out = **in
 	}
+	if in.NodeRebootPolicy != nil {
+		in, out := &in.NodeRebootPolicy, &out.NodeRebootPolicy
+		*out = new(NodeRebootPolicy)
+		**out = **in
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(VirtualMachineSchedule)
+		**out = **in
+	}
 	if in.Template != nil {
 		in, out := &in.Template, &out.Template
 		*out = new(VirtualMachineInstanceTemplateSpec)
@@ -4381,6 +5448,21 @@ func (in *VirtualMachineStatus) DeepCopyInto(out *VirtualMachineStatus) {
 		*out = new(VirtualMachineStartFailure)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(VirtualMachineScheduleStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MemoryDumpRequest != nil {
+		in, out := &in.MemoryDumpRequest, &out.MemoryDumpRequest
+		*out = new(VirtualMachineMemoryDumpRequest)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Availability != nil {
+		in, out := &in.Availability, &out.Availability
+		*out = new(VirtualMachineAvailabilityStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -4437,6 +5519,22 @@ func (in *Volume) DeepCopy() *Volume {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeDiskStatus) DeepCopyInto(out *VolumeDiskStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeDiskStatus.
+func (in *VolumeDiskStatus) DeepCopy() *VolumeDiskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeDiskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeSnapshotStatus) DeepCopyInto(out *VolumeSnapshotStatus) {
 	*out = *in
@@ -4484,7 +5582,7 @@ func (in *VolumeSource) DeepCopyInto(out *VolumeSource) {
 	if in.ContainerDisk != nil {
 		in, out := &in.ContainerDisk, &out.ContainerDisk
 		*out = new(ContainerDiskSource)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Ephemeral != nil {
 		in, out := &in.Ephemeral, &out.Ephemeral
@@ -4526,6 +5624,11 @@ func (in *VolumeSource) DeepCopyInto(out *VolumeSource) {
 		*out = new(DownwardMetricsVolumeSource)
 		**out = **in
 	}
+	if in.MemoryDump != nil {
+		in, out := &in.MemoryDump, &out.MemoryDump
+		*out = new(MemoryDumpVolumeSource)
+		**out = **in
+	}
 	return
 }
 
@@ -4552,6 +5655,16 @@ func (in *VolumeStatus) DeepCopyInto(out *VolumeStatus) {
 		*out = new(HotplugVolumeStatus)
 		**out = **in
 	}
+	if in.MemoryDumpVolume != nil {
+		in, out := &in.MemoryDumpVolume, &out.MemoryDumpVolume
+		*out = new(DomainMemoryDumpInfo)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Disk != nil {
+		in, out := &in.Disk, &out.Disk
+		*out = new(VolumeDiskStatus)
+		**out = **in
+	}
 	return
 }
 
@@ -390,9 +390,12 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.AccessCredential":                                          schema_kubevirtio_client_go_api_v1_AccessCredential(ref),
 		"kubevirt.io/client-go/api/v1.AccessCredentialSecretSource":                              schema_kubevirtio_client_go_api_v1_AccessCredentialSecretSource(ref),
 		"kubevirt.io/client-go/api/v1.AddVolumeOptions":                                          schema_kubevirtio_client_go_api_v1_AddVolumeOptions(ref),
+		"kubevirt.io/client-go/api/v1.AuditLogConfiguration":                                     schema_kubevirtio_client_go_api_v1_AuditLogConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.AuthorizedKeysFile":                                        schema_kubevirtio_client_go_api_v1_AuthorizedKeysFile(ref),
 		"kubevirt.io/client-go/api/v1.BIOS":                                                      schema_kubevirtio_client_go_api_v1_BIOS(ref),
+		"kubevirt.io/client-go/api/v1.BandwidthLimit":                                            schema_kubevirtio_client_go_api_v1_BandwidthLimit(ref),
 		"kubevirt.io/client-go/api/v1.BlockSize":                                                 schema_kubevirtio_client_go_api_v1_BlockSize(ref),
+		"kubevirt.io/client-go/api/v1.BootMenu":                                                  schema_kubevirtio_client_go_api_v1_BootMenu(ref),
 		"kubevirt.io/client-go/api/v1.Bootloader":                                                schema_kubevirtio_client_go_api_v1_Bootloader(ref),
 		"kubevirt.io/client-go/api/v1.CDRomTarget":                                               schema_kubevirtio_client_go_api_v1_CDRomTarget(ref),
 		"kubevirt.io/client-go/api/v1.CPU":                                                       schema_kubevirtio_client_go_api_v1_CPU(ref),
@@ -409,7 +412,10 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.ComponentConfig":                                           schema_kubevirtio_client_go_api_v1_ComponentConfig(ref),
 		"kubevirt.io/client-go/api/v1.ConfigDriveSSHPublicKeyAccessCredentialPropagation":        schema_kubevirtio_client_go_api_v1_ConfigDriveSSHPublicKeyAccessCredentialPropagation(ref),
 		"kubevirt.io/client-go/api/v1.ConfigMapVolumeSource":                                     schema_kubevirtio_client_go_api_v1_ConfigMapVolumeSource(ref),
+		"kubevirt.io/client-go/api/v1.ConsoleRecordingConfiguration":                             schema_kubevirtio_client_go_api_v1_ConsoleRecordingConfiguration(ref),
+		"kubevirt.io/client-go/api/v1.ContainerDiskOverlay":                                      schema_kubevirtio_client_go_api_v1_ContainerDiskOverlay(ref),
 		"kubevirt.io/client-go/api/v1.ContainerDiskSource":                                       schema_kubevirtio_client_go_api_v1_ContainerDiskSource(ref),
+		"kubevirt.io/client-go/api/v1.CrashLoopBackOffConfiguration":                             schema_kubevirtio_client_go_api_v1_CrashLoopBackOffConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.CustomBlockSize":                                           schema_kubevirtio_client_go_api_v1_CustomBlockSize(ref),
 		"kubevirt.io/client-go/api/v1.CustomizeComponents":                                       schema_kubevirtio_client_go_api_v1_CustomizeComponents(ref),
 		"kubevirt.io/client-go/api/v1.CustomizeComponentsPatch":                                  schema_kubevirtio_client_go_api_v1_CustomizeComponentsPatch(ref),
@@ -421,9 +427,12 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.DeveloperConfiguration":                                    schema_kubevirtio_client_go_api_v1_DeveloperConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.Devices":                                                   schema_kubevirtio_client_go_api_v1_Devices(ref),
 		"kubevirt.io/client-go/api/v1.Disk":                                                      schema_kubevirtio_client_go_api_v1_Disk(ref),
+		"kubevirt.io/client-go/api/v1.DiskConfiguration":                                         schema_kubevirtio_client_go_api_v1_DiskConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.DiskDevice":                                                schema_kubevirtio_client_go_api_v1_DiskDevice(ref),
+		"kubevirt.io/client-go/api/v1.DiskIOTune":                                                schema_kubevirtio_client_go_api_v1_DiskIOTune(ref),
 		"kubevirt.io/client-go/api/v1.DiskTarget":                                                schema_kubevirtio_client_go_api_v1_DiskTarget(ref),
 		"kubevirt.io/client-go/api/v1.DiskVerification":                                          schema_kubevirtio_client_go_api_v1_DiskVerification(ref),
+		"kubevirt.io/client-go/api/v1.DomainMemoryDumpInfo":                                      schema_kubevirtio_client_go_api_v1_DomainMemoryDumpInfo(ref),
 		"kubevirt.io/client-go/api/v1.DomainSpec":                                                schema_kubevirtio_client_go_api_v1_DomainSpec(ref),
 		"kubevirt.io/client-go/api/v1.DownwardAPIVolumeSource":                                   schema_kubevirtio_client_go_api_v1_DownwardAPIVolumeSource(ref),
 		"kubevirt.io/client-go/api/v1.DownwardMetricsVolumeSource":                               schema_kubevirtio_client_go_api_v1_DownwardMetricsVolumeSource(ref),
@@ -438,6 +447,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.FeatureVendorID":                                           schema_kubevirtio_client_go_api_v1_FeatureVendorID(ref),
 		"kubevirt.io/client-go/api/v1.Features":                                                  schema_kubevirtio_client_go_api_v1_Features(ref),
 		"kubevirt.io/client-go/api/v1.Filesystem":                                                schema_kubevirtio_client_go_api_v1_Filesystem(ref),
+		"kubevirt.io/client-go/api/v1.FilesystemOverhead":                                        schema_kubevirtio_client_go_api_v1_FilesystemOverhead(ref),
 		"kubevirt.io/client-go/api/v1.FilesystemVirtiofs":                                        schema_kubevirtio_client_go_api_v1_FilesystemVirtiofs(ref),
 		"kubevirt.io/client-go/api/v1.Firmware":                                                  schema_kubevirtio_client_go_api_v1_Firmware(ref),
 		"kubevirt.io/client-go/api/v1.Flags":                                                     schema_kubevirtio_client_go_api_v1_Flags(ref),
@@ -446,22 +456,28 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.GenerationStatus":                                          schema_kubevirtio_client_go_api_v1_GenerationStatus(ref),
 		"kubevirt.io/client-go/api/v1.GuestAgentCommandInfo":                                     schema_kubevirtio_client_go_api_v1_GuestAgentCommandInfo(ref),
 		"kubevirt.io/client-go/api/v1.GuestAgentPing":                                            schema_kubevirtio_client_go_api_v1_GuestAgentPing(ref),
+		"kubevirt.io/client-go/api/v1.GuestExecCommand":                                          schema_kubevirtio_client_go_api_v1_GuestExecCommand(ref),
+		"kubevirt.io/client-go/api/v1.GuestExecOptions":                                          schema_kubevirtio_client_go_api_v1_GuestExecOptions(ref),
+		"kubevirt.io/client-go/api/v1.GuestExecResult":                                           schema_kubevirtio_client_go_api_v1_GuestExecResult(ref),
 		"kubevirt.io/client-go/api/v1.HPETTimer":                                                 schema_kubevirtio_client_go_api_v1_HPETTimer(ref),
 		"kubevirt.io/client-go/api/v1.HostDevice":                                                schema_kubevirtio_client_go_api_v1_HostDevice(ref),
 		"kubevirt.io/client-go/api/v1.HostDisk":                                                  schema_kubevirtio_client_go_api_v1_HostDisk(ref),
 		"kubevirt.io/client-go/api/v1.HotplugVolumeSource":                                       schema_kubevirtio_client_go_api_v1_HotplugVolumeSource(ref),
 		"kubevirt.io/client-go/api/v1.HotplugVolumeStatus":                                       schema_kubevirtio_client_go_api_v1_HotplugVolumeStatus(ref),
 		"kubevirt.io/client-go/api/v1.Hugepages":                                                 schema_kubevirtio_client_go_api_v1_Hugepages(ref),
+		"kubevirt.io/client-go/api/v1.HyperVPassthrough":                                         schema_kubevirtio_client_go_api_v1_HyperVPassthrough(ref),
 		"kubevirt.io/client-go/api/v1.HypervTimer":                                               schema_kubevirtio_client_go_api_v1_HypervTimer(ref),
 		"kubevirt.io/client-go/api/v1.I6300ESBWatchdog":                                          schema_kubevirtio_client_go_api_v1_I6300ESBWatchdog(ref),
 		"kubevirt.io/client-go/api/v1.Input":                                                     schema_kubevirtio_client_go_api_v1_Input(ref),
 		"kubevirt.io/client-go/api/v1.Interface":                                                 schema_kubevirtio_client_go_api_v1_Interface(ref),
+		"kubevirt.io/client-go/api/v1.InterfaceBandwidth":                                        schema_kubevirtio_client_go_api_v1_InterfaceBandwidth(ref),
 		"kubevirt.io/client-go/api/v1.InterfaceBindingMethod":                                    schema_kubevirtio_client_go_api_v1_InterfaceBindingMethod(ref),
 		"kubevirt.io/client-go/api/v1.InterfaceBridge":                                           schema_kubevirtio_client_go_api_v1_InterfaceBridge(ref),
 		"kubevirt.io/client-go/api/v1.InterfaceMacvtap":                                          schema_kubevirtio_client_go_api_v1_InterfaceMacvtap(ref),
 		"kubevirt.io/client-go/api/v1.InterfaceMasquerade":                                       schema_kubevirtio_client_go_api_v1_InterfaceMasquerade(ref),
 		"kubevirt.io/client-go/api/v1.InterfaceSRIOV":                                            schema_kubevirtio_client_go_api_v1_InterfaceSRIOV(ref),
 		"kubevirt.io/client-go/api/v1.InterfaceSlirp":                                            schema_kubevirtio_client_go_api_v1_InterfaceSlirp(ref),
+		"kubevirt.io/client-go/api/v1.InterfaceVhostUser":                                        schema_kubevirtio_client_go_api_v1_InterfaceVhostUser(ref),
 		"kubevirt.io/client-go/api/v1.KVMTimer":                                                  schema_kubevirtio_client_go_api_v1_KVMTimer(ref),
 		"kubevirt.io/client-go/api/v1.KernelBoot":                                                schema_kubevirtio_client_go_api_v1_KernelBoot(ref),
 		"kubevirt.io/client-go/api/v1.KernelBootContainer":                                       schema_kubevirtio_client_go_api_v1_KernelBootContainer(ref),
@@ -474,21 +490,28 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.KubeVirtSpec":                                              schema_kubevirtio_client_go_api_v1_KubeVirtSpec(ref),
 		"kubevirt.io/client-go/api/v1.KubeVirtStatus":                                            schema_kubevirtio_client_go_api_v1_KubeVirtStatus(ref),
 		"kubevirt.io/client-go/api/v1.KubeVirtWorkloadUpdateStrategy":                            schema_kubevirtio_client_go_api_v1_KubeVirtWorkloadUpdateStrategy(ref),
+		"kubevirt.io/client-go/api/v1.LeaderElectionConfiguration":                               schema_kubevirtio_client_go_api_v1_LeaderElectionConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.LogVerbosity":                                              schema_kubevirtio_client_go_api_v1_LogVerbosity(ref),
 		"kubevirt.io/client-go/api/v1.LunTarget":                                                 schema_kubevirtio_client_go_api_v1_LunTarget(ref),
+		"kubevirt.io/client-go/api/v1.MacAddressPool":                                            schema_kubevirtio_client_go_api_v1_MacAddressPool(ref),
 		"kubevirt.io/client-go/api/v1.Machine":                                                   schema_kubevirtio_client_go_api_v1_Machine(ref),
+		"kubevirt.io/client-go/api/v1.MediaChangeOptions":                                        schema_kubevirtio_client_go_api_v1_MediaChangeOptions(ref),
 		"kubevirt.io/client-go/api/v1.MediatedDevicesConfiguration":                              schema_kubevirtio_client_go_api_v1_MediatedDevicesConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.MediatedHostDevice":                                        schema_kubevirtio_client_go_api_v1_MediatedHostDevice(ref),
 		"kubevirt.io/client-go/api/v1.Memory":                                                    schema_kubevirtio_client_go_api_v1_Memory(ref),
+		"kubevirt.io/client-go/api/v1.MemoryDumpVolumeSource":                                    schema_kubevirtio_client_go_api_v1_MemoryDumpVolumeSource(ref),
 		"kubevirt.io/client-go/api/v1.MigrationConfiguration":                                    schema_kubevirtio_client_go_api_v1_MigrationConfiguration(ref),
+		"kubevirt.io/client-go/api/v1.MoveOptions":                                               schema_kubevirtio_client_go_api_v1_MoveOptions(ref),
 		"kubevirt.io/client-go/api/v1.MultusNetwork":                                             schema_kubevirtio_client_go_api_v1_MultusNetwork(ref),
 		"kubevirt.io/client-go/api/v1.NUMA":                                                      schema_kubevirtio_client_go_api_v1_NUMA(ref),
 		"kubevirt.io/client-go/api/v1.NUMAGuestMappingPassthrough":                               schema_kubevirtio_client_go_api_v1_NUMAGuestMappingPassthrough(ref),
 		"kubevirt.io/client-go/api/v1.Network":                                                   schema_kubevirtio_client_go_api_v1_Network(ref),
 		"kubevirt.io/client-go/api/v1.NetworkConfiguration":                                      schema_kubevirtio_client_go_api_v1_NetworkConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.NetworkSource":                                             schema_kubevirtio_client_go_api_v1_NetworkSource(ref),
+		"kubevirt.io/client-go/api/v1.NodeMediatedDeviceTypesConfig":                             schema_kubevirtio_client_go_api_v1_NodeMediatedDeviceTypesConfig(ref),
 		"kubevirt.io/client-go/api/v1.NodePlacement":                                             schema_kubevirtio_client_go_api_v1_NodePlacement(ref),
 		"kubevirt.io/client-go/api/v1.PITTimer":                                                  schema_kubevirtio_client_go_api_v1_PITTimer(ref),
+		"kubevirt.io/client-go/api/v1.PauseOptions":                                              schema_kubevirtio_client_go_api_v1_PauseOptions(ref),
 		"kubevirt.io/client-go/api/v1.PciHostDevice":                                             schema_kubevirtio_client_go_api_v1_PciHostDevice(ref),
 		"kubevirt.io/client-go/api/v1.PermittedHostDevices":                                      schema_kubevirtio_client_go_api_v1_PermittedHostDevices(ref),
 		"kubevirt.io/client-go/api/v1.PersistentVolumeClaimInfo":                                 schema_kubevirtio_client_go_api_v1_PersistentVolumeClaimInfo(ref),
@@ -497,6 +520,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.Port":                                                      schema_kubevirtio_client_go_api_v1_Port(ref),
 		"kubevirt.io/client-go/api/v1.Probe":                                                     schema_kubevirtio_client_go_api_v1_Probe(ref),
 		"kubevirt.io/client-go/api/v1.ProfilerResult":                                            schema_kubevirtio_client_go_api_v1_ProfilerResult(ref),
+		"kubevirt.io/client-go/api/v1.QEMUArg":                                                   schema_kubevirtio_client_go_api_v1_QEMUArg(ref),
 		"kubevirt.io/client-go/api/v1.QemuGuestAgentSSHPublicKeyAccessCredentialPropagation":     schema_kubevirtio_client_go_api_v1_QemuGuestAgentSSHPublicKeyAccessCredentialPropagation(ref),
 		"kubevirt.io/client-go/api/v1.QemuGuestAgentUserPasswordAccessCredentialPropagation":     schema_kubevirtio_client_go_api_v1_QemuGuestAgentUserPasswordAccessCredentialPropagation(ref),
 		"kubevirt.io/client-go/api/v1.RESTClientConfiguration":                                   schema_kubevirtio_client_go_api_v1_RESTClientConfiguration(ref),
@@ -504,6 +528,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.RateLimiter":                                               schema_kubevirtio_client_go_api_v1_RateLimiter(ref),
 		"kubevirt.io/client-go/api/v1.ReloadableComponentConfiguration":                          schema_kubevirtio_client_go_api_v1_ReloadableComponentConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.RemoveVolumeOptions":                                       schema_kubevirtio_client_go_api_v1_RemoveVolumeOptions(ref),
+		"kubevirt.io/client-go/api/v1.RenameOptions":                                             schema_kubevirtio_client_go_api_v1_RenameOptions(ref),
 		"kubevirt.io/client-go/api/v1.ResourceRequirements":                                      schema_kubevirtio_client_go_api_v1_ResourceRequirements(ref),
 		"kubevirt.io/client-go/api/v1.RestartOptions":                                            schema_kubevirtio_client_go_api_v1_RestartOptions(ref),
 		"kubevirt.io/client-go/api/v1.Rng":                                                       schema_kubevirtio_client_go_api_v1_Rng(ref),
@@ -513,21 +538,30 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.SSHPublicKeyAccessCredentialSource":                        schema_kubevirtio_client_go_api_v1_SSHPublicKeyAccessCredentialSource(ref),
 		"kubevirt.io/client-go/api/v1.SecretVolumeSource":                                        schema_kubevirtio_client_go_api_v1_SecretVolumeSource(ref),
 		"kubevirt.io/client-go/api/v1.ServiceAccountVolumeSource":                                schema_kubevirtio_client_go_api_v1_ServiceAccountVolumeSource(ref),
+		"kubevirt.io/client-go/api/v1.SoundDevice":                                               schema_kubevirtio_client_go_api_v1_SoundDevice(ref),
 		"kubevirt.io/client-go/api/v1.StartOptions":                                              schema_kubevirtio_client_go_api_v1_StartOptions(ref),
 		"kubevirt.io/client-go/api/v1.StopOptions":                                               schema_kubevirtio_client_go_api_v1_StopOptions(ref),
+		"kubevirt.io/client-go/api/v1.SwapConfiguration":                                         schema_kubevirtio_client_go_api_v1_SwapConfiguration(ref),
 		"kubevirt.io/client-go/api/v1.SyNICTimer":                                                schema_kubevirtio_client_go_api_v1_SyNICTimer(ref),
 		"kubevirt.io/client-go/api/v1.SysprepSource":                                             schema_kubevirtio_client_go_api_v1_SysprepSource(ref),
 		"kubevirt.io/client-go/api/v1.Timer":                                                     schema_kubevirtio_client_go_api_v1_Timer(ref),
 		"kubevirt.io/client-go/api/v1.TokenBucketRateLimiter":                                    schema_kubevirtio_client_go_api_v1_TokenBucketRateLimiter(ref),
 		"kubevirt.io/client-go/api/v1.TopologyHints":                                             schema_kubevirtio_client_go_api_v1_TopologyHints(ref),
+		"kubevirt.io/client-go/api/v1.USBHostDevice":                                             schema_kubevirtio_client_go_api_v1_USBHostDevice(ref),
+		"kubevirt.io/client-go/api/v1.USBSelector":                                               schema_kubevirtio_client_go_api_v1_USBSelector(ref),
+		"kubevirt.io/client-go/api/v1.UnpauseOptions":                                            schema_kubevirtio_client_go_api_v1_UnpauseOptions(ref),
 		"kubevirt.io/client-go/api/v1.UserPasswordAccessCredential":                              schema_kubevirtio_client_go_api_v1_UserPasswordAccessCredential(ref),
 		"kubevirt.io/client-go/api/v1.UserPasswordAccessCredentialPropagationMethod":             schema_kubevirtio_client_go_api_v1_UserPasswordAccessCredentialPropagationMethod(ref),
 		"kubevirt.io/client-go/api/v1.UserPasswordAccessCredentialSource":                        schema_kubevirtio_client_go_api_v1_UserPasswordAccessCredentialSource(ref),
+		"kubevirt.io/client-go/api/v1.VGPUDisplayOptions":                                        schema_kubevirtio_client_go_api_v1_VGPUDisplayOptions(ref),
+		"kubevirt.io/client-go/api/v1.VGPUOptions":                                               schema_kubevirtio_client_go_api_v1_VGPUOptions(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachine":                                            schema_kubevirtio_client_go_api_v1_VirtualMachine(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineAvailabilityStatus":                          schema_kubevirtio_client_go_api_v1_VirtualMachineAvailabilityStatus(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineCondition":                                   schema_kubevirtio_client_go_api_v1_VirtualMachineCondition(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstance":                                    schema_kubevirtio_client_go_api_v1_VirtualMachineInstance(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceCondition":                           schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceCondition(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceFileSystem":                          schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceFileSystem(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceFileSystemFreeSpace":                 schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceFileSystemFreeSpace(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceFileSystemInfo":                      schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceFileSystemInfo(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceFileSystemList":                      schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceFileSystemList(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestAgentInfo":                      schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceGuestAgentInfo(ref),
@@ -538,6 +572,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigration":                           schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigration(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationCondition":                  schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationCondition(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationList":                       schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationList(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationProgress":                   schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationProgress(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationSpec":                       schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationSpec(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationState":                      schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationState(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationStatus":                     schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationStatus(ref),
@@ -552,15 +587,20 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceReplicaSetSpec":                      schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceReplicaSetSpec(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceReplicaSetStatus":                    schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceReplicaSetStatus(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceSpec":                                schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceSpec(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceStartupTimestamps":                   schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceStartupTimestamps(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceStatus":                              schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceStatus(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineInstanceTemplateSpec":                        schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceTemplateSpec(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineList":                                        schema_kubevirtio_client_go_api_v1_VirtualMachineList(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineMemoryDumpRequest":                           schema_kubevirtio_client_go_api_v1_VirtualMachineMemoryDumpRequest(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineSchedule":                                    schema_kubevirtio_client_go_api_v1_VirtualMachineSchedule(ref),
+		"kubevirt.io/client-go/api/v1.VirtualMachineScheduleStatus":                              schema_kubevirtio_client_go_api_v1_VirtualMachineScheduleStatus(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineSpec":                                        schema_kubevirtio_client_go_api_v1_VirtualMachineSpec(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineStartFailure":                                schema_kubevirtio_client_go_api_v1_VirtualMachineStartFailure(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineStateChangeRequest":                          schema_kubevirtio_client_go_api_v1_VirtualMachineStateChangeRequest(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineStatus":                                      schema_kubevirtio_client_go_api_v1_VirtualMachineStatus(ref),
 		"kubevirt.io/client-go/api/v1.VirtualMachineVolumeRequest":                               schema_kubevirtio_client_go_api_v1_VirtualMachineVolumeRequest(ref),
 		"kubevirt.io/client-go/api/v1.Volume":                                                    schema_kubevirtio_client_go_api_v1_Volume(ref),
+		"kubevirt.io/client-go/api/v1.VolumeDiskStatus":                                          schema_kubevirtio_client_go_api_v1_VolumeDiskStatus(ref),
 		"kubevirt.io/client-go/api/v1.VolumeSnapshotStatus":                                      schema_kubevirtio_client_go_api_v1_VolumeSnapshotStatus(ref),
 		"kubevirt.io/client-go/api/v1.VolumeSource":                                              schema_kubevirtio_client_go_api_v1_VolumeSource(ref),
 		"kubevirt.io/client-go/api/v1.VolumeStatus":                                              schema_kubevirtio_client_go_api_v1_VolumeStatus(ref),
@@ -18782,6 +18822,33 @@ func schema_kubevirtio_client_go_api_v1_AddVolumeOptions(ref common.ReferenceCal
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_AuditLogConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AuditLogConfiguration holds the options of the audit log of virt-api",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"sink": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Sink is where the audit events are written to, either \"stdout\" or \"file\". Defaults to \"stdout\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path is the file which the audit events are appended to, if the sink is \"file\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_AuthorizedKeysFile(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -18823,6 +18890,34 @@ func schema_kubevirtio_client_go_api_v1_BIOS(ref common.ReferenceCallback) commo
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_BandwidthLimit(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BandwidthLimit is a token bucket limiting the traffic of one direction",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"rate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Rate is the average rate in bits per second, e.g. 100M.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"burst": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Burst is the amount of bytes which can exceed the rate at once, e.g. 1Mi. Defaults to the traffic of 100ms at the rate, but at least 64Ki.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+				},
+				Required: []string{"rate"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_BlockSize(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -18848,6 +18943,26 @@ func schema_kubevirtio_client_go_api_v1_BlockSize(ref common.ReferenceCallback)
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_BootMenu(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BootMenu configures the boot menu of the firmware",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"timeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timeout is how long the boot menu waits for a pick, in milliseconds, before it boots the first device of the boot order. At most 65535. Defaults to the timeout of the firmware.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_Bootloader(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -19377,6 +19492,62 @@ func schema_kubevirtio_client_go_api_v1_ConfigMapVolumeSource(ref common.Referen
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_ConsoleRecordingConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ConsoleRecordingConfiguration holds the options for recording serial console and VNC sessions",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"objectStoreURL": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObjectStoreURL is the base URL of a bucket in an object store. Every recorded session is uploaded to it with a HTTP PUT request once the session is closed. If the kubevirt-console-recording-credentials secret exists in the KubeVirt install namespace, its \"token\" key is sent as bearer token.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"recordVNC": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RecordVNC enables the recording of VNC sessions. Only serial console sessions are recorded by default.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"objectStoreURL"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_ContainerDiskOverlay(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ContainerDiskOverlay configures the writable overlay of a containerDisk.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"capacity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Capacity is the size of the disk the guest sees, which has to be at least the size of the image. Defaults to the size of the image.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"claimName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClaimName is the name of a filesystem PersistentVolumeClaim in the namespace of the VirtualMachineInstance to store the overlay on, which keeps the writes of the guest across restarts. Defaults to an emptyDir.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_ContainerDiskSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -19412,10 +19583,45 @@ func schema_kubevirtio_client_go_api_v1_ContainerDiskSource(ref common.Reference
 							Format:      "",
 						},
 					},
+					"overlay": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Overlay configures the writable copy-on-write overlay, which uses the image as its backing file. By default the overlay is stored in an emptyDir and has the size of the image.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.ContainerDiskOverlay"),
+						},
+					},
 				},
 				Required: []string{"image"},
 			},
 		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.ContainerDiskOverlay"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_CrashLoopBackOffConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CrashLoopBackOffConfiguration holds the settings of the exponential backoff which delays restarting VirtualMachineInstances that keep failing",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"maxDelaySeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxDelaySeconds caps the delay before a failed VirtualMachineInstance is started again. Defaults to 300.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"resetAfterSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ResetAfterSeconds is the time a VirtualMachineInstance of a VirtualMachine with the RerunOnFailure run strategy has to run until its failures are no longer counted as a crash loop. Defaults to 600.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -19768,6 +19974,13 @@ func schema_kubevirtio_client_go_api_v1_DeveloperConfiguration(ref common.Refere
 							Ref: ref("kubevirt.io/client-go/api/v1.LogVerbosity"),
 						},
 					},
+					"logFormat": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LogFormat is the format of the lines of the libraries used by the components, like client-go. With json they are written as JSON like the lines of the components, with text they keep their own format. Defaults to text.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -19862,6 +20075,13 @@ func schema_kubevirtio_client_go_api_v1_Devices(ref common.ReferenceCallback) co
 							Format:      "",
 						},
 					},
+					"logSerialConsole": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether to log the output of the auto-attached serial console to the guest-console-log container of the virt-launcher pod, where it can be read with kubectl logs. Not relevant if autoattachSerialConsole is false. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"autoattachMemBalloon": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Whether to attach the Memory balloon device with default period. Period can be adjusted in virt-config. Defaults to true.",
@@ -19869,6 +20089,13 @@ func schema_kubevirtio_client_go_api_v1_Devices(ref common.ReferenceCallback) co
 							Format:      "",
 						},
 					},
+					"freePageReporting": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether the guest reports the memory pages it freed to the host through the Memory balloon device, so that they can be reclaimed. Defaults to true if the memory of the vmi is overcommitted.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"rng": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Whether to have random number generator from host",
@@ -19877,14 +20104,14 @@ func schema_kubevirtio_client_go_api_v1_Devices(ref common.ReferenceCallback) co
 					},
 					"blockMultiQueue": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Whether or not to enable virtio multi-queue for block devices. Defaults to false.",
+							Description: "Whether or not to enable virtio multi-queue for block devices. The number of queues equals the number of vCPUs, unless a disk sets its own queue count. Defaults to false.",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
 					"networkInterfaceMultiqueue": {
 						SchemaProps: spec.SchemaProps{
-							Description: "If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.",
+							Description: "If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs. Interfaces which set their own queue count are not affected.",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
@@ -19949,11 +20176,24 @@ func schema_kubevirtio_client_go_api_v1_Devices(ref common.ReferenceCallback) co
 							Ref:         ref("kubevirt.io/client-go/api/v1.ClientPassthroughDevices"),
 						},
 					},
+					"sound": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether to emulate a sound device.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.SoundDevice"),
+						},
+					},
+					"autoattachVSOCK": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether to attach a VSOCK device to the vmi, through which the host can talk to processes in the guest without any network. The CID of the device is reported in the status of the vmi. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.ClientPassthroughDevices", "kubevirt.io/client-go/api/v1.Disk", "kubevirt.io/client-go/api/v1.Filesystem", "kubevirt.io/client-go/api/v1.GPU", "kubevirt.io/client-go/api/v1.HostDevice", "kubevirt.io/client-go/api/v1.Input", "kubevirt.io/client-go/api/v1.Interface", "kubevirt.io/client-go/api/v1.Rng", "kubevirt.io/client-go/api/v1.Watchdog"},
+			"kubevirt.io/client-go/api/v1.ClientPassthroughDevices", "kubevirt.io/client-go/api/v1.Disk", "kubevirt.io/client-go/api/v1.Filesystem", "kubevirt.io/client-go/api/v1.GPU", "kubevirt.io/client-go/api/v1.HostDevice", "kubevirt.io/client-go/api/v1.Input", "kubevirt.io/client-go/api/v1.Interface", "kubevirt.io/client-go/api/v1.Rng", "kubevirt.io/client-go/api/v1.SoundDevice", "kubevirt.io/client-go/api/v1.Watchdog"},
 	}
 }
 
@@ -20008,6 +20248,13 @@ func schema_kubevirtio_client_go_api_v1_Disk(ref common.ReferenceCallback) commo
 							Format:      "",
 						},
 					},
+					"wwn": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WWN is the World Wide Name of the disk device, made up of 16 hexadecimal digits. Only allowed for disks and cdroms on the sata or scsi bus.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"dedicatedIOThread": {
 						SchemaProps: spec.SchemaProps{
 							Description: "dedicatedIOThread indicates this disk should have an exclusive IO Thread. Enabling this implies useIOThreads = true. Defaults to false.",
@@ -20017,7 +20264,7 @@ func schema_kubevirtio_client_go_api_v1_Disk(ref common.ReferenceCallback) commo
 					},
 					"cache": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Cache specifies which kvm disk cache mode should be used. Supported values are: CacheNone, CacheWriteThrough.",
+							Description: "Cache specifies which kvm disk cache mode should be used. Supported values are: CacheNone, CacheWriteThrough, CacheWriteBack.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -20029,6 +20276,13 @@ func schema_kubevirtio_client_go_api_v1_Disk(ref common.ReferenceCallback) commo
 							Format:      "",
 						},
 					},
+					"discard": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Discard specifies if discard requests of the guest are passed to the storage of the disk. Supported values are: unmap, ignore. Defaults to unmap, or to ignore for preallocated volumes. Not supported for cdroms and floppies.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"tag": {
 						SchemaProps: spec.SchemaProps{
 							Description: "If specified, disk address and its tag will be provided to the guest via config drive metadata",
@@ -20042,12 +20296,75 @@ func schema_kubevirtio_client_go_api_v1_Disk(ref common.ReferenceCallback) commo
 							Ref:         ref("kubevirt.io/client-go/api/v1.BlockSize"),
 						},
 					},
+					"queues": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Queues sets the number of virtio-blk queues of this disk, overriding blockMultiQueue. Only allowed for disks on the virtio bus. Must not exceed the number of vCPUs.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"ioTune": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IOTune limits the throughput and the IOPS of the disk.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.DiskIOTune"),
+						},
+					},
 				},
 				Required: []string{"name"},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.BlockSize", "kubevirt.io/client-go/api/v1.CDRomTarget", "kubevirt.io/client-go/api/v1.DiskTarget", "kubevirt.io/client-go/api/v1.FloppyTarget", "kubevirt.io/client-go/api/v1.LunTarget"},
+			"kubevirt.io/client-go/api/v1.BlockSize", "kubevirt.io/client-go/api/v1.CDRomTarget", "kubevirt.io/client-go/api/v1.DiskIOTune", "kubevirt.io/client-go/api/v1.DiskTarget", "kubevirt.io/client-go/api/v1.FloppyTarget", "kubevirt.io/client-go/api/v1.LunTarget"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_DiskConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DiskConfiguration holds the cluster wide defaults and limits of disks",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"storageClassCacheModes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StorageClassCacheModes maps the names of storage classes to the cache mode of the disks on their PersistentVolumeClaims which don't set a cache mode. Supported values are: none, writethrough, writeback. Disks with writeback can not be live migrated.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"containerDiskEphemeralStorageLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ContainerDiskEphemeralStorageLimit is the amount of data a VirtualMachineInstance can write to each of its containerDisks. virt-launcher pods which write more are evicted.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"defaultBus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DefaultBus is the bus of the disks which don't set one. Supported values are: virtio, sata, scsi, nvme. Defaults to sata.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"virtioWinContainerDiskImage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VirtioWinContainerDiskImage is a containerDisk image with the virtio-win drivers. It is attached as a CD-ROM to Windows VirtualMachineInstances, so the drivers of the virtio devices can be installed during the Windows setup.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
@@ -20090,6 +20407,60 @@ func schema_kubevirtio_client_go_api_v1_DiskDevice(ref common.ReferenceCallback)
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_DiskIOTune(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DiskIOTune limits the I/O of a disk. A total limit can not be combined with a read or write limit of the same kind.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"totalBytesPerSec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TotalBytesPerSec limits the bytes read and written per second, e.g. 100Mi.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"readBytesPerSec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadBytesPerSec limits the bytes read per second.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"writeBytesPerSec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WriteBytesPerSec limits the bytes written per second.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"totalIOPS": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TotalIOPS limits the read and write operations per second.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"readIOPS": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadIOPS limits the read operations per second.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"writeIOPS": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WriteIOPS limits the write operations per second.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_DiskTarget(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -20098,7 +20469,7 @@ func schema_kubevirtio_client_go_api_v1_DiskTarget(ref common.ReferenceCallback)
 				Properties: map[string]spec.Schema{
 					"bus": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi.",
+							Description: "Bus indicates the type of disk device to emulate. supported values: virtio, sata, scsi, nvme.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -20144,13 +20515,54 @@ func schema_kubevirtio_client_go_api_v1_DiskVerification(ref common.ReferenceCal
 	}
 }
 
-func schema_kubevirtio_client_go_api_v1_DomainSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_kubevirtio_client_go_api_v1_DomainMemoryDumpInfo(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "DomainMemoryDumpInfo represents the progress of a memory dump written to a memory dump volume",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"resources": {
+					"startTimestamp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartTimestamp is the time when the memory dump started",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"endTimestamp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EndTimestamp is the time when the memory dump completed",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"claimName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClaimName is the name of the PersistentVolumeClaim the memory dump is written to",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"targetFileName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TargetFileName is the name of the memory dump file on the PersistentVolumeClaim",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_DomainSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"resources": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Resources describes the Compute Resources required by this vmi.",
 							Ref:         ref("kubevirt.io/client-go/api/v1.ResourceRequirements"),
@@ -20211,12 +20623,25 @@ func schema_kubevirtio_client_go_api_v1_DomainSpec(ref common.ReferenceCallback)
 							Ref:         ref("kubevirt.io/client-go/api/v1.Chassis"),
 						},
 					},
+					"qemuArgs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "QEMUArgs are appended to the QEMU command line of the vmi. Requires the QEMUArgs feature gate, only arguments which are allowed in the KubeVirt configuration are accepted.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.QEMUArg"),
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"devices"},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.CPU", "kubevirt.io/client-go/api/v1.Chassis", "kubevirt.io/client-go/api/v1.Clock", "kubevirt.io/client-go/api/v1.Devices", "kubevirt.io/client-go/api/v1.Features", "kubevirt.io/client-go/api/v1.Firmware", "kubevirt.io/client-go/api/v1.Machine", "kubevirt.io/client-go/api/v1.Memory", "kubevirt.io/client-go/api/v1.ResourceRequirements"},
+			"kubevirt.io/client-go/api/v1.CPU", "kubevirt.io/client-go/api/v1.Chassis", "kubevirt.io/client-go/api/v1.Clock", "kubevirt.io/client-go/api/v1.Devices", "kubevirt.io/client-go/api/v1.Features", "kubevirt.io/client-go/api/v1.Firmware", "kubevirt.io/client-go/api/v1.Machine", "kubevirt.io/client-go/api/v1.Memory", "kubevirt.io/client-go/api/v1.QEMUArg", "kubevirt.io/client-go/api/v1.ResourceRequirements"},
 	}
 }
 
@@ -20568,6 +20993,12 @@ func schema_kubevirtio_client_go_api_v1_Features(ref common.ReferenceCallback) c
 							Ref:         ref("kubevirt.io/client-go/api/v1.FeatureHyperv"),
 						},
 					},
+					"hypervPassthrough": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HypervPassthrough enables all the Hyper-V enlightenments which are supported by the host. It can't be combined with hyperv. VMIs with Hyper-V passthrough are not live migratable.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.HyperVPassthrough"),
+						},
+					},
 					"smm": {
 						SchemaProps: spec.SchemaProps{
 							Description: "SMM enables/disables System Management Mode. TSEG not yet implemented.",
@@ -20590,7 +21021,7 @@ func schema_kubevirtio_client_go_api_v1_Features(ref common.ReferenceCallback) c
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.FeatureAPIC", "kubevirt.io/client-go/api/v1.FeatureHyperv", "kubevirt.io/client-go/api/v1.FeatureKVM", "kubevirt.io/client-go/api/v1.FeatureState"},
+			"kubevirt.io/client-go/api/v1.FeatureAPIC", "kubevirt.io/client-go/api/v1.FeatureHyperv", "kubevirt.io/client-go/api/v1.FeatureKVM", "kubevirt.io/client-go/api/v1.FeatureState", "kubevirt.io/client-go/api/v1.HyperVPassthrough"},
 	}
 }
 
@@ -20622,6 +21053,41 @@ func schema_kubevirtio_client_go_api_v1_Filesystem(ref common.ReferenceCallback)
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_FilesystemOverhead(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FilesystemOverhead holds the fractions of filesystem PersistentVolumeClaims reserved for the filesystem, as decimals below 1 with up to three digits, e.g. \"0.055\" for 5.5%",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"global": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Global is the overhead of the storage classes without an entry in StorageClass. Defaults to no overhead.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"storageClass": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StorageClass maps the names of storage classes to their overhead.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_FilesystemVirtiofs(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -20664,11 +21130,17 @@ func schema_kubevirtio_client_go_api_v1_Firmware(ref common.ReferenceCallback) c
 							Ref:         ref("kubevirt.io/client-go/api/v1.KernelBoot"),
 						},
 					},
+					"bootMenu": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BootMenu shows a menu while booting, in which the device to boot from can be picked in the console, instead of booting the devices in their boot order.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.BootMenu"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.Bootloader", "kubevirt.io/client-go/api/v1.KernelBoot"},
+			"kubevirt.io/client-go/api/v1.BootMenu", "kubevirt.io/client-go/api/v1.Bootloader", "kubevirt.io/client-go/api/v1.KernelBoot"},
 	}
 }
 
@@ -20772,10 +21244,18 @@ func schema_kubevirtio_client_go_api_v1_GPU(ref common.ReferenceCallback) common
 							Format: "",
 						},
 					},
+					"virtualGPUOptions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VirtualGPUOptions configures vGPU (mediated device) specific options. It is ignored for GPUs which are passed through as PCI devices.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VGPUOptions"),
+						},
+					},
 				},
 				Required: []string{"name", "deviceName"},
 			},
 		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.VGPUOptions"},
 	}
 }
 
@@ -20872,6 +21352,111 @@ func schema_kubevirtio_client_go_api_v1_GuestAgentPing(ref common.ReferenceCallb
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_GuestExecCommand(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "GuestExecCommand is a command of the guestExecAllowList",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path is the path of the command in the guest, like \"/usr/bin/uptime\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"args": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Args are regular expressions for the arguments of the command. The command can only be run with one argument per expression, which has to match the expression completely. Without args the command can only be run without arguments.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"path"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_GuestExecOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "GuestExecOptions is provided when running a command in the guest of a running VMI",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"command": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Command is the path of the command in the guest. It has to be part of the guestExecAllowList of the KubeVirt configuration.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"args": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Args are passed to the command. They have to match the args of the command in the guestExecAllowList.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"timeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeoutSeconds is the time the command may run. Defaults to 10 seconds.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"command"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_GuestExecResult(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "GuestExecResult is returned once a command ran in the guest of a VMI",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"exitCode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExitCode is the exit code of the command",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"stdOut": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StdOut is the standard output of the command",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"exitCode"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_HPETTimer(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -21041,6 +21626,25 @@ func schema_kubevirtio_client_go_api_v1_Hugepages(ref common.ReferenceCallback)
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_HyperVPassthrough(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"enabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enabled determines if the Hyper-V passthrough mode should be used. Defaults to true.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_HypervTimer(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -21159,6 +21763,11 @@ func schema_kubevirtio_client_go_api_v1_Interface(ref common.ReferenceCallback)
 							Ref: ref("kubevirt.io/client-go/api/v1.InterfaceMacvtap"),
 						},
 					},
+					"vhostuser": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.InterfaceVhostUser"),
+						},
+					},
 					"ports": {
 						SchemaProps: spec.SchemaProps{
 							Description: "List of ports to be forwarded to the virtual machine.",
@@ -21206,12 +21815,52 @@ func schema_kubevirtio_client_go_api_v1_Interface(ref common.ReferenceCallback)
 							Format:      "",
 						},
 					},
+					"queues": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Queues sets the number of vhost queues of this interface, overriding networkInterfaceMultiqueue. Only allowed for virtio interfaces. Must not exceed the number of vCPUs.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"bandwidth": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Bandwidth limits the traffic of the interface. Only allowed for bridge and masquerade interfaces.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.InterfaceBandwidth"),
+						},
+					},
 				},
 				Required: []string{"name"},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.DHCPOptions", "kubevirt.io/client-go/api/v1.InterfaceBridge", "kubevirt.io/client-go/api/v1.InterfaceMacvtap", "kubevirt.io/client-go/api/v1.InterfaceMasquerade", "kubevirt.io/client-go/api/v1.InterfaceSRIOV", "kubevirt.io/client-go/api/v1.InterfaceSlirp", "kubevirt.io/client-go/api/v1.Port"},
+			"kubevirt.io/client-go/api/v1.DHCPOptions", "kubevirt.io/client-go/api/v1.InterfaceBandwidth", "kubevirt.io/client-go/api/v1.InterfaceBridge", "kubevirt.io/client-go/api/v1.InterfaceMacvtap", "kubevirt.io/client-go/api/v1.InterfaceMasquerade", "kubevirt.io/client-go/api/v1.InterfaceSRIOV", "kubevirt.io/client-go/api/v1.InterfaceSlirp", "kubevirt.io/client-go/api/v1.InterfaceVhostUser", "kubevirt.io/client-go/api/v1.Port"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_InterfaceBandwidth(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InterfaceBandwidth limits the traffic of an interface in both directions",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"ingress": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Ingress limits the traffic received by the guest.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.BandwidthLimit"),
+						},
+					},
+					"egress": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Egress limits the traffic sent by the guest.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.BandwidthLimit"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.BandwidthLimit"},
 	}
 }
 
@@ -21247,11 +21896,16 @@ func schema_kubevirtio_client_go_api_v1_InterfaceBindingMethod(ref common.Refere
 							Ref: ref("kubevirt.io/client-go/api/v1.InterfaceMacvtap"),
 						},
 					},
+					"vhostuser": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.InterfaceVhostUser"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.InterfaceBridge", "kubevirt.io/client-go/api/v1.InterfaceMacvtap", "kubevirt.io/client-go/api/v1.InterfaceMasquerade", "kubevirt.io/client-go/api/v1.InterfaceSRIOV", "kubevirt.io/client-go/api/v1.InterfaceSlirp"},
+			"kubevirt.io/client-go/api/v1.InterfaceBridge", "kubevirt.io/client-go/api/v1.InterfaceMacvtap", "kubevirt.io/client-go/api/v1.InterfaceMasquerade", "kubevirt.io/client-go/api/v1.InterfaceSRIOV", "kubevirt.io/client-go/api/v1.InterfaceSlirp", "kubevirt.io/client-go/api/v1.InterfaceVhostUser"},
 	}
 }
 
@@ -21305,6 +21959,17 @@ func schema_kubevirtio_client_go_api_v1_InterfaceSlirp(ref common.ReferenceCallb
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_InterfaceVhostUser(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InterfaceVhostUser connects the interface to a userspace dataplane (e.g. OVS-DPDK or VPP) over a vhost-user socket. QEMU creates the socket in server mode, named after the interface, in the vhostuser directory shared by the virt-launcher pod, and the dataplane connects to it. Requires a Multus network and hugepages backed guest memory.",
+				Type:        []string{"object"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_KVMTimer(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -21672,26 +22337,119 @@ func schema_kubevirtio_client_go_api_v1_KubeVirtConfiguration(ref common.Referen
 							Ref: ref("kubevirt.io/client-go/api/v1.ReloadableComponentConfiguration"),
 						},
 					},
-				},
-			},
-		},
-		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/api/resource.Quantity", "kubevirt.io/client-go/api/v1.DeveloperConfiguration", "kubevirt.io/client-go/api/v1.MediatedDevicesConfiguration", "kubevirt.io/client-go/api/v1.MigrationConfiguration", "kubevirt.io/client-go/api/v1.NetworkConfiguration", "kubevirt.io/client-go/api/v1.PermittedHostDevices", "kubevirt.io/client-go/api/v1.ReloadableComponentConfiguration", "kubevirt.io/client-go/api/v1.SMBiosConfiguration"},
-	}
-}
-
-func schema_kubevirtio_client_go_api_v1_KubeVirtList(ref common.ReferenceCallback) common.OpenAPIDefinition {
-	return common.OpenAPIDefinition{
-		Schema: spec.Schema{
-			SchemaProps: spec.SchemaProps{
-				Description: "KubeVirtList is a list of KubeVirts",
-				Type:        []string{"object"},
-				Properties: map[string]spec.Schema{
-					"kind": {
+					"operatorConfiguration": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
-							Type:        []string{"string"},
-							Format:      "",
+							Ref: ref("kubevirt.io/client-go/api/v1.ReloadableComponentConfiguration"),
+						},
+					},
+					"consoleRecording": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("kubevirt.io/client-go/api/v1.ConsoleRecordingConfiguration"),
+						},
+					},
+					"guestDefaultsUpdateStrategy": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"qemuArgsAllowList": {
+						SchemaProps: spec.SchemaProps{
+							Description: "QEMUArgsAllowList holds the names of the QEMU arguments, like \"-fw_cfg\", which VirtualMachineInstances may append to the QEMU command line. Requires the QEMUArgs feature gate.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"swap": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Swap configures the swap usage of VirtualMachineInstances with Burstable memory. Requires the VMSwap feature gate.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.SwapConfiguration"),
+						},
+					},
+					"deprecatedMachineTypes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DeprecatedMachineTypes holds the machine types which VirtualMachines should no longer use, matched like emulatedMachines. VirtualMachines with a deprecated machine type are updated to machineType, which applies on their next restart.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"crashLoopBackOff": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CrashLoopBackOff configures the delay before VirtualMachines, whose VirtualMachineInstances keep failing, are started again.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.CrashLoopBackOffConfiguration"),
+						},
+					},
+					"filesystemOverhead": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FilesystemOverhead is the fraction of filesystem PersistentVolumeClaims which disk images leave free for the filesystem itself.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.FilesystemOverhead"),
+						},
+					},
+					"disks": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DiskConfiguration holds the cluster wide defaults and limits of disks.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.DiskConfiguration"),
+						},
+					},
+					"guestExecAllowList": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GuestExecAllowList holds the commands, with the arguments they may be run with, which may be run in the guests of VirtualMachineInstances through the guestexec subresource. Requires the GuestExec feature gate.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.GuestExecCommand"),
+									},
+								},
+							},
+						},
+					},
+					"auditLog": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AuditLog enables the audit log of virt-api, which records who accessed which subresource, like the console, of which VirtualMachine or VirtualMachineInstance.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.AuditLogConfiguration"),
+						},
+					},
+					"leaderElection": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LeaderElection configures the leader election of virt-controller and virt-operator. It is applied when they start.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.LeaderElectionConfiguration"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity", "kubevirt.io/client-go/api/v1.AuditLogConfiguration", "kubevirt.io/client-go/api/v1.ConsoleRecordingConfiguration", "kubevirt.io/client-go/api/v1.CrashLoopBackOffConfiguration", "kubevirt.io/client-go/api/v1.DeveloperConfiguration", "kubevirt.io/client-go/api/v1.DiskConfiguration", "kubevirt.io/client-go/api/v1.FilesystemOverhead", "kubevirt.io/client-go/api/v1.GuestExecCommand", "kubevirt.io/client-go/api/v1.LeaderElectionConfiguration", "kubevirt.io/client-go/api/v1.MediatedDevicesConfiguration", "kubevirt.io/client-go/api/v1.MigrationConfiguration", "kubevirt.io/client-go/api/v1.NetworkConfiguration", "kubevirt.io/client-go/api/v1.PermittedHostDevices", "kubevirt.io/client-go/api/v1.ReloadableComponentConfiguration", "kubevirt.io/client-go/api/v1.SMBiosConfiguration", "kubevirt.io/client-go/api/v1.SwapConfiguration"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_KubeVirtList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "KubeVirtList is a list of KubeVirts",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 					"apiVersion": {
@@ -22032,6 +22790,39 @@ func schema_kubevirtio_client_go_api_v1_KubeVirtWorkloadUpdateStrategy(ref commo
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_LeaderElectionConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LeaderElectionConfiguration holds the timing of the leader election of virt-controller and virt-operator",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"leaseDuration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LeaseDuration is the time which candidates wait after the last renewal of the leader, until they try to take the lead. Defaults to 15s.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"renewDeadline": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RenewDeadline is the time which the leader tries to renew its lease, until it gives up the lead. Must be less than the lease duration. Defaults to 10s.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"retryPeriod": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetryPeriod is the time between two attempts to take or to renew the lead. Defaults to 2s.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_LogVerbosity(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -22110,7 +22901,42 @@ func schema_kubevirtio_client_go_api_v1_LunTarget(ref common.ReferenceCallback)
 							Format:      "",
 						},
 					},
+					"reservation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reservation lets the guest issue SCSI-3 persistent reservation commands to the LUN, e.g. for the shared disks of failover clusters. Requires the scsi bus. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_MacAddressPool(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MacAddressPool is an inclusive range of unicast MAC addresses",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"rangeStart": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RangeStart is the first MAC address of the pool, e.g. \"02:00:00:00:00:00\"",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"rangeEnd": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RangeEnd is the last MAC address of the pool, e.g. \"02:00:00:ff:ff:ff\"",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
+				Required: []string{"rangeStart", "rangeEnd"},
 			},
 		},
 	}
@@ -22135,6 +22961,35 @@ func schema_kubevirtio_client_go_api_v1_Machine(ref common.ReferenceCallback) co
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_MediaChangeOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MediaChangeOptions is provided when changing the media of a CD-ROM of a running VMI",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name represents the name of the CD-ROM disk and its volume",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"volumeSource": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VolumeSource represents the source of the media to insert. The media is ejected if no volume source is provided.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.HotplugVolumeSource"),
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.HotplugVolumeSource"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_MediatedDevicesConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -22160,9 +23015,29 @@ func schema_kubevirtio_client_go_api_v1_MediatedDevicesConfiguration(ref common.
 							},
 						},
 					},
+					"nodeMediatedDeviceTypes": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeMediatedDeviceTypes overrides the mediatedDevicesTypes on the nodes matching one or more of their node selectors.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.NodeMediatedDeviceTypesConfig"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.NodeMediatedDeviceTypesConfig"},
 	}
 }
 
@@ -22225,6 +23100,27 @@ func schema_kubevirtio_client_go_api_v1_Memory(ref common.ReferenceCallback) com
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_MemoryDumpVolumeSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MemoryDumpVolumeSource represents a PersistentVolumeClaim a memory dump is written to.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"claimName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClaimName is the name of a PersistentVolumeClaim in the same namespace as the VirtualMachineInstance.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"claimName"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_MigrationConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -22291,6 +23187,13 @@ func schema_kubevirtio_client_go_api_v1_MigrationConfiguration(ref common.Refere
 							Format: "",
 						},
 					},
+					"parallelMigrationThreads": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ParallelMigrationThreads is the number of parallel multifd channels used to transfer the guest memory. Parallel migrations are not combined with post copy migrations.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 				},
 			},
 		},
@@ -22299,6 +23202,41 @@ func schema_kubevirtio_client_go_api_v1_MigrationConfiguration(ref common.Refere
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_MoveOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MoveOptions may be provided on move request.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"newNamespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The namespace the VirtualMachine and its volumes are moved to",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"newNamespace"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_MultusNetwork(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -22320,6 +23258,32 @@ func schema_kubevirtio_client_go_api_v1_MultusNetwork(ref common.ReferenceCallba
 							Format:      "",
 						},
 					},
+					"ipAddresses": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "IPAddresses requests static IP addresses in CIDR notation, e.g. 192.168.1.10/24, for the interface. They are passed to the \"ips\" capability of the CNI plugin. Not supported on the default network.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"ipPool": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IPPool references a pool of the IPAM plugin of the network from which the addresses of the interface are assigned. It is passed as the \"ipPool\" CNI arg. Not supported on the default network.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"networkName"},
 			},
@@ -22416,9 +23380,17 @@ func schema_kubevirtio_client_go_api_v1_NetworkConfiguration(ref common.Referenc
 							Format: "",
 						},
 					},
+					"macAddressPool": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MacAddressPool is the range of MAC addresses which virt-controller assigns to the interfaces of VirtualMachines without an explicit MAC address. The addresses are stored in the VirtualMachine and survive restarts and migrations.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.MacAddressPool"),
+						},
+					},
 				},
 			},
 		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.MacAddressPool"},
 	}
 }
 
@@ -22447,6 +23419,53 @@ func schema_kubevirtio_client_go_api_v1_NetworkSource(ref common.ReferenceCallba
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_NodeMediatedDeviceTypesConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NodeMediatedDeviceTypesConfig holds information about MDEV types to be defined on the nodes matching the NodeSelector",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"nodeSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeSelector must match the labels of a node for the mediated device types to be defined on it.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"mediatedDevicesTypes": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"nodeSelector", "mediatedDevicesTypes"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_NodePlacement(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -22522,6 +23541,52 @@ func schema_kubevirtio_client_go_api_v1_PITTimer(ref common.ReferenceCallback) c
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_PauseOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PauseOptions may be provided on pause request.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"dryRun": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "When present, indicates that the VMI is not paused, but only validated for pausing. An invalid or unrecognized dryRun directive will result in an error response and no further processing of the request. Valid values are: - All: all dry run stages will be processed",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_PciHostDevice(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -22598,11 +23663,28 @@ func schema_kubevirtio_client_go_api_v1_PermittedHostDevices(ref common.Referenc
 							},
 						},
 					},
+					"usb": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.USBHostDevice"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.MediatedHostDevice", "kubevirt.io/client-go/api/v1.PciHostDevice"},
+			"kubevirt.io/client-go/api/v1.MediatedHostDevice", "kubevirt.io/client-go/api/v1.PciHostDevice", "kubevirt.io/client-go/api/v1.USBHostDevice"},
 	}
 }
 
@@ -22660,6 +23742,20 @@ func schema_kubevirtio_client_go_api_v1_PersistentVolumeClaimInfo(ref common.Ref
 							Format:      "",
 						},
 					},
+					"filesystemOverhead": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FilesystemOverhead is the fraction of a filesystem PVC which the disk image leaves free for the filesystem",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"cacheMode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CacheMode is the cache mode of the disks on the PVC which don't set one, as configured for its storage class",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -22865,6 +23961,34 @@ func schema_kubevirtio_client_go_api_v1_ProfilerResult(ref common.ReferenceCallb
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_QEMUArg(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "QEMUArg is an argument which is appended to the QEMU command line.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the argument, including the leading dash, e.g. \"-fw_cfg\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"value": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Value of the argument, e.g. \"name=opt/com.example/config,string=value\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_QemuGuestAgentSSHPublicKeyAccessCredentialPropagation(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -23022,6 +24146,41 @@ func schema_kubevirtio_client_go_api_v1_RemoveVolumeOptions(ref common.Reference
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_RenameOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RenameOptions may be provided on rename request.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"newName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The new name of the VirtualMachine",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"newName"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_ResourceRequirements(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -23288,6 +24447,34 @@ func schema_kubevirtio_client_go_api_v1_ServiceAccountVolumeSource(ref common.Re
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_SoundDevice(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Represents the user's configuration to emulate sound cards in the VMI.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "User's defined name for this sound device",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"model": {
+						SchemaProps: spec.SchemaProps{
+							Description: "We only support ich9 or ac97. If SoundDevice is not set: No sound card is emulated. If SoundDevice is set but Model is not: ich9",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_StartOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -23345,7 +24532,27 @@ func schema_kubevirtio_client_go_api_v1_StopOptions(ref common.ReferenceCallback
 					},
 					"gracePeriod": {
 						SchemaProps: spec.SchemaProps{
-							Description: "this updates the VMIs terminationGracePeriodSeconds during shutdown",
+							Description: "this updates the VMIs terminationGracePeriodSeconds during shutdown",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_SwapConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SwapConfiguration holds the settings which virt-handler applies to the memory cgroup of virt-launcher pods which may use swap",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"swappiness": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Swappiness of the memory cgroup, between 0 and 100. It is only supported with cgroup v1, the swappiness of the node applies with cgroup v2.",
 							Type:        []string{"integer"},
 							Format:      "int64",
 						},
@@ -23498,6 +24705,128 @@ func schema_kubevirtio_client_go_api_v1_TopologyHints(ref common.ReferenceCallba
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_USBHostDevice(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "USBHostDevice represents a host USB device allowed for passthrough",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"selectors": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "The list of vendor:product selectors of the USB devices which are exposed with this resource",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.USBSelector"),
+									},
+								},
+							},
+						},
+					},
+					"resourceName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The name of the resource that is representing the device. Exposed by a device plugin and requested by VMs. Typically of the form vendor.com/product_name",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"externalResourceProvider": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If true, KubeVirt will leave the allocation and monitoring to an external device plugin",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"resourceName"},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.USBSelector"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_USBSelector(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "USBSelector selects USB devices by their vendor and product IDs",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"vendor": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The vendor ID of the USB device, e.g. 046d",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"product": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The product ID of the USB device, e.g. c52b",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"vendor", "product"},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_UnpauseOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "UnpauseOptions may be provided on unpause request.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"dryRun": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "When present, indicates that the VMI is not unpaused, but only validated for unpausing. An invalid or unrecognized dryRun directive will result in an error response and no further processing of the request. Valid values are: - All: all dry run stages will be processed",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_UserPasswordAccessCredential(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -23568,6 +24897,53 @@ func schema_kubevirtio_client_go_api_v1_UserPasswordAccessCredentialSource(ref c
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_VGPUDisplayOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"enabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enabled determines if a display adapter backed by the vGPU should be enabled or disabled on the guest. Defaults to true.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"ramFB": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RamFB enables a boot framebuffer which is used until the guest OS loads a real GPU driver. Defaults to true.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.FeatureState"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.FeatureState"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VGPUOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"display": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Display configures the display adapter backed by the vGPU.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VGPUDisplayOptions"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/client-go/api/v1.VGPUDisplayOptions"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_VirtualMachine(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -23615,6 +24991,46 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachine(ref common.ReferenceCallb
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_VirtualMachineAvailabilityStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineAvailabilityStatus records the starts and the unplanned downtime of a VM, from which its availability metrics are derived",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"startRequestTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartRequestTime is the time the latest start of the VM was requested",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"readyTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadyTime is the time the guest became ready after the latest start request",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"failureTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FailureTime is the time the VMI failed, it is only set while the resulting downtime is ongoing",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"unplannedDowntimeSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UnplannedDowntimeSeconds is the cumulative downtime of the VM after its VMIs failed, without the ongoing downtime",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_VirtualMachineCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -23805,6 +25221,44 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceFileSystem(ref com
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceFileSystemFreeSpace(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineInstanceFileSystemFreeSpace represents the free space of a guest filesystem",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"mountPoint": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"fileSystemType": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"freeBytes": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"totalBytes": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+				},
+				Required: []string{"mountPoint", "fileSystemType", "freeBytes", "totalBytes"},
+			},
+		},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceFileSystemInfo(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -24315,6 +25769,54 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationList(ref
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationProgress(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"updateTimestamp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The time the progress was last updated",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"transferredBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The amount of data transferred to the target so far in bytes",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"memoryRemainingBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The amount of guest memory which still has to be transferred in bytes",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"dirtyPageRate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The rate at which the guest dirties its memory in pages per second",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"iteration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The number of passes over the guest memory, a high count means that the guest dirties its memory faster than it can be transferred",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -24451,11 +25953,17 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceMigrationState(ref
 							Format:      "",
 						},
 					},
+					"progress": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The progress of the migration, updated periodically while the migration is running",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationProgress"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationProgress"},
 	}
 }
 
@@ -24856,9 +26364,16 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceReplicaSetSpec(ref
 							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceTemplateSpec"),
 						},
 					},
-					"paused": {
+					"paused": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Indicates that the replica set is paused.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"headlessService": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Indicates that the replica set is paused.",
+							Description: "Indicates that a headless Service named after the subdomain of the template is created for the VirtualMachineInstances of the replica set. Together with the subdomain it gives every VirtualMachineInstance the DNS name <hostname>.<subdomain>.<namespace>.svc.",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
@@ -24928,7 +26443,7 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceSpec(ref common.Re
 				Properties: map[string]spec.Schema{
 					"priorityClassName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "If specified, indicates the pod's priority. If not specified, the pod priority will be default or zero if there is no default.",
+							Description: "If specified, indicates the pod's priority. If not specified, the pod priority will be default or zero if there is no default. VMIs with a higher priority are evacuated first when a node is drained.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -24982,7 +26497,7 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceSpec(ref common.Re
 					},
 					"evictionStrategy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "EvictionStrategy can be set to \"LiveMigrate\" if the VirtualMachineInstance should be migrated instead of shut-off in case of a node drain.",
+							Description: "EvictionStrategy can be set to \"LiveMigrate\" if the VirtualMachineInstance should be migrated instead of shut-off in case of a node drain, or to \"LiveMigrateIfPossible\" if it should be migrated when it is live-migratable and evicted otherwise.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -25026,6 +26541,12 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceSpec(ref common.Re
 							Ref:         ref("kubevirt.io/client-go/api/v1.Probe"),
 						},
 					},
+					"startupProbe": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Probe indicating that the VirtualMachineInstance has successfully started. Liveness and readiness probes are not executed until it succeeds. VirtualmachineInstances will be stopped if the probe fails. Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes",
+							Ref:         ref("kubevirt.io/client-go/api/v1.Probe"),
+						},
+					},
 					"hostname": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Specifies the hostname of the vmi If not specified, the hostname will be set to the name of the vmi, if dhcp or cloud-init is configured properly.",
@@ -25093,6 +26614,51 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceSpec(ref common.Re
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceStartupTimestamps(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineInstanceStartupTimestamps holds the times at which a VirtualMachineInstance passed the steps of its start. A step which was not reached yet is not set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"podScheduled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodScheduled is the time the virt-launcher pod was scheduled to a node",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"containerStarted": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ContainerStarted is the time the compute container of the virt-launcher pod started",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"domainDefined": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DomainDefined is the time virt-handler saw the domain defined in libvirt",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"domainRunning": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DomainRunning is the time virt-handler saw the domain running",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"guestAgentConnected": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GuestAgentConnected is the time the guest agent connected for the first time",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -25107,6 +26673,13 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceStatus(ref common.
 							Format:      "",
 						},
 					},
+					"nodeBootID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeBootID is the boot ID of the node the VirtualMachineInstance is currently running on. It allows detecting whether the node rebooted while the VirtualMachineInstance was running.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"reason": {
 						SchemaProps: spec.SchemaProps{
 							Description: "A brief CamelCase message indicating details about why the VMI is in this state. e.g. 'NodeUnresponsive'",
@@ -25171,6 +26744,31 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceStatus(ref common.
 							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSInfo"),
 						},
 					},
+					"guestHostname": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GuestHostname is the hostname reported by the guest agent",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"fsFreeSpace": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "FSFreeSpace is the free space of the guest filesystems reported by the guest agent",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceFileSystemFreeSpace"),
+									},
+								},
+							},
+						},
+					},
 					"migrationState": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Represents the status of a live migration",
@@ -25264,11 +26862,24 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineInstanceStatus(ref common.
 							Format:      "",
 						},
 					},
+					"VSOCKCID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VSOCKCID is the guest context ID of the VSOCK device, unique in the cluster. It is only set when autoattachVSOCK is enabled.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"startupTimestamps": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartupTimestamps holds the times at which the VirtualMachineInstance passed the steps of its start",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineInstanceStartupTimestamps"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.TopologyHints", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceCondition", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSInfo", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationState", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceNetworkInterface", "kubevirt.io/client-go/api/v1.VirtualMachineInstancePhaseTransitionTimestamp", "kubevirt.io/client-go/api/v1.VolumeStatus"},
+			"kubevirt.io/client-go/api/v1.TopologyHints", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceCondition", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceFileSystemFreeSpace", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceGuestOSInfo", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceMigrationState", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceNetworkInterface", "kubevirt.io/client-go/api/v1.VirtualMachineInstancePhaseTransitionTimestamp", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceStartupTimestamps", "kubevirt.io/client-go/api/v1.VolumeStatus"},
 	}
 }
 
@@ -25344,6 +26955,136 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineList(ref common.ReferenceC
 	}
 }
 
+func schema_kubevirtio_client_go_api_v1_VirtualMachineMemoryDumpRequest(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineMemoryDumpRequest represents a memory dump of a VM to a PersistentVolumeClaim",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"claimName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClaimName is the name of the PersistentVolumeClaim the memory dump is written to",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is the phase of the memory dump",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"remove": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Remove requests to dissociate the PersistentVolumeClaim from the VM",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"startTimestamp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartTimestamp is the time when the memory dump started",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"endTimestamp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EndTimestamp is the time when the memory dump completed",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"fileName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FileName is the name of the memory dump file on the PersistentVolumeClaim",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is a detailed message about a failed memory dump",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"claimName"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineSchedule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineSchedule defines at which times a VirtualMachine is started and stopped. A schedule acts like the start and stop subresources: a VirtualMachine which was started or stopped in between keeps its state until the next scheduled time.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"start": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Start is a cron expression in the five field format \"minute hour day-of-month month day-of-week\", e.g. \"0 8 * * 1-5\", at whose times the VirtualMachine is started",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"stop": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Stop is a cron expression in the five field format \"minute hour day-of-month month day-of-week\", e.g. \"0 20 * * *\", at whose times the VirtualMachine is stopped",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"timeZone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeZone is the name of the IANA time zone the cron expressions are evaluated in, e.g. \"Europe/Berlin\". Defaults to UTC.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VirtualMachineScheduleStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VirtualMachineScheduleStatus reports the scheduled starts and stops of a VM",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"lastScheduleTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastScheduleTime is the time of the last scheduled start or stop which was applied",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"nextStartTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NextStartTime is the time of the next scheduled start",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"nextStopTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NextStopTime is the time of the next scheduled stop",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_kubevirtio_client_go_api_v1_VirtualMachineSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -25365,6 +27106,19 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineSpec(ref common.ReferenceC
 							Format:      "",
 						},
 					},
+					"nodeRebootPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeRebootPolicy controls what happens to a VirtualMachineInstance which was running on a node that rebooted. Defaults to the behavior of the RunStrategy.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"schedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Schedule starts and stops the VirtualMachine at recurring times",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineSchedule"),
+						},
+					},
 					"template": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Template is the direct specification of VirtualMachineInstance",
@@ -25389,7 +27143,7 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineSpec(ref common.ReferenceC
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.DataVolumeTemplateSpec", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceTemplateSpec"},
+			"kubevirt.io/client-go/api/v1.DataVolumeTemplateSpec", "kubevirt.io/client-go/api/v1.VirtualMachineInstanceTemplateSpec", "kubevirt.io/client-go/api/v1.VirtualMachineSchedule"},
 	}
 }
 
@@ -25572,11 +27326,36 @@ func schema_kubevirtio_client_go_api_v1_VirtualMachineStatus(ref common.Referenc
 							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineStartFailure"),
 						},
 					},
+					"restartCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RestartCount is the number of failed VMIs which were started again after a crash loop backoff",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"schedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Schedule reports the scheduled starts and stops of the VM",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineScheduleStatus"),
+						},
+					},
+					"memoryDumpRequest": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MemoryDumpRequest tracks the memory dump request of the VM and the PersistentVolumeClaim it is associated with",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineMemoryDumpRequest"),
+						},
+					},
+					"availability": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Availability records the starts and the unplanned downtime of the VM",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VirtualMachineAvailabilityStatus"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.VirtualMachineCondition", "kubevirt.io/client-go/api/v1.VirtualMachineStartFailure", "kubevirt.io/client-go/api/v1.VirtualMachineStateChangeRequest", "kubevirt.io/client-go/api/v1.VirtualMachineVolumeRequest", "kubevirt.io/client-go/api/v1.VolumeSnapshotStatus"},
+			"kubevirt.io/client-go/api/v1.VirtualMachineAvailabilityStatus", "kubevirt.io/client-go/api/v1.VirtualMachineCondition", "kubevirt.io/client-go/api/v1.VirtualMachineMemoryDumpRequest", "kubevirt.io/client-go/api/v1.VirtualMachineScheduleStatus", "kubevirt.io/client-go/api/v1.VirtualMachineStartFailure", "kubevirt.io/client-go/api/v1.VirtualMachineStateChangeRequest", "kubevirt.io/client-go/api/v1.VirtualMachineVolumeRequest", "kubevirt.io/client-go/api/v1.VolumeSnapshotStatus"},
 	}
 }
 
@@ -25704,12 +27483,66 @@ func schema_kubevirtio_client_go_api_v1_Volume(ref common.ReferenceCallback) com
 							Ref:         ref("kubevirt.io/client-go/api/v1.DownwardMetricsVolumeSource"),
 						},
 					},
+					"memoryDump": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MemoryDump is a PersistentVolumeClaim which is hotplugged to the virt-launcher pod to receive a memory dump of the VirtualMachineInstance.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.MemoryDumpVolumeSource"),
+						},
+					},
 				},
 				Required: []string{"name"},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.CloudInitConfigDriveSource", "kubevirt.io/client-go/api/v1.CloudInitNoCloudSource", "kubevirt.io/client-go/api/v1.ConfigMapVolumeSource", "kubevirt.io/client-go/api/v1.ContainerDiskSource", "kubevirt.io/client-go/api/v1.DataVolumeSource", "kubevirt.io/client-go/api/v1.DownwardAPIVolumeSource", "kubevirt.io/client-go/api/v1.DownwardMetricsVolumeSource", "kubevirt.io/client-go/api/v1.EmptyDiskSource", "kubevirt.io/client-go/api/v1.EphemeralVolumeSource", "kubevirt.io/client-go/api/v1.HostDisk", "kubevirt.io/client-go/api/v1.PersistentVolumeClaimVolumeSource", "kubevirt.io/client-go/api/v1.SecretVolumeSource", "kubevirt.io/client-go/api/v1.ServiceAccountVolumeSource", "kubevirt.io/client-go/api/v1.SysprepSource"},
+			"kubevirt.io/client-go/api/v1.CloudInitConfigDriveSource", "kubevirt.io/client-go/api/v1.CloudInitNoCloudSource", "kubevirt.io/client-go/api/v1.ConfigMapVolumeSource", "kubevirt.io/client-go/api/v1.ContainerDiskSource", "kubevirt.io/client-go/api/v1.DataVolumeSource", "kubevirt.io/client-go/api/v1.DownwardAPIVolumeSource", "kubevirt.io/client-go/api/v1.DownwardMetricsVolumeSource", "kubevirt.io/client-go/api/v1.EmptyDiskSource", "kubevirt.io/client-go/api/v1.EphemeralVolumeSource", "kubevirt.io/client-go/api/v1.HostDisk", "kubevirt.io/client-go/api/v1.MemoryDumpVolumeSource", "kubevirt.io/client-go/api/v1.PersistentVolumeClaimVolumeSource", "kubevirt.io/client-go/api/v1.SecretVolumeSource", "kubevirt.io/client-go/api/v1.ServiceAccountVolumeSource", "kubevirt.io/client-go/api/v1.SysprepSource"},
+	}
+}
+
+func schema_kubevirtio_client_go_api_v1_VolumeDiskStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "VolumeDiskStatus represents how the disk of a volume is attached to the guest.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type is the type of the disk source, either block or file",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"format": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Format is the format of the disk image, eg: raw or qcow2",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"backingFormat": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BackingFormat is the format of the backing image of the disk, if it has one",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"cache": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cache is the effective cache mode of the disk",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"io": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IO is the effective IO mode of the disk",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -25838,11 +27671,17 @@ func schema_kubevirtio_client_go_api_v1_VolumeSource(ref common.ReferenceCallbac
 							Ref:         ref("kubevirt.io/client-go/api/v1.DownwardMetricsVolumeSource"),
 						},
 					},
+					"memoryDump": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MemoryDump is a PersistentVolumeClaim which is hotplugged to the virt-launcher pod to receive a memory dump of the VirtualMachineInstance.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.MemoryDumpVolumeSource"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.CloudInitConfigDriveSource", "kubevirt.io/client-go/api/v1.CloudInitNoCloudSource", "kubevirt.io/client-go/api/v1.ConfigMapVolumeSource", "kubevirt.io/client-go/api/v1.ContainerDiskSource", "kubevirt.io/client-go/api/v1.DataVolumeSource", "kubevirt.io/client-go/api/v1.DownwardAPIVolumeSource", "kubevirt.io/client-go/api/v1.DownwardMetricsVolumeSource", "kubevirt.io/client-go/api/v1.EmptyDiskSource", "kubevirt.io/client-go/api/v1.EphemeralVolumeSource", "kubevirt.io/client-go/api/v1.HostDisk", "kubevirt.io/client-go/api/v1.PersistentVolumeClaimVolumeSource", "kubevirt.io/client-go/api/v1.SecretVolumeSource", "kubevirt.io/client-go/api/v1.ServiceAccountVolumeSource", "kubevirt.io/client-go/api/v1.SysprepSource"},
+			"kubevirt.io/client-go/api/v1.CloudInitConfigDriveSource", "kubevirt.io/client-go/api/v1.CloudInitNoCloudSource", "kubevirt.io/client-go/api/v1.ConfigMapVolumeSource", "kubevirt.io/client-go/api/v1.ContainerDiskSource", "kubevirt.io/client-go/api/v1.DataVolumeSource", "kubevirt.io/client-go/api/v1.DownwardAPIVolumeSource", "kubevirt.io/client-go/api/v1.DownwardMetricsVolumeSource", "kubevirt.io/client-go/api/v1.EmptyDiskSource", "kubevirt.io/client-go/api/v1.EphemeralVolumeSource", "kubevirt.io/client-go/api/v1.HostDisk", "kubevirt.io/client-go/api/v1.MemoryDumpVolumeSource", "kubevirt.io/client-go/api/v1.PersistentVolumeClaimVolumeSource", "kubevirt.io/client-go/api/v1.SecretVolumeSource", "kubevirt.io/client-go/api/v1.ServiceAccountVolumeSource", "kubevirt.io/client-go/api/v1.SysprepSource"},
 	}
 }
 
@@ -25900,6 +27739,12 @@ func schema_kubevirtio_client_go_api_v1_VolumeStatus(ref common.ReferenceCallbac
 							Ref:         ref("kubevirt.io/client-go/api/v1.HotplugVolumeStatus"),
 						},
 					},
+					"memoryDumpVolume": {
+						SchemaProps: spec.SchemaProps{
+							Description: "If the volume is a memory dump volume, this will contain the memory dump info.",
+							Ref:         ref("kubevirt.io/client-go/api/v1.DomainMemoryDumpInfo"),
+						},
+					},
 					"size": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Represents the size of the volume",
@@ -25907,12 +27752,18 @@ func schema_kubevirtio_client_go_api_v1_VolumeStatus(ref common.ReferenceCallbac
 							Format:      "int64",
 						},
 					},
+					"disk": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Disk is the effective configuration of the disk of the volume in the domain",
+							Ref:         ref("kubevirt.io/client-go/api/v1.VolumeDiskStatus"),
+						},
+					},
 				},
 				Required: []string{"name", "target"},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/client-go/api/v1.HotplugVolumeStatus", "kubevirt.io/client-go/api/v1.PersistentVolumeClaimInfo"},
+			"kubevirt.io/client-go/api/v1.DomainMemoryDumpInfo", "kubevirt.io/client-go/api/v1.HotplugVolumeStatus", "kubevirt.io/client-go/api/v1.PersistentVolumeClaimInfo", "kubevirt.io/client-go/api/v1.VolumeDiskStatus"},
 	}
 }
 
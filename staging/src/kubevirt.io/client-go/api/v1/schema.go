@@ -121,6 +121,14 @@ type ServiceAccountVolumeSource struct {
 type DownwardMetricsVolumeSource struct {
 }
 
+// MemoryDumpVolumeSource represents a PersistentVolumeClaim a memory dump is written to.
+//
+// +k8s:openapi-gen=true
+type MemoryDumpVolumeSource struct {
+	// ClaimName is the name of a PersistentVolumeClaim in the same namespace as the VirtualMachineInstance.
+	ClaimName string `json:"claimName"`
+}
+
 // Represents a Sysprep volume source.
 //
 // +k8s:openapi-gen=true
@@ -216,6 +224,21 @@ type DomainSpec struct {
 	// Chassis specifies the chassis info passed to the domain.
 	// +optional
 	Chassis *Chassis `json:"chassis,omitempty"`
+	// QEMUArgs are appended to the QEMU command line of the vmi. Requires the QEMUArgs
+	// feature gate, only arguments which are allowed in the KubeVirt configuration are accepted.
+	// +optional
+	QEMUArgs []QEMUArg `json:"qemuArgs,omitempty"`
+}
+
+// QEMUArg is an argument which is appended to the QEMU command line.
+//
+// +k8s:openapi-gen=true
+type QEMUArg struct {
+	// Name of the argument, including the leading dash, e.g. "-fw_cfg".
+	Name string `json:"name"`
+	// Value of the argument, e.g. "name=opt/com.example/config,string=value".
+	// +optional
+	Value string `json:"value,omitempty"`
 }
 
 // Chassis specifies the chassis info passed to the domain.
@@ -429,6 +452,19 @@ type Firmware struct {
 	// Settings to set the kernel for booting.
 	// +optional
 	KernelBoot *KernelBoot `json:"kernelBoot,omitempty"`
+	// BootMenu shows a menu while booting, in which the device to boot from can be picked
+	// in the console, instead of booting the devices in their boot order.
+	// +optional
+	BootMenu *BootMenu `json:"bootMenu,omitempty"`
+}
+
+// BootMenu configures the boot menu of the firmware
+// +k8s:openapi-gen=true
+type BootMenu struct {
+	// Timeout is how long the boot menu waits for a pick, in milliseconds, before it boots
+	// the first device of the boot order. At most 65535. Defaults to the timeout of the firmware.
+	// +optional
+	Timeout *uint32 `json:"timeout,omitempty"`
 }
 
 //
@@ -456,19 +492,31 @@ type Devices struct {
 	// Whether to attach the default serial console or not.
 	// Serial console access will not be available if set to false. Defaults to true.
 	AutoattachSerialConsole *bool `json:"autoattachSerialConsole,omitempty"`
+	// Whether to log the output of the auto-attached serial console to the guest-console-log
+	// container of the virt-launcher pod, where it can be read with kubectl logs.
+	// Not relevant if autoattachSerialConsole is false. Defaults to false.
+	// +optional
+	LogSerialConsole *bool `json:"logSerialConsole,omitempty"`
 	// Whether to attach the Memory balloon device with default period.
 	// Period can be adjusted in virt-config.
 	// Defaults to true.
 	// +optional
 	AutoattachMemBalloon *bool `json:"autoattachMemBalloon,omitempty"`
+	// Whether the guest reports the memory pages it freed to the host through the
+	// Memory balloon device, so that they can be reclaimed.
+	// Defaults to true if the memory of the vmi is overcommitted.
+	// +optional
+	FreePageReporting *bool `json:"freePageReporting,omitempty"`
 	// Whether to have random number generator from host
 	// +optional
 	Rng *Rng `json:"rng,omitempty"`
 	// Whether or not to enable virtio multi-queue for block devices.
+	// The number of queues equals the number of vCPUs, unless a disk sets its own queue count.
 	// Defaults to false.
 	// +optional
 	BlockMultiQueue *bool `json:"blockMultiQueue,omitempty"`
 	// If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.
+	// Interfaces which set their own queue count are not affected.
 	// +optional
 	NetworkInterfaceMultiQueue *bool `json:"networkInterfaceMultiqueue,omitempty"`
 	//Whether to attach a GPU device to the vmi.
@@ -486,6 +534,27 @@ type Devices struct {
 	// To configure and access client devices such as redirecting USB
 	// +optional
 	ClientPassthrough *ClientPassthroughDevices `json:"clientPassthrough,omitempty"`
+	// Whether to emulate a sound device.
+	// +optional
+	Sound *SoundDevice `json:"sound,omitempty"`
+	// Whether to attach a VSOCK device to the vmi, through which the host can talk to
+	// processes in the guest without any network. The CID of the device is reported in the
+	// status of the vmi. Defaults to false.
+	// +optional
+	AutoattachVSOCK *bool `json:"autoattachVSOCK,omitempty"`
+}
+
+// Represents the user's configuration to emulate sound cards in the VMI.
+//
+// +k8s:openapi-gen=true
+type SoundDevice struct {
+	// User's defined name for this sound device
+	Name string `json:"name"`
+	// We only support ich9 or ac97.
+	// If SoundDevice is not set: No sound card is emulated.
+	// If SoundDevice is set but Model is not: ich9
+	// +optional
+	Model string `json:"model,omitempty"`
 }
 
 // Represent a subset of client devices that can be accessed by VMI. At the
@@ -537,6 +606,31 @@ type GPU struct {
 	// Name of the GPU device as exposed by a device plugin
 	Name       string `json:"name"`
 	DeviceName string `json:"deviceName"`
+	// VirtualGPUOptions configures vGPU (mediated device) specific options.
+	// It is ignored for GPUs which are passed through as PCI devices.
+	// +optional
+	VirtualGPUOptions *VGPUOptions `json:"virtualGPUOptions,omitempty"`
+}
+
+//
+// +k8s:openapi-gen=true
+type VGPUOptions struct {
+	// Display configures the display adapter backed by the vGPU.
+	// +optional
+	Display *VGPUDisplayOptions `json:"display,omitempty"`
+}
+
+//
+// +k8s:openapi-gen=true
+type VGPUDisplayOptions struct {
+	// Enabled determines if a display adapter backed by the vGPU should be enabled or disabled on the guest.
+	// Defaults to true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// RamFB enables a boot framebuffer which is used until the guest OS loads a real GPU driver.
+	// Defaults to true.
+	// +optional
+	RamFB *FeatureState `json:"ramFB,omitempty"`
 }
 
 //
@@ -564,25 +658,66 @@ type Disk struct {
 	// Serial provides the ability to specify a serial number for the disk device.
 	// +optional
 	Serial string `json:"serial,omitempty"`
+	// WWN is the World Wide Name of the disk device, made up of 16 hexadecimal digits.
+	// Only allowed for disks and cdroms on the sata or scsi bus.
+	// +optional
+	WWN string `json:"wwn,omitempty"`
 	// dedicatedIOThread indicates this disk should have an exclusive IO Thread.
 	// Enabling this implies useIOThreads = true.
 	// Defaults to false.
 	// +optional
 	DedicatedIOThread *bool `json:"dedicatedIOThread,omitempty"`
 	// Cache specifies which kvm disk cache mode should be used.
-	// Supported values are: CacheNone, CacheWriteThrough.
+	// Supported values are: CacheNone, CacheWriteThrough, CacheWriteBack.
 	// +optional
 	Cache DriverCache `json:"cache,omitempty"`
 	// IO specifies which QEMU disk IO mode should be used.
 	// Supported values are: native, default, threads.
 	// +optional
 	IO DriverIO `json:"io,omitempty"`
+	// Discard specifies if discard requests of the guest are passed to the storage of the disk.
+	// Supported values are: unmap, ignore. Defaults to unmap, or to ignore for preallocated volumes.
+	// Not supported for cdroms and floppies.
+	// +optional
+	Discard DriverDiscard `json:"discard,omitempty"`
 	// If specified, disk address and its tag will be provided to the guest via config drive metadata
 	// +optional
 	Tag string `json:"tag,omitempty"`
 	// If specified, the virtual disk will be presented with the given block sizes.
 	// +optional
 	BlockSize *BlockSize `json:"blockSize,omitempty"`
+	// Queues sets the number of virtio-blk queues of this disk, overriding blockMultiQueue.
+	// Only allowed for disks on the virtio bus. Must not exceed the number of vCPUs.
+	// +optional
+	Queues *uint32 `json:"queues,omitempty"`
+	// IOTune limits the throughput and the IOPS of the disk.
+	// +optional
+	IOTune *DiskIOTune `json:"ioTune,omitempty"`
+}
+
+// DiskIOTune limits the I/O of a disk. A total limit can not be combined with
+// a read or write limit of the same kind.
+//
+// +k8s:openapi-gen=true
+type DiskIOTune struct {
+	// TotalBytesPerSec limits the bytes read and written per second, e.g. 100Mi.
+	// +optional
+	TotalBytesPerSec *resource.Quantity `json:"totalBytesPerSec,omitempty"`
+	// ReadBytesPerSec limits the bytes read per second.
+	// +optional
+	ReadBytesPerSec *resource.Quantity `json:"readBytesPerSec,omitempty"`
+	// WriteBytesPerSec limits the bytes written per second.
+	// +optional
+	WriteBytesPerSec *resource.Quantity `json:"writeBytesPerSec,omitempty"`
+	// TotalIOPS limits the read and write operations per second.
+	// +optional
+	TotalIOPS *int64 `json:"totalIOPS,omitempty"`
+	// ReadIOPS limits the read operations per second.
+	// +optional
+	ReadIOPS *int64 `json:"readIOPS,omitempty"`
+	// WriteIOPS limits the write operations per second.
+	// +optional
+	WriteIOPS *int64 `json:"writeIOPS,omitempty"`
 }
 
 // CustomBlockSize represents the desired logical and physical block size for a VM disk.
@@ -621,7 +756,7 @@ type DiskDevice struct {
 // +k8s:openapi-gen=true
 type DiskTarget struct {
 	// Bus indicates the type of disk device to emulate.
-	// supported values: virtio, sata, scsi.
+	// supported values: virtio, sata, scsi, nvme.
 	Bus string `json:"bus,omitempty"`
 	// ReadOnly.
 	// Defaults to false.
@@ -640,6 +775,11 @@ type LunTarget struct {
 	// ReadOnly.
 	// Defaults to false.
 	ReadOnly bool `json:"readonly,omitempty"`
+	// Reservation lets the guest issue SCSI-3 persistent reservation commands to the LUN,
+	// e.g. for the shared disks of failover clusters. Requires the scsi bus.
+	// Defaults to false.
+	// +optional
+	Reservation bool `json:"reservation,omitempty"`
 }
 
 //
@@ -756,6 +896,10 @@ type VolumeSource struct {
 	// DownwardMetrics adds a very small disk to VMIs which contains a limited view of host and guest
 	// metrics. The disk content is compatible with vhostmd (https://github.com/vhostmd/vhostmd) and vm-dump-metrics.
 	DownwardMetrics *DownwardMetricsVolumeSource `json:"downwardMetrics,omitempty"`
+	// MemoryDump is a PersistentVolumeClaim which is hotplugged to the virt-launcher pod
+	// to receive a memory dump of the VirtualMachineInstance.
+	// +optional
+	MemoryDump *MemoryDumpVolumeSource `json:"memoryDump,omitempty"`
 }
 
 // HotplugVolumeSource Represents the source of a volume to mount which are capable
@@ -832,6 +976,24 @@ type ContainerDiskSource struct {
 	// More info: https://kubernetes.io/docs/concepts/containers/images#updating-images
 	// +optional
 	ImagePullPolicy v1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// Overlay configures the writable copy-on-write overlay, which uses the image as its backing file.
+	// By default the overlay is stored in an emptyDir and has the size of the image.
+	// +optional
+	Overlay *ContainerDiskOverlay `json:"overlay,omitempty"`
+}
+
+// ContainerDiskOverlay configures the writable overlay of a containerDisk.
+//
+// +k8s:openapi-gen=true
+type ContainerDiskOverlay struct {
+	// Capacity is the size of the disk the guest sees, which has to be at least the size of the image.
+	// Defaults to the size of the image.
+	// +optional
+	Capacity *resource.Quantity `json:"capacity,omitempty"`
+	// ClaimName is the name of a filesystem PersistentVolumeClaim in the namespace of the VirtualMachineInstance
+	// to store the overlay on, which keeps the writes of the guest across restarts. Defaults to an emptyDir.
+	// +optional
+	ClaimName string `json:"claimName,omitempty"`
 }
 
 // Exactly one of its members must be set.
@@ -1016,6 +1178,10 @@ type Features struct {
 	// Defaults to the machine type setting.
 	// +optional
 	Hyperv *FeatureHyperv `json:"hyperv,omitempty"`
+	// HypervPassthrough enables all the Hyper-V enlightenments which are supported by the host.
+	// It can't be combined with hyperv. VMIs with Hyper-V passthrough are not live migratable.
+	// +optional
+	HypervPassthrough *HyperVPassthrough `json:"hypervPassthrough,omitempty"`
 	// SMM enables/disables System Management Mode.
 	// TSEG not yet implemented.
 	// +optional
@@ -1146,6 +1312,14 @@ type FeatureHyperv struct {
 	EVMCS *FeatureState `json:"evmcs,omitempty"`
 }
 
+// +k8s:openapi-gen=true
+type HyperVPassthrough struct {
+	// Enabled determines if the Hyper-V passthrough mode should be used.
+	// Defaults to true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
 // +k8s:openapi-gen=true
 type FeatureKVM struct {
 	// Hide the KVM hypervisor from standard MSR based discovery.
@@ -1230,6 +1404,38 @@ type Interface struct {
 	// If specified, the virtual network interface address and its tag will be provided to the guest via config drive
 	// +optional
 	Tag string `json:"tag,omitempty"`
+	// Queues sets the number of vhost queues of this interface, overriding networkInterfaceMultiqueue.
+	// Only allowed for virtio interfaces. Must not exceed the number of vCPUs.
+	// +optional
+	Queues *uint32 `json:"queues,omitempty"`
+	// Bandwidth limits the traffic of the interface.
+	// Only allowed for bridge and masquerade interfaces.
+	// +optional
+	Bandwidth *InterfaceBandwidth `json:"bandwidth,omitempty"`
+}
+
+// InterfaceBandwidth limits the traffic of an interface in both directions
+//
+// +k8s:openapi-gen=true
+type InterfaceBandwidth struct {
+	// Ingress limits the traffic received by the guest.
+	// +optional
+	Ingress *BandwidthLimit `json:"ingress,omitempty"`
+	// Egress limits the traffic sent by the guest.
+	// +optional
+	Egress *BandwidthLimit `json:"egress,omitempty"`
+}
+
+// BandwidthLimit is a token bucket limiting the traffic of one direction
+//
+// +k8s:openapi-gen=true
+type BandwidthLimit struct {
+	// Rate is the average rate in bits per second, e.g. 100M.
+	Rate resource.Quantity `json:"rate"`
+	// Burst is the amount of bytes which can exceed the rate at once, e.g. 1Mi.
+	// Defaults to the traffic of 100ms at the rate, but at least 64Ki.
+	// +optional
+	Burst *resource.Quantity `json:"burst,omitempty"`
 }
 
 // Extra DHCP options to use in the interface.
@@ -1272,6 +1478,7 @@ type InterfaceBindingMethod struct {
 	Masquerade *InterfaceMasquerade `json:"masquerade,omitempty"`
 	SRIOV      *InterfaceSRIOV      `json:"sriov,omitempty"`
 	Macvtap    *InterfaceMacvtap    `json:"macvtap,omitempty"`
+	VhostUser  *InterfaceVhostUser  `json:"vhostuser,omitempty"`
 }
 
 //
@@ -1294,6 +1501,14 @@ type InterfaceSRIOV struct{}
 // +k8s:openapi-gen=true
 type InterfaceMacvtap struct{}
 
+// InterfaceVhostUser connects the interface to a userspace dataplane (e.g. OVS-DPDK or VPP)
+// over a vhost-user socket. QEMU creates the socket in server mode, named after the interface,
+// in the vhostuser directory shared by the virt-launcher pod, and the dataplane connects to it.
+// Requires a Multus network and hugepages backed guest memory.
+//
+// +k8s:openapi-gen=true
+type InterfaceVhostUser struct{}
+
 // Port repesents a port to expose from the virtual machine.
 // Default protocol TCP.
 // The port field is mandatory
@@ -1496,4 +1711,17 @@ type MultusNetwork struct {
 	// Select the default network and add it to the
 	// multus-cni.io/default-network annotation.
 	Default bool `json:"default,omitempty"`
+
+	// IPAddresses requests static IP addresses in CIDR notation, e.g. 192.168.1.10/24,
+	// for the interface. They are passed to the "ips" capability of the CNI plugin.
+	// Not supported on the default network.
+	// +optional
+	// +listType=atomic
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+
+	// IPPool references a pool of the IPAM plugin of the network from which the
+	// addresses of the interface are assigned. It is passed as the "ipPool" CNI arg.
+	// Not supported on the default network.
+	// +optional
+	IPPool string `json:"ipPool,omitempty"`
 }
@@ -50,6 +50,13 @@ func (DownwardMetricsVolumeSource) SwaggerDoc() map[string]string {
 	}
 }
 
+func (MemoryDumpVolumeSource) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "MemoryDumpVolumeSource represents a PersistentVolumeClaim a memory dump is written to.\n\n+k8s:openapi-gen=true",
+		"claimName": "ClaimName is the name of a PersistentVolumeClaim in the same namespace as the VirtualMachineInstance.",
+	}
+}
+
 func (SysprepSource) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":          "Represents a Sysprep volume source.\n\n+k8s:openapi-gen=true",
@@ -95,6 +102,15 @@ func (DomainSpec) SwaggerDoc() map[string]string {
 		"devices":         "Devices allows adding disks, network interfaces, and others",
 		"ioThreadsPolicy": "Controls whether or not disks will share IOThreads.\nOmitting IOThreadsPolicy disables use of IOThreads.\nOne of: shared, auto\n+optional",
 		"chassis":         "Chassis specifies the chassis info passed to the domain.\n+optional",
+		"qemuArgs":        "QEMUArgs are appended to the QEMU command line of the vmi. Requires the QEMUArgs\nfeature gate, only arguments which are allowed in the KubeVirt configuration are accepted.\n+optional",
+	}
+}
+
+func (QEMUArg) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "QEMUArg is an argument which is appended to the QEMU command line.\n\n+k8s:openapi-gen=true",
+		"name":  "Name of the argument, including the leading dash, e.g. \"-fw_cfg\".",
+		"value": "Value of the argument, e.g. \"name=opt/com.example/config,string=value\".\n+optional",
 	}
 }
 
@@ -218,6 +234,14 @@ func (Firmware) SwaggerDoc() map[string]string {
 		"bootloader": "Settings to control the bootloader that is used.\n+optional",
 		"serial":     "The system-serial-number in SMBIOS",
 		"kernelBoot": "Settings to set the kernel for booting.\n+optional",
+		"bootMenu":   "BootMenu shows a menu while booting, in which the device to boot from can be picked\nin the console, instead of booting the devices in their boot order.\n+optional",
+	}
+}
+
+func (BootMenu) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "BootMenu configures the boot menu of the firmware\n+k8s:openapi-gen=true",
+		"timeout": "Timeout is how long the boot menu waits for a pick, in milliseconds, before it boots\nthe first device of the boot order. At most 65535. Defaults to the timeout of the firmware.\n+optional",
 	}
 }
 
@@ -233,14 +257,26 @@ func (Devices) SwaggerDoc() map[string]string {
 		"autoattachPodInterface":     "Whether to attach a pod network interface. Defaults to true.",
 		"autoattachGraphicsDevice":   "Whether to attach the default graphics device or not.\nVNC will not be available if set to false. Defaults to true.",
 		"autoattachSerialConsole":    "Whether to attach the default serial console or not.\nSerial console access will not be available if set to false. Defaults to true.",
+		"logSerialConsole":           "Whether to log the output of the auto-attached serial console to the guest-console-log\ncontainer of the virt-launcher pod, where it can be read with kubectl logs.\nNot relevant if autoattachSerialConsole is false. Defaults to false.\n+optional",
 		"autoattachMemBalloon":       "Whether to attach the Memory balloon device with default period.\nPeriod can be adjusted in virt-config.\nDefaults to true.\n+optional",
+		"freePageReporting":          "Whether the guest reports the memory pages it freed to the host through the\nMemory balloon device, so that they can be reclaimed.\nDefaults to true if the memory of the vmi is overcommitted.\n+optional",
 		"rng":                        "Whether to have random number generator from host\n+optional",
-		"blockMultiQueue":            "Whether or not to enable virtio multi-queue for block devices.\nDefaults to false.\n+optional",
-		"networkInterfaceMultiqueue": "If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.\n+optional",
+		"blockMultiQueue":            "Whether or not to enable virtio multi-queue for block devices.\nThe number of queues equals the number of vCPUs, unless a disk sets its own queue count.\nDefaults to false.\n+optional",
+		"networkInterfaceMultiqueue": "If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.\nInterfaces which set their own queue count are not affected.\n+optional",
 		"gpus":                       "Whether to attach a GPU device to the vmi.\n+optional\n+listType=atomic",
 		"filesystems":                "Filesystems describes filesystem which is connected to the vmi.\n+optional\n+listType=atomic",
 		"hostDevices":                "Whether to attach a host device to the vmi.\n+optional\n+listType=atomic",
 		"clientPassthrough":          "To configure and access client devices such as redirecting USB\n+optional",
+		"sound":                      "Whether to emulate a sound device.\n+optional",
+		"autoattachVSOCK":            "Whether to attach a VSOCK device to the vmi, through which the host can talk to\nprocesses in the guest without any network. The CID of the device is reported in the\nstatus of the vmi. Defaults to false.\n+optional",
+	}
+}
+
+func (SoundDevice) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "Represents the user's configuration to emulate sound cards in the VMI.\n\n+k8s:openapi-gen=true",
+		"name":  "User's defined name for this sound device",
+		"model": "We only support ich9 or ac97.\nIf SoundDevice is not set: No sound card is emulated.\nIf SoundDevice is set but Model is not: ich9\n+optional",
 	}
 }
 
@@ -275,8 +311,24 @@ func (FilesystemVirtiofs) SwaggerDoc() map[string]string {
 
 func (GPU) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":     "+k8s:openapi-gen=true",
-		"name": "Name of the GPU device as exposed by a device plugin",
+		"":                  "+k8s:openapi-gen=true",
+		"name":              "Name of the GPU device as exposed by a device plugin",
+		"virtualGPUOptions": "VirtualGPUOptions configures vGPU (mediated device) specific options.\nIt is ignored for GPUs which are passed through as PCI devices.\n+optional",
+	}
+}
+
+func (VGPUOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "+k8s:openapi-gen=true",
+		"display": "Display configures the display adapter backed by the vGPU.\n+optional",
+	}
+}
+
+func (VGPUDisplayOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "+k8s:openapi-gen=true",
+		"enabled": "Enabled determines if a display adapter backed by the vGPU should be enabled or disabled on the guest.\nDefaults to true.\n+optional",
+		"ramFB":   "RamFB enables a boot framebuffer which is used until the guest OS loads a real GPU driver.\nDefaults to true.\n+optional",
 	}
 }
 
@@ -293,11 +345,27 @@ func (Disk) SwaggerDoc() map[string]string {
 		"name":              "Name is the device name",
 		"bootOrder":         "BootOrder is an integer value > 0, used to determine ordering of boot devices.\nLower values take precedence.\nEach disk or interface that has a boot order must have a unique value.\nDisks without a boot order are not tried if a disk with a boot order exists.\n+optional",
 		"serial":            "Serial provides the ability to specify a serial number for the disk device.\n+optional",
+		"wwn":               "WWN is the World Wide Name of the disk device, made up of 16 hexadecimal digits.\nOnly allowed for disks and cdroms on the sata or scsi bus.\n+optional",
 		"dedicatedIOThread": "dedicatedIOThread indicates this disk should have an exclusive IO Thread.\nEnabling this implies useIOThreads = true.\nDefaults to false.\n+optional",
-		"cache":             "Cache specifies which kvm disk cache mode should be used.\nSupported values are: CacheNone, CacheWriteThrough.\n+optional",
+		"cache":             "Cache specifies which kvm disk cache mode should be used.\nSupported values are: CacheNone, CacheWriteThrough, CacheWriteBack.\n+optional",
 		"io":                "IO specifies which QEMU disk IO mode should be used.\nSupported values are: native, default, threads.\n+optional",
+		"discard":           "Discard specifies if discard requests of the guest are passed to the storage of the disk.\nSupported values are: unmap, ignore. Defaults to unmap, or to ignore for preallocated volumes.\nNot supported for cdroms and floppies.\n+optional",
 		"tag":               "If specified, disk address and its tag will be provided to the guest via config drive metadata\n+optional",
 		"blockSize":         "If specified, the virtual disk will be presented with the given block sizes.\n+optional",
+		"queues":            "Queues sets the number of virtio-blk queues of this disk, overriding blockMultiQueue.\nOnly allowed for disks on the virtio bus. Must not exceed the number of vCPUs.\n+optional",
+		"ioTune":            "IOTune limits the throughput and the IOPS of the disk.\n+optional",
+	}
+}
+
+func (DiskIOTune) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                 "DiskIOTune limits the I/O of a disk. A total limit can not be combined with\na read or write limit of the same kind.\n\n+k8s:openapi-gen=true",
+		"totalBytesPerSec": "TotalBytesPerSec limits the bytes read and written per second, e.g. 100Mi.\n+optional",
+		"readBytesPerSec":  "ReadBytesPerSec limits the bytes read per second.\n+optional",
+		"writeBytesPerSec": "WriteBytesPerSec limits the bytes written per second.\n+optional",
+		"totalIOPS":        "TotalIOPS limits the read and write operations per second.\n+optional",
+		"readIOPS":         "ReadIOPS limits the read operations per second.\n+optional",
+		"writeIOPS":        "WriteIOPS limits the write operations per second.\n+optional",
 	}
 }
 
@@ -326,7 +394,7 @@ func (DiskDevice) SwaggerDoc() map[string]string {
 func (DiskTarget) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":           "+k8s:openapi-gen=true",
-		"bus":        "Bus indicates the type of disk device to emulate.\nsupported values: virtio, sata, scsi.",
+		"bus":        "Bus indicates the type of disk device to emulate.\nsupported values: virtio, sata, scsi, nvme.",
 		"readonly":   "ReadOnly.\nDefaults to false.",
 		"pciAddress": "If specified, the virtual disk will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10\n+optional",
 	}
@@ -334,9 +402,10 @@ func (DiskTarget) SwaggerDoc() map[string]string {
 
 func (LunTarget) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":         "+k8s:openapi-gen=true",
-		"bus":      "Bus indicates the type of disk device to emulate.\nsupported values: virtio, sata, scsi.",
-		"readonly": "ReadOnly.\nDefaults to false.",
+		"":            "+k8s:openapi-gen=true",
+		"bus":         "Bus indicates the type of disk device to emulate.\nsupported values: virtio, sata, scsi.",
+		"readonly":    "ReadOnly.\nDefaults to false.",
+		"reservation": "Reservation lets the guest issue SCSI-3 persistent reservation commands to the LUN,\ne.g. for the shared disks of failover clusters. Requires the scsi bus.\nDefaults to false.\n+optional",
 	}
 }
 
@@ -381,6 +450,7 @@ func (VolumeSource) SwaggerDoc() map[string]string {
 		"downwardAPI":           "DownwardAPI represents downward API about the pod that should populate this volume\n+optional",
 		"serviceAccount":        "ServiceAccountVolumeSource represents a reference to a service account.\nThere can only be one volume of this type!\nMore info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/\n+optional",
 		"downwardMetrics":       "DownwardMetrics adds a very small disk to VMIs which contains a limited view of host and guest\nmetrics. The disk content is compatible with vhostmd (https://github.com/vhostmd/vhostmd) and vm-dump-metrics.",
+		"memoryDump":            "MemoryDump is a PersistentVolumeClaim which is hotplugged to the virt-launcher pod\nto receive a memory dump of the VirtualMachineInstance.\n+optional",
 	}
 }
 
@@ -428,6 +498,15 @@ func (ContainerDiskSource) SwaggerDoc() map[string]string {
 		"imagePullSecret": "ImagePullSecret is the name of the Docker registry secret required to pull the image. The secret must already exist.",
 		"path":            "Path defines the path to disk file in the container",
 		"imagePullPolicy": "Image pull policy.\nOne of Always, Never, IfNotPresent.\nDefaults to Always if :latest tag is specified, or IfNotPresent otherwise.\nCannot be updated.\nMore info: https://kubernetes.io/docs/concepts/containers/images#updating-images\n+optional",
+		"overlay":         "Overlay configures the writable copy-on-write overlay, which uses the image as its backing file.\nBy default the overlay is stored in an emptyDir and has the size of the image.\n+optional",
+	}
+}
+
+func (ContainerDiskOverlay) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "ContainerDiskOverlay configures the writable overlay of a containerDisk.\n\n+k8s:openapi-gen=true",
+		"capacity":  "Capacity is the size of the disk the guest sees, which has to be at least the size of the image.\nDefaults to the size of the image.\n+optional",
+		"claimName": "ClaimName is the name of a filesystem PersistentVolumeClaim in the namespace of the VirtualMachineInstance\nto store the overlay on, which keeps the writes of the guest across restarts. Defaults to an emptyDir.\n+optional",
 	}
 }
 
@@ -505,13 +584,14 @@ func (HypervTimer) SwaggerDoc() map[string]string {
 
 func (Features) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":           "+k8s:openapi-gen=true",
-		"acpi":       "ACPI enables/disables ACPI inside the guest.\nDefaults to enabled.\n+optional",
-		"apic":       "Defaults to the machine type setting.\n+optional",
-		"hyperv":     "Defaults to the machine type setting.\n+optional",
-		"smm":        "SMM enables/disables System Management Mode.\nTSEG not yet implemented.\n+optional",
-		"kvm":        "Configure how KVM presence is exposed to the guest.\n+optional",
-		"pvspinlock": "Notify the guest that the host supports paravirtual spinlocks.\nFor older kernels this feature should be explicitly disabled.\n+optional",
+		"":                  "+k8s:openapi-gen=true",
+		"acpi":              "ACPI enables/disables ACPI inside the guest.\nDefaults to enabled.\n+optional",
+		"apic":              "Defaults to the machine type setting.\n+optional",
+		"hyperv":            "Defaults to the machine type setting.\n+optional",
+		"hypervPassthrough": "HypervPassthrough enables all the Hyper-V enlightenments which are supported by the host.\nIt can't be combined with hyperv. VMIs with Hyper-V passthrough are not live migratable.\n+optional",
+		"smm":               "SMM enables/disables System Management Mode.\nTSEG not yet implemented.\n+optional",
+		"kvm":               "Configure how KVM presence is exposed to the guest.\n+optional",
+		"pvspinlock":        "Notify the guest that the host supports paravirtual spinlocks.\nFor older kernels this feature should be explicitly disabled.\n+optional",
 	}
 }
 
@@ -572,6 +652,13 @@ func (FeatureHyperv) SwaggerDoc() map[string]string {
 	}
 }
 
+func (HyperVPassthrough) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "+k8s:openapi-gen=true",
+		"enabled": "Enabled determines if the Hyper-V passthrough mode should be used.\nDefaults to true.\n+optional",
+	}
+}
+
 func (FeatureKVM) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":       "+k8s:openapi-gen=true",
@@ -611,6 +698,24 @@ func (Interface) SwaggerDoc() map[string]string {
 		"pciAddress":  "If specified, the virtual network interface will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10\n+optional",
 		"dhcpOptions": "If specified the network interface will pass additional DHCP options to the VMI\n+optional",
 		"tag":         "If specified, the virtual network interface address and its tag will be provided to the guest via config drive\n+optional",
+		"queues":      "Queues sets the number of vhost queues of this interface, overriding networkInterfaceMultiqueue.\nOnly allowed for virtio interfaces. Must not exceed the number of vCPUs.\n+optional",
+		"bandwidth":   "Bandwidth limits the traffic of the interface.\nOnly allowed for bridge and masquerade interfaces.\n+optional",
+	}
+}
+
+func (InterfaceBandwidth) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "InterfaceBandwidth limits the traffic of an interface in both directions\n\n+k8s:openapi-gen=true",
+		"ingress": "Ingress limits the traffic received by the guest.\n+optional",
+		"egress":  "Egress limits the traffic sent by the guest.\n+optional",
+	}
+}
+
+func (BandwidthLimit) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "BandwidthLimit is a token bucket limiting the traffic of one direction\n\n+k8s:openapi-gen=true",
+		"rate":  "Rate is the average rate in bits per second, e.g. 100M.",
+		"burst": "Burst is the amount of bytes which can exceed the rate at once, e.g. 1Mi.\nDefaults to the traffic of 100ms at the rate, but at least 64Ki.\n+optional",
 	}
 }
 
@@ -668,6 +773,12 @@ func (InterfaceMacvtap) SwaggerDoc() map[string]string {
 	}
 }
 
+func (InterfaceVhostUser) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "InterfaceVhostUser connects the interface to a userspace dataplane (e.g. OVS-DPDK or VPP)\nover a vhost-user socket. QEMU creates the socket in server mode, named after the interface,\nin the vhostuser directory shared by the virt-launcher pod, and the dataplane connects to it.\nRequires a Multus network and hugepages backed guest memory.\n\n+k8s:openapi-gen=true",
+	}
+}
+
 func (Port) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":         "Port repesents a port to expose from the virtual machine.\nDefault protocol TCP.\nThe port field is mandatory\n\n+k8s:openapi-gen=true",
@@ -795,5 +906,7 @@ func (MultusNetwork) SwaggerDoc() map[string]string {
 		"":            "Represents the multus cni network.\n\n+k8s:openapi-gen=true",
 		"networkName": "References to a NetworkAttachmentDefinition CRD object. Format:\n<networkName>, <namespace>/<networkName>. If namespace is not\nspecified, VMI namespace is assumed.",
 		"default":     "Select the default network and add it to the\nmultus-cni.io/default-network annotation.",
+		"ipAddresses": "IPAddresses requests static IP addresses in CIDR notation, e.g. 192.168.1.10/24,\nfor the interface. They are passed to the \"ips\" capability of the CNI plugin.\nNot supported on the default network.\n+optional\n+listType=atomic",
+		"ipPool":      "IPPool references a pool of the IPAM plugin of the network from which the\naddresses of the interface are assigned. It is passed as the \"ipPool\" CNI arg.\nNot supported on the default network.\n+optional",
 	}
 }
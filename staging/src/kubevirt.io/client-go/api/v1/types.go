@@ -93,6 +93,7 @@ type VirtualMachineInstanceSpec struct {
 	// If specified, indicates the pod's priority.
 	// If not specified, the pod priority will be default or zero if there is no
 	// default.
+	// VMIs with a higher priority are evacuated first when a node is drained.
 	// +optional
 	PriorityClassName string `json:"priorityClassName,omitempty"`
 
@@ -113,7 +114,8 @@ type VirtualMachineInstanceSpec struct {
 	Tolerations []k8sv1.Toleration `json:"tolerations,omitempty"`
 
 	// EvictionStrategy can be set to "LiveMigrate" if the VirtualMachineInstance should be
-	// migrated instead of shut-off in case of a node drain.
+	// migrated instead of shut-off in case of a node drain, or to "LiveMigrateIfPossible" if
+	// it should be migrated when it is live-migratable and evicted otherwise.
 	//
 	// +optional
 	EvictionStrategy *EvictionStrategy `json:"evictionStrategy,omitempty"`
@@ -137,6 +139,13 @@ type VirtualMachineInstanceSpec struct {
 	// More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes
 	// +optional
 	ReadinessProbe *Probe `json:"readinessProbe,omitempty"`
+	// Probe indicating that the VirtualMachineInstance has successfully started.
+	// Liveness and readiness probes are not executed until it succeeds.
+	// VirtualmachineInstances will be stopped if the probe fails.
+	// Cannot be updated.
+	// More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes
+	// +optional
+	StartupProbe *Probe `json:"startupProbe,omitempty"`
 	// Specifies the hostname of the vmi
 	// If not specified, the hostname will be set to the name of the vmi, if dhcp or cloud-init is configured properly.
 	// +optional
@@ -177,6 +186,28 @@ type VirtualMachineInstancePhaseTransitionTimestamp struct {
 	PhaseTransitionTimestamp metav1.Time `json:"phaseTransitionTimestamp,omitempty"`
 }
 
+// VirtualMachineInstanceStartupTimestamps holds the times at which a VirtualMachineInstance passed
+// the steps of its start. A step which was not reached yet is not set.
+//
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceStartupTimestamps struct {
+	// PodScheduled is the time the virt-launcher pod was scheduled to a node
+	// +optional
+	PodScheduled *metav1.Time `json:"podScheduled,omitempty"`
+	// ContainerStarted is the time the compute container of the virt-launcher pod started
+	// +optional
+	ContainerStarted *metav1.Time `json:"containerStarted,omitempty"`
+	// DomainDefined is the time virt-handler saw the domain defined in libvirt
+	// +optional
+	DomainDefined *metav1.Time `json:"domainDefined,omitempty"`
+	// DomainRunning is the time virt-handler saw the domain running
+	// +optional
+	DomainRunning *metav1.Time `json:"domainRunning,omitempty"`
+	// GuestAgentConnected is the time the guest agent connected for the first time
+	// +optional
+	GuestAgentConnected *metav1.Time `json:"guestAgentConnected,omitempty"`
+}
+
 // +k8s:openapi-gen=true
 type TopologyHints struct {
 	TSCFrequency *int64 `json:"tscFrequency,omitempty"`
@@ -189,6 +220,10 @@ type TopologyHints struct {
 type VirtualMachineInstanceStatus struct {
 	// NodeName is the name where the VirtualMachineInstance is currently running.
 	NodeName string `json:"nodeName,omitempty"`
+	// NodeBootID is the boot ID of the node the VirtualMachineInstance is currently running on.
+	// It allows detecting whether the node rebooted while the VirtualMachineInstance was running.
+	// +optional
+	NodeBootID string `json:"nodeBootID,omitempty"`
 	// A brief CamelCase message indicating details about why the VMI is in this state. e.g. 'NodeUnresponsive'
 	// +optional
 	Reason string `json:"reason,omitempty"`
@@ -204,6 +239,13 @@ type VirtualMachineInstanceStatus struct {
 	Interfaces []VirtualMachineInstanceNetworkInterface `json:"interfaces,omitempty"`
 	// Guest OS Information
 	GuestOSInfo VirtualMachineInstanceGuestOSInfo `json:"guestOSInfo,omitempty"`
+	// GuestHostname is the hostname reported by the guest agent
+	// +optional
+	GuestHostname string `json:"guestHostname,omitempty"`
+	// FSFreeSpace is the free space of the guest filesystems reported by the guest agent
+	// +optional
+	// +listType=atomic
+	FSFreeSpace []VirtualMachineInstanceFileSystemFreeSpace `json:"fsFreeSpace,omitempty"`
 	// Represents the status of a live migration
 	MigrationState *VirtualMachineInstanceMigrationState `json:"migrationState,omitempty"`
 	// Represents the method using which the vmi can be migrated: live migration or block migration
@@ -245,6 +287,15 @@ type VirtualMachineInstanceStatus struct {
 	// an online vm snapshot
 	// +optional
 	VirtualMachineRevisionName string `json:"virtualMachineRevisionName,omitempty"`
+
+	// VSOCKCID is the guest context ID of the VSOCK device, unique in the cluster.
+	// It is only set when autoattachVSOCK is enabled.
+	// +optional
+	VSOCKCID *uint32 `json:"VSOCKCID,omitempty"`
+
+	// StartupTimestamps holds the times at which the VirtualMachineInstance passed the steps of its start
+	// +optional
+	StartupTimestamps *VirtualMachineInstanceStartupTimestamps `json:"startupTimestamps,omitempty"`
 }
 
 // PersistentVolumeClaimInfo contains the relavant information virt-handler needs cached about a PVC
@@ -268,6 +319,14 @@ type PersistentVolumeClaimInfo struct {
 	// Preallocated indicates if the PVC's storage is preallocated or not
 	// +optional
 	Preallocated bool `json:"preallocated,omitempty"`
+
+	// FilesystemOverhead is the fraction of a filesystem PVC which the disk image leaves free for the filesystem
+	// +optional
+	FilesystemOverhead string `json:"filesystemOverhead,omitempty"`
+
+	// CacheMode is the cache mode of the disks on the PVC which don't set one, as configured for its storage class
+	// +optional
+	CacheMode DriverCache `json:"cacheMode,omitempty"`
 }
 
 // VolumeStatus represents information about the status of volumes attached to the VirtualMachineInstance.
@@ -287,8 +346,31 @@ type VolumeStatus struct {
 	PersistentVolumeClaimInfo *PersistentVolumeClaimInfo `json:"persistentVolumeClaimInfo,omitempty"`
 	// If the volume is hotplug, this will contain the hotplug status.
 	HotplugVolume *HotplugVolumeStatus `json:"hotplugVolume,omitempty"`
+	// If the volume is a memory dump volume, this will contain the memory dump info.
+	MemoryDumpVolume *DomainMemoryDumpInfo `json:"memoryDumpVolume,omitempty"`
 	// Represents the size of the volume
 	Size int64 `json:"size,omitempty"`
+	// Disk is the effective configuration of the disk of the volume in the domain
+	// +optional
+	Disk *VolumeDiskStatus `json:"disk,omitempty"`
+}
+
+// VolumeDiskStatus represents how the disk of a volume is attached to the guest.
+// +k8s:openapi-gen=true
+type VolumeDiskStatus struct {
+	// Type is the type of the disk source, either block or file
+	Type string `json:"type,omitempty"`
+	// Format is the format of the disk image, eg: raw or qcow2
+	Format string `json:"format,omitempty"`
+	// BackingFormat is the format of the backing image of the disk, if it has one
+	// +optional
+	BackingFormat string `json:"backingFormat,omitempty"`
+	// Cache is the effective cache mode of the disk
+	// +optional
+	Cache DriverCache `json:"cache,omitempty"`
+	// IO is the effective IO mode of the disk
+	// +optional
+	IO DriverIO `json:"io,omitempty"`
 }
 
 // HotplugVolumeStatus represents the hotplug status of the volume
@@ -300,6 +382,19 @@ type HotplugVolumeStatus struct {
 	AttachPodUID types.UID `json:"attachPodUID,omitempty"`
 }
 
+// DomainMemoryDumpInfo represents the progress of a memory dump written to a memory dump volume
+// +k8s:openapi-gen=true
+type DomainMemoryDumpInfo struct {
+	// StartTimestamp is the time when the memory dump started
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+	// EndTimestamp is the time when the memory dump completed
+	EndTimestamp *metav1.Time `json:"endTimestamp,omitempty"`
+	// ClaimName is the name of the PersistentVolumeClaim the memory dump is written to
+	ClaimName string `json:"claimName,omitempty"`
+	// TargetFileName is the name of the memory dump file on the PersistentVolumeClaim
+	TargetFileName string `json:"targetFileName,omitempty"`
+}
+
 // VolumePhase indicates the current phase of the hotplug process.
 // +k8s:openapi-gen=true
 type VolumePhase string
@@ -319,6 +414,12 @@ const (
 	HotplugVolumeDetaching VolumePhase = "Detaching"
 	// HotplugVolumeUnMounted means the volume has been unmounted from the virt-launcer pod.
 	HotplugVolumeUnMounted VolumePhase = "UnMountedFromPod"
+	// MemoryDumpVolumeInProgress means the memory dump is being written to the volume.
+	MemoryDumpVolumeInProgress VolumePhase = "MemoryDumpInProgress"
+	// MemoryDumpVolumeCompleted means the memory dump was written to the volume.
+	MemoryDumpVolumeCompleted VolumePhase = "MemoryDumpCompleted"
+	// MemoryDumpVolumeFailed means writing the memory dump to the volume failed.
+	MemoryDumpVolumeFailed VolumePhase = "MemoryDumpFailed"
 )
 
 func (v *VirtualMachineInstance) IsScheduling() bool {
@@ -350,6 +451,10 @@ func (v *VirtualMachineInstance) IsEvictable() bool {
 	return v.Spec.EvictionStrategy != nil && *v.Spec.EvictionStrategy == EvictionStrategyLiveMigrate
 }
 
+func (v *VirtualMachineInstance) IsEvictableIfPossible() bool {
+	return v.Spec.EvictionStrategy != nil && *v.Spec.EvictionStrategy == EvictionStrategyLiveMigrateIfPossible
+}
+
 func (v *VirtualMachineInstance) IsFinal() bool {
 	return v.Status.Phase == Failed || v.Status.Phase == Succeeded
 }
@@ -423,6 +528,8 @@ const (
 	VirtualMachineInstanceIsMigratable VirtualMachineInstanceConditionType = "LiveMigratable"
 	// Reason means that VMI is not live migratioable because of it's disks collection
 	VirtualMachineInstanceReasonDisksNotMigratable = "DisksNotLiveMigratable"
+	// Reason means that VMI is not live migratable because one of its PVCs is not shared
+	VirtualMachineInstanceReasonPVCNotMigratable = "PVCNotLiveMigratable"
 	// Reason means that VMI is not live migratioable because of it's network interfaces collection
 	VirtualMachineInstanceReasonInterfaceNotMigratable = "InterfaceNotLiveMigratable"
 	// Reason means that VMI is not live migratioable because it uses hotplug
@@ -431,6 +538,14 @@ const (
 	VirtualMachineInstanceReasonCPUModeNotMigratable = "CPUModeLiveMigratable"
 	// Reason means that VMI is not live migratable because it uses virtiofs
 	VirtualMachineInstanceReasonVirtIOFSNotMigratable = "VirtIOFSNotLiveMigratable"
+	// Reason means that VMI is not live migratable because it uses the Hyper-V passthrough mode
+	VirtualMachineInstanceReasonHypervPassthroughNotMigratable = "HypervPassthroughNotLiveMigratable"
+
+	// Reflects whether the running domain differs from the VMI spec
+	VirtualMachineInstanceDriftDetected VirtualMachineInstanceConditionType = "DriftDetected"
+
+	// Reflects whether all DataVolumes of the VMI are ready to be used
+	VirtualMachineInstanceDataVolumesReady VirtualMachineInstanceConditionType = "DataVolumesReady"
 )
 
 const (
@@ -445,6 +560,30 @@ const (
 
 	// GuestNotRunningReason indicates on the Ready condition on the VMI if the underlying guest VM is not running
 	GuestNotRunningReason = "GuestNotRunning"
+
+	// DomainDriftedReason indicates on the DriftDetected condition on the VMI that the running domain differs from the VMI spec
+	DomainDriftedReason = "DomainDrifted"
+
+	// SynchronizationFailedReason indicates on the Synchronized condition on the VMI that virt-handler failed to synchronize the VMI with the domain
+	SynchronizationFailedReason = "SynchronizationFailed"
+
+	// AgentChannelConnectedReason indicates on the AgentConnected condition on the VMI that the guest agent channel of the domain is connected
+	AgentChannelConnectedReason = "GuestAgentChannelConnected"
+
+	// PausedByUserReason indicates on the Paused condition on the VMI that the VMI was paused by the user
+	PausedByUserReason = "PausedByUser"
+
+	// PausedIOErrorReason indicates on the Paused condition on the VMI that the VMI was paused because of an IO error
+	PausedIOErrorReason = "PausedIOError"
+
+	// DataVolumesReadyReason indicates on the DataVolumesReady condition on the VMI that all DataVolumes succeeded
+	DataVolumesReadyReason = "AllDataVolumesReady"
+
+	// DataVolumesNotReadyReason indicates on the DataVolumesReady condition on the VMI that a DataVolume does not exist or is still being populated
+	DataVolumesNotReadyReason = "DataVolumesNotReady"
+
+	// DataVolumeFailedReason indicates on the DataVolumesReady condition on the VMI that a DataVolume failed
+	DataVolumeFailedReason = "DataVolumeFailed"
 )
 
 // +k8s:openapi-gen=true
@@ -484,12 +623,12 @@ type VirtualMachineInstanceMigrationCondition struct {
 
 // The migration phase indicates that the job has completed
 func (m *VirtualMachineInstanceMigration) IsFinal() bool {
-	return m.Status.Phase == MigrationFailed || m.Status.Phase == MigrationSucceeded
+	return m.Status.Phase == MigrationFailed || m.Status.Phase == MigrationSucceeded || m.Status.Phase == MigrationAborted
 }
 
 func (m *VirtualMachineInstanceMigration) IsRunning() bool {
 	switch m.Status.Phase {
-	case MigrationFailed, MigrationPending, MigrationPhaseUnset, MigrationSucceeded:
+	case MigrationFailed, MigrationPending, MigrationPhaseUnset, MigrationSucceeded, MigrationAborted:
 		return false
 	}
 	return true
@@ -580,6 +719,25 @@ type VirtualMachineInstanceMigrationState struct {
 	MigrationUID types.UID `json:"migrationUid,omitempty"`
 	// Lets us know if the vmi is currently running pre or post copy migration
 	Mode MigrationMode `json:"mode,omitempty"`
+	// The progress of the migration, updated periodically while the migration is running
+	// +optional
+	Progress *VirtualMachineInstanceMigrationProgress `json:"progress,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceMigrationProgress struct {
+	// The time the progress was last updated
+	// +nullable
+	UpdateTimestamp *metav1.Time `json:"updateTimestamp,omitempty"`
+	// The amount of data transferred to the target so far in bytes
+	TransferredBytes int64 `json:"transferredBytes,omitempty"`
+	// The amount of guest memory which still has to be transferred in bytes
+	MemoryRemainingBytes int64 `json:"memoryRemainingBytes,omitempty"`
+	// The rate at which the guest dirties its memory in pages per second
+	DirtyPageRate int64 `json:"dirtyPageRate,omitempty"`
+	// The number of passes over the guest memory, a high count means that the
+	// guest dirties its memory faster than it can be transferred
+	Iteration int64 `json:"iteration,omitempty"`
 }
 
 //
@@ -762,6 +920,8 @@ const (
 	HypervLabel = "hyperv.node.kubevirt.io/"
 	// This label represents vendor of cpu model on the node
 	CPUModelVendorLabel = "cpu-vendor.node.kubevirt.io/"
+	// This label represents supported disk buses on the node
+	DiskBusLabel = "disk-bus.node.kubevirt.io/"
 
 	// This label represents the host model CPU name
 	HostModelCPULabel = "host-model-cpu.node.kubevirt.io/"
@@ -782,6 +942,58 @@ const (
 
 	// MigrationTransportUnixAnnotation means that the VMI will be migrated using the unix URI
 	MigrationTransportUnixAnnotation string = "kubevirt.io/migrationTransportUnix"
+
+	// MemorySwapAnnotation marks virt-launcher pods of VMIs with Burstable memory, which may use swap
+	// on nodes with swap enabled
+	MemorySwapAnnotation string = "kubevirt.io/memory-swap"
+
+	// These annotations on a namespace provide the defaults for the dataVolumeTemplates of
+	// VirtualMachines created in that namespace. They apply only to fields which are not set.
+	DataVolumeDefaultStorageClassAnnotation string = "kubevirt.io/default-datavolume-storage-class"
+	DataVolumeDefaultVolumeModeAnnotation   string = "kubevirt.io/default-datavolume-volume-mode"
+	// A comma separated list of access modes, e.g. "ReadWriteMany,ReadWriteOnce"
+	DataVolumeDefaultAccessModesAnnotation string = "kubevirt.io/default-datavolume-access-modes"
+
+	// AppliedGuestDefaultsAnnotation is a comma separated list of the guest visible fields which were
+	// set from the cluster defaults, e.g. "machineType,cpuModel,networkInterface".
+	AppliedGuestDefaultsAnnotation string = "kubevirt.io/applied-guest-defaults"
+	// GuestDefaultsUpdateOptOutAnnotation set to "true" on a VirtualMachine prevents restarting it to
+	// apply changed guest visible cluster defaults.
+	GuestDefaultsUpdateOptOutAnnotation string = "kubevirt.io/guest-defaults-update-opt-out"
+	// RenamedFromAnnotation is set on a VirtualMachine which was created by renaming another one
+	// and holds the previous name.
+	RenamedFromAnnotation string = "kubevirt.io/renamed-from"
+	// MovedFromAnnotation is set on a VirtualMachine, and on the DataVolumes cloned for it, when it was
+	// moved from another namespace and holds the previous namespace.
+	MovedFromAnnotation string = "kubevirt.io/moved-from"
+	// MovedFromUIDAnnotation is set on the DataVolumes cloned for a moved VirtualMachine and holds the
+	// UID of the VirtualMachine they were cloned for.
+	MovedFromUIDAnnotation string = "kubevirt.io/moved-from-uid"
+	// StartGroupLabel assigns a VirtualMachine to a start group. Other VirtualMachines of the same
+	// namespace can wait for the VirtualMachines of a start group before they are started.
+	StartGroupLabel string = "kubevirt.io/start-group"
+	// StartAfterAnnotation is a comma separated list of start groups, e.g. "database,cache". The
+	// VirtualMachine is only started once the guest agents of all VirtualMachines of these groups are connected.
+	// VirtualMachines which are halted, or manual ones which are not started, are not waited for.
+	StartAfterAnnotation string = "kubevirt.io/start-after"
+	// LoadBalancerAnnotation set to "true" on a VirtualMachine gives it a dedicated Service of type
+	// LoadBalancer named "<vm name>-lb", which keeps its external IP while the VirtualMachine is restarted.
+	LoadBalancerAnnotation string = "kubevirt.io/load-balancer"
+	// LoadBalancerPortsAnnotation is a comma separated list of the ports and port ranges exposed by the
+	// load balancer, e.g. "22,80,8000-8010/UDP". By default the ports of the interfaces are exposed.
+	LoadBalancerPortsAnnotation string = "kubevirt.io/load-balancer-ports"
+	// LoadBalancerIPAnnotation requests a specific external IP for the load balancer, if the cloud
+	// provider supports it.
+	LoadBalancerIPAnnotation string = "kubevirt.io/load-balancer-ip"
+	// OSLabel names the operating system of a VirtualMachineInstance, e.g. "fedora27" or "win10".
+	// Operating systems starting with "win" are Windows.
+	OSLabel string = "kubevirt.io/os"
+	// VirtioWinDriversAnnotation set to "true" attaches the virtio-win drivers of the cluster to a
+	// VirtualMachineInstance, "false" prevents attaching them to a Windows VirtualMachineInstance.
+	VirtioWinDriversAnnotation string = "kubevirt.io/virtio-win-drivers"
+	// SerialConsoleLogAnnotation set to "true" on a VirtualMachineInstance lets virt-handler record the
+	// output of its serial console from when it starts running, it can be fetched from the console/log subresource.
+	SerialConsoleLogAnnotation string = "kubevirt.io/serial-console-log"
 )
 
 func NewVMI(name string, uid types.UID) *VirtualMachineInstance {
@@ -819,6 +1031,9 @@ const (
 	Resumed                      SyncEvent = "Resumed"
 	AccessCredentialsSyncFailed  SyncEvent = "AccessCredentialsSyncFailed"
 	AccessCredentialsSyncSuccess SyncEvent = "AccessCredentialsSyncSuccess"
+	AgentConnected               SyncEvent = "AgentConnected"
+	AgentDisconnected            SyncEvent = "AgentDisconnected"
+	IOError                      SyncEvent = "IOError"
 )
 
 func (s SyncEvent) String() string {
@@ -942,6 +1157,12 @@ type VirtualMachineInstanceReplicaSetSpec struct {
 	// Indicates that the replica set is paused.
 	// +optional
 	Paused bool `json:"paused,omitempty" protobuf:"varint,7,opt,name=paused"`
+
+	// Indicates that a headless Service named after the subdomain of the template is created
+	// for the VirtualMachineInstances of the replica set. Together with the subdomain it gives
+	// every VirtualMachineInstance the DNS name <hostname>.<subdomain>.<namespace>.svc.
+	// +optional
+	HeadlessService bool `json:"headlessService,omitempty"`
 }
 
 //
@@ -1085,6 +1306,8 @@ const (
 	MigrationSucceeded VirtualMachineInstanceMigrationPhase = "Succeeded"
 	// The migration failed
 	MigrationFailed VirtualMachineInstanceMigrationPhase = "Failed"
+	// The migration was aborted because the migration object was deleted
+	MigrationAborted VirtualMachineInstanceMigrationPhase = "Aborted"
 )
 
 // VirtualMachineInstancePreset defines a VMI spec.domain to be applied to all VMIs that match the provided label selector
@@ -1205,6 +1428,21 @@ const (
 	RunStrategyRerunOnFailure VirtualMachineRunStrategy = "RerunOnFailure"
 )
 
+// NodeRebootPolicy defines how a VirtualMachine reacts when its VirtualMachineInstance
+// was running on a node which rebooted unexpectedly.
+//
+// +k8s:openapi-gen=true
+type NodeRebootPolicy string
+
+const (
+	// NodeRebootPolicyRestart restarts the VirtualMachineInstance after a node reboot,
+	// also if the VirtualMachine has the Manual RunStrategy.
+	NodeRebootPolicyRestart NodeRebootPolicy = "Restart"
+	// NodeRebootPolicyHold keeps the failed VirtualMachineInstance after a node reboot,
+	// also if the RunStrategy would restart it, until the VirtualMachine is restarted explicitly.
+	NodeRebootPolicyHold NodeRebootPolicy = "Hold"
+)
+
 // VirtualMachineSpec describes how the proper VirtualMachine
 // should look like
 //
@@ -1218,6 +1456,15 @@ type VirtualMachineSpec struct {
 	// mutually exclusive with Running
 	RunStrategy *VirtualMachineRunStrategy `json:"runStrategy,omitempty" optional:"true"`
 
+	// NodeRebootPolicy controls what happens to a VirtualMachineInstance which was running
+	// on a node that rebooted. Defaults to the behavior of the RunStrategy.
+	// +optional
+	NodeRebootPolicy *NodeRebootPolicy `json:"nodeRebootPolicy,omitempty"`
+
+	// Schedule starts and stops the VirtualMachine at recurring times
+	// +optional
+	Schedule *VirtualMachineSchedule `json:"schedule,omitempty"`
+
 	// Template is the direct specification of VirtualMachineInstance
 	Template *VirtualMachineInstanceTemplateSpec `json:"template"`
 
@@ -1226,6 +1473,26 @@ type VirtualMachineSpec struct {
 	DataVolumeTemplates []DataVolumeTemplateSpec `json:"dataVolumeTemplates,omitempty"`
 }
 
+// VirtualMachineSchedule defines at which times a VirtualMachine is started and stopped.
+// A schedule acts like the start and stop subresources: a VirtualMachine which was
+// started or stopped in between keeps its state until the next scheduled time.
+//
+// +k8s:openapi-gen=true
+type VirtualMachineSchedule struct {
+	// Start is a cron expression in the five field format "minute hour day-of-month month day-of-week",
+	// e.g. "0 8 * * 1-5", at whose times the VirtualMachine is started
+	// +optional
+	Start string `json:"start,omitempty"`
+	// Stop is a cron expression in the five field format "minute hour day-of-month month day-of-week",
+	// e.g. "0 20 * * *", at whose times the VirtualMachine is stopped
+	// +optional
+	Stop string `json:"stop,omitempty"`
+	// TimeZone is the name of the IANA time zone the cron expressions are evaluated in, e.g. "Europe/Berlin".
+	// Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
 // StateChangeRequestType represents the existing state change requests that are possible
 //
 // +k8s:openapi-gen=true
@@ -1233,8 +1500,10 @@ type StateChangeRequestAction string
 
 // These are the currently defined state change requests
 const (
-	StartRequest StateChangeRequestAction = "Start"
-	StopRequest  StateChangeRequestAction = "Stop"
+	StartRequest  StateChangeRequestAction = "Start"
+	StopRequest   StateChangeRequestAction = "Stop"
+	RenameRequest StateChangeRequestAction = "Rename"
+	MoveRequest   StateChangeRequestAction = "Move"
 )
 
 // VirtualMachinePrintableStatus is a human readable, high-level representation of the status of the virtual machine.
@@ -1327,8 +1596,110 @@ type VirtualMachineStatus struct {
 	// +nullable
 	// +optional
 	StartFailure *VirtualMachineStartFailure `json:"startFailure,omitempty" optional:"true"`
+
+	// RestartCount is the number of failed VMIs which were started again after a
+	// crash loop backoff
+	// +optional
+	RestartCount int `json:"restartCount,omitempty" optional:"true"`
+
+	// Schedule reports the scheduled starts and stops of the VM
+	// +nullable
+	// +optional
+	Schedule *VirtualMachineScheduleStatus `json:"schedule,omitempty" optional:"true"`
+
+	// MemoryDumpRequest tracks the memory dump request of the VM and the
+	// PersistentVolumeClaim it is associated with
+	// +nullable
+	// +optional
+	MemoryDumpRequest *VirtualMachineMemoryDumpRequest `json:"memoryDumpRequest,omitempty" optional:"true"`
+
+	// Availability records the starts and the unplanned downtime of the VM
+	// +nullable
+	// +optional
+	Availability *VirtualMachineAvailabilityStatus `json:"availability,omitempty" optional:"true"`
+}
+
+// VirtualMachineAvailabilityStatus records the starts and the unplanned downtime of a VM,
+// from which its availability metrics are derived
+//
+// +k8s:openapi-gen=true
+type VirtualMachineAvailabilityStatus struct {
+	// StartRequestTime is the time the latest start of the VM was requested
+	// +optional
+	StartRequestTime *metav1.Time `json:"startRequestTime,omitempty"`
+	// ReadyTime is the time the guest became ready after the latest start request
+	// +optional
+	ReadyTime *metav1.Time `json:"readyTime,omitempty"`
+	// FailureTime is the time the VMI failed, it is only set while the resulting downtime is ongoing
+	// +optional
+	FailureTime *metav1.Time `json:"failureTime,omitempty"`
+	// UnplannedDowntimeSeconds is the cumulative downtime of the VM after its VMIs failed,
+	// without the ongoing downtime
+	// +optional
+	UnplannedDowntimeSeconds int64 `json:"unplannedDowntimeSeconds,omitempty"`
+}
+
+// VirtualMachineScheduleStatus reports the scheduled starts and stops of a VM
+//
+// +k8s:openapi-gen=true
+type VirtualMachineScheduleStatus struct {
+	// LastScheduleTime is the time of the last scheduled start or stop which was applied
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// NextStartTime is the time of the next scheduled start
+	// +optional
+	NextStartTime *metav1.Time `json:"nextStartTime,omitempty"`
+	// NextStopTime is the time of the next scheduled stop
+	// +optional
+	NextStopTime *metav1.Time `json:"nextStopTime,omitempty"`
+}
+
+// VirtualMachineMemoryDumpRequest represents a memory dump of a VM to a PersistentVolumeClaim
+//
+// +k8s:openapi-gen=true
+type VirtualMachineMemoryDumpRequest struct {
+	// ClaimName is the name of the PersistentVolumeClaim the memory dump is written to
+	ClaimName string `json:"claimName"`
+	// Phase is the phase of the memory dump
+	// +optional
+	Phase MemoryDumpPhase `json:"phase,omitempty"`
+	// Remove requests to dissociate the PersistentVolumeClaim from the VM
+	// +optional
+	Remove bool `json:"remove,omitempty"`
+	// StartTimestamp is the time when the memory dump started
+	// +optional
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+	// EndTimestamp is the time when the memory dump completed
+	// +optional
+	EndTimestamp *metav1.Time `json:"endTimestamp,omitempty"`
+	// FileName is the name of the memory dump file on the PersistentVolumeClaim
+	// +optional
+	FileName *string `json:"fileName,omitempty"`
+	// Message is a detailed message about a failed memory dump
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
+// MemoryDumpPhase is the phase of a memory dump of a VM
+//
+// +k8s:openapi-gen=true
+type MemoryDumpPhase string
+
+const (
+	// MemoryDumpAssociating means the PersistentVolumeClaim is being hotplugged to the VMI
+	MemoryDumpAssociating MemoryDumpPhase = "Associating"
+	// MemoryDumpInProgress means the memory dump is being written
+	MemoryDumpInProgress MemoryDumpPhase = "InProgress"
+	// MemoryDumpUnmounting means the memory dump was written and the PersistentVolumeClaim is being unplugged from the VMI
+	MemoryDumpUnmounting MemoryDumpPhase = "Unmounting"
+	// MemoryDumpCompleted means the memory dump is available on the PersistentVolumeClaim
+	MemoryDumpCompleted MemoryDumpPhase = "Completed"
+	// MemoryDumpDissociating means the PersistentVolumeClaim is being removed from the VM
+	MemoryDumpDissociating MemoryDumpPhase = "Dissociating"
+	// MemoryDumpFailed means the memory dump failed
+	MemoryDumpFailed MemoryDumpPhase = "Failed"
+)
+
 // +k8s:openapi-gen=true
 type VolumeSnapshotStatus struct {
 	// Volume name
@@ -1389,6 +1760,22 @@ const (
 	// VirtualMachinePaused is added in a virtual machine when its vmi
 	// signals with its own condition that it is paused.
 	VirtualMachinePaused VirtualMachineConditionType = "Paused"
+
+	// VirtualMachineGuestDefaultsOutdated is added in a virtual machine when its vmi runs
+	// with guest visible cluster defaults which have changed since the vmi was started.
+	VirtualMachineGuestDefaultsOutdated VirtualMachineConditionType = "GuestDefaultsOutdated"
+
+	// VirtualMachineRestartRequired is added in a virtual machine when its vmi runs with a
+	// machine type which differs from the one of the vm template. It applies on the next restart.
+	VirtualMachineRestartRequired VirtualMachineConditionType = "RestartRequired"
+
+	// VirtualMachineCrashLoopBackOff is added in a virtual machine when its vmis keep failing
+	// and the next start is delayed by the crash loop backoff.
+	VirtualMachineCrashLoopBackOff VirtualMachineConditionType = "CrashLoopBackOff"
+
+	// VirtualMachineMoveFailed is added in a virtual machine when moving it to another namespace
+	// failed for good. The move request is dropped and the clones of its volumes are removed.
+	VirtualMachineMoveFailed VirtualMachineConditionType = "MoveFailed"
 )
 
 //
@@ -1424,11 +1811,17 @@ type DriverCache string
 // +k8s:openapi-gen=true
 type DriverIO string
 
+//
+// +k8s:openapi-gen=true
+type DriverDiscard string
+
 const (
 	// CacheNone - I/O from the guest is not cached on the host, but may be kept in a writeback disk cache.
 	CacheNone DriverCache = "none"
 	// CacheWriteThrough - I/O from the guest is cached on the host but written through to the physical medium.
 	CacheWriteThrough DriverCache = "writethrough"
+	// CacheWriteBack - I/O from the guest is cached on the host and written to the physical medium when the guest flushes it.
+	CacheWriteBack DriverCache = "writeback"
 
 	// IOThreads - User mode based threads with a shared lock that perform I/O tasks. Can impact performance but offers
 	// more predictable behaviour. This method is also takes fewer CPU cycles to submit I/O requests.
@@ -1439,6 +1832,12 @@ const (
 	// IODefault - Fallback to the default value from the kernel. With recent Kernel versions (for example RHEL-7) the
 	// default is AIO.
 	IODefault DriverIO = "default"
+
+	// DiscardUnmap - Discard requests of the guest are passed to the storage, which frees the discarded blocks of
+	// thin-provisioned volumes.
+	DiscardUnmap DriverDiscard = "unmap"
+	// DiscardIgnore - Discard requests of the guest are ignored, so preallocated volumes keep all of their blocks.
+	DiscardIgnore DriverDiscard = "ignore"
 )
 
 // Handler defines a specific action that should be taken
@@ -1800,7 +2199,8 @@ const (
 )
 
 const (
-	EvictionStrategyLiveMigrate EvictionStrategy = "LiveMigrate"
+	EvictionStrategyLiveMigrate           EvictionStrategy = "LiveMigrate"
+	EvictionStrategyLiveMigrateIfPossible EvictionStrategy = "LiveMigrateIfPossible"
 )
 
 // RestartOptions may be provided when deleting an API object.
@@ -1829,11 +2229,69 @@ type StartOptions struct {
 	Paused bool `json:"paused,omitempty" protobuf:"varint,7,opt,name=paused"`
 }
 
+// PauseOptions may be provided on pause request.
+//
+// +k8s:openapi-gen=true
+type PauseOptions struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// When present, indicates that the VMI is not paused, but only validated for pausing.
+	// An invalid or unrecognized dryRun directive will result in an error response and
+	// no further processing of the request. Valid values are:
+	// - All: all dry run stages will be processed
+	// +optional
+	// +listType=atomic
+	DryRun []string `json:"dryRun,omitempty"`
+}
+
+// UnpauseOptions may be provided on unpause request.
+//
+// +k8s:openapi-gen=true
+type UnpauseOptions struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// When present, indicates that the VMI is not unpaused, but only validated for unpausing.
+	// An invalid or unrecognized dryRun directive will result in an error response and
+	// no further processing of the request. Valid values are:
+	// - All: all dry run stages will be processed
+	// +optional
+	// +listType=atomic
+	DryRun []string `json:"dryRun,omitempty"`
+}
+
 const (
 	StartRequestDataPausedKey  string = "paused"
 	StartRequestDataPausedTrue string = "true"
 )
 
+// RenameOptions may be provided on rename request.
+//
+// +k8s:openapi-gen=true
+type RenameOptions struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// The new name of the VirtualMachine
+	NewName string `json:"newName"`
+}
+
+const (
+	RenameRequestDataNewNameKey string = "newName"
+)
+
+// MoveOptions may be provided on move request.
+//
+// +k8s:openapi-gen=true
+type MoveOptions struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// The namespace the VirtualMachine and its volumes are moved to
+	NewNamespace string `json:"newNamespace"`
+}
+
+const (
+	MoveRequestDataNewNamespaceKey string = "newNamespace"
+)
+
 // StopOptions may be provided when deleting an API object.
 //
 // +k8s:openapi-gen=true
@@ -1923,6 +2381,15 @@ type VirtualMachineInstanceFileSystem struct {
 	TotalBytes     int    `json:"totalBytes"`
 }
 
+// VirtualMachineInstanceFileSystemFreeSpace represents the free space of a guest filesystem
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceFileSystemFreeSpace struct {
+	MountPoint     string `json:"mountPoint"`
+	FileSystemType string `json:"fileSystemType"`
+	FreeBytes      int64  `json:"freeBytes"`
+	TotalBytes     int64  `json:"totalBytes"`
+}
+
 // AddVolumeOptions is provided when dynamically hot plugging a volume and disk
 // +k8s:openapi-gen=true
 type AddVolumeOptions struct {
@@ -1944,6 +2411,54 @@ type RemoveVolumeOptions struct {
 	Name string `json:"name"`
 }
 
+// MediaChangeOptions is provided when changing the media of a CD-ROM of a running VMI
+// +k8s:openapi-gen=true
+type MediaChangeOptions struct {
+	// Name represents the name of the CD-ROM disk and its volume
+	Name string `json:"name"`
+	// VolumeSource represents the source of the media to insert.
+	// The media is ejected if no volume source is provided.
+	// +optional
+	VolumeSource *HotplugVolumeSource `json:"volumeSource,omitempty"`
+}
+
+// GuestExecOptions is provided when running a command in the guest of a running VMI
+// +k8s:openapi-gen=true
+type GuestExecOptions struct {
+	// Command is the path of the command in the guest. It has to be part of the
+	// guestExecAllowList of the KubeVirt configuration.
+	Command string `json:"command"`
+	// Args are passed to the command. They have to match the args of the command in the
+	// guestExecAllowList.
+	// +optional
+	Args []string `json:"args,omitempty"`
+	// TimeoutSeconds is the time the command may run. Defaults to 10 seconds.
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// GuestExecCommand is a command of the guestExecAllowList
+// +k8s:openapi-gen=true
+type GuestExecCommand struct {
+	// Path is the path of the command in the guest, like "/usr/bin/uptime".
+	Path string `json:"path"`
+	// Args are regular expressions for the arguments of the command. The command can only be run
+	// with one argument per expression, which has to match the expression completely.
+	// Without args the command can only be run without arguments.
+	// +optional
+	Args []string `json:"args,omitempty"`
+}
+
+// GuestExecResult is returned once a command ran in the guest of a VMI
+// +k8s:openapi-gen=true
+type GuestExecResult struct {
+	// ExitCode is the exit code of the command
+	ExitCode int32 `json:"exitCode"`
+	// StdOut is the standard output of the command
+	// +optional
+	StdOut string `json:"stdOut,omitempty"`
+}
+
 // +k8s:openapi-gen=true
 type TokenBucketRateLimiter struct {
 	// QPS indicates the maximum QPS to the apiserver from this client.
@@ -2002,6 +2517,172 @@ type KubeVirtConfiguration struct {
 	WebhookConfiguration           *ReloadableComponentConfiguration `json:"webhookConfiguration,omitempty"`
 	ControllerConfiguration        *ReloadableComponentConfiguration `json:"controllerConfiguration,omitempty"`
 	HandlerConfiguration           *ReloadableComponentConfiguration `json:"handlerConfiguration,omitempty"`
+	OperatorConfiguration          *ReloadableComponentConfiguration `json:"operatorConfiguration,omitempty"`
+	ConsoleRecording               *ConsoleRecordingConfiguration    `json:"consoleRecording,omitempty"`
+	GuestDefaultsUpdateStrategy    GuestDefaultsUpdateStrategy       `json:"guestDefaultsUpdateStrategy,omitempty"`
+	// QEMUArgsAllowList holds the names of the QEMU arguments, like "-fw_cfg", which
+	// VirtualMachineInstances may append to the QEMU command line. Requires the QEMUArgs feature gate.
+	QEMUArgsAllowList []string `json:"qemuArgsAllowList,omitempty"`
+	// Swap configures the swap usage of VirtualMachineInstances with Burstable memory.
+	// Requires the VMSwap feature gate.
+	Swap *SwapConfiguration `json:"swap,omitempty"`
+	// DeprecatedMachineTypes holds the machine types which VirtualMachines should no longer use,
+	// matched like emulatedMachines. VirtualMachines with a deprecated machine type are updated to
+	// machineType, which applies on their next restart.
+	DeprecatedMachineTypes []string `json:"deprecatedMachineTypes,omitempty"`
+	// CrashLoopBackOff configures the delay before VirtualMachines, whose VirtualMachineInstances
+	// keep failing, are started again.
+	CrashLoopBackOff *CrashLoopBackOffConfiguration `json:"crashLoopBackOff,omitempty"`
+	// FilesystemOverhead is the fraction of filesystem PersistentVolumeClaims which disk images
+	// leave free for the filesystem itself.
+	FilesystemOverhead *FilesystemOverhead `json:"filesystemOverhead,omitempty"`
+	// DiskConfiguration holds the cluster wide defaults and limits of disks.
+	DiskConfiguration *DiskConfiguration `json:"disks,omitempty"`
+	// GuestExecAllowList holds the commands, with the arguments they may be run with, which may be
+	// run in the guests of VirtualMachineInstances through the guestexec subresource.
+	// Requires the GuestExec feature gate.
+	GuestExecAllowList []GuestExecCommand `json:"guestExecAllowList,omitempty"`
+	// AuditLog enables the audit log of virt-api, which records who accessed which subresource,
+	// like the console, of which VirtualMachine or VirtualMachineInstance.
+	AuditLog *AuditLogConfiguration `json:"auditLog,omitempty"`
+	// LeaderElection configures the leader election of virt-controller and virt-operator.
+	// It is applied when they start.
+	LeaderElection *LeaderElectionConfiguration `json:"leaderElection,omitempty"`
+}
+
+// AuditLogSink is where virt-api writes the audit events to
+type AuditLogSink string
+
+const (
+	// AuditLogSinkStdout writes the audit events as JSON lines to the standard output of virt-api
+	AuditLogSinkStdout AuditLogSink = "stdout"
+	// AuditLogSinkFile appends the audit events as JSON lines to a file in the virt-api container
+	AuditLogSinkFile AuditLogSink = "file"
+)
+
+// AuditLogConfiguration holds the options of the audit log of virt-api
+// +k8s:openapi-gen=true
+type AuditLogConfiguration struct {
+	// Sink is where the audit events are written to, either "stdout" or "file".
+	// Defaults to "stdout".
+	// +optional
+	Sink AuditLogSink `json:"sink,omitempty"`
+	// Path is the file which the audit events are appended to, if the sink is "file".
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// LeaderElectionConfiguration holds the timing of the leader election of virt-controller
+// and virt-operator
+// +k8s:openapi-gen=true
+type LeaderElectionConfiguration struct {
+	// LeaseDuration is the time which candidates wait after the last renewal of the leader,
+	// until they try to take the lead. Defaults to 15s.
+	// +optional
+	LeaseDuration *metav1.Duration `json:"leaseDuration,omitempty"`
+	// RenewDeadline is the time which the leader tries to renew its lease, until it gives up
+	// the lead. Must be less than the lease duration. Defaults to 10s.
+	// +optional
+	RenewDeadline *metav1.Duration `json:"renewDeadline,omitempty"`
+	// RetryPeriod is the time between two attempts to take or to renew the lead.
+	// Defaults to 2s.
+	// +optional
+	RetryPeriod *metav1.Duration `json:"retryPeriod,omitempty"`
+}
+
+// DiskConfiguration holds the cluster wide defaults and limits of disks
+// +k8s:openapi-gen=true
+type DiskConfiguration struct {
+	// StorageClassCacheModes maps the names of storage classes to the cache mode of the disks
+	// on their PersistentVolumeClaims which don't set a cache mode.
+	// Supported values are: none, writethrough, writeback.
+	// Disks with writeback can not be live migrated.
+	// +optional
+	StorageClassCacheModes map[string]DriverCache `json:"storageClassCacheModes,omitempty"`
+	// ContainerDiskEphemeralStorageLimit is the amount of data a VirtualMachineInstance can write
+	// to each of its containerDisks. virt-launcher pods which write more are evicted.
+	// +optional
+	ContainerDiskEphemeralStorageLimit *resource.Quantity `json:"containerDiskEphemeralStorageLimit,omitempty"`
+	// DefaultBus is the bus of the disks which don't set one.
+	// Supported values are: virtio, sata, scsi, nvme. Defaults to sata.
+	// +optional
+	DefaultBus string `json:"defaultBus,omitempty"`
+	// VirtioWinContainerDiskImage is a containerDisk image with the virtio-win drivers. It is attached
+	// as a CD-ROM to Windows VirtualMachineInstances, so the drivers of the virtio devices can be
+	// installed during the Windows setup.
+	// +optional
+	VirtioWinContainerDiskImage string `json:"virtioWinContainerDiskImage,omitempty"`
+}
+
+// FilesystemOverhead holds the fractions of filesystem PersistentVolumeClaims reserved for the
+// filesystem, as decimals below 1 with up to three digits, e.g. "0.055" for 5.5%
+// +k8s:openapi-gen=true
+type FilesystemOverhead struct {
+	// Global is the overhead of the storage classes without an entry in StorageClass.
+	// Defaults to no overhead.
+	// +optional
+	Global string `json:"global,omitempty"`
+	// StorageClass maps the names of storage classes to their overhead.
+	// +optional
+	StorageClass map[string]string `json:"storageClass,omitempty"`
+}
+
+// GuestDefaultsUpdateStrategy defines how VirtualMachines, which run with guest visible cluster
+// defaults which have changed since they were started, are updated.
+// +k8s:openapi-gen=true
+type GuestDefaultsUpdateStrategy string
+
+const (
+	// GuestDefaultsUpdateNone only reports the outdated defaults in the VirtualMachine status
+	GuestDefaultsUpdateNone GuestDefaultsUpdateStrategy = "None"
+	// GuestDefaultsUpdateRestart restarts VirtualMachines with the Always run strategy to apply the
+	// new defaults, unless they opt out with the GuestDefaultsUpdateOptOutAnnotation. At most 10
+	// VirtualMachines are restarted per minute.
+	GuestDefaultsUpdateRestart GuestDefaultsUpdateStrategy = "Restart"
+)
+
+// ConsoleRecordingCredentialsSecretName is the name of the secret in the KubeVirt install namespace
+// whose "token" key is sent as bearer token when uploading console recordings. virt-api may only
+// read this secret.
+const ConsoleRecordingCredentialsSecretName = "kubevirt-console-recording-credentials"
+
+// ConsoleRecordingConfiguration holds the options for recording serial console and VNC sessions
+// +k8s:openapi-gen=true
+type ConsoleRecordingConfiguration struct {
+	// ObjectStoreURL is the base URL of a bucket in an object store. Every recorded session is
+	// uploaded to it with a HTTP PUT request once the session is closed. If the
+	// kubevirt-console-recording-credentials secret exists in the KubeVirt install namespace, its
+	// "token" key is sent as bearer token.
+	ObjectStoreURL string `json:"objectStoreURL"`
+	// RecordVNC enables the recording of VNC sessions. Only serial console sessions are
+	// recorded by default.
+	// +optional
+	RecordVNC *bool `json:"recordVNC,omitempty"`
+}
+
+// SwapConfiguration holds the settings which virt-handler applies to the memory cgroup of
+// virt-launcher pods which may use swap
+// +k8s:openapi-gen=true
+type SwapConfiguration struct {
+	// Swappiness of the memory cgroup, between 0 and 100. It is only supported with cgroup v1,
+	// the swappiness of the node applies with cgroup v2.
+	// +optional
+	Swappiness *int64 `json:"swappiness,omitempty"`
+}
+
+// CrashLoopBackOffConfiguration holds the settings of the exponential backoff which delays
+// restarting VirtualMachineInstances that keep failing
+// +k8s:openapi-gen=true
+type CrashLoopBackOffConfiguration struct {
+	// MaxDelaySeconds caps the delay before a failed VirtualMachineInstance is started again.
+	// Defaults to 300.
+	// +optional
+	MaxDelaySeconds *int64 `json:"maxDelaySeconds,omitempty"`
+	// ResetAfterSeconds is the time a VirtualMachineInstance of a VirtualMachine with the
+	// RerunOnFailure run strategy has to run until its failures are no longer counted as a
+	// crash loop. Defaults to 600.
+	// +optional
+	ResetAfterSeconds *int64 `json:"resetAfterSeconds,omitempty"`
 }
 
 //
@@ -2027,6 +2708,10 @@ type MigrationConfiguration struct {
 	UnsafeMigrationOverride           *bool              `json:"unsafeMigrationOverride,omitempty"`
 	AllowPostCopy                     *bool              `json:"allowPostCopy,omitempty"`
 	DisableTLS                        *bool              `json:"disableTLS,omitempty"`
+	// ParallelMigrationThreads is the number of parallel multifd channels used to transfer
+	// the guest memory. Parallel migrations are not combined with post copy migrations.
+	// +optional
+	ParallelMigrationThreads *uint32 `json:"parallelMigrationThreads,omitempty"`
 }
 
 // DiskVerification holds container disks verification limits
@@ -2052,8 +2737,21 @@ type DeveloperConfiguration struct {
 	MinimumClusterTSCFrequency *int64            `json:"minimumClusterTSCFrequency,omitempty"`
 	DiskVerification           *DiskVerification `json:"diskVerification,omitempty"`
 	LogVerbosity               *LogVerbosity     `json:"logVerbosity,omitempty"`
+	// LogFormat is the format of the lines of the libraries used by the components, like client-go.
+	// With json they are written as JSON like the lines of the components, with text they keep
+	// their own format. Defaults to text.
+	// +optional
+	LogFormat LogFormat `json:"logFormat,omitempty"`
 }
 
+// LogFormat is the format of the log lines of the libraries used by the components
+type LogFormat string
+
+const (
+	LogFormatText LogFormat = "text"
+	LogFormatJSON LogFormat = "json"
+)
+
 // LogVerbosity sets log verbosity level of  various components
 // +k8s:openapi-gen=true
 type LogVerbosity struct {
@@ -2073,6 +2771,8 @@ type PermittedHostDevices struct {
 	PciHostDevices []PciHostDevice `json:"pciHostDevices,omitempty"`
 	// +listType=atomic
 	MediatedDevices []MediatedHostDevice `json:"mediatedDevices,omitempty"`
+	// +listType=atomic
+	USB []USBHostDevice `json:"usb,omitempty"`
 }
 
 // PciHostDevice represents a host PCI device allowed for passthrough
@@ -2099,11 +2799,50 @@ type MediatedHostDevice struct {
 	ExternalResourceProvider bool   `json:"externalResourceProvider,omitempty"`
 }
 
+// USBHostDevice represents a host USB device allowed for passthrough
+// +k8s:openapi-gen=true
+type USBHostDevice struct {
+	// The list of vendor:product selectors of the USB devices which are
+	// exposed with this resource
+	// +listType=atomic
+	Selectors []USBSelector `json:"selectors,omitempty"`
+	// The name of the resource that is representing the device. Exposed by
+	// a device plugin and requested by VMs. Typically of the form
+	// vendor.com/product_name
+	ResourceName string `json:"resourceName"`
+	// If true, KubeVirt will leave the allocation and monitoring to an
+	// external device plugin
+	ExternalResourceProvider bool `json:"externalResourceProvider,omitempty"`
+}
+
+// USBSelector selects USB devices by their vendor and product IDs
+// +k8s:openapi-gen=true
+type USBSelector struct {
+	// The vendor ID of the USB device, e.g. 046d
+	Vendor string `json:"vendor"`
+	// The product ID of the USB device, e.g. c52b
+	Product string `json:"product"`
+}
+
 // MediatedDevicesConfiguration holds inforamtion about MDEV types to be defined, if available
 // +k8s:openapi-gen=true
 type MediatedDevicesConfiguration struct {
 	// +listType=atomic
 	MediatedDevicesTypes []string `json:"mediatedDevicesTypes,omitempty"`
+	// NodeMediatedDeviceTypes overrides the mediatedDevicesTypes on the nodes matching
+	// one or more of their node selectors.
+	// +optional
+	// +listType=atomic
+	NodeMediatedDeviceTypes []NodeMediatedDeviceTypesConfig `json:"nodeMediatedDeviceTypes,omitempty"`
+}
+
+// NodeMediatedDeviceTypesConfig holds information about MDEV types to be defined on the nodes matching the NodeSelector
+// +k8s:openapi-gen=true
+type NodeMediatedDeviceTypesConfig struct {
+	// NodeSelector must match the labels of a node for the mediated device types to be defined on it.
+	NodeSelector map[string]string `json:"nodeSelector"`
+	// +listType=atomic
+	MediatedDevicesTypes []string `json:"mediatedDevicesTypes"`
 }
 
 // NetworkConfiguration holds network options
@@ -2112,6 +2851,20 @@ type NetworkConfiguration struct {
 	NetworkInterface                  string `json:"defaultNetworkInterface,omitempty"`
 	PermitSlirpInterface              *bool  `json:"permitSlirpInterface,omitempty"`
 	PermitBridgeInterfaceOnPodNetwork *bool  `json:"permitBridgeInterfaceOnPodNetwork,omitempty"`
+	// MacAddressPool is the range of MAC addresses which virt-controller assigns to the interfaces
+	// of VirtualMachines without an explicit MAC address. The addresses are stored in the
+	// VirtualMachine and survive restarts and migrations.
+	// +optional
+	MacAddressPool *MacAddressPool `json:"macAddressPool,omitempty"`
+}
+
+// MacAddressPool is an inclusive range of unicast MAC addresses
+// +k8s:openapi-gen=true
+type MacAddressPool struct {
+	// RangeStart is the first MAC address of the pool, e.g. "02:00:00:00:00:00"
+	RangeStart string `json:"rangeStart"`
+	// RangeEnd is the last MAC address of the pool, e.g. "02:00:00:ff:ff:ff"
+	RangeEnd string `json:"rangeEnd"`
 }
 
 // GuestAgentPing configures the guest-agent based ping probe
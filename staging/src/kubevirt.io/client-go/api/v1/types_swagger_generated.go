@@ -19,18 +19,19 @@ func (VirtualMachineInstanceList) SwaggerDoc() map[string]string {
 func (VirtualMachineInstanceSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                              "VirtualMachineInstanceSpec is a description of a VirtualMachineInstance.\n\n+k8s:openapi-gen=true",
-		"priorityClassName":             "If specified, indicates the pod's priority.\nIf not specified, the pod priority will be default or zero if there is no\ndefault.\n+optional",
+		"priorityClassName":             "If specified, indicates the pod's priority.\nIf not specified, the pod priority will be default or zero if there is no\ndefault.\nVMIs with a higher priority are evacuated first when a node is drained.\n+optional",
 		"domain":                        "Specification of the desired behavior of the VirtualMachineInstance on the host.",
 		"nodeSelector":                  "NodeSelector is a selector which must be true for the vmi to fit on a node.\nSelector which must match a node's labels for the vmi to be scheduled on that node.\nMore info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/\n+optional",
 		"affinity":                      "If affinity is specifies, obey all the affinity rules",
 		"schedulerName":                 "If specified, the VMI will be dispatched by specified scheduler.\nIf not specified, the VMI will be dispatched by default scheduler.\n+optional",
 		"tolerations":                   "If toleration is specified, obey all the toleration rules.",
-		"evictionStrategy":              "EvictionStrategy can be set to \"LiveMigrate\" if the VirtualMachineInstance should be\nmigrated instead of shut-off in case of a node drain.\n\n+optional",
+		"evictionStrategy":              "EvictionStrategy can be set to \"LiveMigrate\" if the VirtualMachineInstance should be\nmigrated instead of shut-off in case of a node drain, or to \"LiveMigrateIfPossible\" if\nit should be migrated when it is live-migratable and evicted otherwise.\n\n+optional",
 		"startStrategy":                 "StartStrategy can be set to \"Paused\" if Virtual Machine should be started in paused state.\n\n+optional",
 		"terminationGracePeriodSeconds": "Grace period observed after signalling a VirtualMachineInstance to stop after which the VirtualMachineInstance is force terminated.",
 		"volumes":                       "List of volumes that can be mounted by disks belonging to the vmi.",
 		"livenessProbe":                 "Periodic probe of VirtualMachineInstance liveness.\nVirtualmachineInstances will be stopped if the probe fails.\nCannot be updated.\nMore info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes\n+optional",
 		"readinessProbe":                "Periodic probe of VirtualMachineInstance service readiness.\nVirtualmachineInstances will be removed from service endpoints if the probe fails.\nCannot be updated.\nMore info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes\n+optional",
+		"startupProbe":                  "Probe indicating that the VirtualMachineInstance has successfully started.\nLiveness and readiness probes are not executed until it succeeds.\nVirtualmachineInstances will be stopped if the probe fails.\nCannot be updated.\nMore info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes\n+optional",
 		"hostname":                      "Specifies the hostname of the vmi\nIf not specified, the hostname will be set to the name of the vmi, if dhcp or cloud-init is configured properly.\n+optional",
 		"subdomain":                     "If specified, the fully qualified vmi hostname will be \"<hostname>.<subdomain>.<pod namespace>.svc.<cluster domain>\".\nIf not specified, the vmi will not have a domainname at all. The DNS entry will resolve to the vmi,\nno matter if the vmi itself can pick up a hostname.\n+optional",
 		"networks":                      "List of networks that can be attached to a vm's virtual interface.",
@@ -54,16 +55,30 @@ func (TopologyHints) SwaggerDoc() map[string]string {
 	}
 }
 
+func (VirtualMachineInstanceStartupTimestamps) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                    "VirtualMachineInstanceStartupTimestamps holds the times at which a VirtualMachineInstance passed\nthe steps of its start. A step which was not reached yet is not set.\n\n+k8s:openapi-gen=true",
+		"podScheduled":        "PodScheduled is the time the virt-launcher pod was scheduled to a node\n+optional",
+		"containerStarted":    "ContainerStarted is the time the compute container of the virt-launcher pod started\n+optional",
+		"domainDefined":       "DomainDefined is the time virt-handler saw the domain defined in libvirt\n+optional",
+		"domainRunning":       "DomainRunning is the time virt-handler saw the domain running\n+optional",
+		"guestAgentConnected": "GuestAgentConnected is the time the guest agent connected for the first time\n+optional",
+	}
+}
+
 func (VirtualMachineInstanceStatus) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                              "VirtualMachineInstanceStatus represents information about the status of a VirtualMachineInstance. Status may trail the actual\nstate of a system.\n\n+k8s:openapi-gen=true",
 		"nodeName":                      "NodeName is the name where the VirtualMachineInstance is currently running.",
+		"nodeBootID":                    "NodeBootID is the boot ID of the node the VirtualMachineInstance is currently running on.\nIt allows detecting whether the node rebooted while the VirtualMachineInstance was running.\n+optional",
 		"reason":                        "A brief CamelCase message indicating details about why the VMI is in this state. e.g. 'NodeUnresponsive'\n+optional",
 		"conditions":                    "Conditions are specific points in VirtualMachineInstance's pod runtime.",
 		"phase":                         "Phase is the status of the VirtualMachineInstance in kubernetes world. It is not the VirtualMachineInstance status, but partially correlates to it.",
 		"phaseTransitionTimestamps":     "PhaseTransitionTimestamp is the timestamp of when the last phase change occurred\n+listType=atomic\n+optional",
 		"interfaces":                    "Interfaces represent the details of available network interfaces.",
 		"guestOSInfo":                   "Guest OS Information",
+		"guestHostname":                 "GuestHostname is the hostname reported by the guest agent\n+optional",
+		"fsFreeSpace":                   "FSFreeSpace is the free space of the guest filesystems reported by the guest agent\n+optional\n+listType=atomic",
 		"migrationState":                "Represents the status of a live migration",
 		"migrationMethod":               "Represents the method using which the vmi can be migrated: live migration or block migration",
 		"migrationTransport":            "This represents the migration transport",
@@ -75,16 +90,20 @@ func (VirtualMachineInstanceStatus) SwaggerDoc() map[string]string {
 		"fsFreezeStatus":                "FSFreezeStatus is the state of the fs of the guest\nit can be either frozen or thawed\n+optional",
 		"topologyHints":                 "+optional",
 		"virtualMachineRevisionName":    "VirtualMachineRevisionName is used to get the vm revision of the vmi when doing\nan online vm snapshot\n+optional",
+		"VSOCKCID":                      "VSOCKCID is the guest context ID of the VSOCK device, unique in the cluster.\nIt is only set when autoattachVSOCK is enabled.\n+optional",
+		"startupTimestamps":             "StartupTimestamps holds the times at which the VirtualMachineInstance passed the steps of its start\n+optional",
 	}
 }
 
 func (PersistentVolumeClaimInfo) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":             "PersistentVolumeClaimInfo contains the relavant information virt-handler needs cached about a PVC\n+k8s:openapi-gen=true",
-		"accessModes":  "AccessModes contains the desired access modes the volume should have.\nMore info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#access-modes-1\n+listType=atomic\n+optional",
-		"volumeMode":   "VolumeMode defines what type of volume is required by the claim.\nValue of Filesystem is implied when not included in claim spec.\n+optional",
-		"capacity":     "Capacity represents the capacity set on the corresponding PVC spec\n+optional",
-		"preallocated": "Preallocated indicates if the PVC's storage is preallocated or not\n+optional",
+		"":                   "PersistentVolumeClaimInfo contains the relavant information virt-handler needs cached about a PVC\n+k8s:openapi-gen=true",
+		"accessModes":        "AccessModes contains the desired access modes the volume should have.\nMore info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#access-modes-1\n+listType=atomic\n+optional",
+		"volumeMode":         "VolumeMode defines what type of volume is required by the claim.\nValue of Filesystem is implied when not included in claim spec.\n+optional",
+		"capacity":           "Capacity represents the capacity set on the corresponding PVC spec\n+optional",
+		"preallocated":       "Preallocated indicates if the PVC's storage is preallocated or not\n+optional",
+		"filesystemOverhead": "FilesystemOverhead is the fraction of a filesystem PVC which the disk image leaves free for the filesystem\n+optional",
+		"cacheMode":          "CacheMode is the cache mode of the disks on the PVC which don't set one, as configured for its storage class\n+optional",
 	}
 }
 
@@ -98,7 +117,20 @@ func (VolumeStatus) SwaggerDoc() map[string]string {
 		"message":                   "Message is a detailed message about the current hotplug volume phase",
 		"persistentVolumeClaimInfo": "PersistentVolumeClaimInfo is information about the PVC that handler requires during start flow",
 		"hotplugVolume":             "If the volume is hotplug, this will contain the hotplug status.",
+		"memoryDumpVolume":          "If the volume is a memory dump volume, this will contain the memory dump info.",
 		"size":                      "Represents the size of the volume",
+		"disk":                      "Disk is the effective configuration of the disk of the volume in the domain\n+optional",
+	}
+}
+
+func (VolumeDiskStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "VolumeDiskStatus represents how the disk of a volume is attached to the guest.\n+k8s:openapi-gen=true",
+		"type":          "Type is the type of the disk source, either block or file",
+		"format":        "Format is the format of the disk image, eg: raw or qcow2",
+		"backingFormat": "BackingFormat is the format of the backing image of the disk, if it has one\n+optional",
+		"cache":         "Cache is the effective cache mode of the disk\n+optional",
+		"io":            "IO is the effective IO mode of the disk\n+optional",
 	}
 }
 
@@ -110,6 +142,16 @@ func (HotplugVolumeStatus) SwaggerDoc() map[string]string {
 	}
 }
 
+func (DomainMemoryDumpInfo) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "DomainMemoryDumpInfo represents the progress of a memory dump written to a memory dump volume\n+k8s:openapi-gen=true",
+		"startTimestamp": "StartTimestamp is the time when the memory dump started",
+		"endTimestamp":   "EndTimestamp is the time when the memory dump completed",
+		"claimName":      "ClaimName is the name of the PersistentVolumeClaim the memory dump is written to",
+		"targetFileName": "TargetFileName is the name of the memory dump file on the PersistentVolumeClaim",
+	}
+}
+
 func (VirtualMachineInstanceCondition) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                   "+k8s:openapi-gen=true",
@@ -169,6 +211,18 @@ func (VirtualMachineInstanceMigrationState) SwaggerDoc() map[string]string {
 		"abortStatus":                    "Indicates the final status of the live migration abortion",
 		"migrationUid":                   "The VirtualMachineInstanceMigration object associated with this migration",
 		"mode":                           "Lets us know if the vmi is currently running pre or post copy migration",
+		"progress":                       "The progress of the migration, updated periodically while the migration is running\n+optional",
+	}
+}
+
+func (VirtualMachineInstanceMigrationProgress) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                     "+k8s:openapi-gen=true",
+		"updateTimestamp":      "The time the progress was last updated\n+nullable",
+		"transferredBytes":     "The amount of data transferred to the target so far in bytes",
+		"memoryRemainingBytes": "The amount of guest memory which still has to be transferred in bytes",
+		"dirtyPageRate":        "The rate at which the guest dirties its memory in pages per second",
+		"iteration":            "The number of passes over the guest memory, a high count means that the\nguest dirties its memory faster than it can be transferred",
 	}
 }
 
@@ -194,11 +248,12 @@ func (VirtualMachineInstanceReplicaSetList) SwaggerDoc() map[string]string {
 
 func (VirtualMachineInstanceReplicaSetSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":         "+k8s:openapi-gen=true",
-		"replicas": "Number of desired pods. This is a pointer to distinguish between explicit\nzero and not specified. Defaults to 1.\n+optional",
-		"selector": "Label selector for pods. Existing ReplicaSets whose pods are\nselected by this will be the ones affected by this deployment.",
-		"template": "Template describes the pods that will be created.",
-		"paused":   "Indicates that the replica set is paused.\n+optional",
+		"":                "+k8s:openapi-gen=true",
+		"replicas":        "Number of desired pods. This is a pointer to distinguish between explicit\nzero and not specified. Defaults to 1.\n+optional",
+		"selector":        "Label selector for pods. Existing ReplicaSets whose pods are\nselected by this will be the ones affected by this deployment.",
+		"template":        "Template describes the pods that will be created.",
+		"paused":          "Indicates that the replica set is paused.\n+optional",
+		"headlessService": "Indicates that a headless Service named after the subdomain of the template is created\nfor the VirtualMachineInstances of the replica set. Together with the subdomain it gives\nevery VirtualMachineInstance the DNS name <hostname>.<subdomain>.<namespace>.svc.\n+optional",
 	}
 }
 
@@ -306,11 +361,22 @@ func (VirtualMachineSpec) SwaggerDoc() map[string]string {
 		"":                    "VirtualMachineSpec describes how the proper VirtualMachine\nshould look like\n\n+k8s:openapi-gen=true",
 		"running":             "Running controls whether the associatied VirtualMachineInstance is created or not\nMutually exclusive with RunStrategy",
 		"runStrategy":         "Running state indicates the requested running state of the VirtualMachineInstance\nmutually exclusive with Running",
+		"nodeRebootPolicy":    "NodeRebootPolicy controls what happens to a VirtualMachineInstance which was running\non a node that rebooted. Defaults to the behavior of the RunStrategy.\n+optional",
+		"schedule":            "Schedule starts and stops the VirtualMachine at recurring times\n+optional",
 		"template":            "Template is the direct specification of VirtualMachineInstance",
 		"dataVolumeTemplates": "dataVolumeTemplates is a list of dataVolumes that the VirtualMachineInstance template can reference.\nDataVolumes in this list are dynamically created for the VirtualMachine and are tied to the VirtualMachine's life-cycle.",
 	}
 }
 
+func (VirtualMachineSchedule) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "VirtualMachineSchedule defines at which times a VirtualMachine is started and stopped.\nA schedule acts like the start and stop subresources: a VirtualMachine which was\nstarted or stopped in between keeps its state until the next scheduled time.\n\n+k8s:openapi-gen=true",
+		"start":    "Start is a cron expression in the five field format \"minute hour day-of-month month day-of-week\",\ne.g. \"0 8 * * 1-5\", at whose times the VirtualMachine is started\n+optional",
+		"stop":     "Stop is a cron expression in the five field format \"minute hour day-of-month month day-of-week\",\ne.g. \"0 20 * * *\", at whose times the VirtualMachine is stopped\n+optional",
+		"timeZone": "TimeZone is the name of the IANA time zone the cron expressions are evaluated in, e.g. \"Europe/Berlin\".\nDefaults to UTC.\n+optional",
+	}
+}
+
 func (VirtualMachineStartFailure) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"": "VirtualMachineStartFailure tracks VMIs which failed to transition successfully\nto running using the VM status\n\n+k8s:openapi-gen=true",
@@ -330,6 +396,42 @@ func (VirtualMachineStatus) SwaggerDoc() map[string]string {
 		"volumeRequests":         "VolumeRequests indicates a list of volumes add or remove from the VMI template and\nhotplug on an active running VMI.\n+listType=atomic",
 		"volumeSnapshotStatuses": "VolumeSnapshotStatuses indicates a list of statuses whether snapshotting is\nsupported by each volume.",
 		"startFailure":           "StartFailure tracks consecutive VMI startup failures for the purposes of\ncrash loop backoffs\n+nullable\n+optional",
+		"restartCount":           "RestartCount is the number of failed VMIs which were started again after a\ncrash loop backoff\n+optional",
+		"schedule":               "Schedule reports the scheduled starts and stops of the VM\n+nullable\n+optional",
+		"memoryDumpRequest":      "MemoryDumpRequest tracks the memory dump request of the VM and the\nPersistentVolumeClaim it is associated with\n+nullable\n+optional",
+		"availability":           "Availability records the starts and the unplanned downtime of the VM\n+nullable\n+optional",
+	}
+}
+
+func (VirtualMachineAvailabilityStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                         "VirtualMachineAvailabilityStatus records the starts and the unplanned downtime of a VM,\nfrom which its availability metrics are derived\n\n+k8s:openapi-gen=true",
+		"startRequestTime":         "StartRequestTime is the time the latest start of the VM was requested\n+optional",
+		"readyTime":                "ReadyTime is the time the guest became ready after the latest start request\n+optional",
+		"failureTime":              "FailureTime is the time the VMI failed, it is only set while the resulting downtime is ongoing\n+optional",
+		"unplannedDowntimeSeconds": "UnplannedDowntimeSeconds is the cumulative downtime of the VM after its VMIs failed,\nwithout the ongoing downtime\n+optional",
+	}
+}
+
+func (VirtualMachineScheduleStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                 "VirtualMachineScheduleStatus reports the scheduled starts and stops of a VM\n\n+k8s:openapi-gen=true",
+		"lastScheduleTime": "LastScheduleTime is the time of the last scheduled start or stop which was applied\n+optional",
+		"nextStartTime":    "NextStartTime is the time of the next scheduled start\n+optional",
+		"nextStopTime":     "NextStopTime is the time of the next scheduled stop\n+optional",
+	}
+}
+
+func (VirtualMachineMemoryDumpRequest) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "VirtualMachineMemoryDumpRequest represents a memory dump of a VM to a PersistentVolumeClaim\n\n+k8s:openapi-gen=true",
+		"claimName":      "ClaimName is the name of the PersistentVolumeClaim the memory dump is written to",
+		"phase":          "Phase is the phase of the memory dump\n+optional",
+		"remove":         "Remove requests to dissociate the PersistentVolumeClaim from the VM\n+optional",
+		"startTimestamp": "StartTimestamp is the time when the memory dump started\n+optional",
+		"endTimestamp":   "EndTimestamp is the time when the memory dump completed\n+optional",
+		"fileName":       "FileName is the name of the memory dump file on the PersistentVolumeClaim\n+optional",
+		"message":        "Message is a detailed message about a failed memory dump\n+optional",
 	}
 }
 
@@ -515,6 +617,34 @@ func (StartOptions) SwaggerDoc() map[string]string {
 	}
 }
 
+func (PauseOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "PauseOptions may be provided on pause request.\n\n+k8s:openapi-gen=true",
+		"dryRun": "When present, indicates that the VMI is not paused, but only validated for pausing.\nAn invalid or unrecognized dryRun directive will result in an error response and\nno further processing of the request. Valid values are:\n- All: all dry run stages will be processed\n+optional\n+listType=atomic",
+	}
+}
+
+func (UnpauseOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "UnpauseOptions may be provided on unpause request.\n\n+k8s:openapi-gen=true",
+		"dryRun": "When present, indicates that the VMI is not unpaused, but only validated for unpausing.\nAn invalid or unrecognized dryRun directive will result in an error response and\nno further processing of the request. Valid values are:\n- All: all dry run stages will be processed\n+optional\n+listType=atomic",
+	}
+}
+
+func (RenameOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "RenameOptions may be provided on rename request.\n\n+k8s:openapi-gen=true",
+		"newName": "The new name of the VirtualMachine",
+	}
+}
+
+func (MoveOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "MoveOptions may be provided on move request.\n\n+k8s:openapi-gen=true",
+		"newNamespace": "The namespace the VirtualMachine and its volumes are moved to",
+	}
+}
+
 func (StopOptions) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":            "StopOptions may be provided when deleting an API object.\n\n+k8s:openapi-gen=true",
@@ -572,6 +702,12 @@ func (VirtualMachineInstanceFileSystem) SwaggerDoc() map[string]string {
 	}
 }
 
+func (VirtualMachineInstanceFileSystemFreeSpace) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineInstanceFileSystemFreeSpace represents the free space of a guest filesystem\n+k8s:openapi-gen=true",
+	}
+}
+
 func (AddVolumeOptions) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":             "AddVolumeOptions is provided when dynamically hot plugging a volume and disk\n+k8s:openapi-gen=true",
@@ -588,6 +724,39 @@ func (RemoveVolumeOptions) SwaggerDoc() map[string]string {
 	}
 }
 
+func (MediaChangeOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "MediaChangeOptions is provided when changing the media of a CD-ROM of a running VMI\n+k8s:openapi-gen=true",
+		"name":         "Name represents the name of the CD-ROM disk and its volume",
+		"volumeSource": "VolumeSource represents the source of the media to insert.\nThe media is ejected if no volume source is provided.\n+optional",
+	}
+}
+
+func (GuestExecOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "GuestExecOptions is provided when running a command in the guest of a running VMI\n+k8s:openapi-gen=true",
+		"command":        "Command is the path of the command in the guest. It has to be part of the\nguestExecAllowList of the KubeVirt configuration.",
+		"args":           "Args are passed to the command. They have to match the args of the command in the\nguestExecAllowList.\n+optional",
+		"timeoutSeconds": "TimeoutSeconds is the time the command may run. Defaults to 10 seconds.\n+optional",
+	}
+}
+
+func (GuestExecCommand) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "GuestExecCommand is a command of the guestExecAllowList\n+k8s:openapi-gen=true",
+		"path": "Path is the path of the command in the guest, like \"/usr/bin/uptime\".",
+		"args": "Args are regular expressions for the arguments of the command. The command can only be run\nwith one argument per expression, which has to match the expression completely.\nWithout args the command can only be run without arguments.\n+optional",
+	}
+}
+
+func (GuestExecResult) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "GuestExecResult is returned once a command ran in the guest of a VMI\n+k8s:openapi-gen=true",
+		"exitCode": "ExitCode is the exit code of the command",
+		"stdOut":   "StdOut is the standard output of the command\n+optional",
+	}
+}
+
 func (TokenBucketRateLimiter) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":      "+k8s:openapi-gen=true",
@@ -620,6 +789,73 @@ func (KubeVirtConfiguration) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                            "KubeVirtConfiguration holds all kubevirt configurations\n+k8s:openapi-gen=true",
 		"supportedGuestAgentVersions": "deprecated",
+		"qemuArgsAllowList":           "QEMUArgsAllowList holds the names of the QEMU arguments, like \"-fw_cfg\", which\nVirtualMachineInstances may append to the QEMU command line. Requires the QEMUArgs feature gate.",
+		"swap":                        "Swap configures the swap usage of VirtualMachineInstances with Burstable memory.\nRequires the VMSwap feature gate.",
+		"deprecatedMachineTypes":      "DeprecatedMachineTypes holds the machine types which VirtualMachines should no longer use,\nmatched like emulatedMachines. VirtualMachines with a deprecated machine type are updated to\nmachineType, which applies on their next restart.",
+		"crashLoopBackOff":            "CrashLoopBackOff configures the delay before VirtualMachines, whose VirtualMachineInstances\nkeep failing, are started again.",
+		"filesystemOverhead":          "FilesystemOverhead is the fraction of filesystem PersistentVolumeClaims which disk images\nleave free for the filesystem itself.",
+		"disks":                       "DiskConfiguration holds the cluster wide defaults and limits of disks.",
+		"guestExecAllowList":          "GuestExecAllowList holds the commands, with the arguments they may be run with, which may be\nrun in the guests of VirtualMachineInstances through the guestexec subresource.\nRequires the GuestExec feature gate.",
+		"auditLog":                    "AuditLog enables the audit log of virt-api, which records who accessed which subresource,\nlike the console, of which VirtualMachine or VirtualMachineInstance.",
+		"leaderElection":              "LeaderElection configures the leader election of virt-controller and virt-operator.\nIt is applied when they start.",
+	}
+}
+
+func (AuditLogConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "AuditLogConfiguration holds the options of the audit log of virt-api\n+k8s:openapi-gen=true",
+		"sink": "Sink is where the audit events are written to, either \"stdout\" or \"file\".\nDefaults to \"stdout\".\n+optional",
+		"path": "Path is the file which the audit events are appended to, if the sink is \"file\".\n+optional",
+	}
+}
+
+func (LeaderElectionConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "LeaderElectionConfiguration holds the timing of the leader election of virt-controller\nand virt-operator\n+k8s:openapi-gen=true",
+		"leaseDuration": "LeaseDuration is the time which candidates wait after the last renewal of the leader,\nuntil they try to take the lead. Defaults to 15s.\n+optional",
+		"renewDeadline": "RenewDeadline is the time which the leader tries to renew its lease, until it gives up\nthe lead. Must be less than the lease duration. Defaults to 10s.\n+optional",
+		"retryPeriod":   "RetryPeriod is the time between two attempts to take or to renew the lead.\nDefaults to 2s.\n+optional",
+	}
+}
+
+func (DiskConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                                   "DiskConfiguration holds the cluster wide defaults and limits of disks\n+k8s:openapi-gen=true",
+		"storageClassCacheModes":             "StorageClassCacheModes maps the names of storage classes to the cache mode of the disks\non their PersistentVolumeClaims which don't set a cache mode.\nSupported values are: none, writethrough, writeback.\nDisks with writeback can not be live migrated.\n+optional",
+		"containerDiskEphemeralStorageLimit": "ContainerDiskEphemeralStorageLimit is the amount of data a VirtualMachineInstance can write\nto each of its containerDisks. virt-launcher pods which write more are evicted.\n+optional",
+		"defaultBus":                         "DefaultBus is the bus of the disks which don't set one.\nSupported values are: virtio, sata, scsi, nvme. Defaults to sata.\n+optional",
+		"virtioWinContainerDiskImage":        "VirtioWinContainerDiskImage is a containerDisk image with the virtio-win drivers. It is attached\nas a CD-ROM to Windows VirtualMachineInstances, so the drivers of the virtio devices can be\ninstalled during the Windows setup.\n+optional",
+	}
+}
+
+func (FilesystemOverhead) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "FilesystemOverhead holds the fractions of filesystem PersistentVolumeClaims reserved for the\nfilesystem, as decimals below 1 with up to three digits, e.g. \"0.055\" for 5.5%\n+k8s:openapi-gen=true",
+		"global":       "Global is the overhead of the storage classes without an entry in StorageClass.\nDefaults to no overhead.\n+optional",
+		"storageClass": "StorageClass maps the names of storage classes to their overhead.\n+optional",
+	}
+}
+
+func (ConsoleRecordingConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "ConsoleRecordingConfiguration holds the options for recording serial console and VNC sessions\n+k8s:openapi-gen=true",
+		"objectStoreURL": "ObjectStoreURL is the base URL of a bucket in an object store. Every recorded session is\nuploaded to it with a HTTP PUT request once the session is closed. If the\nkubevirt-console-recording-credentials secret exists in the KubeVirt install namespace, its\n\"token\" key is sent as bearer token.",
+		"recordVNC":      "RecordVNC enables the recording of VNC sessions. Only serial console sessions are\nrecorded by default.\n+optional",
+	}
+}
+
+func (SwapConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "SwapConfiguration holds the settings which virt-handler applies to the memory cgroup of\nvirt-launcher pods which may use swap\n+k8s:openapi-gen=true",
+		"swappiness": "Swappiness of the memory cgroup, between 0 and 100. It is only supported with cgroup v1,\nthe swappiness of the node applies with cgroup v2.\n+optional",
+	}
+}
+
+func (CrashLoopBackOffConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                  "CrashLoopBackOffConfiguration holds the settings of the exponential backoff which delays\nrestarting VirtualMachineInstances that keep failing\n+k8s:openapi-gen=true",
+		"maxDelaySeconds":   "MaxDelaySeconds caps the delay before a failed VirtualMachineInstance is started again.\nDefaults to 300.\n+optional",
+		"resetAfterSeconds": "ResetAfterSeconds is the time a VirtualMachineInstance of a VirtualMachine with the\nRerunOnFailure run strategy has to run until its failures are no longer counted as a\ncrash loop. Defaults to 600.\n+optional",
 	}
 }
 
@@ -631,7 +867,8 @@ func (SMBiosConfiguration) SwaggerDoc() map[string]string {
 
 func (MigrationConfiguration) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"": "MigrationConfiguration holds migration options\n+k8s:openapi-gen=true",
+		"":                         "MigrationConfiguration holds migration options\n+k8s:openapi-gen=true",
+		"parallelMigrationThreads": "ParallelMigrationThreads is the number of parallel multifd channels used to transfer\nthe guest memory. Parallel migrations are not combined with post copy migrations.\n+optional",
 	}
 }
 
@@ -646,6 +883,7 @@ func (DeveloperConfiguration) SwaggerDoc() map[string]string {
 		"":                           "DeveloperConfiguration holds developer options\n+k8s:openapi-gen=true",
 		"useEmulation":               "UseEmulation can be set to true to allow fallback to software emulation\nin case hardware-assisted emulation is not available.",
 		"minimumClusterTSCFrequency": "Allow overriding the automatically determined minimum TSC frequency of the cluster\nand fixate the minimum to this frequency.",
+		"logFormat":                  "LogFormat is the format of the lines of the libraries used by the components, like client-go.\nWith json they are written as JSON like the lines of the components, with text they keep\ntheir own format. Defaults to text.\n+optional",
 	}
 }
 
@@ -661,6 +899,7 @@ func (PermittedHostDevices) SwaggerDoc() map[string]string {
 		"":                "PermittedHostDevices holds inforamtion about devices allowed for passthrough\n+k8s:openapi-gen=true",
 		"pciHostDevices":  "+listType=atomic",
 		"mediatedDevices": "+listType=atomic",
+		"usb":             "+listType=atomic",
 	}
 }
 
@@ -679,16 +918,51 @@ func (MediatedHostDevice) SwaggerDoc() map[string]string {
 	}
 }
 
+func (USBHostDevice) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                         "USBHostDevice represents a host USB device allowed for passthrough\n+k8s:openapi-gen=true",
+		"selectors":                "The list of vendor:product selectors of the USB devices which are\nexposed with this resource\n+listType=atomic",
+		"resourceName":             "The name of the resource that is representing the device. Exposed by\na device plugin and requested by VMs. Typically of the form\nvendor.com/product_name",
+		"externalResourceProvider": "If true, KubeVirt will leave the allocation and monitoring to an\nexternal device plugin",
+	}
+}
+
+func (USBSelector) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "USBSelector selects USB devices by their vendor and product IDs\n+k8s:openapi-gen=true",
+		"vendor":  "The vendor ID of the USB device, e.g. 046d",
+		"product": "The product ID of the USB device, e.g. c52b",
+	}
+}
+
 func (MediatedDevicesConfiguration) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":                     "MediatedDevicesConfiguration holds inforamtion about MDEV types to be defined, if available\n+k8s:openapi-gen=true",
+		"":                        "MediatedDevicesConfiguration holds inforamtion about MDEV types to be defined, if available\n+k8s:openapi-gen=true",
+		"mediatedDevicesTypes":    "+listType=atomic",
+		"nodeMediatedDeviceTypes": "NodeMediatedDeviceTypes overrides the mediatedDevicesTypes on the nodes matching\none or more of their node selectors.\n+optional\n+listType=atomic",
+	}
+}
+
+func (NodeMediatedDeviceTypesConfig) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                     "NodeMediatedDeviceTypesConfig holds information about MDEV types to be defined on the nodes matching the NodeSelector\n+k8s:openapi-gen=true",
+		"nodeSelector":         "NodeSelector must match the labels of a node for the mediated device types to be defined on it.",
 		"mediatedDevicesTypes": "+listType=atomic",
 	}
 }
 
 func (NetworkConfiguration) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"": "NetworkConfiguration holds network options\n+k8s:openapi-gen=true",
+		"":               "NetworkConfiguration holds network options\n+k8s:openapi-gen=true",
+		"macAddressPool": "MacAddressPool is the range of MAC addresses which virt-controller assigns to the interfaces\nof VirtualMachines without an explicit MAC address. The addresses are stored in the\nVirtualMachine and survive restarts and migrations.\n+optional",
+	}
+}
+
+func (MacAddressPool) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "MacAddressPool is an inclusive range of unicast MAC addresses\n+k8s:openapi-gen=true",
+		"rangeStart": "RangeStart is the first MAC address of the pool, e.g. \"02:00:00:00:00:00\"",
+		"rangeEnd":   "RangeEnd is the last MAC address of the pool, e.g. \"02:00:00:ff:ff:ff\"",
 	}
 }
 
@@ -0,0 +1,154 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package v1alpha1
+
+import (
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+const (
+	// VirtualMachinePoolRevisionLabel carries the revision of the pool template a VirtualMachine was created
+	// or last updated from.
+	VirtualMachinePoolRevisionLabel = "pool.kubevirt.io/revision"
+
+	// VirtualMachinePoolIndexPlaceholder is replaced with the index of the VirtualMachine in the pool
+	// in the userData and networkData of the cloudInitNoCloud and cloudInitConfigDrive volumes
+	// of the template. It allows every VirtualMachine to get its own hostname, address or credentials.
+	VirtualMachinePoolIndexPlaceholder = "$(POOL_INDEX)"
+)
+
+// VirtualMachinePool manages a set of VirtualMachines stamped out from the same template.
+// Every VirtualMachine gets a unique name in the form <pool name>-<index>.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachinePool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachinePoolSpec `json:"spec" valid:"required"`
+
+	// +optional
+	// +nullable
+	Status VirtualMachinePoolStatus `json:"status,omitempty"`
+}
+
+// VirtualMachineTemplateSpec describes the VirtualMachines created by a pool
+type VirtualMachineTemplateSpec struct {
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +nullable
+	ObjectMeta metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// VirtualMachineSpec contains the VirtualMachine specification.
+	Spec v1.VirtualMachineSpec `json:"spec,omitempty" valid:"required"`
+}
+
+// VirtualMachinePoolSpec is the spec for a VirtualMachinePool resource
+type VirtualMachinePoolSpec struct {
+	// Number of desired VirtualMachines. This is a pointer to distinguish between explicit
+	// zero and not specified. Defaults to 1.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Label selector for VirtualMachines. It must match the labels of the template.
+	// The pool only manages the VirtualMachines it created, existing VirtualMachines
+	// are not adopted.
+	Selector *metav1.LabelSelector `json:"selector" valid:"required"`
+
+	// Template describes the VirtualMachines that will be created.
+	VirtualMachineTemplate *VirtualMachineTemplateSpec `json:"virtualMachineTemplate" valid:"required"`
+
+	// The maximum number of VirtualMachines which can be unavailable while they are restarted
+	// to pick up a change of the template. Defaults to 1.
+	// +optional
+	MaxUnavailable *int32 `json:"maxUnavailable,omitempty"`
+
+	// Indicates that the pool is paused.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// Indicates that a headless Service named after the subdomain of the VirtualMachineInstance template
+	// is created for the VirtualMachines of the pool. Together with the subdomain it gives every
+	// VirtualMachine the DNS name <pool name>-<index>.<subdomain>.<namespace>.svc.
+	// +optional
+	HeadlessService bool `json:"headlessService,omitempty"`
+}
+
+// VirtualMachinePoolStatus is the status for a VirtualMachinePool resource
+type VirtualMachinePoolStatus struct {
+	// Total number of VirtualMachines targeted by this pool.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// The number of VirtualMachines of this pool which are ready.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// The number of VirtualMachines of this pool which run the current revision of the template.
+	// +optional
+	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
+
+	// The revision of the template the VirtualMachines of this pool are updated to.
+	// +optional
+	CurrentRevision string `json:"currentRevision,omitempty"`
+
+	// Canonical form of the label selector for HPA which consumes it through the scale subresource.
+	// +optional
+	LabelSelector string `json:"labelSelector,omitempty"`
+
+	// +optional
+	Conditions []VirtualMachinePoolCondition `json:"conditions,omitempty"`
+}
+
+// VirtualMachinePoolCondition represents the state of a VirtualMachinePool
+type VirtualMachinePoolCondition struct {
+	Type   VirtualMachinePoolConditionType `json:"type"`
+	Status k8sv1.ConditionStatus           `json:"status"`
+	// +nullable
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// +nullable
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	Reason             string      `json:"reason,omitempty"`
+	Message            string      `json:"message,omitempty"`
+}
+
+// VirtualMachinePoolConditionType is the type of a VirtualMachinePool condition
+type VirtualMachinePoolConditionType string
+
+const (
+	// VirtualMachinePoolReplicaFailure is added in a pool when one of its VirtualMachines
+	// fails to be created or deleted.
+	VirtualMachinePoolReplicaFailure VirtualMachinePoolConditionType = "ReplicaFailure"
+
+	// VirtualMachinePoolReplicaPaused is added in a pool when the pool got paused by the controller.
+	// After this condition was added, it is safe to remove or add VirtualMachines by hand and adjust the replica count by hand.
+	VirtualMachinePoolReplicaPaused VirtualMachinePoolConditionType = "ReplicaPaused"
+)
+
+// VirtualMachinePoolList is a list of VirtualMachinePool resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VirtualMachinePoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VirtualMachinePool `json:"items"`
+}
@@ -0,0 +1,56 @@
+// Code generated by swagger-doc. DO NOT EDIT.
+
+package v1alpha1
+
+func (VirtualMachinePool) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "VirtualMachinePool manages a set of VirtualMachines stamped out from the same template.\nEvery VirtualMachine gets a unique name in the form <pool name>-<index>.\n+genclient\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"status": "+optional\n+nullable",
+	}
+}
+
+func (VirtualMachineTemplateSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "VirtualMachineTemplateSpec describes the VirtualMachines created by a pool",
+		"metadata": "+kubebuilder:pruning:PreserveUnknownFields\n+nullable",
+		"spec":     "VirtualMachineSpec contains the VirtualMachine specification.",
+	}
+}
+
+func (VirtualMachinePoolSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                       "VirtualMachinePoolSpec is the spec for a VirtualMachinePool resource",
+		"replicas":               "Number of desired VirtualMachines. This is a pointer to distinguish between explicit\nzero and not specified. Defaults to 1.\n+optional",
+		"selector":               "Label selector for VirtualMachines. It must match the labels of the template.\nThe pool only manages the VirtualMachines it created, existing VirtualMachines\nare not adopted.",
+		"virtualMachineTemplate": "Template describes the VirtualMachines that will be created.",
+		"maxUnavailable":         "The maximum number of VirtualMachines which can be unavailable while they are restarted\nto pick up a change of the template. Defaults to 1.\n+optional",
+		"paused":                 "Indicates that the pool is paused.\n+optional",
+		"headlessService":        "Indicates that a headless Service named after the subdomain of the VirtualMachineInstance template\nis created for the VirtualMachines of the pool. Together with the subdomain it gives every\nVirtualMachine the DNS name <pool name>-<index>.<subdomain>.<namespace>.svc.\n+optional",
+	}
+}
+
+func (VirtualMachinePoolStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                "VirtualMachinePoolStatus is the status for a VirtualMachinePool resource",
+		"replicas":        "Total number of VirtualMachines targeted by this pool.\n+optional",
+		"readyReplicas":   "The number of VirtualMachines of this pool which are ready.\n+optional",
+		"updatedReplicas": "The number of VirtualMachines of this pool which run the current revision of the template.\n+optional",
+		"currentRevision": "The revision of the template the VirtualMachines of this pool are updated to.\n+optional",
+		"labelSelector":   "Canonical form of the label selector for HPA which consumes it through the scale subresource.\n+optional",
+		"conditions":      "+optional",
+	}
+}
+
+func (VirtualMachinePoolCondition) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                   "VirtualMachinePoolCondition represents the state of a VirtualMachinePool",
+		"lastProbeTime":      "+nullable",
+		"lastTransitionTime": "+nullable",
+	}
+}
+
+func (VirtualMachinePoolList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachinePoolList is a list of VirtualMachinePool resources\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+	}
+}
@@ -3,6 +3,7 @@ package kubecli
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/gorilla/websocket"
 	rest "k8s.io/client-go/rest"
@@ -22,6 +23,10 @@ func (a *AsyncSubresourceError) GetStatusCode() int {
 }
 
 func asyncSubresourceHelper(config *rest.Config, resource, namespace, name string, subresource string) (StreamInterface, error) {
+	return asyncSubresourceHelperWithQuery(config, resource, namespace, name, subresource, nil)
+}
+
+func asyncSubresourceHelperWithQuery(config *rest.Config, resource, namespace, name string, subresource string, queryParams url.Values) (StreamInterface, error) {
 
 	done := make(chan struct{})
 
@@ -40,6 +45,7 @@ func asyncSubresourceHelper(config *rest.Config, resource, namespace, name strin
 	if err != nil {
 		return nil, fmt.Errorf("unable to create request for remote execution: %v", err)
 	}
+	req.URL.RawQuery = queryParams.Encode()
 
 	errChan := make(chan error, 1)
 
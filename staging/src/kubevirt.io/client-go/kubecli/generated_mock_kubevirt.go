@@ -4,6 +4,7 @@
 package kubecli
 
 import (
+	context "context"
 	net "net"
 
 	gomock "github.com/golang/mock/gomock"
@@ -64,6 +65,7 @@ import (
 	versioned "kubevirt.io/client-go/generated/containerized-data-importer/clientset/versioned"
 	versioned0 "kubevirt.io/client-go/generated/external-snapshotter/clientset/versioned"
 	versioned1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned"
+	v1alpha17 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/pool/v1alpha1"
 	v1alpha16 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/snapshot/v1alpha1"
 	versioned2 "kubevirt.io/client-go/generated/network-attachment-definition-client/clientset/versioned"
 	versioned3 "kubevirt.io/client-go/generated/prometheus-operator/clientset/versioned"
@@ -180,6 +182,16 @@ func (_mr *_MockKubevirtClientRecorder) VirtualMachineRestore(arg0 interface{})
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineRestore", arg0)
 }
 
+func (_m *MockKubevirtClient) VirtualMachinePool(namespace string) v1alpha17.VirtualMachinePoolInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachinePool", namespace)
+	ret0, _ := ret[0].(v1alpha17.VirtualMachinePoolInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachinePool(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachinePool", arg0)
+}
+
 func (_m *MockKubevirtClient) ServerVersion() *ServerVersion {
 	ret := _m.ctrl.Call(_m, "ServerVersion")
 	ret0, _ := ret[0].(*ServerVersion)
@@ -801,6 +813,38 @@ func (_mr *_MockStreamInterfaceRecorder) AsConn() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "AsConn")
 }
 
+// Mock of StreamDialer interface
+type MockStreamDialer struct {
+	ctrl     *gomock.Controller
+	recorder *_MockStreamDialerRecorder
+}
+
+// Recorder for MockStreamDialer (not exported)
+type _MockStreamDialerRecorder struct {
+	mock *MockStreamDialer
+}
+
+func NewMockStreamDialer(ctrl *gomock.Controller) *MockStreamDialer {
+	mock := &MockStreamDialer{ctrl: ctrl}
+	mock.recorder = &_MockStreamDialerRecorder{mock}
+	return mock
+}
+
+func (_m *MockStreamDialer) EXPECT() *_MockStreamDialerRecorder {
+	return _m.recorder
+}
+
+func (_m *MockStreamDialer) Dial(ctx context.Context) (StreamInterface, error) {
+	ret := _m.ctrl.Call(_m, "Dial", ctx)
+	ret0, _ := ret[0].(StreamInterface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockStreamDialerRecorder) Dial(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Dial", arg0)
+}
+
 // Mock of VirtualMachineInstanceInterface interface
 type MockVirtualMachineInstanceInterface struct {
 	ctrl     *gomock.Controller
@@ -914,6 +958,39 @@ func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) SerialConsole(arg0, arg
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "SerialConsole", arg0, arg1)
 }
 
+func (_m *MockVirtualMachineInstanceInterface) ConsoleLog(name string) ([]byte, error) {
+	ret := _m.ctrl.Call(_m, "ConsoleLog", name)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) ConsoleLog(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ConsoleLog", arg0)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) DomainLog(name string, lines int) ([]byte, error) {
+	ret := _m.ctrl.Call(_m, "DomainLog", name, lines)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) DomainLog(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "DomainLog", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) PreviewDomainXML(vmi *v117.VirtualMachineInstance) ([]byte, error) {
+	ret := _m.ctrl.Call(_m, "PreviewDomainXML", vmi)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) PreviewDomainXML(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "PreviewDomainXML", arg0)
+}
+
 func (_m *MockVirtualMachineInstanceInterface) USBRedir(vmiName string) (StreamInterface, error) {
 	ret := _m.ctrl.Call(_m, "USBRedir", vmiName)
 	ret0, _ := ret[0].(StreamInterface)
@@ -947,24 +1024,24 @@ func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) PortForward(arg0, arg1,
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "PortForward", arg0, arg1, arg2)
 }
 
-func (_m *MockVirtualMachineInstanceInterface) Pause(name string) error {
-	ret := _m.ctrl.Call(_m, "Pause", name)
+func (_m *MockVirtualMachineInstanceInterface) Pause(name string, pauseOptions *v117.PauseOptions) error {
+	ret := _m.ctrl.Call(_m, "Pause", name, pauseOptions)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) Pause(arg0 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "Pause", arg0)
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) Pause(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Pause", arg0, arg1)
 }
 
-func (_m *MockVirtualMachineInstanceInterface) Unpause(name string) error {
-	ret := _m.ctrl.Call(_m, "Unpause", name)
+func (_m *MockVirtualMachineInstanceInterface) Unpause(name string, unpauseOptions *v117.UnpauseOptions) error {
+	ret := _m.ctrl.Call(_m, "Unpause", name, unpauseOptions)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) Unpause(arg0 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "Unpause", arg0)
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) Unpause(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Unpause", arg0, arg1)
 }
 
 func (_m *MockVirtualMachineInstanceInterface) Freeze(name string) error {
@@ -1020,6 +1097,39 @@ func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) FilesystemList(arg0 int
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "FilesystemList", arg0)
 }
 
+func (_m *MockVirtualMachineInstanceInterface) MemoryDump(name string, options *MemoryDumpOptions) (*MemoryDump, error) {
+	ret := _m.ctrl.Call(_m, "MemoryDump", name, options)
+	ret0, _ := ret[0].(*MemoryDump)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) MemoryDump(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "MemoryDump", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) GuestExec(name string, guestExecOptions *v117.GuestExecOptions) (*v117.GuestExecResult, error) {
+	ret := _m.ctrl.Call(_m, "GuestExec", name, guestExecOptions)
+	ret0, _ := ret[0].(*v117.GuestExecResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) GuestExec(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GuestExec", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) GuestExecStream(name string, guestExecOptions *v117.GuestExecOptions) (*v117.GuestExecResult, error) {
+	ret := _m.ctrl.Call(_m, "GuestExecStream", name, guestExecOptions)
+	ret0, _ := ret[0].(*v117.GuestExecResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) GuestExecStream(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GuestExecStream", arg0, arg1)
+}
+
 func (_m *MockVirtualMachineInstanceInterface) AddVolume(name string, addVolumeOptions *v117.AddVolumeOptions) error {
 	ret := _m.ctrl.Call(_m, "AddVolume", name, addVolumeOptions)
 	ret0, _ := ret[0].(error)
@@ -1040,6 +1150,16 @@ func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) RemoveVolume(arg0, arg1
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "RemoveVolume", arg0, arg1)
 }
 
+func (_m *MockVirtualMachineInstanceInterface) MediaChange(name string, mediaChangeOptions *v117.MediaChangeOptions) error {
+	ret := _m.ctrl.Call(_m, "MediaChange", name, mediaChangeOptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) MediaChange(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "MediaChange", arg0, arg1)
+}
+
 // Mock of ReplicaSetInterface interface
 type MockReplicaSetInterface struct {
 	ctrl     *gomock.Controller
@@ -1439,6 +1559,36 @@ func (_mr *_MockVirtualMachineInterfaceRecorder) Migrate(arg0 interface{}) *gomo
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Migrate", arg0)
 }
 
+func (_m *MockVirtualMachineInterface) MigrateCancel(name string) error {
+	ret := _m.ctrl.Call(_m, "MigrateCancel", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) MigrateCancel(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "MigrateCancel", arg0)
+}
+
+func (_m *MockVirtualMachineInterface) Rename(name string, renameOptions *v117.RenameOptions) error {
+	ret := _m.ctrl.Call(_m, "Rename", name, renameOptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) Rename(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Rename", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInterface) Move(name string, moveOptions *v117.MoveOptions) error {
+	ret := _m.ctrl.Call(_m, "Move", name, moveOptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) Move(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Move", arg0, arg1)
+}
+
 func (_m *MockVirtualMachineInterface) AddVolume(name string, addVolumeOptions *v117.AddVolumeOptions) error {
 	ret := _m.ctrl.Call(_m, "AddVolume", name, addVolumeOptions)
 	ret0, _ := ret[0].(error)
@@ -1470,6 +1620,26 @@ func (_mr *_MockVirtualMachineInterfaceRecorder) PortForward(arg0, arg1, arg2 in
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "PortForward", arg0, arg1, arg2)
 }
 
+func (_m *MockVirtualMachineInterface) MemoryDump(name string, memoryDumpRequest *v117.VirtualMachineMemoryDumpRequest) error {
+	ret := _m.ctrl.Call(_m, "MemoryDump", name, memoryDumpRequest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) MemoryDump(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "MemoryDump", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineInterface) RemoveMemoryDump(name string) error {
+	ret := _m.ctrl.Call(_m, "RemoveMemoryDump", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) RemoveMemoryDump(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "RemoveMemoryDump", arg0)
+}
+
 // Mock of VirtualMachineInstanceMigrationInterface interface
 type MockVirtualMachineInstanceMigrationInterface struct {
 	ctrl     *gomock.Controller
@@ -26,6 +26,7 @@ package kubecli
 */
 
 import (
+	"context"
 	"io"
 	"net"
 
@@ -45,6 +46,7 @@ import (
 	cdiclient "kubevirt.io/client-go/generated/containerized-data-importer/clientset/versioned"
 	k8ssnapshotclient "kubevirt.io/client-go/generated/external-snapshotter/clientset/versioned"
 	generatedclient "kubevirt.io/client-go/generated/kubevirt/clientset/versioned"
+	poolv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/pool/v1alpha1"
 	vmsnapshotv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/snapshot/v1alpha1"
 	networkclient "kubevirt.io/client-go/generated/network-attachment-definition-client/clientset/versioned"
 	promclient "kubevirt.io/client-go/generated/prometheus-operator/clientset/versioned"
@@ -60,6 +62,7 @@ type KubevirtClient interface {
 	VirtualMachineSnapshot(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotInterface
 	VirtualMachineSnapshotContent(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotContentInterface
 	VirtualMachineRestore(namespace string) vmsnapshotv1alpha1.VirtualMachineRestoreInterface
+	VirtualMachinePool(namespace string) poolv1alpha1.VirtualMachinePoolInterface
 	ServerVersion() *ServerVersion
 	ClusterProfiler() *ClusterProfiler
 	GuestfsVersion() *GuestfsVersion
@@ -142,6 +145,10 @@ func (k kubevirt) VirtualMachineRestore(namespace string) vmsnapshotv1alpha1.Vir
 	return k.generatedKubeVirtClient.SnapshotV1alpha1().VirtualMachineRestores(namespace)
 }
 
+func (k kubevirt) VirtualMachinePool(namespace string) poolv1alpha1.VirtualMachinePoolInterface {
+	return k.generatedKubeVirtClient.PoolV1alpha1().VirtualMachinePools(namespace)
+}
+
 func (k kubevirt) KubernetesSnapshotClient() k8ssnapshotclient.Interface {
 	return k.snapshotClient
 }
@@ -160,6 +167,14 @@ type StreamInterface interface {
 	AsConn() net.Conn
 }
 
+// StreamDialer opens a stream to a subresource of a VMI, like the serial console or VNC. It is
+// what the reconnecting helpers use to establish a new stream after the previous one broke.
+type StreamDialer interface {
+	Dial(ctx context.Context) (StreamInterface, error)
+}
+
+// Deprecated: use VirtualMachineInstanceInterface of kubevirt.io/client-go/kubecli/v2, which takes a
+// context on every call. This interface is kept for one more release.
 type VirtualMachineInstanceInterface interface {
 	Get(name string, options *k8smetav1.GetOptions) (*v1.VirtualMachineInstance, error)
 	List(opts *k8smetav1.ListOptions) (*v1.VirtualMachineInstanceList, error)
@@ -169,20 +184,29 @@ type VirtualMachineInstanceInterface interface {
 	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.VirtualMachineInstance, err error)
 	Watch(opts metav1.ListOptions) (watch.Interface, error)
 	SerialConsole(name string, options *SerialConsoleOptions) (StreamInterface, error)
+	ConsoleLog(name string) ([]byte, error)
+	DomainLog(name string, lines int) ([]byte, error)
+	PreviewDomainXML(vmi *v1.VirtualMachineInstance) ([]byte, error)
 	USBRedir(vmiName string) (StreamInterface, error)
 	VNC(name string) (StreamInterface, error)
 	PortForward(name string, port int, protocol string) (StreamInterface, error)
-	Pause(name string) error
-	Unpause(name string) error
+	Pause(name string, pauseOptions *v1.PauseOptions) error
+	Unpause(name string, unpauseOptions *v1.UnpauseOptions) error
 	Freeze(name string) error
 	Unfreeze(name string) error
 	GuestOsInfo(name string) (v1.VirtualMachineInstanceGuestAgentInfo, error)
 	UserList(name string) (v1.VirtualMachineInstanceGuestOSUserList, error)
 	FilesystemList(name string) (v1.VirtualMachineInstanceFileSystemList, error)
+	MemoryDump(name string, options *MemoryDumpOptions) (*MemoryDump, error)
+	GuestExec(name string, guestExecOptions *v1.GuestExecOptions) (*v1.GuestExecResult, error)
+	GuestExecStream(name string, guestExecOptions *v1.GuestExecOptions) (*v1.GuestExecResult, error)
 	AddVolume(name string, addVolumeOptions *v1.AddVolumeOptions) error
 	RemoveVolume(name string, removeVolumeOptions *v1.RemoveVolumeOptions) error
+	MediaChange(name string, mediaChangeOptions *v1.MediaChangeOptions) error
 }
 
+// Deprecated: use ReplicaSetInterface of kubevirt.io/client-go/kubecli/v2, which takes a
+// context on every call. This interface is kept for one more release.
 type ReplicaSetInterface interface {
 	Get(name string, options k8smetav1.GetOptions) (*v1.VirtualMachineInstanceReplicaSet, error)
 	List(opts k8smetav1.ListOptions) (*v1.VirtualMachineInstanceReplicaSetList, error)
@@ -196,6 +220,8 @@ type ReplicaSetInterface interface {
 	PatchStatus(name string, pt types.PatchType, data []byte) (result *v1.VirtualMachineInstanceReplicaSet, err error)
 }
 
+// Deprecated: use VirtualMachineInstancePresetInterface of kubevirt.io/client-go/kubecli/v2, which takes a
+// context on every call. This interface is kept for one more release.
 type VirtualMachineInstancePresetInterface interface {
 	Get(name string, options k8smetav1.GetOptions) (*v1.VirtualMachineInstancePreset, error)
 	List(opts k8smetav1.ListOptions) (*v1.VirtualMachineInstancePresetList, error)
@@ -207,6 +233,9 @@ type VirtualMachineInstancePresetInterface interface {
 
 // VirtualMachineInterface provides convenience methods to work with
 // virtual machines inside the cluster
+//
+// Deprecated: use VirtualMachineInterface of kubevirt.io/client-go/kubecli/v2, which takes a
+// context on every call. This interface is kept for one more release.
 type VirtualMachineInterface interface {
 	Get(name string, options *k8smetav1.GetOptions) (*v1.VirtualMachine, error)
 	List(opts *k8smetav1.ListOptions) (*v1.VirtualMachineList, error)
@@ -222,11 +251,18 @@ type VirtualMachineInterface interface {
 	Stop(name string) error
 	ForceStop(name string, graceperiod int) error
 	Migrate(name string) error
+	MigrateCancel(name string) error
+	Rename(name string, renameOptions *v1.RenameOptions) error
+	Move(name string, moveOptions *v1.MoveOptions) error
 	AddVolume(name string, addVolumeOptions *v1.AddVolumeOptions) error
 	RemoveVolume(name string, removeVolumeOptions *v1.RemoveVolumeOptions) error
 	PortForward(name string, port int, protocol string) (StreamInterface, error)
+	MemoryDump(name string, memoryDumpRequest *v1.VirtualMachineMemoryDumpRequest) error
+	RemoveMemoryDump(name string) error
 }
 
+// Deprecated: use VirtualMachineInstanceMigrationInterface of kubevirt.io/client-go/kubecli/v2, which takes a
+// context on every call. This interface is kept for one more release.
 type VirtualMachineInstanceMigrationInterface interface {
 	Get(name string, options *k8smetav1.GetOptions) (*v1.VirtualMachineInstanceMigration, error)
 	List(opts *k8smetav1.ListOptions) (*v1.VirtualMachineInstanceMigrationList, error)
@@ -238,6 +274,8 @@ type VirtualMachineInstanceMigrationInterface interface {
 	PatchStatus(name string, pt types.PatchType, data []byte) (result *v1.VirtualMachineInstanceMigration, err error)
 }
 
+// Deprecated: use KubeVirtInterface of kubevirt.io/client-go/kubecli/v2, which takes a
+// context on every call. This interface is kept for one more release.
 type KubeVirtInterface interface {
 	Get(name string, options *k8smetav1.GetOptions) (*v1.KubeVirt, error)
 	List(opts *k8smetav1.ListOptions) (*v1.KubeVirtList, error)
@@ -23,6 +23,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/url"
 
 	v1 "kubevirt.io/client-go/api/v1"
@@ -114,3 +115,23 @@ func (v *ClusterProfiler) Dump() (*v1.ClusterProfilerResults, error) {
 	}
 	return &profileResults, nil
 }
+
+// DumpArchive writes the profiler results of all components as gzipped tar archive to the writer
+func (v *ClusterProfiler) DumpArchive(w io.Writer) error {
+	preferredVersion, err := v.preferredVersion()
+	if err != nil {
+		return err
+	}
+
+	// Now, query the preferred version
+	uri := fmt.Sprintf("/apis/%s/dump-cluster-profiler-archive", preferredVersion)
+
+	stream, err := v.restClient.Get().RequestURI(uri).Stream(context.Background())
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	_, err = io.Copy(w, stream)
+	return err
+}
@@ -0,0 +1,187 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// StreamDialerFunc turns a function into a StreamDialer
+type StreamDialerFunc func(ctx context.Context) (StreamInterface, error)
+
+func (f StreamDialerFunc) Dial(ctx context.Context) (StreamInterface, error) {
+	return f(ctx)
+}
+
+// SerialConsoleDialer returns a StreamDialer for the serial console of a VMI
+func SerialConsoleDialer(vmis VirtualMachineInstanceInterface, name string, options *SerialConsoleOptions) StreamDialer {
+	return StreamDialerFunc(func(ctx context.Context) (StreamInterface, error) {
+		return dialWithContext(ctx, func() (StreamInterface, error) {
+			return vmis.SerialConsole(name, options)
+		})
+	})
+}
+
+// VNCDialer returns a StreamDialer for the VNC display of a VMI
+func VNCDialer(vmis VirtualMachineInstanceInterface, name string) StreamDialer {
+	return StreamDialerFunc(func(ctx context.Context) (StreamInterface, error) {
+		return dialWithContext(ctx, func() (StreamInterface, error) {
+			return vmis.VNC(name)
+		})
+	})
+}
+
+// dialWithContext returns as soon as ctx is done, a stream which is established afterwards is closed
+func dialWithContext(ctx context.Context, dial func() (StreamInterface, error)) (StreamInterface, error) {
+	connectionChan := make(chan connectionStruct, 1)
+	go func() {
+		con, err := dial()
+		connectionChan <- connectionStruct{con: con, err: err}
+	}()
+
+	select {
+	case conStruct := <-connectionChan:
+		return conStruct.con, conStruct.err
+	case <-ctx.Done():
+		go func() {
+			if conStruct := <-connectionChan; conStruct.con != nil {
+				conStruct.con.AsConn().Close()
+			}
+		}()
+		return nil, ctx.Err()
+	}
+}
+
+type ReconnectOptions struct {
+	// Backoff between the attempts to connect. If Steps is set, it limits the number of
+	// attempts, otherwise it is retried until the context is done.
+	Backoff wait.Backoff
+	// IsRetriable decides if it is worth to try again after an attempt to connect failed,
+	// IsRetriableStreamError is used if not set
+	IsRetriable func(err error) bool
+	// OnDisconnect is called with the error whenever an attempt to connect failed or an
+	// established stream broke
+	OnDisconnect func(err error)
+}
+
+// DefaultReconnectOptions retries forever, waiting up to 30 seconds between the attempts
+func DefaultReconnectOptions() ReconnectOptions {
+	return ReconnectOptions{
+		Backoff: wait.Backoff{
+			Duration: 1 * time.Second,
+			Factor:   2,
+			Jitter:   0.1,
+			Cap:      30 * time.Second,
+		},
+	}
+}
+
+// IsRetriableStreamError returns true for errors which may go away by themselves, like a VMI which
+// is not running yet or a connection which was interrupted
+func IsRetriableStreamError(err error) bool {
+	switch e := err.(type) {
+	case *AsyncSubresourceError:
+		switch e.GetStatusCode() {
+		case 0, http.StatusBadRequest, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	case net.Error:
+		return true
+	}
+	return false
+}
+
+// DialWithRetry dials until a stream is established, the error is not retriable, the attempts
+// of the backoff are used up or ctx is done
+func DialWithRetry(ctx context.Context, dialer StreamDialer, options ReconnectOptions) (StreamInterface, error) {
+	isRetriable := options.IsRetriable
+	if isRetriable == nil {
+		isRetriable = IsRetriableStreamError
+	}
+	// Steps limits the attempts, the duration keeps growing up to the cap in any case
+	maxAttempts := options.Backoff.Steps
+	backoff := options.Backoff
+	backoff.Steps = math.MaxInt32
+
+	for attempt := 1; ; attempt++ {
+		stream, err := dialer.Dial(ctx)
+		if err == nil {
+			return stream, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if options.OnDisconnect != nil {
+			options.OnDisconnect(err)
+		}
+		if !isRetriable(err) || (maxAttempts > 0 && attempt >= maxAttempts) {
+			return nil, err
+		}
+
+		select {
+		case <-time.After(backoff.Step()):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// StreamWithContext streams until the stream ends or ctx is done, in which case the stream is
+// closed and the error of ctx is returned
+func StreamWithContext(ctx context.Context, stream StreamInterface, options StreamOptions) error {
+	streamErr := make(chan error, 1)
+	go func() {
+		streamErr <- stream.Stream(options)
+	}()
+
+	select {
+	case err := <-streamErr:
+		return err
+	case <-ctx.Done():
+		stream.AsConn().Close()
+		return ctx.Err()
+	}
+}
+
+// StreamWithReconnect streams and reconnects whenever the stream broke, until the input ends,
+// reconnecting fails or ctx is done. Since the input is shared between the streams, one read
+// of the input may get lost when a stream breaks.
+func StreamWithReconnect(ctx context.Context, dialer StreamDialer, options StreamOptions, reconnectOptions ReconnectOptions) error {
+	for {
+		stream, err := DialWithRetry(ctx, dialer, reconnectOptions)
+		if err != nil {
+			return err
+		}
+		err = StreamWithContext(ctx, stream, options)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+		if reconnectOptions.OnDisconnect != nil {
+			reconnectOptions.OnDisconnect(err)
+		}
+	}
+}
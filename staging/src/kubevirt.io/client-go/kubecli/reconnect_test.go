@@ -0,0 +1,193 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// fakeStream returns the configured error or blocks in Stream until it is closed. Without a
+// channel to close, it ends like a stream whose input ended.
+type fakeStream struct {
+	err    error
+	closed chan struct{}
+}
+
+func newFakeStream(err error) *fakeStream {
+	return &fakeStream{err: err, closed: make(chan struct{})}
+}
+
+func (s *fakeStream) Stream(options StreamOptions) error {
+	if s.err != nil {
+		return s.err
+	}
+	if s.closed == nil {
+		return nil
+	}
+	<-s.closed
+	return fmt.Errorf("stream closed")
+}
+
+func (s *fakeStream) AsConn() net.Conn {
+	client, server := net.Pipe()
+	server.Close()
+	close(s.closed)
+	return client
+}
+
+var _ = Describe("Reconnecting streams", func() {
+	var ctrl *gomock.Controller
+	var dialer *MockStreamDialer
+	var options ReconnectOptions
+	var disconnects []error
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		dialer = NewMockStreamDialer(ctrl)
+		disconnects = nil
+		options = ReconnectOptions{
+			Backoff: wait.Backoff{Duration: time.Millisecond, Factor: 2, Cap: 10 * time.Millisecond},
+			OnDisconnect: func(err error) {
+				disconnects = append(disconnects, err)
+			},
+		}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	notRunning := &AsyncSubresourceError{err: "VMI is not running", StatusCode: http.StatusBadRequest}
+	notFound := &AsyncSubresourceError{err: "Virtual Machine not found.", StatusCode: http.StatusNotFound}
+
+	table.DescribeTable("should decide if an error is retriable", func(err error, retriable bool) {
+		Expect(IsRetriableStreamError(err)).To(Equal(retriable))
+	},
+		table.Entry("for a VMI which is not running", notRunning, true),
+		table.Entry("for an unavailable service", &AsyncSubresourceError{StatusCode: http.StatusServiceUnavailable}, true),
+		table.Entry("for an interrupted connection", &AsyncSubresourceError{err: "EOF"}, true),
+		table.Entry("for a network error", &net.OpError{Op: "dial", Err: fmt.Errorf("connection refused")}, true),
+		table.Entry("for a VMI which does not exist", notFound, false),
+		table.Entry("for a forbidden request", &AsyncSubresourceError{StatusCode: http.StatusForbidden}, false),
+		table.Entry("for any other error", fmt.Errorf("invalid config"), false),
+	)
+
+	Context("dialing", func() {
+		It("should retry until the stream is established", func() {
+			stream := newFakeStream(nil)
+			gomock.InOrder(
+				dialer.EXPECT().Dial(gomock.Any()).Return(nil, notRunning).Times(2),
+				dialer.EXPECT().Dial(gomock.Any()).Return(stream, nil),
+			)
+
+			result, err := DialWithRetry(context.Background(), dialer, options)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(stream))
+			Expect(disconnects).To(HaveLen(2))
+		})
+
+		It("should not retry errors which are not retriable", func() {
+			dialer.EXPECT().Dial(gomock.Any()).Return(nil, notFound)
+
+			_, err := DialWithRetry(context.Background(), dialer, options)
+			Expect(err).To(Equal(notFound))
+		})
+
+		It("should give up after the steps of the backoff", func() {
+			options.Backoff.Steps = 3
+			dialer.EXPECT().Dial(gomock.Any()).Return(nil, notRunning).Times(3)
+
+			_, err := DialWithRetry(context.Background(), dialer, options)
+			Expect(err).To(Equal(notRunning))
+		})
+
+		It("should stop when the context is done", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			options.Backoff.Duration = time.Hour
+			dialer.EXPECT().Dial(gomock.Any()).DoAndReturn(func(ctx context.Context) (StreamInterface, error) {
+				cancel()
+				return nil, notRunning
+			})
+
+			_, err := DialWithRetry(ctx, dialer, options)
+			Expect(err).To(Equal(context.Canceled))
+		})
+
+		It("should close a stream which is established after the context is done", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			stream := newFakeStream(nil)
+			dial := make(chan struct{})
+
+			_, err := dialWithContext(ctx, func() (StreamInterface, error) {
+				<-dial
+				return stream, nil
+			})
+			Expect(err).To(Equal(context.Canceled))
+			close(dial)
+			Eventually(stream.closed).Should(BeClosed())
+		})
+	})
+
+	Context("streaming", func() {
+		It("should close the stream when the context is done", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			stream := newFakeStream(nil)
+			cancel()
+
+			err := StreamWithContext(ctx, stream, StreamOptions{})
+			Expect(err).To(Equal(context.Canceled))
+			Expect(stream.closed).To(BeClosed())
+		})
+
+		It("should reconnect when the stream broke", func() {
+			broken := fmt.Errorf("websocket: close 1006 (abnormal closure)")
+			gomock.InOrder(
+				dialer.EXPECT().Dial(gomock.Any()).Return(newFakeStream(broken), nil),
+				dialer.EXPECT().Dial(gomock.Any()).Return(nil, notRunning),
+				dialer.EXPECT().Dial(gomock.Any()).Return(&fakeStream{}, nil),
+			)
+
+			err := StreamWithReconnect(context.Background(), dialer, StreamOptions{}, options)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(disconnects).To(Equal([]error{broken, notRunning}))
+		})
+
+		It("should fail if reconnecting fails", func() {
+			gomock.InOrder(
+				dialer.EXPECT().Dial(gomock.Any()).Return(newFakeStream(fmt.Errorf("broken")), nil),
+				dialer.EXPECT().Dial(gomock.Any()).Return(nil, notFound),
+			)
+
+			err := StreamWithReconnect(context.Background(), dialer, StreamOptions{}, options)
+			Expect(err).To(Equal(notFound))
+		})
+	})
+})
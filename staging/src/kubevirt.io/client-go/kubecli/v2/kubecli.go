@@ -0,0 +1,104 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/transport"
+
+	poolv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/pool/v1alpha1"
+	vmsnapshotv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/snapshot/v1alpha1"
+	kubecliv1 "kubevirt.io/client-go/kubecli"
+)
+
+// Option changes the rest config before the clients are created
+type Option func(config *rest.Config)
+
+// WithTransportWrapper wraps the round tripper of all requests, for example to instrument them with
+// metrics or traces. Wrappers are applied in the order they are passed.
+func WithTransportWrapper(wrapper transport.WrapperFunc) Option {
+	return func(config *rest.Config) {
+		config.Wrap(wrapper)
+	}
+}
+
+// NewForConfig creates a client for the rest config. The hooks registered with
+// kubecli.RegisterRestConfigHook are applied too.
+func NewForConfig(config *rest.Config, options ...Option) (KubevirtClient, error) {
+	shallowCopy := rest.CopyConfig(config)
+	for _, option := range options {
+		option(shallowCopy)
+	}
+	client, err := kubecliv1.GetKubevirtClientFromRESTConfig(shallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	return FromV1(client), nil
+}
+
+// NewFromFlags creates a client for the master url or the kubeconfig file
+func NewFromFlags(master string, kubeconfig string, options ...Option) (KubevirtClient, error) {
+	config, err := clientcmd.BuildConfigFromFlags(master, kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	return NewForConfig(config, options...)
+}
+
+// FromV1 wraps a client without contexts. It allows to move from one call to the other, while both
+// versions of the client are available.
+func FromV1(client kubecliv1.KubevirtClient) KubevirtClient {
+	return &kubevirt{v1: client}
+}
+
+type kubevirt struct {
+	v1 kubecliv1.KubevirtClient
+}
+
+func (k *kubevirt) RestClient() *rest.RESTClient {
+	return k.v1.RestClient()
+}
+
+func (k *kubevirt) Config() *rest.Config {
+	return k.v1.Config()
+}
+
+func (k *kubevirt) V1() kubecliv1.KubevirtClient {
+	return k.v1
+}
+
+// The generated clients of snapshots, restores and pools already take a context
+
+func (k *kubevirt) VirtualMachineSnapshot(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotInterface {
+	return k.v1.VirtualMachineSnapshot(namespace)
+}
+
+func (k *kubevirt) VirtualMachineSnapshotContent(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotContentInterface {
+	return k.v1.VirtualMachineSnapshotContent(namespace)
+}
+
+func (k *kubevirt) VirtualMachineRestore(namespace string) vmsnapshotv1alpha1.VirtualMachineRestoreInterface {
+	return k.v1.VirtualMachineRestore(namespace)
+}
+
+func (k *kubevirt) VirtualMachinePool(namespace string) poolv1alpha1.VirtualMachinePoolInterface {
+	return k.v1.VirtualMachinePool(namespace)
+}
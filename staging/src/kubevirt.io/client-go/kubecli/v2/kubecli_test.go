@@ -0,0 +1,87 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	k8sv1 "k8s.io/api/core/v1"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+type countingRoundTripper struct {
+	delegate http.RoundTripper
+	requests *int
+}
+
+func (c *countingRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	*c.requests++
+	return c.delegate.RoundTrip(request)
+}
+
+var _ = Describe("Kubevirt Client with context", func() {
+
+	var server *ghttp.Server
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		server.AppendHandlers(ghttp.RespondWithJSONEncoded(http.StatusOK, v1.NewMinimalVMI("testvm")))
+	})
+
+	It("should wrap the round tripper of all requests", func() {
+		requests := 0
+		client, err := NewForConfig(&rest.Config{Host: server.URL()}, WithTransportWrapper(func(rt http.RoundTripper) http.RoundTripper {
+			return &countingRoundTripper{delegate: rt, requests: &requests}
+		}))
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = client.VirtualMachineInstance(k8sv1.NamespaceDefault).Get(context.Background(), "testvm", k8smetav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requests).To(Equal(1))
+	})
+
+	It("should not change the passed config", func() {
+		config := &rest.Config{Host: server.URL()}
+		_, err := NewForConfig(config, WithTransportWrapper(func(rt http.RoundTripper) http.RoundTripper {
+			return rt
+		}))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(config.WrapTransport).To(BeNil())
+	})
+
+	It("should share the connection with the v1 client", func() {
+		client, err := NewFromFlags(server.URL(), "")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(FromV1(client.V1()).RestClient()).To(BeIdenticalTo(client.RestClient()))
+		Expect(client.V1().Config()).To(BeIdenticalTo(client.Config()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+})
@@ -0,0 +1,146 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+//go:generate mockgen -source $GOFILE -package=$GOPACKAGE -destination=generated_mock_$GOFILE
+
+// Package kubecli is the second version of the KubeVirt client. Every call takes a context, which
+// cancels the request and limits its duration, and the options are passed by value like in the
+// typed clients of k8s.io/client-go.
+package kubecli
+
+/*
+ ATTENTION: Rerun code generators when interface signatures are modified.
+*/
+
+import (
+	"context"
+
+	autov1 "k8s.io/api/autoscaling/v1"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	poolv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/pool/v1alpha1"
+	vmsnapshotv1alpha1 "kubevirt.io/client-go/generated/kubevirt/clientset/versioned/typed/snapshot/v1alpha1"
+	kubecliv1 "kubevirt.io/client-go/kubecli"
+)
+
+type KubevirtClient interface {
+	VirtualMachineInstance(namespace string) VirtualMachineInstanceInterface
+	VirtualMachine(namespace string) VirtualMachineInterface
+	VirtualMachineInstanceMigration(namespace string) VirtualMachineInstanceMigrationInterface
+	ReplicaSet(namespace string) ReplicaSetInterface
+	VirtualMachineInstancePreset(namespace string) VirtualMachineInstancePresetInterface
+	KubeVirt(namespace string) KubeVirtInterface
+	VirtualMachineSnapshot(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotInterface
+	VirtualMachineSnapshotContent(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotContentInterface
+	VirtualMachineRestore(namespace string) vmsnapshotv1alpha1.VirtualMachineRestoreInterface
+	VirtualMachinePool(namespace string) poolv1alpha1.VirtualMachinePoolInterface
+	RestClient() *rest.RESTClient
+	Config() *rest.Config
+	// V1 returns the client without contexts, which shares the connection with this client
+	V1() kubecliv1.KubevirtClient
+}
+
+type VirtualMachineInstanceInterface interface {
+	Get(ctx context.Context, name string, options k8smetav1.GetOptions) (*v1.VirtualMachineInstance, error)
+	List(ctx context.Context, options k8smetav1.ListOptions) (*v1.VirtualMachineInstanceList, error)
+	Create(ctx context.Context, vmi *v1.VirtualMachineInstance, options k8smetav1.CreateOptions) (*v1.VirtualMachineInstance, error)
+	Update(ctx context.Context, vmi *v1.VirtualMachineInstance, options k8smetav1.UpdateOptions) (*v1.VirtualMachineInstance, error)
+	Delete(ctx context.Context, name string, options k8smetav1.DeleteOptions) error
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, options k8smetav1.PatchOptions, subresources ...string) (*v1.VirtualMachineInstance, error)
+	Watch(ctx context.Context, options k8smetav1.ListOptions) (watch.Interface, error)
+	SerialConsole(ctx context.Context, name string, options *kubecliv1.SerialConsoleOptions) (kubecliv1.StreamInterface, error)
+	VNC(ctx context.Context, name string) (kubecliv1.StreamInterface, error)
+	Pause(ctx context.Context, name string, options *v1.PauseOptions) error
+	Unpause(ctx context.Context, name string, options *v1.UnpauseOptions) error
+	Freeze(ctx context.Context, name string) error
+	Unfreeze(ctx context.Context, name string) error
+	GuestOsInfo(ctx context.Context, name string) (v1.VirtualMachineInstanceGuestAgentInfo, error)
+	UserList(ctx context.Context, name string) (v1.VirtualMachineInstanceGuestOSUserList, error)
+	FilesystemList(ctx context.Context, name string) (v1.VirtualMachineInstanceFileSystemList, error)
+	GuestExec(ctx context.Context, name string, options *v1.GuestExecOptions) (*v1.GuestExecResult, error)
+	GuestExecStream(ctx context.Context, name string, options *v1.GuestExecOptions) (*v1.GuestExecResult, error)
+	DomainLog(ctx context.Context, name string, lines int) ([]byte, error)
+	AddVolume(ctx context.Context, name string, options *v1.AddVolumeOptions) error
+	RemoveVolume(ctx context.Context, name string, options *v1.RemoveVolumeOptions) error
+}
+
+type VirtualMachineInterface interface {
+	Get(ctx context.Context, name string, options k8smetav1.GetOptions) (*v1.VirtualMachine, error)
+	List(ctx context.Context, options k8smetav1.ListOptions) (*v1.VirtualMachineList, error)
+	Create(ctx context.Context, vm *v1.VirtualMachine, options k8smetav1.CreateOptions) (*v1.VirtualMachine, error)
+	Update(ctx context.Context, vm *v1.VirtualMachine, options k8smetav1.UpdateOptions) (*v1.VirtualMachine, error)
+	UpdateStatus(ctx context.Context, vm *v1.VirtualMachine, options k8smetav1.UpdateOptions) (*v1.VirtualMachine, error)
+	Delete(ctx context.Context, name string, options k8smetav1.DeleteOptions) error
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, options k8smetav1.PatchOptions, subresources ...string) (*v1.VirtualMachine, error)
+	Watch(ctx context.Context, options k8smetav1.ListOptions) (watch.Interface, error)
+	Start(ctx context.Context, name string, options *v1.StartOptions) error
+	Stop(ctx context.Context, name string, options *v1.StopOptions) error
+	Restart(ctx context.Context, name string, options *v1.RestartOptions) error
+	Migrate(ctx context.Context, name string) error
+	AddVolume(ctx context.Context, name string, options *v1.AddVolumeOptions) error
+	RemoveVolume(ctx context.Context, name string, options *v1.RemoveVolumeOptions) error
+}
+
+type VirtualMachineInstanceMigrationInterface interface {
+	Get(ctx context.Context, name string, options k8smetav1.GetOptions) (*v1.VirtualMachineInstanceMigration, error)
+	List(ctx context.Context, options k8smetav1.ListOptions) (*v1.VirtualMachineInstanceMigrationList, error)
+	Create(ctx context.Context, migration *v1.VirtualMachineInstanceMigration, options k8smetav1.CreateOptions) (*v1.VirtualMachineInstanceMigration, error)
+	Update(ctx context.Context, migration *v1.VirtualMachineInstanceMigration, options k8smetav1.UpdateOptions) (*v1.VirtualMachineInstanceMigration, error)
+	Delete(ctx context.Context, name string, options k8smetav1.DeleteOptions) error
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, options k8smetav1.PatchOptions, subresources ...string) (*v1.VirtualMachineInstanceMigration, error)
+	Watch(ctx context.Context, options k8smetav1.ListOptions) (watch.Interface, error)
+}
+
+type ReplicaSetInterface interface {
+	Get(ctx context.Context, name string, options k8smetav1.GetOptions) (*v1.VirtualMachineInstanceReplicaSet, error)
+	List(ctx context.Context, options k8smetav1.ListOptions) (*v1.VirtualMachineInstanceReplicaSetList, error)
+	Create(ctx context.Context, replicaset *v1.VirtualMachineInstanceReplicaSet, options k8smetav1.CreateOptions) (*v1.VirtualMachineInstanceReplicaSet, error)
+	Update(ctx context.Context, replicaset *v1.VirtualMachineInstanceReplicaSet, options k8smetav1.UpdateOptions) (*v1.VirtualMachineInstanceReplicaSet, error)
+	UpdateStatus(ctx context.Context, replicaset *v1.VirtualMachineInstanceReplicaSet, options k8smetav1.UpdateOptions) (*v1.VirtualMachineInstanceReplicaSet, error)
+	Delete(ctx context.Context, name string, options k8smetav1.DeleteOptions) error
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, options k8smetav1.PatchOptions, subresources ...string) (*v1.VirtualMachineInstanceReplicaSet, error)
+	Watch(ctx context.Context, options k8smetav1.ListOptions) (watch.Interface, error)
+	GetScale(ctx context.Context, name string, options k8smetav1.GetOptions) (*autov1.Scale, error)
+	UpdateScale(ctx context.Context, name string, scale *autov1.Scale, options k8smetav1.UpdateOptions) (*autov1.Scale, error)
+}
+
+type VirtualMachineInstancePresetInterface interface {
+	Get(ctx context.Context, name string, options k8smetav1.GetOptions) (*v1.VirtualMachineInstancePreset, error)
+	List(ctx context.Context, options k8smetav1.ListOptions) (*v1.VirtualMachineInstancePresetList, error)
+	Create(ctx context.Context, vmiPreset *v1.VirtualMachineInstancePreset, options k8smetav1.CreateOptions) (*v1.VirtualMachineInstancePreset, error)
+	Update(ctx context.Context, vmiPreset *v1.VirtualMachineInstancePreset, options k8smetav1.UpdateOptions) (*v1.VirtualMachineInstancePreset, error)
+	Delete(ctx context.Context, name string, options k8smetav1.DeleteOptions) error
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, options k8smetav1.PatchOptions, subresources ...string) (*v1.VirtualMachineInstancePreset, error)
+	Watch(ctx context.Context, options k8smetav1.ListOptions) (watch.Interface, error)
+}
+
+type KubeVirtInterface interface {
+	Get(ctx context.Context, name string, options k8smetav1.GetOptions) (*v1.KubeVirt, error)
+	List(ctx context.Context, options k8smetav1.ListOptions) (*v1.KubeVirtList, error)
+	Create(ctx context.Context, instance *v1.KubeVirt, options k8smetav1.CreateOptions) (*v1.KubeVirt, error)
+	Update(ctx context.Context, instance *v1.KubeVirt, options k8smetav1.UpdateOptions) (*v1.KubeVirt, error)
+	UpdateStatus(ctx context.Context, instance *v1.KubeVirt, options k8smetav1.UpdateOptions) (*v1.KubeVirt, error)
+	Delete(ctx context.Context, name string, options k8smetav1.DeleteOptions) error
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, options k8smetav1.PatchOptions, subresources ...string) (*v1.KubeVirt, error)
+	Watch(ctx context.Context, options k8smetav1.ListOptions) (watch.Interface, error)
+}
@@ -0,0 +1,88 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package kubecli
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	k8sv1 "k8s.io/api/core/v1"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	kubecliv1 "kubevirt.io/client-go/kubecli"
+)
+
+var _ = Describe("Kubevirt KubeVirt Client with context", func() {
+
+	var server *ghttp.Server
+	var client KubevirtClient
+	basePath := "/apis/kubevirt.io/" + v1.ApiStorageVersion + "/namespaces/default/kubevirts"
+
+	BeforeEach(func() {
+		var err error
+		server = ghttp.NewServer()
+		client, err = NewFromFlags(server.URL(), "")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should get a KubeVirt", func() {
+		kv := kubecliv1.NewMinimalKubeVirt("testkubevirt")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", basePath+"/testkubevirt"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, kv),
+		))
+		fetchedKubeVirt, err := client.KubeVirt(k8sv1.NamespaceDefault).Get(context.Background(), "testkubevirt", k8smetav1.GetOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedKubeVirt).To(Equal(kv))
+	})
+
+	It("should patch a KubeVirt and set its kind", func() {
+		kv := kubecliv1.NewMinimalKubeVirt("testkubevirt")
+		kv.TypeMeta = k8smetav1.TypeMeta{}
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PATCH", basePath+"/testkubevirt"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, kv),
+		))
+		patchedKubeVirt, err := client.KubeVirt(k8sv1.NamespaceDefault).Patch(context.Background(), "testkubevirt", types.MergePatchType, []byte("{}"), k8smetav1.PatchOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(patchedKubeVirt.Kind).To(Equal(v1.KubeVirtGroupVersionKind.Kind))
+	})
+
+	It("should cancel a request when the context is done", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := client.KubeVirt(k8sv1.NamespaceDefault).Get(ctx, "testkubevirt", k8smetav1.GetOptions{})
+
+		Expect(err).To(HaveOccurred())
+		Expect(server.ReceivedRequests()).To(BeEmpty())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+})
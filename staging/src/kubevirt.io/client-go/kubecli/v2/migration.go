@@ -0,0 +1,134 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	"context"
+
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+func (k *kubevirt) VirtualMachineInstanceMigration(namespace string) VirtualMachineInstanceMigrationInterface {
+	return &migration{
+		restClient: k.v1.RestClient(),
+		namespace:  namespace,
+		resource:   "virtualmachineinstancemigrations",
+	}
+}
+
+type migration struct {
+	restClient *rest.RESTClient
+	namespace  string
+	resource   string
+}
+
+func (o *migration) Get(ctx context.Context, name string, options k8smetav1.GetOptions) (*v1.VirtualMachineInstanceMigration, error) {
+	result := &v1.VirtualMachineInstanceMigration{}
+	err := o.restClient.Get().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	result.SetGroupVersionKind(v1.VirtualMachineInstanceMigrationGroupVersionKind)
+	return result, err
+}
+
+func (o *migration) List(ctx context.Context, options k8smetav1.ListOptions) (*v1.VirtualMachineInstanceMigrationList, error) {
+	migrationList := &v1.VirtualMachineInstanceMigrationList{}
+	err := o.restClient.Get().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(migrationList)
+	for i := range migrationList.Items {
+		migrationList.Items[i].SetGroupVersionKind(v1.VirtualMachineInstanceMigrationGroupVersionKind)
+	}
+	return migrationList, err
+}
+
+func (o *migration) Create(ctx context.Context, migration *v1.VirtualMachineInstanceMigration, options k8smetav1.CreateOptions) (*v1.VirtualMachineInstanceMigration, error) {
+	result := &v1.VirtualMachineInstanceMigration{}
+	err := o.restClient.Post().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Body(migration).
+		Do(ctx).
+		Into(result)
+	result.SetGroupVersionKind(v1.VirtualMachineInstanceMigrationGroupVersionKind)
+	return result, err
+}
+
+func (o *migration) Update(ctx context.Context, migration *v1.VirtualMachineInstanceMigration, options k8smetav1.UpdateOptions) (*v1.VirtualMachineInstanceMigration, error) {
+	result := &v1.VirtualMachineInstanceMigration{}
+	err := o.restClient.Put().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(migration.Name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Body(migration).
+		Do(ctx).
+		Into(result)
+	result.SetGroupVersionKind(v1.VirtualMachineInstanceMigrationGroupVersionKind)
+	return result, err
+}
+
+func (o *migration) Delete(ctx context.Context, name string, options k8smetav1.DeleteOptions) error {
+	return o.restClient.Delete().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(name).
+		Body(&options).
+		Do(ctx).
+		Error()
+}
+
+func (o *migration) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, options k8smetav1.PatchOptions, subresources ...string) (*v1.VirtualMachineInstanceMigration, error) {
+	result := &v1.VirtualMachineInstanceMigration{}
+	err := o.restClient.Patch(pt).
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	result.SetGroupVersionKind(v1.VirtualMachineInstanceMigrationGroupVersionKind)
+	return result, err
+}
+
+func (o *migration) Watch(ctx context.Context, options k8smetav1.ListOptions) (watch.Interface, error) {
+	options.Watch = true
+	return o.restClient.Get().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Watch(ctx)
+}
@@ -0,0 +1,171 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	"context"
+
+	autov1 "k8s.io/api/autoscaling/v1"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+func (k *kubevirt) ReplicaSet(namespace string) ReplicaSetInterface {
+	return &replicaSet{
+		restClient: k.v1.RestClient(),
+		namespace:  namespace,
+		resource:   "virtualmachineinstancereplicasets",
+	}
+}
+
+type replicaSet struct {
+	restClient *rest.RESTClient
+	namespace  string
+	resource   string
+}
+
+func (o *replicaSet) Get(ctx context.Context, name string, options k8smetav1.GetOptions) (*v1.VirtualMachineInstanceReplicaSet, error) {
+	result := &v1.VirtualMachineInstanceReplicaSet{}
+	err := o.restClient.Get().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	result.SetGroupVersionKind(v1.VirtualMachineInstanceReplicaSetGroupVersionKind)
+	return result, err
+}
+
+func (o *replicaSet) List(ctx context.Context, options k8smetav1.ListOptions) (*v1.VirtualMachineInstanceReplicaSetList, error) {
+	list := &v1.VirtualMachineInstanceReplicaSetList{}
+	err := o.restClient.Get().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(list)
+	for i := range list.Items {
+		list.Items[i].SetGroupVersionKind(v1.VirtualMachineInstanceReplicaSetGroupVersionKind)
+	}
+	return list, err
+}
+
+func (o *replicaSet) Create(ctx context.Context, replicaset *v1.VirtualMachineInstanceReplicaSet, options k8smetav1.CreateOptions) (*v1.VirtualMachineInstanceReplicaSet, error) {
+	result := &v1.VirtualMachineInstanceReplicaSet{}
+	err := o.restClient.Post().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Body(replicaset).
+		Do(ctx).
+		Into(result)
+	result.SetGroupVersionKind(v1.VirtualMachineInstanceReplicaSetGroupVersionKind)
+	return result, err
+}
+
+func (o *replicaSet) Update(ctx context.Context, replicaset *v1.VirtualMachineInstanceReplicaSet, options k8smetav1.UpdateOptions) (*v1.VirtualMachineInstanceReplicaSet, error) {
+	return o.update(ctx, replicaset, options)
+}
+
+func (o *replicaSet) UpdateStatus(ctx context.Context, replicaset *v1.VirtualMachineInstanceReplicaSet, options k8smetav1.UpdateOptions) (*v1.VirtualMachineInstanceReplicaSet, error) {
+	return o.update(ctx, replicaset, options, "status")
+}
+
+func (o *replicaSet) update(ctx context.Context, replicaset *v1.VirtualMachineInstanceReplicaSet, options k8smetav1.UpdateOptions, subresources ...string) (*v1.VirtualMachineInstanceReplicaSet, error) {
+	result := &v1.VirtualMachineInstanceReplicaSet{}
+	err := o.restClient.Put().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(replicaset.Name).
+		SubResource(subresources...).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Body(replicaset).
+		Do(ctx).
+		Into(result)
+	result.SetGroupVersionKind(v1.VirtualMachineInstanceReplicaSetGroupVersionKind)
+	return result, err
+}
+
+func (o *replicaSet) Delete(ctx context.Context, name string, options k8smetav1.DeleteOptions) error {
+	return o.restClient.Delete().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(name).
+		Body(&options).
+		Do(ctx).
+		Error()
+}
+
+func (o *replicaSet) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, options k8smetav1.PatchOptions, subresources ...string) (*v1.VirtualMachineInstanceReplicaSet, error) {
+	result := &v1.VirtualMachineInstanceReplicaSet{}
+	err := o.restClient.Patch(pt).
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	result.SetGroupVersionKind(v1.VirtualMachineInstanceReplicaSetGroupVersionKind)
+	return result, err
+}
+
+func (o *replicaSet) Watch(ctx context.Context, options k8smetav1.ListOptions) (watch.Interface, error) {
+	options.Watch = true
+	return o.restClient.Get().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Watch(ctx)
+}
+
+func (o *replicaSet) GetScale(ctx context.Context, name string, options k8smetav1.GetOptions) (*autov1.Scale, error) {
+	result := &autov1.Scale{}
+	err := o.restClient.Get().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(name).
+		SubResource("scale").
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+func (o *replicaSet) UpdateScale(ctx context.Context, name string, scale *autov1.Scale, options k8smetav1.UpdateOptions) (*autov1.Scale, error) {
+	result := &autov1.Scale{}
+	err := o.restClient.Put().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(name).
+		SubResource("scale").
+		VersionedParams(&options, scheme.ParameterCodec).
+		Body(scale).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
@@ -0,0 +1,92 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package kubecli
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	autov1 "k8s.io/api/autoscaling/v1"
+	k8sv1 "k8s.io/api/core/v1"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	kubecliv1 "kubevirt.io/client-go/kubecli"
+)
+
+var _ = Describe("Kubevirt VirtualMachineInstanceReplicaSet Client with context", func() {
+
+	var server *ghttp.Server
+	var client KubevirtClient
+	basePath := "/apis/kubevirt.io/" + v1.ApiStorageVersion + "/namespaces/default/virtualmachineinstancereplicasets"
+
+	BeforeEach(func() {
+		var err error
+		server = ghttp.NewServer()
+		client, err = NewFromFlags(server.URL(), "")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should list VirtualMachineInstanceReplicaSets", func() {
+		replicaset := kubecliv1.NewMinimalVirtualMachineInstanceReplicaSet("testreplicaset")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", basePath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, kubecliv1.NewVirtualMachineInstanceReplicaSetList(*replicaset)),
+		))
+		replicasets, err := client.ReplicaSet(k8sv1.NamespaceDefault).List(context.Background(), k8smetav1.ListOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(replicasets.Items).To(HaveLen(1))
+		Expect(replicasets.Items[0]).To(Equal(*replicaset))
+	})
+
+	It("should update the status of a VirtualMachineInstanceReplicaSet", func() {
+		replicaset := kubecliv1.NewMinimalVirtualMachineInstanceReplicaSet("testreplicaset")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", basePath+"/testreplicaset/status"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, replicaset),
+		))
+		updatedReplicaSet, err := client.ReplicaSet(k8sv1.NamespaceDefault).UpdateStatus(context.Background(), replicaset, k8smetav1.UpdateOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updatedReplicaSet).To(Equal(replicaset))
+	})
+
+	It("should update the scale of a VirtualMachineInstanceReplicaSet", func() {
+		scale := &autov1.Scale{Spec: autov1.ScaleSpec{Replicas: 3}}
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", basePath+"/testreplicaset/scale"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, scale),
+		))
+		updatedScale, err := client.ReplicaSet(k8sv1.NamespaceDefault).UpdateScale(context.Background(), "testreplicaset", scale, k8smetav1.UpdateOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updatedScale).To(Equal(scale))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+})
@@ -0,0 +1,176 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	"context"
+	"fmt"
+
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+const vmSubresourceURL = "/apis/subresources.kubevirt.io/%s/namespaces/%s/virtualmachines/%s/%s"
+
+func (k *kubevirt) VirtualMachine(namespace string) VirtualMachineInterface {
+	return &vm{
+		restClient: k.v1.RestClient(),
+		namespace:  namespace,
+		resource:   "virtualmachines",
+	}
+}
+
+type vm struct {
+	restClient *rest.RESTClient
+	namespace  string
+	resource   string
+}
+
+func (v *vm) Get(ctx context.Context, name string, options k8smetav1.GetOptions) (*v1.VirtualMachine, error) {
+	result := &v1.VirtualMachine{}
+	err := v.restClient.Get().
+		Resource(v.resource).
+		Namespace(v.namespace).
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	result.SetGroupVersionKind(v1.VirtualMachineGroupVersionKind)
+	return result, err
+}
+
+func (v *vm) List(ctx context.Context, options k8smetav1.ListOptions) (*v1.VirtualMachineList, error) {
+	vmList := &v1.VirtualMachineList{}
+	err := v.restClient.Get().
+		Resource(v.resource).
+		Namespace(v.namespace).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(vmList)
+	for i := range vmList.Items {
+		vmList.Items[i].SetGroupVersionKind(v1.VirtualMachineGroupVersionKind)
+	}
+	return vmList, err
+}
+
+func (v *vm) Create(ctx context.Context, vm *v1.VirtualMachine, options k8smetav1.CreateOptions) (*v1.VirtualMachine, error) {
+	result := &v1.VirtualMachine{}
+	err := v.restClient.Post().
+		Resource(v.resource).
+		Namespace(v.namespace).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Body(vm).
+		Do(ctx).
+		Into(result)
+	result.SetGroupVersionKind(v1.VirtualMachineGroupVersionKind)
+	return result, err
+}
+
+func (v *vm) Update(ctx context.Context, vm *v1.VirtualMachine, options k8smetav1.UpdateOptions) (*v1.VirtualMachine, error) {
+	return v.update(ctx, vm, options)
+}
+
+func (v *vm) UpdateStatus(ctx context.Context, vm *v1.VirtualMachine, options k8smetav1.UpdateOptions) (*v1.VirtualMachine, error) {
+	return v.update(ctx, vm, options, "status")
+}
+
+func (v *vm) update(ctx context.Context, vm *v1.VirtualMachine, options k8smetav1.UpdateOptions, subresources ...string) (*v1.VirtualMachine, error) {
+	result := &v1.VirtualMachine{}
+	err := v.restClient.Put().
+		Resource(v.resource).
+		Namespace(v.namespace).
+		Name(vm.Name).
+		SubResource(subresources...).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Body(vm).
+		Do(ctx).
+		Into(result)
+	result.SetGroupVersionKind(v1.VirtualMachineGroupVersionKind)
+	return result, err
+}
+
+func (v *vm) Delete(ctx context.Context, name string, options k8smetav1.DeleteOptions) error {
+	return v.restClient.Delete().
+		Resource(v.resource).
+		Namespace(v.namespace).
+		Name(name).
+		Body(&options).
+		Do(ctx).
+		Error()
+}
+
+func (v *vm) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, options k8smetav1.PatchOptions, subresources ...string) (*v1.VirtualMachine, error) {
+	result := &v1.VirtualMachine{}
+	err := v.restClient.Patch(pt).
+		Resource(v.resource).
+		Namespace(v.namespace).
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	result.SetGroupVersionKind(v1.VirtualMachineGroupVersionKind)
+	return result, err
+}
+
+func (v *vm) Watch(ctx context.Context, options k8smetav1.ListOptions) (watch.Interface, error) {
+	options.Watch = true
+	return v.restClient.Get().
+		Resource(v.resource).
+		Namespace(v.namespace).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Watch(ctx)
+}
+
+func (v *vm) Start(ctx context.Context, name string, options *v1.StartOptions) error {
+	return putSubresource(ctx, v.restClient, v.subresourceURL(name, "start"), options)
+}
+
+// Stop stops the VM, a grace period in the options forces the stop
+func (v *vm) Stop(ctx context.Context, name string, options *v1.StopOptions) error {
+	return putSubresource(ctx, v.restClient, v.subresourceURL(name, "stop"), options)
+}
+
+// Restart restarts the VM, a grace period in the options forces the restart
+func (v *vm) Restart(ctx context.Context, name string, options *v1.RestartOptions) error {
+	return putSubresource(ctx, v.restClient, v.subresourceURL(name, "restart"), options)
+}
+
+func (v *vm) Migrate(ctx context.Context, name string) error {
+	return putSubresource(ctx, v.restClient, v.subresourceURL(name, "migrate"), nil)
+}
+
+func (v *vm) AddVolume(ctx context.Context, name string, options *v1.AddVolumeOptions) error {
+	return putSubresource(ctx, v.restClient, v.subresourceURL(name, "addvolume"), options)
+}
+
+func (v *vm) RemoveVolume(ctx context.Context, name string, options *v1.RemoveVolumeOptions) error {
+	return putSubresource(ctx, v.restClient, v.subresourceURL(name, "removevolume"), options)
+}
+
+func (v *vm) subresourceURL(name string, subresource string) string {
+	return fmt.Sprintf(vmSubresourceURL, v1.ApiStorageVersion, v.namespace, name, subresource)
+}
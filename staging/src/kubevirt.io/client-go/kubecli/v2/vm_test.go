@@ -0,0 +1,142 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	k8sv1 "k8s.io/api/core/v1"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	kubecliv1 "kubevirt.io/client-go/kubecli"
+)
+
+var _ = Describe("Kubevirt VirtualMachine Client with context", func() {
+
+	var server *ghttp.Server
+	var client KubevirtClient
+	basePath := "/apis/kubevirt.io/" + v1.ApiStorageVersion + "/namespaces/default/virtualmachines"
+	vmPath := basePath + "/testvm"
+	subVMPath := "/apis/subresources.kubevirt.io/" + v1.ApiStorageVersion + "/namespaces/default/virtualmachines/testvm"
+
+	BeforeEach(func() {
+		var err error
+		server = ghttp.NewServer()
+		client, err = NewFromFlags(server.URL(), "")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should fetch a VirtualMachine", func() {
+		vm := kubecliv1.NewMinimalVM("testvm")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", vmPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+		))
+		fetchedVM, err := client.VirtualMachine(k8sv1.NamespaceDefault).Get(context.Background(), "testvm", k8smetav1.GetOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedVM).To(Equal(vm))
+	})
+
+	It("should fetch a VirtualMachine list", func() {
+		vm := kubecliv1.NewMinimalVM("testvm")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", basePath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, kubecliv1.NewVMList(*vm)),
+		))
+		fetchedVMList, err := client.VirtualMachine(k8sv1.NamespaceDefault).List(context.Background(), k8smetav1.ListOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedVMList.Items).To(HaveLen(1))
+		Expect(fetchedVMList.Items[0]).To(Equal(*vm))
+	})
+
+	It("should update the status of a VirtualMachine", func() {
+		vm := kubecliv1.NewMinimalVM("testvm")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", vmPath+"/status"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+		))
+		updatedVM, err := client.VirtualMachine(k8sv1.NamespaceDefault).UpdateStatus(context.Background(), vm, k8smetav1.UpdateOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updatedVM).To(Equal(vm))
+	})
+
+	It("should start a VirtualMachine", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMPath+"/start"),
+			ghttp.VerifyJSONRepresenting(&v1.StartOptions{Paused: true}),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachine(k8sv1.NamespaceDefault).Start(context.Background(), "testvm", &v1.StartOptions{Paused: true})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should stop a VirtualMachine without options", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMPath+"/stop"),
+			ghttp.VerifyBody([]byte{}),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachine(k8sv1.NamespaceDefault).Stop(context.Background(), "testvm", nil)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should force the restart of a VirtualMachine", func() {
+		gracePeriod := int64(0)
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMPath+"/restart"),
+			ghttp.VerifyJSONRepresenting(&v1.RestartOptions{GracePeriodSeconds: &gracePeriod}),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachine(k8sv1.NamespaceDefault).Restart(context.Background(), "testvm", &v1.RestartOptions{GracePeriodSeconds: &gracePeriod})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should migrate a VirtualMachine", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMPath+"/migrate"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachine(k8sv1.NamespaceDefault).Migrate(context.Background(), "testvm")
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+})
@@ -0,0 +1,264 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	kubecliv1 "kubevirt.io/client-go/kubecli"
+)
+
+const vmiSubresourceURL = "/apis/subresources.kubevirt.io/%s/namespaces/%s/virtualmachineinstances/%s/%s"
+
+func (k *kubevirt) VirtualMachineInstance(namespace string) VirtualMachineInstanceInterface {
+	return &vmis{
+		restClient: k.v1.RestClient(),
+		v1:         k.v1.VirtualMachineInstance(namespace),
+		namespace:  namespace,
+		resource:   "virtualmachineinstances",
+	}
+}
+
+type vmis struct {
+	restClient *rest.RESTClient
+	// v1 opens the websocket streams, which are not handled by the rest client
+	v1        kubecliv1.VirtualMachineInstanceInterface
+	namespace string
+	resource  string
+}
+
+func (v *vmis) Get(ctx context.Context, name string, options k8smetav1.GetOptions) (*v1.VirtualMachineInstance, error) {
+	vmi := &v1.VirtualMachineInstance{}
+	err := v.restClient.Get().
+		Resource(v.resource).
+		Namespace(v.namespace).
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(vmi)
+	vmi.SetGroupVersionKind(v1.VirtualMachineInstanceGroupVersionKind)
+	return vmi, err
+}
+
+func (v *vmis) List(ctx context.Context, options k8smetav1.ListOptions) (*v1.VirtualMachineInstanceList, error) {
+	vmiList := &v1.VirtualMachineInstanceList{}
+	err := v.restClient.Get().
+		Resource(v.resource).
+		Namespace(v.namespace).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(vmiList)
+	for i := range vmiList.Items {
+		vmiList.Items[i].SetGroupVersionKind(v1.VirtualMachineInstanceGroupVersionKind)
+	}
+	return vmiList, err
+}
+
+func (v *vmis) Create(ctx context.Context, vmi *v1.VirtualMachineInstance, options k8smetav1.CreateOptions) (*v1.VirtualMachineInstance, error) {
+	result := &v1.VirtualMachineInstance{}
+	err := v.restClient.Post().
+		Resource(v.resource).
+		Namespace(v.namespace).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Body(vmi).
+		Do(ctx).
+		Into(result)
+	result.SetGroupVersionKind(v1.VirtualMachineInstanceGroupVersionKind)
+	return result, err
+}
+
+func (v *vmis) Update(ctx context.Context, vmi *v1.VirtualMachineInstance, options k8smetav1.UpdateOptions) (*v1.VirtualMachineInstance, error) {
+	result := &v1.VirtualMachineInstance{}
+	err := v.restClient.Put().
+		Resource(v.resource).
+		Namespace(v.namespace).
+		Name(vmi.Name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Body(vmi).
+		Do(ctx).
+		Into(result)
+	result.SetGroupVersionKind(v1.VirtualMachineInstanceGroupVersionKind)
+	return result, err
+}
+
+func (v *vmis) Delete(ctx context.Context, name string, options k8smetav1.DeleteOptions) error {
+	return v.restClient.Delete().
+		Resource(v.resource).
+		Namespace(v.namespace).
+		Name(name).
+		Body(&options).
+		Do(ctx).
+		Error()
+}
+
+func (v *vmis) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, options k8smetav1.PatchOptions, subresources ...string) (*v1.VirtualMachineInstance, error) {
+	result := &v1.VirtualMachineInstance{}
+	err := v.restClient.Patch(pt).
+		Resource(v.resource).
+		Namespace(v.namespace).
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	result.SetGroupVersionKind(v1.VirtualMachineInstanceGroupVersionKind)
+	return result, err
+}
+
+func (v *vmis) Watch(ctx context.Context, options k8smetav1.ListOptions) (watch.Interface, error) {
+	options.Watch = true
+	return v.restClient.Get().
+		Resource(v.resource).
+		Namespace(v.namespace).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Watch(ctx)
+}
+
+// SerialConsole returns as soon as ctx is done, a connection which is established afterwards is closed
+func (v *vmis) SerialConsole(ctx context.Context, name string, options *kubecliv1.SerialConsoleOptions) (kubecliv1.StreamInterface, error) {
+	return kubecliv1.SerialConsoleDialer(v.v1, name, options).Dial(ctx)
+}
+
+// VNC returns as soon as ctx is done, a connection which is established afterwards is closed
+func (v *vmis) VNC(ctx context.Context, name string) (kubecliv1.StreamInterface, error) {
+	return kubecliv1.VNCDialer(v.v1, name).Dial(ctx)
+}
+
+func (v *vmis) Pause(ctx context.Context, name string, options *v1.PauseOptions) error {
+	return putSubresource(ctx, v.restClient, v.subresourceURL(name, "pause"), options)
+}
+
+func (v *vmis) Unpause(ctx context.Context, name string, options *v1.UnpauseOptions) error {
+	return putSubresource(ctx, v.restClient, v.subresourceURL(name, "unpause"), options)
+}
+
+func (v *vmis) Freeze(ctx context.Context, name string) error {
+	return putSubresource(ctx, v.restClient, v.subresourceURL(name, "freeze"), nil)
+}
+
+func (v *vmis) Unfreeze(ctx context.Context, name string) error {
+	return putSubresource(ctx, v.restClient, v.subresourceURL(name, "unfreeze"), nil)
+}
+
+func (v *vmis) GuestOsInfo(ctx context.Context, name string) (v1.VirtualMachineInstanceGuestAgentInfo, error) {
+	guestInfo := v1.VirtualMachineInstanceGuestAgentInfo{}
+	// the guest agent info has no deepcopy functions and can not be decoded by the rest client,
+	// see GuestOsInfo of the v1 client
+	rawInfo, err := v.restClient.Get().RequestURI(v.subresourceURL(name, "guestosinfo")).Do(ctx).Raw()
+	if err != nil {
+		return guestInfo, err
+	}
+	err = json.Unmarshal(rawInfo, &guestInfo)
+	return guestInfo, err
+}
+
+func (v *vmis) UserList(ctx context.Context, name string) (v1.VirtualMachineInstanceGuestOSUserList, error) {
+	userList := v1.VirtualMachineInstanceGuestOSUserList{}
+	err := v.restClient.Get().RequestURI(v.subresourceURL(name, "userlist")).Do(ctx).Into(&userList)
+	return userList, err
+}
+
+func (v *vmis) FilesystemList(ctx context.Context, name string) (v1.VirtualMachineInstanceFileSystemList, error) {
+	fsList := v1.VirtualMachineInstanceFileSystemList{}
+	err := v.restClient.Get().RequestURI(v.subresourceURL(name, "filesystemlist")).Do(ctx).Into(&fsList)
+	return fsList, err
+}
+
+func (v *vmis) GuestExec(ctx context.Context, name string, options *v1.GuestExecOptions) (*v1.GuestExecResult, error) {
+	body, err := json.Marshal(options)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot Marshal to json: %s", err)
+	}
+	result := &v1.GuestExecResult{}
+	rawResult, err := v.restClient.Put().RequestURI(v.subresourceURL(name, "guestexec")).
+		SetHeader("Content-Type", "application/json").Body(body).Do(ctx).Raw()
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(rawResult, result)
+	return result, err
+}
+
+// GuestExecStream returns as soon as ctx is done, the command keeps running in the guest until
+// its timeout and its result is dropped
+func (v *vmis) GuestExecStream(ctx context.Context, name string, options *v1.GuestExecOptions) (*v1.GuestExecResult, error) {
+	type guestExecResult struct {
+		result *v1.GuestExecResult
+		err    error
+	}
+	resultChan := make(chan guestExecResult, 1)
+	go func() {
+		result, err := v.v1.GuestExecStream(name, options)
+		resultChan <- guestExecResult{result: result, err: err}
+	}()
+
+	select {
+	case r := <-resultChan:
+		return r.result, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// DomainLog returns the last lines of the QEMU log of the VMI, virt-handler picks the number of lines for lines <= 0
+func (v *vmis) DomainLog(ctx context.Context, name string, lines int) ([]byte, error) {
+	request := v.restClient.Get().RequestURI(v.subresourceURL(name, "domainlog"))
+	if lines > 0 {
+		request = request.Param("lines", strconv.Itoa(lines))
+	}
+	return request.Do(ctx).Raw()
+}
+
+func (v *vmis) AddVolume(ctx context.Context, name string, options *v1.AddVolumeOptions) error {
+	return putSubresource(ctx, v.restClient, v.subresourceURL(name, "addvolume"), options)
+}
+
+func (v *vmis) RemoveVolume(ctx context.Context, name string, options *v1.RemoveVolumeOptions) error {
+	return putSubresource(ctx, v.restClient, v.subresourceURL(name, "removevolume"), options)
+}
+
+func (v *vmis) subresourceURL(name string, subresource string) string {
+	return fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, subresource)
+}
+
+// putSubresource sends the options as JSON body, no body is sent if options is nil
+func putSubresource(ctx context.Context, restClient *rest.RESTClient, uri string, options interface{}) error {
+	request := restClient.Put().RequestURI(uri)
+	if options != nil && !reflect.ValueOf(options).IsNil() {
+		body, err := json.Marshal(options)
+		if err != nil {
+			return fmt.Errorf("Cannot Marshal to json: %s", err)
+		}
+		request = request.SetHeader("Content-Type", "application/json").Body(body)
+	}
+	return request.Do(ctx).Error()
+}
@@ -0,0 +1,224 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Kubevirt VirtualMachineInstance Client with context", func() {
+
+	var server *ghttp.Server
+	var client KubevirtClient
+	basePath := "/apis/kubevirt.io/" + v1.ApiStorageVersion + "/namespaces/default/virtualmachineinstances"
+	vmiPath := basePath + "/testvm"
+	subVMIPath := "/apis/subresources.kubevirt.io/" + v1.ApiStorageVersion + "/namespaces/default/virtualmachineinstances/testvm"
+
+	BeforeEach(func() {
+		var err error
+		server = ghttp.NewServer()
+		client, err = NewFromFlags(server.URL(), "")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should fetch a VirtualMachineInstance", func() {
+		vmi := v1.NewMinimalVMI("testvm")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", vmiPath, "resourceVersion=1"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+		))
+		fetchedVMI, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Get(context.Background(), "testvm", k8smetav1.GetOptions{ResourceVersion: "1"})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedVMI).To(Equal(vmi))
+	})
+
+	It("should detect non existent VMIs", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", vmiPath),
+			ghttp.RespondWithJSONEncoded(http.StatusNotFound, errors.NewNotFound(schema.GroupResource{}, "testvm")),
+		))
+		_, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Get(context.Background(), "testvm", k8smetav1.GetOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should fetch a VirtualMachineInstance list", func() {
+		vmi := v1.NewMinimalVMI("testvm")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", basePath, "labelSelector=app%3Dtest"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, &v1.VirtualMachineInstanceList{Items: []v1.VirtualMachineInstance{*vmi}}),
+		))
+		fetchedVMIList, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).List(context.Background(), k8smetav1.ListOptions{LabelSelector: "app=test"})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedVMIList.Items).To(HaveLen(1))
+		Expect(fetchedVMIList.Items[0]).To(Equal(*vmi))
+	})
+
+	It("should create a VirtualMachineInstance with a dry run", func() {
+		vmi := v1.NewMinimalVMI("testvm")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("POST", basePath, "dryRun=All"),
+			ghttp.RespondWithJSONEncoded(http.StatusCreated, vmi),
+		))
+		createdVMI, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Create(context.Background(), vmi, k8smetav1.CreateOptions{DryRun: []string{k8smetav1.DryRunAll}})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(createdVMI).To(Equal(vmi))
+	})
+
+	It("should update a VirtualMachineInstance", func() {
+		vmi := v1.NewMinimalVMI("testvm")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", vmiPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+		))
+		updatedVMI, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Update(context.Background(), vmi, k8smetav1.UpdateOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updatedVMI).To(Equal(vmi))
+	})
+
+	It("should patch a VirtualMachineInstance", func() {
+		vmi := v1.NewMinimalVMI("testvm")
+		patch := []byte(`{"metadata":{"labels":{"app":"test"}}}`)
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PATCH", vmiPath),
+			ghttp.VerifyBody(patch),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+		))
+		patchedVMI, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Patch(context.Background(), "testvm", types.MergePatchType, patch, k8smetav1.PatchOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(patchedVMI.Kind).To(Equal(v1.VirtualMachineInstanceGroupVersionKind.Kind))
+	})
+
+	It("should delete a VirtualMachineInstance", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("DELETE", vmiPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Delete(context.Background(), "testvm", k8smetav1.DeleteOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should pause a VirtualMachineInstance", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMIPath+"/pause"),
+			ghttp.VerifyJSONRepresenting(&v1.PauseOptions{DryRun: []string{k8smetav1.DryRunAll}}),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Pause(context.Background(), "testvm", &v1.PauseOptions{DryRun: []string{k8smetav1.DryRunAll}})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should freeze a VirtualMachineInstance without a body", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMIPath+"/freeze"),
+			ghttp.VerifyBody([]byte{}),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Freeze(context.Background(), "testvm")
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should fetch the guest OS info of a VirtualMachineInstance", func() {
+		guestInfo := v1.VirtualMachineInstanceGuestAgentInfo{Hostname: "testvm"}
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", subVMIPath+"/guestosinfo"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, guestInfo),
+		))
+		fetchedInfo, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).GuestOsInfo(context.Background(), "testvm")
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedInfo.Hostname).To(Equal("testvm"))
+	})
+
+	It("should run a command in the guest of a VirtualMachineInstance", func() {
+		options := &v1.GuestExecOptions{Command: "/usr/bin/uptime"}
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMIPath+"/guestexec"),
+			ghttp.VerifyJSONRepresenting(options),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, &v1.GuestExecResult{StdOut: "up 1 day"}),
+		))
+		result, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).GuestExec(context.Background(), "testvm", options)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.StdOut).To(Equal("up 1 day"))
+	})
+
+	It("should fetch the domain log of a VirtualMachineInstance", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", subVMIPath+"/domainlog", "lines=10"),
+			ghttp.RespondWith(http.StatusOK, "qemu-kvm: terminating on signal 15\n"),
+		))
+		domainLog, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).DomainLog(context.Background(), "testvm", 10)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(domainLog)).To(Equal("qemu-kvm: terminating on signal 15\n"))
+	})
+
+	It("should stop waiting for the response when the context is done", func() {
+		done := make(chan struct{})
+		server.AppendHandlers(func(w http.ResponseWriter, r *http.Request) {
+			<-done
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		_, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Get(ctx, "testvm", k8smetav1.GetOptions{})
+		close(done)
+
+		Expect(err).To(HaveOccurred())
+		Expect(ctx.Err()).To(Equal(context.DeadlineExceeded))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+})
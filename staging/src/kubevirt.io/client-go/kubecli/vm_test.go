@@ -187,6 +187,64 @@ var _ = Describe("Kubevirt VirtualMachine Client", func() {
 		Expect(err).ToNot(HaveOccurred())
 	})
 
+	It("should cancel the migration of a VirtualMachine", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMIPath+"/migratecancel"),
+			ghttp.RespondWithJSONEncoded(http.StatusAccepted, nil),
+		))
+		err := client.VirtualMachine(k8sv1.NamespaceDefault).MigrateCancel("testvm")
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should rename a VirtualMachine", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMIPath+"/rename"),
+			ghttp.VerifyBody([]byte(`{"newName":"newvm"}`)),
+			ghttp.RespondWithJSONEncoded(http.StatusAccepted, nil),
+		))
+		err := client.VirtualMachine(k8sv1.NamespaceDefault).Rename("testvm", &virtv1.RenameOptions{NewName: "newvm"})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should move a VirtualMachine", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMIPath+"/move"),
+			ghttp.VerifyBody([]byte(`{"newNamespace":"tenant-b"}`)),
+			ghttp.RespondWithJSONEncoded(http.StatusAccepted, nil),
+		))
+		err := client.VirtualMachine(k8sv1.NamespaceDefault).Move("testvm", &virtv1.MoveOptions{NewNamespace: "tenant-b"})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should request a memory dump of a VirtualMachine", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMIPath+"/memorydump"),
+			ghttp.VerifyBody([]byte(`{"claimName":"dumppvc"}`)),
+			ghttp.RespondWithJSONEncoded(http.StatusAccepted, nil),
+		))
+		err := client.VirtualMachine(k8sv1.NamespaceDefault).MemoryDump("testvm", &virtv1.VirtualMachineMemoryDumpRequest{ClaimName: "dumppvc"})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should remove the memory dump of a VirtualMachine", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMIPath+"/removememorydump"),
+			ghttp.RespondWithJSONEncoded(http.StatusAccepted, nil),
+		))
+		err := client.VirtualMachine(k8sv1.NamespaceDefault).RemoveMemoryDump("testvm")
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
 	AfterEach(func() {
 		server.Close()
 	})
@@ -24,6 +24,7 @@ import (
 
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -232,6 +233,27 @@ func (v *vmis) SerialConsole(name string, options *SerialConsoleOptions) (Stream
 	}
 }
 
+func (v *vmis) ConsoleLog(name string) ([]byte, error) {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "console/log")
+	return v.restClient.Get().RequestURI(uri).Do(context.Background()).Raw()
+}
+
+// DomainLog returns the last lines of the QEMU log of the VMI, virt-handler picks the number of lines for lines <= 0
+func (v *vmis) DomainLog(name string, lines int) ([]byte, error) {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "domainlog")
+	request := v.restClient.Get().RequestURI(uri)
+	if lines > 0 {
+		request = request.Param("lines", strconv.Itoa(lines))
+	}
+	return request.Do(context.Background()).Raw()
+}
+
+// PreviewDomainXML returns the libvirt domain XML which virt-launcher would define for the VMI
+func (v *vmis) PreviewDomainXML(vmi *v1.VirtualMachineInstance) ([]byte, error) {
+	uri := fmt.Sprintf("/apis/subresources.kubevirt.io/%s/namespaces/%s/preview-domain-xml", v1.ApiStorageVersion, v.namespace)
+	return v.restClient.Put().RequestURI(uri).Body(vmi).Do(context.Background()).Raw()
+}
+
 func (v *vmis) Freeze(name string) error {
 	log.Log.Infof("Freeze VMI")
 	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "freeze")
@@ -244,14 +266,22 @@ func (v *vmis) Unfreeze(name string) error {
 	return v.restClient.Put().RequestURI(uri).Do(context.Background()).Error()
 }
 
-func (v *vmis) Pause(name string) error {
+func (v *vmis) Pause(name string, pauseOptions *v1.PauseOptions) error {
+	body, err := json.Marshal(pauseOptions)
+	if err != nil {
+		return fmt.Errorf("Cannot Marshal to json: %s", err)
+	}
 	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "pause")
-	return v.restClient.Put().RequestURI(uri).Do(context.Background()).Error()
+	return v.restClient.Put().RequestURI(uri).Body(body).Do(context.Background()).Error()
 }
 
-func (v *vmis) Unpause(name string) error {
+func (v *vmis) Unpause(name string, unpauseOptions *v1.UnpauseOptions) error {
+	body, err := json.Marshal(unpauseOptions)
+	if err != nil {
+		return fmt.Errorf("Cannot Marshal to json: %s", err)
+	}
 	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "unpause")
-	return v.restClient.Put().RequestURI(uri).Do(context.Background()).Error()
+	return v.restClient.Put().RequestURI(uri).Body(body).Do(context.Background()).Error()
 }
 
 func (v *vmis) Get(name string, options *k8smetav1.GetOptions) (vmi *v1.VirtualMachineInstance, err error) {
@@ -419,6 +449,120 @@ func (v *vmis) FilesystemList(name string) (v1.VirtualMachineInstanceFileSystemL
 	return fsList, err
 }
 
+type MemoryDumpOptions struct {
+	// Offset resumes the download of the previously captured memory dump at the given byte
+	Offset int64
+	// ETag of the memory dump the offset refers to. Without it, or if the dump
+	// changed in the meantime, a new dump is captured and streamed completely
+	ETag string
+}
+
+type MemoryDump struct {
+	io.ReadCloser
+	ETag string
+	// Partial is true if the stream starts at the requested offset
+	Partial bool
+}
+
+// MemoryDump captures a memory dump of the guest and streams it, the caller has to close the stream
+func (v *vmis) MemoryDump(name string, options *MemoryDumpOptions) (*MemoryDump, error) {
+	if options == nil {
+		options = &MemoryDumpOptions{}
+	}
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "memorydump")
+	req, err := http.NewRequest(http.MethodPut, v.restClient.Put().RequestURI(uri).URL().String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if options.Offset > 0 && options.ETag != "" {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", options.Offset))
+		req.Header.Set("If-Range", options.ETag)
+	}
+
+	httpClient := v.restClient.Client
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("can't fetch the memory dump, status: %s, message: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return &MemoryDump{
+		ReadCloser: resp.Body,
+		ETag:       resp.Header.Get("ETag"),
+		Partial:    resp.StatusCode == http.StatusPartialContent,
+	}, nil
+}
+
+func (v *vmis) GuestExec(name string, guestExecOptions *v1.GuestExecOptions) (*v1.GuestExecResult, error) {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "guestexec")
+
+	JSON, err := json.Marshal(guestExecOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	rawResult, err := v.restClient.Put().RequestURI(uri).Body([]byte(JSON)).Do(context.Background()).Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &v1.GuestExecResult{}
+	if err := json.Unmarshal(rawResult, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GuestExecStream runs the command over a websocket, which is not subject to the request timeout of
+// the kube-apiserver, so that it may run for up to ten minutes
+func (v *vmis) GuestExecStream(name string, guestExecOptions *v1.GuestExecOptions) (*v1.GuestExecResult, error) {
+	queryParams := url.Values{
+		"command": []string{guestExecOptions.Command},
+		"arg":     guestExecOptions.Args,
+	}
+	if guestExecOptions.TimeoutSeconds != nil {
+		queryParams.Set("timeoutSeconds", strconv.Itoa(int(*guestExecOptions.TimeoutSeconds)))
+	}
+
+	stream, err := asyncSubresourceHelperWithQuery(v.config, v.resource, v.namespace, name, "guestexec", queryParams)
+	if err != nil {
+		return nil, err
+	}
+	conn := stream.AsConn()
+	defer conn.Close()
+
+	// virt-api writes the result once the command exited and closes the connection
+	rawResult, err := ioutil.ReadAll(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &v1.GuestExecResult{}
+	if err := json.Unmarshal(rawResult, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (v *vmis) MediaChange(name string, mediaChangeOptions *v1.MediaChangeOptions) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "mediachange")
+
+	JSON, err := json.Marshal(mediaChangeOptions)
+
+	if err != nil {
+		return err
+	}
+
+	return v.restClient.Put().RequestURI(uri).Body([]byte(JSON)).Do(context.Background()).Error()
+}
+
 func (v *vmis) AddVolume(name string, addVolumeOptions *v1.AddVolumeOptions) error {
 	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "addvolume")
 
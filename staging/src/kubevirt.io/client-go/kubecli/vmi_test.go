@@ -20,8 +20,10 @@
 package kubecli
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 
 	"github.com/gorilla/websocket"
@@ -224,7 +226,7 @@ var _ = Describe("Kubevirt VirtualMachineInstance Client", func() {
 			ghttp.VerifyRequest("PUT", subVMPath+"/pause"),
 			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
 		))
-		err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Pause("testvm")
+		err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Pause("testvm", &v1.PauseOptions{})
 
 		Expect(server.ReceivedRequests()).To(HaveLen(1))
 		Expect(err).ToNot(HaveOccurred())
@@ -235,7 +237,22 @@ var _ = Describe("Kubevirt VirtualMachineInstance Client", func() {
 			ghttp.VerifyRequest("PUT", subVMPath+"/unpause"),
 			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
 		))
-		err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Unpause("testvm")
+		err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Unpause("testvm", &v1.UnpauseOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should send the dry run option when pausing a VirtualMachineInstance", func() {
+		options := &v1.PauseOptions{DryRun: []string{k8smetav1.DryRunAll}}
+		body, err := json.Marshal(options)
+		Expect(err).ToNot(HaveOccurred())
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMPath+"/pause"),
+			ghttp.VerifyBody(body),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err = client.VirtualMachineInstance(k8sv1.NamespaceDefault).Pause("testvm", options)
 
 		Expect(server.ReceivedRequests()).To(HaveLen(1))
 		Expect(err).ToNot(HaveOccurred())
@@ -278,6 +295,40 @@ var _ = Describe("Kubevirt VirtualMachineInstance Client", func() {
 		Expect(fetchedInfo).To(Equal(osInfo), "fetched info should be the same as passed in")
 	})
 
+	It("should fetch the console log from VirtualMachineInstance via subresource", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", subVMPath+"/console/log"),
+			ghttp.RespondWith(http.StatusOK, "login: "),
+		))
+		consoleLog, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).ConsoleLog("testvm")
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(consoleLog)).To(Equal("login: "))
+	})
+
+	It("should fetch the domain log from VirtualMachineInstance via subresource", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", subVMPath+"/domainlog", "lines=10"),
+			ghttp.RespondWith(http.StatusOK, "qemu-kvm: terminating on signal 15\n"),
+		))
+		domainLog, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).DomainLog("testvm", 10)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(domainLog)).To(Equal("qemu-kvm: terminating on signal 15\n"))
+	})
+
+	It("should preview the domain XML of a VirtualMachineInstance", func() {
+		vmi := v1.NewMinimalVMI("testvm")
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", "/apis/subresources.kubevirt.io/"+v1.ApiStorageVersion+"/namespaces/default/preview-domain-xml"),
+			ghttp.RespondWith(http.StatusOK, "<domain type=\"kvm\"></domain>"),
+		))
+		domainXML, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).PreviewDomainXML(vmi)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(domainXML)).To(Equal("<domain type=\"kvm\"></domain>"))
+	})
+
 	It("should fetch UserList from VirtualMachineInstance via subresource", func() {
 		userList := v1.VirtualMachineInstanceGuestOSUserList{
 			Items: []v1.VirtualMachineInstanceGuestOSUser{
@@ -314,6 +365,69 @@ var _ = Describe("Kubevirt VirtualMachineInstance Client", func() {
 		Expect(fetchedInfo).To(Equal(fileSystemList), "fetched info should be the same as passed in")
 	})
 
+	It("should change the media of a CD-ROM via subresource", func() {
+		mediaChangeOptions := &v1.MediaChangeOptions{Name: "cdrom"}
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMPath+"/mediachange"),
+			ghttp.VerifyBody([]byte(`{"name":"cdrom"}`)),
+			ghttp.RespondWith(http.StatusAccepted, nil),
+		))
+		err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).MediaChange("testvm", mediaChangeOptions)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should run a command in the guest via subresource", func() {
+		guestExecOptions := &v1.GuestExecOptions{Command: "/usr/bin/uptime"}
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMPath+"/guestexec"),
+			ghttp.VerifyBody([]byte(`{"command":"/usr/bin/uptime"}`)),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, v1.GuestExecResult{ExitCode: 0, StdOut: "up 3 days"}),
+		))
+		result, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).GuestExec("testvm", guestExecOptions)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.ExitCode).To(BeEquivalentTo(0))
+		Expect(result.StdOut).To(Equal("up 3 days"))
+	})
+
+	It("should stream the memory dump from VirtualMachineInstance via subresource", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMPath+"/memorydump"),
+			ghttp.RespondWith(http.StatusOK, "dump"),
+		))
+		dump, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).MemoryDump("testvm", nil)
+		Expect(err).ToNot(HaveOccurred())
+		defer dump.Close()
+
+		content, err := ioutil.ReadAll(dump)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("dump"))
+		Expect(dump.Partial).To(BeFalse())
+	})
+
+	It("should resume streaming the memory dump at the given offset", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMPath+"/memorydump"),
+			ghttp.VerifyHeaderKV("Range", "bytes=2-"),
+			ghttp.VerifyHeaderKV("If-Range", `"abc"`),
+			ghttp.RespondWith(http.StatusPartialContent, "mp", http.Header{"ETag": []string{`"abc"`}}),
+		))
+		dump, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).MemoryDump("testvm", &MemoryDumpOptions{Offset: 2, ETag: `"abc"`})
+		Expect(err).ToNot(HaveOccurred())
+		defer dump.Close()
+		Expect(dump.ETag).To(Equal(`"abc"`))
+		Expect(dump.Partial).To(BeTrue())
+	})
+
+	It("should fail to stream the memory dump if the VMI is not running", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMPath+"/memorydump"),
+			ghttp.RespondWith(http.StatusConflict, "VMI is not running"),
+		))
+		_, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).MemoryDump("testvm", nil)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("VMI is not running"))
+	})
+
 	AfterEach(func() {
 		server.Close()
 	})
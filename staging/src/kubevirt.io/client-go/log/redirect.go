@@ -0,0 +1,200 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package log
+
+import (
+	"fmt"
+	"io"
+	golog "log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/golang/glog"
+	"k8s.io/klog/v2"
+)
+
+// klogPackage prefixes the names of the functions of klog
+const klogPackage = "k8s.io/klog/v2."
+
+var (
+	// stdLogHeader matches the header which the standard logger writes with Lshortfile, like "application.go:123: "
+	stdLogHeader = regexp.MustCompile(`^([^\s:]+:\d+): `)
+
+	// klogFunctionLevels maps the prefixes of the exported functions of klog to their level
+	klogFunctionLevels = []struct {
+		prefix string
+		level  LogLevel
+	}{
+		{"Info", INFO},
+		{"Warning", WARNING},
+		{"Error", ERROR},
+		{"Fatal", FATAL},
+		{"Exit", FATAL},
+	}
+
+	klogSeverityChars = map[LogLevel]byte{
+		INFO:    'I',
+		WARNING: 'W',
+		ERROR:   'E',
+		FATAL:   'F',
+	}
+
+	// libraryJSON is 1 while the lines of klog and of the standard logger are written as JSON
+	libraryJSON int32
+
+	// klogTextOutput receives the lines of klog in its own format
+	klogTextOutput io.Writer = os.Stderr
+)
+
+// InitializeLibraryLogging initializes the logging like InitializeLogging. In addition it takes over the
+// lines of klog, which is used by client-go, so that SetLibraryJSONFormat can switch them to JSON
+// at any time. The lines are written in the format of klog until then.
+func InitializeLibraryLogging(comp string) {
+	InitializeLogging(comp)
+	// klog reads its logger without a lock, it is only set once before the components start
+	klog.SetLogger(libraryLogger{logger: Log})
+}
+
+// SetLibraryJSONFormat switches the lines of klog and of the standard logger between their own
+// format and the JSON of the logger of the component, which log aggregation pipelines can parse.
+func SetLibraryJSONFormat(enabled bool) {
+	if enabled {
+		atomic.StoreInt32(&libraryJSON, 1)
+		golog.SetFlags(golog.Lshortfile)
+		golog.SetOutput(libraryLogger{logger: Log})
+	} else {
+		atomic.StoreInt32(&libraryJSON, 0)
+		glog.CopyStandardLogTo(LogLevelNames[INFO])
+	}
+}
+
+// libraryLogger forwards the lines of other loggers to a FilteredLogger
+type libraryLogger struct {
+	logger *FilteredLogger
+}
+
+// Write implements io.Writer for the standard logger, which writes one line per call
+func (l libraryLogger) Write(p []byte) (int, error) {
+	line := strings.TrimSuffix(string(p), "\n")
+	pos := ""
+	if match := stdLogHeader.FindStringSubmatch(line); match != nil {
+		pos = match[1]
+		line = line[len(match[0]):]
+	}
+	l.forward(INFO, pos, line)
+	return len(p), nil
+}
+
+// Info implements logr.Logger for klog. klog clears its header before it calls the logger and
+// passes warnings and fatal lines to Info too, so the level and the position are taken from the
+// stack of the klog call.
+func (l libraryLogger) Info(msg string, keysAndValues ...interface{}) {
+	level, pos := klogCaller(INFO)
+	l.forwardKlog(level, pos, msg, keysAndValues)
+}
+
+// Error implements logr.Logger for klog, which passes the error lines and the lines of ErrorS
+func (l libraryLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	_, pos := klogCaller(ERROR)
+	if err != nil {
+		keysAndValues = append([]interface{}{"reason", err}, keysAndValues...)
+	}
+	l.forwardKlog(ERROR, pos, msg, keysAndValues)
+}
+
+func (l libraryLogger) Enabled() bool {
+	return true
+}
+
+// V returns the same logger, klog filters by its own verbosity before it calls the logger
+func (l libraryLogger) V(_ int) logr.Logger {
+	return l
+}
+
+func (l libraryLogger) WithValues(_ ...interface{}) logr.Logger {
+	return l
+}
+
+func (l libraryLogger) WithName(_ string) logr.Logger {
+	return l
+}
+
+func (l libraryLogger) forwardKlog(level LogLevel, pos string, msg string, keysAndValues []interface{}) {
+	msg = strings.TrimSuffix(msg, "\n")
+	if atomic.LoadInt32(&libraryJSON) == 0 {
+		writeKlogText(level, pos, msg, keysAndValues)
+		return
+	}
+	l.forward(level, pos, msg, keysAndValues...)
+}
+
+func (l libraryLogger) forward(level LogLevel, pos string, msg string, keysAndValues ...interface{}) {
+	params := []interface{}{
+		"level", LogLevelNames[level],
+		"timestamp", time.Now().UTC().Format("2006-01-02T15:04:05.000000Z"),
+		"pos", pos,
+		"component", l.logger.component,
+		"msg", msg,
+	}
+	l.logger.logger.Log(append(params, keysAndValues...)...)
+}
+
+// klogCaller walks the stack up to the first caller outside of klog. The outermost exported
+// function of klog on the way, like Warningf or Verbose.Infof, tells the level.
+func klogCaller(defaultLevel LogLevel) (LogLevel, string) {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	level, inKlog := defaultLevel, false
+	for {
+		frame, more := frames.Next()
+		if strings.HasPrefix(frame.Function, klogPackage) {
+			inKlog = true
+			name := frame.Function[strings.LastIndex(frame.Function, ".")+1:]
+			for _, f := range klogFunctionLevels {
+				if strings.HasPrefix(name, f.prefix) {
+					level = f.level
+				}
+			}
+		} else if inKlog {
+			return level, fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)
+		}
+		if !more {
+			return level, ""
+		}
+	}
+}
+
+// writeKlogText writes a line with the header which klog writes without a logger
+func writeKlogText(level LogLevel, pos string, msg string, keysAndValues []interface{}) {
+	now := time.Now()
+	line := fmt.Sprintf("%c%02d%02d %02d:%02d:%02d.%06d %7d %s] %s",
+		klogSeverityChars[level], now.Month(), now.Day(), now.Hour(), now.Minute(), now.Second(),
+		now.Nanosecond()/1000, os.Getpid(), pos, msg)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		line += fmt.Sprintf(" %v=%q", keysAndValues[i], fmt.Sprint(keysAndValues[i+1]))
+	}
+	fmt.Fprintln(klogTextOutput, line)
+}
@@ -0,0 +1,123 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package log
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"k8s.io/klog/v2"
+)
+
+// loggedParams returns the key value pairs of the last line of the MockLogger as map
+func loggedParams(t *testing.T) map[string]interface{} {
+	assert(t, logCalled, "nothing was logged")
+	params := logParams[len(logParams)-1].([]interface{})
+	loggedParams := map[string]interface{}{}
+	for i := 0; i+1 < len(params); i += 2 {
+		loggedParams[params[i].(string)] = params[i+1]
+	}
+	return loggedParams
+}
+
+func TestStandardLoggerLine(t *testing.T) {
+	setUp()
+	logger := libraryLogger{logger: MakeLogger(MockLogger{})}
+	logger.logger.component = "virt-controller"
+
+	_, err := logger.Write([]byte("application.go:453: leaderelection lost\n"))
+	assert(t, err == nil, "writing failed")
+
+	params := loggedParams(t)
+	assert(t, params["level"] == "info", "line should be logged as info")
+	assert(t, params["pos"] == "application.go:453", "pos should be taken from the line")
+	assert(t, params["component"] == "virt-controller", "component should be set")
+	assert(t, params["msg"] == "leaderelection lost", "header should be removed from the message")
+	tearDown()
+}
+
+// withKlogLogger sends the lines of the real klog to a libraryLogger with a MockLogger
+func withKlogLogger(jsonFormat bool, test func()) {
+	setUp()
+	klog.SetLogger(libraryLogger{logger: MakeLogger(MockLogger{})})
+	if jsonFormat {
+		atomic.StoreInt32(&libraryJSON, 1)
+	}
+	defer func() {
+		atomic.StoreInt32(&libraryJSON, 0)
+		klog.SetLogger(nil)
+		tearDown()
+	}()
+	test()
+}
+
+func TestKlogWarningLine(t *testing.T) {
+	withKlogLogger(true, func() {
+		_, _, line, _ := runtime.Caller(0)
+		klog.Warningf("watch of %s ended", "*v1.Pod")
+
+		params := loggedParams(t)
+		assert(t, params["level"] == "warning", "level should be taken from the klog call")
+		assert(t, params["pos"] == fmt.Sprintf("redirect_test.go:%d", line+1), "pos should be the klog call")
+		assert(t, params["msg"] == "watch of *v1.Pod ended", "message should be kept")
+	})
+}
+
+func TestKlogVerboseInfoLine(t *testing.T) {
+	withKlogLogger(true, func() {
+		klog.V(0).Info("caches populated")
+
+		params := loggedParams(t)
+		assert(t, params["level"] == "info", "level should be taken from the klog call")
+		assert(t, params["msg"] == "caches populated", "message should be kept")
+	})
+}
+
+func TestKlogErrorSLine(t *testing.T) {
+	withKlogLogger(true, func() {
+		klog.ErrorS(fmt.Errorf("connection refused"), "error retrieving resource lock", "lock", "kubevirt/virt-controller")
+
+		params := loggedParams(t)
+		assert(t, params["level"] == "error", "level should be error")
+		assert(t, params["msg"] == "error retrieving resource lock", "message should be kept")
+		assert(t, fmt.Sprint(params["reason"]) == "connection refused", "error should be logged as reason")
+		assert(t, params["lock"] == "kubevirt/virt-controller", "key value pairs should be logged")
+	})
+}
+
+func TestKlogTextLine(t *testing.T) {
+	withKlogLogger(false, func() {
+		output := &bytes.Buffer{}
+		klogTextOutput = output
+		defer func() { klogTextOutput = os.Stderr }()
+
+		_, _, line, _ := runtime.Caller(0)
+		klog.Errorf("error retrieving resource lock")
+
+		assert(t, !logCalled, "line should not be logged as JSON")
+		assert(t, strings.HasPrefix(output.String(), "E"), "severity should be written like klog")
+		assert(t, strings.HasSuffix(output.String(), fmt.Sprintf(" redirect_test.go:%d] error retrieving resource lock\n", line+1)), "position and message should be written like klog")
+	})
+}
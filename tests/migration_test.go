@@ -749,7 +749,7 @@ var _ = Describe("[Serial][rfe_id:393][crit:high][vendor:cnv-qe@redhat.com][leve
 				Expect(libnet.WithIPv6(console.LoginToCirros)(vmi)).To(Succeed())
 
 				By("Pausing the VirtualMachineInstance")
-				virtClient.VirtualMachineInstance(vmi.Namespace).Pause(vmi.Name)
+				virtClient.VirtualMachineInstance(vmi.Namespace).Pause(vmi.Name, &v1.PauseOptions{})
 				tests.WaitForVMICondition(virtClient, vmi, v1.VirtualMachineInstancePaused, 30)
 
 				By("verifying that the vmi is still paused before migration")
@@ -912,7 +912,7 @@ var _ = Describe("[Serial][rfe_id:393][crit:high][vendor:cnv-qe@redhat.com][leve
 				By("Starting a Migration")
 				migration, err = virtClient.VirtualMachineInstanceMigration(migration.Namespace).Create(migration)
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("DisksNotLiveMigratable"))
+				Expect(err.Error()).To(ContainSubstring("PVCNotLiveMigratable"))
 
 				// delete VMI
 				By("Deleting the VMI")
@@ -1775,7 +1775,7 @@ var _ = Describe("[Serial][rfe_id:393][crit:high][vendor:cnv-qe@redhat.com][leve
 				By("Starting a Migration")
 				_, err = virtClient.VirtualMachineInstanceMigration(migration.Namespace).Create(migration)
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("DisksNotLiveMigratable"))
+				Expect(err.Error()).To(ContainSubstring("PVCNotLiveMigratable"))
 
 				// delete VMI
 				By("Deleting the VMI")
@@ -191,7 +191,7 @@ var _ = SIGDescribe("Storage", func() {
 
 					for _, condition := range vmi.Status.Conditions {
 						if condition.Type == v1.VirtualMachineInstancePaused {
-							return condition.Status == k8sv1.ConditionTrue && condition.Reason == "PausedIOError"
+							return condition.Status == k8sv1.ConditionTrue && condition.Reason == v1.PausedIOErrorReason
 						}
 					}
 					return false
@@ -264,7 +264,7 @@ var _ = SIGDescribe("Storage", func() {
 
 						for _, condition := range vmi.Status.Conditions {
 							if condition.Type == v1.VirtualMachineInstancePaused {
-								return condition.Status == k8sv1.ConditionTrue && condition.Reason == "PausedIOError"
+								return condition.Status == k8sv1.ConditionTrue && condition.Reason == v1.PausedIOErrorReason
 							}
 						}
 						return false
@@ -2216,7 +2216,7 @@ var _ = Describe("[sig-compute]Configurations", func() {
 				return false
 			}, 120*time.Second, time.Second).Should(BeTrue())
 			Expect(vmiCondition.Message).To(ContainSubstring("Invalid PCI address " + wrongPciAddress))
-			Expect(vmiCondition.Reason).To(Equal("Synchronizing with the Domain failed."))
+			Expect(vmiCondition.Reason).To(Equal(v1.SynchronizationFailedReason))
 		})
 	})
 	Describe("[rfe_id:897][crit:medium][vendor:cnv-qe@redhat.com][level:component]VirtualMachineInstance with CPU pinning", func() {
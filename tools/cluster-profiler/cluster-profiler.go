@@ -70,15 +70,34 @@ func writeResultsToDisk(dir string, results *v1.ClusterProfilerResults) error {
 	return nil
 }
 
+func writeArchiveToDisk(virtClient kubecli.KubevirtClient, file string) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	err = virtClient.ClusterProfiler().DumpArchive(f)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("SUCCESS: Dumped PProf results for KubeVirt control plane to [%s]\n", file)
+
+	return nil
+}
+
 func main() {
 
 	var cmd string
 	var outputDir string
+	var outputArchive string
 
 	clientConfig := kubecli.DefaultClientConfig(flag.CommandLine)
 
 	flag.StringVar(&cmd, "cmd", "", "The profiler command, start|stop|dump")
 	flag.StringVar(&outputDir, "output-dir", defaultOutputDir, "The directory to store the profiler results in.")
+	flag.StringVar(&outputArchive, "output-archive", "", "The file to store the profiler results in as gzipped tar archive, instead of the output directory.")
 	flag.Parse()
 
 	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(clientConfig)
@@ -100,6 +119,14 @@ func main() {
 		}
 		log.Print("SUCCESS: stopped cpu profiling KubeVirt control plane")
 	case PROFILER_DUMP:
+		if outputArchive != "" {
+			err := writeArchiveToDisk(virtClient, outputArchive)
+			if err != nil {
+				log.Fatalf("Error cluster profiler %s: %v", cmd, err)
+			}
+			break
+		}
+
 		results, err := virtClient.ClusterProfiler().Dump()
 		if err != nil {
 			log.Fatalf("Error cluster profiler %s: %v", cmd, err)
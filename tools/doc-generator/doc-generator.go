@@ -10,7 +10,10 @@ import (
 	"sort"
 	"strings"
 
+	"k8s.io/client-go/util/workqueue"
+
 	domainstats "kubevirt.io/kubevirt/pkg/monitoring/domainstats/prometheus" // import for prometheus metrics
+	_ "kubevirt.io/kubevirt/pkg/monitoring/workqueue/prometheus"
 	_ "kubevirt.io/kubevirt/pkg/virt-controller/watch"
 )
 
@@ -50,12 +53,60 @@ const (
 
 	vmiEvictionBlockerName = "kubevirt_vmi_non_evictable"
 	vmiEvictionBlockerDesc = "Indication for a VirtualMachine that its eviction strategy is set to Live Migration but is not migratable."
+
+	vmiInfoName = "kubevirt_vmi_info"
+	vmiInfoDesc = "Information about a VirtualMachineInstance, the value is always 1.\n\n" +
+		"The labels are the node, phase, os, workload and flavor, the guest OS reported by the guest agent, the eviction strategy and if the launcher image is outdated."
+
+	vmInfoName = "kubevirt_vm_info"
+	vmInfoDesc = "Information about a VirtualMachine, the value is always 1.\n\n" +
+		"The labels are the printable status, the run strategy, the os, workload and flavor of the template and the eviction strategy."
+
+	vmTimeToReadyName = "kubevirt_vm_time_to_ready_seconds"
+	vmTimeToReadyDesc = "Time from the start request of the VirtualMachine to the guest becoming ready, for its latest start."
+
+	vmUnplannedDowntimeName = "kubevirt_vm_unplanned_downtime_seconds_total"
+	vmUnplannedDowntimeDesc = "Cumulative time the VirtualMachine was down after its VirtualMachineInstance failed, until it was ready again or stopped."
+
+	migrationDataProcessedName = "kubevirt_migrate_vmi_data_processed_bytes"
+	migrationDataProcessedDesc = "The amount of data transferred to the target of a running VirtualMachineInstance migration in bytes."
+
+	migrationMemoryRemainingName = "kubevirt_migrate_vmi_memory_remaining_bytes"
+	migrationMemoryRemainingDesc = "The amount of guest memory which still has to be transferred by a running VirtualMachineInstance migration in bytes."
+
+	migrationDirtyPageRateName = "kubevirt_migrate_vmi_dirty_page_rate"
+	migrationDirtyPageRateDesc = "The rate at which the guest of a migrating VirtualMachineInstance dirties its memory in pages per second."
+
+	migrationIterationName = "kubevirt_migrate_vmi_memory_iterations"
+	migrationIterationDesc = "The number of passes over the guest memory of a running VirtualMachineInstance migration."
+
+	migrationPhaseCountName = "kubevirt_migrate_vmi_phase_count"
+	migrationPhaseCountDesc = "Number of VirtualMachineInstance migrations which are not finished yet, per phase."
+
+	migrationAgeName = "kubevirt_migrate_vmi_age_seconds"
+	migrationAgeDesc = "Time since an unfinished VirtualMachineInstance migration was created in seconds."
+
+	migrationDurationName = "kubevirt_migrate_vmi_duration_seconds"
+	migrationDurationDesc = "Time from the creation of a VirtualMachineInstance migration until it finished in seconds."
+
+	migrationFailedName = "kubevirt_migrate_vmi_failed_total"
+	migrationFailedDesc = "Number of failed VirtualMachineInstance migrations, per reason."
+
+	migrationSucceededName = "kubevirt_migrate_vmi_succeeded_total"
+	migrationSucceededDesc = "Number of succeeded VirtualMachineInstance migrations."
+
+	migrationDataTransferredName = "kubevirt_migrate_vmi_data_transferred_bytes_total"
+	migrationDataTransferredDesc = "Amount of data transferred by succeeded VirtualMachineInstance migrations in bytes."
 )
 
 func main() {
 	handler := domainstats.Handler(1)
 	RegisterFakeCollector()
 
+	// the workqueue metrics are only registered once a named queue is created
+	queue := workqueue.NewNamed("doc-generator")
+	defer queue.ShutDown()
+
 	req, err := http.NewRequest(http.MethodGet, "/metrics", nil)
 	checkError(err)
 
@@ -137,6 +188,62 @@ var (
 			name:        vmiEvictionBlockerName,
 			description: vmiEvictionBlockerDesc,
 		},
+		{
+			name:        vmiInfoName,
+			description: vmiInfoDesc,
+		},
+		{
+			name:        vmInfoName,
+			description: vmInfoDesc,
+		},
+		{
+			name:        vmTimeToReadyName,
+			description: vmTimeToReadyDesc,
+		},
+		{
+			name:        vmUnplannedDowntimeName,
+			description: vmUnplannedDowntimeDesc,
+		},
+		{
+			name:        migrationDataProcessedName,
+			description: migrationDataProcessedDesc,
+		},
+		{
+			name:        migrationMemoryRemainingName,
+			description: migrationMemoryRemainingDesc,
+		},
+		{
+			name:        migrationDirtyPageRateName,
+			description: migrationDirtyPageRateDesc,
+		},
+		{
+			name:        migrationIterationName,
+			description: migrationIterationDesc,
+		},
+		{
+			name:        migrationPhaseCountName,
+			description: migrationPhaseCountDesc,
+		},
+		{
+			name:        migrationAgeName,
+			description: migrationAgeDesc,
+		},
+		{
+			name:        migrationDurationName,
+			description: migrationDurationDesc,
+		},
+		{
+			name:        migrationFailedName,
+			description: migrationFailedDesc,
+		},
+		{
+			name:        migrationSucceededName,
+			description: migrationSucceededDesc,
+		},
+		{
+			name:        migrationDataTransferredName,
+			description: migrationDataTransferredDesc,
+		},
 	}
 )
 
@@ -2,6 +2,8 @@ package main
 
 import (
 	"github.com/prometheus/client_golang/prometheus"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"libvirt.org/go/libvirt"
 
 	domainstats "kubevirt.io/kubevirt/pkg/monitoring/domainstats/prometheus"
@@ -48,8 +50,20 @@ func (fc fakeCollector) Collect(ch chan<- prometheus.Metric) {
 	out.Memory.MinorFaultSet = true
 	out.Memory.MajorFaultSet = true
 	out.CPUMapSet = true
+	for i := range out.Block {
+		out.Block[i].ErrorsSet = true
+	}
 
+	guestMemory := resource.MustParse("1Gi")
 	vmi := k6tv1.VirtualMachineInstance{
+		Spec: k6tv1.VirtualMachineInstanceSpec{
+			Domain: k6tv1.DomainSpec{
+				Resources: k6tv1.ResourceRequirements{
+					Requests: k8sv1.ResourceList{k8sv1.ResourceMemory: resource.MustParse("512Mi")},
+				},
+				Memory: &k6tv1.Memory{Guest: &guestMemory},
+			},
+		},
 		Status: k6tv1.VirtualMachineInstanceStatus{
 			Phase:    k6tv1.Running,
 			NodeName: "test",